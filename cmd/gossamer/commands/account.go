@@ -5,9 +5,17 @@
 
 import (
 	"fmt"
+	"io"
+	"strings"
 
+	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/ChainSafe/gossamer/lib/crypto/secp256k1"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/ChainSafe/gossamer/lib/crypto/ss58"
 	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/lib/txbuilder"
 	"github.com/ChainSafe/gossamer/lib/utils"
 	"github.com/spf13/cobra"
 )
@@ -16,9 +24,30 @@ func init() {
 	AccountCmd.Flags().String("keystore-path", "", "path to keystore")
 	AccountCmd.Flags().String("keystore-file", "", "name of keystore file to import")
 	AccountCmd.Flags().String("password", "", "password used to encrypt the keystore. Used with --generate or --unlock")
-	AccountCmd.Flags().String("scheme", crypto.Sr25519Type, "keyring scheme (sr25519, ed25519, secp256k1)")
+	AccountCmd.Flags().String("scheme", crypto.Sr25519Type, "keyring scheme (sr25519, ed25519, secp256k1 or ecdsa)")
+	AccountCmd.Flags().String("derivation-path", "",
+		"derivation path of the generated key, such as //hard/soft. Used with generate")
+	AccountCmd.Flags().String("suri", "",
+		"secret URI of the key: a mnemonic or 0x prefixed seed, an optional derivation path and ///password. "+
+			"Used with inspect and sign")
+	AccountCmd.Flags().String("public-key", "",
+		"0x prefixed hex public key or ss58 address. Used with inspect and verify")
+	AccountCmd.Flags().Uint16("network-prefix", ss58.SubstratePrefix, "ss58 network prefix of the addresses")
+	AccountCmd.Flags().String("network", "",
+		"name of the network of the addresses in the ss58 registry, such as polkadot. Overrides network-prefix")
+	AccountCmd.Flags().String("ss58-registry", "",
+		"path to a JSON ss58 registry to use instead of the registry embedded in gossamer")
+	AccountCmd.Flags().String("message", "", "message to sign or verify. Used with sign and verify")
+	AccountCmd.Flags().Bool("hex", false, "the message is 0x prefixed hex encoded. Used with sign and verify")
+	AccountCmd.Flags().String("signature", "", "0x prefixed hex signature to verify. Used with verify")
+	AccountCmd.Flags().StringSlice("signatories", nil,
+		"ss58 addresses or 0x prefixed hex public keys of the multisig signatories. Used with multisig")
+	AccountCmd.Flags().Uint16("threshold", 0, "number of approvals of the multisig account. Used with multisig")
 }
 
+// ecdsaScheme is the substrate name of the secp256k1 scheme.
+const ecdsaScheme = "ecdsa"
+
 // AccountCmd is the command to manage the gossamer keystore
 var AccountCmd = &cobra.Command{
 	Use:   "account",
@@ -34,7 +63,22 @@ func init() {
 	gossamer account import --keystore-path=path/to/location --keystore-file=keystore.json
 To import a raw key:
 	gossamer account import-raw --keystore-path=path/to/location --keystore-file=keystore.json
-To list keys: gossamer account list --keystore-path=path/to/location`,
+To list keys: gossamer account list --keystore-path=path/to/location
+
+To generate a new sr25519 key with its mnemonic without saving it:
+	gossamer account generate --derivation-path=//stash
+To inspect a key from its secret URI for a network prefix:
+	gossamer account inspect --suri="//Alice" --network=polkadot
+To inspect an address or public key:
+	gossamer account inspect --public-key=5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY
+To sign a message:
+	gossamer account sign --suri="//Alice" --message="hello"
+To verify a signature:
+	gossamer account verify --public-key=5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY --message="hello" \
+		--signature=0x...
+To print the address of a multisig account:
+	gossamer account multisig --threshold=2 \
+		--signatories=5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY,5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if len(args) == 0 {
 			logger.Errorf("account command cannot be empty")
@@ -58,6 +102,22 @@ func init() {
 			if err := listKeys(cmd); err != nil {
 				return err
 			}
+		case "inspect":
+			if err := inspectKey(cmd); err != nil {
+				return err
+			}
+		case "sign":
+			if err := signMessage(cmd); err != nil {
+				return err
+			}
+		case "verify":
+			if err := verifySignature(cmd); err != nil {
+				return err
+			}
+		case "multisig":
+			if err := printMultisigAccount(cmd); err != nil {
+				return err
+			}
 		default:
 			logger.Errorf("invalid account command: %s", args[0])
 			return fmt.Errorf("invalid account command: %s", args[0])
@@ -67,22 +127,22 @@ func init() {
 	},
 }
 
-// generateKeyPair generates a new keypair and saves it to the keystore
+// generateKeyPair generates a new keypair from a new mnemonic, prints it and saves it to the
+// keystore if a keystore path is given
 func generateKeyPair(cmd *cobra.Command) error {
 	keystorePath, err := cmd.Flags().GetString("keystore-path")
 	if err != nil {
 		return fmt.Errorf("failed to get keystore-path: %s", err)
 	}
-	if keystorePath == "" {
-		return fmt.Errorf("keystore-path cannot be empty")
-	}
 
-	scheme, err := cmd.Flags().GetString("scheme")
+	scheme, err := getScheme(cmd)
 	if err != nil {
-		return fmt.Errorf("failed to get scheme: %s", err)
+		return err
 	}
-	if !(scheme == crypto.Ed25519Type || scheme == crypto.Sr25519Type || scheme == crypto.Secp256k1Type) {
-		return fmt.Errorf("invalid scheme: %s", scheme)
+
+	derivationPath, err := cmd.Flags().GetString("derivation-path")
+	if err != nil {
+		return fmt.Errorf("failed to get derivation-path: %s", err)
 	}
 
 	password, err := cmd.Flags().GetString("password")
@@ -90,9 +150,38 @@ func generateKeyPair(cmd *cobra.Command) error {
 		return fmt.Errorf("failed to get password: %s", err)
 	}
 
+	network, err := getNetwork(cmd)
+	if err != nil {
+		return err
+	}
+
 	logger.Info("Generating keypair")
 
-	file, err := keystore.GenerateKeypair(scheme, nil, keystorePath, []byte(password))
+	mnemonic, err := crypto.NewBIP39Mnemonic()
+	if err != nil {
+		return fmt.Errorf("failed to generate mnemonic: %s", err)
+	}
+
+	kp, err := keypairFromSecretURI(scheme, mnemonic+derivationPath)
+	if err != nil {
+		return fmt.Errorf("failed to derive keypair: %w", err)
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "Secret phrase:       %s\n", mnemonic)
+	if derivationPath != "" {
+		fmt.Fprintf(out, "  Derivation path:   %s\n", derivationPath)
+	}
+	err = printKeypair(out, kp, network)
+	if err != nil {
+		return err
+	}
+
+	if keystorePath == "" {
+		return nil
+	}
+
+	file, err := keystore.GenerateKeypair(scheme, kp, keystorePath, []byte(password))
 	if err != nil {
 		logger.Errorf("failed to generate keypair: %s", err)
 		return err
@@ -148,12 +237,9 @@ func importRawKey(cmd *cobra.Command) error {
 		return fmt.Errorf("keystore-file cannot be empty")
 	}
 
-	scheme, err := cmd.Flags().GetString("scheme")
+	scheme, err := getScheme(cmd)
 	if err != nil {
-		return fmt.Errorf("failed to get scheme: %s", err)
-	}
-	if !(scheme == crypto.Ed25519Type || scheme == crypto.Sr25519Type || scheme == crypto.Secp256k1Type) {
-		return fmt.Errorf("invalid scheme: %s", scheme)
+		return err
 	}
 
 	password, err := cmd.Flags().GetString("password")
@@ -190,3 +276,369 @@ func listKeys(cmd *cobra.Command) error {
 
 	return nil
 }
+
+// accountKeypair is a keypair which can sign messages and be saved to the keystore
+type accountKeypair interface {
+	keystore.KeyPair
+	keystore.Privater
+}
+
+// getScheme returns the key scheme flag, where ecdsa is an alias of secp256k1
+func getScheme(cmd *cobra.Command) (crypto.KeyType, error) {
+	scheme, err := cmd.Flags().GetString("scheme")
+	if err != nil {
+		return "", fmt.Errorf("failed to get scheme: %s", err)
+	}
+
+	switch scheme {
+	case crypto.Ed25519Type, crypto.Sr25519Type, crypto.Secp256k1Type:
+		return scheme, nil
+	case ecdsaScheme:
+		return crypto.Secp256k1Type, nil
+	default:
+		return "", fmt.Errorf("invalid scheme: %s", scheme)
+	}
+}
+
+// keypairFromSecretURI returns the keypair of the given scheme for the secret URI
+func keypairFromSecretURI(scheme crypto.KeyType, uri string) (accountKeypair, error) {
+	switch scheme {
+	case crypto.Ed25519Type:
+		return ed25519.NewKeypairFromSecretURI(uri)
+	case crypto.Secp256k1Type:
+		return secp256k1.NewKeypairFromSecretURI(uri)
+	default:
+		return sr25519.NewKeypairFromSecretURI(uri)
+	}
+}
+
+// getNetwork returns the network of the network or network-prefix flags, looked up in the
+// ss58 registry
+func getNetwork(cmd *cobra.Command) (ss58.Network, error) {
+	registryPath, err := cmd.Flags().GetString("ss58-registry")
+	if err != nil {
+		return ss58.Network{}, fmt.Errorf("failed to get ss58-registry: %s", err)
+	}
+
+	registry := ss58.DefaultRegistry()
+	if registryPath != "" {
+		registry, err = ss58.LoadRegistryFile(registryPath)
+		if err != nil {
+			return ss58.Network{}, fmt.Errorf("failed to load ss58 registry: %w", err)
+		}
+	}
+
+	name, err := cmd.Flags().GetString("network")
+	if err != nil {
+		return ss58.Network{}, fmt.Errorf("failed to get network: %s", err)
+	}
+
+	if name != "" {
+		network, ok := registry.NetworkByName(name)
+		if !ok {
+			return ss58.Network{}, fmt.Errorf("unknown network: %s", name)
+		}
+		return network, nil
+	}
+
+	prefix, err := cmd.Flags().GetUint16("network-prefix")
+	if err != nil {
+		return ss58.Network{}, fmt.Errorf("failed to get network-prefix: %s", err)
+	}
+
+	network, ok := registry.Network(prefix)
+	if !ok {
+		// unregistered prefixes can still be used to encode addresses
+		network = ss58.Network{Prefix: prefix}
+	}
+	return network, nil
+}
+
+// accountID returns the account id of the public key, which is the blake2b hash of
+// the public key for secp256k1 keys
+func accountID(publicKey []byte) ([]byte, error) {
+	if len(publicKey) == 32 {
+		return publicKey, nil
+	}
+
+	hash, err := common.Blake2bHash(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	return hash[:], nil
+}
+
+// printPublicKey prints the public key, account id and addresses of the public key
+func printPublicKey(out io.Writer, publicKey []byte, network ss58.Network) error {
+	id, err := accountID(publicKey)
+	if err != nil {
+		return fmt.Errorf("failed to compute account id: %w", err)
+	}
+
+	publicKeyAddress, err := ss58.Encode(network.Prefix, publicKey)
+	if err != nil {
+		return fmt.Errorf("failed to encode public key address: %w", err)
+	}
+
+	address, err := ss58.Encode(network.Prefix, id)
+	if err != nil {
+		return fmt.Errorf("failed to encode address: %w", err)
+	}
+
+	if network.Network != "" {
+		fmt.Fprintf(out, "  Network ID:        %d (%s)\n", network.Prefix, network.Network)
+	} else {
+		fmt.Fprintf(out, "  Network ID:        %d\n", network.Prefix)
+	}
+	fmt.Fprintf(out, "  Public key (hex):  %s\n", common.BytesToHex(publicKey))
+	fmt.Fprintf(out, "  Account ID:        %s\n", common.BytesToHex(id))
+	fmt.Fprintf(out, "  Public key (SS58): %s\n", publicKeyAddress)
+	fmt.Fprintf(out, "  SS58 Address:      %s\n", address)
+	return nil
+}
+
+// printKeypair prints the private key and the public key information of the keypair
+func printKeypair(out io.Writer, kp accountKeypair, network ss58.Network) error {
+	fmt.Fprintf(out, "  Key scheme:        %s\n", kp.Type())
+	fmt.Fprintf(out, "  Private key:       %s\n", kp.Private().Hex())
+	return printPublicKey(out, kp.Public().Encode(), network)
+}
+
+// parsePublicKey returns the public key of a 0x prefixed hex public key or an ss58 address
+func parsePublicKey(in string) ([]byte, error) {
+	if strings.HasPrefix(in, "0x") {
+		return common.HexToBytes(in)
+	}
+
+	_, publicKey, err := ss58.Decode(common.Address(in))
+	return publicKey, err
+}
+
+// getMessage returns the message flag, decoded from hex if the hex flag is set
+func getMessage(cmd *cobra.Command) ([]byte, error) {
+	message, err := cmd.Flags().GetString("message")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get message: %s", err)
+	}
+
+	isHex, err := cmd.Flags().GetBool("hex")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hex: %s", err)
+	}
+
+	if !isHex {
+		return []byte(message), nil
+	}
+
+	decoded, err := common.HexToBytes(message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode hex message: %w", err)
+	}
+	return decoded, nil
+}
+
+// inspectKey prints the keypair of a secret URI, or the addresses of a public key
+func inspectKey(cmd *cobra.Command) error {
+	uri, err := cmd.Flags().GetString("suri")
+	if err != nil {
+		return fmt.Errorf("failed to get suri: %s", err)
+	}
+
+	publicKeyFlag, err := cmd.Flags().GetString("public-key")
+	if err != nil {
+		return fmt.Errorf("failed to get public-key: %s", err)
+	}
+
+	network, err := getNetwork(cmd)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	switch {
+	case uri != "":
+		scheme, err := getScheme(cmd)
+		if err != nil {
+			return err
+		}
+
+		kp, err := keypairFromSecretURI(scheme, uri)
+		if err != nil {
+			return fmt.Errorf("failed to derive keypair: %w", err)
+		}
+
+		fmt.Fprintf(out, "Secret URI:          %s\n", uri)
+		return printKeypair(out, kp, network)
+	case publicKeyFlag != "":
+		publicKey, err := parsePublicKey(publicKeyFlag)
+		if err != nil {
+			return fmt.Errorf("failed to parse public key: %w", err)
+		}
+
+		fmt.Fprintf(out, "Public key:          %s\n", publicKeyFlag)
+		return printPublicKey(out, publicKey, network)
+	default:
+		return fmt.Errorf("suri or public-key must be given")
+	}
+}
+
+// signMessage prints the signature of the message by the keypair of a secret URI
+func signMessage(cmd *cobra.Command) error {
+	uri, err := cmd.Flags().GetString("suri")
+	if err != nil {
+		return fmt.Errorf("failed to get suri: %s", err)
+	}
+	if uri == "" {
+		return fmt.Errorf("suri cannot be empty")
+	}
+
+	scheme, err := getScheme(cmd)
+	if err != nil {
+		return err
+	}
+
+	message, err := getMessage(cmd)
+	if err != nil {
+		return err
+	}
+
+	kp, err := keypairFromSecretURI(scheme, uri)
+	if err != nil {
+		return fmt.Errorf("failed to derive keypair: %w", err)
+	}
+
+	// secp256k1 keys sign the blake2b hash of the message
+	if scheme == crypto.Secp256k1Type {
+		hash, err := common.Blake2bHash(message)
+		if err != nil {
+			return err
+		}
+		message = hash[:]
+	}
+
+	signature, err := kp.Sign(message)
+	if err != nil {
+		return fmt.Errorf("failed to sign message: %w", err)
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), common.BytesToHex(signature))
+	return nil
+}
+
+// verifySignature verifies the signature of the message by a public key
+func verifySignature(cmd *cobra.Command) error {
+	scheme, err := getScheme(cmd)
+	if err != nil {
+		return err
+	}
+
+	publicKeyFlag, err := cmd.Flags().GetString("public-key")
+	if err != nil {
+		return fmt.Errorf("failed to get public-key: %s", err)
+	}
+	if publicKeyFlag == "" {
+		return fmt.Errorf("public-key cannot be empty")
+	}
+
+	signatureFlag, err := cmd.Flags().GetString("signature")
+	if err != nil {
+		return fmt.Errorf("failed to get signature: %s", err)
+	}
+
+	message, err := getMessage(cmd)
+	if err != nil {
+		return err
+	}
+
+	publicKeyBytes, err := parsePublicKey(publicKeyFlag)
+	if err != nil {
+		return fmt.Errorf("failed to parse public key: %w", err)
+	}
+
+	signature, err := common.HexToBytes(signatureFlag)
+	if err != nil {
+		return fmt.Errorf("failed to decode signature: %w", err)
+	}
+
+	var publicKey crypto.PublicKey
+	switch scheme {
+	case crypto.Ed25519Type:
+		publicKey, err = ed25519.NewPublicKey(publicKeyBytes)
+	case crypto.Secp256k1Type:
+		publicKey = new(secp256k1.PublicKey)
+		err = publicKey.Decode(publicKeyBytes)
+
+		// secp256k1 keys sign the blake2b hash of the message, with a recovery byte
+		hash, hashErr := common.Blake2bHash(message)
+		if hashErr != nil {
+			return hashErr
+		}
+		message = hash[:]
+		if len(signature) == secp256k1.SignatureLengthRecovery {
+			signature = signature[:secp256k1.SignatureLength]
+		}
+	default:
+		publicKey, err = sr25519.NewPublicKey(publicKeyBytes)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to decode public key: %w", err)
+	}
+
+	ok, err := publicKey.Verify(message, signature)
+	if err != nil {
+		return fmt.Errorf("failed to verify signature: %w", err)
+	}
+	if !ok {
+		return fmt.Errorf("signature is invalid")
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), "Signature verifies correctly.")
+	return nil
+}
+
+// printMultisigAccount prints the address of the multisig account of the signatories
+func printMultisigAccount(cmd *cobra.Command) error {
+	signatories, err := cmd.Flags().GetStringSlice("signatories")
+	if err != nil {
+		return fmt.Errorf("failed to get signatories: %s", err)
+	}
+
+	threshold, err := cmd.Flags().GetUint16("threshold")
+	if err != nil {
+		return fmt.Errorf("failed to get threshold: %s", err)
+	}
+
+	network, err := getNetwork(cmd)
+	if err != nil {
+		return err
+	}
+
+	accountIDs := make([][]byte, len(signatories))
+	for i, signatory := range signatories {
+		publicKey, err := parsePublicKey(signatory)
+		if err != nil {
+			return fmt.Errorf("failed to parse signatory %s: %w", signatory, err)
+		}
+
+		accountIDs[i], err = accountID(publicKey)
+		if err != nil {
+			return fmt.Errorf("failed to compute account id of signatory %s: %w", signatory, err)
+		}
+	}
+
+	multisigAccountID, err := txbuilder.MultisigAccountID(threshold, accountIDs)
+	if err != nil {
+		return fmt.Errorf("failed to derive multisig account: %w", err)
+	}
+
+	address, err := ss58.Encode(network.Prefix, multisigAccountID)
+	if err != nil {
+		return fmt.Errorf("failed to encode address: %w", err)
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "Multisig account:    %d of %d signatories\n", threshold, len(signatories))
+	fmt.Fprintf(out, "  Account ID:        %s\n", common.BytesToHex(multisigAccountID))
+	fmt.Fprintf(out, "  SS58 Address:      %s\n", address)
+	return nil
+}
@@ -4,9 +4,14 @@
 package commands
 
 import (
+	"bytes"
 	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/ss58"
+	"github.com/ChainSafe/gossamer/lib/txbuilder"
 	"github.com/stretchr/testify/require"
 )
 
@@ -132,3 +137,119 @@ func TestAccountList(t *testing.T) {
 	err = rootCmd.Execute()
 	require.NoError(t, err)
 }
+
+// TestAccountInspect test "gossamer account inspect --suri --network"
+func TestAccountInspect(t *testing.T) {
+	rootCmd, err := NewRootCommand()
+	require.NoError(t, err)
+	rootCmd.AddCommand(AccountCmd)
+
+	out := bytes.NewBuffer(nil)
+	rootCmd.SetOut(out)
+	rootCmd.SetArgs([]string{"account", "inspect", "--suri=//Alice", "--scheme=sr25519", "--network=polkadot"})
+
+	err = rootCmd.Execute()
+	require.NoError(t, err)
+	require.Contains(t, out.String(),
+		"0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d")
+	require.Contains(t, out.String(), "Network ID:        0 (polkadot)")
+	require.Contains(t, out.String(), "15oF4uVJwmo4TdGW7VfQxNLavjCXviqxT9S1MgbjMNHr6Sp5")
+}
+
+// TestAccountInspectPublicKey test "gossamer account inspect --public-key"
+func TestAccountInspectPublicKey(t *testing.T) {
+	rootCmd, err := NewRootCommand()
+	require.NoError(t, err)
+	rootCmd.AddCommand(AccountCmd)
+
+	out := bytes.NewBuffer(nil)
+	rootCmd.SetOut(out)
+	rootCmd.SetArgs([]string{"account", "inspect", "--suri=",
+		"--public-key=5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY", "--network=", "--network-prefix=2"})
+
+	err = rootCmd.Execute()
+	require.NoError(t, err)
+	require.Contains(t, out.String(),
+		"0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d")
+	require.Contains(t, out.String(), "HNZata7iMYWmk5RvZRTiAsSDhV8366zq2YGb3tLH5Upf74F")
+}
+
+// TestAccountSignVerify test "gossamer account sign" and "gossamer account verify"
+func TestAccountSignVerify(t *testing.T) {
+	for _, scheme := range []string{"sr25519", "ed25519", "ecdsa"} {
+		rootCmd, err := NewRootCommand()
+		require.NoError(t, err)
+		rootCmd.AddCommand(AccountCmd)
+
+		out := bytes.NewBuffer(nil)
+		rootCmd.SetOut(out)
+		rootCmd.SetArgs([]string{"account", "inspect", "--suri=//Alice", "--scheme=" + scheme})
+		err = rootCmd.Execute()
+		require.NoError(t, err)
+
+		var publicKey string
+		for _, line := range strings.Split(out.String(), "\n") {
+			if strings.Contains(line, "Public key (hex):") {
+				publicKey = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "Public key (hex):"))
+			}
+		}
+		require.NotEmpty(t, publicKey)
+
+		out.Reset()
+		rootCmd.SetArgs([]string{"account", "sign", "--suri=//Alice", "--scheme=" + scheme,
+			"--message=0x68656c6c6f", "--hex"})
+		err = rootCmd.Execute()
+		require.NoError(t, err)
+		signature := strings.TrimSpace(out.String())
+
+		rootCmd.SetArgs([]string{"account", "verify", "--public-key=" + publicKey, "--scheme=" + scheme,
+			"--message=hello", "--hex=false", "--signature=" + signature})
+		err = rootCmd.Execute()
+		require.NoError(t, err, scheme)
+
+		rootCmd.SetArgs([]string{"account", "verify", "--public-key=" + publicKey, "--scheme=" + scheme,
+			"--message=goodbye", "--hex=false", "--signature=" + signature})
+		err = rootCmd.Execute()
+		require.EqualError(t, err, "signature is invalid", scheme)
+	}
+}
+
+// TestAccountGenerateDerivationPath test "gossamer account generate --derivation-path" without a keystore
+func TestAccountGenerateDerivationPath(t *testing.T) {
+	rootCmd, err := NewRootCommand()
+	require.NoError(t, err)
+	rootCmd.AddCommand(AccountCmd)
+
+	out := bytes.NewBuffer(nil)
+	rootCmd.SetOut(out)
+	rootCmd.SetArgs([]string{"account", "generate", "--keystore-path=", "--scheme=sr25519",
+		"--derivation-path=//stash", "--password="})
+
+	err = rootCmd.Execute()
+	require.NoError(t, err)
+	require.Contains(t, out.String(), "Secret phrase:")
+	require.Contains(t, out.String(), "Derivation path:   //stash")
+}
+
+// TestAccountMultisig test "gossamer account multisig --threshold --signatories"
+func TestAccountMultisig(t *testing.T) {
+	rootCmd, err := NewRootCommand()
+	require.NoError(t, err)
+	rootCmd.AddCommand(AccountCmd)
+
+	out := bytes.NewBuffer(nil)
+	rootCmd.SetOut(out)
+	rootCmd.SetArgs([]string{"account", "multisig", "--threshold=2", "--network=", "--network-prefix=42",
+		"--signatories=5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY," +
+			"0x8eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48"})
+
+	err = rootCmd.Execute()
+	require.NoError(t, err)
+	require.Contains(t, out.String(), "Multisig account:    2 of 2 signatories")
+
+	alice := common.MustHexToBytes("0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d")
+	bob := common.MustHexToBytes("0x8eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48")
+	multisigAccountID, err := txbuilder.MultisigAccountID(2, [][]byte{alice, bob})
+	require.NoError(t, err)
+	require.Contains(t, out.String(), string(ss58.MustEncode(ss58.SubstratePrefix, multisigAccountID)))
+}
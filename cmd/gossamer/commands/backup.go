@@ -0,0 +1,202 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	BackupCmd.Flags().String("output", "",
+		"backup destination, either a file path or an S3 compatible (pre-signed) http(s) URL. Used with create")
+	BackupCmd.Flags().String("input", "",
+		"backup source, either a file path or an S3 compatible (pre-signed) http(s) URL. Used with restore")
+}
+
+// BackupCmd is the command to backup and restore the node database
+var BackupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Backup and restore the node database",
+	Long: `The backup command is used to backup and restore the node database.
+The node must be stopped while a backup is created or restored.
+Backups are consistent at the highest finalised block and verified using their checksum on restore.
+Examples:
+
+To create a backup in a file:
+	gossamer backup create --base-path=path/to/node --output=backup.bin
+To upload a backup to S3 compatible storage using a pre-signed URL:
+	gossamer backup create --base-path=path/to/node --output=https://bucket.s3.amazonaws.com/backup.bin?X-Amz-Signature=...
+To restore a backup:
+	gossamer backup restore --base-path=path/to/node --input=backup.bin`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			logger.Errorf("backup command cannot be empty")
+			return cmd.Help()
+		}
+
+		if basePath == "" {
+			basePath = config.BasePath
+		}
+		if basePath == "" {
+			return fmt.Errorf("base-path must be specified")
+		}
+
+		switch args[0] {
+		case "create":
+			return createBackup(cmd)
+		case "restore":
+			return restoreBackup(cmd)
+		default:
+			logger.Errorf("invalid backup command: %s", args[0])
+			return fmt.Errorf("invalid backup command: %s", args[0])
+		}
+	},
+}
+
+func isURL(location string) bool {
+	return strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://")
+}
+
+// createBackup writes a backup of the database to a file or uploads it to a URL
+func createBackup(cmd *cobra.Command) (err error) {
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return fmt.Errorf("failed to get output: %s", err)
+	}
+	if output == "" {
+		return fmt.Errorf("output cannot be empty")
+	}
+
+	filePath := output
+	if isURL(output) {
+		// S3 compatible storages require the content length of uploads,
+		// so the backup is written to a temporary file first.
+		file, err := os.CreateTemp("", "gossamer-backup-")
+		if err != nil {
+			return fmt.Errorf("creating temporary backup file: %w", err)
+		}
+		filePath = file.Name()
+		err = file.Close()
+		if err != nil {
+			return fmt.Errorf("closing temporary backup file: %w", err)
+		}
+		defer os.Remove(filePath)
+	}
+
+	file, err := os.Create(filepath.Clean(filePath))
+	if err != nil {
+		return fmt.Errorf("creating backup file: %w", err)
+	}
+	defer func() {
+		closeErr := file.Close()
+		if err == nil && closeErr != nil {
+			err = fmt.Errorf("closing backup file: %w", closeErr)
+		}
+	}()
+
+	manifest, err := state.CreateBackup(basePath, file)
+	if err != nil {
+		return fmt.Errorf("creating backup: %w", err)
+	}
+
+	if isURL(output) {
+		err = uploadBackup(cmd.Context(), output, file)
+		if err != nil {
+			return err
+		}
+	}
+
+	logger.Infof("backup of %d entries created at finalised block #%d (%s) with checksum %s",
+		manifest.Entries, manifest.FinalisedNumber, manifest.FinalisedHash, manifest.Checksum)
+	return nil
+}
+
+func uploadBackup(ctx context.Context, url string, file *os.File) error {
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("getting backup file size: %w", err)
+	}
+	_, err = file.Seek(0, io.SeekStart)
+	if err != nil {
+		return fmt.Errorf("seeking backup file: %w", err)
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	// the file is wrapped so it is not closed by the http client
+	request, err := http.NewRequestWithContext(ctx, http.MethodPut, url, io.NopCloser(file))
+	if err != nil {
+		return fmt.Errorf("creating upload request: %w", err)
+	}
+	request.ContentLength = info.Size()
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("uploading backup: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return fmt.Errorf("uploading backup: unexpected status %s: %s", response.Status, body)
+	}
+	return nil
+}
+
+// restoreBackup restores the database from a backup file or URL
+func restoreBackup(cmd *cobra.Command) error {
+	input, err := cmd.Flags().GetString("input")
+	if err != nil {
+		return fmt.Errorf("failed to get input: %s", err)
+	}
+	if input == "" {
+		return fmt.Errorf("input cannot be empty")
+	}
+
+	var reader io.ReadCloser
+	if isURL(input) {
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, input, nil)
+		if err != nil {
+			return fmt.Errorf("creating download request: %w", err)
+		}
+		response, err := http.DefaultClient.Do(request)
+		if err != nil {
+			return fmt.Errorf("downloading backup: %w", err)
+		}
+		if response.StatusCode != http.StatusOK {
+			response.Body.Close()
+			return fmt.Errorf("downloading backup: unexpected status %s", response.Status)
+		}
+		reader = response.Body
+	} else {
+		reader, err = os.Open(filepath.Clean(input))
+		if err != nil {
+			return fmt.Errorf("opening backup file: %w", err)
+		}
+	}
+	defer reader.Close()
+
+	manifest, err := state.RestoreBackup(basePath, reader)
+	if err != nil {
+		return fmt.Errorf("restoring backup: %w", err)
+	}
+
+	logger.Infof("backup of %d entries restored at finalised block #%d (%s)",
+		manifest.Entries, manifest.FinalisedNumber, manifest.FinalisedHash)
+	return nil
+}
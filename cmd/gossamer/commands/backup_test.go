@@ -0,0 +1,52 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_uploadBackup(t *testing.T) {
+	t.Parallel()
+
+	content := []byte("backup content")
+	filePath := filepath.Join(t.TempDir(), "backup")
+	err := os.WriteFile(filePath, content, os.ModePerm)
+	require.NoError(t, err)
+	file, err := os.Open(filePath)
+	require.NoError(t, err)
+	defer file.Close()
+
+	// move the file offset to check the upload starts from the beginning
+	_, err = file.Seek(3, io.SeekStart)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, int64(len(content)), r.ContentLength)
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, content, body)
+
+		if r.URL.Query().Get("fail") != "" {
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	err = uploadBackup(context.Background(), server.URL+"/backup", file)
+	require.NoError(t, err)
+
+	err = uploadBackup(context.Background(), server.URL+"/backup?fail=1", file)
+	assert.ErrorContains(t, err, "uploading backup: unexpected status 403 Forbidden")
+}
@@ -12,6 +12,8 @@
 
 func init() {
 	BuildSpecCmd.Flags().Bool("raw", false, "print raw genesis json")
+	BuildSpecCmd.Flags().
+		Bool("scale", false, "output the raw chain-spec SCALE encoded, which loads with less memory than JSON")
 	BuildSpecCmd.Flags().
 		String("output-path", "", "path to output the recently created chain-spec JSON file")
 }
@@ -25,7 +27,9 @@ func init() {
 To generate raw chain-spec file from default:
 	gossamer build-spec --raw --output chain-spec.json
 To generate raw chain-spec file from specific chain-spec file:
-	gossamer build-spec --raw --chain chain-spec.json --output-path chain-spec-raw.json`,
+	gossamer build-spec --raw --chain chain-spec.json --output-path chain-spec-raw.json
+To convert a raw chain-spec file to a SCALE encoded chain-spec file:
+	gossamer build-spec --scale --chain chain-spec-raw.json --output-path chain-spec-raw.scale`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return execBuildSpec(cmd)
 	},
@@ -39,6 +43,11 @@ func execBuildSpec(cmd *cobra.Command) error {
 		return fmt.Errorf("failed to get raw value: %s", err)
 	}
 
+	scale, err := cmd.Flags().GetBool("scale")
+	if err != nil {
+		return fmt.Errorf("failed to get scale value: %s", err)
+	}
+
 	chainSpec, err := cmd.Flags().GetString("chain")
 	if err != nil {
 		return fmt.Errorf("failed to get genesis-spec value: %s", err)
@@ -58,6 +67,10 @@ func execBuildSpec(cmd *cobra.Command) error {
 		return fmt.Errorf("failed to get output-path value: %s", err)
 	}
 
+	if scale && outputPath == "" {
+		return fmt.Errorf("output-path must be specified with scale")
+	}
+
 	var bs *dot.BuildSpec
 
 	if chainSpec != "" {
@@ -80,9 +93,12 @@ func execBuildSpec(cmd *cobra.Command) error {
 
 	var res []byte
 
-	if raw {
+	switch {
+	case scale:
+		res, err = bs.ToSCALE()
+	case raw:
 		res, err = bs.ToJSONRaw()
-	} else {
+	default:
 		res, err = bs.ToJSON()
 	}
 
@@ -4,8 +4,10 @@
 package commands
 
 import (
+	"path/filepath"
 	"testing"
 
+	"github.com/ChainSafe/gossamer/lib/genesis"
 	"github.com/stretchr/testify/require"
 )
 
@@ -31,6 +33,29 @@ func TestBuildSpecRaw(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// TestBuildSpecSCALE test "gossamer build-spec --chain=chain-spec-raw.json --scale --output-path=chain-spec-raw.scale"
+func TestBuildSpecSCALE(t *testing.T) {
+	rootCmd, err := NewRootCommand()
+	require.NoError(t, err)
+	rootCmd.AddCommand(BuildSpecCmd)
+
+	rootCmd.SetArgs([]string{BuildSpecCmd.Name(), "--chain", testChainSpec, "--scale"})
+	err = rootCmd.Execute()
+	require.EqualError(t, err, "output-path must be specified with scale")
+
+	outputPath := filepath.Join(t.TempDir(), "chain-spec-raw.scale")
+	rootCmd.SetArgs([]string{BuildSpecCmd.Name(), "--chain", testChainSpec, "--scale", "--output-path", outputPath})
+	err = rootCmd.Execute()
+	require.NoError(t, err)
+
+	expected, err := genesis.NewGenesisFromJSONRaw(testChainSpec)
+	require.NoError(t, err)
+	spec, err := genesis.NewGenesisFromFile(outputPath)
+	require.NoError(t, err)
+	require.Equal(t, expected.Genesis.Raw, spec.Genesis.Raw)
+	require.Equal(t, expected.Name, spec.Name)
+}
+
 // TestBuildSpecFromDB test init and build-spec
 //
 //	"gossamer init --chain chain-spec-raw.json --base-path=basepath && \
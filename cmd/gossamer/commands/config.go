@@ -0,0 +1,76 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"fmt"
+	"io"
+
+	cfg "github.com/ChainSafe/gossamer/config"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// ConfigCmd is the command to validate and print the effective configuration
+var ConfigCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Validate or print the effective configuration",
+	Long: `The config command validates or prints the effective configuration of the node.
+The configuration of the chain is overridden by the config file of the base path,
+then by the GSSMR_ prefixed environment variables and finally by the command line flags.
+Keys of the config file which are not part of the configuration schema are rejected.
+Examples:
+
+To validate the configuration:
+	gossamer config validate --base-path=path/to/node
+To print the effective configuration as a TOML config file:
+	gossamer config dump --base-path=path/to/node
+	GSSMR_CORE_HEAP_PAGES=2048 gossamer config dump --base-path=path/to/node --port=7002`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			logger.Errorf("config command cannot be empty")
+			return cmd.Help()
+		}
+
+		switch args[0] {
+		case "validate":
+			return execConfigValidate(cmd.OutOrStdout())
+		case "dump":
+			return execConfigDump(cmd.OutOrStdout())
+		default:
+			logger.Errorf("invalid config command: %s", args[0])
+			return fmt.Errorf("invalid config command: %s", args[0])
+		}
+	},
+}
+
+// execConfigValidate validates the effective configuration.
+func execConfigValidate(w io.Writer) error {
+	if err := config.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	_, err := fmt.Fprintln(w, "configuration is valid")
+	return err
+}
+
+// execConfigDump prints the effective configuration as a TOML config file.
+func execConfigDump(w io.Writer) error {
+	return cfg.WriteConfig(w, config)
+}
+
+// checkConfigFileKeys returns an error if the given config file sets keys
+// which are not part of the configuration schema.
+func checkConfigFileKeys(configFile string) error {
+	fileViper := viper.New()
+	fileViper.SetConfigFile(configFile)
+	if err := fileViper.ReadInConfig(); err != nil {
+		return fmt.Errorf("reading config file: %w", err)
+	}
+
+	if err := cfg.CheckKeys(fileViper.AllKeys()); err != nil {
+		return fmt.Errorf("config file %s: %w", configFile, err)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	cfg "github.com/ChainSafe/gossamer/config"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_checkConfigFileKeys(t *testing.T) {
+	t.Parallel()
+
+	defaultConfig := new(bytes.Buffer)
+	err := cfg.WriteConfig(defaultConfig, cfg.DefaultConfig())
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		content    string
+		errWrapped error
+		errMessage string
+	}{
+		"default_config": {
+			content: defaultConfig.String(),
+		},
+		"unknown_keys": {
+			content:    "name = \"Gossamer\"\nnetwork-port = 7001\n[core]\nrole = 4\nbabe = true\n",
+			errWrapped: cfg.ErrUnknownKeys,
+			errMessage: "unknown configuration keys: core.babe, network-port",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			configFile := filepath.Join(t.TempDir(), "config.toml")
+			err := os.WriteFile(configFile, []byte(testCase.content), 0o600)
+			require.NoError(t, err)
+
+			err = checkConfigFileKeys(configFile)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, "config file "+configFile+": "+testCase.errMessage)
+			}
+		})
+	}
+}
+
+func Test_execConfigDump(t *testing.T) {
+	output := new(bytes.Buffer)
+	err := execConfigDump(output)
+	require.NoError(t, err)
+
+	dumpViper := viper.New()
+	dumpViper.SetConfigType("toml")
+	err = dumpViper.ReadConfig(output)
+	require.NoError(t, err)
+
+	assert.NoError(t, cfg.CheckKeys(dumpViper.AllKeys()))
+	assert.Equal(t, config.Name, dumpViper.GetString("name"))
+	assert.Equal(t, int(config.Network.Port), dumpViper.GetInt("network.port"))
+}
@@ -0,0 +1,25 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	DBCmd.AddCommand(DBStatsCmd, VerifyStateCmd)
+}
+
+// DBCmd is the command grouping the commands inspecting the node database
+var DBCmd = &cobra.Command{
+	Use:   "db",
+	Short: "Inspect and verify the node database",
+	Long: `The db command is used to inspect and verify the database of a stopped node.
+Examples:
+
+To report statistics of the node database:
+	gossamer db stats --base-path=path/to/node
+To verify the state of the finalised block:
+	gossamer db verify-state --base-path=path/to/node`,
+}
@@ -0,0 +1,74 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/utils"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	DBStatsCmd.Flags().Int("largest-keys", state.DefaultLargestKeys, "Number of largest keys to report")
+}
+
+// DBStatsCmd is the command to report statistics of the node database
+var DBStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Report statistics of the node database",
+	Long: `The db stats command iterates over the node database and reports, as JSON,
+the key count and byte sizes of each column, the trie node counts by node type,
+the largest keys and the compaction statistics of the database.
+The node must be stopped while the statistics are collected, otherwise use the dev_dbStats RPC method.
+Examples:
+
+	gossamer db stats --base-path=path/to/node
+	gossamer db stats --base-path=path/to/node --largest-keys=100`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return execDBStats(cmd)
+	},
+}
+
+func execDBStats(cmd *cobra.Command) (err error) {
+	if basePath == "" {
+		basePath = config.BasePath
+	}
+	if basePath == "" {
+		return fmt.Errorf("base-path must be specified")
+	}
+	basePath = utils.ExpandDir(basePath)
+
+	largestKeys, err := cmd.Flags().GetInt("largest-keys")
+	if err != nil {
+		return fmt.Errorf("failed to get largest-keys: %s", err)
+	}
+
+	db, err := database.LoadDatabase(basePath, false)
+	if err != nil {
+		return fmt.Errorf("loading database: %w", err)
+	}
+	defer func() {
+		closeErr := db.Close()
+		if err == nil && closeErr != nil {
+			err = fmt.Errorf("closing database: %w", closeErr)
+		}
+	}()
+
+	stats, err := state.CollectDatabaseStats(db, largestKeys)
+	if err != nil {
+		return fmt.Errorf("collecting database statistics: %w", err)
+	}
+
+	encoder := json.NewEncoder(cmd.OutOrStdout())
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(stats)
+	if err != nil {
+		return fmt.Errorf("encoding database statistics: %w", err)
+	}
+	return nil
+}
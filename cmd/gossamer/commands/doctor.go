@@ -0,0 +1,106 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ChainSafe/gossamer/dot/doctor"
+	"github.com/ChainSafe/gossamer/lib/utils"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	defaults := doctor.DefaultConfig()
+	DoctorCmd.Flags().Uint16("p2p-port", defaults.P2PPort, "P2P port to check the reachability of")
+	DoctorCmd.Flags().String("probe-url", defaults.ProbeURL,
+		"URL of the service probing the reachability of a port, empty to skip the port check")
+	DoctorCmd.Flags().String("ntp-server", defaults.NTPServer,
+		"Address of the NTP server to compare the clock with, empty to skip the clock check")
+	DoctorCmd.Flags().Int64("disk-test-size", defaults.DiskTestSize,
+		"Number of bytes written to measure the disk throughput, 0 to skip the disk check")
+}
+
+// DoctorCmd is the command to diagnose the environment of the node
+var DoctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose the environment of the node",
+	Long: `The doctor command checks the environment of the node and prints the problems found,
+from the most to the least severe. It checks the open files limit, the synchronisation of the
+clock with an NTP server, the write throughput of the disk of the base path, the reachability
+of the P2P port from the internet using a probe service, the key files of the keystore and
+the consistency of the database.
+The database is only checked if the node is stopped.
+Examples:
+
+To diagnose the node:
+	gossamer doctor --base-path=path/to/node
+To diagnose the node without the checks using the network:
+	gossamer doctor --base-path=path/to/node --probe-url= --ntp-server=`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return execDoctor(cmd)
+	},
+}
+
+func execDoctor(cmd *cobra.Command) error {
+	doctorConfig := doctor.DefaultConfig()
+
+	var err error
+	doctorConfig.P2PPort, err = cmd.Flags().GetUint16("p2p-port")
+	if err != nil {
+		return fmt.Errorf("failed to get p2p-port: %s", err)
+	}
+	doctorConfig.ProbeURL, err = cmd.Flags().GetString("probe-url")
+	if err != nil {
+		return fmt.Errorf("failed to get probe-url: %s", err)
+	}
+	doctorConfig.NTPServer, err = cmd.Flags().GetString("ntp-server")
+	if err != nil {
+		return fmt.Errorf("failed to get ntp-server: %s", err)
+	}
+	doctorConfig.DiskTestSize, err = cmd.Flags().GetInt64("disk-test-size")
+	if err != nil {
+		return fmt.Errorf("failed to get disk-test-size: %s", err)
+	}
+	if doctorConfig.DiskTestSize < 0 {
+		return fmt.Errorf("disk-test-size %d must not be negative", doctorConfig.DiskTestSize)
+	}
+
+	if basePath == "" {
+		basePath = config.BasePath
+	}
+	if basePath == "" {
+		return fmt.Errorf("base-path must be specified")
+	}
+	doctorConfig.BasePath = utils.ExpandDir(basePath)
+
+	problems := doctor.Run(cmd.Context(), doctorConfig)
+	return writeDoctorReport(cmd.OutOrStdout(), problems)
+}
+
+// writeDoctorReport writes the problems found by the doctor, one per line
+// followed by the hint to fix it if any.
+func writeDoctorReport(writer io.Writer, problems []doctor.Problem) error {
+	if len(problems) == 0 {
+		_, err := fmt.Fprintln(writer, "no problems found")
+		return err
+	}
+
+	for _, problem := range problems {
+		_, err := fmt.Fprintf(writer, "[%s] %s: %s\n",
+			strings.ToUpper(problem.Severity.String()), problem.Check, problem.Message)
+		if err != nil {
+			return err
+		}
+		if problem.Hint != "" {
+			_, err = fmt.Fprintf(writer, "  hint: %s\n", problem.Hint)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
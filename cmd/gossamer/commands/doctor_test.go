@@ -0,0 +1,45 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/doctor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_writeDoctorReport(t *testing.T) {
+	t.Parallel()
+
+	problems := []doctor.Problem{
+		{
+			Check:    "database",
+			Severity: doctor.SeverityCritical,
+			Message:  "database path/to/node/db does not exist",
+			Hint:     "initialise the node with gossamer init",
+		},
+		{
+			Check:    "disk",
+			Severity: doctor.SeverityWarning,
+			Message:  "check could not run: creating test file: permission denied",
+		},
+	}
+
+	buffer := bytes.NewBuffer(nil)
+	err := writeDoctorReport(buffer, problems)
+	require.NoError(t, err)
+
+	expected := "[CRITICAL] database: database path/to/node/db does not exist\n" +
+		"  hint: initialise the node with gossamer init\n" +
+		"[WARNING] disk: check could not run: creating test file: permission denied\n"
+	assert.Equal(t, expected, buffer.String())
+
+	buffer.Reset()
+	err = writeDoctorReport(buffer, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "no problems found\n", buffer.String())
+}
@@ -0,0 +1,165 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/utils"
+	ctypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	ExportStateCmd.Flags().String("block", "", "Hash of the block to export the state of. "+
+		"Defaults to the highest finalised block")
+	ExportStateCmd.Flags().String("output", "", "Path of the JSON lines file to write. Defaults to the standard output")
+	ExportStateCmd.Flags().String("cursor", "", "Storage key to resume the export after, as logged by a previous export")
+	ExportStateCmd.Flags().Uint64("limit", 0, "Maximum number of entries to export, 0 for no limit")
+	ExportStateCmd.Flags().String("metadata", "",
+		"Path to a file of hex encoded metadata, as returned by state_getMetadata, "+
+			"used instead of the metadata of the exported runtime")
+	ExportStateCmd.Flags().Bool("raw", false, "Export the raw storage entries without decoding them")
+}
+
+// ExportStateCmd is the command to export the state at a block as JSON lines
+var ExportStateCmd = &cobra.Command{
+	Use:   "export-state",
+	Short: "Export the state at a block as decoded JSON lines",
+	Long: `The export-state command walks the state trie at a block and writes
+one JSON line per storage entry, in lexicographic key order.
+Entries are decoded using the runtime metadata (v14 or later) into their pallet, item,
+key parts and value. Entries which cannot be decoded are exported with their raw value.
+The node must be stopped while the state is exported.
+Exports can be resumed using the cursor logged at the end of an export.
+Examples:
+
+To export the state at the highest finalised block:
+	gossamer export-state --base-path=path/to/node --output=state.jsonl
+To export the state at a block in batches of 10000 entries:
+	gossamer export-state --base-path=path/to/node --block=0x... --limit=10000 --output=state-0.jsonl
+	gossamer export-state --base-path=path/to/node --block=0x... --limit=10000 --cursor=0x... --output=state-1.jsonl`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return execExportState(cmd)
+	},
+}
+
+func execExportState(cmd *cobra.Command) (err error) {
+	if basePath == "" {
+		basePath = config.BasePath
+	}
+	if basePath == "" {
+		return fmt.Errorf("base-path must be specified")
+	}
+	basePath = utils.ExpandDir(basePath)
+
+	options, err := getStateExportOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return fmt.Errorf("failed to get output: %s", err)
+	}
+
+	var writer io.Writer = cmd.OutOrStdout()
+	if output != "" {
+		file, err := os.Create(filepath.Clean(output))
+		if err != nil {
+			return fmt.Errorf("creating output file: %w", err)
+		}
+		defer func() {
+			closeErr := file.Close()
+			if err == nil && closeErr != nil {
+				err = fmt.Errorf("closing output file: %w", closeErr)
+			}
+		}()
+		writer = file
+	}
+
+	result, err := state.ExportState(basePath, options, writer)
+	if err != nil {
+		return fmt.Errorf("exporting state: %w", err)
+	}
+
+	if result.Complete {
+		logger.Infof("exported %d entries of the state at block %s with state root %s",
+			result.Entries, result.BlockHash, result.StateRoot)
+	} else {
+		logger.Infof("exported %d entries of the state at block %s with state root %s, "+
+			"resume the export with --block=%s --cursor=%s",
+			result.Entries, result.BlockHash, result.StateRoot, result.BlockHash, common.BytesToHex(result.Cursor))
+	}
+	return nil
+}
+
+func getStateExportOptions(cmd *cobra.Command) (options state.StateExportOptions, err error) {
+	block, err := cmd.Flags().GetString("block")
+	if err != nil {
+		return options, fmt.Errorf("failed to get block: %s", err)
+	}
+	if block != "" {
+		blockHash, err := common.HexToHash(block)
+		if err != nil {
+			return options, fmt.Errorf("invalid block hash: %w", err)
+		}
+		options.BlockHash = &blockHash
+	}
+
+	cursor, err := cmd.Flags().GetString("cursor")
+	if err != nil {
+		return options, fmt.Errorf("failed to get cursor: %s", err)
+	}
+	if cursor != "" {
+		options.Cursor, err = common.HexToBytes(cursor)
+		if err != nil {
+			return options, fmt.Errorf("invalid cursor: %w", err)
+		}
+	}
+
+	options.Limit, err = cmd.Flags().GetUint64("limit")
+	if err != nil {
+		return options, fmt.Errorf("failed to get limit: %s", err)
+	}
+
+	options.Raw, err = cmd.Flags().GetBool("raw")
+	if err != nil {
+		return options, fmt.Errorf("failed to get raw: %s", err)
+	}
+
+	metadataPath, err := cmd.Flags().GetString("metadata")
+	if err != nil {
+		return options, fmt.Errorf("failed to get metadata: %s", err)
+	}
+	if metadataPath != "" && !options.Raw {
+		options.Metadata, err = readMetadataFile(metadataPath)
+		if err != nil {
+			return options, err
+		}
+	}
+
+	return options, nil
+}
+
+func readMetadataFile(path string) (*ctypes.Metadata, error) {
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("reading metadata file: %w", err)
+	}
+
+	metadata := &ctypes.Metadata{}
+	err = codec.DecodeFromHex(strings.TrimSpace(string(content)), metadata)
+	if err != nil {
+		return nil, fmt.Errorf("decoding metadata: %w", err)
+	}
+	return metadata, nil
+}
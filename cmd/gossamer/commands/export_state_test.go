@@ -0,0 +1,37 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	ctypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_readMetadataFile(t *testing.T) {
+	t.Parallel()
+
+	encoded, err := codec.EncodeToHex(ctypes.Metadata{MagicNumber: ctypes.MagicNumber, Version: 14})
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "metadata.hex")
+	err = os.WriteFile(path, []byte(encoded+"\n"), os.ModePerm)
+	require.NoError(t, err)
+
+	metadata, err := readMetadataFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, uint8(14), metadata.Version)
+
+	invalidPath := filepath.Join(t.TempDir(), "invalid.hex")
+	err = os.WriteFile(invalidPath, []byte("0x00"), os.ModePerm)
+	require.NoError(t, err)
+
+	_, err = readMetadataFile(invalidPath)
+	assert.ErrorContains(t, err, "decoding metadata")
+}
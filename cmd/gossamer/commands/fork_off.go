@@ -0,0 +1,254 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/genesis"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/lib/utils"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	ForkOffCmd.Flags().String("rpc-url", "",
+		"URL of the HTTP RPC server of the live node to fork the state of. "+
+			"Defaults to the database of the base path")
+	ForkOffCmd.Flags().String("block", "", "Hash of the block to fork the state at. "+
+		"Defaults to the highest finalised block")
+	ForkOffCmd.Flags().Uint32("page-size", 1000, "Number of storage keys downloaded per RPC request")
+	ForkOffCmd.Flags().String("sudo", "",
+		"ss58 address or 0x prefixed hex account id of the sudo key of the fork. Defaults to Alice")
+	ForkOffCmd.Flags().String("output", "", "Path of the chain spec file to write. Defaults to the standard output")
+}
+
+// ForkOffCmd is the command to create the chain spec of a local fork of a live chain
+var ForkOffCmd = &cobra.Command{
+	Use:   "fork-off",
+	Short: "Create the chain spec of a local fork of the state of a live chain",
+	Long: `The fork-off command downloads the state of a live chain at a block, from the RPC server
+of a running node or from the database of a stopped node, and writes a raw chain spec
+running the forked state on a development chain.
+The chain spec of the --chain flag must be a raw development chain spec. Its block production,
+finality and session state is kept, so the fork is run by its validators, such as Alice, and the
+rest of the state, including the runtime code and the account balances, is copied from the live chain.
+The sudo key is set to the --sudo account, and no new era is forced.
+Examples:
+
+To fork the state of a live node at its highest finalised block:
+	gossamer fork-off --chain=westend-dev --rpc-url=http://localhost:8545 --output=fork.json
+To fork the state of the database of a stopped node at a block:
+	gossamer fork-off --chain=westend-dev --base-path=path/to/node --block=0x... --output=fork.json
+To run the fork:
+	gossamer init --chain=fork.json --base-path=path/to/fork
+	gossamer --chain=fork.json --base-path=path/to/fork --alice`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return execForkOff(cmd)
+	},
+}
+
+func execForkOff(cmd *cobra.Command) (err error) {
+	base, err := forkBaseSpec(cmd)
+	if err != nil {
+		return err
+	}
+	fork, err := genesis.NewFork(base)
+	if err != nil {
+		return fmt.Errorf("creating fork: %w", err)
+	}
+
+	var blockHash *common.Hash
+	block, err := cmd.Flags().GetString("block")
+	if err != nil {
+		return fmt.Errorf("failed to get block: %s", err)
+	}
+	if block != "" {
+		hash, err := common.HexToHash(block)
+		if err != nil {
+			return fmt.Errorf("invalid block hash: %w", err)
+		}
+		blockHash = &hash
+	}
+
+	options, err := getForkOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	rpcURL, err := cmd.Flags().GetString("rpc-url")
+	if err != nil {
+		return fmt.Errorf("failed to get rpc-url: %s", err)
+	}
+
+	var forkedHash common.Hash
+	if rpcURL != "" {
+		pageSize, err := cmd.Flags().GetUint32("page-size")
+		if err != nil {
+			return fmt.Errorf("failed to get page-size: %s", err)
+		}
+		if pageSize == 0 {
+			return fmt.Errorf("page-size must be greater than 0")
+		}
+
+		forkedHash, err = downloadState(cmd.Context(), newRPCClient(rpcURL), blockHash, pageSize, fork.Add)
+		if err != nil {
+			return fmt.Errorf("downloading state: %w", err)
+		}
+	} else {
+		if basePath == "" {
+			return fmt.Errorf("one of rpc-url or base-path must be specified")
+		}
+		forkedHash, err = state.WalkState(utils.ExpandDir(basePath), blockHash, func(key, value []byte) error {
+			fork.Add(key, value)
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("reading state: %w", err)
+		}
+	}
+	logger.Infof("forked %d storage entries of the state at block %s", fork.Entries(), forkedHash)
+
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return fmt.Errorf("failed to get output: %s", err)
+	}
+
+	var writer io.Writer = cmd.OutOrStdout()
+	if output != "" {
+		file, err := os.Create(filepath.Clean(output))
+		if err != nil {
+			return fmt.Errorf("creating output file: %w", err)
+		}
+		defer func() {
+			closeErr := file.Close()
+			if err == nil && closeErr != nil {
+				err = fmt.Errorf("closing output file: %w", closeErr)
+			}
+		}()
+		writer = file
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "    ")
+	err = encoder.Encode(fork.Genesis(options))
+	if err != nil {
+		return fmt.Errorf("writing chain spec: %w", err)
+	}
+	return nil
+}
+
+// forkBaseSpec returns the raw chain spec of the chain flag, which is either a
+// path to a chain spec or the name of a chain.
+func forkBaseSpec(cmd *cobra.Command) (*genesis.Genesis, error) {
+	chain, err := cmd.Flags().GetString("chain")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain: %s", err)
+	}
+	if chain == "" {
+		return nil, fmt.Errorf("chain must be specified")
+	}
+
+	chainSpec := chain
+	if _, err := os.Stat(chain); err != nil {
+		err = parseChainSpec(chain)
+		if err != nil {
+			return nil, err
+		}
+		if config.ChainSpec == "" {
+			return nil, fmt.Errorf("unknown chain: %s", chain)
+		}
+		chainSpec = config.ChainSpec
+	}
+
+	base, err := genesis.NewGenesisFromJSONRaw(chainSpec)
+	if err != nil {
+		return nil, fmt.Errorf("loading chain spec: %w", err)
+	}
+	return base, nil
+}
+
+// getForkOptions returns the fork options of the command flags.
+func getForkOptions(cmd *cobra.Command) (options genesis.ForkOptions, err error) {
+	sudo, err := cmd.Flags().GetString("sudo")
+	if err != nil {
+		return options, fmt.Errorf("failed to get sudo: %s", err)
+	}
+
+	if sudo == "" {
+		keyring, err := keystore.NewSr25519Keyring()
+		if err != nil {
+			return options, fmt.Errorf("creating keyring: %w", err)
+		}
+		options.Sudo = keyring.Alice().Public().Encode()
+		return options, nil
+	}
+
+	options.Sudo, err = parsePublicKey(sudo)
+	if err != nil {
+		return options, fmt.Errorf("invalid sudo: %w", err)
+	}
+	if len(options.Sudo) != 32 {
+		return options, fmt.Errorf("invalid sudo: account id must be 32 bytes")
+	}
+	return options, nil
+}
+
+// stateDownloader downloads the storage of the state of a live node.
+type stateDownloader interface {
+	finalizedHead(ctx context.Context) (common.Hash, error)
+	keysPaged(ctx context.Context, count uint32, startKey []byte, at common.Hash) ([][]byte, error)
+	queryStorageAt(ctx context.Context, keys [][]byte, at common.Hash) ([][]byte, error)
+}
+
+// downloadState calls fn with the storage entries of the state at the block,
+// defaulting to the highest finalised block, downloading the keys and values
+// by pages of the given size. It returns the hash of the block.
+func downloadState(ctx context.Context, downloader stateDownloader, blockHash *common.Hash,
+	pageSize uint32, fn func(key, value []byte)) (hash common.Hash, err error) {
+	if blockHash != nil {
+		hash = *blockHash
+	} else {
+		hash, err = downloader.finalizedHead(ctx)
+		if err != nil {
+			return hash, fmt.Errorf("getting finalised head: %w", err)
+		}
+	}
+
+	var startKey []byte
+	var entries int
+	for {
+		keys, err := downloader.keysPaged(ctx, pageSize, startKey, hash)
+		if err != nil {
+			return hash, fmt.Errorf("getting keys after %s: %w", common.BytesToHex(startKey), err)
+		}
+		if len(keys) == 0 {
+			return hash, nil
+		}
+
+		values, err := downloader.queryStorageAt(ctx, keys, hash)
+		if err != nil {
+			return hash, fmt.Errorf("getting values: %w", err)
+		}
+		for i, key := range keys {
+			if values[i] != nil {
+				fn(key, values[i])
+			}
+		}
+
+		entries += len(keys)
+		logger.Debugf("downloaded %d storage entries", entries)
+		if len(keys) < int(pageSize) {
+			return hash, nil
+		}
+		startKey = keys[len(keys)-1]
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testDownloader serves the sorted storage entries of a state, counting the pages requested.
+type testDownloader struct {
+	head    common.Hash
+	entries map[string][]byte
+	pages   int
+}
+
+func (d *testDownloader) finalizedHead(context.Context) (common.Hash, error) {
+	return d.head, nil
+}
+
+func (d *testDownloader) keysPaged(_ context.Context, count uint32, startKey []byte, at common.Hash) (
+	[][]byte, error) {
+	d.pages++
+
+	var keys [][]byte
+	for key := range d.entries {
+		if bytes.Compare([]byte(key), startKey) > 0 {
+			keys = append(keys, []byte(key))
+		}
+	}
+	slices.SortFunc(keys, bytes.Compare)
+	return keys[:min(len(keys), int(count))], nil
+}
+
+func (d *testDownloader) queryStorageAt(_ context.Context, keys [][]byte, at common.Hash) ([][]byte, error) {
+	values := make([][]byte, len(keys))
+	for i, key := range keys {
+		values[i] = d.entries[string(key)]
+	}
+	return values, nil
+}
+
+func Test_downloadState(t *testing.T) {
+	t.Parallel()
+
+	downloader := &testDownloader{
+		head: common.Hash{1},
+		entries: map[string][]byte{
+			"a": {1}, "b": {2}, "c": {3}, "d": {4}, "e": {5},
+		},
+	}
+
+	downloaded := make(map[string][]byte)
+	hash, err := downloadState(context.Background(), downloader, nil, 2, func(key, value []byte) {
+		downloaded[string(key)] = value
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, common.Hash{1}, hash)
+	assert.Equal(t, downloader.entries, downloaded)
+	// the last page has a single key
+	assert.Equal(t, 3, downloader.pages)
+
+	blockHash := common.Hash{2}
+	hash, err = downloadState(context.Background(), downloader, &blockHash, 5, func([]byte, []byte) {})
+	require.NoError(t, err)
+	assert.Equal(t, blockHash, hash)
+	// the page is full so the next page is requested
+	assert.Equal(t, 5, downloader.pages)
+}
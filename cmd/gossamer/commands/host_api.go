@@ -0,0 +1,145 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	wazero_runtime "github.com/ChainSafe/gossamer/lib/runtime/wazero"
+	"github.com/ChainSafe/gossamer/lib/utils"
+	"github.com/spf13/cobra"
+)
+
+var errHostAPIIncompatible = errors.New("runtime imports host functions which are not provided")
+
+func init() {
+	HostAPICmd.Flags().String("wasm-file", "", "Path to the wasm runtime binary file to check. "+
+		"Defaults to the runtime of the node database")
+}
+
+// HostAPICmd is the command to check the host functions imported by a runtime
+var HostAPICmd = &cobra.Command{
+	Use:   "host-api",
+	Short: "Check the host functions imported by a runtime against the host functions of gossamer",
+	Long: `The host-api command is used to check the host functions imported by a runtime
+against the host functions provided by gossamer, by name, signature and version.
+The report command lists the missing, mismatched and extra host functions, then
+instantiates the runtime if all its imports are provided, and fails if they are not.
+The runtime is read from a wasm file or from the node database, which must not be in use.
+Examples:
+
+To check a runtime wasm file:
+	gossamer host-api report --wasm-file=path/to/runtime.compact.compressed.wasm
+To check the runtime of the best block, or at a block number or hash, of the node database:
+	gossamer host-api report --base-path=path/to/node
+	gossamer host-api report 1000 --base-path=path/to/node`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			logger.Errorf("host-api command cannot be empty")
+			return cmd.Help()
+		}
+
+		switch args[0] {
+		case "report":
+			return execHostAPIReport(cmd, args[1:])
+		default:
+			logger.Errorf("invalid host-api command: %s", args[0])
+			return fmt.Errorf("invalid host-api command: %s", args[0])
+		}
+	},
+}
+
+// execHostAPIReport prints the host API report of the runtime of the wasm
+// file flag, or of the runtime at the given block of the node database.
+func execHostAPIReport(cmd *cobra.Command, args []string) error {
+	wasmFile, err := cmd.Flags().GetString("wasm-file")
+	if err != nil {
+		return fmt.Errorf("failed to get wasm-file: %s", err)
+	}
+
+	var code []byte
+	if wasmFile != "" {
+		code, err = os.ReadFile(filepath.Clean(wasmFile))
+		if err != nil {
+			return fmt.Errorf("reading wasm file: %w", err)
+		}
+	} else {
+		if basePath == "" {
+			basePath = config.BasePath
+		}
+		if basePath == "" {
+			return fmt.Errorf("one of wasm-file or base-path must be specified")
+		}
+
+		var blockID string
+		if len(args) > 0 {
+			blockID = args[0]
+		}
+		_, code, err = loadRuntimeCode(utils.ExpandDir(basePath), blockID)
+		if err != nil {
+			return err
+		}
+	}
+
+	report, err := wazero_runtime.CheckHostAPI(code)
+	if err != nil {
+		return fmt.Errorf("checking host API: %w", err)
+	}
+
+	err = writeHostAPIReport(cmd.OutOrStdout(), report)
+	if err != nil {
+		return err
+	}
+
+	if !report.Compatible() {
+		return fmt.Errorf("%w: %d missing and %d mismatched",
+			errHostAPIIncompatible, len(report.Missing), len(report.Mismatched))
+	}
+
+	version, err := wazero_runtime.GetRuntimeVersion(code)
+	if err != nil {
+		return fmt.Errorf("instantiating runtime: %w", err)
+	}
+	_, err = fmt.Fprintf(cmd.OutOrStdout(), "runtime %s v%d instantiated\n", version.SpecName, version.SpecVersion)
+	return err
+}
+
+// writeHostAPIReport writes the missing, mismatched and extra host functions of the report.
+func writeHostAPIReport(w io.Writer, report wazero_runtime.HostAPIReport) error {
+	lines := []string{
+		fmt.Sprintf("imported host functions: %d", len(report.Imports)),
+		fmt.Sprintf("missing host functions: %d", len(report.Missing)),
+	}
+
+	for _, missing := range report.Missing {
+		line := "  " + missing.Import.String()
+		if len(missing.ProvidedVersions) > 0 {
+			versions := make([]string, len(missing.ProvidedVersions))
+			for i, version := range missing.ProvidedVersions {
+				versions[i] = fmt.Sprintf("%d", version)
+			}
+			line += " (provided versions: " + strings.Join(versions, ", ") + ")"
+		}
+		lines = append(lines, line)
+	}
+
+	lines = append(lines, fmt.Sprintf("mismatched host functions: %d", len(report.Mismatched)))
+	for _, mismatched := range report.Mismatched {
+		lines = append(lines, fmt.Sprintf("  %s imported as %s, provided as %s", mismatched.Import.Name,
+			mismatched.Import.Signature(), mismatched.Provided.Signature()))
+	}
+
+	lines = append(lines, fmt.Sprintf("extra host functions: %d", len(report.Extra)))
+	for _, extra := range report.Extra {
+		lines = append(lines, "  "+extra.String())
+	}
+
+	_, err := fmt.Fprintln(w, strings.Join(lines, "\n"))
+	return err
+}
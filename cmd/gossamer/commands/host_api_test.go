@@ -0,0 +1,52 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"bytes"
+	"testing"
+
+	wazero_runtime "github.com/ChainSafe/gossamer/lib/runtime/wazero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tetratelabs/wazero/api"
+)
+
+func Test_writeHostAPIReport(t *testing.T) {
+	t.Parallel()
+
+	const i32, i64 = api.ValueTypeI32, api.ValueTypeI64
+
+	report := wazero_runtime.HostAPIReport{
+		Imports: make([]wazero_runtime.HostFunction, 3),
+		Missing: []wazero_runtime.MissingHostFunction{
+			{
+				Import: wazero_runtime.HostFunction{
+					Name: "ext_storage_get_version_2", Params: []api.ValueType{i64}, Results: []api.ValueType{i64},
+				},
+				ProvidedVersions: []uint32{1},
+			},
+			{Import: wazero_runtime.HostFunction{Name: "ext_unknown_version_1"}},
+		},
+		Mismatched: []wazero_runtime.MismatchedHostFunction{{
+			Import:   wazero_runtime.HostFunction{Name: "ext_misc_print_num_version_1", Params: []api.ValueType{i32}},
+			Provided: wazero_runtime.HostFunction{Name: "ext_misc_print_num_version_1", Params: []api.ValueType{i64}},
+		}},
+		Extra: []wazero_runtime.HostFunction{{Name: "ext_allocator_free_version_1", Params: []api.ValueType{i32}}},
+	}
+
+	buffer := bytes.NewBuffer(nil)
+	err := writeHostAPIReport(buffer, report)
+	require.NoError(t, err)
+
+	expected := "imported host functions: 3\n" +
+		"missing host functions: 2\n" +
+		"  ext_storage_get_version_2(i64) -> i64 (provided versions: 1)\n" +
+		"  ext_unknown_version_1()\n" +
+		"mismatched host functions: 1\n" +
+		"  ext_misc_print_num_version_1 imported as (i32), provided as (i64)\n" +
+		"extra host functions: 1\n" +
+		"  ext_allocator_free_version_1(i32)\n"
+	assert.Equal(t, expected, buffer.String())
+}
@@ -0,0 +1,60 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/utils"
+	"github.com/spf13/cobra"
+)
+
+// MigrateNodeKeysCmd is the command to migrate the state trie node keys of the node database
+var MigrateNodeKeysCmd = &cobra.Command{
+	Use:   "migrate-node-keys",
+	Short: "Migrate the state trie node keys to locality keys",
+	Long: `The migrate-node-keys command moves the state trie nodes of the node database,
+stored under their hash by previous versions, to their locality key made of the first
+nibbles of their path followed by their hash. Nodes of the same subtree are then
+written next to each other, which reduces the compaction write amplification.
+Nodes not yet migrated are still read from their hash key, so the migration is optional
+and can be interrupted and run again. The node must be stopped while it runs.
+Examples:
+
+	gossamer migrate-node-keys --base-path=path/to/node`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return execMigrateNodeKeys()
+	},
+}
+
+func execMigrateNodeKeys() (err error) {
+	if basePath == "" {
+		basePath = config.BasePath
+	}
+	if basePath == "" {
+		return fmt.Errorf("base-path must be specified")
+	}
+	basePath = utils.ExpandDir(basePath)
+
+	db, err := database.LoadDatabase(basePath, false)
+	if err != nil {
+		return fmt.Errorf("loading database: %w", err)
+	}
+	defer func() {
+		closeErr := db.Close()
+		if err == nil && closeErr != nil {
+			err = fmt.Errorf("closing database: %w", closeErr)
+		}
+	}()
+
+	migratedNodes, err := state.MigrateTrieNodeKeys(db)
+	if err != nil {
+		return fmt.Errorf("migrating trie node keys: %w", err)
+	}
+
+	logger.Infof("migrated %d trie nodes to locality keys", migratedNodes)
+	return nil
+}
@@ -84,7 +84,7 @@ func NewRootCommand() (*cobra.Command, error) {
 			return execRoot(cmd)
 		},
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) (err error) {
-			if !(cmd.Name() == "gossamer" || cmd.Name() == "init") {
+			if !(cmd.Name() == "gossamer" || cmd.Name() == "init" || cmd.Name() == "config") {
 				return nil
 			}
 
@@ -125,7 +125,7 @@ func NewRootCommand() (*cobra.Command, error) {
 				return fmt.Errorf("failed to parse log level: %s", err)
 			}
 
-			if cmd.Name() == "gossamer" {
+			if cmd.Name() == "gossamer" || cmd.Name() == "config" {
 				if err := configureViper(config.BasePath); err != nil {
 					return fmt.Errorf("failed to configure viper: %s", err)
 				}
@@ -133,7 +133,10 @@ func NewRootCommand() (*cobra.Command, error) {
 				if err := ParseConfig(); err != nil {
 					return fmt.Errorf("failed to parse config: %s", err)
 				}
+			}
 
+			// the config command reports the validation errors itself
+			if cmd.Name() == "gossamer" {
 				if err := config.ValidateBasic(); err != nil {
 					return fmt.Errorf("error in config file: %v", err)
 				}
@@ -224,6 +227,15 @@ func addBaseConfigFlags(cmd *cobra.Command) error {
 		"no-telemetry"); err != nil {
 		return fmt.Errorf("failed to add --no-telemetry flag: %s", err)
 	}
+	if err := addStringFlagBindViper(cmd,
+		"panic-policy",
+		config.BaseConfig.PanicPolicy,
+		"Action taken by each subsystem when one of its goroutines panics. "+
+			"Syntax is a list of 'subsystem=policy' (comma separated), "+
+			"e.g. rpc=restart,telemetry=shutdown. Policies are restart and shutdown",
+		"panic-policy"); err != nil {
+		return fmt.Errorf("failed to add --panic-policy flag: %s", err)
+	}
 	if err := addUint32FlagBindViper(cmd,
 		"prometheus-port",
 		config.BaseConfig.PrometheusPort,
@@ -242,6 +254,27 @@ func addBaseConfigFlags(cmd *cobra.Command) error {
 		"state-pruning",
 		string(config.BaseConfig.Pruning),
 		"State trie online pruning")
+	if err := addBoolFlagBindViper(cmd,
+		"compress-block-data",
+		config.BaseConfig.CompressBlockData,
+		"Compress the stored block bodies and justifications",
+		"compress-block-data"); err != nil {
+		return fmt.Errorf("failed to add --compress-block-data flag: %s", err)
+	}
+	if err := addUint32FlagBindViper(cmd,
+		"max-fork-depth",
+		config.BaseConfig.MaxForkDepth,
+		"Number of blocks behind the best block beyond which unfinalised branches are discarded, 0 to disable",
+		"max-fork-depth"); err != nil {
+		return fmt.Errorf("failed to add --max-fork-depth flag: %s", err)
+	}
+	if err := addStringSliceFlagBindViper(cmd,
+		"keep-branches",
+		config.BaseConfig.KeepBranches,
+		"Hashes of blocks whose branches are never discarded",
+		"keep-branches"); err != nil {
+		return fmt.Errorf("failed to add --keep-branches flag: %s", err)
+	}
 	if err := addBoolFlagBindViper(cmd,
 		"prometheus-external",
 		config.BaseConfig.PrometheusExternal,
@@ -276,6 +309,15 @@ func addAccountFlags(cmd *cobra.Command) error {
 		return fmt.Errorf("failed to add --unlock flag: %s", err)
 	}
 
+	if err := addStringFlagBindViper(cmd,
+		"grandpa-kms",
+		config.Account.GrandpaKMS,
+		"Key management service key to sign grandpa votes with, "+
+			"eg. gcpkms://<key version name> or awskms:///<key id or arn>.",
+		"account.grandpa-kms"); err != nil {
+		return fmt.Errorf("failed to add --grandpa-kms flag: %s", err)
+	}
+
 	// Default Account flags
 	cmd.PersistentFlags().BoolVar(&alice,
 		"alice",
@@ -371,6 +413,14 @@ func addNetworkFlags(cmd *cobra.Command) error {
 		return fmt.Errorf("failed to add --discovery-interval flag: %s", err)
 	}
 
+	if err := addDurationFlagBindViper(cmd,
+		"bootnode-check-interval",
+		config.Network.BootnodeCheckInterval,
+		"Interval between two bootnode liveness checks. 0 only checks the bootnodes at startup",
+		"network.bootnode-check-interval"); err != nil {
+		return fmt.Errorf("failed to add --bootnode-check-interval flag: %s", err)
+	}
+
 	if err := addStringFlagBindViper(cmd,
 		"public-ip",
 		config.Network.PublicIP,
@@ -403,6 +453,14 @@ func addNetworkFlags(cmd *cobra.Command) error {
 		return fmt.Errorf("failed to add --listen-addr flag: %s", err)
 	}
 
+	if err := addStringFlagBindViper(cmd,
+		"fuzz-corpus-dir",
+		config.Network.FuzzCorpusDir,
+		"Directory to record the inbound protocol messages into as a fuzz corpus. Empty disables the capture",
+		"network.fuzz-corpus-dir"); err != nil {
+		return fmt.Errorf("failed to add --fuzz-corpus-dir flag: %s", err)
+	}
+
 	return nil
 }
 
@@ -477,6 +535,14 @@ func addRPCFlags(cmd *cobra.Command) error {
 		return fmt.Errorf("failed to add --ws-unsafe-external flag: %s", err)
 	}
 
+	if err := addDurationFlagBindViper(cmd,
+		"rpc-slow-call-threshold",
+		config.RPC.SlowCallThreshold,
+		"Duration above which the RPC calls are logged with their method, request size and caller, 0 to disable",
+		"rpc.slow-call-threshold"); err != nil {
+		return fmt.Errorf("failed to add --rpc-slow-call-threshold flag: %s", err)
+	}
+
 	// dummy flag to conform with the substrate cli
 	cmd.Flags().String("rpc-cors",
 		"",
@@ -529,6 +595,100 @@ func addCoreFlags(cmd *cobra.Command) error {
 		return fmt.Errorf("failed to add --grandpa-interval flag: %s", err)
 	}
 
+	if err := addBoolFlagBindViper(cmd,
+		"grandpa-observer",
+		config.Core.GrandpaObserver,
+		"Follow the GRANDPA rounds without casting votes, if not a GRANDPA authority",
+		"core.grandpa-observer"); err != nil {
+		return fmt.Errorf("failed to add --grandpa-observer flag: %s", err)
+	}
+
+	if err := addUintFlagBindViper(cmd,
+		"max-finality-lag",
+		config.Core.MaxFinalityLag,
+		"Maximum number of unfinalised blocks before the finality lag policy is applied to BABE authoring. "+
+			"0 disables the limit",
+		"core.max-finality-lag"); err != nil {
+		return fmt.Errorf("failed to add --max-finality-lag flag: %s", err)
+	}
+
+	if err := addStringFlagBindViper(cmd,
+		"finality-lag-policy",
+		config.Core.FinalityLagPolicy,
+		"BABE authoring policy when the finality lag is exceeded. One of 'skip' or 'finalised'",
+		"core.finality-lag-policy"); err != nil {
+		return fmt.Errorf("failed to add --finality-lag-policy flag: %s", err)
+	}
+
+	if err := addUint64FlagBindViper(cmd,
+		"heap-pages",
+		config.Core.HeapPages,
+		"Number of wasm heap pages of the runtime, overriding the ':heappages' storage value. "+
+			"0 uses the storage value",
+		"core.heap-pages"); err != nil {
+		return fmt.Errorf("failed to add --heap-pages flag: %s", err)
+	}
+
+	if err := addStringFlagBindViper(cmd,
+		"float-determinism",
+		config.Core.FloatDeterminism,
+		"Policy applied to the float instructions of the runtime. One of 'off', 'warn' or 'canonicalise'",
+		"core.float-determinism"); err != nil {
+		return fmt.Errorf("failed to add --float-determinism flag: %s", err)
+	}
+
+	if err := addUint32FlagBindViper(cmd,
+		"grandpa-journal-size",
+		config.Core.GrandpaJournalSize,
+		"Number of GRANDPA consensus messages kept in the message journal dumped on panic. 0 disables the journal",
+		"core.grandpa-journal-size"); err != nil {
+		return fmt.Errorf("failed to add --grandpa-journal-size flag: %s", err)
+	}
+
+	if err := addUint32FlagBindViper(cmd,
+		"sync-pipeline-depth",
+		config.Core.SyncPipelineDepth,
+		"Number of blocks ahead of the executing block whose justifications are verified during initial sync. "+
+			"0 disables the pipeline",
+		"core.sync-pipeline-depth"); err != nil {
+		return fmt.Errorf("failed to add --sync-pipeline-depth flag: %s", err)
+	}
+
+	if err := addBoolFlagBindViper(cmd,
+		"runtime-upgrade-dry-run",
+		config.Core.RuntimeUpgradeDryRun,
+		"Execute the block following a runtime upgrade again with both the previous and the new runtime, "+
+			"alerting on unexpected state root divergences",
+		"core.runtime-upgrade-dry-run"); err != nil {
+		return fmt.Errorf("failed to add --runtime-upgrade-dry-run flag: %s", err)
+	}
+
+	if err := addStringFlagBindViper(cmd,
+		"standby-lease",
+		config.Core.StandbyLease,
+		"Path of the lease file shared with the nodes running with the same authority keys, "+
+			"of which only the lease holder authors blocks and votes. Empty disables standby mode",
+		"core.standby-lease"); err != nil {
+		return fmt.Errorf("failed to add --standby-lease flag: %s", err)
+	}
+
+	if err := addDurationFlagBindViper(cmd,
+		"standby-lease-ttl",
+		config.Core.StandbyLeaseTTL,
+		"Duration after which a standby node takes over if the active node did not renew its lease",
+		"core.standby-lease-ttl"); err != nil {
+		return fmt.Errorf("failed to add --standby-lease-ttl flag: %s", err)
+	}
+
+	if err := addDurationFlagBindViper(cmd,
+		"authoring-propagation-margin",
+		config.Core.AuthoringPropagationMargin,
+		"Time reserved at the end of each BABE authoring slot to finalise, seal and propagate the block, "+
+			"after which no more extrinsics are applied. 0 defaults to a third of the slot duration",
+		"core.authoring-propagation-margin"); err != nil {
+		return fmt.Errorf("failed to add --authoring-propagation-margin flag: %s", err)
+	}
+
 	return nil
 }
 
@@ -541,6 +701,14 @@ func addStateFlags(cmd *cobra.Command) error {
 		return fmt.Errorf("failed to add --rewind flag: %s", err)
 	}
 
+	if err := addDurationFlagBindViper(cmd,
+		"state-verify-interval", config.State.VerifyInterval,
+		"Interval between two verifications of the state trie of the highest finalised block "+
+			"against the database, 0 to disable",
+		"state.verify-interval"); err != nil {
+		return fmt.Errorf("failed to add --state-verify-interval flag: %s", err)
+	}
+
 	return nil
 }
 
@@ -604,6 +772,12 @@ func execRoot(cmd *cobra.Command) error {
 		return fmt.Errorf("failed to unlock keystore: %s", err)
 	}
 
+	if config.Account.GrandpaKMS != "" {
+		if err := loadGrandpaKMSKey(ks, config.Account.GrandpaKMS); err != nil {
+			return err
+		}
+	}
+
 	if err := config.ValidateBasic(); err != nil {
 		return fmt.Errorf("failed to validate config: %s", err)
 	}
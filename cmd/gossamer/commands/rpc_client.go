@@ -0,0 +1,272 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	ctypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+const (
+	// rpcTimeout is the timeout of a request to the RPC server of the node
+	rpcTimeout = 30 * time.Second
+	// maxRPCResponseSize is the maximum size of a response of the RPC server
+	maxRPCResponseSize = 64 << 20
+)
+
+var errRPCResponse = errors.New("RPC error response")
+
+// rpcClient is a JSON-RPC client of the HTTP RPC server of a running node.
+type rpcClient struct {
+	url    string
+	client *http.Client
+	nextID uint64
+}
+
+func newRPCClient(url string) *rpcClient {
+	return &rpcClient{
+		url:    url,
+		client: &http.Client{Timeout: rpcTimeout},
+	}
+}
+
+type rpcRequest struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      uint64 `json:"id"`
+	Method  string `json:"method"`
+	Params  []any  `json:"params"`
+}
+
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// call calls the RPC method with the given parameters and decodes its result into result.
+func (c *rpcClient) call(ctx context.Context, result any, method string, params ...any) error {
+	if params == nil {
+		params = []any{}
+	}
+	c.nextID++
+	body, err := json.Marshal(rpcRequest{JSONRPC: "2.0", ID: c.nextID, Method: method, Params: params})
+	if err != nil {
+		return fmt.Errorf("encoding %s request: %w", method, err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating %s request: %w", method, err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	httpResponse, err := c.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("sending %s request: %w", method, err)
+	}
+	defer httpResponse.Body.Close()
+
+	var response rpcResponse
+	err = json.NewDecoder(io.LimitReader(httpResponse.Body, maxRPCResponseSize)).Decode(&response)
+	if err != nil {
+		return fmt.Errorf("decoding %s response: %w", method, err)
+	}
+
+	if response.Error != nil {
+		return fmt.Errorf("%w: %s: %s (code %d)", errRPCResponse, method, response.Error.Message, response.Error.Code)
+	}
+
+	if result == nil {
+		return nil
+	}
+	err = json.Unmarshal(response.Result, result)
+	if err != nil {
+		return fmt.Errorf("decoding %s result: %w", method, err)
+	}
+	return nil
+}
+
+// getStorage returns the value of the storage key at the best block, or nil if
+// the key is not set. Unset keys are returned either as null or as an empty string.
+func (c *rpcClient) getStorage(ctx context.Context, key []byte) ([]byte, error) {
+	var value *string
+	err := c.call(ctx, &value, "state_getStorage", common.BytesToHex(key))
+	if err != nil {
+		return nil, err
+	}
+	if value == nil || *value == "" {
+		return nil, nil
+	}
+	return common.HexToBytes(*value)
+}
+
+// metadata returns the decoded metadata of the runtime at the best block.
+func (c *rpcClient) metadata(ctx context.Context) (*ctypes.Metadata, error) {
+	var encoded string
+	err := c.call(ctx, &encoded, "state_getMetadata")
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := &ctypes.Metadata{}
+	err = codec.DecodeFromHex(encoded, metadata)
+	if err != nil {
+		return nil, fmt.Errorf("decoding metadata: %w", err)
+	}
+	return metadata, nil
+}
+
+// runtimeVersion returns the version of the runtime at the best block.
+func (c *rpcClient) runtimeVersion(ctx context.Context) (runtime.Version, error) {
+	var version struct {
+		SpecVersion        uint32 `json:"specVersion"`
+		TransactionVersion uint32 `json:"transactionVersion"`
+	}
+	err := c.call(ctx, &version, "state_getRuntimeVersion")
+	if err != nil {
+		return runtime.Version{}, err
+	}
+	return runtime.Version{
+		SpecVersion:        version.SpecVersion,
+		TransactionVersion: version.TransactionVersion,
+	}, nil
+}
+
+// blockHash returns the hash of the block with the given number, or of the
+// best block if the number is nil.
+func (c *rpcClient) blockHash(ctx context.Context, number *uint64) (common.Hash, error) {
+	params := []any{}
+	if number != nil {
+		params = append(params, *number)
+	}
+
+	var hash string
+	err := c.call(ctx, &hash, "chain_getBlockHash", params...)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.HexToHash(hash)
+}
+
+// finalizedHead returns the hash of the highest finalised block.
+func (c *rpcClient) finalizedHead(ctx context.Context) (common.Hash, error) {
+	var hash string
+	err := c.call(ctx, &hash, "chain_getFinalizedHead")
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.HexToHash(hash)
+}
+
+// keysPaged returns at most count storage keys of the state at the block, in
+// lexicographic order, starting after the start key if it is not empty.
+func (c *rpcClient) keysPaged(ctx context.Context, count uint32, startKey []byte, at common.Hash) (
+	keys [][]byte, err error) {
+	var start *string
+	if len(startKey) > 0 {
+		hexKey := common.BytesToHex(startKey)
+		start = &hexKey
+	}
+
+	var hexKeys []string
+	err = c.call(ctx, &hexKeys, "state_getKeysPaged", "0x", count, start, at.String())
+	if err != nil {
+		return nil, err
+	}
+
+	keys = make([][]byte, len(hexKeys))
+	for i, hexKey := range hexKeys {
+		keys[i], err = common.HexToBytes(hexKey)
+		if err != nil {
+			return nil, fmt.Errorf("decoding key: %w", err)
+		}
+	}
+	return keys, nil
+}
+
+// queryStorageAt returns the values of the storage keys of the state at the
+// block, a value being nil if its key is not set.
+func (c *rpcClient) queryStorageAt(ctx context.Context, keys [][]byte, at common.Hash) (
+	values [][]byte, err error) {
+	hexKeys := make([]string, len(keys))
+	for i, key := range keys {
+		hexKeys[i] = common.BytesToHex(key)
+	}
+
+	var changeSets []struct {
+		Changes [][2]*string `json:"changes"`
+	}
+	err = c.call(ctx, &changeSets, "state_queryStorageAt", hexKeys, at.String())
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string][]byte, len(keys))
+	for _, changeSet := range changeSets {
+		for _, change := range changeSet.Changes {
+			if change[0] == nil || change[1] == nil {
+				continue
+			}
+			changes[*change[0]], err = common.HexToBytes(*change[1])
+			if err != nil {
+				return nil, fmt.Errorf("decoding value: %w", err)
+			}
+		}
+	}
+
+	values = make([][]byte, len(keys))
+	for i, hexKey := range hexKeys {
+		values[i] = changes[hexKey]
+	}
+	return values, nil
+}
+
+// blockNumber returns the number of the block with the given hash.
+func (c *rpcClient) blockNumber(ctx context.Context, hash common.Hash) (uint64, error) {
+	var header struct {
+		Number string `json:"number"`
+	}
+	err := c.call(ctx, &header, "chain_getHeader", hash.String())
+	if err != nil {
+		return 0, err
+	}
+
+	number, err := strconv.ParseUint(header.Number, 0, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parsing block number: %w", err)
+	}
+	return number, nil
+}
+
+// accountNextIndex returns the next nonce of the account with the given ss58 address.
+func (c *rpcClient) accountNextIndex(ctx context.Context, address string) (uint64, error) {
+	var nonce uint64
+	err := c.call(ctx, &nonce, "system_accountNextIndex", address)
+	return nonce, err
+}
+
+// submitExtrinsic submits the extrinsic to the transaction pool of the node
+// and returns its hash.
+func (c *rpcClient) submitExtrinsic(ctx context.Context, extrinsic []byte) (common.Hash, error) {
+	var hash string
+	err := c.call(ctx, &hash, "author_submitExtrinsic", common.BytesToHex(extrinsic))
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.HexToHash(hash)
+}
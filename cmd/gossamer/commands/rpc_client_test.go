@@ -0,0 +1,56 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_rpcClient_getStorage(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request rpcRequest
+		err := json.NewDecoder(r.Body).Decode(&request)
+		require.NoError(t, err)
+		assert.Equal(t, "state_getStorage", request.Method)
+
+		switch request.Params[0] {
+		case "0x01":
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x0a0b"}`))
+		case "0x02":
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":null}`))
+		case "0x03":
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":""}`))
+		default:
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"invalid key"}}`))
+		}
+	}))
+	defer server.Close()
+
+	client := newRPCClient(server.URL)
+
+	value, err := client.getStorage(context.Background(), []byte{1})
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0xa, 0xb}, value)
+
+	value, err = client.getStorage(context.Background(), []byte{2})
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	value, err = client.getStorage(context.Background(), []byte{3})
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	_, err = client.getStorage(context.Background(), []byte{4})
+	assert.ErrorIs(t, err, errRPCResponse)
+	assert.EqualError(t, err, "RPC error response: state_getStorage: invalid key (code -32000)")
+}
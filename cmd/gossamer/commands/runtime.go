@@ -0,0 +1,222 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	wazero_runtime "github.com/ChainSafe/gossamer/lib/runtime/wazero"
+	"github.com/spf13/cobra"
+)
+
+var errInvalidBlockID = errors.New("invalid block id")
+
+// knownRuntimeAPIs are the names of the runtime APIs printed by the runtime
+// inspect command, the other APIs are printed as their blake2b_64 hash.
+var knownRuntimeAPIs = [...]string{
+	"Core",
+	"Metadata",
+	"BlockBuilder",
+	"TaggedTransactionQueue",
+	"OffchainWorkerApi",
+	"BabeApi",
+	"GrandpaApi",
+	"SessionKeys",
+	"AccountNonceApi",
+	"TransactionPaymentApi",
+	"TransactionPaymentCallApi",
+	"AuthorityDiscoveryApi",
+	"ParachainHost",
+	"BeefyApi",
+	"MmrApi",
+	"GenesisBuilder",
+}
+
+// RuntimeCmd is the command to inspect the runtime stored in the database
+var RuntimeCmd = &cobra.Command{
+	Use:   "runtime",
+	Short: "Inspect the runtime of the node database",
+	Long: `The runtime command is used to inspect the runtime stored in the node database.
+The node must not be running.
+Examples:
+
+To inspect the runtime of the best block:
+	gossamer runtime inspect --base-path=path/to/node
+To inspect the runtime at a block number or hash:
+	gossamer runtime inspect 1000 --base-path=path/to/node
+	gossamer runtime inspect 0x3aa9...355a --base-path=path/to/node`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			logger.Errorf("runtime command cannot be empty")
+			return cmd.Help()
+		}
+
+		switch args[0] {
+		case "inspect":
+			return execRuntimeInspect(args[1:])
+		default:
+			logger.Errorf("invalid runtime command: %s", args[0])
+			return fmt.Errorf("invalid runtime command: %s", args[0])
+		}
+	},
+}
+
+// execRuntimeInspect prints the code hash, versions and APIs of the runtime
+// at the given block, or at the best block if no block is given.
+func execRuntimeInspect(args []string) error {
+	if basePath == "" {
+		basePath = config.BasePath
+	}
+
+	if basePath == "" {
+		return fmt.Errorf("basepath must be specified")
+	}
+
+	var blockID string
+	if len(args) > 0 {
+		blockID = args[0]
+	}
+
+	header, code, err := loadRuntimeCode(basePath, blockID)
+	if err != nil {
+		return err
+	}
+
+	version, err := wazero_runtime.GetRuntimeVersion(code)
+	if err != nil {
+		return fmt.Errorf("getting runtime version: %w", err)
+	}
+
+	return writeRuntimeInfo(os.Stdout, header, code, version)
+}
+
+// loadRuntimeCode returns the header of the block with the given number or
+// hash and the runtime code at its state root.
+func loadRuntimeCode(basePath, blockID string) (header *types.Header, code []byte, err error) {
+	db, err := database.LoadDatabase(basePath, false)
+	if err != nil {
+		return nil, nil, fmt.Errorf("loading database: %w", err)
+	}
+	defer func() {
+		closeErr := db.Close()
+		if err == nil && closeErr != nil {
+			err = fmt.Errorf("closing database: %w", closeErr)
+		}
+	}()
+
+	tries := state.NewTries()
+	tries.SetEmptyTrie()
+
+	blockState, err := state.NewBlockState(db, tries, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating block state: %w", err)
+	}
+
+	storageState, err := state.NewStorageState(db, blockState, tries)
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating storage state: %w", err)
+	}
+
+	hash, number, err := parseBlockID(blockID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch {
+	case number != nil:
+		hash, err = blockState.GetHashByNumber(*number)
+		if err != nil {
+			return nil, nil, fmt.Errorf("getting hash of block %d: %w", *number, err)
+		}
+	case hash.IsEmpty():
+		hash = blockState.BestBlockHash()
+	}
+
+	header, err = blockState.GetHeader(hash)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting header of block %s: %w", hash, err)
+	}
+
+	code, err = storageState.LoadCode(&header.StateRoot)
+	if err != nil {
+		return nil, nil, fmt.Errorf("loading runtime code: %w", err)
+	}
+
+	if len(code) == 0 {
+		return nil, nil, fmt.Errorf("no runtime code at block %s", hash)
+	}
+
+	return header, code, nil
+}
+
+// parseBlockID parses a block hash or a block number. An empty block id
+// returns an empty hash and a nil number.
+func parseBlockID(blockID string) (hash common.Hash, number *uint, err error) {
+	if blockID == "" {
+		return hash, nil, nil
+	}
+
+	if strings.HasPrefix(blockID, "0x") {
+		hashBytes, err := common.HexToBytes(blockID)
+		if err != nil || len(hashBytes) != common.HashLength {
+			return hash, nil, fmt.Errorf("%w: %s", errInvalidBlockID, blockID)
+		}
+		return common.BytesToHash(hashBytes), nil, nil
+	}
+
+	parsed, err := strconv.ParseUint(blockID, 10, 0)
+	if err != nil {
+		return hash, nil, fmt.Errorf("%w: %s", errInvalidBlockID, blockID)
+	}
+	blockNumber := uint(parsed)
+	return hash, &blockNumber, nil
+}
+
+// writeRuntimeInfo writes the code hash, versions and APIs of the runtime.
+func writeRuntimeInfo(w io.Writer, header *types.Header, code []byte, version runtime.Version) error {
+	codeHash, err := common.Blake2bHash(code)
+	if err != nil {
+		return fmt.Errorf("hashing runtime code: %w", err)
+	}
+
+	apiNames := make(map[[8]byte]string, len(knownRuntimeAPIs))
+	for _, name := range knownRuntimeAPIs {
+		apiNames[common.MustBlake2b8([]byte(name))] = name
+	}
+
+	lines := []string{
+		fmt.Sprintf("block:               #%d (%s)", header.Number, header.Hash()),
+		fmt.Sprintf("code hash:           %s", codeHash),
+		fmt.Sprintf("code size:           %d bytes", len(code)),
+		fmt.Sprintf("spec name:           %s", version.SpecName),
+		fmt.Sprintf("impl name:           %s", version.ImplName),
+		fmt.Sprintf("authoring version:   %d", version.AuthoringVersion),
+		fmt.Sprintf("spec version:        %d", version.SpecVersion),
+		fmt.Sprintf("impl version:        %d", version.ImplVersion),
+		fmt.Sprintf("transaction version: %d", version.TransactionVersion),
+		fmt.Sprintf("state version:       %d", version.StateVersion),
+		"apis:",
+	}
+
+	for _, api := range version.APIItems {
+		name, ok := apiNames[api.Name]
+		if !ok {
+			name = common.BytesToHex(api.Name[:])
+		}
+		lines = append(lines, fmt.Sprintf("  %s v%d", name, api.Ver))
+	}
+
+	_, err = fmt.Fprintln(w, strings.Join(lines, "\n"))
+	return err
+}
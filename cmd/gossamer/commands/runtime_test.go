@@ -0,0 +1,105 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseBlockID(t *testing.T) {
+	t.Parallel()
+
+	hash := common.MustHexToHash("0x3aa96b0149b6ca3688878bdbd19464448624136398e3ce45b9e755d3ab61355a")
+	number := uint(1000)
+
+	testCases := map[string]struct {
+		blockID    string
+		hash       common.Hash
+		number     *uint
+		errWrapped error
+		errMessage string
+	}{
+		"empty": {},
+		"hash": {
+			blockID: hash.String(),
+			hash:    hash,
+		},
+		"number": {
+			blockID: "1000",
+			number:  &number,
+		},
+		"short_hash": {
+			blockID:    "0x3aa9",
+			errWrapped: errInvalidBlockID,
+			errMessage: "invalid block id: 0x3aa9",
+		},
+		"invalid_number": {
+			blockID:    "-1",
+			errWrapped: errInvalidBlockID,
+			errMessage: "invalid block id: -1",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			hash, number, err := parseBlockID(testCase.blockID)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errMessage != "" {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.hash, hash)
+			assert.Equal(t, testCase.number, number)
+		})
+	}
+}
+
+func Test_writeRuntimeInfo(t *testing.T) {
+	t.Parallel()
+
+	header := types.NewEmptyHeader()
+	header.Number = 7
+	code := []byte{0, 'a', 's', 'm'}
+	version := runtime.Version{
+		SpecName:           []byte("westend"),
+		ImplName:           []byte("parity-westend"),
+		AuthoringVersion:   2,
+		SpecVersion:        9290,
+		ImplVersion:        1,
+		TransactionVersion: 12,
+		StateVersion:       1,
+		APIItems: []runtime.APIItem{
+			{Name: common.MustBlake2b8([]byte("Core")), Ver: 4},
+			{Name: [8]byte{1, 2, 3, 4, 5, 6, 7, 8}, Ver: 1},
+		},
+	}
+
+	buffer := bytes.NewBuffer(nil)
+	err := writeRuntimeInfo(buffer, header, code, version)
+	require.NoError(t, err)
+
+	expected := "block:               #7 (" + header.Hash().String() + ")\n" +
+		"code hash:           " + common.MustBlake2bHash(code).String() + "\n" +
+		"code size:           4 bytes\n" +
+		"spec name:           westend\n" +
+		"impl name:           parity-westend\n" +
+		"authoring version:   2\n" +
+		"spec version:        9290\n" +
+		"impl version:        1\n" +
+		"transaction version: 12\n" +
+		"state version:       1\n" +
+		"apis:\n" +
+		"  Core v4\n" +
+		"  0x0102030405060708 v1\n"
+	assert.Equal(t, expected, buffer.String())
+}
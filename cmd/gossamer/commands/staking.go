@@ -0,0 +1,756 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"slices"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/ss58"
+	"github.com/ChainSafe/gossamer/lib/txbuilder"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	ctypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/spf13/cobra"
+)
+
+// defaultHistoryDepth is the number of eras the rewards can be claimed for,
+// used for runtimes declaring it neither as a constant nor as a storage item.
+const defaultHistoryDepth = 84
+
+var errNoActiveEra = errors.New("no active era")
+
+func init() {
+	StakingCmd.Flags().String("rpc-url", "http://localhost:8545", "URL of the HTTP RPC server of the node")
+	StakingCmd.Flags().String("account", "",
+		"ss58 address or 0x prefixed hex account id of the stash account. "+
+			"Defaults to the account of the suri for payout")
+	StakingCmd.Flags().String("suri", "", "secret URI of the key signing the payout extrinsics. Used with payout")
+	StakingCmd.Flags().String("scheme", "sr25519", "keyring scheme of the suri (sr25519, ed25519, secp256k1 or ecdsa)")
+	StakingCmd.Flags().Int64("era", -1, "only pay out the rewards of this era. Used with payout")
+	StakingCmd.Flags().Uint64("mortal-period", 64,
+		"number of blocks the payout extrinsics are valid for, 0 for immortal extrinsics. Used with payout")
+	StakingCmd.Flags().Bool("submit", false, "submit the payout extrinsics to the node. Used with payout")
+	StakingCmd.Flags().Uint16("network-prefix", ss58.SubstratePrefix, "ss58 network prefix of the addresses")
+	StakingCmd.Flags().String("network", "",
+		"name of the network of the addresses in the ss58 registry, such as polkadot. Overrides network-prefix")
+	StakingCmd.Flags().String("ss58-registry", "",
+		"path to a JSON ss58 registry to use instead of the registry embedded in gossamer")
+}
+
+// StakingCmd is the command to inspect the staking state and pay out staking rewards
+var StakingCmd = &cobra.Command{
+	Use:   "staking",
+	Short: "Inspect eras, nominations and unclaimed staking rewards",
+	Long: `The staking command reads the staking storage of a running node through its RPC server.
+Balances are printed in the smallest unit of the chain.
+Examples:
+
+To print the active era:
+	gossamer staking era --rpc-url=http://localhost:8545
+To print the nominations and exposures of a stash account at the active era:
+	gossamer staking info --account=5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY
+To list the unclaimed payouts of the validators of a stash account:
+	gossamer staking payouts --account=5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY
+To build the payout_stakers extrinsics of the unclaimed payouts, and submit them:
+	gossamer staking payout --suri="//Alice" --submit`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			logger.Errorf("staking command cannot be empty")
+			return cmd.Help()
+		}
+
+		switch args[0] {
+		case "era":
+			return execStakingEra(cmd)
+		case "info":
+			return execStakingInfo(cmd)
+		case "payouts":
+			return execStakingPayouts(cmd)
+		case "payout":
+			return execStakingPayout(cmd)
+		default:
+			logger.Errorf("invalid staking command: %s", args[0])
+			return fmt.Errorf("invalid staking command: %s", args[0])
+		}
+	},
+}
+
+// stakingStorage reads storage values, returning nil for keys which are not set.
+type stakingStorage interface {
+	getStorage(ctx context.Context, key []byte) ([]byte, error)
+}
+
+// stakingReader decodes the storage items of the staking pallet.
+type stakingReader struct {
+	storage  stakingStorage
+	metadata *ctypes.Metadata
+}
+
+type activeEraInfo struct {
+	Index uint32
+	Start *uint64
+}
+
+type nominations struct {
+	Targets     [][32]byte
+	SubmittedIn uint32
+	Suppressed  bool
+}
+
+type unlockChunk struct {
+	Value *big.Int
+	Era   *big.Int
+}
+
+// stakingLedger is the ledger of a stash account. Runtimes paging the exposures
+// name the claimed rewards legacy claimed rewards, and recent runtimes dropped them.
+type stakingLedger struct {
+	Stash          [32]byte
+	Total          *big.Int
+	Active         *big.Int
+	Unlocking      []unlockChunk
+	ClaimedRewards []uint32
+}
+
+type individualExposure struct {
+	Who   [32]byte
+	Value *big.Int
+}
+
+type exposure struct {
+	Total  *big.Int
+	Own    *big.Int
+	Others []individualExposure
+}
+
+type pagedExposureMetadata struct {
+	Total          *big.Int
+	Own            *big.Int
+	NominatorCount uint32
+	PageCount      uint32
+}
+
+type exposurePage struct {
+	PageTotal *big.Int
+	Others    []individualExposure
+}
+
+// eraExposure is the exposure of a validator at an era, the nominators of all
+// the pages being merged for runtimes paging the exposures.
+type eraExposure struct {
+	exposure
+	pageCount uint32
+}
+
+// payout is a payout of a validator and its nominators for an era.
+type payout struct {
+	validator [32]byte
+	era       uint32
+	// pages is the number of unclaimed exposure pages, each paid out by one
+	// payout_stakers call.
+	pages uint32
+}
+
+func (r *stakingReader) hasItem(item string) bool {
+	_, err := r.metadata.FindStorageEntryMetadata("Staking", item)
+	return err == nil
+}
+
+// read decodes the value of the storage item at the given SCALE encoded keys
+// into value, and returns false if the value is not set.
+func (r *stakingReader) read(ctx context.Context, value any, item string, keys ...[]byte) (found bool, err error) {
+	key, err := ctypes.CreateStorageKey(r.metadata, "Staking", item, keys...)
+	if err != nil {
+		return false, fmt.Errorf("creating %s storage key: %w", item, err)
+	}
+
+	data, err := r.storage.getStorage(ctx, key)
+	if err != nil {
+		return false, fmt.Errorf("getting %s: %w", item, err)
+	}
+	if data == nil {
+		return false, nil
+	}
+
+	err = scale.Unmarshal(data, value)
+	if err != nil {
+		return false, fmt.Errorf("decoding %s: %w", item, err)
+	}
+	return true, nil
+}
+
+func encodeEra(era uint32) []byte {
+	return binary.LittleEndian.AppendUint32(nil, era)
+}
+
+// activeEra returns the index of the active era.
+func (r *stakingReader) activeEra(ctx context.Context) (uint32, error) {
+	var info activeEraInfo
+	found, err := r.read(ctx, &info, "ActiveEra")
+	if err != nil {
+		return 0, err
+	}
+	if !found {
+		return 0, errNoActiveEra
+	}
+	return info.Index, nil
+}
+
+// currentEra returns the index of the latest planned era, or nil if none is planned.
+func (r *stakingReader) currentEra(ctx context.Context) (*uint32, error) {
+	var era uint32
+	found, err := r.read(ctx, &era, "CurrentEra")
+	if err != nil || !found {
+		return nil, err
+	}
+	return &era, nil
+}
+
+// historyDepth returns the number of eras the rewards can be claimed for.
+func (r *stakingReader) historyDepth(ctx context.Context) (uint32, error) {
+	value, err := r.metadata.FindConstantValue("Staking", "HistoryDepth")
+	if err == nil {
+		var depth uint32
+		err = scale.Unmarshal(value, &depth)
+		if err != nil {
+			return 0, fmt.Errorf("decoding HistoryDepth constant: %w", err)
+		}
+		return depth, nil
+	}
+
+	if r.hasItem("HistoryDepth") {
+		var depth uint32
+		found, err := r.read(ctx, &depth, "HistoryDepth")
+		if err != nil || found {
+			return depth, err
+		}
+	}
+	return defaultHistoryDepth, nil
+}
+
+// nominations returns the nominations of the stash, or nil if it does not nominate.
+func (r *stakingReader) nominations(ctx context.Context, stash []byte) (*nominations, error) {
+	var n nominations
+	found, err := r.read(ctx, &n, "Nominators", stash)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &n, nil
+}
+
+// ledger returns the ledger of the stash, or nil if the stash is not bonded.
+func (r *stakingReader) ledger(ctx context.Context, stash []byte) (*stakingLedger, error) {
+	var controller [32]byte
+	found, err := r.read(ctx, &controller, "Bonded", stash)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	var ledger stakingLedger
+	found, err = r.read(ctx, &ledger, "Ledger", controller[:])
+	if err == nil && !found {
+		return nil, nil
+	}
+	if err != nil {
+		// ledgers of recent runtimes no longer hold the claimed rewards
+		var head struct {
+			Stash     [32]byte
+			Total     *big.Int
+			Active    *big.Int
+			Unlocking []unlockChunk
+		}
+		_, headErr := r.read(ctx, &head, "Ledger", controller[:])
+		if headErr != nil {
+			return nil, err
+		}
+		ledger = stakingLedger{Stash: head.Stash, Total: head.Total, Active: head.Active, Unlocking: head.Unlocking}
+	}
+	return &ledger, nil
+}
+
+// exposure returns the exposure of the validator at the era, which has a zero
+// total if the validator was not elected.
+func (r *stakingReader) exposure(ctx context.Context, era uint32, validator []byte) (eraExposure, error) {
+	var legacy exposure
+	found, err := r.read(ctx, &legacy, "ErasStakers", encodeEra(era), validator)
+	if err != nil {
+		return eraExposure{}, err
+	}
+	if found && legacy.Total.Sign() > 0 {
+		return eraExposure{exposure: legacy, pageCount: 1}, nil
+	}
+
+	empty := eraExposure{exposure: exposure{Total: new(big.Int), Own: new(big.Int)}}
+	if !r.hasItem("ErasStakersOverview") {
+		return empty, nil
+	}
+
+	var overview pagedExposureMetadata
+	found, err = r.read(ctx, &overview, "ErasStakersOverview", encodeEra(era), validator)
+	if err != nil || !found {
+		return empty, err
+	}
+
+	paged := eraExposure{
+		exposure:  exposure{Total: overview.Total, Own: overview.Own},
+		pageCount: overview.PageCount,
+	}
+	for page := uint32(0); page < overview.PageCount; page++ {
+		var p exposurePage
+		_, err = r.read(ctx, &p, "ErasStakersPaged", encodeEra(era), validator, encodeEra(page))
+		if err != nil {
+			return eraExposure{}, err
+		}
+		paged.Others = append(paged.Others, p.Others...)
+	}
+	return paged, nil
+}
+
+// claimedPages returns the number of exposure pages of the validator already
+// paid out for the era.
+func (r *stakingReader) claimedPages(ctx context.Context, era uint32, validator []byte,
+	ledger *stakingLedger) (uint32, error) {
+	if ledger != nil && slices.Contains(ledger.ClaimedRewards, era) {
+		return ^uint32(0), nil
+	}
+	if !r.hasItem("ClaimedRewards") {
+		return 0, nil
+	}
+
+	var pages []uint32
+	_, err := r.read(ctx, &pages, "ClaimedRewards", encodeEra(era), validator)
+	if err != nil {
+		return 0, err
+	}
+	return uint32(len(pages)), nil
+}
+
+// unclaimedPayouts returns the payouts not claimed yet of the stash and of the
+// validators it nominates, for the eras the rewards can still be claimed for.
+func (r *stakingReader) unclaimedPayouts(ctx context.Context, stash []byte) ([]payout, error) {
+	activeEra, err := r.activeEra(ctx)
+	if err != nil {
+		return nil, err
+	}
+	depth, err := r.historyDepth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	validators := [][32]byte{[32]byte(stash)}
+	n, err := r.nominations(ctx, stash)
+	if err != nil {
+		return nil, err
+	}
+	if n != nil {
+		for _, target := range n.Targets {
+			if !bytes.Equal(target[:], stash) {
+				validators = append(validators, target)
+			}
+		}
+	}
+
+	ledgers := make(map[[32]byte]*stakingLedger, len(validators))
+	for _, validator := range validators {
+		ledgers[validator], err = r.ledger(ctx, validator[:])
+		if err != nil {
+			return nil, fmt.Errorf("getting ledger of %s: %w", common.BytesToHex(validator[:]), err)
+		}
+	}
+
+	firstEra := uint32(0)
+	if activeEra > depth {
+		firstEra = activeEra - depth
+	}
+
+	var payouts []payout
+	for era := firstEra; era < activeEra; era++ {
+		var reward scale.Uint128
+		found, err := r.read(ctx, &reward, "ErasValidatorReward", encodeEra(era))
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			continue
+		}
+
+		for _, validator := range validators {
+			e, err := r.exposure(ctx, era, validator[:])
+			if err != nil {
+				return nil, err
+			}
+			if e.Total.Sign() == 0 || !e.exposes(stash, validator) {
+				continue
+			}
+
+			claimed, err := r.claimedPages(ctx, era, validator[:], ledgers[validator])
+			if err != nil {
+				return nil, err
+			}
+			if claimed < e.pageCount {
+				payouts = append(payouts, payout{validator: validator, era: era, pages: e.pageCount - claimed})
+			}
+		}
+	}
+	return payouts, nil
+}
+
+// exposes returns true if the stash is the validator or one of its exposed nominators.
+func (e eraExposure) exposes(stash []byte, validator [32]byte) bool {
+	return bytes.Equal(stash, validator[:]) || e.nominatorStake(stash) != nil
+}
+
+// nominatorStake returns the stake of the nominator in the exposure, or nil if
+// the nominator is not exposed.
+func (e eraExposure) nominatorStake(nominator []byte) *big.Int {
+	for _, other := range e.Others {
+		if bytes.Equal(other.Who[:], nominator) {
+			return other.Value
+		}
+	}
+	return nil
+}
+
+// stakingSetup connects to the RPC server of the node and returns the client
+// and the staking reader of the best block.
+func stakingSetup(cmd *cobra.Command) (*rpcClient, *stakingReader, error) {
+	url, err := cmd.Flags().GetString("rpc-url")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get rpc-url: %s", err)
+	}
+
+	client := newRPCClient(url)
+	metadata, err := client.metadata(cmd.Context())
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting metadata: %w", err)
+	}
+	if !metadata.ExistsModuleMetadata("Staking") {
+		return nil, nil, fmt.Errorf("the runtime has no staking pallet")
+	}
+
+	return client, &stakingReader{storage: client, metadata: metadata}, nil
+}
+
+// getStash returns the account id of the account flag.
+func getStash(cmd *cobra.Command) ([]byte, error) {
+	account, err := cmd.Flags().GetString("account")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account: %s", err)
+	}
+	if account == "" {
+		return nil, fmt.Errorf("account must be specified")
+	}
+
+	stash, err := parsePublicKey(account)
+	if err != nil {
+		return nil, fmt.Errorf("invalid account: %w", err)
+	}
+	if len(stash) != 32 {
+		return nil, fmt.Errorf("invalid account: account id must be 32 bytes")
+	}
+	return stash, nil
+}
+
+func encodeAddress(network ss58.Network, accountID []byte) string {
+	address, err := ss58.Encode(network.Prefix, accountID)
+	if err != nil {
+		return common.BytesToHex(accountID)
+	}
+	return string(address)
+}
+
+// execStakingEra prints the active era, the current era and the history depth.
+func execStakingEra(cmd *cobra.Command) error {
+	_, reader, err := stakingSetup(cmd)
+	if err != nil {
+		return err
+	}
+	return printEras(cmd.Context(), cmd.OutOrStdout(), reader)
+}
+
+func printEras(ctx context.Context, out io.Writer, reader *stakingReader) error {
+	activeEra, err := reader.activeEra(ctx)
+	if err != nil {
+		return err
+	}
+	currentEra, err := reader.currentEra(ctx)
+	if err != nil {
+		return err
+	}
+	depth, err := reader.historyDepth(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "Active era:    %d\n", activeEra)
+	if currentEra != nil {
+		fmt.Fprintf(out, "Current era:   %d\n", *currentEra)
+	}
+	fmt.Fprintf(out, "History depth: %d\n", depth)
+	return nil
+}
+
+// execStakingInfo prints the ledger, nominations and exposures of the stash at the active era.
+func execStakingInfo(cmd *cobra.Command) error {
+	stash, err := getStash(cmd)
+	if err != nil {
+		return err
+	}
+	network, err := getNetwork(cmd)
+	if err != nil {
+		return err
+	}
+	_, reader, err := stakingSetup(cmd)
+	if err != nil {
+		return err
+	}
+	return printStakingInfo(cmd.Context(), cmd.OutOrStdout(), reader, network, stash)
+}
+
+func printStakingInfo(ctx context.Context, out io.Writer, reader *stakingReader,
+	network ss58.Network, stash []byte) error {
+	activeEra, err := reader.activeEra(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "Stash:      %s\n", encodeAddress(network, stash))
+	fmt.Fprintf(out, "Active era: %d\n", activeEra)
+
+	ledger, err := reader.ledger(ctx, stash)
+	if err != nil {
+		return fmt.Errorf("getting ledger: %w", err)
+	}
+	if ledger == nil {
+		fmt.Fprintln(out, "Bonded:     no")
+	} else {
+		fmt.Fprintf(out, "Bonded:     %s (active %s)\n", ledger.Total, ledger.Active)
+		for _, chunk := range ledger.Unlocking {
+			fmt.Fprintf(out, "  Unlocking %s at era %s\n", chunk.Value, chunk.Era)
+		}
+	}
+
+	own, err := reader.exposure(ctx, activeEra, stash)
+	if err != nil {
+		return fmt.Errorf("getting exposure: %w", err)
+	}
+	if own.Total.Sign() > 0 {
+		fmt.Fprintf(out, "Validator exposure: total %s, own %s, %d nominators\n",
+			own.Total, own.Own, len(own.Others))
+	}
+
+	n, err := reader.nominations(ctx, stash)
+	if err != nil {
+		return fmt.Errorf("getting nominations: %w", err)
+	}
+	if n == nil {
+		fmt.Fprintln(out, "Nominations: none")
+		return nil
+	}
+
+	fmt.Fprintf(out, "Nominations: %d targets submitted in era %d\n", len(n.Targets), n.SubmittedIn)
+	for _, target := range n.Targets {
+		e, err := reader.exposure(ctx, activeEra, target[:])
+		if err != nil {
+			return fmt.Errorf("getting exposure: %w", err)
+		}
+
+		switch stake := e.nominatorStake(stash); {
+		case e.Total.Sign() == 0:
+			fmt.Fprintf(out, "  %s: not elected\n", encodeAddress(network, target[:]))
+		case stake == nil:
+			fmt.Fprintf(out, "  %s: elected, not exposed\n", encodeAddress(network, target[:]))
+		default:
+			fmt.Fprintf(out, "  %s: exposed %s of %s\n", encodeAddress(network, target[:]), stake, e.Total)
+		}
+	}
+	return nil
+}
+
+// execStakingPayouts prints the unclaimed payouts of the stash.
+func execStakingPayouts(cmd *cobra.Command) error {
+	stash, err := getStash(cmd)
+	if err != nil {
+		return err
+	}
+	network, err := getNetwork(cmd)
+	if err != nil {
+		return err
+	}
+	_, reader, err := stakingSetup(cmd)
+	if err != nil {
+		return err
+	}
+
+	payouts, err := reader.unclaimedPayouts(cmd.Context(), stash)
+	if err != nil {
+		return fmt.Errorf("getting unclaimed payouts: %w", err)
+	}
+	printPayouts(cmd.OutOrStdout(), network, payouts)
+	return nil
+}
+
+func printPayouts(out io.Writer, network ss58.Network, payouts []payout) {
+	if len(payouts) == 0 {
+		fmt.Fprintln(out, "No unclaimed payouts")
+		return
+	}
+
+	fmt.Fprintf(out, "%d unclaimed payouts:\n", len(payouts))
+	for _, p := range payouts {
+		fmt.Fprintf(out, "  era %d, validator %s, %d pages\n", p.era, encodeAddress(network, p.validator[:]), p.pages)
+	}
+}
+
+// execStakingPayout builds, and optionally submits, the payout_stakers
+// extrinsics of the unclaimed payouts of the stash.
+func execStakingPayout(cmd *cobra.Command) error {
+	ctx := cmd.Context()
+	out := cmd.OutOrStdout()
+
+	suri, err := cmd.Flags().GetString("suri")
+	if err != nil {
+		return fmt.Errorf("failed to get suri: %s", err)
+	}
+	if suri == "" {
+		return fmt.Errorf("suri must be specified")
+	}
+	scheme, err := getScheme(cmd)
+	if err != nil {
+		return err
+	}
+	signer, err := keypairFromSecretURI(scheme, suri)
+	if err != nil {
+		return fmt.Errorf("failed to create keypair: %w", err)
+	}
+	signerID, err := accountID(signer.Public().Encode())
+	if err != nil {
+		return fmt.Errorf("failed to compute account id: %w", err)
+	}
+
+	stash := signerID
+	if account, _ := cmd.Flags().GetString("account"); account != "" {
+		stash, err = getStash(cmd)
+		if err != nil {
+			return err
+		}
+	}
+
+	era, err := cmd.Flags().GetInt64("era")
+	if err != nil {
+		return fmt.Errorf("failed to get era: %s", err)
+	}
+	period, err := cmd.Flags().GetUint64("mortal-period")
+	if err != nil {
+		return fmt.Errorf("failed to get mortal-period: %s", err)
+	}
+	submit, err := cmd.Flags().GetBool("submit")
+	if err != nil {
+		return fmt.Errorf("failed to get submit: %s", err)
+	}
+	network, err := getNetwork(cmd)
+	if err != nil {
+		return err
+	}
+
+	client, reader, err := stakingSetup(cmd)
+	if err != nil {
+		return err
+	}
+
+	payouts, err := reader.unclaimedPayouts(ctx, stash)
+	if err != nil {
+		return fmt.Errorf("getting unclaimed payouts: %w", err)
+	}
+	if era >= 0 {
+		payouts = slices.DeleteFunc(payouts, func(p payout) bool { return int64(p.era) != era })
+	}
+	printPayouts(out, network, payouts)
+	if len(payouts) == 0 {
+		return nil
+	}
+
+	builder, options, err := payoutBuilder(ctx, client, reader, signerID, period)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range payouts {
+		for page := uint32(0); page < p.pages; page++ {
+			call, err := builder.PayoutStakers(p.validator[:], p.era)
+			if err != nil {
+				return fmt.Errorf("building payout_stakers call: %w", err)
+			}
+			extrinsic, err := builder.BuildSigned(signer, call, options)
+			if err != nil {
+				return fmt.Errorf("building payout_stakers extrinsic: %w", err)
+			}
+			*options.Nonce++
+
+			fmt.Fprintf(out, "payout_stakers(%s, %d): %s\n",
+				encodeAddress(network, p.validator[:]), p.era, common.BytesToHex(extrinsic))
+			if !submit {
+				continue
+			}
+
+			hash, err := client.submitExtrinsic(ctx, extrinsic)
+			if err != nil {
+				return fmt.Errorf("submitting payout_stakers extrinsic: %w", err)
+			}
+			fmt.Fprintf(out, "  submitted: %s\n", hash)
+		}
+	}
+	return nil
+}
+
+// payoutBuilder returns the extrinsic builder of the runtime of the best block
+// and the signing options, starting at the next nonce of the signer.
+func payoutBuilder(ctx context.Context, client *rpcClient, reader *stakingReader, signerID []byte,
+	period uint64) (*txbuilder.Builder, txbuilder.Options, error) {
+	var options txbuilder.Options
+
+	version, err := client.runtimeVersion(ctx)
+	if err != nil {
+		return nil, options, fmt.Errorf("getting runtime version: %w", err)
+	}
+	genesis := uint64(0)
+	genesisHash, err := client.blockHash(ctx, &genesis)
+	if err != nil {
+		return nil, options, fmt.Errorf("getting genesis hash: %w", err)
+	}
+	builder, err := txbuilder.NewBuilder(reader.metadata, version, genesisHash, nil)
+	if err != nil {
+		return nil, options, fmt.Errorf("creating extrinsic builder: %w", err)
+	}
+
+	address, err := ss58.Encode(ss58.SubstratePrefix, signerID)
+	if err != nil {
+		return nil, options, fmt.Errorf("encoding signer address: %w", err)
+	}
+	nonce, err := client.accountNextIndex(ctx, string(address))
+	if err != nil {
+		return nil, options, fmt.Errorf("getting signer nonce: %w", err)
+	}
+	options.Nonce = &nonce
+
+	if period > 0 {
+		options.Period = period
+		options.BlockHash, err = client.blockHash(ctx, nil)
+		if err != nil {
+			return nil, options, fmt.Errorf("getting best block hash: %w", err)
+		}
+		options.BlockNumber, err = client.blockNumber(ctx, options.BlockHash)
+		if err != nil {
+			return nil, options, fmt.Errorf("getting best block number: %w", err)
+		}
+	}
+	return builder, options, nil
+}
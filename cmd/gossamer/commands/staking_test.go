@@ -0,0 +1,162 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+
+	testdata "github.com/ChainSafe/gossamer/dot/rpc/modules/test_data"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/ss58"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/lib/txbuilder"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	ctypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mapStorage map[string][]byte
+
+func (m mapStorage) getStorage(_ context.Context, key []byte) ([]byte, error) {
+	return m[string(key)], nil
+}
+
+func newTestStakingReader(t *testing.T) (*stakingReader, mapStorage) {
+	t.Helper()
+
+	rawMetadata, err := common.HexToBytes(testdata.NewTestMetadata())
+	require.NoError(t, err)
+	metadata, err := txbuilder.DecodeMetadata(rawMetadata)
+	require.NoError(t, err)
+
+	storage := mapStorage{}
+	return &stakingReader{storage: storage, metadata: metadata}, storage
+}
+
+func setStaking(t *testing.T, reader *stakingReader, storage mapStorage, value any, item string, keys ...[]byte) {
+	t.Helper()
+
+	key, err := ctypes.CreateStorageKey(reader.metadata, "Staking", item, keys...)
+	require.NoError(t, err)
+	encoded, err := scale.Marshal(value)
+	require.NoError(t, err)
+	storage[string(key)] = encoded
+}
+
+func testAccounts(t *testing.T) (alice, bob, charlie [32]byte) {
+	t.Helper()
+
+	keyring, err := keystore.NewSr25519Keyring()
+	require.NoError(t, err)
+	return [32]byte(keyring.Alice().Public().Encode()),
+		[32]byte(keyring.Bob().Public().Encode()),
+		[32]byte(keyring.Charlie().Public().Encode())
+}
+
+func Test_stakingReader_unclaimedPayouts(t *testing.T) {
+	t.Parallel()
+
+	reader, storage := newTestStakingReader(t)
+	alice, bob, charlie := testAccounts(t)
+
+	setStaking(t, reader, storage, activeEraInfo{Index: 10}, "ActiveEra")
+	setStaking(t, reader, storage, uint32(3), "HistoryDepth")
+	setStaking(t, reader, storage, nominations{Targets: [][32]byte{bob, charlie}, SubmittedIn: 2},
+		"Nominators", alice[:])
+
+	// bob already claimed the rewards of era 8
+	setStaking(t, reader, storage, bob, "Bonded", bob[:])
+	setStaking(t, reader, storage, stakingLedger{
+		Stash:          bob,
+		Total:          big.NewInt(100),
+		Active:         big.NewInt(100),
+		ClaimedRewards: []uint32{8},
+	}, "Ledger", bob[:])
+
+	// era 6 is out of the history depth, and era 7 has no reward
+	for _, era := range []uint32{6, 8, 9} {
+		setStaking(t, reader, storage, scale.MustNewUint128(big.NewInt(1000)),
+			"ErasValidatorReward", encodeEra(era))
+	}
+
+	aliceExposed := exposure{
+		Total:  big.NewInt(150),
+		Own:    big.NewInt(100),
+		Others: []individualExposure{{Who: alice, Value: big.NewInt(50)}},
+	}
+	for _, era := range []uint32{6, 7, 8, 9} {
+		setStaking(t, reader, storage, aliceExposed, "ErasStakers", encodeEra(era), bob[:])
+	}
+	// alice is not exposed to charlie
+	setStaking(t, reader, storage, exposure{Total: big.NewInt(100), Own: big.NewInt(100)},
+		"ErasStakers", encodeEra(9), charlie[:])
+
+	payouts, err := reader.unclaimedPayouts(context.Background(), alice[:])
+	require.NoError(t, err)
+	assert.Equal(t, []payout{{validator: bob, era: 9, pages: 1}}, payouts)
+
+	// the validator itself also has the unclaimed payout of charlie
+	payouts, err = reader.unclaimedPayouts(context.Background(), charlie[:])
+	require.NoError(t, err)
+	assert.Equal(t, []payout{{validator: charlie, era: 9, pages: 1}}, payouts)
+}
+
+func Test_stakingReader_activeEra(t *testing.T) {
+	t.Parallel()
+
+	reader, storage := newTestStakingReader(t)
+	_, err := reader.activeEra(context.Background())
+	assert.ErrorIs(t, err, errNoActiveEra)
+
+	setStaking(t, reader, storage, activeEraInfo{Index: 3}, "ActiveEra")
+	era, err := reader.activeEra(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint32(3), era)
+
+	// the history depth storage item of the test runtime is not set
+	depth, err := reader.historyDepth(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint32(defaultHistoryDepth), depth)
+}
+
+func Test_printStakingInfo(t *testing.T) {
+	t.Parallel()
+
+	reader, storage := newTestStakingReader(t)
+	alice, bob, charlie := testAccounts(t)
+
+	setStaking(t, reader, storage, activeEraInfo{Index: 4}, "ActiveEra")
+	setStaking(t, reader, storage, alice, "Bonded", alice[:])
+	setStaking(t, reader, storage, stakingLedger{
+		Stash:     alice,
+		Total:     big.NewInt(80),
+		Active:    big.NewInt(50),
+		Unlocking: []unlockChunk{{Value: big.NewInt(30), Era: big.NewInt(6)}},
+	}, "Ledger", alice[:])
+	setStaking(t, reader, storage, nominations{Targets: [][32]byte{bob, charlie}, SubmittedIn: 3},
+		"Nominators", alice[:])
+	setStaking(t, reader, storage, exposure{
+		Total:  big.NewInt(150),
+		Own:    big.NewInt(100),
+		Others: []individualExposure{{Who: alice, Value: big.NewInt(50)}},
+	}, "ErasStakers", encodeEra(4), bob[:])
+
+	network := ss58.Network{Prefix: ss58.SubstratePrefix}
+	out := new(bytes.Buffer)
+	err := printStakingInfo(context.Background(), out, reader, network, alice[:])
+	require.NoError(t, err)
+
+	expected := "Stash:      " + encodeAddress(network, alice[:]) + "\n" +
+		"Active era: 4\n" +
+		"Bonded:     80 (active 50)\n" +
+		"  Unlocking 30 at era 6\n" +
+		"Nominations: 2 targets submitted in era 3\n" +
+		"  " + encodeAddress(network, bob[:]) + ": exposed 50 of 150\n" +
+		"  " + encodeAddress(network, charlie[:]) + ": not elected\n"
+	assert.Equal(t, expected, out.String())
+}
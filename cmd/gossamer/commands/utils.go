@@ -4,6 +4,7 @@
 package commands
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -23,6 +24,8 @@
 	"github.com/ChainSafe/gossamer/chain/westend"
 	westenddev "github.com/ChainSafe/gossamer/chain/westend-dev"
 	westendlocal "github.com/ChainSafe/gossamer/chain/westend-local"
+	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/keystore/kms"
 	gssmros "github.com/ChainSafe/gossamer/lib/os"
 
 	"github.com/ChainSafe/gossamer/lib/genesis"
@@ -97,6 +100,18 @@ func addUint32FlagBindViper(
 	return viper.BindPFlag(viperBindName, cmd.PersistentFlags().Lookup(name))
 }
 
+// addUint64FlagBindViper adds a uint64 flag to the given command and binds it to the given viper name
+func addUint64FlagBindViper(
+	cmd *cobra.Command,
+	name string,
+	defaultValue uint64,
+	usage string,
+	viperBindName string,
+) error {
+	cmd.PersistentFlags().Uint64(name, defaultValue, usage)
+	return viper.BindPFlag(viperBindName, cmd.PersistentFlags().Lookup(name))
+}
+
 // addUint16FlagBindViper adds a uint16 flag to the given command and binds it to the given viper name
 func addUint16FlagBindViper(
 	cmd *cobra.Command,
@@ -198,6 +213,32 @@ func unlockKeystore(ks KeypairInserter, basepath, unlock, password string) error
 	return nil
 }
 
+// grandpaKMSTimeout is the timeout of fetching the public key of the grandpa key of the
+// key management service.
+const grandpaKMSTimeout = 30 * time.Second
+
+// loadGrandpaKMSKey replaces the keys of the grandpa keystore with the key of the key
+// management service URI, so the votes are only ever signed by the key management service.
+func loadGrandpaKMSKey(ks *keystore.GlobalKeystore, uri string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), grandpaKMSTimeout)
+	defer cancel()
+
+	kp, err := kms.NewKeypairFromURI(ctx, uri)
+	if err != nil {
+		return fmt.Errorf("loading grandpa key from key management service: %w", err)
+	}
+
+	gran := keystore.NewBasicKeystore(keystore.GranName, crypto.Ed25519Type)
+	err = gran.Insert(kp)
+	if err != nil {
+		return fmt.Errorf("inserting grandpa key: %w", err)
+	}
+	ks.Gran = gran
+
+	logger.Infof("using grandpa key %s of key management service", kp.Public().Hex())
+	return nil
+}
+
 // getPassword prompts user to enter password
 func getPassword(msg string) []byte {
 	for {
@@ -231,7 +272,7 @@ func parseIdentity() {
 func parseChainSpec(chain string) error {
 	// check if the chain is a path to a chain spec
 	if _, err := os.Stat(chain); err == nil {
-		spec, err := genesis.NewGenesisFromJSONRaw(chain)
+		spec, err := genesis.NewGenesisFromFile(chain)
 		if err != nil {
 			return fmt.Errorf("failed to load chain spec: %s", err)
 		}
@@ -265,12 +306,16 @@ func parseChainSpec(chain string) error {
 	}
 
 	// parse chain spec and set config fields
-	spec, err := genesis.NewGenesisFromJSONRaw(config.ChainSpec)
+	spec, err := genesis.NewGenesisFromFile(config.ChainSpec)
 	if err != nil {
 		return fmt.Errorf("failed to load chain spec: %s", err)
 	}
 
 	config.Network.Bootnodes = spec.Bootnodes
+	if spec.BootnodesEndpoint != nil {
+		config.Network.BootnodesEndpoint = spec.BootnodesEndpoint.URL
+		config.Network.BootnodesEndpointKey = spec.BootnodesEndpoint.PublicKey
+	}
 	config.Network.ProtocolID = spec.ProtocolID
 	parseIdentity()
 
@@ -291,9 +336,10 @@ func configureViper(basePath string) error {
 			// ignore not found error, return other errors
 			return err
 		}
+		return nil
 	}
 
-	return nil
+	return checkConfigFileKeys(viper.ConfigFileUsed())
 }
 
 // ParseConfig parses the config from the command line flags
@@ -0,0 +1,79 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"fmt"
+
+	cfg "github.com/ChainSafe/gossamer/config"
+	"github.com/ChainSafe/gossamer/dot"
+	"github.com/ChainSafe/gossamer/lib/utils"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	VerifyChainCmd.Flags().Uint("from", 1, "Number of the first block to verify")
+	VerifyChainCmd.Flags().Uint("to", 0, "Number of the last block to verify, 0 for the best block")
+}
+
+// VerifyChainCmd is the command to re-execute and verify a range of the canonical chain
+var VerifyChainCmd = &cobra.Command{
+	Use:   "verify-chain",
+	Short: "Re-execute and verify a range of the canonical chain of the node database",
+	Long: `The verify-chain command re-executes the blocks of the canonical chain between
+the --from and --to block numbers, both inclusive, on the state of their parent block.
+For each block, it verifies the BABE seal, the extrinsics root, the execution of the block,
+the resulting state root and the GRANDPA justification if the block has one, and stops at
+the first block failing a check, which is reported with the failed check.
+The state of the parent of every verified block must be in the database, so the range of
+a pruned database must start after the pruned blocks.
+The node must be stopped while the chain is verified.
+Examples:
+
+To verify the whole chain:
+	gossamer verify-chain --base-path=path/to/node
+To verify the blocks from 1000 to 2000:
+	gossamer verify-chain --base-path=path/to/node --from=1000 --to=2000`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return execVerifyChain(cmd)
+	},
+}
+
+func execVerifyChain(cmd *cobra.Command) error {
+	from, err := cmd.Flags().GetUint("from")
+	if err != nil {
+		return fmt.Errorf("failed to get from: %s", err)
+	}
+	to, err := cmd.Flags().GetUint("to")
+	if err != nil {
+		return fmt.Errorf("failed to get to: %s", err)
+	}
+	if to != 0 && from > to {
+		return fmt.Errorf("from %d must not be greater than to %d", from, to)
+	}
+
+	if basePath == "" {
+		basePath = config.BasePath
+	}
+	if basePath == "" {
+		return fmt.Errorf("base-path must be specified")
+	}
+	config.BasePath = utils.ExpandDir(basePath)
+	config.ChainSpec = cfg.GetChainSpec(config.BasePath)
+
+	result, err := dot.VerifyChain(config, from, to)
+	if err != nil {
+		return fmt.Errorf("verifying chain: %w", err)
+	}
+
+	if result.Divergence != nil {
+		return fmt.Errorf("block #%d (%s) failed the %s check after %d verified blocks: %w",
+			result.Divergence.Number, result.Divergence.Hash, result.Divergence.Check,
+			result.Blocks, result.Divergence.Err)
+	}
+
+	logger.Infof("verified %d blocks from #%d to #%d and %d justifications, no divergence found",
+		result.Blocks, result.From, result.To, result.Justifications)
+	return nil
+}
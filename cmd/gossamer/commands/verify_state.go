@@ -0,0 +1,100 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/utils"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	VerifyStateCmd.Flags().Uint("to", 0, "Number of the last block to verify, 0 for the finalised block")
+	VerifyStateCmd.Flags().Uint("count", 1, "Number of blocks to verify, ending at the last block")
+}
+
+// VerifyStateCmd is the command to verify the state tries of blocks against the node database
+var VerifyStateCmd = &cobra.Command{
+	Use:   "verify-state",
+	Short: "Verify the state trie nodes of blocks of the node database",
+	Long: `The db verify-state command walks the state tries of the --count canonical blocks
+ending at the --to block number, and of their child tries, and verifies every trie node
+is in the database, decodes and hashes to the hash it is referenced by, and every hashed
+storage value is in the database and hashes to its hash. The missing or corrupt nodes are
+reported with the block they were found in, along with the highest verified block with an
+intact state, which the node can be rewound to with the --rewind flag after a partial
+corruption of the database rather than synced again.
+The node must be stopped while the state is verified, otherwise use the --state-verify-interval
+flag to verify the state of the finalised block periodically.
+Examples:
+
+To verify the state of the finalised block:
+	gossamer db verify-state --base-path=path/to/node
+To verify the states of the blocks from 1001 to 2000:
+	gossamer db verify-state --base-path=path/to/node --to=2000 --count=1000`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return execVerifyState(cmd)
+	},
+}
+
+func execVerifyState(cmd *cobra.Command) (err error) {
+	to, err := cmd.Flags().GetUint("to")
+	if err != nil {
+		return fmt.Errorf("failed to get to: %s", err)
+	}
+	count, err := cmd.Flags().GetUint("count")
+	if err != nil {
+		return fmt.Errorf("failed to get count: %s", err)
+	}
+	if count == 0 {
+		return fmt.Errorf("count must be greater than 0")
+	}
+
+	if basePath == "" {
+		basePath = config.BasePath
+	}
+	if basePath == "" {
+		return fmt.Errorf("base-path must be specified")
+	}
+	basePath = utils.ExpandDir(basePath)
+
+	db, err := database.LoadDatabase(basePath, false)
+	if err != nil {
+		return fmt.Errorf("loading database: %w", err)
+	}
+	defer func() {
+		closeErr := db.Close()
+		if err == nil && closeErr != nil {
+			err = fmt.Errorf("closing database: %w", closeErr)
+		}
+	}()
+
+	verification, err := state.VerifyState(cmd.Context(), db, to, count)
+	if err != nil {
+		return fmt.Errorf("verifying state: %w", err)
+	}
+
+	for _, block := range verification.Blocks {
+		for _, problem := range block.Problems {
+			logger.Errorf("state trie of block #%d (%s): %s", block.Number, block.Hash, problem)
+		}
+	}
+
+	if !verification.Corrupt() {
+		logger.Infof("verified the state tries of %d blocks with %d trie nodes, no problem found",
+			len(verification.Blocks), verification.Nodes)
+		return nil
+	}
+
+	intact := verification.HighestIntact()
+	if intact == nil {
+		return errors.New("no verified block has an intact state, verify older blocks or sync again")
+	}
+	return fmt.Errorf("state is corrupt, block #%d (%s) is the highest verified block with an intact state "+
+		"and can be rewound to with --rewind=%d", intact.Number, intact.Hash, intact.Number)
+}
@@ -24,8 +24,19 @@ func main() {
 		commands.ImportRuntimeCmd,
 		commands.BuildSpecCmd,
 		commands.PruneStateCmd,
+		commands.BackupCmd,
+		commands.ExportStateCmd,
+		commands.DBCmd,
+		commands.MigrateNodeKeysCmd,
 		commands.ImportStateCmd,
 		commands.VersionCmd,
+		commands.RuntimeCmd,
+		commands.ConfigCmd,
+		commands.StakingCmd,
+		commands.ForkOffCmd,
+		commands.HostAPICmd,
+		commands.VerifyChainCmd,
+		commands.DoctorCmd,
 	)
 	configureCobraCmd("GSSMR")
 	if err := rootCmd.Execute(); err != nil {
@@ -6,6 +6,7 @@
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/ChainSafe/gossamer/dot/state/pruner"
@@ -13,6 +14,7 @@
 	"github.com/ChainSafe/gossamer/lib/genesis"
 	"github.com/ChainSafe/gossamer/lib/os"
 	wazero "github.com/ChainSafe/gossamer/lib/runtime/wazero"
+	"github.com/ChainSafe/gossamer/lib/services"
 	"github.com/adrg/xdg"
 )
 
@@ -29,6 +31,8 @@
 	DefaultRetainBlocks = uint32(512)
 	// DefaultPruning is the default pruning strategy
 	DefaultPruning = pruner.Archive
+	// DefaultPanicPolicy is the default action taken by subsystems when one of their goroutines panics
+	DefaultPanicPolicy = "rpc=restart,telemetry=restart"
 
 	// defaultAccount is the default account key
 	defaultAccount = "alice"
@@ -37,11 +41,17 @@
 	DefaultRole = common.AuthorityRole
 	// DefaultWasmInterpreter is the default wasm interpreter
 	DefaultWasmInterpreter = wazero.Name
+	// DefaultFinalityLagPolicy is the default block authoring policy when the finality lag is exceeded
+	DefaultFinalityLagPolicy = "skip"
+	// DefaultStandbyLeaseTTL is the default duration of the standby lease
+	DefaultStandbyLeaseTTL = 30 * time.Second
 
 	// DefaultNetworkPort is the default network port
 	DefaultNetworkPort = uint16(7001)
 	// DefaultDiscoveryInterval is the default discovery interval
 	DefaultDiscoveryInterval = 10 * time.Second
+	// DefaultBootnodeCheckInterval is the default interval between two bootnode liveness checks
+	DefaultBootnodeCheckInterval = 10 * time.Minute
 	// DefaultMinPeers is the default minimum number of peers
 	DefaultMinPeers = 5
 	// DefaultMaxPeers is the default maximum number of peers
@@ -133,9 +143,13 @@ type BaseConfig struct {
 	PrometheusPort     uint32                      `mapstructure:"prometheus-port,omitempty"`
 	RetainBlocks       uint32                      `mapstructure:"retain-blocks,omitempty"`
 	Pruning            pruner.Mode                 `mapstructure:"pruning,omitempty"`
+	CompressBlockData  bool                        `mapstructure:"compress-block-data,omitempty"`
+	MaxForkDepth       uint32                      `mapstructure:"max-fork-depth,omitempty"`
+	KeepBranches       []string                    `mapstructure:"keep-branches,omitempty"`
 	PrometheusExternal bool                        `mapstructure:"prometheus-external,omitempty"`
 	NoTelemetry        bool                        `mapstructure:"no-telemetry"`
 	TelemetryURLs      []genesis.TelemetryEndpoint `mapstructure:"telemetry-urls,omitempty"`
+	PanicPolicy        string                      `mapstructure:"panic-policy,omitempty"`
 }
 
 // SystemConfig represents the system configuration
@@ -162,13 +176,17 @@ type LogConfig struct {
 type AccountConfig struct {
 	Key    string `mapstructure:"key,omitempty"`
 	Unlock string `mapstructure:"unlock,omitempty"`
+	// GrandpaKMS is the URI of the key management service key the grandpa votes are
+	// signed with instead of the keys of the keystore, either gcpkms://<key version name>
+	// or awskms:///<key id or arn>.
+	GrandpaKMS string `mapstructure:"grandpa-kms,omitempty"`
 }
 
 // NetworkConfig is to marshal/unmarshal toml network config vars
 type NetworkConfig struct {
 	Port              uint16        `mapstructure:"port"`
 	Bootnodes         []string      `mapstructure:"bootnodes"`
-	ProtocolID        string        `mapstructure:"protocol"`
+	ProtocolID        string        `mapstructure:"protocol-id"`
 	NoBootstrap       bool          `mapstructure:"no-bootstrap"`
 	NoMDNS            bool          `mapstructure:"no-mdns"`
 	MinPeers          int           `mapstructure:"min-peers"`
@@ -179,6 +197,18 @@ type NetworkConfig struct {
 	PublicDNS         string        `mapstructure:"public-dns"`
 	NodeKey           string        `mapstructure:"node-key"`
 	ListenAddress     string        `mapstructure:"listen-addr"`
+	// FuzzCorpusDir is the directory the inbound protocol messages are recorded
+	// into as a fuzz corpus, disabled if empty.
+	FuzzCorpusDir string `mapstructure:"fuzz-corpus-dir,omitempty"`
+	// BootnodeCheckInterval is the interval between two bootnode liveness checks.
+	// 0 only checks the bootnodes at startup.
+	BootnodeCheckInterval time.Duration `mapstructure:"bootnode-check-interval,omitempty"`
+	// BootnodesEndpoint is the HTTPS endpoint serving the signed bootnodes list fetched
+	// once all the bootnodes are dead, set from the chain spec. Disabled if empty.
+	BootnodesEndpoint string `mapstructure:"bootnodes-endpoint,omitempty"`
+	// BootnodesEndpointKey is the hex encoded ed25519 public key verifying the
+	// signature of the bootnodes list, set from the chain spec.
+	BootnodesEndpointKey string `mapstructure:"bootnodes-endpoint-key,omitempty"`
 }
 
 // CoreConfig is to marshal/unmarshal toml core config vars
@@ -188,11 +218,50 @@ type CoreConfig struct {
 	GrandpaAuthority bool               `mapstructure:"grandpa-authority"`
 	WasmInterpreter  string             `mapstructure:"wasm-interpreter,omitempty"`
 	GrandpaInterval  time.Duration      `mapstructure:"grandpa-interval,omitempty"`
+	// GrandpaObserver follows the GRANDPA rounds of a node which is not a GRANDPA
+	// authority, importing the votes and commits without ever casting votes.
+	GrandpaObserver bool `mapstructure:"grandpa-observer,omitempty"`
+	// MaxFinalityLag is the maximum number of unfinalised blocks on top of which BABE
+	// authors blocks before applying the FinalityLagPolicy. 0 disables the limit.
+	MaxFinalityLag uint `mapstructure:"max-finality-lag,omitempty"`
+	// FinalityLagPolicy is either "skip" to skip authoring or "finalised" to author
+	// on top of the highest finalised block when the finality lag is exceeded.
+	FinalityLagPolicy string `mapstructure:"finality-lag-policy,omitempty"`
+	// HeapPages overrides the number of wasm heap pages of the runtime set in
+	// the `:heappages` storage key. 0 keeps the on-chain value.
+	HeapPages uint64 `mapstructure:"heap-pages,omitempty"`
+	// FloatDeterminism is the policy applied to the float instructions of the
+	// runtime, either "off", "warn" or "canonicalise".
+	FloatDeterminism string `mapstructure:"float-determinism,omitempty"`
+	// GrandpaJournalSize is the number of GRANDPA consensus messages kept in
+	// the message journal, dumped on panic or by the grandpa_dumpMessageJournal
+	// RPC method. 0 disables the journal.
+	GrandpaJournalSize uint32 `mapstructure:"grandpa-journal-size,omitempty"`
+	// SyncPipelineDepth is the number of blocks ahead of the executing block whose
+	// justifications are verified during initial sync. 0 disables the pipeline.
+	SyncPipelineDepth uint32 `mapstructure:"sync-pipeline-depth,omitempty"`
+	// RuntimeUpgradeDryRun executes the block following a runtime upgrade again with
+	// both the previous and the new runtime, alerting on unexpected state root divergences.
+	RuntimeUpgradeDryRun bool `mapstructure:"runtime-upgrade-dry-run,omitempty"`
+	// StandbyLease is the path of the lease file shared with the other nodes running with
+	// the same authority keys, of which only the lease holder authors blocks and votes
+	// while the others stand by to take over. Standby mode is disabled if empty.
+	StandbyLease string `mapstructure:"standby-lease,omitempty"`
+	// StandbyLeaseTTL is the duration after which a standby node takes over if the
+	// active node did not renew its lease.
+	StandbyLeaseTTL time.Duration `mapstructure:"standby-lease-ttl,omitempty"`
+	// AuthoringPropagationMargin is the time reserved at the end of each BABE authoring
+	// slot to finalise, seal and propagate the block, after which no more extrinsics are
+	// applied. 0 defaults to a third of the slot duration.
+	AuthoringPropagationMargin time.Duration `mapstructure:"authoring-propagation-margin,omitempty"`
 }
 
 // StateConfig contains the configuration for the state.
 type StateConfig struct {
 	Rewind uint `mapstructure:"rewind,omitempty"`
+	// VerifyInterval is the interval between two verifications of the state trie of the
+	// highest finalised block against the database. 0 disables the verification.
+	VerifyInterval time.Duration `mapstructure:"verify-interval,omitempty"`
 }
 
 // RPCConfig is to marshal/unmarshal toml RPC config vars
@@ -206,6 +275,9 @@ type RPCConfig struct {
 	WSPort            uint32   `mapstructure:"ws-port,omitempty"`
 	WSExternal        bool     `mapstructure:"ws-external,omitempty"`
 	UnsafeWSExternal  bool     `mapstructure:"unsafe-ws-external,omitempty"`
+	// SlowCallThreshold is the duration above which the RPC calls are logged with
+	// their method, request size and caller. 0 disables the slow call log.
+	SlowCallThreshold time.Duration `mapstructure:"slow-call-threshold,omitempty"`
 }
 
 // PprofConfig contains the configuration for Pprof.
@@ -239,6 +311,14 @@ func (b *BaseConfig) ValidateBasic() error {
 			uint32Max,
 		)
 	}
+	if _, err := services.ParsePanicPolicies(b.PanicPolicy); err != nil {
+		return fmt.Errorf("invalid panic-policy: %w", err)
+	}
+	for _, hash := range b.KeepBranches {
+		if _, err := common.HexToHash(hash); err != nil {
+			return fmt.Errorf("invalid keep-branches hash %q: %w", hash, err)
+		}
+	}
 
 	return nil
 }
@@ -268,6 +348,17 @@ func (n *NetworkConfig) ValidateBasic() error {
 	if n.DiscoveryInterval == 0 {
 		return fmt.Errorf("discovery-interval cannot be empty")
 	}
+	if n.BootnodeCheckInterval < 0 {
+		return fmt.Errorf("bootnode-check-interval cannot be negative")
+	}
+	if n.BootnodesEndpoint != "" {
+		if !strings.HasPrefix(n.BootnodesEndpoint, "https://") {
+			return fmt.Errorf("bootnodes-endpoint must be an HTTPS URL")
+		}
+		if n.BootnodesEndpointKey == "" {
+			return fmt.Errorf("bootnodes-endpoint-key cannot be empty")
+		}
+	}
 
 	return nil
 }
@@ -280,6 +371,22 @@ func (c *CoreConfig) ValidateBasic() error {
 	if c.WasmInterpreter != wazero.Name {
 		return fmt.Errorf("wasm-interpreter is invalid")
 	}
+	switch c.FinalityLagPolicy {
+	case "", "skip", "finalised":
+	default:
+		return fmt.Errorf("finality-lag-policy is invalid")
+	}
+	switch c.FloatDeterminism {
+	case "", "off", "warn", "canonicalise":
+	default:
+		return fmt.Errorf("float-determinism is invalid")
+	}
+	if c.StandbyLeaseTTL < 0 {
+		return fmt.Errorf("standby-lease-ttl cannot be negative")
+	}
+	if c.AuthoringPropagationMargin < 0 {
+		return fmt.Errorf("authoring-propagation-margin cannot be negative")
+	}
 
 	return nil
 }
@@ -340,6 +447,7 @@ func DefaultConfig() *Config {
 			PrometheusExternal: false,
 			NoTelemetry:        false,
 			TelemetryURLs:      nil,
+			PanicPolicy:        DefaultPanicPolicy,
 		},
 		Log: &LogConfig{
 			Core:    DefaultLogLevel,
@@ -354,8 +462,9 @@ func DefaultConfig() *Config {
 			Wasmer:  DefaultLogLevel,
 		},
 		Account: &AccountConfig{
-			Key:    defaultAccount,
-			Unlock: "",
+			Key:        defaultAccount,
+			Unlock:     "",
+			GrandpaKMS: "",
 		},
 		Core: &CoreConfig{
 			Role:             DefaultRole,
@@ -363,6 +472,9 @@ func DefaultConfig() *Config {
 			GrandpaAuthority: true,
 			WasmInterpreter:  DefaultWasmInterpreter,
 			GrandpaInterval:  DefaultDiscoveryInterval,
+
+			FinalityLagPolicy: DefaultFinalityLagPolicy,
+			StandbyLeaseTTL:   DefaultStandbyLeaseTTL,
 		},
 		Network: &NetworkConfig{
 			Port:              DefaultNetworkPort,
@@ -378,9 +490,12 @@ func DefaultConfig() *Config {
 			PublicDNS:         "",
 			NodeKey:           "",
 			ListenAddress:     "",
+
+			BootnodeCheckInterval: DefaultBootnodeCheckInterval,
 		},
 		State: &StateConfig{
-			Rewind: 0,
+			Rewind:         0,
+			VerifyInterval: 0,
 		},
 		RPC: &RPCConfig{
 			RPCExternal:       false,
@@ -392,6 +507,7 @@ func DefaultConfig() *Config {
 			WSPort:            DefaultWSPort,
 			WSExternal:        false,
 			UnsafeWSExternal:  false,
+			SlowCallThreshold: 0,
 		},
 		Pprof: &PprofConfig{
 			Enabled:          false,
@@ -421,6 +537,7 @@ func DefaultConfigFromSpec(nodeSpec *genesis.Genesis) *Config {
 			PrometheusExternal: false,
 			NoTelemetry:        false,
 			TelemetryURLs:      nil,
+			PanicPolicy:        DefaultPanicPolicy,
 		},
 		Log: &LogConfig{
 			Core:    DefaultLogLevel,
@@ -435,8 +552,9 @@ func DefaultConfigFromSpec(nodeSpec *genesis.Genesis) *Config {
 			Wasmer:  DefaultLogLevel,
 		},
 		Account: &AccountConfig{
-			Key:    defaultAccount,
-			Unlock: "",
+			Key:        defaultAccount,
+			Unlock:     "",
+			GrandpaKMS: "",
 		},
 		Core: &CoreConfig{
 			Role:             DefaultRole,
@@ -444,6 +562,9 @@ func DefaultConfigFromSpec(nodeSpec *genesis.Genesis) *Config {
 			GrandpaAuthority: true,
 			WasmInterpreter:  DefaultWasmInterpreter,
 			GrandpaInterval:  DefaultDiscoveryInterval,
+
+			FinalityLagPolicy: DefaultFinalityLagPolicy,
+			StandbyLeaseTTL:   DefaultStandbyLeaseTTL,
 		},
 		Network: &NetworkConfig{
 			Port:              DefaultNetworkPort,
@@ -459,9 +580,12 @@ func DefaultConfigFromSpec(nodeSpec *genesis.Genesis) *Config {
 			PublicDNS:         "",
 			NodeKey:           "",
 			ListenAddress:     "",
+
+			BootnodeCheckInterval: DefaultBootnodeCheckInterval,
 		},
 		State: &StateConfig{
-			Rewind: 0,
+			Rewind:         0,
+			VerifyInterval: 0,
 		},
 		RPC: &RPCConfig{
 			RPCExternal:       false,
@@ -473,6 +597,7 @@ func DefaultConfigFromSpec(nodeSpec *genesis.Genesis) *Config {
 			WSPort:            DefaultWSPort,
 			WSExternal:        false,
 			UnsafeWSExternal:  false,
+			SlowCallThreshold: 0,
 		},
 		Pprof: &PprofConfig{
 			Enabled:          false,
@@ -499,9 +624,13 @@ func Copy(c *Config) Config {
 			PrometheusPort:     c.PrometheusPort,
 			RetainBlocks:       c.RetainBlocks,
 			Pruning:            c.Pruning,
+			CompressBlockData:  c.CompressBlockData,
+			MaxForkDepth:       c.MaxForkDepth,
+			KeepBranches:       c.KeepBranches,
 			PrometheusExternal: c.PrometheusExternal,
 			NoTelemetry:        c.NoTelemetry,
 			TelemetryURLs:      c.TelemetryURLs,
+			PanicPolicy:        c.PanicPolicy,
 		},
 		Log: &LogConfig{
 			Core:    c.Log.Core,
@@ -516,8 +645,9 @@ func Copy(c *Config) Config {
 			Wasmer:  c.Log.Wasmer,
 		},
 		Account: &AccountConfig{
-			Key:    c.Account.Key,
-			Unlock: c.Account.Unlock,
+			Key:        c.Account.Key,
+			Unlock:     c.Account.Unlock,
+			GrandpaKMS: c.Account.GrandpaKMS,
 		},
 		Core: &CoreConfig{
 			Role:             c.Core.Role,
@@ -525,6 +655,22 @@ func Copy(c *Config) Config {
 			GrandpaAuthority: c.Core.GrandpaAuthority,
 			WasmInterpreter:  c.Core.WasmInterpreter,
 			GrandpaInterval:  c.Core.GrandpaInterval,
+			GrandpaObserver:  c.Core.GrandpaObserver,
+
+			MaxFinalityLag:    c.Core.MaxFinalityLag,
+			FinalityLagPolicy: c.Core.FinalityLagPolicy,
+			HeapPages:         c.Core.HeapPages,
+			FloatDeterminism:  c.Core.FloatDeterminism,
+
+			GrandpaJournalSize: c.Core.GrandpaJournalSize,
+			SyncPipelineDepth:  c.Core.SyncPipelineDepth,
+
+			RuntimeUpgradeDryRun: c.Core.RuntimeUpgradeDryRun,
+
+			StandbyLease:    c.Core.StandbyLease,
+			StandbyLeaseTTL: c.Core.StandbyLeaseTTL,
+
+			AuthoringPropagationMargin: c.Core.AuthoringPropagationMargin,
 		},
 		Network: &NetworkConfig{
 			Port:              c.Network.Port,
@@ -540,9 +686,15 @@ func Copy(c *Config) Config {
 			PublicDNS:         c.Network.PublicDNS,
 			NodeKey:           c.Network.NodeKey,
 			ListenAddress:     c.Network.ListenAddress,
+			FuzzCorpusDir:     c.Network.FuzzCorpusDir,
+
+			BootnodeCheckInterval: c.Network.BootnodeCheckInterval,
+			BootnodesEndpoint:     c.Network.BootnodesEndpoint,
+			BootnodesEndpointKey:  c.Network.BootnodesEndpointKey,
 		},
 		State: &StateConfig{
-			Rewind: c.State.Rewind,
+			Rewind:         c.State.Rewind,
+			VerifyInterval: c.State.VerifyInterval,
 		},
 		RPC: &RPCConfig{
 			UnsafeRPC:         c.RPC.UnsafeRPC,
@@ -554,6 +706,7 @@ func Copy(c *Config) Config {
 			WSPort:            c.RPC.WSPort,
 			WSExternal:        c.RPC.WSExternal,
 			UnsafeWSExternal:  c.RPC.UnsafeWSExternal,
+			SlowCallThreshold: c.RPC.SlowCallThreshold,
 		},
 		Pprof: &PprofConfig{
 			Enabled:          c.Pprof.Enabled,
@@ -0,0 +1,84 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ErrUnknownKeys is returned when a configuration file sets keys which are not
+// part of the configuration schema.
+var ErrUnknownKeys = errors.New("unknown configuration keys")
+
+// Keys returns the sorted keys of the configuration schema, as set in the
+// configuration file, the sections being separated from the key by a dot.
+func Keys() (keys []string) {
+	keys = appendKeys(keys, "", reflect.TypeOf(Config{}))
+	sort.Strings(keys)
+	return keys
+}
+
+func appendKeys(keys []string, prefix string, structType reflect.Type) []string {
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		tag := field.Tag.Get("mapstructure")
+		if tag == "" {
+			continue
+		}
+
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			continue
+		}
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		if options == "squash" {
+			keys = appendKeys(keys, prefix, fieldType)
+			continue
+		}
+
+		key := name
+		if prefix != "" {
+			key = prefix + "." + name
+		}
+
+		if fieldType.Kind() == reflect.Struct && fieldType.PkgPath() == structType.PkgPath() {
+			keys = appendKeys(keys, key, fieldType)
+			continue
+		}
+
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// CheckKeys returns an error wrapping ErrUnknownKeys if any of the given
+// configuration keys is not part of the configuration schema.
+func CheckKeys(keys []string) error {
+	known := make(map[string]struct{})
+	for _, key := range Keys() {
+		known[key] = struct{}{}
+	}
+
+	var unknown []string
+	for _, key := range keys {
+		if _, ok := known[strings.ToLower(key)]; !ok {
+			unknown = append(unknown, key)
+		}
+	}
+
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("%w: %s", ErrUnknownKeys, strings.Join(unknown, ", "))
+	}
+	return nil
+}
@@ -6,6 +6,7 @@
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -41,13 +42,21 @@ func init() {
 func WriteConfigFile(basePath string, config *Config) error {
 	var buffer bytes.Buffer
 	configFilePath := filepath.Join(basePath, defaultConfigFilePath)
-	if err := configTemplate.Execute(&buffer, config); err != nil {
-		return fmt.Errorf("failed to render config template: %w", err)
+	if err := WriteConfig(&buffer, config); err != nil {
+		return err
 	}
 
 	return os.WriteFile(configFilePath, buffer.Bytes(), 0o600)
 }
 
+// WriteConfig renders the config as a TOML config file to the given writer.
+func WriteConfig(w io.Writer, config *Config) error {
+	if err := configTemplate.Execute(w, config); err != nil {
+		return fmt.Errorf("failed to render config template: %w", err)
+	}
+	return nil
+}
+
 // Note: any changes to the comments/variables/mapstructure
 // must be reflected in the appropriate struct in config/config.go
 const defaultConfigTemplate = `# This is a TOML config file.
@@ -94,10 +103,30 @@ func WriteConfigFile(basePath string, config *Config) error {
 # Defaults to "archive"
 pruning = "{{ .BaseConfig.Pruning }}"
 
+# Compress the stored block bodies and justifications with zstd,
+# using a dictionary trained on the chain, and recompress the existing ones in the background
+# Defaults to false
+compress-block-data = {{ .BaseConfig.CompressBlockData }}
+
+# Number of blocks behind the best block beyond which the unfinalised
+# branches are discarded from memory, 0 to never discard them
+# Defaults to 0
+max-fork-depth = {{ .BaseConfig.MaxForkDepth }}
+
+# Hex encoded hashes of blocks whose branches are never discarded (comma separated)
+keep-branches = "{{ StringsJoin .BaseConfig.KeepBranches "," }}"
+
 # Disable connecting to the Substrate telemetry server
 # Defaults to false
 no-telemetry = {{ .BaseConfig.NoTelemetry }}
 
+# Action taken by each subsystem when one of its goroutines panics
+# Syntax is a list of 'subsystem=policy' (comma separated)
+# Subsystems are rpc and telemetry, and policies are restart and shutdown
+# Subsystems not listed shut the node down
+# Defaults to "rpc=restart,telemetry=restart"
+panic-policy = "{{ .BaseConfig.PanicPolicy }}"
+
 # List of telemetry server URLs to connect to
 # Format for each entry:
 # [[telemetry-urls]]
@@ -167,6 +196,11 @@ func WriteConfigFile(basePath string, config *Config) error {
 # Unlock an account. eg. --unlock=0 to unlock account 0
 unlock = "{{ .Account.Unlock }}"
 
+# Key management service key to sign grandpa votes with, instead of the grandpa keys of the keystore
+# eg. gcpkms://projects/<project>/locations/<location>/keyRings/<ring>/cryptoKeys/<key>/cryptoKeyVersions/<version>
+# or awskms:///arn:aws:kms:<region>:<account>:key/<key id>
+grandpa-kms = "{{ .Account.GrandpaKMS }}"
+
 #######################################################
 ###          Network Configuration Options          ###
 #######################################################
@@ -217,6 +251,23 @@ func WriteConfigFile(basePath string, config *Config) error {
 # Multiaddress to listen on
 listen-addr = "{{ .Network.ListenAddress }}"
 
+# Directory the inbound protocol messages are recorded into as a fuzz corpus
+# Defaults to "" (disabled)
+fuzz-corpus-dir = "{{ .Network.FuzzCorpusDir }}"
+
+# Interval between two bootnode liveness checks in duration, 0 only checking
+# the bootnodes at startup
+# Format: "10s", "1m", "1h"
+# Defaults to "10m"
+bootnode-check-interval = "{{ .Network.BootnodeCheckInterval }}"
+
+# HTTPS endpoint serving the signed bootnodes list fetched once all the
+# bootnodes are dead, and the hex encoded ed25519 public key verifying its
+# signature. Both are set from the bootNodesEndpoint of the chain spec
+# Defaults to "" (disabled)
+bootnodes-endpoint = "{{ .Network.BootnodesEndpoint }}"
+bootnodes-endpoint-key = "{{ .Network.BootnodesEndpointKey }}"
+
 #######################################################
 ###             Core Configuration Options          ###
 #######################################################
@@ -242,6 +293,58 @@ func WriteConfigFile(basePath string, config *Config) error {
 # Grandpa interval
 grandpa-interval = "{{ .Core.GrandpaInterval }}"
 
+# Follow the GRANDPA rounds without casting votes, if not a GRANDPA authority
+# Defaults to false
+grandpa-observer = {{ .Core.GrandpaObserver }}
+
+# Maximum number of unfinalised blocks before the finality lag policy is applied to BABE authoring
+# Defaults to 0 (disabled)
+max-finality-lag = {{ .Core.MaxFinalityLag }}
+
+# BABE authoring policy when the finality lag is exceeded
+# One of: "skip" (skip authoring), "finalised" (author on the highest finalised block)
+# Defaults to "skip"
+finality-lag-policy = "{{ .Core.FinalityLagPolicy }}"
+
+# Number of wasm heap pages of the runtime, overriding the ":heappages" storage value
+# Defaults to 0 (use the storage value)
+heap-pages = {{ .Core.HeapPages }}
+
+# Policy applied to the float instructions of the runtime, whose NaN results may differ across hosts
+# One of: "off", "warn" (log runtimes with such instructions), "canonicalise" (canonicalise the NaNs)
+# Defaults to "off"
+float-determinism = "{{ .Core.FloatDeterminism }}"
+
+# Number of GRANDPA consensus messages kept in the message journal, which is
+# dumped to the base path on panic or by the grandpa_dumpMessageJournal RPC method
+# Defaults to 0 (disabled)
+grandpa-journal-size = {{ .Core.GrandpaJournalSize }}
+
+# Number of blocks ahead of the executing block whose justifications are
+# verified during initial sync
+# Defaults to 0 (disabled)
+sync-pipeline-depth = {{ .Core.SyncPipelineDepth }}
+
+# Execute the block following a runtime upgrade again with both the previous
+# and the new runtime, alerting on unexpected state root divergences
+# Defaults to false
+runtime-upgrade-dry-run = {{ .Core.RuntimeUpgradeDryRun }}
+
+# Path of the lease file shared with the other nodes running with the same
+# authority keys, of which only the lease holder authors blocks and votes
+# Defaults to "" (standby mode disabled)
+standby-lease = "{{ .Core.StandbyLease }}"
+
+# Duration after which a standby node takes over if the active node did not renew its lease
+# Format: "10s", "1m", "1h"
+standby-lease-ttl = "{{ .Core.StandbyLeaseTTL }}"
+
+# Time reserved at the end of each BABE authoring slot to finalise, seal and propagate
+# the block, after which no more extrinsics are applied
+# Format: "1s", "500ms"
+# Defaults to "0s" (a third of the slot duration)
+authoring-propagation-margin = "{{ .Core.AuthoringPropagationMargin }}"
+
 #######################################################
 ###            State Configuration Options          ###
 #######################################################
@@ -250,6 +353,11 @@ func WriteConfigFile(basePath string, config *Config) error {
 # Defaults to 0
 rewind = {{ .State.Rewind }}
 
+# Interval between two verifications of the state trie of the highest finalised block,
+# reporting its trie nodes missing from the database or corrupt
+# Defaults to "0s" (disabled)
+verify-interval = "{{ .State.VerifyInterval }}"
+
 #######################################################
 ###              RPC Configuration Options          ###
 #######################################################
@@ -291,6 +399,10 @@ func WriteConfigFile(basePath string, config *Config) error {
 # Defaults to false
 unsafe-ws-external = {{ .RPC.UnsafeWSExternal }}
 
+# Duration above which the RPC calls are logged with their method, request size and caller
+# Defaults to "0s" (disabled)
+slow-call-threshold = "{{ .RPC.SlowCallThreshold }}"
+
 #######################################################
 ###            PPROF Configuration Options          ###
 #######################################################
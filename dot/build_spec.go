@@ -4,6 +4,7 @@
 package dot
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -41,7 +42,21 @@ func (b *BuildSpec) ToJSON() ([]byte, error) {
 
 // ToJSONRaw outputs genesis JSON in raw form
 func (b *BuildSpec) ToJSONRaw() ([]byte, error) {
-	tmpGen := &genesis.Genesis{
+	return json.MarshalIndent(b.rawGenesis(), "", "    ")
+}
+
+// ToSCALE outputs genesis in raw form, SCALE encoded
+func (b *BuildSpec) ToSCALE() ([]byte, error) {
+	var buffer bytes.Buffer
+	err := b.rawGenesis().EncodeSCALE(&buffer)
+	if err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func (b *BuildSpec) rawGenesis() *genesis.Genesis {
+	return &genesis.Genesis{
 		Name:       b.genesis.Name,
 		ID:         b.genesis.ID,
 		ChainType:  b.genesis.ChainType,
@@ -52,15 +67,23 @@ func (b *BuildSpec) ToJSONRaw() ([]byte, error) {
 			Raw: b.genesis.GenesisFields().Raw,
 		},
 	}
-	return json.MarshalIndent(tmpGen, "", "    ")
 }
 
-// BuildFromGenesis builds a BuildSpec based on the human-readable genesis file at path
+// BuildFromGenesis builds a BuildSpec based on the genesis file at path, either a
+// human-readable genesis file or a raw genesis file, JSON formatted or SCALE encoded
 func BuildFromGenesis(path string, authCount int) (*BuildSpec, error) {
-	gen, err := genesis.NewGenesisFromJSON(path, authCount)
+	gen, err := genesis.NewGenesisFromFile(path)
 	if err != nil {
 		return nil, err
 	}
+
+	if gen.Genesis.Runtime != nil {
+		// genesis is human-readable, build its raw storage
+		gen, err = genesis.NewGenesisFromJSON(path, authCount)
+		if err != nil {
+			return nil, err
+		}
+	}
 	bs := &BuildSpec{
 		genesis: gen,
 	}
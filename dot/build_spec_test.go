@@ -269,6 +269,35 @@ func TestBuildSpec_ToJSONRaw(t *testing.T) {
 	}
 }
 
+func TestBuildSpec_ToSCALE(t *testing.T) {
+	b := &BuildSpec{
+		genesis: &genesis.Genesis{
+			Name: "test",
+			Genesis: genesis.Fields{
+				Raw: map[string]map[string]string{"top": {"0x3a636f6465": "0x0102"}},
+				Runtime: &genesis.Runtime{
+					System: &genesis.System{Code: "0x0102"},
+				},
+			},
+		},
+	}
+
+	data, err := b.ToSCALE()
+	require.NoError(t, err)
+	fp := filepath.Join(t.TempDir(), "genesis.scale")
+	require.NoError(t, WriteGenesisSpecFile(data, fp))
+
+	got, err := BuildFromGenesis(fp, 0)
+	require.NoError(t, err)
+	expected := &genesis.Genesis{
+		Name: "test",
+		Genesis: genesis.Fields{
+			Raw: map[string]map[string]string{"top": {"0x3a636f6465": "0x0102"}},
+		},
+	}
+	assert.Equal(t, &BuildSpec{genesis: expected}, got)
+}
+
 func TestWriteGenesisSpecFile(t *testing.T) {
 	type args struct {
 		data []byte
@@ -0,0 +1,228 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package core
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// accountIDLength is the length of the account ids of the session queued keys.
+const accountIDLength = 32
+
+// AuthorityKeyStatus is the status of the local keys of a consensus engine
+// against its on-chain authorities.
+type AuthorityKeyStatus struct {
+	// LocalKeys are the public keys of the engine found in the keystore.
+	LocalKeys []common.Hash
+	// Active is true if a local key is in the current authority set.
+	Active bool
+	// Queued is true if a local key is in the session keys queued for the next session.
+	Queued bool
+}
+
+// AuthorityStatus is the status of the local BABE and GRANDPA keys against the
+// on-chain authority sets at the best block.
+type AuthorityStatus struct {
+	BlockHash common.Hash
+	BABE      AuthorityKeyStatus
+	GRANDPA   AuthorityKeyStatus
+}
+
+// AuthorityStatus compares the local BABE and GRANDPA keys with the current
+// authority sets and with the keys queued for the next session.
+func (s *Service) AuthorityStatus() (*AuthorityStatus, error) {
+	best, err := s.blockState.BestBlockHeader()
+	if err != nil {
+		return nil, fmt.Errorf("getting best block header: %w", err)
+	}
+
+	epoch, err := s.epochState.GetEpochForBlock(best)
+	if err != nil {
+		return nil, fmt.Errorf("getting epoch for best block: %w", err)
+	}
+
+	epochData, err := s.epochState.GetEpochDataRaw(epoch, best)
+	if err != nil {
+		return nil, fmt.Errorf("getting epoch data for epoch %d: %w", epoch, err)
+	}
+
+	babeAuthorities := make([][]byte, len(epochData.Authorities))
+	for i, authority := range epochData.Authorities {
+		babeAuthorities[i] = authority.Key[:]
+	}
+
+	setID, err := s.grandpaState.GetCurrentSetID()
+	if err != nil {
+		return nil, fmt.Errorf("getting current set id: %w", err)
+	}
+
+	voters, err := s.grandpaState.GetAuthorities(setID)
+	if err != nil {
+		return nil, fmt.Errorf("getting authorities of set id %d: %w", setID, err)
+	}
+
+	grandpaAuthorities := make([][]byte, len(voters))
+	for i, voter := range voters {
+		grandpaAuthorities[i] = voter.Key.Encode()
+	}
+
+	queuedKeys, err := s.queuedSessionKeys(best)
+	if err != nil {
+		return nil, fmt.Errorf("getting queued session keys: %w", err)
+	}
+
+	var babeKeystore, granKeystore keystore.Keystore
+	if s.keys != nil {
+		babeKeystore, granKeystore = s.keys.Babe, s.keys.Gran
+	}
+
+	return &AuthorityStatus{
+		BlockHash: best.Hash(),
+		BABE:      newAuthorityKeyStatus(babeKeystore, babeAuthorities, queuedKeys),
+		GRANDPA:   newAuthorityKeyStatus(granKeystore, grandpaAuthorities, queuedKeys),
+	}, nil
+}
+
+// queuedSessionKeys returns the encoded session keys of each validator queued
+// for the next session, read from the `Session QueuedKeys` storage at the given
+// block. The session keys layout depends on the runtime, so each entry is kept
+// as the raw concatenation of the public keys.
+func (s *Service) queuedSessionKeys(header *types.Header) ([][]byte, error) {
+	ts, err := s.storageState.TrieState(&header.StateRoot)
+	if err != nil {
+		return nil, fmt.Errorf("getting trie state: %w", err)
+	}
+
+	raw := ts.Get(sessionQueuedKeysStorageKey())
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	reader := bytes.NewReader(raw)
+	var length uint
+	err = scale.NewDecoder(reader).Decode(&length)
+	if err != nil {
+		return nil, fmt.Errorf("decoding queued keys length: %w", err)
+	}
+
+	if length == 0 {
+		return nil, nil
+	}
+
+	entries := raw[len(raw)-reader.Len():]
+	entrySize := uint(len(entries)) / length
+	if entrySize <= accountIDLength || entrySize*length != uint(len(entries)) {
+		return nil, fmt.Errorf("%w: %d bytes for %d entries", errInvalidQueuedKeys, len(entries), length)
+	}
+
+	queuedKeys := make([][]byte, length)
+	for i := range queuedKeys {
+		entry := entries[uint(i)*entrySize : uint(i+1)*entrySize]
+		queuedKeys[i] = entry[accountIDLength:]
+	}
+	return queuedKeys, nil
+}
+
+func sessionQueuedKeysStorageKey() []byte {
+	session, _ := common.Twox128Hash([]byte("Session"))
+	queuedKeys, _ := common.Twox128Hash([]byte("QueuedKeys"))
+	return append(session, queuedKeys...)
+}
+
+func newAuthorityKeyStatus(ks keystore.Keystore, authorities, queuedKeys [][]byte) (status AuthorityKeyStatus) {
+	if ks == nil {
+		return status
+	}
+
+	for _, publicKey := range ks.PublicKeys() {
+		encoded := publicKey.Encode()
+		status.LocalKeys = append(status.LocalKeys, common.BytesToHash(encoded))
+
+		for _, authority := range authorities {
+			if bytes.Equal(authority, encoded) {
+				status.Active = true
+			}
+		}
+
+		for _, keys := range queuedKeys {
+			if bytes.Contains(keys, encoded) {
+				status.Queued = true
+			}
+		}
+	}
+
+	return status
+}
+
+func (s *Service) hasAuthorityKeys() bool {
+	if s.keys == nil {
+		return false
+	}
+	return (s.keys.Babe != nil && s.keys.Babe.Size() > 0) ||
+		(s.keys.Gran != nil && s.keys.Gran.Size() > 0)
+}
+
+// checkAuthorityKeysForBlock checks the local keys once the first block of a
+// new epoch is imported.
+func (s *Service) checkAuthorityKeysForBlock(header *types.Header) {
+	if !s.hasAuthorityKeys() {
+		return
+	}
+
+	epoch, err := s.epochState.GetEpochForBlock(header)
+	if err != nil {
+		logger.Debugf("failed to get epoch of block %s: %s", header.Hash().Short(), err)
+		return
+	}
+
+	if s.lastCheckedEpoch != nil && *s.lastCheckedEpoch >= epoch {
+		return
+	}
+	s.lastCheckedEpoch = &epoch
+
+	s.checkAuthorityKeys()
+}
+
+// checkAuthorityKeys logs whether the local BABE and GRANDPA keys are in the
+// current and next on-chain authority sets. It is called at startup and on
+// each new epoch, to catch a node running with the wrong keys.
+func (s *Service) checkAuthorityKeys() {
+	if !s.hasAuthorityKeys() {
+		return
+	}
+
+	status, err := s.AuthorityStatus()
+	if err != nil {
+		logger.Warnf("failed to check local keys against the authority sets: %s", err)
+		return
+	}
+
+	for _, engine := range []struct {
+		name   string
+		status AuthorityKeyStatus
+	}{
+		{name: "BABE", status: status.BABE},
+		{name: "GRANDPA", status: status.GRANDPA},
+	} {
+		switch {
+		case len(engine.status.LocalKeys) == 0:
+		case engine.status.Active:
+			logger.Infof("local %s key is in the current authority set at block %s",
+				engine.name, status.BlockHash.Short())
+		case engine.status.Queued:
+			logger.Infof("local %s key is queued for the next session at block %s",
+				engine.name, status.BlockHash.Short())
+		default:
+			logger.Warnf("none of the %d local %s keys is in the current authority set or queued "+
+				"for the next session at block %s, check the node is running with the right keys",
+				len(engine.status.LocalKeys), engine.name, status.BlockHash.Short())
+		}
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package core
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_newAuthorityKeyStatus(t *testing.T) {
+	t.Parallel()
+
+	kr, err := keystore.NewSr25519Keyring()
+	require.NoError(t, err)
+	ks := keystore.NewBasicKeystore(keystore.BabeName, crypto.Sr25519Type)
+	require.NoError(t, ks.Insert(kr.Alice()))
+
+	alice := kr.Alice().Public().Encode()
+	bob := kr.Bob().Public().Encode()
+	queuedKeys := [][]byte{append(append([]byte{}, bob...), alice...)}
+
+	testCases := map[string]struct {
+		ks          keystore.Keystore
+		authorities [][]byte
+		queuedKeys  [][]byte
+		expected    AuthorityKeyStatus
+	}{
+		"no_keystore": {
+			authorities: [][]byte{alice},
+		},
+		"active_and_queued": {
+			ks:          ks,
+			authorities: [][]byte{bob, alice},
+			queuedKeys:  queuedKeys,
+			expected: AuthorityKeyStatus{
+				LocalKeys: []common.Hash{common.BytesToHash(alice)},
+				Active:    true,
+				Queued:    true,
+			},
+		},
+		"wrong_keys": {
+			ks:          ks,
+			authorities: [][]byte{bob},
+			queuedKeys:  [][]byte{bob},
+			expected: AuthorityKeyStatus{
+				LocalKeys: []common.Hash{common.BytesToHash(alice)},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			status := newAuthorityKeyStatus(testCase.ks, testCase.authorities, testCase.queuedKeys)
+			assert.Equal(t, testCase.expected, status)
+		})
+	}
+}
+
+func TestService_queuedSessionKeys(t *testing.T) {
+	t.Parallel()
+
+	accountID := make([]byte, accountIDLength)
+	sessionKeys := func(b byte) []byte {
+		keys := make([]byte, 2*32)
+		keys[0], keys[32] = b, b
+		return keys
+	}
+
+	encodeQueuedKeys := func(entries ...[]byte) []byte {
+		encoded, err := scale.Marshal(uint(len(entries)))
+		require.NoError(t, err)
+		for _, keys := range entries {
+			encoded = append(encoded, accountID...)
+			encoded = append(encoded, keys...)
+		}
+		return encoded
+	}
+
+	testCases := map[string]struct {
+		stored     []byte
+		expected   [][]byte
+		errWrapped error
+		errMessage string
+	}{
+		"no_session_pallet": {},
+		"empty": {
+			stored: encodeQueuedKeys(),
+		},
+		"two_validators": {
+			stored:   encodeQueuedKeys(sessionKeys(1), sessionKeys(2)),
+			expected: [][]byte{sessionKeys(1), sessionKeys(2)},
+		},
+		"truncated": {
+			stored:     encodeQueuedKeys(sessionKeys(1), sessionKeys(2))[:100],
+			errWrapped: errInvalidQueuedKeys,
+			errMessage: "invalid session queued keys: 99 bytes for 2 entries",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ts := rtstorage.NewTrieState(inmemory.NewEmptyTrie())
+			if testCase.stored != nil {
+				require.NoError(t, ts.Put(sessionQueuedKeysStorageKey(), testCase.stored))
+			}
+
+			header := &types.Header{StateRoot: common.Hash{1}}
+			ctrl := gomock.NewController(t)
+			storageState := NewMockStorageState(ctrl)
+			storageState.EXPECT().TrieState(&header.StateRoot).Return(ts, nil)
+
+			service := &Service{storageState: storageState}
+			queuedKeys, err := service.queuedSessionKeys(header)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errMessage != "" {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.expected, queuedKeys)
+		})
+	}
+}
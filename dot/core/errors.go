@@ -15,5 +15,10 @@
 	// ErrEmptyRuntimeCode is returned when the storage :code is empty
 	ErrEmptyRuntimeCode = errors.New("new :code is empty")
 
+	// ErrImportVetoed is returned when an import hook vetoes the import of a block
+	ErrImportVetoed = errors.New("block import vetoed")
+
 	errInvalidTransactionQueueVersion = errors.New("invalid transaction queue version")
+
+	errInvalidQueuedKeys = errors.New("invalid session queued keys")
 )
@@ -0,0 +1,170 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package core
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/lib/storagedecoder"
+	"github.com/ChainSafe/gossamer/lib/txbuilder"
+)
+
+// ImportedBlock is a block imported by the node, given to the import hooks.
+type ImportedBlock struct {
+	Block *types.Block
+	// Events are the events of the block, decoded from the System.Events storage with
+	// the metadata of the runtime executing the block as storagedecoder decodes values.
+	// They are nil if the events cannot be decoded.
+	Events []any
+	// StorageDiff are the changes of the storage made by the block, and are nil if
+	// they are not recorded by the state of the block.
+	StorageDiff []rtstorage.Change
+}
+
+// ImportHook is given the blocks imported by the node synchronously, so that they
+// can be processed in the same process, for example by an indexer embedding the node.
+// The hooks are called in the order they are added, and delay the import of the blocks
+// until they return.
+type ImportHook interface {
+	// PreImport is called after the block is executed and before it is written to the
+	// database. An error vetoes the import of the block if the hook was added with veto,
+	// and is logged otherwise.
+	PreImport(block *ImportedBlock) error
+	// PostImport is called after the block is written to the database.
+	PostImport(block *ImportedBlock)
+}
+
+// importHook is an import hook and whether it can veto the import of blocks.
+type importHook struct {
+	hook ImportHook
+	veto bool
+}
+
+// eventsDecoder is the decoder of the events of the blocks executed by a runtime.
+type eventsDecoder struct {
+	runtime runtime.Instance
+	decoder *storagedecoder.Decoder
+}
+
+// systemEventsKey is the storage key of the events of the block.
+var systemEventsKey = func() []byte {
+	system, _ := common.Twox128Hash([]byte("System"))
+	events, _ := common.Twox128Hash([]byte("Events"))
+	return append(system, events...)
+}()
+
+// AddImportHook adds the hook given the blocks imported by the node. A hook added
+// with veto rejects the import of the blocks its PreImport method returns an error
+// for, which stalls the node on the block, so veto must only be given to trusted hooks.
+func (s *Service) AddImportHook(hook ImportHook, veto bool) {
+	s.importHooksMtx.Lock()
+	defer s.importHooksMtx.Unlock()
+
+	s.importHooks = append(s.importHooks, importHook{hook: hook, veto: veto})
+}
+
+// preImport calls the pre-import hooks with the block, and returns the imported
+// block given to the post-import hooks, which is nil if there are no hooks.
+func (s *Service) preImport(block *types.Block, state *rtstorage.TrieState) (*ImportedBlock, error) {
+	s.importHooksMtx.RLock()
+	hooks := s.importHooks
+	s.importHooksMtx.RUnlock()
+	if len(hooks) == 0 {
+		return nil, nil
+	}
+
+	imported := &ImportedBlock{
+		Block:  block,
+		Events: s.decodeEvents(block, state),
+	}
+	changes := state.Changes()
+	if changes != nil {
+		imported.StorageDiff = changes.Diff()
+	}
+
+	for _, hook := range hooks {
+		err := hook.hook.PreImport(imported)
+		if err == nil {
+			continue
+		}
+		if hook.veto {
+			return nil, fmt.Errorf("%w: %s", ErrImportVetoed, err)
+		}
+		logger.Warnf("import hook failed for block #%d (%s): %s", block.Header.Number, block.Header.Hash(), err)
+	}
+
+	return imported, nil
+}
+
+// postImport calls the post-import hooks with the imported block, if any.
+func (s *Service) postImport(imported *ImportedBlock) {
+	if imported == nil {
+		return
+	}
+
+	s.importHooksMtx.RLock()
+	hooks := s.importHooks
+	s.importHooksMtx.RUnlock()
+
+	for _, hook := range hooks {
+		hook.hook.PostImport(imported)
+	}
+}
+
+// decodeEvents decodes the events of the block from its state, with the metadata of
+// the runtime of its parent block. It returns nil if the events cannot be decoded.
+func (s *Service) decodeEvents(block *types.Block, state *rtstorage.TrieState) []any {
+	encodedEvents := state.Get(systemEventsKey)
+	if encodedEvents == nil {
+		return nil
+	}
+
+	decoder, err := s.getEventsDecoder(block.Header.ParentHash)
+	if err != nil {
+		logger.Debugf("cannot decode events of block #%d (%s): %s", block.Header.Number, block.Header.Hash(), err)
+		return nil
+	}
+
+	entry, err := decoder.Decode(systemEventsKey, encodedEvents)
+	if err != nil {
+		logger.Debugf("cannot decode events of block #%d (%s): %s", block.Header.Number, block.Header.Hash(), err)
+		return nil
+	}
+	events, _ := entry.Value.([]any)
+	return events
+}
+
+// getEventsDecoder returns the decoder of the events of the blocks executed by the
+// runtime of the block. The decoder of the last runtime is cached, since decoding
+// the metadata is expensive and the runtime rarely changes.
+func (s *Service) getEventsDecoder(blockHash common.Hash) (*storagedecoder.Decoder, error) {
+	instance, err := s.blockState.GetRuntime(blockHash)
+	if err != nil {
+		return nil, fmt.Errorf("getting runtime: %w", err)
+	}
+
+	if s.eventsDecoder.runtime == instance {
+		return s.eventsDecoder.decoder, nil
+	}
+
+	rawMetadata, err := instance.Metadata()
+	if err != nil {
+		return nil, fmt.Errorf("calling runtime metadata: %w", err)
+	}
+	metadata, err := txbuilder.DecodeMetadata(rawMetadata)
+	if err != nil {
+		return nil, err
+	}
+	decoder, err := storagedecoder.NewDecoder(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("creating storage decoder: %w", err)
+	}
+
+	s.eventsDecoder = eventsDecoder{runtime: instance, decoder: decoder}
+	return decoder, nil
+}
@@ -0,0 +1,171 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/blocktree"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	ctypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+type testImportHook struct {
+	preImportErr error
+	preImported  []*ImportedBlock
+	postImported []*ImportedBlock
+}
+
+func (h *testImportHook) PreImport(block *ImportedBlock) error {
+	h.preImported = append(h.preImported, block)
+	return h.preImportErr
+}
+
+func (h *testImportHook) PostImport(block *ImportedBlock) {
+	h.postImported = append(h.postImported, block)
+}
+
+// newEventsMetadata returns the encoded metadata of a runtime whose events are u32.
+func newEventsMetadata(t *testing.T) []byte {
+	t.Helper()
+
+	metadata := ctypes.Metadata{
+		MagicNumber: ctypes.MagicNumber,
+		Version:     14,
+	}
+	metadata.AsMetadataV14.Lookup.Types = []ctypes.PortableTypeV14{
+		{
+			ID: ctypes.NewSi1LookupTypeIDFromUInt(0),
+			Type: ctypes.Si1Type{Def: ctypes.Si1TypeDef{
+				IsPrimitive: true,
+				Primitive:   ctypes.Si1TypeDefPrimitive{Si0TypeDefPrimitive: ctypes.IsU32},
+			}},
+		},
+		{
+			ID: ctypes.NewSi1LookupTypeIDFromUInt(1),
+			Type: ctypes.Si1Type{Def: ctypes.Si1TypeDef{
+				IsSequence: true,
+				Sequence:   ctypes.Si1TypeDefSequence{Type: ctypes.NewSi1LookupTypeIDFromUInt(0)},
+			}},
+		},
+	}
+	metadata.AsMetadataV14.Pallets = []ctypes.PalletMetadataV14{{
+		Name:       "System",
+		HasStorage: true,
+		Storage: ctypes.StorageMetadataV14{
+			Prefix: "System",
+			Items: []ctypes.StorageEntryMetadataV14{{
+				Name:     "Events",
+				Modifier: ctypes.StorageFunctionModifierV0{IsDefault: true},
+				Type:     ctypes.StorageEntryTypeV14{IsPlainType: true, AsPlainType: ctypes.NewSi1LookupTypeIDFromUInt(1)},
+			}},
+		},
+	}}
+
+	encoded, err := codec.Encode(metadata)
+	require.NoError(t, err)
+	rawMetadata, err := scale.Marshal(encoded)
+	require.NoError(t, err)
+	return rawMetadata
+}
+
+func Test_Service_handleBlock_importHooks(t *testing.T) {
+	t.Parallel()
+
+	newTestBlock := func() *types.Block {
+		block := types.NewBlock(*types.NewEmptyHeader(), *types.NewBody([]types.Extrinsic{[]byte{21}}))
+		block.Header.Number = 21
+		return &block
+	}
+
+	t.Run("vetoed", func(t *testing.T) {
+		t.Parallel()
+
+		trieState := rtstorage.NewTrieState(inmemory_trie.NewEmptyTrie())
+		block := newTestBlock()
+		logged := &testImportHook{preImportErr: errTestDummyError}
+		vetoing := &testImportHook{preImportErr: errTestDummyError}
+
+		service := &Service{}
+		service.AddImportHook(logged, false)
+		service.AddImportHook(vetoing, true)
+
+		err := service.handleBlock(block, trieState)
+		assert.ErrorIs(t, err, ErrImportVetoed)
+		assert.EqualError(t, err, "block import vetoed: test dummy error")
+		assert.Len(t, logged.preImported, 1)
+		assert.Len(t, vetoing.preImported, 1)
+		assert.Empty(t, logged.postImported)
+		assert.Empty(t, vetoing.postImported)
+	})
+
+	t.Run("imported", func(t *testing.T) {
+		t.Parallel()
+
+		trieState := rtstorage.NewTrieState(inmemory_trie.NewEmptyTrie())
+		trieState.RecordChanges()
+		trieState.StartTransaction()
+		events, err := scale.Marshal([]uint32{1, 2})
+		require.NoError(t, err)
+		require.NoError(t, trieState.Put(systemEventsKey, events))
+		require.NoError(t, trieState.Put([]byte("key"), []byte("value")))
+		trieState.CommitTransaction()
+		block := newTestBlock()
+
+		ctrl := gomock.NewController(t)
+		runtimeMock := NewMockInstance(ctrl)
+		runtimeMock.EXPECT().Metadata().Return(newEventsMetadata(t), nil)
+		mockStorageState := NewMockStorageState(ctrl)
+		mockStorageState.EXPECT().StoreTrie(trieState, &block.Header).Return(nil)
+		mockBlockState := NewMockBlockState(ctrl)
+		mockBlockState.EXPECT().AddBlock(block).Return(blocktree.ErrBlockExists)
+		mockBlockState.EXPECT().GetRuntime(block.Header.ParentHash).Return(runtimeMock, nil).Times(3)
+		mockBlockState.EXPECT().HandleRuntimeChanges(trieState, runtimeMock, block.Header.Hash()).Return(nil)
+		mockGrandpaState := NewMockGrandpaState(ctrl)
+		mockGrandpaState.EXPECT().ApplyForcedChanges(&block.Header).Return(nil)
+		onBlockImportHandlerMock := NewMockBlockImportDigestHandler(ctrl)
+		onBlockImportHandlerMock.EXPECT().HandleDigests(&block.Header).Return(nil)
+
+		service := &Service{
+			storageState:  mockStorageState,
+			blockState:    mockBlockState,
+			grandpaState:  mockGrandpaState,
+			ctx:           context.Background(),
+			onBlockImport: onBlockImportHandlerMock,
+		}
+		failing := &testImportHook{preImportErr: errTestDummyError}
+		hook := &testImportHook{}
+		service.AddImportHook(failing, false)
+		service.AddImportHook(hook, true)
+
+		err = service.handleBlock(block, trieState)
+		require.NoError(t, err)
+
+		expected := &ImportedBlock{
+			Block:  block,
+			Events: []any{uint32(1), uint32(2)},
+			StorageDiff: []rtstorage.Change{
+				{Key: systemEventsKey, Value: events},
+				{Key: []byte("key"), Value: []byte("value")},
+			},
+		}
+		require.Len(t, hook.preImported, 1)
+		assert.Equal(t, expected, hook.preImported[0])
+		require.Len(t, hook.postImported, 1)
+		assert.Same(t, hook.preImported[0], hook.postImported[0])
+		assert.Len(t, failing.postImported, 1)
+
+		// the events decoder of the runtime is cached
+		assert.Equal(t, []any{uint32(1), uint32(2)}, service.decodeEvents(block, trieState))
+	})
+}
@@ -30,6 +30,8 @@ type BlockState interface {
 	BestBlockHeader() (*types.Header, error)
 	AddBlock(*types.Block) error
 	GetHeader(bhash common.Hash) (*types.Header, error)
+	GetHashByNumber(num uint) (common.Hash, error)
+	GetHighestFinalisedHeader() (*types.Header, error)
 	GetBlockStateRoot(bhash common.Hash) (common.Hash, error)
 	RangeInMemory(start, end common.Hash) ([]common.Hash, error)
 	GetBlockBody(hash common.Hash) (*types.Body, error)
@@ -51,6 +53,7 @@ type StorageState interface {
 // EpochState is the interface for state.EpochState
 type EpochState interface {
 	GetEpochForBlock(header *types.Header) (uint64, error)
+	GetEpochDataRaw(epoch uint64, header *types.Header) (*types.EpochDataRaw, error)
 
 	// UpdateSkippedEpochDefinitions updates the skipped epoch definitions by changing the
 	// key from skipped epoch to current epoch on each epoch data raw storage
@@ -63,6 +66,8 @@ type EpochState interface {
 // GrandpaState is the interface for the state.GrandpaState
 type GrandpaState interface {
 	ApplyForcedChanges(importedHeader *types.Header) error
+	GetCurrentSetID() (uint64, error)
+	GetAuthorities(setID uint64) ([]types.GrandpaVoter, error)
 }
 
 // TransactionState is the interface for transaction state methods
@@ -70,7 +75,9 @@ type TransactionState interface {
 	Push(vt *transaction.ValidTransaction) (common.Hash, error)
 	AddToPool(vt *transaction.ValidTransaction) common.Hash
 	RemoveExtrinsic(ext types.Extrinsic)
+	RemoveInvalidExtrinsic(ext types.Extrinsic, reason string)
 	RemoveExtrinsicFromPool(ext types.Extrinsic)
+	Pending() []*transaction.ValidTransaction
 	PendingInPool() []*transaction.ValidTransaction
 	Exists(ext types.Extrinsic) bool
 }
@@ -4,6 +4,7 @@
 package core
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/ChainSafe/gossamer/dot/network"
@@ -40,6 +41,10 @@ func (s *Service) validateTransaction(head *types.Header, rt runtime.Instance,
 	}
 
 	vtx := transaction.NewValidTransaction(tx, validity)
+	vtx.Mortality, err = s.validateMortality(tx, head.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("validating mortality: %w", err)
+	}
 
 	// push to the transaction queue of BABE session
 	hash := s.transactionState.AddToPool(vtx)
@@ -87,7 +92,9 @@ func (s *Service) HandleTransactionMessage(peerID peer.ID, msg *network.Transact
 				}, peerID)
 			case runtime.UnknownTransaction:
 			default:
-				return false, fmt.Errorf("validating transaction from peerID %s: %w", peerID, err)
+				if !errors.Is(err, errInvalidMortality) {
+					return false, fmt.Errorf("validating transaction from peerID %s: %w", peerID, err)
+				}
 			}
 			continue
 		}
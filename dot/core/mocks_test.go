@@ -134,6 +134,21 @@ func (mr *MockBlockStateMockRecorder) GetBlockStateRoot(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockStateRoot", reflect.TypeOf((*MockBlockState)(nil).GetBlockStateRoot), arg0)
 }
 
+// GetHashByNumber mocks base method.
+func (m *MockBlockState) GetHashByNumber(arg0 uint) (common.Hash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHashByNumber", arg0)
+	ret0, _ := ret[0].(common.Hash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHashByNumber indicates an expected call of GetHashByNumber.
+func (mr *MockBlockStateMockRecorder) GetHashByNumber(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHashByNumber", reflect.TypeOf((*MockBlockState)(nil).GetHashByNumber), arg0)
+}
+
 // GetHeader mocks base method.
 func (m *MockBlockState) GetHeader(arg0 common.Hash) (*types.Header, error) {
 	m.ctrl.T.Helper()
@@ -149,6 +164,21 @@ func (mr *MockBlockStateMockRecorder) GetHeader(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeader", reflect.TypeOf((*MockBlockState)(nil).GetHeader), arg0)
 }
 
+// GetHighestFinalisedHeader mocks base method.
+func (m *MockBlockState) GetHighestFinalisedHeader() (*types.Header, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHighestFinalisedHeader")
+	ret0, _ := ret[0].(*types.Header)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHighestFinalisedHeader indicates an expected call of GetHighestFinalisedHeader.
+func (mr *MockBlockStateMockRecorder) GetHighestFinalisedHeader() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHighestFinalisedHeader", reflect.TypeOf((*MockBlockState)(nil).GetHighestFinalisedHeader))
+}
+
 // GetRuntime mocks base method.
 func (m *MockBlockState) GetRuntime(arg0 common.Hash) (runtime.Instance, error) {
 	m.ctrl.T.Helper()
@@ -377,6 +407,20 @@ func (mr *MockTransactionStateMockRecorder) Exists(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockTransactionState)(nil).Exists), arg0)
 }
 
+// Pending mocks base method.
+func (m *MockTransactionState) Pending() []*transaction.ValidTransaction {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pending")
+	ret0, _ := ret[0].([]*transaction.ValidTransaction)
+	return ret0
+}
+
+// Pending indicates an expected call of Pending.
+func (mr *MockTransactionStateMockRecorder) Pending() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pending", reflect.TypeOf((*MockTransactionState)(nil).Pending))
+}
+
 // PendingInPool mocks base method.
 func (m *MockTransactionState) PendingInPool() []*transaction.ValidTransaction {
 	m.ctrl.T.Helper()
@@ -418,6 +462,18 @@ func (mr *MockTransactionStateMockRecorder) RemoveExtrinsic(arg0 any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveExtrinsic", reflect.TypeOf((*MockTransactionState)(nil).RemoveExtrinsic), arg0)
 }
 
+// RemoveInvalidExtrinsic mocks base method.
+func (m *MockTransactionState) RemoveInvalidExtrinsic(arg0 types.Extrinsic, arg1 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RemoveInvalidExtrinsic", arg0, arg1)
+}
+
+// RemoveInvalidExtrinsic indicates an expected call of RemoveInvalidExtrinsic.
+func (mr *MockTransactionStateMockRecorder) RemoveInvalidExtrinsic(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveInvalidExtrinsic", reflect.TypeOf((*MockTransactionState)(nil).RemoveInvalidExtrinsic), arg0, arg1)
+}
+
 // RemoveExtrinsicFromPool mocks base method.
 func (m *MockTransactionState) RemoveExtrinsicFromPool(arg0 types.Extrinsic) {
 	m.ctrl.T.Helper()
@@ -636,3 +692,33 @@ func (mr *MockGrandpaStateMockRecorder) ApplyForcedChanges(arg0 any) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyForcedChanges", reflect.TypeOf((*MockGrandpaState)(nil).ApplyForcedChanges), arg0)
 }
+
+// GetAuthorities mocks base method.
+func (m *MockGrandpaState) GetAuthorities(arg0 uint64) ([]types.GrandpaVoter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthorities", arg0)
+	ret0, _ := ret[0].([]types.GrandpaVoter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuthorities indicates an expected call of GetAuthorities.
+func (mr *MockGrandpaStateMockRecorder) GetAuthorities(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthorities", reflect.TypeOf((*MockGrandpaState)(nil).GetAuthorities), arg0)
+}
+
+// GetCurrentSetID mocks base method.
+func (m *MockGrandpaState) GetCurrentSetID() (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentSetID")
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentSetID indicates an expected call of GetCurrentSetID.
+func (mr *MockGrandpaStateMockRecorder) GetCurrentSetID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentSetID", reflect.TypeOf((*MockGrandpaState)(nil).GetCurrentSetID))
+}
@@ -0,0 +1,134 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package core
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/transaction"
+	"github.com/ChainSafe/gossamer/lib/txbuilder"
+	cscale "github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	ctypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+var errInvalidMortality = errors.New("invalid transaction mortality")
+
+// validateMortality returns the mortality of the extrinsic validated on top of the best block,
+// or nil if the extrinsic is immortal or is not a signed extrinsic. It returns an error wrapping
+// errInvalidMortality if the extrinsic can no longer be included in a block.
+func (s *Service) validateMortality(ext types.Extrinsic, bestBlockHash common.Hash) (
+	mortality *transaction.Mortality, err error) {
+	decodedExt := &ctypes.Extrinsic{}
+	err = cscale.NewDecoder(bytes.NewReader(ext)).Decode(decodedExt)
+	if err != nil {
+		// the era of extrinsics not in the default extrinsic format is unknown
+		return nil, nil //nolint:nilerr
+	}
+	if !decodedExt.IsSigned() || !decodedExt.Signature.Era.IsMortalEra {
+		return nil, nil
+	}
+
+	best, err := s.blockState.GetHeader(bestBlockHash)
+	if err != nil {
+		return nil, fmt.Errorf("getting best block header: %w", err)
+	}
+
+	// the extrinsic is validated as if it was included in the block on top of the best block
+	era := decodedExt.Signature.Era
+	current := uint64(best.Number) + 1
+	mortality = &transaction.Mortality{
+		BirthNumber: uint(txbuilder.EraBirth(era, current)),
+		DeathNumber: uint(txbuilder.EraDeath(era, current)),
+	}
+	if mortality.BirthNumber <= best.Number {
+		mortality.BirthHash, err = s.blockState.GetHashByNumber(mortality.BirthNumber)
+		if err != nil {
+			return nil, fmt.Errorf("getting birth block hash: %w", err)
+		}
+	}
+
+	finalised, err := s.blockState.GetHighestFinalisedHeader()
+	if err != nil {
+		return nil, fmt.Errorf("getting highest finalised header: %w", err)
+	}
+
+	reason, err := s.mortalityInvalidity(mortality, best, finalised)
+	if err != nil {
+		return nil, err
+	} else if reason != "" {
+		return nil, fmt.Errorf("%w: %s", errInvalidMortality, reason)
+	}
+
+	return mortality, nil
+}
+
+// mortalityInvalidity returns why a transaction of the given mortality can no longer be
+// included in a block, or an empty string if it still can. Its era expired if it cannot be
+// included in the block on top of the best block, and its birth block was reorged away if
+// the birth block is not on the finalised chain.
+func (s *Service) mortalityInvalidity(mortality *transaction.Mortality, best, finalised *types.Header) (
+	reason string, err error) {
+	if best.Number+1 >= mortality.DeathNumber {
+		return fmt.Sprintf("era expired after block #%d", mortality.DeathNumber-1), nil
+	}
+
+	if mortality.BirthHash.IsEmpty() || mortality.BirthNumber > finalised.Number {
+		return "", nil
+	}
+
+	finalisedHash, err := s.blockState.GetHashByNumber(mortality.BirthNumber)
+	if err != nil {
+		return "", fmt.Errorf("getting finalised block hash: %w", err)
+	}
+
+	if finalisedHash != mortality.BirthHash {
+		return fmt.Sprintf("birth block #%d %s reorged away by finalised block %s",
+			mortality.BirthNumber, mortality.BirthHash, finalisedHash), nil
+	}
+
+	return "", nil
+}
+
+// evictMortalTransactions removes the transactions from the pool and queue which can
+// no longer be included in a block, and notifies the reason they were removed.
+func (s *Service) evictMortalTransactions(bestBlockHash common.Hash) error {
+	var mortalTxs []*transaction.ValidTransaction
+	for _, tx := range s.transactionState.Pending() {
+		if tx.Mortality != nil {
+			mortalTxs = append(mortalTxs, tx)
+		}
+	}
+
+	if len(mortalTxs) == 0 {
+		return nil
+	}
+
+	best, err := s.blockState.GetHeader(bestBlockHash)
+	if err != nil {
+		return fmt.Errorf("getting best block header: %w", err)
+	}
+
+	finalised, err := s.blockState.GetHighestFinalisedHeader()
+	if err != nil {
+		return fmt.Errorf("getting highest finalised header: %w", err)
+	}
+
+	for _, tx := range mortalTxs {
+		reason, err := s.mortalityInvalidity(tx.Mortality, best, finalised)
+		if err != nil {
+			return fmt.Errorf("checking mortality of extrinsic %s: %w", tx.Extrinsic, err)
+		} else if reason == "" {
+			continue
+		}
+
+		logger.Debugf("removing extrinsic %s from transaction pool: %s", tx.Extrinsic, reason)
+		s.transactionState.RemoveInvalidExtrinsic(tx.Extrinsic, reason)
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package core
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/lib/transaction"
+	"github.com/ChainSafe/gossamer/lib/txbuilder"
+	ctypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// generateMortalExtrinsic returns a signed extrinsic valid for 8 blocks from block 10.
+func generateMortalExtrinsic(t *testing.T) types.Extrinsic {
+	t.Helper()
+
+	builder, err := txbuilder.NewBuilder(generateTestCentrifugeMetadata(t),
+		runtime.Version{SpecVersion: specVersion}, common.Hash{}, nil)
+	require.NoError(t, err)
+
+	keyring, err := keystore.NewSr25519Keyring()
+	require.NoError(t, err)
+	bob, err := ctypes.NewMultiAddressFromHexAccountID(keyring.Bob().Public().Hex())
+	require.NoError(t, err)
+
+	call, err := builder.Call("Balances.transfer", bob, ctypes.NewUCompactFromUInt(12345))
+	require.NoError(t, err)
+
+	nonce := uint64(0)
+	options := txbuilder.Options{BlockHash: common.Hash{10}, BlockNumber: 10, Period: 8, Nonce: &nonce}
+	extrinsic, err := builder.BuildSigned(keyring.Alice(), call, options)
+	require.NoError(t, err)
+	return extrinsic
+}
+
+func Test_Service_validateMortality(t *testing.T) {
+	t.Parallel()
+
+	mortalExtrinsic := generateMortalExtrinsic(t)
+	immortalExtrinsic, _, _ := generateExtrinsic(t)
+	bestBlockHash := common.Hash{0xbe}
+
+	testCases := map[string]struct {
+		extrinsic       types.Extrinsic
+		finalisedNumber uint
+		finalisedHash   common.Hash
+		mortality       *transaction.Mortality
+		errWrapped      error
+		errMessage      string
+	}{
+		"not_signed_extrinsic": {
+			extrinsic: types.Extrinsic{1, 2, 3},
+		},
+		"immortal_extrinsic": {
+			extrinsic: immortalExtrinsic,
+		},
+		"birth_block_not_finalised": {
+			extrinsic:       mortalExtrinsic,
+			finalisedNumber: 9,
+			mortality:       &transaction.Mortality{BirthNumber: 10, BirthHash: common.Hash{10}, DeathNumber: 18},
+		},
+		"birth_block_finalised": {
+			extrinsic:       mortalExtrinsic,
+			finalisedNumber: 10,
+			finalisedHash:   common.Hash{10},
+			mortality:       &transaction.Mortality{BirthNumber: 10, BirthHash: common.Hash{10}, DeathNumber: 18},
+		},
+		"birth_block_reorged_away": {
+			extrinsic:       mortalExtrinsic,
+			finalisedNumber: 10,
+			finalisedHash:   common.Hash{11},
+			errWrapped:      errInvalidMortality,
+			errMessage: "invalid transaction mortality: birth block #10 " +
+				"0x0a00000000000000000000000000000000000000000000000000000000000000 reorged away by finalised block " +
+				"0x0b00000000000000000000000000000000000000000000000000000000000000",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			blockState := NewMockBlockState(ctrl)
+			if testCase.finalisedNumber > 0 {
+				blockState.EXPECT().GetHeader(bestBlockHash).Return(&types.Header{Number: 10}, nil)
+				blockState.EXPECT().GetHashByNumber(uint(10)).Return(common.Hash{10}, nil)
+				blockState.EXPECT().GetHighestFinalisedHeader().
+					Return(&types.Header{Number: testCase.finalisedNumber}, nil)
+			}
+			if !testCase.finalisedHash.IsEmpty() {
+				blockState.EXPECT().GetHashByNumber(uint(10)).Return(testCase.finalisedHash, nil)
+			}
+			service := &Service{blockState: blockState}
+
+			mortality, err := service.validateMortality(testCase.extrinsic, bestBlockHash)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errMessage != "" {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.mortality, mortality)
+		})
+	}
+}
+
+func Test_Service_evictMortalTransactions(t *testing.T) {
+	t.Parallel()
+
+	bestBlockHash := common.Hash{0xbe}
+	mortality := &transaction.Mortality{BirthNumber: 10, BirthHash: common.Hash{10}, DeathNumber: 18}
+	immortalTx := &transaction.ValidTransaction{Extrinsic: types.Extrinsic{1}}
+	mortalTx := &transaction.ValidTransaction{Extrinsic: types.Extrinsic{2}, Mortality: mortality}
+
+	testCases := map[string]struct {
+		pending         []*transaction.ValidTransaction
+		bestNumber      uint
+		finalisedNumber uint
+		finalisedHash   common.Hash
+		evictionReason  string
+	}{
+		"no_mortal_transaction": {
+			pending: []*transaction.ValidTransaction{immortalTx},
+		},
+		"valid": {
+			pending:         []*transaction.ValidTransaction{immortalTx, mortalTx},
+			bestNumber:      16,
+			finalisedNumber: 12,
+			finalisedHash:   common.Hash{10},
+		},
+		"era_expired": {
+			pending:         []*transaction.ValidTransaction{mortalTx},
+			bestNumber:      17,
+			finalisedNumber: 12,
+			evictionReason:  "era expired after block #17",
+		},
+		"birth_block_reorged_away": {
+			pending:         []*transaction.ValidTransaction{mortalTx},
+			bestNumber:      16,
+			finalisedNumber: 12,
+			finalisedHash:   common.Hash{11},
+			evictionReason: "birth block #10 0x0a00000000000000000000000000000000000000000000000000000000000000 " +
+				"reorged away by finalised block 0x0b00000000000000000000000000000000000000000000000000000000000000",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			transactionState := NewMockTransactionState(ctrl)
+			transactionState.EXPECT().Pending().Return(testCase.pending)
+			if testCase.evictionReason != "" {
+				transactionState.EXPECT().RemoveInvalidExtrinsic(mortalTx.Extrinsic, testCase.evictionReason)
+			}
+
+			blockState := NewMockBlockState(ctrl)
+			if testCase.bestNumber > 0 {
+				blockState.EXPECT().GetHeader(bestBlockHash).Return(&types.Header{Number: testCase.bestNumber}, nil)
+				blockState.EXPECT().GetHighestFinalisedHeader().
+					Return(&types.Header{Number: testCase.finalisedNumber}, nil)
+			}
+			if !testCase.finalisedHash.IsEmpty() {
+				blockState.EXPECT().GetHashByNumber(uint(10)).Return(testCase.finalisedHash, nil)
+			}
+
+			service := &Service{
+				blockState:       blockState,
+				transactionState: transactionState,
+			}
+
+			err := service.evictMortalTransactions(bestBlockHash)
+			require.NoError(t, err)
+		})
+	}
+}
@@ -57,6 +57,16 @@ type Service struct {
 	// Keystore
 	keys          *keystore.GlobalKeystore
 	onBlockImport BlockImportDigestHandler
+
+	// epoch of the last check of the local keys against the authority sets,
+	// only accessed by the block handling goroutine
+	lastCheckedEpoch *uint64
+
+	importHooksMtx sync.RWMutex
+	importHooks    []importHook
+	// decoder of the events given to the import hooks, only accessed while
+	// handling a block with the storage state locked
+	eventsDecoder eventsDecoder
 }
 
 // Config holds the configuration for the core Service.
@@ -106,6 +116,7 @@ func NewService(cfg *Config) (*Service, error) {
 
 // Start starts the core service
 func (s *Service) Start() error {
+	s.checkAuthorityKeys()
 	go s.handleBlocksAsync()
 	return nil
 }
@@ -250,8 +261,13 @@ func (s *Service) handleBlock(block *types.Block, state *rtstorage.TrieState) er
 		return ErrNilBlockHandlerParameter
 	}
 
+	imported, err := s.preImport(block, state)
+	if err != nil {
+		return err
+	}
+
 	// store updates state trie nodes in database
-	err := s.storageState.StoreTrie(state, &block.Header)
+	err = s.storageState.StoreTrie(state, &block.Header)
 	if err != nil {
 		logger.Warnf("failed to store state trie for imported block %s: %s",
 			block.Header.Hash(), err)
@@ -301,6 +317,8 @@ func (s *Service) handleBlock(block *types.Block, state *rtstorage.TrieState) er
 		return err
 	}
 
+	s.postImport(imported)
+
 	go func() {
 		s.lock.Lock()
 		defer s.lock.Unlock()
@@ -339,6 +357,9 @@ func (s *Service) handleCodeSubstitution(hash common.Hash,
 		Keystore:    rt.Keystore(),
 		NodeStorage: rt.NodeStorage(),
 		Network:     rt.NetworkService(),
+		HeapPages:   rt.HeapPages(),
+
+		FloatDeterminism: rt.FloatDeterminism(),
 	}
 
 	if rt.Validator() {
@@ -383,6 +404,12 @@ func (s *Service) handleBlocksAsync() {
 				// TODO remove once gossamer is in stable state
 				panic(fmt.Errorf("failed to maintain txn pool after re-org: %s", err))
 			}
+
+			if err := s.evictMortalTransactions(bestBlockHash); err != nil {
+				logger.Errorf("failed to evict mortal transactions: %s", err)
+			}
+
+			s.checkAuthorityKeysForBlock(&block.Header)
 		case <-s.ctx.Done():
 			return
 		}
@@ -459,6 +486,14 @@ func (s *Service) handleChainReorg(best, curr common.Hash) error {
 				continue
 			}
 			vtx := transaction.NewValidTransaction(ext, transactionValidity)
+			vtx.Mortality, err = s.validateMortality(ext, bestBlockHash)
+			if errors.Is(err, errInvalidMortality) {
+				logger.Debugf("skipping extrinsic %s in chain reorg: %s", ext, err)
+				s.transactionState.RemoveExtrinsic(ext)
+				continue
+			} else if err != nil {
+				return fmt.Errorf("validating mortality: %w", err)
+			}
 			s.transactionState.AddToPool(vtx)
 		}
 	}
@@ -510,7 +545,9 @@ func (s *Service) maintainTransactionPool(block *types.Block, bestBlockHash comm
 			continue
 		}
 
+		mortality := tx.Mortality
 		tx = transaction.NewValidTransaction(tx.Extrinsic, txnValidity)
+		tx.Mortality = mortality
 
 		// Err is only thrown if tx is already in pool, in which case it still gets removed
 		h, _ := s.transactionState.Push(tx)
@@ -605,6 +642,10 @@ func (s *Service) HandleSubmittedExtrinsic(ext types.Extrinsic) error {
 
 	// add transaction to pool
 	vtx := transaction.NewValidTransaction(ext, transactionValidity)
+	vtx.Mortality, err = s.validateMortality(ext, bestBlockHash)
+	if err != nil {
+		return fmt.Errorf("validating mortality: %w", err)
+	}
 	s.transactionState.AddToPool(vtx)
 
 	// broadcast transaction
@@ -23,9 +23,8 @@
 	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
 	wazero_runtime "github.com/ChainSafe/gossamer/lib/runtime/wazero"
 	"github.com/ChainSafe/gossamer/lib/transaction"
+	"github.com/ChainSafe/gossamer/lib/txbuilder"
 	"github.com/ChainSafe/gossamer/pkg/scale"
-	cscale "github.com/centrifuge/go-substrate-rpc-client/v4/scale"
-	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
 	ctypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 
@@ -85,35 +84,19 @@ func generateExtrinsic(t *testing.T) (extrinsic, externalExtrinsic types.Extrins
 	bob, err := ctypes.NewMultiAddressFromHexAccountID(bobPub)
 	require.NoError(t, err)
 
-	const balanceTransfer = "Balances.transfer"
-	call, err := ctypes.NewCall(meta, balanceTransfer, bob, ctypes.NewUCompactFromUInt(12345))
+	builder, err := txbuilder.NewBuilder(meta, rv, common.Hash{}, nil)
 	require.NoError(t, err)
 
-	// Create the extrinsic
-	centrifugeExtrinsic := ctypes.NewExtrinsic(call)
-	testGenHash := ctypes.NewHash(common.Hash{}.ToBytes())
+	const balanceTransfer = "Balances.transfer"
+	call, err := builder.Call(balanceTransfer, bob, ctypes.NewUCompactFromUInt(12345))
 	require.NoError(t, err)
-	o := ctypes.SignatureOptions{
-		BlockHash:          testGenHash,
-		Era:                ctypes.ExtrinsicEra{IsImmortalEra: true},
-		GenesisHash:        testGenHash,
-		Nonce:              ctypes.NewUCompactFromUInt(uint64(0)),
-		SpecVersion:        ctypes.U32(rv.SpecVersion),
-		Tip:                ctypes.NewUCompactFromUInt(0),
-		TransactionVersion: ctypes.U32(rv.TransactionVersion),
-	}
 
 	// Sign the transaction using Alice's default account
-	err = centrifugeExtrinsic.Sign(signature.TestKeyringPairAlice, o)
-	require.NoError(t, err)
-
-	// Encode the signed extrinsic
-	extEnc := bytes.Buffer{}
-	encoder := cscale.NewEncoder(&extEnc)
-	err = centrifugeExtrinsic.Encode(*encoder)
+	nonce := uint64(0)
+	signedExtrinsic, err := builder.BuildSigned(keyring.Alice(), call, txbuilder.Options{Nonce: &nonce})
 	require.NoError(t, err)
 
-	encExt := []types.Extrinsic{extEnc.Bytes()}
+	encExt := []types.Extrinsic{signedExtrinsic}
 	testHeader := types.NewEmptyHeader()
 	testExternalExt := types.Extrinsic(bytes.Join([][]byte{
 		{byte(types.TxnExternal)},
@@ -261,6 +244,8 @@ func Test_Service_handleCodeSubstitution(t *testing.T) {
 				storedRuntime.EXPECT().Keystore().Return(nil)
 				storedRuntime.EXPECT().NodeStorage().Return(runtime.NodeStorage{})
 				storedRuntime.EXPECT().NetworkService().Return(nil)
+				storedRuntime.EXPECT().HeapPages().Return(uint64(0))
+				storedRuntime.EXPECT().FloatDeterminism().Return(runtime.FloatDeterminismOff)
 				storedRuntime.EXPECT().Validator().Return(false)
 
 				blockState := NewMockBlockState(ctrl)
@@ -287,6 +272,8 @@ func Test_Service_handleCodeSubstitution(t *testing.T) {
 				storedRuntime.EXPECT().Keystore().Return(nil)
 				storedRuntime.EXPECT().NodeStorage().Return(runtime.NodeStorage{})
 				storedRuntime.EXPECT().NetworkService().Return(nil)
+				storedRuntime.EXPECT().HeapPages().Return(uint64(0))
+				storedRuntime.EXPECT().FloatDeterminism().Return(runtime.FloatDeterminismOff)
 				storedRuntime.EXPECT().Validator().Return(true)
 
 				blockState := NewMockBlockState(ctrl)
@@ -316,6 +303,8 @@ func Test_Service_handleCodeSubstitution(t *testing.T) {
 				storedRuntime.EXPECT().Keystore().Return(nil)
 				storedRuntime.EXPECT().NodeStorage().Return(runtime.NodeStorage{})
 				storedRuntime.EXPECT().NetworkService().Return(nil)
+				storedRuntime.EXPECT().HeapPages().Return(uint64(0))
+				storedRuntime.EXPECT().FloatDeterminism().Return(runtime.FloatDeterminismOff)
 				storedRuntime.EXPECT().Validator().Return(true)
 
 				blockState := NewMockBlockState(ctrl)
@@ -0,0 +1,92 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package doctor
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"time"
+)
+
+const ntpPacketLength = 48
+
+// ntpEpochOffset is the number of seconds from the NTP epoch, 1900, to the unix epoch.
+const ntpEpochOffset = 2208988800
+
+// checkClock checks the clock is synchronised with the NTP server, since the
+// BABE slots are derived from the clock.
+func checkClock(ctx context.Context, config Config) ([]Problem, error) {
+	if config.NTPServer == "" {
+		return nil, nil
+	}
+
+	offset, err := clockOffset(ctx, config.NTPServer)
+	if err != nil {
+		return nil, err
+	}
+
+	if offset.Abs() <= config.MaxClockOffset {
+		return nil, nil
+	}
+
+	return []Problem{{
+		Severity: SeverityCritical,
+		Message: fmt.Sprintf("clock is %s off from %s, more than %s, blocks may be produced in the wrong slots",
+			offset, config.NTPServer, config.MaxClockOffset),
+		Hint: "synchronise the clock with NTP, for example with chrony or systemd-timesyncd",
+	}}, nil
+}
+
+// clockOffset returns the offset of the NTP server clock from the local clock,
+// using a single SNTP request.
+func clockOffset(ctx context.Context, server string) (offset time.Duration, err error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "udp", server)
+	if err != nil {
+		return 0, fmt.Errorf("dialing NTP server: %w", err)
+	}
+	defer conn.Close()
+
+	deadline, ok := ctx.Deadline()
+	if ok {
+		err = conn.SetDeadline(deadline)
+		if err != nil {
+			return 0, fmt.Errorf("setting deadline: %w", err)
+		}
+	}
+
+	request := make([]byte, ntpPacketLength)
+	// leap indicator 0, version 3, client mode
+	request[0] = 0x1b
+	sentAt := time.Now()
+	_, err = conn.Write(request)
+	if err != nil {
+		return 0, fmt.Errorf("sending NTP request: %w", err)
+	}
+
+	response := make([]byte, ntpPacketLength)
+	n, err := conn.Read(response)
+	if err != nil {
+		return 0, fmt.Errorf("reading NTP response: %w", err)
+	}
+	receivedAt := time.Now()
+	if n < ntpPacketLength {
+		return 0, fmt.Errorf("NTP response is %d bytes, expected %d", n, ntpPacketLength)
+	}
+
+	serverReceivedAt := ntpTime(response[32:40])
+	serverSentAt := ntpTime(response[40:48])
+	return (serverReceivedAt.Sub(sentAt) + serverSentAt.Sub(receivedAt)) / 2, nil
+}
+
+// ntpTime decodes an NTP timestamp, which is the number of seconds since the
+// NTP epoch followed by the fraction of second, both big endian.
+func ntpTime(timestamp []byte) time.Time {
+	seconds := int64(binary.BigEndian.Uint32(timestamp[:4])) - ntpEpochOffset
+	fraction := uint64(binary.BigEndian.Uint32(timestamp[4:]))
+	nanoseconds := int64((fraction * uint64(time.Second)) >> 32)
+	return time.Unix(seconds, nanoseconds)
+}
@@ -0,0 +1,72 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package doctor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/internal/database"
+)
+
+// checkDatabase checks the database of the base path is initialised, and is
+// consistent so that the node starts without rolling back its finalised block.
+func checkDatabase(_ context.Context, config Config) (problems []Problem, err error) {
+	directory := filepath.Join(config.BasePath, database.DefaultDatabaseDir)
+	_, err = os.Stat(directory)
+	if errors.Is(err, os.ErrNotExist) {
+		return []Problem{{
+			Severity: SeverityCritical,
+			Message:  fmt.Sprintf("database %s does not exist", directory),
+			Hint:     "initialise the node with gossamer init",
+		}}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("getting information of database directory: %w", err)
+	}
+
+	db, err := database.LoadDatabase(config.BasePath, false)
+	if err != nil {
+		return []Problem{{
+			Severity: SeverityWarning,
+			Message:  fmt.Sprintf("database %s cannot be opened: %s", directory, err),
+			Hint:     "stop the node to check the database",
+		}}, nil
+	}
+	defer func() {
+		closeErr := db.Close()
+		if err == nil && closeErr != nil {
+			err = fmt.Errorf("closing database: %w", closeErr)
+		}
+	}()
+
+	report, err := state.CheckDatabase(db)
+	if err != nil {
+		return []Problem{{
+			Severity: SeverityCritical,
+			Message:  fmt.Sprintf("database %s is corrupted: %s", directory, err),
+			Hint:     "restore the database from a backup, or initialise the node again",
+		}}, nil
+	}
+
+	switch {
+	case report.Finalised == nil:
+		return []Problem{{
+			Severity: SeverityCritical,
+			Message:  fmt.Sprintf("database %s is not initialised", directory),
+			Hint:     "initialise the node with gossamer init",
+		}}, nil
+	case report.Repaired():
+		return []Problem{{
+			Severity: SeverityWarning,
+			Message: fmt.Sprintf("database %s was not closed cleanly, the next start repairs it "+
+				"with the finalised block #%d (%s)", directory, report.Finalised.Number, report.Finalised.Hash()),
+		}}, nil
+	}
+
+	return nil, nil
+}
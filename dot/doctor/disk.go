@@ -0,0 +1,77 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+)
+
+const diskChunkSize = 1 << 20
+
+// checkDisk checks the write throughput of the disk of the base path, by writing
+// and syncing a temporary file.
+func checkDisk(ctx context.Context, config Config) ([]Problem, error) {
+	if config.DiskTestSize == 0 {
+		return nil, nil
+	}
+
+	throughput, err := diskThroughput(ctx, config.BasePath, config.DiskTestSize)
+	if err != nil {
+		return nil, err
+	}
+
+	if throughput >= config.MinDiskThroughput {
+		return nil, nil
+	}
+
+	return []Problem{{
+		Severity: SeverityWarning,
+		Message: fmt.Sprintf("disk write throughput is %.1f MiB/s, lower than %.1f MiB/s, "+
+			"the node may fall behind the chain", throughput/(1<<20), config.MinDiskThroughput/(1<<20)),
+		Hint: "use a local SSD for the base path",
+	}}, nil
+}
+
+// diskThroughput returns the write throughput, in bytes per second, of the
+// disk of the directory.
+func diskThroughput(ctx context.Context, directory string, size int64) (throughput float64, err error) {
+	file, err := os.CreateTemp(directory, "doctor-*.tmp")
+	if err != nil {
+		return 0, fmt.Errorf("creating test file: %w", err)
+	}
+	defer func() {
+		closeErr := file.Close()
+		removeErr := os.Remove(file.Name())
+		if err == nil && closeErr != nil {
+			err = fmt.Errorf("closing test file: %w", closeErr)
+		} else if err == nil && removeErr != nil {
+			err = fmt.Errorf("removing test file: %w", removeErr)
+		}
+	}()
+
+	chunk := make([]byte, diskChunkSize)
+	start := time.Now()
+	for written := int64(0); written < size; written += int64(len(chunk)) {
+		err = ctx.Err()
+		if err != nil {
+			return 0, err
+		}
+		if size-written < int64(len(chunk)) {
+			chunk = chunk[:size-written]
+		}
+		_, err = file.Write(chunk)
+		if err != nil {
+			return 0, fmt.Errorf("writing test file: %w", err)
+		}
+	}
+	err = file.Sync()
+	if err != nil {
+		return 0, fmt.Errorf("syncing test file: %w", err)
+	}
+
+	return float64(size) / time.Since(start).Seconds(), nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	cfg "github.com/ChainSafe/gossamer/config"
+)
+
+// Severity is how much a problem affects the node.
+type Severity uint8
+
+const (
+	// SeverityInfo is a problem worth knowing about, which does not affect the node.
+	SeverityInfo Severity = iota
+	// SeverityWarning is a problem degrading the node.
+	SeverityWarning
+	// SeverityCritical is a problem preventing the node from working correctly.
+	SeverityCritical
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityCritical:
+		return "critical"
+	default:
+		return fmt.Sprintf("unknown severity %d", uint8(s))
+	}
+}
+
+// Problem is a problem found by a check.
+type Problem struct {
+	// Check is the name of the check which found the problem.
+	Check    string
+	Severity Severity
+	Message  string
+	// Hint is how to fix the problem, and can be empty.
+	Hint string
+}
+
+// Config is the configuration of the checks.
+type Config struct {
+	// BasePath is the base path of the node.
+	BasePath string
+	// P2PPort is the TCP port the node listens on for peers.
+	P2PPort uint16
+	// ProbeURL is the URL of the service probing the reachability of a TCP port of this
+	// host, to which the port number is appended. The port check is skipped if it is empty.
+	ProbeURL string
+	// NTPServer is the address of the NTP server the clock is compared with.
+	// The clock check is skipped if it is empty.
+	NTPServer string
+	// DiskTestSize is the number of bytes written to measure the disk throughput.
+	// The disk check is skipped if it is zero.
+	DiskTestSize int64
+	// MinOpenFiles is the minimum limit of open file descriptors.
+	MinOpenFiles uint64
+	// MinDiskThroughput is the minimum disk write throughput, in bytes per second.
+	MinDiskThroughput float64
+	// MaxClockOffset is the maximum offset of the clock from the NTP server.
+	MaxClockOffset time.Duration
+	// Timeout is the timeout of each check.
+	Timeout time.Duration
+}
+
+// DefaultConfig returns the default configuration of the checks.
+func DefaultConfig() Config {
+	return Config{
+		P2PPort:           cfg.DefaultNetworkPort,
+		ProbeURL:          "https://ifconfig.co/port/",
+		NTPServer:         "pool.ntp.org:123",
+		DiskTestSize:      64 << 20,
+		MinOpenFiles:      10000,
+		MinDiskThroughput: 50 << 20,
+		MaxClockOffset:    500 * time.Millisecond,
+		Timeout:           10 * time.Second,
+	}
+}
+
+type check struct {
+	name string
+	run  func(ctx context.Context, config Config) ([]Problem, error)
+}
+
+var checks = []check{
+	{name: "open-files", run: checkOpenFiles},
+	{name: "clock", run: checkClock},
+	{name: "disk", run: checkDisk},
+	{name: "port", run: checkPort},
+	{name: "keystore", run: checkKeystore},
+	{name: "database", run: checkDatabase},
+}
+
+// Run runs the checks and returns the problems found, from the most to the least
+// severe. A check which cannot run is reported as a warning.
+func Run(ctx context.Context, config Config) (problems []Problem) {
+	for _, check := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, config.Timeout)
+		found, err := check.run(checkCtx, config)
+		cancel()
+		if err != nil {
+			problems = append(problems, Problem{
+				Check:    check.name,
+				Severity: SeverityWarning,
+				Message:  fmt.Sprintf("check could not run: %s", err),
+			})
+			continue
+		}
+
+		for i := range found {
+			found[i].Check = check.name
+		}
+		problems = append(problems, found...)
+	}
+
+	sort.SliceStable(problems, func(i, j int) bool {
+		return problems[i].Severity > problems[j].Severity
+	})
+	return problems
+}
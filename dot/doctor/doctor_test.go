@@ -0,0 +1,259 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package doctor
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestConfig returns a configuration with the checks using the network skipped.
+func newTestConfig(t *testing.T) Config {
+	t.Helper()
+
+	config := DefaultConfig()
+	config.BasePath = t.TempDir()
+	config.ProbeURL = ""
+	config.NTPServer = ""
+	config.DiskTestSize = 0
+	config.MinOpenFiles = 0
+	return config
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	config := newTestConfig(t)
+	keystoreDir := filepath.Join(config.BasePath, "keystore")
+	require.NoError(t, os.Mkdir(keystoreDir, 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(keystoreDir, "bad.key"), []byte("{}"), 0o644))
+
+	problems := Run(context.Background(), config)
+
+	keyPath := filepath.Join(keystoreDir, "bad.key")
+	databasePath := filepath.Join(config.BasePath, database.DefaultDatabaseDir)
+	expected := []Problem{
+		{
+			Check:    "keystore",
+			Severity: SeverityCritical,
+			Message:  fmt.Sprintf("key file %s cannot be loaded: no ciphertext", keyPath),
+			Hint:     "restore the key file from a backup, or import the key again with gossamer account import",
+		},
+		{
+			Check:    "database",
+			Severity: SeverityCritical,
+			Message:  fmt.Sprintf("database %s does not exist", databasePath),
+			Hint:     "initialise the node with gossamer init",
+		},
+		{
+			Check:    "keystore",
+			Severity: SeverityWarning,
+			Message:  fmt.Sprintf("key file %s is accessible by other users with mode -rw-r--r--", keyPath),
+			Hint:     fmt.Sprintf("restrict the access with chmod 600 %s", keyPath),
+		},
+	}
+	assert.Equal(t, expected, problems)
+}
+
+func TestRun_checkError(t *testing.T) {
+	t.Parallel()
+
+	config := newTestConfig(t)
+	config.DiskTestSize = 1
+	config.BasePath = filepath.Join(config.BasePath, "missing")
+
+	problems := Run(context.Background(), config)
+
+	require.NotEmpty(t, problems)
+	assert.Equal(t, "database", problems[0].Check)
+	assert.Equal(t, SeverityCritical, problems[0].Severity)
+	require.Len(t, problems, 2)
+	assert.Equal(t, "disk", problems[1].Check)
+	assert.Equal(t, SeverityWarning, problems[1].Severity)
+	assert.Contains(t, problems[1].Message, "check could not run: creating test file: ")
+}
+
+// serveNTP answers the SNTP requests with the local time shifted by the offset.
+func serveNTP(t *testing.T, offset time.Duration) (address string) {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	go func() {
+		request := make([]byte, ntpPacketLength)
+		for {
+			_, from, err := conn.ReadFrom(request)
+			if err != nil {
+				return
+			}
+
+			now := time.Now().Add(offset)
+			seconds := uint32(now.Unix() + ntpEpochOffset)
+			fraction := uint32((uint64(now.Nanosecond()) << 32) / uint64(time.Second))
+			response := make([]byte, ntpPacketLength)
+			response[0] = 0x1c
+			for _, start := range []int{32, 40} {
+				binary.BigEndian.PutUint32(response[start:], seconds)
+				binary.BigEndian.PutUint32(response[start+4:], fraction)
+			}
+			_, _ = conn.WriteTo(response, from)
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+func Test_checkClock(t *testing.T) {
+	t.Parallel()
+
+	config := newTestConfig(t)
+	config.NTPServer = serveNTP(t, 0)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	problems, err := checkClock(ctx, config)
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+
+	config.NTPServer = serveNTP(t, -3*time.Second)
+	problems, err = checkClock(ctx, config)
+	require.NoError(t, err)
+	require.Len(t, problems, 1)
+	assert.Equal(t, SeverityCritical, problems[0].Severity)
+
+	offset, err := clockOffset(ctx, config.NTPServer)
+	require.NoError(t, err)
+	assert.InDelta(t, -3*time.Second, offset, float64(100*time.Millisecond))
+}
+
+func Test_checkDisk(t *testing.T) {
+	t.Parallel()
+
+	config := newTestConfig(t)
+	config.DiskTestSize = 3<<20 + 1
+
+	problems, err := checkDisk(context.Background(), config)
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+
+	config.MinDiskThroughput = 1 << 60
+	problems, err = checkDisk(context.Background(), config)
+	require.NoError(t, err)
+	require.Len(t, problems, 1)
+	assert.Equal(t, SeverityWarning, problems[0].Severity)
+
+	// the test file is removed
+	entries, err := os.ReadDir(config.BasePath)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func Test_checkPort(t *testing.T) {
+	t.Parallel()
+
+	reachable := map[string]bool{"/port/7001": true}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"ip":"127.0.0.1","port":0,"reachable":%t}`, reachable[r.URL.Path])
+	}))
+	t.Cleanup(server.Close)
+
+	config := newTestConfig(t)
+	config.ProbeURL = server.URL + "/port/"
+	config.P2PPort = 7001
+
+	problems, err := checkPort(context.Background(), config)
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+
+	config.P2PPort = 7002
+	problems, err = checkPort(context.Background(), config)
+	require.NoError(t, err)
+	expected := []Problem{{
+		Severity: SeverityWarning,
+		Message:  "P2P port 7002 is not reachable from the internet, peers cannot connect to the node",
+		Hint:     "open the port in the firewall and forward it from the router",
+	}}
+	assert.Equal(t, expected, problems)
+
+	failing := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(failing.Close)
+	_, err = probePort(context.Background(), failing.URL+"/", 1)
+	assert.ErrorIs(t, err, errProbeStatus)
+}
+
+func Test_checkKeystore(t *testing.T) {
+	t.Parallel()
+
+	config := newTestConfig(t)
+
+	problems, err := checkKeystore(context.Background(), config)
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+
+	for _, keyType := range []string{crypto.Sr25519Type, crypto.Ed25519Type, crypto.Secp256k1Type} {
+		_, err = keystore.GenerateKeypair(keyType, nil, config.BasePath, []byte("password"))
+		require.NoError(t, err)
+	}
+	problems, err = checkKeystore(context.Background(), config)
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+
+	path, err := keystore.GenerateKeypair(crypto.Sr25519Type, nil, config.BasePath, nil)
+	require.NoError(t, err)
+	renamed := filepath.Join(filepath.Dir(path), "renamed.key")
+	require.NoError(t, os.Rename(path, renamed))
+	unknown := filepath.Join(filepath.Dir(path), "unknown.key")
+	require.NoError(t, os.WriteFile(unknown, []byte(`{"Type":"rsa","PublicKey":"0x01","Ciphertext":"AQ=="}`), 0o600))
+
+	problems, err = checkKeystore(context.Background(), config)
+	require.NoError(t, err)
+	require.Len(t, problems, 2)
+	assert.Equal(t, SeverityWarning, problems[0].Severity)
+	assert.Equal(t, fmt.Sprintf("rename the key file to %s", filepath.Base(path)), problems[0].Hint)
+	assert.Equal(t, SeverityCritical, problems[1].Severity)
+	assert.Equal(t, fmt.Sprintf(`key file %s cannot be loaded: unknown key type: "rsa"`, unknown), problems[1].Message)
+}
+
+func Test_checkDatabase(t *testing.T) {
+	t.Parallel()
+
+	config := newTestConfig(t)
+	databasePath := filepath.Join(config.BasePath, database.DefaultDatabaseDir)
+
+	db, err := database.LoadDatabase(config.BasePath, false)
+	require.NoError(t, err)
+
+	// the database is locked while it is open
+	problems, err := checkDatabase(context.Background(), config)
+	require.NoError(t, err)
+	require.Len(t, problems, 1)
+	assert.Equal(t, SeverityWarning, problems[0].Severity)
+	assert.Equal(t, "stop the node to check the database", problems[0].Hint)
+
+	require.NoError(t, db.Close())
+	problems, err = checkDatabase(context.Background(), config)
+	require.NoError(t, err)
+	expected := []Problem{{
+		Severity: SeverityCritical,
+		Message:  fmt.Sprintf("database %s is not initialised", databasePath),
+		Hint:     "initialise the node with gossamer init",
+	}}
+	assert.Equal(t, expected, problems)
+}
@@ -0,0 +1,116 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package doctor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/ChainSafe/gossamer/lib/crypto/secp256k1"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+)
+
+var (
+	errUnknownKeyType = errors.New("unknown key type")
+	errNoCiphertext   = errors.New("no ciphertext")
+)
+
+// checkKeystore checks the key files of the keystore directory of the base path
+// can be loaded, match their file name and are only accessible by their owner.
+func checkKeystore(_ context.Context, config Config) (problems []Problem, err error) {
+	directory := filepath.Join(config.BasePath, "keystore")
+	entries, err := os.ReadDir(directory)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("reading keystore directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".key" {
+			continue
+		}
+		path := filepath.Join(directory, entry.Name())
+
+		info, err := entry.Info()
+		if err != nil {
+			return nil, fmt.Errorf("getting information of key file %s: %w", entry.Name(), err)
+		}
+		if info.Mode().Perm()&0o077 != 0 {
+			problems = append(problems, Problem{
+				Severity: SeverityWarning,
+				Message:  fmt.Sprintf("key file %s is accessible by other users with mode %s", path, info.Mode().Perm()),
+				Hint:     fmt.Sprintf("restrict the access with chmod 600 %s", path),
+			})
+		}
+
+		publicKey, err := readKeyFile(path)
+		if err != nil {
+			problems = append(problems, Problem{
+				Severity: SeverityCritical,
+				Message:  fmt.Sprintf("key file %s cannot be loaded: %s", path, err),
+				Hint:     "restore the key file from a backup, or import the key again with gossamer account import",
+			})
+			continue
+		}
+
+		expectedName := strings.TrimPrefix(publicKey, "0x") + ".key"
+		if entry.Name() != expectedName {
+			problems = append(problems, Problem{
+				Severity: SeverityWarning,
+				Message:  fmt.Sprintf("key file %s is not named after its public key %s", path, publicKey),
+				Hint:     fmt.Sprintf("rename the key file to %s", expectedName),
+			})
+		}
+	}
+
+	return problems, nil
+}
+
+// readKeyFile decodes the key file and its public key, and returns the public key.
+func readKeyFile(path string) (publicKey string, err error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return "", fmt.Errorf("reading: %w", err)
+	}
+
+	var encrypted keystore.EncryptedKeystore
+	err = json.Unmarshal(data, &encrypted)
+	if err != nil {
+		return "", fmt.Errorf("decoding: %w", err)
+	}
+	if len(encrypted.Ciphertext) == 0 {
+		return "", errNoCiphertext
+	}
+
+	encodedPublicKey, err := common.HexToBytes(encrypted.PublicKey)
+	if err != nil {
+		return "", fmt.Errorf("decoding public key: %w", err)
+	}
+
+	switch encrypted.Type {
+	case crypto.Sr25519Type:
+		_, err = sr25519.NewPublicKey(encodedPublicKey)
+	case crypto.Ed25519Type:
+		_, err = ed25519.NewPublicKey(encodedPublicKey)
+	case crypto.Secp256k1Type:
+		err = new(secp256k1.PublicKey).Decode(encodedPublicKey)
+	default:
+		return "", fmt.Errorf("%w: %q", errUnknownKeyType, encrypted.Type)
+	}
+	if err != nil {
+		return "", fmt.Errorf("decoding %s public key: %w", encrypted.Type, err)
+	}
+
+	return encrypted.PublicKey, nil
+}
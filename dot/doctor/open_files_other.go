@@ -0,0 +1,13 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+//go:build !unix
+
+package doctor
+
+import "context"
+
+// checkOpenFiles does nothing, since the limit of open file descriptors is specific to unix.
+func checkOpenFiles(context.Context, Config) ([]Problem, error) {
+	return nil, nil
+}
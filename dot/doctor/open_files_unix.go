@@ -0,0 +1,33 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+//go:build unix
+
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"syscall"
+)
+
+// checkOpenFiles checks the limit of open file descriptors of the process is high
+// enough for the database files and the peer connections.
+func checkOpenFiles(_ context.Context, config Config) ([]Problem, error) {
+	var limit syscall.Rlimit
+	err := syscall.Getrlimit(syscall.RLIMIT_NOFILE, &limit)
+	if err != nil {
+		return nil, fmt.Errorf("getting open files limit: %w", err)
+	}
+
+	if uint64(limit.Cur) >= config.MinOpenFiles {
+		return nil, nil
+	}
+
+	return []Problem{{
+		Severity: SeverityWarning,
+		Message: fmt.Sprintf("open files limit %d is lower than %d, the node may run out of file descriptors",
+			limit.Cur, config.MinOpenFiles),
+		Hint: fmt.Sprintf("raise the limit with ulimit -n %d, or LimitNOFILE with systemd", config.MinOpenFiles),
+	}}, nil
+}
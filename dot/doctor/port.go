@@ -0,0 +1,90 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package doctor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+)
+
+var errProbeStatus = errors.New("probe responded with unexpected status")
+
+// probeResponse is the response of the probe service.
+type probeResponse struct {
+	Reachable bool `json:"reachable"`
+}
+
+// checkPort checks the P2P port is reachable from the internet, so that other
+// peers can connect to the node. If the port is free, it is listened on during
+// the check, and otherwise it is assumed to be listened on by a running node.
+func checkPort(ctx context.Context, config Config) ([]Problem, error) {
+	if config.ProbeURL == "" {
+		return nil, nil
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", config.P2PPort))
+	if err == nil {
+		defer listener.Close()
+		go acceptAndClose(listener)
+	}
+
+	reachable, err := probePort(ctx, config.ProbeURL, config.P2PPort)
+	if err != nil {
+		return nil, err
+	}
+
+	if reachable {
+		return nil, nil
+	}
+
+	return []Problem{{
+		Severity: SeverityWarning,
+		Message: fmt.Sprintf("P2P port %d is not reachable from the internet, peers cannot connect to the node",
+			config.P2PPort),
+		Hint: "open the port in the firewall and forward it from the router",
+	}}, nil
+}
+
+// acceptAndClose closes the connections accepted by the listener, until the
+// listener is closed.
+func acceptAndClose(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		_ = conn.Close()
+	}
+}
+
+// probePort asks the probe service whether the TCP port of this host is reachable.
+func probePort(ctx context.Context, probeURL string, port uint16) (reachable bool, err error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, probeURL+strconv.Itoa(int(port)), nil)
+	if err != nil {
+		return false, fmt.Errorf("creating probe request: %w", err)
+	}
+	request.Header.Set("Accept", "application/json")
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return false, fmt.Errorf("requesting probe: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("%w: %s", errProbeStatus, response.Status)
+	}
+
+	var decoded probeResponse
+	err = json.NewDecoder(response.Body).Decode(&decoded)
+	if err != nil {
+		return false, fmt.Errorf("decoding probe response: %w", err)
+	}
+	return decoded.Reachable, nil
+}
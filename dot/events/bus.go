@@ -0,0 +1,44 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package events
+
+import (
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// RoundCompleted is the event published when a GRANDPA round is completed.
+type RoundCompleted struct {
+	Round uint64
+	SetID uint64
+	// Finalised is the hash of the highest finalised block once the round completed.
+	Finalised common.Hash
+}
+
+// Bus is the intra-node event bus, grouping the topics of the events shared
+// between the node services.
+type Bus struct {
+	// BlockImported delivers the blocks added to the block tree.
+	BlockImported *Topic[*types.Block]
+	// BestBlockChanged delivers the header of the new best block.
+	BestBlockChanged *Topic[*types.Header]
+	// BlockFinalised delivers the finalisation information of the finalised blocks.
+	BlockFinalised *Topic[*types.FinalisationInfo]
+	// PeerConnected delivers the ID of the peers connected.
+	PeerConnected *Topic[peer.ID]
+	// RoundCompleted delivers the GRANDPA rounds completed.
+	RoundCompleted *Topic[RoundCompleted]
+}
+
+// NewBus creates an event bus.
+func NewBus() *Bus {
+	return &Bus{
+		BlockImported:    NewTopic[*types.Block]("block_imported"),
+		BestBlockChanged: NewTopic[*types.Header]("best_block_changed"),
+		BlockFinalised:   NewTopic[*types.FinalisationInfo]("block_finalised"),
+		PeerConnected:    NewTopic[peer.ID]("peer_connected"),
+		RoundCompleted:   NewTopic[RoundCompleted]("round_completed"),
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package events
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var droppedEvents = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "gossamer_events",
+	Name:      "dropped_total",
+	Help:      "total number of events dropped because of a full subscriber queue, by topic",
+}, []string{"topic"})
+
+// DropPolicy is the policy applied when an event is published to a
+// subscriber whose queue is full.
+type DropPolicy uint8
+
+const (
+	// DropNewest drops the published event, keeping the queued events.
+	DropNewest DropPolicy = iota
+	// DropOldest drops the oldest queued event to make room for the published event.
+	DropOldest
+)
+
+// Topic is a typed event stream delivering each published event to all its
+// subscribers, without ever blocking the publisher.
+type Topic[T any] struct {
+	name        string
+	mtx         sync.RWMutex
+	subscribers map[*Subscription[T]]struct{}
+	dropped     prometheus.Counter
+}
+
+// NewTopic creates a topic with the given name, used to label its metrics.
+func NewTopic[T any](name string) *Topic[T] {
+	return &Topic[T]{
+		name:        name,
+		subscribers: make(map[*Subscription[T]]struct{}),
+		dropped:     droppedEvents.WithLabelValues(name),
+	}
+}
+
+// Subscribe subscribes to the topic with a queue of the given capacity and the
+// drop policy applied when the queue is full.
+func (t *Topic[T]) Subscribe(capacity int, policy DropPolicy) *Subscription[T] {
+	return t.SubscribeChannel(make(chan T, capacity), policy)
+}
+
+// SubscribeChannel subscribes to the topic using the given channel as queue,
+// with the drop policy applied when the channel is full.
+func (t *Topic[T]) SubscribeChannel(ch chan T, policy DropPolicy) *Subscription[T] {
+	subscription := &Subscription[T]{
+		topic:  t,
+		queue:  ch,
+		policy: policy,
+	}
+
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	t.subscribers[subscription] = struct{}{}
+	return subscription
+}
+
+// HasSubscribers returns true if the topic has at least one subscriber.
+func (t *Topic[T]) HasSubscribers() bool {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+	return len(t.subscribers) > 0
+}
+
+// Publish delivers the event to all the subscribers of the topic.
+func (t *Topic[T]) Publish(event T) {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+
+	for subscription := range t.subscribers {
+		if !subscription.deliver(event) {
+			t.dropped.Inc()
+		}
+	}
+}
+
+func (t *Topic[T]) unsubscribe(subscription *Subscription[T]) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	delete(t.subscribers, subscription)
+}
+
+// Subscription is a subscription to a topic.
+type Subscription[T any] struct {
+	topic   *Topic[T]
+	queue   chan T
+	policy  DropPolicy
+	dropped atomic.Uint64
+	// deliverMtx serialises the deliveries to the queue, so the oldest
+	// event dropped is the one the published event replaces.
+	deliverMtx sync.Mutex
+}
+
+// Events returns the channel the events of the topic are delivered to.
+func (s *Subscription[T]) Events() <-chan T {
+	return s.queue
+}
+
+// Dropped returns the number of events dropped for this subscription.
+func (s *Subscription[T]) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// Unsubscribe stops the delivery of the events to the subscription.
+// The events channel is not closed, since it may still be read by the subscriber.
+func (s *Subscription[T]) Unsubscribe() {
+	s.topic.unsubscribe(s)
+}
+
+// deliver queues the event according to the drop policy of the subscription,
+// and returns false if an event was dropped.
+func (s *Subscription[T]) deliver(event T) (delivered bool) {
+	s.deliverMtx.Lock()
+	defer s.deliverMtx.Unlock()
+
+	select {
+	case s.queue <- event:
+		return true
+	default:
+	}
+
+	s.dropped.Add(1)
+	if s.policy == DropNewest {
+		return false
+	}
+
+	// the subscriber may have emptied the queue meanwhile
+	select {
+	case <-s.queue:
+	default:
+	}
+
+	select {
+	case s.queue <- event:
+	default:
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func drain[T any](subscription *Subscription[T]) (events []T) {
+	for {
+		select {
+		case event := <-subscription.Events():
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func Test_Topic_Publish(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		policy  DropPolicy
+		events  []int
+		dropped uint64
+	}{
+		"drop_newest": {
+			policy:  DropNewest,
+			events:  []int{1, 2},
+			dropped: 2,
+		},
+		"drop_oldest": {
+			policy:  DropOldest,
+			events:  []int{3, 4},
+			dropped: 2,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			topic := NewTopic[int]("test")
+			subscription := topic.Subscribe(2, testCase.policy)
+			// a slow subscriber does not affect the other subscribers
+			other := topic.Subscribe(4, DropNewest)
+
+			for event := 1; event <= 4; event++ {
+				topic.Publish(event)
+			}
+
+			assert.Equal(t, testCase.events, drain(subscription))
+			assert.Equal(t, testCase.dropped, subscription.Dropped())
+			assert.Equal(t, []int{1, 2, 3, 4}, drain(other))
+			assert.Zero(t, other.Dropped())
+		})
+	}
+}
+
+func Test_Subscription_Unsubscribe(t *testing.T) {
+	t.Parallel()
+
+	topic := NewTopic[int]("test")
+	assert.False(t, topic.HasSubscribers())
+
+	ch := make(chan int, 1)
+	subscription := topic.SubscribeChannel(ch, DropNewest)
+	assert.True(t, topic.HasSubscribers())
+
+	topic.Publish(1)
+	subscription.Unsubscribe()
+	topic.Publish(2)
+
+	assert.False(t, topic.HasSubscribers())
+	assert.Equal(t, []int{1}, drain(subscription))
+	assert.Equal(t, 1, cap(ch))
+}
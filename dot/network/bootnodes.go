@@ -0,0 +1,201 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	libp2pnetwork "github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	// bootnodesEndpointTimeout is the timeout of the bootnodes endpoint requests
+	bootnodesEndpointTimeout = 30 * time.Second
+	// maxBootnodesListSize is the maximum size of the bootnodes list fetched
+	maxBootnodesListSize = 1 << 20
+)
+
+var (
+	errBootnodesEndpointStatus = errors.New("unexpected bootnodes endpoint status")
+	errBootnodesSignature      = errors.New("invalid bootnodes list signature")
+)
+
+var (
+	bootnodesAliveGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "gossamer_network_bootnodes",
+		Name:      "alive",
+		Help:      "number of bootnodes reachable at the last liveness check",
+	})
+	bootnodesDeadGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "gossamer_network_bootnodes",
+		Name:      "dead",
+		Help:      "number of bootnodes unreachable at the last liveness check",
+	})
+	bootnodesFetchFailures = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "gossamer_network_bootnodes",
+		Name:      "endpoint_failures_total",
+		Help:      "total number of failures to fetch the bootnodes list from the bootnodes endpoint",
+	})
+)
+
+// signedBootnodes is the bootnodes list served by the bootnodes endpoint.
+// The signature is the ed25519 signature of the bootnodes joined with new lines.
+type signedBootnodes struct {
+	Bootnodes []string `json:"bootNodes"`
+	Signature string   `json:"signature"`
+}
+
+// bootnodeChecker probes the bootnodes at startup and periodically, reporting
+// the dead ones, and replaces them with the bootnodes list fetched from the
+// bootnodes endpoint once all of them are dead.
+type bootnodeChecker struct {
+	host      *host
+	interval  time.Duration
+	bootnodes []peer.AddrInfo
+
+	// bootnodes endpoint, disabled if the url is empty
+	endpointURL string
+	endpointKey []byte
+	client      *http.Client
+}
+
+func newBootnodeChecker(h *host, interval time.Duration, endpointURL, endpointKey string) (
+	*bootnodeChecker, error) {
+	checker := &bootnodeChecker{
+		host:        h,
+		interval:    interval,
+		bootnodes:   h.bootnodes,
+		endpointURL: endpointURL,
+		client:      &http.Client{Timeout: bootnodesEndpointTimeout},
+	}
+
+	if endpointURL != "" {
+		key, err := common.HexToBytes(endpointKey)
+		if err != nil {
+			return nil, fmt.Errorf("decoding bootnodes endpoint public key: %w", err)
+		}
+		checker.endpointKey = key
+	}
+
+	return checker, nil
+}
+
+// run checks the bootnodes until the context is canceled.
+func (c *bootnodeChecker) run(ctx context.Context) {
+	c.check(ctx)
+
+	if c.interval == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.check(ctx)
+		}
+	}
+}
+
+// check probes the bootnodes and rotates them if all of them are dead.
+func (c *bootnodeChecker) check(ctx context.Context) {
+	alive := c.probe()
+	if alive > 0 || c.endpointURL == "" {
+		return
+	}
+
+	logger.Warnf("all %d bootnodes are dead, fetching bootnodes from %s", len(c.bootnodes), c.endpointURL)
+	bootnodes, err := c.fetch(ctx)
+	if err != nil {
+		bootnodesFetchFailures.Inc()
+		logger.Errorf("fetching bootnodes from %s: %s", c.endpointURL, err)
+		return
+	}
+
+	c.bootnodes = bootnodes
+	for _, addrInfo := range bootnodes {
+		c.host.p2pHost.Peerstore().AddAddrs(addrInfo.ID, addrInfo.Addrs, peerstore.PermanentAddrTTL)
+		c.host.cm.peerSetHandler.AddPeer(0, addrInfo.ID)
+	}
+	logger.Infof("rotated to %d bootnodes fetched from %s", len(bootnodes), c.endpointURL)
+
+	c.probe()
+}
+
+// probe connects to the bootnodes not connected yet, and returns the number of
+// bootnodes reachable.
+func (c *bootnodeChecker) probe() (alive int) {
+	for _, addrInfo := range c.bootnodes {
+		if c.host.p2pHost.Network().Connectedness(addrInfo.ID) == libp2pnetwork.Connected {
+			alive++
+			continue
+		}
+
+		err := c.host.connect(addrInfo)
+		if err != nil {
+			logger.Warnf("bootnode %s is unreachable: %s", addrInfo.ID, err)
+			continue
+		}
+		alive++
+	}
+
+	bootnodesAliveGauge.Set(float64(alive))
+	bootnodesDeadGauge.Set(float64(len(c.bootnodes) - alive))
+	logger.Debugf("%d of %d bootnodes are alive", alive, len(c.bootnodes))
+	return alive
+}
+
+// fetch fetches the bootnodes list from the bootnodes endpoint and verifies its signature.
+func (c *bootnodeChecker) fetch(ctx context.Context) (bootnodes []peer.AddrInfo, err error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, c.endpointURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	response, err := c.client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("sending request: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %s", errBootnodesEndpointStatus, response.Status)
+	}
+
+	var signed signedBootnodes
+	err = json.NewDecoder(io.LimitReader(response.Body, maxBootnodesListSize)).Decode(&signed)
+	if err != nil {
+		return nil, fmt.Errorf("decoding bootnodes list: %w", err)
+	}
+
+	signature, err := common.HexToBytes(signed.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("decoding signature: %w", err)
+	}
+
+	message := []byte(strings.Join(signed.Bootnodes, "\n"))
+	err = ed25519.VerifySignature(c.endpointKey, signature, message)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errBootnodesSignature, err)
+	}
+
+	return stringsToAddrInfos(signed.Bootnodes)
+}
@@ -0,0 +1,90 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_bootnodeChecker_fetch(t *testing.T) {
+	t.Parallel()
+
+	keypair, err := ed25519.GenerateKeypair()
+	require.NoError(t, err)
+	otherKeypair, err := ed25519.GenerateKeypair()
+	require.NoError(t, err)
+
+	bootnodes := []string{
+		"/ip4/127.0.0.1/tcp/7001/p2p/12D3KooWHHzSeKaY8xuZVzkLbKFfvNgPPeKhFBGrMbNzbm5akpqu",
+		"/ip4/127.0.0.1/tcp/7002/p2p/12D3KooWPHWFrfaJzxPnqnAYAoRUyAHHKYACmEJCoSdSn6Jb2Vbc",
+	}
+	message := []byte(strings.Join(bootnodes, "\n"))
+
+	signature, err := keypair.Sign(message)
+	require.NoError(t, err)
+	otherSignature, err := otherKeypair.Sign(message)
+	require.NoError(t, err)
+
+	expectedBootnodes, err := stringsToAddrInfos(bootnodes)
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		status     int
+		signature  []byte
+		errWrapped error
+	}{
+		"valid_signature": {
+			status:    http.StatusOK,
+			signature: signature,
+		},
+		"invalid_signature": {
+			status:     http.StatusOK,
+			signature:  otherSignature,
+			errWrapped: errBootnodesSignature,
+		},
+		"bad_status": {
+			status:     http.StatusNotFound,
+			errWrapped: errBootnodesEndpointStatus,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(testCase.status)
+				_ = json.NewEncoder(w).Encode(signedBootnodes{
+					Bootnodes: bootnodes,
+					Signature: common.BytesToHex(testCase.signature),
+				})
+			}))
+			defer server.Close()
+
+			checker := &bootnodeChecker{
+				endpointURL: server.URL,
+				endpointKey: keypair.Public().Encode(),
+				client:      server.Client(),
+			}
+
+			fetched, err := checker.fetch(context.Background())
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped == nil {
+				assert.Equal(t, expectedBootnodes, fetched)
+			}
+		})
+	}
+}
@@ -13,6 +13,7 @@
 	"github.com/adrg/xdg"
 	"github.com/libp2p/go-libp2p/core/crypto"
 
+	"github.com/ChainSafe/gossamer/dot/events"
 	"github.com/ChainSafe/gossamer/dot/network/ratelimiters"
 	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/internal/metrics"
@@ -69,6 +70,9 @@ type Config struct {
 	Syncer             Syncer
 	WarpSyncProvider   WarpSyncProvider
 	TransactionHandler TransactionHandler
+	// KeyChangesProvider serves the key changes requests of the light protocol,
+	// which are refused if nil.
+	KeyChangesProvider KeyChangesProvider
 
 	// Used to specify the address broadcasted to other peers, and avoids using pubip.Get
 	PublicIP string
@@ -81,6 +85,15 @@ type Config struct {
 	RandSeed int64
 	// Bootnodes the peer addresses used for bootstrapping
 	Bootnodes []string
+	// BootnodeCheckInterval is the interval between two bootnode liveness checks,
+	// the bootnodes being only checked at startup if zero.
+	BootnodeCheckInterval time.Duration
+	// BootnodesEndpoint is the HTTPS endpoint serving the signed bootnodes list
+	// fetched once all the bootnodes are dead, disabled if empty.
+	BootnodesEndpoint string
+	// BootnodesEndpointKey is the hex encoded ed25519 public key verifying the
+	// signature of the bootnodes list.
+	BootnodesEndpointKey string
 	// ProtocolID the protocol ID for network messages
 	ProtocolID string
 	// NoBootstrap disables bootstrapping
@@ -101,6 +114,10 @@ type Config struct {
 	// NodeKey is the private hex encoded Ed25519 key to build the p2p identity
 	NodeKey string
 
+	// FuzzCorpusDir is the directory the inbound protocol messages are recorded
+	// into as a fuzz corpus. The capture mode is disabled if it is empty.
+	FuzzCorpusDir string
+
 	// privateKey the private key for the network p2p identity
 	privateKey crypto.PrivKey
 
@@ -115,8 +132,12 @@ type Config struct {
 	Telemetry Telemetry
 	Metrics   metrics.IntervalConfig
 
+	// Events is the event bus the peer connections are published to, if not nil.
+	Events *events.Bus
+
 	// Spam limiters configuration
-	warpSyncSpamLimiter RateLimiter
+	warpSyncSpamLimiter   RateLimiter
+	keyChangesSpamLimiter RateLimiter
 }
 
 // build checks the configuration, sets up the private key for the network service,
@@ -166,6 +187,14 @@ func (c *Config) build() error {
 		)
 	}
 
+	// set key changes spam limiter to default
+	if c.keyChangesSpamLimiter == nil {
+		c.keyChangesSpamLimiter = ratelimiters.NewSlidingWindowRateLimiter(
+			maxKeyChangesRequestsPerPeer,
+			keyChangesRequestsWindow,
+		)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,145 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/network/messages"
+)
+
+// maxFuzzCorpusEntries is the maximum number of corpus entries captured per
+// fuzz target, so a node left in capture mode does not fill its disk.
+const maxFuzzCorpusEntries = 1000
+
+// fuzzCapture records the inbound protocol messages into a fuzz corpus. Each
+// message is written to the corpus directory of the fuzz target of its decoder,
+// in the Go fuzzing corpus format, so the directories can be copied to the
+// testdata/fuzz directory of the package defining the fuzz target.
+//
+// Only the message bytes are recorded: the entries are named after the hash
+// of their content and have their times zeroed, so the corpus does not tell
+// which peer sent a message, nor when or in which order it was received.
+// A nil fuzzCapture is disabled.
+type fuzzCapture struct {
+	mtx     sync.Mutex
+	dir     string
+	entries map[string]int
+}
+
+func newFuzzCapture(dir string) *fuzzCapture {
+	if dir == "" {
+		return nil
+	}
+	return &fuzzCapture{
+		dir:     dir,
+		entries: make(map[string]int),
+	}
+}
+
+// fuzzTarget returns the name of the fuzz target of the decoder of the
+// message, or an empty string if no fuzz target covers it.
+func fuzzTarget(msg messages.P2PMessage) string {
+	switch msg.(type) {
+	case *BlockAnnounceMessage:
+		return "FuzzDecodeBlockAnnounceMessage"
+	case *BlockAnnounceHandshake:
+		return "FuzzDecodeBlockAnnounceHandshake"
+	case *TransactionMessage:
+		return "FuzzDecodeTransactionMessage"
+	case *ConsensusMessage:
+		// the consensus messages are GRANDPA messages decoded by lib/grandpa
+		return "FuzzDecodeConsensusMessage"
+	case *messages.BlockRequestMessage:
+		return "FuzzDecodeBlockRequestMessage"
+	case *messages.BlockResponseMessage:
+		return "FuzzDecodeBlockResponseMessage"
+	default:
+		return ""
+	}
+}
+
+// capture records the encoded message decoded into msg.
+func (c *fuzzCapture) capture(msg messages.P2PMessage, encoded []byte) {
+	if c == nil {
+		return
+	}
+
+	target := fuzzTarget(msg)
+	if target == "" {
+		return
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	err := c.write(target, encoded)
+	if err != nil {
+		logger.Warnf("capturing fuzz corpus entry for %s: %s", target, err)
+	}
+}
+
+func (c *fuzzCapture) write(target string, encoded []byte) error {
+	dir := filepath.Join(c.dir, target)
+	count, ok := c.entries[target]
+	if !ok {
+		err := os.MkdirAll(dir, 0o700)
+		if err != nil {
+			return fmt.Errorf("creating corpus directory: %w", err)
+		}
+
+		dirEntries, err := os.ReadDir(dir)
+		if err != nil {
+			return fmt.Errorf("reading corpus directory: %w", err)
+		}
+		count = len(dirEntries)
+		c.entries[target] = count
+	}
+
+	if count >= maxFuzzCorpusEntries {
+		return nil
+	}
+
+	sum := sha256.Sum256(encoded)
+	path := filepath.Join(dir, hex.EncodeToString(sum[:])[:16])
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if errors.Is(err, fs.ErrExist) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("creating corpus entry: %w", err)
+	}
+
+	_, err = file.WriteString(marshalFuzzCorpusEntry(encoded))
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("writing corpus entry: %w", err)
+	}
+
+	err = file.Close()
+	if err != nil {
+		return fmt.Errorf("closing corpus entry: %w", err)
+	}
+	c.entries[target]++
+
+	err = os.Chtimes(path, time.Unix(0, 0), time.Unix(0, 0))
+	if err != nil {
+		return fmt.Errorf("zeroing corpus entry times: %w", err)
+	}
+	return nil
+}
+
+// marshalFuzzCorpusEntry returns the Go fuzzing corpus file of a fuzz target
+// taking a single []byte argument.
+func marshalFuzzCorpusEntry(data []byte) string {
+	return "go test fuzz v1\n[]byte(" + strconv.Quote(string(data)) + ")\n"
+}
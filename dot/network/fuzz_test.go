@@ -0,0 +1,154 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/network/messages"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The fuzz targets below are named after the corpus directories written by
+// the --fuzz-corpus-dir capture mode, so a captured corpus can be copied to
+// testdata/fuzz to seed them. A successfully decoded message must encode.
+
+func addEncodedSeed(f *testing.F, msg messages.P2PMessage) {
+	f.Helper()
+	encoded, err := msg.Encode()
+	require.NoError(f, err)
+	f.Add(encoded)
+}
+
+func FuzzDecodeBlockAnnounceMessage(f *testing.F) {
+	digest := types.NewDigest()
+	err := digest.Add(types.PreRuntimeDigest{
+		ConsensusEngineID: types.BabeEngineID,
+		Data:              []byte{1, 2, 3, 4},
+	})
+	require.NoError(f, err)
+	addEncodedSeed(f, &BlockAnnounceMessage{
+		ParentHash: common.Hash{1},
+		Number:     2,
+		Digest:     digest,
+		BestBlock:  true,
+	})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		msg, err := decodeBlockAnnounceMessage(data)
+		if err != nil {
+			return
+		}
+		_, err = msg.Encode()
+		require.NoError(t, err)
+	})
+}
+
+func FuzzDecodeBlockAnnounceHandshake(f *testing.F) {
+	addEncodedSeed(f, &BlockAnnounceHandshake{
+		Roles:           common.FullNodeRole,
+		BestBlockNumber: 1,
+		BestBlockHash:   common.Hash{1},
+		GenesisHash:     common.Hash{2},
+	})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		hs, err := decodeBlockAnnounceHandshake(data)
+		if err != nil {
+			return
+		}
+		_, err = hs.Encode()
+		require.NoError(t, err)
+	})
+}
+
+func FuzzDecodeTransactionMessage(f *testing.F) {
+	addEncodedSeed(f, &TransactionMessage{
+		Extrinsics: []types.Extrinsic{{1, 2, 3}, {4, 5}},
+	})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		msg, err := decodeTransactionMessage(data)
+		if err != nil {
+			return
+		}
+		_, err = msg.Encode()
+		require.NoError(t, err)
+	})
+}
+
+func FuzzDecodeBlockRequestMessage(f *testing.F) {
+	addEncodedSeed(f, messages.NewBlockRequest(
+		*messages.NewFromBlock(uint(1)), 16, messages.BootstrapRequestData, messages.Ascending))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		msg, err := decodeSyncMessage(data, "", true)
+		if err != nil {
+			return
+		}
+		_, err = msg.Encode()
+		require.NoError(t, err)
+	})
+}
+
+func FuzzDecodeBlockResponseMessage(f *testing.F) {
+	header := types.NewHeader(common.Hash{1}, common.Hash{2}, common.Hash{3}, 4, nil)
+	body := types.NewBody([]types.Extrinsic{{1, 2, 3}})
+	addEncodedSeed(f, &messages.BlockResponseMessage{
+		BlockData: []*types.BlockData{{
+			Hash:   header.Hash(),
+			Header: header,
+			Body:   body,
+		}},
+	})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		msg := new(messages.BlockResponseMessage)
+		err := msg.Decode(data)
+		if err != nil {
+			return
+		}
+		_, err = msg.Encode()
+		require.NoError(t, err)
+	})
+}
+
+func Test_fuzzCapture(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	capture := newFuzzCapture(dir)
+
+	capture.capture(&TransactionMessage{}, []byte{1, 2})
+	capture.capture(&TransactionMessage{}, []byte{1, 2})
+	capture.capture(&ConsensusMessage{}, []byte("\x00\"a"))
+	capture.capture(&messages.StateRequest{}, []byte{3})
+
+	transactions, err := os.ReadDir(filepath.Join(dir, "FuzzDecodeTransactionMessage"))
+	require.NoError(t, err)
+	require.Len(t, transactions, 1)
+
+	consensus, err := os.ReadDir(filepath.Join(dir, "FuzzDecodeConsensusMessage"))
+	require.NoError(t, err)
+	require.Len(t, consensus, 1)
+	entry, err := os.ReadFile(filepath.Join(dir, "FuzzDecodeConsensusMessage", consensus[0].Name()))
+	require.NoError(t, err)
+	assert.Equal(t, "go test fuzz v1\n[]byte(\"\\x00\\\"a\")\n", string(entry))
+
+	info, err := consensus[0].Info()
+	require.NoError(t, err)
+	assert.Zero(t, info.ModTime().Unix())
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+
+	var disabled *fuzzCapture
+	disabled.capture(&TransactionMessage{}, []byte{1})
+}
@@ -82,8 +82,15 @@ type host struct {
 	ds              *badger.Datastore
 	messageCache    *messageCache
 	bwc             *metrics.BandwidthCounter
+	peerStats       *peerStats
+	lanes           *priorityLanes
 	closeSync       sync.Once
 	externalAddr    ma.Multiaddr
+
+	// handlers are the stream handlers registered on the host, so they are
+	// registered again on the host replacing it when the identity is rotated.
+	handlers   map[protocol.ID]network.StreamHandler
+	handlersMu sync.Mutex
 }
 
 func newHost(ctx context.Context, cfg *Config) (*host, error) {
@@ -277,7 +284,10 @@ func newHost(ctx context.Context, cfg *Config) (*host, error) {
 		persistentPeers: pps,
 		messageCache:    msgCache,
 		bwc:             bwc,
+		peerStats:       newPeerStats(),
+		lanes:           newPriorityLanes(),
 		externalAddr:    externalAddr,
+		handlers:        make(map[protocol.ID]network.StreamHandler),
 	}
 
 	cm.host = host
@@ -318,9 +328,23 @@ func (h *host) close() error {
 
 // registerStreamHandler registers the stream handler for the given protocol id.
 func (h *host) registerStreamHandler(pid protocol.ID, handler func(network.Stream)) {
+	h.handlersMu.Lock()
+	defer h.handlersMu.Unlock()
+	h.handlers[pid] = handler
 	h.p2pHost.SetStreamHandler(pid, handler)
 }
 
+// streamHandlers returns a copy of the stream handlers registered on the host.
+func (h *host) streamHandlers() map[protocol.ID]network.StreamHandler {
+	h.handlersMu.Lock()
+	defer h.handlersMu.Unlock()
+	handlers := make(map[protocol.ID]network.StreamHandler, len(h.handlers))
+	for pid, handler := range h.handlers {
+		handlers[pid] = handler
+	}
+	return handlers
+}
+
 // connect connects the host to a specific peer address
 func (h *host) connect(p peer.AddrInfo) (err error) {
 	h.p2pHost.Peerstore().AddAddrs(p.ID, p.Addrs, peerstore.PermanentAddrTTL)
@@ -380,7 +404,8 @@ func (h *host) writeToStream(s network.Stream, msg messages.P2PMessage) error {
 	lenBytes := Uint64ToLEB128(msgLen)
 	encMsg = append(lenBytes, encMsg...)
 
-	sent, err := s.Write(encMsg)
+	remotePeer := s.Conn().RemotePeer()
+	sent, err := h.lanes.write(remotePeer, s, messageLane(msg), encMsg)
 	if err != nil {
 		return err
 	}
@@ -390,6 +415,7 @@ func (h *host) writeToStream(s network.Stream, msg messages.P2PMessage) error {
 	}
 
 	h.bwc.LogSentMessage(int64(sent))
+	h.peerStats.logSent(remotePeer, s.Protocol(), sent)
 
 	return nil
 }
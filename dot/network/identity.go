@@ -0,0 +1,145 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+)
+
+// drainPollInterval is the interval at which the request streams are checked
+// while they are drained before the identity is rotated.
+const drainPollInterval = 100 * time.Millisecond
+
+var (
+	// ErrIdentityNotRotatable is returned by RotateIdentity when the p2p identity is
+	// given by the node key option or the random seed rather than the key file.
+	ErrIdentityNotRotatable = errors.New("p2p identity not loaded from key file")
+	// ErrServiceStopped is returned by RotateIdentity when the network service is stopped.
+	ErrServiceStopped = errors.New("network service stopped")
+)
+
+// RotateIdentity replaces the p2p identity of the node with a new random key, for
+// when the key is suspected to be compromised, and returns the new peer ID.
+//
+// The new key replaces the key file first, so the node keeps the new identity if
+// it stops during the rotation. The host then stops accepting requests and gives
+// the request streams in flight the drain timeout to complete, before it is closed
+// and replaced by a host with the new identity, which announces itself on the DHT
+// and connects to the bootnodes and peers again. The network service is stopped
+// during the rotation, and stays stopped if the new host cannot be started.
+func (s *Service) RotateIdentity(drainTimeout time.Duration) (peerID string, err error) {
+	if s.cfg.NodeKey != "" || s.cfg.RandSeed != 0 {
+		return "", ErrIdentityNotRotatable
+	}
+
+	s.rotationMu.Lock()
+	defer s.rotationMu.Unlock()
+
+	if s.IsStopped() {
+		return "", ErrServiceStopped
+	}
+
+	key, err := generateKey(0, s.cfg.BasePath)
+	if err != nil {
+		return "", fmt.Errorf("generating key: %w", err)
+	}
+
+	previousID := s.host.id()
+	handlers := s.host.streamHandlers()
+	requestProtocols := s.requestProtocols()
+	for _, pid := range requestProtocols {
+		s.host.p2pHost.RemoveStreamHandler(pid)
+	}
+	open := s.drainStreams(requestProtocols, drainTimeout)
+	if open > 0 {
+		logger.Warnf("closing %d request streams still open after %s", open, drainTimeout)
+	}
+
+	err = s.Stop()
+	if err != nil {
+		return "", fmt.Errorf("stopping network service: %w", err)
+	}
+	s.routines.Wait()
+
+	s.cfg.privateKey = key
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	host, err := newHost(s.ctx, s.cfg)
+	if err != nil {
+		s.cancel()
+		return "", fmt.Errorf("creating host: %w", err)
+	}
+	for pid, handler := range handlers {
+		host.registerStreamHandler(pid, handler)
+	}
+
+	bootnodeChecker, err := newBootnodeChecker(host, s.cfg.BootnodeCheckInterval,
+		s.cfg.BootnodesEndpoint, s.cfg.BootnodesEndpointKey)
+	if err != nil {
+		s.cancel()
+		return "", fmt.Errorf("creating bootnode checker: %w", err)
+	}
+
+	s.notificationsMu.Lock()
+	for _, prtl := range s.notificationsProtocols {
+		prtl.peersData = newPeersData()
+	}
+	s.notificationsMu.Unlock()
+	s.lightRequestMu.Lock()
+	s.lightRequest = make(map[peer.ID]struct{})
+	s.lightRequestMu.Unlock()
+
+	s.host = host
+	s.mdns = newMDNS(host)
+	s.bootnodeChecker = bootnodeChecker
+	s.streamManager = newStreamManager(s.ctx)
+	s.closeCh = make(chan struct{})
+
+	err = s.startHost()
+	if err != nil {
+		return "", fmt.Errorf("starting host: %w", err)
+	}
+
+	logger.Infof("rotated p2p identity from %s to %s", previousID, host.id())
+	return host.id().String(), nil
+}
+
+// requestProtocols returns the request-response protocols served by the host.
+func (s *Service) requestProtocols() []protocol.ID {
+	genesisHashProtocolID := protocol.ID(s.cfg.BlockState.GenesisHash().String())
+	return []protocol.ID{
+		s.host.protocolID + SyncID,
+		s.host.protocolID + lightID,
+		genesisHashProtocolID + WarpSyncID,
+	}
+}
+
+// drainStreams waits for the streams of the given protocols open on the host to be
+// closed, for at most the timeout, and returns the number of streams still open.
+func (s *Service) drainStreams(protocols []protocol.ID, timeout time.Duration) (open int) {
+	deadline := time.Now().Add(timeout)
+	for {
+		open = 0
+		for _, conn := range s.host.p2pHost.Network().Conns() {
+			for _, stream := range conn.GetStreams() {
+				for _, pid := range protocols {
+					if stream.Protocol() == pid {
+						open++
+						break
+					}
+				}
+			}
+		}
+
+		if open == 0 || !time.Now().Before(deadline) {
+			return open
+		}
+		time.Sleep(drainPollInterval)
+	}
+}
@@ -29,6 +29,7 @@ func (s *Service) readStream(stream libp2pnetwork.Stream, decoder messageDecoder
 		}
 
 		s.streamManager.logMessageReceived(stream.ID())
+		s.host.peerStats.logReceived(peer, stream.Protocol(), n)
 
 		// decode message based on message type
 		// stream should always be inbound if it passes through service.readStream
@@ -39,6 +40,7 @@ func (s *Service) readStream(stream libp2pnetwork.Stream, decoder messageDecoder
 				stream.ID(), stream.Protocol(), err)
 			continue
 		}
+		s.fuzzCapture.capture(msg, msgBytes[:n])
 
 		logger.Tracef(
 			"host %s received message from peer %s: %s",
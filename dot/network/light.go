@@ -4,7 +4,9 @@
 package network
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/ChainSafe/gossamer/dot/network/messages"
 	"github.com/ChainSafe/gossamer/dot/types"
@@ -15,6 +17,30 @@
 	"github.com/libp2p/go-libp2p/core/peer"
 )
 
+const (
+	// maxKeyChangesRequestsPerPeer is the maximum number of key changes requests served to
+	// a peer within keyChangesRequestsWindow, since each request reads the storage of a
+	// range of blocks.
+	maxKeyChangesRequestsPerPeer = 16
+	keyChangesRequestsWindow     = time.Minute
+)
+
+var (
+	errKeyChangesUnsupported       = errors.New("key changes proofs unsupported")
+	errInvalidRemoteChangesRequest = errors.New("invalid remote changes request")
+	errKeyChangesRateLimited       = errors.New("key changes requests rate limited")
+)
+
+// KeyChangesProvider generates the proofs of the changes of storage keys served to
+// light clients.
+type KeyChangesProvider interface {
+	// GenerateKeyChangesProof returns the headers of the blocks after the first block up
+	// to the last block in which the value of the key changed, and the trie proof nodes
+	// of these changes.
+	GenerateKeyChangesProof(first, last common.Hash, key []byte) (
+		changed []*types.Header, encodedProofNodes [][]byte, err error)
+}
+
 // handleLightStream handles streams with the <protocol-id>/light/2 protocol ID
 func (s *Service) handleLightStream(stream libp2pnetwork.Stream) {
 	s.readStream(stream, s.decodeLightMessage, s.handleLightMsg, MaxBlockResponseSize)
@@ -49,12 +75,16 @@ func (s *Service) handleLightMsg(stream libp2pnetwork.Stream, msg messages.P2PMe
 
 	resp := NewLightResponse()
 	switch {
+	// decoded requests have all their sub-requests set, the changes requests being the
+	// ones with a block range
+	case lr.RemoteChangesRequest != nil && lr.RemoteChangesRequest.LastBlock != nil:
+		resp.RemoteChangesResponse, err = s.remoteChangesResp(stream.Conn().RemotePeer(), lr.RemoteChangesRequest)
 	case lr.RemoteCallRequest != nil:
 		resp.RemoteCallResponse, err = remoteCallResp(lr.RemoteCallRequest)
 	case lr.RemoteHeaderRequest != nil:
 		resp.RemoteHeaderResponse, err = remoteHeaderResp(lr.RemoteHeaderRequest)
 	case lr.RemoteChangesRequest != nil:
-		resp.RemoteChangesResponse, err = remoteChangeResp(lr.RemoteChangesRequest)
+		resp.RemoteChangesResponse, err = s.remoteChangesResp(stream.Conn().RemotePeer(), lr.RemoteChangesRequest)
 	case lr.RemoteReadRequest != nil:
 		resp.RemoteReadResponse, err = remoteReadResp(lr.RemoteReadRequest)
 	case lr.RemoteReadChildRequest != nil:
@@ -290,14 +320,20 @@ func newRemoteHeaderRequest() *RemoteHeaderRequest {
 	}
 }
 
-// RemoteChangesRequest ...
+// RemoteChangesRequest requests the blocks of a range in which the value of a storage
+// key changed, with the proofs of these changes.
 type RemoteChangesRequest struct {
+	// FirstBlock is the block the changes are looked for from, the value of the key
+	// at this block being proven too.
 	FirstBlock *common.Hash
-	LastBlock  *common.Hash
-	Min        []byte
-	Max        []byte
+	// LastBlock is the last block the changes are looked for at.
+	LastBlock *common.Hash
+	// Min and Max are unused, since no changes tries are kept to prove the changes.
+	Min []byte
+	Max []byte
+	// StorageKey is the key of the child trie of the key, unsupported.
 	StorageKey *[]byte
-	key        []byte
+	Key        []byte
 }
 
 func newRemoteChangesRequest() RemoteChangesRequest {
@@ -307,6 +343,7 @@ func newRemoteChangesRequest() RemoteChangesRequest {
 		Min:        []byte{},
 		Max:        []byte{},
 		StorageKey: nil,
+		Key:        []byte{},
 	}
 }
 
@@ -344,10 +381,16 @@ func newRemoteHeaderResponse() *RemoteHeaderResponse {
 	}
 }
 
-// RemoteChangesResponse ...
+// RemoteChangesResponse is the response to a RemoteChangesRequest.
 type RemoteChangesResponse struct {
-	Max        []byte
-	Proof      [][]byte
+	// Max and RootsProof are unused, since no changes tries are kept to prove the changes.
+	Max []byte
+	// Proof are the trie proof nodes of the value of the key at the first block, at
+	// each block in which the key changed and at their parents. No proof is returned
+	// for the states in which the key is not set.
+	Proof [][]byte
+	// Roots are the blocks in which the value of the key changed, in ascending order,
+	// each as a pair of the SCALE encoded block number and the block state root.
 	Roots      [][]Pair
 	RootsProof []byte
 }
@@ -385,7 +428,7 @@ func (rc *RemoteChangesRequest) String() string {
 		string(rc.Min),
 		string(rc.Max),
 		storageKey,
-		string(rc.key),
+		string(rc.Key),
 	)
 }
 
@@ -450,9 +493,6 @@ func (rh *RemoteHeaderResponse) String() string {
 func remoteCallResp(_ *RemoteCallRequest) (*RemoteCallResponse, error) {
 	return &RemoteCallResponse{}, nil
 }
-func remoteChangeResp(_ *RemoteChangesRequest) (*RemoteChangesResponse, error) {
-	return &RemoteChangesResponse{}, nil
-}
 func remoteHeaderResp(_ *RemoteHeaderRequest) (*RemoteHeaderResponse, error) {
 	return &RemoteHeaderResponse{}, nil
 }
@@ -462,3 +502,41 @@ func remoteReadChildResp(_ *RemoteReadChildRequest) (*RemoteReadResponse, error)
 func remoteReadResp(_ *RemoteReadRequest) (*RemoteReadResponse, error) {
 	return &RemoteReadResponse{}, nil
 }
+
+// remoteChangesResp returns the response to the key changes request of the peer. All the
+// valid requests of the peer are metered, including the ones rejected for exceeding
+// maxKeyChangesRequestsPerPeer, so a peer spamming requests is not served until it stops.
+func (s *Service) remoteChangesResp(from peer.ID, req *RemoteChangesRequest) (*RemoteChangesResponse, error) {
+	if s.keyChangesProvider == nil {
+		return nil, errKeyChangesUnsupported
+	}
+	if req.FirstBlock == nil || req.LastBlock == nil {
+		return nil, fmt.Errorf("%w: missing block range", errInvalidRemoteChangesRequest)
+	}
+	if req.StorageKey != nil {
+		return nil, fmt.Errorf("%w: child trie changes", errKeyChangesUnsupported)
+	}
+
+	peerID := common.MustBlake2bHash([]byte(from))
+	s.keyChangesSpamLimiter.AddRequest(peerID)
+	if s.keyChangesSpamLimiter.IsLimitExceeded(peerID) {
+		return nil, fmt.Errorf("%w: peer %s", errKeyChangesRateLimited, from)
+	}
+
+	changed, proof, err := s.keyChangesProvider.GenerateKeyChangesProof(*req.FirstBlock, *req.LastBlock, req.Key)
+	if err != nil {
+		return nil, fmt.Errorf("generating key changes proof: %w", err)
+	}
+
+	resp := newRemoteChangesResponse()
+	resp.Proof = proof
+	resp.Roots = make([][]Pair, len(changed))
+	for i, header := range changed {
+		number, err := scale.Marshal(header.Number)
+		if err != nil {
+			return nil, fmt.Errorf("encoding block number: %w", err)
+		}
+		resp.Roots[i] = []Pair{{First: number, Second: header.StateRoot.ToBytes()}}
+	}
+	return resp, nil
+}
@@ -18,7 +18,7 @@
 
 func TestEncodeLightRequest(t *testing.T) {
 	t.Parallel()
-	exp := common.MustHexToBytes("0x0000000000000000000000000000")
+	exp := common.MustHexToBytes("0x000000000000000000000000000000")
 
 	testLightRequest := NewLightRequest()
 	enc, err := testLightRequest.Encode()
@@ -139,7 +139,7 @@ func TestHandleLightMessage_Response(t *testing.T) {
 	err = s.handleLightMsg(stream, msg)
 	require.Error(t, err, expectedErr, msg.String())
 
-	// Testing remoteChangeResp()
+	// Testing remoteChangesResp()
 	msg = &LightRequest{
 		RemoteChangesRequest: &RemoteChangesRequest{},
 	}
@@ -0,0 +1,100 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/network/ratelimiters"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestService_remoteChangesResp(t *testing.T) {
+	t.Parallel()
+
+	first := common.Hash{1}
+	last := common.Hash{2}
+	key := []byte("key")
+	req := &RemoteChangesRequest{FirstBlock: &first, LastBlock: &last, Min: []byte{}, Max: []byte{}, Key: key}
+
+	ctrl := gomock.NewController(t)
+	provider := NewMockKeyChangesProvider(ctrl)
+	s := &Service{
+		keyChangesProvider: provider,
+		keyChangesSpamLimiter: ratelimiters.NewSlidingWindowRateLimiter(
+			maxKeyChangesRequestsPerPeer, keyChangesRequestsWindow),
+	}
+	const from = peer.ID("peer")
+
+	changed := []*types.Header{{Number: 3, StateRoot: common.Hash{3}}, {Number: 70, StateRoot: common.Hash{70}}}
+	proof := [][]byte{{1, 2}, {3, 4}}
+	provider.EXPECT().GenerateKeyChangesProof(first, last, key).Return(changed, proof, nil)
+	resp, err := s.remoteChangesResp(from, req)
+	require.NoError(t, err)
+	assert.Equal(t, proof, resp.Proof)
+	assert.Equal(t, [][]Pair{
+		{{First: []byte{3 << 2}, Second: common.Hash{3}.ToBytes()}},
+		{{First: []byte{0x19, 0x01}, Second: common.Hash{70}.ToBytes()}},
+	}, resp.Roots)
+
+	// the requests sent are decoded as changes requests
+	lr := NewLightRequest()
+	lr.RemoteChangesRequest = req
+	encoded, err := lr.Encode()
+	require.NoError(t, err)
+	decoded, err := newLightRequestFromBytes(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, req, decoded.RemoteChangesRequest)
+
+	errTest := errors.New("test error")
+	provider.EXPECT().GenerateKeyChangesProof(first, last, key).Return(nil, nil, errTest)
+	_, err = s.remoteChangesResp(from, req)
+	assert.ErrorIs(t, err, errTest)
+
+	storageKey := []byte("child")
+	_, err = s.remoteChangesResp(from, &RemoteChangesRequest{FirstBlock: &first, LastBlock: &last, StorageKey: &storageKey})
+	assert.ErrorIs(t, err, errKeyChangesUnsupported)
+
+	_, err = s.remoteChangesResp(from, &RemoteChangesRequest{LastBlock: &last})
+	assert.ErrorIs(t, err, errInvalidRemoteChangesRequest)
+
+	_, err = (&Service{}).remoteChangesResp(from, req)
+	assert.ErrorIs(t, err, errKeyChangesUnsupported)
+}
+
+func TestService_remoteChangesResp_rateLimited(t *testing.T) {
+	t.Parallel()
+
+	first := common.Hash{1}
+	last := common.Hash{2}
+	req := &RemoteChangesRequest{FirstBlock: &first, LastBlock: &last, Key: []byte("key")}
+
+	ctrl := gomock.NewController(t)
+	provider := NewMockKeyChangesProvider(ctrl)
+	s := &Service{
+		keyChangesProvider:    provider,
+		keyChangesSpamLimiter: ratelimiters.NewSlidingWindowRateLimiter(2, time.Minute),
+	}
+
+	provider.EXPECT().GenerateKeyChangesProof(first, last, req.Key).Return(nil, nil, nil).Times(3)
+	for i := 0; i < 2; i++ {
+		_, err := s.remoteChangesResp(peer.ID("spammer"), req)
+		require.NoError(t, err)
+	}
+
+	// the requests of a peer beyond the limit are not served
+	_, err := s.remoteChangesResp(peer.ID("spammer"), req)
+	assert.ErrorIs(t, err, errKeyChangesRateLimited)
+
+	// while the requests of other peers are
+	_, err = s.remoteChangesResp(peer.ID("other"), req)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,212 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+)
+
+const (
+	// peerStatsBucketDuration is the duration covered by each bucket of the rolling window.
+	peerStatsBucketDuration = 10 * time.Second
+	// peerStatsBuckets is the number of buckets of the rolling window, which
+	// therefore covers the last minute of traffic.
+	peerStatsBuckets = 6
+
+	topTalkersInterval = time.Minute
+	topTalkersCount    = 5
+)
+
+// trafficCounters counts the messages and bytes exchanged in each direction.
+type trafficCounters struct {
+	messagesIn  uint64
+	messagesOut uint64
+	bytesIn     uint64
+	bytesOut    uint64
+}
+
+func (c *trafficCounters) add(other trafficCounters) {
+	c.messagesIn += other.messagesIn
+	c.messagesOut += other.messagesOut
+	c.bytesIn += other.bytesIn
+	c.bytesOut += other.bytesOut
+}
+
+// protocolTraffic is the traffic exchanged with a peer over a protocol, with
+// the total since the peer connected and the buckets of the rolling window.
+type protocolTraffic struct {
+	total   trafficCounters
+	buckets [peerStatsBuckets]trafficCounters
+	// epochs is the bucket epoch counted by each of the buckets
+	epochs [peerStatsBuckets]int64
+}
+
+func (p *protocolTraffic) log(epoch int64, counters trafficCounters) {
+	p.total.add(counters)
+
+	i := epoch % peerStatsBuckets
+	if p.epochs[i] != epoch {
+		p.epochs[i] = epoch
+		p.buckets[i] = trafficCounters{}
+	}
+	p.buckets[i].add(counters)
+}
+
+// recent returns the traffic counted by the buckets of the rolling window ending at the given epoch.
+func (p *protocolTraffic) recent(epoch int64) (counters trafficCounters) {
+	for i, bucketEpoch := range p.epochs {
+		if bucketEpoch > epoch-peerStatsBuckets && bucketEpoch <= epoch {
+			counters.add(p.buckets[i])
+		}
+	}
+	return counters
+}
+
+// peerStats tracks the traffic exchanged with each connected peer per protocol.
+type peerStats struct {
+	sync.Mutex
+	now   func() time.Time
+	peers map[peer.ID]map[protocol.ID]*protocolTraffic
+}
+
+func newPeerStats() *peerStats {
+	return &peerStats{
+		now:   time.Now,
+		peers: make(map[peer.ID]map[protocol.ID]*protocolTraffic),
+	}
+}
+
+func (ps *peerStats) epoch() int64 {
+	return ps.now().UnixNano() / int64(peerStatsBucketDuration)
+}
+
+func (ps *peerStats) log(who peer.ID, pid protocol.ID, counters trafficCounters) {
+	ps.Lock()
+	defer ps.Unlock()
+
+	protocols, has := ps.peers[who]
+	if !has {
+		protocols = make(map[protocol.ID]*protocolTraffic)
+		ps.peers[who] = protocols
+	}
+
+	traffic, has := protocols[pid]
+	if !has {
+		traffic = new(protocolTraffic)
+		protocols[pid] = traffic
+	}
+
+	traffic.log(ps.epoch(), counters)
+}
+
+// logReceived counts a message of the given size received from the peer over the protocol.
+func (ps *peerStats) logReceived(who peer.ID, pid protocol.ID, size int) {
+	ps.log(who, pid, trafficCounters{messagesIn: 1, bytesIn: uint64(size)})
+}
+
+// logSent counts a message of the given size sent to the peer over the protocol.
+func (ps *peerStats) logSent(who peer.ID, pid protocol.ID, size int) {
+	ps.log(who, pid, trafficCounters{messagesOut: 1, bytesOut: uint64(size)})
+}
+
+// remove drops the traffic counted for a disconnected peer.
+func (ps *peerStats) remove(who peer.ID) {
+	ps.Lock()
+	defer ps.Unlock()
+	delete(ps.peers, who)
+}
+
+// stats returns the traffic exchanged with each peer, sorted by peer id.
+func (ps *peerStats) stats() []common.PeerStats {
+	ps.Lock()
+	defer ps.Unlock()
+
+	epoch := ps.epoch()
+	stats := make([]common.PeerStats, 0, len(ps.peers))
+	for who, protocols := range ps.peers {
+		peerStats := common.PeerStats{
+			PeerID:    who.String(),
+			Protocols: make([]common.ProtocolStats, 0, len(protocols)),
+		}
+
+		for pid, traffic := range protocols {
+			recent := traffic.recent(epoch)
+			peerStats.MessagesIn += traffic.total.messagesIn
+			peerStats.MessagesOut += traffic.total.messagesOut
+			peerStats.BytesIn += traffic.total.bytesIn
+			peerStats.BytesOut += traffic.total.bytesOut
+			peerStats.RecentBytesIn += recent.bytesIn
+			peerStats.RecentBytesOut += recent.bytesOut
+
+			peerStats.Protocols = append(peerStats.Protocols, common.ProtocolStats{
+				Protocol:       string(pid),
+				MessagesIn:     traffic.total.messagesIn,
+				MessagesOut:    traffic.total.messagesOut,
+				BytesIn:        traffic.total.bytesIn,
+				BytesOut:       traffic.total.bytesOut,
+				RecentBytesIn:  recent.bytesIn,
+				RecentBytesOut: recent.bytesOut,
+			})
+		}
+
+		sort.Slice(peerStats.Protocols, func(i, j int) bool {
+			return peerStats.Protocols[i].Protocol < peerStats.Protocols[j].Protocol
+		})
+		stats = append(stats, peerStats)
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].PeerID < stats[j].PeerID
+	})
+	return stats
+}
+
+// topTalkers returns at most n peers with traffic in the rolling window,
+// sorted by decreasing volume of recent bytes in both directions.
+func (ps *peerStats) topTalkers(n int) []common.PeerStats {
+	stats := ps.stats()
+
+	talkers := stats[:0]
+	for _, peerStats := range stats {
+		if peerStats.RecentBytesIn+peerStats.RecentBytesOut > 0 {
+			talkers = append(talkers, peerStats)
+		}
+	}
+
+	sort.SliceStable(talkers, func(i, j int) bool {
+		return talkers[i].RecentBytesIn+talkers[i].RecentBytesOut >
+			talkers[j].RecentBytesIn+talkers[j].RecentBytesOut
+	})
+
+	if len(talkers) > n {
+		talkers = talkers[:n]
+	}
+	return talkers
+}
+
+// formatTopTalkers formats the top talkers on a single line, with the busiest protocol of each peer.
+func formatTopTalkers(talkers []common.PeerStats) string {
+	entries := make([]string, len(talkers))
+	for i, talker := range talkers {
+		var busiest common.ProtocolStats
+		for _, protocolStats := range talker.Protocols {
+			if protocolStats.RecentBytesIn+protocolStats.RecentBytesOut >
+				busiest.RecentBytesIn+busiest.RecentBytesOut {
+				busiest = protocolStats
+			}
+		}
+
+		entries[i] = fmt.Sprintf("%s in=%dB out=%dB (busiest %s)",
+			talker.PeerID, talker.RecentBytesIn, talker.RecentBytesOut, busiest.Protocol)
+	}
+	return strings.Join(entries, ", ")
+}
@@ -0,0 +1,90 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_peerStats(t *testing.T) {
+	t.Parallel()
+
+	const (
+		peerA       = peer.ID("a")
+		peerB       = peer.ID("b")
+		blockAnnPid = protocol.ID("/dot/block-announces/1")
+		syncPid     = protocol.ID("/dot/sync/2")
+	)
+
+	now := time.Unix(1000, 0)
+	stats := newPeerStats()
+	stats.now = func() time.Time { return now }
+
+	stats.logReceived(peerA, syncPid, 100)
+	stats.logSent(peerA, syncPid, 10)
+	stats.logReceived(peerB, blockAnnPid, 5)
+
+	// the first messages leave the rolling window
+	now = now.Add(peerStatsBucketDuration * peerStatsBuckets)
+	stats.logReceived(peerA, blockAnnPid, 20)
+	stats.logReceived(peerB, blockAnnPid, 50)
+	stats.logSent(peerB, blockAnnPid, 50)
+
+	expected := []common.PeerStats{{
+		PeerID:        peerA.String(),
+		MessagesIn:    2,
+		MessagesOut:   1,
+		BytesIn:       120,
+		BytesOut:      10,
+		RecentBytesIn: 20,
+		Protocols: []common.ProtocolStats{{
+			Protocol:      string(blockAnnPid),
+			MessagesIn:    1,
+			BytesIn:       20,
+			RecentBytesIn: 20,
+		}, {
+			Protocol:    string(syncPid),
+			MessagesIn:  1,
+			MessagesOut: 1,
+			BytesIn:     100,
+			BytesOut:    10,
+		}},
+	}, {
+		PeerID:         peerB.String(),
+		MessagesIn:     2,
+		MessagesOut:    1,
+		BytesIn:        55,
+		BytesOut:       50,
+		RecentBytesIn:  50,
+		RecentBytesOut: 50,
+		Protocols: []common.ProtocolStats{{
+			Protocol:       string(blockAnnPid),
+			MessagesIn:     2,
+			MessagesOut:    1,
+			BytesIn:        55,
+			BytesOut:       50,
+			RecentBytesIn:  50,
+			RecentBytesOut: 50,
+		}},
+	}}
+	assert.Equal(t, expected, stats.stats())
+
+	talkers := stats.topTalkers(1)
+	assert.Equal(t, expected[1:], talkers)
+	assert.Equal(t, peerB.String()+" in=50B out=50B (busiest /dot/block-announces/1)",
+		formatTopTalkers(talkers))
+
+	stats.remove(peerB)
+	assert.Equal(t, expected[:1], stats.stats())
+
+	// a peer without recent traffic is not a top talker
+	now = now.Add(peerStatsBucketDuration * peerStatsBuckets)
+	assert.Empty(t, stats.topTalkers(topTalkersCount))
+}
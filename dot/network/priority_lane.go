@@ -0,0 +1,119 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"sync"
+
+	"github.com/ChainSafe/gossamer/dot/network/messages"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var highPriorityWaitsTotal = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: "gossamer_network",
+	Name:      "high_priority_waits_total",
+	Help:      "total number of high priority writes which waited for a write to the same peer",
+})
+
+type writeLane uint8
+
+const (
+	lowPriorityLane writeLane = iota
+	highPriorityLane
+)
+
+// messageLane returns the lane of a message: grandpa messages and block
+// announcements go on the high priority lane, transactions and the requests
+// and responses of the other protocols on the low priority lane.
+func messageLane(msg messages.P2PMessage) writeLane {
+	switch msg.(type) {
+	case *ConsensusMessage, *BlockAnnounceMessage:
+		return highPriorityLane
+	default:
+		return lowPriorityLane
+	}
+}
+
+type laneGate struct {
+	cond        *sync.Cond
+	writing     bool
+	highWaiting int
+	users       int
+}
+
+// priorityLanes serialises the writes to each peer so that each message is
+// written whole, letting the pending writes of the high priority lane go before
+// the pending writes of the low priority lane. Since each protocol has its own
+// stream, the writes are tracked per peer rather than per stream, so that the
+// grandpa messages to a peer go before its pending transactions. The writes to
+// different peers do not wait for each other.
+type priorityLanes struct {
+	mutex sync.Mutex
+	gates map[peer.ID]*laneGate
+}
+
+func newPriorityLanes() *priorityLanes {
+	return &priorityLanes{
+		gates: make(map[peer.ID]*laneGate),
+	}
+}
+
+func (l *priorityLanes) acquire(p peer.ID, lane writeLane) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	gate, ok := l.gates[p]
+	if !ok {
+		gate = &laneGate{cond: sync.NewCond(&l.mutex)}
+		l.gates[p] = gate
+	}
+	gate.users++
+
+	if lane == highPriorityLane {
+		if gate.writing {
+			highPriorityWaitsTotal.Inc()
+		}
+		gate.highWaiting++
+		for gate.writing {
+			gate.cond.Wait()
+		}
+		gate.highWaiting--
+	} else {
+		for gate.writing || gate.highWaiting > 0 {
+			gate.cond.Wait()
+		}
+	}
+
+	gate.writing = true
+}
+
+func (l *priorityLanes) release(p peer.ID) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	gate := l.gates[p]
+	gate.writing = false
+	gate.users--
+	if gate.users == 0 {
+		delete(l.gates, p)
+		return
+	}
+	gate.cond.Broadcast()
+}
+
+// write writes the whole data to the stream to the given peer on the given lane,
+// once the writes pending to the peer with a higher priority are done. The lanes
+// mutex is not held while writing. If the lanes are nil the data is written directly.
+func (l *priorityLanes) write(p peer.ID, s network.Stream, lane writeLane, data []byte) (sent int, err error) {
+	if l == nil {
+		return s.Write(data)
+	}
+
+	l.acquire(p, lane)
+	defer l.release(p)
+	return s.Write(data)
+}
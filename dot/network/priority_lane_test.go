@@ -0,0 +1,134 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/network/messages"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_messageLane(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, highPriorityLane, messageLane(&ConsensusMessage{}))
+	assert.Equal(t, highPriorityLane, messageLane(&BlockAnnounceMessage{}))
+	assert.Equal(t, lowPriorityLane, messageLane(&TransactionMessage{}))
+	assert.Equal(t, lowPriorityLane, messageLane(&BlockAnnounceHandshake{}))
+}
+
+func Test_priorityLanes_highPriorityFirst(t *testing.T) {
+	t.Parallel()
+
+	const remotePeer = peer.ID("remote")
+	lanes := newPriorityLanes()
+	lanes.acquire(remotePeer, lowPriorityLane)
+
+	order := make(chan writeLane, 2)
+	waitQueued := func(waiting int) {
+		require.Eventually(t, func() bool {
+			lanes.mutex.Lock()
+			defer lanes.mutex.Unlock()
+			return lanes.gates[remotePeer].users == waiting+1
+		}, time.Second, time.Millisecond)
+	}
+
+	go func() {
+		lanes.acquire(remotePeer, lowPriorityLane)
+		order <- lowPriorityLane
+		lanes.release(remotePeer)
+	}()
+	waitQueued(1)
+
+	go func() {
+		lanes.acquire(remotePeer, highPriorityLane)
+		order <- highPriorityLane
+		lanes.release(remotePeer)
+	}()
+	waitQueued(2)
+
+	lanes.release(remotePeer)
+	assert.Equal(t, highPriorityLane, <-order)
+	assert.Equal(t, lowPriorityLane, <-order)
+
+	require.Eventually(t, func() bool {
+		lanes.mutex.Lock()
+		defer lanes.mutex.Unlock()
+		return len(lanes.gates) == 0
+	}, time.Second, time.Millisecond)
+}
+
+func Test_priorityLanes_write_consensusOvertakesTransaction(t *testing.T) {
+	t.Parallel()
+
+	const remotePeer = peer.ID("remote")
+	ctrl := gomock.NewController(t)
+	transactionStream := NewMockStream(ctrl)
+	grandpaStream := NewMockStream(ctrl)
+	lanes := newPriorityLanes()
+
+	// a write to the peer is in progress on its sync stream
+	lanes.acquire(remotePeer, lowPriorityLane)
+
+	transactionMessage := &TransactionMessage{}
+	consensusMessage := &ConsensusMessage{Data: []byte{1}}
+	written := make(chan messages.P2PMessage, 2)
+	transactionStream.EXPECT().Write([]byte{1}).DoAndReturn(func(data []byte) (int, error) {
+		written <- transactionMessage
+		return len(data), nil
+	})
+	grandpaStream.EXPECT().Write([]byte{2}).DoAndReturn(func(data []byte) (int, error) {
+		written <- consensusMessage
+		return len(data), nil
+	})
+
+	waitQueued := func(waiting int) {
+		require.Eventually(t, func() bool {
+			lanes.mutex.Lock()
+			defer lanes.mutex.Unlock()
+			return lanes.gates[remotePeer].users == waiting+1
+		}, time.Second, time.Millisecond)
+	}
+
+	go func() {
+		_, err := lanes.write(remotePeer, transactionStream, messageLane(transactionMessage), []byte{1})
+		assert.NoError(t, err)
+	}()
+	waitQueued(1)
+
+	go func() {
+		_, err := lanes.write(remotePeer, grandpaStream, messageLane(consensusMessage), []byte{2})
+		assert.NoError(t, err)
+	}()
+	waitQueued(2)
+
+	lanes.release(remotePeer)
+	assert.Equal(t, messages.P2PMessage(consensusMessage), <-written)
+	assert.Equal(t, messages.P2PMessage(transactionMessage), <-written)
+}
+
+func Test_priorityLanes_write(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	stream := NewMockStream(ctrl)
+	lanes := newPriorityLanes()
+
+	// a write in progress to another peer does not delay the write
+	lanes.acquire(peer.ID("other"), lowPriorityLane)
+	defer lanes.release(peer.ID("other"))
+
+	// the message is written whole, even on the low priority lane
+	data := make([]byte, 64*1024)
+	stream.EXPECT().Write(data).Return(len(data), nil)
+
+	sent, err := lanes.write(peer.ID("remote"), stream, lowPriorityLane, data)
+	require.NoError(t, err)
+	assert.Equal(t, len(data), sent)
+}
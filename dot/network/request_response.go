@@ -28,6 +28,7 @@ type RequestResponseProtocol struct {
 	protocolID      protocol.ID
 	responseBufMu   sync.Mutex
 	responseBuf     []byte
+	fuzzCapture     *fuzzCapture
 }
 
 func (rrp *RequestResponseProtocol) Do(to peer.ID, req, res messages.P2PMessage) error {
@@ -82,6 +83,7 @@ func (rrp *RequestResponseProtocol) receiveResponse(stream libp2pnetwork.Stream,
 		}, stream.Conn().RemotePeer())
 		return fmt.Errorf("failed to decode block response: %w", err)
 	}
+	rrp.fuzzCapture.capture(msg, buf[:n])
 
 	return nil
 }
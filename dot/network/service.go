@@ -12,6 +12,7 @@
 	"sync"
 	"time"
 
+	"github.com/ChainSafe/gossamer/dot/events"
 	"github.com/ChainSafe/gossamer/dot/network/messages"
 	"github.com/ChainSafe/gossamer/dot/peerset"
 	"github.com/ChainSafe/gossamer/dot/telemetry"
@@ -131,6 +132,7 @@ type Service struct {
 	syncer             Syncer
 	transactionHandler TransactionHandler
 	warpSyncProvider   WarpSyncProvider
+	keyChangesProvider KeyChangesProvider
 
 	// Configuration options
 	noBootstrap bool
@@ -145,9 +147,21 @@ type Service struct {
 	closeCh           chan struct{}
 
 	telemetry Telemetry
+	events    *events.Bus
 
 	// Spam control
-	warpSyncSpamLimiter RateLimiter
+	warpSyncSpamLimiter   RateLimiter
+	keyChangesSpamLimiter RateLimiter
+
+	// fuzzCapture records the inbound messages into a fuzz corpus, nil if disabled
+	fuzzCapture *fuzzCapture
+
+	bootnodeChecker *bootnodeChecker
+
+	// rotationMu serialises the rotations of the p2p identity
+	rotationMu sync.Mutex
+	// routines are the goroutines using the host, running until the service is stopped
+	routines sync.WaitGroup
 }
 
 // NewService creates a new network service from the configuration and message channels
@@ -193,6 +207,13 @@ func NewService(cfg *Config) (*Service, error) {
 		return nil, fmt.Errorf("failed to create host: %w", err)
 	}
 
+	bootnodeChecker, err := newBootnodeChecker(host, cfg.BootnodeCheckInterval,
+		cfg.BootnodesEndpoint, cfg.BootnodesEndpointKey)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("creating bootnode checker: %w", err)
+	}
+
 	bufPool := &sync.Pool{
 		New: func() interface{} {
 			b := make([]byte, maxMessageSize)
@@ -200,20 +221,12 @@ func NewService(cfg *Config) (*Service, error) {
 		},
 	}
 
-	serviceTag := string(host.protocolID)
-	notifee := NewNotifeeTracker(host.p2pHost.Peerstore(), host.cm.peerSetHandler)
-	mdnsLogger := log.NewFromGlobal(log.AddContext("module", "mdns"))
-	mdnsLogger.Debugf(
-		"Creating mDNS discovery service with host %s and protocol %s...",
-		host.id(), host.protocolID)
-	mdnsService := mdns.NewMdnsService(host.p2pHost, serviceTag, notifee)
-
 	network := &Service{
 		ctx:                    ctx,
 		cancel:                 cancel,
 		cfg:                    cfg,
 		host:                   host,
-		mdns:                   mdnsService,
+		mdns:                   newMDNS(host),
 		gossip:                 newGossip(),
 		blockState:             cfg.BlockState,
 		transactionHandler:     cfg.TransactionHandler,
@@ -221,6 +234,7 @@ func NewService(cfg *Config) (*Service, error) {
 		noMDNS:                 cfg.NoMDNS,
 		syncer:                 cfg.Syncer,
 		warpSyncProvider:       cfg.WarpSyncProvider,
+		keyChangesProvider:     cfg.KeyChangesProvider,
 		notificationsProtocols: make(map[MessageType]*notificationsProtocol),
 		lightRequest:           make(map[peer.ID]struct{}),
 		telemetryInterval:      cfg.telemetryInterval,
@@ -228,13 +242,28 @@ func NewService(cfg *Config) (*Service, error) {
 		bufPool:                bufPool,
 		streamManager:          newStreamManager(ctx),
 		telemetry:              cfg.Telemetry,
+		events:                 cfg.Events,
 		Metrics:                cfg.Metrics,
 		warpSyncSpamLimiter:    cfg.warpSyncSpamLimiter,
+		keyChangesSpamLimiter:  cfg.keyChangesSpamLimiter,
+		fuzzCapture:            newFuzzCapture(cfg.FuzzCorpusDir),
+		bootnodeChecker:        bootnodeChecker,
 	}
 
 	return network, nil
 }
 
+// newMDNS returns the mDNS discovery service of the host.
+func newMDNS(host *host) MDNS {
+	serviceTag := string(host.protocolID)
+	notifee := NewNotifeeTracker(host.p2pHost.Peerstore(), host.cm.peerSetHandler)
+	mdnsLogger := log.NewFromGlobal(log.AddContext("module", "mdns"))
+	mdnsLogger.Debugf(
+		"Creating mDNS discovery service with host %s and protocol %s...",
+		host.id(), host.protocolID)
+	return mdns.NewMdnsService(host.p2pHost, serviceTag, notifee)
+}
+
 // SetSyncer sets the Syncer used by the network service
 func (s *Service) SetSyncer(syncer Syncer) {
 	s.syncer = syncer
@@ -301,6 +330,11 @@ func (s *Service) Start() error {
 		logger.Warnf("failed to register notifications protocol with transaction id %s: %s", transactionsID, err)
 	}
 
+	return s.startHost()
+}
+
+// startHost starts the host of the network service, and the services and routines using it.
+func (s *Service) startHost() (err error) {
 	// this handles all new connections (incoming and outgoing)
 	// it creates a per-protocol mutex for sending outbound handshakes to the peer
 	// connectHandler is a part of libp2p.Notifiee interface implementation and getting called in the very end
@@ -311,6 +345,10 @@ func (s *Service) Start() error {
 		}
 		const setID = 0
 		s.host.cm.peerSetHandler.Incoming(setID, peerID)
+
+		if s.events != nil {
+			s.events.PeerConnected.Publish(peerID)
+		}
 	}
 
 	// when a peer gets disconnected, we should clear all handshake data we have for it.
@@ -320,6 +358,7 @@ func (s *Service) Start() error {
 			prtl.peersData.deleteInboundHandshakeData(peerID)
 			prtl.peersData.deleteOutboundHandshakeData(peerID)
 		}
+		s.host.peerStats.remove(peerID)
 	}
 
 	// log listening addresses to console
@@ -338,8 +377,9 @@ func (s *Service) Start() error {
 	// TODO: this is basically a hack that is used only in unit tests to disable kademilia dht.
 	// Should be replaced with a mock instead.
 	if !s.noDiscover {
+		discovery := s.host.discovery
 		go func() {
-			err = s.host.discovery.start()
+			err := discovery.start()
 			if err != nil {
 				logger.Errorf("failed to begin DHT discovery: %s", err)
 			}
@@ -352,12 +392,14 @@ func (s *Service) Start() error {
 
 	if s.Metrics.Publish {
 		processStartTimeGauge.Set(float64(time.Now().Unix()))
-		go s.updateMetrics()
+		s.spawn(s.updateMetrics)
 	}
 
-	go s.logPeerCount()
-	go s.publishNetworkTelemetry(s.closeCh)
-	go s.sentBlockIntervalTelemetry()
+	s.spawn(s.logPeerCount)
+	s.spawn(s.logTopTalkers)
+	closeCh := s.closeCh
+	s.spawn(func() { s.publishNetworkTelemetry(closeCh) })
+	s.spawn(s.sentBlockIntervalTelemetry)
 	s.streamManager.start()
 
 	return nil
@@ -425,6 +467,25 @@ func (s *Service) logPeerCount() {
 	}
 }
 
+func (s *Service) logTopTalkers() {
+	ticker := time.NewTicker(topTalkersInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			talkers := s.host.peerStats.topTalkers(topTalkersCount)
+			if len(talkers) == 0 {
+				continue
+			}
+			logger.Infof("top talkers over the last %s: %s",
+				peerStatsBucketDuration*peerStatsBuckets, formatTopTalkers(talkers))
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
 func (s *Service) publishNetworkTelemetry(done <-chan struct{}) {
 	ticker := time.NewTicker(s.telemetryInterval)
 	defer ticker.Stop()
@@ -631,6 +692,7 @@ func (s *Service) GetRequestResponseProtocol(subprotocol string, requestTimeout
 		protocolID:      protocolID,
 		responseBuf:     make([]byte, maxResponseSize),
 		responseBufMu:   sync.Mutex{},
+		fuzzCapture:     s.fuzzCapture,
 	}
 }
 
@@ -686,6 +748,12 @@ func (s *Service) Peers() []common.PeerInfo {
 	return peers
 }
 
+// PeerStats returns the messages and bytes exchanged with each connected peer per protocol,
+// in total and over the last minute, needed for the rpc server
+func (s *Service) PeerStats() []common.PeerStats {
+	return s.host.peerStats.stats()
+}
+
 // AddReservedPeers insert new peers to the peerstore with PermanentAddrTTL
 func (s *Service) AddReservedPeers(addrs ...string) error {
 	return s.host.addReservedPeers(addrs...)
@@ -728,9 +796,20 @@ func (s *Service) startPeerSetHandler() {
 	// wait for peerSetHandler to start.
 	if !s.noBootstrap {
 		s.host.bootstrap()
+		go s.bootnodeChecker.run(s.ctx)
 	}
 
-	go s.startProcessingMsg()
+	s.spawn(s.startProcessingMsg)
+}
+
+// spawn runs the function in a goroutine, which must return once the service is
+// stopped, and which is waited for before the host is replaced.
+func (s *Service) spawn(f func()) {
+	s.routines.Add(1)
+	go func() {
+		defer s.routines.Done()
+		f()
+	}()
 }
 
 // processMessage process messages from PeerSetHandler. Responsible for Connecting and Drop connection with peers.
@@ -263,3 +263,68 @@ func TestHandleConn(t *testing.T) {
 	}
 	require.NoError(t, err)
 }
+
+func TestService_RotateIdentity(t *testing.T) {
+	t.Parallel()
+
+	configA := &Config{
+		BasePath:    t.TempDir(),
+		Port:        availablePort(t),
+		NoBootstrap: true,
+		NoMDNS:      true,
+	}
+	nodeA := createTestService(t, configA)
+	previousID := nodeA.host.id()
+
+	configB := &Config{
+		BasePath:    t.TempDir(),
+		Port:        availablePort(t),
+		NoBootstrap: true,
+		NoMDNS:      true,
+	}
+	nodeB := createTestService(t, configB)
+
+	err := nodeB.host.connect(addrInfo(nodeA.host))
+	if failedToDial(err) {
+		time.Sleep(TestBackoffTimeout)
+		err = nodeB.host.connect(addrInfo(nodeA.host))
+	}
+	require.NoError(t, err)
+
+	peerID, err := nodeA.RotateIdentity(time.Second)
+	require.NoError(t, err)
+	require.NotEqual(t, previousID.String(), peerID)
+	require.Equal(t, peerID, nodeA.host.id().String())
+	require.False(t, nodeA.IsStopped())
+
+	// the new key replaced the key file
+	key, err := loadKey(configA.BasePath)
+	require.NoError(t, err)
+	require.True(t, key.Equals(configA.privateKey))
+
+	// the stream handlers are registered on the new host
+	require.Contains(t, nodeA.host.protocols(), string(nodeA.host.protocolID+SyncID))
+
+	err = nodeB.host.connect(addrInfo(nodeA.host))
+	if failedToDial(err) {
+		time.Sleep(TestBackoffTimeout)
+		err = nodeB.host.connect(addrInfo(nodeA.host))
+	}
+	require.NoError(t, err)
+}
+
+func TestService_RotateIdentity_notRotatable(t *testing.T) {
+	t.Parallel()
+
+	config := &Config{
+		BasePath:    t.TempDir(),
+		Port:        availablePort(t),
+		RandSeed:    1,
+		NoBootstrap: true,
+		NoMDNS:      true,
+	}
+	node := createTestService(t, config)
+
+	_, err := node.RotateIdentity(time.Second)
+	require.ErrorIs(t, err, ErrIdentityNotRotatable)
+}
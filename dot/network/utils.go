@@ -114,23 +114,39 @@ func makeDir(fp string) error {
 	return e
 }
 
-// saveKey attempts to save a private key to the provided filepath
+// saveKey attempts to save a private key to the provided filepath. The key is written
+// to a temporary file which then replaces the key file, so the key file always holds
+// either the previous or the new key.
 func saveKey(priv crypto.PrivKey, fp string) (err error) {
-	pth := path.Join(filepath.Clean(fp), DefaultKeyFile)
-	f, err := os.Create(filepath.Clean(pth))
-	if err != nil {
-		return err
-	}
 	raw, err := priv.Raw()
 	if err != nil {
 		return err
 	}
 	enc := make([]byte, hex.EncodedLen(len(raw)))
 	hex.Encode(enc, raw)
+
+	pth := path.Join(filepath.Clean(fp), DefaultKeyFile)
+	f, err := os.CreateTemp(filepath.Clean(fp), DefaultKeyFile+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = f.Close()
+			_ = os.Remove(f.Name())
+		}
+	}()
+
 	if _, err = f.Write(enc); err != nil {
 		return err
 	}
-	return f.Close()
+	if err = f.Sync(); err != nil {
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), pth)
 }
 
 func Uint64ToLEB128(in uint64) []byte {
@@ -5,6 +5,7 @@
 
 import (
 	"bytes"
+	"os"
 	"testing"
 
 	libp2pnetwork "github.com/libp2p/go-libp2p/core/network"
@@ -96,6 +97,26 @@ func TestGenerateKey(t *testing.T) {
 	require.Equal(t, keyC, keyD)
 }
 
+func TestSaveKey(t *testing.T) {
+	testDir := t.TempDir()
+
+	keyA, err := generateKey(0, testDir)
+	require.NoError(t, err)
+	keyB, err := generateKey(0, testDir)
+	require.NoError(t, err)
+
+	// the key file is replaced, without leaving temporary files behind
+	key, err := loadKey(testDir)
+	require.NoError(t, err)
+	require.True(t, key.Equals(keyB))
+	require.False(t, key.Equals(keyA))
+
+	entries, err := os.ReadDir(testDir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, DefaultKeyFile, entries[0].Name())
+}
+
 func TestReadLEB128ToUint64(t *testing.T) {
 	tests := []struct {
 		input  []byte
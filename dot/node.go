@@ -34,7 +34,9 @@
 	"github.com/ChainSafe/gossamer/lib/grandpa"
 	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/lib/runtime/offchain"
 	"github.com/ChainSafe/gossamer/lib/services"
+	"github.com/ChainSafe/gossamer/lib/standby"
 )
 
 var logger = log.NewFromGlobal(log.AddContext("pkg", "dot"))
@@ -46,6 +48,7 @@ type Node struct {
 	wg              sync.WaitGroup
 	started         chan struct{}
 	metricsServer   *metrics.Server
+	supervisors     *services.Supervisors // supervisors of the goroutines of the node subsystems
 }
 
 type nodeBuilderIface interface {
@@ -137,7 +140,7 @@ func (nodeBuilder) initNode(config *cfg.Config) error {
 		config.Name, config.ID, config.BasePath, config.ChainSpec)
 
 	// create genesis from configuration file
-	gen, err := genesis.NewGenesisFromJSONRaw(config.ChainSpec)
+	gen, err := genesis.NewGenesisFromFile(config.ChainSpec)
 	if err != nil {
 		return fmt.Errorf("failed to load genesis from file: %w", err)
 	}
@@ -162,7 +165,7 @@ func (nodeBuilder) initNode(config *cfg.Config) error {
 		return fmt.Errorf("failed to create genesis block from trie: %w", err)
 	}
 
-	telemetryMailer, err := setupTelemetry(config, nil)
+	telemetryMailer, err := setupTelemetry(config, nil, nil)
 	if err != nil {
 		return fmt.Errorf("cannot setup telemetry mailer: %w", err)
 	}
@@ -289,7 +292,13 @@ func newNode(config *cfg.Config,
 		return nil, fmt.Errorf("cannot load genesis data: %w", err)
 	}
 
-	telemetryMailer, err := setupTelemetry(config, gd)
+	panicPolicies, err := services.ParsePanicPolicies(config.PanicPolicy)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse panic policies: %w", err)
+	}
+	supervisors := services.NewSupervisors(panicPolicies, log.NewFromGlobal(log.AddContext("pkg", "supervisor")))
+
+	telemetryMailer, err := setupTelemetry(config, gd, supervisors.Supervisor("telemetry"))
 	if err != nil {
 		return nil, fmt.Errorf("cannot setup telemetry mailer: %w", err)
 	}
@@ -347,6 +356,11 @@ func newNode(config *cfg.Config,
 	if err != nil {
 		return nil, err
 	}
+	nodeSrvcs = append(nodeSrvcs, offchain.NewPruner(offchain.DefaultPruneInterval, offchainStorages(ns)...))
+
+	if config.State.VerifyInterval > 0 {
+		nodeSrvcs = append(nodeSrvcs, state.NewStateVerifier(stateSrvc, config.State.VerifyInterval))
+	}
 
 	err = builder.loadRuntime(config, ns, stateSrvc, ks, networkSrvc)
 	if err != nil {
@@ -367,10 +381,22 @@ func newNode(config *cfg.Config,
 	}
 	nodeSrvcs = append(nodeSrvcs, coreSrvc)
 
+	var standbySrvc *standby.Service
+	if config.Core.StandbyLease != "" {
+		standbySrvc, err = createStandbyService(config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create standby service: %w", err)
+		}
+		nodeSrvcs = append(nodeSrvcs, standbySrvc)
+	}
+
 	fg, err := builder.createGRANDPAService(config, stateSrvc, ks.Gran, networkSrvc, telemetryMailer)
 	if err != nil {
 		return nil, err
 	}
+	if standbySrvc != nil {
+		fg.SetVoteGuard(standbySrvc)
+	}
 	nodeSrvcs = append(nodeSrvcs, fg)
 
 	syncer, err := builder.newSyncService(config, stateSrvc, fg, ver, coreSrvc, networkSrvc, telemetryMailer)
@@ -388,6 +414,9 @@ func newNode(config *cfg.Config,
 	if err != nil {
 		return nil, err
 	}
+	if standbySrvc != nil {
+		bp.SetSlotGuard(standbySrvc)
+	}
 	nodeSrvcs = append(nodeSrvcs, bp)
 
 	// check if rpc service is enabled
@@ -403,6 +432,7 @@ func newNode(config *cfg.Config,
 			system:        sysSrvc,
 			blockFinality: fg,
 			syncer:        syncer.(rpc.SyncAPI),
+			supervisor:    supervisors.Supervisor("rpc"),
 		}
 		rpcSrvc, err = builder.createRPCService(cRPCParams)
 		if err != nil {
@@ -420,6 +450,7 @@ func newNode(config *cfg.Config,
 		Name:            config.Name,
 		ServiceRegistry: serviceRegistry,
 		started:         make(chan struct{}),
+		supervisors:     supervisors,
 	}
 
 	for _, srvc := range nodeSrvcs {
@@ -438,7 +469,8 @@ func newNode(config *cfg.Config,
 	return node, nil
 }
 
-func setupTelemetry(config *cfg.Config, genesisData *genesis.Data) (mailer Telemetry, err error) {
+func setupTelemetry(config *cfg.Config, genesisData *genesis.Data, supervisor *services.Supervisor) (
+	mailer Telemetry, err error) {
 	if config.NoTelemetry {
 		return telemetry.NewNoopMailer(), nil
 	}
@@ -455,7 +487,7 @@ func setupTelemetry(config *cfg.Config, genesisData *genesis.Data) (mailer Telem
 
 	telemetryLogger := log.NewFromGlobal(log.AddContext("pkg", "telemetry"))
 	return telemetry.BootstrapMailer(context.TODO(),
-		telemetryEndpoints, telemetryLogger)
+		telemetryEndpoints, telemetryLogger, supervisor)
 }
 
 // stores the global node name to reuse
@@ -490,13 +522,23 @@ func (n *Node) Start() error {
 	// start all dot node services
 	n.ServiceRegistry.StartAll()
 
+	// a nil channel is never ready, for nodes created without supervisors
+	var panicShutdown <-chan struct{}
+	if n.supervisors != nil {
+		panicShutdown = n.supervisors.Shutdown()
+	}
+
 	n.wg.Add(1)
 	go func() {
 		sigc := make(chan os.Signal, 1)
 		signal.Notify(sigc, syscall.SIGINT, syscall.SIGTERM)
 		defer signal.Stop(sigc)
-		<-sigc
-		logger.Info("signal interrupt, shutting down...")
+		select {
+		case <-sigc:
+			logger.Info("signal interrupt, shutting down...")
+		case <-panicShutdown:
+			logger.Info("subsystem panic, shutting down...")
+		}
 		n.Stop()
 	}()
 
@@ -518,6 +560,15 @@ func (n *Node) Stop() {
 	}
 }
 
+// AddImportHook adds the hook given the blocks imported by the node, as the
+// AddImportHook method of the core service does, so that the node can be embedded
+// by indexers processing the blocks in the same process. The hook must be added
+// before the node is started to be given all the imported blocks.
+func (n *Node) AddImportHook(hook core.ImportHook, veto bool) {
+	coreSrvc := n.ServiceRegistry.Get(&core.Service{}).(*core.Service)
+	coreSrvc.AddImportHook(hook, veto)
+}
+
 func (nodeBuilder) loadRuntime(config *cfg.Config, ns *runtime.NodeStorage,
 	stateSrvc *state.Service, ks *keystore.GlobalKeystore,
 	net *network.Service) error {
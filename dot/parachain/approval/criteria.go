@@ -0,0 +1,309 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+// Package approval implements the relay VRF assignment criteria of parachain approval voting,
+// which assign validators to check the candidates included by relay chain blocks.
+package approval
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/gtank/merlin"
+)
+
+const (
+	relayVRFModuloContext    = "A&V MOD"
+	relayVRFDelayContext     = "A&V DELAY"
+	assignedCoreContext      = "A&V ASSIGNED"
+	coreRandomnessContext    = "A&V CORE"
+	trancheRandomnessContext = "A&V TRANCHE"
+)
+
+var (
+	// ErrValidatorIndexOutOfBounds is returned by CheckAssignmentCert when the validator
+	// has no assignment key in the session.
+	ErrValidatorIndexOutOfBounds = errors.New("validator index out of bounds")
+	// ErrSampleOutOfBounds is returned by CheckAssignmentCert when the sample of a relay
+	// VRF modulo assignment is not less than the number of samples.
+	ErrSampleOutOfBounds = errors.New("sample out of bounds")
+	// ErrCoreIndexOutOfBounds is returned by CheckAssignmentCert when the claimed core is
+	// not less than the number of cores.
+	ErrCoreIndexOutOfBounds = errors.New("core index out of bounds")
+	// ErrIsInBackingGroup is returned by CheckAssignmentCert when the validator is in the
+	// group which backed the candidate, so it cannot check it.
+	ErrIsInBackingGroup = errors.New("validator is in backing group")
+	// ErrCoreIndexMismatch is returned by CheckAssignmentCert when the certificate assigns
+	// the validator to another core than the claimed core.
+	ErrCoreIndexMismatch = errors.New("core index mismatch")
+	// ErrInvalidVRF is returned by CheckAssignmentCert when the VRF signature of the
+	// certificate is invalid.
+	ErrInvalidVRF = errors.New("invalid vrf signature")
+)
+
+func relayVRFModuloTranscript(story RelayVRFStory, sample uint32) *merlin.Transcript {
+	t := merlin.NewTranscript(relayVRFModuloContext)
+	t.AppendMessage([]byte("RC-VRF"), story[:])
+	t.AppendMessage([]byte("sample"), binary.LittleEndian.AppendUint32(nil, sample))
+	return t
+}
+
+func relayVRFDelayTranscript(story RelayVRFStory, core CoreIndex) *merlin.Transcript {
+	t := merlin.NewTranscript(relayVRFDelayContext)
+	t.AppendMessage([]byte("RC-VRF"), story[:])
+	t.AppendMessage([]byte("core"), binary.LittleEndian.AppendUint32(nil, uint32(core)))
+	return t
+}
+
+// assignedCoreTranscript is committed to the proof of a relay VRF modulo assignment,
+// so the assignment cannot be claimed for another core.
+func assignedCoreTranscript(core CoreIndex) *merlin.Transcript {
+	t := merlin.NewTranscript(assignedCoreContext)
+	t.AppendMessage([]byte("core"), binary.LittleEndian.AppendUint32(nil, uint32(core)))
+	return t
+}
+
+// vrfRandomness returns the little endian integer made from the VRF output of the
+// transcript under the context.
+func vrfRandomness(output [sr25519.VRFOutputLength]byte, key *sr25519.PublicKey,
+	t *merlin.Transcript, context string) (uint32, error) {
+	inout, err := sr25519.AttachInput(output, key, t)
+	if err != nil {
+		return 0, err
+	}
+	randomness, err := inout.MakeBytes(4, []byte(context))
+	if err != nil {
+		return 0, fmt.Errorf("making vrf bytes: %w", err)
+	}
+	return binary.LittleEndian.Uint32(randomness), nil
+}
+
+// relayVRFModuloCore returns the core a relay VRF modulo sample is assigned to.
+func relayVRFModuloCore(output [sr25519.VRFOutputLength]byte, key *sr25519.PublicKey,
+	story RelayVRFStory, sample, nCores uint32) (CoreIndex, error) {
+	randomness, err := vrfRandomness(output, key, relayVRFModuloTranscript(story, sample), coreRandomnessContext)
+	if err != nil {
+		return 0, err
+	}
+	return CoreIndex(randomness % nCores), nil
+}
+
+// relayVRFDelayTranche returns the tranche of a relay VRF delay assignment to the core.
+func relayVRFDelayTranche(output [sr25519.VRFOutputLength]byte, key *sr25519.PublicKey,
+	story RelayVRFStory, core CoreIndex, config *Config) (DelayTranche, error) {
+	randomness, err := vrfRandomness(output, key, relayVRFDelayTranscript(story, core), trancheRandomnessContext)
+	if err != nil {
+		return 0, err
+	}
+	return delayTranche(randomness, config.NDelayTranches, config.ZerothDelayTrancheWidth), nil
+}
+
+// delayTranche reduces the randomness to a tranche, where the first tranches of the
+// given width are merged into tranche zero, so it is wider than the others.
+func delayTranche(randomness, nDelayTranches, zerothDelayTrancheWidth uint32) DelayTranche {
+	wideTranche := randomness % (nDelayTranches + zerothDelayTrancheWidth)
+	if wideTranche < zerothDelayTrancheWidth {
+		return 0
+	}
+	return DelayTranche(wideTranche - zerothDelayTrancheWidth)
+}
+
+func isInBackingGroup(validatorGroups [][]ValidatorIndex, validator ValidatorIndex, group GroupIndex) bool {
+	if int(group) >= len(validatorGroups) {
+		return false
+	}
+	for _, member := range validatorGroups[group] {
+		if member == validator {
+			return true
+		}
+	}
+	return false
+}
+
+// ComputeAssignments returns the assignments of the validator with the keypair to
+// check the candidates leaving the cores, by core. No assignment is returned if the
+// keypair is not an assignment key of the session. The validator is assigned to the
+// cores given by the relay VRF modulo samples in tranche zero, and to each other
+// core in the tranche given by its relay VRF delay, except the cores whose candidate
+// was backed by its group.
+func ComputeAssignments(keypair *sr25519.Keypair, story RelayVRFStory, config *Config,
+	leavingCores []LeavingCore) (assignments map[CoreIndex]OurAssignment, err error) {
+	assignments = make(map[CoreIndex]OurAssignment)
+	if config.NCores == 0 || len(config.AssignmentKeys) == 0 || len(config.ValidatorGroups) == 0 {
+		return assignments, nil
+	}
+
+	publicKey := keypair.Public().Encode()
+	validatorIndex := -1
+	for i, key := range config.AssignmentKeys {
+		if key != nil && bytes.Equal(key.Encode(), publicKey) {
+			validatorIndex = i
+			break
+		}
+	}
+	if validatorIndex < 0 {
+		return assignments, nil
+	}
+	index := ValidatorIndex(validatorIndex)
+
+	cores := make(map[CoreIndex]struct{}, len(leavingCores))
+	for _, leaving := range leavingCores {
+		if !isInBackingGroup(config.ValidatorGroups, index, leaving.GroupIndex) {
+			cores[leaving.CoreIndex] = struct{}{}
+		}
+	}
+
+	err = computeRelayVRFModuloAssignments(keypair, index, story, config, cores, assignments)
+	if err != nil {
+		return nil, fmt.Errorf("computing relay vrf modulo assignments: %w", err)
+	}
+	err = computeRelayVRFDelayAssignments(keypair, index, story, config, cores, assignments)
+	if err != nil {
+		return nil, fmt.Errorf("computing relay vrf delay assignments: %w", err)
+	}
+	return assignments, nil
+}
+
+func computeRelayVRFModuloAssignments(keypair *sr25519.Keypair, index ValidatorIndex, story RelayVRFStory,
+	config *Config, cores map[CoreIndex]struct{}, assignments map[CoreIndex]OurAssignment) error {
+	publicKey := keypair.Public().(*sr25519.PublicKey)
+	for sample := uint32(0); sample < config.RelayVRFModuloSamples; sample++ {
+		// the core is derived from the output, which does not depend on the extra
+		// transcript, so it is known before signing
+		output, _, err := keypair.VrfSign(relayVRFModuloTranscript(story, sample))
+		if err != nil {
+			return err
+		}
+		core, err := relayVRFModuloCore(output, publicKey, story, sample, config.NCores)
+		if err != nil {
+			return err
+		}
+		if _, ok := cores[core]; !ok {
+			continue
+		}
+		if _, ok := assignments[core]; ok {
+			// the first sample assigned to the core is kept
+			continue
+		}
+
+		output, proof, err := keypair.VrfSignExtra(relayVRFModuloTranscript(story, sample),
+			assignedCoreTranscript(core))
+		if err != nil {
+			return err
+		}
+		kind, err := NewAssignmentCertKind(RelayVRFModulo{Sample: sample})
+		if err != nil {
+			return err
+		}
+		assignments[core] = OurAssignment{
+			Cert: AssignmentCert{
+				Kind: kind,
+				Vrf:  VrfSignature{Output: output, Proof: proof},
+			},
+			Tranche:        0,
+			ValidatorIndex: index,
+		}
+	}
+	return nil
+}
+
+func computeRelayVRFDelayAssignments(keypair *sr25519.Keypair, index ValidatorIndex, story RelayVRFStory,
+	config *Config, cores map[CoreIndex]struct{}, assignments map[CoreIndex]OurAssignment) error {
+	publicKey := keypair.Public().(*sr25519.PublicKey)
+	for core := range cores {
+		if _, ok := assignments[core]; ok {
+			// the relay vrf modulo assignments are in tranche zero already
+			continue
+		}
+
+		output, proof, err := keypair.VrfSign(relayVRFDelayTranscript(story, core))
+		if err != nil {
+			return err
+		}
+		tranche, err := relayVRFDelayTranche(output, publicKey, story, core, config)
+		if err != nil {
+			return err
+		}
+		kind, err := NewAssignmentCertKind(RelayVRFDelay{CoreIndex: core})
+		if err != nil {
+			return err
+		}
+		assignments[core] = OurAssignment{
+			Cert: AssignmentCert{
+				Kind: kind,
+				Vrf:  VrfSignature{Output: output, Proof: proof},
+			},
+			Tranche:        tranche,
+			ValidatorIndex: index,
+		}
+	}
+	return nil
+}
+
+// CheckAssignmentCert checks the certificate of the assignment of the validator to
+// check the candidate leaving the claimed core, which was backed by the backing group,
+// and returns the tranche of the assignment.
+func CheckAssignmentCert(claimedCore CoreIndex, validatorIndex ValidatorIndex, config *Config,
+	story RelayVRFStory, cert AssignmentCert, backingGroup GroupIndex) (DelayTranche, error) {
+	if int(validatorIndex) >= len(config.AssignmentKeys) || config.AssignmentKeys[validatorIndex] == nil {
+		return 0, fmt.Errorf("%w: %d", ErrValidatorIndexOutOfBounds, validatorIndex)
+	}
+	publicKey := config.AssignmentKeys[validatorIndex]
+
+	if uint32(claimedCore) >= config.NCores {
+		return 0, fmt.Errorf("%w: %d", ErrCoreIndexOutOfBounds, claimedCore)
+	}
+	if isInBackingGroup(config.ValidatorGroups, validatorIndex, backingGroup) {
+		return 0, ErrIsInBackingGroup
+	}
+
+	kind, err := cert.Kind.Value()
+	if err != nil {
+		return 0, fmt.Errorf("getting certificate kind: %w", err)
+	}
+
+	switch kind := kind.(type) {
+	case RelayVRFModulo:
+		if kind.Sample >= config.RelayVRFModuloSamples {
+			return 0, fmt.Errorf("%w: %d", ErrSampleOutOfBounds, kind.Sample)
+		}
+
+		ok, err := publicKey.VrfVerifyExtra(relayVRFModuloTranscript(story, kind.Sample),
+			cert.Vrf.Output, cert.Vrf.Proof, assignedCoreTranscript(claimedCore))
+		if err != nil {
+			return 0, fmt.Errorf("verifying relay vrf modulo: %w", err)
+		}
+		if !ok {
+			return 0, ErrInvalidVRF
+		}
+
+		core, err := relayVRFModuloCore(cert.Vrf.Output, publicKey, story, kind.Sample, config.NCores)
+		if err != nil {
+			return 0, err
+		}
+		if core != claimedCore {
+			return 0, fmt.Errorf("%w: assigned to %d, claimed %d", ErrCoreIndexMismatch, core, claimedCore)
+		}
+		return 0, nil
+	case RelayVRFDelay:
+		if kind.CoreIndex != claimedCore {
+			return 0, fmt.Errorf("%w: assigned to %d, claimed %d", ErrCoreIndexMismatch, kind.CoreIndex, claimedCore)
+		}
+
+		ok, err := publicKey.VrfVerify(relayVRFDelayTranscript(story, kind.CoreIndex),
+			cert.Vrf.Output, cert.Vrf.Proof)
+		if err != nil {
+			return 0, fmt.Errorf("verifying relay vrf delay: %w", err)
+		}
+		if !ok {
+			return 0, ErrInvalidVRF
+		}
+
+		return relayVRFDelayTranche(cert.Vrf.Output, publicKey, story, kind.CoreIndex, config)
+	default:
+		return 0, fmt.Errorf("unsupported certificate kind: %T", kind)
+	}
+}
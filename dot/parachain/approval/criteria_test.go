@@ -0,0 +1,261 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package approval
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStory() (story RelayVRFStory) {
+	for i := range story {
+		story[i] = byte(i)
+	}
+	return story
+}
+
+func newTestConfig(t *testing.T, keyring *keystore.Sr25519Keyring) *Config {
+	t.Helper()
+
+	config := &Config{
+		ValidatorGroups:         [][]ValidatorIndex{{0, 1}, {2, 3}, {4, 5}, {6, 7, 8}},
+		NCores:                  100,
+		ZerothDelayTrancheWidth: 0,
+		RelayVRFModuloSamples:   4,
+		NDelayTranches:          89,
+	}
+	for _, keypair := range keyring.Keys {
+		config.AssignmentKeys = append(config.AssignmentKeys, keypair.Public().(*sr25519.PublicKey))
+	}
+	return config
+}
+
+func TestRelayVRF_vectors(t *testing.T) {
+	t.Parallel()
+
+	keyring, err := keystore.NewSr25519Keyring()
+	require.NoError(t, err)
+	story := newTestStory()
+
+	// the VRF outputs are deterministic, so the cores and tranches derived from them
+	// are pinned for the development keys, over the story 0x000102...1f
+	tests := map[string]struct {
+		keypair       *sr25519.Keypair
+		moduloCores   []CoreIndex
+		delayTranches []DelayTranche
+	}{
+		"alice": {
+			keypair:       keyring.KeyAlice,
+			moduloCores:   []CoreIndex{10, 62, 20, 95},
+			delayTranches: []DelayTranche{26, 41, 29, 43},
+		},
+		"bob": {
+			keypair:       keyring.KeyBob,
+			moduloCores:   []CoreIndex{77, 2, 12, 21},
+			delayTranches: []DelayTranche{17, 80, 12, 24},
+		},
+		"charlie": {
+			keypair:       keyring.KeyCharlie,
+			moduloCores:   []CoreIndex{82, 65, 13, 7},
+			delayTranches: []DelayTranche{72, 79, 11, 43},
+		},
+	}
+
+	for name, testCase := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			publicKey := testCase.keypair.Public().(*sr25519.PublicKey)
+			config := &Config{NCores: 100, NDelayTranches: 89}
+
+			var moduloCores []CoreIndex
+			for sample := uint32(0); sample < uint32(len(testCase.moduloCores)); sample++ {
+				output, _, err := testCase.keypair.VrfSign(relayVRFModuloTranscript(story, sample))
+				require.NoError(t, err)
+				core, err := relayVRFModuloCore(output, publicKey, story, sample, config.NCores)
+				require.NoError(t, err)
+				moduloCores = append(moduloCores, core)
+			}
+			assert.Equal(t, testCase.moduloCores, moduloCores)
+
+			var delayTranches []DelayTranche
+			for core := CoreIndex(0); core < CoreIndex(len(testCase.delayTranches)); core++ {
+				output, _, err := testCase.keypair.VrfSign(relayVRFDelayTranscript(story, core))
+				require.NoError(t, err)
+				tranche, err := relayVRFDelayTranche(output, publicKey, story, core, config)
+				require.NoError(t, err)
+				delayTranches = append(delayTranches, tranche)
+			}
+			assert.Equal(t, testCase.delayTranches, delayTranches)
+		})
+	}
+}
+
+func Test_delayTranche(t *testing.T) {
+	t.Parallel()
+
+	// the first tranches of the zeroth tranche width are merged into tranche zero
+	assert.Equal(t, DelayTranche(0), delayTranche(0, 40, 6))
+	assert.Equal(t, DelayTranche(0), delayTranche(5, 40, 6))
+	assert.Equal(t, DelayTranche(1), delayTranche(7, 40, 6))
+	assert.Equal(t, DelayTranche(39), delayTranche(45, 40, 6))
+	assert.Equal(t, DelayTranche(0), delayTranche(46, 40, 6))
+	assert.Equal(t, DelayTranche(10), delayTranche(10, 40, 0))
+}
+
+func TestComputeAssignments(t *testing.T) {
+	t.Parallel()
+
+	keyring, err := keystore.NewSr25519Keyring()
+	require.NoError(t, err)
+	story := newTestStory()
+	config := newTestConfig(t, keyring)
+
+	// alice is assigned to cores 10, 62, 20 and 95 by the relay vrf modulo samples
+	leavingCores := []LeavingCore{
+		{CoreIndex: 0, GroupIndex: 1},
+		{CoreIndex: 1, GroupIndex: 0},
+		{CoreIndex: 10, GroupIndex: 2},
+		{CoreIndex: 20, GroupIndex: 0},
+		{CoreIndex: 62, GroupIndex: 3},
+	}
+	assignments, err := ComputeAssignments(keyring.KeyAlice, story, config, leavingCores)
+	require.NoError(t, err)
+
+	// the cores backed by the group of alice are not assigned
+	require.Len(t, assignments, 3)
+	assert.NotContains(t, assignments, CoreIndex(1))
+	assert.NotContains(t, assignments, CoreIndex(20))
+
+	expectedKinds := map[CoreIndex]any{
+		0:  RelayVRFDelay{CoreIndex: 0},
+		10: RelayVRFModulo{Sample: 0},
+		62: RelayVRFModulo{Sample: 1},
+	}
+	expectedTranches := map[CoreIndex]DelayTranche{0: 26, 10: 0, 62: 0}
+	for core, assignment := range assignments {
+		kind, err := assignment.Cert.Kind.Value()
+		require.NoError(t, err)
+		assert.Equal(t, expectedKinds[core], kind, core)
+		assert.Equal(t, expectedTranches[core], assignment.Tranche, core)
+		assert.Equal(t, ValidatorIndex(0), assignment.ValidatorIndex)
+
+		tranche, err := CheckAssignmentCert(core, 0, config, story, assignment.Cert, 1)
+		require.NoError(t, err, core)
+		assert.Equal(t, assignment.Tranche, tranche, core)
+	}
+
+	// validators without an assignment key in the session are not assigned
+	config.AssignmentKeys = config.AssignmentKeys[1:]
+	assignments, err = ComputeAssignments(keyring.KeyAlice, story, config, leavingCores)
+	require.NoError(t, err)
+	assert.Empty(t, assignments)
+}
+
+func TestCheckAssignmentCert(t *testing.T) {
+	t.Parallel()
+
+	keyring, err := keystore.NewSr25519Keyring()
+	require.NoError(t, err)
+	story := newTestStory()
+	config := newTestConfig(t, keyring)
+
+	assignments, err := ComputeAssignments(keyring.KeyAlice, story, config, []LeavingCore{
+		{CoreIndex: 0, GroupIndex: 1},
+		{CoreIndex: 10, GroupIndex: 1},
+	})
+	require.NoError(t, err)
+	delayCert := assignments[0].Cert
+	moduloCert := assignments[10].Cert
+
+	otherStory := story
+	otherStory[0] = 0xff
+	moduloKind, err := NewAssignmentCertKind(RelayVRFModulo{Sample: 4})
+	require.NoError(t, err)
+	unsampledCert := moduloCert
+	unsampledCert.Kind = moduloKind
+
+	tests := map[string]struct {
+		core           CoreIndex
+		validatorIndex ValidatorIndex
+		story          RelayVRFStory
+		cert           AssignmentCert
+		backingGroup   GroupIndex
+		errWrapped     error
+	}{
+		"validator_index_out_of_bounds": {
+			core: 10, validatorIndex: 9, story: story, cert: moduloCert, backingGroup: 1,
+			errWrapped: ErrValidatorIndexOutOfBounds,
+		},
+		"core_index_out_of_bounds": {
+			core: 100, story: story, cert: moduloCert, backingGroup: 1,
+			errWrapped: ErrCoreIndexOutOfBounds,
+		},
+		"in_backing_group": {
+			core: 10, story: story, cert: moduloCert, backingGroup: 0,
+			errWrapped: ErrIsInBackingGroup,
+		},
+		"sample_out_of_bounds": {
+			core: 10, story: story, cert: unsampledCert, backingGroup: 1,
+			errWrapped: ErrSampleOutOfBounds,
+		},
+		"modulo_claimed_for_other_core": {
+			// the assigned core is committed to the proof
+			core: 11, story: story, cert: moduloCert, backingGroup: 1,
+			errWrapped: ErrInvalidVRF,
+		},
+		"modulo_other_story": {
+			core: 10, story: otherStory, cert: moduloCert, backingGroup: 1,
+			errWrapped: ErrInvalidVRF,
+		},
+		"modulo_other_validator": {
+			core: 10, validatorIndex: 2, story: story, cert: moduloCert, backingGroup: 0,
+			errWrapped: ErrInvalidVRF,
+		},
+		"delay_claimed_for_other_core": {
+			core: 1, story: story, cert: delayCert, backingGroup: 1,
+			errWrapped: ErrCoreIndexMismatch,
+		},
+		"delay_other_story": {
+			core: 0, story: otherStory, cert: delayCert, backingGroup: 1,
+			errWrapped: ErrInvalidVRF,
+		},
+	}
+
+	for name, testCase := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := CheckAssignmentCert(testCase.core, testCase.validatorIndex, config,
+				testCase.story, testCase.cert, testCase.backingGroup)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+		})
+	}
+}
+
+func TestAssignmentCert_scale(t *testing.T) {
+	t.Parallel()
+
+	kind, err := NewAssignmentCertKind(RelayVRFDelay{CoreIndex: 3})
+	require.NoError(t, err)
+	cert := AssignmentCert{Kind: kind}
+	cert.Vrf.Output[0] = 1
+	cert.Vrf.Proof[63] = 2
+
+	encoded, err := scale.Marshal(cert)
+	require.NoError(t, err)
+	expected := append([]byte{1, 3, 0, 0, 0, 1}, make([]byte, 31+63)...)
+	expected = append(expected, 2)
+	assert.Equal(t, expected, encoded)
+
+	var decoded AssignmentCert
+	err = scale.Unmarshal(encoded, &decoded)
+	require.NoError(t, err)
+	assert.Equal(t, cert, decoded)
+}
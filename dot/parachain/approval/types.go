@@ -0,0 +1,153 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package approval
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// RelayVRFStory is the randomness of a relay chain block, from which the assignments
+// of the validators to check the candidates included by the block are derived.
+type RelayVRFStory [32]byte
+
+// CoreIndex is the index of an availability core.
+type CoreIndex uint32
+
+// ValidatorIndex is the index of a validator in the session.
+type ValidatorIndex uint32
+
+// GroupIndex is the index of a validator group in the session.
+type GroupIndex uint32
+
+// DelayTranche is the tranche of an assignment, which is the number of tranches the
+// validator waits for after the block is imported before checking the candidate.
+type DelayTranche uint32
+
+// VrfSignature is the output and proof of a VRF signature.
+type VrfSignature struct {
+	Output [sr25519.VRFOutputLength]byte
+	Proof  [sr25519.VRFProofLength]byte
+}
+
+// AssignmentCert is the certificate a validator gives to prove its assignment to
+// check a candidate.
+type AssignmentCert struct {
+	Kind AssignmentCertKind
+	Vrf  VrfSignature
+}
+
+// RelayVRFModulo is an assignment to a core derived from the sample-th VRF output
+// over the relay VRF story, which is always in tranche zero.
+type RelayVRFModulo struct {
+	Sample uint32
+}
+
+// RelayVRFDelay is an assignment to a core, whose tranche is derived from the VRF
+// output over the relay VRF story and the core.
+type RelayVRFDelay struct {
+	CoreIndex CoreIndex
+}
+
+// assignmentCertKindValues are the kinds of assignment certificates.
+type assignmentCertKindValues interface {
+	RelayVRFModulo | RelayVRFDelay
+}
+
+// AssignmentCertKind is the kind of an assignment certificate.
+type AssignmentCertKind struct {
+	inner any
+}
+
+func setAssignmentCertKind[Value assignmentCertKindValues](mvdt *AssignmentCertKind, value Value) {
+	mvdt.inner = value
+}
+
+// SetValue sets the kind of the assignment certificate.
+func (mvdt *AssignmentCertKind) SetValue(value any) (err error) {
+	switch value := value.(type) {
+	case RelayVRFModulo:
+		setAssignmentCertKind(mvdt, value)
+		return
+
+	case RelayVRFDelay:
+		setAssignmentCertKind(mvdt, value)
+		return
+
+	default:
+		return fmt.Errorf("unsupported type")
+	}
+}
+
+// IndexValue returns the index and value of the kind of the assignment certificate.
+func (mvdt AssignmentCertKind) IndexValue() (index uint, value any, err error) {
+	switch mvdt.inner.(type) {
+	case RelayVRFModulo:
+		return 0, mvdt.inner, nil
+
+	case RelayVRFDelay:
+		return 1, mvdt.inner, nil
+
+	}
+	return 0, nil, scale.ErrUnsupportedVaryingDataTypeValue
+}
+
+// Value returns the kind of the assignment certificate.
+func (mvdt AssignmentCertKind) Value() (value any, err error) {
+	_, value, err = mvdt.IndexValue()
+	return
+}
+
+// ValueAt returns the zero value of the kind of assignment certificate at the index.
+func (mvdt AssignmentCertKind) ValueAt(index uint) (value any, err error) {
+	switch index {
+	case 0:
+		return *new(RelayVRFModulo), nil
+
+	case 1:
+		return *new(RelayVRFDelay), nil
+
+	}
+	return nil, scale.ErrUnknownVaryingDataTypeValue
+}
+
+// NewAssignmentCertKind returns an assignment certificate kind set to the value.
+func NewAssignmentCertKind(value any) (AssignmentCertKind, error) {
+	kind := AssignmentCertKind{}
+	err := kind.SetValue(value)
+	return kind, err
+}
+
+// Config is the session configuration the assignments are computed and checked with.
+type Config struct {
+	// AssignmentKeys are the assignment keys of the validators, by validator index.
+	AssignmentKeys []*sr25519.PublicKey
+	// ValidatorGroups are the validators of each backing group, by group index.
+	ValidatorGroups [][]ValidatorIndex
+	// NCores is the number of availability cores.
+	NCores uint32
+	// ZerothDelayTrancheWidth is the number of delay tranches merged into tranche zero.
+	ZerothDelayTrancheWidth uint32
+	// RelayVRFModuloSamples is the number of samples of relay VRF modulo assignments.
+	RelayVRFModuloSamples uint32
+	// NDelayTranches is the number of delay tranches.
+	NDelayTranches uint32
+}
+
+// LeavingCore is a core whose candidate was included by the relay chain block, and
+// is to be checked by the validators assigned to it.
+type LeavingCore struct {
+	CoreIndex CoreIndex
+	// GroupIndex is the index of the group which backed the candidate.
+	GroupIndex GroupIndex
+}
+
+// OurAssignment is an assignment of the local validator to check a candidate.
+type OurAssignment struct {
+	Cert           AssignmentCert
+	Tranche        DelayTranche
+	ValidatorIndex ValidatorIndex
+}
@@ -58,6 +58,11 @@
 	// IncompleteHeaderReason is used when peer sends block with invalid header.
 	IncompleteHeaderReason = "Incomplete header"
 
+	// InvalidDigestValue is used when peer sends a block header with invalid digest items.
+	InvalidDigestValue Reputation = -(1 << 12)
+	// InvalidDigestReason is used when peer sends a block header with invalid digest items.
+	InvalidDigestReason = "Invalid header digest"
+
 	// BannedThresholdValue used when we need to ban peer.
 	BannedThresholdValue Reputation = 82 * (math.MinInt32 / 100)
 	// BannedReason used when we need to ban peer.
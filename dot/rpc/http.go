@@ -14,6 +14,7 @@
 	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/lib/services"
 	"github.com/go-playground/validator/v10"
 	"github.com/gorilla/mux"
 	"github.com/gorilla/rpc/v2"
@@ -26,6 +27,8 @@ type HTTPServer struct {
 	rpcServer    *rpc.Server // Actual RPC call handler
 	serverConfig *HTTPServerConfig
 	wsConns      []*subscription.WSConn
+	// runtimeCalls is the cache of read-only runtime call results shared by the modules.
+	runtimeCalls *modules.RuntimeCallCache
 }
 
 // HTTPServerConfig configures the HTTPServer
@@ -42,6 +45,9 @@ type HTTPServerConfig struct {
 	SystemAPI           SystemAPI
 	SyncStateAPI        SyncStateAPI
 	SyncAPI             SyncAPI
+	DatabaseAPI         DatabaseAPI
+	EpochStateAPI       modules.EpochStateAPI
+	GrandpaStateAPI     modules.GrandpaStateAPI
 	NodeStorage         *runtime.NodeStorage
 	RPCUnsafe           bool
 	RPCExternal         bool
@@ -52,6 +58,15 @@ type HTTPServerConfig struct {
 	WSUnsafeExternal    bool
 	WSPort              uint32
 	Modules             []string
+	// RetainBlocks is the number of blocks behind the best block after which
+	// the cached results of the runtime calls made at a block expire.
+	RetainBlocks uint32
+	// SlowCallThreshold is the duration above which the RPC calls are logged.
+	// 0 disables the slow call log.
+	SlowCallThreshold time.Duration
+	// Supervisor runs the goroutines of the servers and websocket connections.
+	// It can be nil, in which case their panics are not recovered.
+	Supervisor *services.Supervisor
 }
 
 func (h *HTTPServerConfig) rpcUnsafeEnabled() bool {
@@ -82,8 +97,12 @@ func NewHTTPServer(cfg *HTTPServerConfig) *HTTPServer {
 		rpcServer:    rpc.NewServer(),
 		serverConfig: cfg,
 	}
+	if cfg.BlockAPI != nil {
+		server.runtimeCalls = modules.NewRuntimeCallCache(cfg.BlockAPI, uint(cfg.RetainBlocks))
+	}
 
 	server.RegisterModules(cfg.Modules)
+	cfg.Supervisor.SetSnapshotter(server)
 	return server
 }
 
@@ -96,20 +115,25 @@ func (h *HTTPServer) RegisterModules(mods []string) {
 		case "system":
 			srvc = modules.NewSystemModule(h.serverConfig.NetworkAPI, h.serverConfig.SystemAPI,
 				h.serverConfig.CoreAPI, h.serverConfig.StorageAPI, h.serverConfig.TransactionQueueAPI,
-				h.serverConfig.BlockAPI, h.serverConfig.SyncAPI)
+				h.serverConfig.BlockAPI, h.serverConfig.SyncAPI).WithRuntimeCallCache(h.runtimeCalls)
 		case "author":
 			srvc = modules.NewAuthorModule(h.logger, h.serverConfig.CoreAPI, h.serverConfig.TransactionQueueAPI)
 		case "chain":
 			srvc = modules.NewChainModule(h.serverConfig.BlockAPI)
 		case "grandpa":
-			srvc = modules.NewGrandpaModule(h.serverConfig.BlockAPI, h.serverConfig.BlockFinalityAPI)
+			srvc = modules.NewGrandpaModule(h.serverConfig.BlockAPI, h.serverConfig.BlockFinalityAPI,
+				h.serverConfig.GrandpaStateAPI)
 		case "state":
 			srvc = modules.NewStateModule(h.serverConfig.NetworkAPI, h.serverConfig.StorageAPI,
-				h.serverConfig.CoreAPI, h.serverConfig.BlockAPI)
+				h.serverConfig.CoreAPI, h.serverConfig.BlockAPI).WithRuntimeCallCache(h.runtimeCalls)
 		case "rpc":
 			srvc = modules.NewRPCModule(h.serverConfig.RPCAPI)
 		case "dev":
-			srvc = modules.NewDevModule(h.serverConfig.BlockProducerAPI, h.serverConfig.NetworkAPI)
+			srvc = modules.NewDevModule(h.serverConfig.BlockProducerAPI, h.serverConfig.NetworkAPI,
+				h.serverConfig.DatabaseAPI)
+		case "babe":
+			srvc = modules.NewBabeModule(h.serverConfig.BlockProducerAPI, h.serverConfig.BlockAPI,
+				h.serverConfig.EpochStateAPI)
 		case "offchain":
 			srvc = modules.NewOffchainModule(h.serverConfig.NodeStorage)
 		case "childstate":
@@ -117,7 +141,7 @@ func (h *HTTPServer) RegisterModules(mods []string) {
 		case "syncstate":
 			srvc = modules.NewSyncStateModule(h.serverConfig.SyncStateAPI)
 		case "payment":
-			srvc = modules.NewPaymentModule(h.serverConfig.BlockAPI)
+			srvc = modules.NewPaymentModule(h.serverConfig.BlockAPI).WithRuntimeCallCache(h.runtimeCalls)
 		default:
 			h.logger.Warn("Unrecognised module: " + mod)
 			continue
@@ -140,9 +164,16 @@ func (h *HTTPServer) Start() error {
 	h.rpcServer.RegisterCodec(NewDotUpCodec(), "application/json")
 	h.rpcServer.RegisterCodec(NewDotUpCodec(), "application/json;charset=UTF-8")
 
+	metrics := &callMetrics{
+		logger:            h.logger,
+		slowCallThreshold: h.serverConfig.SlowCallThreshold,
+	}
+	h.rpcServer.RegisterBeforeFunc(metrics.before)
+	h.rpcServer.RegisterAfterFunc(metrics.after)
+
 	h.logger.Infof("Starting HTTP Server on host %s and port %d...", h.serverConfig.Host, h.serverConfig.RPCPort)
 	r := mux.NewRouter()
-	r.Handle("/", h.rpcServer)
+	r.Handle("/", metrics.instrument(h.rpcServer))
 
 	validate := validator.New()
 	// Add custom validator for `common.Hash`
@@ -150,7 +181,7 @@ func (h *HTTPServer) Start() error {
 
 	h.rpcServer.RegisterValidateRequestFunc(rpcValidator(h.serverConfig, validate))
 
-	go func() {
+	h.serverConfig.Supervisor.Go(func() {
 		server := &http.Server{
 			Addr:              fmt.Sprintf(":%d", h.serverConfig.RPCPort),
 			ReadHeaderTimeout: 5 * time.Second,
@@ -161,7 +192,7 @@ func (h *HTTPServer) Start() error {
 		if err != nil {
 			h.logger.Errorf("http error: %s", err)
 		}
-	}()
+	})
 
 	if !h.serverConfig.exposeWS() {
 		return nil
@@ -171,7 +202,7 @@ func (h *HTTPServer) Start() error {
 		h.serverConfig.Host, h.serverConfig.WSPort)
 	ws := mux.NewRouter()
 	ws.Handle("/", h)
-	go func() {
+	h.serverConfig.Supervisor.Go(func() {
 		wsServer := &http.Server{
 			Addr:              fmt.Sprintf(":%d", h.serverConfig.WSPort),
 			ReadHeaderTimeout: 5 * time.Second,
@@ -182,7 +213,7 @@ func (h *HTTPServer) Start() error {
 		if err != nil {
 			h.logger.Errorf("http error: %s", err)
 		}
-	}()
+	})
 
 	return nil
 }
@@ -243,7 +274,17 @@ func (h *HTTPServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	wsc := NewWSConn(ws, h.serverConfig)
 	h.wsConns = append(h.wsConns, wsc)
 
-	go wsc.HandleConn()
+	h.serverConfig.Supervisor.Go(wsc.HandleConn)
+}
+
+// Snapshot returns a description of the state of the server and of its websocket connections.
+func (h *HTTPServer) Snapshot() string {
+	subscriptions := 0
+	for _, conn := range h.wsConns {
+		subscriptions += len(conn.Subscriptions)
+	}
+	return fmt.Sprintf("rpc port %d, websocket port %d, modules %v, %d websocket connections with %d subscriptions",
+		h.serverConfig.RPCPort, h.serverConfig.WSPort, h.serverConfig.Modules, len(h.wsConns), subscriptions)
 }
 
 // NewWSConn to create new WebSocket Connection struct
@@ -256,6 +297,7 @@ func NewWSConn(conn *websocket.Conn, cfg *HTTPServerConfig) *subscription.WSConn
 		BlockAPI:      cfg.BlockAPI,
 		CoreAPI:       cfg.CoreAPI,
 		TxStateAPI:    cfg.TransactionQueueAPI,
+		RoundStateAPI: cfg.BlockFinalityAPI,
 		RPCHost:       fmt.Sprintf("http://%s:%d/", cfg.Host, cfg.RPCPort),
 		HTTP: &http.Client{
 			Timeout: time.Second * 30,
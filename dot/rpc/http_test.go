@@ -58,7 +58,8 @@ func TestRegisterModules(t *testing.T) {
 
 func TestUnsafeRPCProtection(t *testing.T) {
 	cfg := &HTTPServerConfig{
-		Modules:           []string{"system", "author", "chain", "state", "rpc", "grandpa", "dev", "syncstate"},
+		Modules: []string{"system", "author", "chain", "state", "rpc", "grandpa", "dev", "syncstate", "babe",
+			"offchain", "childstate"},
 		RPCPort:           7878,
 		RPCAPI:            NewService(),
 		RPCUnsafeExternal: false,
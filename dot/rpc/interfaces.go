@@ -5,10 +5,12 @@
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/ChainSafe/gossamer/dot/core"
 	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/babe"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
 	"github.com/ChainSafe/gossamer/lib/genesis"
@@ -56,12 +58,14 @@ type NetworkAPI interface {
 	Health() common.Health
 	NetworkState() common.NetworkState
 	Peers() []common.PeerInfo
+	PeerStats() []common.PeerStats
 	NodeRoles() common.NetworkRole
 	Stop() error
 	Start() error
 	StartingBlock() int64
 	AddReservedPeers(addrs ...string) error
 	RemoveReservedPeers(addrs ...string) error
+	RotateIdentity(drainTimeout time.Duration) (peerID string, err error)
 }
 
 // BlockProducerAPI is the interface for BlockProducer methods
@@ -70,14 +74,15 @@ type BlockProducerAPI interface {
 	Resume() error
 	EpochLength() uint64
 	SlotDuration() uint64
+	EpochAuthorship() (map[string]babe.EpochAuthorship, error)
 }
 
 // TransactionStateAPI ...
 type TransactionStateAPI interface {
 	AddToPool(*transaction.ValidTransaction) common.Hash
 	Pending() []*transaction.ValidTransaction
-	GetStatusNotifierChannel(ext types.Extrinsic) chan transaction.Status
-	FreeStatusNotifierChannel(ch chan transaction.Status)
+	GetStatusNotifierChannel(ext types.Extrinsic) chan transaction.StatusNotification
+	FreeStatusNotifierChannel(ch chan transaction.StatusNotification)
 }
 
 // CoreAPI is the interface for the core methods
@@ -89,6 +94,7 @@ type CoreAPI interface {
 	GetMetadata(bhash *common.Hash) ([]byte, error)
 	DecodeSessionKeys(enc []byte) ([]byte, error)
 	GetReadProofAt(block common.Hash, keys [][]byte) (common.Hash, [][]byte, error)
+	AuthorityStatus() (*core.AuthorityStatus, error)
 }
 
 // API is the interface for methods related to RPC service
@@ -113,6 +119,15 @@ type BlockFinalityAPI interface {
 	GetVoters() grandpa.Voters
 	PreVotes() []ed25519.PublicKeyBytes
 	PreCommits() []ed25519.PublicKeyBytes
+	DumpMessageJournal() (string, error)
+	ImportJustification(hash common.Hash, justification []byte) (round, setID uint64, err error)
+	GetRoundStateNotifierChannel() chan *grandpa.RoundStateUpdate
+	FreeRoundStateNotifierChannel(ch chan *grandpa.RoundStateUpdate)
+}
+
+// DatabaseAPI is the interface to get statistics of the node database
+type DatabaseAPI interface {
+	DatabaseStats(largestKeys int) (*state.DatabaseStats, error)
 }
 
 // SyncStateAPI is the interface to interact with sync state.
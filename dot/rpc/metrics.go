@@ -0,0 +1,131 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package rpc
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/gorilla/rpc/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	rpcCallDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "gossamer_rpc",
+		Name:      "call_duration_seconds",
+		Help:      "duration of the RPC calls, by method",
+		Buckets:   []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 5, 10, 30},
+	}, []string{"method"})
+	rpcCallErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "gossamer_rpc",
+		Name:      "call_errors_total",
+		Help:      "total number of RPC calls returning an error, by method",
+	}, []string{"method"})
+	rpcCallsInFlight = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "gossamer_rpc",
+		Name:      "calls_in_flight",
+		Help:      "number of RPC calls being processed, by method",
+	}, []string{"method"})
+)
+
+type rpcCallKey struct{}
+
+// rpcCall is the state of an RPC call being processed, stored in the context of its request.
+type rpcCall struct {
+	start time.Time
+	// body counts the bytes of the JSON-RPC request, whose size is mostly the size
+	// of the params of the call.
+	body *countingReadCloser
+}
+
+// callMetrics instruments the RPC calls with per method latency, error and in flight
+// metrics, and logs the calls slower than the slow call threshold. The calls made over
+// websocket connections are forwarded to the HTTP server, so they are instrumented too,
+// with the node as caller.
+type callMetrics struct {
+	logger            *log.Logger
+	slowCallThreshold time.Duration
+}
+
+// instrument wraps the handler of the RPC server, recording the start time and request
+// size of the calls in the context of their requests.
+func (m *callMetrics) instrument(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call := &rpcCall{start: time.Now()}
+		r = r.WithContext(context.WithValue(r.Context(), rpcCallKey{}, call))
+		if r.Body != nil {
+			call.body = &countingReadCloser{ReadCloser: r.Body}
+			r.Body = call.body
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// before is registered as the before function of the RPC server, called once the method
+// of the call is decoded.
+func (*callMetrics) before(info *rpc.RequestInfo) {
+	rpcCallsInFlight.WithLabelValues(methodLabel(info.Method)).Inc()
+}
+
+// after is registered as the after function of the RPC server, called once the response
+// of the call is written.
+func (m *callMetrics) after(info *rpc.RequestInfo) {
+	method := methodLabel(info.Method)
+	rpcCallsInFlight.WithLabelValues(method).Dec()
+	if info.Error != nil {
+		rpcCallErrors.WithLabelValues(method).Inc()
+	}
+
+	call, ok := info.Request.Context().Value(rpcCallKey{}).(*rpcCall)
+	if !ok {
+		return
+	}
+	duration := time.Since(call.start)
+	rpcCallDuration.WithLabelValues(method).Observe(duration.Seconds())
+
+	if m.slowCallThreshold == 0 || duration < m.slowCallThreshold {
+		return
+	}
+	var requestSize int64
+	if call.body != nil {
+		requestSize = call.body.read
+	}
+	m.logger.Warnf("slow rpc call %s: request size %d bytes, duration %s, caller %s",
+		method, requestSize, duration, callerIP(info.Request))
+}
+
+// methodLabel returns the JSON-RPC name of the method, such as chain_getBlock.
+func methodLabel(method string) string {
+	label, err := snakeCaseFormat(method)
+	if err != nil {
+		return method
+	}
+	return label
+}
+
+func callerIP(r *http.Request) string {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return ip
+}
+
+// countingReadCloser counts the bytes read from the body of a request.
+type countingReadCloser struct {
+	io.ReadCloser
+	read int64
+}
+
+func (c *countingReadCloser) Read(p []byte) (n int, err error) {
+	n, err = c.ReadCloser.Read(p)
+	c.read += int64(n)
+	return n, err
+}
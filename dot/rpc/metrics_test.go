@@ -0,0 +1,83 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package rpc
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/gorilla/rpc/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errMetricsTestCall = errors.New("test call failed")
+
+type metricsTestService struct {
+	inFlight float64
+}
+
+// MetricsTestRequest is exported since the RPC server only registers methods whose
+// arguments are exported types.
+type MetricsTestRequest struct {
+	Fail bool `json:"fail"`
+}
+
+func (s *metricsTestService) Call(_ *http.Request, req *MetricsTestRequest, res *string) error {
+	s.inFlight = testutil.ToFloat64(rpcCallsInFlight.WithLabelValues("metricstest_call"))
+	if req.Fail {
+		return errMetricsTestCall
+	}
+	*res = "ok"
+	return nil
+}
+
+func Test_callMetrics(t *testing.T) {
+	var logs bytes.Buffer
+	metrics := &callMetrics{
+		logger:            log.New(log.SetWriter(&logs)),
+		slowCallThreshold: time.Hour,
+	}
+
+	service := &metricsTestService{}
+	server := rpc.NewServer()
+	server.RegisterCodec(NewDotUpCodec(), "application/json")
+	require.NoError(t, server.RegisterService(service, "metricstest"))
+	server.RegisterBeforeFunc(metrics.before)
+	server.RegisterAfterFunc(metrics.after)
+	handler := metrics.instrument(server)
+
+	call := func(params string) {
+		body := `{"jsonrpc":"2.0","method":"metricstest_call","params":` + params + `,"id":1}`
+		request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		request.Header.Set("Content-Type", "application/json")
+		handler.ServeHTTP(httptest.NewRecorder(), request)
+	}
+
+	call(`{"fail":false}`)
+	call(`{"fail":true}`)
+
+	assert.Equal(t, float64(1), service.inFlight)
+	assert.Equal(t, float64(0), testutil.ToFloat64(rpcCallsInFlight.WithLabelValues("metricstest_call")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(rpcCallErrors.WithLabelValues("metricstest_call")))
+	var metric dto.Metric
+	histogram := rpcCallDuration.WithLabelValues("metricstest_call").(prometheus.Histogram)
+	require.NoError(t, histogram.Write(&metric))
+	assert.Equal(t, uint64(2), metric.GetHistogram().GetSampleCount())
+	assert.Empty(t, logs.String())
+
+	metrics.slowCallThreshold = time.Nanosecond
+	call(`{"fail":false}`)
+	assert.Contains(t, logs.String(), "slow rpc call metricstest_call: request size 76 bytes")
+	assert.Contains(t, logs.String(), "caller 192.0.2.1")
+}
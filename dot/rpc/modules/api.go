@@ -4,9 +4,12 @@
 package modules
 
 import (
+	"time"
+
 	"github.com/ChainSafe/gossamer/dot/core"
 	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/babe"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
 	"github.com/ChainSafe/gossamer/lib/genesis"
@@ -54,12 +57,14 @@ type NetworkAPI interface {
 	Health() common.Health
 	NetworkState() common.NetworkState
 	Peers() []common.PeerInfo
+	PeerStats() []common.PeerStats
 	NodeRoles() common.NetworkRole
 	Stop() error
 	Start() error
 	StartingBlock() int64
 	AddReservedPeers(addrs ...string) error
 	RemoveReservedPeers(addrs ...string) error
+	RotateIdentity(drainTimeout time.Duration) (peerID string, err error)
 }
 
 // BlockProducerAPI is the interface for BlockProducer methods
@@ -68,6 +73,7 @@ type BlockProducerAPI interface {
 	Resume() error
 	EpochLength() uint64
 	SlotDuration() uint64
+	EpochAuthorship() (map[string]babe.EpochAuthorship, error)
 }
 
 // TransactionStateAPI ...
@@ -84,6 +90,7 @@ type CoreAPI interface {
 	GetMetadata(bhash *common.Hash) ([]byte, error)
 	DecodeSessionKeys(enc []byte) ([]byte, error)
 	GetReadProofAt(block common.Hash, keys [][]byte) (common.Hash, [][]byte, error)
+	AuthorityStatus() (*core.AuthorityStatus, error)
 }
 
 // RPCAPI is the interface for methods related to RPC service
@@ -107,6 +114,8 @@ type BlockFinalityAPI interface {
 	GetVoters() grandpa.Voters
 	PreVotes() []ed25519.PublicKeyBytes
 	PreCommits() []ed25519.PublicKeyBytes
+	DumpMessageJournal() (string, error)
+	ImportJustification(hash common.Hash, justification []byte) (round, setID uint64, err error)
 }
 
 // RuntimeStorageAPI is the interface to interacts with the node storage
@@ -117,6 +126,29 @@ type RuntimeStorageAPI interface {
 	GetPersistent(k []byte) ([]byte, error)
 }
 
+// EpochStateAPI is the interface for the BABE epoch state
+type EpochStateAPI interface {
+	GetEpochForBlock(header *types.Header) (uint64, error)
+	GetEpochDataRaw(epoch uint64, header *types.Header) (*types.EpochDataRaw, error)
+	GetConfigData(epoch uint64, header *types.Header) (*types.ConfigData, error)
+	GetStartSlotForEpoch(epoch uint64, bestBlockHash common.Hash) (uint64, error)
+	GetEpochLength() uint64
+}
+
+// GrandpaStateAPI is the interface for the GRANDPA authority sets state
+type GrandpaStateAPI interface {
+	GetCurrentSetID() (uint64, error)
+	GetAuthorities(setID uint64) ([]types.GrandpaVoter, error)
+	AuthoritiesAt(blockHash common.Hash) (setID uint64, voters []types.GrandpaVoter, err error)
+	GetSetIDByBlockNumber(blockNumber uint) (uint64, error)
+	GetSetIDChange(setID uint64) (blockNumber uint, err error)
+}
+
+// DatabaseAPI is the interface to get statistics of the node database
+type DatabaseAPI interface {
+	DatabaseStats(largestKeys int) (*state.DatabaseStats, error)
+}
+
 // SyncStateAPI is the interface to interact with sync state.
 type SyncStateAPI interface {
 	GenSyncSpec(raw bool) (*genesis.Genesis, error)
@@ -8,6 +8,7 @@
 	"net/http"
 	"strings"
 
+	"github.com/ChainSafe/gossamer/dot/core"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/lib/common"
@@ -91,6 +92,32 @@ type ExtrinsicStatus struct {
 // ExtrinsicHashResponse is used as Extrinsic hash response
 type ExtrinsicHashResponse string
 
+// AuthorityKeyStatusResponse is the status of the local keys of a consensus engine
+type AuthorityKeyStatusResponse struct {
+	LocalKeys []string `json:"localKeys"`
+	Active    bool     `json:"active"`
+	Queued    bool     `json:"queued"`
+}
+
+// AuthorityStatusResponse is the response to the RPC call author_authorityStatus
+type AuthorityStatusResponse struct {
+	BlockHash string                     `json:"blockHash"`
+	Babe      AuthorityKeyStatusResponse `json:"babe"`
+	Grandpa   AuthorityKeyStatusResponse `json:"grandpa"`
+}
+
+func newAuthorityKeyStatusResponse(status core.AuthorityKeyStatus) AuthorityKeyStatusResponse {
+	localKeys := make([]string, len(status.LocalKeys))
+	for i, key := range status.LocalKeys {
+		localKeys[i] = key.String()
+	}
+	return AuthorityKeyStatusResponse{
+		LocalKeys: localKeys,
+		Active:    status.Active,
+		Queued:    status.Queued,
+	}
+}
+
 // NewAuthorModule creates a new Author module.
 func NewAuthorModule(logger *log.Logger, coreAPI CoreAPI, txStateAPI TransactionStateAPI) *AuthorModule {
 	logger = logger.New(log.AddContext("service", "RPC"), log.AddContext("module", "author"))
@@ -179,6 +206,22 @@ func (am *AuthorModule) HasKey(r *http.Request, req *[]string, res *bool) error
 	return err
 }
 
+// AuthorityStatus returns whether the local BABE and GRANDPA keys are in the current
+// authority sets and in the keys queued for the next session.
+func (am *AuthorModule) AuthorityStatus(r *http.Request, _ *EmptyRequest, res *AuthorityStatusResponse) error {
+	status, err := am.coreAPI.AuthorityStatus()
+	if err != nil {
+		return err
+	}
+
+	*res = AuthorityStatusResponse{
+		BlockHash: status.BlockHash.String(),
+		Babe:      newAuthorityKeyStatusResponse(status.BABE),
+		Grandpa:   newAuthorityKeyStatusResponse(status.GRANDPA),
+	}
+	return nil
+}
+
 // PendingExtrinsics Returns all pending extrinsics
 func (am *AuthorModule) PendingExtrinsics(r *http.Request, req *EmptyRequest, res *PendingExtrinsicsResponse) error {
 	pending := am.txStateAPI.Pending()
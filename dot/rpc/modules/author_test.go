@@ -10,6 +10,7 @@
 	"net/http"
 	"testing"
 
+	"github.com/ChainSafe/gossamer/dot/core"
 	"github.com/ChainSafe/gossamer/dot/rpc/modules/mocks"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/internal/log"
@@ -494,3 +495,46 @@ type args struct {
 		})
 	}
 }
+
+func TestAuthorModule_AuthorityStatus(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+
+	status := &core.AuthorityStatus{
+		BlockHash: common.Hash{1},
+		BABE: core.AuthorityKeyStatus{
+			LocalKeys: []common.Hash{{2}},
+			Active:    true,
+		},
+		GRANDPA: core.AuthorityKeyStatus{
+			LocalKeys: []common.Hash{{3}},
+			Queued:    true,
+		},
+	}
+	coreAPI := mocks.NewMockCoreAPI(ctrl)
+	coreAPI.EXPECT().AuthorityStatus().Return(status, nil)
+
+	module := &AuthorModule{coreAPI: coreAPI}
+	var res AuthorityStatusResponse
+	err := module.AuthorityStatus(nil, nil, &res)
+	require.NoError(t, err)
+
+	expected := AuthorityStatusResponse{
+		BlockHash: common.Hash{1}.String(),
+		Babe: AuthorityKeyStatusResponse{
+			LocalKeys: []string{common.Hash{2}.String()},
+			Active:    true,
+		},
+		Grandpa: AuthorityKeyStatusResponse{
+			LocalKeys: []string{common.Hash{3}.String()},
+			Queued:    true,
+		},
+	}
+	assert.Equal(t, expected, res)
+
+	errTest := errors.New("test error")
+	coreAPI.EXPECT().AuthorityStatus().Return(nil, errTest)
+	err = module.AuthorityStatus(nil, nil, &res)
+	assert.ErrorIs(t, err, errTest)
+}
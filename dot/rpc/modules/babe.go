@@ -0,0 +1,323 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package modules
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/babe"
+	"github.com/ChainSafe/gossamer/lib/common"
+)
+
+const (
+	// defaultBlockVRFOutputs is the number of blocks returned by babe_blockVrfOutputs if no count is given
+	defaultBlockVRFOutputs = 10
+	// maxBlockVRFOutputs is the maximum number of blocks returned by babe_blockVrfOutputs
+	maxBlockVRFOutputs = 1000
+)
+
+var errTooManyBlocks = errors.New("too many blocks requested")
+
+// BabeModule is an RPC module providing access to BABE block production information
+type BabeModule struct {
+	blockProducerAPI BlockProducerAPI
+	blockAPI         BlockAPI
+	epochState       EpochStateAPI
+}
+
+// NewBabeModule creates a new Babe module.
+func NewBabeModule(bp BlockProducerAPI, blockAPI BlockAPI, epochState EpochStateAPI) *BabeModule {
+	return &BabeModule{
+		blockProducerAPI: bp,
+		blockAPI:         blockAPI,
+		epochState:       epochState,
+	}
+}
+
+// EpochAuthorshipResponse maps the SS58 address of each local BABE key to the slots
+// of the current epoch it can claim.
+type EpochAuthorshipResponse map[string]babe.EpochAuthorship
+
+// EpochAuthorship returns the upcoming slots of the current epoch that can be claimed
+// by the local BABE keys, as primary, secondary plain or secondary VRF slots.
+func (bm *BabeModule) EpochAuthorship(_ *http.Request, _ *EmptyRequest, res *EpochAuthorshipResponse) error {
+	if bm.blockProducerAPI == nil {
+		return errors.New("not a block producer")
+	}
+
+	authorship, err := bm.blockProducerAPI.EpochAuthorship()
+	if err != nil {
+		return err
+	}
+
+	*res = authorship
+	return nil
+}
+
+// BabeRandomnessRequest is the request of the epoch randomness at a block,
+// defaulting to the best block.
+type BabeRandomnessRequest struct {
+	Block *common.Hash
+}
+
+// EpochRandomness is the randomness of an epoch.
+type EpochRandomness struct {
+	Epoch      uint64      `json:"epoch"`
+	Randomness common.Hash `json:"randomness"`
+}
+
+// RandomnessDerivation is the recomputation of the randomness of the next epoch,
+// which hashes the randomness of the current epoch, the index of the next epoch
+// and the VRF randomness of the primary blocks of the previous epoch.
+type RandomnessDerivation struct {
+	// VRFEpoch is the epoch of the blocks whose VRF randomness is accumulated.
+	VRFEpoch uint64 `json:"vrfEpoch"`
+	// VRFOutputs is the number of primary blocks of VRFEpoch, whose VRF randomness is accumulated.
+	VRFOutputs uint32 `json:"vrfOutputs"`
+	// Computed is the recomputed randomness of the next epoch.
+	Computed common.Hash `json:"computed"`
+	// Matches is true if the recomputed randomness is the announced randomness of the next epoch.
+	Matches bool `json:"matches"`
+}
+
+// BabeRandomnessResponse is the randomness of the current and next epochs at a block.
+type BabeRandomnessResponse struct {
+	Block   common.Hash      `json:"block"`
+	Current EpochRandomness  `json:"current"`
+	Next    *EpochRandomness `json:"next"`
+	// Derivation is nil if the next epoch randomness is not announced yet or is
+	// the genesis randomness.
+	Derivation *RandomnessDerivation `json:"derivation"`
+}
+
+// Randomness returns the randomness of the current and next epochs at the block,
+// and how the randomness of the next epoch is derived from the VRF outputs of
+// the primary blocks of the previous epoch.
+func (bm *BabeModule) Randomness(_ *http.Request, req *BabeRandomnessRequest, res *BabeRandomnessResponse) error {
+	header, err := bm.requestedHeader(req.Block)
+	if err != nil {
+		return err
+	}
+
+	epoch, err := bm.epochState.GetEpochForBlock(header)
+	if err != nil {
+		return fmt.Errorf("getting epoch of block %s: %w", header.Hash(), err)
+	}
+	epochData, err := bm.epochState.GetEpochDataRaw(epoch, header)
+	if err != nil {
+		return fmt.Errorf("getting data of epoch %d: %w", epoch, err)
+	}
+
+	response := BabeRandomnessResponse{
+		Block:   header.Hash(),
+		Current: EpochRandomness{Epoch: epoch, Randomness: epochData.Randomness},
+	}
+
+	nextEpochData, err := bm.epochState.GetEpochDataRaw(epoch+1, header)
+	if err != nil || nextEpochData == nil {
+		// the next epoch data is announced by the first block of the epoch
+		*res = response
+		return nil
+	}
+	response.Next = &EpochRandomness{Epoch: epoch + 1, Randomness: nextEpochData.Randomness}
+
+	// the randomness of the first two epochs is the genesis randomness
+	if epoch == 0 {
+		*res = response
+		return nil
+	}
+
+	vrfRandomness, err := bm.epochVRFRandomness(header, epoch-1)
+	if err != nil {
+		return err
+	}
+	computed, err := babe.NextEpochRandomness(epochData.Randomness, epoch+1, vrfRandomness)
+	if err != nil {
+		return fmt.Errorf("computing next epoch randomness: %w", err)
+	}
+	response.Derivation = &RandomnessDerivation{
+		VRFEpoch:   epoch - 1,
+		VRFOutputs: uint32(len(vrfRandomness)),
+		Computed:   computed,
+		Matches:    computed == nextEpochData.Randomness,
+	}
+
+	*res = response
+	return nil
+}
+
+// BlockVRFOutputsRequest is the request of the VRF outputs of the count blocks
+// ending at a block, defaulting to the best block.
+type BlockVRFOutputsRequest struct {
+	Count uint32
+	Block *common.Hash
+}
+
+// BlockVRFOutput is the slot claim of a block, with its VRF output and the
+// randomness it contributes to the epoch randomness, which are nil for secondary plain claims.
+type BlockVRFOutput struct {
+	Number         uint         `json:"number"`
+	Hash           common.Hash  `json:"hash"`
+	Epoch          uint64       `json:"epoch"`
+	Slot           uint64       `json:"slot"`
+	AuthorityIndex uint32       `json:"authorityIndex"`
+	Claim          string       `json:"claim"`
+	VRFOutput      *common.Hash `json:"vrfOutput"`
+	Randomness     *common.Hash `json:"randomness"`
+}
+
+// BlockVrfOutputs returns the slot claims and VRF outputs of the recent blocks,
+// from the most recent one.
+func (bm *BabeModule) BlockVrfOutputs(_ *http.Request, req *BlockVRFOutputsRequest,
+	res *[]BlockVRFOutput) error {
+	count := req.Count
+	if count == 0 {
+		count = defaultBlockVRFOutputs
+	}
+	if count > maxBlockVRFOutputs {
+		return fmt.Errorf("%w: %d is more than %d", errTooManyBlocks, count, maxBlockVRFOutputs)
+	}
+
+	header, err := bm.requestedHeader(req.Block)
+	if err != nil {
+		return err
+	}
+
+	epochs := make(map[uint64]*types.EpochDataRaw)
+	outputs := make([]BlockVRFOutput, 0, count)
+	for header.Number > 0 {
+		epoch, err := bm.epochState.GetEpochForBlock(header)
+		if err != nil {
+			return fmt.Errorf("getting epoch of block %s: %w", header.Hash(), err)
+		}
+
+		output, err := bm.blockVRFOutput(header, epoch, epochs)
+		if err != nil {
+			return err
+		}
+		outputs = append(outputs, output)
+		if uint32(len(outputs)) == count {
+			break
+		}
+
+		parentHash := header.ParentHash
+		header, err = bm.blockAPI.GetHeader(parentHash)
+		if err != nil {
+			return fmt.Errorf("getting header of block %s: %w", parentHash, err)
+		}
+	}
+
+	*res = outputs
+	return nil
+}
+
+// requestedHeader returns the header of the block, or of the best block if the block is nil.
+func (bm *BabeModule) requestedHeader(block *common.Hash) (*types.Header, error) {
+	hash := bm.blockAPI.BestBlockHash()
+	if block != nil {
+		hash = *block
+	}
+
+	header, err := bm.blockAPI.GetHeader(hash)
+	if err != nil {
+		return nil, fmt.Errorf("getting header of block %s: %w", hash, err)
+	}
+	return header, nil
+}
+
+// blockVRFOutput returns the slot claim of the block of the epoch, caching the epoch data in epochs.
+func (bm *BabeModule) blockVRFOutput(header *types.Header, epoch uint64, epochs map[uint64]*types.EpochDataRaw) (
+	output BlockVRFOutput, err error) {
+	hash := header.Hash()
+	blockVRF, err := babe.GetBlockVRF(header)
+	if err != nil {
+		return output, fmt.Errorf("getting slot claim of block %s: %w", hash, err)
+	}
+
+	output = BlockVRFOutput{
+		Number:         header.Number,
+		Hash:           hash,
+		Epoch:          epoch,
+		Slot:           blockVRF.Slot,
+		AuthorityIndex: blockVRF.AuthorityIndex,
+		Claim:          blockVRF.Claim,
+	}
+	if blockVRF.Output == nil {
+		return output, nil
+	}
+
+	randomness, err := bm.vrfRandomness(header, blockVRF, epoch, epochs)
+	if err != nil {
+		return output, err
+	}
+
+	vrfOutput := common.Hash(*blockVRF.Output)
+	output.VRFOutput = &vrfOutput
+	output.Randomness = (*common.Hash)(&randomness)
+	return output, nil
+}
+
+// vrfRandomness returns the VRF randomness of the slot claim of the block of
+// the epoch, caching the epoch data in epochs.
+func (bm *BabeModule) vrfRandomness(header *types.Header, blockVRF babe.BlockVRF, epoch uint64,
+	epochs map[uint64]*types.EpochDataRaw) (randomness babe.Randomness, err error) {
+	epochData, ok := epochs[epoch]
+	if !ok {
+		epochData, err = bm.epochState.GetEpochDataRaw(epoch, header)
+		if err != nil {
+			return randomness, fmt.Errorf("getting data of epoch %d: %w", epoch, err)
+		}
+		epochs[epoch] = epochData
+	}
+
+	randomness, _, err = babe.VRFRandomness(blockVRF, epoch, epochData)
+	if err != nil {
+		return randomness, fmt.Errorf("computing VRF randomness of block %s: %w", header.Hash(), err)
+	}
+	return randomness, nil
+}
+
+// epochVRFRandomness returns the VRF randomness of the primary blocks of the epoch,
+// in block order, walking back the chain from the header. The VRF outputs of the
+// secondary blocks are not accumulated into the epoch randomness.
+func (bm *BabeModule) epochVRFRandomness(header *types.Header, epoch uint64) ([]babe.Randomness, error) {
+	epochs := make(map[uint64]*types.EpochDataRaw)
+	var vrfRandomness []babe.Randomness
+	for header.Number > 0 {
+		blockEpoch, err := bm.epochState.GetEpochForBlock(header)
+		if err != nil {
+			return nil, fmt.Errorf("getting epoch of block %s: %w", header.Hash(), err)
+		}
+		if blockEpoch < epoch {
+			break
+		}
+
+		if blockEpoch == epoch {
+			blockVRF, err := babe.GetBlockVRF(header)
+			if err != nil {
+				return nil, fmt.Errorf("getting slot claim of block %s: %w", header.Hash(), err)
+			}
+			if blockVRF.Claim == babe.PrimaryClaim {
+				randomness, err := bm.vrfRandomness(header, blockVRF, epoch, epochs)
+				if err != nil {
+					return nil, err
+				}
+				vrfRandomness = append(vrfRandomness, randomness)
+			}
+		}
+
+		parentHash := header.ParentHash
+		header, err = bm.blockAPI.GetHeader(parentHash)
+		if err != nil {
+			return nil, fmt.Errorf("getting header of block %s: %w", parentHash, err)
+		}
+	}
+
+	slices.Reverse(vrfRandomness)
+	return vrfRandomness, nil
+}
@@ -0,0 +1,245 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package modules
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/rpc/modules/mocks"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/babe"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/gtank/merlin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestBabeModule_EpochAuthorship(t *testing.T) {
+	t.Parallel()
+
+	authorship := map[string]babe.EpochAuthorship{
+		"5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY": {
+			Primary:      []uint64{10, 12},
+			Secondary:    []uint64{11},
+			SecondaryVRF: []uint64{},
+		},
+	}
+	errTest := errors.New("test error")
+
+	testCases := map[string]struct {
+		blockProducerAPIBuilder func(ctrl *gomock.Controller) BlockProducerAPI
+		expected                EpochAuthorshipResponse
+		errMessage              string
+	}{
+		"not_a_block_producer": {
+			blockProducerAPIBuilder: func(*gomock.Controller) BlockProducerAPI { return nil },
+			errMessage:              "not a block producer",
+		},
+		"block_producer_error": {
+			blockProducerAPIBuilder: func(ctrl *gomock.Controller) BlockProducerAPI {
+				mockBlockProducerAPI := mocks.NewMockBlockProducerAPI(ctrl)
+				mockBlockProducerAPI.EXPECT().EpochAuthorship().Return(nil, errTest)
+				return mockBlockProducerAPI
+			},
+			errMessage: "test error",
+		},
+		"ok": {
+			blockProducerAPIBuilder: func(ctrl *gomock.Controller) BlockProducerAPI {
+				mockBlockProducerAPI := mocks.NewMockBlockProducerAPI(ctrl)
+				mockBlockProducerAPI.EXPECT().EpochAuthorship().Return(authorship, nil)
+				return mockBlockProducerAPI
+			},
+			expected: authorship,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			module := NewBabeModule(testCase.blockProducerAPIBuilder(ctrl), nil, nil)
+			var res EpochAuthorshipResponse
+			err := module.EpochAuthorship(nil, nil, &res)
+			if testCase.errMessage != "" {
+				assert.EqualError(t, err, testCase.errMessage)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expected, res)
+		})
+	}
+}
+
+// newBabeTestChain returns a chain of headers numbered from 1, with the given
+// pre-runtime digests signed by Alice, and the epoch data of Alice.
+func newBabeTestChain(t *testing.T, preDigests ...interface {
+	ToPreRuntimeDigest() (*types.PreRuntimeDigest, error)
+}) (headers []*types.Header, epochData *types.EpochDataRaw) {
+	t.Helper()
+
+	keyring, err := keystore.NewSr25519Keyring()
+	require.NoError(t, err)
+	epochData = &types.EpochDataRaw{
+		Authorities: []types.AuthorityRaw{{Key: keyring.Alice().Public().(*sr25519.PublicKey).AsBytes()}},
+		Randomness:  [types.RandomnessLength]byte{1},
+	}
+
+	parentHash := common.Hash{}
+	for i, preDigest := range preDigests {
+		digest, err := preDigest.ToPreRuntimeDigest()
+		require.NoError(t, err)
+		header := types.NewEmptyHeader()
+		header.Number = uint(i + 1)
+		header.ParentHash = parentHash
+		require.NoError(t, header.Digest.Add(*digest))
+		headers = append(headers, header)
+		parentHash = header.Hash()
+	}
+	return headers, epochData
+}
+
+func newTestVRFOutput(t *testing.T) [sr25519.VRFOutputLength]byte {
+	t.Helper()
+
+	keyring, err := keystore.NewSr25519Keyring()
+	require.NoError(t, err)
+	output, _, err := keyring.Alice().(*sr25519.Keypair).VrfSign(merlin.NewTranscript("test"))
+	require.NoError(t, err)
+	return output
+}
+
+func mockBabeTestChain(ctrl *gomock.Controller, headers []*types.Header, epochs []uint64) (
+	*mocks.MockBlockAPI, *mocks.MockEpochStateAPI) {
+	blockAPI := mocks.NewMockBlockAPI(ctrl)
+	blockAPI.EXPECT().BestBlockHash().Return(headers[len(headers)-1].Hash()).AnyTimes()
+	blockAPI.EXPECT().GetHeader(gomock.Any()).DoAndReturn(func(hash common.Hash) (*types.Header, error) {
+		for _, header := range headers {
+			if header.Hash() == hash {
+				return header, nil
+			}
+		}
+		return types.NewEmptyHeader(), nil
+	}).AnyTimes()
+
+	epochState := mocks.NewMockEpochStateAPI(ctrl)
+	epochState.EXPECT().GetEpochForBlock(gomock.Any()).DoAndReturn(func(header *types.Header) (uint64, error) {
+		return epochs[header.Number-1], nil
+	}).AnyTimes()
+	return blockAPI, epochState
+}
+
+func TestBabeModule_Randomness(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	output := newTestVRFOutput(t)
+	var proof [sr25519.VRFProofLength]byte
+	headers, epochData := newBabeTestChain(t,
+		types.NewBabePrimaryPreDigest(0, 1, output, proof),
+		types.NewBabePrimaryPreDigest(0, 11, output, proof),
+		types.NewBabeSecondaryVRFPreDigest(0, 12, output, proof),
+		types.NewBabeSecondaryPlainPreDigest(0, 13),
+		types.NewBabePrimaryPreDigest(0, 21, output, proof),
+	)
+	blockAPI, epochState := mockBabeTestChain(ctrl, headers, []uint64{0, 1, 1, 1, 2})
+
+	// only the primary block of epoch 1 is accumulated
+	vrfRandomness, _, err := babe.VRFRandomness(
+		babe.BlockVRF{Slot: 11, Claim: babe.PrimaryClaim, Output: &output}, 1, epochData)
+	require.NoError(t, err)
+	computed, err := babe.NextEpochRandomness(epochData.Randomness, 3, []babe.Randomness{vrfRandomness})
+	require.NoError(t, err)
+
+	best := headers[4]
+	nextEpochData := &types.EpochDataRaw{Randomness: computed}
+	epochState.EXPECT().GetEpochDataRaw(uint64(2), best).Return(epochData, nil)
+	epochState.EXPECT().GetEpochDataRaw(uint64(3), best).Return(nextEpochData, nil)
+	epochState.EXPECT().GetEpochDataRaw(uint64(1), headers[1]).Return(epochData, nil)
+
+	module := NewBabeModule(nil, blockAPI, epochState)
+	var res BabeRandomnessResponse
+	err = module.Randomness(nil, &BabeRandomnessRequest{}, &res)
+	require.NoError(t, err)
+
+	expected := BabeRandomnessResponse{
+		Block:   best.Hash(),
+		Current: EpochRandomness{Epoch: 2, Randomness: epochData.Randomness},
+		Next:    &EpochRandomness{Epoch: 3, Randomness: computed},
+		Derivation: &RandomnessDerivation{
+			VRFEpoch:   1,
+			VRFOutputs: 1,
+			Computed:   computed,
+			Matches:    true,
+		},
+	}
+	assert.Equal(t, expected, res)
+
+	// the next epoch data is not announced yet
+	epochState.EXPECT().GetEpochDataRaw(uint64(1), headers[2]).Return(epochData, nil)
+	epochState.EXPECT().GetEpochDataRaw(uint64(2), headers[2]).Return(nil, errors.New("not found"))
+	block := headers[2].Hash()
+	res = BabeRandomnessResponse{}
+	err = module.Randomness(nil, &BabeRandomnessRequest{Block: &block}, &res)
+	require.NoError(t, err)
+	expected = BabeRandomnessResponse{
+		Block:   block,
+		Current: EpochRandomness{Epoch: 1, Randomness: epochData.Randomness},
+	}
+	assert.Equal(t, expected, res)
+}
+
+func TestBabeModule_BlockVrfOutputs(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	output := newTestVRFOutput(t)
+	var proof [sr25519.VRFProofLength]byte
+	headers, epochData := newBabeTestChain(t,
+		types.NewBabePrimaryPreDigest(0, 1, output, proof),
+		types.NewBabeSecondaryPlainPreDigest(0, 2),
+		types.NewBabeSecondaryVRFPreDigest(0, 3, output, proof),
+	)
+	blockAPI, epochState := mockBabeTestChain(ctrl, headers, []uint64{0, 0, 0})
+	epochState.EXPECT().GetEpochDataRaw(uint64(0), headers[2]).Return(epochData, nil).Times(2)
+
+	module := NewBabeModule(nil, blockAPI, epochState)
+
+	err := module.BlockVrfOutputs(nil, &BlockVRFOutputsRequest{Count: maxBlockVRFOutputs + 1}, nil)
+	assert.ErrorIs(t, err, errTooManyBlocks)
+
+	var res []BlockVRFOutput
+	err = module.BlockVrfOutputs(nil, &BlockVRFOutputsRequest{}, &res)
+	require.NoError(t, err)
+
+	randomness, _, err := babe.VRFRandomness(
+		babe.BlockVRF{Slot: 3, Claim: babe.SecondaryVRFClaim, Output: &output}, 0, epochData)
+	require.NoError(t, err)
+	vrfOutput := common.Hash(output)
+	secondaryRandomness := common.Hash(randomness)
+	randomness, _, err = babe.VRFRandomness(
+		babe.BlockVRF{Slot: 1, Claim: babe.PrimaryClaim, Output: &output}, 0, epochData)
+	require.NoError(t, err)
+	primaryRandomness := common.Hash(randomness)
+
+	// the blocks are returned from the best block down to the first block
+	expected := []BlockVRFOutput{
+		{Number: 3, Hash: headers[2].Hash(), Slot: 3, Claim: babe.SecondaryVRFClaim,
+			VRFOutput: &vrfOutput, Randomness: &secondaryRandomness},
+		{Number: 2, Hash: headers[1].Hash(), Slot: 2, Claim: babe.SecondaryClaim},
+		{Number: 1, Hash: headers[0].Hash(), Slot: 1, Claim: babe.PrimaryClaim,
+			VRFOutput: &vrfOutput, Randomness: &primaryRandomness},
+	}
+	assert.Equal(t, expected, res)
+
+	res = nil
+	err = module.BlockVrfOutputs(nil, &BlockVRFOutputsRequest{Count: 1}, &res)
+	require.NoError(t, err)
+	assert.Equal(t, expected[:1], res)
+}
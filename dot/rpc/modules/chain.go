@@ -7,7 +7,10 @@
 	"fmt"
 	"net/http"
 	"regexp"
+	"runtime"
 	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
@@ -24,6 +27,16 @@ type ChainBlockNumberRequest struct {
 	Block interface{}
 }
 
+// MaxChainBatchSize is the maximum number of blocks requested at once
+// by chain_getHeaders and chain_getBlocks.
+const MaxChainBatchSize = 1000
+
+// ChainBatchRequest holds the blocks requested by chain_getHeaders and chain_getBlocks,
+// each either a block hash or a block number, as accepted by chain_getBlockHash.
+type ChainBatchRequest struct {
+	Blocks []interface{}
+}
+
 // ChainFinalizedHeadRequest ...
 type ChainFinalizedHeadRequest struct {
 	Round uint64
@@ -55,18 +68,28 @@ type ChainBlockResponse struct {
 	Block ChainBlock `json:"block"`
 }
 
+// ChainBlockHeadersResponse holds the headers of the blocks of a ChainBatchRequest, in order
+type ChainBlockHeadersResponse []ChainBlockHeaderResponse
+
+// ChainBlocksResponse holds the blocks of a ChainBatchRequest, in order
+type ChainBlocksResponse []ChainBlockResponse
+
 // ChainHashResponse interface to handle response
 type ChainHashResponse interface{}
 
 // ChainModule is an RPC module providing access to storage API points.
 type ChainModule struct {
 	blockAPI BlockAPI
+	// batchSlots is the pool shared by the batch requests, which bounds the number of
+	// blocks fetched from the database and decoded concurrently across all of them.
+	batchSlots chan struct{}
 }
 
 // NewChainModule creates a new State module.
 func NewChainModule(api BlockAPI) *ChainModule {
 	return &ChainModule{
-		blockAPI: api,
+		blockAPI:   api,
+		batchSlots: make(chan struct{}, runtime.NumCPU()),
 	}
 }
 
@@ -79,20 +102,25 @@ func (cm *ChainModule) GetBlock(r *http.Request, req *ChainHashRequest, res *Cha
 		return err
 	}
 
-	res.Block.Header, err = HeaderToJSON(block.Header)
-	if err != nil {
-		return err
-	}
+	*res, err = blockToJSON(*block)
+	return err
+}
 
-	if block.Body != nil {
-		ext, err := block.Body.AsEncodedExtrinsics()
+// GetBlocks Get header and body of each of the requested blocks, given by hash or number.
+// The blocks are fetched concurrently, and at most MaxChainBatchSize can be requested at once.
+func (cm *ChainModule) GetBlocks(r *http.Request, req *ChainBatchRequest, res *ChainBlocksResponse) error {
+	blocks, err := fetchBatch(cm, req.Blocks, func(hash common.Hash) (ChainBlockResponse, error) {
+		block, err := cm.blockAPI.GetBlockByHash(hash)
 		if err != nil {
-			return err
-		}
-		for _, e := range ext {
-			res.Block.Body = append(res.Block.Body, e.String())
+			return ChainBlockResponse{}, err
 		}
+		return blockToJSON(*block)
+	})
+	if err != nil {
+		return err
 	}
+
+	*res = blocks
 	return nil
 }
 
@@ -151,6 +179,24 @@ func (cm *ChainModule) GetHeader(r *http.Request, req *ChainHashRequest, res *Ch
 	return err
 }
 
+// GetHeaders Get header of each of the requested blocks, given by hash or number.
+// The headers are fetched concurrently, and at most MaxChainBatchSize can be requested at once.
+func (cm *ChainModule) GetHeaders(r *http.Request, req *ChainBatchRequest, res *ChainBlockHeadersResponse) error {
+	headers, err := fetchBatch(cm, req.Blocks, func(hash common.Hash) (ChainBlockHeaderResponse, error) {
+		header, err := cm.blockAPI.GetHeader(hash)
+		if err != nil {
+			return ChainBlockHeaderResponse{}, err
+		}
+		return HeaderToJSON(*header)
+	})
+	if err != nil {
+		return err
+	}
+
+	*res = headers
+	return nil
+}
+
 // SubscribeFinalizedHeads handled by websocket handler, but this func should remain
 // here so it's added to rpc_methods list
 func (cm *ChainModule) SubscribeFinalizedHeads(_ *http.Request, _ *EmptyRequest, _ *ChainBlockHeaderResponse) error {
@@ -202,34 +248,112 @@ func (cm *ChainModule) unwindRequest(req interface{}) ([]string, error) {
 // lookupHashByInterface parses given interface to determine block number, then
 // finds hash for that block number
 func (cm *ChainModule) lookupHashByInterface(i interface{}) (string, error) {
-	var num uint
+	num, err := parseBlockNumber(i)
+	if err != nil {
+		return "", err
+	}
+
+	h, err := cm.blockAPI.GetHashByNumber(num)
+	if err != nil {
+		return "", err
+	}
+
+	return h.String(), nil
+}
+
+// parseBlockNumber parses the block number given as a number or a string
+func parseBlockNumber(i interface{}) (uint, error) {
 	switch x := i.(type) {
 	case float64:
-		num = uint(x)
+		return uint(x), nil
 	case string:
 		// remove leading 0x (if there is one)
 		re, err := regexp.Compile(`0x`)
 		if err != nil {
-			return "", err
+			return 0, err
 		}
 		x = re.ReplaceAllString(x, "")
 
 		xUint64, err := strconv.ParseUint(x, 10, 64)
 		if err != nil {
-			return "", err
+			return 0, err
 		}
-		num = uint(xUint64)
-
+		return uint(xUint64), nil
 	default:
-		return "", fmt.Errorf("unknown request number type: %T", x)
+		return 0, fmt.Errorf("unknown request number type: %T", x)
 	}
+}
 
-	h, err := cm.blockAPI.GetHashByNumber(num)
+// lookupBatchHash returns the hash of a block of a batch request, given either by
+// hash or by number.
+func (cm *ChainModule) lookupBatchHash(block interface{}) (common.Hash, error) {
+	if x, ok := block.(string); ok && strings.HasPrefix(x, "0x") && len(x) == 2+2*common.HashLength {
+		return common.HexToHash(x)
+	}
+
+	num, err := parseBlockNumber(block)
 	if err != nil {
-		return "", err
+		return common.Hash{}, err
 	}
+	return cm.blockAPI.GetHashByNumber(num)
+}
 
-	return h.String(), nil
+// fetchBatch looks up the blocks of a batch request and calls fetch for each of them
+// concurrently, in the pool shared by the batch requests. The results are returned in
+// the order of the blocks, or the error of the first block failing.
+func fetchBatch[T any](cm *ChainModule, blocks []interface{}, fetch func(common.Hash) (T, error)) ([]T, error) {
+	if len(blocks) > MaxChainBatchSize {
+		return nil, fmt.Errorf("%w: %d blocks requested, at most %d allowed",
+			ErrBatchTooLarge, len(blocks), MaxChainBatchSize)
+	}
+
+	results := make([]T, len(blocks))
+	errs := make([]error, len(blocks))
+	var wg sync.WaitGroup
+	for i, block := range blocks {
+		cm.batchSlots <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-cm.batchSlots
+				wg.Done()
+			}()
+
+			hash, err := cm.lookupBatchHash(block)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			results[i], errs[i] = fetch(hash)
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("block %v at index %d: %w", blocks[i], i, err)
+		}
+	}
+	return results, nil
+}
+
+// blockToJSON converts types.Block to ChainBlockResponse
+func blockToJSON(block types.Block) (res ChainBlockResponse, err error) {
+	res.Block.Header, err = HeaderToJSON(block.Header)
+	if err != nil {
+		return ChainBlockResponse{}, err
+	}
+
+	if block.Body != nil {
+		ext, err := block.Body.AsEncodedExtrinsics()
+		if err != nil {
+			return ChainBlockResponse{}, err
+		}
+		for _, e := range ext {
+			res.Block.Body = append(res.Block.Body, e.String())
+		}
+	}
+	return res, nil
 }
 
 // HeaderToJSON converts types.Header to ChainBlockHeaderResponse
@@ -431,6 +431,83 @@ type args struct {
 	}
 }
 
+func TestChainModule_GetHeaders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	firstHash := common.Hash{1}
+	secondHash := common.Hash{2}
+	firstHeader := types.NewEmptyHeader()
+	firstHeader.Number = 1
+	secondHeader := types.NewEmptyHeader()
+	secondHeader.Number = 2
+
+	mockBlockAPI := mocks.NewMockBlockAPI(ctrl)
+	mockBlockAPI.EXPECT().GetHashByNumber(uint(2)).Return(secondHash, nil)
+	mockBlockAPI.EXPECT().GetHeader(firstHash).Return(firstHeader, nil)
+	mockBlockAPI.EXPECT().GetHeader(secondHash).Return(secondHeader, nil)
+
+	firstRes, err := HeaderToJSON(*firstHeader)
+	require.NoError(t, err)
+	secondRes, err := HeaderToJSON(*secondHeader)
+	require.NoError(t, err)
+
+	cm := NewChainModule(mockBlockAPI)
+	req := &ChainBatchRequest{Blocks: []interface{}{firstHash.String(), float64(2)}}
+	var res ChainBlockHeadersResponse
+	err = cm.GetHeaders(nil, req, &res)
+	require.NoError(t, err)
+	assert.Equal(t, ChainBlockHeadersResponse{firstRes, secondRes}, res)
+
+	mockBlockAPIErr := mocks.NewMockBlockAPI(ctrl)
+	mockBlockAPIErr.EXPECT().GetHeader(firstHash).Return(firstHeader, nil)
+	mockBlockAPIErr.EXPECT().GetHeader(secondHash).Return(nil, errors.New("GetHeader Error"))
+
+	cm = NewChainModule(mockBlockAPIErr)
+	req = &ChainBatchRequest{Blocks: []interface{}{firstHash.String(), secondHash.String()}}
+	res = nil
+	err = cm.GetHeaders(nil, req, &res)
+	assert.EqualError(t, err, "block "+secondHash.String()+" at index 1: GetHeader Error")
+	assert.Nil(t, res)
+
+	req = &ChainBatchRequest{Blocks: make([]interface{}, MaxChainBatchSize+1)}
+	err = cm.GetHeaders(nil, req, &res)
+	assert.ErrorIs(t, err, ErrBatchTooLarge)
+}
+
+func TestChainModule_GetBlocks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	firstHash := common.Hash{1}
+	secondHash := common.Hash{2}
+	emptyBlock := types.NewEmptyBlock()
+	bodyBlock := types.NewEmptyBlock()
+	bodyBlock.Body = types.BytesArrayToExtrinsics([][]byte{{1}})
+
+	mockBlockAPI := mocks.NewMockBlockAPI(ctrl)
+	mockBlockAPI.EXPECT().GetHashByNumber(uint(1)).Return(firstHash, nil)
+	mockBlockAPI.EXPECT().GetBlockByHash(firstHash).Return(&emptyBlock, nil)
+	mockBlockAPI.EXPECT().GetBlockByHash(secondHash).Return(&bodyBlock, nil)
+
+	emptyRes, err := blockToJSON(emptyBlock)
+	require.NoError(t, err)
+	bodyRes, err := blockToJSON(bodyBlock)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"0x0401"}, bodyRes.Block.Body)
+
+	cm := NewChainModule(mockBlockAPI)
+	req := &ChainBatchRequest{Blocks: []interface{}{"1", secondHash.String()}}
+	var res ChainBlocksResponse
+	err = cm.GetBlocks(nil, req, &res)
+	require.NoError(t, err)
+	assert.Equal(t, ChainBlocksResponse{emptyRes, bodyRes}, res)
+
+	req = &ChainBatchRequest{Blocks: []interface{}{true}}
+	res = nil
+	err = cm.GetBlocks(nil, req, &res)
+	assert.EqualError(t, err, "block true at index 0: unknown request number type: bool")
+	assert.Nil(t, res)
+}
+
 func TestChainModule_ErrSubscriptionTransport(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
@@ -4,9 +4,13 @@
 package modules
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/trie"
 )
 
 // GetKeysRequest represents the request to retrieve the keys of a child storage
@@ -37,6 +41,26 @@ type GetChildStorageRequest struct {
 	Hash     *common.Hash
 }
 
+// ChildStateKeysPagedRequest holds json fields
+type ChildStateKeysPagedRequest struct {
+	ChildStorageKey string       `json:"childStorageKey" validate:"required"`
+	Prefix          string       `json:"prefix"`
+	Qty             uint32       `json:"qty"`
+	AfterKey        string       `json:"afterKey"`
+	Hash            *common.Hash `json:"block"`
+}
+
+// ChildStateStorageEntriesRequest holds json fields
+type ChildStateStorageEntriesRequest struct {
+	ChildStorageKey string       `json:"childStorageKey" validate:"required"`
+	Keys            []string     `json:"keys" validate:"required"`
+	Hash            *common.Hash `json:"block"`
+}
+
+// ChildStateStorageEntriesResponse holds the hex encoded values of the requested
+// child storage entries, nil for the entries which do not exist.
+type ChildStateStorageEntriesResponse []*string
+
 // ChildStateModule is the module responsible to implement all the childstate RPC calls
 type ChildStateModule struct {
 	storageAPI StorageAPI
@@ -166,3 +190,115 @@ func (cs *ChildStateModule) GetStorage(
 
 	return nil
 }
+
+// maxChildKeysPaged is the maximum number of keys returned by childstate_getKeysPaged
+const maxChildKeysPaged = 1000
+
+var errTooManyKeys = errors.New("too many keys requested")
+
+// GetKeysPaged returns up to `qty` keys of the specified child storage with the
+// given prefix, starting after `afterKey`.
+func (cs *ChildStateModule) GetKeysPaged(
+	_ *http.Request, req *ChildStateKeysPagedRequest, res *StateStorageKeysResponse) error {
+	if req.Qty > maxChildKeysPaged {
+		return fmt.Errorf("%w: %d is more than %d", errTooManyKeys, req.Qty, maxChildKeysPaged)
+	}
+
+	prefix, err := common.HexToBytes(hexOrEmpty(req.Prefix))
+	if err != nil {
+		return fmt.Errorf("decoding prefix: %w", err)
+	}
+
+	afterKey, err := common.HexToBytes(hexOrEmpty(req.AfterKey))
+	if err != nil {
+		return fmt.Errorf("decoding after key: %w", err)
+	}
+
+	child, err := cs.childTrie(req.Hash, req.ChildStorageKey)
+	if err != nil {
+		return err
+	}
+
+	keys := make(StateStorageKeysResponse, 0)
+	if child != nil && req.Qty > 0 {
+		// the keys are iterated from the first key with the prefix after the after key
+		candidates := child.PrefixedKeys(prefix)
+		if bytes.Compare(afterKey, prefix) >= 0 {
+			candidates = child.KeysFrom(afterKey)
+		}
+		for key := range candidates {
+			if !bytes.HasPrefix(key, prefix) {
+				break
+			}
+			keys = append(keys, common.BytesToHex(key))
+			if uint32(len(keys)) >= req.Qty {
+				break
+			}
+		}
+	}
+
+	*res = keys
+	return nil
+}
+
+// GetStorageEntries returns the values of the given keys of the specified child storage.
+func (cs *ChildStateModule) GetStorageEntries(
+	_ *http.Request, req *ChildStateStorageEntriesRequest, res *ChildStateStorageEntriesResponse) error {
+	keys := make([][]byte, len(req.Keys))
+	for i, hexKey := range req.Keys {
+		key, err := common.HexToBytes(hexKey)
+		if err != nil {
+			return fmt.Errorf("decoding key %s: %w", hexKey, err)
+		}
+		keys[i] = key
+	}
+
+	child, err := cs.childTrie(req.Hash, req.ChildStorageKey)
+	if err != nil {
+		return err
+	}
+
+	entries := make(ChildStateStorageEntriesResponse, len(keys))
+	if child != nil {
+		for i, key := range keys {
+			value := child.Get(key)
+			if value != nil {
+				hexValue := common.BytesToHex(value)
+				entries[i] = &hexValue
+			}
+		}
+	}
+
+	*res = entries
+	return nil
+}
+
+// childTrie returns the child trie with the given hex encoded key at the given
+// block, or at the best block if no block hash is given.
+func (cs *ChildStateModule) childTrie(blockHash *common.Hash, hexChildStorageKey string) (trie.Trie, error) {
+	childStorageKey, err := common.HexToBytes(hexChildStorageKey)
+	if err != nil {
+		return nil, fmt.Errorf("decoding child storage key: %w", err)
+	}
+
+	var hash common.Hash
+	if blockHash == nil {
+		hash = cs.blockAPI.BestBlockHash()
+	} else {
+		hash = *blockHash
+	}
+
+	stateRoot, err := cs.storageAPI.GetStateRootFromBlock(&hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return cs.storageAPI.GetStorageChild(stateRoot, childStorageKey)
+}
+
+func hexOrEmpty(s string) string {
+	if s == "" {
+		return "0x"
+	}
+	return s
+}
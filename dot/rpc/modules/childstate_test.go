@@ -12,6 +12,7 @@
 	"github.com/ChainSafe/gossamer/lib/common"
 	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
 	"github.com/ChainSafe/gossamer/pkg/trie"
+	"github.com/ChainSafe/gossamer/pkg/trie/inmemory"
 	"go.uber.org/mock/gomock"
 
 	"github.com/stretchr/testify/assert"
@@ -494,3 +495,151 @@ type args struct {
 		})
 	}
 }
+
+func newTestChildTrie(t *testing.T) trie.Trie {
+	t.Helper()
+
+	child := inmemory.NewEmptyTrie()
+	for _, key := range []string{":another_child", ":child_first", ":child_second"} {
+		require.NoError(t, child.Put([]byte(key), []byte(key+"_value")))
+	}
+	return child
+}
+
+func TestChildStateModule_GetKeysPaged(t *testing.T) {
+	t.Parallel()
+
+	bestHash := common.Hash{1}
+	stateRoot := common.Hash{2}
+	childKey := []byte(":child_storage_key")
+
+	testCases := map[string]struct {
+		request    *ChildStateKeysPagedRequest
+		childErr   error
+		expected   StateStorageKeysResponse
+		errMessage string
+	}{
+		"first_page": {
+			request: &ChildStateKeysPagedRequest{
+				ChildStorageKey: common.BytesToHex(childKey),
+				Qty:             2,
+			},
+			expected: StateStorageKeysResponse{
+				common.BytesToHex([]byte(":another_child")),
+				common.BytesToHex([]byte(":child_first")),
+			},
+		},
+		"after_key_with_prefix": {
+			request: &ChildStateKeysPagedRequest{
+				ChildStorageKey: common.BytesToHex(childKey),
+				Prefix:          common.BytesToHex([]byte(":child")),
+				Qty:             10,
+				AfterKey:        common.BytesToHex([]byte(":child_first")),
+			},
+			expected: StateStorageKeysResponse{
+				common.BytesToHex([]byte(":child_second")),
+			},
+		},
+		"after_key_without_prefix": {
+			request: &ChildStateKeysPagedRequest{
+				ChildStorageKey: common.BytesToHex(childKey),
+				Prefix:          common.BytesToHex([]byte(":child")),
+				Qty:             10,
+				AfterKey:        common.BytesToHex([]byte(":a")),
+			},
+			expected: StateStorageKeysResponse{
+				common.BytesToHex([]byte(":child_first")),
+				common.BytesToHex([]byte(":child_second")),
+			},
+		},
+		"after_last_key_with_prefix": {
+			request: &ChildStateKeysPagedRequest{
+				ChildStorageKey: common.BytesToHex(childKey),
+				Prefix:          common.BytesToHex([]byte(":another")),
+				Qty:             10,
+				AfterKey:        common.BytesToHex([]byte(":another_child")),
+			},
+			expected: StateStorageKeysResponse{},
+		},
+		"too_many_keys": {
+			request: &ChildStateKeysPagedRequest{
+				ChildStorageKey: common.BytesToHex(childKey),
+				Qty:             maxChildKeysPaged + 1,
+			},
+			errMessage: "too many keys requested: 1001 is more than 1000",
+		},
+		"invalid_prefix": {
+			request: &ChildStateKeysPagedRequest{
+				ChildStorageKey: common.BytesToHex(childKey),
+				Prefix:          "0xzz",
+			},
+			errMessage: "decoding prefix: encoding/hex: invalid byte: U+007A 'z': 0xzz",
+		},
+		"child_error": {
+			request: &ChildStateKeysPagedRequest{
+				ChildStorageKey: common.BytesToHex(childKey),
+				Qty:             1,
+			},
+			childErr:   errors.New("test error"),
+			errMessage: "test error",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			blockAPI := apimocks.NewMockBlockAPI(ctrl)
+			storageAPI := apimocks.NewMockStorageAPI(ctrl)
+			if testCase.errMessage == "" || testCase.childErr != nil {
+				blockAPI.EXPECT().BestBlockHash().Return(bestHash)
+				storageAPI.EXPECT().GetStateRootFromBlock(&bestHash).Return(&stateRoot, nil)
+				var child trie.Trie
+				if testCase.childErr == nil {
+					child = newTestChildTrie(t)
+				}
+				storageAPI.EXPECT().GetStorageChild(&stateRoot, childKey).Return(child, testCase.childErr)
+			}
+
+			var res StateStorageKeysResponse
+			err := NewChildStateModule(storageAPI, blockAPI).GetKeysPaged(nil, testCase.request, &res)
+			if testCase.errMessage != "" {
+				assert.EqualError(t, err, testCase.errMessage)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, testCase.expected, res)
+		})
+	}
+}
+
+func TestChildStateModule_GetStorageEntries(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	hash := common.Hash{1}
+	stateRoot := common.Hash{2}
+	childKey := []byte(":child_storage_key")
+
+	storageAPI := apimocks.NewMockStorageAPI(ctrl)
+	storageAPI.EXPECT().GetStateRootFromBlock(&hash).Return(&stateRoot, nil)
+	storageAPI.EXPECT().GetStorageChild(&stateRoot, childKey).Return(newTestChildTrie(t), nil)
+
+	request := &ChildStateStorageEntriesRequest{
+		ChildStorageKey: common.BytesToHex(childKey),
+		Keys: []string{
+			common.BytesToHex([]byte(":child_first")),
+			common.BytesToHex([]byte(":missing")),
+		},
+		Hash: &hash,
+	}
+
+	var res ChildStateStorageEntriesResponse
+	err := NewChildStateModule(storageAPI, nil).GetStorageEntries(nil, request, &res)
+	require.NoError(t, err)
+
+	value := common.BytesToHex([]byte(":child_first_value"))
+	assert.Equal(t, ChildStateStorageEntriesResponse{&value, nil}, res)
+}
@@ -8,6 +8,7 @@
 	"errors"
 	"net/http"
 
+	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/lib/common"
 )
 
@@ -16,17 +17,26 @@
 var networkStoppedMsg = "network service stopped"
 var networkStartedMsg = "network service started"
 
+// DBStatsRequest is the request to get the statistics of the node database.
+type DBStatsRequest struct {
+	// LargestKeys is the number of largest keys to report,
+	// defaulting to state.DefaultLargestKeys if 0.
+	LargestKeys uint32
+}
+
 // DevModule is an RPC module that provides developer endpoints
 type DevModule struct {
 	networkAPI       NetworkAPI
 	blockProducerAPI BlockProducerAPI
+	databaseAPI      DatabaseAPI
 }
 
 // NewDevModule creates a new Dev module.
-func NewDevModule(bp BlockProducerAPI, net NetworkAPI) *DevModule {
+func NewDevModule(bp BlockProducerAPI, net NetworkAPI, db DatabaseAPI) *DevModule {
 	return &DevModule{
 		networkAPI:       net,
 		blockProducerAPI: bp,
+		databaseAPI:      db,
 	}
 }
 
@@ -76,6 +86,28 @@ func (m *DevModule) EpochLength(r *http.Request, req *EmptyRequest, res *string)
 	return err
 }
 
+// DbStats Dev RPC to return the per column key counts and sizes, trie node counts,
+// largest keys and compaction statistics of the node database.
+// It iterates over all the database entries, so it can take a while on large databases.
+func (m *DevModule) DbStats(r *http.Request, req *DBStatsRequest, res *state.DatabaseStats) error {
+	if m.databaseAPI == nil {
+		return errors.New("database statistics not available")
+	}
+
+	largestKeys := state.DefaultLargestKeys
+	if req.LargestKeys != 0 {
+		largestKeys = int(req.LargestKeys)
+	}
+
+	stats, err := m.databaseAPI.DatabaseStats(largestKeys)
+	if err != nil {
+		return err
+	}
+
+	*res = *stats
+	return nil
+}
+
 // uint64ToHex converts a uint64 to a hexed string
 func uint64ToHex(input uint64) string {
 	buffer := make([]byte, 8)
@@ -81,7 +81,7 @@ func newBABEService(t *testing.T) *babe.Service {
 func TestDevControl_Babe(t *testing.T) {
 	t.Skip() // skip for now, blocks on `babe.Service.Resume()`
 	bs := newBABEService(t)
-	m := NewDevModule(bs, nil)
+	m := NewDevModule(bs, nil, nil)
 
 	var res string
 	err := m.Control(nil, &[]string{"babe", "stop"}, &res)
@@ -97,7 +97,7 @@ func TestDevControl_Babe(t *testing.T) {
 
 func TestDevControl_Network(t *testing.T) {
 	net := newNetworkService(t)
-	m := NewDevModule(nil, net)
+	m := NewDevModule(nil, net, nil)
 
 	var res string
 	err := m.Control(nil, &[]string{"network", "stop"}, &res)
@@ -113,7 +113,7 @@ func TestDevControl_Network(t *testing.T) {
 
 func TestDevControl_SlotDuration(t *testing.T) {
 	bs := newBABEService(t)
-	m := NewDevModule(bs, nil)
+	m := NewDevModule(bs, nil, nil)
 
 	slotDurationSource := m.blockProducerAPI.SlotDuration()
 
@@ -127,7 +127,7 @@ func TestDevControl_SlotDuration(t *testing.T) {
 
 func TestDevControl_EpochLength(t *testing.T) {
 	bs := newBABEService(t)
-	m := NewDevModule(bs, nil)
+	m := NewDevModule(bs, nil, nil)
 
 	epochLengthSource := m.blockProducerAPI.EpochLength()
 
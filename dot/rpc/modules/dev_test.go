@@ -9,6 +9,7 @@
 	"testing"
 
 	"github.com/ChainSafe/gossamer/dot/rpc/modules/mocks"
+	"github.com/ChainSafe/gossamer/dot/state"
 	"go.uber.org/mock/gomock"
 
 	"github.com/stretchr/testify/assert"
@@ -58,7 +59,7 @@ func TestDevModule_EpochLength(t *testing.T) {
 
 	mockBlockProducerAPI := mocks.NewMockBlockProducerAPI(ctrl)
 	mockBlockProducerAPI.EXPECT().EpochLength().Return(uint64(23))
-	devModule := NewDevModule(mockBlockProducerAPI, nil)
+	devModule := NewDevModule(mockBlockProducerAPI, nil, nil)
 
 	type fields struct {
 		networkAPI       NetworkAPI
@@ -312,3 +313,53 @@ type args struct {
 		})
 	}
 }
+
+func TestDevModule_DbStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	stats := &state.DatabaseStats{
+		Columns: []state.ColumnStats{{Name: "block", Keys: 1, KeyBytes: 2, ValueBytes: 3}},
+	}
+	mockDatabaseAPI := mocks.NewMockDatabaseAPI(ctrl)
+	mockDatabaseAPI.EXPECT().DatabaseStats(state.DefaultLargestKeys).Return(stats, nil)
+	mockDatabaseAPI.EXPECT().DatabaseStats(3).Return(nil, errors.New("stats error"))
+
+	tests := []struct {
+		name        string
+		databaseAPI DatabaseAPI
+		req         *DBStatsRequest
+		expErr      error
+		exp         state.DatabaseStats
+	}{
+		{
+			name:        "DBStats_OK",
+			databaseAPI: mockDatabaseAPI,
+			req:         &DBStatsRequest{},
+			exp:         *stats,
+		},
+		{
+			name:        "DBStats_Err",
+			databaseAPI: mockDatabaseAPI,
+			req:         &DBStatsRequest{LargestKeys: 3},
+			expErr:      errors.New("stats error"),
+		},
+		{
+			name:   "DBStats_Unavailable",
+			req:    &DBStatsRequest{},
+			expErr: errors.New("database statistics not available"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewDevModule(nil, nil, tt.databaseAPI)
+			res := state.DatabaseStats{}
+			err := m.DbStats(nil, tt.req, &res)
+			if tt.expErr != nil {
+				assert.EqualError(t, err, tt.expErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.exp, res)
+		})
+	}
+}
@@ -8,4 +8,6 @@
 var (
 	ErrSubscriptionTransport = errors.New("subscriptions are not available on this transport")
 	ErrStartBlockHashEmpty   = errors.New("the start block hash cannot be an empty value")
+	ErrRuntimeCodeNotFound   = errors.New("runtime code not found")
+	ErrBatchTooLarge         = errors.New("too many blocks requested")
 )
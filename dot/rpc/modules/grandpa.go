@@ -7,21 +7,25 @@
 	"fmt"
 	"net/http"
 
+	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/ChainSafe/gossamer/pkg/scale"
 )
 
 // GrandpaModule init parameters
 type GrandpaModule struct {
 	blockAPI         BlockAPI
 	blockFinalityAPI BlockFinalityAPI
+	grandpaState     GrandpaStateAPI
 }
 
 // NewGrandpaModule creates a new Grandpa rpc module.
-func NewGrandpaModule(api BlockAPI, finalityAPI BlockFinalityAPI) *GrandpaModule {
+func NewGrandpaModule(api BlockAPI, finalityAPI BlockFinalityAPI, grandpaState GrandpaStateAPI) *GrandpaModule {
 	return &GrandpaModule{
 		blockAPI:         api,
 		blockFinalityAPI: finalityAPI,
+		grandpaState:     grandpaState,
 	}
 }
 
@@ -47,6 +51,10 @@ type RoundStateResponse struct {
 	Background []RoundState `json:"background"`
 }
 
+// maxFinalityProofHeaders is the maximum number of headers after the requested
+// block included in a finality proof.
+const maxFinalityProofHeaders = 100_000
+
 // ProveFinalityRequest request struct
 type ProveFinalityRequest struct {
 	BlockNumber uint32 `json:"blockNumber"`
@@ -55,16 +63,35 @@ type ProveFinalityRequest struct {
 // ProveFinalityResponse is an optional SCALE encoded proof array
 type ProveFinalityResponse []string
 
-// ProveFinality for the provided block number, the Justification for the last block in the set is written to the
-// response. The response is a SCALE encoded proof array.  The proof array is empty if the block number is
-// not finalized.
+// ProveFinality for the provided block number, the SCALE encoded finality proof of the block is written to the
+// response. The proof holds the justified block, which is the last block of the authority set of the requested
+// block, or the highest finalised block for the current authority set, along with its justification and the
+// headers after the requested block up to the justified block, so the finality of the requested block follows
+// from the justification and the ancestry of the justified block.
+// The proof array is empty if the block number is not finalized.
 // Returns error which are included in the response if they occur.
 func (gm *GrandpaModule) ProveFinality(r *http.Request, req *ProveFinalityRequest, res *ProveFinalityResponse) error {
-	blockHash, err := gm.blockAPI.GetHashByNumber(uint(req.BlockNumber))
+	finalisedHash, err := gm.blockAPI.GetHighestFinalisedHash()
+	if err != nil {
+		return fmt.Errorf("getting highest finalised hash: %w", err)
+	}
+
+	finalisedHeader, err := gm.blockAPI.GetHeader(finalisedHash)
+	if err != nil {
+		return fmt.Errorf("getting highest finalised header: %w", err)
+	}
+
+	blockNumber := uint(req.BlockNumber)
+	if blockNumber > finalisedHeader.Number {
+		return nil
+	}
+
+	justifiedNumber, justifiedHash, err := gm.justifiedBlock(blockNumber, finalisedHeader.Number, finalisedHash)
 	if err != nil {
 		return err
 	}
-	hasJustification, err := gm.blockAPI.HasJustification(blockHash)
+
+	hasJustification, err := gm.blockAPI.HasJustification(justifiedHash)
 	if err != nil {
 		return fmt.Errorf("checking for justification: %w", err)
 	}
@@ -73,15 +100,72 @@ func (gm *GrandpaModule) ProveFinality(r *http.Request, req *ProveFinalityReques
 		*res = append(*res, "GRANDPA prove finality rpc failed: Block not covered by authority set changes")
 		return nil
 	}
-	justification, err := gm.blockAPI.GetJustification(blockHash)
+
+	proof := types.GrandpaFinalityProof{Block: justifiedHash}
+	proof.Justification, err = gm.blockAPI.GetJustification(justifiedHash)
 	if err != nil {
 		return fmt.Errorf("getting justification: %w", err)
 	}
-	*res = append(*res, common.BytesToHex(justification))
 
+	lastNumber := min(justifiedNumber, blockNumber+maxFinalityProofHeaders)
+	for number := blockNumber + 1; number <= lastNumber; number++ {
+		hash, err := gm.blockAPI.GetHashByNumber(number)
+		if err != nil {
+			return fmt.Errorf("getting hash of block %d: %w", number, err)
+		}
+
+		header, err := gm.blockAPI.GetHeader(hash)
+		if err != nil {
+			return fmt.Errorf("getting header of block %d: %w", number, err)
+		}
+		proof.UnknownHeaders = append(proof.UnknownHeaders, *header)
+	}
+
+	encodedProof, err := scale.Marshal(proof)
+	if err != nil {
+		return fmt.Errorf("encoding finality proof: %w", err)
+	}
+
+	*res = append(*res, common.BytesToHex(encodedProof))
 	return nil
 }
 
+// justifiedBlock returns the number and hash of the block whose justification proves the
+// finality of the finalised block with the given number: the last block of its authority
+// set, which is always justified, or the highest finalised block for the current set.
+func (gm *GrandpaModule) justifiedBlock(blockNumber, finalisedNumber uint, finalisedHash common.Hash) (
+	justifiedNumber uint, justifiedHash common.Hash, err error) {
+	setID, err := gm.grandpaState.GetSetIDByBlockNumber(blockNumber)
+	if err != nil {
+		return 0, common.Hash{}, fmt.Errorf("getting set id of block %d: %w", blockNumber, err)
+	}
+
+	currentSetID, err := gm.grandpaState.GetCurrentSetID()
+	if err != nil {
+		return 0, common.Hash{}, fmt.Errorf("getting current set id: %w", err)
+	}
+
+	if setID >= currentSetID {
+		return finalisedNumber, finalisedHash, nil
+	}
+
+	justifiedNumber, err = gm.grandpaState.GetSetIDChange(setID + 1)
+	if err != nil {
+		return 0, common.Hash{}, fmt.Errorf("getting last block of set id %d: %w", setID, err)
+	}
+
+	// forced changes are applied before the last block of their set is finalised
+	if justifiedNumber > finalisedNumber {
+		return finalisedNumber, finalisedHash, nil
+	}
+
+	justifiedHash, err = gm.blockAPI.GetHashByNumber(justifiedNumber)
+	if err != nil {
+		return 0, common.Hash{}, fmt.Errorf("getting hash of block %d: %w", justifiedNumber, err)
+	}
+	return justifiedNumber, justifiedHash, nil
+}
+
 // RoundState returns the state of the current best round state as well as the ongoing background rounds.
 func (gm *GrandpaModule) RoundState(r *http.Request, req *EmptyRequest, res *RoundStateResponse) error {
 	voters := gm.blockFinalityAPI.GetVoters()
@@ -126,6 +210,90 @@ func (gm *GrandpaModule) RoundState(r *http.Request, req *EmptyRequest, res *Rou
 	return nil
 }
 
+// DumpMessageJournal writes the GRANDPA consensus messages of the message journal
+// to a file in the base path and returns the path of the file. Each line of the
+// file is a JSON record of a message received or sent.
+func (gm *GrandpaModule) DumpMessageJournal(r *http.Request, req *EmptyRequest, res *string) error {
+	path, err := gm.blockFinalityAPI.DumpMessageJournal()
+	if err != nil {
+		return err
+	}
+
+	*res = path
+	return nil
+}
+
+// SubmitJustificationRequest is the request to import the GRANDPA justification of
+// an imported block, with the SCALE encoded justification in hex.
+type SubmitJustificationRequest struct {
+	BlockHash     common.Hash
+	Justification string
+}
+
+// SubmitJustificationResponse is the round and set id of an imported justification.
+type SubmitJustificationResponse struct {
+	Round uint64 `json:"round"`
+	SetID uint64 `json:"setId"`
+}
+
+// SubmitJustification imports the GRANDPA justification of an already imported block
+// from an external source, such as a bridge relayer or a backup node. The justification
+// is verified against the authority set of the block, which is then finalised.
+func (gm *GrandpaModule) SubmitJustification(r *http.Request, req *SubmitJustificationRequest,
+	res *SubmitJustificationResponse) error {
+	justification, err := common.HexToBytes(req.Justification)
+	if err != nil {
+		return fmt.Errorf("decoding justification: %w", err)
+	}
+
+	round, setID, err := gm.blockFinalityAPI.ImportJustification(req.BlockHash, justification)
+	if err != nil {
+		return fmt.Errorf("importing justification: %w", err)
+	}
+
+	*res = SubmitJustificationResponse{Round: round, SetID: setID}
+	return nil
+}
+
+// AuthoritiesAtRequest is the request for the GRANDPA authority set in effect at a block.
+type AuthoritiesAtRequest struct {
+	BlockHash common.Hash
+}
+
+// GrandpaAuthority is a GRANDPA authority with its ed25519 public key in hex.
+type GrandpaAuthority struct {
+	Key    string `json:"key"`
+	Weight uint64 `json:"weight"`
+}
+
+// AuthoritiesAtResponse is the GRANDPA authority set in effect at a block.
+type AuthoritiesAtResponse struct {
+	SetID       uint64             `json:"setId"`
+	Authorities []GrandpaAuthority `json:"authorities"`
+}
+
+// AuthoritiesAt returns the id and the authorities of the GRANDPA authority set in
+// effect at a block retained by the node, whose justification they sign, so bridges
+// and auditors can verify the justifications of old blocks.
+func (gm *GrandpaModule) AuthoritiesAt(r *http.Request, req *AuthoritiesAtRequest,
+	res *AuthoritiesAtResponse) error {
+	setID, voters, err := gm.grandpaState.AuthoritiesAt(req.BlockHash)
+	if err != nil {
+		return fmt.Errorf("getting authorities at block %s: %w", req.BlockHash, err)
+	}
+
+	authorities := make([]GrandpaAuthority, len(voters))
+	for i, voter := range voters {
+		authorities[i] = GrandpaAuthority{
+			Key:    voter.Key.Hex(),
+			Weight: voter.ID,
+		}
+	}
+
+	*res = AuthoritiesAtResponse{SetID: setID, Authorities: authorities}
+	return nil
+}
+
 func thresholdWeight(totalWeight uint32) uint32 {
 	return totalWeight * 2 / 3
 }
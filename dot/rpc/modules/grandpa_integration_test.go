@@ -10,9 +10,11 @@
 
 	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
 	"github.com/ChainSafe/gossamer/lib/grandpa"
 	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/pkg/scale"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -32,16 +34,24 @@ func TestGrandpaProveFinality(t *testing.T) {
 		t.Errorf("Fail: bestblock failed")
 	}
 
-	gmSvc := NewGrandpaModule(testStateService.Block, nil)
+	gmSvc := NewGrandpaModule(testStateService.Block, nil, testStateService.Grandpa)
 
-	testStateService.Block.SetJustification(bestBlock.Header.ParentHash, make([]byte, 10))
-	testStateService.Block.SetJustification(bestBlock.Header.Hash(), make([]byte, 11))
+	justification := make([]byte, 11)
+	err = testStateService.Block.SetJustification(bestBlock.Header.Hash(), justification)
+	require.NoError(t, err)
+	err = testStateService.Block.SetFinalisedHash(bestBlock.Header.Hash(), 1, 0)
+	require.NoError(t, err)
 
-	expectedResponse := &ProveFinalityResponse{"0x0000000000000000000000"}
+	expectedProof := types.GrandpaFinalityProof{
+		Block:          bestBlock.Header.Hash(),
+		Justification:  justification,
+		UnknownHeaders: []types.Header{bestBlock.Header},
+	}
+	expectedResponse := &ProveFinalityResponse{common.BytesToHex(scale.MustMarshal(expectedProof))}
 
 	res := new(ProveFinalityResponse)
 	err = gmSvc.ProveFinality(nil, &ProveFinalityRequest{
-		BlockNumber: uint32(bestBlock.Header.Number),
+		BlockNumber: uint32(bestBlock.Header.Number - 1),
 	}, res)
 
 	if err != nil {
@@ -80,7 +90,7 @@ func TestRoundState(t *testing.T) {
 		kr.Bob().Public().(*ed25519.PublicKey).AsBytes(),
 	})
 
-	mod := NewGrandpaModule(nil, grandpamock)
+	mod := NewGrandpaModule(nil, grandpamock, nil)
 
 	res := new(RoundStateResponse)
 	err := mod.RoundState(nil, nil, res)
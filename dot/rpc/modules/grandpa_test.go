@@ -14,6 +14,7 @@
 	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
 	"github.com/ChainSafe/gossamer/lib/grandpa"
 	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/pkg/scale"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
@@ -23,16 +24,45 @@ func TestGrandpaModule_ProveFinality(t *testing.T) {
 
 	mockError := errors.New("test mock error")
 
+	header3 := &types.Header{ParentHash: common.Hash{2}, Number: 3, Digest: types.NewDigest()}
+	header4 := &types.Header{ParentHash: header3.Hash(), Number: 4, Digest: types.NewDigest()}
+	header5 := &types.Header{ParentHash: header4.Hash(), Number: 5, Digest: types.NewDigest()}
+	header6 := &types.Header{ParentHash: header5.Hash(), Number: 6, Digest: types.NewDigest()}
+	finalisedHash := header6.Hash()
+
+	expectFinalisedHeader := func(mockBlockAPI *MockBlockAPI) {
+		mockBlockAPI.EXPECT().GetHighestFinalisedHash().Return(finalisedHash, nil)
+		mockBlockAPI.EXPECT().GetHeader(finalisedHash).Return(header6, nil)
+	}
+	expectHeaders := func(mockBlockAPI *MockBlockAPI, headers ...*types.Header) {
+		for _, header := range headers {
+			mockBlockAPI.EXPECT().GetHashByNumber(header.Number).Return(header.Hash(), nil)
+			mockBlockAPI.EXPECT().GetHeader(header.Hash()).Return(header, nil)
+		}
+	}
+	expectSetID := func(blockNumber uint, setID, currentSetID uint64) func(*gomock.Controller) GrandpaStateAPI {
+		return func(ctrl *gomock.Controller) GrandpaStateAPI {
+			grandpaState := mocks.NewMockGrandpaStateAPI(ctrl)
+			grandpaState.EXPECT().GetSetIDByBlockNumber(blockNumber).Return(setID, nil)
+			grandpaState.EXPECT().GetCurrentSetID().Return(currentSetID, nil)
+			if setID < currentSetID {
+				grandpaState.EXPECT().GetSetIDChange(setID+1).Return(uint(5), nil)
+			}
+			return grandpaState
+		}
+	}
+
 	tests := map[string]struct {
-		blockAPIBuilder func(ctrl *gomock.Controller) BlockAPI
-		request         *ProveFinalityRequest
-		expErr          error
-		exp             ProveFinalityResponse
+		blockAPIBuilder        func(ctrl *gomock.Controller) BlockAPI
+		grandpaStateAPIBuilder func(ctrl *gomock.Controller) GrandpaStateAPI
+		request                *ProveFinalityRequest
+		expErr                 error
+		exp                    ProveFinalityResponse
 	}{
-		"error_during_get_hash_by_number": {
+		"error_during_get_highest_finalised_hash": {
 			blockAPIBuilder: func(ctrl *gomock.Controller) BlockAPI {
 				mockBlockAPI := NewMockBlockAPI(ctrl)
-				mockBlockAPI.EXPECT().GetHashByNumber(uint(1)).Return(common.Hash{}, mockError)
+				mockBlockAPI.EXPECT().GetHighestFinalisedHash().Return(common.Hash{}, mockError)
 				return mockBlockAPI
 			},
 			request: &ProveFinalityRequest{
@@ -40,55 +70,127 @@ func TestGrandpaModule_ProveFinality(t *testing.T) {
 			},
 			expErr: mockError,
 		},
+		"block_not_finalised": {
+			blockAPIBuilder: func(ctrl *gomock.Controller) BlockAPI {
+				mockBlockAPI := NewMockBlockAPI(ctrl)
+				expectFinalisedHeader(mockBlockAPI)
+				return mockBlockAPI
+			},
+			request: &ProveFinalityRequest{
+				BlockNumber: 7,
+			},
+		},
+		"error_during_get_set_id": {
+			blockAPIBuilder: func(ctrl *gomock.Controller) BlockAPI {
+				mockBlockAPI := NewMockBlockAPI(ctrl)
+				expectFinalisedHeader(mockBlockAPI)
+				return mockBlockAPI
+			},
+			grandpaStateAPIBuilder: func(ctrl *gomock.Controller) GrandpaStateAPI {
+				grandpaState := mocks.NewMockGrandpaStateAPI(ctrl)
+				grandpaState.EXPECT().GetSetIDByBlockNumber(uint(1)).Return(uint64(0), mockError)
+				return grandpaState
+			},
+			request: &ProveFinalityRequest{
+				BlockNumber: 1,
+			},
+			expErr: mockError,
+		},
 		"error_during_has_justification": {
 			blockAPIBuilder: func(ctrl *gomock.Controller) BlockAPI {
 				mockBlockAPI := NewMockBlockAPI(ctrl)
-				mockBlockAPI.EXPECT().GetHashByNumber(uint(2)).Return(common.Hash{2}, nil)
-				mockBlockAPI.EXPECT().HasJustification(common.Hash{2}).Return(false, mockError)
+				expectFinalisedHeader(mockBlockAPI)
+				mockBlockAPI.EXPECT().HasJustification(finalisedHash).Return(false, mockError)
 				return mockBlockAPI
 			},
+			grandpaStateAPIBuilder: expectSetID(2, 1, 1),
 			request: &ProveFinalityRequest{
 				BlockNumber: 2,
 			},
 			expErr: mockError,
 		},
-		"has_justification_is_false": {
+		"justified_block_without_justification": {
 			blockAPIBuilder: func(ctrl *gomock.Controller) BlockAPI {
 				mockBlockAPI := NewMockBlockAPI(ctrl)
-				mockBlockAPI.EXPECT().GetHashByNumber(uint(2)).Return(common.Hash{2}, nil)
-				mockBlockAPI.EXPECT().HasJustification(common.Hash{2}).Return(false, nil)
+				expectFinalisedHeader(mockBlockAPI)
+				mockBlockAPI.EXPECT().HasJustification(finalisedHash).Return(false, nil)
 				return mockBlockAPI
 			},
+			grandpaStateAPIBuilder: expectSetID(6, 1, 1),
 			request: &ProveFinalityRequest{
-				BlockNumber: 2,
+				BlockNumber: 6,
 			},
 			exp: ProveFinalityResponse{"GRANDPA prove finality rpc failed: Block not covered by authority set changes"},
 		},
 		"error_during_getJustification": {
 			blockAPIBuilder: func(ctrl *gomock.Controller) BlockAPI {
 				mockBlockAPI := NewMockBlockAPI(ctrl)
-				mockBlockAPI.EXPECT().GetHashByNumber(uint(3)).Return(common.Hash{3}, nil)
-				mockBlockAPI.EXPECT().HasJustification(common.Hash{3}).Return(true, nil)
-				mockBlockAPI.EXPECT().GetJustification(common.Hash{3}).Return(nil, mockError)
+				expectFinalisedHeader(mockBlockAPI)
+				mockBlockAPI.EXPECT().HasJustification(finalisedHash).Return(true, nil)
+				mockBlockAPI.EXPECT().GetJustification(finalisedHash).Return(nil, mockError)
 				return mockBlockAPI
 			},
+			grandpaStateAPIBuilder: expectSetID(3, 1, 1),
 			request: &ProveFinalityRequest{
 				BlockNumber: 3,
 			},
 			expErr: mockError,
 		},
-		"happy_path": {
+		"justified_block": {
+			blockAPIBuilder: func(ctrl *gomock.Controller) BlockAPI {
+				mockBlockAPI := NewMockBlockAPI(ctrl)
+				expectFinalisedHeader(mockBlockAPI)
+				mockBlockAPI.EXPECT().HasJustification(finalisedHash).Return(true, nil)
+				mockBlockAPI.EXPECT().GetJustification(finalisedHash).Return([]byte(`justification`), nil)
+				return mockBlockAPI
+			},
+			grandpaStateAPIBuilder: expectSetID(6, 1, 1),
+			request: &ProveFinalityRequest{
+				BlockNumber: 6,
+			},
+			exp: ProveFinalityResponse{common.BytesToHex(scale.MustMarshal(types.GrandpaFinalityProof{
+				Block:         finalisedHash,
+				Justification: []byte(`justification`),
+			}))},
+		},
+		"block_of_current_set_finalised_by_descendant": {
 			blockAPIBuilder: func(ctrl *gomock.Controller) BlockAPI {
 				mockBlockAPI := NewMockBlockAPI(ctrl)
-				mockBlockAPI.EXPECT().GetHashByNumber(uint(4)).Return(common.Hash{4}, nil)
-				mockBlockAPI.EXPECT().HasJustification(common.Hash{4}).Return(true, nil)
-				mockBlockAPI.EXPECT().GetJustification(common.Hash{4}).Return([]byte(`justification`), nil)
+				expectFinalisedHeader(mockBlockAPI)
+				mockBlockAPI.EXPECT().HasJustification(finalisedHash).Return(true, nil)
+				mockBlockAPI.EXPECT().GetJustification(finalisedHash).Return([]byte(`justification`), nil)
+				expectHeaders(mockBlockAPI, header5, header6)
 				return mockBlockAPI
 			},
+			grandpaStateAPIBuilder: expectSetID(4, 1, 1),
 			request: &ProveFinalityRequest{
 				BlockNumber: 4,
 			},
-			exp: ProveFinalityResponse{common.BytesToHex([]byte(`justification`))},
+			exp: ProveFinalityResponse{common.BytesToHex(scale.MustMarshal(types.GrandpaFinalityProof{
+				Block:          finalisedHash,
+				Justification:  []byte(`justification`),
+				UnknownHeaders: []types.Header{*header5, *header6},
+			}))},
+		},
+		"block_of_previous_set_finalised_by_last_block_of_set": {
+			blockAPIBuilder: func(ctrl *gomock.Controller) BlockAPI {
+				mockBlockAPI := NewMockBlockAPI(ctrl)
+				expectFinalisedHeader(mockBlockAPI)
+				mockBlockAPI.EXPECT().GetHashByNumber(uint(5)).Return(header5.Hash(), nil)
+				mockBlockAPI.EXPECT().HasJustification(header5.Hash()).Return(true, nil)
+				mockBlockAPI.EXPECT().GetJustification(header5.Hash()).Return([]byte(`justification`), nil)
+				expectHeaders(mockBlockAPI, header4, header5)
+				return mockBlockAPI
+			},
+			grandpaStateAPIBuilder: expectSetID(3, 0, 1),
+			request: &ProveFinalityRequest{
+				BlockNumber: 3,
+			},
+			exp: ProveFinalityResponse{common.BytesToHex(scale.MustMarshal(types.GrandpaFinalityProof{
+				Block:          header5.Hash(),
+				Justification:  []byte(`justification`),
+				UnknownHeaders: []types.Header{*header4, *header5},
+			}))},
 		},
 	}
 	for name, tt := range tests {
@@ -99,6 +201,9 @@ func TestGrandpaModule_ProveFinality(t *testing.T) {
 			gm := &GrandpaModule{
 				blockAPI: tt.blockAPIBuilder(ctrl),
 			}
+			if tt.grandpaStateAPIBuilder != nil {
+				gm.grandpaState = tt.grandpaStateAPIBuilder(ctrl)
+			}
 			res := ProveFinalityResponse(nil)
 			err := gm.ProveFinality(nil, tt.request, &res)
 			assert.Equal(t, tt.exp, res)
@@ -214,3 +319,161 @@ type args struct {
 		})
 	}
 }
+
+func TestGrandpaModule_DumpMessageJournal(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+
+	testCases := map[string]struct {
+		path   string
+		err    error
+		expErr error
+	}{
+		"dumped": {
+			path: "/base/grandpa_journal_1.jsonl",
+		},
+		"error": {
+			err:    errTest,
+			expErr: errTest,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			blockFinalityAPI := mocks.NewMockBlockFinalityAPI(ctrl)
+			blockFinalityAPI.EXPECT().DumpMessageJournal().Return(testCase.path, testCase.err)
+			module := NewGrandpaModule(nil, blockFinalityAPI, nil)
+
+			var res string
+			err := module.DumpMessageJournal(nil, &EmptyRequest{}, &res)
+			assert.ErrorIs(t, err, testCase.expErr)
+			assert.Equal(t, testCase.path, res)
+		})
+	}
+}
+
+func TestGrandpaModule_SubmitJustification(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+	hash := common.Hash{1}
+
+	testCases := map[string]struct {
+		justification string
+		importErr     error
+		expectImport  bool
+		res           SubmitJustificationResponse
+		errWrapped    error
+		errMessage    string
+	}{
+		"no_prefix": {
+			justification: "0102",
+			errWrapped:    common.ErrNoPrefix,
+			errMessage:    "decoding justification: could not byteify non 0x prefixed string: 0102",
+		},
+		"import_error": {
+			justification: "0x0102",
+			expectImport:  true,
+			importErr:     errTest,
+			errWrapped:    errTest,
+			errMessage:    "importing justification: test error",
+		},
+		"imported": {
+			justification: "0x0102",
+			expectImport:  true,
+			res:           SubmitJustificationResponse{Round: 3, SetID: 1},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			blockFinalityAPI := mocks.NewMockBlockFinalityAPI(ctrl)
+			if testCase.expectImport {
+				blockFinalityAPI.EXPECT().ImportJustification(hash, []byte{1, 2}).
+					Return(testCase.res.Round, testCase.res.SetID, testCase.importErr)
+			}
+			module := NewGrandpaModule(nil, blockFinalityAPI, nil)
+
+			var res SubmitJustificationResponse
+			err := module.SubmitJustification(nil, &SubmitJustificationRequest{
+				BlockHash:     hash,
+				Justification: testCase.justification,
+			}, &res)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errMessage != "" {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.res, res)
+		})
+	}
+}
+
+func TestGrandpaModule_AuthoritiesAt(t *testing.T) {
+	t.Parallel()
+
+	kr, err := keystore.NewEd25519Keyring()
+	assert.NoError(t, err)
+
+	errTest := errors.New("test error")
+	hash := common.Hash{1}
+	voters := []types.GrandpaVoter{
+		{Key: *kr.Alice().Public().(*ed25519.PublicKey), ID: 1},
+		{Key: *kr.Bob().Public().(*ed25519.PublicKey), ID: 2},
+	}
+
+	testCases := map[string]struct {
+		setID      uint64
+		voters     []types.GrandpaVoter
+		stateErr   error
+		res        AuthoritiesAtResponse
+		errWrapped error
+		errMessage string
+	}{
+		"state_error": {
+			stateErr:   errTest,
+			errWrapped: errTest,
+			errMessage: "getting authorities at block " + hash.String() + ": test error",
+		},
+		"authorities": {
+			setID:  3,
+			voters: voters,
+			res: AuthoritiesAtResponse{
+				SetID: 3,
+				Authorities: []GrandpaAuthority{
+					{Key: kr.Alice().Public().Hex(), Weight: 1},
+					{Key: kr.Bob().Public().Hex(), Weight: 2},
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			grandpaState := mocks.NewMockGrandpaStateAPI(ctrl)
+			grandpaState.EXPECT().AuthoritiesAt(hash).
+				Return(testCase.setID, testCase.voters, testCase.stateErr)
+			module := NewGrandpaModule(nil, nil, grandpaState)
+
+			var res AuthoritiesAtResponse
+			err := module.AuthoritiesAt(nil, &AuthoritiesAtRequest{BlockHash: hash}, &res)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errMessage != "" {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.res, res)
+		})
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package modules
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/genesis"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// persistedEpochRegular is the index of the Regular variant of the
+// PersistedEpoch and PersistedEpochHeader enums of Substrate.
+const persistedEpochRegular byte = 1
+
+// babeEpoch is the SCALE layout of a BABE epoch in the Substrate epoch changes.
+type babeEpoch struct {
+	EpochIndex  uint64
+	StartSlot   uint64
+	Duration    uint64
+	Authorities []types.AuthorityRaw
+	Randomness  [types.RandomnessLength]byte
+	C1          uint64
+	C2          uint64
+	// AllowedSlots is the secondary slots configuration of the epoch.
+	AllowedSlots byte
+}
+
+// persistedEpoch is a regular BABE epoch, encoded as a PersistedEpoch enum.
+type persistedEpoch babeEpoch
+
+func (e persistedEpoch) MarshalSCALE() ([]byte, error) {
+	encoded, err := scale.Marshal(babeEpoch(e))
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{persistedEpochRegular}, encoded...), nil
+}
+
+// persistedEpochHeader is the slot range of a regular BABE epoch, encoded as a
+// PersistedEpochHeader enum.
+type persistedEpochHeader struct {
+	StartSlot uint64
+	EndSlot   uint64
+}
+
+func (h persistedEpochHeader) MarshalSCALE() ([]byte, error) {
+	encoded, err := scale.Marshal(struct{ StartSlot, EndSlot uint64 }(h))
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{persistedEpochRegular}, encoded...), nil
+}
+
+type epochForkTreeNode struct {
+	Hash     common.Hash
+	Number   uint32
+	Data     persistedEpochHeader
+	Children []epochForkTreeNode
+}
+
+type epochForkTree struct {
+	Roots               []epochForkTreeNode
+	BestFinalisedNumber *uint32
+}
+
+type epochChangesEntry struct {
+	Hash   common.Hash
+	Number uint32
+	Epoch  persistedEpoch
+}
+
+// epochChanges is the SCALE layout of the Substrate BABE epoch changes, made of a fork
+// tree of the epoch headers and a map of the epochs by the block they are keyed by.
+type epochChanges struct {
+	Inner  epochForkTree
+	Epochs []epochChangesEntry
+}
+
+type grandpaAuthority struct {
+	Key    [32]byte
+	Weight uint64
+}
+
+// pendingChange is a pending GRANDPA authority set change. Pending changes are
+// not tracked, so there is never any to encode.
+type pendingChange struct{}
+
+type pendingChangesForkTree struct {
+	Roots               []pendingChange
+	BestFinalisedNumber *uint32
+}
+
+type authoritySetChange struct {
+	SetID       uint64
+	BlockNumber uint32
+}
+
+// grandpaAuthoritySet is the SCALE layout of the Substrate GRANDPA authority set.
+type grandpaAuthoritySet struct {
+	CurrentAuthorities     []grandpaAuthority
+	SetID                  uint64
+	PendingStandardChanges pendingChangesForkTree
+	PendingForcedChanges   []pendingChange
+	AuthoritySetChanges    []authoritySetChange
+}
+
+// lightSyncState returns the light sync state at the highest finalised block.
+// The epoch changes only hold the epoch of the finalised block, keyed by the
+// finalised block, and the BABE finalised block weight is not tracked, so is 0.
+func (s syncState) lightSyncState() (*genesis.LightSyncState, error) {
+	finalisedHash, err := s.blockAPI.GetHighestFinalisedHash()
+	if err != nil {
+		return nil, fmt.Errorf("getting highest finalised hash: %w", err)
+	}
+
+	finalisedHeader, err := s.blockAPI.GetHeader(finalisedHash)
+	if err != nil {
+		return nil, fmt.Errorf("getting highest finalised header: %w", err)
+	}
+
+	encodedHeader, err := scale.Marshal(*finalisedHeader)
+	if err != nil {
+		return nil, fmt.Errorf("encoding finalised header: %w", err)
+	}
+
+	encodedEpochChanges, err := s.encodeEpochChanges(finalisedHeader)
+	if err != nil {
+		return nil, fmt.Errorf("encoding epoch changes: %w", err)
+	}
+
+	encodedAuthoritySet, err := s.encodeAuthoritySet()
+	if err != nil {
+		return nil, fmt.Errorf("encoding authority set: %w", err)
+	}
+
+	return &genesis.LightSyncState{
+		FinalizedBlockHeader: common.BytesToHex(encodedHeader),
+		BabeEpochChanges:     common.BytesToHex(encodedEpochChanges),
+		GrandpaAuthoritySet:  common.BytesToHex(encodedAuthoritySet),
+	}, nil
+}
+
+func (s syncState) encodeEpochChanges(finalisedHeader *types.Header) ([]byte, error) {
+	epoch, err := s.epochState.GetEpochForBlock(finalisedHeader)
+	if err != nil {
+		return nil, fmt.Errorf("getting epoch for finalised block: %w", err)
+	}
+
+	epochData, err := s.epochState.GetEpochDataRaw(epoch, finalisedHeader)
+	if err != nil {
+		return nil, fmt.Errorf("getting epoch data for epoch %d: %w", epoch, err)
+	}
+
+	configData, err := s.epochState.GetConfigData(epoch, finalisedHeader)
+	if err != nil {
+		return nil, fmt.Errorf("getting config data for epoch %d: %w", epoch, err)
+	}
+
+	startSlot, err := s.epochState.GetStartSlotForEpoch(epoch, finalisedHeader.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("getting start slot for epoch %d: %w", epoch, err)
+	}
+
+	epochLength := s.epochState.GetEpochLength()
+	number := uint32(finalisedHeader.Number) //nolint:gosec
+	changes := epochChanges{
+		Inner: epochForkTree{
+			Roots: []epochForkTreeNode{{
+				Hash:     finalisedHeader.Hash(),
+				Number:   number,
+				Data:     persistedEpochHeader{StartSlot: startSlot, EndSlot: startSlot + epochLength},
+				Children: []epochForkTreeNode{},
+			}},
+			BestFinalisedNumber: &number,
+		},
+		Epochs: []epochChangesEntry{{
+			Hash:   finalisedHeader.Hash(),
+			Number: number,
+			Epoch: persistedEpoch{
+				EpochIndex:   epoch,
+				StartSlot:    startSlot,
+				Duration:     epochLength,
+				Authorities:  epochData.Authorities,
+				Randomness:   epochData.Randomness,
+				C1:           configData.C1,
+				C2:           configData.C2,
+				AllowedSlots: configData.SecondarySlots,
+			},
+		}},
+	}
+	return scale.Marshal(changes)
+}
+
+func (s syncState) encodeAuthoritySet() ([]byte, error) {
+	setID, err := s.grandpaState.GetCurrentSetID()
+	if err != nil {
+		return nil, fmt.Errorf("getting current set id: %w", err)
+	}
+
+	voters, err := s.grandpaState.GetAuthorities(setID)
+	if err != nil {
+		return nil, fmt.Errorf("getting authorities of set id %d: %w", setID, err)
+	}
+
+	authorities := make([]grandpaAuthority, len(voters))
+	for i, voter := range voters {
+		authorities[i] = grandpaAuthority{Key: voter.Key.AsBytes(), Weight: 1}
+	}
+
+	return scale.Marshal(grandpaAuthoritySet{
+		CurrentAuthorities:     authorities,
+		SetID:                  setID,
+		PendingStandardChanges: pendingChangesForkTree{Roots: []pendingChange{}},
+		PendingForcedChanges:   []pendingChange{},
+		AuthoritySetChanges:    []authoritySetChange{},
+	})
+}
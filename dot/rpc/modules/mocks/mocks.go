@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/ChainSafe/gossamer/dot/rpc/modules (interfaces: StorageAPI,BlockAPI,NetworkAPI,BlockProducerAPI,TransactionStateAPI,CoreAPI,SystemAPI,BlockFinalityAPI,RuntimeStorageAPI,SyncStateAPI)
+// Source: github.com/ChainSafe/gossamer/dot/rpc/modules (interfaces: StorageAPI,BlockAPI,NetworkAPI,BlockProducerAPI,TransactionStateAPI,CoreAPI,SystemAPI,BlockFinalityAPI,RuntimeStorageAPI,SyncStateAPI,EpochStateAPI,GrandpaStateAPI,DatabaseAPI)
 //
 // Generated by this command:
 //
-//	mockgen -destination=mocks/mocks.go -package mocks . StorageAPI,BlockAPI,NetworkAPI,BlockProducerAPI,TransactionStateAPI,CoreAPI,SystemAPI,BlockFinalityAPI,RuntimeStorageAPI,SyncStateAPI
+//	mockgen -destination=mocks/mocks.go -package mocks . StorageAPI,BlockAPI,NetworkAPI,BlockProducerAPI,TransactionStateAPI,CoreAPI,SystemAPI,BlockFinalityAPI,RuntimeStorageAPI,SyncStateAPI,EpochStateAPI,GrandpaStateAPI,DatabaseAPI
 //
 
 // Package mocks is a generated GoMock package.
@@ -11,10 +11,12 @@
 
 import (
 	reflect "reflect"
+	time "time"
 
 	core "github.com/ChainSafe/gossamer/dot/core"
 	state "github.com/ChainSafe/gossamer/dot/state"
 	types "github.com/ChainSafe/gossamer/dot/types"
+	babe "github.com/ChainSafe/gossamer/lib/babe"
 	common "github.com/ChainSafe/gossamer/lib/common"
 	ed25519 "github.com/ChainSafe/gossamer/lib/crypto/ed25519"
 	genesis "github.com/ChainSafe/gossamer/lib/genesis"
@@ -512,6 +514,20 @@ func (mr *MockNetworkAPIMockRecorder) NodeRoles() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NodeRoles", reflect.TypeOf((*MockNetworkAPI)(nil).NodeRoles))
 }
 
+// PeerStats mocks base method.
+func (m *MockNetworkAPI) PeerStats() []common.PeerStats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PeerStats")
+	ret0, _ := ret[0].([]common.PeerStats)
+	return ret0
+}
+
+// PeerStats indicates an expected call of PeerStats.
+func (mr *MockNetworkAPIMockRecorder) PeerStats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PeerStats", reflect.TypeOf((*MockNetworkAPI)(nil).PeerStats))
+}
+
 // Peers mocks base method.
 func (m *MockNetworkAPI) Peers() []common.PeerInfo {
 	m.ctrl.T.Helper()
@@ -544,6 +560,21 @@ func (mr *MockNetworkAPIMockRecorder) RemoveReservedPeers(arg0 ...any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveReservedPeers", reflect.TypeOf((*MockNetworkAPI)(nil).RemoveReservedPeers), arg0...)
 }
 
+// RotateIdentity mocks base method.
+func (m *MockNetworkAPI) RotateIdentity(arg0 time.Duration) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateIdentity", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateIdentity indicates an expected call of RotateIdentity.
+func (mr *MockNetworkAPIMockRecorder) RotateIdentity(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateIdentity", reflect.TypeOf((*MockNetworkAPI)(nil).RotateIdentity), arg0)
+}
+
 // Start mocks base method.
 func (m *MockNetworkAPI) Start() error {
 	m.ctrl.T.Helper()
@@ -609,6 +640,21 @@ func (m *MockBlockProducerAPI) EXPECT() *MockBlockProducerAPIMockRecorder {
 	return m.recorder
 }
 
+// EpochAuthorship mocks base method.
+func (m *MockBlockProducerAPI) EpochAuthorship() (map[string]babe.EpochAuthorship, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EpochAuthorship")
+	ret0, _ := ret[0].(map[string]babe.EpochAuthorship)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EpochAuthorship indicates an expected call of EpochAuthorship.
+func (mr *MockBlockProducerAPIMockRecorder) EpochAuthorship() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EpochAuthorship", reflect.TypeOf((*MockBlockProducerAPI)(nil).EpochAuthorship))
+}
+
 // EpochLength mocks base method.
 func (m *MockBlockProducerAPI) EpochLength() uint64 {
 	m.ctrl.T.Helper()
@@ -725,6 +771,21 @@ func (m *MockCoreAPI) EXPECT() *MockCoreAPIMockRecorder {
 	return m.recorder
 }
 
+// AuthorityStatus mocks base method.
+func (m *MockCoreAPI) AuthorityStatus() (*core.AuthorityStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorityStatus")
+	ret0, _ := ret[0].(*core.AuthorityStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthorityStatus indicates an expected call of AuthorityStatus.
+func (mr *MockCoreAPIMockRecorder) AuthorityStatus() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorityStatus", reflect.TypeOf((*MockCoreAPI)(nil).AuthorityStatus))
+}
+
 // DecodeSessionKeys mocks base method.
 func (m *MockCoreAPI) DecodeSessionKeys(arg0 []byte) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -945,6 +1006,21 @@ func (m *MockBlockFinalityAPI) EXPECT() *MockBlockFinalityAPIMockRecorder {
 	return m.recorder
 }
 
+// DumpMessageJournal mocks base method.
+func (m *MockBlockFinalityAPI) DumpMessageJournal() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DumpMessageJournal")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DumpMessageJournal indicates an expected call of DumpMessageJournal.
+func (mr *MockBlockFinalityAPIMockRecorder) DumpMessageJournal() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DumpMessageJournal", reflect.TypeOf((*MockBlockFinalityAPI)(nil).DumpMessageJournal))
+}
+
 // GetRound mocks base method.
 func (m *MockBlockFinalityAPI) GetRound() uint64 {
 	m.ctrl.T.Helper()
@@ -987,6 +1063,22 @@ func (mr *MockBlockFinalityAPIMockRecorder) GetVoters() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVoters", reflect.TypeOf((*MockBlockFinalityAPI)(nil).GetVoters))
 }
 
+// ImportJustification mocks base method.
+func (m *MockBlockFinalityAPI) ImportJustification(arg0 common.Hash, arg1 []byte) (uint64, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportJustification", arg0, arg1)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ImportJustification indicates an expected call of ImportJustification.
+func (mr *MockBlockFinalityAPIMockRecorder) ImportJustification(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportJustification", reflect.TypeOf((*MockBlockFinalityAPI)(nil).ImportJustification), arg0, arg1)
+}
+
 // PreCommits mocks base method.
 func (m *MockBlockFinalityAPI) PreCommits() []ed25519.PublicKeyBytes {
 	m.ctrl.T.Helper()
@@ -1133,3 +1225,237 @@ func (mr *MockSyncStateAPIMockRecorder) GenSyncSpec(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenSyncSpec", reflect.TypeOf((*MockSyncStateAPI)(nil).GenSyncSpec), arg0)
 }
+
+// MockEpochStateAPI is a mock of EpochStateAPI interface.
+type MockEpochStateAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockEpochStateAPIMockRecorder
+}
+
+// MockEpochStateAPIMockRecorder is the mock recorder for MockEpochStateAPI.
+type MockEpochStateAPIMockRecorder struct {
+	mock *MockEpochStateAPI
+}
+
+// NewMockEpochStateAPI creates a new mock instance.
+func NewMockEpochStateAPI(ctrl *gomock.Controller) *MockEpochStateAPI {
+	mock := &MockEpochStateAPI{ctrl: ctrl}
+	mock.recorder = &MockEpochStateAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEpochStateAPI) EXPECT() *MockEpochStateAPIMockRecorder {
+	return m.recorder
+}
+
+// GetConfigData mocks base method.
+func (m *MockEpochStateAPI) GetConfigData(arg0 uint64, arg1 *types.Header) (*types.ConfigData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConfigData", arg0, arg1)
+	ret0, _ := ret[0].(*types.ConfigData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConfigData indicates an expected call of GetConfigData.
+func (mr *MockEpochStateAPIMockRecorder) GetConfigData(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigData", reflect.TypeOf((*MockEpochStateAPI)(nil).GetConfigData), arg0, arg1)
+}
+
+// GetEpochDataRaw mocks base method.
+func (m *MockEpochStateAPI) GetEpochDataRaw(arg0 uint64, arg1 *types.Header) (*types.EpochDataRaw, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEpochDataRaw", arg0, arg1)
+	ret0, _ := ret[0].(*types.EpochDataRaw)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEpochDataRaw indicates an expected call of GetEpochDataRaw.
+func (mr *MockEpochStateAPIMockRecorder) GetEpochDataRaw(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEpochDataRaw", reflect.TypeOf((*MockEpochStateAPI)(nil).GetEpochDataRaw), arg0, arg1)
+}
+
+// GetEpochForBlock mocks base method.
+func (m *MockEpochStateAPI) GetEpochForBlock(arg0 *types.Header) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEpochForBlock", arg0)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEpochForBlock indicates an expected call of GetEpochForBlock.
+func (mr *MockEpochStateAPIMockRecorder) GetEpochForBlock(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEpochForBlock", reflect.TypeOf((*MockEpochStateAPI)(nil).GetEpochForBlock), arg0)
+}
+
+// GetEpochLength mocks base method.
+func (m *MockEpochStateAPI) GetEpochLength() uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEpochLength")
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// GetEpochLength indicates an expected call of GetEpochLength.
+func (mr *MockEpochStateAPIMockRecorder) GetEpochLength() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEpochLength", reflect.TypeOf((*MockEpochStateAPI)(nil).GetEpochLength))
+}
+
+// GetStartSlotForEpoch mocks base method.
+func (m *MockEpochStateAPI) GetStartSlotForEpoch(arg0 uint64, arg1 common.Hash) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStartSlotForEpoch", arg0, arg1)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStartSlotForEpoch indicates an expected call of GetStartSlotForEpoch.
+func (mr *MockEpochStateAPIMockRecorder) GetStartSlotForEpoch(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStartSlotForEpoch", reflect.TypeOf((*MockEpochStateAPI)(nil).GetStartSlotForEpoch), arg0, arg1)
+}
+
+// MockGrandpaStateAPI is a mock of GrandpaStateAPI interface.
+type MockGrandpaStateAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockGrandpaStateAPIMockRecorder
+}
+
+// MockGrandpaStateAPIMockRecorder is the mock recorder for MockGrandpaStateAPI.
+type MockGrandpaStateAPIMockRecorder struct {
+	mock *MockGrandpaStateAPI
+}
+
+// NewMockGrandpaStateAPI creates a new mock instance.
+func NewMockGrandpaStateAPI(ctrl *gomock.Controller) *MockGrandpaStateAPI {
+	mock := &MockGrandpaStateAPI{ctrl: ctrl}
+	mock.recorder = &MockGrandpaStateAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGrandpaStateAPI) EXPECT() *MockGrandpaStateAPIMockRecorder {
+	return m.recorder
+}
+
+// AuthoritiesAt mocks base method.
+func (m *MockGrandpaStateAPI) AuthoritiesAt(arg0 common.Hash) (uint64, []types.GrandpaVoter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthoritiesAt", arg0)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].([]types.GrandpaVoter)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AuthoritiesAt indicates an expected call of AuthoritiesAt.
+func (mr *MockGrandpaStateAPIMockRecorder) AuthoritiesAt(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthoritiesAt", reflect.TypeOf((*MockGrandpaStateAPI)(nil).AuthoritiesAt), arg0)
+}
+
+// GetAuthorities mocks base method.
+func (m *MockGrandpaStateAPI) GetAuthorities(arg0 uint64) ([]types.GrandpaVoter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthorities", arg0)
+	ret0, _ := ret[0].([]types.GrandpaVoter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuthorities indicates an expected call of GetAuthorities.
+func (mr *MockGrandpaStateAPIMockRecorder) GetAuthorities(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthorities", reflect.TypeOf((*MockGrandpaStateAPI)(nil).GetAuthorities), arg0)
+}
+
+// GetCurrentSetID mocks base method.
+func (m *MockGrandpaStateAPI) GetCurrentSetID() (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentSetID")
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentSetID indicates an expected call of GetCurrentSetID.
+func (mr *MockGrandpaStateAPIMockRecorder) GetCurrentSetID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentSetID", reflect.TypeOf((*MockGrandpaStateAPI)(nil).GetCurrentSetID))
+}
+
+// GetSetIDByBlockNumber mocks base method.
+func (m *MockGrandpaStateAPI) GetSetIDByBlockNumber(arg0 uint) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSetIDByBlockNumber", arg0)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSetIDByBlockNumber indicates an expected call of GetSetIDByBlockNumber.
+func (mr *MockGrandpaStateAPIMockRecorder) GetSetIDByBlockNumber(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSetIDByBlockNumber", reflect.TypeOf((*MockGrandpaStateAPI)(nil).GetSetIDByBlockNumber), arg0)
+}
+
+// GetSetIDChange mocks base method.
+func (m *MockGrandpaStateAPI) GetSetIDChange(arg0 uint64) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSetIDChange", arg0)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSetIDChange indicates an expected call of GetSetIDChange.
+func (mr *MockGrandpaStateAPIMockRecorder) GetSetIDChange(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSetIDChange", reflect.TypeOf((*MockGrandpaStateAPI)(nil).GetSetIDChange), arg0)
+}
+
+// MockDatabaseAPI is a mock of DatabaseAPI interface.
+type MockDatabaseAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockDatabaseAPIMockRecorder
+}
+
+// MockDatabaseAPIMockRecorder is the mock recorder for MockDatabaseAPI.
+type MockDatabaseAPIMockRecorder struct {
+	mock *MockDatabaseAPI
+}
+
+// NewMockDatabaseAPI creates a new mock instance.
+func NewMockDatabaseAPI(ctrl *gomock.Controller) *MockDatabaseAPI {
+	mock := &MockDatabaseAPI{ctrl: ctrl}
+	mock.recorder = &MockDatabaseAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDatabaseAPI) EXPECT() *MockDatabaseAPIMockRecorder {
+	return m.recorder
+}
+
+// DatabaseStats mocks base method.
+func (m *MockDatabaseAPI) DatabaseStats(arg0 int) (*state.DatabaseStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DatabaseStats", arg0)
+	ret0, _ := ret[0].(*state.DatabaseStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DatabaseStats indicates an expected call of DatabaseStats.
+func (mr *MockDatabaseAPIMockRecorder) DatabaseStats(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DatabaseStats", reflect.TypeOf((*MockDatabaseAPI)(nil).DatabaseStats), arg0)
+}
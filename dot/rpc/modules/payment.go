@@ -6,6 +6,7 @@
 import (
 	"net/http"
 
+	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
 )
 
@@ -27,6 +28,8 @@ type PaymentQueryInfoResponse struct {
 // PaymentModule holds all the RPC implementation of polkadot payment rpc api
 type PaymentModule struct {
 	blockAPI BlockAPI
+	// runtimeCalls caches the results of the read-only runtime calls, it can be nil.
+	runtimeCalls *RuntimeCallCache
 }
 
 // NewPaymentModule returns a pointer to PaymentModule
@@ -36,6 +39,12 @@ func NewPaymentModule(blockAPI BlockAPI) *PaymentModule {
 	}
 }
 
+// WithRuntimeCallCache sets the cache of the read-only runtime call results of the module.
+func (p *PaymentModule) WithRuntimeCallCache(cache *RuntimeCallCache) *PaymentModule {
+	p.runtimeCalls = cache
+	return p
+}
+
 // QueryInfo query the known data about the fee of an extrinsic at the given block
 func (p *PaymentModule) QueryInfo(_ *http.Request, req *PaymentQueryInfoRequest, res *PaymentQueryInfoResponse) error {
 	var hash common.Hash
@@ -55,7 +64,8 @@ func (p *PaymentModule) QueryInfo(_ *http.Request, req *PaymentQueryInfoRequest,
 		return err
 	}
 
-	encQueryInfo, err := r.PaymentQueryInfo(ext)
+	encQueryInfo, err := cachedRuntimeCall(p.runtimeCalls, &hash, "TransactionPaymentApi_query_info", ext,
+		func() (*types.RuntimeDispatchInfo, error) { return r.PaymentQueryInfo(ext) })
 	if err != nil {
 		return err
 	}
@@ -12,13 +12,25 @@
 	UnsafeMethods = []string{
 		"system_addReservedPeer",
 		"system_removeReservedPeer",
+		"system_rotateNodeKey",
 		"author_submitExtrinsic",
 		"author_removeExtrinsic",
 		"author_insertKey",
 		"author_rotateKeys",
+		"author_authorityStatus",
+		"offchain_localStorageGet",
+		"offchain_localStorageSet",
 		"state_getPairs",
 		"state_getKeysPaged",
 		"state_queryStorage",
+		"childstate_getKeysPaged",
+		"grandpa_dumpMessageJournal",
+		"grandpa_submitJustification",
+		"grandpa_proveFinality",
+		"babe_epochAuthorship",
+		"babe_randomness",
+		"babe_blockVrfOutputs",
+		"dev_dbStats",
 	}
 
 	// AliasesMethods is a map that links the original methods to their aliases
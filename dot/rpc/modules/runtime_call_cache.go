@@ -0,0 +1,132 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package modules
+
+import (
+	"github.com/ChainSafe/gossamer/lib/common"
+	lrucache "github.com/ChainSafe/gossamer/lib/utils/lru-cache"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"golang.org/x/sync/singleflight"
+)
+
+// runtimeCallCacheCapacity is the maximum number of runtime call results cached.
+const runtimeCallCacheCapacity = 1024
+
+var (
+	runtimeCallCacheHits = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "gossamer_rpc",
+		Name:      "runtime_call_cache_hits_total",
+		Help:      "total number of read-only runtime calls answered from the cache",
+	})
+	runtimeCallCacheMisses = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "gossamer_rpc",
+		Name:      "runtime_call_cache_misses_total",
+		Help:      "total number of read-only runtime calls executed by the runtime",
+	})
+)
+
+// cacheableRuntimeCalls are the runtime API methods which only read the state of
+// the block they are called at, so their result is cached for the block.
+var cacheableRuntimeCalls = map[string]struct{}{
+	"Core_version":                                     {},
+	"Metadata_metadata":                                {},
+	"Metadata_metadata_at_version":                     {},
+	"Metadata_metadata_versions":                       {},
+	"AccountNonceApi_account_nonce":                    {},
+	"TransactionPaymentApi_query_info":                 {},
+	"TransactionPaymentApi_query_fee_details":          {},
+	"TransactionPaymentApi_query_weight_to_fee":        {},
+	"TransactionPaymentApi_query_length_to_fee":        {},
+	"TransactionPaymentCallApi_query_call_info":        {},
+	"TransactionPaymentCallApi_query_call_fee_details": {},
+}
+
+type runtimeCallKey struct {
+	blockHash common.Hash
+	method    string
+	args      string
+}
+
+type runtimeCallResult struct {
+	// number is the number of the block the call was made at.
+	number uint
+	value  any
+}
+
+// RuntimeCallCache caches the results of the read-only runtime calls made by the
+// RPC modules, keyed by block hash, method and arguments. A result expires once
+// its block falls behind the best block by more than the retained blocks, since
+// the state of the block may then have been pruned.
+type RuntimeCallCache struct {
+	blockAPI     BlockAPI
+	retainBlocks uint
+	results      *lrucache.LRUCache[runtimeCallKey, *runtimeCallResult]
+	// calls deduplicates the concurrent identical calls missing the cache.
+	calls singleflight.Group
+}
+
+// NewRuntimeCallCache returns a RuntimeCallCache expiring the results of the
+// calls made at blocks more than retainBlocks behind the best block.
+func NewRuntimeCallCache(blockAPI BlockAPI, retainBlocks uint) *RuntimeCallCache {
+	return &RuntimeCallCache{
+		blockAPI:     blockAPI,
+		retainBlocks: retainBlocks,
+		results:      lrucache.NewLRUCache[runtimeCallKey, *runtimeCallResult](runtimeCallCacheCapacity),
+	}
+}
+
+// expired returns true if the given block number is out of the retention window.
+func (c *RuntimeCallCache) expired(number uint) bool {
+	bestHeader, err := c.blockAPI.GetHeader(c.blockAPI.BestBlockHash())
+	if err != nil {
+		return true
+	}
+	return bestHeader.Number > number+c.retainBlocks
+}
+
+// cachedRuntimeCall returns the cached result of the runtime call of the method with the
+// given arguments at the given block, or the best block if nil, and otherwise makes the
+// call and caches its result if it succeeds. A nil cache always makes the call.
+// The cached results are shared between callers, which must not modify them.
+func cachedRuntimeCall[T any](c *RuntimeCallCache, blockHash *common.Hash, method string, args []byte,
+	call func() (T, error)) (T, error) {
+	if c == nil {
+		return call()
+	}
+
+	var hash common.Hash
+	if blockHash == nil {
+		hash = c.blockAPI.BestBlockHash()
+	} else {
+		hash = *blockHash
+	}
+
+	header, err := c.blockAPI.GetHeader(hash)
+	if err != nil {
+		// the call fails or is made at a block unknown to the block state
+		return call()
+	}
+
+	key := runtimeCallKey{blockHash: hash, method: method, args: string(args)}
+	if result := c.results.Get(key); result != nil && !c.expired(result.number) {
+		runtimeCallCacheHits.Inc()
+		return result.value.(T), nil
+	}
+	runtimeCallCacheMisses.Inc()
+
+	value, err, _ := c.calls.Do(string(hash[:])+method+"\x00"+key.args, func() (any, error) {
+		value, err := call()
+		if err != nil {
+			return nil, err
+		}
+		c.results.Put(key, &runtimeCallResult{number: header.Number, value: value})
+		return value, nil
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return value.(T), nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package modules
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_cachedRuntimeCall(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	blockAPI := NewMockBlockAPI(ctrl)
+
+	blockHash := common.Hash{1}
+	bestHash := common.Hash{2}
+	best := &types.Header{Number: 10}
+	blockAPI.EXPECT().BestBlockHash().Return(bestHash).AnyTimes()
+	blockAPI.EXPECT().GetHeader(blockHash).Return(&types.Header{Number: 5}, nil).AnyTimes()
+	blockAPI.EXPECT().GetHeader(bestHash).DoAndReturn(func(common.Hash) (*types.Header, error) {
+		return best, nil
+	}).AnyTimes()
+
+	cache := NewRuntimeCallCache(blockAPI, 8)
+
+	calls := 0
+	call := func() ([]byte, error) {
+		calls++
+		return []byte{byte(calls)}, nil
+	}
+
+	result, err := cachedRuntimeCall(cache, &blockHash, "Metadata_metadata", nil, call)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1}, result)
+
+	result, err = cachedRuntimeCall(cache, &blockHash, "Metadata_metadata", nil, call)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1}, result)
+
+	// the arguments are part of the key
+	result, err = cachedRuntimeCall(cache, &blockHash, "Metadata_metadata", []byte{1}, call)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{2}, result)
+
+	// the errors are not cached
+	errTest := errors.New("test error")
+	_, err = cachedRuntimeCall(cache, &blockHash, "Core_version", nil, func() ([]byte, error) {
+		return nil, errTest
+	})
+	assert.ErrorIs(t, err, errTest)
+	result, err = cachedRuntimeCall(cache, &blockHash, "Core_version", nil, call)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{3}, result)
+
+	// the results expire once the block is out of the retention window
+	best = &types.Header{Number: 14}
+	result, err = cachedRuntimeCall(cache, &blockHash, "Metadata_metadata", nil, call)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{4}, result)
+
+	// a nil block hash is the best block
+	result, err = cachedRuntimeCall(cache, nil, "Metadata_metadata", nil, call)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{5}, result)
+	result, err = cachedRuntimeCall(cache, &bestHash, "Metadata_metadata", nil, call)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{5}, result)
+
+	// a nil cache always makes the call
+	result, err = cachedRuntimeCall(nil, &blockHash, "Metadata_metadata", nil, call)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{6}, result)
+}
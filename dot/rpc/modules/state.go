@@ -45,6 +45,11 @@ type StateRuntimeVersionRequest struct {
 	Bhash *common.Hash
 }
 
+// StateRuntimeCodeRequest is hash value
+type StateRuntimeCodeRequest struct {
+	Bhash *common.Hash
+}
+
 // StatePairRequest holds json field
 type StatePairRequest struct {
 	Prefix *string `validate:"required"`
@@ -150,6 +155,14 @@ type StateRuntimeVersionResponse struct {
 	Apis               []interface{} `json:"apis"`
 }
 
+// StateRuntimeCodeResponse holds the runtime code of a block with its
+// blake2b hash and the version of the runtime built from it.
+type StateRuntimeCodeResponse struct {
+	Code    string                      `json:"code"`
+	Hash    common.Hash                 `json:"hash"`
+	Version StateRuntimeVersionResponse `json:"version"`
+}
+
 // NewStateRuntimeVersionResponse converts a runtime.Version to a
 // StateRuntimeVersionResponse struct.
 func NewStateRuntimeVersionResponse(runtimeVersion runtime.Version) (
@@ -180,6 +193,8 @@ type StateModule struct {
 	storageAPI StorageAPI
 	coreAPI    CoreAPI
 	blockAPI   BlockAPI
+	// runtimeCalls caches the results of the read-only runtime calls, it can be nil.
+	runtimeCalls *RuntimeCallCache
 }
 
 // NewStateModule creates a new State module.
@@ -192,6 +207,12 @@ func NewStateModule(net NetworkAPI, storage StorageAPI, core CoreAPI, blockAPI B
 	}
 }
 
+// WithRuntimeCallCache sets the cache of the read-only runtime call results of the module.
+func (sm *StateModule) WithRuntimeCallCache(cache *RuntimeCallCache) *StateModule {
+	sm.runtimeCalls = cache
+	return sm
+}
+
 // GetPairs returns the keys with prefix, leave empty to get all the keys.
 func (sm *StateModule) GetPairs(_ *http.Request, req *StatePairRequest, res *StatePairResponse) error {
 	var (
@@ -254,19 +275,41 @@ func (sm *StateModule) Call(_ *http.Request, req *StateCallRequest, res *StateCa
 		blockHash = *req.Block
 	}
 
-	rt, err := sm.blockAPI.GetRuntime(blockHash)
-	if err != nil {
-		return fmt.Errorf("get runtime: %w", err)
-	}
+	call := func() ([]byte, error) {
+		rt, err := sm.blockAPI.GetRuntime(blockHash)
+		if err != nil {
+			return nil, fmt.Errorf("get runtime: %w", err)
+		}
 
-	request, err := common.HexToBytes(req.Params)
-	if err != nil {
-		return fmt.Errorf("convert hex to bytes: %w", err)
+		request, err := common.HexToBytes(req.Params)
+		if err != nil {
+			return nil, fmt.Errorf("convert hex to bytes: %w", err)
+		}
+
+		exec := rt.Exec
+		if readOnly, ok := rt.(runtime.ReadOnlyExecutor); ok {
+			// state calls never persist storage changes, so they can run concurrently
+			exec = readOnly.ExecReadOnly
+		}
+
+		response, err := exec(req.Method, request)
+		if err != nil {
+			return nil, fmt.Errorf("runtime exec: %w", err)
+		}
+		return response, nil
 	}
 
-	response, err := rt.Exec(req.Method, request)
+	var (
+		response []byte
+		err      error
+	)
+	if _, ok := cacheableRuntimeCalls[req.Method]; ok {
+		response, err = cachedRuntimeCall(sm.runtimeCalls, &blockHash, req.Method, []byte(req.Params), call)
+	} else {
+		response, err = call()
+	}
 	if err != nil {
-		return fmt.Errorf("runtime exec: %w", err)
+		return err
 	}
 
 	*res = StateCallResponse(common.BytesToHex(response))
@@ -282,7 +325,16 @@ func (sm *StateModule) GetKeysPaged(_ *http.Request, req *StateStorageKeyRequest
 	if err != nil {
 		return err
 	}
-	keys, err := sm.storageAPI.GetKeysWithPrefix(req.Block, hPrefix)
+
+	var stateRootHash *common.Hash
+	if req.Block != nil {
+		stateRootHash, err = sm.storageAPI.GetStateRootFromBlock(req.Block)
+		if err != nil {
+			return err
+		}
+	}
+
+	keys, err := sm.storageAPI.GetKeysWithPrefix(stateRootHash, hPrefix)
 	if err != nil {
 		return fmt.Errorf("cannot get keys with prefix %s: %w", hPrefix, err)
 	}
@@ -304,7 +356,8 @@ func (sm *StateModule) GetKeysPaged(_ *http.Request, req *StateStorageKeyRequest
 
 // GetMetadata calls runtime Metadata_metadata function
 func (sm *StateModule) GetMetadata(_ *http.Request, req *StateRuntimeMetadataQuery, res *StateMetadataResponse) error {
-	metadata, err := sm.coreAPI.GetMetadata(req.Bhash)
+	metadata, err := cachedRuntimeCall(sm.runtimeCalls, req.Bhash, "Metadata_metadata", nil,
+		func() ([]byte, error) { return sm.coreAPI.GetMetadata(req.Bhash) })
 	if err != nil {
 		return err
 	}
@@ -350,7 +403,8 @@ func (sm *StateModule) GetReadProof(
 // If no block hash is provided, the latest version gets returned.
 func (sm *StateModule) GetRuntimeVersion(
 	_ *http.Request, req *StateRuntimeVersionRequest, res *StateRuntimeVersionResponse) error {
-	rtVersion, err := sm.coreAPI.GetRuntimeVersion(req.Bhash)
+	rtVersion, err := cachedRuntimeCall(sm.runtimeCalls, req.Bhash, "Core_version", nil,
+		func() (runtime.Version, error) { return sm.coreAPI.GetRuntimeVersion(req.Bhash) })
 	if err != nil {
 		return err
 	}
@@ -359,6 +413,48 @@ func (sm *StateModule) GetRuntimeVersion(
 	return nil
 }
 
+// GetRuntimeCode returns the `:code` storage entry at a given block, its blake2b
+// hash and the runtime version it embeds.
+// If no block hash is provided, the latest code gets returned.
+func (sm *StateModule) GetRuntimeCode(
+	_ *http.Request, req *StateRuntimeCodeRequest, res *StateRuntimeCodeResponse) error {
+	var (
+		code []byte
+		err  error
+	)
+
+	if req.Bhash != nil {
+		code, err = sm.storageAPI.GetStorageByBlockHash(req.Bhash, common.CodeKey)
+	} else {
+		code, err = sm.storageAPI.GetStorage(nil, common.CodeKey)
+	}
+	if err != nil {
+		return fmt.Errorf("getting runtime code: %w", err)
+	}
+
+	if len(code) == 0 {
+		return ErrRuntimeCodeNotFound
+	}
+
+	codeHash, err := common.Blake2bHash(code)
+	if err != nil {
+		return fmt.Errorf("hashing runtime code: %w", err)
+	}
+
+	rtVersion, err := cachedRuntimeCall(sm.runtimeCalls, req.Bhash, "Core_version", nil,
+		func() (runtime.Version, error) { return sm.coreAPI.GetRuntimeVersion(req.Bhash) })
+	if err != nil {
+		return fmt.Errorf("getting runtime version: %w", err)
+	}
+
+	*res = StateRuntimeCodeResponse{
+		Code:    common.BytesToHex(code),
+		Hash:    codeHash,
+		Version: NewStateRuntimeVersionResponse(rtVersion),
+	}
+	return nil
+}
+
 // GetStorage Returns a storage entry at a specific block's state.
 // If not block hash is provided, the latest value is returned.
 func (sm *StateModule) GetStorage(
@@ -204,6 +204,13 @@ func TestStateModuleGetKeysPaged(t *testing.T) {
 	mockStorageAPIErr.EXPECT().GetKeysWithPrefix((*common.Hash)(nil), common.MustHexToBytes("0x")).
 		Return(nil, errors.New("GetKeysWithPrefix Err"))
 
+	blockHash := common.Hash{1}
+	stateRoot := common.Hash{2}
+	mockStorageAPIBlock := mocks.NewMockStorageAPI(ctrl)
+	mockStorageAPIBlock.EXPECT().GetStateRootFromBlock(&blockHash).Return(&stateRoot, nil)
+	mockStorageAPIBlock.EXPECT().GetKeysWithPrefix(&stateRoot, common.MustHexToBytes("0x")).
+		Return([][]byte{{1, 1, 1}}, nil)
+
 	type fields struct {
 		networkAPI NetworkAPI
 		storageAPI StorageAPI
@@ -241,6 +248,18 @@ type args struct {
 			},
 			exp: StateStorageKeysResponse{"0x010101"},
 		},
+		{
+			name:   "state at block",
+			fields: fields{nil, mockStorageAPIBlock, nil},
+			args: args{
+				req: &StateStorageKeyRequest{
+					Qty:      1,
+					AfterKey: "0x01",
+					Block:    &blockHash,
+				},
+			},
+			exp: StateStorageKeysResponse{"0x010101"},
+		},
 		{
 			name:   "GetKeysWithPrefix Error",
 			fields: fields{nil, mockStorageAPIErr, nil},
@@ -553,6 +572,123 @@ type args struct {
 	}
 }
 
+func TestStateModuleGetRuntimeCode(t *testing.T) {
+	t.Parallel()
+
+	hash := common.MustHexToHash("0x3aa96b0149b6ca3688878bdbd19464448624136398e3ce45b9e755d3ab61355a")
+	code := []byte{0, 'a', 's', 'm'}
+	version := runtime.Version{
+		SpecName:    []byte("polkadot"),
+		ImplName:    []byte("parity-polkadot"),
+		SpecVersion: 25,
+	}
+	errTest := errors.New("test error")
+
+	testCases := map[string]struct {
+		storageAPIBuilder func(ctrl *gomock.Controller) StorageAPI
+		coreAPIBuilder    func(ctrl *gomock.Controller) CoreAPI
+		request           *StateRuntimeCodeRequest
+		expected          StateRuntimeCodeResponse
+		errWrapped        error
+		errMessage        string
+	}{
+		"at_block": {
+			storageAPIBuilder: func(ctrl *gomock.Controller) StorageAPI {
+				storageAPI := mocks.NewMockStorageAPI(ctrl)
+				storageAPI.EXPECT().GetStorageByBlockHash(&hash, common.CodeKey).Return(code, nil)
+				return storageAPI
+			},
+			coreAPIBuilder: func(ctrl *gomock.Controller) CoreAPI {
+				coreAPI := mocks.NewMockCoreAPI(ctrl)
+				coreAPI.EXPECT().GetRuntimeVersion(&hash).Return(version, nil)
+				return coreAPI
+			},
+			request: &StateRuntimeCodeRequest{Bhash: &hash},
+			expected: StateRuntimeCodeResponse{
+				Code:    "0x0061736d",
+				Hash:    common.MustBlake2bHash(code),
+				Version: NewStateRuntimeVersionResponse(version),
+			},
+		},
+		"latest": {
+			storageAPIBuilder: func(ctrl *gomock.Controller) StorageAPI {
+				storageAPI := mocks.NewMockStorageAPI(ctrl)
+				storageAPI.EXPECT().GetStorage((*common.Hash)(nil), common.CodeKey).Return(code, nil)
+				return storageAPI
+			},
+			coreAPIBuilder: func(ctrl *gomock.Controller) CoreAPI {
+				coreAPI := mocks.NewMockCoreAPI(ctrl)
+				coreAPI.EXPECT().GetRuntimeVersion((*common.Hash)(nil)).Return(version, nil)
+				return coreAPI
+			},
+			request: &StateRuntimeCodeRequest{},
+			expected: StateRuntimeCodeResponse{
+				Code:    "0x0061736d",
+				Hash:    common.MustBlake2bHash(code),
+				Version: NewStateRuntimeVersionResponse(version),
+			},
+		},
+		"storage_error": {
+			storageAPIBuilder: func(ctrl *gomock.Controller) StorageAPI {
+				storageAPI := mocks.NewMockStorageAPI(ctrl)
+				storageAPI.EXPECT().GetStorageByBlockHash(&hash, common.CodeKey).Return(nil, errTest)
+				return storageAPI
+			},
+			coreAPIBuilder: func(ctrl *gomock.Controller) CoreAPI { return nil },
+			request:        &StateRuntimeCodeRequest{Bhash: &hash},
+			errWrapped:     errTest,
+			errMessage:     "getting runtime code: test error",
+		},
+		"code_not_found": {
+			storageAPIBuilder: func(ctrl *gomock.Controller) StorageAPI {
+				storageAPI := mocks.NewMockStorageAPI(ctrl)
+				storageAPI.EXPECT().GetStorageByBlockHash(&hash, common.CodeKey).Return(nil, nil)
+				return storageAPI
+			},
+			coreAPIBuilder: func(ctrl *gomock.Controller) CoreAPI { return nil },
+			request:        &StateRuntimeCodeRequest{Bhash: &hash},
+			errWrapped:     ErrRuntimeCodeNotFound,
+			errMessage:     "runtime code not found",
+		},
+		"version_error": {
+			storageAPIBuilder: func(ctrl *gomock.Controller) StorageAPI {
+				storageAPI := mocks.NewMockStorageAPI(ctrl)
+				storageAPI.EXPECT().GetStorageByBlockHash(&hash, common.CodeKey).Return(code, nil)
+				return storageAPI
+			},
+			coreAPIBuilder: func(ctrl *gomock.Controller) CoreAPI {
+				coreAPI := mocks.NewMockCoreAPI(ctrl)
+				coreAPI.EXPECT().GetRuntimeVersion(&hash).Return(runtime.Version{}, errTest)
+				return coreAPI
+			},
+			request:    &StateRuntimeCodeRequest{Bhash: &hash},
+			errWrapped: errTest,
+			errMessage: "getting runtime version: test error",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			sm := &StateModule{
+				storageAPI: testCase.storageAPIBuilder(ctrl),
+				coreAPI:    testCase.coreAPIBuilder(ctrl),
+			}
+
+			var res StateRuntimeCodeResponse
+			err := sm.GetRuntimeCode(nil, testCase.request, &res)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errMessage != "" {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.expected, res)
+		})
+	}
+}
+
 func TestStateModuleGetStorage(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
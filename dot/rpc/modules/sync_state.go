@@ -4,6 +4,7 @@
 package modules
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/ChainSafe/gossamer/lib/common"
@@ -40,10 +41,14 @@ func (ss *SyncStateModule) GenSyncSpec(_ *http.Request, req *GenSyncSpecRequest,
 // syncState implements SyncStateAPI.
 type syncState struct {
 	chainSpecification *genesis.Genesis
+	blockAPI           BlockAPI
+	epochState         EpochStateAPI
+	grandpaState       GrandpaStateAPI
 }
 
 // NewStateSync creates an instance of SyncStateAPI given a chain specification.
-func NewStateSync(gData *genesis.Data, storageAPI StorageAPI) (SyncStateAPI, error) {
+func NewStateSync(gData *genesis.Data, storageAPI StorageAPI, blockAPI BlockAPI,
+	epochState EpochStateAPI, grandpaState GrandpaStateAPI) (SyncStateAPI, error) {
 	tmpGen := &genesis.Genesis{
 		Name:       "",
 		ID:         "",
@@ -71,11 +76,17 @@ func NewStateSync(gData *genesis.Data, storageAPI StorageAPI) (SyncStateAPI, err
 	tmpGen.ID = gData.ID
 	tmpGen.Bootnodes = common.BytesToStringArray(gData.Bootnodes)
 	tmpGen.ProtocolID = gData.ProtocolID
-	return syncState{chainSpecification: tmpGen}, nil
+	return syncState{
+		chainSpecification: tmpGen,
+		blockAPI:           blockAPI,
+		epochState:         epochState,
+		grandpaState:       grandpaState,
+	}, nil
 }
 
 // GenSyncSpec returns the JSON serialised chain specification running the node
-// (i.e. the current state), with a sync state.
+// (i.e. the current state), with a light sync state checkpoint at the highest
+// finalised block, allowing light clients to start syncing from it.
 func (s syncState) GenSyncSpec(raw bool) (*genesis.Genesis, error) {
 	if raw {
 		err := s.chainSpecification.ToRaw()
@@ -84,5 +95,12 @@ func (s syncState) GenSyncSpec(raw bool) (*genesis.Genesis, error) {
 		}
 	}
 
-	return s.chainSpecification, nil
+	lightSyncState, err := s.lightSyncState()
+	if err != nil {
+		return nil, fmt.Errorf("building light sync state: %w", err)
+	}
+
+	chainSpecification := *s.chainSpecification
+	chainSpecification.LightSyncState = lightSyncState
+	return &chainSpecification, nil
 }
@@ -6,14 +6,19 @@
 import (
 	"errors"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/ChainSafe/gossamer/dot/rpc/modules/mocks"
+	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
 	"github.com/ChainSafe/gossamer/lib/genesis"
+	"github.com/ChainSafe/gossamer/pkg/scale"
 	"go.uber.org/mock/gomock"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSyncStateModule_GenSyncSpec(t *testing.T) {
@@ -134,7 +139,7 @@ type args struct {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			res, err := NewStateSync(tt.args.gData, tt.args.storageAPI)
+			res, err := NewStateSync(tt.args.gData, tt.args.storageAPI, nil, nil, nil)
 			if tt.expErr != nil {
 				assert.EqualError(t, err, tt.expErr.Error())
 			} else {
@@ -146,6 +151,57 @@ type args struct {
 }
 
 func Test_syncState_GenSyncSpec(t *testing.T) {
+	header := &types.Header{
+		ParentHash: common.Hash{1},
+		Number:     3,
+		StateRoot:  common.Hash{2},
+	}
+	encodedHeader, err := scale.Marshal(*header)
+	require.NoError(t, err)
+
+	epochData := &types.EpochDataRaw{
+		Authorities: []types.AuthorityRaw{{Key: [32]byte{3}, Weight: 1}},
+		Randomness:  [32]byte{4},
+	}
+	configData := &types.ConfigData{C1: 1, C2: 4, SecondarySlots: 1}
+
+	ctrl := gomock.NewController(t)
+	blockAPI := mocks.NewMockBlockAPI(ctrl)
+	blockAPI.EXPECT().GetHighestFinalisedHash().Return(header.Hash(), nil).AnyTimes()
+	blockAPI.EXPECT().GetHeader(header.Hash()).Return(header, nil).AnyTimes()
+	epochState := mocks.NewMockEpochStateAPI(ctrl)
+	epochState.EXPECT().GetEpochForBlock(header).Return(uint64(1), nil).AnyTimes()
+	epochState.EXPECT().GetEpochDataRaw(uint64(1), header).Return(epochData, nil).AnyTimes()
+	epochState.EXPECT().GetConfigData(uint64(1), header).Return(configData, nil).AnyTimes()
+	epochState.EXPECT().GetStartSlotForEpoch(uint64(1), header.Hash()).Return(uint64(10), nil).AnyTimes()
+	epochState.EXPECT().GetEpochLength().Return(uint64(10)).AnyTimes()
+	grandpaState := mocks.NewMockGrandpaStateAPI(ctrl)
+	grandpaState.EXPECT().GetCurrentSetID().Return(uint64(2), nil).AnyTimes()
+	grandpaState.EXPECT().GetAuthorities(uint64(2)).
+		Return([]types.GrandpaVoter{{Key: ed25519.PublicKey{5}}}, nil).AnyTimes()
+
+	hash := header.Hash().String()[2:]
+	epochHeader := "01" + // Regular variant
+		"0a00000000000000" + "1400000000000000" // start and end slots
+	epoch := "01" + // Regular variant
+		"0100000000000000" + "0a00000000000000" + "0a00000000000000" + // index, start slot and duration
+		"04" + "03" + strings.Repeat("00", 31) + "0100000000000000" + // authorities
+		"04" + strings.Repeat("00", 31) + // randomness
+		"0100000000000000" + "0400000000000000" + "01" // c and allowed slots
+	lightSyncState := &genesis.LightSyncState{
+		FinalizedBlockHeader: common.BytesToHex(encodedHeader),
+		BabeEpochChanges: "0x" +
+			"04" + hash + "03000000" + epochHeader + "00" + // fork tree roots
+			"01" + "03000000" + // best finalised number
+			"04" + hash + "03000000" + epoch, // epochs
+		GrandpaAuthoritySet: "0x" +
+			"04" + "05" + strings.Repeat("00", 31) + "0100000000000000" + // current authorities
+			"0200000000000000" + // set id
+			"00" + "00" + // pending standard changes
+			"00" + // pending forced changes
+			"00", // authority set changes
+	}
+
 	type fields struct {
 		chainSpecification genesis.Genesis
 	}
@@ -162,7 +218,7 @@ type args struct {
 		{
 			name:   "GenSyncSpec False",
 			fields: fields{genesis.Genesis{}},
-			exp:    genesis.Genesis{},
+			exp:    genesis.Genesis{LightSyncState: lightSyncState},
 		},
 		{
 			name:   "GenSyncSpec True",
@@ -170,13 +226,16 @@ type args struct {
 			args: args{
 				raw: true,
 			},
-			exp: genesis.Genesis{},
+			exp: genesis.Genesis{LightSyncState: lightSyncState},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := syncState{
 				chainSpecification: &tt.fields.chainSpecification,
+				blockAPI:           blockAPI,
+				epochState:         epochState,
+				grandpaState:       grandpaState,
 			}
 			res, err := s.GenSyncSpec(tt.args.raw)
 			if tt.expErr != nil {
@@ -185,6 +244,21 @@ type args struct {
 				assert.NoError(t, err)
 			}
 			assert.Equal(t, tt.exp, *res)
+			assert.Nil(t, tt.fields.chainSpecification.LightSyncState)
 		})
 	}
 }
+
+func Test_syncState_GenSyncSpec_error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	blockAPI := mocks.NewMockBlockAPI(ctrl)
+	blockAPI.EXPECT().GetHighestFinalisedHash().Return(common.Hash{}, errors.New("test error"))
+
+	s := syncState{
+		chainSpecification: &genesis.Genesis{},
+		blockAPI:           blockAPI,
+	}
+	res, err := s.GenSyncSpec(false)
+	assert.EqualError(t, err, "building light sync state: getting highest finalised hash: test error")
+	assert.Nil(t, res)
+}
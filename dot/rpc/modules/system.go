@@ -6,12 +6,14 @@
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"math/big"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/ChainSafe/gossamer/lib/common"
-	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/crypto/ss58"
 	"github.com/ChainSafe/gossamer/pkg/scale"
 	"github.com/btcsuite/btcutil/base58"
 	ctypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
@@ -27,6 +29,8 @@ type SystemModule struct {
 	txStateAPI TransactionStateAPI
 	blockAPI   BlockAPI
 	syncAPI    SyncAPI
+	// runtimeCalls caches the results of the read-only runtime calls, it can be nil.
+	runtimeCalls *RuntimeCallCache
 }
 
 // EmptyRequest represents an RPC request with no fields
@@ -52,6 +56,9 @@ type SystemNetworkStateResponse struct {
 // SystemPeersResponse struct to marshal json
 type SystemPeersResponse []common.PeerInfo
 
+// SystemPeerStatsResponse struct to marshal json
+type SystemPeerStatsResponse []common.PeerStats
+
 // U64Response holds U64 response
 type U64Response uint64
 
@@ -82,6 +89,12 @@ func NewSystemModule(net NetworkAPI, sys SystemAPI, core CoreAPI,
 	}
 }
 
+// WithRuntimeCallCache sets the cache of the read-only runtime call results of the module.
+func (sm *SystemModule) WithRuntimeCallCache(cache *RuntimeCallCache) *SystemModule {
+	sm.runtimeCalls = cache
+	return sm
+}
+
 // Chain returns the runtime chain
 func (sm *SystemModule) Chain(r *http.Request, req *EmptyRequest, res *string) error {
 	*res = sm.systemAPI.ChainName()
@@ -136,6 +149,13 @@ func (sm *SystemModule) Peers(r *http.Request, req *EmptyRequest, res *SystemPee
 	return nil
 }
 
+// PeerStats returns the messages and bytes exchanged with each connected peer per protocol,
+// in total and over the last minute
+func (sm *SystemModule) PeerStats(r *http.Request, req *EmptyRequest, res *SystemPeerStatsResponse) error {
+	*res = sm.networkAPI.PeerStats()
+	return nil
+}
+
 // NodeRoles Returns the roles the node is running as.
 func (sm *SystemModule) NodeRoles(r *http.Request, req *EmptyRequest, res *[]interface{}) error {
 	resultArray := []interface{}{}
@@ -164,7 +184,10 @@ func (sm *SystemModule) AccountNextIndex(r *http.Request, req *StringRequest, re
 	if req == nil || req.String == "" {
 		return errors.New("account address must be valid")
 	}
-	addressPubKey := crypto.PublicAddressToByteArray(common.Address(req.String))
+	_, addressPubKey, err := ss58.Decode(common.Address(req.String))
+	if err != nil {
+		return fmt.Errorf("decoding account address: %w", err)
+	}
 
 	// check pending transactions for extrinsics singed by addressPubKey
 	pending := sm.txStateAPI.Pending()
@@ -194,7 +217,8 @@ func (sm *SystemModule) AccountNextIndex(r *http.Request, req *StringRequest, re
 
 	// no extrinsic signed by request found in pending transactions, so look in storage
 	// get metadata to build storage storageKey
-	rawMeta, err := sm.coreAPI.GetMetadata(nil)
+	rawMeta, err := cachedRuntimeCall(sm.runtimeCalls, nil, "Metadata_metadata", nil,
+		func() ([]byte, error) { return sm.coreAPI.GetMetadata(nil) })
 	if err != nil {
 		return err
 	}
@@ -289,3 +313,33 @@ func (sm *SystemModule) RemoveReservedPeer(r *http.Request, req *StringRequest,
 
 	return sm.networkAPI.RemoveReservedPeers(req.String)
 }
+
+// RotateNodeKeyRequest is the request to rotate the network identity of the node.
+type RotateNodeKeyRequest struct {
+	// DrainTimeout is the number of seconds given to the requests in flight to
+	// complete before the connections are closed, defaulting to
+	// defaultDrainTimeout if 0.
+	DrainTimeout uint32
+}
+
+// defaultDrainTimeout is the default time given to the requests in flight to complete
+// before the connections are closed when the network identity is rotated.
+const defaultDrainTimeout = 10 * time.Second
+
+// RotateNodeKey replaces the network key of the node with a new random key, persisted
+// to the key file, for when the key is suspected to be compromised. The connections
+// are drained and closed, and the node announces its new peer ID, which is returned.
+func (sm *SystemModule) RotateNodeKey(r *http.Request, req *RotateNodeKeyRequest, res *string) error {
+	drainTimeout := defaultDrainTimeout
+	if req.DrainTimeout != 0 {
+		drainTimeout = time.Duration(req.DrainTimeout) * time.Second
+	}
+
+	peerID, err := sm.networkAPI.RotateIdentity(drainTimeout)
+	if err != nil {
+		return fmt.Errorf("rotating network identity: %w", err)
+	}
+
+	*res = peerID
+	return nil
+}
@@ -7,6 +7,7 @@
 	"errors"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/ChainSafe/gossamer/dot/rpc/modules/mocks"
 	testdata "github.com/ChainSafe/gossamer/dot/rpc/modules/test_data"
@@ -153,6 +154,31 @@ func TestSystemModule_PeersTest(t *testing.T) {
 	require.Equal(t, SystemPeersResponse{}, sysPeerRes)
 }
 
+func TestSystemModule_PeerStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	stats := []common.PeerStats{{
+		PeerID:     "peer",
+		MessagesIn: 1,
+		BytesIn:    10,
+		Protocols: []common.ProtocolStats{{
+			Protocol:   "/dot/sync/2",
+			MessagesIn: 1,
+			BytesIn:    10,
+		}},
+	}}
+	mockNetworkAPI := mocks.NewMockNetworkAPI(ctrl)
+	mockNetworkAPI.EXPECT().PeerStats().Return(stats)
+	sm := &SystemModule{
+		networkAPI: mockNetworkAPI,
+	}
+
+	var res SystemPeerStatsResponse
+	err := sm.PeerStats(nil, &EmptyRequest{}, &res)
+	require.NoError(t, err)
+	require.Equal(t, SystemPeerStatsResponse(stats), res)
+}
+
 func TestSystemModule_NodeRolesTest(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
@@ -281,6 +307,14 @@ type args struct {
 			args:      args{},
 			expErr:    errors.New("account address must be valid"),
 		},
+		{
+			name:      "invalid_checksum",
+			sysModule: NewSystemModule(nil, nil, mockCoreAPI, mockStorageAPI, mockTxStateAPI, nil, nil),
+			args: args{
+				req: &StringRequest{String: "5FrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"},
+			},
+			expErr: errors.New("decoding account address: invalid ss58 address: invalid checksum"),
+		},
 		{
 			name:      "found_in_pending_transactions",
 			sysModule: NewSystemModule(nil, nil, mockCoreAPI, mockStorageAPI, mockTxStateAPI, nil, nil),
@@ -293,7 +327,7 @@ type args struct {
 			name:      "not_found_in_pending_transactions",
 			sysModule: NewSystemModule(nil, nil, mockCoreAPI, mockStorageAPI, mockTxStateAPI, nil, nil),
 			args: args{
-				req: &StringRequest{String: "5FrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"},
+				req: &StringRequest{String: "5FrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKuxvW"},
 			},
 			exp: U64Response(3),
 		},
@@ -301,7 +335,7 @@ type args struct {
 			name:      "GetMetadata Err",
 			sysModule: NewSystemModule(nil, nil, mockCoreAPIErr, mockStorageAPI, mockTxStateAPI, nil, nil),
 			args: args{
-				req: &StringRequest{String: "5FrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"},
+				req: &StringRequest{String: "5FrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKuxvW"},
 			},
 			expErr: errors.New("getMetadata error"),
 		},
@@ -309,7 +343,7 @@ type args struct {
 			name:      "Magic Number Mismatch",
 			sysModule: NewSystemModule(nil, nil, mockCoreAPIMagicNumMismatch, mockStorageAPI, mockTxStateAPI, nil, nil),
 			args: args{
-				req: &StringRequest{String: "5FrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"},
+				req: &StringRequest{String: "5FrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKuxvW"},
 			},
 			expErr: errors.New("magic number mismatch: expected 0x6174656d, found 0xe03056ea"),
 		},
@@ -317,7 +351,7 @@ type args struct {
 			name:      "GetStorage Err",
 			sysModule: NewSystemModule(nil, nil, mockCoreAPI, mockStorageAPIErr, mockTxStateAPI, nil, nil),
 			args: args{
-				req: &StringRequest{String: "5FrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"},
+				req: &StringRequest{String: "5FrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKuxvW"},
 			},
 			expErr: errors.New("getStorage error"),
 		},
@@ -644,3 +678,48 @@ type args struct {
 		})
 	}
 }
+
+func TestSystemModule_RotateNodeKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	errTest := errors.New("test error")
+	tests := map[string]struct {
+		req          *RotateNodeKeyRequest
+		drainTimeout time.Duration
+		peerID       string
+		rotateErr    error
+		expErr       error
+		exp          string
+	}{
+		"default_drain_timeout": {
+			req:          &RotateNodeKeyRequest{},
+			drainTimeout: defaultDrainTimeout,
+			peerID:       "12D3KooWDX3rTCWWbibUKEobBjBjzFQqD94Mc4riN8B7iQRu7waa",
+			exp:          "12D3KooWDX3rTCWWbibUKEobBjBjzFQqD94Mc4riN8B7iQRu7waa",
+		},
+		"drain_timeout": {
+			req:          &RotateNodeKeyRequest{DrainTimeout: 3},
+			drainTimeout: 3 * time.Second,
+			peerID:       "12D3KooWDX3rTCWWbibUKEobBjBjzFQqD94Mc4riN8B7iQRu7waa",
+			exp:          "12D3KooWDX3rTCWWbibUKEobBjBjzFQqD94Mc4riN8B7iQRu7waa",
+		},
+		"rotate_error": {
+			req:          &RotateNodeKeyRequest{},
+			drainTimeout: defaultDrainTimeout,
+			rotateErr:    errTest,
+			expErr:       errTest,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockNetworkAPI := mocks.NewMockNetworkAPI(ctrl)
+			mockNetworkAPI.EXPECT().RotateIdentity(tt.drainTimeout).Return(tt.peerID, tt.rotateErr)
+			sm := NewSystemModule(mockNetworkAPI, nil, nil, nil, nil, nil, nil)
+
+			var res string
+			err := sm.RotateNodeKey(nil, tt.req, &res)
+			assert.ErrorIs(t, err, tt.expErr)
+			assert.Equal(t, tt.exp, res)
+		})
+	}
+}
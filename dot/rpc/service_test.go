@@ -23,9 +23,9 @@ func TestNewService(t *testing.T) {
 }
 
 func TestService_Methods(t *testing.T) {
-	qtySystemMethods := 15
+	qtySystemMethods := 17
 	qtyRPCMethods := 1
-	qtyAuthorMethods := 8
+	qtyAuthorMethods := 9
 
 	rpcService := NewService()
 	sysMod := modules.NewSystemModule(nil, nil, nil, nil, nil, nil, nil)
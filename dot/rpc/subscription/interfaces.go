@@ -7,6 +7,7 @@
 	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/grandpa"
 	"github.com/ChainSafe/gossamer/lib/runtime"
 	"github.com/ChainSafe/gossamer/lib/transaction"
 )
@@ -29,8 +30,8 @@ type BlockAPI interface {
 
 // TransactionStateAPI is the interface to get and free status notifier channels
 type TransactionStateAPI interface {
-	GetStatusNotifierChannel(ext types.Extrinsic) chan transaction.Status
-	FreeStatusNotifierChannel(ch chan transaction.Status)
+	GetStatusNotifierChannel(ext types.Extrinsic) chan transaction.StatusNotification
+	FreeStatusNotifierChannel(ch chan transaction.StatusNotification)
 }
 
 // CoreAPI is the interface for the core methods
@@ -38,3 +39,9 @@ type CoreAPI interface {
 	GetRuntimeVersion(bhash *common.Hash) (runtime.Version, error)
 	HandleSubmittedExtrinsic(types.Extrinsic) error
 }
+
+// RoundStateAPI is the interface to get and free grandpa round state notifier channels
+type RoundStateAPI interface {
+	GetRoundStateNotifierChannel() chan *grandpa.RoundStateUpdate
+	FreeRoundStateNotifierChannel(ch chan *grandpa.RoundStateUpdate)
+}
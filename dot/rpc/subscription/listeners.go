@@ -14,6 +14,7 @@
 	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/grandpa"
 	"github.com/ChainSafe/gossamer/lib/runtime"
 	"github.com/ChainSafe/gossamer/lib/transaction"
 )
@@ -26,6 +27,7 @@
 	chainNewHeadMethod           = "chain_newHead"
 	chainAllHeadMethod           = "chain_allHead"
 	stateStorageMethod           = "state_storage"
+	gossamerRoundStateMethod     = "gossamer_roundState"
 )
 
 var (
@@ -299,10 +301,10 @@ type ExtrinsicSubmitListener struct {
 	importedHash  common.Hash
 	finalisedChan chan *types.FinalisationInfo
 	// txStatusChan is used to know when transaction/extrinsic becomes part of the
-	// ready queue or future queue.
+	// ready queue or future queue, or leaves them for a given reason.
 	// we are using transaction.PriorityQueue for ready queue and transaction.Pool
 	// for future queue.
-	txStatusChan  chan transaction.Status
+	txStatusChan  chan transaction.StatusNotification
 	done          chan struct{}
 	cancel        chan struct{}
 	cancelTimeout time.Duration
@@ -310,7 +312,7 @@ type ExtrinsicSubmitListener struct {
 
 // NewExtrinsicSubmitListener constructor to build new ExtrinsicSubmitListener
 func NewExtrinsicSubmitListener(conn *WSConn, extBytes []byte,
-	importedChan chan *types.Block, txStatusChan chan transaction.Status,
+	importedChan chan *types.Block, txStatusChan chan transaction.StatusNotification,
 	finalisedChan chan *types.FinalisationInfo) *ExtrinsicSubmitListener {
 	return &ExtrinsicSubmitListener{
 		wsconn:        conn,
@@ -377,7 +379,11 @@ func (l *ExtrinsicSubmitListener) Listen() {
 					return
 				}
 
-				l.wsconn.safeSend(newSubscriptionResponse(authorExtrinsicUpdatesMethod, l.subID, txStatus.String()))
+				var result interface{} = txStatus.Status.String()
+				if txStatus.Reason != "" {
+					result = map[string]interface{}{txStatus.Status.String(): txStatus.Reason}
+				}
+				l.wsconn.safeSend(newSubscriptionResponse(authorExtrinsicUpdatesMethod, l.subID, result))
 			}
 		}
 	}()
@@ -481,6 +487,79 @@ func (g *GrandpaJustificationListener) Stop() error {
 	return cancelWithTimeout(g.cancel, g.done, g.cancelTimeout)
 }
 
+// RoundStateTarget is the block targeted by a round state update
+type RoundStateTarget struct {
+	Hash   string `json:"hash"`
+	Number uint32 `json:"number"`
+}
+
+// RoundStateResult is the result pushed for each grandpa round state update
+type RoundStateResult struct {
+	Event  string           `json:"event"`
+	Round  uint64           `json:"round"`
+	SetID  uint64           `json:"setId"`
+	Stage  string           `json:"stage,omitempty"`
+	Voter  string           `json:"voter,omitempty"`
+	Target RoundStateTarget `json:"target"`
+}
+
+func newRoundStateResult(update *grandpa.RoundStateUpdate) RoundStateResult {
+	result := RoundStateResult{
+		Event: string(update.Event),
+		Round: update.Round,
+		SetID: update.SetID,
+		Target: RoundStateTarget{
+			Hash:   update.Target.Hash.String(),
+			Number: update.Target.Number,
+		},
+	}
+	if update.Event == grandpa.RoundStateVote {
+		result.Stage = update.Stage.String()
+		result.Voter = common.BytesToHex(update.Voter[:])
+	}
+	return result
+}
+
+// RoundStateListener pushes the incremental updates of the grandpa round state
+type RoundStateListener struct {
+	cancel        chan struct{}
+	cancelTimeout time.Duration
+	done          chan struct{}
+	wsconn        *WSConn
+	subID         uint32
+	roundStateCh  chan *grandpa.RoundStateUpdate
+}
+
+// Listen will start a goroutine that listens to the round state updates
+func (r *RoundStateListener) Listen() {
+	go func() {
+		defer func() {
+			r.wsconn.RoundStateAPI.FreeRoundStateNotifierChannel(r.roundStateCh)
+			close(r.done)
+		}()
+
+		for {
+			select {
+			case <-r.cancel:
+				return
+
+			case update, ok := <-r.roundStateCh:
+				if !ok {
+					return
+				}
+
+				r.wsconn.safeSend(newSubscriptionResponse(gossamerRoundStateMethod, r.subID,
+					newRoundStateResult(update)))
+			}
+		}
+	}()
+}
+
+// Stop will cancel all the goroutines that are executing
+func (r *RoundStateListener) Stop() error {
+	return cancelWithTimeout(r.cancel, r.done, r.cancelTimeout)
+}
+
 func cancelWithTimeout(cancel, done chan struct{}, t time.Duration) error {
 	close(cancel)
 
@@ -10,6 +10,7 @@
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -180,7 +181,7 @@ func TestExtrinsicSubmitListener_Listen(t *testing.T) {
 
 	notifyImportedChan := make(chan *types.Block, 100)
 	notifyFinalizedChan := make(chan *types.FinalisationInfo, 100)
-	txStatusChan := make(chan transaction.Status)
+	txStatusChan := make(chan transaction.StatusNotification)
 
 	BlockAPI := mocks.NewMockBlockAPI(ctrl)
 	BlockAPI.EXPECT().FreeImportedBlockNotifierChannel(gomock.Any())
@@ -240,6 +241,17 @@ func TestExtrinsicSubmitListener_Listen(t *testing.T) {
 		newSubscriptionResponse(authorExtrinsicUpdatesMethod, esl.subID, resFinalised))
 	require.NoError(t, err)
 	require.Equal(t, string(expectedFinalizedBytes)+"\n", string(msg))
+
+	txStatusChan <- transaction.StatusNotification{Status: transaction.Invalid, Reason: "era expired"}
+	time.Sleep(time.Second * 2)
+
+	_, msg, err = ws.ReadMessage()
+	require.NoError(t, err)
+	resInvalid := map[string]interface{}{"invalid": "era expired"}
+	expectedInvalidBytes, err := json.Marshal(
+		newSubscriptionResponse(authorExtrinsicUpdatesMethod, esl.subID, resInvalid))
+	require.NoError(t, err)
+	require.Equal(t, string(expectedInvalidBytes)+"\n", string(msg))
 }
 
 func TestGrandpaJustification_Listen(t *testing.T) {
@@ -364,3 +376,66 @@ func TestRuntimeChannelListener_Listen(t *testing.T) {
 	time.Sleep(time.Millisecond * 10)
 	require.Equal(t, expectedUpdateResponse, mockConnection.lastMessage)
 }
+
+type roundStateAPIStub struct {
+	ch    chan *grandpa.RoundStateUpdate
+	freed bool
+}
+
+func (r *roundStateAPIStub) GetRoundStateNotifierChannel() chan *grandpa.RoundStateUpdate {
+	return r.ch
+}
+
+func (r *roundStateAPIStub) FreeRoundStateNotifierChannel(chan *grandpa.RoundStateUpdate) {
+	r.freed = true
+}
+
+func TestRoundStateListener_Listen(t *testing.T) {
+	wsconn, ws, cancel := setupWSConn(t)
+	defer cancel()
+
+	roundStateAPI := &roundStateAPIStub{ch: make(chan *grandpa.RoundStateUpdate)}
+	wsconn.RoundStateAPI = roundStateAPI
+	wsconn.Subscriptions = make(map[uint32]Listener)
+
+	listener, err := wsconn.initRoundStateListener(1, nil)
+	require.NoError(t, err)
+
+	_, msg, err := ws.ReadMessage()
+	require.NoError(t, err)
+	require.Equal(t, `{"jsonrpc":"2.0","result":1,"id":1}`+"\n", string(msg))
+
+	listener.Listen()
+
+	target := grandpa.Vote{Hash: common.Hash{1}, Number: 2}
+	roundStateAPI.ch <- &grandpa.RoundStateUpdate{
+		Event:  grandpa.RoundStateVote,
+		Round:  3,
+		SetID:  4,
+		Voter:  [32]byte{5},
+		Target: target,
+	}
+	roundStateAPI.ch <- &grandpa.RoundStateUpdate{
+		Event:  grandpa.RoundStateFinalised,
+		Round:  3,
+		SetID:  4,
+		Target: target,
+	}
+
+	_, msg, err = ws.ReadMessage()
+	require.NoError(t, err)
+	expected := `{"jsonrpc":"2.0","method":"gossamer_roundState","params":{"result":` +
+		`{"event":"vote","round":3,"setId":4,"stage":"prevote","voter":"0x05%s",` +
+		`"target":{"hash":"%s","number":2}},"subscription":1}}` + "\n"
+	expected = fmt.Sprintf(expected, strings.Repeat("00", 31), target.Hash)
+	require.Equal(t, expected, string(msg))
+
+	_, msg, err = ws.ReadMessage()
+	require.NoError(t, err)
+	expected = `{"jsonrpc":"2.0","method":"gossamer_roundState","params":{"result":` +
+		`{"event":"finalised","round":3,"setId":4,"target":{"hash":"%s","number":2}},"subscription":1}}` + "\n"
+	require.Equal(t, fmt.Sprintf(expected, target.Hash), string(msg))
+
+	require.NoError(t, listener.Stop())
+	require.True(t, roundStateAPI.freed)
+}
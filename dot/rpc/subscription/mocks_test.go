@@ -41,7 +41,7 @@ func (m *MockTransactionStateAPI) EXPECT() *MockTransactionStateAPIMockRecorder
 }
 
 // FreeStatusNotifierChannel mocks base method.
-func (m *MockTransactionStateAPI) FreeStatusNotifierChannel(arg0 chan transaction.Status) {
+func (m *MockTransactionStateAPI) FreeStatusNotifierChannel(arg0 chan transaction.StatusNotification) {
 	m.ctrl.T.Helper()
 	m.ctrl.Call(m, "FreeStatusNotifierChannel", arg0)
 }
@@ -53,10 +53,10 @@ func (mr *MockTransactionStateAPIMockRecorder) FreeStatusNotifierChannel(arg0 an
 }
 
 // GetStatusNotifierChannel mocks base method.
-func (m *MockTransactionStateAPI) GetStatusNotifierChannel(arg0 types.Extrinsic) chan transaction.Status {
+func (m *MockTransactionStateAPI) GetStatusNotifierChannel(arg0 types.Extrinsic) chan transaction.StatusNotification {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetStatusNotifierChannel", arg0)
-	ret0, _ := ret[0].(chan transaction.Status)
+	ret0, _ := ret[0].(chan transaction.StatusNotification)
 	return ret0
 }
 
@@ -19,6 +19,7 @@
 	stateSubscribeStorage          string = "state_subscribeStorage"
 	stateSubscribeRuntimeVersion   string = "state_subscribeRuntimeVersion"
 	grandpaSubscribeJustifications string = "grandpa_subscribeJustifications"
+	gossamerSubscribeRoundState    string = "gossamer_subscribeRoundState"
 )
 
 type setupListener func(reqid float64, params interface{}) (Listener, error)
@@ -46,6 +47,8 @@ func (c *WSConn) getSetupListener(method string) setupListener {
 		return c.initRuntimeVersionListener
 	case grandpaSubscribeJustifications:
 		return c.initGrandpaJustificationListener
+	case gossamerSubscribeRoundState:
+		return c.initRoundStateListener
 	default:
 		return nil
 	}
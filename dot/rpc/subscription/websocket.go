@@ -38,6 +38,7 @@ type httpclient interface {
 	errEmptyMethod             = errors.New("empty method")
 	errStorageNotSet           = errors.New("error StorageAPI not set")
 	errBlockAPINotSet          = errors.New("error BlockAPI not set")
+	errRoundStateAPINotSet     = errors.New("error RoundStateAPI not set")
 )
 
 var logger = log.NewFromGlobal(log.AddContext("pkg", "rpc/subscription"))
@@ -53,6 +54,7 @@ type WSConn struct {
 	BlockAPI      BlockAPI
 	CoreAPI       CoreAPI
 	TxStateAPI    TransactionStateAPI
+	RoundStateAPI RoundStateAPI
 	RPCHost       string
 	HTTP          httpclient
 }
@@ -408,6 +410,33 @@ func (c *WSConn) initGrandpaJustificationListener(reqID float64, _ interface{})
 	return jl, nil
 }
 
+func (c *WSConn) initRoundStateListener(reqID float64, _ interface{}) (Listener, error) {
+	if c.RoundStateAPI == nil {
+		c.safeSendError(reqID, nil, errRoundStateAPINotSet.Error())
+		return nil, errRoundStateAPINotSet
+	}
+
+	rsl := &RoundStateListener{
+		cancel:        make(chan struct{}, 1),
+		done:          make(chan struct{}, 1),
+		wsconn:        c,
+		cancelTimeout: defaultCancelTimeout,
+	}
+
+	rsl.roundStateCh = c.RoundStateAPI.GetRoundStateNotifierChannel()
+
+	c.mu.Lock()
+
+	rsl.subID = atomic.AddUint32(&c.qtyListeners, 1)
+	c.Subscriptions[rsl.subID] = rsl
+
+	c.mu.Unlock()
+
+	c.safeSend(NewSubscriptionResponseJSON(rsl.subID, reqID))
+
+	return rsl, nil
+}
+
 func (c *WSConn) safeSend(msg interface{}) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
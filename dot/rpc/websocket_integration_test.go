@@ -75,7 +75,7 @@ func TestHTTPServer_ServeHTTP(t *testing.T) {
 	sAPI := modules.NewMockAnyStorageAPI(ctrl)
 
 	TxStateAPI := NewMockTransactionStateAPI(ctrl)
-	TxStateAPI.EXPECT().GetStatusNotifierChannel(gomock.Any()).Return(make(chan transaction.Status))
+	TxStateAPI.EXPECT().GetStatusNotifierChannel(gomock.Any()).Return(make(chan transaction.StatusNotification))
 
 	cfg := &HTTPServerConfig{
 		Modules:             []string{"system", "chain"},
@@ -4,6 +4,7 @@
 package dot
 
 import (
+	"crypto/rand"
 	"errors"
 	"fmt"
 	"strings"
@@ -20,21 +21,22 @@
 	"github.com/ChainSafe/gossamer/dot/sync"
 	"github.com/ChainSafe/gossamer/dot/system"
 	"github.com/ChainSafe/gossamer/dot/types"
-	"github.com/ChainSafe/gossamer/internal/database"
 	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/internal/metrics"
 	"github.com/ChainSafe/gossamer/internal/pprof"
 	"github.com/ChainSafe/gossamer/lib/babe"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto"
-	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
 	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
 	"github.com/ChainSafe/gossamer/lib/genesis"
 	"github.com/ChainSafe/gossamer/lib/grandpa"
 	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/lib/runtime/offchain"
 	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
 	wazero_runtime "github.com/ChainSafe/gossamer/lib/runtime/wazero"
+	"github.com/ChainSafe/gossamer/lib/services"
+	"github.com/ChainSafe/gossamer/lib/standby"
 )
 
 const blockRequestTimeout = 20 * time.Second
@@ -45,6 +47,7 @@ type BlockProducer interface {
 	Resume() error
 	EpochLength() uint64
 	SlotDuration() uint64
+	EpochAuthorship() (map[string]babe.EpochAuthorship, error)
 }
 
 type rpcServiceSettings struct {
@@ -57,19 +60,16 @@ type rpcServiceSettings struct {
 	system        *system.Service
 	blockFinality *grandpa.Service
 	syncer        rpc.SyncAPI
-}
-
-func newInMemoryDB() (database.Database, error) {
-	return database.LoadDatabase("", true)
+	supervisor    *services.Supervisor
 }
 
 // createStateService creates the state service and initialise state database
 func (nodeBuilder) createStateService(config *cfg.Config) (*state.Service, error) {
 	logger.Debug("creating state service...")
 
-	gen, err := genesis.NewGenesisFromJSONRaw(config.ChainSpec)
+	gen, err := genesis.NewGenesisFromFile(config.ChainSpec)
 	if err != nil {
-		return nil, fmt.Errorf("genesis from json raw: %w", err)
+		return nil, fmt.Errorf("genesis from file: %w", err)
 	}
 
 	if !gen.IsRaw() {
@@ -103,11 +103,22 @@ func (nodeBuilder) createStateService(config *cfg.Config) (*state.Service, error
 		return nil, err
 	}
 
+	keepBranches := make([]common.Hash, len(config.KeepBranches))
+	for i, hash := range config.KeepBranches {
+		keepBranches[i], err = common.HexToHash(hash)
+		if err != nil {
+			return nil, fmt.Errorf("parsing keep-branches hash: %w", err)
+		}
+	}
+
 	stateConfig := state.Config{
 		Path:              config.BasePath,
 		LogLevel:          stateLogLevel,
 		Metrics:           metrics.NewIntervalConfig(config.PrometheusExternal),
 		GenesisBABEConfig: babeCfg,
+		CompressBlockData: config.CompressBlockData,
+		MaxForkDepth:      uint(config.MaxForkDepth),
+		KeepBranches:      keepBranches,
 	}
 
 	stateSrvc := state.NewService(stateConfig)
@@ -139,18 +150,24 @@ func startStateService(config cfg.StateConfig, stateSrvc *state.Service) error {
 }
 
 func (nodeBuilder) createRuntimeStorage(st *state.Service) (*runtime.NodeStorage, error) {
-	localStorage, err := newInMemoryDB()
-	if err != nil {
-		return nil, err
-	}
-
 	return &runtime.NodeStorage{
-		LocalStorage:      localStorage,
-		PersistentStorage: database.NewTable(st.DB(), "offlinestorage"),
+		LocalStorage:      offchain.NewStorage(st.DB(), offchain.LocalPrefix),
+		PersistentStorage: offchain.NewStorage(st.DB(), offchain.PersistentPrefix),
 		BaseDB:            st.Base,
 	}, nil
 }
 
+// offchainStorages returns the offchain storages of the node storage, whose expired
+// entries are removed by the offchain pruner.
+func offchainStorages(ns *runtime.NodeStorage) (storages []*offchain.Storage) {
+	for _, storage := range []runtime.BasicStorage{ns.LocalStorage, ns.PersistentStorage} {
+		if offchainStorage, ok := storage.(*offchain.Storage); ok {
+			storages = append(storages, offchainStorage)
+		}
+	}
+	return storages
+}
+
 func createRuntime(config *cfg.Config, ns runtime.NodeStorage, st *state.Service,
 	ks *keystore.GlobalKeystore, net *network.Service, code []byte) (
 	rt runtime.Instance, err error) {
@@ -184,6 +201,11 @@ func createRuntime(config *cfg.Config, ns runtime.NodeStorage, st *state.Service
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse wasmer log level: %w", err)
 	}
+	floatDeterminism, err := runtime.ParseFloatDeterminism(config.Core.FloatDeterminism)
+	if err != nil {
+		return nil, err
+	}
+
 	switch config.Core.WasmInterpreter {
 	case wazero_runtime.Name:
 		rtCfg := wazero_runtime.Config{
@@ -195,6 +217,9 @@ func createRuntime(config *cfg.Config, ns runtime.NodeStorage, st *state.Service
 			Transaction: st.Transaction,
 			Role:        config.Core.Role,
 			CodeHash:    codeHash,
+			HeapPages:   config.Core.HeapPages,
+
+			FloatDeterminism: floatDeterminism,
 		}
 
 		// create runtime executor
@@ -256,6 +281,11 @@ func (nodeBuilder) createBABEServiceWithBuilder(config *cfg.Config, st *state.Se
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse babe log level: %w", err)
 	}
+	finalityLagPolicy, err := babe.ParseFinalityLagPolicy(config.Core.FinalityLagPolicy)
+	if err != nil {
+		return nil, err
+	}
+
 	bcfg := &babe.ServiceConfig{
 		LogLvl:             babeLogLevel,
 		BlockState:         st.Block,
@@ -266,6 +296,10 @@ func (nodeBuilder) createBABEServiceWithBuilder(config *cfg.Config, st *state.Se
 		Authority:          config.Core.BabeAuthority,
 		IsDev:              config.ID == "dev",
 		Telemetry:          telemetryMailer,
+		MaxFinalityLag:     config.Core.MaxFinalityLag,
+		FinalityLagPolicy:  finalityLagPolicy,
+		PropagationMargin:  config.Core.AuthoringPropagationMargin,
+		DigestInjectors:    babe.RegisteredDigestInjectors(),
 	}
 
 	if config.Core.BabeAuthority {
@@ -376,6 +410,13 @@ func (nodeBuilder) createNetworkService(config *cfg.Config, stateSrvc *state.Ser
 		NodeKey:           config.Network.NodeKey,
 		ListenAddress:     config.Network.ListenAddress,
 		WarpSyncProvider:  warpSyncProvider,
+		FuzzCorpusDir:     config.Network.FuzzCorpusDir,
+
+		BootnodeCheckInterval: config.Network.BootnodeCheckInterval,
+		BootnodesEndpoint:     config.Network.BootnodesEndpoint,
+		BootnodesEndpointKey:  config.Network.BootnodesEndpointKey,
+		Events:                stateSrvc.Block.Events(),
+		KeyChangesProvider:    stateSrvc.Storage,
 	}
 
 	networkSrvc, err := network.NewService(&networkConfig)
@@ -407,7 +448,8 @@ func (nodeBuilder) createRPCService(params rpcServiceSettings) (*rpc.HTTPServer,
 		return nil, fmt.Errorf("failed to load genesis data: %s", err)
 	}
 
-	syncStateSrvc, err := modules.NewStateSync(genesisData, params.state.Storage)
+	syncStateSrvc, err := modules.NewStateSync(genesisData, params.state.Storage, params.state.Block,
+		params.state.Epoch, params.state.Grandpa)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create sync state service: %s", err)
 	}
@@ -429,6 +471,9 @@ func (nodeBuilder) createRPCService(params rpcServiceSettings) (*rpc.HTTPServer,
 		RPCAPI:              rpcService,
 		SyncStateAPI:        syncStateSrvc,
 		SyncAPI:             params.syncer,
+		DatabaseAPI:         params.state,
+		EpochStateAPI:       params.state.Epoch,
+		GrandpaStateAPI:     params.state.Grandpa,
 		SystemAPI:           params.system,
 		RPCUnsafe:           params.config.RPC.UnsafeRPC,
 		RPCExternal:         params.config.RPC.RPCExternal,
@@ -439,6 +484,9 @@ func (nodeBuilder) createRPCService(params rpcServiceSettings) (*rpc.HTTPServer,
 		WSUnsafeExternal:    params.config.RPC.UnsafeWSExternal,
 		WSPort:              params.config.RPC.WSPort,
 		Modules:             params.config.RPC.Modules,
+		RetainBlocks:        params.config.RetainBlocks,
+		SlowCallThreshold:   params.config.RPC.SlowCallThreshold,
+		Supervisor:          params.supervisor,
 	}
 
 	return rpc.NewHTTPServer(rpcConfig), nil
@@ -489,20 +537,44 @@ func (nodeBuilder) createGRANDPAService(config *cfg.Config, st *state.Service, k
 		GrandpaState: st.Grandpa,
 		Voters:       voters,
 		Authority:    config.Core.GrandpaAuthority,
+		Observer:     config.Core.GrandpaObserver,
 		Network:      net,
 		Interval:     config.Core.GrandpaInterval,
 		Telemetry:    telemetryMailer,
+		JournalSize:  config.Core.GrandpaJournalSize,
+		JournalDir:   config.BasePath,
+		Events:       st.Block.Events(),
 	}
 
 	if config.Core.GrandpaAuthority {
-		gsCfg.Keypair = keys[0].(*ed25519.Keypair)
+		gsCfg.Keypair = keys[0]
 	}
 
 	return grandpa.NewService(gsCfg)
 }
 
+// createStandbyService creates the standby service running the node either as the active
+// node or as a standby node of the nodes sharing the standby lease.
+func createStandbyService(config *cfg.Config) (*standby.Service, error) {
+	// the holder is unique for each run, so a restarted node waits
+	// for its previous lease to lapse before taking over again.
+	suffix := make([]byte, 4)
+	_, err := rand.Read(suffix)
+	if err != nil {
+		return nil, fmt.Errorf("generating lease holder: %w", err)
+	}
+
+	return standby.NewService(standby.Config{
+		Lease:    standby.NewFileLease(config.Core.StandbyLease),
+		Holder:   fmt.Sprintf("%s-%x", config.Name, suffix),
+		LeaseTTL: config.Core.StandbyLeaseTTL,
+	}), nil
+}
+
 func (nodeBuilder) createBlockVerifier(st *state.Service) *babe.VerificationManager {
-	return babe.NewVerificationManager(st.Block, st.Slot, st.Epoch)
+	verificationManager := babe.NewVerificationManager(st.Block, st.Slot, st.Epoch)
+	verificationManager.SetDigestInjectors(babe.RegisteredDigestInjectors())
+	return verificationManager
 }
 
 func (nodeBuilder) newSyncService(config *cfg.Config, st *state.Service, fg sync.FinalityGadget,
@@ -531,6 +603,10 @@ func (nodeBuilder) newSyncService(config *cfg.Config, st *state.Service, fg sync
 		Telemetry:          telemetryMailer,
 		BadBlocks:          genesisData.BadBlocks,
 		RequestMaker:       requestMaker,
+		GrandpaState:       st.Grandpa,
+		PipelineDepth:      int(config.Core.SyncPipelineDepth),
+
+		RuntimeUpgradeDryRun: config.Core.RuntimeUpgradeDryRun,
 	}
 	fullSync := sync.NewFullSyncStrategy(syncCfg)
 
@@ -0,0 +1,339 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"golang.org/x/crypto/blake2b"
+)
+
+// backupMagic identifies a gossamer database backup stream and its format version.
+var backupMagic = []byte("gsmrbk01")
+
+const (
+	backupRecordEntry byte = 1
+	backupRecordEnd   byte = 0
+	// backupBatchEntries is the number of entries written in a single batch on restore.
+	backupBatchEntries = 10_000
+	// maxBackupFieldLength is the maximum length of a key or value read from a backup.
+	maxBackupFieldLength = 256 << 20
+)
+
+var (
+	ErrBackupInvalidFormat    = errors.New("invalid backup format")
+	ErrBackupChecksumMismatch = errors.New("backup checksum mismatch")
+	ErrBackupDatabaseExists   = errors.New("database already exists")
+	ErrBackupFinalisedMissing = errors.New("finalised block missing from restored database")
+)
+
+// BackupManifest describes a database backup.
+type BackupManifest struct {
+	// FinalisedHash and FinalisedNumber identify the highest finalised
+	// block of the database at the time the backup was taken.
+	FinalisedHash   common.Hash
+	FinalisedNumber uint64
+	// Entries is the number of key-value pairs in the backup.
+	Entries uint64
+	// Checksum is the blake2b-256 hash of the backup stream, excluding the checksum itself.
+	Checksum common.Hash
+}
+
+// CreateBackup writes a backup of the database found in the given base path to w.
+// All the database tables are read from the same point-in-time view, so the backup
+// is consistent with the highest finalised block recorded in its manifest.
+// The node must not be running while the backup is created.
+func CreateBackup(basePath string, w io.Writer) (manifest BackupManifest, err error) {
+	db, err := database.LoadDatabase(basePath, false)
+	if err != nil {
+		return manifest, fmt.Errorf("loading database: %w", err)
+	}
+	defer func() {
+		closeErr := db.Close()
+		if err == nil && closeErr != nil {
+			err = fmt.Errorf("closing database: %w", closeErr)
+		}
+	}()
+
+	iterator, err := db.NewIterator()
+	if err != nil {
+		return manifest, fmt.Errorf("creating database iterator: %w", err)
+	}
+	defer iterator.Release()
+
+	// the finalised block is read after the iterator is created so it is
+	// guaranteed to be part of the point-in-time view of the iterator.
+	tries := NewTries()
+	tries.SetEmptyTrie()
+	blockState, err := NewBlockState(db, tries, nil)
+	if err != nil {
+		return manifest, fmt.Errorf("creating block state: %w", err)
+	}
+
+	finalised, err := blockState.GetHighestFinalisedHeader()
+	if err != nil {
+		return manifest, fmt.Errorf("getting highest finalised header: %w", err)
+	}
+	manifest.FinalisedHash = finalised.Hash()
+	manifest.FinalisedNumber = uint64(finalised.Number)
+
+	hasher, err := blake2b.New256(nil)
+	if err != nil {
+		return manifest, fmt.Errorf("creating hasher: %w", err)
+	}
+	bufferedWriter := bufio.NewWriter(w)
+	writer := io.MultiWriter(bufferedWriter, hasher)
+
+	header := make([]byte, 0, len(backupMagic)+common.HashLength+8)
+	header = append(header, backupMagic...)
+	header = append(header, manifest.FinalisedHash.ToBytes()...)
+	header = binary.LittleEndian.AppendUint64(header, manifest.FinalisedNumber)
+	_, err = writer.Write(header)
+	if err != nil {
+		return manifest, fmt.Errorf("writing backup header: %w", err)
+	}
+
+	var lengthBuffer [binary.MaxVarintLen64]byte
+	for iterator.First(); iterator.Valid(); iterator.Next() {
+		_, err = writer.Write([]byte{backupRecordEntry})
+		if err != nil {
+			return manifest, fmt.Errorf("writing backup entry: %w", err)
+		}
+
+		for _, field := range [][]byte{iterator.Key(), iterator.Value()} {
+			n := binary.PutUvarint(lengthBuffer[:], uint64(len(field)))
+			_, err = writer.Write(lengthBuffer[:n])
+			if err != nil {
+				return manifest, fmt.Errorf("writing backup entry: %w", err)
+			}
+			_, err = writer.Write(field)
+			if err != nil {
+				return manifest, fmt.Errorf("writing backup entry: %w", err)
+			}
+		}
+		manifest.Entries++
+	}
+
+	trailer := binary.LittleEndian.AppendUint64([]byte{backupRecordEnd}, manifest.Entries)
+	_, err = writer.Write(trailer)
+	if err != nil {
+		return manifest, fmt.Errorf("writing backup trailer: %w", err)
+	}
+
+	manifest.Checksum = common.BytesToHash(hasher.Sum(nil))
+	_, err = bufferedWriter.Write(manifest.Checksum.ToBytes())
+	if err != nil {
+		return manifest, fmt.Errorf("writing backup checksum: %w", err)
+	}
+
+	err = bufferedWriter.Flush()
+	if err != nil {
+		return manifest, fmt.Errorf("flushing backup: %w", err)
+	}
+
+	return manifest, nil
+}
+
+// RestoreBackup restores the backup read from r into the database of the given base path,
+// which must not already contain a database. The database is first restored in a temporary
+// directory and only moved in place once the backup checksum and the presence of its finalised
+// block have been verified.
+func RestoreBackup(basePath string, r io.Reader) (manifest BackupManifest, err error) {
+	databasePath := filepath.Join(basePath, database.DefaultDatabaseDir)
+	entries, err := os.ReadDir(databasePath)
+	if err == nil && len(entries) > 0 {
+		return manifest, fmt.Errorf("%w: %s", ErrBackupDatabaseExists, databasePath)
+	} else if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return manifest, fmt.Errorf("reading database directory: %w", err)
+	}
+
+	err = os.MkdirAll(basePath, os.ModePerm)
+	if err != nil {
+		return manifest, fmt.Errorf("creating base path: %w", err)
+	}
+
+	restorePath, err := os.MkdirTemp(basePath, "restore-")
+	if err != nil {
+		return manifest, fmt.Errorf("creating restore directory: %w", err)
+	}
+	defer func() {
+		removeErr := os.RemoveAll(restorePath)
+		if err == nil && removeErr != nil {
+			err = fmt.Errorf("removing restore directory: %w", removeErr)
+		}
+	}()
+
+	manifest, err = restoreBackup(restorePath, r)
+	if err != nil {
+		return manifest, err
+	}
+
+	err = os.Remove(databasePath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return manifest, fmt.Errorf("removing empty database directory: %w", err)
+	}
+
+	err = os.Rename(filepath.Join(restorePath, database.DefaultDatabaseDir), databasePath)
+	if err != nil {
+		return manifest, fmt.Errorf("moving restored database: %w", err)
+	}
+
+	return manifest, nil
+}
+
+func restoreBackup(restorePath string, r io.Reader) (manifest BackupManifest, err error) {
+	db, err := database.LoadDatabase(restorePath, false)
+	if err != nil {
+		return manifest, fmt.Errorf("loading database: %w", err)
+	}
+	defer func() {
+		closeErr := db.Close()
+		if err == nil && closeErr != nil {
+			err = fmt.Errorf("closing database: %w", closeErr)
+		}
+	}()
+
+	hasher, err := blake2b.New256(nil)
+	if err != nil {
+		return manifest, fmt.Errorf("creating hasher: %w", err)
+	}
+	reader := &hashingReader{reader: bufio.NewReader(r), hasher: hasher}
+
+	header := make([]byte, len(backupMagic)+common.HashLength+8)
+	_, err = io.ReadFull(reader, header)
+	if err != nil {
+		return manifest, fmt.Errorf("reading backup header: %w", err)
+	}
+	if !bytes.Equal(header[:len(backupMagic)], backupMagic) {
+		return manifest, fmt.Errorf("%w: unexpected magic bytes 0x%x",
+			ErrBackupInvalidFormat, header[:len(backupMagic)])
+	}
+	header = header[len(backupMagic):]
+	manifest.FinalisedHash = common.BytesToHash(header[:common.HashLength])
+	manifest.FinalisedNumber = binary.LittleEndian.Uint64(header[common.HashLength:])
+
+	batch := db.NewBatch()
+	defer batch.Close()
+
+	for {
+		var recordType byte
+		recordType, err = reader.ReadByte()
+		if err != nil {
+			return manifest, fmt.Errorf("reading backup record: %w", err)
+		}
+
+		if recordType == backupRecordEnd {
+			break
+		} else if recordType != backupRecordEntry {
+			return manifest, fmt.Errorf("%w: unknown record type %d", ErrBackupInvalidFormat, recordType)
+		}
+
+		var key, value []byte
+		key, err = reader.readField()
+		if err != nil {
+			return manifest, fmt.Errorf("reading backup entry key: %w", err)
+		}
+		value, err = reader.readField()
+		if err != nil {
+			return manifest, fmt.Errorf("reading backup entry value: %w", err)
+		}
+
+		err = batch.Put(key, value)
+		if err != nil {
+			return manifest, fmt.Errorf("writing entry to batch: %w", err)
+		}
+		manifest.Entries++
+
+		if manifest.Entries%backupBatchEntries == 0 {
+			err = batch.Flush()
+			if err != nil {
+				return manifest, fmt.Errorf("flushing batch: %w", err)
+			}
+			batch.Reset()
+		}
+	}
+
+	entries := make([]byte, 8)
+	_, err = io.ReadFull(reader, entries)
+	if err != nil {
+		return manifest, fmt.Errorf("reading backup trailer: %w", err)
+	}
+	if binary.LittleEndian.Uint64(entries) != manifest.Entries {
+		return manifest, fmt.Errorf("%w: trailer declares %d entries but %d were read",
+			ErrBackupInvalidFormat, binary.LittleEndian.Uint64(entries), manifest.Entries)
+	}
+
+	computedChecksum := common.BytesToHash(hasher.Sum(nil))
+	_, err = io.ReadFull(reader.reader, manifest.Checksum[:])
+	if err != nil {
+		return manifest, fmt.Errorf("reading backup checksum: %w", err)
+	}
+	if computedChecksum != manifest.Checksum {
+		return manifest, fmt.Errorf("%w: expected %s but computed %s",
+			ErrBackupChecksumMismatch, manifest.Checksum, computedChecksum)
+	}
+
+	err = batch.Flush()
+	if err != nil {
+		return manifest, fmt.Errorf("flushing batch: %w", err)
+	}
+
+	hasFinalisedHeader, err := database.NewTable(db, blockPrefix).Has(headerKey(manifest.FinalisedHash))
+	if err != nil {
+		return manifest, fmt.Errorf("checking finalised header: %w", err)
+	}
+	if !hasFinalisedHeader {
+		return manifest, fmt.Errorf("%w: %s", ErrBackupFinalisedMissing, manifest.FinalisedHash)
+	}
+
+	return manifest, nil
+}
+
+// hashingReader hashes all the bytes read from the underlying reader.
+type hashingReader struct {
+	reader *bufio.Reader
+	hasher hash.Hash
+}
+
+func (h *hashingReader) Read(p []byte) (n int, err error) {
+	n, err = h.reader.Read(p)
+	_, _ = h.hasher.Write(p[:n])
+	return n, err
+}
+
+func (h *hashingReader) ReadByte() (byte, error) {
+	b, err := h.reader.ReadByte()
+	if err == nil {
+		_, _ = h.hasher.Write([]byte{b})
+	}
+	return b, err
+}
+
+func (h *hashingReader) readField() ([]byte, error) {
+	length, err := binary.ReadUvarint(h)
+	if err != nil {
+		return nil, err
+	}
+	if length > maxBackupFieldLength {
+		return nil, fmt.Errorf("%w: field length %d exceeds maximum %d",
+			ErrBackupInvalidFormat, length, maxBackupFieldLength)
+	}
+
+	field := make([]byte, length)
+	_, err = io.ReadFull(h, field)
+	if err != nil {
+		return nil, err
+	}
+	return field, nil
+}
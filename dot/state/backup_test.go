@@ -0,0 +1,136 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestBackupBasePath(t *testing.T) (basePath string, entries map[string][]byte) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	telemetryMock := NewMockTelemetry(ctrl)
+	telemetryMock.EXPECT().SendMessage(gomock.Any()).AnyTimes()
+
+	basePath = t.TempDir()
+	db, err := database.LoadDatabase(basePath, false)
+	require.NoError(t, err)
+
+	_, err = NewBlockStateFromGenesis(db, newTriesEmpty(), testGenesisHeader, telemetryMock)
+	require.NoError(t, err)
+	err = database.NewTable(db, storagePrefix).Put([]byte("key"), []byte("value"))
+	require.NoError(t, err)
+
+	entries = readDatabaseEntries(t, db)
+	err = db.Close()
+	require.NoError(t, err)
+
+	return basePath, entries
+}
+
+func readDatabaseEntries(t *testing.T, db database.Database) map[string][]byte {
+	t.Helper()
+
+	iterator, err := db.NewIterator()
+	require.NoError(t, err)
+	defer iterator.Release()
+
+	entries := make(map[string][]byte)
+	for iterator.First(); iterator.Valid(); iterator.Next() {
+		entries[string(iterator.Key())] = bytes.Clone(iterator.Value())
+	}
+	return entries
+}
+
+func Test_CreateBackup_RestoreBackup(t *testing.T) {
+	t.Parallel()
+
+	basePath, expectedEntries := newTestBackupBasePath(t)
+
+	buffer := bytes.NewBuffer(nil)
+	manifest, err := CreateBackup(basePath, buffer)
+	require.NoError(t, err)
+	assert.Equal(t, testGenesisHeader.Hash(), manifest.FinalisedHash)
+	assert.Equal(t, uint64(0), manifest.FinalisedNumber)
+	assert.Equal(t, uint64(len(expectedEntries)), manifest.Entries)
+
+	restoreBasePath := t.TempDir()
+	restoredManifest, err := RestoreBackup(restoreBasePath, buffer)
+	require.NoError(t, err)
+	assert.Equal(t, manifest, restoredManifest)
+
+	db, err := database.LoadDatabase(restoreBasePath, false)
+	require.NoError(t, err)
+	defer db.Close()
+	assert.Equal(t, expectedEntries, readDatabaseEntries(t, db))
+
+	matches, err := filepath.Glob(filepath.Join(restoreBasePath, "restore-*"))
+	require.NoError(t, err)
+	assert.Empty(t, matches)
+}
+
+func Test_RestoreBackup_errors(t *testing.T) {
+	t.Parallel()
+
+	basePath, _ := newTestBackupBasePath(t)
+	buffer := bytes.NewBuffer(nil)
+	_, err := CreateBackup(basePath, buffer)
+	require.NoError(t, err)
+	backup := buffer.Bytes()
+
+	testCases := map[string]struct {
+		backup     func() []byte
+		basePath   func(t *testing.T) string
+		errWrapped error
+	}{
+		"database_exists": {
+			backup:     func() []byte { return backup },
+			basePath:   func(*testing.T) string { return basePath },
+			errWrapped: ErrBackupDatabaseExists,
+		},
+		"invalid_magic": {
+			backup: func() []byte {
+				corrupted := bytes.Clone(backup)
+				corrupted[0] = 'x'
+				return corrupted
+			},
+			errWrapped: ErrBackupInvalidFormat,
+		},
+		"checksum_mismatch": {
+			backup: func() []byte {
+				corrupted := bytes.Clone(backup)
+				corrupted[len(corrupted)-1] ^= 0xff
+				return corrupted
+			},
+			errWrapped: ErrBackupChecksumMismatch,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			restoreBasePath := t.TempDir()
+			if testCase.basePath != nil {
+				restoreBasePath = testCase.basePath(t)
+			}
+
+			_, err := RestoreBackup(restoreBasePath, bytes.NewReader(testCase.backup()))
+			assert.ErrorIs(t, err, testCase.errWrapped)
+
+			if testCase.basePath == nil {
+				assert.NoDirExists(t, filepath.Join(restoreBasePath, database.DefaultDatabaseDir))
+			}
+		})
+	}
+}
@@ -11,6 +11,7 @@
 	"sync"
 	"time"
 
+	"github.com/ChainSafe/gossamer/dot/events"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/internal/database"
 	"github.com/ChainSafe/gossamer/lib/blocktree"
@@ -62,14 +63,22 @@ type BlockState struct {
 	lastSetID         uint64
 	unfinalisedBlocks *hashToBlockMap
 	tries             *Tries
+	compression       *blockDataCompression
+
+	// maxForkDepth is the number of blocks behind the best block beyond which
+	// the unfinalised branches are discarded, 0 if they are never discarded.
+	maxForkDepth uint
+	// keptBranches is the set of block hashes whose branches are never discarded.
+	keptBranches map[common.Hash]struct{}
 
 	// State variables
 	pausedLock sync.RWMutex
 	pause      chan struct{}
 
 	// block notifiers
-	imported                       map[chan *types.Block]struct{}
-	finalised                      map[chan *types.FinalisationInfo]struct{}
+	events                         *events.Bus
+	imported                       map[chan *types.Block]*events.Subscription[*types.Block]
+	finalised                      map[chan *types.FinalisationInfo]*events.Subscription[*types.FinalisationInfo]
 	finalisedLock                  sync.RWMutex
 	importedLock                   sync.RWMutex
 	runtimeUpdateSubscriptionsLock sync.RWMutex
@@ -86,13 +95,20 @@ func NewBlockState(db database.Database, trs *Tries, telemetry Telemetry) (*Bloc
 		db:                         database.NewTable(db, blockPrefix),
 		unfinalisedBlocks:          newHashToBlockMap(),
 		tries:                      trs,
-		imported:                   make(map[chan *types.Block]struct{}),
-		finalised:                  make(map[chan *types.FinalisationInfo]struct{}),
+		events:                     events.NewBus(),
+		imported:                   make(map[chan *types.Block]*events.Subscription[*types.Block]),
+		finalised:                  make(map[chan *types.FinalisationInfo]*events.Subscription[*types.FinalisationInfo]),
 		runtimeUpdateSubscriptions: make(map[uint32]chan<- runtime.Version),
 		telemetry:                  telemetry,
 		pause:                      make(chan struct{}),
 	}
 
+	compression, err := newBlockDataCompression(bs.db)
+	if err != nil {
+		return nil, fmt.Errorf("creating block data compression: %w", err)
+	}
+	bs.compression = compression
+
 	gh, err := bs.db.Get(headerHashKey(0))
 	if err != nil {
 		return nil, fmt.Errorf("cannot get block 0: %w", err)
@@ -120,8 +136,9 @@ func NewBlockStateFromGenesis(db database.Database, trs *Tries, header *types.He
 		db:                         database.NewTable(db, blockPrefix),
 		unfinalisedBlocks:          newHashToBlockMap(),
 		tries:                      trs,
-		imported:                   make(map[chan *types.Block]struct{}),
-		finalised:                  make(map[chan *types.FinalisationInfo]struct{}),
+		events:                     events.NewBus(),
+		imported:                   make(map[chan *types.Block]*events.Subscription[*types.Block]),
+		finalised:                  make(map[chan *types.FinalisationInfo]*events.Subscription[*types.FinalisationInfo]),
 		runtimeUpdateSubscriptions: make(map[uint32]chan<- runtime.Version),
 		genesisHash:                header.Hash(),
 		lastFinalised:              header.Hash(),
@@ -129,6 +146,12 @@ func NewBlockStateFromGenesis(db database.Database, trs *Tries, header *types.He
 		pause:                      make(chan struct{}),
 	}
 
+	compression, err := newBlockDataCompression(bs.db)
+	if err != nil {
+		return nil, fmt.Errorf("creating block data compression: %w", err)
+	}
+	bs.compression = compression
+
 	if err := bs.setArrivalTime(header.Hash(), time.Now()); err != nil {
 		return nil, err
 	}
@@ -452,7 +475,7 @@ func (bs *BlockState) HasBlockBody(hash common.Hash) (bool, error) {
 		return true, nil
 	}
 
-	return bs.db.Has(blockBodyKey(hash))
+	return hasBlockData(bs.db, blockBodyKey(hash), prefixKey(hash, compressedBlockBodyPrefix))
 }
 
 // GetBlockBody will return Body for a given hash
@@ -462,7 +485,7 @@ func (bs *BlockState) GetBlockBody(hash common.Hash) (body *types.Body, err erro
 		return body, nil
 	}
 
-	data, err := bs.db.Get(blockBodyKey(hash))
+	data, err := bs.getBlockData(blockBodyKey(hash), prefixKey(hash, compressedBlockBodyPrefix))
 	if err != nil {
 		return nil, err
 	}
@@ -477,7 +500,7 @@ func (bs *BlockState) SetBlockBody(hash common.Hash, body *types.Body) error {
 		return err
 	}
 
-	return bs.db.Put(blockBodyKey(hash), encodedBody)
+	return bs.putBlockData(blockBodyKey(hash), prefixKey(hash, compressedBlockBodyPrefix), encodedBody)
 }
 
 // SetFirstNonOriginSlotNumber saves the first non-origin slot number into the DB
@@ -534,6 +557,8 @@ func (bs *BlockState) AddBlockWithArrivalTime(block *types.Block, arrivalTime ti
 		return errNilBlockBody
 	}
 
+	bestBlockHash := bs.bt.BestBlockHash()
+
 	// add block to blocktree
 	if err := bs.bt.AddBlock(&block.Header, arrivalTime); err != nil {
 		return err
@@ -541,9 +566,35 @@ func (bs *BlockState) AddBlockWithArrivalTime(block *types.Block, arrivalTime ti
 
 	bs.unfinalisedBlocks.store(block)
 	go bs.notifyImported(block)
+
+	bs.pruneStaleBranches()
+
+	// the added block is the only block which can become the best block
+	if bs.bt.BestBlockHash() != bestBlockHash {
+		go bs.notifyBestBlockChanged(&block.Header)
+	}
 	return nil
 }
 
+// pruneStaleBranches discards the unfinalised branches more than maxForkDepth blocks
+// behind the best block, along with their blocks and tries held in memory.
+func (bs *BlockState) pruneStaleBranches() {
+	if bs.maxForkDepth == 0 {
+		return
+	}
+
+	pruned := bs.bt.PruneStaleBranches(bs.maxForkDepth, bs.keptBranches)
+	for _, hash := range pruned {
+		blockHeader := bs.unfinalisedBlocks.delete(hash)
+		if blockHeader == nil {
+			continue
+		}
+
+		bs.tries.delete(blockHeader.StateRoot)
+		logger.Debugf("discarded stale block number %d with hash %s", blockHeader.Number, hash)
+	}
+}
+
 // GetAllBlocksAtNumber returns all unfinalised blocks with the given number
 func (bs *BlockState) GetAllBlocksAtNumber(num uint) ([]common.Hash, error) {
 	return bs.bt.GetHashesAtNumber(num), nil
@@ -904,6 +955,9 @@ func (bs *BlockState) HandleRuntimeChanges(newState *rtstorage.TrieState,
 		NodeStorage: parentRuntimeInstance.NodeStorage(),
 		Network:     parentRuntimeInstance.NetworkService(),
 		CodeHash:    currCodeHash,
+		HeapPages:   parentRuntimeInstance.HeapPages(),
+
+		FloatDeterminism: parentRuntimeInstance.FloatDeterminism(),
 	}
 
 	if parentRuntimeInstance.Validator() {
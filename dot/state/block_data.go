@@ -64,12 +64,12 @@ func (bs *BlockState) GetMessageQueue(hash common.Hash) ([]byte, error) {
 
 // HasJustification returns if the db contains a Justification at the given hash
 func (bs *BlockState) HasJustification(hash common.Hash) (bool, error) {
-	return bs.db.Has(prefixKey(hash, justificationPrefix))
+	return hasBlockData(bs.db, prefixKey(hash, justificationPrefix), prefixKey(hash, compressedJustificationPrefix))
 }
 
 // SetJustification sets a Justification in the database
 func (bs *BlockState) SetJustification(hash common.Hash, data []byte) error {
-	err := bs.db.Put(prefixKey(hash, justificationPrefix), data)
+	err := bs.putBlockData(prefixKey(hash, justificationPrefix), prefixKey(hash, compressedJustificationPrefix), data)
 	if err != nil {
 		return err
 	}
@@ -79,7 +79,7 @@ func (bs *BlockState) SetJustification(hash common.Hash, data []byte) error {
 
 // GetJustification retrieves a Justification from the database
 func (bs *BlockState) GetJustification(hash common.Hash) ([]byte, error) {
-	data, err := bs.db.Get(prefixKey(hash, justificationPrefix))
+	data, err := bs.getBlockData(prefixKey(hash, justificationPrefix), prefixKey(hash, compressedJustificationPrefix))
 	if err != nil {
 		return nil, err
 	}
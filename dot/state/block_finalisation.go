@@ -155,6 +155,7 @@ func (bs *BlockState) SetFinalisedHash(hash common.Hash, round, setID uint64) er
 		bs.notifyFinalized(hash, round, setID)
 	}
 
+	bestBlockHash := bs.bt.BestBlockHash()
 	pruned := bs.bt.Prune(hash)
 	for _, hash := range pruned {
 		blockHeader := bs.unfinalisedBlocks.delete(hash)
@@ -166,6 +167,14 @@ func (bs *BlockState) SetFinalisedHash(hash common.Hash, round, setID uint64) er
 		logger.Tracef("pruned block number %d with hash %s", blockHeader.Number, hash)
 	}
 
+	if newBestBlockHash := bs.bt.BestBlockHash(); newBestBlockHash != bestBlockHash {
+		bestBlockHeader, err := bs.GetHeader(newBestBlockHash)
+		if err != nil {
+			return fmt.Errorf("getting best block header: %w", err)
+		}
+		go bs.notifyBestBlockChanged(bestBlockHeader)
+	}
+
 	header, err := bs.GetHeader(hash)
 	if err != nil {
 		return fmt.Errorf("failed to get finalised header, hash: %s, error: %s", hash, err)
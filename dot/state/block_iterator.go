@@ -0,0 +1,190 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+)
+
+var errMissingBlockNumber = errors.New("missing block number entry")
+
+// BlockIterator iterates over the blocks of a chain in ascending block number order.
+// The hashes of the finalised blocks are read in one pass over the block number index
+// of the database, and the hashes of the unfinalised blocks are taken from the block
+// tree when the iterator is created, instead of looking up each block number.
+// It must be released after use.
+type BlockIterator struct {
+	blockState *BlockState
+
+	// numberIterator iterates over the block number index, for the blocks
+	// before the first block of hashes. It is created on first use.
+	numberIterator database.Iterator
+	// hashes are the hashes of the blocks from the number firstHashNumber.
+	hashes          []common.Hash
+	firstHashNumber uint
+
+	next uint
+	end  uint
+
+	hash   common.Hash
+	header *types.Header
+	err    error
+}
+
+// CanonicalIterator returns an iterator over the blocks of the best chain, from the
+// block with the given number to the best block at the time the iterator is created.
+func (bs *BlockState) CanonicalIterator(fromNumber uint) (*BlockIterator, error) {
+	bestHash := bs.BestBlockHash()
+	bestHeader, err := bs.GetHeader(bestHash)
+	if err != nil {
+		return nil, fmt.Errorf("getting best block header: %w", err)
+	}
+
+	// the start hash is not in the block tree, so the range starts at its root
+	hashes, err := bs.bt.Range(common.Hash{}, bestHash)
+	if err != nil {
+		return nil, fmt.Errorf("getting block tree range: %w", err)
+	}
+
+	return &BlockIterator{
+		blockState:      bs,
+		hashes:          hashes,
+		firstHashNumber: bestHeader.Number + 1 - uint(len(hashes)),
+		next:            fromNumber,
+		end:             bestHeader.Number,
+	}, nil
+}
+
+// RangeIterator returns an iterator over the blocks between the start and end
+// block hashes, both inclusive. The start block must be an ancestor of the end block.
+func (bs *BlockState) RangeIterator(startHash, endHash common.Hash) (*BlockIterator, error) {
+	startHeader, err := bs.GetHeader(startHash)
+	if err != nil {
+		return nil, fmt.Errorf("getting start header: %w", err)
+	}
+	endHeader, err := bs.GetHeader(endHash)
+	if err != nil {
+		return nil, fmt.Errorf("getting end header: %w", err)
+	}
+	if startHeader.Number > endHeader.Number {
+		return nil, fmt.Errorf("%w", ErrStartGreaterThanEnd)
+	}
+
+	canonicalEndHash, err := bs.GetHashByNumber(endHeader.Number)
+	if err != nil || canonicalEndHash != endHash {
+		// the end block is not on the best chain, so its ancestry is walked
+		hashes, err := bs.Range(startHash, endHash)
+		if err != nil {
+			return nil, err
+		}
+		return &BlockIterator{
+			blockState:      bs,
+			hashes:          hashes,
+			firstHashNumber: startHeader.Number,
+			next:            startHeader.Number,
+			end:             endHeader.Number,
+		}, nil
+	}
+
+	canonicalStartHash, err := bs.GetHashByNumber(startHeader.Number)
+	if err != nil {
+		return nil, fmt.Errorf("getting hash of block number %d: %w", startHeader.Number, err)
+	}
+	if canonicalStartHash != startHash {
+		return nil, fmt.Errorf("%w: expecting %s, found: %s",
+			ErrStartHashMismatch, startHash.Short(), canonicalStartHash.Short())
+	}
+
+	iterator, err := bs.CanonicalIterator(startHeader.Number)
+	if err != nil {
+		return nil, err
+	}
+	iterator.end = endHeader.Number
+	return iterator, nil
+}
+
+// Next moves the iterator to the next block, and returns false once all the
+// blocks are iterated over or an error occurred, which is returned by Err.
+func (it *BlockIterator) Next() bool {
+	if it.err != nil || it.next > it.end {
+		return false
+	}
+
+	hash, err := it.nextHash()
+	if err != nil {
+		it.err = fmt.Errorf("getting hash of block number %d: %w", it.next, err)
+		return false
+	}
+
+	header, err := it.blockState.GetHeader(hash)
+	if err != nil {
+		it.err = fmt.Errorf("getting header of block %s: %w", hash, err)
+		return false
+	}
+
+	it.hash = hash
+	it.header = header
+	it.next++
+	return true
+}
+
+func (it *BlockIterator) nextHash() (common.Hash, error) {
+	if it.next >= it.firstHashNumber {
+		index := it.next - it.firstHashNumber
+		if index >= uint(len(it.hashes)) {
+			return common.Hash{}, fmt.Errorf("%w: after %d hashes", errMissingBlockNumber, len(it.hashes))
+		}
+		return it.hashes[index], nil
+	}
+
+	valid := false
+	if it.numberIterator == nil {
+		iterator, err := it.blockState.db.NewPrefixIterator(headerHashPrefix)
+		if err != nil {
+			return common.Hash{}, fmt.Errorf("creating block number iterator: %w", err)
+		}
+		it.numberIterator = iterator
+		valid = iterator.SeekGE(append([]byte(blockPrefix), headerHashKey(uint64(it.next))...))
+	} else {
+		valid = it.numberIterator.Next()
+	}
+
+	if !valid || decodeHeaderHashKey(it.numberIterator.Key()) != it.next {
+		return common.Hash{}, fmt.Errorf("%w", errMissingBlockNumber)
+	}
+	return common.NewHash(it.numberIterator.Value()), nil
+}
+
+// Hash returns the hash of the current block.
+func (it *BlockIterator) Hash() common.Hash {
+	return it.hash
+}
+
+// Header returns the header of the current block.
+func (it *BlockIterator) Header() *types.Header {
+	return it.header
+}
+
+// Body returns the body of the current block, which is only read when requested.
+func (it *BlockIterator) Body() (*types.Body, error) {
+	return it.blockState.GetBlockBody(it.hash)
+}
+
+// Err returns the error which stopped the iteration, if any.
+func (it *BlockIterator) Err() error {
+	return it.err
+}
+
+// Release releases the database iterator of the iterator.
+func (it *BlockIterator) Release() {
+	if it.numberIterator != nil {
+		it.numberIterator.Release()
+		it.numberIterator = nil
+	}
+}
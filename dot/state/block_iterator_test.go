@@ -0,0 +1,175 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/trie"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// newIteratorTestBlockState returns a block state with a chain of 16 blocks, of
+// which the first 8 are finalised, and a branch of 2 blocks from the block 12.
+// It returns the hashes of the chain from the genesis block and of the branch.
+func newIteratorTestBlockState(t *testing.T) (blockState *BlockState, chain, branch []common.Hash) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	telemetryMock := NewMockTelemetry(ctrl)
+	telemetryMock.EXPECT().SendMessage(gomock.Any()).AnyTimes()
+
+	genesisHeader := &types.Header{
+		Number:    0,
+		StateRoot: trie.EmptyHash,
+		Digest:    types.NewDigest(),
+	}
+	blockState, err := NewBlockStateFromGenesis(NewInMemoryDB(t), newTriesEmpty(), genesisHeader, telemetryMock)
+	require.NoError(t, err)
+
+	addChain := func(parentHash common.Hash, from, to uint, extrinsic byte) (hashes []common.Hash) {
+		for number := from; number <= to; number++ {
+			block := &types.Block{
+				Header: types.Header{
+					Number:     number,
+					Digest:     createPrimaryBABEDigest(t),
+					ParentHash: parentHash,
+					StateRoot:  common.Hash{extrinsic},
+				},
+				Body: *types.NewBody([]types.Extrinsic{{extrinsic, byte(number)}}),
+			}
+			require.NoError(t, blockState.AddBlock(block))
+			parentHash = block.Header.Hash()
+			hashes = append(hashes, parentHash)
+		}
+		return hashes
+	}
+
+	chain = append([]common.Hash{genesisHeader.Hash()}, addChain(genesisHeader.Hash(), 1, 16, 0)...)
+	branch = addChain(chain[12], 13, 14, 1)
+	require.NoError(t, blockState.SetFinalisedHash(chain[8], 0, 0))
+	return blockState, chain, branch
+}
+
+func iterate(t *testing.T, iterator *BlockIterator) (hashes []common.Hash) {
+	t.Helper()
+	defer iterator.Release()
+
+	for iterator.Next() {
+		assert.Equal(t, iterator.Hash(), iterator.Header().Hash())
+		hashes = append(hashes, iterator.Hash())
+	}
+	require.NoError(t, iterator.Err())
+	return hashes
+}
+
+func TestBlockState_CanonicalIterator(t *testing.T) {
+	t.Parallel()
+
+	blockState, chain, _ := newIteratorTestBlockState(t)
+
+	testCases := map[string]struct {
+		fromNumber uint
+		expected   []common.Hash
+	}{
+		"from_genesis": {
+			expected: chain,
+		},
+		"from_finalised_block": {
+			fromNumber: 5,
+			expected:   chain[5:],
+		},
+		"from_unfinalised_block": {
+			fromNumber: 12,
+			expected:   chain[12:],
+		},
+		"after_best_block": {
+			fromNumber: 17,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			iterator, err := blockState.CanonicalIterator(testCase.fromNumber)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, iterate(t, iterator))
+		})
+	}
+}
+
+func TestBlockState_RangeIterator(t *testing.T) {
+	t.Parallel()
+
+	blockState, chain, branch := newIteratorTestBlockState(t)
+
+	testCases := map[string]struct {
+		start, end common.Hash
+		expected   []common.Hash
+		errWrapped error
+	}{
+		"finalised_blocks": {
+			start:    chain[2],
+			end:      chain[6],
+			expected: chain[2:7],
+		},
+		"finalised_and_unfinalised_blocks": {
+			start:    chain[4],
+			end:      chain[14],
+			expected: chain[4:15],
+		},
+		"branch": {
+			start:    chain[10],
+			end:      branch[1],
+			expected: append(append([]common.Hash{}, chain[10:13]...), branch...),
+		},
+		"start_after_end": {
+			start:      chain[6],
+			end:        chain[2],
+			errWrapped: ErrStartGreaterThanEnd,
+		},
+		"start_not_ancestor_of_end": {
+			start:      branch[0],
+			end:        chain[16],
+			errWrapped: ErrStartHashMismatch,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			iterator, err := blockState.RangeIterator(testCase.start, testCase.end)
+			require.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				return
+			}
+			assert.Equal(t, testCase.expected, iterate(t, iterator))
+		})
+	}
+}
+
+func TestBlockIterator_Body(t *testing.T) {
+	t.Parallel()
+
+	blockState, chain, _ := newIteratorTestBlockState(t)
+
+	iterator, err := blockState.RangeIterator(chain[7], chain[9])
+	require.NoError(t, err)
+	defer iterator.Release()
+
+	for number := 7; iterator.Next(); number++ {
+		body, err := iterator.Body()
+		require.NoError(t, err)
+		assert.Equal(t, types.NewBody([]types.Extrinsic{{0, byte(number)}}), body)
+	}
+	require.NoError(t, iterator.Err())
+}
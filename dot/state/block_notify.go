@@ -7,6 +7,7 @@
 	"errors"
 	"sync"
 
+	"github.com/ChainSafe/gossamer/dot/events"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/runtime"
@@ -15,13 +16,18 @@
 
 const defaultBufferSize = 128
 
+// Events returns the event bus the block state publishes its events to.
+func (bs *BlockState) Events() *events.Bus {
+	return bs.events
+}
+
 // GetImportedBlockNotifierChannel function to retrieve a imported block notifier channel
 func (bs *BlockState) GetImportedBlockNotifierChannel() chan *types.Block {
 	bs.importedLock.Lock()
 	defer bs.importedLock.Unlock()
 
 	ch := make(chan *types.Block, defaultBufferSize)
-	bs.imported[ch] = struct{}{}
+	bs.imported[ch] = bs.events.BlockImported.SubscribeChannel(ch, events.DropNewest)
 	return ch
 }
 
@@ -31,7 +37,7 @@ func (bs *BlockState) GetFinalisedNotifierChannel() chan *types.FinalisationInfo
 	defer bs.finalisedLock.Unlock()
 
 	ch := make(chan *types.FinalisationInfo, defaultBufferSize)
-	bs.finalised[ch] = struct{}{}
+	bs.finalised[ch] = bs.events.BlockFinalised.SubscribeChannel(ch, events.DropNewest)
 
 	return ch
 }
@@ -40,6 +46,12 @@ func (bs *BlockState) GetFinalisedNotifierChannel() chan *types.FinalisationInfo
 func (bs *BlockState) FreeImportedBlockNotifierChannel(ch chan *types.Block) {
 	bs.importedLock.Lock()
 	defer bs.importedLock.Unlock()
+
+	subscription, ok := bs.imported[ch]
+	if !ok {
+		return
+	}
+	subscription.Unsubscribe()
 	delete(bs.imported, ch)
 }
 
@@ -48,33 +60,26 @@ func (bs *BlockState) FreeFinalisedNotifierChannel(ch chan *types.FinalisationIn
 	bs.finalisedLock.Lock()
 	defer bs.finalisedLock.Unlock()
 
+	subscription, ok := bs.finalised[ch]
+	if !ok {
+		return
+	}
+	subscription.Unsubscribe()
 	delete(bs.finalised, ch)
 }
 
 func (bs *BlockState) notifyImported(block *types.Block) {
-	bs.importedLock.RLock()
-	defer bs.importedLock.RUnlock()
-
-	if len(bs.imported) == 0 {
-		return
-	}
+	logger.Trace("notifying imported block subscribers...")
+	bs.events.BlockImported.Publish(block)
+}
 
-	logger.Trace("notifying imported block channels...")
-	for ch := range bs.imported {
-		go func(ch chan *types.Block) {
-			select {
-			case ch <- block:
-			default:
-			}
-		}(ch)
-	}
+func (bs *BlockState) notifyBestBlockChanged(header *types.Header) {
+	logger.Trace("notifying best block subscribers...")
+	bs.events.BestBlockChanged.Publish(header)
 }
 
 func (bs *BlockState) notifyFinalized(hash common.Hash, round, setID uint64) {
-	bs.finalisedLock.RLock()
-	defer bs.finalisedLock.RUnlock()
-
-	if len(bs.finalised) == 0 {
+	if !bs.events.BlockFinalised.HasSubscribers() {
 		return
 	}
 
@@ -84,21 +89,12 @@ func (bs *BlockState) notifyFinalized(hash common.Hash, round, setID uint64) {
 		return
 	}
 
-	logger.Debug("notifying finalised block channels...")
-	info := &types.FinalisationInfo{
+	logger.Debug("notifying finalised block subscribers...")
+	bs.events.BlockFinalised.Publish(&types.FinalisationInfo{
 		Header: *header,
 		Round:  round,
 		SetID:  setID,
-	}
-
-	for ch := range bs.finalised {
-		go func(ch chan *types.FinalisationInfo) {
-			select {
-			case ch <- info:
-			default:
-			}
-		}(ch)
-	}
+	})
 }
 
 func (bs *BlockState) notifyRuntimeUpdated(version runtime.Version) {
@@ -8,7 +8,9 @@
 	"testing"
 	"time"
 
+	"github.com/ChainSafe/gossamer/dot/events"
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/runtime"
 	"github.com/stretchr/testify/require"
 )
@@ -41,6 +43,28 @@ func TestFreeImportedBlockNotifierChannel(t *testing.T) {
 	require.Equal(t, 0, len(bs.imported))
 }
 
+func TestBestBlockChangedEvent(t *testing.T) {
+	bs := newTestBlockState(t, newTriesEmpty())
+	subscription := bs.Events().BestBlockChanged.Subscribe(defaultBufferSize, events.DropNewest)
+	defer subscription.Unsubscribe()
+
+	chain, _ := AddBlocksToState(t, bs, 3, false)
+
+	received := make(map[common.Hash]struct{}, len(chain))
+	for range chain {
+		select {
+		case header := <-subscription.Events():
+			received[header.Hash()] = struct{}{}
+		case <-time.After(testMessageTimeout):
+			t.Fatal("did not receive best block header")
+		}
+	}
+
+	for _, header := range chain {
+		require.Contains(t, received, header.Hash())
+	}
+}
+
 func TestFinalizedChannel(t *testing.T) {
 	bs := newTestBlockState(t, newTriesEmpty())
 
@@ -397,6 +397,35 @@ func TestIsBlockOnCurrentChain(t *testing.T) {
 	}
 }
 
+func TestAddBlock_pruneStaleBranches(t *testing.T) {
+	bs := newTestBlockState(t, newTriesEmpty())
+	bs.maxForkDepth = 2
+
+	addBlock := func(parent *types.Header, stateRoot common.Hash) *types.Header {
+		header := &types.Header{
+			Number:     parent.Number + 1,
+			Digest:     createPrimaryBABEDigest(t),
+			ParentHash: parent.Hash(),
+			StateRoot:  stateRoot,
+		}
+		err := bs.AddBlock(&types.Block{Header: *header, Body: sampleBlockBody})
+		require.NoError(t, err)
+		return header
+	}
+
+	fork := addBlock(testGenesisHeader, common.Hash{1})
+	best := testGenesisHeader
+	for i := 0; i < 3; i++ {
+		best = addBlock(best, common.Hash{})
+		require.NotNil(t, bs.unfinalisedBlocks.getBlock(fork.Hash()))
+	}
+
+	// the fork is now more than 2 blocks behind the best block
+	best = addBlock(best, common.Hash{})
+	require.Nil(t, bs.unfinalisedBlocks.getBlock(fork.Hash()))
+	require.Equal(t, []common.Hash{best.Hash()}, bs.Leaves())
+}
+
 func TestAddBlock_BlockNumberToHash(t *testing.T) {
 	bs := newTestBlockState(t, newTriesEmpty())
 	currChain, branchChains := AddBlocksToState(t, bs, 8, false)
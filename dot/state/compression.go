@@ -0,0 +1,350 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/klauspost/compress/zstd"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	// compressionDictionarySize is the maximum size of the raw content of the trained dictionary.
+	compressionDictionarySize = 64 * 1024
+	// compressionTrainingSamples is the maximum number of stored values sampled to train the dictionary.
+	compressionTrainingSamples = 2048
+	// compressionMinTrainingSamples is the number of samples below which no dictionary is
+	// trained, and the values are compressed without a dictionary.
+	compressionMinTrainingSamples = 64
+	// recompressionBatchSize is the number of values recompressed in a single batch.
+	recompressionBatchSize = 256
+)
+
+var (
+	compressedBlockBodyPrefix     = []byte("zbl") // compressedBlockBodyPrefix + hash -> compressed body
+	compressedJustificationPrefix = []byte("zjc") // compressedJustificationPrefix + hash -> compressed justification
+	compressionDictionaryKey      = []byte("zdc") // compressionDictionaryKey -> zstd dictionary of the chain
+
+	uncompressedBytesCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "gossamer_state_compression",
+		Name:      "uncompressed_bytes_total",
+		Help:      "total size of the block bodies and justifications before compression",
+	})
+	compressedBytesCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "gossamer_state_compression",
+		Name:      "compressed_bytes_total",
+		Help:      "total size of the block bodies and justifications after compression",
+	})
+	recompressedValuesCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "gossamer_state_compression",
+		Name:      "recompressed_values_total",
+		Help:      "total number of stored block bodies and justifications recompressed",
+	})
+)
+
+// blockDataCompression compresses the stored block bodies and justifications.
+// The compressed values are stored under their own prefix, so they can be told
+// apart from the values stored before compression was enabled.
+type blockDataCompression struct {
+	// lock serialises the writes of block data with the recompression,
+	// so a recompressed value never overwrites a more recent one.
+	lock    sync.Mutex
+	decoder *zstd.Decoder
+	// encoder is nil if the compression of new values is disabled, in
+	// which case the values compressed previously can still be read.
+	encoder *zstd.Encoder
+}
+
+// newBlockDataCompression returns a blockDataCompression reading the values
+// compressed with the dictionary stored in the database, if any.
+func newBlockDataCompression(db BlockStateDatabase) (*blockDataCompression, error) {
+	dictionary, err := db.Get(compressionDictionaryKey)
+	if err != nil && !errors.Is(err, database.ErrNotFound) {
+		return nil, fmt.Errorf("getting compression dictionary: %w", err)
+	}
+
+	decoder, err := newBlockDataDecoder(dictionary)
+	if err != nil {
+		return nil, err
+	}
+
+	return &blockDataCompression{decoder: decoder}, nil
+}
+
+func newBlockDataDecoder(dictionary []byte) (*zstd.Decoder, error) {
+	var options []zstd.DOption
+	if dictionary != nil {
+		options = append(options, zstd.WithDecoderDicts(dictionary))
+	}
+
+	decoder, err := zstd.NewReader(nil, options...)
+	if err != nil {
+		return nil, fmt.Errorf("creating zstd decoder: %w", err)
+	}
+	return decoder, nil
+}
+
+// enable enables the compression of new values, first training a dictionary on the
+// values stored in the database if none was trained yet.
+func (c *blockDataCompression) enable(db BlockStateDatabase, genesisHash common.Hash) error {
+	dictionary, err := db.Get(compressionDictionaryKey)
+	if errors.Is(err, database.ErrNotFound) {
+		dictionary, err = trainCompressionDictionary(db, genesisHash)
+		if err != nil {
+			return fmt.Errorf("training compression dictionary: %w", err)
+		}
+
+		if dictionary != nil {
+			err = c.setDictionary(db, dictionary)
+			if err != nil {
+				return err
+			}
+		}
+	} else if err != nil {
+		return fmt.Errorf("getting compression dictionary: %w", err)
+	}
+
+	options := []zstd.EOption{zstd.WithEncoderConcurrency(1)}
+	if dictionary != nil {
+		options = append(options, zstd.WithEncoderDict(dictionary))
+	}
+
+	c.encoder, err = zstd.NewWriter(nil, options...)
+	if err != nil {
+		return fmt.Errorf("creating zstd encoder: %w", err)
+	}
+	return nil
+}
+
+// setDictionary stores the trained dictionary and replaces the decoder with one using it.
+func (c *blockDataCompression) setDictionary(db BlockStateDatabase, dictionary []byte) error {
+	err := db.Put(compressionDictionaryKey, dictionary)
+	if err != nil {
+		return fmt.Errorf("storing compression dictionary: %w", err)
+	}
+
+	decoder, err := newBlockDataDecoder(dictionary)
+	if err != nil {
+		return err
+	}
+	c.decoder.Close()
+	c.decoder = decoder
+	return nil
+}
+
+func (c *blockDataCompression) compress(data []byte) []byte {
+	compressed := c.encoder.EncodeAll(data, nil)
+	uncompressedBytesCounter.Add(float64(len(data)))
+	compressedBytesCounter.Add(float64(len(compressed)))
+	return compressed
+}
+
+func (c *blockDataCompression) decompress(data []byte) ([]byte, error) {
+	decompressed, err := c.decoder.DecodeAll(data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("decompressing: %w", err)
+	}
+	return decompressed, nil
+}
+
+// trainCompressionDictionary trains a dictionary on a sample of the stored block bodies
+// and justifications, and returns nil if there are not enough of them to train one.
+// The dictionary id is derived from the genesis hash, so it is specific to the chain.
+func trainCompressionDictionary(db BlockStateDatabase, genesisHash common.Hash) ([]byte, error) {
+	var samples [][]byte
+	for _, prefix := range [][]byte{blockBodyPrefix, justificationPrefix} {
+		iterator, err := db.NewPrefixIterator(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("creating iterator: %w", err)
+		}
+
+		for valid := iterator.First(); valid && len(samples) < compressionTrainingSamples; valid = iterator.Next() {
+			samples = append(samples, bytes.Clone(iterator.Value()))
+		}
+		iterator.Release()
+	}
+
+	if len(samples) < compressionMinTrainingSamples {
+		return nil, nil
+	}
+
+	// the raw content of the dictionary is made of the last samples
+	start := len(samples)
+	for size := 0; start > 0 && size < compressionDictionarySize; start-- {
+		size += len(samples[start-1])
+	}
+	history := bytes.Join(samples[start:], nil)
+	if len(history) > compressionDictionarySize {
+		history = history[len(history)-compressionDictionarySize:]
+	}
+
+	id := binary.LittleEndian.Uint32(genesisHash[:4])
+	if id == 0 {
+		id = 1
+	}
+
+	dictionary, err := zstd.BuildDict(zstd.BuildDictOptions{
+		ID:       id,
+		Contents: samples,
+		History:  history,
+		Offsets:  [3]int{1, 4, 8},
+	})
+	if err != nil {
+		// the samples can be too small or too uniform to build a dictionary,
+		// in which case the values are compressed without one.
+		logger.Warnf("cannot build compression dictionary, compressing without one: %s", err)
+		return nil, nil
+	}
+	return dictionary, nil
+}
+
+// putBlockData stores the given block data under the raw key, or compressed
+// under the compressed key if compression is enabled, and deletes the value
+// stored under the other key.
+func (bs *BlockState) putBlockData(rawKey, compressedKey, data []byte) error {
+	if bs.compression == nil {
+		return bs.db.Put(rawKey, data)
+	}
+
+	bs.compression.lock.Lock()
+	defer bs.compression.lock.Unlock()
+
+	batch := bs.db.NewBatch()
+	defer batch.Close()
+
+	if bs.compression.encoder != nil {
+		err := batch.Put(compressedKey, bs.compression.compress(data))
+		if err != nil {
+			return err
+		}
+		err = batch.Del(rawKey)
+		if err != nil {
+			return err
+		}
+	} else {
+		err := batch.Put(rawKey, data)
+		if err != nil {
+			return err
+		}
+		err = batch.Del(compressedKey)
+		if err != nil {
+			return err
+		}
+	}
+
+	return batch.Flush()
+}
+
+// getBlockData returns the block data stored under the raw key, or else the
+// decompressed block data stored under the compressed key.
+func (bs *BlockState) getBlockData(rawKey, compressedKey []byte) ([]byte, error) {
+	data, err := bs.db.Get(rawKey)
+	if bs.compression == nil || !errors.Is(err, database.ErrNotFound) {
+		return data, err
+	}
+
+	data, err = bs.db.Get(compressedKey)
+	if err != nil {
+		return nil, err
+	}
+	return bs.compression.decompress(data)
+}
+
+// hasBlockData returns true if block data is stored under the raw or the compressed key.
+func hasBlockData(db database.Reader, rawKey, compressedKey []byte) (bool, error) {
+	has, err := db.Has(rawKey)
+	if has || err != nil {
+		return has, err
+	}
+	return db.Has(compressedKey)
+}
+
+// recompressBlockData compresses the block bodies and justifications stored before
+// compression was enabled, until all of them are compressed or done is closed.
+func (bs *BlockState) recompressBlockData(done <-chan interface{}) (recompressed uint64, err error) {
+	var iterator database.Iterator
+	for _, prefixes := range [][2][]byte{
+		{blockBodyPrefix, compressedBlockBodyPrefix},
+		{justificationPrefix, compressedJustificationPrefix},
+	} {
+		iterator, err = bs.db.NewPrefixIterator(prefixes[0])
+		if err != nil {
+			return recompressed, fmt.Errorf("creating iterator: %w", err)
+		}
+
+		hashes := make([]common.Hash, 0, recompressionBatchSize)
+		for valid := iterator.First(); valid; {
+			hashes = hashes[:0]
+			for ; valid && len(hashes) < recompressionBatchSize; valid = iterator.Next() {
+				key := iterator.Key()
+				hashes = append(hashes, common.BytesToHash(key[len(key)-common.HashLength:]))
+			}
+
+			select {
+			case <-done:
+				iterator.Release()
+				return recompressed, nil
+			default:
+			}
+
+			var n uint64
+			n, err = bs.recompressBatch(hashes, prefixes[0], prefixes[1])
+			recompressed += n
+			if err != nil {
+				iterator.Release()
+				return recompressed, err
+			}
+		}
+		iterator.Release()
+	}
+
+	return recompressed, nil
+}
+
+func (bs *BlockState) recompressBatch(hashes []common.Hash, rawPrefix, compressedPrefix []byte) (
+	recompressed uint64, err error) {
+	bs.compression.lock.Lock()
+	defer bs.compression.lock.Unlock()
+
+	batch := bs.db.NewBatch()
+	defer batch.Close()
+
+	for _, hash := range hashes {
+		rawKey := prefixKey(hash, rawPrefix)
+		// the value is read again under the lock, since it can have been
+		// rewritten since the iterator was created.
+		data, err := bs.db.Get(rawKey)
+		if errors.Is(err, database.ErrNotFound) {
+			continue
+		} else if err != nil {
+			return 0, fmt.Errorf("getting block data: %w", err)
+		}
+
+		err = batch.Put(prefixKey(hash, compressedPrefix), bs.compression.compress(data))
+		if err != nil {
+			return 0, err
+		}
+		err = batch.Del(rawKey)
+		if err != nil {
+			return 0, err
+		}
+		recompressed++
+	}
+
+	err = batch.Flush()
+	if err != nil {
+		return 0, fmt.Errorf("flushing batch: %w", err)
+	}
+
+	recompressedValuesCounter.Add(float64(recompressed))
+	return recompressed, nil
+}
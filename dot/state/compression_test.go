@@ -0,0 +1,111 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_BlockState_compression(t *testing.T) {
+	t.Parallel()
+
+	bs := newTestBlockState(t, newTriesEmpty())
+
+	const storedBefore = compressionMinTrainingSamples + 16
+	bodies := make(map[common.Hash]*types.Body, storedBefore+1)
+	justifications := make(map[common.Hash][]byte, storedBefore+1)
+	for i := 0; i < storedBefore; i++ {
+		hash := common.Hash{byte(i), 1}
+		bodies[hash] = types.NewBody([]types.Extrinsic{
+			[]byte(fmt.Sprintf("timestamp.set(%d)", 6000*i)),
+			[]byte(fmt.Sprintf("balances.transfer_keep_alive(alice, bob, %d)", i)),
+		})
+		justifications[hash] = []byte(fmt.Sprintf("justification of block %d, round %d", i, i/3))
+
+		require.NoError(t, bs.SetBlockBody(hash, bodies[hash]))
+		require.NoError(t, bs.SetJustification(hash, justifications[hash]))
+	}
+
+	require.NoError(t, bs.compression.enable(bs.db, bs.genesisHash))
+	has, err := bs.db.Has(compressionDictionaryKey)
+	require.NoError(t, err)
+	assert.True(t, has)
+
+	// values stored once compression is enabled are compressed
+	hash := common.Hash{0xff}
+	bodies[hash] = types.NewBody([]types.Extrinsic{[]byte("timestamp.set(1)")})
+	justifications[hash] = []byte("justification of block 255")
+	require.NoError(t, bs.SetBlockBody(hash, bodies[hash]))
+	require.NoError(t, bs.SetJustification(hash, justifications[hash]))
+	has, err = bs.db.Has(blockBodyKey(hash))
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	recompressed, err := bs.recompressBlockData(make(chan interface{}))
+	require.NoError(t, err)
+	// the genesis body is recompressed too
+	assert.Equal(t, uint64(2*storedBefore+1), recompressed)
+
+	for hash, body := range bodies {
+		has, err := bs.db.Has(blockBodyKey(hash))
+		require.NoError(t, err)
+		assert.False(t, has)
+
+		has, err = bs.HasBlockBody(hash)
+		require.NoError(t, err)
+		assert.True(t, has)
+		storedBody, err := bs.GetBlockBody(hash)
+		require.NoError(t, err)
+		assert.Equal(t, body, storedBody)
+
+		has, err = bs.HasJustification(hash)
+		require.NoError(t, err)
+		assert.True(t, has)
+		justification, err := bs.GetJustification(hash)
+		require.NoError(t, err)
+		assert.Equal(t, justifications[hash], justification)
+	}
+
+	// once compression is disabled again, the compressed values are still read
+	// and the justifications rewritten replace the compressed ones.
+	compression, err := newBlockDataCompression(bs.db)
+	require.NoError(t, err)
+	bs.compression = compression
+
+	storedBody, err := bs.GetBlockBody(hash)
+	require.NoError(t, err)
+	assert.Equal(t, bodies[hash], storedBody)
+
+	require.NoError(t, bs.SetJustification(hash, []byte("new justification")))
+	justification, err := bs.GetJustification(hash)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("new justification"), justification)
+	has, err = bs.db.Has(prefixKey(hash, compressedJustificationPrefix))
+	require.NoError(t, err)
+	assert.False(t, has)
+}
+
+func Test_trainCompressionDictionary_notEnoughSamples(t *testing.T) {
+	t.Parallel()
+
+	bs := newTestBlockState(t, newTriesEmpty())
+
+	dictionary, err := trainCompressionDictionary(bs.db, bs.genesisHash)
+	require.NoError(t, err)
+	assert.Nil(t, dictionary)
+
+	// values are then compressed without a dictionary
+	require.NoError(t, bs.compression.enable(bs.db, bs.genesisHash))
+	hash := common.Hash{1}
+	require.NoError(t, bs.SetJustification(hash, []byte("justification")))
+	justification, err := bs.GetJustification(hash)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("justification"), justification)
+}
@@ -0,0 +1,160 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"bytes"
+	"container/heap"
+	"fmt"
+	"sort"
+
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/trie/node"
+)
+
+// DefaultLargestKeys is the default number of largest keys reported in the database statistics.
+const DefaultLargestKeys = 10
+
+// otherColumn is the column of the keys not prefixed by a table prefix.
+const otherColumn = "other"
+
+// databaseColumns are the table prefixes of the database, including the
+// offline storage table of the runtime created by the node builder.
+var databaseColumns = []string{
+	blockPrefix,
+	epochPrefix,
+	grandpaPrefix,
+	"offlinestorage",
+	storagePrefix,
+	slotTablePrefix,
+}
+
+// DatabaseStats are statistics of the content of the database.
+type DatabaseStats struct {
+	Columns []ColumnStats `json:"columns"`
+	// TrieNodes is the number of trie nodes of the storage column by node variant.
+	// Storage values stored by hash are counted as the variant matching their first byte,
+	// and entries which are not valid node encodings are counted as Unknown.
+	TrieNodes   map[string]uint64        `json:"trieNodes"`
+	LargestKeys []KeyStats               `json:"largestKeys"`
+	Compaction  database.CompactionStats `json:"compaction"`
+}
+
+// ColumnStats are the statistics of a column of the database.
+type ColumnStats struct {
+	Name       string `json:"name"`
+	Keys       uint64 `json:"keys"`
+	KeyBytes   uint64 `json:"keyBytes"`
+	ValueBytes uint64 `json:"valueBytes"`
+}
+
+// KeyStats are the statistics of a database entry.
+type KeyStats struct {
+	Column string `json:"column"`
+	Key    string `json:"key"`
+	Size   uint64 `json:"size"`
+}
+
+// DatabaseStats returns the statistics of the database of the node, reporting
+// the given number of largest keys. It iterates over all the database entries.
+func (s *Service) DatabaseStats(largestKeys int) (*DatabaseStats, error) {
+	return CollectDatabaseStats(s.db, largestKeys)
+}
+
+// CollectDatabaseStats iterates over all the entries of the database to collect
+// its statistics, reporting the given number of largest keys.
+func CollectDatabaseStats(db database.Database, largestKeys int) (*DatabaseStats, error) {
+	iterator, err := db.NewIterator()
+	if err != nil {
+		return nil, fmt.Errorf("creating database iterator: %w", err)
+	}
+	defer iterator.Release()
+
+	columns := make(map[string]*ColumnStats, len(databaseColumns)+1)
+	trieNodes := make(map[string]uint64)
+	largest := &keyStatsHeap{}
+
+	for valid := iterator.First(); valid; valid = iterator.Next() {
+		key, value := iterator.Key(), iterator.Value()
+		column := databaseColumn(key)
+
+		columnStats, ok := columns[column]
+		if !ok {
+			columnStats = &ColumnStats{Name: column}
+			columns[column] = columnStats
+		}
+		columnStats.Keys++
+		columnStats.KeyBytes += uint64(len(key))
+		columnStats.ValueBytes += uint64(len(value))
+
+		if column == storagePrefix {
+			variant := "Unknown"
+			if len(value) > 0 {
+				name, err := node.VariantName(value[0])
+				if err == nil {
+					variant = name
+				}
+			}
+			trieNodes[variant]++
+		}
+
+		size := uint64(len(key) + len(value))
+		if largestKeys > 0 && (largest.Len() < largestKeys || size > (*largest)[0].Size) {
+			heap.Push(largest, KeyStats{
+				Column: column,
+				Key:    common.BytesToHex(key),
+				Size:   size,
+			})
+			if largest.Len() > largestKeys {
+				heap.Pop(largest)
+			}
+		}
+	}
+
+	stats := &DatabaseStats{
+		Columns:     make([]ColumnStats, 0, len(columns)),
+		TrieNodes:   trieNodes,
+		LargestKeys: append([]KeyStats{}, *largest...),
+		Compaction:  db.CompactionStats(),
+	}
+	for _, columnStats := range columns {
+		stats.Columns = append(stats.Columns, *columnStats)
+	}
+	sort.Slice(stats.Columns, func(i, j int) bool {
+		return stats.Columns[i].Name < stats.Columns[j].Name
+	})
+	sort.Slice(stats.LargestKeys, func(i, j int) bool {
+		return stats.LargestKeys[i].Size > stats.LargestKeys[j].Size
+	})
+	return stats, nil
+}
+
+func databaseColumn(key []byte) string {
+	for _, column := range databaseColumns {
+		if bytes.HasPrefix(key, []byte(column)) {
+			return column
+		}
+	}
+	return otherColumn
+}
+
+// keyStatsHeap is a min heap of key statistics by size.
+type keyStatsHeap []KeyStats
+
+func (h keyStatsHeap) Len() int           { return len(h) }
+func (h keyStatsHeap) Less(i, j int) bool { return h[i].Size < h[j].Size }
+func (h keyStatsHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *keyStatsHeap) Push(x any) {
+	*h = append(*h, x.(KeyStats))
+}
+
+func (h *keyStatsHeap) Pop() any {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	*h = old[:n-1]
+	return item
+}
@@ -0,0 +1,58 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectDatabaseStats(t *testing.T) {
+	t.Parallel()
+
+	db, err := database.NewPebble(t.TempDir(), true)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+
+	entries := map[string][]byte{
+		"block" + "hdr1":   make([]byte, 10),
+		"block" + "hdr2":   make([]byte, 100),
+		"storage" + "leaf": {0b0100_0001, 1},
+		"storage" + "brch": {0b1000_0001, 1},
+		"storage" + "bad":  {0b0000_1000},
+		"genesis_data":     make([]byte, 50),
+	}
+	for key, value := range entries {
+		err = db.Put([]byte(key), value)
+		require.NoError(t, err)
+	}
+
+	stats, err := CollectDatabaseStats(db, 2)
+	require.NoError(t, err)
+
+	expectedColumns := []ColumnStats{
+		{Name: "block", Keys: 2, KeyBytes: 18, ValueBytes: 110},
+		{Name: "other", Keys: 1, KeyBytes: 12, ValueBytes: 50},
+		{Name: "storage", Keys: 3, KeyBytes: 32, ValueBytes: 5},
+	}
+	assert.Equal(t, expectedColumns, stats.Columns)
+
+	expectedTrieNodes := map[string]uint64{
+		"Leaf":    1,
+		"Branch":  1,
+		"Unknown": 1,
+	}
+	assert.Equal(t, expectedTrieNodes, stats.TrieNodes)
+
+	expectedLargestKeys := []KeyStats{
+		{Column: "block", Key: "0x626c6f636b68647232", Size: 109},
+		{Column: "other", Key: "0x67656e657369735f64617461", Size: 62},
+	}
+	assert.Equal(t, expectedLargestKeys, stats.LargestKeys)
+}
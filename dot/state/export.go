@@ -0,0 +1,236 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	wazero_runtime "github.com/ChainSafe/gossamer/lib/runtime/wazero"
+	"github.com/ChainSafe/gossamer/lib/storagedecoder"
+	"github.com/ChainSafe/gossamer/lib/txbuilder"
+	"github.com/ChainSafe/gossamer/pkg/trie"
+	ctypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// StateExportOptions are the options of a state export.
+type StateExportOptions struct {
+	// BlockHash is the hash of the block to export the state of,
+	// defaulting to the highest finalised block if nil.
+	BlockHash *common.Hash
+	// Cursor is the storage key after which the export starts, as returned
+	// by a previous export. The export starts from the first key if it is empty.
+	Cursor []byte
+	// Limit is the maximum number of entries to export, with 0 meaning no limit.
+	Limit uint64
+	// Metadata is used to decode the storage entries. If nil, the metadata
+	// is read from the runtime stored in the exported state.
+	Metadata *ctypes.Metadata
+	// Raw disables the decoding of the storage entries.
+	Raw bool
+}
+
+// StateExportRecord is an exported storage entry, written as a JSON line.
+// The decoded entry is nil if the export is raw or if the entry cannot be
+// decoded, in which case the raw value is exported along with the decoding error.
+type StateExportRecord struct {
+	Key string `json:"key"`
+	*storagedecoder.Entry
+	Raw   string `json:"raw,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// StateExportResult describes a state export.
+type StateExportResult struct {
+	BlockHash common.Hash
+	StateRoot common.Hash
+	// Entries is the number of entries exported.
+	Entries uint64
+	// Cursor is the last exported key, to resume the export from.
+	Cursor []byte
+	// Complete is true if the export reached the last key of the state.
+	Complete bool
+}
+
+// ExportState writes the storage entries of the state at a block of the database
+// found in the given base path to w, as JSON lines in lexicographic key order.
+// The node must not be running while the state is exported.
+func ExportState(basePath string, options StateExportOptions, w io.Writer) (result StateExportResult, err error) {
+	db, err := database.LoadDatabase(basePath, false)
+	if err != nil {
+		return result, fmt.Errorf("loading database: %w", err)
+	}
+	defer func() {
+		closeErr := db.Close()
+		if err == nil && closeErr != nil {
+			err = fmt.Errorf("closing database: %w", closeErr)
+		}
+	}()
+
+	t, header, err := loadStateTrie(db, options.BlockHash)
+	if err != nil {
+		return result, err
+	}
+	result.BlockHash = header.Hash()
+	result.StateRoot = header.StateRoot
+
+	var decoder *storagedecoder.Decoder
+	if !options.Raw {
+		metadata := options.Metadata
+		if metadata == nil {
+			metadata, err = runtimeMetadata(t)
+			if err != nil {
+				return result, fmt.Errorf("getting runtime metadata: %w", err)
+			}
+		}
+
+		decoder, err = storagedecoder.NewDecoder(metadata)
+		if err != nil {
+			return result, fmt.Errorf("creating storage decoder: %w", err)
+		}
+	}
+
+	result.Entries, result.Cursor, result.Complete, err = exportTrie(t, decoder, options.Cursor, options.Limit, w)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// WalkState calls fn with the storage entries of the state at a block of the database
+// found in the given base path, in lexicographic key order, and returns the hash of
+// the block. The block defaults to the highest finalised block if the block hash is nil.
+// The node must not be running while the state is walked.
+func WalkState(basePath string, blockHash *common.Hash, fn func(key, value []byte) error) (
+	hash common.Hash, err error) {
+	db, err := database.LoadDatabase(basePath, false)
+	if err != nil {
+		return hash, fmt.Errorf("loading database: %w", err)
+	}
+	defer func() {
+		closeErr := db.Close()
+		if err == nil && closeErr != nil {
+			err = fmt.Errorf("closing database: %w", closeErr)
+		}
+	}()
+
+	t, header, err := loadStateTrie(db, blockHash)
+	if err != nil {
+		return hash, err
+	}
+
+	for key := range t.KeysFrom(nil) {
+		err = fn(key, t.Get(key))
+		if err != nil {
+			return hash, err
+		}
+	}
+	return header.Hash(), nil
+}
+
+// loadStateTrie loads the state trie at a block of the database, defaulting to
+// the highest finalised block if the block hash is nil, and returns it with the
+// header of the block.
+func loadStateTrie(db database.Database, blockHash *common.Hash) (trie.Trie, *types.Header, error) {
+	tries := NewTries()
+	tries.SetEmptyTrie()
+	blockState, err := NewBlockState(db, tries, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating block state: %w", err)
+	}
+
+	var hash common.Hash
+	if blockHash != nil {
+		hash = *blockHash
+	} else {
+		hash, err = blockState.GetHighestFinalisedHash()
+		if err != nil {
+			return nil, nil, fmt.Errorf("getting highest finalised hash: %w", err)
+		}
+	}
+
+	header, err := blockState.GetHeader(hash)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting header of block %s: %w", hash, err)
+	}
+
+	storageState, err := NewStorageState(db, blockState, tries)
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating storage state: %w", err)
+	}
+
+	t, err := storageState.LoadFromDB(header.StateRoot)
+	if err != nil {
+		return nil, nil, fmt.Errorf("loading state trie: %w", err)
+	}
+	return t, header, nil
+}
+
+// runtimeMetadata returns the metadata of the runtime stored in the trie.
+func runtimeMetadata(t trie.Trie) (*ctypes.Metadata, error) {
+	instance, err := wazero_runtime.NewInstanceFromTrie(t, wazero_runtime.Config{
+		Storage: rtstorage.NewTrieState(t),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating runtime instance: %w", err)
+	}
+	defer instance.Stop()
+
+	rawMetadata, err := instance.Metadata()
+	if err != nil {
+		return nil, fmt.Errorf("calling runtime metadata: %w", err)
+	}
+
+	return txbuilder.DecodeMetadata(rawMetadata)
+}
+
+// exportTrie writes the entries of the trie found after the cursor to w, up to
+// the limit if it is not zero. A nil decoder exports raw entries.
+func exportTrie(t trie.TrieRead, decoder *storagedecoder.Decoder, cursor []byte, limit uint64, w io.Writer) (
+	entries uint64, last []byte, complete bool, err error) {
+	writer := bufio.NewWriter(w)
+	encoder := json.NewEncoder(writer)
+
+	last = cursor
+	complete = true
+	for key := range t.KeysFrom(cursor) {
+		if limit > 0 && entries == limit {
+			complete = false
+			break
+		}
+
+		value := t.Get(key)
+		record := StateExportRecord{Key: common.BytesToHex(key)}
+		if decoder == nil {
+			record.Raw = common.BytesToHex(value)
+		} else {
+			entry, err := decoder.Decode(key, value)
+			if err != nil {
+				record.Raw = common.BytesToHex(value)
+				record.Error = err.Error()
+			} else {
+				record.Entry = &entry
+			}
+		}
+
+		err = encoder.Encode(record)
+		if err != nil {
+			return entries, last, false, fmt.Errorf("encoding state entry: %w", err)
+		}
+		entries++
+		last = key
+	}
+
+	err = writer.Flush()
+	if err != nil {
+		return entries, last, false, fmt.Errorf("writing state entries: %w", err)
+	}
+	return entries, last, complete, nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/storagedecoder"
+	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+	ctypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readExportRecords(t *testing.T, buffer *bytes.Buffer) (records []StateExportRecord) {
+	t.Helper()
+
+	for _, line := range strings.Split(strings.TrimSpace(buffer.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var record StateExportRecord
+		err := json.Unmarshal([]byte(line), &record)
+		require.NoError(t, err)
+		records = append(records, record)
+	}
+	return records
+}
+
+func Test_exportTrie(t *testing.T) {
+	t.Parallel()
+
+	tr := inmemory_trie.NewEmptyTrie()
+	for _, key := range []string{"a", "b", "c"} {
+		err := tr.Put([]byte(key), []byte("value_"+key))
+		require.NoError(t, err)
+	}
+
+	var buffer bytes.Buffer
+	entries, cursor, complete, err := exportTrie(tr, nil, nil, 2, &buffer)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), entries)
+	assert.Equal(t, []byte("b"), cursor)
+	assert.False(t, complete)
+
+	expected := []StateExportRecord{
+		{Key: "0x61", Raw: "0x76616c75655f61"},
+		{Key: "0x62", Raw: "0x76616c75655f62"},
+	}
+	assert.Equal(t, expected, readExportRecords(t, &buffer))
+
+	buffer.Reset()
+	entries, cursor, complete, err = exportTrie(tr, nil, cursor, 2, &buffer)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), entries)
+	assert.Equal(t, []byte("c"), cursor)
+	assert.True(t, complete)
+
+	expected = []StateExportRecord{
+		{Key: "0x63", Raw: "0x76616c75655f63"},
+	}
+	assert.Equal(t, expected, readExportRecords(t, &buffer))
+}
+
+func Test_exportTrie_undecodable(t *testing.T) {
+	t.Parallel()
+
+	tr := inmemory_trie.NewEmptyTrie()
+	err := tr.Put([]byte(":code"), []byte{1})
+	require.NoError(t, err)
+
+	decoder, err := storagedecoder.NewDecoder(&ctypes.Metadata{Version: 14})
+	require.NoError(t, err)
+
+	var buffer bytes.Buffer
+	entries, cursor, complete, err := exportTrie(tr, decoder, nil, 0, &buffer)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), entries)
+	assert.Equal(t, []byte(":code"), cursor)
+	assert.True(t, complete)
+
+	records := readExportRecords(t, &buffer)
+	require.Len(t, records, 1)
+	assert.Equal(t, "0x3a636f6465", records[0].Key)
+	assert.Nil(t, records[0].Entry)
+	assert.Equal(t, "0x01", records[0].Raw)
+	assert.Contains(t, records[0].Error, storagedecoder.ErrUnknownKey.Error())
+}
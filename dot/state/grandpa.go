@@ -9,6 +9,7 @@
 	"fmt"
 	"slices"
 	"strconv"
+	"sync"
 
 	"github.com/ChainSafe/gossamer/dot/telemetry"
 	"github.com/ChainSafe/gossamer/dot/types"
@@ -44,6 +45,9 @@ type GrandpaState struct {
 	forcedChanges        *orderedPendingChanges
 	scheduledChangeRoots *changeTree
 	telemetry            Telemetry
+
+	// voteLockMutex serialises checking and persisting the vote locks
+	voteLockMutex sync.Mutex
 }
 
 // NewGrandpaStateFromGenesis returns a new GrandpaState given the grandpa genesis authorities
@@ -179,28 +183,28 @@ func (s *GrandpaState) ApplyScheduledChanges(finalizedHeader *types.Header) erro
 		return nil
 	}
 
-	logger.Debugf("applying scheduled change: %s", changeToApply.change)
+	logger.Debugf("applying scheduled change: %s", changeToApply.Data)
 
 	newSetID, err := s.IncrementSetID()
 	if err != nil {
 		return fmt.Errorf("cannot increment set id: %w", err)
 	}
 
-	grandpaVotersAuthorities := types.NewGrandpaVotersFromAuthorities(changeToApply.change.nextAuthorities)
+	grandpaVotersAuthorities := types.NewGrandpaVotersFromAuthorities(changeToApply.Data.nextAuthorities)
 	err = s.setAuthorities(newSetID, grandpaVotersAuthorities)
 	if err != nil {
 		return fmt.Errorf("cannot set authorities: %w", err)
 	}
 
-	err = s.setChangeSetIDAtBlock(newSetID, changeToApply.change.effectiveNumber())
+	err = s.setChangeSetIDAtBlock(newSetID, changeToApply.Data.effectiveNumber())
 	if err != nil {
 		return fmt.Errorf("cannot set the change set id at block: %w", err)
 	}
 
 	logger.Debugf("Applying authority set change scheduled at block #%d",
-		changeToApply.change.announcingHeader.Number)
+		changeToApply.Data.announcingHeader.Number)
 
-	canonHeightString := strconv.FormatUint(uint64(changeToApply.change.announcingHeader.Number), 10)
+	canonHeightString := strconv.FormatUint(uint64(changeToApply.Data.announcingHeader.Number), 10)
 	s.telemetry.SendMessage(telemetry.NewAfgApplyingScheduledAuthoritySetChange(
 		canonHeightString,
 	))
@@ -223,17 +227,17 @@ func (s *GrandpaState) ApplyForcedChanges(importedBlockHeader *types.Header) err
 	bestFinalizedNumber := forcedChange.bestFinalizedNumber
 
 	dependant, err := s.scheduledChangeRoots.lookupChangeWhere(func(pcn *pendingChangeNode) (bool, error) {
-		if pcn.change.effectiveNumber() > uint(bestFinalizedNumber) {
+		if pcn.Data.effectiveNumber() > uint(bestFinalizedNumber) {
 			return false, nil
 		}
 
-		scheduledBlockHash := pcn.change.announcingHeader.Hash()
+		scheduledBlockHash := pcn.Data.announcingHeader.Hash()
 		return s.blockState.IsDescendantOf(scheduledBlockHash, forcedChangeHash)
 	})
 	if err != nil {
 		return fmt.Errorf("cannot check pending changes while applying forced change: %w", err)
 	} else if dependant != nil {
-		return fmt.Errorf("%w: %s", errPendingScheduledChanges, dependant.change)
+		return fmt.Errorf("%w: %s", errPendingScheduledChanges, dependant.Data)
 	}
 
 	logger.Debugf("Applying authority set forced change: %s", forcedChange)
@@ -294,12 +298,12 @@ func (s *GrandpaState) NextGrandpaAuthorityChange(bestBlockHash common.Hash, bes
 	}
 
 	scheduledChangeNode, err := s.scheduledChangeRoots.lookupChangeWhere(func(pcn *pendingChangeNode) (bool, error) {
-		isDecendant, err := s.blockState.IsDescendantOf(pcn.change.announcingHeader.Hash(), bestBlockHash)
+		isDecendant, err := s.blockState.IsDescendantOf(pcn.Data.announcingHeader.Hash(), bestBlockHash)
 		if err != nil {
 			return false, fmt.Errorf("cannot check ancestry: %w", err)
 		}
 
-		return isDecendant && pcn.change.effectiveNumber() <= bestBlockNumber, nil
+		return isDecendant && pcn.Data.effectiveNumber() <= bestBlockNumber, nil
 	})
 	if err != nil {
 		return 0, fmt.Errorf("cannot get forced change on chain of %s: %w",
@@ -308,7 +312,7 @@ func (s *GrandpaState) NextGrandpaAuthorityChange(bestBlockHash common.Hash, bes
 
 	var next uint
 	if scheduledChangeNode != nil {
-		next = scheduledChangeNode.change.effectiveNumber()
+		next = scheduledChangeNode.Data.effectiveNumber()
 	}
 
 	if forcedChange != nil && (forcedChange.effectiveNumber() < next || next == 0) {
@@ -496,6 +500,76 @@ func (s *GrandpaState) GetSetIDByBlockNumber(blockNumber uint) (uint64, error) {
 	}
 }
 
+// AuthoritiesAt returns the id and the voters of the GRANDPA authority set in effect at
+// the block, which signs its justification, for any block retained by the block state.
+// The set of a finalised block is resolved from the set id changes applied. The set of a
+// block which is not finalised yet also follows the scheduled changes announced in its
+// ancestry and enacted before it, which are only applied once they are finalised.
+func (s *GrandpaState) AuthoritiesAt(blockHash common.Hash) (setID uint64, voters []types.GrandpaVoter, err error) {
+	header, err := s.blockState.GetHeader(blockHash)
+	if err != nil {
+		return 0, nil, fmt.Errorf("getting header: %w", err)
+	}
+
+	setID, err = s.GetSetIDByBlockNumber(header.Number)
+	if err != nil {
+		return 0, nil, fmt.Errorf("getting set id of block number %d: %w", header.Number, err)
+	}
+
+	finalisedHeader, err := s.blockState.GetHighestFinalisedHeader()
+	if err != nil {
+		return 0, nil, fmt.Errorf("getting highest finalised header: %w", err)
+	}
+
+	if header.Number > finalisedHeader.Number {
+		change, enacted, err := s.enactedScheduledChange(blockHash, header.Number)
+		if err != nil {
+			return 0, nil, fmt.Errorf("getting scheduled changes enacted: %w", err)
+		}
+		if change != nil {
+			return setID + enacted, types.NewGrandpaVotersFromAuthorities(change.nextAuthorities), nil
+		}
+	}
+
+	voters, err = s.GetAuthorities(setID)
+	if err != nil {
+		return 0, nil, fmt.Errorf("getting authorities of set id %d: %w", setID, err)
+	}
+	return setID, voters, nil
+}
+
+// enactedScheduledChange walks the scheduled changes announced in the ancestry of the
+// block and enacted before it, and returns the last one with the number of changes walked.
+// A change is enacted at its effective number, so it applies to the blocks after it.
+func (s *GrandpaState) enactedScheduledChange(blockHash common.Hash, blockNumber uint) (
+	change *pendingChange, enacted uint64, err error) {
+	nodes := s.scheduledChangeRoots.roots()
+	for {
+		var next *pendingChangeNode
+		for _, node := range nodes {
+			if node.Data.effectiveNumber() >= blockNumber {
+				continue
+			}
+
+			isDescendant, err := s.blockState.IsDescendantOf(node.Hash, blockHash)
+			if err != nil {
+				return nil, 0, fmt.Errorf("cannot check ancestry: %w", err)
+			}
+			if isDescendant {
+				next = node
+				break
+			}
+		}
+
+		if next == nil {
+			return change, enacted, nil
+		}
+		change = next.Data
+		enacted++
+		nodes = next.Children
+	}
+}
+
 // SetNextPause sets the next grandpa pause at the given block number
 func (s *GrandpaState) SetNextPause(number uint) error {
 	value := common.UintToBytes(number)
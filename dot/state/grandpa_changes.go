@@ -5,10 +5,12 @@
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/forktree"
 )
 
 type conditionFunc[T any] func(T) (bool, error)
@@ -136,189 +138,102 @@ func (oc *orderedPendingChanges) pruneAll() {
 	*oc = make([]pendingChange, 0, oc.Len())
 }
 
-type pendingChangeNode struct {
-	change *pendingChange
-	nodes  []*pendingChangeNode
-}
-
-// importNode method is called recursivelly until we found a node that import the pending change as one of
-// its children. The node which should import the pending change must be a ancestor with a
-// lower block number than the pending change.
-func (c *pendingChangeNode) importNode(blockHash common.Hash, blockNumber uint, pendingChange *pendingChange,
-	isDescendantOf isDescendantOfFunc) (imported bool, err error) {
-	announcingHash := c.change.announcingHeader.Hash()
-
-	if blockHash == announcingHash {
-		return false, fmt.Errorf("%w: %s", errDuplicateHashes, blockHash)
-	}
-
-	isDescendant, err := isDescendantOf(announcingHash, blockHash)
-	if err != nil {
-		return false, fmt.Errorf("cannot check ancestry: %w", err)
-	}
-
-	if !isDescendant {
-		return false, nil
-	}
+// pendingChangeNode is a scheduled change node of the change tree
+type pendingChangeNode = forktree.Node[common.Hash, uint, *pendingChange]
 
-	if blockNumber <= c.change.announcingHeader.Number {
-		return false, nil
-	}
+// changeTree keeps track of the scheduled changes per fork, the
+// change nodes being placed by descendency order and number
+type changeTree struct {
+	tree forktree.ForkTree[common.Hash, uint, *pendingChange]
+}
 
-	for _, childrenNodes := range c.nodes {
-		imported, err := childrenNodes.importNode(blockHash, blockNumber, pendingChange, isDescendantOf)
-		if err != nil {
-			return false, err
-		}
+func (ct *changeTree) Len() int { return ct.tree.Len() }
 
-		if imported {
-			return true, nil
-		}
-	}
-
-	childrenNode := &pendingChangeNode{change: pendingChange}
-	c.nodes = append(c.nodes, childrenNode)
-	return true, nil
+// roots returns the root change nodes of the tree
+func (ct *changeTree) roots() []*pendingChangeNode {
+	return slices.Collect(ct.tree.Roots())
 }
 
-// changeTree keeps track of the changes per fork allowing
-// n forks in the same structure, this structure is intended
-// to be an acyclic directed graph where the change nodes are
-// placed by descendency order and number, you can ensure an
-// node ancestry using the `isDescendantOfFunc`
-type changeTree []*pendingChangeNode
-
-func (ct *changeTree) Len() int { return len(*ct) }
 func (ct *changeTree) importChange(pendingChange *pendingChange, isDescendantOf isDescendantOfFunc) error {
-	for _, root := range *ct {
-		imported, err := root.importNode(pendingChange.announcingHeader.Hash(),
-			pendingChange.announcingHeader.Number, pendingChange, isDescendantOf)
-
-		if err != nil {
-			return err
-		}
-
-		if imported {
-			logger.Debugf("changes on header %s (%d) imported successfully",
-				pendingChange.announcingHeader.Hash(), pendingChange.announcingHeader.Number)
-			return nil
-		}
-	}
-
-	pendingChangeNode := &pendingChangeNode{
-		change: pendingChange,
+	announcingHash := pendingChange.announcingHeader.Hash()
+	err := ct.tree.Import(announcingHash, pendingChange.announcingHeader.Number, pendingChange,
+		forktree.IsDescendantOf[common.Hash](isDescendantOf))
+	if err != nil {
+		return err
 	}
 
-	*ct = append(*ct, pendingChangeNode)
+	logger.Debugf("changes on header %s (%d) imported successfully",
+		announcingHash, pendingChange.announcingHeader.Number)
 	return nil
 }
 
-// lookupChangesWhere returns the first change which satisfy the
+// lookupChangesWhere returns the first root change which satisfy the
 // condition whithout modify the current state of the change tree
 func (ct *changeTree) lookupChangeWhere(condition conditionFunc[*pendingChangeNode]) (
 	changeNode *pendingChangeNode, err error) {
-	for _, root := range *ct {
-		ok, err := condition(root)
-		if err != nil {
-			return nil, fmt.Errorf("failed while applying condition: %w", err)
-		}
-
-		if ok {
-			return root, nil
-		}
+	changeNode, err = ct.tree.FindRoot(condition)
+	if err != nil {
+		return nil, fmt.Errorf("failed while applying condition: %w", err)
 	}
 
-	return nil, nil
+	return changeNode, nil
 }
 
-// findApplicable try to retrieve an applicable change
-// from the tree, if it finds a change node then it will update the
-// tree roots with the change node's children otherwise it will
-// prune nodes that does not belongs to the same chain as `hash` argument
+// findApplicable try to retrieve an applicable change from the tree, if it finds
+// a change node then it will update the tree roots with the change node's children.
+// The tree is then pruned of the changes which are not descendants of the `hash` argument,
+// including the children of the applied change which were announced on other forks:
+// these could only be applied once their fork is finalized, which can no longer happen.
 func (ct *changeTree) findApplicable(hash common.Hash, number uint,
 	isDescendantOf isDescendantOfFunc) (changeNode *pendingChangeNode, err error) {
-
-	changeNode, err = ct.findApplicableChange(hash, number, isDescendantOf)
+	changeNode, err = ct.tree.FinalizeRoot(hash, forktree.IsDescendantOf[common.Hash](isDescendantOf),
+		func(pcn *pendingChangeNode) (bool, error) {
+			return isApplicableChange(pcn, hash, number, isDescendantOf)
+		})
 	if err != nil {
-		return nil, err
-	}
-
-	if changeNode == nil {
-		err := ct.pruneChanges(hash, isDescendantOf)
-		if err != nil {
-			return nil, fmt.Errorf("cannot prune changes: %w", err)
-		}
-	} else {
-		*ct = make([]*pendingChangeNode, len(changeNode.nodes))
-		copy(*ct, changeNode.nodes)
+		return nil, fmt.Errorf("failed while applying condition: %w", err)
 	}
 
 	return changeNode, nil
 }
 
-// findApplicableChange iterates through the change tree
-// roots looking for the change node which:
+// isApplicableChange returns true if the change node:
 // 1. contains the same hash as the one we're looking for.
 // 2. contains a lower or equal effective number as the one we're looking for.
 // 3. does not contains pending changes to be applied.
-func (ct *changeTree) findApplicableChange(hash common.Hash, number uint,
-	isDescendantOf isDescendantOfFunc) (changeNode *pendingChangeNode, err error) {
-	return ct.lookupChangeWhere(func(pcn *pendingChangeNode) (bool, error) {
-		if pcn.change.effectiveNumber() > number {
-			return false, nil
-		}
-
-		changeNodeHash := pcn.change.announcingHeader.Hash()
-		if hash != changeNodeHash {
-			isDescendant, err := isDescendantOf(changeNodeHash, hash)
-			if err != nil {
-				return false, fmt.Errorf("cannot verify ancestry: %w", err)
-			}
+func isApplicableChange(pcn *pendingChangeNode, hash common.Hash, number uint,
+	isDescendantOf isDescendantOfFunc) (bool, error) {
+	if pcn.Data.effectiveNumber() > number {
+		return false, nil
+	}
 
-			if !isDescendant {
-				return false, nil
-			}
+	if hash != pcn.Hash {
+		isDescendant, err := isDescendantOf(pcn.Hash, hash)
+		if err != nil {
+			return false, fmt.Errorf("cannot verify ancestry: %w", err)
 		}
 
-		// the changes must be applied in order, so we need to check if our finalized header
-		// is ahead of any children, if it is that means some previous change was not applied
-		for _, child := range pcn.nodes {
-			isDescendant, err := isDescendantOf(child.change.announcingHeader.Hash(), hash)
-			if err != nil {
-				return false, fmt.Errorf("cannot verify ancestry: %w", err)
-			}
-
-			if child.change.announcingHeader.Number <= number && isDescendant {
-				return false, errUnfinalizedAncestor
-			}
+		if !isDescendant {
+			return false, nil
 		}
+	}
 
-		return true, nil
-	})
-}
-
-// pruneChanges will remove changes whose are not descendant of the hash argument
-// this function updates the current state of the change tree
-func (ct *changeTree) pruneChanges(hash common.Hash, isDescendantOf isDescendantOfFunc) error {
-	var onBranchChanges []*pendingChangeNode
-
-	for _, root := range *ct {
-		scheduledChangeHash := root.change.announcingHeader.Hash()
-
-		isDescendant, err := isDescendantOf(hash, scheduledChangeHash)
+	// the changes must be applied in order, so we need to check if our finalized header
+	// is ahead of any children, if it is that means some previous change was not applied
+	for _, child := range pcn.Children {
+		isDescendant, err := isDescendantOf(child.Hash, hash)
 		if err != nil {
-			return fmt.Errorf("cannot verify ancestry: %w", err)
+			return false, fmt.Errorf("cannot verify ancestry: %w", err)
 		}
 
-		if isDescendant {
-			onBranchChanges = append(onBranchChanges, root)
+		if child.Number <= number && isDescendant {
+			return false, errUnfinalizedAncestor
 		}
 	}
 
-	*ct = onBranchChanges
-	return nil
+	return true, nil
 }
 
 func (ct *changeTree) pruneAll() {
-	*ct = []*pendingChangeNode{}
+	ct.tree.Clear()
 }
@@ -241,27 +241,27 @@ type headersToAdd struct {
 				require.NoError(t, err)
 			}
 
-			require.Len(t, *gs.scheduledChangeRoots, tt.expectedRoots)
+			require.Equal(t, tt.expectedRoots, gs.scheduledChangeRoots.Len())
 
-			for _, root := range *gs.scheduledChangeRoots {
-				parentHash := root.change.announcingHeader.Hash()
-				assertDescendantChildren(t, parentHash, gs.blockState.IsDescendantOf, root.nodes)
+			for _, root := range gs.scheduledChangeRoots.roots() {
+				parentHash := root.Data.announcingHeader.Hash()
+				assertDescendantChildren(t, parentHash, gs.blockState.IsDescendantOf, root.Children)
 			}
 		})
 	}
 }
 
 func assertDescendantChildren(t *testing.T, parentHash common.Hash, isDescendantOfFunc isDescendantOfFunc,
-	changes changeTree) {
+	changes []*pendingChangeNode) {
 	t.Helper()
 
 	for _, scheduled := range changes {
-		scheduledChangeHash := scheduled.change.announcingHeader.Hash()
+		scheduledChangeHash := scheduled.Data.announcingHeader.Hash()
 		isDescendant, err := isDescendantOfFunc(parentHash, scheduledChangeHash)
 		require.NoError(t, err)
 		require.Truef(t, isDescendant, "%s is not descendant of %s", scheduledChangeHash, parentHash)
 
-		assertDescendantChildren(t, scheduledChangeHash, isDescendantOfFunc, scheduled.nodes)
+		assertDescendantChildren(t, scheduledChangeHash, isDescendantOfFunc, scheduled.Children)
 	}
 }
 
@@ -566,6 +566,80 @@ func TestNextGrandpaAuthorityChange(t *testing.T) {
 	}
 }
 
+func TestGrandpaState_AuthoritiesAt(t *testing.T) {
+	t.Parallel()
+
+	sr25519Keyring, err := keystore.NewSr25519Keyring()
+	require.NoError(t, err)
+
+	db := NewInMemoryDB(t)
+	blockState := testBlockState(t, db)
+	gs, err := NewGrandpaStateFromGenesis(db, blockState, testAuths, nil)
+	require.NoError(t, err)
+
+	// blocks #1 to #11
+	chainHeaders := issueBlocksWithBABEPrimary(t, sr25519Keyring.KeyAlice, gs.blockState,
+		testGenesisHeader, 10)
+
+	firstChangeAuths := []types.GrandpaAuthoritiesRaw{
+		{Key: kr.Bob().Public().(*ed25519.PublicKey).AsBytes(), ID: 1},
+	}
+	secondChangeAuths := []types.GrandpaAuthoritiesRaw{
+		{Key: kr.Charlie().Public().(*ed25519.PublicKey).AsBytes(), ID: 1},
+		{Key: kr.Dave().Public().(*ed25519.PublicKey).AsBytes(), ID: 1},
+	}
+	// announced at #4 and enacted at #6, then announced and enacted at #8
+	err = gs.addScheduledChange(chainHeaders[3], types.GrandpaScheduledChange{Auths: firstChangeAuths, Delay: 2})
+	require.NoError(t, err)
+	err = gs.addScheduledChange(chainHeaders[7], types.GrandpaScheduledChange{Auths: secondChangeAuths})
+	require.NoError(t, err)
+
+	firstChangeVoters, err := types.NewGrandpaVotersFromAuthoritiesRaw(firstChangeAuths)
+	require.NoError(t, err)
+	secondChangeVoters, err := types.NewGrandpaVotersFromAuthoritiesRaw(secondChangeAuths)
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		blockHash      common.Hash
+		expectedSetID  uint64
+		expectedVoters []types.GrandpaVoter
+	}{
+		"genesis": {
+			blockHash:      testGenesisHeader.Hash(),
+			expectedVoters: testAuths,
+		},
+		"change_enacted_at_block": {
+			blockHash:      chainHeaders[5].Hash(),
+			expectedVoters: testAuths,
+		},
+		"after_first_change": {
+			blockHash:      chainHeaders[6].Hash(),
+			expectedSetID:  1,
+			expectedVoters: firstChangeVoters,
+		},
+		"after_second_change": {
+			blockHash:      chainHeaders[8].Hash(),
+			expectedSetID:  2,
+			expectedVoters: secondChangeVoters,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			setID, voters, err := gs.AuthoritiesAt(testCase.blockHash)
+			require.NoError(t, err)
+			require.Equal(t, testCase.expectedSetID, setID)
+			require.Equal(t, testCase.expectedVoters, voters)
+		})
+	}
+
+	_, _, err = gs.AuthoritiesAt(common.Hash{1})
+	require.ErrorIs(t, err, database.ErrNotFound)
+}
+
 func TestApplyForcedChanges(t *testing.T) {
 	t.Parallel()
 
@@ -1080,6 +1154,57 @@ func TestApplyScheduledChangeGetApplicableChange(t *testing.T) {
 				}(),
 			},
 		},
+		"apply_change_and_prune_children_on_other_forks": {
+			generateForks: genericForks,
+			changes: func(gs *GrandpaState, headers [][]*types.Header) {
+				chainABlock4 := headers[0][3] // block 4 from chain A
+				gs.addScheduledChange(chainABlock4, types.GrandpaScheduledChange{
+					Delay: 0,
+					Auths: []types.GrandpaAuthoritiesRaw{
+						{Key: keyring.KeyAlice.Public().(*sr25519.PublicKey).AsBytes()},
+						{Key: keyring.KeyFerdie.Public().(*sr25519.PublicKey).AsBytes()},
+						{Key: keyring.KeyGeorge.Public().(*sr25519.PublicKey).AsBytes()},
+					},
+				})
+
+				// both changes are children of the change on block 4 from chain A,
+				// the change on chain C being pruned once chain A is finalized
+				chainABlock8 := headers[0][7] // block 8 from chain A should keep
+				gs.addScheduledChange(chainABlock8, types.GrandpaScheduledChange{
+					Delay: 0,
+					Auths: []types.GrandpaAuthoritiesRaw{
+						{Key: keyring.KeyCharlie.Public().(*sr25519.PublicKey).AsBytes()},
+						{Key: keyring.KeyIan.Public().(*sr25519.PublicKey).AsBytes()},
+						{Key: keyring.KeyEve.Public().(*sr25519.PublicKey).AsBytes()},
+					},
+				})
+
+				chainCBlock8 := headers[2][1] // block 8 from chain C should be pruned
+				gs.addScheduledChange(chainCBlock8, types.GrandpaScheduledChange{
+					Delay: 0,
+					Auths: []types.GrandpaAuthoritiesRaw{
+						{Key: keyring.KeyBob.Public().(*sr25519.PublicKey).AsBytes()},
+						{Key: keyring.KeyIan.Public().(*sr25519.PublicKey).AsBytes()},
+						{Key: keyring.KeyEve.Public().(*sr25519.PublicKey).AsBytes()},
+					},
+				})
+			},
+			finalizedHeader:                 [2]int{0, 6}, // finalize block number 7 from chain A
+			expectedScheduledChangeRootsLen: 1,
+			expectedChange: &pendingChange{
+				delay: 0,
+				nextAuthorities: func() []types.Authority {
+					auths, _ := types.GrandpaAuthoritiesRawToAuthorities(
+						[]types.GrandpaAuthoritiesRaw{
+							{Key: keyring.KeyAlice.Public().(*sr25519.PublicKey).AsBytes()},
+							{Key: keyring.KeyFerdie.Public().(*sr25519.PublicKey).AsBytes()},
+							{Key: keyring.KeyGeorge.Public().(*sr25519.PublicKey).AsBytes()},
+						},
+					)
+					return auths
+				}(),
+			},
+		},
 		"finalized_header_with_no_scheduled_change_should_purge_other_pending_changes": {
 			generateForks:                   genericForks,
 			expectedScheduledChangeRootsLen: 1,
@@ -1116,6 +1241,21 @@ func TestApplyScheduledChangeGetApplicableChange(t *testing.T) {
 			},
 			finalizedHeader: [2]int{0, 6}, // finalize block number 7 from chain A
 		},
+		"pending_change_announced_on_ancestor_of_finalized_header_should_be_pruned": {
+			generateForks: genericForks,
+			changes: func(gs *GrandpaState, headers [][]*types.Header) {
+				chainABlock4 := headers[0][3] // block 4 from chain A should be pruned
+				gs.addScheduledChange(chainABlock4, types.GrandpaScheduledChange{
+					Delay: 10,
+					Auths: []types.GrandpaAuthoritiesRaw{
+						{Key: keyring.KeyAlice.Public().(*sr25519.PublicKey).AsBytes()},
+						{Key: keyring.KeyIan.Public().(*sr25519.PublicKey).AsBytes()},
+						{Key: keyring.KeyEve.Public().(*sr25519.PublicKey).AsBytes()},
+					},
+				})
+			},
+			finalizedHeader: [2]int{0, 6}, // finalize block number 7 from chain A
+		},
 		"finalising_header_with_pending_changes_should_return_unfinalized_acestor": {
 			generateForks: genericForks,
 			changes: func(gs *GrandpaState, headers [][]*types.Header) {
@@ -1181,16 +1321,16 @@ func TestApplyScheduledChangeGetApplicableChange(t *testing.T) {
 
 			if tt.expectedChange != nil {
 				require.NoError(t, err)
-				require.Equal(t, tt.expectedChange.delay, changeNode.change.delay)
-				require.Equal(t, tt.expectedChange.nextAuthorities, changeNode.change.nextAuthorities)
+				require.Equal(t, tt.expectedChange.delay, changeNode.Data.delay)
+				require.Equal(t, tt.expectedChange.nextAuthorities, changeNode.Data.nextAuthorities)
 			} else {
 				require.Nil(t, changeNode)
 			}
 
-			require.Len(t, *gs.scheduledChangeRoots, tt.expectedScheduledChangeRootsLen)
+			require.Equal(t, tt.expectedScheduledChangeRootsLen, gs.scheduledChangeRoots.Len())
 			// make sure all the next scheduled changes are descendant of the finalized hash
 			assertDescendantChildren(t,
-				selectedHeader.Hash(), gs.blockState.IsDescendantOf, *gs.scheduledChangeRoots)
+				selectedHeader.Hash(), gs.blockState.IsDescendantOf, gs.scheduledChangeRoots.roots())
 		})
 	}
 }
@@ -1429,11 +1569,11 @@ func TestApplyScheduledChange(t *testing.T) {
 				}
 
 				assertDescendantChildren(t,
-					selectedFinalizedHeader.Hash(), gs.blockState.IsDescendantOf, *gs.scheduledChangeRoots)
+					selectedFinalizedHeader.Hash(), gs.blockState.IsDescendantOf, gs.scheduledChangeRoots.roots())
 			}
 
 			require.Len(t, *gs.forcedChanges, tt.expectedForcedChangesLen)
-			require.Len(t, *gs.scheduledChangeRoots, tt.expectedScheduledChangeRootsLen)
+			require.Equal(t, tt.expectedScheduledChangeRootsLen, gs.scheduledChangeRoots.Len())
 
 			currentSetID, err := gs.GetCurrentSetID()
 			require.NoError(t, err)
@@ -0,0 +1,99 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// voteLockPrefix + stage -> voteLock
+var voteLockPrefix = []byte("vlk")
+
+// voteLockStages is the number of GRANDPA vote stages: prevote, precommit and primary proposal.
+const voteLockStages = 3
+
+// voteLock is the last vote of the node for a stage.
+type voteLock struct {
+	SetID uint64
+	Round uint64
+	Vote  types.GrandpaVote
+}
+
+// after returns true if the vote lock is for a later round or set id than the given ones.
+func (l voteLock) after(round, setID uint64) bool {
+	return l.SetID > setID || (l.SetID == setID && l.Round > round)
+}
+
+func voteLockKey(stage uint8) []byte {
+	return append(bytes.Clone(voteLockPrefix), stage)
+}
+
+func (s *GrandpaState) getVoteLock(stage uint8) (lock *voteLock, err error) {
+	encodedLock, err := s.db.Get(voteLockKey(stage))
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	lock = new(voteLock)
+	err = scale.Unmarshal(encodedLock, lock)
+	if err != nil {
+		return nil, fmt.Errorf("decoding vote lock: %w", err)
+	}
+	return lock, nil
+}
+
+// LockVote persists the vote of the node for the stage of the round and set id, before
+// the vote is signed and broadcast, and syncs the database so the vote lock survives a
+// crash of the node. It returns false if the vote cannot be cast without equivocating,
+// that is if a different vote is locked for the stage of the round, or if a vote of a
+// later round or set id is locked. Only the last vote of each stage is kept, since no
+// vote of a round older than the last locked round can be cast.
+func (s *GrandpaState) LockVote(round, setID uint64, stage uint8, vote types.GrandpaVote) (locked bool, err error) {
+	if stage >= voteLockStages {
+		return false, fmt.Errorf("invalid vote stage %d", stage)
+	}
+
+	s.voteLockMutex.Lock()
+	defer s.voteLockMutex.Unlock()
+
+	for lockStage := uint8(0); lockStage < voteLockStages; lockStage++ {
+		lock, err := s.getVoteLock(lockStage)
+		if err != nil {
+			return false, fmt.Errorf("getting vote lock for stage %d: %w", lockStage, err)
+		}
+
+		switch {
+		case lock == nil:
+		case lock.after(round, setID):
+			return false, nil
+		case lockStage == stage && lock.SetID == setID && lock.Round == round:
+			return lock.Vote == vote, nil
+		}
+	}
+
+	encodedLock, err := scale.Marshal(voteLock{SetID: setID, Round: round, Vote: vote})
+	if err != nil {
+		return false, fmt.Errorf("encoding vote lock: %w", err)
+	}
+
+	err = s.db.Put(voteLockKey(stage), encodedLock)
+	if err != nil {
+		return false, fmt.Errorf("putting vote lock: %w", err)
+	}
+
+	err = s.db.Sync()
+	if err != nil {
+		return false, fmt.Errorf("syncing vote lock: %w", err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGrandpaState_LockVote(t *testing.T) {
+	t.Parallel()
+
+	db := NewInMemoryDB(t)
+	gs, err := NewGrandpaStateFromGenesis(db, nil, testAuths, nil)
+	require.NoError(t, err)
+
+	const prevote, precommit = 0, 1
+	voteA := types.GrandpaVote{Hash: common.Hash{1}, Number: 1}
+	voteB := types.GrandpaVote{Hash: common.Hash{2}, Number: 2}
+
+	locked, err := gs.LockVote(5, 1, prevote, voteA)
+	require.NoError(t, err)
+	assert.True(t, locked)
+
+	// the same vote can be cast again, for example after a restart
+	locked, err = gs.LockVote(5, 1, prevote, voteA)
+	require.NoError(t, err)
+	assert.True(t, locked)
+
+	locked, err = gs.LockVote(5, 1, prevote, voteB)
+	require.NoError(t, err)
+	assert.False(t, locked)
+
+	locked, err = gs.LockVote(5, 1, precommit, voteB)
+	require.NoError(t, err)
+	assert.True(t, locked)
+
+	// the vote locks survive a restart of the node
+	gs = NewGrandpaState(db, nil, nil)
+	locked, err = gs.LockVote(5, 1, precommit, voteA)
+	require.NoError(t, err)
+	assert.False(t, locked)
+
+	locked, err = gs.LockVote(6, 1, prevote, voteB)
+	require.NoError(t, err)
+	assert.True(t, locked)
+
+	// votes of rounds older than the last locked round are not cast
+	locked, err = gs.LockVote(5, 1, precommit, voteB)
+	require.NoError(t, err)
+	assert.False(t, locked)
+
+	locked, err = gs.LockVote(7, 0, prevote, voteB)
+	require.NoError(t, err)
+	assert.False(t, locked)
+
+	// votes of a new set id start from round 1
+	locked, err = gs.LockVote(1, 2, prevote, voteA)
+	require.NoError(t, err)
+	assert.True(t, locked)
+
+	// only the last vote of each stage is kept
+	lock, err := gs.getVoteLock(precommit)
+	require.NoError(t, err)
+	assert.Equal(t, &voteLock{SetID: 1, Round: 5, Vote: voteB}, lock)
+
+	_, err = gs.LockVote(1, 2, 3, voteA)
+	assert.EqualError(t, err, "invalid vote stage 3")
+}
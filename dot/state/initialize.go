@@ -43,7 +43,7 @@ func (s *Service) Initialise(gen *genesis.Genesis, header *types.Header, t trie.
 
 	// TODO: all trie related db operations should be done in pkg/trie
 	if inmemoryTrie, ok := t.(*inmemory_trie.InMemoryTrie); ok {
-		if err = inmemoryTrie.WriteDirty(database.NewTable(db, storagePrefix)); err != nil {
+		if err = inmemoryTrie.WriteDirty(newStorageTable(db)); err != nil {
 			return fmt.Errorf("failed to write genesis trie to database: %w", err)
 		}
 	}
@@ -142,7 +142,7 @@ func (s *Service) storeInitialValues(data *genesis.Data, t trie.Trie) error {
 	// write genesis trie to database
 	// TODO: all trie related db operations should be done in pkg/trie
 	if inmemoryTrie, ok := t.(*inmemory_trie.InMemoryTrie); ok {
-		if err := inmemoryTrie.WriteDirty(database.NewTable(s.db, storagePrefix)); err != nil {
+		if err := inmemoryTrie.WriteDirty(newStorageTable(s.db)); err != nil {
 			return fmt.Errorf("failed to write genesis trie to database: %w", err)
 		}
 	}
@@ -47,7 +47,7 @@ type InmemoryStorageState struct {
 // and database located at basePath.
 func NewStorageState(db database.Database, blockState *BlockState,
 	tries *Tries) (*InmemoryStorageState, error) {
-	storageTable := database.NewTable(db, storagePrefix)
+	storageTable := newStorageTable(db)
 
 	return &InmemoryStorageState{
 		blockState:   blockState,
@@ -13,6 +13,7 @@
 type GrandpaDatabase interface {
 	GetPutDeleter
 	NewPrefixIterator(prefix []byte) (database.Iterator, error)
+	Sync() error
 }
 
 // GetPutDeleter has methods to get, put and delete key values.
@@ -26,6 +27,7 @@ type BlockStateDatabase interface {
 	GetPutDeleter
 	Haser
 	NewBatcher
+	NewPrefixIterator(prefix []byte) (database.Iterator, error)
 }
 
 // GetPutter has methods to get and put key values.
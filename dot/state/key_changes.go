@@ -0,0 +1,96 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/trie/inmemory/proof"
+)
+
+// MaxKeyChangesRange is the maximum number of blocks of a key changes proof range. It is
+// kept small since the storage of every block of the range is read for a single request.
+const MaxKeyChangesRange = 256
+
+// ErrKeyChangesRangeTooLarge is returned when a key changes proof range has more
+// than MaxKeyChangesRange blocks.
+var ErrKeyChangesRangeTooLarge = errors.New("key changes range too large")
+
+// GenerateKeyChangesProof returns the headers of the blocks after the first block up to
+// the last block in which the value of the key changed, compared to their parent block,
+// along with the deduplicated proof nodes of the value of the key at the first block, at
+// each of these blocks and at their parents. Since no changes tries are kept, the values
+// are read from the state of every block of the range, which must not be pruned. The
+// proof generator cannot prove the absence of a key, so no proof is returned for the
+// states in which the key is not set.
+func (s *InmemoryStorageState) GenerateKeyChangesProof(first, last common.Hash, key []byte) (
+	changed []*types.Header, encodedProofNodes [][]byte, err error) {
+	firstHeader, err := s.blockState.GetHeader(first)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting header of first block %s: %w", first, err)
+	}
+	lastHeader, err := s.blockState.GetHeader(last)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting header of last block %s: %w", last, err)
+	}
+	// the range size is checked from the block numbers, before walking the range
+	// loads every header of it
+	if lastHeader.Number >= firstHeader.Number && lastHeader.Number-firstHeader.Number+1 > MaxKeyChangesRange {
+		return nil, nil, fmt.Errorf("%w: %d blocks from %s to %s, maximum is %d",
+			ErrKeyChangesRangeTooLarge, lastHeader.Number-firstHeader.Number+1, first, last, MaxKeyChangesRange)
+	}
+
+	hashes, err := s.blockState.Range(first, last)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting range from %s to %s: %w", first, last, err)
+	}
+
+	headers := make([]*types.Header, len(hashes))
+	values := make([][]byte, len(hashes))
+	for i, hash := range hashes {
+		headers[i], err = s.blockState.GetHeader(hash)
+		if err != nil {
+			return nil, nil, fmt.Errorf("getting header of block %s: %w", hash, err)
+		}
+		values[i], err = s.GetStorage(&headers[i].StateRoot, key)
+		if err != nil {
+			return nil, nil, fmt.Errorf("getting storage of block %s: %w", hash, err)
+		}
+	}
+
+	proven := make([]bool, len(hashes))
+	proven[0] = true
+	for i := 1; i < len(hashes); i++ {
+		if bytes.Equal(values[i], values[i-1]) {
+			continue
+		}
+		changed = append(changed, headers[i])
+		proven[i-1] = true
+		proven[i] = true
+	}
+
+	nodesSeen := make(map[string]struct{})
+	for i, header := range headers {
+		if !proven[i] || values[i] == nil {
+			continue
+		}
+		nodes, err := proof.Generate(header.StateRoot[:], [][]byte{key}, s.db)
+		if err != nil {
+			return nil, nil, fmt.Errorf("generating proof for block %s: %w", hashes[i], err)
+		}
+		for _, node := range nodes {
+			if _, ok := nodesSeen[string(node)]; ok {
+				continue
+			}
+			nodesSeen[string(node)] = struct{}{}
+			encodedProofNodes = append(encodedProofNodes, node)
+		}
+	}
+
+	return changed, encodedProofNodes, nil
+}
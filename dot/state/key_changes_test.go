@@ -0,0 +1,95 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/trie"
+	"github.com/ChainSafe/gossamer/pkg/trie/inmemory/proof"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorage_GenerateKeyChangesProof(t *testing.T) {
+	storage := newTestStorageState(t)
+	key := []byte("watched")
+
+	// the value of the watched key at blocks #1 to #5, the key being deleted at block #4
+	values := [][]byte{[]byte("a"), []byte("a"), []byte("b"), nil, []byte("c")}
+	headers := make([]*types.Header, len(values))
+	parentHash := testGenesisHeader.Hash()
+	for i, value := range values {
+		ts, err := storage.TrieState(&trie.EmptyHash)
+		require.NoError(t, err)
+		ts.Put([]byte("other"), []byte{byte(i)})
+		if value != nil {
+			ts.Put(key, value)
+		}
+		require.NoError(t, storage.StoreTrie(ts, nil))
+
+		block := &types.Block{
+			Header: types.Header{
+				ParentHash: parentHash,
+				Number:     uint(i + 1),
+				StateRoot:  ts.Trie().MustHash(),
+				Digest:     createPrimaryBABEDigest(t),
+			},
+			Body: types.Body{},
+		}
+		require.NoError(t, storage.blockState.AddBlock(block))
+		headers[i] = &block.Header
+		parentHash = block.Header.Hash()
+	}
+
+	changed, encodedProofNodes, err := storage.GenerateKeyChangesProof(headers[0].Hash(), headers[4].Hash(), key)
+	require.NoError(t, err)
+	require.Len(t, changed, 3)
+	assert.Equal(t, []common.Hash{headers[2].Hash(), headers[3].Hash(), headers[4].Hash()},
+		[]common.Hash{changed[0].Hash(), changed[1].Hash(), changed[2].Hash()})
+	for i, value := range values {
+		err := proof.Verify(encodedProofNodes, headers[i].StateRoot[:], key, value)
+		if value == nil {
+			assert.ErrorIs(t, err, proof.ErrRootNodeNotFound)
+			continue
+		}
+		assert.NoError(t, err)
+	}
+
+	// the value of the first block of the range is proven even if it never changes
+	changed, encodedProofNodes, err = storage.GenerateKeyChangesProof(headers[0].Hash(), headers[1].Hash(), key)
+	require.NoError(t, err)
+	assert.Empty(t, changed)
+	assert.NoError(t, proof.Verify(encodedProofNodes, headers[0].StateRoot[:], key, values[0]))
+}
+
+func TestStorage_GenerateKeyChangesProof_rangeTooLarge(t *testing.T) {
+	storage := newTestStorageState(t)
+
+	// only the first and last headers are stored, so walking the range would fail
+	// on the first missing header instead of returning ErrKeyChangesRangeTooLarge
+	first := &types.Header{
+		ParentHash: testGenesisHeader.Hash(),
+		Number:     1,
+		Digest:     createPrimaryBABEDigest(t),
+	}
+	last := &types.Header{
+		ParentHash: common.Hash{1},
+		Number:     MaxKeyChangesRange + 1,
+		Digest:     createPrimaryBABEDigest(t),
+	}
+	require.NoError(t, storage.blockState.SetHeader(first))
+	require.NoError(t, storage.blockState.SetHeader(last))
+
+	_, err := storage.blockState.Range(first.Hash(), last.Hash())
+	require.ErrorIs(t, err, database.ErrNotFound)
+
+	changed, encodedProofNodes, err := storage.GenerateKeyChangesProof(first.Hash(), last.Hash(), []byte("watched"))
+	assert.ErrorIs(t, err, ErrKeyChangesRangeTooLarge)
+	assert.Nil(t, changed)
+	assert.Nil(t, encodedProofNodes)
+}
@@ -0,0 +1,126 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/ChainSafe/gossamer/pkg/trie"
+	triedb "github.com/ChainSafe/gossamer/pkg/trie/db"
+	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+)
+
+// storageTable is the database table of the state tries. Trie nodes are written
+// under their locality key, made of the first nibbles of their path followed by
+// their hash, so the nodes of a subtree are adjacent in the database keyspace.
+// This reduces the number of sorted tables overlapped by the nodes written for a
+// block, and so the compaction write amplification. Nodes written before the
+// keys were migrated are read from their hash key.
+type storageTable struct {
+	database.Table
+}
+
+func newStorageTable(db database.Database) *storageTable {
+	return &storageTable{
+		Table: database.NewTable(db, storagePrefix),
+	}
+}
+
+// NodeKey returns the locality key of the trie node with the given path and hash.
+func (*storageTable) NodeKey(path, nodeHash []byte) []byte {
+	return triedb.LocalityNodeKey(path, nodeHash)
+}
+
+// hasRootNode returns true if the root node of the trie with the given
+// root hash is stored under its locality key or under its hash.
+func (s *storageTable) hasRootNode(rootHash common.Hash) (has bool, err error) {
+	has, err = s.Has(s.NodeKey(nil, rootHash.ToBytes()))
+	if err != nil || has {
+		return has, err
+	}
+	return s.Has(rootHash.ToBytes())
+}
+
+// MigrateTrieNodeKeys migrates the trie nodes of the state tries of all the block
+// headers of the database from their hash key to their locality key, and deletes
+// their hash key once all the tries are migrated. Trie nodes not reachable from the
+// state root of a block header are left under their hash key. The migration can be
+// interrupted and run again, and returns the number of trie nodes migrated.
+// The node must be stopped while the database is migrated.
+func MigrateTrieNodeKeys(db database.Database) (migratedNodes int, err error) {
+	stateRoots, err := headerStateRoots(database.NewTable(db, blockPrefix))
+	if err != nil {
+		return 0, fmt.Errorf("getting state roots: %w", err)
+	}
+
+	storage := newStorageTable(db)
+	migrated := make(map[common.Hash]struct{})
+	for i, stateRoot := range stateRoots {
+		batch := storage.NewBatch()
+		err = inmemory_trie.MigrateNodeKeys(storage, batch, storage, stateRoot, migrated)
+		if err != nil {
+			batch.Reset()
+			return 0, fmt.Errorf("migrating trie with root %s: %w", stateRoot, err)
+		}
+
+		err = batch.Flush()
+		if err != nil {
+			return 0, fmt.Errorf("writing migrated trie with root %s: %w", stateRoot, err)
+		}
+		logger.Debugf("migrated trie %d/%d with root %s", i+1, len(stateRoots), stateRoot)
+	}
+
+	batch := storage.NewBatch()
+	for nodeHash := range migrated {
+		err = batch.Del(nodeHash.ToBytes())
+		if err != nil {
+			batch.Reset()
+			return 0, fmt.Errorf("deleting hash key of node %s: %w", nodeHash, err)
+		}
+	}
+
+	err = batch.Flush()
+	if err != nil {
+		return 0, fmt.Errorf("deleting hash keys of migrated nodes: %w", err)
+	}
+
+	return len(migrated), nil
+}
+
+// headerStateRoots returns the distinct non empty state roots of the block headers.
+func headerStateRoots(blockDB database.Table) (stateRoots []common.Hash, err error) {
+	iterator, err := blockDB.NewPrefixIterator(headerPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("creating header iterator: %w", err)
+	}
+	defer iterator.Release()
+
+	seen := make(map[common.Hash]struct{})
+	for valid := iterator.First(); valid; valid = iterator.Next() {
+		header := types.NewEmptyHeader()
+		err = scale.Unmarshal(iterator.Value(), header)
+		if err != nil {
+			return nil, fmt.Errorf("decoding header: %w", err)
+		}
+
+		if header.StateRoot == trie.EmptyHash {
+			continue
+		}
+		if _, ok := seen[header.StateRoot]; ok {
+			continue
+		}
+		seen[header.StateRoot] = struct{}{}
+		stateRoots = append(stateRoots, header.StateRoot)
+	}
+
+	if len(stateRoots) == 0 {
+		return nil, errors.New("no block header found")
+	}
+	return stateRoots, nil
+}
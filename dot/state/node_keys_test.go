@@ -0,0 +1,141 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	triedb "github.com/ChainSafe/gossamer/pkg/trie/db"
+	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateTrieNodeKeys(t *testing.T) {
+	t.Parallel()
+
+	db := NewInMemoryDB(t)
+	hashTable := database.NewTable(db, storagePrefix)
+	blockDB := database.NewTable(db, blockPrefix)
+
+	tr := inmemory_trie.NewEmptyTrie()
+	for i := 0; i < 100; i++ {
+		tr.Put([]byte(fmt.Sprintf("key%d", i)), []byte(fmt.Sprintf("value%d", i)))
+	}
+
+	expectedMigrated := make(map[common.Hash]struct{})
+	var stateRoots []common.Hash
+	for number := uint(1); number <= 2; number++ {
+		tr.Put([]byte("key0"), []byte{byte(number)})
+		require.NoError(t, tr.WriteDirty(hashTable))
+		stateRoot := tr.MustHash()
+		stateRoots = append(stateRoots, stateRoot)
+		inmemory_trie.PopulateNodeHashes(tr.RootNode(), expectedMigrated)
+
+		header := &types.Header{Number: number, StateRoot: stateRoot, Digest: types.NewDigest()}
+		require.NoError(t, blockDB.Put(headerKey(header.Hash()), scale.MustMarshal(*header)))
+	}
+
+	migratedNodes, err := MigrateTrieNodeKeys(db)
+	require.NoError(t, err)
+	assert.Equal(t, len(expectedMigrated), migratedNodes)
+
+	storage := newStorageTable(db)
+	for _, stateRoot := range stateRoots {
+		_, err = hashTable.Get(stateRoot.ToBytes())
+		require.ErrorIs(t, err, database.ErrNotFound)
+
+		has, err := storage.hasRootNode(stateRoot)
+		require.NoError(t, err)
+		assert.True(t, has)
+
+		trieFromDB := inmemory_trie.NewEmptyTrie()
+		require.NoError(t, trieFromDB.Load(storage, stateRoot))
+		assert.Equal(t, stateRoot, trieFromDB.MustHash())
+	}
+
+	migratedNodes, err = MigrateTrieNodeKeys(db)
+	require.NoError(t, err)
+	assert.Zero(t, migratedNodes)
+}
+
+// BenchmarkTrieNodeKeys compares the compaction I/O of the database when writing the
+// state tries of successive blocks with the trie nodes stored under their hash and
+// under their locality key. Each iteration writes the state trie of a block updating
+// storage values of a few pallets, and the bytes written by the compactions of the
+// database are reported by block.
+//
+//	go test ./dot/state -run=^$ -bench=BenchmarkTrieNodeKeys -benchtime=2000x
+func BenchmarkTrieNodeKeys(b *testing.B) {
+	benchmarks := map[string]func(db database.Database) triedb.NewBatcher{
+		"hash_keys": func(db database.Database) triedb.NewBatcher {
+			return database.NewTable(db, storagePrefix)
+		},
+		"locality_keys": func(db database.Database) triedb.NewBatcher {
+			return newStorageTable(db)
+		},
+	}
+
+	for name, newStorage := range benchmarks {
+		b.Run(name, func(b *testing.B) {
+			db, err := database.NewPebble(b.TempDir(), false)
+			require.NoError(b, err)
+			defer db.Close()
+			storage := newStorage(db)
+
+			const pallets, accounts, updatesPerBlock = 8, 20000, 200
+			generator := rand.New(rand.NewSource(1)) //skipcq: GSC-G404
+			palletPrefixes := make([][]byte, pallets)
+			for i := range palletPrefixes {
+				palletPrefixes[i] = make([]byte, 32)
+				_, _ = generator.Read(palletPrefixes[i])
+			}
+
+			storageKey := func() []byte {
+				prefix := palletPrefixes[generator.Intn(pallets)]
+				key := append([]byte{}, prefix...)
+				account := generator.Intn(accounts)
+				accountHash := common.MustBlake2bHash([]byte{byte(account >> 8), byte(account)})
+				return append(key, accountHash[:]...)
+			}
+			value := make([]byte, 64)
+
+			tr := inmemory_trie.NewEmptyTrie()
+			for i := 0; i < accounts; i++ {
+				_, _ = generator.Read(value)
+				tr.Put(storageKey(), value)
+			}
+			require.NoError(b, tr.WriteDirty(storage))
+			initialStats := db.CompactionStats()
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for j := 0; j < updatesPerBlock; j++ {
+					_, _ = generator.Read(value)
+					tr.Put(storageKey(), value)
+				}
+				err = tr.WriteDirty(storage)
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.StopTimer()
+
+			stats := db.CompactionStats()
+			var bytesCompacted uint64
+			for level, levelStats := range stats.Levels {
+				bytesCompacted += levelStats.BytesCompacted - initialStats.Levels[level].BytesCompacted
+			}
+			b.ReportMetric(float64(bytesCompacted)/float64(b.N), "compacted-B/block")
+			b.ReportMetric(float64(stats.Count-initialStats.Count), "compactions")
+			b.ReportMetric(float64(stats.DiskSpaceUsage), "disk-B")
+		})
+	}
+}
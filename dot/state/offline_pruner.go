@@ -11,6 +11,7 @@
 
 	"github.com/ChainSafe/gossamer/internal/database"
 	"github.com/ChainSafe/gossamer/lib/common"
+	triedb "github.com/ChainSafe/gossamer/pkg/trie/db"
 	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
 )
 
@@ -119,24 +120,25 @@ func (p *OfflinePruner) SetBloomFilter() (err error) {
 		return fmt.Errorf("not enough block to perform pruning")
 	}
 
-	// loop from latest to last `retainBlockNum` blocks
-	for blockNum := header.Number; blockNum > 0 && blockNum >= latestBlockNum-uint(p.retainBlockNum); {
-		var tr *inmemory_trie.InMemoryTrie
-		loadedTrie, err := p.storageState.LoadFromDB(header.StateRoot)
+	// loop over the last `retainBlockNum` blocks up to the latest one
+	firstBlockNum := latestBlockNum - uint(p.retainBlockNum)
+	iterator, err := p.blockState.CanonicalIterator(max(firstBlockNum, 1))
+	if err != nil {
+		return fmt.Errorf("creating block iterator: %w", err)
+	}
+	defer iterator.Release()
 
-		tr = loadedTrie.(*inmemory_trie.InMemoryTrie)
+	for iterator.Next() && iterator.Header().Number <= latestBlockNum {
+		loadedTrie, err := p.storageState.LoadFromDB(iterator.Header().StateRoot)
 		if err != nil {
 			return err
 		}
 
+		tr := loadedTrie.(*inmemory_trie.InMemoryTrie)
 		inmemory_trie.PopulateNodeHashes(tr.RootNode(), nodeHashes)
-
-		// get parent header of current block
-		header, err = p.blockState.GetHeader(header.ParentHash)
-		if err != nil {
-			return err
-		}
-		blockNum = header.Number
+	}
+	if err := iterator.Err(); err != nil {
+		return fmt.Errorf("iterating over blocks: %w", err)
 	}
 
 	for key := range nodeHashes {
@@ -185,6 +187,9 @@ func (p *OfflinePruner) Prune() error {
 
 		// Storage keys not found in filter database are deleted.
 		nodeHash := bytes.TrimPrefix(key, storagePrefixBytes)
+		if len(nodeHash) == triedb.LocalityNodeKeyLength {
+			nodeHash = nodeHash[triedb.LocalityPrefixLength:]
+		}
 		_, err := p.filterDatabase.Get(nodeHash)
 		if err != nil {
 			if errors.Is(err, database.ErrNotFound) {
@@ -0,0 +1,295 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/ChainSafe/gossamer/pkg/trie"
+)
+
+var (
+	errNoConsistentBlock   = errors.New("no consistent finalised block found")
+	errMissingHeader       = errors.New("header not found")
+	errMissingBody         = errors.New("block body not found")
+	errMissingState        = errors.New("state trie not found")
+	errNotCanonical        = errors.New("block number entry points to another block")
+	errMissingNumberEntry  = errors.New("block number entry not found")
+	errFinalisedHashAbsent = errors.New("finalised hash not found")
+)
+
+// DiscardedBlock is a finalised block dropped by the database repair.
+type DiscardedBlock struct {
+	Number uint
+	Hash   common.Hash
+	Reason error
+}
+
+// RepairReport describes what the database repair discarded to get back to a
+// consistent finalised block.
+type RepairReport struct {
+	// Finalised is the header of the finalised block the database was rolled back to.
+	Finalised *types.Header
+	// Discarded are the finalised blocks ahead of the data found in the database,
+	// from the highest to the lowest.
+	Discarded []DiscardedBlock
+	// DanglingNumbers are the block numbers above the finalised block which had
+	// a block number entry, left over from an interrupted finalisation.
+	DanglingNumbers []uint
+}
+
+// Repaired returns true if the repair changed the database.
+func (r *RepairReport) Repaired() bool {
+	return len(r.Discarded) > 0 || len(r.DanglingNumbers) > 0
+}
+
+// repairDatabase checks the finalised block pointer against the block and
+// storage data of the database, which can disagree after an unclean shutdown
+// since they are not written atomically. It rolls the finalised block back to
+// the highest finalised block with its header, body, block number entry and
+// state trie root in the database, and deletes the block number entries above it.
+func repairDatabase(db database.Database) (report *RepairReport, err error) {
+	report, pointerKey, err := checkDatabase(db)
+	if err != nil {
+		return nil, err
+	}
+
+	if !report.Repaired() {
+		return report, nil
+	}
+
+	blockDB := database.NewTable(db, blockPrefix)
+	batch := blockDB.NewBatch()
+	for _, number := range report.DanglingNumbers {
+		err = batch.Del(headerHashKey(uint64(number)))
+		if err != nil {
+			return nil, fmt.Errorf("deleting block number entry %d: %w", number, err)
+		}
+	}
+
+	err = batch.Put(pointerKey, report.Finalised.Hash().ToBytes())
+	if err != nil {
+		return nil, fmt.Errorf("setting finalised hash: %w", err)
+	}
+
+	err = batch.Flush()
+	if err != nil {
+		return nil, fmt.Errorf("writing repaired database: %w", err)
+	}
+
+	return report, nil
+}
+
+// CheckDatabase returns what the database repair run when the state service starts
+// would change in the database, without changing it. The finalised block of the
+// report is nil if the database is not initialised.
+func CheckDatabase(db database.Database) (*RepairReport, error) {
+	report, _, err := checkDatabase(db)
+	return report, err
+}
+
+// checkDatabase returns the report of the database repair, and the key of the
+// finalised block pointer, which is nil if the database is not initialised.
+func checkDatabase(db database.Database) (report *RepairReport, pointerKey []byte, err error) {
+	blockDB := database.NewTable(db, blockPrefix)
+	storageDB := newStorageTable(db)
+
+	encodedRoundAndSetID, err := blockDB.Get(highestRoundAndSetIDKey)
+	if errors.Is(err, database.ErrNotFound) {
+		// the database is not initialised, there is nothing to repair.
+		return new(RepairReport), nil, nil
+	} else if err != nil {
+		return nil, nil, fmt.Errorf("getting highest round and set id: %w", err)
+	}
+
+	pointerKey = append(common.FinalizedBlockHashKey, encodedRoundAndSetID...)
+	report = new(RepairReport)
+
+	var (
+		hash   common.Hash
+		number uint
+	)
+	encodedHash, err := blockDB.Get(pointerKey)
+	switch {
+	case err == nil:
+		hash = common.NewHash(encodedHash)
+		header, err := getHeaderFromTable(blockDB, hash)
+		if err != nil {
+			highestNumber, err := highestNumberEntry(blockDB)
+			if err != nil {
+				return nil, nil, err
+			}
+			report.Discarded = append(report.Discarded, DiscardedBlock{Hash: hash, Reason: errMissingHeader})
+			number = highestNumber
+			hash = common.Hash{}
+		} else {
+			number = header.Number
+		}
+	case errors.Is(err, database.ErrNotFound):
+		report.Discarded = append(report.Discarded, DiscardedBlock{Reason: errFinalisedHashAbsent})
+		number, err = highestNumberEntry(blockDB)
+		if err != nil {
+			return nil, nil, err
+		}
+	default:
+		return nil, nil, fmt.Errorf("getting finalised hash: %w", err)
+	}
+
+	for {
+		if hash.IsEmpty() {
+			encodedHash, err := blockDB.Get(headerHashKey(uint64(number)))
+			if err == nil {
+				hash = common.NewHash(encodedHash)
+			} else if !errors.Is(err, database.ErrNotFound) {
+				return nil, nil, fmt.Errorf("getting hash of block %d: %w", number, err)
+			}
+		}
+
+		var header *types.Header
+		if !hash.IsEmpty() {
+			header, err = checkFinalisedBlock(blockDB, storageDB, hash, number)
+		} else {
+			err = errMissingNumberEntry
+		}
+		if err == nil {
+			report.Finalised = header
+			break
+		}
+
+		report.Discarded = append(report.Discarded, DiscardedBlock{Number: number, Hash: hash, Reason: err})
+		if number == 0 {
+			return nil, nil, fmt.Errorf("%w: %s", errNoConsistentBlock, err)
+		}
+		number--
+		hash = common.Hash{}
+	}
+
+	report.DanglingNumbers, err = danglingNumberEntries(blockDB, report.Finalised.Number)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return report, pointerKey, nil
+}
+
+func logRepairReport(report *RepairReport) {
+	if !report.Repaired() {
+		return
+	}
+
+	logger.Warnf("database was not shut down cleanly, rolled back to finalised block #%d (%s)",
+		report.Finalised.Number, report.Finalised.Hash())
+	for _, discarded := range report.Discarded {
+		logger.Warnf("discarded finalised block #%d (%s): %s", discarded.Number, discarded.Hash, discarded.Reason)
+	}
+	if len(report.DanglingNumbers) > 0 {
+		logger.Warnf("discarded %d block number entries above the finalised block, from #%d to #%d",
+			len(report.DanglingNumbers), report.DanglingNumbers[0],
+			report.DanglingNumbers[len(report.DanglingNumbers)-1])
+	}
+}
+
+// checkFinalisedBlock returns the header of the finalised block with the given
+// hash and number if all of its data is in the database. The state trie is
+// checked with its root node only, since a trie is written in a single batch.
+func checkFinalisedBlock(blockDB database.Table, storageDB *storageTable, hash common.Hash, number uint) (
+	header *types.Header, err error) {
+	header, err = getHeaderFromTable(blockDB, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	encodedHash, err := blockDB.Get(headerHashKey(uint64(number)))
+	if err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			return nil, errMissingNumberEntry
+		}
+		return nil, fmt.Errorf("getting hash of block %d: %w", number, err)
+	}
+
+	if header.Number != number || !bytes.Equal(encodedHash, hash.ToBytes()) {
+		return nil, errNotCanonical
+	}
+
+	has, err := hasBlockData(blockDB, blockBodyKey(hash), prefixKey(hash, compressedBlockBodyPrefix))
+	if err != nil {
+		return nil, fmt.Errorf("checking block body: %w", err)
+	} else if !has {
+		return nil, errMissingBody
+	}
+
+	if header.StateRoot == trie.EmptyHash {
+		return header, nil
+	}
+
+	has, err = storageDB.hasRootNode(header.StateRoot)
+	if err != nil {
+		return nil, fmt.Errorf("checking state trie root: %w", err)
+	} else if !has {
+		return nil, fmt.Errorf("%w: root %s", errMissingState, header.StateRoot)
+	}
+
+	return header, nil
+}
+
+func getHeaderFromTable(blockDB database.Table, hash common.Hash) (*types.Header, error) {
+	data, err := blockDB.Get(headerKey(hash))
+	if err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			return nil, errMissingHeader
+		}
+		return nil, fmt.Errorf("getting header: %w", err)
+	}
+
+	header := types.NewEmptyHeader()
+	err = scale.Unmarshal(data, header)
+	if err != nil || header.Empty() {
+		return nil, errMissingHeader
+	}
+
+	return header, nil
+}
+
+// highestNumberEntry returns the highest block number with a block number entry.
+func highestNumberEntry(blockDB database.Table) (number uint, err error) {
+	iterator, err := blockDB.NewPrefixIterator(headerHashPrefix)
+	if err != nil {
+		return 0, fmt.Errorf("creating block number iterator: %w", err)
+	}
+	defer iterator.Release()
+
+	for valid := iterator.First(); valid; valid = iterator.Next() {
+		number = decodeHeaderHashKey(iterator.Key())
+	}
+	return number, nil
+}
+
+// danglingNumberEntries returns the block numbers above the given finalised
+// block number which have a block number entry.
+func danglingNumberEntries(blockDB database.Table, finalised uint) (numbers []uint, err error) {
+	iterator, err := blockDB.NewPrefixIterator(headerHashPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("creating block number iterator: %w", err)
+	}
+	defer iterator.Release()
+
+	start := append([]byte(blockPrefix), headerHashKey(uint64(finalised)+1)...)
+	for valid := iterator.SeekGE(start); valid; valid = iterator.Next() {
+		numbers = append(numbers, decodeHeaderHashKey(iterator.Key()))
+	}
+	return numbers, nil
+}
+
+// decodeHeaderHashKey returns the block number of a block number entry key
+// read from a database iterator.
+func decodeHeaderHashKey(key []byte) uint {
+	return uint(binary.BigEndian.Uint64(key[len(key)-8:]))
+}
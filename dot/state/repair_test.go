@@ -0,0 +1,201 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// newRepairTestChain writes a genesis block and `length` finalised blocks
+// with their state trie roots, and returns the database and the headers.
+func newRepairTestChain(t *testing.T, length uint) (database.Database, []*types.Header) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	telemetryMock := NewMockTelemetry(ctrl)
+	telemetryMock.EXPECT().SendMessage(gomock.Any()).AnyTimes()
+
+	db := NewInMemoryDB(t)
+	bs, err := NewBlockStateFromGenesis(db, newTriesEmpty(), testGenesisHeader, telemetryMock)
+	require.NoError(t, err)
+
+	storageDB := database.NewTable(db, storagePrefix)
+	headers := []*types.Header{testGenesisHeader}
+	for number := uint(1); number <= length; number++ {
+		header := &types.Header{
+			ParentHash: headers[number-1].Hash(),
+			Number:     number,
+			StateRoot:  common.Hash{byte(number)},
+			Digest:     types.NewDigest(),
+		}
+		headers = append(headers, header)
+
+		require.NoError(t, bs.SetHeader(header))
+		require.NoError(t, bs.SetBlockBody(header.Hash(), types.NewBody(nil)))
+		require.NoError(t, bs.db.Put(headerHashKey(uint64(number)), header.Hash().ToBytes()))
+		require.NoError(t, storageDB.Put(header.StateRoot.ToBytes(), []byte{1}))
+	}
+
+	require.NoError(t, bs.db.Put(finalisedHashKey(1, 0), headers[length].Hash().ToBytes()))
+	require.NoError(t, bs.db.Put(highestRoundAndSetIDKey, roundAndSetIDToBytes(1, 0)))
+
+	return db, headers
+}
+
+func Test_repairDatabase(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		tear            func(t *testing.T, db database.Database, headers []*types.Header)
+		finalised       uint
+		discarded       []DiscardedBlock
+		danglingNumbers []uint
+	}{
+		"consistent": {
+			finalised: 5,
+		},
+		"missing_state": {
+			tear: func(t *testing.T, db database.Database, headers []*types.Header) {
+				storageDB := database.NewTable(db, storagePrefix)
+				require.NoError(t, storageDB.Del(headers[5].StateRoot.ToBytes()))
+				require.NoError(t, storageDB.Del(headers[4].StateRoot.ToBytes()))
+			},
+			finalised: 3,
+			discarded: []DiscardedBlock{
+				{Number: 5, Reason: errMissingState},
+				{Number: 4, Reason: errMissingState},
+			},
+			danglingNumbers: []uint{4, 5},
+		},
+		"missing_body": {
+			tear: func(t *testing.T, db database.Database, headers []*types.Header) {
+				blockDB := database.NewTable(db, blockPrefix)
+				require.NoError(t, blockDB.Del(blockBodyKey(headers[5].Hash())))
+			},
+			finalised: 4,
+			discarded: []DiscardedBlock{
+				{Number: 5, Reason: errMissingBody},
+			},
+			danglingNumbers: []uint{5},
+		},
+		"finalised_header_missing": {
+			tear: func(t *testing.T, db database.Database, headers []*types.Header) {
+				blockDB := database.NewTable(db, blockPrefix)
+				require.NoError(t, blockDB.Del(headerKey(headers[5].Hash())))
+			},
+			finalised: 4,
+			discarded: []DiscardedBlock{
+				{Reason: errMissingHeader},
+				{Number: 5, Reason: errMissingHeader},
+			},
+			danglingNumbers: []uint{5},
+		},
+		"finalised_hash_missing": {
+			tear: func(t *testing.T, db database.Database, headers []*types.Header) {
+				blockDB := database.NewTable(db, blockPrefix)
+				require.NoError(t, blockDB.Del(finalisedHashKey(1, 0)))
+			},
+			finalised: 5,
+			discarded: []DiscardedBlock{
+				{Reason: errFinalisedHashAbsent},
+			},
+		},
+		"dangling_number_entries": {
+			tear: func(t *testing.T, db database.Database, headers []*types.Header) {
+				blockDB := database.NewTable(db, blockPrefix)
+				require.NoError(t, blockDB.Put(finalisedHashKey(1, 0), headers[3].Hash().ToBytes()))
+			},
+			finalised:       3,
+			danglingNumbers: []uint{4, 5},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			db, headers := newRepairTestChain(t, 5)
+			if testCase.tear != nil {
+				testCase.tear(t, db, headers)
+			}
+
+			report, err := repairDatabase(db)
+			require.NoError(t, err)
+
+			assert.Equal(t, headers[testCase.finalised].Hash(), report.Finalised.Hash())
+			assert.Equal(t, testCase.danglingNumbers, report.DanglingNumbers)
+			require.Len(t, report.Discarded, len(testCase.discarded))
+			for i, expected := range testCase.discarded {
+				assert.Equal(t, expected.Number, report.Discarded[i].Number)
+				assert.ErrorIs(t, report.Discarded[i].Reason, expected.Reason)
+			}
+
+			// the repaired database is consistent and opens on the finalised block.
+			report, err = repairDatabase(db)
+			require.NoError(t, err)
+			assert.False(t, report.Repaired())
+
+			bs, err := NewBlockState(db, newTriesEmpty(), nil)
+			require.NoError(t, err)
+			finalised, err := bs.GetHighestFinalisedHeader()
+			require.NoError(t, err)
+			assert.Equal(t, headers[testCase.finalised].Hash(), finalised.Hash())
+		})
+	}
+}
+
+func Test_repairDatabase_notInitialised(t *testing.T) {
+	t.Parallel()
+
+	report, err := repairDatabase(NewInMemoryDB(t))
+	require.NoError(t, err)
+	assert.False(t, report.Repaired())
+}
+
+func Test_repairDatabase_noConsistentBlock(t *testing.T) {
+	t.Parallel()
+
+	db, headers := newRepairTestChain(t, 1)
+	blockDB := database.NewTable(db, blockPrefix)
+	require.NoError(t, blockDB.Del(blockBodyKey(headers[0].Hash())))
+	require.NoError(t, blockDB.Del(blockBodyKey(headers[1].Hash())))
+
+	_, err := repairDatabase(db)
+	assert.ErrorIs(t, err, errNoConsistentBlock)
+	assert.EqualError(t, err, "no consistent finalised block found: block body not found")
+}
+
+func TestCheckDatabase(t *testing.T) {
+	t.Parallel()
+
+	db, headers := newRepairTestChain(t, 5)
+	blockDB := database.NewTable(db, blockPrefix)
+	require.NoError(t, blockDB.Del(blockBodyKey(headers[5].Hash())))
+
+	report, err := CheckDatabase(db)
+	require.NoError(t, err)
+	assert.True(t, report.Repaired())
+	assert.Equal(t, headers[4].Hash(), report.Finalised.Hash())
+	assert.Equal(t, []uint{5}, report.DanglingNumbers)
+
+	// the database is left unchanged.
+	finalisedHash, err := blockDB.Get(finalisedHashKey(1, 0))
+	require.NoError(t, err)
+	assert.Equal(t, headers[5].Hash().ToBytes(), finalisedHash)
+	has, err := blockDB.Has(headerHashKey(5))
+	require.NoError(t, err)
+	assert.True(t, has)
+
+	report, err = CheckDatabase(NewInMemoryDB(t))
+	require.NoError(t, err)
+	assert.Nil(t, report.Finalised)
+}
@@ -6,6 +6,7 @@
 import (
 	"fmt"
 	"path/filepath"
+	"sync"
 
 	"github.com/ChainSafe/gossamer/dot/state/pruner"
 	"github.com/ChainSafe/gossamer/dot/types"
@@ -13,6 +14,7 @@
 	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/internal/metrics"
 	"github.com/ChainSafe/gossamer/lib/blocktree"
+	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/pkg/trie"
 	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
 )
@@ -37,6 +39,13 @@ type Service struct {
 	closeCh           chan interface{}
 	genesisBABEConfig *types.BabeConfiguration
 
+	// compressBlockData enables the compression of the block bodies and justifications
+	compressBlockData bool
+	recompression     sync.WaitGroup
+
+	maxForkDepth uint
+	keepBranches []common.Hash
+
 	PrunerCfg pruner.Config
 	Telemetry Telemetry
 
@@ -58,6 +67,12 @@ type Config struct {
 	Telemetry         Telemetry
 	Metrics           metrics.IntervalConfig
 	GenesisBABEConfig *types.BabeConfiguration
+	CompressBlockData bool
+	// MaxForkDepth is the number of blocks behind the best block beyond which the
+	// unfinalised branches are discarded, 0 to never discard them.
+	MaxForkDepth uint
+	// KeepBranches are the hashes of the blocks whose branches are never discarded.
+	KeepBranches []common.Hash
 }
 
 // NewService create a new instance of Service
@@ -75,6 +90,9 @@ func NewService(config Config) *Service {
 		PrunerCfg:         config.PrunerCfg,
 		Telemetry:         config.Telemetry,
 		genesisBABEConfig: config.GenesisBABEConfig,
+		compressBlockData: config.CompressBlockData,
+		maxForkDepth:      config.MaxForkDepth,
+		keepBranches:      config.KeepBranches,
 	}
 }
 
@@ -120,6 +138,14 @@ func (s *Service) Start() (err error) {
 		return nil
 	}
 
+	if !s.isMemDB {
+		report, err := repairDatabase(s.db)
+		if err != nil {
+			return fmt.Errorf("checking database consistency: %w", err)
+		}
+		logRepairReport(report)
+	}
+
 	tries := NewTries()
 	tries.SetEmptyTrie()
 
@@ -129,6 +155,22 @@ func (s *Service) Start() (err error) {
 		return fmt.Errorf("failed to create block state: %w", err)
 	}
 
+	s.Block.maxForkDepth = s.maxForkDepth
+	s.Block.keptBranches = make(map[common.Hash]struct{}, len(s.keepBranches))
+	for _, hash := range s.keepBranches {
+		s.Block.keptBranches[hash] = struct{}{}
+	}
+
+	if s.compressBlockData {
+		err = s.Block.compression.enable(s.Block.db, s.Block.genesisHash)
+		if err != nil {
+			return fmt.Errorf("enabling block data compression: %w", err)
+		}
+
+		s.recompression.Add(1)
+		go s.recompressBlockData()
+	}
+
 	// retrieve latest header
 	bestHeader, err := s.Block.GetHighestFinalisedHeader()
 	if err != nil {
@@ -249,6 +291,7 @@ func (s *Service) Rewind(toBlock uint) error {
 // Stop closes each state database
 func (s *Service) Stop() error {
 	close(s.closeCh)
+	s.recompression.Wait()
 
 	hash, err := s.Block.GetHighestFinalisedHash()
 	if err != nil {
@@ -264,6 +307,21 @@ func (s *Service) Stop() error {
 	return s.db.Close()
 }
 
+// recompressBlockData compresses in the background the block bodies and
+// justifications stored before the compression was enabled.
+func (s *Service) recompressBlockData() {
+	defer s.recompression.Done()
+
+	recompressed, err := s.Block.recompressBlockData(s.closeCh)
+	if err != nil {
+		logger.Errorf("recompressing block data: %s", err)
+		return
+	}
+	if recompressed > 0 {
+		logger.Infof("recompressed %d stored block bodies and justifications", recompressed)
+	}
+}
+
 // Import imports the given state corresponding to the given header and sets the head of the chain
 // to it. Additionally, it uses the first slot to correctly set the epoch number of the block.
 func (s *Service) Import(header *types.Header, t trie.Trie,
@@ -284,7 +342,7 @@ func (s *Service) Import(header *types.Header, t trie.Trie,
 	}
 
 	storage := &InmemoryStorageState{
-		db: database.NewTable(s.db, storagePrefix),
+		db: newStorageTable(s.db),
 	}
 
 	epoch, err := NewEpochState(s.db, block, s.genesisBABEConfig)
@@ -21,7 +21,7 @@ type TransactionState struct {
 
 	// notifierChannels are used to notify transaction status. It maps a channel to
 	// hex string of the extrinsic it is supposed to notify about.
-	notifierChannels map[chan transaction.Status]string
+	notifierChannels map[chan transaction.StatusNotification]string
 	notifierLock     sync.RWMutex
 
 	telemetry Telemetry
@@ -32,14 +32,14 @@ func NewTransactionState(telemetry Telemetry) *TransactionState {
 	return &TransactionState{
 		queue:            transaction.NewPriorityQueue(),
 		pool:             transaction.NewPool(),
-		notifierChannels: make(map[chan transaction.Status]string),
+		notifierChannels: make(map[chan transaction.StatusNotification]string),
 		telemetry:        telemetry,
 	}
 }
 
 // Push pushes a transaction to the queue, ordered by priority
 func (s *TransactionState) Push(vt *transaction.ValidTransaction) (common.Hash, error) {
-	s.notifyStatus(vt.Extrinsic, transaction.Ready)
+	s.notifyStatus(vt.Extrinsic, transaction.StatusNotification{Status: transaction.Ready})
 	return s.queue.Push(vt)
 }
 
@@ -81,6 +81,13 @@ func (s *TransactionState) RemoveExtrinsic(ext types.Extrinsic) {
 	s.queue.RemoveExtrinsic(ext)
 }
 
+// RemoveInvalidExtrinsic removes an extrinsic which is no longer valid from the queue
+// and pool, and notifies it is invalid for the given reason.
+func (s *TransactionState) RemoveInvalidExtrinsic(ext types.Extrinsic, reason string) {
+	s.RemoveExtrinsic(ext)
+	s.notifyStatus(ext, transaction.StatusNotification{Status: transaction.Invalid, Reason: reason})
+}
+
 // RemoveExtrinsicFromPool removes an extrinsic from the pool
 func (s *TransactionState) RemoveExtrinsicFromPool(ext types.Extrinsic) {
 	s.pool.Remove(ext.Hash())
@@ -88,7 +95,7 @@ func (s *TransactionState) RemoveExtrinsicFromPool(ext types.Extrinsic) {
 
 // AddToPool adds a transaction to the pool
 func (s *TransactionState) AddToPool(vt *transaction.ValidTransaction) common.Hash {
-	s.notifyStatus(vt.Extrinsic, transaction.Future)
+	s.notifyStatus(vt.Extrinsic, transaction.StatusNotification{Status: transaction.Future})
 
 	hash := s.pool.Insert(vt)
 
@@ -100,24 +107,24 @@ func (s *TransactionState) AddToPool(vt *transaction.ValidTransaction) common.Ha
 }
 
 // GetStatusNotifierChannel creates and returns a status notifier channel.
-func (s *TransactionState) GetStatusNotifierChannel(ext types.Extrinsic) chan transaction.Status {
+func (s *TransactionState) GetStatusNotifierChannel(ext types.Extrinsic) chan transaction.StatusNotification {
 	s.notifierLock.Lock()
 	defer s.notifierLock.Unlock()
 
-	ch := make(chan transaction.Status, defaultBufferSize)
+	ch := make(chan transaction.StatusNotification, defaultBufferSize)
 	s.notifierChannels[ch] = ext.String()
 	return ch
 }
 
 // FreeStatusNotifierChannel deletes given status notifier channel from our map.
-func (s *TransactionState) FreeStatusNotifierChannel(ch chan transaction.Status) {
+func (s *TransactionState) FreeStatusNotifierChannel(ch chan transaction.StatusNotification) {
 	s.notifierLock.Lock()
 	defer s.notifierLock.Unlock()
 
 	delete(s.notifierChannels, ch)
 }
 
-func (s *TransactionState) notifyStatus(ext types.Extrinsic, status transaction.Status) {
+func (s *TransactionState) notifyStatus(ext types.Extrinsic, status transaction.StatusNotification) {
 	s.notifierLock.Lock()
 	defer s.notifierLock.Unlock()
 
@@ -131,7 +138,7 @@ func (s *TransactionState) notifyStatus(ext types.Extrinsic, status transaction.
 			continue
 		}
 		wg.Add(1)
-		go func(ch chan transaction.Status) {
+		go func(ch chan transaction.StatusNotification) {
 			defer wg.Done()
 
 			select {
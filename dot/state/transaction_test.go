@@ -112,10 +112,10 @@ func TestTransactionState_NotifierChannels(t *testing.T) {
 	close(notifierChannel)
 
 	for status := range notifierChannel {
-		if status.String() == transaction.Future.String() {
+		if status.Status == transaction.Future {
 			futureCount++
 		}
-		if status.String() == transaction.Ready.String() {
+		if status.Status == transaction.Ready {
 			readyCount++
 		}
 	}
@@ -123,3 +123,24 @@ func TestTransactionState_NotifierChannels(t *testing.T) {
 	require.Equal(t, expectedFutureCount, futureCount)
 	require.Equal(t, expectedReadyCount, readyCount)
 }
+
+func TestTransactionState_RemoveInvalidExtrinsic(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	telemetryMock := NewMockTelemetry(ctrl)
+	telemetryMock.EXPECT().SendMessage(gomock.Any())
+
+	ts := NewTransactionState(telemetryMock)
+
+	ext := types.Extrinsic{1}
+	vt := transaction.NewValidTransaction(ext, transaction.NewValidity(0, nil, nil, 0, false))
+	ts.AddToPool(vt)
+
+	notifierChannel := ts.GetStatusNotifierChannel(ext)
+	defer ts.FreeStatusNotifierChannel(notifierChannel)
+
+	ts.RemoveInvalidExtrinsic(ext, "era expired")
+
+	require.False(t, ts.Exists(ext))
+	expected := transaction.StatusNotification{Status: transaction.Invalid, Reason: "era expired"}
+	require.Equal(t, expected, <-notifierChannel)
+}
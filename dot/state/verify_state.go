@@ -0,0 +1,203 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+)
+
+// BlockStateVerification is the result of the verification of the state trie of a block.
+type BlockStateVerification struct {
+	Number    uint
+	Hash      common.Hash
+	StateRoot common.Hash
+	// Problems are the trie nodes of the state trie missing or corrupt.
+	Problems []inmemory_trie.NodeProblem
+}
+
+// StateVerification is the result of the verification of the state tries of a range
+// of canonical blocks.
+type StateVerification struct {
+	// Blocks are the blocks verified, from the highest to the lowest.
+	Blocks []BlockStateVerification
+	// Nodes is the number of trie nodes read.
+	Nodes int
+}
+
+// Corrupt returns true if the state trie of a verified block has a problem.
+func (v *StateVerification) Corrupt() bool {
+	for _, block := range v.Blocks {
+		if len(block.Problems) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// HighestIntact returns the highest verified block with all the nodes of its state
+// trie intact, which the chain can be rewound to, or nil if there is none.
+func (v *StateVerification) HighestIntact() *BlockStateVerification {
+	for i := range v.Blocks {
+		if len(v.Blocks[i].Problems) == 0 {
+			return &v.Blocks[i]
+		}
+	}
+	return nil
+}
+
+// VerifyState verifies the state tries of the given count of canonical blocks ending
+// at the block number to, or at the highest finalised block if to is 0, as VerifyState
+// does on the database of the node.
+func (s *Service) VerifyState(ctx context.Context, to, count uint) (*StateVerification, error) {
+	if to == 0 {
+		header, err := s.Block.GetHighestFinalisedHeader()
+		if err != nil {
+			return nil, fmt.Errorf("getting highest finalised header: %w", err)
+		}
+		to = header.Number
+	}
+	return verifyState(ctx, s.db, to, count)
+}
+
+// VerifyState walks the state tries of the given count of canonical blocks ending at
+// the block number to, or at the finalised block the database repair would keep if to
+// is 0, and reports their trie nodes missing from the database or corrupt, so a partially
+// corrupt database can be rewound to the highest block with an intact state rather than
+// synced again. The nodes shared by the tries of consecutive blocks are verified once.
+// The node must be stopped while the database is verified.
+func VerifyState(ctx context.Context, db database.Database, to, count uint) (*StateVerification, error) {
+	if to == 0 {
+		report, err := CheckDatabase(db)
+		if err != nil {
+			return nil, fmt.Errorf("checking database: %w", err)
+		}
+		if report.Finalised == nil {
+			return nil, errors.New("database is not initialised")
+		}
+		to = report.Finalised.Number
+	}
+	return verifyState(ctx, db, to, count)
+}
+
+func verifyState(ctx context.Context, db database.Database, to, count uint) (*StateVerification, error) {
+	if count == 0 {
+		return nil, errors.New("count must be greater than 0")
+	}
+
+	blockDB := database.NewTable(db, blockPrefix)
+	storage := newStorageTable(db)
+	verified := make(map[common.Hash]struct{})
+	verification := new(StateVerification)
+	for number := to; to-number < count; number-- {
+		encodedHash, err := blockDB.Get(headerHashKey(uint64(number)))
+		if errors.Is(err, database.ErrNotFound) {
+			return nil, fmt.Errorf("block #%d: %w", number, errMissingNumberEntry)
+		} else if err != nil {
+			return nil, fmt.Errorf("getting hash of block %d: %w", number, err)
+		}
+
+		hash := common.NewHash(encodedHash)
+		header, err := getHeaderFromTable(blockDB, hash)
+		if err != nil {
+			return nil, fmt.Errorf("block #%d (%s): %w", number, hash, err)
+		}
+
+		nodes, problems, err := inmemory_trie.VerifyNodes(ctx, storage, header.StateRoot, verified)
+		if err != nil {
+			return nil, fmt.Errorf("verifying state trie of block #%d (%s): %w", number, hash, err)
+		}
+		verification.Nodes += nodes
+		verification.Blocks = append(verification.Blocks, BlockStateVerification{
+			Number:    number,
+			Hash:      hash,
+			StateRoot: header.StateRoot,
+			Problems:  problems,
+		})
+		logger.Debugf("verified state trie of block #%d (%s): %d nodes read, %d problems",
+			number, hash, nodes, len(problems))
+
+		if number == 0 {
+			break
+		}
+	}
+
+	return verification, nil
+}
+
+// StateVerifier is a service verifying the state trie of the highest finalised block
+// periodically, to detect a partial corruption of the database early.
+type StateVerifier struct {
+	state    *Service
+	interval time.Duration
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+// NewStateVerifier returns a service verifying the state trie of the highest finalised
+// block of the state service at the given interval.
+func NewStateVerifier(state *Service, interval time.Duration) *StateVerifier {
+	return &StateVerifier{
+		state:    state,
+		interval: interval,
+		done:     make(chan struct{}),
+	}
+}
+
+// Start starts verifying the state trie periodically.
+func (v *StateVerifier) Start() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	v.cancel = cancel
+	go v.run(ctx)
+	return nil
+}
+
+// Stop stops verifying the state trie, interrupting a verification in progress.
+func (v *StateVerifier) Stop() error {
+	v.cancel()
+	<-v.done
+	return nil
+}
+
+func (v *StateVerifier) run(ctx context.Context) {
+	defer close(v.done)
+
+	ticker := time.NewTicker(v.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			v.verify(ctx)
+		}
+	}
+}
+
+func (v *StateVerifier) verify(ctx context.Context) {
+	verification, err := v.state.VerifyState(ctx, 0, 1)
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			logger.Errorf("verifying state trie: %s", err)
+		}
+		return
+	}
+
+	for _, block := range verification.Blocks {
+		for _, problem := range block.Problems {
+			logger.Errorf("state trie of finalised block #%d (%s) is corrupt: %s", block.Number, block.Hash, problem)
+		}
+	}
+	if verification.Corrupt() {
+		logger.Errorf("the database is corrupt, run the db verify-state command with the node stopped " +
+			"to find the highest block with an intact state to rewind to")
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyState(t *testing.T) {
+	t.Parallel()
+
+	db := NewInMemoryDB(t)
+	storage := newStorageTable(db)
+	blockDB := database.NewTable(db, blockPrefix)
+
+	tr := inmemory_trie.NewEmptyTrie()
+	for i := 0; i < 100; i++ {
+		tr.Put([]byte(fmt.Sprintf("key%d", i)), []byte(fmt.Sprintf("value%d", i)))
+	}
+
+	var headers []*types.Header
+	for number := uint(1); number <= 3; number++ {
+		tr.Put([]byte("key0"), []byte{byte(number)})
+		require.NoError(t, tr.WriteDirty(storage))
+
+		header := &types.Header{Number: number, StateRoot: tr.MustHash(), Digest: types.NewDigest()}
+		headers = append(headers, header)
+		require.NoError(t, blockDB.Put(headerKey(header.Hash()), scale.MustMarshal(*header)))
+		require.NoError(t, blockDB.Put(headerHashKey(uint64(number)), header.Hash().ToBytes()))
+	}
+
+	verification, err := VerifyState(context.Background(), db, 3, 3)
+	require.NoError(t, err)
+	require.Len(t, verification.Blocks, 3)
+	assert.False(t, verification.Corrupt())
+	for i, block := range verification.Blocks {
+		assert.Equal(t, headers[2-i].Number, block.Number)
+		assert.Equal(t, headers[2-i].StateRoot, block.StateRoot)
+	}
+
+	// the state root of the last block is missing
+	stateRoot := headers[2].StateRoot
+	require.NoError(t, storage.Del(storage.NodeKey(nil, stateRoot.ToBytes())))
+
+	verification, err = VerifyState(context.Background(), db, 3, 3)
+	require.NoError(t, err)
+	assert.True(t, verification.Corrupt())
+	require.Len(t, verification.Blocks[0].Problems, 1)
+	assert.Equal(t, stateRoot, verification.Blocks[0].Problems[0].Hash)
+	assert.ErrorIs(t, verification.Blocks[0].Problems[0].Err, database.ErrNotFound)
+	assert.Equal(t, uint(2), verification.HighestIntact().Number)
+
+	_, err = VerifyState(context.Background(), db, 5, 1)
+	assert.ErrorIs(t, err, errMissingNumberEntry)
+
+	_, err = VerifyState(context.Background(), db, 3, 0)
+	assert.EqualError(t, err, "count must be greater than 0")
+}
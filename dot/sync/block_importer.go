@@ -9,11 +9,14 @@
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/ChainSafe/gossamer/dot/telemetry"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/babe/inherents"
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
 	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -52,6 +55,11 @@
 		VerifyBlockJustification(common.Hash, uint, []byte) (round uint64, setID uint64, err error)
 	}
 
+	// GrandpaState is the interface for the GRANDPA authority sets state
+	GrandpaState interface {
+		GetCurrentSetID() (uint64, error)
+	}
+
 	// BlockImportHandler is the interface for the handler of newly imported blocks
 	BlockImportHandler interface {
 		HandleBlockImport(block *types.Block, state *rtstorage.TrieState, announce bool) error
@@ -66,6 +74,11 @@ type blockImporter struct {
 	finalityGadget     FinalityGadget
 	blockImportHandler BlockImportHandler
 	telemetry          Telemetry
+	grandpaState       GrandpaState
+	pipelineDepth      int
+	inherentProviders  *inherents.Providers
+
+	runtimeUpgradeDryRun bool
 }
 
 func newBlockImporter(cfg *FullSyncConfig) *blockImporter {
@@ -77,10 +90,20 @@ func newBlockImporter(cfg *FullSyncConfig) *blockImporter {
 		finalityGadget:     cfg.FinalityGadget,
 		blockImportHandler: cfg.BlockImportHandler,
 		telemetry:          cfg.Telemetry,
+		grandpaState:       cfg.GrandpaState,
+		pipelineDepth:      cfg.PipelineDepth,
+		inherentProviders:  inherents.NewImportProviders(),
+
+		runtimeUpgradeDryRun: cfg.RuntimeUpgradeDryRun,
 	}
 }
 
 func (b *blockImporter) importBlock(bd *types.BlockData, origin BlockOrigin) (imported bool, err error) {
+	return b.importPreparedBlock(&preparedBlock{blockData: bd}, origin)
+}
+
+func (b *blockImporter) importPreparedBlock(prepared *preparedBlock, origin BlockOrigin) (imported bool, err error) {
+	bd := prepared.blockData
 	blockAlreadyExists, err := b.blockState.HasHeader(bd.Hash)
 	if err != nil && !errors.Is(err, database.ErrNotFound) {
 		return false, err
@@ -90,7 +113,7 @@ func (b *blockImporter) importBlock(bd *types.BlockData, origin BlockOrigin) (im
 		return false, nil
 	}
 
-	err = b.processBlockData(*bd, origin)
+	err = b.processBlockData(*bd, prepared.justification, origin)
 	if err != nil {
 		logger.Errorf("processing block #%d (%s) failed: %s", bd.Header.Number, bd.Hash, err)
 		return false, err
@@ -102,7 +125,10 @@ func (b *blockImporter) importBlock(bd *types.BlockData, origin BlockOrigin) (im
 // processBlockData processes the BlockData from a BlockResponse and
 // returns the index of the last BlockData it handled on success,
 // or the index of the block data that errored on failure.
-func (b *blockImporter) processBlockData(blockData types.BlockData, origin BlockOrigin) error {
+// The justification verified ahead by the import pipeline, if any, is
+// used instead of verifying the block justification.
+func (b *blockImporter) processBlockData(blockData types.BlockData, verified *verifiedJustification,
+	origin BlockOrigin) error {
 	if blockData.Header != nil {
 		var (
 			hasJustification = blockData.Justification != nil && len(*blockData.Justification) > 0
@@ -112,8 +138,7 @@ func (b *blockImporter) processBlockData(blockData types.BlockData, origin Block
 
 		if hasJustification {
 			var err error
-			round, setID, err = b.finalityGadget.VerifyBlockJustification(
-				blockData.Header.Hash(), blockData.Header.Number, *blockData.Justification)
+			round, setID, err = b.verifyJustification(blockData.Header, *blockData.Justification, verified)
 			if err != nil {
 				return fmt.Errorf("verifying justification: %w", err)
 			}
@@ -151,6 +176,11 @@ func (b *blockImporter) processBlockData(blockData types.BlockData, origin Block
 func (b *blockImporter) processBlockDataWithHeaderAndBody(blockData types.BlockData,
 	origin BlockOrigin) (err error) {
 
+	err = blockData.Header.Digest.ValidateOrder()
+	if err != nil {
+		return fmt.Errorf("validating header digest: %w", err)
+	}
+
 	if origin != networkInitialSync {
 		err = b.babeVerifier.VerifyBlock(blockData.Header)
 		if err != nil {
@@ -171,7 +201,9 @@ func (b *blockImporter) processBlockDataWithHeaderAndBody(blockData types.BlockD
 		Body:   *blockData.Body,
 	}
 
-	err = b.handleBlock(block)
+	// as for the BABE verification, the inherents are not checked during the initial sync
+	checkInherents := origin != networkInitialSync
+	err = b.handleBlock(block, checkInherents)
 	if err != nil {
 		return fmt.Errorf("handling block: %w", err)
 	}
@@ -179,8 +211,8 @@ func (b *blockImporter) processBlockDataWithHeaderAndBody(blockData types.BlockD
 	return nil
 }
 
-// handleBlock executes blocks and writes them to disk
-func (b *blockImporter) handleBlock(block *types.Block) error {
+// handleBlock checks the inherents of blocks if requested, executes them and writes them to disk
+func (b *blockImporter) handleBlock(block *types.Block, checkInherents bool) error {
 	parent, err := b.blockState.GetHeader(block.Header.ParentHash)
 	if err != nil {
 		return fmt.Errorf("%w: %s", errFailedToGetParent, err)
@@ -206,6 +238,15 @@ func (b *blockImporter) handleBlock(block *types.Block) error {
 
 	rt.SetContextStorage(ts)
 
+	if checkInherents {
+		err = b.checkInherents(rt, ts, block, parent)
+		if err != nil {
+			return fmt.Errorf("checking inherents of block %d: %w", block.Header.Number, err)
+		}
+	}
+
+	// the changes are recorded for the import hooks
+	ts.RecordChanges()
 	_, err = rt.ExecuteBlock(block)
 	if err != nil {
 		return fmt.Errorf("failed to execute block %d: %w", block.Header.Number, err)
@@ -217,6 +258,13 @@ func (b *blockImporter) handleBlock(block *types.Block) error {
 	}
 
 	blockHash := block.Header.Hash()
+	if b.runtimeUpgradeDryRun {
+		err = b.dryRunRuntimeUpgrade(block, parent, rt)
+		if err != nil {
+			logger.Warnf("runtime upgrade dry run of block #%d (%s) failed: %s", block.Header.Number, blockHash, err)
+		}
+	}
+
 	b.telemetry.SendMessage(telemetry.NewBlockImport(
 		&blockHash,
 		block.Header.Number,
@@ -224,3 +272,23 @@ func (b *blockImporter) handleBlock(block *types.Block) error {
 
 	return nil
 }
+
+// checkInherents checks the inherents of the block with the runtime at the parent state,
+// using the current time and the slot of the block as inherent data. The changes made to
+// the state by the runtime are rolled back.
+func (b *blockImporter) checkInherents(rt runtime.Instance, ts *rtstorage.TrieState,
+	block *types.Block, parent *types.Header) error {
+	slot, err := types.GetSlotFromHeader(&block.Header)
+	if err != nil {
+		return fmt.Errorf("getting slot from header: %w", err)
+	}
+
+	ts.StartTransaction()
+	defer ts.RollbackTransaction()
+
+	return b.inherentProviders.CheckInherents(rt, block, inherents.Context{
+		Parent:    parent,
+		Slot:      slot,
+		Timestamp: time.Now(),
+	})
+}
@@ -0,0 +1,56 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/babe/inherents"
+	mocksruntime "github.com/ChainSafe/gossamer/lib/runtime/mocks"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_blockImporter_checkInherents(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+
+	preRuntimeDigest, err := types.NewBabeSecondaryPlainPreDigest(0, 7).ToPreRuntimeDigest()
+	require.NoError(t, err)
+	digest := types.NewDigest()
+	require.NoError(t, digest.Add(*preRuntimeDigest))
+
+	parent := &types.Header{Number: 1}
+	block := &types.Block{Header: types.Header{Number: 2, Digest: digest}}
+	ts := rtstorage.NewTrieState(inmemory.NewEmptyTrie())
+
+	instance := mocksruntime.NewMockInstance(ctrl)
+	instance.EXPECT().CheckInherents(block, gomock.Any()).DoAndReturn(
+		func(_ *types.Block, data *types.InherentData) (*types.CheckInherentsResult, error) {
+			expected := types.NewInherentData()
+			require.NoError(t, expected.SetInherent(types.Babeslot, uint64(7)))
+			assert.Equal(t, expected.Data[types.Babeslot.Bytes()], data.Data[types.Babeslot.Bytes()])
+
+			// changes made by the runtime are rolled back
+			require.NoError(t, ts.Put([]byte("key"), []byte("value")))
+
+			return &types.CheckInherentsResult{
+				Errors: types.InherentData{Data: map[[8]byte][]byte{
+					types.Timstap0.Bytes(): {0},
+				}},
+			}, nil
+		})
+
+	importer := &blockImporter{inherentProviders: inherents.NewImportProviders()}
+	err = importer.checkInherents(instance, ts, block, parent)
+	assert.ErrorIs(t, err, inherents.ErrInherentCheckFailed)
+	assert.EqualError(t, err, "inherent check failed: "+
+		"inherent timstap0: the time since the last timestamp is lower than the minimum period")
+	assert.Nil(t, ts.Get([]byte("key")))
+}
@@ -43,10 +43,18 @@ type FullSyncConfig struct {
 	BadBlocks          []string
 	NumOfTasks         int
 	RequestMaker       network.RequestMaker
+	GrandpaState       GrandpaState
+	// PipelineDepth is the number of blocks ahead of the executing block whose
+	// justifications are verified during initial sync, 0 disabling the pipeline.
+	PipelineDepth int
+	// RuntimeUpgradeDryRun executes the child block of a block enacting a runtime
+	// upgrade again with both the previous and the new runtime, alerting on
+	// unexpected state root divergences.
+	RuntimeUpgradeDryRun bool
 }
 
 type importer interface {
-	importBlock(*types.BlockData, BlockOrigin) (imported bool, err error)
+	importBlocks(*Fragment, BlockOrigin) (imported int, err error)
 }
 
 // FullSyncStrategy protocol is the "default" protocol.
@@ -211,15 +219,10 @@ func (f *FullSyncStrategy) Process(results []*SyncTaskResult) (
 
 	// this loop goal is to import ready blocks as well as update the highestFinalized header
 	for nextBlocksToImport.Len() > 0 || len(disjointFragments) > 0 {
-		for blockToImport := range nextBlocksToImport.Iter() {
-			imported, err := f.blockImporter.importBlock(blockToImport, networkInitialSync)
-			if err != nil {
-				return false, nil, nil, fmt.Errorf("while handling ready block: %w", err)
-			}
-
-			if imported {
-				f.syncedBlocks += 1
-			}
+		imported, err := f.blockImporter.importBlocks(nextBlocksToImport, networkInitialSync)
+		f.syncedBlocks += imported
+		if err != nil {
+			return false, nil, nil, fmt.Errorf("while handling ready block: %w", err)
 		}
 
 		nextBlocksToImport = new(Fragment)
@@ -326,6 +329,20 @@ func (f *FullSyncStrategy) OnBlockAnnounce(from peer.ID, msg *network.BlockAnnou
 		}, errBadBlockReceived
 	}
 
+	err = blockAnnounceHeader.Digest.ValidateOrder()
+	if err != nil {
+		logger.Infof("block announce from %s: #%d (%s) has an invalid digest: %s",
+			from, blockAnnounceHeader.Number, blockAnnounceHeaderHash, err)
+
+		return &Change{
+			who: from,
+			rep: peerset.ReputationChange{
+				Value:  peerset.InvalidDigestValue,
+				Reason: peerset.InvalidDigestReason,
+			},
+		}, fmt.Errorf("validating block announce digest: %w", err)
+	}
+
 	if msg.BestBlock {
 		f.peers.update(from, blockAnnounceHeaderHash, uint32(blockAnnounceHeader.Number)) //nolint:gosec
 	}
@@ -465,6 +482,23 @@ func validateResults(results []*SyncTaskResult, badBlocks []string) (repChanges
 		}
 
 		for _, block := range response.BlockData {
+			if block.Header != nil {
+				err := block.Header.Digest.ValidateOrder()
+				if err != nil {
+					logger.Warnf("%s sent block #%d (%s) with invalid digest: %s",
+						result.who, block.Number(), block.Hash, err)
+					repChanges = append(repChanges, Change{
+						who: result.who,
+						rep: peerset.ReputationChange{
+							Value:  peerset.InvalidDigestValue,
+							Reason: peerset.InvalidDigestReason,
+						},
+					})
+
+					continue resultLoop
+				}
+			}
+
 			if slices.Contains(badBlocks, block.Hash.String()) {
 				logger.Warnf("%s sent a known bad block: #%d (%s)",
 					result.who, block.Number(), block.Hash.String())
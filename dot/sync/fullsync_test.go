@@ -234,11 +234,15 @@ func TestFullSyncProcess(t *testing.T) {
 			Return(false, nil).
 			Times(2)
 
+		importedBlocks := 0
 		mockImporter := NewMockimporter(ctrl)
 		mockImporter.EXPECT().
-			importBlock(gomock.AssignableToTypeOf(&types.BlockData{}), networkInitialSync).
-			Return(true, nil).
-			Times(10 + 128 + 128)
+			importBlocks(gomock.AssignableToTypeOf(&Fragment{}), networkInitialSync).
+			DoAndReturn(func(fragment *Fragment, _ BlockOrigin) (int, error) {
+				importedBlocks += fragment.Len()
+				return fragment.Len(), nil
+			}).
+			AnyTimes()
 
 		cfg := &FullSyncConfig{
 			BlockState: mockBlockState,
@@ -289,10 +293,47 @@ func TestFullSyncProcess(t *testing.T) {
 		require.Equal(t, fs.requestQueue.Len(), 0)
 		require.Len(t, fs.unreadyBlocks.incompleteBlocks, 0)
 		require.Len(t, fs.unreadyBlocks.disjointFragments, 0)
+		require.Equal(t, 10+128+128, importedBlocks)
 	})
 }
 
 func TestFullSyncBlockAnnounce(t *testing.T) {
+	t.Run("announce_block_with_invalid_digest", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockBlockState := NewMockBlockState(ctrl)
+		mockBlockState.EXPECT().IsPaused().Return(false)
+
+		fs := NewFullSyncStrategy(&FullSyncConfig{
+			BlockState: mockBlockState,
+		})
+
+		digest := types.NewDigest()
+		err := digest.Add(
+			types.SealDigest{ConsensusEngineID: types.BabeEngineID},
+			types.ConsensusDigest{ConsensusEngineID: types.BabeEngineID},
+		)
+		require.NoError(t, err)
+
+		announcer := peer.ID("announcer")
+		rep, err := fs.OnBlockAnnounce(announcer, &network.BlockAnnounceMessage{
+			ParentHash: common.BytesToHash([]byte{0, 1, 2}),
+			Number:     1,
+			Digest:     digest,
+			BestBlock:  true,
+		})
+		require.ErrorIs(t, err, types.ErrConsensusDigestAfterSeal)
+
+		expectedReputation := &Change{
+			who: announcer,
+			rep: peerset.ReputationChange{
+				Value:  peerset.InvalidDigestValue,
+				Reason: peerset.InvalidDigestReason,
+			},
+		}
+		require.Equal(t, expectedReputation, rep)
+		require.Zero(t, fs.requestQueue.Len())
+	})
+
 	t.Run("announce_a_far_block_without_any_commom_ancestor", func(t *testing.T) {
 		highestFinalizedHeader := &types.Header{
 			ParentHash:     common.BytesToHash([]byte{0}),
@@ -485,3 +526,39 @@ func TestFullSyncBlockAnnounce(t *testing.T) {
 	})
 
 }
+
+func TestValidateResults_invalidDigest(t *testing.T) {
+	t.Parallel()
+
+	digest := types.NewDigest()
+	err := digest.Add(
+		*types.NewBABEPreRuntimeDigest([]byte{1}),
+		*types.NewBABEPreRuntimeDigest([]byte{2}),
+	)
+	require.NoError(t, err)
+
+	header := types.NewHeader(common.Hash{1}, common.Hash{}, common.Hash{}, 1, digest)
+	sender := peer.ID("sender")
+	results := []*SyncTaskResult{{
+		who:       sender,
+		completed: true,
+		request: messages.NewBlockRequest(*messages.NewFromBlock(uint(1)), 1,
+			messages.RequestedDataHeader, messages.Ascending),
+		response: &messages.BlockResponseMessage{
+			BlockData: []*types.BlockData{{Hash: header.Hash(), Header: header}},
+		},
+	}}
+
+	repChanges, peersToBlock, validRes := validateResults(results, nil)
+
+	expectedRepChanges := []Change{{
+		who: sender,
+		rep: peerset.ReputationChange{
+			Value:  peerset.InvalidDigestValue,
+			Reason: peerset.InvalidDigestReason,
+		},
+	}}
+	require.Equal(t, expectedRepChanges, repChanges)
+	require.Empty(t, peersToBlock)
+	require.Empty(t, validRes)
+}
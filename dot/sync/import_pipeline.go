@@ -0,0 +1,171 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var pipelinedJustifications = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: "gossamer_sync",
+	Name:      "pipelined_justifications_total",
+	Help:      "total number of block justifications verified ahead of the execution of their block",
+})
+
+// preparedBlock is a block whose import stages not depending on the state of
+// its parent ran ahead of its execution.
+type preparedBlock struct {
+	blockData *types.BlockData
+	// justification is the block justification verified ahead of its execution,
+	// nil if the block has none or if it could not be verified ahead.
+	justification *verifiedJustification
+}
+
+// verifiedJustification is the result of the verification of a block justification,
+// valid as long as the current GRANDPA set id is the one it was verified with.
+type verifiedJustification struct {
+	round        uint64
+	setID        uint64
+	currentSetID uint64
+}
+
+// importBlocks imports the blocks of the fragment in order and returns the number
+// of blocks imported. If the pipeline depth is not zero, the justifications of the
+// next blocks are verified while a block executes, and the state of the parent of
+// the first block is loaded meanwhile. Blocks still execute one after the other, so
+// a block only executes once the state of its parent is committed.
+func (b *blockImporter) importBlocks(fragment *Fragment, origin BlockOrigin) (imported int, err error) {
+	if b.pipelineDepth == 0 {
+		for bd := range fragment.Iter() {
+			ok, err := b.importBlock(bd, origin)
+			if err != nil {
+				return imported, err
+			}
+			if ok {
+				imported++
+			}
+		}
+		return imported, nil
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+
+	for prepared := range b.prepareBlocks(fragment, done) {
+		ok, err := b.importPreparedBlock(<-prepared, origin)
+		if err != nil {
+			return imported, err
+		}
+		if ok {
+			imported++
+		}
+	}
+	return imported, nil
+}
+
+// prepareBlocks prepares the blocks of the fragment concurrently, up to the pipeline
+// depth blocks ahead of the block being imported, and sends the prepared blocks
+// futures in the order of the fragment. It stops preparing blocks once done is closed.
+func (b *blockImporter) prepareBlocks(fragment *Fragment, done <-chan struct{}) <-chan chan *preparedBlock {
+	futures := make(chan chan *preparedBlock, b.pipelineDepth)
+	go func() {
+		defer close(futures)
+
+		first := true
+		for bd := range fragment.Iter() {
+			future := make(chan *preparedBlock, 1)
+			select {
+			case futures <- future:
+			case <-done:
+				return
+			}
+
+			go func(bd *types.BlockData, prefetchState bool) {
+				future <- b.prepareBlock(bd, prefetchState)
+			}(bd, first)
+			first = false
+		}
+	}()
+	return futures
+}
+
+// prepareBlock runs the import stages of the block not depending on the state of
+// its parent. The justification of the block is verified against the authority
+// sets known before its ancestors are imported, so a justification failing to
+// verify ahead is verified again when the block is imported.
+func (b *blockImporter) prepareBlock(bd *types.BlockData, prefetchState bool) *preparedBlock {
+	prepared := &preparedBlock{blockData: bd}
+	if bd.Header == nil {
+		return prepared
+	}
+
+	if prefetchState {
+		b.prefetchParentState(bd.Header)
+	}
+
+	if bd.Justification == nil || len(*bd.Justification) == 0 || b.grandpaState == nil {
+		return prepared
+	}
+
+	currentSetID, err := b.grandpaState.GetCurrentSetID()
+	if err != nil {
+		logger.Debugf("getting current set id to verify justification of block #%d (%s): %s",
+			bd.Header.Number, bd.Hash, err)
+		return prepared
+	}
+
+	round, setID, err := b.finalityGadget.VerifyBlockJustification(
+		bd.Header.Hash(), bd.Header.Number, *bd.Justification)
+	if err != nil {
+		logger.Debugf("verifying ahead justification of block #%d (%s): %s", bd.Header.Number, bd.Hash, err)
+		return prepared
+	}
+
+	prepared.justification = &verifiedJustification{
+		round:        round,
+		setID:        setID,
+		currentSetID: currentSetID,
+	}
+	return prepared
+}
+
+// prefetchParentState loads the state trie of the parent of the block, if the
+// parent is imported, so it is not loaded from the database when the block executes.
+func (b *blockImporter) prefetchParentState(header *types.Header) {
+	parent, err := b.blockState.GetHeader(header.ParentHash)
+	if err != nil {
+		return
+	}
+
+	b.storageState.Lock()
+	defer b.storageState.Unlock()
+
+	_, err = b.storageState.TrieState(&parent.StateRoot)
+	if err != nil {
+		logger.Debugf("prefetching state of block #%d (%s): %s", parent.Number, header.ParentHash, err)
+	}
+}
+
+// verifyJustification verifies the justification of the block, unless it was
+// verified ahead with the current GRANDPA set id.
+func (b *blockImporter) verifyJustification(header *types.Header, justification []byte,
+	verified *verifiedJustification) (round uint64, setID uint64, err error) {
+	if verified != nil {
+		currentSetID, err := b.grandpaState.GetCurrentSetID()
+		if err != nil {
+			return 0, 0, fmt.Errorf("getting current set id: %w", err)
+		}
+
+		if currentSetID == verified.currentSetID {
+			pipelinedJustifications.Inc()
+			return verified.round, verified.setID, nil
+		}
+	}
+
+	return b.finalityGadget.VerifyBlockJustification(header.Hash(), header.Number, justification)
+}
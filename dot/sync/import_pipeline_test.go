@@ -0,0 +1,226 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_blockImporter_prepareBlock(t *testing.T) {
+	t.Parallel()
+
+	header := &types.Header{ParentHash: common.Hash{1}, Number: 2}
+	justification := []byte{1, 2, 3}
+	errTest := errors.New("test error")
+
+	testCases := map[string]struct {
+		blockData             *types.BlockData
+		prefetchState         bool
+		blockStateBuilder     func(ctrl *gomock.Controller) BlockState
+		storageStateBuilder   func(ctrl *gomock.Controller) StorageState
+		grandpaStateBuilder   func(ctrl *gomock.Controller) GrandpaState
+		finalityGadgetBuilder func(ctrl *gomock.Controller) FinalityGadget
+		justification         *verifiedJustification
+	}{
+		"no_justification": {
+			blockData: &types.BlockData{Hash: header.Hash(), Header: header},
+		},
+		"justification_verified": {
+			blockData: &types.BlockData{Hash: header.Hash(), Header: header, Justification: &justification},
+			grandpaStateBuilder: func(ctrl *gomock.Controller) GrandpaState {
+				mock := NewMockGrandpaState(ctrl)
+				mock.EXPECT().GetCurrentSetID().Return(uint64(3), nil)
+				return mock
+			},
+			finalityGadgetBuilder: func(ctrl *gomock.Controller) FinalityGadget {
+				mock := NewMockFinalityGadget(ctrl)
+				mock.EXPECT().VerifyBlockJustification(header.Hash(), uint(2), justification).
+					Return(uint64(1), uint64(3), nil)
+				return mock
+			},
+			justification: &verifiedJustification{round: 1, setID: 3, currentSetID: 3},
+		},
+		"justification_verification_failed": {
+			blockData: &types.BlockData{Hash: header.Hash(), Header: header, Justification: &justification},
+			grandpaStateBuilder: func(ctrl *gomock.Controller) GrandpaState {
+				mock := NewMockGrandpaState(ctrl)
+				mock.EXPECT().GetCurrentSetID().Return(uint64(3), nil)
+				return mock
+			},
+			finalityGadgetBuilder: func(ctrl *gomock.Controller) FinalityGadget {
+				mock := NewMockFinalityGadget(ctrl)
+				mock.EXPECT().VerifyBlockJustification(header.Hash(), uint(2), justification).
+					Return(uint64(0), uint64(0), errTest)
+				return mock
+			},
+		},
+		"prefetch_parent_state": {
+			blockData:     &types.BlockData{Hash: header.Hash(), Header: header},
+			prefetchState: true,
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				mock := NewMockBlockState(ctrl)
+				mock.EXPECT().GetHeader(header.ParentHash).Return(&types.Header{StateRoot: common.Hash{4}}, nil)
+				return mock
+			},
+			storageStateBuilder: func(ctrl *gomock.Controller) StorageState {
+				mock := NewMockStorageState(ctrl)
+				mock.EXPECT().Lock()
+				mock.EXPECT().TrieState(&common.Hash{4}).Return(nil, nil)
+				mock.EXPECT().Unlock()
+				return mock
+			},
+		},
+		"prefetch_unknown_parent_state": {
+			blockData:     &types.BlockData{Hash: header.Hash(), Header: header},
+			prefetchState: true,
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				mock := NewMockBlockState(ctrl)
+				mock.EXPECT().GetHeader(header.ParentHash).Return(nil, errTest)
+				return mock
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			importer := &blockImporter{}
+			if testCase.blockStateBuilder != nil {
+				importer.blockState = testCase.blockStateBuilder(ctrl)
+			}
+			if testCase.storageStateBuilder != nil {
+				importer.storageState = testCase.storageStateBuilder(ctrl)
+			}
+			if testCase.grandpaStateBuilder != nil {
+				importer.grandpaState = testCase.grandpaStateBuilder(ctrl)
+			}
+			if testCase.finalityGadgetBuilder != nil {
+				importer.finalityGadget = testCase.finalityGadgetBuilder(ctrl)
+			}
+
+			prepared := importer.prepareBlock(testCase.blockData, testCase.prefetchState)
+			assert.Equal(t, testCase.blockData, prepared.blockData)
+			assert.Equal(t, testCase.justification, prepared.justification)
+		})
+	}
+}
+
+func Test_blockImporter_verifyJustification(t *testing.T) {
+	t.Parallel()
+
+	header := &types.Header{Number: 2}
+	justification := []byte{1, 2, 3}
+
+	t.Run("verified_with_current_set_id", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+
+		grandpaState := NewMockGrandpaState(ctrl)
+		grandpaState.EXPECT().GetCurrentSetID().Return(uint64(3), nil)
+		importer := &blockImporter{grandpaState: grandpaState}
+
+		round, setID, err := importer.verifyJustification(header, justification,
+			&verifiedJustification{round: 1, setID: 3, currentSetID: 3})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), round)
+		assert.Equal(t, uint64(3), setID)
+	})
+
+	t.Run("verified_with_previous_set_id", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+
+		grandpaState := NewMockGrandpaState(ctrl)
+		grandpaState.EXPECT().GetCurrentSetID().Return(uint64(4), nil)
+		finalityGadget := NewMockFinalityGadget(ctrl)
+		finalityGadget.EXPECT().VerifyBlockJustification(header.Hash(), uint(2), justification).
+			Return(uint64(2), uint64(4), nil)
+		importer := &blockImporter{grandpaState: grandpaState, finalityGadget: finalityGadget}
+
+		round, setID, err := importer.verifyJustification(header, justification,
+			&verifiedJustification{round: 1, setID: 3, currentSetID: 3})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(2), round)
+		assert.Equal(t, uint64(4), setID)
+	})
+
+	t.Run("not_verified", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+
+		finalityGadget := NewMockFinalityGadget(ctrl)
+		finalityGadget.EXPECT().VerifyBlockJustification(header.Hash(), uint(2), justification).
+			Return(uint64(2), uint64(4), nil)
+		importer := &blockImporter{finalityGadget: finalityGadget}
+
+		round, setID, err := importer.verifyJustification(header, justification, nil)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(2), round)
+		assert.Equal(t, uint64(4), setID)
+	})
+}
+
+func Test_blockImporter_importBlocks(t *testing.T) {
+	t.Parallel()
+
+	blocks := make([]*types.BlockData, 8)
+	for i := range blocks {
+		header := &types.Header{Number: uint(i + 1)}
+		blocks[i] = &types.BlockData{Hash: header.Hash(), Header: header}
+	}
+
+	for _, pipelineDepth := range []int{0, 1, 3} {
+		ctrl := gomock.NewController(t)
+
+		blockState := NewMockBlockState(ctrl)
+		var calls []any
+		for _, bd := range blocks {
+			calls = append(calls, blockState.EXPECT().HasHeader(bd.Hash).Return(true, nil))
+		}
+		gomock.InOrder(calls...)
+		if pipelineDepth > 0 {
+			// the parent state of the first block is prefetched
+			blockState.EXPECT().GetHeader(common.Hash{}).Return(nil, errors.New("not found"))
+		}
+
+		importer := &blockImporter{blockState: blockState, pipelineDepth: pipelineDepth}
+		imported, err := importer.importBlocks(NewFragment(blocks), networkInitialSync)
+		require.NoError(t, err)
+		assert.Equal(t, 0, imported)
+	}
+}
+
+func Test_blockImporter_importBlocks_error(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	blocks := make([]*types.BlockData, 8)
+	for i := range blocks {
+		header := &types.Header{Number: uint(i + 1)}
+		blocks[i] = &types.BlockData{Hash: header.Hash(), Header: header}
+	}
+
+	errTest := errors.New("test error")
+	blockState := NewMockBlockState(ctrl)
+	blockState.EXPECT().GetHeader(common.Hash{}).Return(nil, errTest)
+	gomock.InOrder(
+		blockState.EXPECT().HasHeader(blocks[0].Hash).Return(true, nil),
+		blockState.EXPECT().HasHeader(blocks[1].Hash).Return(false, errTest),
+	)
+
+	importer := &blockImporter{blockState: blockState, pipelineDepth: 2}
+	imported, err := importer.importBlocks(NewFragment(blocks), networkInitialSync)
+	require.ErrorIs(t, err, errTest)
+	assert.Equal(t, 0, imported)
+}
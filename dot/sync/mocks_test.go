@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/ChainSafe/gossamer/dot/sync (interfaces: Telemetry,BlockState,StorageState,TransactionState,BabeVerifier,FinalityGadget,BlockImportHandler,Network)
+// Source: github.com/ChainSafe/gossamer/dot/sync (interfaces: Telemetry,BlockState,StorageState,TransactionState,BabeVerifier,FinalityGadget,GrandpaState,BlockImportHandler,Network)
 //
 // Generated by this command:
 //
-//	mockgen -destination=mocks_test.go -package=sync . Telemetry,BlockState,StorageState,TransactionState,BabeVerifier,FinalityGadget,BlockImportHandler,Network
+//	mockgen -destination=mocks_test.go -package=sync . Telemetry,BlockState,StorageState,TransactionState,BabeVerifier,FinalityGadget,GrandpaState,BlockImportHandler,Network
 //
 
 // Package sync is a generated GoMock package.
@@ -606,6 +606,44 @@ func (mr *MockFinalityGadgetMockRecorder) VerifyBlockJustification(arg0, arg1, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyBlockJustification", reflect.TypeOf((*MockFinalityGadget)(nil).VerifyBlockJustification), arg0, arg1, arg2)
 }
 
+// MockGrandpaState is a mock of GrandpaState interface.
+type MockGrandpaState struct {
+	ctrl     *gomock.Controller
+	recorder *MockGrandpaStateMockRecorder
+}
+
+// MockGrandpaStateMockRecorder is the mock recorder for MockGrandpaState.
+type MockGrandpaStateMockRecorder struct {
+	mock *MockGrandpaState
+}
+
+// NewMockGrandpaState creates a new mock instance.
+func NewMockGrandpaState(ctrl *gomock.Controller) *MockGrandpaState {
+	mock := &MockGrandpaState{ctrl: ctrl}
+	mock.recorder = &MockGrandpaStateMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGrandpaState) EXPECT() *MockGrandpaStateMockRecorder {
+	return m.recorder
+}
+
+// GetCurrentSetID mocks base method.
+func (m *MockGrandpaState) GetCurrentSetID() (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentSetID")
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentSetID indicates an expected call of GetCurrentSetID.
+func (mr *MockGrandpaStateMockRecorder) GetCurrentSetID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentSetID", reflect.TypeOf((*MockGrandpaState)(nil).GetCurrentSetID))
+}
+
 // MockBlockImportHandler is a mock of BlockImportHandler interface.
 type MockBlockImportHandler struct {
 	ctrl     *gomock.Controller
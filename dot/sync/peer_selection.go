@@ -0,0 +1,171 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/network/messages"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// ewmaWeight is the weight of the latest response in the moving averages
+	// of the response latency and success rate of the peers
+	ewmaWeight = 0.2
+	// initialLatency is the response latency assumed for peers not requested yet
+	initialLatency = time.Second
+	// minSuccessRate bounds the success rate of the peers, so peers which failed
+	// many requests are still selected from time to time and can recover
+	minSuccessRate = 0.05
+	// behindTargetFactor scales the weight of the peers whose advertised best
+	// block is below the last block requested
+	behindTargetFactor = 0.1
+	// explorationProbability is the probability of selecting one of the peers
+	// requested fewer than explorationRequests times, regardless of their weight
+	explorationProbability = 0.1
+	explorationRequests    = 3
+)
+
+// peerStats are the statistics of the responses of a peer to our requests
+type peerStats struct {
+	// latency is the exponentially weighted moving average of the latency of the
+	// responses of the peer
+	latency time.Duration
+	// successRate is the exponentially weighted moving average of the success of
+	// the requests to the peer, from 0 to 1
+	successRate float64
+	// bestNumber is the best block number advertised by the peer
+	bestNumber uint32
+	requests   uint
+}
+
+// peerSelector selects the peers to send requests to, at random weighted by the
+// latency and success rate of their responses and by their advertised best block,
+// so most requests go to the fastest peers while a few slow peers do not stall the
+// sync. Peers rarely requested are explored on purpose, so their statistics are known.
+type peerSelector struct {
+	mtx    sync.Mutex
+	stats  map[peer.ID]*peerStats
+	random *rand.Rand
+}
+
+func newPeerSelector() *peerSelector {
+	return &peerSelector{
+		stats:  make(map[peer.ID]*peerStats),
+		random: rand.New(rand.NewSource(time.Now().UnixNano())), //nolint:gosec
+	}
+}
+
+// peerStats returns the statistics of the peer, which must be called with the mutex held
+func (p *peerSelector) peerStats(who peer.ID) *peerStats {
+	stats, ok := p.stats[who]
+	if !ok {
+		stats = &peerStats{
+			latency:     initialLatency,
+			successRate: 1,
+		}
+		p.stats[who] = stats
+	}
+	return stats
+}
+
+// updateBestNumber records the best block number advertised by the peer
+func (p *peerSelector) updateBestNumber(who peer.ID, bestNumber uint32) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	stats := p.peerStats(who)
+	if bestNumber > stats.bestNumber {
+		stats.bestNumber = bestNumber
+	}
+}
+
+// recordResponse updates the statistics of the peer with a request which took the
+// latency given and succeeded or not
+func (p *peerSelector) recordResponse(who peer.ID, latency time.Duration, success bool) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	stats := p.peerStats(who)
+	var successValue float64
+	if success {
+		successValue = 1
+		// the latency of failed requests is not meaningful, as they may time out or
+		// fail right away
+		stats.latency = time.Duration((1-ewmaWeight)*float64(stats.latency) + ewmaWeight*float64(latency))
+	}
+	stats.successRate = (1-ewmaWeight)*stats.successRate + ewmaWeight*successValue
+	stats.requests++
+}
+
+// removePeer forgets the statistics of the peer
+func (p *peerSelector) removePeer(who peer.ID) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	delete(p.stats, who)
+}
+
+// selectPeer returns the index of the peer to send the request to among the candidates,
+// which must not be empty
+func (p *peerSelector) selectPeer(candidates []peer.ID, request messages.P2PMessage) int {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if p.random.Float64() < explorationProbability {
+		unexplored := make([]int, 0, len(candidates))
+		for i, candidate := range candidates {
+			if p.peerStats(candidate).requests < explorationRequests {
+				unexplored = append(unexplored, i)
+			}
+		}
+		if len(unexplored) > 0 {
+			return unexplored[p.random.Intn(len(unexplored))]
+		}
+	}
+
+	target, hasTarget := requestTarget(request)
+	weights := make([]float64, len(candidates))
+	var totalWeight float64
+	for i, candidate := range candidates {
+		stats := p.peerStats(candidate)
+		weight := max(stats.successRate, minSuccessRate) / max(stats.latency.Seconds(), time.Millisecond.Seconds())
+		if hasTarget && stats.bestNumber < target {
+			weight *= behindTargetFactor
+		}
+		weights[i] = weight
+		totalWeight += weight
+	}
+
+	threshold := p.random.Float64() * totalWeight
+	for i, weight := range weights {
+		threshold -= weight
+		if threshold < 0 {
+			return i
+		}
+	}
+	return len(candidates) - 1
+}
+
+// requestTarget returns the number of the last block requested by the request, if
+// it is an ascending block request starting from a block number
+func requestTarget(request messages.P2PMessage) (target uint32, ok bool) {
+	blockRequest, ok := request.(*messages.BlockRequestMessage)
+	if !ok || blockRequest.Direction != messages.Ascending {
+		return 0, false
+	}
+	start, ok := blockRequest.StartingBlock.RawValue().(uint)
+	if !ok {
+		return 0, false
+	}
+
+	target = uint32(start) //nolint:gosec
+	if blockRequest.Max != nil && *blockRequest.Max > 0 {
+		target += *blockRequest.Max - 1
+	}
+	return target, true
+}
@@ -0,0 +1,139 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/network/messages"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPeerSelector_recordResponse(t *testing.T) {
+	t.Parallel()
+
+	selector := newPeerSelector()
+	selector.recordResponse(peer.ID("alice"), 2*time.Second, true)
+	selector.recordResponse(peer.ID("alice"), time.Minute, false)
+
+	stats := selector.stats[peer.ID("alice")]
+	assert.Equal(t, 1200*time.Millisecond, stats.latency)
+	assert.InDelta(t, 0.8, stats.successRate, 1e-9)
+	assert.Equal(t, uint(2), stats.requests)
+
+	selector.removePeer(peer.ID("alice"))
+	assert.Empty(t, selector.stats)
+}
+
+func TestPeerSelector_selectPeer(t *testing.T) {
+	t.Parallel()
+
+	fast, slow, behind := peer.ID("fast"), peer.ID("slow"), peer.ID("behind")
+	selector := newPeerSelector()
+	selector.random = rand.New(rand.NewSource(1)) //nolint:gosec
+	selector.updateBestNumber(fast, 1000)
+	selector.updateBestNumber(slow, 1000)
+	selector.updateBestNumber(behind, 10)
+	for _, who := range []peer.ID{fast, slow, behind} {
+		for i := 0; i < explorationRequests; i++ {
+			selector.recordResponse(who, 100*time.Millisecond, true)
+		}
+	}
+	for i := 0; i < 20; i++ {
+		selector.recordResponse(slow, 10*time.Second, true)
+	}
+	// the best block number advertised only increases
+	selector.updateBestNumber(fast, 500)
+
+	candidates := []peer.ID{fast, slow, behind}
+	request := messages.NewBlockRequest(*messages.NewFromBlock(uint(100)), 128,
+		messages.BootstrapRequestData, messages.Ascending)
+
+	selected := make(map[peer.ID]int)
+	for i := 0; i < 1000; i++ {
+		selected[candidates[selector.selectPeer(candidates, request)]]++
+	}
+	assert.Greater(t, selected[fast], 800)
+	assert.Less(t, selected[slow], 50)
+	assert.Less(t, selected[behind], 150)
+
+	// the peers not requested yet are explored
+	newcomer := peer.ID("newcomer")
+	candidates = append(candidates, newcomer)
+	selected = make(map[peer.ID]int)
+	for i := 0; i < 1000; i++ {
+		selected[candidates[selector.selectPeer(candidates, request)]]++
+	}
+	assert.Greater(t, selected[newcomer], int(1000*explorationProbability/2))
+}
+
+func TestRequestTarget(t *testing.T) {
+	t.Parallel()
+
+	request := messages.NewBlockRequest(*messages.NewFromBlock(uint(100)), 128,
+		messages.BootstrapRequestData, messages.Ascending)
+	target, ok := requestTarget(request)
+	require.True(t, ok)
+	assert.Equal(t, uint32(227), target)
+
+	request = messages.NewBlockRequest(*messages.NewFromBlock(uint(100)), 128,
+		messages.BootstrapRequestData, messages.Descending)
+	_, ok = requestTarget(request)
+	assert.False(t, ok)
+
+	_, ok = requestTarget(&messages.BlockResponseMessage{})
+	assert.False(t, ok)
+}
+
+func TestSyncWorkerPool_submitRequests(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	failing, working := peer.ID("failing"), peer.ID("working")
+
+	pool := newSyncWorkerPool(nil)
+	require.NoError(t, pool.fromBlockAnnounceHandshake(failing, 100))
+	require.NoError(t, pool.fromBlockAnnounceHandshake(working, 100))
+
+	requestMaker := NewMockRequestMaker(ctrl)
+	requestMaker.EXPECT().Do(failing, gomock.Any(), gomock.Any()).Return(errors.New("test error")).MaxTimes(1)
+	requestMaker.EXPECT().Do(working, gomock.Any(), gomock.Any()).Return(nil).Times(3)
+
+	tasks := make([]*SyncTask, 3)
+	for i := range tasks {
+		tasks[i] = &SyncTask{
+			requestMaker: requestMaker,
+			request: messages.NewBlockRequest(*messages.NewFromBlock(uint(i * 128)), 128,
+				messages.BootstrapRequestData, messages.Ascending),
+			response: &messages.BlockResponseMessage{},
+		}
+	}
+
+	// the tasks failed by a worker are retried with the other workers
+	results := pool.submitRequests(tasks)
+	require.Len(t, results, 3)
+	for _, result := range results {
+		assert.True(t, result.completed)
+		assert.Equal(t, working, result.who)
+	}
+
+	// the tasks fail once all the workers failed
+	pool.removeWorker(working)
+	failingRequestMaker := NewMockRequestMaker(ctrl)
+	failingRequestMaker.EXPECT().Do(failing, gomock.Any(), gomock.Any()).Return(errors.New("test error"))
+	for _, task := range tasks {
+		task.requestMaker = failingRequestMaker
+	}
+	results = pool.submitRequests(tasks)
+	require.Len(t, results, 3)
+	for _, result := range results {
+		assert.False(t, result.completed)
+	}
+}
@@ -171,7 +171,7 @@ func (s *SyncService) Stop() error {
 
 func (s *SyncService) HandleBlockAnnounceHandshake(from peer.ID, msg *network.BlockAnnounceHandshake) error {
 	logger.Infof("receiving a block announce handshake from %s", from.String())
-	if err := s.workerPool.fromBlockAnnounceHandshake(from); err != nil {
+	if err := s.workerPool.fromBlockAnnounceHandshake(from, msg.BestBlockNumber); err != nil {
 		return err
 	}
 
@@ -182,6 +182,10 @@ func (s *SyncService) HandleBlockAnnounceHandshake(from peer.ID, msg *network.Bl
 }
 
 func (s *SyncService) HandleBlockAnnounce(from peer.ID, msg *network.BlockAnnounceMessage) error {
+	if msg.BestBlock {
+		s.workerPool.fromBlockAnnounce(from, uint32(msg.Number)) //nolint:gosec
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
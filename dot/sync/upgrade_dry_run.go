@@ -0,0 +1,126 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Divergence patterns reported by the runtime upgrade dry run.
+const (
+	// divergenceNewInstanceFailed is reported when the upgraded runtime fails to
+	// execute again a block it successfully imported.
+	divergenceNewInstanceFailed = "new_instance_failed"
+	// divergenceNewInstanceStateRoot is reported when the upgraded runtime executes
+	// the block to a state root different from the block header one.
+	divergenceNewInstanceStateRoot = "new_instance_state_root"
+	// divergenceOldInstanceSucceeded is reported when the runtime replaced by the
+	// upgrade still executes the block, which is expected to fail.
+	divergenceOldInstanceSucceeded = "old_instance_succeeded"
+)
+
+var (
+	upgradeDryRuns = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "gossamer_sync",
+		Name:      "runtime_upgrade_dry_runs_total",
+		Help:      "total number of blocks executed again in shadow mode after a runtime upgrade",
+	})
+	upgradeDryRunDivergences = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "gossamer_sync",
+		Name:      "runtime_upgrade_divergences_total",
+		Help:      "total number of unexpected results of the runtime upgrade dry runs, by pattern",
+	}, []string{"pattern"})
+)
+
+// dryRunResult is the outcome of a block execution in shadow mode.
+type dryRunResult struct {
+	stateRoot common.Hash
+	err       error
+}
+
+// upgradeDivergences returns the unexpected patterns of the shadow executions
+// of a block by the runtime it was imported with and by the runtime replaced
+// by the upgrade enacted in its parent block.
+func upgradeDivergences(stateRoot common.Hash, oldInstance, newInstance dryRunResult) (patterns []string) {
+	switch {
+	case newInstance.err != nil:
+		patterns = append(patterns, divergenceNewInstanceFailed)
+	case newInstance.stateRoot != stateRoot:
+		patterns = append(patterns, divergenceNewInstanceStateRoot)
+	}
+
+	if oldInstance.err == nil {
+		patterns = append(patterns, divergenceOldInstanceSucceeded)
+	}
+
+	return patterns
+}
+
+// dryRunRuntimeUpgrade executes the given block again in shadow mode, with both
+// the runtime instance it was imported with and the previous runtime instance,
+// when its parent block enacted a runtime upgrade. Unexpected results are logged
+// and counted, the imported state is left untouched.
+func (b *blockImporter) dryRunRuntimeUpgrade(block *types.Block, parent *types.Header,
+	newInstance runtime.Instance) error {
+	if parent.Number == 0 {
+		return nil
+	}
+
+	oldInstance, err := b.blockState.GetRuntime(parent.ParentHash)
+	if err != nil {
+		return fmt.Errorf("getting runtime of block %s: %w", parent.ParentHash, err)
+	}
+
+	if oldInstance.GetCodeHash() == newInstance.GetCodeHash() {
+		return nil
+	}
+
+	upgradeDryRuns.Inc()
+	newResult := b.dryRunBlock(block, parent.StateRoot, newInstance)
+	oldResult := b.dryRunBlock(block, parent.StateRoot, oldInstance)
+
+	patterns := upgradeDivergences(block.Header.StateRoot, oldResult, newResult)
+	for _, pattern := range patterns {
+		upgradeDryRunDivergences.WithLabelValues(pattern).Inc()
+	}
+
+	if len(patterns) == 0 {
+		logger.Infof("runtime upgrade dry run of block #%d (%s) from code %s to code %s passed",
+			block.Header.Number, block.Header.Hash(), oldInstance.GetCodeHash(), newInstance.GetCodeHash())
+		return nil
+	}
+
+	logger.Errorf("runtime upgrade dry run of block #%d (%s) from code %s to code %s diverged %v: "+
+		"new instance state root %s (error: %v), old instance state root %s (error: %v), expected state root %s",
+		block.Header.Number, block.Header.Hash(), oldInstance.GetCodeHash(), newInstance.GetCodeHash(), patterns,
+		newResult.stateRoot, newResult.err, oldResult.stateRoot, oldResult.err, block.Header.StateRoot)
+	return nil
+}
+
+// dryRunBlock executes the block with the given runtime instance on a snapshot
+// of the parent state.
+func (b *blockImporter) dryRunBlock(block *types.Block, parentStateRoot common.Hash,
+	instance runtime.Instance) (result dryRunResult) {
+	ts, err := b.storageState.TrieState(&parentStateRoot)
+	if err != nil {
+		result.err = fmt.Errorf("getting trie state: %w", err)
+		return result
+	}
+
+	instance.SetContextStorage(ts)
+	_, err = instance.ExecuteBlock(block)
+	if err != nil {
+		result.err = fmt.Errorf("executing block: %w", err)
+		return result
+	}
+
+	result.stateRoot, result.err = ts.Root()
+	return result
+}
@@ -0,0 +1,171 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	mocksruntime "github.com/ChainSafe/gossamer/lib/runtime/mocks"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/pkg/trie"
+	"github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_upgradeDivergences(t *testing.T) {
+	t.Parallel()
+
+	stateRoot := common.Hash{1}
+	errTest := errors.New("test error")
+
+	testCases := map[string]struct {
+		oldInstance dryRunResult
+		newInstance dryRunResult
+		patterns    []string
+	}{
+		"expected": {
+			oldInstance: dryRunResult{err: errTest},
+			newInstance: dryRunResult{stateRoot: stateRoot},
+		},
+		"new_instance_failed": {
+			oldInstance: dryRunResult{err: errTest},
+			newInstance: dryRunResult{err: errTest},
+			patterns:    []string{divergenceNewInstanceFailed},
+		},
+		"new_instance_state_root": {
+			oldInstance: dryRunResult{err: errTest},
+			newInstance: dryRunResult{stateRoot: common.Hash{2}},
+			patterns:    []string{divergenceNewInstanceStateRoot},
+		},
+		"old_instance_succeeded": {
+			oldInstance: dryRunResult{stateRoot: stateRoot},
+			newInstance: dryRunResult{stateRoot: stateRoot},
+			patterns:    []string{divergenceOldInstanceSucceeded},
+		},
+		"both_diverged": {
+			oldInstance: dryRunResult{stateRoot: common.Hash{3}},
+			newInstance: dryRunResult{err: errTest},
+			patterns:    []string{divergenceNewInstanceFailed, divergenceOldInstanceSucceeded},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			patterns := upgradeDivergences(stateRoot, testCase.oldInstance, testCase.newInstance)
+			assert.Equal(t, testCase.patterns, patterns)
+		})
+	}
+}
+
+func Test_blockImporter_dryRunRuntimeUpgrade(t *testing.T) {
+	t.Parallel()
+
+	parent := &types.Header{ParentHash: common.Hash{1}, Number: 2, StateRoot: trie.EmptyHash}
+	block := &types.Block{Header: types.Header{ParentHash: parent.Hash(), Number: 3, StateRoot: trie.EmptyHash}}
+	errTest := errors.New("test error")
+
+	testCases := map[string]struct {
+		parent            *types.Header
+		blockStateBuilder func(ctrl *gomock.Controller, newInstance runtime.Instance) BlockState
+		newInstanceCalls  func(mock *mocksruntime.MockInstance)
+		dryRun            bool
+		errWrapped        error
+		errMessage        string
+	}{
+		"genesis_parent": {
+			parent: &types.Header{},
+		},
+		"get_runtime_error": {
+			parent: parent,
+			blockStateBuilder: func(ctrl *gomock.Controller, _ runtime.Instance) BlockState {
+				mock := NewMockBlockState(ctrl)
+				mock.EXPECT().GetRuntime(common.Hash{1}).Return(nil, errTest)
+				return mock
+			},
+			errWrapped: errTest,
+			errMessage: "getting runtime of block " +
+				"0x0100000000000000000000000000000000000000000000000000000000000000: test error",
+		},
+		"no_runtime_upgrade": {
+			parent: parent,
+			blockStateBuilder: func(ctrl *gomock.Controller, newInstance runtime.Instance) BlockState {
+				mock := NewMockBlockState(ctrl)
+				mock.EXPECT().GetRuntime(common.Hash{1}).Return(newInstance, nil)
+				return mock
+			},
+			newInstanceCalls: func(mock *mocksruntime.MockInstance) {
+				mock.EXPECT().GetCodeHash().Return(common.Hash{4}).Times(2)
+			},
+		},
+		"runtime_upgrade": {
+			parent: parent,
+			blockStateBuilder: func(ctrl *gomock.Controller, _ runtime.Instance) BlockState {
+				oldInstance := mocksruntime.NewMockInstance(ctrl)
+				oldInstance.EXPECT().GetCodeHash().Return(common.Hash{5}).AnyTimes()
+				oldInstance.EXPECT().SetContextStorage(gomock.Any())
+				oldInstance.EXPECT().ExecuteBlock(block).Return(nil, errTest)
+
+				mock := NewMockBlockState(ctrl)
+				mock.EXPECT().GetRuntime(common.Hash{1}).Return(oldInstance, nil)
+				return mock
+			},
+			newInstanceCalls: func(mock *mocksruntime.MockInstance) {
+				mock.EXPECT().GetCodeHash().Return(common.Hash{4}).AnyTimes()
+				var storage runtime.Storage
+				mock.EXPECT().SetContextStorage(gomock.Any()).Do(func(s runtime.Storage) {
+					storage = s
+				})
+				// the runtime executes the block in a storage transaction
+				mock.EXPECT().ExecuteBlock(block).DoAndReturn(func(*types.Block) ([]byte, error) {
+					storage.StartTransaction()
+					return nil, nil
+				})
+			},
+			dryRun: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			newInstance := mocksruntime.NewMockInstance(ctrl)
+			if testCase.newInstanceCalls != nil {
+				testCase.newInstanceCalls(newInstance)
+			}
+
+			importer := &blockImporter{}
+			if testCase.blockStateBuilder != nil {
+				importer.blockState = testCase.blockStateBuilder(ctrl, newInstance)
+			}
+
+			if testCase.dryRun {
+				storageState := NewMockStorageState(ctrl)
+				storageState.EXPECT().TrieState(&trie.EmptyHash).
+					DoAndReturn(func(*common.Hash) (*rtstorage.TrieState, error) {
+						return rtstorage.NewTrieState(inmemory.NewEmptyTrie()), nil
+					}).Times(2)
+				importer.storageState = storageState
+			}
+
+			err := importer.dryRunRuntimeUpgrade(block, testCase.parent, newInstance)
+
+			require.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@
 
 import (
 	"errors"
+	"slices"
 	"sync"
 	"time"
 
@@ -43,6 +44,7 @@ type syncWorkerPool struct {
 	network     Network
 	workers     map[peer.ID]struct{}
 	ignorePeers map[peer.ID]struct{}
+	selector    *peerSelector
 }
 
 func newSyncWorkerPool(net Network) *syncWorkerPool {
@@ -50,6 +52,7 @@ func newSyncWorkerPool(net Network) *syncWorkerPool {
 		network:     net,
 		workers:     make(map[peer.ID]struct{}),
 		ignorePeers: make(map[peer.ID]struct{}),
+		selector:    newPeerSelector(),
 	}
 
 	return swp
@@ -57,7 +60,7 @@ func newSyncWorkerPool(net Network) *syncWorkerPool {
 
 // fromBlockAnnounceHandshake stores the peer which send us a handshake as
 // a possible source for requesting blocks/state/warp proofs
-func (s *syncWorkerPool) fromBlockAnnounceHandshake(who peer.ID) error {
+func (s *syncWorkerPool) fromBlockAnnounceHandshake(who peer.ID, bestNumber uint32) error {
 	s.mtx.Lock()
 	defer s.mtx.Unlock()
 
@@ -65,6 +68,8 @@ func (s *syncWorkerPool) fromBlockAnnounceHandshake(who peer.ID) error {
 		return ErrPeerIgnored
 	}
 
+	s.selector.updateBestNumber(who, bestNumber)
+
 	_, has := s.workers[who]
 	if has {
 		return nil
@@ -75,6 +80,76 @@ func (s *syncWorkerPool) fromBlockAnnounceHandshake(who peer.ID) error {
 	return nil
 }
 
+// fromBlockAnnounce records the best block announced by the peer
+func (s *syncWorkerPool) fromBlockAnnounce(who peer.ID, bestNumber uint32) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	if _, ok := s.workers[who]; ok {
+		s.selector.updateBestNumber(who, bestNumber)
+	}
+}
+
+// requestWorkers are the workers available to the tasks of a call to submitRequests.
+// The workers failing a task are not given another task of the call.
+type requestWorkers struct {
+	mtx  sync.Mutex
+	cond *sync.Cond
+	idle []peer.ID
+	// remaining is the number of idle and busy workers which have not failed a task
+	remaining int
+	selector  *peerSelector
+}
+
+func newRequestWorkers(workers []peer.ID, selector *peerSelector) *requestWorkers {
+	w := &requestWorkers{
+		idle:      workers,
+		remaining: len(workers),
+		selector:  selector,
+	}
+	w.cond = sync.NewCond(&w.mtx)
+	return w
+}
+
+// acquire waits for an idle worker and returns the worker selected for the request,
+// or false if all the workers failed
+func (w *requestWorkers) acquire(request messages.P2PMessage) (peer.ID, bool) {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	for len(w.idle) == 0 {
+		if w.remaining == 0 {
+			return "", false
+		}
+		w.cond.Wait()
+	}
+
+	i := w.selector.selectPeer(w.idle, request)
+	who := w.idle[i]
+	w.idle = slices.Delete(w.idle, i, i+1)
+	return who, true
+}
+
+// release returns the worker to the idle workers after it completed a task
+func (w *requestWorkers) release(who peer.ID) {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	w.idle = append(w.idle, who)
+	w.cond.Signal()
+}
+
+// fail removes the worker after it failed a task
+func (w *requestWorkers) fail() {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	w.remaining--
+	if w.remaining == 0 {
+		w.cond.Broadcast()
+	}
+}
+
 // submitRequests blocks until all tasks have been completed or there are no workers
 // left in the pool to retry failed tasks
 func (s *syncWorkerPool) submitRequests(tasks []*SyncTask) []*SyncTaskResult {
@@ -85,11 +160,7 @@ func (s *syncWorkerPool) submitRequests(tasks []*SyncTask) []*SyncTaskResult {
 	s.mtx.RLock()
 	defer s.mtx.RUnlock()
 
-	pids := maps.Keys(s.workers)
-	workerPool := make(chan peer.ID, len(pids))
-	for _, worker := range pids {
-		workerPool <- worker
-	}
+	workers := newRequestWorkers(maps.Keys(s.workers), s.selector)
 
 	failedTasks := make(chan *SyncTask, len(tasks))
 	results := make(chan *SyncTaskResult, len(tasks))
@@ -99,7 +170,7 @@ func (s *syncWorkerPool) submitRequests(tasks []*SyncTask) []*SyncTaskResult {
 		wg.Add(1)
 		go func(t *SyncTask) {
 			defer wg.Done()
-			executeTask(t, workerPool, failedTasks, results)
+			executeTask(t, workers, failedTasks, results)
 		}(task)
 	}
 
@@ -107,19 +178,11 @@ func (s *syncWorkerPool) submitRequests(tasks []*SyncTask) []*SyncTaskResult {
 	go func() {
 		defer wg.Done()
 		for task := range failedTasks {
-			if len(workerPool) > 0 {
-				wg.Add(1)
-				go func(t *SyncTask) {
-					defer wg.Done()
-					executeTask(t, workerPool, failedTasks, results)
-				}(task)
-			} else {
-				results <- &SyncTaskResult{
-					completed: false,
-					request:   task.request,
-					response:  nil,
-				}
-			}
+			wg.Add(1)
+			go func(t *SyncTask) {
+				defer wg.Done()
+				executeTask(t, workers, failedTasks, results)
+			}(task)
 		}
 	}()
 
@@ -141,23 +204,33 @@ func (s *syncWorkerPool) submitRequests(tasks []*SyncTask) []*SyncTaskResult {
 	}(len(tasks))
 
 	wg.Wait()
-	close(workerPool)
 	close(results)
 
 	return <-allResults
 }
 
-func executeTask(task *SyncTask, workerPool chan peer.ID, failedTasks chan *SyncTask, results chan *SyncTaskResult) {
-	worker := <-workerPool
+func executeTask(task *SyncTask, workers *requestWorkers, failedTasks chan *SyncTask, results chan *SyncTaskResult) {
+	worker, ok := workers.acquire(task.request)
+	if !ok {
+		results <- &SyncTaskResult{
+			completed: false,
+			request:   task.request,
+			response:  nil,
+		}
+		return
+	}
 	logger.Infof("[EXECUTING] worker %s", worker)
 
+	startedAt := time.Now()
 	err := task.requestMaker.Do(worker, task.request, task.response)
+	workers.selector.recordResponse(worker, time.Since(startedAt), err == nil)
 	if err != nil {
 		logger.Infof("[ERR] worker %s, request: %s, err: %s", worker, task.request.String(), err.Error())
+		workers.fail()
 		failedTasks <- task
 	} else {
 		logger.Infof("[FINISHED] worker %s, request: %s", worker, task.request.String())
-		workerPool <- worker
+		workers.release(worker)
 		results <- &SyncTaskResult{
 			who:       worker,
 			completed: true,
@@ -173,6 +246,7 @@ func (s *syncWorkerPool) ignorePeerAsWorker(who peer.ID) {
 
 	delete(s.workers, who)
 	s.ignorePeers[who] = struct{}{}
+	s.selector.removePeer(who)
 }
 
 func (s *syncWorkerPool) removeWorker(who peer.ID) {
@@ -180,6 +254,7 @@ func (s *syncWorkerPool) removeWorker(who peer.ID) {
 	defer s.mtx.Unlock()
 
 	delete(s.workers, who)
+	s.selector.removePeer(who)
 }
 
 // totalWorkers only returns available or busy workers
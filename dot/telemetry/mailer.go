@@ -7,16 +7,19 @@
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"sync"
 	"time"
 
 	"github.com/ChainSafe/gossamer/lib/genesis"
+	"github.com/ChainSafe/gossamer/lib/services"
 	"github.com/gorilla/websocket"
 )
 
 var ErrTimoutMessageSending = errors.New("timeout sending telemetry message")
 
 type telemetryConnection struct {
+	endpoint  string
 	wsconn    *websocket.Conn
 	verbosity int
 	sync.Mutex
@@ -26,17 +29,20 @@ type telemetryConnection struct {
 type Mailer struct {
 	mutex *sync.Mutex
 
-	logger Logger
+	logger     Logger
+	supervisor *services.Supervisor
 
 	connections []*telemetryConnection
 }
 
-// BootstrapMailer setup the mailer, the connections and start the async message shipment
-func BootstrapMailer(ctx context.Context, conns []*genesis.TelemetryEndpoint, logger Logger) (
-	mailer *Mailer, err error) {
+// BootstrapMailer setup the mailer, the connections and start the async message shipment.
+// The messages are shipped in goroutines run by the supervisor, which can be nil.
+func BootstrapMailer(ctx context.Context, conns []*genesis.TelemetryEndpoint, logger Logger,
+	supervisor *services.Supervisor) (mailer *Mailer, err error) {
 	mailer = &Mailer{
-		mutex:  new(sync.Mutex),
-		logger: logger,
+		mutex:      new(sync.Mutex),
+		logger:     logger,
+		supervisor: supervisor,
 	}
 
 	for _, v := range conns {
@@ -64,6 +70,7 @@ func BootstrapMailer(ctx context.Context, conns []*genesis.TelemetryEndpoint, lo
 			}
 
 			mailer.connections = append(mailer.connections, &telemetryConnection{
+				endpoint:  v.Endpoint,
 				wsconn:    conn,
 				verbosity: v.Verbosity,
 			})
@@ -71,6 +78,8 @@ func BootstrapMailer(ctx context.Context, conns []*genesis.TelemetryEndpoint, lo
 		}
 	}
 
+	supervisor.SetSnapshotter(mailer)
+
 	return mailer, nil
 }
 
@@ -79,7 +88,18 @@ func (m *Mailer) SendMessage(msg json.Marshaler) {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
-	go m.shipTelemetryMessage(msg)
+	m.supervisor.Go(func() {
+		m.shipTelemetryMessage(msg)
+	})
+}
+
+// Snapshot returns a description of the telemetry connections of the mailer.
+func (m *Mailer) Snapshot() string {
+	endpoints := make([]string, len(m.connections))
+	for i, conn := range m.connections {
+		endpoints[i] = fmt.Sprintf("%s (verbosity %d)", conn.endpoint, conn.verbosity)
+	}
+	return fmt.Sprintf("%d telemetry connections: %v", len(m.connections), endpoints)
 }
 
 func (m *Mailer) shipTelemetryMessage(msg json.Marshaler) {
@@ -48,7 +48,7 @@ func newTestMailer(t *testing.T, handler http.HandlerFunc) (mailer *Mailer) {
 
 	logger := log.New(log.SetWriter(io.Discard))
 
-	mailer, err := BootstrapMailer(context.Background(), testEndpoints, logger)
+	mailer, err := BootstrapMailer(context.Background(), testEndpoints, logger, nil)
 	require.NoError(t, err)
 
 	return mailer
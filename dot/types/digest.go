@@ -4,12 +4,25 @@
 package types
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/ChainSafe/gossamer/pkg/scale"
 )
 
+var (
+	// ErrInvalidDigestOrder is wrapped by the errors of digests not ordered as mandated by the spec.
+	ErrInvalidDigestOrder = errors.New("invalid digest order")
+	// ErrSealNotLast is returned when a seal is followed by other digest items.
+	ErrSealNotLast = fmt.Errorf("%w: seal is not the last digest item", ErrInvalidDigestOrder)
+	// ErrConsensusDigestAfterSeal is returned when a consensus digest follows a seal.
+	ErrConsensusDigestAfterSeal = fmt.Errorf("%w: consensus digest after seal", ErrInvalidDigestOrder)
+	// ErrDuplicatePreRuntimeDigest is returned when there is more than one
+	// pre-runtime digest for the same consensus engine.
+	ErrDuplicatePreRuntimeDigest = fmt.Errorf("%w: duplicate pre-runtime digest", ErrInvalidDigestOrder)
+)
+
 // DigestItem is a varying date type that holds type identifier and a scaled encoded message payload.
 type digestItem struct {
 	ConsensusEngineID ConsensusEngineID
@@ -111,6 +124,41 @@ func (d *Digest) Add(values ...any) (err error) {
 	return nil
 }
 
+// ValidateOrder checks the digest items are ordered as mandated by the spec:
+// there is at most one pre-runtime digest per consensus engine and the seal,
+// if any, is the last digest item.
+func (d Digest) ValidateOrder() error {
+	preRuntimeEngines := make(map[ConsensusEngineID]struct{})
+	sealIndex := -1
+	for i, item := range d {
+		value, err := item.Value()
+		if err != nil {
+			return fmt.Errorf("getting digest item %d value: %w", i, err)
+		}
+
+		if sealIndex >= 0 {
+			if _, ok := value.(ConsensusDigest); ok {
+				return fmt.Errorf("%w: consensus digest at index %d after seal at index %d",
+					ErrConsensusDigestAfterSeal, i, sealIndex)
+			}
+			return fmt.Errorf("%w: seal at index %d of %d digest items", ErrSealNotLast, sealIndex, len(d))
+		}
+
+		switch value := value.(type) {
+		case PreRuntimeDigest:
+			_, has := preRuntimeEngines[value.ConsensusEngineID]
+			if has {
+				return fmt.Errorf("%w: for engine %s at index %d",
+					ErrDuplicatePreRuntimeDigest, value.ConsensusEngineID.ToBytes(), i)
+			}
+			preRuntimeEngines[value.ConsensusEngineID] = struct{}{}
+		case SealDigest:
+			sealIndex = i
+		}
+	}
+	return nil
+}
+
 func (d *Digest) String() string {
 	stringTypes := make([]string, len(*d))
 	for i, vdt := range *d {
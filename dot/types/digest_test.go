@@ -244,3 +244,68 @@ func TestSealDigest(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, diValue, vValue)
 }
+
+func TestDigest_ValidateOrder(t *testing.T) {
+	t.Parallel()
+
+	babePreRuntime := PreRuntimeDigest{ConsensusEngineID: BabeEngineID, Data: []byte{1}}
+	otherPreRuntime := PreRuntimeDigest{ConsensusEngineID: ConsensusEngineID{'a', 'u', 'r', 'a'}, Data: []byte{2}}
+	consensus := ConsensusDigest{ConsensusEngineID: BabeEngineID, Data: []byte{3}}
+	seal := SealDigest{ConsensusEngineID: BabeEngineID, Data: []byte{4}}
+	newDigest := func(items ...any) Digest {
+		digest := NewDigest()
+		err := digest.Add(items...)
+		require.NoError(t, err)
+		return digest
+	}
+
+	testCases := map[string]struct {
+		digest     Digest
+		errWrapped error
+		errMessage string
+	}{
+		"empty": {
+			digest: NewDigest(),
+		},
+		"unsealed": {
+			digest: newDigest(babePreRuntime, consensus, RuntimeEnvironmentUpdated{}),
+		},
+		"sealed": {
+			digest: newDigest(babePreRuntime, otherPreRuntime, consensus, seal),
+		},
+		"duplicate_pre_runtime": {
+			digest:     newDigest(babePreRuntime, consensus, babePreRuntime, seal),
+			errWrapped: ErrDuplicatePreRuntimeDigest,
+			errMessage: "invalid digest order: duplicate pre-runtime digest: for engine BABE at index 2",
+		},
+		"consensus_after_seal": {
+			digest:     newDigest(babePreRuntime, seal, consensus),
+			errWrapped: ErrConsensusDigestAfterSeal,
+			errMessage: "invalid digest order: consensus digest after seal: " +
+				"consensus digest at index 2 after seal at index 1",
+		},
+		"seal_not_last": {
+			digest:     newDigest(babePreRuntime, seal, seal),
+			errWrapped: ErrSealNotLast,
+			errMessage: "invalid digest order: seal is not the last digest item: seal at index 1 of 3 digest items",
+		},
+		"invalid_item": {
+			digest:     append(newDigest(babePreRuntime), NewDigestItem()),
+			errWrapped: scale.ErrUnsupportedVaryingDataTypeValue,
+			errMessage: "getting digest item 1 value: unsupported VaryingDataTypeValue",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := testCase.digest.ValidateOrder()
+			require.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				require.EqualError(t, err, testCase.errMessage)
+			}
+		})
+	}
+}
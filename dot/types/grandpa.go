@@ -172,6 +172,15 @@ type FinalisationInfo struct {
 	SetID  uint64
 }
 
+// GrandpaFinalityProof proves the finality of a block with the justification of
+// a block finalised at or after it, and the headers after the proven block up to
+// the justified block.
+type GrandpaFinalityProof struct {
+	Block          common.Hash
+	Justification  []byte
+	UnknownHeaders []Header
+}
+
 // GrandpaSignedVote represents a signed precommit message for a finalised block
 type GrandpaSignedVote struct {
 	Vote        GrandpaVote
@@ -51,6 +51,12 @@ func (ii InherentIdentifier) Bytes() [8]byte {
 	return kb
 }
 
+// String returns the inherent identifier as the string of its bytes.
+func (ii InherentIdentifier) String() string {
+	kb := ii.Bytes()
+	return string(kb[:])
+}
+
 // InherentData contains a mapping of inherent keys to values
 // keys must be 8 bytes, values are a scale-encoded byte array
 type InherentData struct {
@@ -125,3 +131,15 @@ func (d *InherentData) Encode() ([]byte, error) {
 
 	return buffer.Bytes(), nil
 }
+
+// CheckInherentsResult is the result of checking the inherents of a block
+// with the runtime API function BlockBuilder_check_inherents.
+type CheckInherentsResult struct {
+	// Okay is true if all the inherents are valid.
+	Okay bool
+	// FatalError is true if one of the errors is fatal, in which case
+	// the inherents following the failing one were not checked.
+	FatalError bool
+	// Errors contains the SCALE encoded errors by inherent identifier.
+	Errors InherentData
+}
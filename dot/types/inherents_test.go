@@ -6,6 +6,7 @@
 import (
 	"testing"
 
+	"github.com/ChainSafe/gossamer/pkg/scale"
 	"github.com/stretchr/testify/require"
 )
 
@@ -43,3 +44,22 @@ func TestInherentDataMarshal(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckInherentsResultUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	// okay false, fatal error true, and the timestamp error TooFarInFuture
+	encoded := []byte{0, 1, 4, 116, 105, 109, 115, 116, 97, 112, 48, 4, 1}
+
+	var result CheckInherentsResult
+	err := scale.Unmarshal(encoded, &result)
+	require.NoError(t, err)
+
+	expected := CheckInherentsResult{
+		FatalError: true,
+		Errors: InherentData{
+			Data: map[[8]byte][]byte{Timstap0.Bytes(): {1}},
+		},
+	}
+	require.Equal(t, expected, result)
+}
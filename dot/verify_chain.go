@@ -0,0 +1,295 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dot
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	cfg "github.com/ChainSafe/gossamer/config"
+	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/ChainSafe/gossamer/lib/babe"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/grandpa"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	wazero_runtime "github.com/ChainSafe/gossamer/lib/runtime/wazero"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/ChainSafe/gossamer/pkg/trie"
+	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+)
+
+// Checks run on each block by the chain verification.
+const (
+	CheckSeal           = "seal"
+	CheckExtrinsicsRoot = "extrinsics root"
+	CheckExecution      = "execution"
+	CheckStateRoot      = "state root"
+	CheckJustification  = "justification"
+)
+
+var (
+	errVerifyGenesis = errors.New("the genesis block cannot be verified, the range must start at block 1 or later")
+	// errVerificationSetup wraps the errors preventing a block from being verified,
+	// which are not a divergence of the block itself.
+	errVerificationSetup = errors.New("cannot verify block")
+)
+
+// ChainDivergence is the first block of a verified range failing one of the checks.
+type ChainDivergence struct {
+	Number uint
+	Hash   common.Hash
+	// Check is the check the block failed.
+	Check string
+	Err   error
+}
+
+// ChainVerification is the result of the verification of a range of the canonical chain.
+type ChainVerification struct {
+	From uint
+	To   uint
+	// Blocks is the number of blocks which passed all the checks.
+	Blocks uint
+	// Justifications is the number of justifications verified.
+	Justifications uint
+	// Divergence is the first block failing a check, or nil if all the blocks of the range passed them.
+	Divergence *ChainDivergence
+}
+
+// chainVerifier re-executes the blocks of the chain on the state of their parent.
+type chainVerifier struct {
+	stateSrvc    *state.Service
+	verification *babe.VerificationManager
+
+	// instance is the runtime instance of the code of the last parent state,
+	// re-created only when the code changes.
+	instance *wazero_runtime.Instance
+	layout   trie.TrieLayout
+}
+
+// VerifyChain re-executes the blocks of the canonical chain from and to the given block numbers,
+// both inclusive, checking their seal, extrinsics root, execution, state root and justification,
+// and stops at the first block failing a check. The range ends at the best block if to is 0.
+// The node must be stopped while the chain is verified.
+func VerifyChain(config *cfg.Config, from, to uint) (result ChainVerification, err error) {
+	if from == 0 {
+		return result, errVerifyGenesis
+	}
+
+	stateSrvc, err := nodeBuilder{}.createStateService(config)
+	if err != nil {
+		return result, fmt.Errorf("creating state service: %w", err)
+	}
+
+	err = stateSrvc.Start()
+	if err != nil {
+		return result, fmt.Errorf("starting state service: %w", err)
+	}
+	defer func() {
+		stopErr := stateSrvc.Stop()
+		if err == nil && stopErr != nil {
+			err = fmt.Errorf("stopping state service: %w", stopErr)
+		}
+	}()
+
+	bestHeader, err := stateSrvc.Block.BestBlockHeader()
+	if err != nil {
+		return result, fmt.Errorf("getting best block header: %w", err)
+	}
+	if to == 0 || to > bestHeader.Number {
+		to = bestHeader.Number
+	}
+	if from > to {
+		return result, fmt.Errorf("start block %d is after end block %d", from, to)
+	}
+	result.From, result.To = from, to
+
+	verifier := &chainVerifier{
+		stateSrvc:    stateSrvc,
+		verification: babe.NewVerificationManager(stateSrvc.Block, stateSrvc.Slot, stateSrvc.Epoch),
+	}
+	defer func() {
+		if verifier.instance != nil {
+			verifier.instance.Stop()
+		}
+	}()
+
+	// the iterator starts at the parent of the first block to verify
+	iterator, err := stateSrvc.Block.CanonicalIterator(from - 1)
+	if err != nil {
+		return result, fmt.Errorf("creating block iterator: %w", err)
+	}
+	defer iterator.Release()
+
+	var parent *types.Header
+	for iterator.Next() && iterator.Header().Number <= to {
+		header := iterator.Header()
+		if parent == nil {
+			parent = header
+			continue
+		}
+
+		body, err := iterator.Body()
+		if err != nil {
+			return result, fmt.Errorf("getting body of block #%d (%s): %w", header.Number, iterator.Hash(), err)
+		}
+
+		check, justified, err := verifier.verifyBlock(parent, iterator.Hash(), header, body)
+		if errors.Is(err, errVerificationSetup) {
+			return result, err
+		} else if err != nil {
+			result.Divergence = &ChainDivergence{
+				Number: header.Number,
+				Hash:   iterator.Hash(),
+				Check:  check,
+				Err:    err,
+			}
+			return result, nil
+		}
+
+		result.Blocks++
+		if justified {
+			result.Justifications++
+		}
+		if header.Number%1000 == 0 {
+			logger.Infof("verified blocks up to #%d (%s)", header.Number, iterator.Hash())
+		}
+		parent = header
+	}
+
+	if iterator.Err() != nil {
+		return result, fmt.Errorf("iterating over blocks: %w", iterator.Err())
+	}
+	return result, nil
+}
+
+// verifyBlock runs the checks on the given block, and returns the check failed if any,
+// and whether the block has a justification which was verified.
+func (v *chainVerifier) verifyBlock(parent *types.Header, hash common.Hash, header *types.Header,
+	body *types.Body) (check string, justified bool, err error) {
+	// the seal is verified on a copy, since the verification removes it from the header temporarily
+	headerCopy, err := header.DeepCopy()
+	if err != nil {
+		return "", false, fmt.Errorf("%w #%d: copying header: %w", errVerificationSetup, header.Number, err)
+	}
+	err = v.verification.VerifyBlock(headerCopy)
+	if err != nil {
+		return CheckSeal, false, err
+	}
+
+	ts, err := v.stateSrvc.Storage.TrieState(&parent.StateRoot)
+	if err != nil {
+		return "", false, fmt.Errorf("%w #%d: getting state of parent block, it may have been pruned: %w",
+			errVerificationSetup, header.Number, err)
+	}
+
+	err = v.setRuntime(ts)
+	if err != nil {
+		return "", false, fmt.Errorf("%w #%d: %w", errVerificationSetup, header.Number, err)
+	}
+
+	extrinsicsRoot, err := computeExtrinsicsRoot(body, v.layout)
+	if err != nil {
+		return CheckExtrinsicsRoot, false, err
+	}
+	if extrinsicsRoot != header.ExtrinsicsRoot {
+		return CheckExtrinsicsRoot, false, fmt.Errorf("computed extrinsics root %s, header extrinsics root %s",
+			extrinsicsRoot, header.ExtrinsicsRoot)
+	}
+
+	block := types.NewBlock(*header, *body)
+	_, err = v.instance.ExecuteBlock(&block)
+	if err != nil {
+		return CheckExecution, false, err
+	}
+
+	stateRoot, err := ts.Root()
+	if err != nil {
+		return CheckStateRoot, false, err
+	}
+	if stateRoot != header.StateRoot {
+		return CheckStateRoot, false, fmt.Errorf("computed state root %s, header state root %s",
+			stateRoot, header.StateRoot)
+	}
+
+	justified, err = v.stateSrvc.Block.HasJustification(hash)
+	if err != nil {
+		return "", false, fmt.Errorf("%w #%d: checking justification: %w", errVerificationSetup, header.Number, err)
+	}
+	if !justified {
+		return "", false, nil
+	}
+
+	justification, err := v.stateSrvc.Block.GetJustification(hash)
+	if err != nil {
+		return "", false, fmt.Errorf("%w #%d: getting justification: %w", errVerificationSetup, header.Number, err)
+	}
+
+	_, _, err = grandpa.VerifyJustification(v.stateSrvc.Grandpa, hash, header.Number, justification)
+	if err != nil {
+		return CheckJustification, false, err
+	}
+	return "", true, nil
+}
+
+// setRuntime sets the trie state as the storage of the runtime instance, re-creating
+// the instance first if the runtime code of the trie state changed.
+func (v *chainVerifier) setRuntime(ts *rtstorage.TrieState) error {
+	codeHash, err := ts.LoadCodeHash()
+	if err != nil {
+		return fmt.Errorf("loading code hash: %w", err)
+	}
+
+	if v.instance == nil || v.instance.GetCodeHash() != codeHash {
+		if v.instance != nil {
+			v.instance.Stop()
+			v.instance = nil
+		}
+
+		instance, err := wazero_runtime.NewInstance(ts.LoadCode(), wazero_runtime.Config{
+			Storage:  ts,
+			LogLvl:   log.Critical,
+			CodeHash: codeHash,
+		})
+		if err != nil {
+			return fmt.Errorf("creating runtime instance of code %s: %w", codeHash, err)
+		}
+		v.instance = instance
+
+		version, err := instance.Version()
+		if err != nil {
+			return fmt.Errorf("getting runtime version of code %s: %w", codeHash, err)
+		}
+
+		v.layout, err = trie.ParseVersion(version.StateVersion)
+		if err != nil {
+			return fmt.Errorf("parsing state version of code %s: %w", codeHash, err)
+		}
+	}
+
+	v.instance.SetContextStorage(ts)
+	return nil
+}
+
+// computeExtrinsicsRoot returns the root of the ordered trie of the encoded extrinsics of the body,
+// keyed by their compact encoded index, as computed by the runtime.
+func computeExtrinsicsRoot(body *types.Body, layout trie.TrieLayout) (common.Hash, error) {
+	extrinsics, err := body.AsEncodedExtrinsics()
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("encoding extrinsics: %w", err)
+	}
+
+	entries := make(trie.Entries, len(extrinsics))
+	for i, extrinsic := range extrinsics {
+		key, err := scale.Marshal(big.NewInt(int64(i)))
+		if err != nil {
+			return common.Hash{}, fmt.Errorf("encoding extrinsic index %d: %w", i, err)
+		}
+		entries[i] = trie.Entry{Key: key, Value: extrinsic}
+	}
+
+	return layout.Root(inmemory_trie.NewEmptyTrie(), entries)
+}
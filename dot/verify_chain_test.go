@@ -0,0 +1,43 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dot
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/ChainSafe/gossamer/pkg/trie"
+	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_computeExtrinsicsRoot(t *testing.T) {
+	t.Parallel()
+
+	root, err := computeExtrinsicsRoot(types.NewBody(nil), trie.V0)
+	require.NoError(t, err)
+	assert.Equal(t, trie.EmptyHash, root)
+
+	extrinsics := []types.Extrinsic{{1, 2, 3}, {4, 5}}
+	root, err = computeExtrinsicsRoot(types.NewBody(extrinsics), trie.V1)
+	require.NoError(t, err)
+
+	expectedTrie := inmemory_trie.NewEmptyTrie()
+	expectedTrie.SetVersion(trie.V1)
+	for i, extrinsic := range extrinsics {
+		encoded, err := scale.Marshal(extrinsic)
+		require.NoError(t, err)
+		require.NoError(t, expectedTrie.Put([]byte{byte(i << 2)}, encoded))
+	}
+	assert.Equal(t, expectedTrie.MustHash(), root)
+}
+
+func TestVerifyChain_genesis(t *testing.T) {
+	t.Parallel()
+
+	_, err := VerifyChain(nil, 0, 10)
+	assert.ErrorIs(t, err, errVerifyGenesis)
+}
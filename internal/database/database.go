@@ -52,6 +52,10 @@ type Database interface {
 	NewBatch() Batch
 	NewIterator() (Iterator, error)
 	NewPrefixIterator(prefix []byte) (Iterator, error)
+	CompactionStats() CompactionStats
+	// Sync syncs the write-ahead log of the database to disk, so the
+	// previous writes are not lost if the machine crashes.
+	Sync() error
 }
 
 type Table interface {
@@ -61,6 +65,7 @@ type Table interface {
 	NewBatch() Batch
 	NewIterator() (Iterator, error)
 	NewPrefixIterator(prefix []byte) (Iterator, error)
+	Sync() error
 }
 
 const DefaultDatabaseDir = "db"
@@ -409,6 +409,20 @@ func (mr *MockDatabaseMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockDatabase)(nil).Close))
 }
 
+// CompactionStats mocks base method.
+func (m *MockDatabase) CompactionStats() database.CompactionStats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompactionStats")
+	ret0, _ := ret[0].(database.CompactionStats)
+	return ret0
+}
+
+// CompactionStats indicates an expected call of CompactionStats.
+func (mr *MockDatabaseMockRecorder) CompactionStats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompactionStats", reflect.TypeOf((*MockDatabase)(nil).CompactionStats))
+}
+
 // Del mocks base method.
 func (m *MockDatabase) Del(key []byte) error {
 	m.ctrl.T.Helper()
@@ -539,6 +553,20 @@ func (mr *MockDatabaseMockRecorder) Put(key, value any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockDatabase)(nil).Put), key, value)
 }
 
+// Sync mocks base method.
+func (m *MockDatabase) Sync() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sync")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Sync indicates an expected call of Sync.
+func (mr *MockDatabaseMockRecorder) Sync() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sync", reflect.TypeOf((*MockDatabase)(nil).Sync))
+}
+
 // MockTable is a mock of Table interface.
 type MockTable struct {
 	ctrl     *gomock.Controller
@@ -676,3 +704,17 @@ func (mr *MockTableMockRecorder) Put(key, value any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockTable)(nil).Put), key, value)
 }
+
+// Sync mocks base method.
+func (m *MockTable) Sync() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sync")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Sync indicates an expected call of Sync.
+func (mr *MockTableMockRecorder) Sync() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sync", reflect.TypeOf((*MockTable)(nil).Sync))
+}
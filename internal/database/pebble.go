@@ -110,6 +110,15 @@ func (p *PebbleDB) Flush() error {
 	return nil
 }
 
+func (p *PebbleDB) Sync() error {
+	err := p.db.LogData(nil, pebble.Sync)
+	if err != nil {
+		return fmt.Errorf("syncing database: %w", err)
+	}
+
+	return nil
+}
+
 // NewBatch returns an implementation of Batch interface using the
 // internal database
 func (p *PebbleDB) NewBatch() Batch {
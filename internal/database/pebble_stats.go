@@ -0,0 +1,53 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package database
+
+// CompactionStats are the compaction statistics of the database.
+type CompactionStats struct {
+	// DiskSpaceUsage is the number of bytes used by the database on disk.
+	DiskSpaceUsage uint64 `json:"diskSpaceUsage"`
+	// Count is the number of compactions run since the database was opened.
+	Count int64 `json:"count"`
+	// InProgress is the number of compactions in progress.
+	InProgress int64 `json:"inProgress"`
+	// EstimatedDebt is the number of bytes to compact for the LSM tree to be stable.
+	EstimatedDebt uint64       `json:"estimatedDebt"`
+	Levels        []LevelStats `json:"levels"`
+}
+
+// LevelStats are the statistics of a level of the LSM tree of the database.
+type LevelStats struct {
+	Level int   `json:"level"`
+	Files int64 `json:"files"`
+	Size  int64 `json:"size"`
+	// Score is the compaction score of the level, the level being
+	// compacted when it is at least 1.
+	Score float64 `json:"score"`
+	// BytesCompacted is the number of bytes written by compactions
+	// to the level since the database was opened.
+	BytesCompacted uint64 `json:"bytesCompacted"`
+}
+
+// CompactionStats returns the compaction statistics of the database.
+func (p *PebbleDB) CompactionStats() CompactionStats {
+	metrics := p.db.Metrics()
+	stats := CompactionStats{
+		DiskSpaceUsage: metrics.DiskSpaceUsage(),
+		Count:          metrics.Compact.Count,
+		InProgress:     metrics.Compact.NumInProgress,
+		EstimatedDebt:  metrics.Compact.EstimatedDebt,
+		Levels:         make([]LevelStats, len(metrics.Levels)),
+	}
+
+	for level, levelMetrics := range metrics.Levels {
+		stats.Levels[level] = LevelStats{
+			Level:          level,
+			Files:          levelMetrics.NumFiles,
+			Size:           levelMetrics.Size,
+			Score:          levelMetrics.Score,
+			BytesCompacted: levelMetrics.BytesCompacted,
+		}
+	}
+	return stats
+}
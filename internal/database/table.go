@@ -49,6 +49,10 @@ func (t *table) Flush() error {
 	return t.db.Flush()
 }
 
+func (t *table) Sync() error {
+	return t.db.Sync()
+}
+
 func (t *table) NewBatch() Batch {
 	return &tableBatch{
 		batch:  t.db.NewBatch(),
@@ -177,9 +177,9 @@ func NewSecretURI(s string) (SecretURI, error) {
 		}
 		switch name {
 		case "path":
-			junctionMatches := junctionRegex.FindAllString(matches[i], -1)
+			junctionMatches := junctionRegex.FindAllStringSubmatch(matches[i], -1)
 			for _, jm := range junctionMatches {
-				junctions = append(junctions, NewDeriveJunctionFromString(jm))
+				junctions = append(junctions, NewDeriveJunctionFromString(jm[1]))
 			}
 		case "phrase":
 			if matches[i] != "" {
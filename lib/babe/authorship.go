@@ -0,0 +1,69 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package babe
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+)
+
+// EpochAuthorship are the slots of an epoch an authority can claim, by type of claim.
+type EpochAuthorship struct {
+	Primary      []uint64 `json:"primary"`
+	Secondary    []uint64 `json:"secondary"`
+	SecondaryVRF []uint64 `json:"secondary_vrf"`
+}
+
+// EpochAuthorship returns the slots of the current epoch, from the current slot,
+// that can be claimed by the local BABE key using the randomness of the epoch.
+// The slots are keyed by the SS58 address of the key.
+func (b *Service) EpochAuthorship() (map[string]EpochAuthorship, error) {
+	if b.keypair == nil {
+		return nil, ErrNotAuthority
+	}
+
+	b.RLock()
+	epochHandler := b.epochHandler
+	b.RUnlock()
+	if epochHandler == nil {
+		return nil, errEpochNotInitiated
+	}
+
+	currentSlot := getCurrentSlot(b.constants.slotDuration)
+	slots := make([]uint64, 0, len(epochHandler.slotToPreRuntimeDigest))
+	for slot := range epochHandler.slotToPreRuntimeDigest {
+		if slot >= currentSlot {
+			slots = append(slots, slot)
+		}
+	}
+	slices.Sort(slots)
+
+	authorship := EpochAuthorship{
+		Primary:      []uint64{},
+		Secondary:    []uint64{},
+		SecondaryVRF: []uint64{},
+	}
+	for _, slot := range slots {
+		preDigest, err := types.DecodeBabePreDigest(epochHandler.slotToPreRuntimeDigest[slot].Data)
+		if err != nil {
+			return nil, fmt.Errorf("decoding pre-runtime digest of slot %d: %w", slot, err)
+		}
+
+		switch preDigest.(type) {
+		case types.BabePrimaryPreDigest:
+			authorship.Primary = append(authorship.Primary, slot)
+		case types.BabeSecondaryPlainPreDigest:
+			authorship.Secondary = append(authorship.Secondary, slot)
+		case types.BabeSecondaryVRFPreDigest:
+			authorship.SecondaryVRF = append(authorship.SecondaryVRF, slot)
+		default:
+			return nil, fmt.Errorf("%w: %T", errInvalidSlotTechnique, preDigest)
+		}
+	}
+
+	address := string(b.keypair.Public().Address())
+	return map[string]EpochAuthorship{address: authorship}, nil
+}
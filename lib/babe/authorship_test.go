@@ -0,0 +1,74 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package babe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_EpochAuthorship(t *testing.T) {
+	t.Parallel()
+
+	keypair := keyring.Alice().(*sr25519.Keypair)
+	const slotDuration = time.Hour
+	currentSlot := getCurrentSlot(slotDuration)
+
+	mustDigest := func(digest *types.PreRuntimeDigest, err error) *types.PreRuntimeDigest {
+		require.NoError(t, err)
+		return digest
+	}
+	var vrfOutput [sr25519.VRFOutputLength]byte
+	var vrfProof [sr25519.VRFProofLength]byte
+	slotToPreRuntimeDigest := map[uint64]*types.PreRuntimeDigest{
+		// past slots of the epoch are not reported
+		currentSlot - 1: mustDigest(
+			types.NewBabePrimaryPreDigest(0, currentSlot-1, vrfOutput, vrfProof).ToPreRuntimeDigest()),
+		currentSlot + 3: mustDigest(
+			types.NewBabePrimaryPreDigest(0, currentSlot+3, vrfOutput, vrfProof).ToPreRuntimeDigest()),
+		currentSlot: mustDigest(
+			types.NewBabePrimaryPreDigest(0, currentSlot, vrfOutput, vrfProof).ToPreRuntimeDigest()),
+		currentSlot + 1: mustDigest(
+			types.NewBabeSecondaryPlainPreDigest(0, currentSlot+1).ToPreRuntimeDigest()),
+		currentSlot + 2: mustDigest(
+			types.NewBabeSecondaryVRFPreDigest(0, currentSlot+2, vrfOutput, vrfProof).ToPreRuntimeDigest()),
+	}
+
+	service := &Service{
+		keypair:   keypair,
+		constants: constants{slotDuration: slotDuration, epochLength: 10},
+	}
+
+	_, err := service.EpochAuthorship()
+	assert.ErrorIs(t, err, errEpochNotInitiated)
+
+	service.epochHandler = &epochHandler{
+		descriptor: &epochDescriptor{
+			epoch:     1,
+			startSlot: currentSlot - 2,
+			endSlot:   currentSlot + 8,
+		},
+		slotToPreRuntimeDigest: slotToPreRuntimeDigest,
+	}
+
+	authorship, err := service.EpochAuthorship()
+	require.NoError(t, err)
+
+	expected := map[string]EpochAuthorship{
+		string(keypair.Public().Address()): {
+			Primary:      []uint64{currentSlot, currentSlot + 3},
+			Secondary:    []uint64{currentSlot + 1},
+			SecondaryVRF: []uint64{currentSlot + 2},
+		},
+	}
+	assert.Equal(t, expected, authorship)
+
+	_, err = (&Service{}).EpochAuthorship()
+	assert.ErrorIs(t, err, ErrNotAuthority)
+}
@@ -47,6 +47,12 @@ type Service struct {
 
 	telemetry Telemetry
 	wg        sync.WaitGroup
+
+	finalityLagBreaker *finalityLagBreaker
+	slotGuard          SlotGuard
+	readAhead          *readAhead
+	propagationMargin  time.Duration
+	digestInjectors    *DigestInjectors
 }
 
 // ServiceConfig represents a BABE configuration
@@ -62,6 +68,16 @@ type ServiceConfig struct {
 	IsDev              bool
 	Authority          bool
 	Telemetry          Telemetry
+	// MaxFinalityLag is the maximum number of blocks between the best block and the
+	// highest finalised block before FinalityLagPolicy is applied. 0 disables the limit.
+	MaxFinalityLag    uint
+	FinalityLagPolicy FinalityLagPolicy
+	// PropagationMargin is the time reserved at the end of each authoring slot to
+	// finalise, seal and propagate the block. 0 defaults to a third of the slot duration.
+	PropagationMargin time.Duration
+	// DigestInjectors inject the digest items of additional consensus engines in the
+	// blocks authored, if not nil.
+	DigestInjectors *DigestInjectors
 }
 
 // Validate returns error if config does not contain required attributes
@@ -88,6 +104,10 @@ func (Builder) NewServiceIFace(cfg *ServiceConfig) (service *Service, err error)
 	if err != nil {
 		return nil, fmt.Errorf("cannot get slot duration: %w", err)
 	}
+	if cfg.PropagationMargin >= slotDuration {
+		return nil, fmt.Errorf("%w: %s is not less than the slot duration %s",
+			errInvalidPropagationMargin, cfg.PropagationMargin, slotDuration)
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -107,7 +127,11 @@ func (Builder) NewServiceIFace(cfg *ServiceConfig) (service *Service, err error)
 			slotDuration: slotDuration,
 			epochLength:  cfg.EpochState.GetEpochLength(),
 		},
-		telemetry: cfg.Telemetry,
+		telemetry:          cfg.Telemetry,
+		finalityLagBreaker: newFinalityLagBreaker(cfg.MaxFinalityLag, cfg.FinalityLagPolicy),
+		readAhead:          newReadAhead(),
+		propagationMargin:  cfg.PropagationMargin,
+		digestInjectors:    cfg.DigestInjectors,
 	}
 
 	logger.Debugf(
@@ -130,6 +154,10 @@ func NewService(cfg *ServiceConfig) (*Service, error) {
 	if err != nil {
 		return nil, fmt.Errorf("cannot get slot duration: %w", err)
 	}
+	if cfg.PropagationMargin >= slotDuration {
+		return nil, fmt.Errorf("%w: %s is not less than the slot duration %s",
+			errInvalidPropagationMargin, cfg.PropagationMargin, slotDuration)
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -149,7 +177,11 @@ func NewService(cfg *ServiceConfig) (*Service, error) {
 			slotDuration: slotDuration,
 			epochLength:  cfg.EpochState.GetEpochLength(),
 		},
-		telemetry: cfg.Telemetry,
+		telemetry:          cfg.Telemetry,
+		finalityLagBreaker: newFinalityLagBreaker(cfg.MaxFinalityLag, cfg.FinalityLagPolicy),
+		readAhead:          newReadAhead(),
+		propagationMargin:  cfg.PropagationMargin,
+		digestInjectors:    cfg.DigestInjectors,
 	}
 
 	logger.Debugf(
@@ -216,6 +248,12 @@ func (b *Service) Resume() error {
 	return nil
 }
 
+// SetSlotGuard sets the guard checked before authoring a block in a slot.
+// It must be called before the service is started.
+func (b *Service) SetSlotGuard(slotGuard SlotGuard) {
+	b.slotGuard = slotGuard
+}
+
 // IsPaused returns if the service is paused or not (ie. producing blocks)
 func (b *Service) IsPaused() bool {
 	select {
@@ -291,12 +329,17 @@ func (b *Service) initiateAndGetEpochHandler(epoch uint64) (*epochHandler, error
 	logger.Debugf("initiated epoch with threshold %s, randomness 0x%x and authorities %v",
 		epochDescriptor.data.threshold, epochDescriptor.data.randomness[:], epochDescriptor.data.authorities)
 
-	return newEpochHandler(
+	handler, err := newEpochHandler(
 		epochDescriptor,
 		b.constants,
 		b.handleSlot,
 		b.keypair,
 	)
+	if err != nil {
+		return nil, err
+	}
+	handler.readAhead = b.readAheadSlot
+	return handler, nil
 }
 
 func (b *Service) runEngine() error {
@@ -330,10 +373,13 @@ func (b *Service) handleEpoch(epoch uint64) (next uint64, err error) {
 		wg.Wait()
 	}()
 
-	b.epochHandler, err = b.initiateAndGetEpochHandler(epoch)
+	epochHandler, err := b.initiateAndGetEpochHandler(epoch)
 	if err != nil {
 		return 0, fmt.Errorf("cannot initiate and get epoch handler: %w", err)
 	}
+	b.Lock()
+	b.epochHandler = epochHandler
+	b.Unlock()
 
 	nextEpochStarts := b.epochHandler.descriptor.endSlot
 	nextEpochStartTime := getSlotStartTime(nextEpochStarts, b.constants.slotDuration)
@@ -384,6 +430,11 @@ func (b *Service) getParentForBlockAuthoring(slotNum uint64) (*types.Header, err
 		return nil, errNilParentHeader
 	}
 
+	parentHeader, err = b.applyFinalityLagBreaker(parentHeader)
+	if err != nil {
+		return nil, err
+	}
+
 	atGenesisBlock := b.blockState.GenesisHash() == parentHeader.Hash()
 	if !atGenesisBlock {
 		bestBlockSlotNum, err := b.blockState.GetSlotForBlock(parentHeader.Hash())
@@ -423,6 +474,17 @@ func (b *Service) handleSlot(epoch uint64, slot Slot,
 	authorityIndex uint32,
 	preRuntimeDigest *types.PreRuntimeDigest,
 ) error {
+	if b.slotGuard != nil {
+		allowed, err := b.slotGuard.GuardSlot(slot.number)
+		if err != nil {
+			return fmt.Errorf("guarding slot %d: %w", slot.number, err)
+		}
+		if !allowed {
+			logger.Debugf("not authoring in slot %d since the slot guard disallows it", slot.number)
+			return nil
+		}
+	}
+
 	parent, err := b.getParentForBlockAuthoring(slot.number)
 	if err != nil {
 		return fmt.Errorf("could not get parent for claiming slot %d: %w", slot.number, err)
@@ -444,12 +506,22 @@ func (b *Service) handleSlot(epoch uint64, slot Slot,
 	}
 
 	rt.SetContextStorage(ts)
+	// the changes are recorded for the import hooks
+	ts.RecordChanges()
+	if b.readAhead != nil {
+		ts.RecordReads()
+	}
 
 	block, err := b.buildBlock(parent, slot, rt, authorityIndex, preRuntimeDigest)
 	if err != nil {
 		return err
 	}
 
+	if b.readAhead != nil {
+		hits := b.readAhead.record(ts.Reads())
+		logger.Debugf("%d storage keys read ahead were read when building block %d", hits, block.Header.Number)
+	}
+
 	logger.Infof(
 		"built block %d with hash %s, state root %s, epoch %d and slot %d",
 		block.Header.Number, block.Header.Hash(), block.Header.StateRoot, epoch, slot.number)
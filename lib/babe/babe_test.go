@@ -0,0 +1,58 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package babe
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testSlotGuard struct {
+	allowed bool
+	err     error
+}
+
+func (g testSlotGuard) GuardSlot(uint64) (allowed bool, err error) {
+	return g.allowed, g.err
+}
+
+func Test_Service_handleSlot_slotGuard(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+
+	testCases := map[string]struct {
+		slotGuard  SlotGuard
+		errWrapped error
+		errMessage string
+	}{
+		"disallowed": {
+			slotGuard: testSlotGuard{},
+		},
+		"guard_error": {
+			slotGuard:  testSlotGuard{err: errTest},
+			errWrapped: errTest,
+			errMessage: "guarding slot 5: test error",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// the block state is not set since no block is authored
+			service := &Service{}
+			service.SetSlotGuard(testCase.slotGuard)
+
+			err := service.handleSlot(1, Slot{number: 5}, 0, nil)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errMessage != "" {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+		})
+	}
+}
@@ -16,6 +16,8 @@
 	"github.com/ChainSafe/gossamer/lib/transaction"
 	"github.com/ChainSafe/gossamer/pkg/scale"
 	ethmetrics "github.com/ethereum/go-ethereum/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 )
 
 const (
@@ -23,6 +25,21 @@
 	buildBlockErrors = "gossamer/proposer/block/constructed/errors"
 )
 
+var (
+	applyExtrinsicSeconds = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "gossamer_babe",
+		Name:      "apply_extrinsic_seconds",
+		Help:      "time taken to apply an extrinsic when building a block",
+		Buckets:   []float64{0.0001, 0.0005, 0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1},
+	})
+	extrinsicBudgetExhaustedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "gossamer_babe",
+		Name:      "extrinsic_budget_exhausted_total",
+		Help: "number of blocks built without applying all the available extrinsics " +
+			"because the slot time left was less than the time taken by the slowest extrinsic",
+	})
+)
+
 // construct a block for this slot with the given parent
 func (b *Service) buildBlock(parent *types.Header, slot Slot, rt Runtime,
 	authorityIndex uint32, preRuntimeDigest *types.PreRuntimeDigest) (*types.Block, error) {
@@ -33,6 +50,8 @@ func (b *Service) buildBlock(parent *types.Header, slot Slot, rt Runtime,
 		authorityIndex,
 		preRuntimeDigest,
 	)
+	builder.propagationMargin = b.propagationMargin
+	builder.digestInjectors = b.digestInjectors
 
 	// is necessary to enable ethmetrics to be possible register values
 	ethmetrics.Enabled = true
@@ -57,6 +76,12 @@ type BlockBuilder struct {
 	blockState            BlockState
 	currentAuthorityIndex uint32
 	preRuntimeDigest      *types.PreRuntimeDigest
+	// propagationMargin is the time reserved at the end of the slot to finalise,
+	// seal and propagate the block. It defaults to a third of the slot duration if 0.
+	propagationMargin time.Duration
+	inherentProviders *inherents.Providers
+	// digestInjectors inject the digest items of additional consensus engines, if not nil.
+	digestInjectors *DigestInjectors
 }
 
 // NewBlockBuilder creates a new block builder.
@@ -73,6 +98,7 @@ func NewBlockBuilder(
 		blockState:            bs,
 		currentAuthorityIndex: authidx,
 		preRuntimeDigest:      preRuntimeDigest,
+		inherentProviders:     inherents.NewAuthoringProviders(),
 	}
 }
 
@@ -86,6 +112,21 @@ func (b *BlockBuilder) buildBlock(parent *types.Header, slot Slot, rt Runtime) (
 	if err != nil {
 		return nil, err
 	}
+
+	injectedDigests, err := b.digestInjectors.Inject(DigestContext{
+		Parent:         parent,
+		Slot:           slot.number,
+		AuthorityIndex: b.currentAuthorityIndex,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, injectedDigest := range injectedDigests {
+		err = digest.Add(injectedDigest)
+		if err != nil {
+			return nil, err
+		}
+	}
 	header := types.NewHeader(parent.Hash(), common.Hash{}, common.Hash{}, number, digest)
 
 	// initialise block header
@@ -97,7 +138,7 @@ func (b *BlockBuilder) buildBlock(parent *types.Header, slot Slot, rt Runtime) (
 	logger.Trace("initialised block")
 
 	// add block inherents
-	inherents, err := buildBlockInherents(slot, rt, parent)
+	inherents, err := b.buildBlockInherents(slot, rt, parent)
 	if err != nil {
 		return nil, fmt.Errorf("cannot build inherents: %s", err)
 	}
@@ -129,6 +170,11 @@ func (b *BlockBuilder) buildBlock(parent *types.Header, slot Slot, rt Runtime) (
 		return nil, err
 	}
 
+	err = header.Digest.ValidateOrder()
+	if err != nil {
+		return nil, fmt.Errorf("validating block digest: %w", err)
+	}
+
 	logger.Trace("built block seal")
 
 	body, err := extrinsicsToBody(inherents, included)
@@ -170,13 +216,21 @@ func (b *BlockBuilder) buildBlockSeal(header *types.Header) (*types.SealDigest,
 
 // buildBlockExtrinsics applies extrinsics to the block. it returns an array of included extrinsics.
 // for each extrinsic in queue, add it to the block, until the slot ends or the block is full.
+// It also stops when the time left before the end of the slot, minus the propagation margin,
+// is less than the time taken by the slowest extrinsic applied, whatever its weight,
+// so slow hardware doesn't author blocks it cannot propagate in time.
 // if any extrinsic fails, it returns an empty array and an error.
 func (b *BlockBuilder) buildBlockExtrinsics(slot Slot, rt ExtrinsicHandler) []*transaction.ValidTransaction {
 	var included []*transaction.ValidTransaction
 
-	slotEnd := slot.start.Add(slot.duration * 2 / 3) // reserve last 1/3 of slot for block finalisation
-	timeout := slotEnd.Sub(slot.start)               // timeout relative to the slot start
+	margin := b.propagationMargin
+	if margin == 0 {
+		margin = slot.duration / 3 // reserve last 1/3 of slot for block finalisation
+	}
+	slotEnd := slot.start.Add(slot.duration - margin)
+	timeout := slotEnd.Sub(slot.start) // timeout relative to the slot start
 	slotTimer := time.NewTimer(timeout)
+	budget := extrinsicBudget{deadline: time.Now().Add(timeout)}
 
 	for {
 		txn := b.transactionState.PopWithTimer(slotTimer.C)
@@ -185,10 +239,20 @@ func (b *BlockBuilder) buildBlockExtrinsics(slot Slot, rt ExtrinsicHandler) []*t
 			break
 		}
 
+		if budget.exhausted(time.Now()) {
+			logger.Debugf("not applying more extrinsics since %s is left before the slot deadline "+
+				"and the slowest extrinsic took %s", time.Until(budget.deadline), budget.slowest)
+			extrinsicBudgetExhaustedTotal.Inc()
+			b.addToQueue([]*transaction.ValidTransaction{txn})
+			break
+		}
+
 		extrinsic := txn.Extrinsic
 		logger.Tracef("build block, applying extrinsic %s", extrinsic)
 
+		start := time.Now()
 		ret, err := rt.ApplyExtrinsic(extrinsic)
+		budget.track(time.Since(start))
 		if err != nil {
 			logger.Warnf("determining apply extrinsic call error: %s", err)
 			continue
@@ -226,34 +290,34 @@ func (b *BlockBuilder) buildBlockExtrinsics(slot Slot, rt ExtrinsicHandler) []*t
 	return included
 }
 
-func buildBlockInherents(slot Slot, rt ExtrinsicHandler, parent *types.Header) ([][]byte, error) {
-	// Setup inherents: add timstap0
-	idata := types.NewInherentData()
-	err := idata.SetInherent(types.Timstap0, uint64(slot.start.UnixMilli())) //nolint:gosec
-	if err != nil {
-		return nil, err
-	}
-
-	// add babeslot
-	err = idata.SetInherent(types.Babeslot, slot.number)
-	if err != nil {
-		return nil, err
-	}
+// extrinsicBudget tracks the time taken to apply the extrinsics of a block being built.
+type extrinsicBudget struct {
+	deadline time.Time
+	slowest  time.Duration
+}
 
-	parachainInherent := inherents.ParachainInherentData{
-		ParentHeader: *parent,
+// track records the time taken to apply an extrinsic.
+func (e *extrinsicBudget) track(elapsed time.Duration) {
+	applyExtrinsicSeconds.Observe(elapsed.Seconds())
+	if elapsed > e.slowest {
+		e.slowest = elapsed
 	}
+}
 
-	// add parachn0 and newheads
-	// for now we can use "empty" values, as we require parachain-specific
-	// logic to actually provide the data.
-
-	if err = idata.SetInherent(types.Parachn0, parachainInherent); err != nil {
-		return nil, fmt.Errorf("setting inherent %q: %w", types.Parachn0, err)
-	}
+// exhausted returns true if the time left before the deadline is less than the
+// time taken by the slowest extrinsic applied.
+func (e *extrinsicBudget) exhausted(now time.Time) bool {
+	return e.deadline.Sub(now) < e.slowest
+}
 
-	if err = idata.SetInherent(types.Newheads, []byte{0}); err != nil {
-		return nil, fmt.Errorf("setting inherent %q: %w", types.Newheads, err)
+func (b *BlockBuilder) buildBlockInherents(slot Slot, rt ExtrinsicHandler, parent *types.Header) ([][]byte, error) {
+	idata, err := b.inherentProviders.CreateInherentData(inherents.Context{
+		Parent:    parent,
+		Slot:      slot.number,
+		Timestamp: slot.start,
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	ienc, err := idata.Encode()
@@ -130,7 +130,7 @@ func TestApplyExtrinsicAfterFirstBlockFinalized(t *testing.T) {
 	err = rt.InitializeBlock(header)
 	require.NoError(t, err)
 
-	_, err = buildBlockInherents(slot, rt, parentHeader)
+	_, err = builder.buildBlockInherents(slot, rt, parentHeader)
 	require.NoError(t, err)
 
 	ext := runtime.NewTestExtrinsic(t, rt, emptyHash, parentHeader.Hash(), 0, signature.TestKeyringPairAlice,
@@ -167,7 +167,7 @@ func TestApplyExtrinsicAfterFirstBlockFinalized(t *testing.T) {
 	err = rt.InitializeBlock(header2)
 	require.NoError(t, err)
 
-	_, err = buildBlockInherents(slot2, rt, header1)
+	_, err = builder.buildBlockInherents(slot2, rt, header1)
 	require.NoError(t, err)
 
 	res, err := rt.ApplyExtrinsic(common.MustHexToBytes(ext2))
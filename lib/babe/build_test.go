@@ -0,0 +1,97 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package babe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/transaction"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// sleepingExtrinsicHandler applies extrinsics successfully after sleeping for
+// the duration of the first byte of the extrinsic in milliseconds.
+type sleepingExtrinsicHandler struct{}
+
+func (sleepingExtrinsicHandler) InherentExtrinsics([]byte) ([]byte, error) { return nil, nil }
+
+func (sleepingExtrinsicHandler) ApplyExtrinsic(data types.Extrinsic) ([]byte, error) {
+	time.Sleep(time.Duration(data[0]) * time.Millisecond)
+	return []byte{0, 0}, nil
+}
+
+func Test_extrinsicBudget_exhausted(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	testCases := map[string]struct {
+		budget    extrinsicBudget
+		exhausted bool
+	}{
+		"no_extrinsic_applied": {
+			budget: extrinsicBudget{deadline: now.Add(time.Millisecond)},
+		},
+		"enough_time_left": {
+			budget: extrinsicBudget{deadline: now.Add(time.Second), slowest: time.Millisecond},
+		},
+		"not_enough_time_left": {
+			budget:    extrinsicBudget{deadline: now.Add(time.Millisecond), slowest: time.Second},
+			exhausted: true,
+		},
+		"deadline_passed": {
+			budget:    extrinsicBudget{deadline: now.Add(-time.Millisecond)},
+			exhausted: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testCase.exhausted, testCase.budget.exhausted(now))
+		})
+	}
+}
+
+func Test_extrinsicBudget_track(t *testing.T) {
+	t.Parallel()
+
+	var budget extrinsicBudget
+	budget.track(2 * time.Millisecond)
+	budget.track(time.Millisecond)
+	assert.Equal(t, 2*time.Millisecond, budget.slowest)
+}
+
+func Test_BlockBuilder_buildBlockExtrinsics_budget(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+
+	slow := &transaction.ValidTransaction{Extrinsic: types.Extrinsic{120}}
+	next := &transaction.ValidTransaction{Extrinsic: types.Extrinsic{1}}
+
+	transactionState := NewMockTransactionState(ctrl)
+	gomock.InOrder(
+		transactionState.EXPECT().PopWithTimer(gomock.Any()).Return(slow),
+		transactionState.EXPECT().PopWithTimer(gomock.Any()).Return(next),
+	)
+	// the next transaction is queued again since the 120ms taken by the slow
+	// transaction is more than the 80ms left before the deadline
+	transactionState.EXPECT().Push(next).Return(common.Hash{}, nil)
+
+	builder := &BlockBuilder{
+		transactionState:  transactionState,
+		propagationMargin: 100 * time.Millisecond,
+	}
+	slot := Slot{start: time.Now(), duration: 300 * time.Millisecond}
+
+	included := builder.buildBlockExtrinsics(slot, sleepingExtrinsicHandler{})
+	require.Equal(t, []*transaction.ValidTransaction{slow}, included)
+}
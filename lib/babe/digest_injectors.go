@@ -0,0 +1,175 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package babe
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+)
+
+var (
+	ErrDigestInjectorAlreadyRegistered = errors.New("digest injector already registered")
+	ErrReservedEngineID                = errors.New("consensus engine id is reserved")
+	ErrInvalidInjectedDigest           = errors.New("invalid injected digest")
+)
+
+// reservedEngineIDs are the consensus engine ids whose digest items cannot be injected.
+var reservedEngineIDs = map[types.ConsensusEngineID]struct{}{
+	types.BabeEngineID:    {},
+	types.GrandpaEngineID: {},
+}
+
+// DigestContext is the context of the block authored whose digest items are injected.
+type DigestContext struct {
+	// Parent is the header of the parent of the block.
+	Parent *types.Header
+	// Slot is the BABE slot number of the block.
+	Slot uint64
+	// AuthorityIndex is the index of the BABE authority authoring the block.
+	AuthorityIndex uint32
+}
+
+// DigestInjector injects the digest items of an additional consensus engine, such as an
+// experimental engine on a devnet, in the blocks authored by the node, and verifies them
+// in the blocks imported.
+// The items are pre-runtime digests following the BABE pre-runtime digest: they are passed
+// to the runtime when initialising the block, so the digest computed by the runtime when
+// executing the block on import matches the digest of its header.
+type DigestInjector interface {
+	// EngineID returns the consensus engine id of the digest items injected.
+	EngineID() types.ConsensusEngineID
+	// InjectDigest returns the data of the digest item of the block authored,
+	// or nil if the block has no digest item for the engine.
+	InjectDigest(ctx DigestContext) (data []byte, err error)
+	// VerifyDigest verifies the digest item of the block imported, which is nil
+	// if the block has no digest item for the engine.
+	VerifyDigest(header *types.Header, digest *types.PreRuntimeDigest) error
+}
+
+// DigestInjectors is a registry of digest injectors, used both when authoring and when
+// importing blocks so the blocks authored pass the checks of the nodes sharing the registry.
+type DigestInjectors struct {
+	mutex     sync.RWMutex
+	injectors []DigestInjector
+}
+
+// NewDigestInjectors returns a registry of the given digest injectors.
+// It panics if an injector cannot be registered.
+func NewDigestInjectors(injectors ...DigestInjector) *DigestInjectors {
+	d := &DigestInjectors{}
+	for _, injector := range injectors {
+		err := d.Register(injector)
+		if err != nil {
+			panic(err)
+		}
+	}
+	return d
+}
+
+var registeredDigestInjectors = NewDigestInjectors()
+
+// RegisterDigestInjector registers the digest injector in the registry used by the node,
+// typically from the init function of the package implementing the consensus engine.
+func RegisterDigestInjector(injector DigestInjector) error {
+	return registeredDigestInjectors.Register(injector)
+}
+
+// RegisteredDigestInjectors returns the registry of the digest injectors registered
+// with RegisterDigestInjector.
+func RegisteredDigestInjectors() *DigestInjectors {
+	return registeredDigestInjectors
+}
+
+// Register registers the digest injector, and returns an error wrapping
+// ErrReservedEngineID if its engine id is the id of an engine run by the node, or
+// ErrDigestInjectorAlreadyRegistered if an injector is already registered for its engine id.
+func (d *DigestInjectors) Register(injector DigestInjector) error {
+	engineID := injector.EngineID()
+	if _, reserved := reservedEngineIDs[engineID]; reserved {
+		return fmt.Errorf("%w: %s", ErrReservedEngineID, engineID.ToBytes())
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	for _, registered := range d.injectors {
+		if registered.EngineID() == engineID {
+			return fmt.Errorf("%w: %s", ErrDigestInjectorAlreadyRegistered, engineID.ToBytes())
+		}
+	}
+	d.injectors = append(d.injectors, injector)
+	return nil
+}
+
+// Len returns the number of digest injectors registered.
+func (d *DigestInjectors) Len() int {
+	if d == nil {
+		return 0
+	}
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+	return len(d.injectors)
+}
+
+// Inject returns the pre-runtime digests injected by the injectors in the block authored,
+// in the order the injectors were registered.
+func (d *DigestInjectors) Inject(ctx DigestContext) ([]types.PreRuntimeDigest, error) {
+	if d == nil {
+		return nil, nil
+	}
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	var digests []types.PreRuntimeDigest
+	for _, injector := range d.injectors {
+		data, err := injector.InjectDigest(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("injecting digest for engine %s: %w", injector.EngineID().ToBytes(), err)
+		}
+		if data == nil {
+			continue
+		}
+		digests = append(digests, types.PreRuntimeDigest{
+			ConsensusEngineID: injector.EngineID(),
+			Data:              data,
+		})
+	}
+	return digests, nil
+}
+
+// Verify verifies the pre-runtime digests of the engines of the injectors in the block
+// imported, and returns an error wrapping ErrInvalidInjectedDigest if one is invalid.
+func (d *DigestInjectors) Verify(header *types.Header) error {
+	if d.Len() == 0 {
+		return nil
+	}
+
+	preRuntimeDigests := make(map[types.ConsensusEngineID]*types.PreRuntimeDigest)
+	for i, item := range header.Digest {
+		value, err := item.Value()
+		if err != nil {
+			return fmt.Errorf("getting digest item %d value: %w", i, err)
+		}
+		preRuntimeDigest, ok := value.(types.PreRuntimeDigest)
+		if ok {
+			preRuntimeDigests[preRuntimeDigest.ConsensusEngineID] = &preRuntimeDigest
+		}
+	}
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	for _, injector := range d.injectors {
+		err := injector.VerifyDigest(header, preRuntimeDigests[injector.EngineID()])
+		if err != nil {
+			return fmt.Errorf("%w: for engine %s: %w", ErrInvalidInjectedDigest, injector.EngineID().ToBytes(), err)
+		}
+	}
+	return nil
+}
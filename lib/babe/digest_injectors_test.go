@@ -0,0 +1,145 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package babe
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errTestDigestMismatch = errors.New("digest mismatch")
+
+// slotDigestInjector injects the slot number of the block, and requires it on import
+// if required is set.
+type slotDigestInjector struct {
+	engineID types.ConsensusEngineID
+	required bool
+}
+
+func (i slotDigestInjector) EngineID() types.ConsensusEngineID { return i.engineID }
+
+func (slotDigestInjector) InjectDigest(ctx DigestContext) ([]byte, error) {
+	return []byte{byte(ctx.Slot)}, nil
+}
+
+func (i slotDigestInjector) VerifyDigest(_ *types.Header, digest *types.PreRuntimeDigest) error {
+	switch {
+	case digest == nil && i.required:
+		return errTestDigestMismatch
+	case digest != nil && !bytes.Equal(digest.Data, []byte{7}):
+		return errTestDigestMismatch
+	}
+	return nil
+}
+
+// skipDigestInjector never injects a digest item.
+type skipDigestInjector struct{}
+
+func (skipDigestInjector) EngineID() types.ConsensusEngineID {
+	return types.ConsensusEngineID{'s', 'k', 'i', 'p'}
+}
+
+func (skipDigestInjector) InjectDigest(DigestContext) ([]byte, error) { return nil, nil }
+
+func (skipDigestInjector) VerifyDigest(*types.Header, *types.PreRuntimeDigest) error { return nil }
+
+func Test_DigestInjectors_Register(t *testing.T) {
+	t.Parallel()
+
+	injectors := NewDigestInjectors(slotDigestInjector{engineID: types.ConsensusEngineID{'e', 'x', 'p', '1'}})
+
+	err := injectors.Register(slotDigestInjector{engineID: types.ConsensusEngineID{'e', 'x', 'p', '1'}})
+	assert.ErrorIs(t, err, ErrDigestInjectorAlreadyRegistered)
+
+	err = injectors.Register(slotDigestInjector{engineID: types.BabeEngineID})
+	assert.ErrorIs(t, err, ErrReservedEngineID)
+
+	err = injectors.Register(slotDigestInjector{engineID: types.GrandpaEngineID})
+	assert.ErrorIs(t, err, ErrReservedEngineID)
+
+	require.NoError(t, injectors.Register(skipDigestInjector{}))
+	assert.Equal(t, 2, injectors.Len())
+
+	var nilInjectors *DigestInjectors
+	assert.Equal(t, 0, nilInjectors.Len())
+}
+
+func Test_DigestInjectors_Inject(t *testing.T) {
+	t.Parallel()
+
+	engineID := types.ConsensusEngineID{'e', 'x', 'p', '1'}
+	injectors := NewDigestInjectors(skipDigestInjector{}, slotDigestInjector{engineID: engineID})
+
+	digests, err := injectors.Inject(DigestContext{Slot: 7})
+	require.NoError(t, err)
+	expected := []types.PreRuntimeDigest{{ConsensusEngineID: engineID, Data: []byte{7}}}
+	assert.Equal(t, expected, digests)
+
+	var nilInjectors *DigestInjectors
+	digests, err = nilInjectors.Inject(DigestContext{Slot: 7})
+	require.NoError(t, err)
+	assert.Nil(t, digests)
+}
+
+func Test_DigestInjectors_Verify(t *testing.T) {
+	t.Parallel()
+
+	engineID := types.ConsensusEngineID{'e', 'x', 'p', '1'}
+	newHeader := func(t *testing.T, data []byte) *types.Header {
+		t.Helper()
+		digest := types.NewDigest()
+		require.NoError(t, digest.Add(*types.NewBABEPreRuntimeDigest([]byte{1})))
+		if data != nil {
+			require.NoError(t, digest.Add(types.PreRuntimeDigest{ConsensusEngineID: engineID, Data: data}))
+		}
+		require.NoError(t, digest.Add(types.SealDigest{ConsensusEngineID: types.BabeEngineID}))
+		return &types.Header{Digest: digest}
+	}
+
+	testCases := map[string]struct {
+		injectors  *DigestInjectors
+		header     *types.Header
+		errWrapped error
+	}{
+		"no_injectors": {
+			header: newHeader(t, []byte{1}),
+		},
+		"valid_digest": {
+			injectors: NewDigestInjectors(slotDigestInjector{engineID: engineID, required: true}),
+			header:    newHeader(t, []byte{7}),
+		},
+		"invalid_digest": {
+			injectors:  NewDigestInjectors(slotDigestInjector{engineID: engineID}),
+			header:     newHeader(t, []byte{1}),
+			errWrapped: ErrInvalidInjectedDigest,
+		},
+		"optional_digest_missing": {
+			injectors: NewDigestInjectors(slotDigestInjector{engineID: engineID}),
+			header:    newHeader(t, nil),
+		},
+		"required_digest_missing": {
+			injectors:  NewDigestInjectors(slotDigestInjector{engineID: engineID, required: true}),
+			header:     newHeader(t, nil),
+			errWrapped: ErrInvalidInjectedDigest,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := testCase.injectors.Verify(testCase.header)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.ErrorIs(t, err, errTestDigestMismatch)
+			}
+		})
+	}
+}
@@ -25,6 +25,8 @@ type epochHandler struct {
 	slotToPreRuntimeDigest map[uint64]*types.PreRuntimeDigest
 
 	handleSlot handleSlotFunc
+	// readAhead, if set, is called with the next slot when it is an authoring slot.
+	readAhead func(slot uint64)
 }
 
 func newEpochHandler(epochDescriptor *epochDescriptor, constants constants,
@@ -82,17 +84,21 @@ func (h *epochHandler) run(ctx context.Context, errCh chan<- error) {
 
 		// check if the slot is an authoring slot otherwise wait for the next slot
 		preRuntimeDigest, has := h.slotToPreRuntimeDigest[currentSlot.number]
-		if !has {
-			continue
+		if has {
+			err = h.handleSlot(
+				h.descriptor.epoch,
+				currentSlot,
+				h.descriptor.data.authorityIndex,
+				preRuntimeDigest)
+			if err != nil {
+				logger.Warnf("failed to handle slot %d: %s", currentSlot.number, err)
+			}
 		}
 
-		err = h.handleSlot(
-			h.descriptor.epoch,
-			currentSlot,
-			h.descriptor.data.authorityIndex,
-			preRuntimeDigest)
-		if err != nil {
-			logger.Warnf("failed to handle slot %d: %s", currentSlot.number, err)
+		// read the storage ahead of the next slot if it is an authoring slot
+		nextSlot := currentSlot.number + 1
+		if _, has := h.slotToPreRuntimeDigest[nextSlot]; has && h.readAhead != nil {
+			h.readAhead(nextSlot)
 		}
 	}
 }
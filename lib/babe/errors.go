@@ -23,9 +23,14 @@
 	"github.com/ChainSafe/gossamer/pkg/scale"
 )
 
+// ErrUnknownAuthority is the kind of errors returned for a block or slot claim of
+// an authority which is not in the authority set of the epoch. The errors
+// below which belong to it also match it with errors.Is.
+var ErrUnknownAuthority = errors.New("unknown authority")
+
 var (
 	// ErrAuthIndexOutOfBound is returned when a authority index doesn't exist
-	ErrAuthIndexOutOfBound = errors.New("authority index doesn't exist")
+	ErrAuthIndexOutOfBound = newKindError(ErrUnknownAuthority, "authority index doesn't exist")
 
 	// ErrBadSlotClaim is returned when a slot claim is invalid
 	ErrBadSlotClaim = errors.New("could not verify slot claim VRF proof")
@@ -49,7 +54,7 @@
 	ErrVRFOutputOverThreshold = errors.New("vrf output over threshold")
 
 	// ErrInvalidBlockProducerIndex is returned when the producer of a block isn't in the authority set
-	ErrInvalidBlockProducerIndex = errors.New("block producer is not in authority set")
+	ErrInvalidBlockProducerIndex = newKindError(ErrUnknownAuthority, "block producer is not in authority set")
 
 	// ErrAuthorityAlreadyDisabled is returned when attempting to disabled an already-disabled authority
 	ErrAuthorityAlreadyDisabled = errors.New("authority has already been disabled")
@@ -74,8 +79,31 @@
 	errLastDigestItemNotSeal      = errors.New("last digest item is not seal")
 	errLaggingSlot                = errors.New("current slot is smaller than slot of best block")
 	errNoDigest                   = errors.New("no digest provided")
+	errFinalityLagExceeded        = errors.New("finality lag exceeds maximum")
+	errInvalidFinalityLagPolicy   = errors.New("invalid finality lag policy")
+	errInvalidPropagationMargin   = errors.New("invalid propagation margin")
+	errEpochNotInitiated          = errors.New("epoch not initiated")
 )
 
+// kindError is an error belonging to a kind of errors, which errors.Is matches
+// against both the error itself and its kind.
+type kindError struct {
+	kind    error
+	message string
+}
+
+func newKindError(kind error, message string) error {
+	return &kindError{kind: kind, message: message}
+}
+
+func (e *kindError) Error() string {
+	return e.message
+}
+
+func (e *kindError) Unwrap() error {
+	return e.kind
+}
+
 // A DispatchOutcomeError is outcome of dispatching the extrinsic
 type DispatchOutcomeError struct {
 	msg string // description of error
@@ -4,6 +4,7 @@
 package babe
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -86,3 +87,15 @@ func TestApplyExtrinsicErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestErrUnknownAuthority(t *testing.T) {
+	t.Parallel()
+
+	err := fmt.Errorf("verifying block: %w", ErrInvalidBlockProducerIndex)
+	require.ErrorIs(t, err, ErrUnknownAuthority)
+	require.ErrorIs(t, err, ErrInvalidBlockProducerIndex)
+	require.EqualError(t, err, "verifying block: block producer is not in authority set")
+
+	require.ErrorIs(t, ErrAuthIndexOutOfBound, ErrUnknownAuthority)
+	require.NotErrorIs(t, ErrBadSignature, ErrUnknownAuthority)
+}
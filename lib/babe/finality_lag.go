@@ -0,0 +1,135 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package babe
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// FinalityLagPolicy is the behaviour of the block author when the
+// finality lag exceeds the configured maximum finality lag.
+type FinalityLagPolicy string
+
+const (
+	// FinalityLagSkip skips authoring until the finality lag is back under the maximum.
+	FinalityLagSkip FinalityLagPolicy = "skip"
+	// FinalityLagAuthorOnFinalised authors on top of the highest finalised block
+	// instead of the best block.
+	FinalityLagAuthorOnFinalised FinalityLagPolicy = "finalised"
+)
+
+// ParseFinalityLagPolicy parses a finality lag policy from its string
+// representation. An empty string defaults to FinalityLagSkip.
+func ParseFinalityLagPolicy(s string) (FinalityLagPolicy, error) {
+	switch FinalityLagPolicy(s) {
+	case "", FinalityLagSkip:
+		return FinalityLagSkip, nil
+	case FinalityLagAuthorOnFinalised:
+		return FinalityLagAuthorOnFinalised, nil
+	default:
+		return "", fmt.Errorf("%w: %q", errInvalidFinalityLagPolicy, s)
+	}
+}
+
+var (
+	finalityLagGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "gossamer_babe",
+		Name:      "finality_lag",
+		Help:      "number of blocks between the best block and the highest finalised block when authoring",
+	})
+	finalityLagBreakerOpenGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "gossamer_babe",
+		Name:      "finality_lag_breaker_open",
+		Help:      "1 if block authoring is restricted because the finality lag exceeds its maximum, 0 otherwise",
+	})
+	finalityLagBreakerTripsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "gossamer_babe",
+		Name:      "finality_lag_breaker_trips_total",
+		Help:      "total number of times block authoring was restricted because of the finality lag",
+	})
+)
+
+// finalityLagBreaker restricts block authoring when the best block is too
+// far ahead of the highest finalised block, so a validator does not keep
+// extending a fork which cannot be finalised.
+type finalityLagBreaker struct {
+	maxLag uint
+	policy FinalityLagPolicy
+
+	mutex sync.Mutex
+	open  bool
+}
+
+func newFinalityLagBreaker(maxLag uint, policy FinalityLagPolicy) *finalityLagBreaker {
+	return &finalityLagBreaker{
+		maxLag: maxLag,
+		policy: policy,
+	}
+}
+
+// enabled returns true if a maximum finality lag is configured.
+func (f *finalityLagBreaker) enabled() bool {
+	return f != nil && f.maxLag > 0
+}
+
+// check records the finality lag between the best and finalised headers and
+// returns true if it exceeds the maximum finality lag. An alert is logged
+// each time the breaker opens or closes.
+func (f *finalityLagBreaker) check(best, finalised *types.Header) (exceeded bool) {
+	var lag uint
+	if best.Number > finalised.Number {
+		lag = best.Number - finalised.Number
+	}
+	finalityLagGauge.Set(float64(lag))
+	exceeded = lag > f.maxLag
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	switch {
+	case exceeded && !f.open:
+		finalityLagBreakerTripsTotal.Inc()
+		finalityLagBreakerOpenGauge.Set(1)
+		logger.Errorf("finality lag of %d blocks exceeds maximum of %d blocks: "+
+			"best block is #%d (%s) and finalised block is #%d (%s), applying policy %q",
+			lag, f.maxLag, best.Number, best.Hash(), finalised.Number, finalised.Hash(), f.policy)
+	case !exceeded && f.open:
+		finalityLagBreakerOpenGauge.Set(0)
+		logger.Infof("finality lag of %d blocks is back under maximum of %d blocks, resuming authoring on best block",
+			lag, f.maxLag)
+	}
+	f.open = exceeded
+
+	return exceeded
+}
+
+// applyFinalityLagBreaker returns the header to author on top of given the
+// best block header, according to the finality lag breaker.
+func (b *Service) applyFinalityLagBreaker(best *types.Header) (*types.Header, error) {
+	if !b.finalityLagBreaker.enabled() {
+		return best, nil
+	}
+
+	finalised, err := b.blockState.GetHighestFinalisedHeader()
+	if err != nil {
+		return nil, fmt.Errorf("could not get highest finalised header: %w", err)
+	}
+
+	if !b.finalityLagBreaker.check(best, finalised) {
+		return best, nil
+	}
+
+	switch b.finalityLagBreaker.policy {
+	case FinalityLagAuthorOnFinalised:
+		return finalised, nil
+	default:
+		return nil, fmt.Errorf("%w: best block is #%d and finalised block is #%d",
+			errFinalityLagExceeded, best.Number, finalised.Number)
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package babe
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestParseFinalityLagPolicy(t *testing.T) {
+	t.Parallel()
+
+	policy, err := ParseFinalityLagPolicy("")
+	assert.NoError(t, err)
+	assert.Equal(t, FinalityLagSkip, policy)
+
+	policy, err = ParseFinalityLagPolicy("finalised")
+	assert.NoError(t, err)
+	assert.Equal(t, FinalityLagAuthorOnFinalised, policy)
+
+	_, err = ParseFinalityLagPolicy("invalid")
+	assert.ErrorIs(t, err, errInvalidFinalityLagPolicy)
+}
+
+func TestService_applyFinalityLagBreaker(t *testing.T) {
+	t.Parallel()
+
+	best := &types.Header{Number: 20}
+	finalised := &types.Header{Number: 10}
+
+	testCases := map[string]struct {
+		maxLag         uint
+		policy         FinalityLagPolicy
+		expectFinality bool
+		expectedHeader *types.Header
+		errWrapped     error
+		expectedOpen   bool
+	}{
+		"disabled": {
+			expectedHeader: best,
+		},
+		"lag_under_maximum": {
+			maxLag:         10,
+			expectFinality: true,
+			expectedHeader: best,
+		},
+		"lag_exceeded_skip": {
+			maxLag:         9,
+			policy:         FinalityLagSkip,
+			expectFinality: true,
+			errWrapped:     errFinalityLagExceeded,
+			expectedOpen:   true,
+		},
+		"lag_exceeded_author_on_finalised": {
+			maxLag:         9,
+			policy:         FinalityLagAuthorOnFinalised,
+			expectFinality: true,
+			expectedHeader: finalised,
+			expectedOpen:   true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			blockState := NewMockBlockState(ctrl)
+			if testCase.expectFinality {
+				blockState.EXPECT().GetHighestFinalisedHeader().Return(finalised, nil)
+			}
+
+			service := &Service{
+				blockState:         blockState,
+				finalityLagBreaker: newFinalityLagBreaker(testCase.maxLag, testCase.policy),
+			}
+
+			header, err := service.applyFinalityLagBreaker(best)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			assert.Equal(t, testCase.expectedHeader, header)
+			assert.Equal(t, testCase.expectedOpen, service.finalityLagBreaker.open)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package inherents
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+)
+
+var (
+	errTimestampTooEarly       = errors.New("the time since the last timestamp is lower than the minimum period")
+	errTimestampTooFarInFuture = errors.New("the timestamp of the block is too far in the future")
+)
+
+// TimestampProvider provides the timestamp inherent data, which is the
+// timestamp of the context in milliseconds since the Unix epoch.
+type TimestampProvider struct{}
+
+// Identifier returns the timestamp inherent identifier.
+func (TimestampProvider) Identifier() types.InherentIdentifier { return types.Timstap0 }
+
+// ProvideInherentData sets the timestamp inherent data.
+func (TimestampProvider) ProvideInherentData(ctx Context, data *types.InherentData) error {
+	return data.SetInherent(types.Timstap0, uint64(ctx.Timestamp.UnixMilli())) //nolint:gosec
+}
+
+// DecodeError decodes the timestamp inherent error of the runtime.
+func (TimestampProvider) DecodeError(encoded []byte) error {
+	if len(encoded) == 1 {
+		switch encoded[0] {
+		case 0:
+			return errTimestampTooEarly
+		case 1:
+			return errTimestampTooFarInFuture
+		}
+	}
+	return fmt.Errorf("unknown timestamp inherent error 0x%x", encoded)
+}
+
+// BabeSlotProvider provides the BABE slot inherent data, which is the slot number of the context.
+type BabeSlotProvider struct{}
+
+// Identifier returns the BABE slot inherent identifier.
+func (BabeSlotProvider) Identifier() types.InherentIdentifier { return types.Babeslot }
+
+// ProvideInherentData sets the BABE slot inherent data.
+func (BabeSlotProvider) ProvideInherentData(ctx Context, data *types.InherentData) error {
+	return data.SetInherent(types.Babeslot, ctx.Slot)
+}
+
+// ParachainProvider provides the parachain inherent data. For now it only
+// contains the parent header, since providing bitfields, backed candidates
+// and disputes requires parachain-specific logic.
+type ParachainProvider struct{}
+
+// Identifier returns the parachain inherent identifier.
+func (ParachainProvider) Identifier() types.InherentIdentifier { return types.Parachn0 }
+
+// ProvideInherentData sets the parachain inherent data.
+func (ParachainProvider) ProvideInherentData(ctx Context, data *types.InherentData) error {
+	if ctx.Parent == nil {
+		return fmt.Errorf("no parent header")
+	}
+
+	return data.SetInherent(types.Parachn0, ParachainInherentData{
+		ParentHeader: *ctx.Parent,
+	})
+}
+
+// NewHeadsProvider provides the inherent data of the new minimally-attested
+// parachain heads, which is empty for now.
+type NewHeadsProvider struct{}
+
+// Identifier returns the new heads inherent identifier.
+func (NewHeadsProvider) Identifier() types.InherentIdentifier { return types.Newheads }
+
+// ProvideInherentData sets the new heads inherent data.
+func (NewHeadsProvider) ProvideInherentData(_ Context, data *types.InherentData) error {
+	return data.SetInherent(types.Newheads, []byte{0})
+}
+
+// UnclesProvider provides the uncles inherent data. Uncles are not tracked,
+// so the list of uncle headers provided is empty.
+type UnclesProvider struct{}
+
+// Identifier returns the uncles inherent identifier.
+func (UnclesProvider) Identifier() types.InherentIdentifier { return types.Uncles00 }
+
+// ProvideInherentData sets the uncles inherent data.
+func (UnclesProvider) ProvideInherentData(_ Context, data *types.InherentData) error {
+	return data.SetInherent(types.Uncles00, []types.Header{})
+}
@@ -0,0 +1,166 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package inherents
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+)
+
+var (
+	ErrProviderAlreadyRegistered = errors.New("inherent data provider already registered")
+	ErrInherentCheckFailed       = errors.New("inherent check failed")
+)
+
+// Context is the context of the block whose inherent data is provided.
+type Context struct {
+	// Parent is the header of the parent of the block.
+	Parent *types.Header
+	// Slot is the BABE slot number of the block.
+	Slot uint64
+	// Timestamp is the timestamp of the block when authoring it,
+	// and the current time when checking its inherents.
+	Timestamp time.Time
+}
+
+// Provider provides the inherent data of an inherent identifier.
+type Provider interface {
+	// Identifier returns the identifier of the inherent data provided.
+	Identifier() types.InherentIdentifier
+	// ProvideInherentData sets the inherent data of the block in the data.
+	ProvideInherentData(ctx Context, data *types.InherentData) error
+}
+
+// ErrorDecoder is implemented by providers able to decode the errors returned
+// by the runtime when checking their inherent.
+type ErrorDecoder interface {
+	// DecodeError returns an error describing the SCALE encoded inherent error.
+	DecodeError(encoded []byte) error
+}
+
+// Checker checks the inherents of a block against inherent data.
+type Checker interface {
+	CheckInherents(block *types.Block, data *types.InherentData) (*types.CheckInherentsResult, error)
+}
+
+// Providers is a registry of inherent data providers.
+type Providers struct {
+	providers []Provider
+}
+
+// NewProviders returns a registry of the given inherent data providers.
+// It panics if two providers have the same identifier.
+func NewProviders(providers ...Provider) *Providers {
+	p := &Providers{}
+	for _, provider := range providers {
+		err := p.Register(provider)
+		if err != nil {
+			panic(err)
+		}
+	}
+	return p
+}
+
+// NewAuthoringProviders returns a registry of the inherent data providers used
+// when authoring a block: timestamp, BABE slot, parachain data and uncles.
+func NewAuthoringProviders() *Providers {
+	return NewProviders(
+		TimestampProvider{},
+		BabeSlotProvider{},
+		ParachainProvider{},
+		NewHeadsProvider{},
+		UnclesProvider{},
+	)
+}
+
+// NewImportProviders returns a registry of the inherent data providers used
+// when checking the inherents of an imported block: timestamp and BABE slot.
+func NewImportProviders() *Providers {
+	return NewProviders(
+		TimestampProvider{},
+		BabeSlotProvider{},
+	)
+}
+
+// Register registers the inherent data provider, and returns an error
+// wrapping ErrProviderAlreadyRegistered if a provider is already registered
+// for its identifier.
+func (p *Providers) Register(provider Provider) error {
+	if p.provider(provider.Identifier().Bytes()) != nil {
+		return fmt.Errorf("%w: %s", ErrProviderAlreadyRegistered, provider.Identifier())
+	}
+	p.providers = append(p.providers, provider)
+	return nil
+}
+
+func (p *Providers) provider(key [8]byte) Provider {
+	for _, provider := range p.providers {
+		if provider.Identifier().Bytes() == key {
+			return provider
+		}
+	}
+	return nil
+}
+
+// CreateInherentData returns the inherent data set by all the providers.
+func (p *Providers) CreateInherentData(ctx Context) (*types.InherentData, error) {
+	data := types.NewInherentData()
+	for _, provider := range p.providers {
+		err := provider.ProvideInherentData(ctx, data)
+		if err != nil {
+			return nil, fmt.Errorf("providing inherent %s: %w", provider.Identifier(), err)
+		}
+	}
+	return data, nil
+}
+
+// CheckInherents checks the inherents of the block using the checker, with the
+// inherent data created by the providers. The error returned wraps
+// ErrInherentCheckFailed and names the identifiers of the failing inherents.
+func (p *Providers) CheckInherents(checker Checker, block *types.Block, ctx Context) error {
+	data, err := p.CreateInherentData(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := checker.CheckInherents(block, data)
+	if err != nil {
+		return fmt.Errorf("checking inherents: %w", err)
+	}
+
+	if result.Okay {
+		return nil
+	}
+
+	keys := make([][8]byte, 0, len(result.Errors.Data))
+	for key := range result.Errors.Data {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i][:], keys[j][:]) < 0
+	})
+
+	failures := make([]string, len(keys))
+	for i, key := range keys {
+		failures[i] = describeInherentError(p.provider(key), key, result.Errors.Data[key])
+	}
+
+	if len(failures) == 0 {
+		return fmt.Errorf("%w: no inherent error returned", ErrInherentCheckFailed)
+	}
+	return fmt.Errorf("%w: %s", ErrInherentCheckFailed, strings.Join(failures, "; "))
+}
+
+func describeInherentError(provider Provider, key [8]byte, encoded []byte) string {
+	if decoder, ok := provider.(ErrorDecoder); ok {
+		return fmt.Sprintf("inherent %s: %s", key[:], decoder.DecodeError(encoded))
+	}
+	return fmt.Sprintf("inherent %s: error 0x%x", key[:], encoded)
+}
@@ -0,0 +1,139 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package inherents
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type checkerFunc func(block *types.Block, data *types.InherentData) (*types.CheckInherentsResult, error)
+
+func (f checkerFunc) CheckInherents(block *types.Block, data *types.InherentData) (
+	*types.CheckInherentsResult, error) {
+	return f(block, data)
+}
+
+func Test_Providers_Register(t *testing.T) {
+	t.Parallel()
+
+	providers := NewProviders(TimestampProvider{})
+	err := providers.Register(BabeSlotProvider{})
+	require.NoError(t, err)
+
+	err = providers.Register(TimestampProvider{})
+	assert.ErrorIs(t, err, ErrProviderAlreadyRegistered)
+	assert.EqualError(t, err, "inherent data provider already registered: timstap0")
+}
+
+func Test_Providers_CreateInherentData(t *testing.T) {
+	t.Parallel()
+
+	parent := &types.Header{Number: 1}
+	ctx := Context{
+		Parent:    parent,
+		Slot:      2,
+		Timestamp: time.UnixMilli(3),
+	}
+
+	data, err := NewAuthoringProviders().CreateInherentData(ctx)
+	require.NoError(t, err)
+
+	expected := types.NewInherentData()
+	require.NoError(t, expected.SetInherent(types.Timstap0, uint64(3)))
+	require.NoError(t, expected.SetInherent(types.Babeslot, uint64(2)))
+	require.NoError(t, expected.SetInherent(types.Parachn0, ParachainInherentData{ParentHeader: *parent}))
+	require.NoError(t, expected.SetInherent(types.Newheads, []byte{0}))
+	require.NoError(t, expected.SetInherent(types.Uncles00, []types.Header{}))
+	assert.Equal(t, expected, data)
+
+	_, err = NewAuthoringProviders().CreateInherentData(Context{})
+	assert.EqualError(t, err, "providing inherent parachn0: no parent header")
+}
+
+func Test_Providers_CheckInherents(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+	block := &types.Block{Header: types.Header{Number: 2}}
+	ctx := Context{Slot: 2, Timestamp: time.UnixMilli(3)}
+
+	testCases := map[string]struct {
+		result     *types.CheckInherentsResult
+		checkErr   error
+		errWrapped error
+		errMessage string
+	}{
+		"okay": {
+			result: &types.CheckInherentsResult{Okay: true},
+		},
+		"check_error": {
+			checkErr:   errTest,
+			errWrapped: errTest,
+			errMessage: "checking inherents: test error",
+		},
+		"decoded_error": {
+			result: &types.CheckInherentsResult{
+				Errors: types.InherentData{Data: map[[8]byte][]byte{
+					types.Timstap0.Bytes(): {1},
+				}},
+			},
+			errWrapped: ErrInherentCheckFailed,
+			errMessage: "inherent check failed: " +
+				"inherent timstap0: the timestamp of the block is too far in the future",
+		},
+		"undecoded_errors": {
+			result: &types.CheckInherentsResult{
+				FatalError: true,
+				Errors: types.InherentData{Data: map[[8]byte][]byte{
+					types.Parachn0.Bytes(): {1, 2},
+					types.Babeslot.Bytes(): {3},
+				}},
+			},
+			errWrapped: ErrInherentCheckFailed,
+			errMessage: "inherent check failed: " +
+				"inherent babeslot: error 0x03; inherent parachn0: error 0x0102",
+		},
+		"no_error": {
+			result:     &types.CheckInherentsResult{},
+			errWrapped: ErrInherentCheckFailed,
+			errMessage: "inherent check failed: no inherent error returned",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			checker := checkerFunc(func(checked *types.Block, data *types.InherentData) (
+				*types.CheckInherentsResult, error) {
+				assert.Equal(t, block, checked)
+				expected, err := NewImportProviders().CreateInherentData(ctx)
+				require.NoError(t, err)
+				assert.Equal(t, expected, data)
+				return testCase.result, testCase.checkErr
+			})
+
+			err := NewImportProviders().CheckInherents(checker, block, ctx)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+		})
+	}
+}
+
+func Test_TimestampProvider_DecodeError(t *testing.T) {
+	t.Parallel()
+
+	assert.ErrorIs(t, TimestampProvider{}.DecodeError([]byte{0}), errTimestampTooEarly)
+	assert.ErrorIs(t, TimestampProvider{}.DecodeError([]byte{1}), errTimestampTooFarInFuture)
+	assert.EqualError(t, TimestampProvider{}.DecodeError([]byte{2, 3}), "unknown timestamp inherent error 0x0203")
+}
@@ -31,3 +31,9 @@ type ExtrinsicHandler interface {
 type Telemetry interface {
 	SendMessage(msg json.Marshaler)
 }
+
+// SlotGuard guards block authoring, for example to only author blocks on the
+// active node of nodes running with the same authority keys.
+type SlotGuard interface {
+	GuardSlot(slot uint64) (allowed bool, err error)
+}
@@ -104,15 +104,18 @@ func (mr *MockInstanceMockRecorder) BabeSubmitReportEquivocationUnsignedExtrinsi
 }
 
 // CheckInherents mocks base method.
-func (m *MockInstance) CheckInherents() {
+func (m *MockInstance) CheckInherents(arg0 *types.Block, arg1 *types.InherentData) (*types.CheckInherentsResult, error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "CheckInherents")
+	ret := m.ctrl.Call(m, "CheckInherents", arg0, arg1)
+	ret0, _ := ret[0].(*types.CheckInherentsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // CheckInherents indicates an expected call of CheckInherents.
-func (mr *MockInstanceMockRecorder) CheckInherents() *gomock.Call {
+func (mr *MockInstanceMockRecorder) CheckInherents(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckInherents", reflect.TypeOf((*MockInstance)(nil).CheckInherents))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckInherents", reflect.TypeOf((*MockInstance)(nil).CheckInherents), arg0, arg1)
 }
 
 // DecodeSessionKeys mocks base method.
@@ -175,6 +178,20 @@ func (mr *MockInstanceMockRecorder) FinalizeBlock() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinalizeBlock", reflect.TypeOf((*MockInstance)(nil).FinalizeBlock))
 }
 
+// FloatDeterminism mocks base method.
+func (m *MockInstance) FloatDeterminism() runtime.FloatDeterminism {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FloatDeterminism")
+	ret0, _ := ret[0].(runtime.FloatDeterminism)
+	return ret0
+}
+
+// FloatDeterminism indicates an expected call of FloatDeterminism.
+func (mr *MockInstanceMockRecorder) FloatDeterminism() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FloatDeterminism", reflect.TypeOf((*MockInstance)(nil).FloatDeterminism))
+}
+
 // GenerateSessionKeys mocks base method.
 func (m *MockInstance) GenerateSessionKeys() {
 	m.ctrl.T.Helper()
@@ -245,6 +262,20 @@ func (mr *MockInstanceMockRecorder) GrandpaSubmitReportEquivocationUnsignedExtri
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrandpaSubmitReportEquivocationUnsignedExtrinsic", reflect.TypeOf((*MockInstance)(nil).GrandpaSubmitReportEquivocationUnsignedExtrinsic), arg0, arg1)
 }
 
+// HeapPages mocks base method.
+func (m *MockInstance) HeapPages() uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HeapPages")
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// HeapPages indicates an expected call of HeapPages.
+func (mr *MockInstanceMockRecorder) HeapPages() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HeapPages", reflect.TypeOf((*MockInstance)(nil).HeapPages))
+}
+
 // InherentExtrinsics mocks base method.
 func (m *MockInstance) InherentExtrinsics(arg0 []byte) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,122 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package babe
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+)
+
+// babeVRFInOutContext is the context of the randomness the VRF output of a
+// block contributes to the randomness of the epochs.
+// https://github.com/paritytech/polkadot-sdk/blob/master/substrate/primitives/consensus/babe/src/lib.rs
+var babeVRFInOutContext = []byte("BabeVRFInOutContext")
+
+// Slot claim types of the BABE pre-runtime digests
+const (
+	PrimaryClaim      = "primary"
+	SecondaryClaim    = "secondary"
+	SecondaryVRFClaim = "secondary_vrf"
+)
+
+// BlockVRF is the slot claim of the BABE pre-runtime digest of a block.
+type BlockVRF struct {
+	AuthorityIndex uint32
+	Slot           uint64
+	// Claim is one of PrimaryClaim, SecondaryClaim or SecondaryVRFClaim.
+	Claim string
+	// Output is the VRF output of the claim, nil for secondary plain claims.
+	Output *[sr25519.VRFOutputLength]byte
+}
+
+// GetBlockVRF returns the slot claim of the BABE pre-runtime digest of the header.
+func GetBlockVRF(header *types.Header) (blockVRF BlockVRF, err error) {
+	if len(header.Digest) == 0 {
+		return blockVRF, fmt.Errorf("for block hash %s: %w", header.Hash(), errNoDigest)
+	}
+
+	digestValue, err := header.Digest[0].Value()
+	if err != nil {
+		return blockVRF, fmt.Errorf("getting first digest type value: %w", err)
+	}
+	preDigest, ok := digestValue.(types.PreRuntimeDigest)
+	if !ok {
+		return blockVRF, types.ErrNoFirstPreDigest
+	}
+
+	babePreDigest, err := types.DecodeBabePreDigest(preDigest.Data)
+	if err != nil {
+		return blockVRF, fmt.Errorf("decoding babe pre-digest: %w", err)
+	}
+
+	switch d := babePreDigest.(type) {
+	case types.BabePrimaryPreDigest:
+		return BlockVRF{AuthorityIndex: d.AuthorityIndex, Slot: d.SlotNumber, Claim: PrimaryClaim,
+			Output: &d.VRFOutput}, nil
+	case types.BabeSecondaryVRFPreDigest:
+		return BlockVRF{AuthorityIndex: d.AuthorityIndex, Slot: d.SlotNumber, Claim: SecondaryVRFClaim,
+			Output: &d.VrfOutput}, nil
+	case types.BabeSecondaryPlainPreDigest:
+		return BlockVRF{AuthorityIndex: d.AuthorityIndex, Slot: d.SlotNumber, Claim: SecondaryClaim}, nil
+	default:
+		return blockVRF, fmt.Errorf("%w: %T", errInvalidSlotTechnique, babePreDigest)
+	}
+}
+
+// VRFRandomness returns the randomness the VRF output of a block contributes to
+// the randomness of the epochs, given the data of the epoch of the block.
+// It returns false if the block has no VRF output.
+func VRFRandomness(blockVRF BlockVRF, epoch uint64, epochData *types.EpochDataRaw) (
+	randomness Randomness, ok bool, err error) {
+	if blockVRF.Output == nil {
+		return randomness, false, nil
+	}
+
+	if int(blockVRF.AuthorityIndex) >= len(epochData.Authorities) {
+		return randomness, false, fmt.Errorf("%w: index %d for %d authorities",
+			ErrInvalidBlockProducerIndex, blockVRF.AuthorityIndex, len(epochData.Authorities))
+	}
+	authority := epochData.Authorities[blockVRF.AuthorityIndex]
+	pub, err := sr25519.NewPublicKey(authority.Key[:])
+	if err != nil {
+		return randomness, false, fmt.Errorf("decoding authority key: %w", err)
+	}
+
+	t := makeTranscript(epochData.Randomness, blockVRF.Slot, epoch)
+	inout, err := sr25519.AttachInput(*blockVRF.Output, pub, t)
+	if err != nil {
+		return randomness, false, fmt.Errorf("attaching sr25519 input: %w", err)
+	}
+
+	bytes, err := inout.MakeBytes(types.RandomnessLength, babeVRFInOutContext)
+	if err != nil {
+		return randomness, false, fmt.Errorf("making sr25519 bytes: %w", err)
+	}
+	copy(randomness[:], bytes)
+	return randomness, true, nil
+}
+
+// NextEpochRandomness computes the randomness of the next epoch as the BABE pallet
+// does when the current epoch starts, hashing the randomness of the current
+// epoch, the index of the next epoch and the VRF randomness of the primary blocks
+// of the previous epoch, in block order.
+// https://github.com/paritytech/polkadot-sdk/blob/master/substrate/frame/babe/src/lib.rs
+func NextEpochRandomness(current Randomness, nextEpoch uint64, vrfRandomness []Randomness) (Randomness, error) {
+	data := make([]byte, 0, types.RandomnessLength+8+len(vrfRandomness)*types.RandomnessLength)
+	data = append(data, current[:]...)
+	data = binary.LittleEndian.AppendUint64(data, nextEpoch)
+	for _, randomness := range vrfRandomness {
+		data = append(data, randomness[:]...)
+	}
+
+	hash, err := common.Blake2bHash(data)
+	if err != nil {
+		return Randomness{}, fmt.Errorf("hashing randomness: %w", err)
+	}
+	return hash, nil
+}
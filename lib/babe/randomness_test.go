@@ -0,0 +1,118 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package babe
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPreDigestHeader(t *testing.T, preDigest interface {
+	ToPreRuntimeDigest() (*types.PreRuntimeDigest, error)
+}) *types.Header {
+	t.Helper()
+
+	digest, err := preDigest.ToPreRuntimeDigest()
+	require.NoError(t, err)
+	header := types.NewEmptyHeader()
+	header.Number = 1
+	require.NoError(t, header.Digest.Add(*digest))
+	return header
+}
+
+func Test_GetBlockVRF(t *testing.T) {
+	t.Parallel()
+
+	output := [sr25519.VRFOutputLength]byte{1}
+	var proof [sr25519.VRFProofLength]byte
+
+	testCases := map[string]struct {
+		header     *types.Header
+		blockVRF   BlockVRF
+		errWrapped error
+	}{
+		"no_digest": {
+			header:     types.NewEmptyHeader(),
+			errWrapped: errNoDigest,
+		},
+		"primary": {
+			header:   newPreDigestHeader(t, types.NewBabePrimaryPreDigest(1, 10, output, proof)),
+			blockVRF: BlockVRF{AuthorityIndex: 1, Slot: 10, Claim: PrimaryClaim, Output: &output},
+		},
+		"secondary_plain": {
+			header:   newPreDigestHeader(t, types.NewBabeSecondaryPlainPreDigest(2, 11)),
+			blockVRF: BlockVRF{AuthorityIndex: 2, Slot: 11, Claim: SecondaryClaim},
+		},
+		"secondary_vrf": {
+			header:   newPreDigestHeader(t, types.NewBabeSecondaryVRFPreDigest(3, 12, output, proof)),
+			blockVRF: BlockVRF{AuthorityIndex: 3, Slot: 12, Claim: SecondaryVRFClaim, Output: &output},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			blockVRF, err := GetBlockVRF(testCase.header)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			assert.Equal(t, testCase.blockVRF, blockVRF)
+		})
+	}
+}
+
+func Test_VRFRandomness(t *testing.T) {
+	t.Parallel()
+
+	keypair := keyring.Alice().(*sr25519.Keypair)
+	epochData := &types.EpochDataRaw{
+		Authorities: []types.AuthorityRaw{{Key: keypair.Public().(*sr25519.PublicKey).AsBytes(), Weight: 1}},
+		Randomness:  [types.RandomnessLength]byte{9},
+	}
+	const slot, epoch = 100, 2
+
+	output, _, err := keypair.VrfSign(makeTranscript(epochData.Randomness, slot, epoch))
+	require.NoError(t, err)
+
+	randomness, ok, err := VRFRandomness(BlockVRF{Slot: slot, Claim: PrimaryClaim, Output: &output},
+		epoch, epochData)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.NotEqual(t, Randomness{}, randomness)
+
+	// the randomness depends on the transcript of the claim
+	otherEpochRandomness, _, err := VRFRandomness(BlockVRF{Slot: slot, Claim: PrimaryClaim, Output: &output},
+		epoch+1, epochData)
+	require.NoError(t, err)
+	assert.NotEqual(t, randomness, otherEpochRandomness)
+
+	_, ok, err = VRFRandomness(BlockVRF{Slot: slot, Claim: SecondaryClaim}, epoch, epochData)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, _, err = VRFRandomness(BlockVRF{AuthorityIndex: 1, Slot: slot, Claim: PrimaryClaim, Output: &output},
+		epoch, epochData)
+	assert.ErrorIs(t, err, ErrInvalidBlockProducerIndex)
+}
+
+func Test_NextEpochRandomness(t *testing.T) {
+	t.Parallel()
+
+	current := Randomness{1}
+	vrfRandomness := []Randomness{{2}, {3}}
+
+	randomness, err := NextEpochRandomness(current, 5, vrfRandomness)
+	require.NoError(t, err)
+
+	data := append([]byte{}, current[:]...)
+	data = append(data, 5, 0, 0, 0, 0, 0, 0, 0)
+	data = append(data, vrfRandomness[0][:]...)
+	data = append(data, vrfRandomness[1][:]...)
+	assert.Equal(t, Randomness(common.MustBlake2bHash(data)), randomness)
+}
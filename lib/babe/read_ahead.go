@@ -0,0 +1,185 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package babe
+
+import (
+	"sync"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/lib/transaction"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	// maxReadAheadKeys is the maximum number of storage keys read ahead of an authoring slot
+	maxReadAheadKeys = 4096
+	// readAheadHistory is the number of recently authored blocks whose read keys are read ahead
+	readAheadHistory = 4
+	// accountIDLength is the length of the account ids of the nonce tags
+	accountIDLength = 32
+	// nonceTagLength is the length of the tags provided by the nonce check of signed
+	// transactions, which are the SCALE encoding of the account id and nonce.
+	nonceTagLength = accountIDLength + 4
+)
+
+var (
+	readAheadKeysTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "gossamer_babe",
+		Name:      "read_ahead_keys_total",
+		Help:      "total number of storage keys read ahead of authoring slots",
+	})
+	readAheadHitsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "gossamer_babe",
+		Name:      "read_ahead_hits_total",
+		Help:      "total number of storage keys read ahead of authoring slots which were read when authoring",
+	})
+	readAheadHitRatio = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "gossamer_babe",
+		Name:      "read_ahead_hit_ratio",
+		Help:      "ratio of the storage keys read ahead of the last authored block which were read when authoring it",
+	})
+)
+
+// readAhead predicts the storage keys read when authoring a block, so they can
+// be read before the authoring slot starts, and tracks how many of them are
+// read when authoring.
+type readAhead struct {
+	mtx sync.Mutex
+	// history holds the keys read when authoring the most recent blocks, oldest first.
+	history    [][][]byte
+	prefetched map[string]struct{}
+}
+
+func newReadAhead() *readAhead {
+	return &readAhead{}
+}
+
+// predict returns the storage keys likely read when authoring a block including
+// the pending transactions: the System.Account keys of the accounts of the nonce
+// tags provided by the transactions, then the keys read when authoring the most
+// recent blocks, from the most recent one.
+func (r *readAhead) predict(pending []*transaction.ValidTransaction) (keys [][]byte) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	seen := make(map[string]struct{})
+	add := func(key []byte) (full bool) {
+		if _, has := seen[string(key)]; has {
+			return false
+		}
+		seen[string(key)] = struct{}{}
+		keys = append(keys, key)
+		return len(keys) == maxReadAheadKeys
+	}
+
+	for _, tx := range pending {
+		if tx.Validity == nil {
+			continue
+		}
+		for _, tag := range tx.Validity.Provides {
+			if len(tag) != nonceTagLength {
+				continue
+			}
+			if add(systemAccountKey(tag[:accountIDLength])) {
+				return keys
+			}
+		}
+	}
+
+	for i := len(r.history) - 1; i >= 0; i-- {
+		for _, key := range r.history[i] {
+			if add(key) {
+				return keys
+			}
+		}
+	}
+	return keys
+}
+
+// prefetch reads the keys from the trie state, loading the trie nodes on their
+// paths, and remembers them to count the hits of the next authored block.
+func (r *readAhead) prefetch(ts *rtstorage.TrieState, keys [][]byte) {
+	prefetched := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		_ = ts.Get(key)
+		prefetched[string(key)] = struct{}{}
+	}
+	readAheadKeysTotal.Add(float64(len(keys)))
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.prefetched = prefetched
+}
+
+// record records the keys read when authoring a block, and returns how many of
+// the keys prefetched before authoring it were read.
+func (r *readAhead) record(reads [][]byte) (hits int) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if len(reads) > maxReadAheadKeys {
+		reads = reads[:maxReadAheadKeys]
+	}
+	r.history = append(r.history, reads)
+	if len(r.history) > readAheadHistory {
+		r.history = r.history[1:]
+	}
+
+	if len(r.prefetched) == 0 {
+		return 0
+	}
+
+	for _, key := range reads {
+		if _, has := r.prefetched[string(key)]; has {
+			hits++
+		}
+	}
+	readAheadHitsTotal.Add(float64(hits))
+	readAheadHitRatio.Set(float64(hits) / float64(len(r.prefetched)))
+	r.prefetched = nil
+	return hits
+}
+
+// systemAccountKey returns the storage key of the System.Account entry of the account id.
+func systemAccountKey(accountID []byte) []byte {
+	system, _ := common.Twox128Hash([]byte("System"))
+	account, _ := common.Twox128Hash([]byte("Account"))
+	hash, _ := common.Blake2b128(accountID)
+
+	key := make([]byte, 0, len(system)+len(account)+len(hash)+len(accountID))
+	key = append(key, system...)
+	key = append(key, account...)
+	key = append(key, hash...)
+	return append(key, accountID...)
+}
+
+// readAheadSlot reads ahead the storage keys likely read when authoring a block
+// in the slot from the state of the best block, so the trie is loaded before the
+// slot starts.
+func (b *Service) readAheadSlot(slot uint64) {
+	if b.readAhead == nil {
+		return
+	}
+
+	bestBlock, err := b.blockState.BestBlockHeader()
+	if err != nil {
+		logger.Debugf("not reading ahead of slot %d: getting best block header: %s", slot, err)
+		return
+	}
+
+	b.storageState.Lock()
+	ts, err := b.storageState.TrieState(&bestBlock.StateRoot)
+	b.storageState.Unlock()
+	if err != nil || ts == nil {
+		logger.Debugf("not reading ahead of slot %d: getting trie state with state root %s: %v",
+			slot, bestBlock.StateRoot, err)
+		return
+	}
+
+	keys := b.readAhead.predict(b.transactionState.Pending())
+	b.readAhead.prefetch(ts, keys)
+	logger.Tracef("read ahead %d storage keys of slot %d from state root %s", len(keys), slot, bestBlock.StateRoot)
+}
@@ -0,0 +1,129 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package babe
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/lib/transaction"
+	"github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newNonceTag(accountID byte, nonce byte) []byte {
+	tag := bytes.Repeat([]byte{accountID}, accountIDLength)
+	return append(tag, nonce, 0, 0, 0)
+}
+
+func Test_systemAccountKey(t *testing.T) {
+	t.Parallel()
+
+	accountID := bytes.Repeat([]byte{1}, accountIDLength)
+	expected := common.MustHexToBytes("0x26aa394eea5630e07c48ae0c9558cef7b99d880ec681799c0cf30e8886371da9")
+	hash, err := common.Blake2b128(accountID)
+	require.NoError(t, err)
+	expected = append(expected, hash...)
+	expected = append(expected, accountID...)
+
+	assert.Equal(t, expected, systemAccountKey(accountID))
+}
+
+func Test_readAhead_predict(t *testing.T) {
+	t.Parallel()
+
+	r := newReadAhead()
+	r.history = [][][]byte{{[]byte("old"), []byte("both")}, {[]byte("recent"), []byte("both")}}
+
+	pending := []*transaction.ValidTransaction{
+		{Validity: &transaction.Validity{Provides: [][]byte{newNonceTag(1, 0), []byte("other tag")}}},
+		{Validity: &transaction.Validity{Provides: [][]byte{newNonceTag(1, 1), newNonceTag(2, 0)}}},
+		{},
+	}
+
+	keys := r.predict(pending)
+	expected := [][]byte{
+		systemAccountKey(bytes.Repeat([]byte{1}, accountIDLength)),
+		systemAccountKey(bytes.Repeat([]byte{2}, accountIDLength)),
+		[]byte("recent"),
+		[]byte("both"),
+		[]byte("old"),
+	}
+	assert.Equal(t, expected, keys)
+}
+
+func Test_readAhead_predict_bounded(t *testing.T) {
+	t.Parallel()
+
+	r := newReadAhead()
+	reads := make([][]byte, maxReadAheadKeys+1)
+	for i := range reads {
+		reads[i] = []byte{byte(i >> 8), byte(i)}
+	}
+	r.record(reads)
+
+	keys := r.predict(nil)
+	assert.Len(t, keys, maxReadAheadKeys)
+}
+
+func Test_readAhead_record(t *testing.T) {
+	t.Parallel()
+
+	r := newReadAhead()
+	ts := rtstorage.NewTrieState(inmemory.NewEmptyTrie())
+
+	// no keys were prefetched before the first block
+	hits := r.record([][]byte{[]byte("a")})
+	assert.Zero(t, hits)
+
+	r.prefetch(ts, [][]byte{[]byte("a"), []byte("b"), []byte("c")})
+	hits = r.record([][]byte{[]byte("b"), []byte("c"), []byte("d")})
+	assert.Equal(t, 2, hits)
+	assert.Nil(t, r.prefetched)
+
+	for i := 0; i < readAheadHistory; i++ {
+		r.record([][]byte{{byte(i)}})
+	}
+	require.Len(t, r.history, readAheadHistory)
+	assert.Equal(t, [][]byte{{0}}, r.history[0])
+}
+
+func Test_Service_readAheadSlot(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+
+	accountID := bytes.Repeat([]byte{1}, accountIDLength)
+	trie := inmemory.NewEmptyTrie()
+	require.NoError(t, trie.Put(systemAccountKey(accountID), []byte{1}))
+	ts := rtstorage.NewTrieState(trie)
+
+	bestBlock := &types.Header{Number: 1, StateRoot: common.Hash{2}}
+	blockState := NewMockBlockState(ctrl)
+	blockState.EXPECT().BestBlockHeader().Return(bestBlock, nil)
+	storageState := NewMockStorageState(ctrl)
+	storageState.EXPECT().Lock()
+	storageState.EXPECT().TrieState(&bestBlock.StateRoot).Return(ts, nil)
+	storageState.EXPECT().Unlock()
+	transactionState := NewMockTransactionState(ctrl)
+	transactionState.EXPECT().Pending().Return([]*transaction.ValidTransaction{
+		{Validity: &transaction.Validity{Provides: [][]byte{newNonceTag(1, 0)}}},
+	})
+
+	service := &Service{
+		blockState:       blockState,
+		storageState:     storageState,
+		transactionState: transactionState,
+		readAhead:        newReadAhead(),
+	}
+	service.readAheadSlot(10)
+
+	hits := service.readAhead.record([][]byte{systemAccountKey(accountID)})
+	assert.Equal(t, 1, hits)
+}
@@ -31,6 +31,7 @@ type BlockState interface {
 	GetSlotForBlock(common.Hash) (uint64, error)
 	IsDescendantOf(parent, child common.Hash) (bool, error)
 	NumberIsFinalised(blockNumber uint) (bool, error)
+	GetHighestFinalisedHeader() (*types.Header, error)
 	GetRuntime(blockHash common.Hash) (runtime runtime.Instance, err error)
 	StoreRuntime(common.Hash, runtime.Instance)
 	GetBlockByHash(common.Hash) (*types.Block, error)
@@ -53,6 +54,7 @@ type StorageState interface {
 type TransactionState interface {
 	Push(vt *transaction.ValidTransaction) (common.Hash, error)
 	PopWithTimer(timerCh <-chan time.Time) (tx *transaction.ValidTransaction)
+	Pending() []*transaction.ValidTransaction
 }
 
 // EpochState is the interface for epoch methods
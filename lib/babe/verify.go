@@ -12,6 +12,7 @@
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/ChainSafe/gossamer/lib/equivocation"
 	"github.com/ChainSafe/gossamer/pkg/scale"
 )
 
@@ -46,6 +47,8 @@ type VerificationManager struct {
 	// branches of the chain, so we need to keep track of all of them.
 	// map of epoch number -> block producer index -> block number and hash
 	onDisabled map[uint64]map[uint32][]*onDisabledInfo
+	// digestInjectors verify the digest items of additional consensus engines, if not nil.
+	digestInjectors *DigestInjectors
 }
 
 // NewVerificationManager returns a new NewVerificationManager
@@ -59,6 +62,14 @@ func NewVerificationManager(blockState BlockState, slotState SlotState, epochSta
 	}
 }
 
+// SetDigestInjectors sets the digest injectors verifying the digest items of additional
+// consensus engines in the blocks verified.
+func (v *VerificationManager) SetDigestInjectors(digestInjectors *DigestInjectors) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	v.digestInjectors = digestInjectors
+}
+
 // SetOnDisabled sets the BABE authority with the given index as disabled for the rest of the epoch
 func (v *VerificationManager) SetOnDisabled(index uint32, header *types.Header) error {
 	epoch, err := v.epochState.GetEpochForBlock(header)
@@ -166,7 +177,15 @@ func (v *VerificationManager) VerifyBlock(header *types.Header) error {
 	}
 
 	verifier := newVerifier(v.blockState, v.slotState, currentBlockEpoch, info, slotDuration)
-	return verifier.verifyAuthorshipRight(header)
+	err = verifier.verifyAuthorshipRight(header)
+	if err != nil {
+		return err
+	}
+
+	v.lock.Lock()
+	digestInjectors := v.digestInjectors
+	v.lock.Unlock()
+	return digestInjectors.Verify(header)
 }
 
 func (v *VerificationManager) getVerifierInfo(epoch uint64, header *types.Header) (*verifierInfo, error) {
@@ -203,6 +222,9 @@ type verifier struct {
 	threshold      *scale.Uint128
 	secondarySlots bool
 	slotDuration   time.Duration
+	reportRetry    equivocation.RetryPolicy
+	// equivocationReports tracks the equivocation reports being submitted in the background
+	equivocationReports *sync.WaitGroup
 }
 
 // newVerifier returns a Verifier for the epoch described by the given descriptor
@@ -217,6 +239,9 @@ func newVerifier(blockState BlockState, slotState SlotState,
 		threshold:      info.threshold,
 		secondarySlots: info.secondarySlots,
 		slotDuration:   slotDuration,
+		reportRetry:    equivocation.DefaultRetryPolicy,
+
+		equivocationReports: new(sync.WaitGroup),
 	}
 }
 
@@ -331,7 +356,15 @@ func (b *verifier) verifyAuthorshipRight(header *types.Header) error {
 	return nil
 }
 
+// submitAndReportEquivocation reports the equivocation to the runtime, retrying
+// transient failures according to the verifier's retry policy.
 func (b *verifier) submitAndReportEquivocation(equivocationProof *types.BabeEquivocationProof) error {
+	return equivocation.Submit(equivocation.BABE, b.reportRetry, func() error {
+		return b.reportEquivocation(equivocationProof)
+	})
+}
+
+func (b *verifier) reportEquivocation(equivocationProof *types.BabeEquivocationProof) error {
 	bestBlockHash := b.blockState.BestBlockHash()
 	runtimeInstance, err := b.blockState.GetRuntime(bestBlockHash)
 	if err != nil {
@@ -343,7 +376,8 @@ func (b *verifier) submitAndReportEquivocation(equivocationProof *types.BabeEqui
 	if err != nil {
 		return fmt.Errorf("getting key ownership proof from runtime: %w", err)
 	} else if keyOwnershipProof == nil {
-		return errEmptyKeyOwnershipProof
+		// the offender is no longer part of the session, retrying won't help.
+		return equivocation.Permanent(errEmptyKeyOwnershipProof)
 	}
 
 	err = runtimeInstance.BabeSubmitReportEquivocationUnsignedExtrinsic(*equivocationProof, keyOwnershipProof)
@@ -386,10 +420,16 @@ func (b *verifier) verifyBlockEquivocation(header *types.Header) (bool, error) {
 		return false, nil
 	}
 
-	err = b.submitAndReportEquivocation(equivocationProof)
-	if err != nil {
-		return false, fmt.Errorf("submiting equivocation: %w", err)
-	}
+	// the report is submitted in the background, since its retries would otherwise
+	// hold up the block import.
+	b.equivocationReports.Add(1)
+	go func() {
+		defer b.equivocationReports.Done()
+		err := b.submitAndReportEquivocation(equivocationProof)
+		if err != nil {
+			logger.Errorf("submitting equivocation: %s", err)
+		}
+	}()
 
 	return true, nil
 }
@@ -6,6 +6,7 @@
 import (
 	"errors"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -14,6 +15,7 @@
 	"github.com/ChainSafe/gossamer/lib/babe/mocks"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/ChainSafe/gossamer/lib/equivocation"
 	"github.com/ChainSafe/gossamer/pkg/scale"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -857,13 +859,14 @@ func Test_verifyBlockEquivocation(t *testing.T) {
 					blockState:   mockBlockState,
 					slotState:    mockSlotState,
 					slotDuration: 6 * time.Second,
+
+					equivocationReports: new(sync.WaitGroup),
 				}
 			},
 		},
 		"failed_to_get_runtime_while_submiting_equivocation": {
-			header:    defaultHeader,
-			wantErr:   getRuntimeErr,
-			errString: "submiting equivocation: getting runtime: mock get runtime error",
+			expected: true,
+			header:   defaultHeader,
 			buildVerifier: func(t *testing.T) *verifier {
 				ctrl := gomock.NewController(t)
 
@@ -902,6 +905,8 @@ func Test_verifyBlockEquivocation(t *testing.T) {
 					blockState:   mockBlockState,
 					slotState:    mockSlotState,
 					slotDuration: 6 * time.Second,
+
+					equivocationReports: new(sync.WaitGroup),
 				}
 			},
 		},
@@ -914,6 +919,9 @@ func Test_verifyBlockEquivocation(t *testing.T) {
 			t.Parallel()
 			verifier := tt.buildVerifier(t)
 			out, err := verifier.verifyBlockEquivocation(tt.header)
+			if out {
+				verifier.equivocationReports.Wait()
+			}
 			require.ErrorIs(t, err, tt.wantErr)
 			if tt.errString != "" {
 				require.EqualError(t, err, tt.errString)
@@ -988,18 +996,28 @@ func Test_verifier_submitAndReportEquivocation(t *testing.T) {
 	mockRuntime.EXPECT().BabeGenerateKeyOwnershipProof(slot, offenderPublicKey).Return(keyOwnershipProof, nil)
 	mockRuntime.EXPECT().BabeSubmitReportEquivocationUnsignedExtrinsic(equivocationProof, keyOwnershipProof).Return(nil)
 
-	mockBlockState.EXPECT().BestBlockHash().Return(firstHash).Times(2)
+	mockBlockState.EXPECT().BestBlockHash().Return(firstHash)
 	mockBlockState.EXPECT().GetRuntime(firstHash).Return(mockRuntime, nil)
 
 	err = verifier.submitAndReportEquivocation(&equivocationProof)
 	assert.NoError(t, err)
 
-	// fails on not being able to get a runtime
-	mockBlockState.EXPECT().GetRuntime(firstHash).Return(nil, errors.New("test error"))
+	// fails on not being able to get a runtime, retrying according to the policy
+	verifier.reportRetry = equivocation.RetryPolicy{MaxAttempts: 2}
+	mockBlockState.EXPECT().BestBlockHash().Return(firstHash).Times(2)
+	mockBlockState.EXPECT().GetRuntime(firstHash).Return(nil, errors.New("test error")).Times(2)
 
 	err = verifier.submitAndReportEquivocation(&equivocationProof)
 	assert.EqualError(t, err, "getting runtime: test error")
 
+	// does not retry when the key ownership proof is empty
+	mockBlockState.EXPECT().BestBlockHash().Return(firstHash)
+	mockBlockState.EXPECT().GetRuntime(firstHash).Return(mockRuntime, nil)
+	mockRuntime.EXPECT().BabeGenerateKeyOwnershipProof(slot, offenderPublicKey).Return(nil, nil)
+
+	err = verifier.submitAndReportEquivocation(&equivocationProof)
+	assert.ErrorIs(t, err, errEmptyKeyOwnershipProof)
+
 }
 
 func Test_verifier_verifyAuthorshipRightEquivocatory(t *testing.T) {
@@ -1217,6 +1235,7 @@ func Test_verifier_verifyAuthorshipRightEquivocatory(t *testing.T) {
 			argHeader := tt.setupHeader(t)
 			verifier := tt.setupVerifier(t, argHeader)
 			err := verifier.verifyAuthorshipRight(argHeader)
+			verifier.equivocationReports.Wait()
 			if tt.expErr != nil {
 				assert.EqualError(t, err, tt.expErr(argHeader).Error())
 				return
@@ -306,6 +306,56 @@ func (bt *BlockTree) Prune(finalised Hash) (pruned []Hash) {
 	return pruned
 }
 
+// PruneStaleBranches removes the branches whose head is more than maxForkDepth blocks
+// behind the best block, so the blocktree does not grow without bound when finality
+// stalls. Only the blocks which are not ancestors of the best block or of another
+// branch head are removed, and the branches containing any of the kept hashes are
+// never removed. It returns the hashes of the removed blocks.
+func (bt *BlockTree) PruneStaleBranches(maxForkDepth uint, kept map[Hash]struct{}) (pruned []Hash) {
+	bt.Lock()
+	defer bt.Unlock()
+
+	if maxForkDepth == 0 || len(bt.root.children) == 0 {
+		return nil
+	}
+
+	best := bt.best()
+	for _, leaf := range bt.leaves.nodes() {
+		if leaf == best || leaf.number+maxForkDepth >= best.number {
+			continue
+		}
+
+		// find the first block of the branch, which is the child of the last
+		// block shared with the best chain or another branch.
+		first := leaf
+		for len(first.parent.children) == 1 {
+			first = first.parent
+		}
+
+		branch := first.getAllDescendants(nil)
+		if containsAny(branch, kept) {
+			continue
+		}
+
+		first.parent.deleteChild(first)
+		bt.leaves.delete(leaf.hash)
+		bt.runtimes.onBranchPruned(branch)
+		pruned = append(pruned, branch...)
+	}
+
+	leavesGauge.Set(float64(len(bt.leaves.nodes())))
+	return pruned
+}
+
+func containsAny(hashes []Hash, set map[Hash]struct{}) bool {
+	for _, hash := range hashes {
+		if _, has := set[hash]; has {
+			return true
+		}
+	}
+	return false
+}
+
 // String utilises github.com/disiqueira/gotree to create a printable tree
 func (bt *BlockTree) String() string {
 	bt.RLock()
@@ -1058,3 +1058,54 @@ func BenchmarkBlockTreeSubBlockchain(b *testing.B) {
 	}
 
 }
+
+func Test_BlockTree_PruneStaleBranches(t *testing.T) {
+	t.Parallel()
+
+	bt, hashes := createFlatTree(t, 10)
+
+	addFork := func(parent Hash, number uint) Hash {
+		header := &types.Header{
+			ParentHash: parent,
+			Number:     number,
+			StateRoot:  Hash{0x1},
+			Digest:     createPrimaryBABEDigest(t),
+		}
+		err := bt.AddBlock(header, time.Unix(0, 0))
+		require.NoError(t, err)
+		return header.Hash()
+	}
+
+	// 0 -> 1 -> ... -> 10
+	//      |    |               `-> 9'
+	//      |    `-> 3' -> 4'
+	//      `-> 2'
+	staleFork := addFork(hashes[2], 3)
+	staleForkHead := addFork(staleFork, 4)
+	keptFork := addFork(hashes[1], 2)
+	recentFork := addFork(hashes[8], 9)
+
+	ctrl := gomock.NewController(t)
+	staleRuntime := NewMockInstance(ctrl)
+	staleRuntime.EXPECT().Stop()
+	sharedRuntime := NewMockInstance(ctrl)
+	bt.StoreRuntime(hashes[0], sharedRuntime)
+	bt.StoreRuntime(staleFork, staleRuntime)
+	bt.StoreRuntime(staleForkHead, sharedRuntime)
+
+	pruned := bt.PruneStaleBranches(0, nil)
+	assert.Empty(t, pruned)
+
+	kept := map[Hash]struct{}{keptFork: {}}
+	pruned = bt.PruneStaleBranches(3, kept)
+	assert.ElementsMatch(t, []Hash{staleFork, staleForkHead}, pruned)
+
+	assert.ElementsMatch(t, []Hash{hashes[10], keptFork, recentFork}, bt.Leaves())
+	assert.Nil(t, bt.getNode(staleFork))
+	assert.Equal(t, hashes[10], bt.BestBlockHash())
+	assert.ElementsMatch(t, []Hash{hashes[0]}, bt.runtimes.hashes())
+
+	// the remaining branches are either kept or recent enough
+	pruned = bt.PruneStaleBranches(3, kept)
+	assert.Empty(t, pruned)
+}
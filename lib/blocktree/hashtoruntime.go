@@ -42,6 +42,32 @@ func (h *hashToRuntime) delete(hash Hash) {
 	inMemoryRuntimesGauge.Dec()
 }
 
+// onBranchPruned deletes the runtimes of the blocks of a pruned branch, and
+// stops the ones which are no longer used by any other block.
+func (h *hashToRuntime) onBranchPruned(prunedHashes []common.Hash) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	prunedRuntimes := make(map[runtime.Instance]struct{})
+	for _, hash := range prunedHashes {
+		instance, has := h.mapping[hash]
+		if !has {
+			continue
+		}
+		prunedRuntimes[instance] = struct{}{}
+		delete(h.mapping, hash)
+	}
+
+	for _, instance := range h.mapping {
+		delete(prunedRuntimes, instance)
+	}
+	for instance := range prunedRuntimes {
+		instance.Stop()
+	}
+
+	inMemoryRuntimesGauge.Set(float64(len(h.mapping)))
+}
+
 func (h *hashToRuntime) hashes() (hashes []common.Hash) {
 	h.mutex.RLock()
 	defer h.mutex.RUnlock()
@@ -107,6 +107,15 @@ func (lm *leafMap) toMap() map[common.Hash]*node {
 	return mmap
 }
 
+// delete deletes the leaves with the given hashes from the map
+func (lm *leafMap) delete(hashes ...Hash) {
+	lm.Lock()
+	defer lm.Unlock()
+	for _, hash := range hashes {
+		lm.smap.Delete(hash)
+	}
+}
+
 func (lm *leafMap) nodes() []*node {
 	lm.RLock()
 	defer lm.RUnlock()
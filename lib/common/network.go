@@ -28,6 +28,30 @@ type PeerInfo struct {
 	BestNumber uint64
 }
 
+// PeerStats is the traffic exchanged with a connected peer needed for the rpc server.
+// The totals cover the whole connection, and the recent fields cover the rolling window.
+type PeerStats struct {
+	PeerID         string
+	MessagesIn     uint64
+	MessagesOut    uint64
+	BytesIn        uint64
+	BytesOut       uint64
+	RecentBytesIn  uint64
+	RecentBytesOut uint64
+	Protocols      []ProtocolStats
+}
+
+// ProtocolStats is the traffic exchanged with a peer over a single protocol.
+type ProtocolStats struct {
+	Protocol       string
+	MessagesIn     uint64
+	MessagesOut    uint64
+	BytesIn        uint64
+	BytesOut       uint64
+	RecentBytesIn  uint64
+	RecentBytesOut uint64
+}
+
 // NetworkRole is the type of node.
 type NetworkRole byte
 
@@ -11,6 +11,7 @@
 	"fmt"
 	"io"
 
+	primitivesed25519 "github.com/ChainSafe/gossamer/internal/primitives/core/ed25519"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto"
 
@@ -132,6 +133,17 @@ func NewKeypairFromMnenomic(mnemonic, password string) (*Keypair, error) {
 	return NewKeypairFromSeed(seed[:32])
 }
 
+// NewKeypairFromSecretURI returns the Keypair of the secret URI, made of a BIP39 mnemonic
+// or a 0x prefixed hex encoded seed, followed by the hard (//) junctions of its derivation
+// path and by an optional ///password. Soft junctions are not supported by ed25519 keys.
+func NewKeypairFromSecretURI(uri string) (*Keypair, error) {
+	_, seed, err := primitivesed25519.NewPairFromStringWithSeed(uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	return NewKeypairFromSeed(seed[:])
+}
+
 // GenerateKeypair returns a new ed25519 keypair
 func GenerateKeypair() (*Keypair, error) {
 	buf := make([]byte, SeedLength)
@@ -178,3 +178,18 @@ func TestPublicKeyFromPrivate(t *testing.T) {
 	addr := crypto.PublicKeyToAddress(kp.Public())
 	require.Equal(t, "5FA9nQDVg267DEd8m1ZypXLBnvN7SFxYwV7ndqSYGiN9TTpu", string(addr))
 }
+
+func TestNewKeypairFromSecretURI(t *testing.T) {
+	t.Parallel()
+
+	keypair, err := NewKeypairFromSecretURI("//Alice")
+	require.NoError(t, err)
+	require.Equal(t, "0x88dc3417d5058ec4b4503e0c12ea1a0a89be200fe98922423d4334014fa6b0ee", keypair.Public().Hex())
+
+	keypair, err = NewKeypairFromSecretURI("0xabf8e5bdbe30c65656c0a3cbd181ff8a56294a69dfedd27982aace4a76909115")
+	require.NoError(t, err)
+	require.Equal(t, "0x88dc3417d5058ec4b4503e0c12ea1a0a89be200fe98922423d4334014fa6b0ee", keypair.Public().Hex())
+
+	_, err = NewKeypairFromSecretURI("//Alice/soft")
+	require.Error(t, err)
+}
@@ -5,10 +5,10 @@
 
 import (
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/ss58"
 
 	"github.com/btcsuite/btcutil/base58"
 	bip39 "github.com/cosmos/go-bip39"
-	"golang.org/x/crypto/blake2b"
 )
 
 // KeyType str
@@ -44,27 +44,15 @@ type PrivateKey interface {
 	Hex() string
 }
 
-var ss58Prefix = []byte("SS58PRE")
-
 // PublicKeyToAddress returns an ss58 address given a PublicKey
 // see: https://github.com/paritytech/substrate/wiki/External-Address-Format-(SS58)
 // also see: https://github.com/paritytech/substrate/blob/master/primitives/core/src/crypto.rs#L275
 func PublicKeyToAddress(pub PublicKey) common.Address {
-	enc := append([]byte{42}, pub.Encode()...)
-	return publicKeyBytesToAddress(enc)
-}
-
-func publicKeyBytesToAddress(b []byte) common.Address {
-	hasher, err := blake2b.New(64, nil)
-	if err != nil {
-		return ""
-	}
-	_, err = hasher.Write(append(ss58Prefix, b...))
+	address, err := ss58.Encode(ss58.SubstratePrefix, pub.Encode())
 	if err != nil {
 		return ""
 	}
-	checksum := hasher.Sum(nil)
-	return common.Address(base58.Encode(append(b, checksum[:2]...)))
+	return address
 }
 
 // PublicAddressToByteArray returns []byte address for given PublicKey Address
@@ -8,9 +8,13 @@
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"strings"
 
+	"github.com/ChainSafe/go-schnorrkel"
+	corecrypto "github.com/ChainSafe/gossamer/internal/primitives/core/crypto"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/pkg/scale"
 	secp256k1 "github.com/ethereum/go-ethereum/crypto"
 )
 
@@ -127,6 +131,62 @@ func NewKeypairFromPrivateKeyString(in string) (*Keypair, error) {
 	return NewKeypairFromPrivate(priv)
 }
 
+// NewKeypairFromSecretURI returns the Keypair of the secret URI, made of a BIP39 mnemonic
+// or a 0x prefixed hex encoded seed, followed by the hard (//) junctions of its derivation
+// path and by an optional ///password. Soft junctions are not supported by secp256k1 keys.
+func NewKeypairFromSecretURI(uri string) (*Keypair, error) {
+	secretURI, err := corecrypto.NewSecretURI(uri)
+	if err != nil {
+		return nil, fmt.Errorf("parsing secret uri: %w", err)
+	}
+
+	var password string
+	if secretURI.Password != nil {
+		password = *secretURI.Password
+	}
+
+	var seed [PrivateKeyLength]byte
+	if hexSeed, ok := strings.CutPrefix(secretURI.Phrase, "0x"); ok {
+		decoded, err := hex.DecodeString(hexSeed)
+		if err != nil {
+			return nil, fmt.Errorf("decoding seed: %w", err)
+		}
+		if len(decoded) != PrivateKeyLength {
+			return nil, fmt.Errorf("seed is %d bytes instead of %d", len(decoded), PrivateKeyLength)
+		}
+		seed = [PrivateKeyLength]byte(decoded)
+	} else {
+		bigSeed, err := schnorrkel.SeedFromMnemonic(secretURI.Phrase, password)
+		if err != nil {
+			return nil, err
+		}
+		copy(seed[:], bigSeed[:PrivateKeyLength])
+	}
+
+	for _, junction := range secretURI.Junctions {
+		chainCode, ok := junction.Value().(corecrypto.DeriveJunctionHard)
+		if !ok {
+			return nil, errors.New("soft junctions are not supported by secp256k1 keys")
+		}
+
+		encoded := scale.MustMarshal(struct {
+			ID        string
+			Seed      [PrivateKeyLength]byte
+			ChainCode [32]byte
+		}{"Secp256k1HDKD", seed, chainCode})
+		seed, err = common.Blake2bHash(encoded)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	priv, err := NewPrivateKey(seed[:])
+	if err != nil {
+		return nil, err
+	}
+	return NewKeypairFromPrivate(priv)
+}
+
 // GenerateKeypair will generate a Keypair
 func GenerateKeypair() (*Keypair, error) {
 	priv, err := secp256k1.GenerateKey()
@@ -7,7 +7,9 @@
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"strings"
 
+	corecrypto "github.com/ChainSafe/gossamer/internal/primitives/core/crypto"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto"
 
@@ -159,6 +161,63 @@ func NewKeypairFromMnenomic(mnemonic, password string) (*Keypair, error) {
 	}, nil
 }
 
+// NewKeypairFromSecretURI returns the Keypair of the secret URI, made of a BIP39 mnemonic
+// or a 0x prefixed hex encoded seed, followed by the hard (//) and soft (/) junctions of
+// its derivation path and by an optional ///password. A secret URI starting with a
+// junction derives from the development mnemonic, such as //Alice.
+func NewKeypairFromSecretURI(uri string) (*Keypair, error) {
+	secretURI, err := corecrypto.NewSecretURI(uri)
+	if err != nil {
+		return nil, fmt.Errorf("parsing secret uri: %w", err)
+	}
+
+	var password string
+	if secretURI.Password != nil {
+		password = *secretURI.Password
+	}
+
+	var msc *sr25519.MiniSecretKey
+	if hexSeed, ok := strings.CutPrefix(secretURI.Phrase, "0x"); ok {
+		seed, err := hex.DecodeString(hexSeed)
+		if err != nil {
+			return nil, fmt.Errorf("decoding seed: %w", err)
+		}
+		if len(seed) != SeedLength {
+			return nil, fmt.Errorf("seed is %d bytes instead of %d", len(seed), SeedLength)
+		}
+		msc, err = sr25519.NewMiniSecretKeyFromRaw([SeedLength]byte(seed))
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		msc, err = sr25519.MiniSecretKeyFromMnemonic(secretURI.Phrase, password)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	secret := msc.ExpandEd25519()
+	for _, junction := range secretURI.Junctions {
+		var extended *sr25519.ExtendedKey
+		switch chainCode := junction.Value().(type) {
+		case corecrypto.DeriveJunctionHard:
+			extended, err = sr25519.DeriveKeyHard(secret, nil, chainCode)
+		case corecrypto.DeriveJunctionSoft:
+			extended, err = sr25519.DeriveKeySimple(secret, nil, chainCode)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("deriving junction: %w", err)
+		}
+
+		secret, err = extended.Secret()
+		if err != nil {
+			return nil, fmt.Errorf("getting derived secret: %w", err)
+		}
+	}
+
+	return NewKeypair(secret)
+}
+
 // NewPrivateKey creates a new private key using the input bytes
 func NewPrivateKey(in []byte) (*PrivateKey, error) {
 	if len(in) != PrivateKeyLength {
@@ -9,6 +9,8 @@
 	"fmt"
 	"testing"
 
+	sr25519 "github.com/ChainSafe/go-schnorrkel"
+	corecrypto "github.com/ChainSafe/gossamer/internal/primitives/core/crypto"
 	"github.com/ChainSafe/gossamer/lib/crypto"
 	bip39 "github.com/cosmos/go-bip39"
 	"github.com/gtank/merlin"
@@ -98,6 +100,48 @@ func TestVrfSignAndVerify(t *testing.T) {
 	require.True(t, ok)
 }
 
+func TestVrfSignExtraAndVerifyExtra(t *testing.T) {
+	kp, err := GenerateKeypair()
+	require.NoError(t, err)
+	pub := kp.Public().(*PublicKey)
+
+	newExtra := func(data string) *merlin.Transcript {
+		extra := merlin.NewTranscript("extra")
+		extra.AppendMessage([]byte("data"), []byte(data))
+		return extra
+	}
+
+	out, proof, err := kp.VrfSignExtra(merlin.NewTranscript("helloworld"), newExtra("a"))
+	require.NoError(t, err)
+
+	ok, err := pub.VrfVerifyExtra(merlin.NewTranscript("helloworld"), out, proof, newExtra("a"))
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// the extra transcript is committed to the proof
+	ok, err = pub.VrfVerifyExtra(merlin.NewTranscript("helloworld"), out, proof, newExtra("b"))
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// but not to the output
+	expectedOut, _, err := kp.VrfSign(merlin.NewTranscript("helloworld"))
+	require.NoError(t, err)
+	require.Equal(t, expectedOut, out)
+
+	// a proof with the default extra transcript is a schnorrkel proof
+	out, proof, err = kp.VrfSignExtra(merlin.NewTranscript("helloworld"), merlin.NewTranscript("VRF"))
+	require.NoError(t, err)
+	ok, err = pub.VrfVerify(merlin.NewTranscript("helloworld"), out, proof)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	out, proof, err = kp.VrfSign(merlin.NewTranscript("helloworld"))
+	require.NoError(t, err)
+	ok, err = pub.VrfVerifyExtra(merlin.NewTranscript("helloworld"), out, proof, merlin.NewTranscript("VRF"))
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
 func TestSignAndVerify_Deprecated(t *testing.T) {
 	kp, err := GenerateKeypair()
 	require.NoError(t, err)
@@ -182,3 +226,65 @@ func TestVerifySignature(t *testing.T) {
 	}
 
 }
+
+func TestNewKeypairFromSecretURI(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		uri       string
+		publicKey string
+	}{
+		"development_phrase": {
+			uri:       "bottom drive obey lake curtain smoke basket hold race lonely fit walk",
+			publicKey: "0x46ebddef8cd9bb167dc30878d7113b7e168e6f0646beffd77d69d39bad76b47a",
+		},
+		"hard_junction": {
+			uri:       "//Alice",
+			publicKey: "0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d",
+		},
+		"hard_junctions": {
+			uri:       "//Alice//stash",
+			publicKey: "0xbe5ddb1579b72e84524fc29e78609e3caf42e85aa118ebfe0b0ad404b5bdd25f",
+		},
+		"seed": {
+			uri:       "0xe5be9a5092b81bca64be81d212e7f2f9eba183bb7a90954f7b76361f6edb5c0a",
+			publicKey: "0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			keypair, err := NewKeypairFromSecretURI(testCase.uri)
+			require.NoError(t, err)
+			require.Equal(t, testCase.publicKey, keypair.Public().Hex())
+		})
+	}
+}
+
+func TestNewKeypairFromSecretURI_SoftJunction(t *testing.T) {
+	t.Parallel()
+
+	parent, err := NewKeypairFromSecretURI("//Alice")
+	require.NoError(t, err)
+	child, err := NewKeypairFromSecretURI("//Alice/1")
+	require.NoError(t, err)
+
+	// soft junctions can be derived from the public key of the parent
+	chainCode, err := corecrypto.NewDeriveJunctionSoft(1)
+	require.NoError(t, err)
+	extended, err := sr25519.DeriveKeySimple(parent.public.key, nil, chainCode)
+	require.NoError(t, err)
+	expected, err := extended.Public()
+	require.NoError(t, err)
+	require.Equal(t, expected.Encode(), [PublicKeyLength]byte(child.Public().Encode()))
+
+	message := []byte("message")
+	signature, err := child.Sign(message)
+	require.NoError(t, err)
+	ok, err := child.Public().Verify(message, signature)
+	require.NoError(t, err)
+	require.True(t, ok)
+}
@@ -0,0 +1,147 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sr25519
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	"github.com/gtank/merlin"
+	r255 "github.com/gtank/ristretto255"
+)
+
+// VrfSignExtra creates a VRF output and proof from a message and keypair, with the
+// extra transcript committed to the proof only, not to the output.
+func (kp *Keypair) VrfSignExtra(t, extra *merlin.Transcript) (
+	[VRFOutputLength]byte, [VRFProofLength]byte, error) {
+	return kp.private.VrfSignExtra(t, extra)
+}
+
+// VrfSignExtra creates a VRF output and proof from a message and private key, with the
+// extra transcript committed to the proof only, not to the output. This is schnorrkel's
+// vrf_sign_extra, which binds additional data to the proof without changing the output.
+func (k *PrivateKey) VrfSignExtra(t, extra *merlin.Transcript) (
+	out [VRFOutputLength]byte, proof [VRFProofLength]byte, err error) {
+	if k.key == nil {
+		return out, proof, errors.New("key is nil")
+	}
+	if extra == nil {
+		return out, proof, errors.New("extra transcript provided is nil")
+	}
+
+	// the proof created with the output is discarded, since it commits to the
+	// default extra transcript
+	inout, _, err := k.key.VrfSign(t)
+	if err != nil {
+		return out, proof, err
+	}
+	input, output, err := decodeInOut(inout.Encode())
+	if err != nil {
+		return out, proof, err
+	}
+
+	pub, err := k.key.Public()
+	if err != nil {
+		return out, proof, err
+	}
+	publicKey := pub.Encode()
+	secret := r255.NewScalar()
+	secretKey := k.key.Encode()
+	err = secret.Decode(secretKey[:])
+	if err != nil {
+		return out, proof, fmt.Errorf("decoding secret scalar: %w", err)
+	}
+
+	var randomBytes [64]byte
+	_, err = rand.Read(randomBytes[:])
+	if err != nil {
+		return out, proof, fmt.Errorf("generating witness: %w", err)
+	}
+	witness := r255.NewScalar().FromUniformBytes(randomBytes[:])
+
+	commitment := r255.NewElement().ScalarBaseMult(witness)
+	inputCommitment := r255.NewElement().ScalarMult(witness, input)
+	challenge := dleqChallenge(extra, input, output, commitment, inputCommitment, publicKey[:])
+	response := r255.NewScalar().Subtract(witness, r255.NewScalar().Multiply(challenge, secret))
+
+	copy(out[:], output.Encode(nil))
+	copy(proof[:32], challenge.Encode(nil))
+	copy(proof[32:], response.Encode(nil))
+	return out, proof, nil
+}
+
+// VrfVerifyExtra confirms that the output and proof are valid given a message, the
+// extra transcript committed to the proof, and public key.
+func (k *PublicKey) VrfVerifyExtra(t *merlin.Transcript, out [VRFOutputLength]byte,
+	proof [VRFProofLength]byte, extra *merlin.Transcript) (bool, error) {
+	if k.key == nil {
+		return false, errors.New("nil public key")
+	}
+	if extra == nil {
+		return false, errors.New("extra transcript provided is nil")
+	}
+
+	challenge := r255.NewScalar()
+	err := challenge.Decode(proof[:32])
+	if err != nil {
+		return false, fmt.Errorf("decoding proof challenge: %w", err)
+	}
+	response := r255.NewScalar()
+	err = response.Decode(proof[32:])
+	if err != nil {
+		return false, fmt.Errorf("decoding proof response: %w", err)
+	}
+
+	inout, err := AttachInput(out, k, t)
+	if err != nil {
+		return false, err
+	}
+	input, output, err := decodeInOut(inout.Encode())
+	if err != nil {
+		return false, err
+	}
+
+	publicKey := k.key.Encode()
+	publicPoint := r255.NewElement()
+	err = publicPoint.Decode(publicKey[:])
+	if err != nil {
+		return false, fmt.Errorf("decoding public key point: %w", err)
+	}
+
+	commitment := r255.NewElement().VarTimeDoubleScalarBaseMult(challenge, publicPoint, response)
+	inputCommitment := r255.NewElement().VarTimeMultiScalarMult(
+		[]*r255.Scalar{challenge, response}, []*r255.Element{output, input})
+	expected := dleqChallenge(extra, input, output, commitment, inputCommitment, publicKey[:])
+	return expected.Equal(challenge) == 1, nil
+}
+
+// dleqChallenge returns the challenge of the proof that the output and public key have the
+// same discrete logarithm with respect to the input and base point. The public key is
+// committed after the commitments, as schnorrkel does for Kusama and Polkadot.
+func dleqChallenge(t *merlin.Transcript, input, output, commitment, inputCommitment *r255.Element,
+	publicKey []byte) *r255.Scalar {
+	t.AppendMessage([]byte("proto-name"), []byte("DLEQProof"))
+	t.AppendMessage([]byte("vrf:h"), input.Encode(nil))
+	t.AppendMessage([]byte("vrf:R=g^r"), commitment.Encode(nil))
+	t.AppendMessage([]byte("vrf:h^r"), inputCommitment.Encode(nil))
+	t.AppendMessage([]byte("vrf:pk"), publicKey)
+	t.AppendMessage([]byte("vrf:h^sk"), output.Encode(nil))
+	return r255.NewScalar().FromUniformBytes(t.ExtractBytes([]byte("prove"), 64))
+}
+
+// decodeInOut decodes the input and output points of a VRF input and output encoding.
+func decodeInOut(encoded []byte) (input, output *r255.Element, err error) {
+	input = r255.NewElement()
+	err = input.Decode(encoded[:32])
+	if err != nil {
+		return nil, nil, fmt.Errorf("decoding vrf input: %w", err)
+	}
+	output = r255.NewElement()
+	err = output.Decode(encoded[32:])
+	if err != nil {
+		return nil, nil, fmt.Errorf("decoding vrf output: %w", err)
+	}
+	return input, output, nil
+}
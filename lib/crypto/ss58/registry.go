@@ -0,0 +1,130 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package ss58
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// ErrDuplicateNetwork is returned when a registry contains two networks with
+// the same prefix or the same name.
+var ErrDuplicateNetwork = errors.New("duplicate network")
+
+// registryJSON is a subset of the SS58 registry of
+// https://github.com/paritytech/ss58-registry/blob/main/ss58-registry.json
+//
+//go:embed registry.json
+var registryJSON []byte
+
+// Network is a network of the SS58 registry.
+type Network struct {
+	Prefix      uint16   `json:"prefix"`
+	Network     string   `json:"network"`
+	DisplayName string   `json:"displayName"`
+	Symbols     []string `json:"symbols"`
+	Decimals    []uint8  `json:"decimals"`
+	// StandardAccount is the account type of the network, such as *25519 or
+	// secp256k1. It is empty for networks without a standard account.
+	StandardAccount string `json:"standardAccount,omitempty"`
+	Website         string `json:"website,omitempty"`
+}
+
+// Registry is a set of networks indexed by prefix and name.
+type Registry struct {
+	byPrefix map[uint16]Network
+	byName   map[string]Network
+}
+
+// NewRegistry returns a registry of the networks.
+func NewRegistry(networks []Network) (*Registry, error) {
+	r := &Registry{
+		byPrefix: make(map[uint16]Network, len(networks)),
+		byName:   make(map[string]Network, len(networks)),
+	}
+
+	for _, network := range networks {
+		if network.Prefix > MaxPrefix {
+			return nil, fmt.Errorf("network %s: %w: %d is greater than %d",
+				network.Network, ErrInvalidPrefix, network.Prefix, MaxPrefix)
+		}
+		if _, has := r.byPrefix[network.Prefix]; has {
+			return nil, fmt.Errorf("%w: prefix %d", ErrDuplicateNetwork, network.Prefix)
+		}
+		if _, has := r.byName[network.Network]; has {
+			return nil, fmt.Errorf("%w: name %s", ErrDuplicateNetwork, network.Network)
+		}
+		r.byPrefix[network.Prefix] = network
+		r.byName[network.Network] = network
+	}
+	return r, nil
+}
+
+// LoadRegistry reads a registry in the JSON format of the SS58 registry.
+func LoadRegistry(reader io.Reader) (*Registry, error) {
+	var content struct {
+		Registry []Network `json:"registry"`
+	}
+	err := json.NewDecoder(reader).Decode(&content)
+	if err != nil {
+		return nil, fmt.Errorf("decoding registry: %w", err)
+	}
+	return NewRegistry(content.Registry)
+}
+
+// LoadRegistryFile reads a registry from a file in the JSON format of the SS58 registry.
+func LoadRegistryFile(path string) (*Registry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening registry file: %w", err)
+	}
+	defer file.Close()
+
+	return LoadRegistry(file)
+}
+
+var defaultRegistry = mustLoadDefaultRegistry()
+
+func mustLoadDefaultRegistry() *Registry {
+	r, err := LoadRegistry(bytes.NewReader(registryJSON))
+	if err != nil {
+		panic(fmt.Sprintf("loading default registry: %s", err))
+	}
+	return r
+}
+
+// DefaultRegistry returns the registry of the well known networks embedded in gossamer.
+func DefaultRegistry() *Registry {
+	return defaultRegistry
+}
+
+// Network returns the network with the prefix.
+func (r *Registry) Network(prefix uint16) (network Network, ok bool) {
+	network, ok = r.byPrefix[prefix]
+	return network, ok
+}
+
+// NetworkByName returns the network with the name, such as polkadot.
+func (r *Registry) NetworkByName(name string) (network Network, ok bool) {
+	network, ok = r.byName[name]
+	return network, ok
+}
+
+// Networks returns the networks of the registry sorted by prefix.
+func (r *Registry) Networks() []Network {
+	networks := make([]Network, 0, len(r.byPrefix))
+	for _, network := range r.byPrefix {
+		networks = append(networks, network)
+	}
+	sort.Slice(networks, func(i, j int) bool {
+		return networks[i].Prefix < networks[j].Prefix
+	})
+	return networks
+}
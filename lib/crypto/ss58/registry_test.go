@@ -0,0 +1,130 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package ss58
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DefaultRegistry(t *testing.T) {
+	t.Parallel()
+
+	registry := DefaultRegistry()
+
+	polkadot, ok := registry.Network(0)
+	require.True(t, ok)
+	assert.Equal(t, Network{
+		Prefix:          0,
+		Network:         "polkadot",
+		DisplayName:     "Polkadot Relay Chain",
+		Symbols:         []string{"DOT"},
+		Decimals:        []uint8{10},
+		StandardAccount: "*25519",
+		Website:         "https://polkadot.network",
+	}, polkadot)
+
+	kusama, ok := registry.NetworkByName("kusama")
+	require.True(t, ok)
+	assert.Equal(t, uint16(2), kusama.Prefix)
+
+	moonbeam, ok := registry.Network(1284)
+	require.True(t, ok)
+	assert.Equal(t, "secp256k1", moonbeam.StandardAccount)
+
+	_, ok = registry.Network(MaxPrefix)
+	assert.False(t, ok)
+
+	networks := registry.Networks()
+	for i := 1; i < len(networks); i++ {
+		assert.Less(t, networks[i-1].Prefix, networks[i].Prefix)
+	}
+}
+
+func Test_LoadRegistry(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		json       string
+		networks   []Network
+		errWrapped error
+		errMessage string
+	}{
+		"valid": {
+			json: `{"registry": [
+				{"prefix": 42, "network": "substrate", "displayName": "Substrate", "symbols": [], "decimals": [],
+					"standardAccount": "*25519", "website": "https://substrate.io/"},
+				{"prefix": 1, "network": "BareSr25519", "displayName": "Bare sr25519", "symbols": [], "decimals": [],
+					"standardAccount": "Sr25519", "website": null},
+				{"prefix": 4450, "network": "g1", "displayName": "Ğ1", "symbols": ["G1"], "decimals": [2],
+					"standardAccount": "*25519", "website": "https://duniter.org"}
+			]}`,
+			networks: []Network{
+				{Prefix: 1, Network: "BareSr25519", DisplayName: "Bare sr25519", Symbols: []string{}, Decimals: []uint8{},
+					StandardAccount: "Sr25519"},
+				{Prefix: 42, Network: "substrate", DisplayName: "Substrate", Symbols: []string{}, Decimals: []uint8{},
+					StandardAccount: "*25519", Website: "https://substrate.io/"},
+				{Prefix: 4450, Network: "g1", DisplayName: "Ğ1", Symbols: []string{"G1"}, Decimals: []uint8{2},
+					StandardAccount: "*25519", Website: "https://duniter.org"},
+			},
+		},
+		"invalid_json": {
+			json:       `{"registry": [`,
+			errMessage: "decoding registry: unexpected EOF",
+		},
+		"duplicate_prefix": {
+			json:       `{"registry": [{"prefix": 0, "network": "a"}, {"prefix": 0, "network": "b"}]}`,
+			errWrapped: ErrDuplicateNetwork,
+			errMessage: "duplicate network: prefix 0",
+		},
+		"duplicate_name": {
+			json:       `{"registry": [{"prefix": 0, "network": "a"}, {"prefix": 1, "network": "a"}]}`,
+			errWrapped: ErrDuplicateNetwork,
+			errMessage: "duplicate network: name a",
+		},
+		"prefix_too_large": {
+			json:       `{"registry": [{"prefix": 16384, "network": "a"}]}`,
+			errWrapped: ErrInvalidPrefix,
+			errMessage: "network a: invalid ss58 address: invalid network prefix: 16384 is greater than 16383",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			registry, err := LoadRegistry(strings.NewReader(testCase.json))
+			if testCase.errMessage != "" {
+				if testCase.errWrapped != nil {
+					assert.ErrorIs(t, err, testCase.errWrapped)
+				}
+				assert.EqualError(t, err, testCase.errMessage)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.networks, registry.Networks())
+		})
+	}
+}
+
+func Test_LoadRegistryFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "ss58-registry.json")
+	err := os.WriteFile(path, registryJSON, 0o600)
+	require.NoError(t, err)
+
+	registry, err := LoadRegistryFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultRegistry().Networks(), registry.Networks())
+
+	_, err = LoadRegistryFile(filepath.Join(t.TempDir(), "missing.json"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
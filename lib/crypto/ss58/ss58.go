@@ -0,0 +1,129 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+// Package ss58 implements the SS58 address format used by substrate chains.
+// See https://docs.substrate.io/reference/address-formats/
+package ss58
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/btcsuite/btcutil/base58"
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	// SubstratePrefix is the network prefix of generic substrate chains.
+	SubstratePrefix uint16 = 42
+	// MaxPrefix is the highest network prefix encodable in an address.
+	MaxPrefix uint16 = 16383
+
+	checksumLength = 2
+)
+
+var (
+	// ErrInvalidAddress is returned when decoding an invalid address. All the
+	// address decoding errors wrap it.
+	ErrInvalidAddress = errors.New("invalid ss58 address")
+	// ErrInvalidPrefix is returned when the network prefix of an address is reserved
+	// or greater than MaxPrefix.
+	ErrInvalidPrefix = fmt.Errorf("%w: invalid network prefix", ErrInvalidAddress)
+	// ErrInvalidLength is returned when the account id of an address is neither
+	// 32 nor 33 bytes long.
+	ErrInvalidLength = fmt.Errorf("%w: invalid length", ErrInvalidAddress)
+	// ErrInvalidChecksum is returned when the checksum of an address does not match.
+	ErrInvalidChecksum = fmt.Errorf("%w: invalid checksum", ErrInvalidAddress)
+)
+
+var checksumPrefix = []byte("SS58PRE")
+
+// Encode returns the address of the 32 bytes account id, or 33 bytes compressed
+// ecdsa public key, for the network prefix.
+func Encode(prefix uint16, accountID []byte) (common.Address, error) {
+	if prefix > MaxPrefix {
+		return "", fmt.Errorf("%w: %d is greater than %d", ErrInvalidPrefix, prefix, MaxPrefix)
+	}
+	if len(accountID) != 32 && len(accountID) != 33 {
+		return "", fmt.Errorf("%w: %d bytes account id", ErrInvalidLength, len(accountID))
+	}
+
+	payload := make([]byte, 0, 2+len(accountID)+checksumLength)
+	if prefix < 64 {
+		payload = append(payload, byte(prefix))
+	} else {
+		// the 14 bits prefix is split in two bytes, the first one starting with 0b01
+		payload = append(payload,
+			byte((prefix&0b1111_1100)>>2)|0b0100_0000,
+			byte(prefix>>8)|byte(prefix&0b11)<<6,
+		)
+	}
+	payload = append(payload, accountID...)
+	payload = append(payload, checksum(payload)...)
+	return common.Address(base58.Encode(payload)), nil
+}
+
+// MustEncode is like Encode but panics on error.
+func MustEncode(prefix uint16, accountID []byte) common.Address {
+	address, err := Encode(prefix, accountID)
+	if err != nil {
+		panic(err)
+	}
+	return address
+}
+
+// Decode returns the network prefix and the 32 bytes account id, or 33 bytes
+// compressed ecdsa public key, of the address after validating its checksum.
+func Decode(address common.Address) (prefix uint16, accountID []byte, err error) {
+	decoded := base58.Decode(string(address))
+	if len(decoded) == 0 {
+		return 0, nil, fmt.Errorf("%w: not base58 encoded", ErrInvalidAddress)
+	}
+
+	prefixLength := 1
+	switch {
+	case decoded[0] < 64:
+		prefix = uint16(decoded[0])
+	case decoded[0] < 128 && len(decoded) > 1:
+		prefixLength = 2
+		lower := decoded[0]<<2 | decoded[1]>>6
+		upper := decoded[1] & 0b0011_1111
+		prefix = uint16(lower) | uint16(upper)<<8
+	default:
+		return 0, nil, fmt.Errorf("%w: first byte %d", ErrInvalidPrefix, decoded[0])
+	}
+
+	accountLength := len(decoded) - prefixLength - checksumLength
+	if accountLength != 32 && accountLength != 33 {
+		return 0, nil, fmt.Errorf("%w: %d bytes address", ErrInvalidLength, len(decoded))
+	}
+
+	payload := decoded[:len(decoded)-checksumLength]
+	if !bytes.Equal(checksum(payload), decoded[len(payload):]) {
+		return 0, nil, ErrInvalidChecksum
+	}
+
+	return prefix, payload[prefixLength:], nil
+}
+
+// DecodeForPrefix decodes the address like Decode, and checks its network prefix
+// is the expected one.
+func DecodeForPrefix(address common.Address, prefix uint16) (accountID []byte, err error) {
+	decodedPrefix, accountID, err := Decode(address)
+	if err != nil {
+		return nil, err
+	}
+	if decodedPrefix != prefix {
+		return nil, fmt.Errorf("%w: expected %d but got %d", ErrInvalidPrefix, prefix, decodedPrefix)
+	}
+	return accountID, nil
+}
+
+// checksum returns the checksum of the address payload, made of the first bytes of
+// its blake2b-512 hash.
+func checksum(payload []byte) []byte {
+	hash := blake2b.Sum512(append(append([]byte{}, checksumPrefix...), payload...))
+	return hash[:checksumLength]
+}
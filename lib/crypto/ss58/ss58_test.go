@@ -0,0 +1,234 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package ss58
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	alice = common.MustHexToBytes("0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d")
+	bob   = common.MustHexToBytes("0x8eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48")
+	// aliceECDSA is the compressed ecdsa public key of //Alice
+	aliceECDSA = common.MustHexToBytes("0x020a1091341fe5664bfa1782d5e04779689068c916b04cb365ec3153755684d9a1")
+)
+
+func Test_Encode_Decode(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		prefix    uint16
+		accountID []byte
+		address   common.Address
+	}{
+		"alice_polkadot": {
+			prefix:    0,
+			accountID: alice,
+			address:   "15oF4uVJwmo4TdGW7VfQxNLavjCXviqxT9S1MgbjMNHr6Sp5",
+		},
+		"bob_polkadot": {
+			prefix:    0,
+			accountID: bob,
+			address:   "14E5nqKAp3oAJcmzgZhUD2RcptBeUBScxKHgJKU4HPNcKVf3",
+		},
+		"alice_bare_sr25519": {
+			prefix:    1,
+			accountID: alice,
+			address:   "BauKu2iL4fncgfy22YSLGc1aDLpyuUUe5z8yNF2pDtLNr4E",
+		},
+		"alice_kusama": {
+			prefix:    2,
+			accountID: alice,
+			address:   "HNZata7iMYWmk5RvZRTiAsSDhV8366zq2YGb3tLH5Upf74F",
+		},
+		"bob_kusama": {
+			prefix:    2,
+			accountID: bob,
+			address:   "FoQJpPyadYccjavVdTWxpxU7rUEaYhfLCPwXgkfD6Zat9QP",
+		},
+		"alice_astar": {
+			prefix:    5,
+			accountID: alice,
+			address:   "ajYMsCKsEAhEvHpeA4XqsfiA9v1CdzZPrCfS6pEfeGHW9j8",
+		},
+		"alice_edgeware": {
+			prefix:    7,
+			accountID: alice,
+			address:   "nJrsrH8dov9Z36kTDpabgCZT8CbK1FbmjJvfU6qbMTG4g4c",
+		},
+		"alice_acala": {
+			prefix:    10,
+			accountID: alice,
+			address:   "25fqepuLngYL2DK9ApTejNzqPadUUZ9ALYyKWX2jyvEiuZLa",
+		},
+		"alice_centrifuge": {
+			prefix:    36,
+			accountID: alice,
+			address:   "4g8zNcypnFHE5jqCifLGYoutCCM7uKWhF1NjWHka29hQE2rx",
+		},
+		"alice_substrate": {
+			prefix:    42,
+			accountID: alice,
+			address:   "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY",
+		},
+		"bob_substrate": {
+			prefix:    42,
+			accountID: bob,
+			address:   "5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty",
+		},
+		"alice_highest_single_byte_prefix": {
+			prefix:    63,
+			accountID: alice,
+			address:   "7NPoMQbiA6trJKkjB35uk96MeJD4PGWkLQLH7k7hXEkZpiba",
+		},
+		"alice_lowest_two_bytes_prefix": {
+			prefix:    64,
+			accountID: alice,
+			address:   "cEaNSpz4PxFcZ7nT1VEKrKewH67rfx6MfcM6yKojyyPz7qaqp",
+		},
+		"alice_heiko": {
+			prefix:    110,
+			accountID: alice,
+			address:   "hJKzPoi3MQnSLvbShxeDmzbtHncrMXe5zwS3Wa36P6kXeNpcv",
+		},
+		"alice_prefix_255": {
+			prefix:    255,
+			accountID: alice,
+			address:   "yGHXkYLYqxijLKKfd9Q2CB9shRVu8rPNBS53wvwGTutYg4zTg",
+		},
+		"alice_moonbeam": {
+			prefix:    1284,
+			accountID: alice,
+			address:   "VdvKmYJfD4VXA9fzz1SbmCo2eYHSzUFbaDCZSuaNKJAe8YNg6",
+		},
+		"bob_moonbeam": {
+			prefix:    1284,
+			accountID: bob,
+			address:   "VdtkcGEV4vmXFzfWUaWdpTT7gSSS71iCEiNR7rDEeEBitmYFZ",
+		},
+		"alice_interlay": {
+			prefix:    2032,
+			accountID: alice,
+			address:   "wdCJ8CsZchTEfUP8Xz1eZKNRjW5cuYjJ9fh6pcZNXezsysBrJ",
+		},
+		"alice_contextfree": {
+			prefix:    11820,
+			accountID: alice,
+			address:   "a7SvTrjvshEMePMEZpEkYMekuZMPpDwMNqfUx8N8ScEEQYfM8",
+		},
+		"alice_highest_prefix": {
+			prefix:    16383,
+			accountID: alice,
+			address:   "yNa8JpqfFB3q8A29rCwSgxvdU94ufJw2yKKxDgznS5m1PoFvn",
+		},
+		"alice_ecdsa_substrate": {
+			prefix:    42,
+			accountID: aliceECDSA,
+			address:   "KW39r9CJjAVzmkf9zQ4YDb2hqfAVGdRqn53eRqyruqpxAP5YL",
+		},
+		"alice_ecdsa_polkadot": {
+			prefix:    0,
+			accountID: aliceECDSA,
+			address:   "1CoWvCoktJHtTXSDybnqa1Evg2weWuhRusAiry1dU1fNR2Fy",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			address, err := Encode(testCase.prefix, testCase.accountID)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.address, address)
+
+			prefix, accountID, err := Decode(testCase.address)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.prefix, prefix)
+			assert.Equal(t, testCase.accountID, accountID)
+		})
+	}
+}
+
+func Test_Encode_errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := Encode(MaxPrefix+1, alice)
+	assert.ErrorIs(t, err, ErrInvalidPrefix)
+	assert.EqualError(t, err, "invalid ss58 address: invalid network prefix: 16384 is greater than 16383")
+
+	_, err = Encode(SubstratePrefix, alice[:20])
+	assert.ErrorIs(t, err, ErrInvalidLength)
+	assert.EqualError(t, err, "invalid ss58 address: invalid length: 20 bytes account id")
+}
+
+func Test_Decode_errors(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		address    common.Address
+		errWrapped error
+		errMessage string
+	}{
+		"empty": {
+			errWrapped: ErrInvalidAddress,
+			errMessage: "invalid ss58 address: not base58 encoded",
+		},
+		"not_base58": {
+			address:    "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQ0",
+			errWrapped: ErrInvalidAddress,
+			errMessage: "invalid ss58 address: not base58 encoded",
+		},
+		"bad_checksum": {
+			address:    "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQZ",
+			errWrapped: ErrInvalidChecksum,
+			errMessage: "invalid ss58 address: invalid checksum",
+		},
+		"bad_checksum_two_bytes_prefix": {
+			address:    "a8SvTrjvshEMePMEZpEkYMekuZMPpDwMNqfUx8N8ScEEQYfM8",
+			errWrapped: ErrInvalidChecksum,
+			errMessage: "invalid ss58 address: invalid checksum",
+		},
+		"too_short": {
+			address:    common.Address(base58.Encode([]byte{42, 1, 2, 3})),
+			errWrapped: ErrInvalidLength,
+			errMessage: "invalid ss58 address: invalid length: 4 bytes address",
+		},
+		"reserved_prefix": {
+			address:    common.Address(base58.Encode(append([]byte{128}, alice...))),
+			errWrapped: ErrInvalidPrefix,
+			errMessage: "invalid ss58 address: invalid network prefix: first byte 128",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, _, err := Decode(testCase.address)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errMessage != "" {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+		})
+	}
+}
+
+func Test_DecodeForPrefix(t *testing.T) {
+	t.Parallel()
+
+	accountID, err := DecodeForPrefix("15oF4uVJwmo4TdGW7VfQxNLavjCXviqxT9S1MgbjMNHr6Sp5", 0)
+	require.NoError(t, err)
+	assert.Equal(t, alice, accountID)
+
+	_, err = DecodeForPrefix("15oF4uVJwmo4TdGW7VfQxNLavjCXviqxT9S1MgbjMNHr6Sp5", 2)
+	assert.ErrorIs(t, err, ErrInvalidPrefix)
+	assert.EqualError(t, err, "invalid ss58 address: invalid network prefix: expected 2 but got 0")
+}
@@ -0,0 +1,107 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package equivocation
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Engine is the consensus engine an equivocation was detected for.
+type Engine string
+
+const (
+	// BABE is the engine label used for block production equivocations.
+	BABE Engine = "babe"
+	// GRANDPA is the engine label used for finality vote equivocations.
+	GRANDPA Engine = "grandpa"
+)
+
+const (
+	resultSubmitted = "submitted"
+	resultFailed    = "failed"
+)
+
+var (
+	logger = log.NewFromGlobal(log.AddContext("pkg", "equivocation"))
+
+	reportsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "gossamer_equivocation",
+		Name:      "reports_total",
+		Help:      "number of equivocation reports by consensus engine and submission result",
+	}, []string{"engine", "result"})
+	retriesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "gossamer_equivocation",
+		Name:      "report_retries_total",
+		Help:      "number of equivocation report submission retries by consensus engine",
+	}, []string{"engine"})
+)
+
+// RetryPolicy configures how many times a failed report submission is attempted.
+// The zero value attempts the submission exactly once.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of submission attempts.
+	MaxAttempts uint
+	// Backoff is the delay before the first retry, doubled for every further retry.
+	Backoff time.Duration
+}
+
+// DefaultRetryPolicy is the retry policy used by the consensus services.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	Backoff:     100 * time.Millisecond,
+}
+
+type permanentError struct {
+	err error
+}
+
+func (p *permanentError) Error() string { return p.err.Error() }
+func (p *permanentError) Unwrap() error { return p.err }
+
+// Permanent marks the error as not transient, so the submission is not retried.
+// The returned error wraps the given error.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// Submit calls `submit` to report an equivocation for the given engine, retrying
+// on failure according to the retry policy, unless the error is marked as permanent.
+// The last error encountered is returned as is.
+func Submit(engine Engine, policy RetryPolicy, submit func() error) (err error) {
+	attempts := policy.MaxAttempts
+	if attempts == 0 {
+		attempts = 1
+	}
+
+	backoff := policy.Backoff
+	for attempt := uint(1); ; attempt++ {
+		err = submit()
+		if err == nil {
+			reportsTotal.WithLabelValues(string(engine), resultSubmitted).Inc()
+			return nil
+		}
+
+		var permanent *permanentError
+		if errors.As(err, &permanent) || attempt >= attempts {
+			break
+		}
+
+		logger.Debugf("%s equivocation report attempt %d of %d failed, retrying in %s: %s",
+			engine, attempt, attempts, backoff, err)
+		retriesTotal.WithLabelValues(string(engine)).Inc()
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+
+	reportsTotal.WithLabelValues(string(engine), resultFailed).Inc()
+	return err
+}
@@ -0,0 +1,106 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package equivocation
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Submit(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+
+	testCases := map[string]struct {
+		engine           Engine
+		policy           RetryPolicy
+		errs             []error
+		expectedAttempts int
+		errWrapped       error
+	}{
+		"success_first_attempt": {
+			engine:           "test_success",
+			policy:           RetryPolicy{MaxAttempts: 3},
+			errs:             []error{nil},
+			expectedAttempts: 1,
+		},
+		"zero_policy_single_attempt": {
+			engine:           "test_zero_policy",
+			errs:             []error{errTest},
+			expectedAttempts: 1,
+			errWrapped:       errTest,
+		},
+		"transient_error_retried": {
+			engine:           "test_transient",
+			policy:           RetryPolicy{MaxAttempts: 3},
+			errs:             []error{errTest, errTest, nil},
+			expectedAttempts: 3,
+		},
+		"retries_exhausted": {
+			engine:           "test_exhausted",
+			policy:           RetryPolicy{MaxAttempts: 2},
+			errs:             []error{errTest, errTest},
+			expectedAttempts: 2,
+			errWrapped:       errTest,
+		},
+		"permanent_error_not_retried": {
+			engine:           "test_permanent",
+			policy:           RetryPolicy{MaxAttempts: 3},
+			errs:             []error{Permanent(errTest)},
+			expectedAttempts: 1,
+			errWrapped:       errTest,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			attempts := 0
+			err := Submit(testCase.engine, testCase.policy, func() error {
+				err := testCase.errs[attempts]
+				attempts++
+				return err
+			})
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			assert.Equal(t, testCase.expectedAttempts, attempts)
+
+			engine := string(testCase.engine)
+			if testCase.errWrapped == nil {
+				assert.Equal(t, 1., counterValue(t, reportsTotal.WithLabelValues(engine, resultSubmitted)))
+			} else {
+				assert.Equal(t, 1., counterValue(t, reportsTotal.WithLabelValues(engine, resultFailed)))
+			}
+			assert.Equal(t, float64(testCase.expectedAttempts-1),
+				counterValue(t, retriesTotal.WithLabelValues(engine)))
+		})
+	}
+}
+
+func Test_Permanent(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, Permanent(nil))
+
+	errTest := errors.New("test error")
+	err := Permanent(errTest)
+	assert.ErrorIs(t, err, errTest)
+	assert.EqualError(t, err, "test error")
+}
+
+func counterValue(t *testing.T, counter prometheus.Counter) float64 {
+	t.Helper()
+	var metric dto.Metric
+	err := counter.Write(&metric)
+	require.NoError(t, err)
+	return metric.GetCounter().GetValue()
+}
@@ -0,0 +1,176 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package genesis
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+)
+
+const (
+	// sudoKeyHex is the hex encoding of:
+	// Twox128Hash("Sudo") + Twox128Hash("Key")
+	sudoKeyHex = "0x5c0d1176a568c1f92944340dbfed9e9c530ebca703c85910e7164cb7d1c9e47b"
+	// systemLastRuntimeUpgradeKeyHex is the hex encoding of:
+	// Twox128Hash("System") + Twox128Hash("LastRuntimeUpgrade")
+	systemLastRuntimeUpgradeKeyHex = systemPrefixHex + "f9cce9c888469bb1a0dceaa129672ef8"
+	// stakingPrefixHex is the hex encoding of: Twox128Hash("Staking")
+	stakingPrefixHex = "0x5f3e4907f716ac89b6347d15ececedca"
+	// stakingForceEraKeyHex is the hex encoding of:
+	// Twox128Hash("Staking") + Twox128Hash("ForceEra")
+	stakingForceEraKeyHex = stakingPrefixHex + "f7dad0317324aecae8744b87fc95f2f3"
+	// forceNoneHex is the hex encoding of the SCALE encoded ForceNone variant of the staking Forcing enum
+	forceNoneHex = "0x02"
+)
+
+var codeKey = []byte(":code")
+
+// forkSkippedPallets are the pallets whose storage is kept from the base chain spec
+// instead of being copied from the forked state, since they hold the block
+// production and finality state and the session keys of the validators.
+var forkSkippedPallets = []string{
+	"System",
+	"Session",
+	"Babe",
+	"Grandpa",
+	"GrandpaFinality",
+	"FinalityTracker",
+	"Authorship",
+	"ImOnline",
+	"AuthorityDiscovery",
+	"Beefy",
+	"BeefyMmrLeaf",
+}
+
+// ErrForkBaseNotRaw is returned when the base chain spec of a fork is not a raw chain spec.
+var ErrForkBaseNotRaw = errors.New("base chain spec is not raw")
+
+// ForkOptions are the options of a forked chain spec.
+type ForkOptions struct {
+	// Sudo is the account id set as the sudo key. The sudo key of the
+	// forked state is kept if it is nil.
+	Sudo []byte
+}
+
+// Fork builds the chain spec of a local fork of a live chain from the storage
+// entries of its state, starting from a raw development chain spec. The storage
+// of the block production, finality and session pallets is kept from the base
+// chain spec, so the fork is run by the validators of the base chain spec.
+type Fork struct {
+	base    *Genesis
+	top     map[string]string
+	skipped [][]byte
+	// copiedPrefixes are the key prefixes of the skipped pallets copied from
+	// the forked state
+	copiedPrefixes [][]byte
+	hasStaking     bool
+	entries        uint64
+}
+
+// NewFork creates a new fork of the raw base chain spec.
+func NewFork(base *Genesis) (*Fork, error) {
+	if base.Genesis.Raw == nil || base.Genesis.Raw["top"] == nil {
+		return nil, ErrForkBaseNotRaw
+	}
+
+	var err error
+	skipped := make([][]byte, len(forkSkippedPallets))
+	for i, pallet := range forkSkippedPallets {
+		skipped[i], err = common.Twox128Hash([]byte(pallet))
+		if err != nil {
+			return nil, fmt.Errorf("hashing %s prefix: %w", pallet, err)
+		}
+	}
+
+	// the balances of the accounts, and the runtime version the forked state
+	// is migrated to, are copied from the forked state
+	copiedPrefixes := make([][]byte, 2)
+	for i, keyHex := range []string{systemAccountKeyHex, systemLastRuntimeUpgradeKeyHex} {
+		copiedPrefixes[i], err = common.HexToBytes(keyHex)
+		if err != nil {
+			return nil, fmt.Errorf("decoding key %s: %w", keyHex, err)
+		}
+	}
+
+	return &Fork{
+		base:           base,
+		top:            maps.Clone(base.Genesis.Raw["top"]),
+		skipped:        skipped,
+		copiedPrefixes: copiedPrefixes,
+	}, nil
+}
+
+// Add adds the storage entry of the forked state, unless it belongs to a
+// pallet kept from the base chain spec. The only well known key copied is
+// the runtime code, and child tries are not copied.
+func (f *Fork) Add(key, value []byte) {
+	if !f.copied(key) {
+		return
+	}
+
+	hexKey := common.BytesToHex(key)
+	if strings.HasPrefix(hexKey, stakingPrefixHex) {
+		f.hasStaking = true
+	}
+	f.top[hexKey] = common.BytesToHex(value)
+	f.entries++
+}
+
+func (f *Fork) copied(key []byte) bool {
+	// well known keys, including the child trie roots, start with a colon
+	if len(key) > 0 && key[0] == ':' {
+		return bytes.Equal(key, codeKey)
+	}
+
+	for _, prefix := range f.copiedPrefixes {
+		if bytes.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+
+	for _, prefix := range f.skipped {
+		if bytes.HasPrefix(key, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// Entries returns the number of storage entries copied from the forked state.
+func (f *Fork) Entries() uint64 {
+	return f.entries
+}
+
+// Genesis returns the chain spec of the fork. No new era is forced so the
+// validators of the base chain spec are not replaced by the validators of the
+// forked state.
+func (f *Fork) Genesis(options ForkOptions) *Genesis {
+	top := maps.Clone(f.top)
+	if options.Sudo != nil {
+		top[sudoKeyHex] = common.BytesToHex(options.Sudo)
+	}
+	if f.hasStaking {
+		top[stakingForceEraKeyHex] = forceNoneHex
+	}
+
+	raw := maps.Clone(f.base.Genesis.Raw)
+	raw["top"] = top
+
+	return &Genesis{
+		Name:               f.base.Name + "-fork",
+		ID:                 f.base.ID + "-fork",
+		ChainType:          "Development",
+		Bootnodes:          []string{},
+		TelemetryEndpoints: f.base.TelemetryEndpoints,
+		ProtocolID:         f.base.ProtocolID,
+		Genesis:            Fields{Raw: raw},
+		Properties:         f.base.Properties,
+		ConsensusEngine:    f.base.ConsensusEngine,
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package genesis
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_forkKeys(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		pallet, item string
+		keyHex       string
+	}{
+		"sudo_key":                    {pallet: "Sudo", item: "Key", keyHex: sudoKeyHex},
+		"system_last_runtime_upgrade": {pallet: "System", item: "LastRuntimeUpgrade", keyHex: systemLastRuntimeUpgradeKeyHex},
+		"staking_force_era":           {pallet: "Staking", item: "ForceEra", keyHex: stakingForceEraKeyHex},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			key, err := generateStorageKey(testCase.pallet, testCase.item)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.keyHex, key)
+		})
+	}
+}
+
+func Test_Fork(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewFork(&Genesis{})
+	assert.ErrorIs(t, err, ErrForkBaseNotRaw)
+
+	babeKey, err := generateStorageKey("Babe", "Authorities")
+	require.NoError(t, err)
+	systemNumberKey, err := generateStorageKey("System", "Number")
+	require.NoError(t, err)
+	accountKey := systemAccountKeyHex + "01"
+	balancesKey, err := generateStorageKey("Balances", "TotalIssuance")
+	require.NoError(t, err)
+	stakingKey, err := generateStorageKey("Staking", "ActiveEra")
+	require.NoError(t, err)
+
+	base := &Genesis{
+		Name:       "Development",
+		ID:         "dev",
+		Bootnodes:  []string{"/ip4/127.0.0.1/tcp/7001/p2p/12D3KooWHHzSeKaY8xuZVzkLbKFfvNgPPeKhFBGrMbNzbm5akpqu"},
+		ProtocolID: "dot",
+		Genesis: Fields{Raw: map[string]map[string]string{
+			"top": {
+				"0x3a636f6465":                 "0x01",
+				GrandpaAuthoritiesKeyHex:       "0x02",
+				babeKey:                        "0x03",
+				systemNumberKey:                "0x04",
+				systemLastRuntimeUpgradeKeyHex: "0x05",
+			},
+			"childrenDefault": {},
+		}},
+	}
+
+	fork, err := NewFork(base)
+	require.NoError(t, err)
+
+	live := map[string]string{
+		"0x3a636f6465":                 "0x11",
+		GrandpaAuthoritiesKeyHex:       "0x12",
+		babeKey:                        "0x13",
+		systemNumberKey:                "0x14",
+		accountKey:                     "0x15",
+		systemLastRuntimeUpgradeKeyHex: "0x19",
+		balancesKey:                    "0x16",
+		stakingKey:                     "0x17",
+		common.BytesToHex([]byte(":child_storage:default:test")): "0x18",
+	}
+	for key, value := range live {
+		fork.Add(common.MustHexToBytes(key), common.MustHexToBytes(value))
+	}
+	assert.Equal(t, uint64(5), fork.Entries())
+
+	sudo := make([]byte, 32)
+	sudo[0] = 0xaa
+	forked := fork.Genesis(ForkOptions{Sudo: sudo})
+
+	expectedTop := map[string]string{
+		"0x3a636f6465":                 "0x11",
+		GrandpaAuthoritiesKeyHex:       "0x02",
+		babeKey:                        "0x03",
+		systemNumberKey:                "0x04",
+		accountKey:                     "0x15",
+		systemLastRuntimeUpgradeKeyHex: "0x19",
+		balancesKey:                    "0x16",
+		stakingKey:                     "0x17",
+		sudoKeyHex:                     common.BytesToHex(sudo),
+		stakingForceEraKeyHex:          forceNoneHex,
+	}
+	assert.Equal(t, expectedTop, forked.Genesis.Raw["top"])
+	assert.Equal(t, map[string]string{}, forked.Genesis.Raw["childrenDefault"])
+	assert.Equal(t, "Development-fork", forked.Name)
+	assert.Equal(t, "dev-fork", forked.ID)
+	assert.Empty(t, forked.Bootnodes)
+
+	// the base chain spec is not modified
+	assert.Equal(t, "0x01", base.Genesis.Raw["top"]["0x3a636f6465"])
+}
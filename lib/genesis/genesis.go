@@ -16,6 +16,7 @@ type Genesis struct {
 	ID                 string                 `json:"id"`
 	ChainType          string                 `json:"chainType"`
 	Bootnodes          []string               `json:"bootNodes"`
+	BootnodesEndpoint  *BootnodesEndpoint     `json:"bootNodesEndpoint,omitempty"`
 	TelemetryEndpoints []interface{}          `json:"telemetryEndpoints"`
 	ProtocolID         string                 `json:"protocolId"`
 	Genesis            Fields                 `json:"genesis"`
@@ -24,6 +25,25 @@ type Genesis struct {
 	BadBlocks          []string               `json:"badBlocks"`
 	ConsensusEngine    string                 `json:"consensusEngine"`
 	CodeSubstitutes    map[string]string      `json:"codeSubstitutes"`
+	LightSyncState     *LightSyncState        `json:"lightSyncState,omitempty"`
+}
+
+// LightSyncState is a checkpoint of a chain specification, from which light clients can
+// start syncing instead of syncing from genesis. Its fields are hex encoded SCALE values
+// using the layout of the Substrate sync state.
+type LightSyncState struct {
+	FinalizedBlockHeader     string `json:"finalizedBlockHeader"`
+	BabeEpochChanges         string `json:"babeEpochChanges"`
+	BabeFinalizedBlockWeight uint32 `json:"babeFinalizedBlockWeight"`
+	GrandpaAuthoritySet      string `json:"grandpaAuthoritySet"`
+}
+
+// BootnodesEndpoint is the HTTPS endpoint serving an updated list of bootnodes,
+// signed with the ed25519 key of the chain maintainers.
+type BootnodesEndpoint struct {
+	URL string `json:"url"`
+	// PublicKey is the hex encoded ed25519 public key verifying the list signature.
+	PublicKey string `json:"publicKey"`
 }
 
 // Data defines the genesis file data formatted for trie storage
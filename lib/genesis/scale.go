@@ -0,0 +1,256 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package genesis
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+// scaleMagic prefixes the SCALE encoded chain specs. Its first byte cannot start a JSON
+// document, so the encoding of a chain spec file is detected from its first bytes.
+var scaleMagic = []byte{0x00, 'g', 's', 'p'}
+
+const (
+	// scaleVersion is the version of the layout of the SCALE encoded chain specs.
+	scaleVersion uint8 = 1
+	// maxStorageSizeHint bounds the number of entries allocated before decoding the
+	// storage entries, whose number is read from the chain spec file.
+	maxStorageSizeHint = 1 << 16
+	// maxBytesSizeHint bounds the size allocated before reading a byte array, whose length
+	// is read from the chain spec file.
+	maxBytesSizeHint = 1 << 20
+)
+
+var (
+	ErrNotSCALEChainSpec       = errors.New("not a SCALE encoded chain spec")
+	ErrUnsupportedSCALEVersion = errors.New("unsupported SCALE encoded chain spec version")
+)
+
+// EncodeSCALE writes the chain spec in its compact SCALE encoded form: the magic bytes
+// and the layout version, followed by the JSON encoding of the chain spec without its raw
+// storage, and then by the raw storage keys and values as bytes rather than hex strings.
+// The storage entries are written one at a time, sorted by key, so encoding the chain
+// spec does not build its whole encoding in memory.
+func (g *Genesis) EncodeSCALE(w io.Writer) error {
+	metadata := *g
+	metadata.Genesis.Raw = nil
+	metadataJSON, err := json.Marshal(metadata)
+	if err != nil {
+		return fmt.Errorf("encoding metadata: %w", err)
+	}
+
+	buffered := bufio.NewWriter(w)
+	encoder := scale.NewEncoder(buffered)
+
+	_, err = buffered.Write(scaleMagic)
+	if err != nil {
+		return fmt.Errorf("writing magic: %w", err)
+	}
+
+	raw := g.Genesis.Raw
+	children := maps.Keys(raw)
+	slices.Sort(children)
+	for _, value := range []any{scaleVersion, metadataJSON, uint32(len(children))} {
+		err = encoder.Encode(value)
+		if err != nil {
+			return fmt.Errorf("encoding header: %w", err)
+		}
+	}
+
+	for _, child := range children {
+		err = encodeSCALEStorage(encoder, child, raw[child])
+		if err != nil {
+			return fmt.Errorf("encoding %s storage: %w", child, err)
+		}
+	}
+
+	return buffered.Flush()
+}
+
+func encodeSCALEStorage(encoder *scale.Encoder, child string, storage map[string]string) error {
+	err := encoder.Encode(child)
+	if err != nil {
+		return err
+	}
+	err = encoder.Encode(uint32(len(storage)))
+	if err != nil {
+		return err
+	}
+
+	keys := maps.Keys(storage)
+	slices.Sort(keys)
+	for _, key := range keys {
+		keyBytes, err := common.HexToBytes(key)
+		if err != nil {
+			return fmt.Errorf("decoding key: %w", err)
+		}
+		valueBytes, err := common.HexToBytes(storage[key])
+		if err != nil {
+			return fmt.Errorf("decoding value of key %s: %w", key, err)
+		}
+
+		err = encoder.Encode(keyBytes)
+		if err != nil {
+			return err
+		}
+		err = encoder.Encode(valueBytes)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewGenesisFromSCALE parses a SCALE encoded chain spec file, written by EncodeSCALE.
+// The storage entries are decoded one at a time from the file, so its peak memory is
+// close to the memory of the chain spec decoded, unlike parsing a JSON chain spec.
+func NewGenesisFromSCALE(file string) (*Genesis, error) {
+	f, err := os.Open(filepath.Clean(file))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return decodeSCALE(bufio.NewReader(f))
+}
+
+// NewGenesisFromFile parses a chain spec file, either SCALE encoded or JSON formatted.
+func NewGenesisFromFile(file string) (*Genesis, error) {
+	f, err := os.Open(filepath.Clean(file))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	reader := bufio.NewReader(f)
+	prefix, err := reader.Peek(len(scaleMagic))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("reading chain spec: %w", err)
+	}
+	if !bytes.Equal(prefix, scaleMagic) {
+		return NewGenesisFromJSONRaw(file)
+	}
+
+	return decodeSCALE(reader)
+}
+
+func decodeSCALE(reader *bufio.Reader) (*Genesis, error) {
+	magic := make([]byte, len(scaleMagic))
+	_, err := io.ReadFull(reader, magic)
+	if err != nil || !bytes.Equal(magic, scaleMagic) {
+		return nil, ErrNotSCALEChainSpec
+	}
+
+	decoder := scale.NewDecoder(fullReader{reader})
+	var version uint8
+	err = decoder.Decode(&version)
+	if err != nil {
+		return nil, fmt.Errorf("decoding version: %w", err)
+	}
+	if version != scaleVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedSCALEVersion, version)
+	}
+
+	metadataJSON, err := decodeSCALEBytes(decoder, reader)
+	if err != nil {
+		return nil, fmt.Errorf("decoding metadata: %w", err)
+	}
+	g := new(Genesis)
+	err = json.Unmarshal(metadataJSON, g)
+	if err != nil {
+		return nil, fmt.Errorf("parsing metadata: %w", err)
+	}
+
+	var children uint32
+	err = decoder.Decode(&children)
+	if err != nil {
+		return nil, fmt.Errorf("decoding number of storages: %w", err)
+	}
+	if children == 0 {
+		return g, nil
+	}
+
+	g.Genesis.Raw = make(map[string]map[string]string)
+	for i := uint32(0); i < children; i++ {
+		child, storage, err := decodeSCALEStorage(decoder, reader)
+		if err != nil {
+			return nil, fmt.Errorf("decoding storage %d: %w", i, err)
+		}
+		g.Genesis.Raw[child] = storage
+	}
+	return g, nil
+}
+
+func decodeSCALEStorage(decoder *scale.Decoder, reader io.Reader) (
+	child string, storage map[string]string, err error) {
+	childBytes, err := decodeSCALEBytes(decoder, reader)
+	if err != nil {
+		return "", nil, err
+	}
+	var entries uint32
+	err = decoder.Decode(&entries)
+	if err != nil {
+		return "", nil, err
+	}
+
+	storage = make(map[string]string, min(entries, maxStorageSizeHint))
+	for i := uint32(0); i < entries; i++ {
+		key, err := decodeSCALEBytes(decoder, reader)
+		if err != nil {
+			return "", nil, fmt.Errorf("decoding key %d: %w", i, err)
+		}
+		value, err := decodeSCALEBytes(decoder, reader)
+		if err != nil {
+			return "", nil, fmt.Errorf("decoding value %d: %w", i, err)
+		}
+		storage[common.BytesToHex(key)] = common.BytesToHex(value)
+	}
+	return string(childBytes), storage, nil
+}
+
+// decodeSCALEBytes decodes a SCALE encoded byte array, growing it as its bytes are read
+// rather than allocating the length read from the chain spec file upfront.
+func decodeSCALEBytes(decoder *scale.Decoder, reader io.Reader) ([]byte, error) {
+	var length uint
+	err := decoder.Decode(&length)
+	if err != nil {
+		return nil, fmt.Errorf("decoding length: %w", err)
+	}
+
+	if length <= maxBytesSizeHint {
+		b := make([]byte, length)
+		_, err = io.ReadFull(reader, b)
+		return b, err
+	}
+
+	var buffer bytes.Buffer
+	buffer.Grow(maxBytesSizeHint)
+	_, err = io.CopyN(&buffer, reader, int64(length))
+	if err != nil {
+		return nil, fmt.Errorf("reading %d bytes: %w", length, err)
+	}
+	return buffer.Bytes(), nil
+}
+
+// fullReader reads until the buffer given is full, since the SCALE decoder
+// expects each read to return the size requested.
+type fullReader struct {
+	reader io.Reader
+}
+
+func (r fullReader) Read(b []byte) (int, error) {
+	return io.ReadFull(r.reader, b)
+}
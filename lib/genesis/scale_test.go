@@ -0,0 +1,103 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package genesis
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSCALEGenesis() *Genesis {
+	return &Genesis{
+		Name:               "gossamer",
+		ID:                 "gossamer",
+		Bootnodes:          []string{"/dns4/p2p.gossamer.test/tcp/30333"},
+		TelemetryEndpoints: []interface{}{"wss://telemetry.polkadot.io/submit/", float64(1)},
+		ProtocolID:         "/gossamer/test/0",
+		Properties: map[string]interface{}{
+			"ss58Format":  float64(0),
+			"tokenSymbol": "DOT",
+		},
+		Genesis: Fields{
+			Raw: map[string]map[string]string{
+				"top": {
+					"0x3a636f6465":         "0x0102",
+					"0x3a6865617070616765": "0x",
+				},
+				"childrenDefault": {"0x01": "0x02"},
+			},
+		},
+	}
+}
+
+func TestGenesis_EncodeSCALE(t *testing.T) {
+	t.Parallel()
+
+	expected := newTestSCALEGenesis()
+	var encoded bytes.Buffer
+	require.NoError(t, expected.EncodeSCALE(&encoded))
+
+	jsonEncoded, err := json.Marshal(expected)
+	require.NoError(t, err)
+	assert.Less(t, encoded.Len(), len(jsonEncoded))
+
+	// the encoding is deterministic
+	var encodedAgain bytes.Buffer
+	require.NoError(t, expected.EncodeSCALE(&encodedAgain))
+	assert.Equal(t, encoded.Bytes(), encodedAgain.Bytes())
+
+	scaleFile := filepath.Join(t.TempDir(), "genesis.scale")
+	require.NoError(t, os.WriteFile(scaleFile, encoded.Bytes(), os.ModePerm))
+	jsonFile := filepath.Join(t.TempDir(), "genesis.json")
+	require.NoError(t, os.WriteFile(jsonFile, jsonEncoded, os.ModePerm))
+
+	genesis, err := NewGenesisFromSCALE(scaleFile)
+	require.NoError(t, err)
+	assert.Equal(t, expected, genesis)
+
+	genesis, err = NewGenesisFromFile(scaleFile)
+	require.NoError(t, err)
+	assert.Equal(t, expected, genesis)
+
+	genesis, err = NewGenesisFromFile(jsonFile)
+	require.NoError(t, err)
+	assert.Equal(t, expected, genesis)
+
+	_, err = NewGenesisFromSCALE(jsonFile)
+	assert.ErrorIs(t, err, ErrNotSCALEChainSpec)
+
+	unsupported := bytes.Clone(encoded.Bytes())
+	unsupported[len(scaleMagic)] = scaleVersion + 1
+	require.NoError(t, os.WriteFile(scaleFile, unsupported, os.ModePerm))
+	_, err = NewGenesisFromFile(scaleFile)
+	assert.ErrorIs(t, err, ErrUnsupportedSCALEVersion)
+
+	truncated := encoded.Bytes()[:encoded.Len()-1]
+	require.NoError(t, os.WriteFile(scaleFile, truncated, os.ModePerm))
+	_, err = NewGenesisFromFile(scaleFile)
+	assert.Error(t, err)
+}
+
+func TestGenesis_EncodeSCALE_chainSpec(t *testing.T) {
+	t.Parallel()
+
+	expected, err := NewGenesisFromJSONRaw("../../chain/westend-local/westend-local-spec-raw.json")
+	require.NoError(t, err)
+
+	scaleFile := filepath.Join(t.TempDir(), "westend-local-spec-raw.scale")
+	f, err := os.Create(scaleFile)
+	require.NoError(t, err)
+	require.NoError(t, expected.EncodeSCALE(f))
+	require.NoError(t, f.Close())
+
+	genesis, err := NewGenesisFromFile(scaleFile)
+	require.NoError(t, err)
+	assert.Equal(t, expected, genesis)
+}
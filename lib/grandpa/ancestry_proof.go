@@ -0,0 +1,57 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+)
+
+var errVoteNotDescendantOfBase = newKindError(ErrNotDescendant, "vote target is not a descendant of base")
+
+// voteAncestries returns the minimal set of headers a recipient needs to
+// validate the ancestry of the targets of the given votes down to the base
+// block, which is the target of a commit. These are the headers from each vote
+// target, included, down to the base block, excluded. Each header is returned
+// once, in the order the votes are given, and no header is returned for votes
+// on the base block itself.
+func voteAncestries(blockState BlockState, base common.Hash, votes []SignedVote) (
+	headers []types.Header, err error) {
+	// the base header is only needed for votes on descendants of the base.
+	var baseHeader *types.Header
+	visited := map[common.Hash]struct{}{base: {}}
+	for _, vote := range votes {
+		hash := vote.Vote.Hash
+		for {
+			if _, ok := visited[hash]; ok {
+				break
+			}
+
+			if baseHeader == nil {
+				baseHeader, err = blockState.GetHeader(base)
+				if err != nil {
+					return nil, fmt.Errorf("getting base header: %w", err)
+				}
+			}
+
+			header, err := blockState.GetHeader(hash)
+			if err != nil {
+				return nil, fmt.Errorf("getting header of block %s: %w", hash, err)
+			}
+
+			if header.Number <= baseHeader.Number {
+				return nil, fmt.Errorf("%w: vote for block %s, base %s",
+					errVoteNotDescendantOfBase, vote.Vote.Hash, base)
+			}
+
+			headers = append(headers, *header)
+			visited[hash] = struct{}{}
+			hash = header.ParentHash
+		}
+	}
+
+	return headers, nil
+}
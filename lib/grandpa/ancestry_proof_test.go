@@ -0,0 +1,89 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_voteAncestries(t *testing.T) {
+	t.Parallel()
+
+	// base <- a1 <- a2
+	//      <- b1
+	base := &types.Header{Number: 10, Digest: types.NewDigest()}
+	a1 := &types.Header{ParentHash: base.Hash(), Number: 11, Digest: types.NewDigest()}
+	a2 := &types.Header{ParentHash: a1.Hash(), Number: 12, Digest: types.NewDigest()}
+	b1 := &types.Header{ParentHash: base.Hash(), Number: 11, StateRoot: common.Hash{1}, Digest: types.NewDigest()}
+	other := &types.Header{ParentHash: common.Hash{9}, Number: 10, Digest: types.NewDigest()}
+	headers := map[common.Hash]*types.Header{}
+	for _, header := range []*types.Header{base, a1, a2, b1, other} {
+		headers[header.Hash()] = header
+	}
+
+	vote := func(header *types.Header) SignedVote {
+		return SignedVote{Vote: Vote{Hash: header.Hash(), Number: uint32(header.Number)}}
+	}
+
+	errTest := errors.New("test error")
+
+	testCases := map[string]struct {
+		votes      []SignedVote
+		getErr     error
+		headers    []types.Header
+		errWrapped error
+	}{
+		"votes_on_base": {
+			votes: []SignedVote{vote(base), vote(base)},
+		},
+		"shared_ancestry": {
+			votes:   []SignedVote{vote(a1), vote(a2), vote(b1), vote(a2)},
+			headers: []types.Header{*a1, *a2, *b1},
+		},
+		"descendant_first": {
+			votes:   []SignedVote{vote(a2), vote(a1)},
+			headers: []types.Header{*a2, *a1},
+		},
+		"not_descendant": {
+			votes:      []SignedVote{vote(a1), vote(other)},
+			errWrapped: errVoteNotDescendantOfBase,
+		},
+		"get_header_error": {
+			votes:      []SignedVote{vote(a1)},
+			getErr:     errTest,
+			errWrapped: errTest,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			blockState := NewMockBlockState(ctrl)
+			// no header is read for votes on the base block.
+			blockState.EXPECT().GetHeader(gomock.Any()).DoAndReturn(
+				func(hash common.Hash) (*types.Header, error) {
+					if hash == base.Hash() && testCase.headers == nil && testCase.errWrapped == nil {
+						t.Fatal("unexpected base header read")
+					}
+					if hash != base.Hash() && testCase.getErr != nil {
+						return nil, testCase.getErr
+					}
+					return headers[hash], nil
+				}).AnyTimes()
+
+			ancestries, err := voteAncestries(blockState, base.Hash(), testCase.votes)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			assert.Equal(t, testCase.headers, ancestries)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"sync"
+)
+
+// commitVerifier is a pool of workers verifying the precommits of commit
+// messages concurrently, so verifying commits with hundreds of precommits
+// does not hold up the caller for the sum of all the signature and
+// ancestry checks.
+type commitVerifier struct {
+	jobs    chan func()
+	wg      sync.WaitGroup
+	mutex   sync.RWMutex
+	stopped bool
+}
+
+func newCommitVerifier(workers int) *commitVerifier {
+	v := &commitVerifier{
+		jobs: make(chan func(), workers),
+	}
+
+	v.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer v.wg.Done()
+			for job := range v.jobs {
+				job()
+			}
+		}()
+	}
+
+	return v
+}
+
+// run calls task for each index in [0, n) on the workers and returns once
+// all the tasks are done. If the verifier is nil or stopped, the tasks are
+// run sequentially in the calling goroutine.
+func (v *commitVerifier) run(n int, task func(i int)) {
+	if v != nil {
+		v.mutex.RLock()
+		defer v.mutex.RUnlock()
+	}
+
+	if v == nil || v.stopped {
+		for i := 0; i < n; i++ {
+			task(i)
+		}
+		return
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		i := i
+		v.jobs <- func() {
+			defer wg.Done()
+			task(i)
+		}
+	}
+	wg.Wait()
+}
+
+// stop stops the workers once the submitted jobs are done.
+func (v *commitVerifier) stop() {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	if v.stopped {
+		return
+	}
+	v.stopped = true
+	close(v.jobs)
+	v.wg.Wait()
+}
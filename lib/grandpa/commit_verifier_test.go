@@ -0,0 +1,60 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_commitVerifier_run(t *testing.T) {
+	t.Parallel()
+
+	const tasks = 100
+
+	testCases := map[string]struct {
+		verifier func() *commitVerifier
+	}{
+		"nil_verifier": {
+			verifier: func() *commitVerifier { return nil },
+		},
+		"workers": {
+			verifier: func() *commitVerifier { return newCommitVerifier(4) },
+		},
+		"stopped_verifier": {
+			verifier: func() *commitVerifier {
+				v := newCommitVerifier(4)
+				v.stop()
+				return v
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			verifier := testCase.verifier()
+			results := make([]int, tasks)
+			var calls atomic.Int64
+			verifier.run(tasks, func(i int) {
+				calls.Add(1)
+				results[i] = i * i
+			})
+
+			assert.Equal(t, int64(tasks), calls.Load())
+			for i, result := range results {
+				assert.Equal(t, i*i, result)
+			}
+
+			if verifier != nil {
+				verifier.stop()
+				verifier.stop()
+			}
+		})
+	}
+}
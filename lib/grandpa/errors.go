@@ -9,6 +9,26 @@
 	"github.com/ChainSafe/gossamer/lib/blocktree"
 )
 
+// Kinds of errors returned by the public entry points of the package, matched with
+// errors.Is. The more specific errors below which belong to a kind also match it.
+var (
+	// ErrNotDescendant is the kind of errors returned for a block which is not a
+	// descendant of the block it is required to descend from
+	ErrNotDescendant = errors.New("block is not a descendant")
+
+	// ErrUnknownAuthority is the kind of errors returned for a vote or signature
+	// of a key which is not in the authority set
+	ErrUnknownAuthority = errors.New("unknown authority")
+
+	// ErrWrongSetID is the kind of errors returned for a message or justification
+	// of another authority set than the expected one
+	ErrWrongSetID = errors.New("wrong set id")
+
+	// ErrStaleRound is the kind of errors returned for a message of a round which
+	// is behind the current round
+	ErrStaleRound = errors.New("stale round")
+)
+
 var (
 	// ErrBlockDoesNotExist is returned when trying to validate a vote for a block that doesn't exist
 	ErrBlockDoesNotExist = errors.New("block does not exist")
@@ -19,13 +39,13 @@
 	// ErrSetIDMismatch is returned when trying to validate a vote message
 	// with an invalid voter set ID, or when receiving a catch up message
 	// with a different set ID
-	ErrSetIDMismatch = errors.New("set IDs do not match")
+	ErrSetIDMismatch = newKindError(ErrWrongSetID, "set IDs do not match")
 
 	// ErrEquivocation is returned when trying to validate a vote for that is equivocatory
 	ErrEquivocation = errors.New("vote is equivocatory")
 
 	// ErrVoterNotFound is returned when trying to validate a vote for a voter that isn't in the voter set
-	ErrVoterNotFound = errors.New("voter is not in voter set")
+	ErrVoterNotFound = newKindError(ErrUnknownAuthority, "voter is not in voter set")
 
 	// ErrDescendantNotFound is returned when trying to validate a vote
 	// for a block that isn't a descendant of the last finalised block
@@ -78,15 +98,50 @@
 
 	// ErrPrecommitBlockMismatch is returned when a precommit hash within a
 	// justification is not a descendant of the committed block
-	ErrPrecommitBlockMismatch = errors.New("precommit block is not descendant of committed block")
+	ErrPrecommitBlockMismatch = newKindError(ErrNotDescendant, "precommit block is not descendant of committed block")
 
 	// ErrAuthorityNotInSet is returned when a precommit within a justification is signed by a key not in the authority set
-	ErrAuthorityNotInSet = errors.New("authority is not in set")
+	ErrAuthorityNotInSet = newKindError(ErrUnknownAuthority, "authority is not in set")
+
+	// ErrMessageTooLarge is returned when a network message exceeds the maximum grandpa message size
+	ErrMessageTooLarge = errors.New("message exceeds maximum size")
 
-	errVoteToSignatureMismatch  = errors.New("votes and authority count mismatch")
-	errVoteBlockMismatch        = errors.New("block in vote is not descendant of previously finalised block")
+	// ErrTooManyVotes is returned when a commit message or catch up response contains more
+	// votes or signatures than the voter set can produce
+	ErrTooManyVotes = errors.New("message contains too many votes")
+
+	// ErrBlockAlreadyFinalised is returned when importing the justification of a block
+	// which is not after the highest finalised block
+	ErrBlockAlreadyFinalised = errors.New("block is already finalised")
+
+	// ErrNoCompletedRounds is returned when summarising the participation of the
+	// authorities of a set without any completed round in the round reports
+	ErrNoCompletedRounds = errors.New("no completed rounds for set")
+
+	errVoteToSignatureMismatch = errors.New("votes and authority count mismatch")
+	errVoteBlockMismatch       = newKindError(ErrNotDescendant,
+		"block in vote is not descendant of previously finalised block")
 	errVoteFromSelf             = errors.New("got vote from ourselves")
 	errRoundOutOfBounds         = errors.New("round out of bounds")
 	errRoundsMismatch           = errors.New("rounds mismatch")
 	errInvalidEquivocationStage = errors.New("invalid stage for equivocating")
 )
+
+// kindError is an error belonging to a kind of errors, which errors.Is matches
+// against both the error itself and its kind.
+type kindError struct {
+	kind    error
+	message string
+}
+
+func newKindError(kind error, message string) error {
+	return &kindError{kind: kind, message: message}
+}
+
+func (e *kindError) Error() string {
+	return e.message
+}
+
+func (e *kindError) Unwrap() error {
+	return e.kind
+}
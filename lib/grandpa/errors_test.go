@@ -0,0 +1,65 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_errorKinds(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		err     error
+		kind    error
+		message string
+	}{
+		"set_id_mismatch": {
+			err:     ErrSetIDMismatch,
+			kind:    ErrWrongSetID,
+			message: "set IDs do not match",
+		},
+		"voter_not_found": {
+			err:     ErrVoterNotFound,
+			kind:    ErrUnknownAuthority,
+			message: "voter is not in voter set",
+		},
+		"authority_not_in_set": {
+			err:     ErrAuthorityNotInSet,
+			kind:    ErrUnknownAuthority,
+			message: "authority is not in set",
+		},
+		"precommit_block_mismatch": {
+			err:     ErrPrecommitBlockMismatch,
+			kind:    ErrNotDescendant,
+			message: "precommit block is not descendant of committed block",
+		},
+		"vote_block_mismatch": {
+			err:     errVoteBlockMismatch,
+			kind:    ErrNotDescendant,
+			message: "block in vote is not descendant of previously finalised block",
+		},
+		"vote_not_descendant_of_base": {
+			err:     errVoteNotDescendantOfBase,
+			kind:    ErrNotDescendant,
+			message: "vote target is not a descendant of base",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			wrapped := fmt.Errorf("wrapping: %w", testCase.err)
+			assert.ErrorIs(t, wrapped, testCase.kind)
+			assert.ErrorIs(t, wrapped, testCase.err)
+			assert.EqualError(t, testCase.err, testCase.message)
+			assert.NotErrorIs(t, ErrStaleRound, testCase.err)
+		})
+	}
+}
@@ -33,6 +33,8 @@ type finalisationHandler struct {
 	// are ephemeral services with a lifetime of a round
 	newServices   func() (engine, voting ephemeralService)
 	initiateRound func() error
+	// roundCompleted is called once the ephemeral services of a round completed
+	roundCompleted func()
 
 	stopCh      chan struct{}
 	handlerDone chan struct{}
@@ -47,10 +49,11 @@ func newFinalisationHandler(service *Service) *finalisationHandler {
 			votingRound := newvotingRoundHandler(service, finalisationEngine.actionCh)
 			return finalisationEngine, votingRound
 		},
-		initiateRound: service.initiateRound,
-		stopCh:        make(chan struct{}),
-		handlerDone:   make(chan struct{}),
-		firstRun:      true,
+		initiateRound:  service.initiateRound,
+		roundCompleted: service.publishRoundCompleted,
+		stopCh:         make(chan struct{}),
+		handlerDone:    make(chan struct{}),
+		firstRun:       true,
 	}
 }
 
@@ -188,6 +191,9 @@ func (fh *finalisationHandler) runEphemeralServices(ready chan<- struct{}) error
 
 		finish := votingRoundErr == nil && finalisationEngineErr == nil
 		if finish {
+			if fh.roundCompleted != nil {
+				fh.roundCompleted()
+			}
 			return nil
 		}
 	}
@@ -234,6 +240,11 @@ func (h *votingRoundHandler) Run() (err error) {
 		case action := <-h.finalisationEngineCh:
 			switch action {
 			case determinePrevote:
+				if h.grandpaService.observer {
+					// observers follow the round without casting votes
+					continue
+				}
+
 				isPrimary, err := h.grandpaService.handleIsPrimary()
 				if err != nil {
 					return fmt.Errorf("handling primary: %w", err)
@@ -246,12 +257,16 @@ func (h *votingRoundHandler) Run() (err error) {
 
 				signedpreVote, prevoteMessage, err :=
 					h.grandpaService.createSignedVoteAndVoteMessage(preVote, prevote)
-				if err != nil {
+				if isVoteNotCast(err) {
+					logger.Warnf("not sending pre-vote of round %d: %s", h.grandpaService.state.round, err)
+					continue
+				} else if err != nil {
 					return fmt.Errorf("creating signed vote: %w", err)
 				}
 
 				if !isPrimary {
 					h.grandpaService.prevotes.Store(h.grandpaService.publicKeyBytes(), signedpreVote)
+					h.grandpaService.observeVote(h.grandpaService.publicKeyBytes(), prevote, *preVote)
 				}
 
 				logger.Debugf("sending pre-vote message: {%v}", prevoteMessage)
@@ -261,6 +276,10 @@ func (h *votingRoundHandler) Run() (err error) {
 				}
 
 			case determinePrecommit:
+				if h.grandpaService.observer {
+					continue
+				}
+
 				preCommit, err := h.grandpaService.determinePreCommit()
 				if err != nil {
 					return fmt.Errorf("determining pre-commit: %w", err)
@@ -268,11 +287,15 @@ func (h *votingRoundHandler) Run() (err error) {
 
 				signedPreCommit, precommitMessage, err :=
 					h.grandpaService.createSignedVoteAndVoteMessage(preCommit, precommit)
-				if err != nil {
+				if isVoteNotCast(err) {
+					logger.Warnf("not sending pre-commit of round %d: %s", h.grandpaService.state.round, err)
+					continue
+				} else if err != nil {
 					return fmt.Errorf("creating signed vote: %w", err)
 				}
 
 				h.grandpaService.precommits.Store(h.grandpaService.publicKeyBytes(), signedPreCommit)
+				h.grandpaService.observeVote(h.grandpaService.publicKeyBytes(), precommit, *preCommit)
 				logger.Debugf("sending pre-commit message: {%v}", precommitMessage)
 				err = h.grandpaService.sendPrecommitMessage(precommitMessage)
 				if err != nil {
@@ -280,6 +303,16 @@ func (h *votingRoundHandler) Run() (err error) {
 				}
 
 			case finalize:
+				if h.grandpaService.observer {
+					// the commit message is left to the voters to broadcast
+					h.grandpaService.telemetry.SendMessage(telemetry.NewAfgFinalizedBlocksUpTo(
+						h.grandpaService.head.Hash(),
+						fmt.Sprint(h.grandpaService.head.Number),
+					))
+					logger.Debugf("round completed in %s", time.Since(start))
+					return nil
+				}
+
 				commitMessage, err := h.grandpaService.newCommitMessage(
 					h.grandpaService.head, h.grandpaService.state.round, h.grandpaService.state.setID)
 				if err != nil {
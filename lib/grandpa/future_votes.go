@@ -0,0 +1,100 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"sync"
+
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// maxRoundsAhead is the number of rounds ahead of the current round
+	// whose vote messages are buffered until their round starts.
+	maxRoundsAhead = 2
+	// futureVotesCapacity is the maximum number of vote messages buffered
+	// for the rounds ahead of the current round.
+	futureVotesCapacity = 2048
+)
+
+type futureVoteKey struct {
+	authorityID ed25519.PublicKeyBytes
+	stage       Subround
+}
+
+type futureRound struct {
+	setID uint64
+	round uint64
+}
+
+// futureVotes buffers the vote messages received for the rounds ahead of the current
+// round, sent by voters whose round timers run slightly ahead of ours, so they are
+// replayed once their round starts instead of being dropped.
+type futureVotes struct {
+	sync.Mutex
+	rounds   map[futureRound]map[futureVoteKey]networkVoteMessage
+	length   int
+	capacity int
+}
+
+func newFutureVotes(capacity int) *futureVotes {
+	return &futureVotes{
+		rounds:   make(map[futureRound]map[futureVoteKey]networkVoteMessage),
+		capacity: capacity,
+	}
+}
+
+// add buffers the vote message, replacing the vote of the same authority for the
+// same stage of the round, and returns false if the buffer is full.
+func (fv *futureVotes) add(from peer.ID, message *VoteMessage) (added bool) {
+	fv.Lock()
+	defer fv.Unlock()
+
+	round := futureRound{setID: message.SetID, round: message.Round}
+	key := futureVoteKey{authorityID: message.Message.AuthorityID, stage: message.Message.Stage}
+	votes, has := fv.rounds[round]
+	if !has {
+		votes = make(map[futureVoteKey]networkVoteMessage)
+		fv.rounds[round] = votes
+	}
+
+	if _, has := votes[key]; !has {
+		if fv.length >= fv.capacity {
+			if len(votes) == 0 {
+				delete(fv.rounds, round)
+			}
+			return false
+		}
+		fv.length++
+	}
+
+	votes[key] = networkVoteMessage{from: from, msg: message}
+	return true
+}
+
+// take removes and returns the vote messages buffered for the given round, and
+// drops the vote messages of the rounds before it and of the previous set ids.
+// The vote messages of the following set ids are kept until their set starts.
+func (fv *futureVotes) take(setID, round uint64) (messages []networkVoteMessage) {
+	fv.Lock()
+	defer fv.Unlock()
+
+	for bufferedRound, votes := range fv.rounds {
+		if bufferedRound.setID > setID ||
+			(bufferedRound.setID == setID && bufferedRound.round > round) {
+			continue
+		}
+
+		if bufferedRound.setID == setID && bufferedRound.round == round {
+			for _, vote := range votes {
+				messages = append(messages, vote)
+			}
+		}
+		fv.length -= len(votes)
+		delete(fv.rounds, bufferedRound)
+	}
+
+	return messages
+}
@@ -0,0 +1,65 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_futureVotes(t *testing.T) {
+	t.Parallel()
+
+	newVoteMessage := func(setID, round uint64, stage Subround, authority byte, hash byte) *VoteMessage {
+		return &VoteMessage{
+			Round: round,
+			SetID: setID,
+			Message: SignedMessage{
+				Stage:       stage,
+				BlockHash:   common.Hash{hash},
+				AuthorityID: ed25519.PublicKeyBytes{authority},
+			},
+		}
+	}
+
+	votes := newFutureVotes(4)
+
+	assert.True(t, votes.add("a", newVoteMessage(1, 3, prevote, 1, 1)))
+	assert.True(t, votes.add("a", newVoteMessage(1, 3, precommit, 1, 1)))
+	// the vote of the same authority for the same stage is replaced
+	assert.True(t, votes.add("b", newVoteMessage(1, 3, prevote, 1, 2)))
+	assert.True(t, votes.add("b", newVoteMessage(1, 4, prevote, 2, 1)))
+	assert.True(t, votes.add("c", newVoteMessage(0, 4, prevote, 3, 1)))
+	// the buffer is full
+	assert.False(t, votes.add("d", newVoteMessage(1, 4, prevote, 4, 1)))
+	assert.True(t, votes.add("d", newVoteMessage(1, 4, prevote, 2, 3)))
+
+	taken := votes.take(1, 3)
+	assert.ElementsMatch(t, []networkVoteMessage{
+		{from: "b", msg: newVoteMessage(1, 3, prevote, 1, 2)},
+		{from: "a", msg: newVoteMessage(1, 3, precommit, 1, 1)},
+	}, taken)
+
+	// the votes of the next set id are kept until the set starts
+	assert.True(t, votes.add("e", newVoteMessage(2, 1, prevote, 5, 1)))
+
+	// the votes of the previous set ids are dropped with the votes of the round
+	assert.Empty(t, votes.take(1, 3))
+	assert.Equal(t, 2, votes.length)
+
+	assert.Equal(t, []networkVoteMessage{
+		{from: peer.ID("d"), msg: newVoteMessage(1, 4, prevote, 2, 3)},
+	}, votes.take(1, 4))
+	assert.Equal(t, 1, votes.length)
+
+	assert.Equal(t, []networkVoteMessage{
+		{from: peer.ID("e"), msg: newVoteMessage(2, 1, prevote, 5, 1)},
+	}, votes.take(2, 1))
+	assert.Equal(t, 0, votes.length)
+	assert.Empty(t, votes.rounds)
+}
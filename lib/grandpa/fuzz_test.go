@@ -0,0 +1,45 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/require"
+)
+
+// FuzzDecodeConsensusMessage is named after the corpus directory of the GRANDPA
+// gossip messages written by the network --fuzz-corpus-dir capture mode.
+// A successfully decoded message must encode.
+func FuzzDecodeConsensusMessage(f *testing.F) {
+	seeds := []GrandpaMessage{
+		&VoteMessage{Round: 1, SetID: 2, Message: SignedMessage{
+			Stage:       prevote,
+			BlockHash:   common.Hash{1},
+			Number:      3,
+			AuthorityID: [32]byte{2},
+		}},
+		&CommitMessage{Round: 1, SetID: 2, Vote: Vote{Hash: common.Hash{1}, Number: 3},
+			Precommits: []Vote{{Hash: common.Hash{1}, Number: 3}},
+			AuthData:   []AuthData{{AuthorityID: [32]byte{2}}}},
+		&NeighbourPacketV1{Round: 1, SetID: 2, Number: 3},
+		&CatchUpRequest{Round: 1, SetID: 2},
+	}
+	for _, seed := range seeds {
+		cm, err := seed.ToConsensusMessage()
+		require.NoError(f, err)
+		f.Add(cm.Data)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		m, err := decodeMessage(&network.ConsensusMessage{Data: data})
+		if err != nil {
+			return
+		}
+		_, err = m.ToConsensusMessage()
+		require.NoError(t, err)
+	})
+}
@@ -9,10 +9,12 @@
 	"encoding/json"
 	"errors"
 	"fmt"
+	"runtime"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/ChainSafe/gossamer/dot/events"
 	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/dot/telemetry"
 	"github.com/ChainSafe/gossamer/dot/types"
@@ -21,6 +23,8 @@
 	"github.com/ChainSafe/gossamer/lib/blocktree"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/ChainSafe/gossamer/lib/equivocation"
+	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/pkg/scale"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/prometheus/client_golang/prometheus"
@@ -50,11 +54,12 @@ type Service struct {
 	cancel         context.CancelFunc
 	blockState     BlockState
 	grandpaState   GrandpaState
-	keypair        *ed25519.Keypair // TODO: change to grandpa keystore (#1870)
+	keypair        keystore.KeyPair
 	mapLock        sync.Mutex
 	chanLock       sync.Mutex
 	roundLock      sync.Mutex
 	authority      bool          // run the service as an authority (ie participate in voting)
+	observer       bool          // run the rounds without casting votes, if not an authority
 	paused         atomic.Value  // the service will be paused if it is waiting for catch up responses
 	resumed        chan struct{} // this channel will be closed when the service resumes
 	messageHandler *MessageHandler
@@ -70,6 +75,7 @@ type Service struct {
 	pvEquivocations map[ed25519.PublicKeyBytes][]*SignedVote // equivocatory votes for current pre-vote stage
 	pcEquivocations map[ed25519.PublicKeyBytes][]*SignedVote // equivocatory votes for current pre-commit stage
 	tracker         *tracker                                 // tracker of vote messages we may need in the future
+	futureVotes     *futureVotes                             // vote messages of the rounds ahead of the current round
 	head            *types.Header                            // most recently finalised block
 
 	// historical information
@@ -83,6 +89,38 @@ type Service struct {
 	telemetry Telemetry
 
 	neighborTracker *neighborTracker
+
+	// peers muted on the grandpa protocol after sending malformed messages
+	quarantine *peerQuarantine
+
+	// vote arrival latencies of the recent rounds
+	roundReporter *roundReporter
+	// subscribers to the incremental updates of the round state
+	roundStateNotifier *roundStateNotifier
+
+	// workers verifying the precommits of commit messages
+	commitVerifier *commitVerifier
+	// finalisationLock serialises finalising blocks, from rounds or commit messages
+	finalisationLock sync.Mutex
+
+	// retry policy for submitting equivocation reports to the runtime
+	equivocationReportRetry equivocation.RetryPolicy
+	// equivocationReports tracks the equivocation reports being submitted in the background
+	equivocationReports sync.WaitGroup
+
+	// journal of the last consensus messages received and sent, nil if disabled
+	journal *messageJournal
+
+	// threshold signers the votes are delegated to, nil if the votes are signed with the keypair
+	thresholdSigner      ThresholdSigner
+	thresholdSignTimeout time.Duration
+	thresholdVotes       *thresholdVotes
+
+	// guard checked before voting, nil if the votes are not guarded
+	voteGuard VoteGuard
+
+	// event bus the completed rounds are published to, nil if not published
+	events *events.Bus
 }
 
 // Config represents a GRANDPA service configuration
@@ -92,10 +130,29 @@ type Config struct {
 	GrandpaState GrandpaState
 	Network      Network
 	Voters       []Voter
-	Keypair      *ed25519.Keypair
-	Authority    bool
-	Interval     time.Duration
-	Telemetry    Telemetry
+	// Keypair is the ed25519 keypair the votes are signed with, which may be held
+	// by a key management service.
+	Keypair   keystore.KeyPair
+	Authority bool
+	// Observer runs the rounds of a node which is not an authority, importing the
+	// votes and commits and finalising blocks without ever casting votes.
+	// It is ignored if Authority is set.
+	Observer  bool
+	Interval  time.Duration
+	Telemetry Telemetry
+	// JournalSize is the number of consensus messages kept in the message
+	// journal, which is disabled if zero.
+	JournalSize uint32
+	// JournalDir is the directory of the message journal and its dumps.
+	JournalDir string
+	// ThresholdSigner is the experimental threshold signing committee the votes are
+	// delegated to, in which case the keypair is not used to sign votes.
+	ThresholdSigner ThresholdSigner
+	// ThresholdSignTimeout is the duration to wait for the partial signatures of a
+	// vote before not casting it, and defaults to 2 seconds if zero.
+	ThresholdSignTimeout time.Duration
+	// Events is the event bus the completed rounds are published to, if not nil.
+	Events *events.Bus
 }
 
 // NewService returns a new GRANDPA Service instance.
@@ -103,13 +160,16 @@ func NewService(cfg *Config) (*Service, error) {
 	logger.Patch(log.SetLevel(cfg.LogLvl))
 
 	var pub string
-	if cfg.Authority {
+	switch {
+	case cfg.Authority && cfg.ThresholdSigner != nil:
+		pub = cfg.ThresholdSigner.PublicKey().String()
+	case cfg.Authority:
 		pub = cfg.Keypair.Public().Hex()
 	}
 
 	logger.Debugf(
-		"creating service with authority=%t, pub=%s and voter set %s",
-		cfg.Authority, pub, Voters(cfg.Voters))
+		"creating service with authority=%t, observer=%t, pub=%s and voter set %s",
+		cfg.Authority, cfg.Observer && !cfg.Authority, pub, Voters(cfg.Voters))
 
 	// get latest finalised header
 	head, err := cfg.BlockState.GetFinalisedHeader(0, 0)
@@ -133,6 +193,20 @@ func NewService(cfg *Config) (*Service, error) {
 		cfg.Interval = defaultGrandpaInterval
 	}
 
+	if cfg.ThresholdSignTimeout == 0 {
+		cfg.ThresholdSignTimeout = defaultThresholdSignTimeout
+	}
+
+	journal, err := newMessageJournal(cfg.JournalDir, cfg.JournalSize)
+	if err != nil {
+		return nil, fmt.Errorf("creating message journal: %w", err)
+	}
+
+	serviceNetwork := cfg.Network
+	if journal != nil {
+		serviceNetwork = &journalingNetwork{Network: cfg.Network, journal: journal}
+	}
+
 	neighborMsgChan := make(chan neighborData)
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -144,6 +218,7 @@ func NewService(cfg *Config) (*Service, error) {
 		grandpaState:       cfg.GrandpaState,
 		keypair:            cfg.Keypair,
 		authority:          cfg.Authority,
+		observer:           cfg.Observer && !cfg.Authority,
 		prevotes:           new(sync.Map),
 		precommits:         new(sync.Map),
 		pvEquivocations:    make(map[ed25519.PublicKeyBytes][]*SignedVote),
@@ -152,11 +227,25 @@ func NewService(cfg *Config) (*Service, error) {
 		bestFinalCandidate: make(map[uint64]*Vote),
 		head:               head,
 		resumed:            make(chan struct{}),
-		network:            cfg.Network,
+		network:            serviceNetwork,
 		finalisedCh:        finalisedCh,
 		interval:           cfg.Interval,
 		telemetry:          cfg.Telemetry,
 		neighborMsgChan:    neighborMsgChan,
+		quarantine:         newPeerQuarantine(peerQuarantineDuration, maxQuarantinedPeers),
+		roundReporter:      newRoundReporter(),
+		roundStateNotifier: newRoundStateNotifier(),
+		commitVerifier:     newCommitVerifier(runtime.NumCPU()),
+		futureVotes:        newFutureVotes(futureVotesCapacity),
+		journal:            journal,
+
+		equivocationReportRetry: equivocation.DefaultRetryPolicy,
+
+		thresholdSigner:      cfg.ThresholdSigner,
+		thresholdSignTimeout: cfg.ThresholdSignTimeout,
+		thresholdVotes:       newThresholdVotes(),
+
+		events: cfg.Events,
 	}
 
 	s.neighborTracker = newNeighborTracker(s, neighborMsgChan)
@@ -175,15 +264,16 @@ func NewService(cfg *Config) (*Service, error) {
 func (s *Service) Start() error {
 	s.neighborTracker.Start()
 
-	// if we're not an authority, we don't need to worry about the voting process.
-	// the grandpa service is only used to verify incoming block justifications
-	if !s.authority {
+	// if we're neither an authority nor an observer, we don't need to worry about the
+	// voting process. the grandpa service is only used to verify incoming block justifications
+	if !s.playsRounds() {
 		return nil
 	}
 
 	s.tracker.start()
 
 	go func() {
+		defer s.journal.dumpOnPanic()
 		err := s.initiate()
 		if err != nil {
 			panic(fmt.Sprintf("running grandpa service: %s", err))
@@ -203,15 +293,38 @@ func (s *Service) Stop() error {
 
 	s.neighborTracker.Stop()
 	close(s.neighborTracker.neighborMsgChan)
+	s.commitVerifier.stop()
 
-	if !s.authority {
-		return nil
+	if s.playsRounds() {
+		s.tracker.stop()
 	}
+	s.equivocationReports.Wait()
 
-	s.tracker.stop()
+	err := s.journal.close()
+	if err != nil {
+		return fmt.Errorf("closing message journal: %w", err)
+	}
 	return nil
 }
 
+// playsRounds returns true if the service runs the grandpa rounds, either voting
+// in them as an authority or following them as an observer.
+func (s *Service) playsRounds() bool {
+	return s.authority || s.observer
+}
+
+// SetVoteGuard sets the guard checked before voting.
+// It must be called before the service is started.
+func (s *Service) SetVoteGuard(voteGuard VoteGuard) {
+	s.voteGuard = voteGuard
+}
+
+// DumpMessageJournal writes the consensus messages of the message journal to a
+// new file in the journal directory, and returns the path of the file.
+func (s *Service) DumpMessageJournal() (string, error) {
+	return s.journal.dump()
+}
+
 // authorities returns the current grandpa authorities
 func (s *Service) authorityKeySet() (authorityKeys map[string]struct{}) {
 	authorityKeys = make(map[string]struct{}, len(s.state.voters))
@@ -245,12 +358,14 @@ func (s *Service) updateAuthorities() error {
 		return fmt.Errorf("cannot get authorities for set id %d: %w", currSetID, err)
 	}
 
+	s.roundLock.Lock()
 	s.state.voters = nextAuthorities
 	s.state.setID = currSetID
 	// round resets to 1 after a set ID change,
 	// setting to 0 before incrementing indicates
 	// the setID has been increased
 	s.state.round = 0
+	s.roundLock.Unlock()
 	roundGauge.Set(float64(s.state.round))
 
 	s.sendTelemetryAuthoritySet()
@@ -259,11 +374,18 @@ func (s *Service) updateAuthorities() error {
 }
 
 func (s *Service) publicKeyBytes() ed25519.PublicKeyBytes {
+	if s.thresholdSigner != nil {
+		return s.thresholdSigner.PublicKey()
+	}
+	if s.keypair == nil {
+		// observers have no keys
+		return ed25519.PublicKeyBytes{}
+	}
 	return s.keypair.Public().(*ed25519.PublicKey).AsBytes()
 }
 
 func (s *Service) sendTelemetryAuthoritySet() {
-	authorityID := s.keypair.Public().Hex()
+	authorityID := s.publicKeyBytes().String()
 	authorities := make([]string, len(s.state.voters))
 	for i, voter := range s.state.voters {
 		authorities[i] = fmt.Sprint(voter.ID)
@@ -310,8 +432,10 @@ func (s *Service) initiateRound() error {
 
 	if setID > s.state.setID {
 		logger.Debugf("found block finalised in higher setID, updating our setID to be %d...", setID)
+		s.roundLock.Lock()
 		s.state.setID = setID
 		s.state.round = round
+		s.roundLock.Unlock()
 	}
 
 	s.head, err = s.blockState.GetFinalisedHeader(round, setID)
@@ -332,18 +456,44 @@ func (s *Service) initiateRound() error {
 
 	// make sure no votes can be validated while we are incrementing rounds
 	s.roundLock.Lock()
-	defer s.roundLock.Unlock()
-
 	s.state.round++
 	logger.Debugf("incrementing grandpa round, next round will be %d", s.state.round)
 	s.prevotes = new(sync.Map)
 	s.precommits = new(sync.Map)
 	s.pvEquivocations = make(map[ed25519.PublicKeyBytes][]*SignedVote)
 	s.pcEquivocations = make(map[ed25519.PublicKeyBytes][]*SignedVote)
+	s.roundReporter.startRound(s.state.round, s.state.setID, s.state.voters)
+	bufferedVotes := s.futureVotes.take(s.state.setID, s.state.round)
+	s.roundLock.Unlock()
+
+	// replay the votes received ahead of the round
+	for _, vote := range bufferedVotes {
+		_, err = s.validateVoteMessage(vote.from, vote.msg)
+		if err != nil {
+			logger.Debugf("failed to handle buffered vote message %v from peer id %s: %s", vote.msg, vote.from, err)
+		}
+	}
+	if len(bufferedVotes) > 0 {
+		logger.Debugf("replayed %d vote messages received ahead of round %d", len(bufferedVotes), s.state.round)
+	}
 
 	return nil
 }
 
+// publishRoundCompleted publishes the current round to the event bus, once
+// its voting round and finalisation engine completed.
+func (s *Service) publishRoundCompleted() {
+	if s.events == nil {
+		return
+	}
+
+	s.events.RoundCompleted.Publish(events.RoundCompleted{
+		Round:     s.state.round,
+		SetID:     s.state.setID,
+		Finalised: s.head.Hash(),
+	})
+}
+
 // initiate initates the grandpa service to begin voting in sequential rounds
 func (s *Service) initiate() error {
 	finalisationHandler := newFinalisationHandler(s)
@@ -368,7 +518,8 @@ func (s *Service) handleIsPrimary() (bool, error) {
 
 	// if primary, broadcast the best final candidate from the previous round
 	// otherwise, do nothing
-	if !bytes.Equal(primary.Key.Encode(), s.keypair.Public().Encode()) {
+	publicKey := s.publicKeyBytes()
+	if !bytes.Equal(primary.Key.Encode(), publicKey[:]) {
 		return false, nil
 	}
 
@@ -388,11 +539,15 @@ func (s *Service) handleIsPrimary() (bool, error) {
 
 	// send primary prevote message to network
 	spv, primProposal, err := s.createSignedVoteAndVoteMessage(pv, primaryProposal)
-	if err != nil {
+	if isVoteNotCast(err) {
+		logger.Warnf("not sending primary proposal of round %d: %s", s.state.round, err)
+		return false, nil
+	} else if err != nil {
 		return false, fmt.Errorf("failed to create primary proposal message: %w", err)
 	}
 
 	s.prevotes.Store(s.publicKeyBytes(), spv)
+	s.observeVote(s.publicKeyBytes(), primaryProposal, *pv)
 
 	msg, err := primProposal.ToConsensusMessage()
 	if err != nil {
@@ -461,6 +616,7 @@ func (s *Service) retrieveBestFinalCandidate() (bestFinalCandidate *types.Grandp
 	if err != nil {
 		return nil, 0, fmt.Errorf("getting best final candidate: %w", err)
 	}
+	s.roundStateNotifier.notifyEstimate(s.state.round, s.state.setID, *bestFinalCandidate)
 
 	if bestFinalCandidate.Number < uint32(s.head.Number) { //nolint:gosec
 		return nil, 0, fmt.Errorf("%w: candidate number %d, latest finalized block number %d",
@@ -659,11 +815,19 @@ func (s *Service) finalise() error {
 		return err
 	}
 
-	pcj, err := scale.Marshal(*newJustification(s.state.round, bfc.Hash, bfc.Number, pcs))
+	ancestries, err := voteAncestries(s.blockState, bfc.Hash, pcs)
+	if err != nil {
+		return fmt.Errorf("building precommits ancestry proof: %w", err)
+	}
+
+	pcj, err := scale.Marshal(*newJustification(s.state.round, bfc.Hash, bfc.Number, pcs, ancestries))
 	if err != nil {
 		return err
 	}
 
+	s.finalisationLock.Lock()
+	defer s.finalisationLock.Unlock()
+
 	if err = s.blockState.SetJustification(bfc.Hash, pcj); err != nil {
 		return err
 	}
@@ -685,6 +849,7 @@ func (s *Service) finalise() error {
 	if err = s.blockState.SetFinalisedHash(bfc.Hash, s.state.round, s.state.setID); err != nil {
 		return err
 	}
+	s.notifyFinalised(s.state.round, s.state.setID, *bfc)
 
 	return s.grandpaState.SetLatestRound(s.state.round)
 }
@@ -1190,7 +1355,7 @@ func (s *Service) handleCommitMessage(commitMessage *CommitMessage) error {
 
 	// check justification here
 	err = verifyCommitMessageJustification(*commitMessage, s.state.setID,
-		s.state.threshold(), s.authorityKeySet(), s.blockState)
+		s.state.threshold(), s.authorityKeySet(), s.blockState, s.commitVerifier)
 	if err != nil {
 		if errors.Is(err, blocktree.ErrStartNodeNotFound) {
 			// we haven't synced the committed block yet, add this to the tracker for later processing
@@ -1199,10 +1364,25 @@ func (s *Service) handleCommitMessage(commitMessage *CommitMessage) error {
 		return fmt.Errorf("verifying commit message justification: %w", err)
 	}
 
+	// only the apply step is done under the finalisation lock, the commit being
+	// checked again in case it was finalised while its precommits were verified.
+	s.finalisationLock.Lock()
+	defer s.finalisationLock.Unlock()
+
+	has, err = s.blockState.HasFinalisedBlock(commitMessage.Round, s.state.setID)
+	if err != nil {
+		return fmt.Errorf("checking for a finalized block in the block state: %w", err)
+	}
+
+	if has {
+		return nil
+	}
+
 	err = s.blockState.SetFinalisedHash(commitMessage.Vote.Hash, commitMessage.Round, s.state.setID)
 	if err != nil {
 		return fmt.Errorf("setting finalised hash: %w", err)
 	}
+	s.notifyFinalised(commitMessage.Round, s.state.setID, commitMessage.Vote)
 
 	preCommitSigned, err := compactToJustification(commitMessage.Precommits, commitMessage.AuthData)
 	if err != nil {
@@ -1219,7 +1399,7 @@ func (s *Service) handleCommitMessage(commitMessage *CommitMessage) error {
 }
 
 func verifyCommitMessageJustification(commitMessage CommitMessage, setID uint64, threshold uint64,
-	authorityKeySet map[string]struct{}, blockState BlockState) error {
+	authorityKeySet map[string]struct{}, blockState BlockState, verifier *commitVerifier) error {
 	if len(commitMessage.Precommits) != len(commitMessage.AuthData) {
 		return fmt.Errorf("%w: precommits len: %d, authorities len: %d",
 			ErrPrecommitSignatureMismatch, len(commitMessage.Precommits), len(commitMessage.AuthData))
@@ -1246,44 +1426,30 @@ func verifyCommitMessageJustification(commitMessage CommitMessage, setID uint64,
 	}
 
 	eqvVoters := getEquivocatoryVoters(commitMessage.AuthData)
-	var totalValidPrecommits int
-	for i, preCommit := range commitMessage.Precommits {
-		justification := &SignedVote{
-			Vote:        preCommit,
-			Signature:   commitMessage.AuthData[i].Signature,
-			AuthorityID: commitMessage.AuthData[i].AuthorityID,
-		}
-
-		err := verifyJustification(justification, commitMessage.Round, setID, precommit, authorityKeySet)
-		if err != nil {
-			logger.Errorf("verifying justification: %s", err)
-			continue
-		}
-
-		isDescendant, err := blockState.IsDescendantOf(commitMessage.Vote.Hash, justification.Vote.Hash)
-		if err != nil {
-			logger.Warnf("could not check for descendant: %s", err)
-			continue
-		}
-
-		precommitedHeader, err := blockState.GetHeader(preCommit.Hash)
-		if err != nil {
-			return fmt.Errorf("getting header: %w", err)
-		}
 
-		if precommitedHeader.Number != uint(preCommit.Number) {
-			const errFormat = "%w: pre commit corresponding header has block number %d " +
-				"and pre commit has block number %d"
+	// the precommits are verified concurrently, the result of each
+	// precommit being stored at its index.
+	type precommitResult struct {
+		valid bool
+		err   error
+	}
+	results := make([]precommitResult, len(commitMessage.Precommits))
+	verifier.run(len(commitMessage.Precommits), func(i int) {
+		valid, err := verifyCommitPrecommit(commitMessage, i, setID, authorityKeySet, blockState)
+		results[i] = precommitResult{valid: valid, err: err}
+	})
 
-			return fmt.Errorf(errFormat,
-				ErrBlockNumbersMismatch, precommitedHeader.Number, preCommit.Number)
+	var totalValidPrecommits int
+	for i, result := range results {
+		if result.err != nil {
+			return result.err
 		}
 
 		if _, ok := eqvVoters[commitMessage.AuthData[i].AuthorityID]; ok {
 			continue
 		}
 
-		if isDescendant {
+		if result.valid {
 			totalValidPrecommits++
 		}
 	}
@@ -1299,6 +1465,46 @@ func verifyCommitMessageJustification(commitMessage CommitMessage, setID uint64,
 	return nil
 }
 
+// verifyCommitPrecommit verifies the signature and ancestry of the precommit at the given index
+// of the commit message. It returns false if the precommit is invalid, and an error if the
+// precommit block number does not match its block.
+func verifyCommitPrecommit(commitMessage CommitMessage, i int, setID uint64,
+	authorityKeySet map[string]struct{}, blockState BlockState) (valid bool, err error) {
+	preCommit := commitMessage.Precommits[i]
+	justification := &SignedVote{
+		Vote:        preCommit,
+		Signature:   commitMessage.AuthData[i].Signature,
+		AuthorityID: commitMessage.AuthData[i].AuthorityID,
+	}
+
+	err = verifyJustification(justification, commitMessage.Round, setID, precommit, authorityKeySet)
+	if err != nil {
+		logger.Errorf("verifying justification: %s", err)
+		return false, nil
+	}
+
+	isDescendant, err := blockState.IsDescendantOf(commitMessage.Vote.Hash, justification.Vote.Hash)
+	if err != nil {
+		logger.Warnf("could not check for descendant: %s", err)
+		return false, nil
+	}
+
+	precommitedHeader, err := blockState.GetHeader(preCommit.Hash)
+	if err != nil {
+		return false, fmt.Errorf("getting header: %w", err)
+	}
+
+	if precommitedHeader.Number != uint(preCommit.Number) {
+		const errFormat = "%w: pre commit corresponding header has block number %d " +
+			"and pre commit has block number %d"
+
+		return false, fmt.Errorf(errFormat,
+			ErrBlockNumbersMismatch, precommitedHeader.Number, preCommit.Number)
+	}
+
+	return isDescendant, nil
+}
+
 func verifyJustification(justification *SignedVote, round, setID uint64,
 	stage Subround, authorityKeys map[string]struct{}) error {
 	fullVote := FullVote{
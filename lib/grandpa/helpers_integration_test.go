@@ -10,6 +10,7 @@
 	"time"
 
 	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/dot/peerset"
 	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/internal/database"
@@ -75,6 +76,8 @@ func (n *testNetwork) SendMessage(_ peer.ID, _ NotificationsMessage) error {
 	return nil
 }
 
+func (*testNetwork) ReportPeer(_ peerset.ReputationChange, _ peer.ID) {}
+
 func (n *testNetwork) SendJustificationRequest(to peer.ID, num uint32) {
 	n.justificationRequest = &testJustificationRequest{
 		to:  to,
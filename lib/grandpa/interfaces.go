@@ -9,3 +9,9 @@
 type Telemetry interface {
 	SendMessage(msg json.Marshaler)
 }
+
+// VoteGuard guards voting, for example to only vote on the active
+// node of nodes running with the same authority keys.
+type VoteGuard interface {
+	GuardVote(round, setID uint64) (allowed bool, err error)
+}
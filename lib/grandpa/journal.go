@@ -0,0 +1,273 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// journalFileName is the name of the journal file in the journal directory.
+	journalFileName = "grandpa_journal"
+	// journalSlotSize is the size of a journal slot. Messages larger than a slot
+	// are truncated, which only happens for commit messages and catch up
+	// responses of very large voter sets.
+	journalSlotSize = 128 * 1024
+	// journalHeaderSize is the size of the journal header, made of the magic
+	// bytes, the number of slots, the slot size and the number of records written.
+	journalHeaderSize = 8 + 4 + 4 + 8
+	// journalRecordHeaderSize is the size of a record header, made of the
+	// message length, the time, the direction and the peer id length.
+	journalRecordHeaderSize = 4 + 8 + 1 + 1
+)
+
+var journalMagic = [8]byte{'g', 'p', 'j', 'r', 'n', 'l', '0', '1'}
+
+var errJournalDisabled = errors.New("consensus message journal is disabled")
+
+// journalDirection is the direction of a journaled message.
+type journalDirection byte
+
+const (
+	journalReceived journalDirection = iota
+	journalSent
+)
+
+func (d journalDirection) String() string {
+	if d == journalSent {
+		return "sent"
+	}
+	return "received"
+}
+
+// JournalRecord is a journaled consensus message, as written to journal dumps.
+// The message is the data of the network consensus message, which is the SCALE
+// encoded GRANDPA message. The peer is empty for gossiped messages.
+type JournalRecord struct {
+	Time      time.Time `json:"time"`
+	Direction string    `json:"direction"`
+	Peer      string    `json:"peer,omitempty"`
+	Truncated bool      `json:"truncated,omitempty"`
+	Message   string    `json:"message"`
+}
+
+// messageJournal is a ring buffer of the last consensus messages received and
+// sent. It is backed by a memory mapped file where possible, so the messages of
+// a node killed without flushing the journal can still be dumped on restart.
+// A nil journal is disabled and ignores all calls.
+type messageJournal struct {
+	mtx    sync.Mutex
+	dir    string
+	buffer journalBuffer
+	slots  uint32
+	now    func() time.Time
+}
+
+// journalBuffer is the memory of the journal.
+type journalBuffer interface {
+	Bytes() []byte
+	Close() error
+}
+
+// newMessageJournal opens the journal with the given number of slots in the
+// directory, keeping the messages journaled by a previous run with the same
+// number of slots.
+func newMessageJournal(dir string, slots uint32) (*messageJournal, error) {
+	if slots == 0 {
+		return nil, nil //nolint:nilnil
+	}
+
+	size := journalHeaderSize + int(slots)*journalSlotSize
+	buffer, err := openJournalBuffer(filepath.Join(dir, journalFileName), size)
+	if err != nil {
+		return nil, fmt.Errorf("opening journal buffer: %w", err)
+	}
+
+	j := &messageJournal{
+		dir:    dir,
+		buffer: buffer,
+		slots:  slots,
+		now:    time.Now,
+	}
+
+	header := buffer.Bytes()[:journalHeaderSize]
+	if !bytes.Equal(header[:8], journalMagic[:]) ||
+		binary.LittleEndian.Uint32(header[8:]) != slots ||
+		binary.LittleEndian.Uint32(header[12:]) != journalSlotSize {
+		copy(header, journalMagic[:])
+		binary.LittleEndian.PutUint32(header[8:], slots)
+		binary.LittleEndian.PutUint32(header[12:], journalSlotSize)
+		binary.LittleEndian.PutUint64(header[16:], 0)
+	}
+
+	return j, nil
+}
+
+func (j *messageJournal) written() uint64 {
+	return binary.LittleEndian.Uint64(j.buffer.Bytes()[16:])
+}
+
+// record journals the data of a consensus message.
+func (j *messageJournal) record(direction journalDirection, from peer.ID, data []byte) {
+	if j == nil {
+		return
+	}
+
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+
+	written := j.written()
+	offset := journalHeaderSize + int(written%uint64(j.slots))*journalSlotSize
+	slot := j.buffer.Bytes()[offset : offset+journalSlotSize]
+
+	peerID := []byte(from)
+	if len(peerID) > 255 {
+		peerID = peerID[:255]
+	}
+
+	binary.LittleEndian.PutUint32(slot, uint32(len(data))) //nolint:gosec
+	binary.LittleEndian.PutUint64(slot[4:], uint64(j.now().UnixNano()))
+	slot[12] = byte(direction)
+	slot[13] = byte(len(peerID))
+	copy(slot[journalRecordHeaderSize:], peerID)
+	copy(slot[journalRecordHeaderSize+len(peerID):], data)
+
+	binary.LittleEndian.PutUint64(j.buffer.Bytes()[16:], written+1)
+}
+
+// records returns the journaled messages, from the oldest to the newest.
+func (j *messageJournal) records() []JournalRecord {
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+
+	written := j.written()
+	first := uint64(0)
+	if written > uint64(j.slots) {
+		first = written - uint64(j.slots)
+	}
+
+	records := make([]JournalRecord, 0, written-first)
+	for i := first; i < written; i++ {
+		offset := journalHeaderSize + int(i%uint64(j.slots))*journalSlotSize
+		slot := j.buffer.Bytes()[offset : offset+journalSlotSize]
+
+		length := int(binary.LittleEndian.Uint32(slot))
+		peerLength := int(slot[13])
+		start := journalRecordHeaderSize + peerLength
+		stored := min(length, journalSlotSize-start)
+
+		var peerID string
+		if peerLength > 0 {
+			peerID = peer.ID(slot[journalRecordHeaderSize:start]).String()
+		}
+
+		records = append(records, JournalRecord{
+			Time:      time.Unix(0, int64(binary.LittleEndian.Uint64(slot[4:]))).UTC(), //nolint:gosec
+			Direction: journalDirection(slot[12]).String(),
+			Peer:      peerID,
+			Truncated: stored < length,
+			Message:   common.BytesToHex(slot[start : start+stored]),
+		})
+	}
+	return records
+}
+
+// dump writes the journaled messages to a new file in the journal directory,
+// one JSON record per line from the oldest to the newest, and returns its path.
+func (j *messageJournal) dump() (path string, err error) {
+	if j == nil {
+		return "", errJournalDisabled
+	}
+
+	path = filepath.Join(j.dir, fmt.Sprintf("%s_%d.jsonl", journalFileName, j.now().UnixNano()))
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return "", fmt.Errorf("creating journal dump: %w", err)
+	}
+	defer func() {
+		closeErr := file.Close()
+		if err == nil && closeErr != nil {
+			err = fmt.Errorf("closing journal dump: %w", closeErr)
+		}
+	}()
+
+	writer := bufio.NewWriter(file)
+	encoder := json.NewEncoder(writer)
+	for _, record := range j.records() {
+		err = encoder.Encode(record)
+		if err != nil {
+			return "", fmt.Errorf("encoding journal record: %w", err)
+		}
+	}
+
+	err = writer.Flush()
+	if err != nil {
+		return "", fmt.Errorf("writing journal dump: %w", err)
+	}
+	return path, nil
+}
+
+// dumpOnPanic dumps the journal if the calling goroutine panics, and panics
+// again. It must be deferred.
+func (j *messageJournal) dumpOnPanic() {
+	if j == nil {
+		return
+	}
+
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	path, err := j.dump()
+	if err != nil {
+		logger.Errorf("dumping consensus message journal on panic: %s", err)
+	} else {
+		logger.Criticalf("dumped consensus message journal to %s on panic", path)
+	}
+	panic(r)
+}
+
+func (j *messageJournal) close() error {
+	if j == nil {
+		return nil
+	}
+
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+	return j.buffer.Close()
+}
+
+// journalingNetwork journals the consensus messages sent through the network.
+type journalingNetwork struct {
+	Network
+	journal *messageJournal
+}
+
+func (n *journalingNetwork) GossipMessage(msg network.NotificationsMessage) {
+	if cm, ok := msg.(*network.ConsensusMessage); ok {
+		n.journal.record(journalSent, "", cm.Data)
+	}
+	n.Network.GossipMessage(msg)
+}
+
+func (n *journalingNetwork) SendMessage(to peer.ID, msg NotificationsMessage) error {
+	if cm, ok := msg.(*network.ConsensusMessage); ok {
+		n.journal.record(journalSent, to, cm.Data)
+	}
+	return n.Network.SendMessage(to, msg)
+}
@@ -0,0 +1,20 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+//go:build !unix
+
+package grandpa
+
+// memoryBuffer is a journal buffer held in memory, used where memory mapped
+// files are not supported. Its contents are lost if the process is killed.
+type memoryBuffer struct {
+	data []byte
+}
+
+func openJournalBuffer(_ string, size int) (journalBuffer, error) {
+	return &memoryBuffer{data: make([]byte, size)}, nil
+}
+
+func (b *memoryBuffer) Bytes() []byte { return b.data }
+
+func (b *memoryBuffer) Close() error { return nil }
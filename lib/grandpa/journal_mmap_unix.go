@@ -0,0 +1,44 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+//go:build unix
+
+package grandpa
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// mmapBuffer is a journal buffer mapped to a file, so its contents survive
+// the process being killed.
+type mmapBuffer struct {
+	data []byte
+}
+
+func openJournalBuffer(path string, size int) (journalBuffer, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("opening journal file: %w", err)
+	}
+	defer file.Close()
+
+	err = file.Truncate(int64(size))
+	if err != nil {
+		return nil, fmt.Errorf("resizing journal file: %w", err)
+	}
+
+	data, err := syscall.Mmap(int(file.Fd()), 0, size, syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, fmt.Errorf("mapping journal file: %w", err)
+	}
+
+	return &mmapBuffer{data: data}, nil
+}
+
+func (b *mmapBuffer) Bytes() []byte { return b.data }
+
+func (b *mmapBuffer) Close() error {
+	return syscall.Munmap(b.data)
+}
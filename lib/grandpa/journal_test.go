@@ -0,0 +1,94 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readJournalDump(t *testing.T, path string) (records []JournalRecord) {
+	t.Helper()
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 4*journalSlotSize)
+	for scanner.Scan() {
+		var record JournalRecord
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		records = append(records, record)
+	}
+	require.NoError(t, scanner.Err())
+	return records
+}
+
+func Test_messageJournal(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	journal, err := newMessageJournal(dir, 2)
+	require.NoError(t, err)
+	now := time.Unix(1, 0).UTC()
+	journal.now = func() time.Time { return now }
+
+	from := peer.ID("peer")
+	journal.record(journalReceived, from, []byte{1})
+	journal.record(journalSent, "", []byte{2})
+	journal.record(journalSent, from, make([]byte, journalSlotSize))
+
+	path, err := journal.dump()
+	require.NoError(t, err)
+	expected := []JournalRecord{{
+		Time:      now,
+		Direction: "sent",
+		Message:   "0x02",
+	}, {
+		Time:      now,
+		Direction: "sent",
+		Peer:      from.String(),
+		Truncated: true,
+	}}
+	records := readJournalDump(t, path)
+	require.Len(t, records, 2)
+	assert.Len(t, records[1].Message, 2+2*(journalSlotSize-journalRecordHeaderSize-len(from)))
+	records[1].Message = ""
+	assert.Equal(t, expected, records)
+
+	require.NoError(t, journal.close())
+
+	// the journaled messages are kept when the journal is reopened
+	journal, err = newMessageJournal(dir, 2)
+	require.NoError(t, err)
+	assert.Len(t, journal.records(), 2)
+	require.NoError(t, journal.close())
+
+	// and discarded if its size changes
+	journal, err = newMessageJournal(dir, 3)
+	require.NoError(t, err)
+	assert.Empty(t, journal.records())
+	require.NoError(t, journal.close())
+}
+
+func Test_messageJournal_disabled(t *testing.T) {
+	t.Parallel()
+
+	journal, err := newMessageJournal(t.TempDir(), 0)
+	require.NoError(t, err)
+	assert.Nil(t, journal)
+
+	journal.record(journalReceived, "", []byte{1})
+	_, err = journal.dump()
+	assert.ErrorIs(t, err, errJournalDisabled)
+	assert.NoError(t, journal.close())
+}
@@ -0,0 +1,49 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+)
+
+// ImportJustification imports the justification of an already imported block from an
+// external source, such as a bridge relayer or a backup node, which is useful when the
+// node is poorly connected to the voters. The justification is verified against the
+// authority set of the block, then the block is finalised and the justification stored.
+// It returns the round and set id of the justification.
+func (s *Service) ImportJustification(hash common.Hash, justification []byte) (round, setID uint64, err error) {
+	header, err := s.blockState.GetHeader(hash)
+	if err != nil {
+		return 0, 0, fmt.Errorf("getting header of block %s: %w", hash, err)
+	}
+
+	highestFinalised, err := s.blockState.GetHighestFinalisedHeader()
+	if err != nil {
+		return 0, 0, fmt.Errorf("getting highest finalised header: %w", err)
+	}
+	if header.Number <= highestFinalised.Number {
+		return 0, 0, fmt.Errorf("%w: block #%d is not after the highest finalised block #%d",
+			ErrBlockAlreadyFinalised, header.Number, highestFinalised.Number)
+	}
+
+	round, setID, err = s.VerifyBlockJustification(hash, header.Number, justification)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	err = s.blockState.SetFinalisedHash(hash, round, setID)
+	if err != nil {
+		return 0, 0, fmt.Errorf("setting finalised hash: %w", err)
+	}
+	err = s.blockState.SetJustification(hash, justification)
+	if err != nil {
+		return 0, 0, fmt.Errorf("setting justification of block #%d: %w", header.Number, err)
+	}
+
+	logger.Infof("finalised block #%d (%s) with imported justification of round %d and set id %d",
+		header.Number, hash, round, setID)
+	return round, setID, nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestService_ImportJustification(t *testing.T) {
+	t.Parallel()
+
+	justification := common.MustHexToBytes(westendBlock512Justification)
+	header := &types.Header{Number: 512}
+	errTest := errors.New("test error")
+
+	testCases := map[string]struct {
+		newService func(ctrl *gomock.Controller) *Service
+		hash       common.Hash
+		round      uint64
+		errWrapped error
+		errMessage string
+	}{
+		"block_not_imported": {
+			newService: func(ctrl *gomock.Controller) *Service {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetHeader(westendBlock512Hash).Return(nil, errTest)
+				return &Service{blockState: blockState}
+			},
+			hash:       westendBlock512Hash,
+			errWrapped: errTest,
+			errMessage: "getting header of block " +
+				"0x5895897f12e1a670609929433ac7a69dcae90e0cc2d9c32c0dce0e2a5e5e614e: test error",
+		},
+		"block_already_finalised": {
+			newService: func(ctrl *gomock.Controller) *Service {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetHeader(westendBlock512Hash).Return(header, nil)
+				blockState.EXPECT().GetHighestFinalisedHeader().Return(&types.Header{Number: 512}, nil)
+				return &Service{blockState: blockState}
+			},
+			hash:       westendBlock512Hash,
+			errWrapped: ErrBlockAlreadyFinalised,
+			errMessage: "block is already finalised: block #512 is not after the highest finalised block #512",
+		},
+		"justification_of_other_block": {
+			newService: func(ctrl *gomock.Controller) *Service {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetHeader(common.Hash{1}).Return(header, nil)
+				blockState.EXPECT().GetHighestFinalisedHeader().Return(&types.Header{Number: 500}, nil)
+				grandpaState := NewMockGrandpaState(ctrl)
+				grandpaState.EXPECT().GetSetIDByBlockNumber(uint(512)).Return(uint64(0), nil)
+				grandpaState.EXPECT().GetAuthorities(uint64(0)).Return(westendSetID0Voters(t), nil)
+				return &Service{blockState: blockState, grandpaState: grandpaState}
+			},
+			hash:       common.Hash{1},
+			errMessage: "decoding and verifying justification: invalid commit target in grandpa justification",
+		},
+		"finalised": {
+			newService: func(ctrl *gomock.Controller) *Service {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetHeader(westendBlock512Hash).Return(header, nil)
+				blockState.EXPECT().GetHighestFinalisedHeader().Return(&types.Header{Number: 500}, nil)
+				blockState.EXPECT().SetFinalisedHash(westendBlock512Hash, uint64(713), uint64(0)).Return(nil)
+				blockState.EXPECT().SetJustification(westendBlock512Hash, justification).Return(nil)
+				grandpaState := NewMockGrandpaState(ctrl)
+				grandpaState.EXPECT().GetSetIDByBlockNumber(uint(512)).Return(uint64(0), nil)
+				grandpaState.EXPECT().GetAuthorities(uint64(0)).Return(westendSetID0Voters(t), nil)
+				return &Service{blockState: blockState, grandpaState: grandpaState}
+			},
+			hash:  westendBlock512Hash,
+			round: 713,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			service := testCase.newService(ctrl)
+			round, setID, err := service.ImportJustification(testCase.hash, justification)
+
+			if testCase.errWrapped != nil {
+				assert.ErrorIs(t, err, testCase.errWrapped)
+			}
+			if testCase.errMessage != "" {
+				assert.EqualError(t, err, testCase.errMessage)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, testCase.round, round)
+			assert.Zero(t, setID)
+		})
+	}
+}
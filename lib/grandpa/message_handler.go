@@ -115,7 +115,7 @@ func (h *MessageHandler) handleCatchUpRequest(msg *CatchUpRequest) (*ConsensusMe
 }
 
 func (h *MessageHandler) handleCatchUpResponse(msg *CatchUpResponse) error {
-	if !h.grandpa.authority {
+	if !h.grandpa.playsRounds() {
 		return nil
 	}
 
@@ -366,12 +366,20 @@ func (h *MessageHandler) verifyPreCommitJustification(msg *CatchUpResponse) erro
 func (s *Service) VerifyBlockJustification(finalizedHash common.Hash, finalizedNumber uint, encoded []byte) (
 	round uint64, setID uint64, err error,
 ) {
-	setID, err = s.grandpaState.GetSetIDByBlockNumber(finalizedNumber)
+	return VerifyJustification(s.grandpaState, finalizedHash, finalizedNumber, encoded)
+}
+
+// VerifyJustification verifies the finality justification for a block against the authorities
+// of the set the block belongs to, as stored in the given grandpa state, and returns the round
+// and set id of the justification.
+func VerifyJustification(grandpaState GrandpaState, finalizedHash common.Hash, finalizedNumber uint,
+	encoded []byte) (round uint64, setID uint64, err error) {
+	setID, err = grandpaState.GetSetIDByBlockNumber(finalizedNumber)
 	if err != nil {
 		return 0, 0, fmt.Errorf("cannot get set ID from block number: %w", err)
 	}
 
-	auths, err := s.grandpaState.GetAuthorities(setID)
+	auths, err := grandpaState.GetAuthorities(setID)
 	if err != nil {
 		return 0, 0, fmt.Errorf("cannot get authorities for set ID: %w", err)
 	}
@@ -997,7 +997,7 @@ func Test_VerifyCommitMessageJustification_ShouldRemoveEquivocatoryVotes(t *test
 	}
 
 	err = verifyCommitMessageJustification(*testCommitData, h.grandpa.state.setID,
-		h.grandpa.state.threshold(), h.grandpa.authorityKeySet(), h.blockState)
+		h.grandpa.state.threshold(), h.grandpa.authorityKeySet(), h.blockState, h.grandpa.commitVerifier)
 
 	require.NoError(t, err)
 }
@@ -17,6 +17,9 @@
 
 const grandpaID1 = "grandpa/1"
 
+// maxGrandpaMessageSize is the maximum size of the data of a grandpa consensus message.
+const maxGrandpaMessageSize = network.MaxGrandpaNotificationSize
+
 // NotificationsMessage is an alias for network.NotificationsMessage
 type NotificationsMessage = network.NotificationsMessage
 
@@ -106,20 +109,45 @@ func (s *Service) handleNetworkMessage(from peer.ID, msg NotificationsMessage) (
 		return false, nil
 	}
 
+	if s.quarantine.contains(from) {
+		quarantinedMessagesTotal.Inc()
+		return false, nil
+	}
+
 	cm, ok := msg.(*network.ConsensusMessage)
 	if !ok {
 		return false, ErrInvalidMessageType
 	}
 
+	s.journal.record(journalReceived, from, cm.Data)
+	defer s.journal.dumpOnPanic()
+
 	if len(cm.Data) < 2 {
 		return false, nil
 	}
 
+	if uint64(len(cm.Data)) > maxGrandpaMessageSize {
+		s.quarantinePeer(from, malformedReasonTooLarge)
+		return false, fmt.Errorf("%w: %d bytes exceeds %d bytes",
+			ErrMessageTooLarge, len(cm.Data), maxGrandpaMessageSize)
+	}
+
 	m, err := decodeMessage(cm)
 	if err != nil {
+		s.quarantinePeer(from, malformedReasonDecode)
 		return false, err
 	}
 
+	err = s.checkMessageBounds(m)
+	if err != nil {
+		s.quarantinePeer(from, malformedReasonTooManyVotes)
+		return false, err
+	}
+
+	if vote, ok := m.(*VoteMessage); ok && !s.filterVoteSetID(from, vote) {
+		return false, nil
+	}
+
 	resp, err := s.messageHandler.handleMessage(from, m)
 	if err != nil {
 		return false, err
@@ -145,6 +173,39 @@ func (s *Service) handleNetworkMessage(from peer.ID, msg NotificationsMessage) (
 	return true, nil
 }
 
+// checkMessageBounds checks the number of votes and signatures of commit messages
+// and catch up responses for the current voter set, where each voter produces at
+// most one vote, or two if it equivocates. Messages for other sets are rejected
+// by the message handler and are only bounded by the maximum message size.
+func (s *Service) checkMessageBounds(m GrandpaMessage) error {
+	var setID uint64
+	var counts []int
+	switch msg := m.(type) {
+	case *CommitMessage:
+		setID, counts = msg.SetID, []int{len(msg.Precommits), len(msg.AuthData)}
+	case *CatchUpResponse:
+		setID, counts = msg.SetID, []int{len(msg.PreVoteJustification), len(msg.PreCommitJustification)}
+	default:
+		return nil
+	}
+
+	s.roundLock.Lock()
+	currentSetID, voters := s.state.setID, len(s.state.voters)
+	s.roundLock.Unlock()
+
+	if setID != currentSetID {
+		return nil
+	}
+
+	maxVotes := 2 * voters
+	for _, count := range counts {
+		if count > maxVotes {
+			return fmt.Errorf("%w: %d votes for %d voters", ErrTooManyVotes, count, voters)
+		}
+	}
+	return nil
+}
+
 // decodeMessage decodes a network-level consensus message into a GRANDPA VoteMessage or CommitMessage
 func decodeMessage(cm *network.ConsensusMessage) (m GrandpaMessage, err error) {
 	msg := newGrandpaMessage()
@@ -0,0 +1,125 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/peerset"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// peerQuarantineDuration is the duration during which the grandpa messages
+// of a peer which sent a malformed message are dropped.
+const peerQuarantineDuration = 5 * time.Minute
+
+// maxQuarantinedPeers is the maximum number of peers quarantined at once, the
+// peer whose quarantine ends first being released to make room for a new one.
+const maxQuarantinedPeers = 1024
+
+const (
+	malformedReasonTooLarge     = "too_large"
+	malformedReasonDecode       = "decode"
+	malformedReasonTooManyVotes = "too_many_votes"
+)
+
+var (
+	malformedMessagesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "gossamer_grandpa",
+		Name:      "malformed_messages_total",
+		Help:      "total number of malformed grandpa messages received, by reason",
+	}, []string{"reason"})
+	quarantinedMessagesTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "gossamer_grandpa",
+		Name:      "quarantined_messages_total",
+		Help:      "total number of grandpa messages dropped because their peer is quarantined",
+	})
+	quarantinedPeersGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "gossamer_grandpa",
+		Name:      "quarantined_peers",
+		Help:      "number of peers currently quarantined on the grandpa protocol",
+	})
+)
+
+// peerQuarantine tracks the peers muted on the grandpa protocol.
+type peerQuarantine struct {
+	mutex    sync.Mutex
+	until    map[peer.ID]time.Time
+	duration time.Duration
+	maxPeers int
+	now      func() time.Time
+}
+
+func newPeerQuarantine(duration time.Duration, maxPeers int) *peerQuarantine {
+	return &peerQuarantine{
+		until:    make(map[peer.ID]time.Time),
+		duration: duration,
+		maxPeers: maxPeers,
+		now:      time.Now,
+	}
+}
+
+// add quarantines the peer, extending its quarantine if it is already quarantined.
+// Expired quarantines are removed, and if the quarantine is still full the peer
+// whose quarantine ends first is released.
+func (q *peerQuarantine) add(p peer.ID) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	now := q.now()
+	_, quarantined := q.until[p]
+	if !quarantined && len(q.until) >= q.maxPeers {
+		var first peer.ID
+		var firstUntil time.Time
+		for quarantinedPeer, until := range q.until {
+			if !now.Before(until) {
+				delete(q.until, quarantinedPeer)
+				continue
+			}
+			if firstUntil.IsZero() || until.Before(firstUntil) {
+				first, firstUntil = quarantinedPeer, until
+			}
+		}
+		if len(q.until) >= q.maxPeers {
+			delete(q.until, first)
+		}
+	}
+
+	q.until[p] = now.Add(q.duration)
+	quarantinedPeersGauge.Set(float64(len(q.until)))
+}
+
+// contains returns true if the peer is quarantined, removing expired quarantines.
+func (q *peerQuarantine) contains(p peer.ID) bool {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	until, ok := q.until[p]
+	if !ok {
+		return false
+	}
+
+	if q.now().Before(until) {
+		return true
+	}
+
+	delete(q.until, p)
+	quarantinedPeersGauge.Set(float64(len(q.until)))
+	return false
+}
+
+// quarantinePeer lowers the reputation of a peer which sent a malformed
+// message and mutes it on the grandpa protocol.
+func (s *Service) quarantinePeer(p peer.ID, reason string) {
+	malformedMessagesTotal.WithLabelValues(reason).Inc()
+	s.quarantine.add(p)
+	s.network.ReportPeer(peerset.ReputationChange{
+		Value:  peerset.BadMessageValue,
+		Reason: peerset.BadMessageReason,
+	}, p)
+	logger.Debugf("quarantined peer %s for %s: %s", p, peerQuarantineDuration, reason)
+}
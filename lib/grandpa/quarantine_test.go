@@ -0,0 +1,127 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/peerset"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_peerQuarantine(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(0, 0)
+	quarantine := newPeerQuarantine(time.Minute, maxQuarantinedPeers)
+	quarantine.now = func() time.Time { return now }
+
+	assert.False(t, quarantine.contains("a"))
+
+	quarantine.add("a")
+	assert.True(t, quarantine.contains("a"))
+	assert.False(t, quarantine.contains("b"))
+
+	now = now.Add(time.Minute)
+	assert.False(t, quarantine.contains("a"))
+	assert.Empty(t, quarantine.until)
+}
+
+func Test_peerQuarantine_maxPeers(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(0, 0)
+	quarantine := newPeerQuarantine(time.Minute, 2)
+	quarantine.now = func() time.Time { return now }
+
+	quarantine.add("a")
+	now = now.Add(time.Second)
+	quarantine.add("b")
+	now = now.Add(time.Second)
+
+	// extending a quarantine does not release any peer
+	quarantine.add("b")
+	assert.Len(t, quarantine.until, 2)
+
+	// the peer whose quarantine ends first is released
+	quarantine.add("c")
+	assert.False(t, quarantine.contains("a"))
+	assert.True(t, quarantine.contains("b"))
+	assert.True(t, quarantine.contains("c"))
+
+	// expired quarantines are released first
+	now = now.Add(time.Minute)
+	quarantine.add("d")
+	quarantine.add("e")
+	assert.Len(t, quarantine.until, 2)
+	assert.True(t, quarantine.contains("d"))
+	assert.True(t, quarantine.contains("e"))
+}
+
+func Test_Service_handleNetworkMessage_quarantine(t *testing.T) {
+	t.Parallel()
+
+	const setID = 1
+	commit := &CommitMessage{
+		SetID:      setID,
+		Precommits: make([]Vote, 3),
+		AuthData:   make([]AuthData, 3),
+	}
+	tooManyVotes, err := commit.ToConsensusMessage()
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		message    *ConsensusMessage
+		errWrapped error
+	}{
+		"too_large": {
+			message:    &ConsensusMessage{Data: make([]byte, maxGrandpaMessageSize+1)},
+			errWrapped: ErrMessageTooLarge,
+		},
+		"decode_error": {
+			message: &ConsensusMessage{Data: []byte{9, 0}},
+		},
+		"too_many_votes": {
+			message:    tooManyVotes,
+			errWrapped: ErrTooManyVotes,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			const from = peer.ID("peer")
+			network := NewMockNetwork(ctrl)
+			network.EXPECT().ReportPeer(peerset.ReputationChange{
+				Value:  peerset.BadMessageValue,
+				Reason: peerset.BadMessageReason,
+			}, from)
+
+			s := &Service{
+				state:      NewState([]Voter{{ID: 1}}, setID, 1),
+				network:    network,
+				quarantine: newPeerQuarantine(time.Minute, maxQuarantinedPeers),
+			}
+
+			propagate, err := s.handleNetworkMessage(from, testCase.message)
+			assert.False(t, propagate)
+			require.Error(t, err)
+			if testCase.errWrapped != nil {
+				assert.ErrorIs(t, err, testCase.errWrapped)
+			}
+
+			// further messages of the peer are dropped without being decoded
+			propagate, err = s.handleNetworkMessage(from, testCase.message)
+			assert.False(t, propagate)
+			assert.NoError(t, err)
+		})
+	}
+}
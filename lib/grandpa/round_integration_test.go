@@ -470,6 +470,117 @@ func TestPlayGrandpaRoundMultipleRounds(t *testing.T) {
 	}
 }
 
+func TestPlayGrandpaRoundObserver(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+
+	ed25519Keyring, err := keystore.NewEd25519Keyring()
+	require.NoError(t, err)
+
+	voters := []*ed25519.Keypair{
+		ed25519Keyring.Alice().(*ed25519.Keypair),
+		ed25519Keyring.Bob().(*ed25519.Keypair),
+		ed25519Keyring.Charlie().(*ed25519.Keypair),
+		ed25519Keyring.Dave().(*ed25519.Keypair),
+	}
+
+	grandpaVoters := make([]types.GrandpaVoter, 0, len(voters))
+	for _, kp := range voters {
+		grandpaVoters = append(grandpaVoters, types.GrandpaVoter{
+			Key: *kp.Public().(*ed25519.PublicKey),
+		})
+	}
+
+	// the last service is an observer without keys
+	grandpaServices := make([]*Service, len(voters)+1)
+	for idx := range grandpaServices {
+		const subroundInterval = 100 * time.Millisecond
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		st := newTestState(t)
+		grandpaServices[idx] = &Service{
+			ctx:          ctx,
+			cancel:       cancel,
+			paused:       atomic.Value{},
+			blockState:   st.Block,
+			grandpaState: st.Grandpa,
+			interval:     subroundInterval,
+			state: &State{
+				round:  1,
+				setID:  0,
+				voters: grandpaVoters,
+			},
+			head:               testGenesisHeader,
+			prevotes:           new(sync.Map),
+			precommits:         new(sync.Map),
+			pvEquivocations:    make(map[ed25519.PublicKeyBytes][]*SignedVote),
+			pcEquivocations:    make(map[ed25519.PublicKeyBytes][]*SignedVote),
+			preVotedBlock:      make(map[uint64]*Vote),
+			bestFinalCandidate: make(map[uint64]*Vote),
+		}
+		if idx < len(voters) {
+			grandpaServices[idx].authority = true
+			grandpaServices[idx].keypair = voters[idx]
+		} else {
+			grandpaServices[idx].observer = true
+		}
+		grandpaServices[idx].paused.Store(false)
+
+		const withBranches = false
+		const baseLength = 4
+		state.AddBlocksToState(t,
+			grandpaServices[idx].blockState.(*state.BlockState),
+			baseLength, withBranches)
+	}
+
+	observer := grandpaServices[len(voters)]
+	for idx, grandpaService := range grandpaServices {
+		telemetryMock := NewMockTelemetry(ctrl)
+		grandpaService.telemetry = telemetryMock
+		telemetryMock.EXPECT().SendMessage(gomock.Any()).AnyTimes()
+
+		mockNet := NewMockNetwork(ctrl)
+		grandpaService.network = mockNet
+		if grandpaService == observer {
+			// the observer never gossips votes nor commits
+			continue
+		}
+
+		mockNet.EXPECT().
+			GossipMessage(gomock.Any()).
+			Do(func(arg0 any) {
+				consensusMessage, ok := arg0.(*network.ConsensusMessage)
+				require.True(t, ok, "expecting *network.ConsensusMessage, got %T", arg0)
+
+				message, err := decodeMessage(consensusMessage)
+				require.NoError(t, err)
+
+				voteMessage, ok := message.(*VoteMessage)
+				if !ok {
+					return
+				}
+				for neighbourIdx, neighbour := range grandpaServices {
+					if neighbourIdx != idx {
+						neighbour.handleVoteMessage(peer.ID(fmt.Sprint(idx)), voteMessage)
+					}
+				}
+			}).
+			AnyTimes()
+	}
+
+	runfinalisationServices(t, grandpaServices)
+
+	const round, setID uint64 = 1, 0
+	assertSamefinalisationAndChainGrowth(t, grandpaServices, round, setID)
+
+	// the observer imported the votes of the voters without casting its own
+	require.Greater(t, uint64(observer.lenVotes(precommit)), observer.state.threshold())
+	_, has := observer.loadVote(ed25519.PublicKeyBytes{}, prevote)
+	require.False(t, has)
+}
+
 // runfinalisationServices is designed to handle many grandpa services and starts, for each service,
 // the finalisation engine and the voting round engine which will take care of reach finalisation
 func runfinalisationServices(t *testing.T, grandpaServices []*Service) {
@@ -567,6 +678,10 @@ func TestSendingVotesInRightStage(t *testing.T) {
 		NextGrandpaAuthorityChange(testGenesisHeader.Hash(), testGenesisHeader.Number).
 		Return(uint(0), state.ErrNoNextAuthorityChange).
 		AnyTimes()
+	mockedGrandpaState.EXPECT().
+		LockVote(uint64(1), uint64(0), gomock.Any(), gomock.AssignableToTypeOf(Vote{})).
+		Return(true, nil).
+		AnyTimes()
 	mockedGrandpaState.EXPECT().
 		SetPrevotes(uint64(1), uint64(0), gomock.AssignableToTypeOf([]types.GrandpaSignedVote{})).
 		Return(nil)
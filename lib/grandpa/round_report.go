@@ -0,0 +1,277 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// maxRoundReports is the number of reports of completed rounds kept in memory.
+const maxRoundReports = 32
+
+var (
+	voteArrivalHistogram = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "gossamer_grandpa",
+		Name:      "vote_arrival_seconds",
+		Help:      "time between the start of a round and the arrival of the first vote of each voter, by stage",
+		Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2, 4, 8, 16, 32, 64},
+	}, []string{"stage"})
+	missingVotesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "gossamer_grandpa",
+		Name:      "missing_votes_total",
+		Help:      "total number of voters which did not vote in a completed round, by stage",
+	}, []string{"stage"})
+)
+
+// RoundReport reports the arrival latency of the votes of a round, measured
+// from the start of the round, and the voters which never voted.
+type RoundReport struct {
+	Round uint64
+	SetID uint64
+	Start time.Time
+	// Complete is true once the next round has started.
+	Complete bool
+	// Voters is the voter set of the round, in voter set order.
+	Voters             []ed25519.PublicKeyBytes
+	PrevoteLatencies   map[ed25519.PublicKeyBytes]time.Duration
+	PrecommitLatencies map[ed25519.PublicKeyBytes]time.Duration
+	// MissingPrevotes and MissingPrecommits are the voters without any vote in
+	// the stage, in voter set order. They are only set once the round is complete.
+	MissingPrevotes   []ed25519.PublicKeyBytes
+	MissingPrecommits []ed25519.PublicKeyBytes
+}
+
+func (r *RoundReport) copy() RoundReport {
+	copied := *r
+	copied.PrevoteLatencies = make(map[ed25519.PublicKeyBytes]time.Duration, len(r.PrevoteLatencies))
+	for voter, latency := range r.PrevoteLatencies {
+		copied.PrevoteLatencies[voter] = latency
+	}
+	copied.PrecommitLatencies = make(map[ed25519.PublicKeyBytes]time.Duration, len(r.PrecommitLatencies))
+	for voter, latency := range r.PrecommitLatencies {
+		copied.PrecommitLatencies[voter] = latency
+	}
+	copied.Voters = slices.Clone(r.Voters)
+	copied.MissingPrevotes = slices.Clone(r.MissingPrevotes)
+	copied.MissingPrecommits = slices.Clone(r.MissingPrecommits)
+	return copied
+}
+
+// roundReporter records the vote arrival latencies of the current round and
+// keeps the reports of the last completed rounds.
+type roundReporter struct {
+	mutex     sync.Mutex
+	current   *RoundReport
+	voters    []ed25519.PublicKeyBytes
+	completed []RoundReport
+	now       func() time.Time
+}
+
+func newRoundReporter() *roundReporter {
+	return &roundReporter{now: time.Now}
+}
+
+// startRound completes the current round, if any, and starts recording the given round.
+func (r *roundReporter) startRound(round, setID uint64, voters []Voter) {
+	if r == nil {
+		return
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.complete()
+
+	r.voters = make([]ed25519.PublicKeyBytes, len(voters))
+	for i, voter := range voters {
+		r.voters[i] = voter.Key.AsBytes()
+	}
+	r.current = &RoundReport{
+		Round:              round,
+		SetID:              setID,
+		Start:              r.now(),
+		Voters:             r.voters,
+		PrevoteLatencies:   make(map[ed25519.PublicKeyBytes]time.Duration),
+		PrecommitLatencies: make(map[ed25519.PublicKeyBytes]time.Duration),
+	}
+}
+
+// recordVote records the arrival of a vote of the current round. Only the
+// first vote of a voter for each stage is recorded.
+func (r *roundReporter) recordVote(voter ed25519.PublicKeyBytes, stage Subround) {
+	if r == nil {
+		return
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.current == nil {
+		return
+	}
+
+	latencies := r.current.PrevoteLatencies
+	if stage == precommit {
+		latencies = r.current.PrecommitLatencies
+	}
+
+	if _, ok := latencies[voter]; ok {
+		return
+	}
+
+	latency := r.now().Sub(r.current.Start)
+	latencies[voter] = latency
+	voteArrivalHistogram.WithLabelValues(stageLabel(stage)).Observe(latency.Seconds())
+}
+
+// complete moves the current round to the completed rounds, listing the
+// voters which did not vote. It must be called with the mutex held.
+func (r *roundReporter) complete() {
+	if r.current == nil {
+		return
+	}
+
+	report := r.current
+	report.Complete = true
+	for _, voter := range r.voters {
+		if _, ok := report.PrevoteLatencies[voter]; !ok {
+			report.MissingPrevotes = append(report.MissingPrevotes, voter)
+		}
+		if _, ok := report.PrecommitLatencies[voter]; !ok {
+			report.MissingPrecommits = append(report.MissingPrecommits, voter)
+		}
+	}
+	missingVotesTotal.WithLabelValues(stageLabel(prevote)).Add(float64(len(report.MissingPrevotes)))
+	missingVotesTotal.WithLabelValues(stageLabel(precommit)).Add(float64(len(report.MissingPrecommits)))
+
+	if len(report.MissingPrevotes) > 0 || len(report.MissingPrecommits) > 0 {
+		logger.Debugf("round %d of set %d completed with %d missing prevotes and %d missing precommits",
+			report.Round, report.SetID, len(report.MissingPrevotes), len(report.MissingPrecommits))
+	}
+
+	r.completed = append(r.completed, *report)
+	if len(r.completed) > maxRoundReports {
+		r.completed = r.completed[len(r.completed)-maxRoundReports:]
+	}
+	r.current = nil
+}
+
+// reports returns the reports of the completed rounds followed by the report
+// of the current round, from the oldest to the most recent round.
+func (r *roundReporter) reports() []RoundReport {
+	if r == nil {
+		return nil
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	reports := make([]RoundReport, 0, len(r.completed)+1)
+	for i := range r.completed {
+		reports = append(reports, r.completed[i].copy())
+	}
+	if r.current != nil {
+		reports = append(reports, r.current.copy())
+	}
+	return reports
+}
+
+// participation summarises the participation of the authorities in the completed
+// rounds of the given set which are still kept.
+func (r *roundReporter) participation(setID uint64) (summary ParticipationSummary, err error) {
+	if r == nil {
+		return summary, fmt.Errorf("%w: %d", ErrNoCompletedRounds, setID)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	summary.SetID = setID
+	indexes := make(map[ed25519.PublicKeyBytes]int)
+	for i := range r.completed {
+		report := &r.completed[i]
+		if report.SetID != setID {
+			continue
+		}
+
+		if summary.Rounds == 0 {
+			summary.FirstRound = report.Round
+			summary.Authorities = make([]AuthorityParticipation, len(report.Voters))
+			for j, voter := range report.Voters {
+				summary.Authorities[j].Authority = voter
+				indexes[voter] = j
+			}
+		}
+		summary.LastRound = report.Round
+		summary.Rounds++
+
+		for voter, j := range indexes {
+			_, prevoted := report.PrevoteLatencies[voter]
+			_, precommitted := report.PrecommitLatencies[voter]
+			if prevoted {
+				summary.Authorities[j].Prevoted++
+			}
+			if precommitted {
+				summary.Authorities[j].Precommitted++
+			}
+			if prevoted || precommitted {
+				summary.Authorities[j].Voted++
+			}
+		}
+	}
+
+	if summary.Rounds == 0 {
+		return summary, fmt.Errorf("%w: %d", ErrNoCompletedRounds, setID)
+	}
+	return summary, nil
+}
+
+func stageLabel(stage Subround) string {
+	if stage == precommit {
+		return "precommit"
+	}
+	return "prevote"
+}
+
+// RoundReports returns the vote arrival reports of the recent rounds, from the
+// oldest to the current round.
+func (s *Service) RoundReports() []RoundReport {
+	return s.roundReporter.reports()
+}
+
+// AuthorityParticipation is the number of completed rounds an authority voted in.
+type AuthorityParticipation struct {
+	Authority    ed25519.PublicKeyBytes
+	Prevoted     uint64
+	Precommitted uint64
+	// Voted is the number of rounds with a prevote or a precommit of the authority.
+	Voted uint64
+}
+
+// ParticipationSummary summarises the participation of the authorities of a set
+// in its completed rounds, from FirstRound to LastRound.
+type ParticipationSummary struct {
+	SetID      uint64
+	FirstRound uint64
+	LastRound  uint64
+	// Rounds is the number of completed rounds summarised, which is less than the
+	// number of rounds between FirstRound and LastRound if some were not recorded.
+	Rounds uint64
+	// Authorities is the participation of each authority, in voter set order.
+	Authorities []AuthorityParticipation
+}
+
+// Participation summarises the participation of each authority of the given set
+// in the completed rounds kept in the round reports. It returns ErrNoCompletedRounds
+// if none of the kept rounds belongs to the set.
+func (s *Service) Participation(setID uint64) (ParticipationSummary, error) {
+	return s.roundReporter.participation(setID)
+}
@@ -0,0 +1,129 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_roundReporter(t *testing.T) {
+	t.Parallel()
+
+	kr, err := keystore.NewEd25519Keyring()
+	require.NoError(t, err)
+	alice := kr.Alice().Public().(*ed25519.PublicKey)
+	bob := kr.Bob().Public().(*ed25519.PublicKey)
+	voters := []Voter{{Key: *alice, ID: 0}, {Key: *bob, ID: 1}}
+
+	now := time.Unix(100, 0)
+	reporter := newRoundReporter()
+	reporter.now = func() time.Time { return now }
+
+	// votes are ignored until a round starts
+	reporter.recordVote(alice.AsBytes(), prevote)
+	assert.Empty(t, reporter.reports())
+
+	reporter.startRound(1, 2, voters)
+	now = now.Add(time.Second)
+	reporter.recordVote(alice.AsBytes(), primaryProposal)
+	now = now.Add(time.Second)
+	reporter.recordVote(alice.AsBytes(), prevote)
+	reporter.recordVote(bob.AsBytes(), prevote)
+	reporter.recordVote(alice.AsBytes(), precommit)
+
+	reports := reporter.reports()
+	require.Len(t, reports, 1)
+	assert.False(t, reports[0].Complete)
+	assert.Empty(t, reports[0].MissingPrecommits)
+
+	now = now.Add(time.Second)
+	reporter.startRound(2, 2, voters)
+
+	expected := RoundReport{
+		Round:    1,
+		SetID:    2,
+		Start:    time.Unix(100, 0),
+		Complete: true,
+		Voters:   []ed25519.PublicKeyBytes{alice.AsBytes(), bob.AsBytes()},
+		PrevoteLatencies: map[ed25519.PublicKeyBytes]time.Duration{
+			alice.AsBytes(): time.Second,
+			bob.AsBytes():   2 * time.Second,
+		},
+		PrecommitLatencies: map[ed25519.PublicKeyBytes]time.Duration{
+			alice.AsBytes(): 2 * time.Second,
+		},
+		MissingPrecommits: []ed25519.PublicKeyBytes{bob.AsBytes()},
+	}
+
+	reports = reporter.reports()
+	require.Len(t, reports, 2)
+	assert.Equal(t, expected, reports[0])
+	assert.Equal(t, uint64(2), reports[1].Round)
+	assert.Empty(t, reports[1].PrevoteLatencies)
+
+	for round := uint64(3); round < 3+maxRoundReports; round++ {
+		reporter.startRound(round, 2, voters)
+	}
+	reports = reporter.reports()
+	assert.Len(t, reports, maxRoundReports+1)
+	assert.Equal(t, uint64(2), reports[0].Round)
+	assert.Equal(t, voters[0].Key.AsBytes(), reports[0].MissingPrevotes[0])
+}
+
+func Test_roundReporter_participation(t *testing.T) {
+	t.Parallel()
+
+	kr, err := keystore.NewEd25519Keyring()
+	require.NoError(t, err)
+	alice := kr.Alice().Public().(*ed25519.PublicKey)
+	bob := kr.Bob().Public().(*ed25519.PublicKey)
+	charlie := kr.Charlie().Public().(*ed25519.PublicKey)
+	voters := []Voter{{Key: *alice, ID: 0}, {Key: *bob, ID: 1}, {Key: *charlie, ID: 2}}
+
+	reporter := newRoundReporter()
+	_, err = reporter.participation(1)
+	assert.ErrorIs(t, err, ErrNoCompletedRounds)
+
+	reporter.startRound(1, 0, voters[:2])
+	reporter.startRound(1, 1, voters)
+	reporter.recordVote(alice.AsBytes(), prevote)
+	reporter.recordVote(alice.AsBytes(), precommit)
+	reporter.recordVote(bob.AsBytes(), prevote)
+	reporter.startRound(2, 1, voters)
+	reporter.recordVote(alice.AsBytes(), prevote)
+	reporter.recordVote(alice.AsBytes(), precommit)
+	reporter.recordVote(bob.AsBytes(), precommit)
+	// the current round is not summarised until completed
+	reporter.startRound(3, 1, voters)
+	reporter.recordVote(charlie.AsBytes(), prevote)
+
+	expected := ParticipationSummary{
+		SetID:      1,
+		FirstRound: 1,
+		LastRound:  2,
+		Rounds:     2,
+		Authorities: []AuthorityParticipation{
+			{Authority: alice.AsBytes(), Prevoted: 2, Precommitted: 2, Voted: 2},
+			{Authority: bob.AsBytes(), Prevoted: 1, Precommitted: 1, Voted: 2},
+			{Authority: charlie.AsBytes()},
+		},
+	}
+	summary, err := reporter.participation(1)
+	require.NoError(t, err)
+	assert.Equal(t, expected, summary)
+
+	summary, err = reporter.participation(0)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), summary.Rounds)
+	assert.Len(t, summary.Authorities, 2)
+
+	_, err = reporter.participation(2)
+	assert.ErrorIs(t, err, ErrNoCompletedRounds)
+}
@@ -0,0 +1,144 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"sync"
+
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+)
+
+const roundStateBufferSize = 128
+
+// RoundStateEvent is the kind of a RoundStateUpdate.
+type RoundStateEvent string
+
+const (
+	// RoundStateVote is sent when a vote of the current round is observed.
+	RoundStateVote RoundStateEvent = "vote"
+	// RoundStateEstimate is sent when the estimate of the current round changes.
+	RoundStateEstimate RoundStateEvent = "estimate"
+	// RoundStateFinalised is sent when a block is finalised.
+	RoundStateFinalised RoundStateEvent = "finalised"
+)
+
+// RoundStateUpdate is an incremental update of the state of the grandpa rounds.
+type RoundStateUpdate struct {
+	Event RoundStateEvent
+	Round uint64
+	SetID uint64
+	// Stage and Voter are only set for vote updates.
+	Stage Subround
+	Voter ed25519.PublicKeyBytes
+	// Target is the target of the vote, the new estimate or the finalised block.
+	Target Vote
+}
+
+// roundStateNotifier sends round state updates to the registered channels,
+// dropping the updates of the channels which are full.
+type roundStateNotifier struct {
+	mutex    sync.RWMutex
+	channels map[chan *RoundStateUpdate]struct{}
+
+	estimateMutex sync.Mutex
+	estimateRound uint64
+	estimateSetID uint64
+	estimate      *Vote
+}
+
+func newRoundStateNotifier() *roundStateNotifier {
+	return &roundStateNotifier{
+		channels: make(map[chan *RoundStateUpdate]struct{}),
+	}
+}
+
+func (n *roundStateNotifier) subscribe() chan *RoundStateUpdate {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	ch := make(chan *RoundStateUpdate, roundStateBufferSize)
+	n.channels[ch] = struct{}{}
+	return ch
+}
+
+func (n *roundStateNotifier) unsubscribe(ch chan *RoundStateUpdate) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	delete(n.channels, ch)
+}
+
+func (n *roundStateNotifier) notify(update *RoundStateUpdate) {
+	if n == nil {
+		return
+	}
+
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+
+	for ch := range n.channels {
+		select {
+		case ch <- update:
+		default:
+			logger.Tracef("round state channel full, dropping %s update", update.Event)
+		}
+	}
+}
+
+// notifyEstimate notifies the estimate of the given round only if it changed
+// since the last call.
+func (n *roundStateNotifier) notifyEstimate(round, setID uint64, estimate Vote) {
+	if n == nil {
+		return
+	}
+
+	n.estimateMutex.Lock()
+	if n.estimate != nil && n.estimateRound == round && n.estimateSetID == setID && *n.estimate == estimate {
+		n.estimateMutex.Unlock()
+		return
+	}
+	n.estimateRound, n.estimateSetID, n.estimate = round, setID, &estimate
+	n.estimateMutex.Unlock()
+
+	n.notify(&RoundStateUpdate{
+		Event:  RoundStateEstimate,
+		Round:  round,
+		SetID:  setID,
+		Target: estimate,
+	})
+}
+
+// GetRoundStateNotifierChannel returns a channel receiving the updates of the
+// round state. The updates are dropped while the channel is full.
+func (s *Service) GetRoundStateNotifierChannel() chan *RoundStateUpdate {
+	return s.roundStateNotifier.subscribe()
+}
+
+// FreeRoundStateNotifierChannel stops sending round state updates to the channel.
+func (s *Service) FreeRoundStateNotifierChannel(ch chan *RoundStateUpdate) {
+	s.roundStateNotifier.unsubscribe(ch)
+}
+
+// observeVote records the arrival of a vote of the current round and notifies
+// the round state subscribers.
+func (s *Service) observeVote(voter ed25519.PublicKeyBytes, stage Subround, target Vote) {
+	s.roundReporter.recordVote(voter, stage)
+	s.roundStateNotifier.notify(&RoundStateUpdate{
+		Event:  RoundStateVote,
+		Round:  s.state.round,
+		SetID:  s.state.setID,
+		Stage:  stage,
+		Voter:  voter,
+		Target: target,
+	})
+}
+
+func (s *Service) notifyFinalised(round, setID uint64, finalised Vote) {
+	s.roundStateNotifier.notify(&RoundStateUpdate{
+		Event:  RoundStateFinalised,
+		Round:  round,
+		SetID:  setID,
+		Target: finalised,
+	})
+}
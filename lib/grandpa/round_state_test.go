@@ -0,0 +1,65 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_roundStateNotifications(t *testing.T) {
+	t.Parallel()
+
+	service := &Service{
+		state:              &State{round: 3, setID: 1},
+		roundReporter:      newRoundReporter(),
+		roundStateNotifier: newRoundStateNotifier(),
+	}
+
+	ch := service.GetRoundStateNotifierChannel()
+	voter := ed25519.PublicKeyBytes{1}
+	target := Vote{Hash: common.Hash{2}, Number: 5}
+
+	service.observeVote(voter, precommit, target)
+	require.Len(t, ch, 1)
+	assert.Equal(t, &RoundStateUpdate{
+		Event:  RoundStateVote,
+		Round:  3,
+		SetID:  1,
+		Stage:  precommit,
+		Voter:  voter,
+		Target: target,
+	}, <-ch)
+
+	// only estimate changes are notified
+	service.roundStateNotifier.notifyEstimate(3, 1, target)
+	service.roundStateNotifier.notifyEstimate(3, 1, target)
+	service.roundStateNotifier.notifyEstimate(4, 1, target)
+	require.Len(t, ch, 2)
+	assert.Equal(t, RoundStateEstimate, (<-ch).Event)
+	assert.Equal(t, uint64(4), (<-ch).Round)
+
+	service.notifyFinalised(3, 1, target)
+	require.Len(t, ch, 1)
+	assert.Equal(t, &RoundStateUpdate{
+		Event:  RoundStateFinalised,
+		Round:  3,
+		SetID:  1,
+		Target: target,
+	}, <-ch)
+
+	// updates are dropped rather than blocking once the channel is full
+	for i := 0; i < roundStateBufferSize+1; i++ {
+		service.notifyFinalised(3, 1, target)
+	}
+	assert.Len(t, ch, roundStateBufferSize)
+
+	service.FreeRoundStateNotifierChannel(ch)
+	service.notifyFinalised(3, 1, target)
+	assert.Len(t, ch, roundStateBufferSize)
+}
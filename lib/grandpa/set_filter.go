@@ -0,0 +1,95 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"errors"
+
+	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	// setFilterFutureSet is the reason of the votes for the set following the current
+	// set while an authority set change is imminent, which are buffered.
+	setFilterFutureSet = "future_set"
+	// setFilterStaleSet is the reason of the votes for a set before the current set.
+	setFilterStaleSet = "stale_set"
+	// setFilterUnknownSet is the reason of the votes for a set after the current set
+	// which is not known to be imminent.
+	setFilterUnknownSet = "unknown_set"
+)
+
+var filteredVotesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "gossamer_grandpa",
+	Name:      "set_filtered_votes_total",
+	Help:      "total number of grandpa vote messages not handled because of their set id, by reason",
+}, []string{"reason"})
+
+// filterVoteSetID returns true if the vote message is for the current authority set,
+// and should be handled. The vote messages for the set following the current set are
+// buffered until the set starts if an authority set change is imminent, and the other
+// vote messages are dropped, before their signature is checked, so the votes gossiped
+// by the voters ahead or behind us during an authority set transition do not waste
+// signature checks nor spam the logs with set id mismatches.
+func (s *Service) filterVoteSetID(from peer.ID, m *VoteMessage) (accepted bool) {
+	// the round lock is held so the vote is buffered before the buffered votes of the
+	// first round of the next set are replayed.
+	s.roundLock.Lock()
+	defer s.roundLock.Unlock()
+
+	switch {
+	case m.SetID == s.state.setID:
+		return true
+	case m.SetID < s.state.setID:
+		filteredVotesTotal.WithLabelValues(setFilterStaleSet).Inc()
+		logger.Tracef("dropped vote message for stale set id %d from peer %s, current set id is %d",
+			m.SetID, from, s.state.setID)
+		return false
+	case m.SetID == s.state.setID+1 && s.setChangeImminent():
+		filteredVotesTotal.WithLabelValues(setFilterFutureSet).Inc()
+		// rounds start from 1 in a new set
+		if m.Round <= 1+maxRoundsAhead && s.futureVotes.add(from, m) {
+			logger.Tracef("buffered vote message for round %d of the next set id %d from peer %s",
+				m.Round, m.SetID, from)
+		}
+		return false
+	default:
+		filteredVotesTotal.WithLabelValues(setFilterUnknownSet).Inc()
+		logger.Tracef("dropped vote message for unknown set id %d from peer %s, current set id is %d",
+			m.SetID, from, s.state.setID)
+		return false
+	}
+}
+
+// setChangeImminent returns true if the authority set following the current set is
+// known, either because the change was applied to the grandpa state and the voter has
+// not started the set yet, or because a change is scheduled on the best chain.
+func (s *Service) setChangeImminent() bool {
+	currentSetID, err := s.grandpaState.GetCurrentSetID()
+	if err != nil {
+		logger.Debugf("getting current set id: %s", err)
+		return false
+	}
+	if currentSetID > s.state.setID {
+		return true
+	}
+
+	bestBlockHeader, err := s.blockState.BestBlockHeader()
+	if err != nil {
+		logger.Debugf("getting best block header: %s", err)
+		return false
+	}
+
+	_, err = s.grandpaState.NextGrandpaAuthorityChange(bestBlockHeader.Hash(), bestBlockHeader.Number)
+	if err != nil {
+		if !errors.Is(err, state.ErrNoNextAuthorityChange) {
+			logger.Debugf("getting next grandpa authority change: %s", err)
+		}
+		return false
+	}
+	return true
+}
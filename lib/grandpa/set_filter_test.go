@@ -0,0 +1,96 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Service_filterVoteSetID(t *testing.T) {
+	t.Parallel()
+
+	const setID = 2
+	bestBlockHeader := &types.Header{Number: 10, Digest: types.NewDigest()}
+
+	testCases := map[string]struct {
+		message          *VoteMessage
+		currentSetID     uint64
+		nextChangeErr    error
+		expectNextChange bool
+		accepted         bool
+		buffered         bool
+	}{
+		"current_set": {
+			message:  &VoteMessage{SetID: setID, Round: 1},
+			accepted: true,
+		},
+		"stale_set": {
+			message: &VoteMessage{SetID: setID - 1, Round: 1},
+		},
+		"future_set_applied": {
+			message:      &VoteMessage{SetID: setID + 1, Round: 1},
+			currentSetID: setID + 1,
+			buffered:     true,
+		},
+		"future_set_scheduled": {
+			message:          &VoteMessage{SetID: setID + 1, Round: 1},
+			currentSetID:     setID,
+			expectNextChange: true,
+			buffered:         true,
+		},
+		"future_set_round_too_far": {
+			message:      &VoteMessage{SetID: setID + 1, Round: 2 + maxRoundsAhead},
+			currentSetID: setID + 1,
+		},
+		"unknown_set_no_change": {
+			message:          &VoteMessage{SetID: setID + 1, Round: 1},
+			currentSetID:     setID,
+			expectNextChange: true,
+			nextChangeErr:    state.ErrNoNextAuthorityChange,
+		},
+		"unknown_set_too_far": {
+			message: &VoteMessage{SetID: setID + 2, Round: 1},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			grandpaState := NewMockGrandpaState(ctrl)
+			blockState := NewMockBlockState(ctrl)
+			if testCase.currentSetID != 0 {
+				grandpaState.EXPECT().GetCurrentSetID().Return(testCase.currentSetID, nil)
+			}
+			if testCase.expectNextChange {
+				blockState.EXPECT().BestBlockHeader().Return(bestBlockHeader, nil)
+				grandpaState.EXPECT().NextGrandpaAuthorityChange(bestBlockHeader.Hash(), bestBlockHeader.Number).
+					Return(uint(20), testCase.nextChangeErr)
+			}
+
+			s := &Service{
+				state:        NewState([]Voter{{ID: 1}}, setID, 5),
+				grandpaState: grandpaState,
+				blockState:   blockState,
+				futureVotes:  newFutureVotes(futureVotesCapacity),
+			}
+
+			accepted := s.filterVoteSetID("peer", testCase.message)
+			assert.Equal(t, testCase.accepted, accepted)
+
+			var expectedLength int
+			if testCase.buffered {
+				expectedLength = 1
+			}
+			assert.Equal(t, expectedLength, s.futureVotes.length)
+		})
+	}
+}
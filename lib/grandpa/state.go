@@ -8,6 +8,7 @@
 	"github.com/libp2p/go-libp2p/core/protocol"
 
 	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/dot/peerset"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/runtime"
@@ -53,12 +54,14 @@ type GrandpaState interface {
 	GetPrecommits(round, setID uint64) ([]SignedVote, error)
 	NextGrandpaAuthorityChange(bestBlockHash common.Hash, bestBlockNumber uint) (blockHeight uint, err error)
 	GetAuthoritiesChangesFromBlock(blockNumber uint) ([]uint, error)
+	LockVote(round, setID uint64, stage uint8, vote Vote) (locked bool, err error)
 }
 
 // Network is the interface required by GRANDPA for the network
 type Network interface {
 	GossipMessage(msg network.NotificationsMessage)
 	SendMessage(to peer.ID, msg NotificationsMessage) error
+	ReportPeer(change peerset.ReputationChange, p peer.ID)
 	RegisterNotificationsProtocol(sub protocol.ID,
 		messageID network.MessageType,
 		handshakeGetter network.HandshakeGetter,
@@ -0,0 +1,166 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const defaultThresholdSignTimeout = 2 * time.Second
+
+var (
+	errThresholdSignatureMissing = errors.New("threshold signature not aggregated")
+	errConflictingThresholdVote  = errors.New("conflicting vote already requested to the threshold signers")
+
+	thresholdSignaturesMissing = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "gossamer_grandpa",
+		Name:      "threshold_signatures_missing_total",
+		Help:      "total number of votes not cast since their threshold signature could not be aggregated",
+	})
+)
+
+// PartialSignature is the signature share of a vote by a member of a threshold signing committee.
+type PartialSignature struct {
+	SignerIndex uint32
+	Signature   []byte
+}
+
+// ThresholdSigner is a threshold signing committee backing a single GRANDPA authority key,
+// so several nodes can vote for the authority without any of them holding its key.
+// The committee members are expected to never sign two conflicting votes, and the voter
+// never requests them to, so the authority cannot equivocate. This voting mode is experimental.
+type ThresholdSigner interface {
+	// PublicKey returns the authority key the committee signs for.
+	PublicKey() ed25519.PublicKeyBytes
+	// Threshold returns the number of partial signatures needed to sign a message.
+	Threshold() int
+	// RequestPartialSignatures requests the committee members to sign the message and sends
+	// their partial signatures on the returned channel as they arrive. The channel is closed
+	// once all the members replied, and the requests are cancelled once the context is done.
+	RequestPartialSignatures(ctx context.Context, msg []byte) (partials <-chan PartialSignature, err error)
+	// Aggregate returns the signature of the message by the authority key from the
+	// threshold number of partial signatures given.
+	Aggregate(msg []byte, partials []PartialSignature) (signature []byte, err error)
+}
+
+// thresholdVoteKey identifies the vote of the authority for a subround.
+type thresholdVoteKey struct {
+	setID uint64
+	round uint64
+	stage Subround
+}
+
+// thresholdVotes records the votes requested to the threshold signers
+// for the current round, to never request conflicting votes.
+type thresholdVotes struct {
+	sync.Mutex
+	requested map[thresholdVoteKey][]byte
+}
+
+func newThresholdVotes() *thresholdVotes {
+	return &thresholdVotes{
+		requested: make(map[thresholdVoteKey][]byte),
+	}
+}
+
+// request records the vote message for the subround, and returns an error if
+// a different vote message was already requested for the subround. The votes
+// of previous rounds and voter sets are forgotten.
+func (t *thresholdVotes) request(key thresholdVoteKey, msg []byte) error {
+	t.Lock()
+	defer t.Unlock()
+
+	for requestedKey := range t.requested {
+		if requestedKey.setID != key.setID || requestedKey.round < key.round {
+			delete(t.requested, requestedKey)
+		}
+	}
+
+	requestedMsg, ok := t.requested[key]
+	if ok && !bytes.Equal(requestedMsg, msg) {
+		return fmt.Errorf("%w: for stage %s of round %d and set id %d",
+			errConflictingThresholdVote, key.stage, key.round, key.setID)
+	}
+
+	t.requested[key] = msg
+	return nil
+}
+
+// signVote signs the vote message of the subround with the authority key, or
+// with the threshold signers if the voter delegates signing to them.
+func (s *Service) signVote(msg []byte, stage Subround) (signature []byte, err error) {
+	if s.thresholdSigner == nil {
+		return s.keypair.Sign(msg)
+	}
+
+	key := thresholdVoteKey{setID: s.state.setID, round: s.state.round, stage: stage}
+	err = s.thresholdVotes.request(key, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err = s.thresholdSign(msg)
+	if errors.Is(err, errThresholdSignatureMissing) {
+		thresholdSignaturesMissing.Inc()
+	}
+	return signature, err
+}
+
+// thresholdSign collects the partial signatures of the message from the threshold signers
+// until the threshold is reached, and aggregates them into a signature of the authority key.
+// It returns an error wrapping errThresholdSignatureMissing if the threshold is not reached
+// before the threshold sign timeout.
+func (s *Service) thresholdSign(msg []byte) (signature []byte, err error) {
+	ctx, cancel := context.WithTimeout(s.ctx, s.thresholdSignTimeout)
+	defer cancel()
+
+	partials, err := s.thresholdSigner.RequestPartialSignatures(ctx, msg)
+	if err != nil {
+		return nil, fmt.Errorf("requesting partial signatures: %w", err)
+	}
+
+	threshold := s.thresholdSigner.Threshold()
+	collected := make([]PartialSignature, 0, threshold)
+	signers := make(map[uint32]struct{}, threshold)
+	for len(collected) < threshold {
+		select {
+		case partial, ok := <-partials:
+			if !ok {
+				return nil, fmt.Errorf("%w: %d of %d partial signatures received",
+					errThresholdSignatureMissing, len(collected), threshold)
+			}
+
+			if _, has := signers[partial.SignerIndex]; has {
+				continue
+			}
+			signers[partial.SignerIndex] = struct{}{}
+			collected = append(collected, partial)
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w: %d of %d partial signatures received before %s: %w",
+				errThresholdSignatureMissing, len(collected), threshold, s.thresholdSignTimeout, ctx.Err())
+		}
+	}
+
+	signature, err = s.thresholdSigner.Aggregate(msg, collected)
+	if err != nil {
+		return nil, fmt.Errorf("%w: aggregating partial signatures: %w", errThresholdSignatureMissing, err)
+	}
+
+	publicKey := s.thresholdSigner.PublicKey()
+	err = ed25519.VerifySignature(publicKey[:], signature, msg)
+	if err != nil {
+		return nil, fmt.Errorf("%w: verifying aggregated signature: %w", errThresholdSignatureMissing, err)
+	}
+
+	return signature, nil
+}
@@ -0,0 +1,154 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testThresholdSigner is a threshold signer sending the given number of partial
+// signatures, and aggregating them by signing the message with its keypair.
+type testThresholdSigner struct {
+	keypair     *ed25519.Keypair
+	threshold   int
+	partials    int
+	closeEarly  bool
+	badSigner   bool
+	requestsErr error
+}
+
+func (t *testThresholdSigner) PublicKey() ed25519.PublicKeyBytes {
+	return t.keypair.Public().(*ed25519.PublicKey).AsBytes()
+}
+
+func (t *testThresholdSigner) Threshold() int { return t.threshold }
+
+func (t *testThresholdSigner) RequestPartialSignatures(ctx context.Context, _ []byte) (
+	<-chan PartialSignature, error) {
+	if t.requestsErr != nil {
+		return nil, t.requestsErr
+	}
+
+	partials := make(chan PartialSignature, t.partials)
+	for i := 0; i < t.partials; i++ {
+		// the first signer replies twice
+		partials <- PartialSignature{SignerIndex: uint32(i / 2)} //nolint:gosec
+	}
+	if t.closeEarly {
+		close(partials)
+	}
+	return partials, nil
+}
+
+func (t *testThresholdSigner) Aggregate(msg []byte, partials []PartialSignature) ([]byte, error) {
+	if len(partials) != t.threshold {
+		return nil, errors.New("wrong number of partial signatures")
+	}
+	if t.badSigner {
+		return make([]byte, 64), nil
+	}
+	return t.keypair.Sign(msg)
+}
+
+func Test_Service_signVote(t *testing.T) {
+	t.Parallel()
+
+	keypair, err := ed25519.GenerateKeypair()
+	require.NoError(t, err)
+	msg := []byte("vote")
+	errTest := errors.New("test error")
+
+	testCases := map[string]struct {
+		signer     *testThresholdSigner
+		errWrapped error
+		errMessage string
+	}{
+		"threshold_reached": {
+			signer: &testThresholdSigner{keypair: keypair, threshold: 2, partials: 4},
+		},
+		"timeout": {
+			signer:     &testThresholdSigner{keypair: keypair, threshold: 2, partials: 2},
+			errWrapped: errThresholdSignatureMissing,
+			errMessage: "threshold signature not aggregated: 1 of 2 partial signatures " +
+				"received before 10ms: context deadline exceeded",
+		},
+		"signers_replied": {
+			signer:     &testThresholdSigner{keypair: keypair, threshold: 2, partials: 2, closeEarly: true},
+			errWrapped: errThresholdSignatureMissing,
+			errMessage: "threshold signature not aggregated: 1 of 2 partial signatures received",
+		},
+		"invalid_aggregated_signature": {
+			signer:     &testThresholdSigner{keypair: keypair, threshold: 1, partials: 1, badSigner: true},
+			errWrapped: errThresholdSignatureMissing,
+		},
+		"request_error": {
+			signer:     &testThresholdSigner{keypair: keypair, threshold: 1, requestsErr: errTest},
+			errWrapped: errTest,
+			errMessage: "requesting partial signatures: test error",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			s := &Service{
+				ctx:                  context.Background(),
+				state:                NewState(nil, 1, 2),
+				thresholdSigner:      testCase.signer,
+				thresholdSignTimeout: 10 * time.Millisecond,
+				thresholdVotes:       newThresholdVotes(),
+			}
+
+			signature, err := s.signVote(msg, prevote)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errMessage != "" {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			if testCase.errWrapped != nil {
+				return
+			}
+
+			err = ed25519.VerifySignature(keypair.Public().Encode(), signature, msg)
+			assert.NoError(t, err)
+			assert.Equal(t, keypair.Public().(*ed25519.PublicKey).AsBytes(), s.publicKeyBytes())
+		})
+	}
+}
+
+func Test_thresholdVotes_request(t *testing.T) {
+	t.Parallel()
+
+	votes := newThresholdVotes()
+	key := thresholdVoteKey{setID: 1, round: 2, stage: prevote}
+
+	err := votes.request(key, []byte("a"))
+	require.NoError(t, err)
+
+	// the same vote can be requested again
+	err = votes.request(key, []byte("a"))
+	require.NoError(t, err)
+
+	err = votes.request(key, []byte("b"))
+	assert.ErrorIs(t, err, errConflictingThresholdVote)
+	assert.True(t, isVoteNotCast(err))
+	assert.EqualError(t, err, "conflicting vote already requested to the threshold signers: "+
+		"for stage prevote of round 2 and set id 1")
+
+	err = votes.request(thresholdVoteKey{setID: 1, round: 2, stage: precommit}, []byte("b"))
+	require.NoError(t, err)
+
+	// votes of previous rounds are forgotten
+	err = votes.request(thresholdVoteKey{setID: 1, round: 3, stage: prevote}, []byte("b"))
+	require.NoError(t, err)
+	assert.Len(t, votes.requested, 1)
+}
@@ -120,9 +120,12 @@ type Commit struct {
 type Justification struct {
 	Round  uint64
 	Commit Commit
+	// VoteAncestries are the headers routing the precommit targets to the commit target.
+	VoteAncestries []types.Header
 }
 
-func newJustification(round uint64, hash common.Hash, number uint32, j []SignedVote) *Justification {
+func newJustification(round uint64, hash common.Hash, number uint32, j []SignedVote,
+	voteAncestries []types.Header) *Justification {
 	return &Justification{
 		Round: round,
 		Commit: Commit{
@@ -130,5 +133,6 @@ func newJustification(round uint64, hash common.Hash, number uint32, j []SignedV
 			Number:     number,
 			Precommits: j,
 		},
+		VoteAncestries: voteAncestries,
 	}
 }
@@ -71,7 +71,7 @@ func TestSignedVoteArrayEncoding(t *testing.T) {
 
 func TestJustification(t *testing.T) {
 	t.Parallel()
-	exp := common.MustHexToBytes("0x6300000000000000000000000000000000000000000000000000000000000000000000000000000000000000040a0b0c0d00000000000000000000000000000000000000000000000000000000e7030000010203040000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000506070800000000000000000000000000000000000000000000000000000000") //nolint:lll
+	exp := common.MustHexToBytes("0x6300000000000000000000000000000000000000000000000000000000000000000000000000000000000000040a0b0c0d00000000000000000000000000000000000000000000000000000000e703000001020304000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000050607080000000000000000000000000000000000000000000000000000000000") //nolint:lll
 	just := SignedVote{
 		Vote:        *testVote,
 		Signature:   testSignature,
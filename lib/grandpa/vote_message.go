@@ -12,6 +12,7 @@
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/blocktree"
 	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/ChainSafe/gossamer/lib/equivocation"
 	"github.com/ChainSafe/gossamer/pkg/scale"
 
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -20,6 +21,8 @@
 var (
 	errBeforeFinalizedBlock   = errors.New("before latest finalized block")
 	errEmptyKeyOwnershipProof = errors.New("key ownership proof is nil")
+	errVoteLocked             = errors.New("conflicting vote already locked")
+	errVoteGuarded            = errors.New("vote disallowed by the vote guard")
 )
 
 type networkVoteMessage struct {
@@ -61,12 +64,32 @@ func (s *Service) createSignedVoteAndVoteMessage(vote *Vote, stage Subround) (*S
 		return nil, nil, err
 	}
 
-	sig, err := s.keypair.Sign(msg)
+	if s.voteGuard != nil {
+		allowed, err := s.voteGuard.GuardVote(s.state.round, s.state.setID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("guarding vote: %w", err)
+		} else if !allowed {
+			return nil, nil, fmt.Errorf("%w: for stage %s of round %d and set id %d",
+				errVoteGuarded, stage, s.state.round, s.state.setID)
+		}
+	}
+
+	// the vote is locked before it is signed, so a conflicting vote
+	// is never signed for the same stage, even after a restart.
+	locked, err := s.grandpaState.LockVote(s.state.round, s.state.setID, uint8(stage), *vote)
+	if err != nil {
+		return nil, nil, fmt.Errorf("locking vote: %w", err)
+	} else if !locked {
+		return nil, nil, fmt.Errorf("%w: for stage %s of round %d and set id %d",
+			errVoteLocked, stage, s.state.round, s.state.setID)
+	}
+
+	sig, err := s.signVote(msg, stage)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	publicKeyBytes := s.keypair.Public().(*ed25519.PublicKey).AsBytes()
+	publicKeyBytes := s.publicKeyBytes()
 	pc := &SignedVote{
 		Vote:        *vote,
 		Signature:   ed25519.NewSignatureBytes(sig),
@@ -90,6 +113,17 @@ func (s *Service) createSignedVoteAndVoteMessage(vote *Vote, stage Subround) (*S
 	return pc, vm, nil
 }
 
+// isVoteNotCast returns true if the error is returned when a vote cannot be signed
+// without risking an equivocation, when the threshold signers did not sign it or when
+// the vote guard disallows it, in which case the voter does not cast the vote and
+// carries on with the round.
+func isVoteNotCast(err error) bool {
+	return errors.Is(err, errVoteLocked) ||
+		errors.Is(err, errVoteGuarded) ||
+		errors.Is(err, errThresholdSignatureMissing) ||
+		errors.Is(err, errConflictingThresholdVote)
+}
+
 // validateVoteMessage validates a VoteMessage and adds it to the current votes
 // it returns the resulting vote if validated, error otherwise
 func (s *Service) validateVoteMessage(from peer.ID, m *VoteMessage) (*Vote, error) {
@@ -97,6 +131,11 @@ func (s *Service) validateVoteMessage(from peer.ID, m *VoteMessage) (*Vote, erro
 	s.roundLock.Lock()
 	defer s.roundLock.Unlock()
 
+	// check the set id first, which is cheaper than checking the signature
+	if m.SetID != s.state.setID {
+		return nil, ErrSetIDMismatch
+	}
+
 	// check for message signature
 	pk, err := ed25519.NewPublicKey(m.Message.AuthorityID[:])
 	if err != nil {
@@ -112,10 +151,6 @@ func (s *Service) validateVoteMessage(from peer.ID, m *VoteMessage) (*Vote, erro
 		return nil, fmt.Errorf("validating message signature: %w", err)
 	}
 
-	if m.SetID != s.state.setID {
-		return nil, ErrSetIDMismatch
-	}
-
 	const maxRoundsLag = 1
 	minRoundAccepted := s.state.round - maxRoundsLag
 	if minRoundAccepted > s.state.round {
@@ -123,15 +158,18 @@ func (s *Service) validateVoteMessage(from peer.ID, m *VoteMessage) (*Vote, erro
 		minRoundAccepted = 0
 	}
 
-	const maxRoundsAhead = 1
 	maxRoundAccepted := s.state.round + maxRoundsAhead
 
 	if m.Round < minRoundAccepted || m.Round > maxRoundAccepted {
 		// Discard message
 		// TODO: affect peer reputation, this is shameful impolite behaviour
 		// https://github.com/ChainSafe/gossamer/issues/2505
-		return nil, fmt.Errorf("%w: received round: %d, round should be between: <%d, %d>",
+		err := fmt.Errorf("%w: received round: %d, round should be between: <%d, %d>",
 			errRoundOutOfBounds, m.Round, minRoundAccepted, maxRoundAccepted)
+		if m.Round < minRoundAccepted {
+			err = fmt.Errorf("%w: %w", ErrStaleRound, err)
+		}
+		return nil, err
 	}
 
 	if m.Round < s.state.round {
@@ -159,14 +197,15 @@ func (s *Service) validateVoteMessage(from peer.ID, m *VoteMessage) (*Vote, erro
 		}
 
 		// TODO: get justification if your round is lower, or just do catch-up? (#1815)
-		return nil, fmt.Errorf("%w: received round %d but state round is %d",
-			errRoundsMismatch, m.Round, s.state.round)
+		return nil, fmt.Errorf("%w: %w: received round %d but state round is %d",
+			ErrStaleRound, errRoundsMismatch, m.Round, s.state.round)
 	} else if m.Round > s.state.round {
-
-		// Message round is higher by 1 than the round of our state,
-		// we may be lagging behind, so store the message in the tracker
-		// for processing later in the coming few milliseconds.
-		s.tracker.addVote(from, m)
+		// Message round is ahead of the round of our state, we may be lagging behind,
+		// so buffer the message to replay it when its round starts, or else store it
+		// in the tracker for processing later in the coming few milliseconds.
+		if !s.futureVotes.add(from, m) {
+			s.tracker.addVote(from, m)
+		}
 		return nil, fmt.Errorf("%w: received round %d but state round is %d",
 			errRoundsMismatch, m.Round, s.state.round)
 	}
@@ -202,7 +241,7 @@ func (s *Service) validateVoteMessage(from peer.ID, m *VoteMessage) (*Vote, erro
 		AuthorityID: pk.AsBytes(),
 	}
 
-	err = s.checkAndReportEquivocation(voter, just, m.Message.Stage)
+	err = s.checkAndReportEquivocation(voter, just, m.Message.Stage, m.Round, m.SetID)
 	if err != nil {
 		return nil, fmt.Errorf("checking for equivocation: %w", err)
 	}
@@ -213,14 +252,39 @@ func (s *Service) validateVoteMessage(from peer.ID, m *VoteMessage) (*Vote, erro
 	case precommit:
 		s.precommits.Store(pk.AsBytes(), just)
 	}
+	s.observeVote(pk.AsBytes(), m.Message.Stage, *vote)
 
 	return vote, nil
 }
 
 // checkAndReportEquivocation checks if the vote is an equivocatory vote.
 // If it is an equivocatory vote, the error `ErrEquivocation` is returned, the service's votes and
-// equivocations are updated and the equivocation is reported to the runtime.
-func (s *Service) checkAndReportEquivocation(voter *Voter, vote *SignedVote, stage Subround) error {
+// equivocations are updated and the equivocation is reported to the runtime. The report is
+// submitted in the background, since its retries would otherwise hold up the vote handling,
+// for the round and set id of the vote, which may no longer be the current ones by then.
+func (s *Service) checkAndReportEquivocation(voter *Voter, vote *SignedVote, stage Subround,
+	round, setID uint64) error {
+	existingVote, err := s.checkEquivocation(voter, vote, stage)
+	if existingVote != nil {
+		s.equivocationReports.Add(1)
+		go func() {
+			defer s.equivocationReports.Done()
+			err := equivocation.Submit(equivocation.GRANDPA, s.equivocationReportRetry, func() error {
+				return s.reportEquivocation(round, setID, stage, existingVote, vote)
+			})
+			if err != nil {
+				logger.Errorf("reporting equivocation: %s", err)
+			}
+		}()
+	}
+	return err
+}
+
+// checkEquivocation updates the service's votes and equivocations with the vote, and returns
+// the error `ErrEquivocation` if it is an equivocatory vote. The existing vote of the voter is
+// returned if the vote is the voter's first equivocation of the subround, to be reported.
+func (s *Service) checkEquivocation(voter *Voter, vote *SignedVote, stage Subround) (
+	existingVote *SignedVote, err error) {
 	v := voter.Key.AsBytes()
 
 	// save justification, since equivocatory vote may still be used in justification
@@ -240,42 +304,24 @@ func (s *Service) checkAndReportEquivocation(voter *Voter, vote *SignedVote, sta
 	if has {
 		// if the voter has already equivocated, every vote in that round is an equivocatory vote
 		eq[v] = append(eq[v], vote)
-		return fmt.Errorf("%w: voter %s",
+		return nil, fmt.Errorf("%w: voter %s",
 			ErrEquivocation, v)
 	}
 
-	existingVote, has := s.loadVote(v, stage)
-	if !has {
-		return nil
+	existingVote, has = s.loadVote(v, stage)
+	if !has || existingVote.Vote.Hash == vote.Vote.Hash {
+		return nil, nil
 	}
 
-	if has && existingVote.Vote.Hash != vote.Vote.Hash {
-		// the voter has already voted, all their votes are now equivocatory
-		eq[v] = []*SignedVote{existingVote, vote}
-		s.deleteVote(v, stage)
-
-		err := s.reportEquivocation(stage, existingVote, vote)
-		if err != nil {
-			logger.Errorf("reporting equivocation: %s", err)
-		}
-		return fmt.Errorf("%w: voter %s has existing vote %s and new vote %s",
-			ErrEquivocation, v, existingVote.Vote.Hash, vote.Vote.Hash)
-	}
-
-	return nil
+	// the voter has already voted, all their votes are now equivocatory
+	eq[v] = []*SignedVote{existingVote, vote}
+	s.deleteVote(v, stage)
+	return existingVote, fmt.Errorf("%w: voter %s has existing vote %s and new vote %s",
+		ErrEquivocation, v, existingVote.Vote.Hash, vote.Vote.Hash)
 }
 
-func (s *Service) reportEquivocation(stage Subround, existingVote *SignedVote, currentVote *SignedVote) error {
-	setID, err := s.grandpaState.GetCurrentSetID()
-	if err != nil {
-		return fmt.Errorf("getting authority set id: %w", err)
-	}
-
-	round, err := s.grandpaState.GetLatestRound()
-	if err != nil {
-		return fmt.Errorf("getting latest round: %w", err)
-	}
-
+func (s *Service) reportEquivocation(round, setID uint64, stage Subround,
+	existingVote *SignedVote, currentVote *SignedVote) error {
 	pubKey := existingVote.AuthorityID
 
 	bestBlockHash := s.blockState.BestBlockHash()
@@ -288,7 +334,8 @@ func (s *Service) reportEquivocation(stage Subround, existingVote *SignedVote, c
 	if err != nil {
 		return fmt.Errorf("getting key ownership proof: %w", err)
 	} else if opaqueKeyOwnershipProof == nil {
-		return errEmptyKeyOwnershipProof
+		// the offender is no longer part of the set, retrying won't help.
+		return equivocation.Permanent(errEmptyKeyOwnershipProof)
 	}
 
 	grandpaEquivocation := types.GrandpaEquivocation{
@@ -313,7 +360,7 @@ func (s *Service) reportEquivocation(stage Subround, existingVote *SignedVote, c
 			return fmt.Errorf("setting grandpa equivocation VDT as precommit equivocation: %w", err)
 		}
 	case primaryProposal:
-		return fmt.Errorf("%w: %s (%d)", errInvalidEquivocationStage, stage, stage)
+		return equivocation.Permanent(fmt.Errorf("%w: %s (%d)", errInvalidEquivocationStage, stage, stage))
 	default:
 		panic(fmt.Sprintf("equivocation stage not implemented: %s (%d)", stage, stage))
 
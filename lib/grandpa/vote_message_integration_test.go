@@ -41,7 +41,7 @@ func TestCheckForEquivocation_NoEquivocation(t *testing.T) {
 	for _, v := range newTestVoters(t) {
 		err = gs.checkAndReportEquivocation(&v, &SignedVote{
 			Vote: *vote,
-		}, prevote)
+		}, prevote, 0, 0)
 		require.NoError(t, err)
 	}
 }
@@ -80,8 +80,9 @@ func TestCheckForEquivocation_WithEquivocation(t *testing.T) {
 
 	err = gs.checkAndReportEquivocation(&voter, &SignedVote{
 		Vote: *vote2,
-	}, prevote)
+	}, prevote, 0, 0)
 	require.ErrorIs(t, err, ErrEquivocation)
+	gs.equivocationReports.Wait()
 
 	require.Equal(t, 0, gs.lenVotes(prevote))
 	require.Equal(t, 1, len(gs.pvEquivocations))
@@ -122,8 +123,9 @@ func TestCheckForEquivocation_WithExistingEquivocation(t *testing.T) {
 
 	err = gs.checkAndReportEquivocation(&voter, &SignedVote{
 		Vote: *vote2,
-	}, prevote)
+	}, prevote, 0, 0)
 	require.ErrorIs(t, err, ErrEquivocation)
+	gs.equivocationReports.Wait()
 
 	require.Equal(t, 0, gs.lenVotes(prevote))
 	require.Equal(t, 1, len(gs.pvEquivocations))
@@ -132,7 +134,7 @@ func TestCheckForEquivocation_WithExistingEquivocation(t *testing.T) {
 
 	err = gs.checkAndReportEquivocation(&voter, &SignedVote{
 		Vote: *vote3,
-	}, prevote)
+	}, prevote, 0, 0)
 	require.ErrorIs(t, err, ErrEquivocation)
 
 	require.Equal(t, 0, gs.lenVotes(prevote))
@@ -241,6 +243,48 @@ func TestValidateMessage_SetIDMismatch(t *testing.T) {
 	require.Equal(t, err, ErrSetIDMismatch)
 }
 
+func TestValidateMessage_FutureRound(t *testing.T) {
+	t.Parallel()
+	st := newTestState(t)
+	net := newTestNetwork(t)
+
+	kr, err := keystore.NewEd25519Keyring()
+	require.NoError(t, err)
+
+	cfg := &Config{
+		BlockState:   st.Block,
+		GrandpaState: st.Grandpa,
+		Network:      net,
+		Interval:     time.Second,
+	}
+
+	gs, err := NewService(cfg)
+	require.NoError(t, err)
+	state.AddBlocksToState(t, st.Block, 3, false)
+
+	h, err := st.Block.BestBlockHeader()
+	require.NoError(t, err)
+
+	gs.keypair = kr.Alice().(*ed25519.Keypair)
+	gs.state.round = 3
+	_, msg, err := gs.createSignedVoteAndVoteMessage(NewVoteFromHeader(h), prevote)
+	require.NoError(t, err)
+	gs.state.round = 4
+	_, tooFarMsg, err := gs.createSignedVoteAndVoteMessage(NewVoteFromHeader(h), prevote)
+	require.NoError(t, err)
+	gs.keypair = kr.Bob().(*ed25519.Keypair)
+
+	// the vote of a round two rounds ahead is buffered until its round starts
+	gs.state.round = 1
+	_, err = gs.validateVoteMessage("alice", msg)
+	require.ErrorIs(t, err, errRoundsMismatch)
+
+	_, err = gs.validateVoteMessage("alice", tooFarMsg)
+	require.ErrorIs(t, err, errRoundOutOfBounds)
+
+	require.Equal(t, []networkVoteMessage{{from: "alice", msg: msg}}, gs.futureVotes.take(gs.state.setID, 3))
+}
+
 func TestValidateMessage_Equivocation(t *testing.T) {
 	t.Parallel()
 	st := newTestState(t)
@@ -283,6 +327,7 @@ func TestValidateMessage_Equivocation(t *testing.T) {
 
 	_, err = gs.validateVoteMessage("", msg)
 	require.ErrorIs(t, err, ErrEquivocation)
+	gs.equivocationReports.Wait()
 }
 
 func TestValidateMessage_BlockDoesNotExist(t *testing.T) {
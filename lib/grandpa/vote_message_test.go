@@ -5,10 +5,12 @@
 
 import (
 	"errors"
+	"sync"
 	"testing"
 
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -52,6 +54,8 @@ func TestService_reportEquivocation(t *testing.T) {
 		Equivocation: *equivocationVote,
 	}
 	type args struct {
+		round        uint64
+		setID        uint64
 		stage        Subround
 		existingVote *SignedVote
 		currentVote  *SignedVote
@@ -64,42 +68,15 @@ type args struct {
 		expErr         error
 		expErrMsg      string
 	}{
-		{
-			name: "get_setID_error",
-			serviceBuilder: func(ctrl *gomock.Controller) *Service {
-				mockGrandpaStateSetIDErr := NewMockGrandpaState(ctrl)
-				mockGrandpaStateSetIDErr.EXPECT().GetCurrentSetID().Return(uint64(0), errTestError)
-				return &Service{grandpaState: mockGrandpaStateSetIDErr}
-			},
-			expErr:    errTestError,
-			expErrMsg: "getting authority set id: test dummy error",
-		},
-		{
-			name: "get_latest_round_error",
-			serviceBuilder: func(ctrl *gomock.Controller) *Service {
-				mockGrandpaStateRoundErr := NewMockGrandpaState(ctrl)
-				mockGrandpaStateRoundErr.EXPECT().GetCurrentSetID().Return(uint64(1), nil)
-				mockGrandpaStateRoundErr.EXPECT().GetLatestRound().Return(uint64(0), errTestError)
-				return &Service{grandpaState: mockGrandpaStateRoundErr}
-			},
-			expErr:    errTestError,
-			expErrMsg: "getting latest round: test dummy error",
-		},
 		{
 			name: "get_runtime_error",
 			serviceBuilder: func(ctrl *gomock.Controller) *Service {
-				mockGrandpaStateOk := NewMockGrandpaState(ctrl)
-				mockGrandpaStateOk.EXPECT().GetCurrentSetID().Return(uint64(1), nil)
-				mockGrandpaStateOk.EXPECT().GetLatestRound().Return(uint64(1), nil)
 				mockBlockStateGetRuntimeErr := NewMockBlockState(ctrl)
 				mockBlockStateGetRuntimeErr.EXPECT().BestBlockHash().Return(dummyHash)
 				mockBlockStateGetRuntimeErr.EXPECT().GetRuntime(dummyHash).Return(nil, errTestError)
-				return &Service{
-					grandpaState: mockGrandpaStateOk,
-					blockState:   mockBlockStateGetRuntimeErr,
-				}
+				return &Service{blockState: mockBlockStateGetRuntimeErr}
 			},
-			args:      args{existingVote: signedVote},
+			args:      args{round: 1, setID: 1, existingVote: signedVote},
 			expErr:    errTestError,
 			expErrMsg: "getting runtime: test dummy error",
 		},
@@ -109,19 +86,13 @@ type args struct {
 				mockRuntimeInstanceGenerateProofErr := NewMockInstance(ctrl)
 				mockRuntimeInstanceGenerateProofErr.EXPECT().GrandpaGenerateKeyOwnershipProof(uint64(1), testAuthorityID).
 					Return(types.GrandpaOpaqueKeyOwnershipProof{}, errTestError)
-				mockGrandpaStateOk := NewMockGrandpaState(ctrl)
-				mockGrandpaStateOk.EXPECT().GetCurrentSetID().Return(uint64(1), nil)
-				mockGrandpaStateOk.EXPECT().GetLatestRound().Return(uint64(1), nil)
 				mockBlockStateGenerateProofErr := NewMockBlockState(ctrl)
 				mockBlockStateGenerateProofErr.EXPECT().BestBlockHash().Return(dummyHash)
 				mockBlockStateGenerateProofErr.EXPECT().GetRuntime(dummyHash).
 					Return(mockRuntimeInstanceGenerateProofErr, nil)
-				return &Service{
-					grandpaState: mockGrandpaStateOk,
-					blockState:   mockBlockStateGenerateProofErr,
-				}
+				return &Service{blockState: mockBlockStateGenerateProofErr}
 			},
-			args:      args{existingVote: signedVote},
+			args:      args{round: 1, setID: 1, existingVote: signedVote},
 			expErr:    errTestError,
 			expErrMsg: "getting key ownership proof: test dummy error",
 		},
@@ -131,19 +102,15 @@ type args struct {
 				mockRuntimeInstanceReportEquivocationErr := NewMockInstance(ctrl)
 				mockRuntimeInstanceReportEquivocationErr.EXPECT().GrandpaGenerateKeyOwnershipProof(uint64(1), testAuthorityID).
 					Return(keyOwnershipProof, nil)
-				mockGrandpaStateOk := NewMockGrandpaState(ctrl)
-				mockGrandpaStateOk.EXPECT().GetCurrentSetID().Return(uint64(1), nil)
-				mockGrandpaStateOk.EXPECT().GetLatestRound().Return(uint64(1), nil)
 				mockBlockStateReportEquivocationErr := NewMockBlockState(ctrl)
 				mockBlockStateReportEquivocationErr.EXPECT().BestBlockHash().Return(dummyHash)
 				mockBlockStateReportEquivocationErr.EXPECT().GetRuntime(dummyHash).
 					Return(mockRuntimeInstanceReportEquivocationErr, nil)
-				return &Service{
-					grandpaState: mockGrandpaStateOk,
-					blockState:   mockBlockStateReportEquivocationErr,
-				}
+				return &Service{blockState: mockBlockStateReportEquivocationErr}
 			},
 			args: args{
+				round:        1,
+				setID:        1,
 				stage:        primaryProposal,
 				existingVote: signedVote,
 				currentVote:  signedVote2,
@@ -160,19 +127,15 @@ type args struct {
 				mockRuntimeInstanceReportEquivocationErr.EXPECT().
 					GrandpaSubmitReportEquivocationUnsignedExtrinsic(equivocationProof, keyOwnershipProof).
 					Return(errTestError)
-				mockGrandpaStateOk := NewMockGrandpaState(ctrl)
-				mockGrandpaStateOk.EXPECT().GetCurrentSetID().Return(uint64(1), nil)
-				mockGrandpaStateOk.EXPECT().GetLatestRound().Return(uint64(1), nil)
 				mockBlockStateReportEquivocationErr := NewMockBlockState(ctrl)
 				mockBlockStateReportEquivocationErr.EXPECT().BestBlockHash().Return(dummyHash)
 				mockBlockStateReportEquivocationErr.EXPECT().GetRuntime(dummyHash).
 					Return(mockRuntimeInstanceReportEquivocationErr, nil)
-				return &Service{
-					grandpaState: mockGrandpaStateOk,
-					blockState:   mockBlockStateReportEquivocationErr,
-				}
+				return &Service{blockState: mockBlockStateReportEquivocationErr}
 			},
 			args: args{
+				round:        1,
+				setID:        1,
 				stage:        prevote,
 				existingVote: signedVote,
 				currentVote:  signedVote2,
@@ -189,18 +152,14 @@ type args struct {
 				mockRuntimeInstanceOk.EXPECT().
 					GrandpaSubmitReportEquivocationUnsignedExtrinsic(equivocationProof, keyOwnershipProof).
 					Return(nil)
-				mockGrandpaStateOk := NewMockGrandpaState(ctrl)
-				mockGrandpaStateOk.EXPECT().GetCurrentSetID().Return(uint64(1), nil)
-				mockGrandpaStateOk.EXPECT().GetLatestRound().Return(uint64(1), nil)
 				mockBlockStateOk := NewMockBlockState(ctrl)
 				mockBlockStateOk.EXPECT().BestBlockHash().Return(dummyHash)
 				mockBlockStateOk.EXPECT().GetRuntime(dummyHash).Return(mockRuntimeInstanceOk, nil)
-				return &Service{
-					grandpaState: mockGrandpaStateOk,
-					blockState:   mockBlockStateOk,
-				}
+				return &Service{blockState: mockBlockStateOk}
 			},
 			args: args{
+				round:        1,
+				setID:        1,
 				stage:        prevote,
 				existingVote: signedVote,
 				currentVote:  signedVote2,
@@ -213,7 +172,8 @@ type args struct {
 			t.Parallel()
 			ctrl := gomock.NewController(t)
 			service := tt.serviceBuilder(ctrl)
-			err := service.reportEquivocation(tt.args.stage, tt.args.existingVote, tt.args.currentVote)
+			err := service.reportEquivocation(tt.args.round, tt.args.setID, tt.args.stage,
+				tt.args.existingVote, tt.args.currentVote)
 			assert.ErrorIs(t, err, tt.expErr)
 			if tt.expErr != nil {
 				assert.EqualError(t, err, tt.expErrMsg)
@@ -221,3 +181,134 @@ type args struct {
 		})
 	}
 }
+
+func TestService_checkAndReportEquivocation_reportOutsideLock(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	existingVote := &SignedVote{Vote: *testVote, AuthorityID: testAuthorityID}
+	vote := &SignedVote{Vote: Vote{Hash: common.Hash{9}, Number: testVote.Number}, AuthorityID: testAuthorityID}
+
+	// the report blocks until the votes lock is taken while it is being submitted
+	reporting := make(chan struct{})
+	locked := make(chan struct{})
+	blockState := NewMockBlockState(ctrl)
+	blockState.EXPECT().BestBlockHash().DoAndReturn(func() common.Hash {
+		close(reporting)
+		<-locked
+		return dummyHash
+	})
+	blockState.EXPECT().GetRuntime(dummyHash).Return(nil, errTestError)
+
+	service := &Service{
+		blockState:      blockState,
+		prevotes:        new(sync.Map),
+		pvEquivocations: make(map[ed25519.PublicKeyBytes][]*SignedVote),
+	}
+	service.prevotes.Store(ed25519.PublicKeyBytes(testAuthorityID), existingVote)
+	publicKey, err := ed25519.NewPublicKey(testAuthorityID[:])
+	require.NoError(t, err)
+	voter := &Voter{Key: *publicKey}
+
+	err = service.checkAndReportEquivocation(voter, vote, prevote, 1, 1)
+	assert.ErrorIs(t, err, ErrEquivocation)
+
+	<-reporting
+	service.mapLock.Lock()
+	assert.Equal(t, []*SignedVote{existingVote, vote}, service.pvEquivocations[testAuthorityID])
+	service.mapLock.Unlock()
+	close(locked)
+	service.equivocationReports.Wait()
+}
+
+type testVoteGuard struct {
+	allowed bool
+	err     error
+}
+
+func (g testVoteGuard) GuardVote(_, _ uint64) (allowed bool, err error) {
+	return g.allowed, g.err
+}
+
+func TestService_createSignedVoteAndVoteMessage(t *testing.T) {
+	t.Parallel()
+
+	keypair, err := ed25519.GenerateKeypair()
+	require.NoError(t, err)
+	vote := NewVote(dummyHash, 1)
+
+	testCases := map[string]struct {
+		voteGuard  VoteGuard
+		lockVote   bool
+		locked     bool
+		lockErr    error
+		errWrapped error
+		errMessage string
+		notCast    bool
+	}{
+		"vote_locked": {
+			lockVote: true,
+			locked:   true,
+		},
+		"conflicting_vote_locked": {
+			lockVote:   true,
+			errWrapped: errVoteLocked,
+			errMessage: "conflicting vote already locked: for stage precommit of round 2 and set id 1",
+			notCast:    true,
+		},
+		"lock_error": {
+			lockVote:   true,
+			lockErr:    errTestError,
+			errWrapped: errTestError,
+			errMessage: "locking vote: test dummy error",
+		},
+		"vote_guard_allows": {
+			voteGuard: testVoteGuard{allowed: true},
+			lockVote:  true,
+			locked:    true,
+		},
+		"vote_guard_disallows": {
+			voteGuard:  testVoteGuard{},
+			errWrapped: errVoteGuarded,
+			errMessage: "vote disallowed by the vote guard: for stage precommit of round 2 and set id 1",
+			notCast:    true,
+		},
+		"vote_guard_error": {
+			voteGuard:  testVoteGuard{err: errTestError},
+			errWrapped: errTestError,
+			errMessage: "guarding vote: test dummy error",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			grandpaState := NewMockGrandpaState(ctrl)
+			if testCase.lockVote {
+				grandpaState.EXPECT().LockVote(uint64(2), uint64(1), uint8(precommit), *vote).
+					Return(testCase.locked, testCase.lockErr)
+			}
+			s := &Service{
+				grandpaState: grandpaState,
+				keypair:      keypair,
+				state:        NewState(nil, 1, 2),
+				voteGuard:    testCase.voteGuard,
+			}
+
+			signedVote, voteMessage, err := s.createSignedVoteAndVoteMessage(vote, precommit)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+				assert.Equal(t, testCase.notCast, isVoteNotCast(err))
+				return
+			}
+
+			assert.Equal(t, *vote, signedVote.Vote)
+			assert.Equal(t, keypair.Public().(*ed25519.PublicKey).AsBytes(), signedVote.AuthorityID)
+			assert.Equal(t, uint64(2), voteMessage.Round)
+		})
+	}
+}
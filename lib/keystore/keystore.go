@@ -12,6 +12,7 @@
 
 var (
 	ErrInvalidKeystoreName = errors.New("invalid keystore name")
+	ErrReadOnlyKeystore    = errors.New("keystore is read-only")
 )
 
 // Name represents a defined keystore name
@@ -111,3 +112,36 @@ func (k *GlobalKeystore) GetKeystore(name []byte) (Keystore, error) {
 		return nil, ErrInvalidKeystoreName
 	}
 }
+
+// ReadOnly returns a global keystore with the keys of this one, whose keystores
+// refuse to insert keys.
+func (k *GlobalKeystore) ReadOnly() *GlobalKeystore {
+	return &GlobalKeystore{
+		Babe: newReadOnlyKeystore(k.Babe),
+		Gran: newReadOnlyKeystore(k.Gran),
+		Acco: newReadOnlyKeystore(k.Acco),
+		Aura: newReadOnlyKeystore(k.Aura),
+		Para: newReadOnlyKeystore(k.Para),
+		Asgn: newReadOnlyKeystore(k.Asgn),
+		Imon: newReadOnlyKeystore(k.Imon),
+		Audi: newReadOnlyKeystore(k.Audi),
+		Dumy: newReadOnlyKeystore(k.Dumy),
+	}
+}
+
+// readOnlyKeystore is a keystore refusing to insert keys.
+type readOnlyKeystore struct {
+	Keystore
+}
+
+func newReadOnlyKeystore(ks Keystore) Keystore {
+	if ks == nil {
+		return nil
+	}
+	return readOnlyKeystore{Keystore: ks}
+}
+
+// Insert returns ErrReadOnlyKeystore.
+func (readOnlyKeystore) Insert(KeyPair) error {
+	return ErrReadOnlyKeystore
+}
@@ -0,0 +1,31 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keystore
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGlobalKeystore_ReadOnly(t *testing.T) {
+	t.Parallel()
+
+	globalKeystore := NewGlobalKeystore()
+	kp, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+	err = globalKeystore.Babe.Insert(kp)
+	require.NoError(t, err)
+
+	readOnly := globalKeystore.ReadOnly()
+	assert.Equal(t, kp, readOnly.Babe.GetKeypair(kp.Public()))
+
+	newKp, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+	err = readOnly.Babe.Insert(newKp)
+	assert.ErrorIs(t, err, ErrReadOnlyKeystore)
+	assert.Equal(t, 1, globalKeystore.Babe.Size())
+}
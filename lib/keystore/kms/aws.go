@@ -0,0 +1,239 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package kms
+
+import (
+	"bytes"
+	"context"
+	stded25519 "crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	awsURIPrefix = "awskms:///"
+
+	awsService       = "kms"
+	awsContentType   = "application/x-amz-json-1.1"
+	awsDateFormat    = "20060102T150405Z"
+	awsSignAlgorithm = "ED25519_SHA_512"
+)
+
+var (
+	// ErrNoAWSRegion is returned when the AWS region is neither in the key ARN nor in
+	// the AWS_REGION environment variable.
+	ErrNoAWSRegion = errors.New("no AWS region in key ARN nor in AWS_REGION")
+	// ErrNoAWSCredentials is returned when the AWS credentials environment variables are not set.
+	ErrNoAWSCredentials = errors.New("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY must be set")
+)
+
+// awsCredentials are the credentials requests to AWS are signed with.
+type awsCredentials struct {
+	accessKeyID     string
+	secretAccessKey string
+	sessionToken    string
+}
+
+// AWSBackend is an AWS KMS key with the ECC_NIST_EDWARDS25519 key spec. It authenticates
+// with the credentials of the AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN
+// environment variables.
+type AWSBackend struct {
+	keyID       string
+	region      string
+	endpoint    string
+	credentials awsCredentials
+	client      *http.Client
+	now         func() time.Time
+}
+
+// NewAWSBackend returns a backend for the key of the given id, alias or ARN. The region
+// of the key is taken from its ARN, or from the AWS_REGION environment variable.
+func NewAWSBackend(keyID string) (*AWSBackend, error) {
+	region := os.Getenv("AWS_REGION")
+	// arn:aws:kms:<region>:<account>:key/<id>
+	arnFields := strings.Split(keyID, ":")
+	if len(arnFields) >= 6 && arnFields[0] == "arn" {
+		region = arnFields[3]
+	}
+	if region == "" {
+		return nil, fmt.Errorf("%w: %s", ErrNoAWSRegion, keyID)
+	}
+
+	credentials := awsCredentials{
+		accessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		secretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		sessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+	if credentials.accessKeyID == "" || credentials.secretAccessKey == "" {
+		return nil, ErrNoAWSCredentials
+	}
+
+	return &AWSBackend{
+		keyID:       keyID,
+		region:      region,
+		endpoint:    "https://kms." + region + ".amazonaws.com/",
+		credentials: credentials,
+		client:      &http.Client{},
+		now:         time.Now,
+	}, nil
+}
+
+// PublicKey returns the public key of the key.
+func (b *AWSBackend) PublicKey(ctx context.Context) (stded25519.PublicKey, error) {
+	request := struct {
+		KeyID string `json:"KeyId"`
+	}{
+		KeyID: b.keyID,
+	}
+
+	var response struct {
+		PublicKey []byte `json:"PublicKey"`
+	}
+	err := b.do(ctx, "GetPublicKey", request, &response)
+	if err != nil {
+		return nil, fmt.Errorf("getting public key of %s: %w", b.keyID, err)
+	}
+	return parsePublicKey(response.PublicKey)
+}
+
+// Sign signs the message with the key.
+func (b *AWSBackend) Sign(ctx context.Context, msg []byte) ([]byte, error) {
+	request := struct {
+		KeyID            string `json:"KeyId"`
+		Message          []byte `json:"Message"`
+		MessageType      string `json:"MessageType"`
+		SigningAlgorithm string `json:"SigningAlgorithm"`
+	}{
+		KeyID:            b.keyID,
+		Message:          msg,
+		MessageType:      "RAW",
+		SigningAlgorithm: awsSignAlgorithm,
+	}
+
+	var response struct {
+		Signature []byte `json:"Signature"`
+	}
+	err := b.do(ctx, "Sign", request, &response)
+	if err != nil {
+		return nil, fmt.Errorf("signing with %s: %w", b.keyID, err)
+	}
+	return response.Signature, nil
+}
+
+func (b *AWSBackend) do(ctx context.Context, action string, request, response any) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("encoding request: %w", err)
+	}
+
+	newRequest := func() (*http.Request, error) {
+		request, err := http.NewRequest(http.MethodPost, b.endpoint, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		request.Header.Set("Content-Type", awsContentType)
+		request.Header.Set("X-Amz-Target", "TrentService."+action)
+		signAWSRequest(request, body, b.credentials, b.region, awsService, b.now())
+		return request, nil
+	}
+	return doRequest(ctx, b.client, newRequest, classifyAWSError, response)
+}
+
+func classifyAWSError(statusCode int, body []byte) requestError {
+	var response struct {
+		Type string `json:"__type"`
+	}
+	_ = json.Unmarshal(body, &response)
+	// the type may be prefixed with a namespace, as in com.amazonaws.kms#AccessDeniedException
+	errorType := response.Type[strings.LastIndex(response.Type, "#")+1:]
+
+	switch {
+	case errorType == "AccessDeniedException", errorType == "UnrecognizedClientException",
+		errorType == "InvalidSignatureException", errorType == "ExpiredTokenException",
+		errorType == "KMSInvalidStateException", errorType == "DisabledException",
+		statusCode == http.StatusUnauthorized, statusCode == http.StatusForbidden:
+		return requestDenied
+	case errorType == "ThrottlingException", errorType == "LimitExceededException",
+		statusCode == http.StatusTooManyRequests:
+		return requestThrottled
+	case statusCode >= http.StatusInternalServerError:
+		return requestRetryable
+	default:
+		return requestFailed
+	}
+}
+
+// signAWSRequest signs the request with the AWS signature version 4.
+func signAWSRequest(request *http.Request, body []byte, credentials awsCredentials, region, service string,
+	now time.Time) {
+	date := now.UTC().Format(awsDateFormat)
+	day := date[:8]
+	payloadHash := sha256Hex(body)
+
+	request.Header.Set("X-Amz-Date", date)
+	if credentials.sessionToken != "" {
+		request.Header.Set("X-Amz-Security-Token", credentials.sessionToken)
+	}
+
+	headers := map[string]string{"host": request.URL.Host}
+	for name, values := range request.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	headerNames := make([]string, 0, len(headers))
+	for name := range headers {
+		headerNames = append(headerNames, name)
+	}
+	sort.Strings(headerNames)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range headerNames {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(headerNames, ";")
+
+	path := request.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		path,
+		request.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := day + "/" + region + "/" + service + "/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + date + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	key := hmacSHA256([]byte("AWS4"+credentials.secretAccessKey), day)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	request.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+credentials.accessKeyID+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func sha256Hex(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
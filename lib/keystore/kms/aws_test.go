@@ -0,0 +1,132 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package kms
+
+import (
+	"context"
+	stded25519 "crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_signAWSRequest(t *testing.T) {
+	t.Parallel()
+
+	// example of the AWS signature version 4 documentation
+	request, err := http.NewRequest(http.MethodGet, "https://iam.amazonaws.com/?Action=ListUsers&Version=2010-05-08", nil)
+	require.NoError(t, err)
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	credentials := awsCredentials{
+		accessKeyID:     "AKIDEXAMPLE",
+		secretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+	}
+	now := time.Date(2015, time.August, 30, 12, 36, 0, 0, time.UTC)
+
+	signAWSRequest(request, nil, credentials, "us-east-1", "iam", now)
+
+	expected := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/iam/aws4_request, " +
+		"SignedHeaders=content-type;host;x-amz-date, " +
+		"Signature=5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7"
+	assert.Equal(t, expected, request.Header.Get("Authorization"))
+	assert.Equal(t, "20150830T123600Z", request.Header.Get("X-Amz-Date"))
+}
+
+func Test_NewAWSBackend(t *testing.T) {
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+
+	_, err := NewAWSBackend("alias/grandpa")
+	assert.ErrorIs(t, err, ErrNoAWSRegion)
+
+	backend, err := NewAWSBackend("arn:aws:kms:eu-west-1:111122223333:key/1234abcd")
+	require.NoError(t, err)
+	assert.Equal(t, "eu-west-1", backend.region)
+	assert.Equal(t, "https://kms.eu-west-1.amazonaws.com/", backend.endpoint)
+
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "")
+	_, err = NewAWSBackend("arn:aws:kms:eu-west-1:111122223333:key/1234abcd")
+	assert.ErrorIs(t, err, ErrNoAWSCredentials)
+}
+
+func Test_AWSBackend(t *testing.T) {
+	t.Parallel()
+
+	public, private, err := stded25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(public)
+	require.NoError(t, err)
+
+	var signErrorType atomic.Value
+	signErrorType.Store("")
+	var signRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, awsContentType, r.Header.Get("Content-Type"))
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/"))
+
+		var request struct {
+			KeyID            string `json:"KeyId"`
+			Message          []byte `json:"Message"`
+			SigningAlgorithm string `json:"SigningAlgorithm"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Equal(t, "alias/grandpa", request.KeyID)
+
+		switch r.Header.Get("X-Amz-Target") {
+		case "TrentService.GetPublicKey":
+			_ = json.NewEncoder(w).Encode(map[string][]byte{"PublicKey": der})
+		case "TrentService.Sign":
+			signRequests.Add(1)
+			if errorType := signErrorType.Load().(string); errorType != "" {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"__type":"` + errorType + `","message":"failed"}`))
+				return
+			}
+			assert.Equal(t, awsSignAlgorithm, request.SigningAlgorithm)
+			_ = json.NewEncoder(w).Encode(map[string][]byte{"Signature": stded25519.Sign(private, request.Message)})
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	backend := &AWSBackend{
+		keyID:       "alias/grandpa",
+		region:      "us-east-1",
+		endpoint:    server.URL,
+		credentials: awsCredentials{accessKeyID: "AKIDEXAMPLE", secretAccessKey: "secret"},
+		client:      server.Client(),
+		now:         time.Now,
+	}
+	kp, err := NewKeypair(context.Background(), backend, 10, DefaultSignTimeout)
+	require.NoError(t, err)
+	assert.Equal(t, []byte(public), kp.Public().Encode())
+
+	_, err = kp.Sign([]byte("precommit"))
+	require.NoError(t, err)
+
+	// access denied errors are not retried
+	signErrorType.Store("AccessDeniedException")
+	signRequests.Store(0)
+	_, err = kp.Sign([]byte("precommit"))
+	assert.ErrorIs(t, err, ErrPermissionDenied)
+	assert.Equal(t, int32(1), signRequests.Load())
+
+	// throttling errors are retried
+	signErrorType.Store("com.amazonaws.kms#ThrottlingException")
+	signRequests.Store(0)
+	_, err = kp.Sign([]byte("precommit"))
+	assert.ErrorIs(t, err, ErrRateLimited)
+	assert.Equal(t, int32(maxAttempts), signRequests.Load())
+}
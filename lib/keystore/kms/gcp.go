@@ -0,0 +1,192 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package kms
+
+import (
+	"bytes"
+	"context"
+	stded25519 "crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	gcpURIPrefix = "gcpkms://"
+
+	gcpEndpoint = "https://cloudkms.googleapis.com"
+	// gcpMetadataHost is the host of the metadata server of the Google Cloud instances,
+	// which provides the access tokens of their service account.
+	gcpMetadataHost     = "metadata.google.internal"
+	gcpMetadataHostEnv  = "GCE_METADATA_HOST"
+	gcpAccessTokenEnv   = "GOOGLE_OAUTH_ACCESS_TOKEN"
+	gcpTokenExpiryDelta = 30 * time.Second
+)
+
+// GCPBackend is a Google Cloud KMS key version with the EC_SIGN_ED25519 algorithm.
+// It authenticates with the access token of the GOOGLE_OAUTH_ACCESS_TOKEN environment
+// variable if set, or of the service account of the instance otherwise. Since the
+// expiry of the access token of the environment variable is unknown, it is used until
+// it is rejected, after which the access tokens of the service account are used.
+type GCPBackend struct {
+	name         string
+	endpoint     string
+	metadataHost string
+	client       *http.Client
+
+	tokenMutex  sync.Mutex
+	token       string
+	tokenExpiry time.Time
+}
+
+// NewGCPBackend returns a backend for the key version of the given resource name,
+// projects/<project>/locations/<location>/keyRings/<ring>/cryptoKeys/<key>/cryptoKeyVersions/<version>.
+func NewGCPBackend(name string) *GCPBackend {
+	metadataHost := os.Getenv(gcpMetadataHostEnv)
+	if metadataHost == "" {
+		metadataHost = gcpMetadataHost
+	}
+
+	return &GCPBackend{
+		name:         name,
+		endpoint:     gcpEndpoint,
+		metadataHost: metadataHost,
+		client:       &http.Client{},
+		token:        os.Getenv(gcpAccessTokenEnv),
+	}
+}
+
+// PublicKey returns the public key of the key version.
+func (b *GCPBackend) PublicKey(ctx context.Context) (stded25519.PublicKey, error) {
+	var response struct {
+		PEM string `json:"pem"`
+	}
+	err := b.do(ctx, http.MethodGet, b.endpoint+"/v1/"+b.name+"/publicKey", nil, &response)
+	if err != nil {
+		return nil, fmt.Errorf("getting public key of %s: %w", b.name, err)
+	}
+	return parsePublicKey([]byte(response.PEM))
+}
+
+// Sign signs the message with the key version.
+func (b *GCPBackend) Sign(ctx context.Context, msg []byte) ([]byte, error) {
+	request := struct {
+		Data string `json:"data"`
+	}{
+		Data: base64.StdEncoding.EncodeToString(msg),
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("encoding request: %w", err)
+	}
+
+	var response struct {
+		Signature string `json:"signature"`
+	}
+	err = b.do(ctx, http.MethodPost, b.endpoint+"/v1/"+b.name+":asymmetricSign", body, &response)
+	if err != nil {
+		return nil, fmt.Errorf("signing with %s: %w", b.name, err)
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(response.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("decoding signature: %w", err)
+	}
+	return signature, nil
+}
+
+// do sends the request with the access token, and sends it again with a new access
+// token if the access token is rejected, such as when it expired before its expiry time.
+func (b *GCPBackend) do(ctx context.Context, method, url string, body []byte, response any) error {
+	for attempt := 0; ; attempt++ {
+		token, err := b.accessToken(ctx)
+		if err != nil {
+			return fmt.Errorf("getting access token: %w", err)
+		}
+
+		newRequest := func() (*http.Request, error) {
+			request, err := http.NewRequest(method, url, bytes.NewReader(body))
+			if err != nil {
+				return nil, err
+			}
+			request.Header.Set("Authorization", "Bearer "+token)
+			if body != nil {
+				request.Header.Set("Content-Type", "application/json")
+			}
+			return request, nil
+		}
+		err = doRequest(ctx, b.client, newRequest, classifyGCPError, response)
+		if attempt == 0 && errors.Is(err, errUnauthenticated) {
+			b.invalidateToken(token)
+			continue
+		}
+		return err
+	}
+}
+
+// accessToken returns the cached access token, or a new access token of the service
+// account of the instance if it expired or was invalidated.
+func (b *GCPBackend) accessToken(ctx context.Context) (string, error) {
+	b.tokenMutex.Lock()
+	defer b.tokenMutex.Unlock()
+
+	if b.token != "" && (b.tokenExpiry.IsZero() || time.Now().Before(b.tokenExpiry)) {
+		return b.token, nil
+	}
+
+	newRequest := func() (*http.Request, error) {
+		request, err := http.NewRequest(http.MethodGet,
+			"http://"+b.metadataHost+"/computeMetadata/v1/instance/service-accounts/default/token", nil)
+		if err != nil {
+			return nil, err
+		}
+		request.Header.Set("Metadata-Flavor", "Google")
+		return request, nil
+	}
+
+	var response struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	err := doRequest(ctx, b.client, newRequest, classifyGCPError, &response)
+	if err != nil {
+		return "", err
+	}
+
+	b.token = response.AccessToken
+	b.tokenExpiry = time.Now().Add(time.Duration(response.ExpiresIn)*time.Second - gcpTokenExpiryDelta)
+	return b.token, nil
+}
+
+// invalidateToken clears the cached access token if it is the token given, which was
+// rejected, so a new access token is requested by the next call to accessToken.
+func (b *GCPBackend) invalidateToken(token string) {
+	b.tokenMutex.Lock()
+	defer b.tokenMutex.Unlock()
+
+	if b.token == token {
+		b.token = ""
+		b.tokenExpiry = time.Time{}
+	}
+}
+
+func classifyGCPError(statusCode int, _ []byte) requestError {
+	switch {
+	case statusCode == http.StatusUnauthorized:
+		return requestUnauthenticated
+	case statusCode == http.StatusForbidden:
+		return requestDenied
+	case statusCode == http.StatusTooManyRequests:
+		return requestThrottled
+	case statusCode >= http.StatusInternalServerError:
+		return requestRetryable
+	default:
+		return requestFailed
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package kms
+
+import (
+	"context"
+	stded25519 "crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testGCPKeyName = "projects/p/locations/global/keyRings/r/cryptoKeys/grandpa/cryptoKeyVersions/1"
+
+func newTestGCPServer(t *testing.T, private stded25519.PrivateKey, signStatus *atomic.Int32,
+	tokenRequests *atomic.Int32) *httptest.Server {
+	t.Helper()
+
+	der, err := x509.MarshalPKIXPublicKey(private.Public())
+	require.NoError(t, err)
+	publicPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/computeMetadata/v1/instance/service-accounts/default/token",
+		func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "Google", r.Header.Get("Metadata-Flavor"))
+			tokenRequests.Add(1)
+			_, _ = w.Write([]byte(`{"access_token":"token","expires_in":3600}`))
+		})
+	mux.HandleFunc("/v1/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/" + testGCPKeyName + "/publicKey":
+			_ = json.NewEncoder(w).Encode(map[string]string{"pem": string(publicPEM)})
+		case "/v1/" + testGCPKeyName + ":asymmetricSign":
+			if status := signStatus.Load(); status != 0 {
+				w.WriteHeader(int(status))
+				return
+			}
+			var request struct {
+				Data string `json:"data"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			msg, err := base64.StdEncoding.DecodeString(request.Data)
+			require.NoError(t, err)
+			signature := base64.StdEncoding.EncodeToString(stded25519.Sign(private, msg))
+			_ = json.NewEncoder(w).Encode(map[string]string{"signature": signature})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func Test_GCPBackend(t *testing.T) {
+	t.Parallel()
+
+	_, private, err := stded25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	var signStatus, tokenRequests atomic.Int32
+	server := newTestGCPServer(t, private, &signStatus, &tokenRequests)
+
+	backend := &GCPBackend{
+		name:         testGCPKeyName,
+		endpoint:     server.URL,
+		metadataHost: strings.TrimPrefix(server.URL, "http://"),
+		client:       server.Client(),
+	}
+	kp, err := NewKeypair(context.Background(), backend, 10, DefaultSignTimeout)
+	require.NoError(t, err)
+	assert.Equal(t, []byte(private.Public().(stded25519.PublicKey)), kp.Public().Encode())
+
+	_, err = kp.Sign([]byte("prevote"))
+	require.NoError(t, err)
+	// the access token is cached
+	assert.Equal(t, int32(1), tokenRequests.Load())
+
+	signStatus.Store(http.StatusForbidden)
+	_, err = kp.Sign([]byte("prevote"))
+	assert.ErrorIs(t, err, ErrPermissionDenied)
+
+	signStatus.Store(http.StatusTooManyRequests)
+	_, err = kp.Sign([]byte("prevote"))
+	assert.ErrorIs(t, err, ErrRateLimited)
+}
+
+func Test_GCPBackend_rejectedToken(t *testing.T) {
+	t.Parallel()
+
+	_, private, err := stded25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	var signStatus, tokenRequests atomic.Int32
+	server := newTestGCPServer(t, private, &signStatus, &tokenRequests)
+
+	// the token of the environment variable has no known expiry
+	backend := &GCPBackend{
+		name:         testGCPKeyName,
+		endpoint:     server.URL,
+		metadataHost: strings.TrimPrefix(server.URL, "http://"),
+		client:       server.Client(),
+		token:        "expired",
+	}
+
+	// the rejected token is replaced by a token of the service account
+	_, err = backend.PublicKey(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), tokenRequests.Load())
+	_, err = backend.Sign(context.Background(), []byte("prevote"))
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), tokenRequests.Load())
+
+	// the request is sent again once with a new token
+	signStatus.Store(http.StatusUnauthorized)
+	_, err = backend.Sign(context.Background(), []byte("prevote"))
+	assert.ErrorIs(t, err, ErrPermissionDenied)
+	assert.Equal(t, int32(2), tokenRequests.Load())
+}
@@ -0,0 +1,287 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+// Package kms provides keypairs signing with ed25519 keys held by cloud key management
+// services, so validators can vote with GRANDPA keys which never leave the service.
+package kms
+
+import (
+	"bytes"
+	"context"
+	stded25519 "crypto/ed25519"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+)
+
+const (
+	// DefaultSignTimeout is the default timeout of a signature by the key management
+	// service, including its retries.
+	DefaultSignTimeout = 2 * time.Second
+	// DefaultRateLimit is the default number of signatures per second requested to the
+	// key management service, below the quotas of the cloud providers.
+	DefaultRateLimit = 20
+
+	// maxAttempts is the number of attempts of a request to the key management service
+	// which is throttled or fails with a server error.
+	maxAttempts = 3
+	// retryBackoff is the delay before the first retry of a request, doubled for each retry.
+	retryBackoff = 100 * time.Millisecond
+	// maxResponseSize bounds the size of the responses of the key management service.
+	maxResponseSize = 1 << 20
+)
+
+var (
+	// ErrPermissionDenied is returned when the credentials are not allowed to use the key.
+	ErrPermissionDenied = errors.New("permission denied by the key management service")
+	// ErrRateLimited is returned when a signature exceeds the local rate limit, or the
+	// key management service still throttles its requests after the retries.
+	ErrRateLimited = errors.New("key management service rate limited")
+	// ErrInvalidSignature is returned when the key management service returns a signature
+	// which is not valid for the cached public key.
+	ErrInvalidSignature = errors.New("invalid signature returned by the key management service")
+	// ErrNotEd25519 is returned when the key of the key management service is not an ed25519 key.
+	ErrNotEd25519 = errors.New("key is not an ed25519 key")
+	// ErrUnsupportedURI is returned for a key URI which is neither a gcpkms:// nor an awskms:// URI.
+	ErrUnsupportedURI = errors.New("unsupported key management service URI")
+
+	// errUnauthenticated is wrapped with ErrPermissionDenied when the credentials are
+	// rejected, such as an expired or revoked access token.
+	errUnauthenticated = errors.New("unauthenticated")
+)
+
+// Backend is a key management service holding an ed25519 private key.
+type Backend interface {
+	// PublicKey returns the ed25519 public key of the key.
+	PublicKey(ctx context.Context) (stded25519.PublicKey, error)
+	// Sign returns the ed25519 signature of the message by the key.
+	Sign(ctx context.Context, msg []byte) (signature []byte, err error)
+}
+
+// Keypair is an ed25519 keypair whose private key is held by a key management service.
+// Its public key is fetched once and cached, and the signatures are rate limited and
+// verified against it, so a misconfigured key never produces invalid votes.
+type Keypair struct {
+	backend Backend
+	public  *ed25519.PublicKey
+	limiter *rateLimiter
+	timeout time.Duration
+}
+
+// NewKeypair returns a keypair signing with the key of the backend, at most rateLimit
+// times per second, each signature timing out after the timeout given. It fetches and
+// caches the public key of the key.
+func NewKeypair(ctx context.Context, backend Backend, rateLimit int, timeout time.Duration) (*Keypair, error) {
+	publicKey, err := backend.PublicKey(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting public key: %w", err)
+	}
+
+	public, err := ed25519.NewPublicKey(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("decoding public key: %w", err)
+	}
+
+	return &Keypair{
+		backend: backend,
+		public:  public,
+		limiter: newRateLimiter(rateLimit, time.Now),
+		timeout: timeout,
+	}, nil
+}
+
+// NewKeypairFromURI returns a keypair signing with the key of the given URI, either
+// gcpkms://projects/<project>/locations/<location>/keyRings/<ring>/cryptoKeys/<key>/cryptoKeyVersions/<version>
+// for Google Cloud KMS or awskms:///<key id or arn> for AWS KMS, with the default rate
+// limit and sign timeout.
+func NewKeypairFromURI(ctx context.Context, uri string) (*Keypair, error) {
+	var backend Backend
+	switch {
+	case strings.HasPrefix(uri, gcpURIPrefix):
+		backend = NewGCPBackend(strings.TrimPrefix(uri, gcpURIPrefix))
+	case strings.HasPrefix(uri, awsURIPrefix):
+		var err error
+		backend, err = NewAWSBackend(strings.TrimPrefix(uri, awsURIPrefix))
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedURI, uri)
+	}
+
+	return NewKeypair(ctx, backend, DefaultRateLimit, DefaultSignTimeout)
+}
+
+// Sign signs the message with the key of the key management service.
+func (kp *Keypair) Sign(msg []byte) ([]byte, error) {
+	if !kp.limiter.allow() {
+		return nil, fmt.Errorf("%w: more than %d signatures per second", ErrRateLimited, kp.limiter.rate)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), kp.timeout)
+	defer cancel()
+
+	signature, err := kp.backend.Sign(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	ok, err := kp.public.Verify(msg, signature)
+	if err != nil || !ok {
+		return nil, fmt.Errorf("%w: for public key %s", ErrInvalidSignature, kp.public.Hex())
+	}
+	return signature, nil
+}
+
+// Public returns the cached public key of the key.
+func (kp *Keypair) Public() crypto.PublicKey {
+	return kp.public
+}
+
+// Type returns ed25519.
+func (*Keypair) Type() crypto.KeyType {
+	return crypto.Ed25519Type
+}
+
+// rateLimiter is a token bucket allowing rate events per second, in bursts of up to rate events.
+type rateLimiter struct {
+	mutex  sync.Mutex
+	rate   int
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+func newRateLimiter(rate int, now func() time.Time) *rateLimiter {
+	return &rateLimiter{
+		rate:   rate,
+		tokens: float64(rate),
+		last:   now(),
+		now:    now,
+	}
+}
+
+// allow returns true and consumes a token if one is available.
+func (r *rateLimiter) allow() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	now := r.now()
+	r.tokens = min(float64(r.rate), r.tokens+now.Sub(r.last).Seconds()*float64(r.rate))
+	r.last = now
+	if r.tokens < 1 {
+		return false
+	}
+	r.tokens--
+	return true
+}
+
+// parsePublicKey parses an ed25519 public key encoded as a DER or PEM SubjectPublicKeyInfo.
+func parsePublicKey(encoded []byte) (stded25519.PublicKey, error) {
+	block, _ := pem.Decode(encoded)
+	if block != nil {
+		encoded = block.Bytes
+	}
+
+	publicKey, err := x509.ParsePKIXPublicKey(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("parsing public key: %w", err)
+	}
+
+	ed25519PublicKey, ok := publicKey.(stded25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", ErrNotEd25519, publicKey)
+	}
+	return ed25519PublicKey, nil
+}
+
+// requestError is the classification of the error response of a key management service.
+type requestError int
+
+const (
+	requestFailed requestError = iota
+	requestDenied
+	requestUnauthenticated
+	requestThrottled
+	requestRetryable
+)
+
+// doRequest sends the request built by newRequest and decodes its JSON response into
+// the response given, retrying the throttled requests and the server errors with an
+// exponential backoff until the context is done. The error responses are classified
+// by classify.
+func doRequest(ctx context.Context, client *http.Client, newRequest func() (*http.Request, error),
+	classify func(statusCode int, body []byte) requestError, response any) error {
+	backoff := retryBackoff
+	var err error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if attempt > 0 {
+			timer := time.NewTimer(backoff)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return fmt.Errorf("%w (last error: %w)", ctx.Err(), err)
+			case <-timer.C:
+			}
+			backoff *= 2
+		}
+
+		var retry bool
+		retry, err = doRequestOnce(ctx, client, newRequest, classify, response)
+		if err == nil || !retry {
+			return err
+		}
+	}
+	return err
+}
+
+func doRequestOnce(ctx context.Context, client *http.Client, newRequest func() (*http.Request, error),
+	classify func(statusCode int, body []byte) requestError, response any) (retry bool, err error) {
+	request, err := newRequest()
+	if err != nil {
+		return false, fmt.Errorf("creating request: %w", err)
+	}
+
+	httpResponse, err := client.Do(request.WithContext(ctx))
+	if err != nil {
+		return ctx.Err() == nil, fmt.Errorf("sending request: %w", err)
+	}
+	defer httpResponse.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(httpResponse.Body, maxResponseSize))
+	if err != nil {
+		return ctx.Err() == nil, fmt.Errorf("reading response: %w", err)
+	}
+
+	if httpResponse.StatusCode != http.StatusOK {
+		message := strings.TrimSpace(string(bytes.ToValidUTF8(body, nil)))
+		switch classify(httpResponse.StatusCode, body) {
+		case requestDenied:
+			return false, fmt.Errorf("%w: %s", ErrPermissionDenied, message)
+		case requestUnauthenticated:
+			return false, fmt.Errorf("%w: %w: %s", ErrPermissionDenied, errUnauthenticated, message)
+		case requestThrottled:
+			return true, fmt.Errorf("%w: %s", ErrRateLimited, message)
+		case requestRetryable:
+			return true, fmt.Errorf("status %d: %s", httpResponse.StatusCode, message)
+		default:
+			return false, fmt.Errorf("status %d: %s", httpResponse.StatusCode, message)
+		}
+	}
+
+	err = json.Unmarshal(body, response)
+	if err != nil {
+		return false, fmt.Errorf("decoding response: %w", err)
+	}
+	return false, nil
+}
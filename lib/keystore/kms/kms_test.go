@@ -0,0 +1,144 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package kms
+
+import (
+	"context"
+	stded25519 "crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ keystore.KeyPair = (*Keypair)(nil)
+
+// fakeBackend is a backend signing with a local private key.
+type fakeBackend struct {
+	private   stded25519.PrivateKey
+	signCalls int
+	signErr   error
+	tamper    bool
+}
+
+func newFakeBackend(t *testing.T) *fakeBackend {
+	t.Helper()
+	_, private, err := stded25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return &fakeBackend{private: private}
+}
+
+func (b *fakeBackend) PublicKey(context.Context) (stded25519.PublicKey, error) {
+	return b.private.Public().(stded25519.PublicKey), nil
+}
+
+func (b *fakeBackend) Sign(_ context.Context, msg []byte) ([]byte, error) {
+	b.signCalls++
+	if b.signErr != nil {
+		return nil, b.signErr
+	}
+	signature := stded25519.Sign(b.private, msg)
+	if b.tamper {
+		signature[0] ^= 0xff
+	}
+	return signature, nil
+}
+
+func Test_Keypair(t *testing.T) {
+	t.Parallel()
+
+	backend := newFakeBackend(t)
+	kp, err := NewKeypair(context.Background(), backend, 10, time.Second)
+	require.NoError(t, err)
+
+	assert.Equal(t, crypto.Ed25519Type, kp.Type())
+	assert.Equal(t, []byte(backend.private.Public().(stded25519.PublicKey)), kp.Public().Encode())
+
+	msg := []byte("precommit")
+	signature, err := kp.Sign(msg)
+	require.NoError(t, err)
+	ok, err := ed25519.Verify(kp.Public().(*ed25519.PublicKey), msg, signature)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	backend.tamper = true
+	_, err = kp.Sign(msg)
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+
+	backend.signErr = ErrPermissionDenied
+	_, err = kp.Sign(msg)
+	assert.ErrorIs(t, err, ErrPermissionDenied)
+}
+
+func Test_Keypair_rateLimited(t *testing.T) {
+	t.Parallel()
+
+	backend := newFakeBackend(t)
+	kp, err := NewKeypair(context.Background(), backend, 2, time.Second)
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		_, err = kp.Sign([]byte("prevote"))
+		require.NoError(t, err)
+	}
+	_, err = kp.Sign([]byte("prevote"))
+	assert.ErrorIs(t, err, ErrRateLimited)
+	assert.Equal(t, 2, backend.signCalls)
+}
+
+func Test_rateLimiter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(0, 0)
+	limiter := newRateLimiter(2, func() time.Time { return now })
+
+	assert.True(t, limiter.allow())
+	assert.True(t, limiter.allow())
+	assert.False(t, limiter.allow())
+
+	now = now.Add(500 * time.Millisecond)
+	assert.True(t, limiter.allow())
+	assert.False(t, limiter.allow())
+
+	// the tokens do not accumulate beyond the burst
+	now = now.Add(time.Minute)
+	assert.True(t, limiter.allow())
+	assert.True(t, limiter.allow())
+	assert.False(t, limiter.allow())
+}
+
+func Test_parsePublicKey(t *testing.T) {
+	t.Parallel()
+
+	public, _, err := stded25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(public)
+	require.NoError(t, err)
+
+	parsed, err := parsePublicKey(der)
+	require.NoError(t, err)
+	assert.Equal(t, public, parsed)
+
+	parsed, err = parsePublicKey(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	require.NoError(t, err)
+	assert.Equal(t, public, parsed)
+
+	_, err = parsePublicKey([]byte("not a key"))
+	assert.Error(t, err)
+}
+
+func Test_NewKeypairFromURI_unsupported(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewKeypairFromURI(context.Background(), "vault://key")
+	assert.True(t, errors.Is(err, ErrUnsupportedURI))
+}
@@ -60,6 +60,12 @@
 			"the difference between the allocator's bumper and the heap base.",
 	})
 
+	memoryGrownPagesCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "gossamer_allocator",
+		Name:      "memory_grown_pages_total",
+		Help:      "the number of wasm pages the linear memory was grown by to serve allocations",
+	})
+
 	logger = log.NewFromGlobal(
 		log.AddContext("pkg", "runtime-allocator"),
 	)
@@ -338,14 +344,23 @@ type FreeingBumpHeapAllocator struct {
 	freeLists              *FreeLists
 	poisoned               bool
 	lastObservedMemorySize uint64
+	maxPages               uint32
 	stats                  AllocationStats
 }
 
 func NewFreeingBumpHeapAllocator(heapBase uint32) *FreeingBumpHeapAllocator {
+	return NewFreeingBumpHeapAllocatorWithMaxPages(heapBase, MaxWasmPages)
+}
+
+// NewFreeingBumpHeapAllocatorWithMaxPages returns an allocator which does not
+// grow the linear memory beyond the given number of pages. The limit is capped
+// to MaxWasmPages.
+func NewFreeingBumpHeapAllocatorWithMaxPages(heapBase, maxPages uint32) *FreeingBumpHeapAllocator {
 	alignedHeapBase := (heapBase + Aligment - 1) / Aligment * Aligment
 	return &FreeingBumpHeapAllocator{
 		originalHeapBase:       alignedHeapBase,
 		bumper:                 alignedHeapBase,
+		maxPages:               min(maxPages, MaxWasmPages),
 		freeLists:              NewFreeLists(),
 		poisoned:               false,
 		lastObservedMemorySize: 0,
@@ -358,6 +373,11 @@ func NewFreeingBumpHeapAllocator(heapBase uint32) *FreeingBumpHeapAllocator {
 	}
 }
 
+// MaxPages returns the number of pages the allocator grows the linear memory up to.
+func (f *FreeingBumpHeapAllocator) MaxPages() uint32 {
+	return f.maxPages
+}
+
 // Allocate gets the requested number of bytes to allocate and returns a pointer.
 // The maximum size which can be allocated is 32MiB.
 // There is no minimum size, but whatever size is passed into this function is rounded
@@ -418,7 +438,7 @@ func (f *FreeingBumpHeapAllocator) Allocate(mem runtime.Memory, size uint32) (pt
 		headerPtr = value.headerPtr
 	case Nil:
 		// Corresponding free list is empty. Allocate a new item
-		newPtr, err := bump(&f.bumper, order.size()+HeaderSize, mem)
+		newPtr, err := bump(&f.bumper, order.size()+HeaderSize, f.maxPages, mem)
 		if err != nil {
 			return 0, fmt.Errorf("bumping: %w", err)
 		}
@@ -507,7 +527,7 @@ func (f *FreeingBumpHeapAllocator) Deallocate(mem runtime.Memory, ptr uint32) (e
 	return nil
 }
 
-func bump(bumper *uint32, size uint32, mem runtime.Memory) (uint32, error) {
+func bump(bumper *uint32, size, maxPages uint32, mem runtime.Memory) (uint32, error) {
 	requiredSize := uint64(*bumper) + uint64(size)
 
 	if requiredSize > mem.Size() {
@@ -521,19 +541,19 @@ func bump(bumper *uint32, size uint32, mem runtime.Memory) (uint32, error) {
 			panic(fmt.Sprintf("page size cannot fit into uint32, current memory size: %d", mem.Size()))
 		}
 
-		if currentPages >= MaxWasmPages {
-			return 0, fmt.Errorf("%w: current pages %d greater than max wasm pages %d",
-				ErrAllocatorOutOfSpace, currentPages, MaxWasmPages)
+		if currentPages >= maxPages {
+			return 0, fmt.Errorf("%w: current pages %d greater than max pages %d",
+				ErrAllocatorOutOfSpace, currentPages, maxPages)
 		}
 
-		if requiredPages > MaxWasmPages {
-			return 0, fmt.Errorf("%w: required pages %d greater than max wasm pages %d",
-				ErrAllocatorOutOfSpace, requiredPages, MaxWasmPages)
+		if requiredPages > maxPages {
+			return 0, fmt.Errorf("%w: required pages %d greater than max pages %d",
+				ErrAllocatorOutOfSpace, requiredPages, maxPages)
 		}
 
 		// ideally we want to double our current number of pages,
 		// as long as it's less than the double absolute max we can have
-		nextPages := min(currentPages*2, MaxWasmPages)
+		nextPages := min(currentPages*2, maxPages)
 		// ... but if even more pages are required then try to allocate that many
 		nextPages = max(nextPages, requiredPages)
 
@@ -543,6 +563,8 @@ func bump(bumper *uint32, size uint32, mem runtime.Memory) (uint32, error) {
 				ErrCannotGrowLinearMemory, currentPages, nextPages)
 		}
 
+		memoryGrownPagesCounter.Add(float64(nextPages - currentPages))
+
 		pagesIncrease := (mem.Size() / PageSize) == uint64(nextPages)
 		if !pagesIncrease {
 			logger.Errorf("number of pages should have increased! previous: %d, desired: %d", currentPages, nextPages)
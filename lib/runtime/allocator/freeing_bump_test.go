@@ -197,6 +197,23 @@ func TestShouldNotAllocateIfFull(t *testing.T) {
 	require.ErrorIs(t, err, ErrCannotGrowLinearMemory)
 }
 
+func TestShouldNotGrowBeyondMaxPages(t *testing.T) {
+	mem := NewMemoryInstanceWithPages(t, 1)
+	heap := NewFreeingBumpHeapAllocatorWithMaxPages(0, 2)
+	require.Equal(t, uint32(2), heap.MaxPages())
+
+	// the memory doubles to the maximum number of pages
+	ptr1, err := heap.Allocate(mem, PageSize)
+	require.NoError(t, err)
+	require.Equal(t, uint32(HeaderSize), ptr1)
+	require.Equal(t, uint32(2), mem.pages())
+
+	ptr2, err := heap.Allocate(mem, PageSize)
+	require.Zero(t, ptr2)
+	require.ErrorIs(t, err, ErrAllocatorOutOfSpace)
+	require.Equal(t, uint32(2), mem.pages())
+}
+
 func TestShouldAllocateMaxPossibleAllocationSize(t *testing.T) {
 	mem := NewMemoryInstanceWithPages(t, 1)
 	heap := NewFreeingBumpHeapAllocator(0)
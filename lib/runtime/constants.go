@@ -58,6 +58,8 @@
 	BlockBuilderInherentExtrinsics = "BlockBuilder_inherent_extrinsics"
 	// BlockBuilderApplyExtrinsic is the runtime API call BlockBuilder_apply_extrinsic
 	BlockBuilderApplyExtrinsic = "BlockBuilder_apply_extrinsic"
+	// BlockBuilderCheckInherents is the runtime API call BlockBuilder_check_inherents
+	BlockBuilderCheckInherents = "BlockBuilder_check_inherents"
 	// BlockBuilderFinalizeBlock is the runtime API call BlockBuilder_finalize_block
 	BlockBuilderFinalizeBlock = "BlockBuilder_finalize_block"
 	// DecodeSessionKeys is the runtime API call SessionKeys_decode_session_keys
@@ -0,0 +1,45 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package runtime
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidFloatDeterminism is returned when parsing an unknown float determinism policy.
+var ErrInvalidFloatDeterminism = errors.New("invalid float determinism policy")
+
+// FloatDeterminism is the policy applied to runtimes with float instructions.
+// The bit pattern of the NaNs produced by float arithmetic is left to the host
+// by the wasm specification, so a runtime storing or hashing the bits of such a
+// NaN could produce results which differ across nodes.
+type FloatDeterminism string
+
+const (
+	// FloatDeterminismOff runs runtimes without checking their float instructions.
+	FloatDeterminismOff FloatDeterminism = "off"
+	// FloatDeterminismWarn logs a warning for runtimes with float instructions
+	// producing NaNs of unspecified bit patterns, or with vector instructions.
+	FloatDeterminismWarn FloatDeterminism = "warn"
+	// FloatDeterminismCanonicalise rewrites runtimes so the float instructions
+	// produce the canonical NaN, and rejects runtimes with vector instructions
+	// whose NaNs are not canonicalised.
+	FloatDeterminismCanonicalise FloatDeterminism = "canonicalise"
+)
+
+// ParseFloatDeterminism parses a float determinism policy from its string
+// representation. An empty string defaults to FloatDeterminismOff.
+func ParseFloatDeterminism(s string) (FloatDeterminism, error) {
+	switch FloatDeterminism(s) {
+	case "", FloatDeterminismOff:
+		return FloatDeterminismOff, nil
+	case FloatDeterminismWarn:
+		return FloatDeterminismWarn, nil
+	case FloatDeterminismCanonicalise:
+		return FloatDeterminismCanonicalise, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrInvalidFloatDeterminism, s)
+	}
+}
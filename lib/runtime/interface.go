@@ -21,6 +21,8 @@ type Instance interface {
 	Exec(function string, data []byte) ([]byte, error)
 	SetContextStorage(s Storage)
 	GetCodeHash() common.Hash
+	HeapPages() uint64
+	FloatDeterminism() FloatDeterminism
 	Version() (Version, error)
 	Metadata() (metadata []byte, err error)
 	BabeConfiguration() (*types.BabeConfiguration, error)
@@ -33,7 +35,7 @@ type Instance interface {
 	ExecuteBlock(block *types.Block) ([]byte, error)
 	DecodeSessionKeys(enc []byte) ([]byte, error)
 	PaymentQueryInfo(ext []byte) (*types.RuntimeDispatchInfo, error)
-	CheckInherents()
+	CheckInherents(block *types.Block, data *types.InherentData) (*types.CheckInherentsResult, error)
 	BabeGenerateKeyOwnershipProof(slot uint64, authorityID [32]byte) (
 		types.OpaqueKeyOwnershipProof, error)
 	BabeSubmitReportEquivocationUnsignedExtrinsic(
@@ -49,3 +51,9 @@ type Instance interface {
 		equivocationProof types.GrandpaEquivocationProof, keyOwnershipProof types.GrandpaOpaqueKeyOwnershipProof,
 	) error
 }
+
+// ReadOnlyExecutor is implemented by instances able to run calls concurrently,
+// each over its own view of the storage, without persisting storage changes.
+type ReadOnlyExecutor interface {
+	ExecReadOnly(function string, data []byte) ([]byte, error)
+}
@@ -68,9 +68,27 @@ func (_m *Instance) BabeConfiguration() (*types.BabeConfiguration, error) {
 	return r0, r1
 }
 
-// CheckInherents provides a mock function with given fields:
-func (_m *Instance) CheckInherents() {
-	_m.Called()
+// CheckInherents provides a mock function with given fields: block, data
+func (_m *Instance) CheckInherents(block *types.Block, data *types.InherentData) (*types.CheckInherentsResult, error) {
+	ret := _m.Called(block, data)
+
+	var r0 *types.CheckInherentsResult
+	if rf, ok := ret.Get(0).(func(*types.Block, *types.InherentData) *types.CheckInherentsResult); ok {
+		r0 = rf(block, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.CheckInherentsResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*types.Block, *types.InherentData) error); ok {
+		r1 = rf(block, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // DecodeSessionKeys provides a mock function with given fields: enc
@@ -0,0 +1,77 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package offchain
+
+import (
+	"time"
+
+	"github.com/ChainSafe/gossamer/internal/log"
+)
+
+// DefaultPruneInterval is the default interval at which the expired entries of the
+// offchain storages are removed.
+const DefaultPruneInterval = time.Minute
+
+var logger = log.NewFromGlobal(log.AddContext("pkg", "offchain"))
+
+// Pruner is a service removing the expired entries of offchain storages periodically.
+type Pruner struct {
+	storages []*Storage
+	interval time.Duration
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewPruner returns a pruner removing the expired entries of the given storages
+// at the given interval.
+func NewPruner(interval time.Duration, storages ...*Storage) *Pruner {
+	return &Pruner{
+		storages: storages,
+		interval: interval,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start starts removing the expired entries periodically.
+func (p *Pruner) Start() error {
+	go p.run()
+	return nil
+}
+
+// Stop stops removing the expired entries, and waits for a removal in progress.
+func (p *Pruner) Stop() error {
+	close(p.stop)
+	<-p.done
+	return nil
+}
+
+func (p *Pruner) run() {
+	defer close(p.done)
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+			p.prune()
+		}
+	}
+}
+
+func (p *Pruner) prune() {
+	for _, storage := range p.storages {
+		pruned, err := storage.PruneExpired()
+		if err != nil {
+			logger.Errorf("pruning expired offchain storage entries: %s", err)
+			continue
+		}
+		if pruned > 0 {
+			logger.Debugf("pruned %d expired offchain storage entries", pruned)
+		}
+	}
+}
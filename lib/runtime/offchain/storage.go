@@ -0,0 +1,247 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package offchain
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ChainSafe/gossamer/internal/database"
+)
+
+const (
+	// PersistentPrefix is the database prefix of the persistent offchain storage,
+	// which is shared between the forks and kept across restarts.
+	PersistentPrefix = "offlinestorage"
+	// LocalPrefix is the database prefix of the local offchain storage.
+	LocalPrefix = "offchainlocal"
+	// expiryPrefix is prepended to the prefix of a storage to get the database
+	// prefix of the expiry times of its entries.
+	expiryPrefix = "offchainexpiry:"
+)
+
+// Storage is an offchain storage kept in a database under its own prefix. The entries
+// may be given a time to live, after which they are no longer returned, and removed
+// from the database by PruneExpired. All its methods are safe for concurrent use.
+type Storage struct {
+	db             database.Database
+	valuesPrefix   []byte
+	expiriesPrefix []byte
+	// mu serialises the writes, so a compare-and-set is atomic.
+	mu  sync.Mutex
+	now func() time.Time
+}
+
+// NewStorage returns an offchain storage keeping its entries in the database under
+// the given prefix.
+func NewStorage(db database.Database, prefix string) *Storage {
+	return &Storage{
+		db:             db,
+		valuesPrefix:   []byte(prefix),
+		expiriesPrefix: []byte(expiryPrefix + prefix),
+		now:            time.Now,
+	}
+}
+
+func (s *Storage) valueKey(key []byte) []byte {
+	return bytes.Join([][]byte{s.valuesPrefix, key}, nil)
+}
+
+func (s *Storage) expiryKey(key []byte) []byte {
+	return bytes.Join([][]byte{s.expiriesPrefix, key}, nil)
+}
+
+// expired returns true if the entry with the given key has a time to live which passed.
+func (s *Storage) expired(key []byte) (bool, error) {
+	encoded, err := s.db.Get(s.expiryKey(key))
+	if errors.Is(err, database.ErrNotFound) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("getting expiry time: %w", err)
+	}
+	return !s.now().Before(decodeExpiry(encoded)), nil
+}
+
+// Get returns the value of the given key, or nil if the key is not set or expired.
+func (s *Storage) Get(key []byte) ([]byte, error) {
+	value, err := s.db.Get(s.valueKey(key))
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	expired, err := s.expired(key)
+	if err != nil {
+		return nil, err
+	}
+	if expired {
+		return nil, nil
+	}
+	return value, nil
+}
+
+// Put sets the value of the given key, without time to live.
+func (s *Storage) Put(key, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.put(key, value, 0)
+}
+
+// PutWithTTL sets the value of the given key, which expires after the time to live.
+func (s *Storage) PutWithTTL(key, value []byte, ttl time.Duration) error {
+	if ttl <= 0 {
+		return fmt.Errorf("time to live must be positive: %s", ttl)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.put(key, value, ttl)
+}
+
+// put sets the value of the given key, and its expiry time if the time to live is
+// not zero. It must be called with the lock held.
+func (s *Storage) put(key, value []byte, ttl time.Duration) error {
+	batch := s.db.NewBatch()
+	defer batch.Close()
+
+	err := batch.Put(s.valueKey(key), value)
+	if err != nil {
+		return err
+	}
+	if ttl == 0 {
+		err = batch.Del(s.expiryKey(key))
+	} else {
+		err = batch.Put(s.expiryKey(key), encodeExpiry(s.now().Add(ttl)))
+	}
+	if err != nil {
+		return err
+	}
+	return batch.Flush()
+}
+
+// Del removes the given key.
+func (s *Storage) Del(key []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.del(key)
+}
+
+// del removes the given key. It must be called with the lock held.
+func (s *Storage) del(key []byte) error {
+	batch := s.db.NewBatch()
+	defer batch.Close()
+
+	err := batch.Del(s.valueKey(key))
+	if err != nil {
+		return err
+	}
+	err = batch.Del(s.expiryKey(key))
+	if err != nil {
+		return err
+	}
+	return batch.Flush()
+}
+
+// CompareAndSet sets the value of the given key to the new value, without time to
+// live, if its current value is the old value, where a nil old value means the key
+// must not be set, and returns true if the value was set.
+func (s *Storage) CompareAndSet(key []byte, oldValue *[]byte, newValue []byte) (set bool, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, err := s.Get(key)
+	if err != nil {
+		return false, err
+	}
+
+	switch {
+	case oldValue == nil && current != nil:
+		return false, nil
+	case oldValue != nil && (current == nil || !bytes.Equal(current, *oldValue)):
+		return false, nil
+	}
+
+	err = s.put(key, newValue, 0)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Iterate calls f with the keys and values of the entries with keys starting with the
+// given prefix, in ascending key order, until f returns false. The expired entries
+// are skipped. The key and value passed to f are only valid until f returns.
+func (s *Storage) Iterate(prefix []byte, f func(key, value []byte) bool) error {
+	iterator, err := s.db.NewPrefixIterator(s.valueKey(prefix))
+	if err != nil {
+		return fmt.Errorf("creating iterator: %w", err)
+	}
+	defer iterator.Release()
+
+	for valid := iterator.First(); valid; valid = iterator.Next() {
+		key := iterator.Key()[len(s.valuesPrefix):]
+		expired, err := s.expired(key)
+		if err != nil {
+			return err
+		}
+		if expired {
+			continue
+		}
+		if !f(key, iterator.Value()) {
+			break
+		}
+	}
+	return nil
+}
+
+// PruneExpired removes the entries whose time to live passed from the database,
+// and returns the number of entries removed.
+func (s *Storage) PruneExpired() (pruned int, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	iterator, err := s.db.NewPrefixIterator(s.expiriesPrefix)
+	if err != nil {
+		return 0, fmt.Errorf("creating iterator: %w", err)
+	}
+	defer iterator.Release()
+
+	now := s.now()
+	var expiredKeys [][]byte
+	for valid := iterator.First(); valid; valid = iterator.Next() {
+		if now.Before(decodeExpiry(iterator.Value())) {
+			continue
+		}
+		key := bytes.Clone(iterator.Key()[len(s.expiriesPrefix):])
+		expiredKeys = append(expiredKeys, key)
+	}
+
+	for _, key := range expiredKeys {
+		err = s.del(key)
+		if err != nil {
+			return pruned, fmt.Errorf("removing expired key 0x%x: %w", key, err)
+		}
+		pruned++
+	}
+	return pruned, nil
+}
+
+func encodeExpiry(expiry time.Time) []byte {
+	encoded := make([]byte, 8)
+	binary.BigEndian.PutUint64(encoded, uint64(expiry.UnixNano())) //nolint:gosec
+	return encoded
+}
+
+func decodeExpiry(encoded []byte) time.Time {
+	if len(encoded) != 8 {
+		// a corrupted expiry time expires the entry
+		return time.Time{}
+	}
+	return time.Unix(0, int64(binary.BigEndian.Uint64(encoded))) //nolint:gosec
+}
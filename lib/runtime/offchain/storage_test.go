@@ -0,0 +1,217 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package offchain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStorage(t *testing.T, db database.Database, prefix string) (storage *Storage, now *time.Time) {
+	t.Helper()
+
+	storage = NewStorage(db, prefix)
+	now = new(time.Time)
+	*now = time.Unix(1000, 0)
+	storage.now = func() time.Time { return *now }
+	return storage, now
+}
+
+func newTestDB(t *testing.T) database.Database {
+	t.Helper()
+
+	db, err := database.LoadDatabase(t.TempDir(), false)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		err := db.Close()
+		require.NoError(t, err)
+	})
+	return db
+}
+
+func TestStorage_PutGetDel(t *testing.T) {
+	t.Parallel()
+
+	storage, _ := newTestStorage(t, newTestDB(t), LocalPrefix)
+
+	value, err := storage.Get([]byte("key"))
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	err = storage.Put([]byte("key"), []byte("value"))
+	require.NoError(t, err)
+	value, err = storage.Get([]byte("key"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+
+	err = storage.Del([]byte("key"))
+	require.NoError(t, err)
+	value, err = storage.Get([]byte("key"))
+	require.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+func TestStorage_prefixes(t *testing.T) {
+	t.Parallel()
+
+	db := newTestDB(t)
+	local, _ := newTestStorage(t, db, LocalPrefix)
+	persistent, _ := newTestStorage(t, db, PersistentPrefix)
+
+	err := local.Put([]byte("key"), []byte("local"))
+	require.NoError(t, err)
+	err = persistent.Put([]byte("key"), []byte("persistent"))
+	require.NoError(t, err)
+
+	value, err := local.Get([]byte("key"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("local"), value)
+	value, err = persistent.Get([]byte("key"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("persistent"), value)
+
+	// the persistent storage keeps the keys of the former database table
+	value, err = database.NewTable(db, PersistentPrefix).Get([]byte("key"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("persistent"), value)
+}
+
+func TestStorage_PutWithTTL(t *testing.T) {
+	t.Parallel()
+
+	storage, now := newTestStorage(t, newTestDB(t), LocalPrefix)
+
+	err := storage.PutWithTTL([]byte("key"), []byte("value"), 0)
+	require.EqualError(t, err, "time to live must be positive: 0s")
+
+	err = storage.PutWithTTL([]byte("key"), []byte("value"), time.Minute)
+	require.NoError(t, err)
+
+	*now = now.Add(time.Minute - time.Nanosecond)
+	value, err := storage.Get([]byte("key"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+
+	*now = now.Add(time.Nanosecond)
+	value, err = storage.Get([]byte("key"))
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	// setting the key again without time to live clears the expiry time
+	err = storage.Put([]byte("key"), []byte("other"))
+	require.NoError(t, err)
+	value, err = storage.Get([]byte("key"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("other"), value)
+}
+
+func TestStorage_CompareAndSet(t *testing.T) {
+	t.Parallel()
+
+	storage, now := newTestStorage(t, newTestDB(t), PersistentPrefix)
+	old := []byte("old")
+
+	set, err := storage.CompareAndSet([]byte("key"), &old, []byte("new"))
+	require.NoError(t, err)
+	assert.False(t, set)
+
+	set, err = storage.CompareAndSet([]byte("key"), nil, old)
+	require.NoError(t, err)
+	assert.True(t, set)
+
+	set, err = storage.CompareAndSet([]byte("key"), nil, []byte("new"))
+	require.NoError(t, err)
+	assert.False(t, set)
+
+	other := []byte("other")
+	set, err = storage.CompareAndSet([]byte("key"), &other, []byte("new"))
+	require.NoError(t, err)
+	assert.False(t, set)
+
+	set, err = storage.CompareAndSet([]byte("key"), &old, []byte("new"))
+	require.NoError(t, err)
+	assert.True(t, set)
+
+	value, err := storage.Get([]byte("key"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("new"), value)
+
+	// an expired key is not set
+	err = storage.PutWithTTL([]byte("expiring"), old, time.Second)
+	require.NoError(t, err)
+	*now = now.Add(time.Second)
+	set, err = storage.CompareAndSet([]byte("expiring"), nil, []byte("new"))
+	require.NoError(t, err)
+	assert.True(t, set)
+}
+
+func TestStorage_Iterate(t *testing.T) {
+	t.Parallel()
+
+	db := newTestDB(t)
+	storage, now := newTestStorage(t, db, LocalPrefix)
+	other, _ := newTestStorage(t, db, PersistentPrefix)
+
+	require.NoError(t, storage.Put([]byte("a1"), []byte("1")))
+	require.NoError(t, storage.PutWithTTL([]byte("a2"), []byte("2"), time.Second))
+	require.NoError(t, storage.Put([]byte("a3"), []byte("3")))
+	require.NoError(t, storage.Put([]byte("b1"), []byte("4")))
+	require.NoError(t, other.Put([]byte("a4"), []byte("5")))
+	*now = now.Add(time.Second)
+
+	entries := map[string]string{}
+	err := storage.Iterate([]byte("a"), func(key, value []byte) bool {
+		entries[string(key)] = string(value)
+		return true
+	})
+	require.NoError(t, err)
+	expected := map[string]string{"a1": "1", "a3": "3"}
+	assert.Equal(t, expected, entries)
+
+	var keys []string
+	err = storage.Iterate(nil, func(key, _ []byte) bool {
+		keys = append(keys, string(key))
+		return len(keys) < 2
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a1", "a3"}, keys)
+}
+
+func TestStorage_PruneExpired(t *testing.T) {
+	t.Parallel()
+
+	db := newTestDB(t)
+	storage, now := newTestStorage(t, db, LocalPrefix)
+
+	require.NoError(t, storage.PutWithTTL([]byte("short"), []byte("1"), time.Second))
+	require.NoError(t, storage.PutWithTTL([]byte("long"), []byte("2"), time.Hour))
+	require.NoError(t, storage.Put([]byte("forever"), []byte("3")))
+
+	pruned, err := storage.PruneExpired()
+	require.NoError(t, err)
+	assert.Zero(t, pruned)
+
+	*now = now.Add(time.Minute)
+	pruned, err = storage.PruneExpired()
+	require.NoError(t, err)
+	assert.Equal(t, 1, pruned)
+
+	has, err := db.Has([]byte(LocalPrefix + "short"))
+	require.NoError(t, err)
+	assert.False(t, has)
+	has, err = db.Has([]byte(expiryPrefix + LocalPrefix + "short"))
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	value, err := storage.Get([]byte("long"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("2"), value)
+	value, err = storage.Get([]byte("forever"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("3"), value)
+}
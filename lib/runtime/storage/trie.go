@@ -14,6 +14,7 @@
 
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/pkg/trie"
+	"github.com/ChainSafe/gossamer/pkg/trie/inmemory"
 	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
 )
@@ -26,6 +27,65 @@ type TrieState struct {
 	mtx          sync.RWMutex
 	state        trie.Trie
 	transactions *list.List
+	recorded     *Changes
+
+	// readsMtx guards reads, which are recorded by Get under the read lock of mtx.
+	readsMtx sync.Mutex
+	reads    map[string]struct{}
+}
+
+// Changes are the storage changes applied to the state of a TrieState, recorded
+// for the block import hooks.
+type Changes struct {
+	diffs []*storageDiff
+}
+
+// Change is a change of the value of a storage key.
+type Change struct {
+	// ChildKey is the key of the child trie of the key, and is nil for the main trie.
+	ChildKey []byte
+	Key      []byte
+	// Value is the new value of the key, and is nil if the key is deleted. A deleted
+	// child trie is a deleted key of the main trie.
+	Value []byte
+}
+
+// Diff returns the net changes of the storage, with the changes of the main trie
+// first followed by the changes of the child tries, ordered by child key and key.
+func (c *Changes) Diff() []Change {
+	type changeKey struct {
+		child, key string
+	}
+	latest := make(map[changeKey]Change)
+	for _, diff := range c.diffs {
+		// the changes are ordered as applyToTrie applies them
+		for key, value := range diff.upserts {
+			latest[changeKey{key: key}] = Change{Key: []byte(key), Value: value}
+		}
+		for child, childDiff := range diff.childChangeSet {
+			for key, value := range childDiff.upserts {
+				latest[changeKey{child: child, key: key}] = Change{
+					ChildKey: []byte(child), Key: []byte(key), Value: value,
+				}
+			}
+			for key := range childDiff.deletes {
+				latest[changeKey{child: child, key: key}] = Change{ChildKey: []byte(child), Key: []byte(key)}
+			}
+		}
+		for key := range diff.deletes {
+			latest[changeKey{key: key}] = Change{Key: []byte(key)}
+		}
+	}
+
+	changes := maps.Values(latest)
+	slices.SortFunc(changes, func(a, b Change) int {
+		order := bytes.Compare(a.ChildKey, b.ChildKey)
+		if order != 0 {
+			return order
+		}
+		return bytes.Compare(a.Key, b.Key)
+	})
+	return changes
 }
 
 // NewTrieState initialises and returns a new TrieState instance
@@ -93,9 +153,57 @@ func (t *TrieState) CommitTransaction() {
 		// This is the last transaction so we apply all the changes to our state
 		tx := t.transactions.Remove(t.transactions.Back()).(*storageDiff)
 		tx.applyToTrie(t.state)
+		if t.recorded != nil {
+			t.recorded.diffs = append(t.recorded.diffs, tx)
+		}
 	}
 }
 
+// RecordChanges starts recording the changes applied to the state when the
+// outermost transactions are committed, which are returned by Changes.
+func (t *TrieState) RecordChanges() {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	t.recorded = &Changes{}
+}
+
+// Changes returns the changes applied to the state since RecordChanges was
+// called, or nil if the changes are not recorded.
+func (t *TrieState) Changes() *Changes {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+
+	return t.recorded
+}
+
+// RecordReads starts recording the keys read from the state with Get and Has,
+// which are returned by Reads.
+func (t *TrieState) RecordReads() {
+	t.readsMtx.Lock()
+	defer t.readsMtx.Unlock()
+
+	t.reads = make(map[string]struct{})
+}
+
+// Reads returns the keys read since RecordReads was called in lexicographical
+// order, or nil if the reads are not recorded.
+func (t *TrieState) Reads() [][]byte {
+	t.readsMtx.Lock()
+	defer t.readsMtx.Unlock()
+
+	if t.reads == nil {
+		return nil
+	}
+
+	keys := make([][]byte, 0, len(t.reads))
+	for key := range t.reads {
+		keys = append(keys, []byte(key))
+	}
+	slices.SortFunc(keys, bytes.Compare)
+	return keys
+}
+
 // Trie returns the TrieState's underlying trie
 func (t *TrieState) Trie() trie.Trie {
 	t.mtx.RLock()
@@ -104,6 +212,30 @@ func (t *TrieState) Trie() trie.Trie {
 	return t.state
 }
 
+// ErrOverlayUnsupported is returned when creating an overlay of a TrieState whose
+// trie cannot be snapshotted.
+var ErrOverlayUnsupported = errors.New("overlay unsupported for trie")
+
+// Overlay returns a new TrieState starting from the current state, including the
+// changes of the running transaction. Changes made to the overlay are never applied
+// to this TrieState. The overlay works on a copy-on-write snapshot of the trie so it
+// can be committed and rooted independently, so only in-memory tries are supported.
+func (t *TrieState) Overlay() (*TrieState, error) {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+
+	inMemoryTrie, ok := t.state.(*inmemory.InMemoryTrie)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", ErrOverlayUnsupported, t.state)
+	}
+
+	overlay := NewTrieState(inMemoryTrie.Snapshot())
+	if currentTx := t.getCurrentTransaction(); currentTx != nil {
+		overlay.transactions.PushBack(currentTx.snapshot())
+	}
+	return overlay, nil
+}
+
 // Put puts a key-value pair in the trie
 func (t *TrieState) Put(key, value []byte) (err error) {
 	t.mtx.Lock()
@@ -124,6 +256,12 @@ func (t *TrieState) Get(key []byte) []byte {
 	t.mtx.RLock()
 	defer t.mtx.RUnlock()
 
+	t.readsMtx.Lock()
+	if t.reads != nil {
+		t.reads[string(key)] = struct{}{}
+	}
+	t.readsMtx.Unlock()
+
 	// If we find the key or it is deleted return from latest transaction
 	if currentTx := t.getCurrentTransaction(); currentTx != nil {
 		val, deleted := currentTx.get(string(key))
@@ -745,3 +745,108 @@ func BenchmarkNextKey(b *testing.B) {
 		}
 	}
 }
+
+func TestTrieState_Overlay(t *testing.T) {
+	t.Parallel()
+
+	ts := NewTrieState(inmemory_trie.NewEmptyTrie())
+	require.NoError(t, ts.Put([]byte("committed"), []byte("a")))
+
+	ts.StartTransaction()
+	require.NoError(t, ts.Put([]byte("pending"), []byte("b")))
+
+	overlay, err := ts.Overlay()
+	require.NoError(t, err)
+	require.Equal(t, []byte("a"), overlay.Get([]byte("committed")))
+	require.Equal(t, []byte("b"), overlay.Get([]byte("pending")))
+
+	require.NoError(t, overlay.Put([]byte("committed"), []byte("c")))
+	require.NoError(t, overlay.Delete([]byte("pending")))
+	_, err = overlay.Root()
+	require.NoError(t, err)
+
+	require.Equal(t, []byte("c"), overlay.Trie().Get([]byte("committed")))
+	require.Equal(t, []byte("a"), ts.Get([]byte("committed")))
+	require.Equal(t, []byte("b"), ts.Get([]byte("pending")))
+
+	ts.RollbackTransaction()
+	require.Nil(t, ts.Get([]byte("pending")))
+	require.Equal(t, []byte("a"), ts.Trie().Get([]byte("committed")))
+
+	// tries which cannot be snapshotted would share their state with the overlay
+	_, err = NewTrieState(unsnapshottableTrie{}).Overlay()
+	require.ErrorIs(t, err, ErrOverlayUnsupported)
+}
+
+type unsnapshottableTrie struct {
+	trie.Trie
+}
+
+func TestTrieState_RecordChanges(t *testing.T) {
+	t.Parallel()
+
+	ts := NewTrieState(inmemory_trie.NewEmptyTrie())
+	require.NoError(t, ts.Put([]byte("unrecorded"), []byte("a")))
+	require.Nil(t, ts.Changes())
+	ts.RecordChanges()
+
+	ts.StartTransaction()
+	require.NoError(t, ts.Put([]byte("inserted"), []byte("b")))
+	ts.CommitTransaction()
+
+	ts.StartTransaction()
+	require.NoError(t, ts.Put([]byte("rolled back"), []byte("c")))
+	ts.RollbackTransaction()
+
+	require.Equal(t, []Change{{Key: []byte("inserted"), Value: []byte("b")}}, ts.Changes().Diff())
+}
+
+func TestChanges_Diff(t *testing.T) {
+	t.Parallel()
+
+	ts := NewTrieState(inmemory_trie.NewEmptyTrie())
+	require.NoError(t, ts.Put([]byte("deleted"), []byte("a")))
+	require.NoError(t, ts.SetChildStorage([]byte("child"), []byte("cleared"), []byte("b")))
+	ts.RecordChanges()
+
+	ts.StartTransaction()
+	require.NoError(t, ts.Put([]byte("updated"), []byte("c")))
+	require.NoError(t, ts.Put([]byte("reinserted"), []byte("d")))
+	require.NoError(t, ts.SetChildStorage([]byte("child"), []byte("key"), []byte("e")))
+	ts.CommitTransaction()
+
+	ts.StartTransaction()
+	require.NoError(t, ts.Put([]byte("updated"), []byte("f")))
+	require.NoError(t, ts.Delete([]byte("reinserted")))
+	require.NoError(t, ts.Delete([]byte("deleted")))
+	require.NoError(t, ts.ClearChildStorage([]byte("child"), []byte("cleared")))
+	ts.CommitTransaction()
+
+	expected := []Change{
+		{Key: []byte("deleted")},
+		{Key: []byte("reinserted")},
+		{Key: []byte("updated"), Value: []byte("f")},
+		{ChildKey: []byte("child"), Key: []byte("cleared")},
+		{ChildKey: []byte("child"), Key: []byte("key"), Value: []byte("e")},
+	}
+	require.Equal(t, expected, ts.Changes().Diff())
+}
+
+func TestTrieState_RecordReads(t *testing.T) {
+	t.Parallel()
+
+	ts := NewTrieState(inmemory_trie.NewEmptyTrie())
+	require.NoError(t, ts.Put([]byte("b"), []byte("1")))
+	require.Nil(t, ts.Get([]byte("unrecorded")))
+	require.Nil(t, ts.Reads())
+
+	ts.RecordReads()
+	require.Empty(t, ts.Reads())
+
+	require.Equal(t, []byte("1"), ts.Get([]byte("b")))
+	require.False(t, ts.Has([]byte("a")))
+	require.Nil(t, ts.Get([]byte("b-missing")))
+	require.Nil(t, ts.Get([]byte("a")))
+
+	require.Equal(t, [][]byte{[]byte("a"), []byte("b"), []byte("b-missing")}, ts.Reads())
+}
@@ -4,6 +4,12 @@
 package runtime
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ChainSafe/gossamer/internal/database"
 	"github.com/ChainSafe/gossamer/lib/crypto"
 	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/lib/runtime/offchain"
@@ -45,6 +51,109 @@ func (n *NodeStorage) GetPersistent(k []byte) ([]byte, error) {
 	return n.PersistentStorage.Get(k)
 }
 
+// compareAndSetter is implemented by the storages setting values atomically,
+// such as offchain.Storage.
+type compareAndSetter interface {
+	CompareAndSet(key []byte, oldValue *[]byte, newValue []byte) (set bool, err error)
+}
+
+// CompareAndSet sets the value of the key in the node storage of the given kind to the
+// new value if its current value is the old value, where a nil old value means the key
+// must not be set, and returns true if the value was set. It is atomic if the storage
+// implements CompareAndSet, as offchain.Storage does.
+func (n *NodeStorage) CompareAndSet(kind NodeStorageType, key []byte, oldValue *[]byte,
+	newValue []byte) (set bool, err error) {
+	var storage BasicStorage
+	switch kind {
+	case NodeStorageTypePersistent:
+		storage = n.PersistentStorage
+	case NodeStorageTypeLocal:
+		storage = n.LocalStorage
+	default:
+		return false, fmt.Errorf("unknown node storage type: %d", kind)
+	}
+
+	if casStorage, ok := storage.(compareAndSetter); ok {
+		return casStorage.CompareAndSet(key, oldValue, newValue)
+	}
+
+	current, err := storage.Get(key)
+	if errors.Is(err, database.ErrNotFound) {
+		current = nil
+	} else if err != nil {
+		return false, err
+	}
+	switch {
+	case oldValue == nil && current != nil:
+		return false, nil
+	case oldValue != nil && (current == nil || !bytes.Equal(current, *oldValue)):
+		return false, nil
+	}
+
+	err = storage.Put(key, newValue)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Overlay returns a node storage buffering the writes in memory on top of this node
+// storage, so they are seen by the reads of the returned node storage only and are
+// never persisted.
+func (n *NodeStorage) Overlay() NodeStorage {
+	return NodeStorage{
+		LocalStorage:      newStorageOverlay(n.LocalStorage),
+		PersistentStorage: newStorageOverlay(n.PersistentStorage),
+		BaseDB:            newStorageOverlay(n.BaseDB),
+	}
+}
+
+// storageOverlay is a basic storage keeping its writes in memory, and reading
+// the keys it did not write from its base storage.
+type storageOverlay struct {
+	base BasicStorage
+	mu   sync.Mutex
+	// changes maps the keys written to their values, nil for deleted keys.
+	changes map[string][]byte
+}
+
+func newStorageOverlay(base BasicStorage) BasicStorage {
+	if base == nil {
+		return nil
+	}
+	return &storageOverlay{
+		base:    base,
+		changes: make(map[string][]byte),
+	}
+}
+
+func (s *storageOverlay) Put(key []byte, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.changes[string(key)] = append([]byte{}, value...)
+	return nil
+}
+
+func (s *storageOverlay) Get(key []byte) ([]byte, error) {
+	s.mu.Lock()
+	value, changed := s.changes[string(key)]
+	s.mu.Unlock()
+	if !changed {
+		return s.base.Get(key)
+	}
+	if value == nil {
+		return nil, database.ErrNotFound
+	}
+	return append([]byte{}, value...), nil
+}
+
+func (s *storageOverlay) Del(key []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.changes[string(key)] = nil
+	return nil
+}
+
 type Allocator interface {
 	Allocate(mem Memory, size uint32) (uint32, error)
 	Deallocate(mem Memory, ptr uint32) error
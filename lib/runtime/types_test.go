@@ -8,6 +8,7 @@
 	"testing"
 	"time"
 
+	"github.com/ChainSafe/gossamer/internal/database"
 	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto"
@@ -135,3 +136,45 @@ func TestAllCryptoTypeSignature(t *testing.T) {
 
 	require.True(t, signVerify.Finish())
 }
+
+func TestNodeStorage_Overlay(t *testing.T) {
+	nodeStorage := NodeStorage{
+		LocalStorage:      NewInMemoryDB(t),
+		PersistentStorage: NewInMemoryDB(t),
+	}
+	err := nodeStorage.SetPersistent([]byte("kept"), []byte{1})
+	require.NoError(t, err)
+	err = nodeStorage.SetPersistent([]byte("deleted"), []byte{2})
+	require.NoError(t, err)
+
+	overlay := nodeStorage.Overlay()
+	require.Nil(t, overlay.BaseDB)
+
+	err = overlay.SetLocal([]byte("added"), []byte{3})
+	require.NoError(t, err)
+	err = overlay.PersistentStorage.Del([]byte("deleted"))
+	require.NoError(t, err)
+	set, err := overlay.CompareAndSet(NodeStorageTypePersistent, []byte("kept"), &[]byte{1}, []byte{4})
+	require.NoError(t, err)
+	require.True(t, set)
+
+	// the overlay reads its own writes
+	value, err := overlay.GetLocal([]byte("added"))
+	require.NoError(t, err)
+	require.Equal(t, []byte{3}, value)
+	value, err = overlay.GetPersistent([]byte("kept"))
+	require.NoError(t, err)
+	require.Equal(t, []byte{4}, value)
+	_, err = overlay.GetPersistent([]byte("deleted"))
+	require.ErrorIs(t, err, database.ErrNotFound)
+
+	// the node storage is left untouched
+	_, err = nodeStorage.GetLocal([]byte("added"))
+	require.ErrorIs(t, err, database.ErrNotFound)
+	value, err = nodeStorage.GetPersistent([]byte("kept"))
+	require.NoError(t, err)
+	require.Equal(t, []byte{1}, value)
+	value, err = nodeStorage.GetPersistent([]byte("deleted"))
+	require.NoError(t, err)
+	require.Equal(t, []byte{2}, value)
+}
@@ -0,0 +1,461 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package wazero_runtime
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+)
+
+var (
+	errInvalidWasm        = errors.New("invalid wasm code")
+	errUnknownOpcode      = errors.New("unknown opcode")
+	errVectorInstructions = errors.New("runtime has vector instructions")
+)
+
+var (
+	wasmHeader = []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+	// canonicalNaN32 and canonicalNaN64 are the little endian encodings of the
+	// positive quiet NaNs with an empty payload.
+	canonicalNaN32 = []byte{0x00, 0x00, 0xc0, 0x7f}
+	canonicalNaN64 = []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xf8, 0x7f}
+)
+
+const (
+	sectionType     = 1
+	sectionFunction = 3
+	sectionCode     = 10
+
+	valueTypeF32 byte = 0x7d
+	valueTypeF64 byte = 0x7c
+)
+
+// floatReport counts the float instructions of a runtime which can produce
+// results differing across hosts.
+type floatReport struct {
+	// nanProducing is the number of float instructions producing NaNs of
+	// unspecified bit patterns.
+	nanProducing uint
+	// vectorFunctions is the number of functions with vector instructions.
+	vectorFunctions uint
+}
+
+func (r floatReport) deterministic() bool {
+	return r.nanProducing == 0 && r.vectorFunctions == 0
+}
+
+// applyFloatDeterminism applies the float determinism policy to the decompressed
+// runtime code, returning the code to instantiate.
+func applyFloatDeterminism(code []byte, policy runtime.FloatDeterminism, codeHash common.Hash) ([]byte, error) {
+	switch policy {
+	case runtime.FloatDeterminismWarn:
+		_, report, err := rewriteFloats(code, false)
+		if err != nil {
+			return nil, fmt.Errorf("checking float instructions: %w", err)
+		}
+		if !report.deterministic() {
+			logger.Warnf("runtime with code hash %s may not be deterministic across hosts: "+
+				"%d float instructions producing NaNs and %d functions with vector instructions",
+				codeHash, report.nanProducing, report.vectorFunctions)
+		}
+		return code, nil
+	case runtime.FloatDeterminismCanonicalise:
+		rewritten, report, err := rewriteFloats(code, true)
+		if err != nil {
+			return nil, fmt.Errorf("canonicalising float instructions: %w", err)
+		}
+		if report.nanProducing > 0 {
+			logger.Infof("canonicalised NaNs of %d float instructions of runtime with code hash %s",
+				report.nanProducing, codeHash)
+		}
+		return rewritten, nil
+	default:
+		return code, nil
+	}
+}
+
+// rewriteFloats counts the float instructions of the code which can produce results
+// differing across hosts. If canonicalise is true, it returns the code with each
+// instruction producing a NaN followed by instructions replacing the NaN by the
+// canonical NaN, and fails if a function has vector instructions. Instructions
+// such as neg, abs and copysign only move the sign bit of their operand, so their
+// results are deterministic once the NaNs produced are canonical.
+func rewriteFloats(code []byte, canonicalise bool) (rewritten []byte, report floatReport, err error) {
+	if !bytes.HasPrefix(code, wasmHeader) {
+		return nil, report, fmt.Errorf("%w: missing header", errInvalidWasm)
+	}
+
+	var paramCounts, funcTypes []uint64
+
+	rewritten = make([]byte, 0, len(code))
+	rewritten = append(rewritten, wasmHeader...)
+	r := &wasmReader{code: code, offset: len(wasmHeader)}
+	for !r.done() {
+		id, err := r.byte()
+		if err != nil {
+			return nil, report, err
+		}
+		size, err := r.u32()
+		if err != nil {
+			return nil, report, err
+		}
+		contents, err := r.bytes(size)
+		if err != nil {
+			return nil, report, err
+		}
+
+		section := &wasmReader{code: contents}
+		switch id {
+		case sectionType:
+			paramCounts, err = readParamCounts(section)
+		case sectionFunction:
+			funcTypes, err = readVectorOfU32(section)
+		case sectionCode:
+			contents, err = rewriteCodeSection(section, paramCounts, funcTypes, canonicalise, &report)
+		}
+		if err != nil {
+			return nil, report, fmt.Errorf("section %d: %w", id, err)
+		}
+
+		rewritten = append(rewritten, id)
+		rewritten = binary.AppendUvarint(rewritten, uint64(len(contents)))
+		rewritten = append(rewritten, contents...)
+	}
+
+	return rewritten, report, nil
+}
+
+func readParamCounts(r *wasmReader) (paramCounts []uint64, err error) {
+	count, err := r.u32()
+	if err != nil {
+		return nil, err
+	}
+	paramCounts = make([]uint64, count)
+	for i := range paramCounts {
+		form, err := r.byte()
+		if err != nil {
+			return nil, err
+		}
+		if form != 0x60 {
+			return nil, fmt.Errorf("%w: function type form 0x%x", errInvalidWasm, form)
+		}
+		params, err := r.u32()
+		if err != nil {
+			return nil, err
+		}
+		_, err = r.bytes(params)
+		if err != nil {
+			return nil, err
+		}
+		results, err := r.u32()
+		if err != nil {
+			return nil, err
+		}
+		_, err = r.bytes(results)
+		if err != nil {
+			return nil, err
+		}
+		paramCounts[i] = params
+	}
+	return paramCounts, nil
+}
+
+func readVectorOfU32(r *wasmReader) (values []uint64, err error) {
+	count, err := r.u32()
+	if err != nil {
+		return nil, err
+	}
+	values = make([]uint64, count)
+	for i := range values {
+		values[i], err = r.u32()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+func rewriteCodeSection(r *wasmReader, paramCounts, funcTypes []uint64, canonicalise bool,
+	report *floatReport) (contents []byte, err error) {
+	count, err := r.u32()
+	if err != nil {
+		return nil, err
+	}
+	if count != uint64(len(funcTypes)) {
+		return nil, fmt.Errorf("%w: %d function bodies for %d functions", errInvalidWasm, count, len(funcTypes))
+	}
+
+	contents = binary.AppendUvarint(nil, count)
+	for i := uint64(0); i < count; i++ {
+		size, err := r.u32()
+		if err != nil {
+			return nil, err
+		}
+		body, err := r.bytes(size)
+		if err != nil {
+			return nil, err
+		}
+
+		typeIndex := funcTypes[i]
+		if typeIndex >= uint64(len(paramCounts)) {
+			return nil, fmt.Errorf("%w: function %d has type %d", errInvalidWasm, i, typeIndex)
+		}
+
+		body, err = rewriteFunction(body, paramCounts[typeIndex], canonicalise, report)
+		if err != nil {
+			return nil, fmt.Errorf("function %d: %w", i, err)
+		}
+		contents = binary.AppendUvarint(contents, uint64(len(body)))
+		contents = append(contents, body...)
+	}
+	return contents, nil
+}
+
+// rewriteFunction scans the instructions of the function body and, if canonicalise
+// is true, rewrites them using a scratch local of each float type.
+func rewriteFunction(body []byte, params uint64, canonicalise bool, report *floatReport) ([]byte, error) {
+	r := &wasmReader{code: body}
+	declarations, err := r.u32()
+	if err != nil {
+		return nil, err
+	}
+	locals := params
+	for i := uint64(0); i < declarations; i++ {
+		count, err := r.u32()
+		if err != nil {
+			return nil, err
+		}
+		_, err = r.byte()
+		if err != nil {
+			return nil, err
+		}
+		locals += count
+	}
+	localsEnd := r.offset
+
+	// the scratch locals are declared after the existing locals
+	scratch32, scratch64 := locals, locals+1
+	var uses32, uses64 bool
+	instructions := make([]byte, 0, len(body)-localsEnd)
+	for !r.done() {
+		start := r.offset
+		opcode, err := r.byte()
+		if err != nil {
+			return nil, err
+		}
+
+		if opcode == 0xfd {
+			// vector instructions are not decoded, so the rest of the body is skipped
+			report.vectorFunctions++
+			if canonicalise {
+				return nil, errVectorInstructions
+			}
+			return body, nil
+		}
+
+		err = r.skipImmediates(opcode)
+		if err != nil {
+			return nil, err
+		}
+		instructions = append(instructions, body[start:r.offset]...)
+
+		switch {
+		case producesNaN32(opcode):
+			report.nanProducing++
+			uses32 = true
+			instructions = appendCanonicalise(instructions, scratch32, 0x43, canonicalNaN32, 0x5b)
+		case producesNaN64(opcode):
+			report.nanProducing++
+			uses64 = true
+			instructions = appendCanonicalise(instructions, scratch64, 0x44, canonicalNaN64, 0x61)
+		}
+	}
+
+	if !canonicalise || (!uses32 && !uses64) {
+		return body, nil
+	}
+
+	rewritten := binary.AppendUvarint(nil, declarations+2)
+	rewritten = append(rewritten, body[binaryUvarintLen(body):localsEnd]...)
+	rewritten = append(rewritten, 0x01, valueTypeF32, 0x01, valueTypeF64)
+	return append(rewritten, instructions...), nil
+}
+
+// appendCanonicalise appends the instructions replacing a NaN on top of the stack
+// by the canonical NaN, using the scratch local to compare the value with itself.
+func appendCanonicalise(instructions []byte, scratch uint64, constOpcode byte, nan []byte, eqOpcode byte) []byte {
+	instructions = append(instructions, 0x22) // local.tee
+	instructions = binary.AppendUvarint(instructions, scratch)
+	instructions = append(instructions, constOpcode)
+	instructions = append(instructions, nan...)
+	for i := 0; i < 2; i++ {
+		instructions = append(instructions, 0x20) // local.get
+		instructions = binary.AppendUvarint(instructions, scratch)
+	}
+	return append(instructions, eqOpcode, 0x1b) // select
+}
+
+// producesNaN32 returns true for the f32 arithmetic instructions, from ceil to max,
+// and f32.demote_f64.
+func producesNaN32(opcode byte) bool {
+	return (opcode >= 0x8d && opcode <= 0x97) || opcode == 0xb6
+}
+
+// producesNaN64 returns true for the f64 arithmetic instructions, from ceil to max,
+// and f64.promote_f32.
+func producesNaN64(opcode byte) bool {
+	return (opcode >= 0x9b && opcode <= 0xa5) || opcode == 0xbb
+}
+
+func binaryUvarintLen(b []byte) int {
+	_, n := binary.Uvarint(b)
+	return n
+}
+
+type wasmReader struct {
+	code   []byte
+	offset int
+}
+
+func (r *wasmReader) done() bool {
+	return r.offset >= len(r.code)
+}
+
+func (r *wasmReader) byte() (byte, error) {
+	if r.done() {
+		return 0, fmt.Errorf("%w: unexpected end", errInvalidWasm)
+	}
+	b := r.code[r.offset]
+	r.offset++
+	return b, nil
+}
+
+func (r *wasmReader) bytes(n uint64) ([]byte, error) {
+	if n > uint64(len(r.code)-r.offset) {
+		return nil, fmt.Errorf("%w: unexpected end", errInvalidWasm)
+	}
+	b := r.code[r.offset : r.offset+int(n)]
+	r.offset += int(n)
+	return b, nil
+}
+
+func (r *wasmReader) u32() (uint64, error) {
+	value, n := binary.Uvarint(r.code[r.offset:])
+	if n <= 0 || value > 1<<32-1 {
+		return 0, fmt.Errorf("%w: malformed integer", errInvalidWasm)
+	}
+	r.offset += n
+	return value, nil
+}
+
+// skipLEB skips a signed or unsigned LEB128 encoded integer.
+func (r *wasmReader) skipLEB() error {
+	for {
+		b, err := r.byte()
+		if err != nil {
+			return err
+		}
+		if b&0x80 == 0 {
+			return nil
+		}
+	}
+}
+
+func (r *wasmReader) skipLEBs(n int) error {
+	for i := 0; i < n; i++ {
+		err := r.skipLEB()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// skipImmediates skips the immediates of the instruction with the given opcode,
+// for the instructions of wasm 2.0 other than the vector instructions.
+func (r *wasmReader) skipImmediates(opcode byte) error {
+	switch {
+	case opcode == 0x02 || opcode == 0x03 || opcode == 0x04: // block, loop, if
+		blockType, err := r.byte()
+		if err != nil {
+			return err
+		}
+		if blockType&0x80 != 0 {
+			// type index encoded as a signed LEB128 integer
+			return r.skipLEB()
+		}
+		return nil
+	case opcode == 0x0c || opcode == 0x0d || opcode == 0x10 || opcode == 0x12: // br, br_if, call, return_call
+		return r.skipLEB()
+	case opcode == 0x0e: // br_table
+		count, err := r.u32()
+		if err != nil {
+			return err
+		}
+		return r.skipLEBs(int(count) + 1)
+	case opcode == 0x11 || opcode == 0x13: // call_indirect, return_call_indirect
+		return r.skipLEBs(2)
+	case opcode == 0x1c: // select with types
+		count, err := r.u32()
+		if err != nil {
+			return err
+		}
+		_, err = r.bytes(count)
+		return err
+	case opcode >= 0x20 && opcode <= 0x26: // variable and table access
+		return r.skipLEB()
+	case opcode >= 0x28 && opcode <= 0x3e: // memory access
+		return r.skipLEBs(2)
+	case opcode == 0x3f || opcode == 0x40: // memory.size, memory.grow
+		return r.skipLEB()
+	case opcode == 0x41 || opcode == 0x42: // i32.const, i64.const
+		return r.skipLEB()
+	case opcode == 0x43: // f32.const
+		_, err := r.bytes(4)
+		return err
+	case opcode == 0x44: // f64.const
+		_, err := r.bytes(8)
+		return err
+	case opcode == 0xd0: // ref.null
+		_, err := r.byte()
+		return err
+	case opcode == 0xd2: // ref.func
+		return r.skipLEB()
+	case opcode == 0xfc:
+		return r.skipMiscImmediates()
+	case opcode <= 0x01, opcode == 0x05, opcode == 0x0b, opcode == 0x0f,
+		opcode == 0x1a, opcode == 0x1b, opcode >= 0x45 && opcode <= 0xc4, opcode == 0xd1:
+		return nil
+	default:
+		return fmt.Errorf("%w: 0x%x", errUnknownOpcode, opcode)
+	}
+}
+
+// skipMiscImmediates skips the sub-opcode and immediates of an instruction
+// prefixed with 0xfc.
+func (r *wasmReader) skipMiscImmediates() error {
+	subOpcode, err := r.u32()
+	if err != nil {
+		return err
+	}
+	switch {
+	case subOpcode <= 7: // saturating truncations
+		return nil
+	case subOpcode == 8: // memory.init
+		return r.skipLEBs(2)
+	case subOpcode == 9 || subOpcode == 11 || subOpcode == 13: // data.drop, memory.fill, elem.drop
+		return r.skipLEB()
+	case subOpcode == 10 || subOpcode == 12 || subOpcode == 14: // memory.copy, table.init, table.copy
+		return r.skipLEBs(2)
+	case subOpcode >= 15 && subOpcode <= 17: // table.grow, table.size, table.fill
+		return r.skipLEB()
+	default:
+		return fmt.Errorf("%w: 0xfc %d", errUnknownOpcode, subOpcode)
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package wazero_runtime
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+)
+
+// newFloatTestModule returns the code of a module exporting the function "f" with
+// the given parameter and result types, local declarations and instructions.
+func newFloatTestModule(params []byte, result byte, locals []byte, instructions []byte) []byte {
+	section := func(id byte, contents []byte) []byte {
+		encoded := binary.AppendUvarint([]byte{id}, uint64(len(contents)))
+		return append(encoded, contents...)
+	}
+
+	functionType := append([]byte{0x01, 0x60, byte(len(params))}, params...)
+	functionType = append(functionType, 0x01, result)
+
+	body := append(locals, instructions...)
+	body = append(body, 0x0b) // end
+	code := binary.AppendUvarint([]byte{0x01}, uint64(len(body)))
+	code = append(code, body...)
+
+	module := append([]byte{}, wasmHeader...)
+	module = append(module, section(sectionType, functionType)...)
+	module = append(module, section(sectionFunction, []byte{0x01, 0x00})...)
+	module = append(module, section(7, []byte{0x01, 0x01, 'f', 0x00, 0x00})...) // export
+	return append(module, section(sectionCode, code)...)
+}
+
+func callFloatTestModule(t *testing.T, code []byte, params ...uint64) uint64 {
+	t.Helper()
+
+	ctx := context.Background()
+	rt := wazero.NewRuntime(ctx)
+	defer rt.Close(ctx)
+
+	mod, err := rt.Instantiate(ctx, code)
+	require.NoError(t, err)
+	results, err := mod.ExportedFunction("f").Call(ctx, params...)
+	require.NoError(t, err)
+	return results[0]
+}
+
+func Test_rewriteFloats(t *testing.T) {
+	t.Parallel()
+
+	const f32, f64, i32 = valueTypeF32, valueTypeF64, byte(0x7f)
+	// NaNs with a payload, which arithmetic instructions may propagate
+	nan32 := api.EncodeF32(math.Float32frombits(0x7fa00001))
+	nan64 := api.EncodeF64(math.Float64frombits(0x7ff4000000000001))
+
+	testCases := map[string]struct {
+		code         []byte
+		params       []uint64
+		report       floatReport
+		canonicalErr error
+		result       uint64
+	}{
+		"f32_add": {
+			code: newFloatTestModule([]byte{f32, f32}, f32, []byte{0x00},
+				[]byte{0x20, 0x00, 0x20, 0x01, 0x92}),
+			params: []uint64{nan32, api.EncodeF32(1)},
+			report: floatReport{nanProducing: 1},
+			result: 0x7fc00000,
+		},
+		"f64_sqrt_with_locals": {
+			// the scratch locals are declared after the i32 local
+			code: newFloatTestModule([]byte{f64}, f64, []byte{0x01, 0x01, i32},
+				[]byte{0x41, 0x07, 0x21, 0x01, 0x20, 0x00, 0x9f}),
+			params: []uint64{nan64},
+			report: floatReport{nanProducing: 1},
+			result: 0x7ff8000000000000,
+		},
+		"f64_number": {
+			code: newFloatTestModule([]byte{f64, f64}, f64, []byte{0x00},
+				[]byte{0x20, 0x00, 0x20, 0x01, 0xa2, 0x20, 0x00, 0xa0}),
+			params: []uint64{api.EncodeF64(3), api.EncodeF64(2)},
+			report: floatReport{nanProducing: 2},
+			result: api.EncodeF64(9),
+		},
+		"f32_neg_only": {
+			code: newFloatTestModule([]byte{f32}, f32, []byte{0x00},
+				[]byte{0x20, 0x00, 0x8c}),
+			params: []uint64{api.EncodeF32(1)},
+			result: api.EncodeF32(-1),
+		},
+		"vector": {
+			// v128.const 0, f32x4.extract_lane 0
+			code: newFloatTestModule(nil, f32, []byte{0x00},
+				append(append([]byte{0xfd, 0x0c}, make([]byte, 16)...), 0xfd, 0x1f, 0x00)),
+			report:       floatReport{vectorFunctions: 1},
+			canonicalErr: errVectorInstructions,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			checked, report, err := rewriteFloats(testCase.code, false)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.report, report)
+			assert.Equal(t, testCase.code, checked)
+
+			rewritten, report, err := rewriteFloats(testCase.code, true)
+			assert.ErrorIs(t, err, testCase.canonicalErr)
+			if testCase.canonicalErr != nil {
+				return
+			}
+			assert.Equal(t, testCase.report, report)
+
+			result := callFloatTestModule(t, rewritten, testCase.params...)
+			assert.Equal(t, testCase.result, result)
+		})
+	}
+}
+
+func Test_rewriteFloats_invalid(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := rewriteFloats([]byte{0x00, 0x61}, false)
+	assert.ErrorIs(t, err, errInvalidWasm)
+
+	code := newFloatTestModule(nil, valueTypeF32, []byte{0x00}, []byte{0xff})
+	_, _, err = rewriteFloats(code, false)
+	assert.ErrorIs(t, err, errUnknownOpcode)
+}
@@ -0,0 +1,188 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package wazero_runtime
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+)
+
+// hostModuleName is the name of the module of the host functions imported by the runtimes
+const hostModuleName = "env"
+
+// hostFunctionVersionRegex matches the version suffix of a host function name
+var hostFunctionVersionRegex = regexp.MustCompile(`^(.+)_version_(\d+)$`)
+
+// HostFunction is the name and signature of a host function.
+type HostFunction struct {
+	Name    string
+	Params  []api.ValueType
+	Results []api.ValueType
+}
+
+func newHostFunction(name string, definition api.FunctionDefinition) HostFunction {
+	return HostFunction{
+		Name:    name,
+		Params:  definition.ParamTypes(),
+		Results: definition.ResultTypes(),
+	}
+}
+
+// Signature returns the wasm signature of the host function, such as (i32, i64) -> i64.
+func (f HostFunction) Signature() string {
+	params := make([]string, len(f.Params))
+	for i, param := range f.Params {
+		params[i] = api.ValueTypeName(param)
+	}
+	signature := "(" + strings.Join(params, ", ") + ")"
+
+	switch len(f.Results) {
+	case 0:
+		return signature
+	case 1:
+		return signature + " -> " + api.ValueTypeName(f.Results[0])
+	default:
+		results := make([]string, len(f.Results))
+		for i, result := range f.Results {
+			results[i] = api.ValueTypeName(result)
+		}
+		return signature + " -> (" + strings.Join(results, ", ") + ")"
+	}
+}
+
+func (f HostFunction) String() string {
+	return f.Name + f.Signature()
+}
+
+// Version returns the name of the host function without its version suffix,
+// and its version, which is zero if the name has no version suffix.
+func (f HostFunction) Version() (base string, version uint32) {
+	matches := hostFunctionVersionRegex.FindStringSubmatch(f.Name)
+	if matches == nil {
+		return f.Name, 0
+	}
+	parsed, err := strconv.ParseUint(matches[2], 10, 32)
+	if err != nil {
+		return f.Name, 0
+	}
+	return matches[1], uint32(parsed)
+}
+
+func (f HostFunction) sameSignature(other HostFunction) bool {
+	return slices.Equal(f.Params, other.Params) && slices.Equal(f.Results, other.Results)
+}
+
+// MissingHostFunction is a host function imported by a runtime and not provided.
+type MissingHostFunction struct {
+	Import HostFunction
+	// ProvidedVersions are the versions of the host function which are provided.
+	ProvidedVersions []uint32
+}
+
+// MismatchedHostFunction is a host function imported by a runtime with a
+// different signature than the one provided.
+type MismatchedHostFunction struct {
+	Import   HostFunction
+	Provided HostFunction
+}
+
+// HostAPIReport is the result of checking the host functions imported by a
+// runtime against the host functions provided.
+type HostAPIReport struct {
+	// Imports are the host functions imported by the runtime, sorted by name.
+	Imports []HostFunction
+	// Missing are the imported host functions which are not provided.
+	Missing []MissingHostFunction
+	// Mismatched are the imported host functions provided with a different signature.
+	Mismatched []MismatchedHostFunction
+	// Extra are the provided host functions which are not imported by the runtime.
+	Extra []HostFunction
+}
+
+// Compatible returns true if all the host functions imported by the runtime
+// are provided with the same signature.
+func (r HostAPIReport) Compatible() bool {
+	return len(r.Missing) == 0 && len(r.Mismatched) == 0
+}
+
+// CheckHostAPI compiles the runtime code and checks the host functions it
+// imports against the host functions provided, by name, signature and version.
+func CheckHostAPI(code []byte) (report HostAPIReport, err error) {
+	ctx := context.Background()
+	rt := wazero.NewRuntime(ctx)
+	defer rt.Close(ctx)
+
+	hostCompiledModule, err := compileHostModule(ctx, rt)
+	if err != nil {
+		return report, fmt.Errorf("compiling host module: %w", err)
+	}
+
+	code, err = decompressWasm(code)
+	if err != nil {
+		return report, fmt.Errorf("decompressing runtime code: %w", err)
+	}
+
+	guestCompiledModule, err := rt.CompileModule(ctx, code)
+	if err != nil {
+		return report, fmt.Errorf("compiling runtime code: %w", err)
+	}
+
+	provided := make(map[string]HostFunction)
+	providedVersions := make(map[string][]uint32)
+	for name, definition := range hostCompiledModule.ExportedFunctions() {
+		function := newHostFunction(name, definition)
+		provided[name] = function
+		base, version := function.Version()
+		providedVersions[base] = append(providedVersions[base], version)
+	}
+
+	imported := make(map[string]struct{})
+	for _, definition := range guestCompiledModule.ImportedFunctions() {
+		moduleName, name, _ := definition.Import()
+		if moduleName != hostModuleName {
+			name = moduleName + "." + name
+		}
+		function := newHostFunction(name, definition)
+		report.Imports = append(report.Imports, function)
+		imported[name] = struct{}{}
+
+		providedFunction, ok := provided[name]
+		switch {
+		case !ok:
+			base, _ := function.Version()
+			versions := slices.Clone(providedVersions[base])
+			slices.Sort(versions)
+			report.Missing = append(report.Missing, MissingHostFunction{
+				Import:           function,
+				ProvidedVersions: versions,
+			})
+		case !function.sameSignature(providedFunction):
+			report.Mismatched = append(report.Mismatched, MismatchedHostFunction{
+				Import:   function,
+				Provided: providedFunction,
+			})
+		}
+	}
+
+	for name, function := range provided {
+		if _, ok := imported[name]; !ok {
+			report.Extra = append(report.Extra, function)
+		}
+	}
+
+	byName := func(a, b HostFunction) int { return strings.Compare(a.Name, b.Name) }
+	slices.SortFunc(report.Imports, byName)
+	slices.SortFunc(report.Extra, byName)
+	slices.SortFunc(report.Missing, func(a, b MissingHostFunction) int { return byName(a.Import, b.Import) })
+	slices.SortFunc(report.Mismatched, func(a, b MismatchedHostFunction) int { return byName(a.Import, b.Import) })
+
+	return report, nil
+}
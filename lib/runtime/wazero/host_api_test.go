@@ -0,0 +1,112 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package wazero_runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tetratelabs/wazero/api"
+)
+
+type testImport struct {
+	module, name    string
+	params, results []api.ValueType
+}
+
+// encodeImportsModule encodes a wasm module only importing the given functions.
+func encodeImportsModule(imports []testImport) []byte {
+	encodeName := func(name string) []byte {
+		return append([]byte{byte(len(name))}, name...)
+	}
+	encodeSection := func(id byte, entries []byte) []byte {
+		section := append([]byte{byte(len(imports))}, entries...)
+		return append([]byte{id, byte(len(section))}, section...)
+	}
+
+	var types, entries []byte
+	for i, imported := range imports {
+		types = append(types, 0x60, byte(len(imported.params)))
+		types = append(types, imported.params...)
+		types = append(types, byte(len(imported.results)))
+		types = append(types, imported.results...)
+
+		entries = append(entries, encodeName(imported.module)...)
+		entries = append(entries, encodeName(imported.name)...)
+		entries = append(entries, 0x00, byte(i))
+	}
+
+	module := []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+	module = append(module, encodeSection(1, types)...)
+	return append(module, encodeSection(2, entries)...)
+}
+
+func Test_CheckHostAPI(t *testing.T) {
+	t.Parallel()
+
+	const i32, i64 = api.ValueTypeI32, api.ValueTypeI64
+
+	code := encodeImportsModule([]testImport{
+		{module: "env", name: "ext_logging_log_version_1", params: []api.ValueType{i32, i64, i64}},
+		{module: "env", name: "ext_allocator_malloc_version_1", params: []api.ValueType{i64},
+			results: []api.ValueType{i64}},
+		{module: "env", name: "ext_logging_log_version_9"},
+		{module: "other", name: "function"},
+	})
+
+	report, err := CheckHostAPI(code)
+	require.NoError(t, err)
+
+	assert.False(t, report.Compatible())
+	require.Len(t, report.Imports, 4)
+	assert.Equal(t, "ext_allocator_malloc_version_1(i64) -> i64", report.Imports[0].String())
+
+	expectedMissing := []MissingHostFunction{
+		{
+			Import:           HostFunction{Name: "ext_logging_log_version_9"},
+			ProvidedVersions: []uint32{1},
+		},
+		{
+			Import: HostFunction{Name: "other.function"},
+		},
+	}
+	assert.Equal(t, expectedMissing, report.Missing)
+
+	require.Len(t, report.Mismatched, 1)
+	assert.Equal(t, "ext_allocator_malloc_version_1(i64) -> i64", report.Mismatched[0].Import.String())
+	assert.Equal(t, "ext_allocator_malloc_version_1(i32) -> i32", report.Mismatched[0].Provided.String())
+
+	assert.NotEmpty(t, report.Extra)
+	for _, function := range report.Extra {
+		assert.NotEqual(t, "ext_logging_log_version_1", function.Name)
+		assert.NotEqual(t, "ext_allocator_malloc_version_1", function.Name)
+	}
+}
+
+func Test_HostFunction_Version(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		name    string
+		base    string
+		version uint32
+	}{
+		"versioned":    {name: "ext_storage_get_version_1", base: "ext_storage_get", version: 1},
+		"two_digits":   {name: "ext_trie_root_version_12", base: "ext_trie_root", version: 12},
+		"no_version":   {name: "ext_storage_get", base: "ext_storage_get"},
+		"empty_suffix": {name: "ext_storage_get_version_", base: "ext_storage_get_version_"},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			base, version := HostFunction{Name: testCase.name}.Version()
+			assert.Equal(t, testCase.base, base)
+			assert.Equal(t, testCase.version, version)
+		})
+	}
+}
@@ -8,12 +8,13 @@
 	"context"
 	"crypto/rand"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"math"
 	"math/big"
-	"reflect"
 	"time"
 
+	"github.com/ChainSafe/gossamer/internal/database"
 	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto"
@@ -1704,34 +1705,27 @@ func ext_offchain_local_storage_compare_and_set_version_1(
 
 	storageKey := read(m, key)
 
-	var storedValue []byte
-	var err error
-
-	switch runtime.NodeStorageType(kind) {
-	case runtime.NodeStorageTypePersistent:
-		storedValue, err = rtCtx.NodeStorage.PersistentStorage.Get(storageKey)
-	case runtime.NodeStorageTypeLocal:
-		storedValue, err = rtCtx.NodeStorage.LocalStorage.Get(storageKey)
-	}
-
+	var oldVal *[]byte
+	err := scale.Unmarshal(read(m, oldValue), &oldVal)
 	if err != nil {
-		logger.Errorf("failed to get value from storage: %s", err)
+		logger.Errorf("failed to decode old value: %s", err)
 		return 0
 	}
 
-	oldVal := read(m, oldValue)
 	newVal := read(m, newValue)
-	if reflect.DeepEqual(storedValue, oldVal) {
-		cp := make([]byte, len(newVal))
-		copy(cp, newVal)
-		err = rtCtx.NodeStorage.LocalStorage.Put(storageKey, cp)
-		if err != nil {
-			logger.Errorf("failed to set value in storage: %s", err)
-			return 0
-		}
+	cp := make([]byte, len(newVal))
+	copy(cp, newVal)
+
+	set, err := rtCtx.NodeStorage.CompareAndSet(runtime.NodeStorageType(kind), storageKey, oldVal, cp)
+	if err != nil {
+		logger.Errorf("failed to compare and set value in storage: %s", err)
+		return 0
 	}
 
-	return 1
+	if set {
+		return 1
+	}
+	return 0
 }
 
 func ext_offchain_local_storage_get_version_1(ctx context.Context, m api.Module, kind uint32, key uint64) uint64 {
@@ -1754,7 +1748,9 @@ func ext_offchain_local_storage_get_version_1(ctx context.Context, m api.Module,
 
 	var encodedOption []byte
 	if err != nil || res == nil {
-		logger.Errorf("failed to get value from storage: %s", err)
+		if err != nil && !errors.Is(err, database.ErrNotFound) {
+			logger.Errorf("failed to get value from storage: %s", err)
+		}
 		encodedOption = noneEncoded
 	} else {
 		encodedOption = res
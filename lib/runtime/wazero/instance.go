@@ -8,6 +8,7 @@
 	"context"
 	"errors"
 	"fmt"
+	goruntime "runtime"
 	"sync"
 
 	"github.com/ChainSafe/gossamer/dot/types"
@@ -17,8 +18,8 @@
 	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
 	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/lib/runtime"
-	"github.com/ChainSafe/gossamer/lib/runtime/allocator"
 	"github.com/ChainSafe/gossamer/lib/runtime/offchain"
+	"github.com/ChainSafe/gossamer/lib/runtime/storage"
 	"github.com/ChainSafe/gossamer/lib/transaction"
 	"github.com/ChainSafe/gossamer/pkg/scale"
 	"github.com/ChainSafe/gossamer/pkg/trie"
@@ -37,12 +38,17 @@ type runtimeContextKeyType struct{
 
 var runtimeContextKey = runtimeContextKeyType{}
 
-var _ runtime.Instance = (*Instance)(nil)
+var (
+	_ runtime.Instance         = (*Instance)(nil)
+	_ runtime.ReadOnlyExecutor = (*Instance)(nil)
+)
 
 type wazeroMeta struct {
 	config      wazero.RuntimeConfig
 	cache       wazero.CompilationCache
 	guestModule wazero.CompiledModule
+	// executors are idle runtimes kept to run read-only calls concurrently
+	executors chan *executor
 }
 
 // Instance backed by wazero.Runtime
@@ -52,7 +58,12 @@ type Instance struct {
 	Context      *runtime.Context
 	wasmByteCode []byte
 	codeHash     common.Hash
+	heapPages    uint64
+	floats       runtime.FloatDeterminism
 	metadata     wazeroMeta
+	// execLock is held exclusively by Exec, which may persist storage changes,
+	// and shared by ExecReadOnly calls.
+	execLock sync.RWMutex
 	sync.Mutex
 }
 
@@ -67,6 +78,12 @@ type Config struct {
 	Transaction    runtime.TransactionState
 	CodeHash       common.Hash
 	DefaultVersion *runtime.Version
+	// HeapPages overrides the number of heap pages set in the `:heappages`
+	// storage key if it is not zero.
+	HeapPages uint64
+	// FloatDeterminism is the policy applied to the float instructions of the
+	// runtime code. The zero value runs the code without checking them.
+	FloatDeterminism runtime.FloatDeterminism
 }
 
 func decompressWasm(code []byte) ([]byte, error) {
@@ -110,15 +127,11 @@ func NewInstanceFromTrie(t trie.Trie, cfg Config) (*Instance, error) {
 	return NewInstance(code, cfg)
 }
 
-func newRuntime(ctx context.Context,
-	code []byte,
-	config wazero.RuntimeConfig,
-) (api.Module, wazero.Runtime, wazero.CompiledModule, error) {
-	rt := wazero.NewRuntimeWithConfig(ctx, config)
-
+// compileHostModule compiles the "env" module of the host functions imported by the runtimes.
+func compileHostModule(ctx context.Context, rt wazero.Runtime) (wazero.CompiledModule, error) {
 	const i32, i64 = api.ValueTypeI32, api.ValueTypeI64
 
-	hostCompiledModule, err := rt.NewHostModuleBuilder("env").
+	return rt.NewHostModuleBuilder("env").
 		// values from newer kusama/polkadot runtimes
 		ExportMemory("memory", MemoryMinPages).
 		NewFunctionBuilder().
@@ -641,7 +654,15 @@ func newRuntime(ctx context.Context,
 		).
 		Export("ext_crypto_ecdsa_generate_version_1").
 		Compile(ctx)
+}
+
+func newRuntime(ctx context.Context,
+	code []byte,
+	config wazero.RuntimeConfig,
+) (api.Module, wazero.Runtime, wazero.CompiledModule, error) {
+	rt := wazero.NewRuntimeWithConfig(ctx, config)
 
+	hostCompiledModule, err := compileHostModule(ctx, rt)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -673,6 +694,15 @@ func NewInstance(code []byte, cfg Config) (instance *Instance, err error) {
 	logger.Debug("instantiating a runtime!")
 	logger.Patch(log.SetLevel(cfg.LogLvl), log.SetCallerFunc(true))
 
+	code, err = decompressWasm(code)
+	if err != nil {
+		return nil, fmt.Errorf("decompressing runtime code: %w", err)
+	}
+	code, err = applyFloatDeterminism(code, cfg.FloatDeterminism, cfg.CodeHash)
+	if err != nil {
+		return nil, err
+	}
+
 	// Prepare a cache directory.
 	ctx := context.Background()
 	cache := wazero.NewCompilationCache()
@@ -694,12 +724,15 @@ func NewInstance(code []byte, cfg Config) (instance *Instance, err error) {
 			SigVerifier:     crypto.NewSignatureVerifier(logger),
 			OffchainHTTPSet: offchain.NewHTTPSet(),
 		},
-		Module:   mod,
-		codeHash: cfg.CodeHash,
+		Module:    mod,
+		codeHash:  cfg.CodeHash,
+		heapPages: cfg.HeapPages,
+		floats:    cfg.FloatDeterminism,
 		metadata: wazeroMeta{
 			config:      config,
 			cache:       cache,
 			guestModule: guestCompiledModule,
+			executors:   make(chan *executor, goruntime.NumCPU()),
 		},
 	}
 
@@ -722,10 +755,97 @@ func NewInstance(code []byte, cfg Config) (instance *Instance, err error) {
 var ErrExportFunctionNotFound = errors.New("export function not found")
 
 func (i *Instance) Exec(function string, data []byte) ([]byte, error) {
+	i.execLock.Lock()
+	defer i.execLock.Unlock()
 	i.Lock()
 	defer i.Unlock()
 
-	mod, err := i.Runtime.InstantiateModule(context.Background(), i.metadata.guestModule, wazero.NewModuleConfig())
+	return i.exec(i.Runtime, i.metadata.guestModule, i.Context, function, data)
+}
+
+// ExecReadOnly executes the runtime function without persisting any storage change.
+// The call runs on its own wazero runtime, so its memory and heap allocator are not
+// shared with other calls, and over copy-on-write overlays of the context storage and
+// offchain node storage. It cannot insert keys in the keystore nor submit transactions
+// to the pool. Read-only calls therefore run concurrently with each other, and only
+// wait for calls made with Exec.
+func (i *Instance) ExecReadOnly(function string, data []byte) ([]byte, error) {
+	i.execLock.RLock()
+	defer i.execLock.RUnlock()
+
+	i.Lock()
+	rtContext := *i.Context
+	i.Unlock()
+
+	if trieState, ok := rtContext.Storage.(*storage.TrieState); ok {
+		overlay, err := trieState.Overlay()
+		if err != nil {
+			return nil, fmt.Errorf("creating storage overlay: %w", err)
+		}
+		rtContext.Storage = overlay
+	}
+	rtContext.NodeStorage = rtContext.NodeStorage.Overlay()
+	if rtContext.Keystore != nil {
+		rtContext.Keystore = rtContext.Keystore.ReadOnly()
+	}
+	rtContext.Transaction = nil
+	rtContext.SigVerifier = crypto.NewSignatureVerifier(logger)
+
+	e, err := i.getExecutor()
+	if err != nil {
+		return nil, err
+	}
+	defer i.putExecutor(e)
+
+	return i.exec(e.runtime, e.guestModule, &rtContext, function, data)
+}
+
+// executor is a wazero runtime with its own host module and memory,
+// used to run read-only calls concurrently.
+type executor struct {
+	runtime     wazero.Runtime
+	guestModule wazero.CompiledModule
+}
+
+func (i *Instance) getExecutor() (*executor, error) {
+	select {
+	case e := <-i.metadata.executors:
+		return e, nil
+	default:
+	}
+
+	// the compilation cache is shared with the instance runtime,
+	// so creating a new executor does not compile the code again.
+	mod, rt, guestModule, err := newRuntime(context.Background(), i.wasmByteCode, i.metadata.config)
+	if err != nil {
+		return nil, fmt.Errorf("creating executor runtime: %w", err)
+	}
+
+	err = mod.Close(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("closing executor module: %w", err)
+	}
+
+	return &executor{
+		runtime:     rt,
+		guestModule: guestModule,
+	}, nil
+}
+
+func (i *Instance) putExecutor(e *executor) {
+	select {
+	case i.metadata.executors <- e:
+	default:
+		err := e.runtime.Close(context.Background())
+		if err != nil {
+			logger.Errorf("closing executor runtime: %s", err)
+		}
+	}
+}
+
+func (i *Instance) exec(rt wazero.Runtime, guestModule wazero.CompiledModule, rtContext *runtime.Context,
+	function string, data []byte) ([]byte, error) {
+	mod, err := rt.InstantiateModule(context.Background(), guestModule, wazero.NewModuleConfig())
 	if mod == nil {
 		return nil, fmt.Errorf("instantiate guest module: nil")
 	}
@@ -746,15 +866,19 @@ func (i *Instance) Exec(function string, data []byte) ([]byte, error) {
 	}
 
 	heapBase := api.DecodeU32(encodedHeapBase.Get())
-	i.Context.Allocator = allocator.NewFreeingBumpHeapAllocator(heapBase)
+	heapAllocator := newAllocator(rtContext.Storage, i.heapPages, heapBase, initialMemoryPages(guestModule))
+	rtContext.Allocator = heapAllocator
 
 	memory := mod.Memory()
 	if memory == nil {
 		panic("nil memory")
 	}
+	defer func() {
+		recordMemoryUsage(i.codeHash, memory.Size(), heapAllocator.MaxPages())
+	}()
 
 	dataLength := uint32(len(data)) //nolint:gosec
-	inputPtr, err := i.Context.Allocator.Allocate(memory, dataLength)
+	inputPtr, err := rtContext.Allocator.Allocate(memory, dataLength)
 	if err != nil {
 		return nil, fmt.Errorf("allocating input memory: %w", err)
 	}
@@ -769,7 +893,7 @@ func (i *Instance) Exec(function string, data []byte) ([]byte, error) {
 		return nil, fmt.Errorf("%w: %s", ErrExportFunctionNotFound, function)
 	}
 
-	ctx := context.WithValue(context.Background(), runtimeContextKey, i.Context)
+	ctx := context.WithValue(context.Background(), runtimeContextKey, rtContext)
 	values, err := runtimeFunc.Call(ctx, api.EncodeU32(inputPtr), api.EncodeU32(dataLength))
 	if err != nil {
 		return nil, fmt.Errorf("running runtime function: %w", err)
@@ -963,6 +1087,25 @@ func (in *Instance) FinalizeBlock() (*types.Header, error) {
 
 // ExecuteBlock calls runtime function Core_execute_block
 func (in *Instance) ExecuteBlock(block *types.Block) ([]byte, error) {
+	b, err := withoutSeal(block)
+	if err != nil {
+		return nil, err
+	}
+
+	bdEnc, err := b.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	// start an changeset at the beginning of the block execution
+	// then clear prefix can work correctly by ignoring
+	// keys included under current block execution
+	in.Context.Storage.StartTransaction()
+	return in.Exec(runtime.CoreExecuteBlock, bdEnc)
+}
+
+// withoutSeal returns a copy of the block without its seal digest.
+func withoutSeal(block *types.Block) (*types.Block, error) {
 	// copy block since we're going to modify it
 	b, err := block.DeepCopy()
 	if err != nil {
@@ -988,16 +1131,7 @@ func (in *Instance) ExecuteBlock(block *types.Block) ([]byte, error) {
 		}
 	}
 
-	bdEnc, err := b.Encode()
-	if err != nil {
-		return nil, err
-	}
-
-	// start an changeset at the beginning of the block execution
-	// then clear prefix can work correctly by ignoring
-	// keys included under current block execution
-	in.Context.Storage.StartTransaction()
-	return in.Exec(runtime.CoreExecuteBlock, bdEnc)
+	return &b, nil
 }
 
 // DecodeSessionKeys decodes the given public session keys. Returns a list of raw public keys including their key type.
@@ -1065,9 +1199,37 @@ func (in *Instance) QueryCallFeeDetails(ext []byte) (*types.FeeDetails, error) {
 	return dispatchInfo, nil
 }
 
-// CheckInherents checks inherents in the block verification process.
-// TODO: use this in block verification process (#1873)
-func (*Instance) CheckInherents() {}
+// CheckInherents calls runtime API function BlockBuilder_check_inherents to check
+// the inherents of the block, without its seal, against the inherent data.
+func (in *Instance) CheckInherents(block *types.Block, data *types.InherentData) (
+	*types.CheckInherentsResult, error) {
+	b, err := withoutSeal(block)
+	if err != nil {
+		return nil, err
+	}
+
+	blockEnc, err := b.Encode()
+	if err != nil {
+		return nil, fmt.Errorf("encoding block: %w", err)
+	}
+
+	dataEnc, err := data.Encode()
+	if err != nil {
+		return nil, fmt.Errorf("encoding inherent data: %w", err)
+	}
+
+	ret, err := in.Exec(runtime.BlockBuilderCheckInherents, append(blockEnc, dataEnc...))
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(types.CheckInherentsResult)
+	if err = scale.Unmarshal(ret, result); err != nil {
+		return nil, fmt.Errorf("decoding check inherents result: %w", err)
+	}
+
+	return result, nil
+}
 
 // GrandpaGenerateKeyOwnershipProof returns grandpa key ownership proof from the runtime.
 func (in *Instance) GrandpaGenerateKeyOwnershipProof(authSetID uint64, authorityID ed25519.PublicKeyBytes) (
@@ -1137,6 +1299,17 @@ func (in *Instance) GetCodeHash() common.Hash {
 	return in.codeHash
 }
 
+// HeapPages returns the number of heap pages overriding the `:heappages`
+// storage key, or zero if it is not overridden.
+func (in *Instance) HeapPages() uint64 {
+	return in.heapPages
+}
+
+// FloatDeterminism returns the policy applied to the float instructions of the runtime code.
+func (in *Instance) FloatDeterminism() runtime.FloatDeterminism {
+	return in.floats
+}
+
 // NodeStorage to get reference to runtime node service
 func (in *Instance) NodeStorage() runtime.NodeStorage {
 	return in.Context.NodeStorage
@@ -1178,6 +1351,8 @@ func (in *Instance) SetContextStorage(s runtime.Storage) {
 // Stop closes the WASM instance, its imports and clears
 // the context allocator in a thread-safe way.
 func (in *Instance) Stop() {
+	in.execLock.Lock()
+	defer in.execLock.Unlock()
 	in.Lock()
 	defer in.Unlock()
 	err := in.Runtime.Close(context.Background())
@@ -1185,6 +1360,14 @@ func (in *Instance) Stop() {
 		log.Errorf("runtime failed to close: %v", err)
 	}
 
+	for len(in.metadata.executors) > 0 {
+		e := <-in.metadata.executors
+		err = e.runtime.Close(context.Background())
+		if err != nil {
+			log.Errorf("executor runtime failed to close: %v", err)
+		}
+	}
+
 	err = in.metadata.cache.Close(context.Background())
 	if err != nil {
 		log.Errorf("closing the wazero compilation cache: %v", err)
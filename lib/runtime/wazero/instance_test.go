@@ -5,6 +5,7 @@
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"math/big"
 	"os"
@@ -622,6 +623,57 @@ func TestInstance_InitializeBlock_PolkadotRuntime(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestInstance_ExecReadOnly(t *testing.T) {
+	rt := NewTestInstance(t, runtime.WESTEND_RUNTIME_v0929)
+
+	expectedVersion, err := rt.Exec(runtime.CoreVersion, []byte{})
+	require.NoError(t, err)
+
+	const parallelCalls = 8
+	results := make(chan []byte, parallelCalls)
+	errs := make(chan error, parallelCalls)
+	for i := 0; i < parallelCalls; i++ {
+		go func() {
+			result, err := rt.ExecReadOnly(runtime.CoreVersion, []byte{})
+			results <- result
+			errs <- err
+		}()
+	}
+
+	for i := 0; i < parallelCalls; i++ {
+		require.NoError(t, <-errs)
+		assert.Equal(t, expectedVersion, <-results)
+	}
+
+	// storage changes made by read-only calls are discarded
+	encodedHeader, err := scale.Marshal(types.Header{Number: 1, Digest: types.NewDigest()})
+	require.NoError(t, err)
+	_, err = rt.ExecReadOnly(runtime.CoreInitializeBlock, encodedHeader)
+	require.NoError(t, err)
+
+	assert.Empty(t, rt.Context.Storage.(*storage.TrieState).TrieEntries())
+}
+
+func TestInstance_ExecReadOnly_offchainStorage(t *testing.T) {
+	inst := NewTestInstance(t, runtime.HOST_API_TEST_RUNTIME, TestWithVersion(DefaultVersion))
+
+	testKey := []byte("key1")
+	err := inst.Context.NodeStorage.PersistentStorage.Put(testKey, []byte{1})
+	require.NoError(t, err)
+
+	encKind, err := scale.Marshal(int32(runtime.NodeStorageTypePersistent))
+	require.NoError(t, err)
+	encKey, err := scale.Marshal(testKey)
+	require.NoError(t, err)
+
+	_, err = inst.ExecReadOnly("rtm_ext_offchain_local_storage_clear_version_1", append(encKind, encKey...))
+	require.NoError(t, err)
+
+	value, err := inst.Context.NodeStorage.PersistentStorage.Get(testKey)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1}, value)
+}
+
 func TestInstance_ExecuteBlock_WestendRuntime(t *testing.T) {
 	instance := NewTestInstance(t, runtime.WESTEND_RUNTIME_v0929)
 	block := runtime.InitializeRuntimeToTest(t, instance, &types.Header{})
@@ -1339,3 +1391,39 @@ func TestInstance_GrandpaSubmitReportEquivocationUnsignedExtrinsic(t *testing.T)
 	err = runtime.GrandpaSubmitReportEquivocationUnsignedExtrinsic(equivocationProof, opaqueKeyOwnershipProof)
 	require.NoError(t, err)
 }
+
+// BenchmarkInstance_Exec compares calls serialised by Exec with concurrent
+// ExecReadOnly calls; run it with `-cpu 1,2,4,8` to see the latter scale with cores.
+func BenchmarkInstance_Exec(b *testing.B) {
+	runtimePath, err := runtime.GetRuntime(context.Background(), runtime.WESTEND_RUNTIME_v0929)
+	require.NoError(b, err)
+	code, err := os.ReadFile(filepath.Clean(runtimePath))
+	require.NoError(b, err)
+
+	rt, err := NewInstance(code, Config{
+		Storage: storage.NewTrieState(inmemory_trie.NewEmptyTrie()),
+		LogLvl:  log.Critical,
+	})
+	require.NoError(b, err)
+	b.Cleanup(rt.Stop)
+
+	benchmarks := map[string]func(function string, data []byte) ([]byte, error){
+		"exec":           rt.Exec,
+		"exec_read_only": rt.ExecReadOnly,
+	}
+
+	for name, execFunc := range benchmarks {
+		execFunc := execFunc
+		b.Run(name, func(b *testing.B) {
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					_, err := execFunc(runtime.CoreVersion, []byte{})
+					if err != nil {
+						b.Error(err)
+						return
+					}
+				}
+			})
+		})
+	}
+}
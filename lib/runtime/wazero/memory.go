@@ -0,0 +1,89 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package wazero_runtime
+
+import (
+	"encoding/binary"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/lib/runtime/allocator"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/tetratelabs/wazero"
+)
+
+var (
+	memoryPagesGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "gossamer_runtime",
+		Name:      "memory_pages",
+		Help:      "number of wasm pages of the linear memory after the last call, by runtime code hash",
+	}, []string{"code_hash"})
+	memoryMaxPagesGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "gossamer_runtime",
+		Name:      "memory_max_pages",
+		Help:      "number of wasm pages the linear memory can grow up to, by runtime code hash",
+	}, []string{"code_hash"})
+)
+
+// defaultHeapPages is the number of heap pages of the runtimes which do not set
+// the `:heappages` storage key, as in substrate.
+const defaultHeapPages = 2048
+
+// heapPages returns the number of heap pages of the runtime, which is the
+// given override if it is not zero, or else the value of the `:heappages`
+// storage key, or defaultHeapPages if neither is set.
+func heapPages(storage runtime.Storage, override uint64) (pages uint64) {
+	if override != 0 {
+		return override
+	}
+
+	if storage == nil {
+		return defaultHeapPages
+	}
+
+	encoded := storage.Get(common.HeapPagesKey)
+	if len(encoded) != 8 {
+		if len(encoded) != 0 {
+			logger.Warnf("ignoring invalid %s storage value 0x%x", common.HeapPagesKey, encoded)
+		}
+		return defaultHeapPages
+	}
+
+	return binary.LittleEndian.Uint64(encoded)
+}
+
+// initialMemoryPages returns the initial number of pages of the linear memory
+// the guest module imports or defines.
+func initialMemoryPages(guestModule wazero.CompiledModule) uint32 {
+	for _, memory := range guestModule.ImportedMemories() {
+		return memory.Min()
+	}
+	for _, memory := range guestModule.ExportedMemories() {
+		return memory.Min()
+	}
+	return 0
+}
+
+// maxMemoryPages returns the number of pages the linear memory can grow up to,
+// which are the initial pages of the runtime memory and its heap pages on top
+// of them, like the static heap allocation strategy of substrate.
+func maxMemoryPages(initialPages uint32, heapPages uint64) uint32 {
+	return uint32(min(uint64(initialPages)+heapPages, allocator.MaxWasmPages))
+}
+
+// newAllocator returns the heap allocator for a call of the runtime, limited
+// to the heap pages of the runtime.
+func newAllocator(storage runtime.Storage, heapPagesOverride uint64,
+	heapBase, initialPages uint32) *allocator.FreeingBumpHeapAllocator {
+	pages := heapPages(storage, heapPagesOverride)
+	return allocator.NewFreeingBumpHeapAllocatorWithMaxPages(heapBase, maxMemoryPages(initialPages, pages))
+}
+
+// recordMemoryUsage sets the memory metrics of the runtime with the given code hash.
+func recordMemoryUsage(codeHash common.Hash, memorySize uint64, maxPages uint32) {
+	label := codeHash.String()
+	memoryPagesGauge.WithLabelValues(label).Set(float64(memorySize / allocator.PageSize))
+	memoryMaxPagesGauge.WithLabelValues(label).Set(float64(maxPages))
+}
@@ -0,0 +1,107 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package wazero_runtime
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime/allocator"
+	"github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tetratelabs/wazero"
+)
+
+func Test_heapPages(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		storageValue []byte
+		override     uint64
+		pages        uint64
+	}{
+		"not_set": {
+			pages: defaultHeapPages,
+		},
+		"storage": {
+			storageValue: []byte{0, 4, 0, 0, 0, 0, 0, 0},
+			pages:        1024,
+		},
+		"override": {
+			storageValue: []byte{0, 4, 0, 0, 0, 0, 0, 0},
+			override:     4096,
+			pages:        4096,
+		},
+		"invalid_storage_value": {
+			storageValue: []byte{1, 2},
+			pages:        defaultHeapPages,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			trieState := storage.NewTrieState(inmemory.NewEmptyTrie())
+			if testCase.storageValue != nil {
+				trieState.StartTransaction()
+				require.NoError(t, trieState.Put(common.HeapPagesKey, testCase.storageValue))
+				trieState.CommitTransaction()
+			}
+
+			assert.Equal(t, testCase.pages, heapPages(trieState, testCase.override))
+		})
+	}
+}
+
+func Test_maxMemoryPages(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, uint32(2048), maxMemoryPages(0, 2048))
+	assert.Equal(t, uint32(2065), maxMemoryPages(17, 2048))
+	assert.Equal(t, uint32(allocator.MaxWasmPages), maxMemoryPages(17, 1<<40))
+}
+
+func Test_initialMemoryPages(t *testing.T) {
+	t.Parallel()
+
+	header := []byte{0x00, 'a', 's', 'm', 0x01, 0x00, 0x00, 0x00}
+	testCases := map[string]struct {
+		sections []byte
+		pages    uint32
+	}{
+		"imported_memory": {
+			// import section with the env memory of 17 pages
+			sections: []byte{0x02, 0x0f, 0x01, 0x03, 'e', 'n', 'v',
+				0x06, 'm', 'e', 'm', 'o', 'r', 'y', 0x02, 0x00, 0x11},
+			pages: 17,
+		},
+		"exported_memory": {
+			// memory section with a memory of 5 pages, and export section of the memory
+			sections: []byte{0x05, 0x03, 0x01, 0x00, 0x05,
+				0x07, 0x0a, 0x01, 0x06, 'm', 'e', 'm', 'o', 'r', 'y', 0x02, 0x00},
+			pages: 5,
+		},
+		"no_memory": {},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			rt := wazero.NewRuntime(ctx)
+			defer rt.Close(ctx)
+
+			guestModule, err := rt.CompileModule(ctx, append(header, testCase.sections...))
+			require.NoError(t, err)
+			assert.Equal(t, testCase.pages, initialMemoryPages(guestModule))
+		})
+	}
+}
@@ -10,4 +10,5 @@ type Logger interface {
 	Infof(format string, args ...interface{})
 	Warnf(format string, args ...interface{})
 	Errorf(format string, args ...interface{})
+	Criticalf(format string, args ...interface{})
 }
@@ -0,0 +1,208 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package services
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PanicPolicy is the action taken by a supervisor when a goroutine of its subsystem panics.
+type PanicPolicy string
+
+const (
+	// PanicPolicyShutdown requests a clean shutdown of the node.
+	PanicPolicyShutdown PanicPolicy = "shutdown"
+	// PanicPolicyRestart runs the goroutine which panicked again, unless the goroutines
+	// of the subsystem were restarted too many times recently, in which case it is
+	// left stopped so the other subsystems keep running.
+	PanicPolicyRestart PanicPolicy = "restart"
+)
+
+const (
+	// maxRestarts is the maximum number of restarts of the goroutines of a subsystem
+	// within restartWindow.
+	maxRestarts   = 5
+	restartWindow = time.Minute
+)
+
+var ErrPanicPolicyNotValid = errors.New("panic policy is not valid")
+
+// ParsePanicPolicies parses a comma separated list of 'subsystem=policy', such as
+// "rpc=restart,telemetry=restart", and returns the policies by subsystem name.
+func ParsePanicPolicies(s string) (policies map[string]PanicPolicy, err error) {
+	policies = make(map[string]PanicPolicy)
+	if strings.TrimSpace(s) == "" {
+		return policies, nil
+	}
+
+	for _, entry := range strings.Split(s, ",") {
+		parts := strings.Split(entry, "=")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("%w: %q is not in the format 'subsystem=policy'", ErrPanicPolicyNotValid, entry)
+		}
+
+		subsystem := strings.TrimSpace(parts[0])
+		policy := PanicPolicy(strings.TrimSpace(parts[1]))
+		switch policy {
+		case PanicPolicyShutdown, PanicPolicyRestart:
+		default:
+			return nil, fmt.Errorf("%w: %q for subsystem %s", ErrPanicPolicyNotValid, policy, subsystem)
+		}
+		policies[subsystem] = policy
+	}
+
+	return policies, nil
+}
+
+// Snapshotter can be implemented by subsystems to describe their state,
+// which is logged when one of their goroutines panics.
+type Snapshotter interface {
+	Snapshot() string
+}
+
+// Supervisors creates the supervisors of the subsystems of a node, which share
+// the shutdown requested when a goroutine panics.
+type Supervisors struct {
+	policies     map[string]PanicPolicy
+	logger       Logger
+	shutdown     chan struct{}
+	shutdownOnce sync.Once
+}
+
+// NewSupervisors creates supervisors using the panic policies by subsystem name.
+// Subsystems without a policy are shut down when one of their goroutines panics.
+func NewSupervisors(policies map[string]PanicPolicy, logger Logger) *Supervisors {
+	return &Supervisors{
+		policies: policies,
+		logger:   logger,
+		shutdown: make(chan struct{}),
+	}
+}
+
+// Supervisor returns a new supervisor for the goroutines of the subsystem.
+func (s *Supervisors) Supervisor(subsystem string) *Supervisor {
+	policy, ok := s.policies[subsystem]
+	if !ok {
+		policy = PanicPolicyShutdown
+	}
+
+	return &Supervisor{
+		subsystem:   subsystem,
+		policy:      policy,
+		supervisors: s,
+	}
+}
+
+// Shutdown returns a channel closed once a shutdown is requested by a supervisor.
+func (s *Supervisors) Shutdown() <-chan struct{} {
+	return s.shutdown
+}
+
+func (s *Supervisors) requestShutdown() {
+	s.shutdownOnce.Do(func() {
+		close(s.shutdown)
+	})
+}
+
+// Supervisor runs the goroutines of a subsystem, and recovers from their panics
+// according to the panic policy of the subsystem, so a panic never takes down
+// the other subsystems of the node.
+type Supervisor struct {
+	subsystem   string
+	policy      PanicPolicy
+	supervisors *Supervisors
+
+	mtx         sync.Mutex
+	snapshotter Snapshotter
+	restarts    []time.Time
+}
+
+// SetSnapshotter sets the snapshotter describing the state of the subsystem.
+func (s *Supervisor) SetSnapshotter(snapshotter Snapshotter) {
+	if s == nil {
+		return
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.snapshotter = snapshotter
+}
+
+// Go runs the function in a goroutine supervised by the supervisor.
+// If the supervisor is nil, the goroutine is not supervised.
+func (s *Supervisor) Go(fn func()) {
+	if s == nil {
+		go fn()
+		return
+	}
+
+	go s.run(fn)
+}
+
+func (s *Supervisor) run(fn func()) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+
+		logger := s.supervisors.logger
+		logger.Criticalf("goroutine of subsystem %s panicked: %v\nsubsystem state: %s\n%s",
+			s.subsystem, r, s.snapshot(), debug.Stack())
+
+		if s.policy != PanicPolicyRestart {
+			logger.Errorf("shutting down after panic in subsystem %s", s.subsystem)
+			s.supervisors.requestShutdown()
+			return
+		}
+
+		if !s.restart(time.Now()) {
+			logger.Errorf("not restarting goroutine of subsystem %s: restarted %d times in the last %s",
+				s.subsystem, maxRestarts, restartWindow)
+			return
+		}
+
+		logger.Warnf("restarting goroutine of subsystem %s", s.subsystem)
+		go s.run(fn)
+	}()
+
+	fn()
+}
+
+func (s *Supervisor) snapshot() string {
+	s.mtx.Lock()
+	snapshotter := s.snapshotter
+	s.mtx.Unlock()
+
+	if snapshotter == nil {
+		return "not available"
+	}
+	return snapshotter.Snapshot()
+}
+
+// restart records a restart at the given time, and returns false if the
+// subsystem was restarted too many times within the restart window.
+func (s *Supervisor) restart(now time.Time) (ok bool) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	recent := s.restarts[:0]
+	for _, restart := range s.restarts {
+		if now.Sub(restart) < restartWindow {
+			recent = append(recent, restart)
+		}
+	}
+	s.restarts = recent
+
+	if len(s.restarts) >= maxRestarts {
+		return false
+	}
+	s.restarts = append(s.restarts, now)
+	return true
+}
@@ -0,0 +1,147 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package services
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testSnapshotter string
+
+func (s testSnapshotter) Snapshot() string { return string(s) }
+
+func Test_ParsePanicPolicies(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		s          string
+		policies   map[string]PanicPolicy
+		errWrapped error
+		errMessage string
+	}{
+		"empty": {
+			policies: map[string]PanicPolicy{},
+		},
+		"policies": {
+			s: "rpc=restart, telemetry = shutdown",
+			policies: map[string]PanicPolicy{
+				"rpc":       PanicPolicyRestart,
+				"telemetry": PanicPolicyShutdown,
+			},
+		},
+		"bad_format": {
+			s:          "rpc",
+			errWrapped: ErrPanicPolicyNotValid,
+			errMessage: "panic policy is not valid: \"rpc\" is not in the format 'subsystem=policy'",
+		},
+		"unknown_policy": {
+			s:          "rpc=ignore",
+			errWrapped: ErrPanicPolicyNotValid,
+			errMessage: "panic policy is not valid: \"ignore\" for subsystem rpc",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			policies, err := ParsePanicPolicies(testCase.s)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.policies, policies)
+		})
+	}
+}
+
+func Test_Supervisor_Go_restart(t *testing.T) {
+	t.Parallel()
+
+	supervisors := NewSupervisors(map[string]PanicPolicy{"rpc": PanicPolicyRestart},
+		log.New(log.SetWriter(io.Discard)))
+	supervisor := supervisors.Supervisor("rpc")
+	supervisor.SetSnapshotter(testSnapshotter("state"))
+
+	runs := make(chan int)
+	run := 0
+	supervisor.Go(func() {
+		run++
+		runs <- run
+		panic("test panic")
+	})
+
+	// the goroutine is restarted until it is restarted too many times
+	for expected := 1; expected <= maxRestarts+1; expected++ {
+		assert.Equal(t, expected, <-runs)
+	}
+
+	select {
+	case run := <-runs:
+		t.Fatalf("goroutine restarted %d times", run)
+	case <-supervisors.Shutdown():
+		t.Fatal("shutdown requested")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func Test_Supervisor_Go_shutdown(t *testing.T) {
+	t.Parallel()
+
+	supervisors := NewSupervisors(nil, log.New(log.SetWriter(io.Discard)))
+	supervisor := supervisors.Supervisor("telemetry")
+
+	supervisor.Go(func() {
+		panic("test panic")
+	})
+
+	select {
+	case <-supervisors.Shutdown():
+	case <-time.After(time.Second):
+		t.Fatal("shutdown not requested")
+	}
+
+	// other panics do not close the shutdown channel again
+	done := make(chan struct{})
+	supervisor.Go(func() {
+		defer close(done)
+		panic("test panic")
+	})
+	<-done
+}
+
+func Test_Supervisor_Go_nil(t *testing.T) {
+	t.Parallel()
+
+	var supervisor *Supervisor
+	supervisor.SetSnapshotter(testSnapshotter("state"))
+
+	done := make(chan struct{})
+	supervisor.Go(func() {
+		close(done)
+	})
+	<-done
+}
+
+func Test_Supervisor_restart(t *testing.T) {
+	t.Parallel()
+
+	supervisor := &Supervisor{}
+	now := time.Now()
+	for i := 0; i < maxRestarts; i++ {
+		require.True(t, supervisor.restart(now))
+	}
+	assert.False(t, supervisor.restart(now))
+
+	// restarts older than the restart window are forgotten
+	assert.True(t, supervisor.restart(now.Add(restartWindow)))
+	assert.Len(t, supervisor.restarts, 1)
+}
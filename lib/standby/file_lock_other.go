@@ -0,0 +1,17 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+//go:build !unix
+
+package standby
+
+import (
+	"errors"
+	"os"
+)
+
+var errFileLockNotSupported = errors.New("file locks are not supported on this platform")
+
+func lockFile(*os.File) error {
+	return errFileLockNotSupported
+}
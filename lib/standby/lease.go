@@ -0,0 +1,183 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package standby
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// Lease is shared by an active node and its standby nodes running with the same authority
+// keys. Only the holder of the unexpired lease authors blocks and votes. The slots and rounds
+// it authors and votes in are recorded in the lease, so a node taking over the lease never
+// authors in a slot or votes in a round the previous holder authored or voted in.
+type Lease interface {
+	// Acquire acquires the lease for the holder for the given duration, or extends it if the
+	// holder already holds it. It returns false if another holder holds the unexpired lease.
+	Acquire(holder string, ttl time.Duration) (acquired bool, err error)
+	// Release releases the lease if the holder holds it.
+	Release(holder string) error
+	// LockSlot records the holder authors a block in the slot. It returns false if the holder
+	// does not hold the unexpired lease, or if the slot is older than the last slot locked,
+	// or if the slot was locked by another holder.
+	LockSlot(holder string, slot uint64) (locked bool, err error)
+	// LockRound records the holder votes in the round of the set id. It returns false if the
+	// holder does not hold the unexpired lease, or if the round is older than the last round
+	// locked, or if the round was locked by another holder.
+	LockRound(holder string, setID, round uint64) (locked bool, err error)
+}
+
+// leaseRecord is the content of the lease file.
+type leaseRecord struct {
+	Holder      string    `json:"holder"`
+	Expiry      time.Time `json:"expiry"`
+	Slot        uint64    `json:"slot"`
+	SlotHolder  string    `json:"slotHolder"`
+	SetID       uint64    `json:"setId"`
+	Round       uint64    `json:"round"`
+	RoundHolder string    `json:"roundHolder"`
+}
+
+func (r *leaseRecord) heldBy(holder string, now time.Time) bool {
+	return r.Holder == holder && now.Before(r.Expiry)
+}
+
+var _ Lease = (*FileLease)(nil)
+
+// FileLease is a lease stored in a file on a filesystem shared by the nodes, which must
+// support advisory file locks. The nodes clocks are expected to be synchronised, although
+// a clock drift only delays the standby node taking over and never leads to equivocations.
+type FileLease struct {
+	path string
+	now  func() time.Time
+}
+
+// NewFileLease returns a lease stored in the file at the given path, created if it does not exist.
+func NewFileLease(path string) *FileLease {
+	return &FileLease{
+		path: path,
+		now:  time.Now,
+	}
+}
+
+// Acquire acquires or extends the lease for the holder.
+func (f *FileLease) Acquire(holder string, ttl time.Duration) (acquired bool, err error) {
+	err = f.update(func(record *leaseRecord, now time.Time) (write bool) {
+		if record.Holder != holder && now.Before(record.Expiry) {
+			return false
+		}
+		record.Holder = holder
+		record.Expiry = now.Add(ttl)
+		acquired = true
+		return true
+	})
+	return acquired, err
+}
+
+// Release releases the lease if the holder holds it.
+func (f *FileLease) Release(holder string) error {
+	return f.update(func(record *leaseRecord, now time.Time) (write bool) {
+		if !record.heldBy(holder, now) {
+			return false
+		}
+		record.Expiry = now
+		return true
+	})
+}
+
+// LockSlot records the holder authors a block in the slot.
+func (f *FileLease) LockSlot(holder string, slot uint64) (locked bool, err error) {
+	err = f.update(func(record *leaseRecord, now time.Time) (write bool) {
+		switch {
+		case !record.heldBy(holder, now), slot < record.Slot:
+			return false
+		case slot == record.Slot:
+			locked = record.SlotHolder == holder
+			return false
+		}
+		record.Slot = slot
+		record.SlotHolder = holder
+		locked = true
+		return true
+	})
+	return locked, err
+}
+
+// LockRound records the holder votes in the round of the set id.
+func (f *FileLease) LockRound(holder string, setID, round uint64) (locked bool, err error) {
+	err = f.update(func(record *leaseRecord, now time.Time) (write bool) {
+		switch {
+		case !record.heldBy(holder, now),
+			setID < record.SetID,
+			setID == record.SetID && round < record.Round:
+			return false
+		case setID == record.SetID && round == record.Round:
+			locked = record.RoundHolder == holder
+			return false
+		}
+		record.SetID = setID
+		record.Round = round
+		record.RoundHolder = holder
+		locked = true
+		return true
+	})
+	return locked, err
+}
+
+// update locks the lease file, decodes its record and calls the update function with it,
+// and writes the record back to the file if the update function returns true.
+func (f *FileLease) update(updateFn func(record *leaseRecord, now time.Time) (write bool)) (err error) {
+	file, err := os.OpenFile(f.path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return fmt.Errorf("opening lease file: %w", err)
+	}
+	defer func() {
+		closeErr := file.Close()
+		if err == nil && closeErr != nil {
+			err = fmt.Errorf("closing lease file: %w", closeErr)
+		}
+	}()
+
+	err = lockFile(file)
+	if err != nil {
+		return fmt.Errorf("locking lease file: %w", err)
+	}
+	// the lock is released when the file is closed
+
+	var record leaseRecord
+	err = json.NewDecoder(file).Decode(&record)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("decoding lease: %w", err)
+	}
+
+	if !updateFn(&record, f.now()) {
+		return nil
+	}
+
+	encodedRecord, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("encoding lease: %w", err)
+	}
+
+	err = file.Truncate(0)
+	if err != nil {
+		return fmt.Errorf("truncating lease file: %w", err)
+	}
+
+	_, err = file.WriteAt(encodedRecord, 0)
+	if err != nil {
+		return fmt.Errorf("writing lease file: %w", err)
+	}
+
+	err = file.Sync()
+	if err != nil {
+		return fmt.Errorf("syncing lease file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,148 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package standby
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestFileLease(t *testing.T, now *time.Time) *FileLease {
+	t.Helper()
+	lease := NewFileLease(filepath.Join(t.TempDir(), "lease"))
+	lease.now = func() time.Time { return *now }
+	return lease
+}
+
+func Test_FileLease_Acquire(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1000, 0)
+	lease := newTestFileLease(t, &now)
+
+	acquired, err := lease.Acquire("a", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+
+	acquired, err = lease.Acquire("b", time.Minute)
+	require.NoError(t, err)
+	assert.False(t, acquired)
+
+	// the holder extends its lease
+	now = now.Add(59 * time.Second)
+	acquired, err = lease.Acquire("a", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+
+	now = now.Add(59 * time.Second)
+	acquired, err = lease.Acquire("b", time.Minute)
+	require.NoError(t, err)
+	assert.False(t, acquired)
+
+	// the lease lapsed
+	now = now.Add(time.Second)
+	acquired, err = lease.Acquire("b", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+
+	err = lease.Release("a")
+	require.NoError(t, err)
+	acquired, err = lease.Acquire("a", time.Minute)
+	require.NoError(t, err)
+	assert.False(t, acquired)
+
+	err = lease.Release("b")
+	require.NoError(t, err)
+	acquired, err = lease.Acquire("a", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+}
+
+func Test_FileLease_LockSlot(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1000, 0)
+	lease := newTestFileLease(t, &now)
+
+	locked, err := lease.LockSlot("a", 10)
+	require.NoError(t, err)
+	assert.False(t, locked, "lease not held")
+
+	_, err = lease.Acquire("a", time.Minute)
+	require.NoError(t, err)
+
+	locked, err = lease.LockSlot("a", 10)
+	require.NoError(t, err)
+	assert.True(t, locked)
+
+	locked, err = lease.LockSlot("a", 10)
+	require.NoError(t, err)
+	assert.True(t, locked)
+
+	locked, err = lease.LockSlot("a", 9)
+	require.NoError(t, err)
+	assert.False(t, locked)
+
+	// the previous holder authored in the slot before its lease lapsed
+	now = now.Add(time.Minute)
+	_, err = lease.Acquire("b", time.Minute)
+	require.NoError(t, err)
+
+	locked, err = lease.LockSlot("b", 10)
+	require.NoError(t, err)
+	assert.False(t, locked)
+
+	locked, err = lease.LockSlot("a", 11)
+	require.NoError(t, err)
+	assert.False(t, locked, "lease lost")
+
+	locked, err = lease.LockSlot("b", 11)
+	require.NoError(t, err)
+	assert.True(t, locked)
+}
+
+func Test_FileLease_LockRound(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1000, 0)
+	lease := newTestFileLease(t, &now)
+
+	_, err := lease.Acquire("a", time.Minute)
+	require.NoError(t, err)
+
+	locked, err := lease.LockRound("a", 1, 5)
+	require.NoError(t, err)
+	assert.True(t, locked)
+
+	locked, err = lease.LockRound("a", 1, 5)
+	require.NoError(t, err)
+	assert.True(t, locked)
+
+	locked, err = lease.LockRound("a", 0, 6)
+	require.NoError(t, err)
+	assert.False(t, locked)
+
+	now = now.Add(time.Minute)
+	_, err = lease.Acquire("b", time.Minute)
+	require.NoError(t, err)
+
+	locked, err = lease.LockRound("b", 1, 5)
+	require.NoError(t, err)
+	assert.False(t, locked)
+
+	locked, err = lease.LockRound("b", 2, 1)
+	require.NoError(t, err)
+	assert.True(t, locked)
+
+	// the lease survives being reopened
+	reopened := NewFileLease(lease.path)
+	reopened.now = lease.now
+	locked, err = reopened.LockRound("b", 2, 1)
+	require.NoError(t, err)
+	assert.True(t, locked)
+}
@@ -0,0 +1,146 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package standby
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// DefaultLeaseTTL is the default duration of the lease, after which a standby node
+// takes over authoring and voting if the active node did not renew it.
+const DefaultLeaseTTL = 30 * time.Second
+
+var (
+	logger = log.NewFromGlobal(log.AddContext("pkg", "standby"))
+
+	activeGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "gossamer_standby",
+		Name:      "active",
+		Help:      "1 if the node holds the standby lease and authors blocks and votes, 0 otherwise",
+	})
+)
+
+// Config is the configuration of the standby service.
+type Config struct {
+	// Lease is the lease shared with the other nodes running with the same authority keys.
+	Lease Lease
+	// Holder identifies the node in the lease, and must be unique among the nodes.
+	Holder string
+	// LeaseTTL is the duration of the lease, renewed every third of it by the active node.
+	// It defaults to DefaultLeaseTTL.
+	LeaseTTL time.Duration
+}
+
+// Service runs the node either as the active node, authoring blocks and voting, or as a
+// standby node taking over once the lease of the active node lapses. It guards the block
+// authoring and voting of the node, which are only allowed if the node holds the lease.
+type Service struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	lease  Lease
+	holder string
+	ttl    time.Duration
+	active atomic.Bool
+}
+
+// NewService returns a new standby service.
+func NewService(cfg Config) *Service {
+	if cfg.LeaseTTL == 0 {
+		cfg.LeaseTTL = DefaultLeaseTTL
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Service{
+		ctx:    ctx,
+		cancel: cancel,
+		done:   make(chan struct{}),
+		lease:  cfg.Lease,
+		holder: cfg.Holder,
+		ttl:    cfg.LeaseTTL,
+	}
+}
+
+// Start tries to acquire the lease, and keeps renewing or acquiring it in the background.
+func (s *Service) Start() error {
+	s.heartbeat()
+	go s.run()
+	return nil
+}
+
+// Stop stops renewing the lease and releases it, so a standby node can take over.
+func (s *Service) Stop() error {
+	s.cancel()
+	<-s.done
+
+	s.setActive(false)
+	return s.lease.Release(s.holder)
+}
+
+// IsActive returns true if the node holds the lease.
+func (s *Service) IsActive() bool {
+	return s.active.Load()
+}
+
+// GuardSlot returns true if the node is allowed to author a block in the slot.
+func (s *Service) GuardSlot(slot uint64) (allowed bool, err error) {
+	if !s.IsActive() {
+		return false, nil
+	}
+	return s.lease.LockSlot(s.holder, slot)
+}
+
+// GuardVote returns true if the node is allowed to vote in the round of the set id.
+func (s *Service) GuardVote(round, setID uint64) (allowed bool, err error) {
+	if !s.IsActive() {
+		return false, nil
+	}
+	return s.lease.LockRound(s.holder, setID, round)
+}
+
+func (s *Service) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.ttl / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			s.heartbeat()
+		}
+	}
+}
+
+// heartbeat acquires or renews the lease. The node becomes a standby node if it fails to.
+func (s *Service) heartbeat() {
+	acquired, err := s.lease.Acquire(s.holder, s.ttl)
+	if err != nil {
+		logger.Errorf("acquiring lease: %s", err)
+	}
+	s.setActive(acquired)
+}
+
+func (s *Service) setActive(active bool) {
+	if s.active.Swap(active) == active {
+		return
+	}
+
+	if active {
+		activeGauge.Set(1)
+		logger.Infof("acquired lease as %s, authoring blocks and voting", s.holder)
+	} else {
+		activeGauge.Set(0)
+		logger.Infof("lost lease as %s, running as standby node", s.holder)
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package standby
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Service_takeOver(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "lease")
+	const ttl = 300 * time.Millisecond
+	active := NewService(Config{Lease: NewFileLease(path), Holder: "active", LeaseTTL: ttl})
+	standby := NewService(Config{Lease: NewFileLease(path), Holder: "standby", LeaseTTL: ttl})
+
+	err := active.Start()
+	require.NoError(t, err)
+	err = standby.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		err := standby.Stop()
+		assert.NoError(t, err)
+	})
+
+	assert.True(t, active.IsActive())
+	assert.False(t, standby.IsActive())
+
+	allowed, err := active.GuardVote(1, 0)
+	require.NoError(t, err)
+	assert.True(t, allowed)
+	allowed, err = standby.GuardSlot(1)
+	require.NoError(t, err)
+	assert.False(t, allowed)
+
+	err = active.Stop()
+	require.NoError(t, err)
+	assert.False(t, active.IsActive())
+
+	assert.Eventually(t, standby.IsActive, 2*ttl, ttl/10)
+
+	// the standby node does not vote in the round the active node voted in
+	allowed, err = standby.GuardVote(1, 0)
+	require.NoError(t, err)
+	assert.False(t, allowed)
+	allowed, err = standby.GuardVote(2, 0)
+	require.NoError(t, err)
+	assert.True(t, allowed)
+	allowed, err = standby.GuardSlot(1)
+	require.NoError(t, err)
+	assert.True(t, allowed)
+}
@@ -0,0 +1,192 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+// Package storagedecoder decodes the storage keys and values of a runtime into
+// JSON friendly values, using the type information of the runtime metadata.
+package storagedecoder
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	ctypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const storagePrefixLength = 32
+
+var (
+	// ErrUnsupportedMetadataVersion is returned when the metadata does not
+	// carry the type information needed to decode storage, before v14.
+	ErrUnsupportedMetadataVersion = errors.New("unsupported metadata version")
+	// ErrUnknownKey is returned when a storage key does not belong to any
+	// storage item of the metadata, such as the well known keys.
+	ErrUnknownKey = errors.New("unknown storage key")
+)
+
+// Entry is a decoded storage entry.
+type Entry struct {
+	Pallet string    `json:"pallet"`
+	Item   string    `json:"item"`
+	Keys   []KeyPart `json:"keys,omitempty"`
+	Value  any       `json:"value"`
+}
+
+// KeyPart is a decoded part of the key of a storage map entry. The value is
+// only decoded for hashers keeping the key, and the hash is empty for the
+// identity hasher.
+type KeyPart struct {
+	Hasher string `json:"hasher"`
+	Hash   string `json:"hash,omitempty"`
+	Value  any    `json:"value,omitempty"`
+}
+
+// storageItem is a storage item of the metadata.
+type storageItem struct {
+	pallet string
+	entry  ctypes.StorageEntryMetadataV14
+}
+
+// Decoder decodes storage entries using the metadata of a runtime.
+type Decoder struct {
+	types types
+	items map[[storagePrefixLength]byte]storageItem
+}
+
+// NewDecoder creates a decoder for the storage items of the metadata, which
+// must be at least v14.
+func NewDecoder(metadata *ctypes.Metadata) (*Decoder, error) {
+	if metadata.Version < 14 {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedMetadataVersion, metadata.Version)
+	}
+
+	d := &Decoder{
+		types: make(types, len(metadata.AsMetadataV14.Lookup.Types)),
+		items: make(map[[storagePrefixLength]byte]storageItem),
+	}
+	for i := range metadata.AsMetadataV14.Lookup.Types {
+		portableType := &metadata.AsMetadataV14.Lookup.Types[i]
+		d.types[portableType.ID.Int64()] = &portableType.Type
+	}
+
+	for _, pallet := range metadata.AsMetadataV14.Pallets {
+		if !pallet.HasStorage {
+			continue
+		}
+
+		palletHash, err := common.Twox128Hash([]byte(pallet.Storage.Prefix))
+		if err != nil {
+			return nil, fmt.Errorf("hashing pallet prefix: %w", err)
+		}
+
+		for _, entry := range pallet.Storage.Items {
+			itemHash, err := common.Twox128Hash([]byte(entry.Name))
+			if err != nil {
+				return nil, fmt.Errorf("hashing storage item name: %w", err)
+			}
+
+			var prefix [storagePrefixLength]byte
+			copy(prefix[:], palletHash)
+			copy(prefix[16:], itemHash)
+			d.items[prefix] = storageItem{pallet: string(pallet.Name), entry: entry}
+		}
+	}
+	return d, nil
+}
+
+// Decode decodes the storage entry of the key and value. It returns an error
+// wrapping ErrUnknownKey for keys of no storage item of the metadata.
+func (d *Decoder) Decode(key, value []byte) (entry Entry, err error) {
+	if len(key) < storagePrefixLength {
+		return entry, fmt.Errorf("%w: 0x%x", ErrUnknownKey, key)
+	}
+
+	item, ok := d.items[[storagePrefixLength]byte(key[:storagePrefixLength])]
+	if !ok {
+		return entry, fmt.Errorf("%w: 0x%x", ErrUnknownKey, key)
+	}
+
+	entry.Pallet = item.pallet
+	entry.Item = string(item.entry.Name)
+
+	valueType := item.entry.Type.AsPlainType
+	if item.entry.Type.IsMap {
+		valueType = item.entry.Type.AsMap.Value
+		entry.Keys, err = d.decodeKeys(item.entry.Type.AsMap, key[storagePrefixLength:])
+		if err != nil {
+			return entry, fmt.Errorf("decoding keys of %s.%s: %w", entry.Pallet, entry.Item, err)
+		}
+	}
+
+	entry.Value, err = d.types.decodeAll(valueType.Int64(), value)
+	if err != nil {
+		return entry, fmt.Errorf("decoding value of %s.%s: %w", entry.Pallet, entry.Item, err)
+	}
+	return entry, nil
+}
+
+// decodeKeys decodes the parts of the key of a map entry following the storage prefix.
+func (d *Decoder) decodeKeys(mapType ctypes.MapTypeV14, key []byte) ([]KeyPart, error) {
+	keyTypes := []ctypes.Si1LookupTypeID{mapType.Key}
+	if len(mapType.Hashers) > 1 {
+		keyType, ok := d.types[mapType.Key.Int64()]
+		if !ok {
+			return nil, fmt.Errorf("%w: %d", errTypeNotFound, mapType.Key.Int64())
+		}
+		if !keyType.Def.IsTuple || len(keyType.Def.Tuple) != len(mapType.Hashers) {
+			return nil, fmt.Errorf("key type %d is not a tuple of %d types", mapType.Key.Int64(), len(mapType.Hashers))
+		}
+		keyTypes = keyType.Def.Tuple
+	}
+
+	r := &reader{data: key}
+	parts := make([]KeyPart, len(mapType.Hashers))
+	for i, hasher := range mapType.Hashers {
+		name, hashLength, concat := hasherInfo(hasher)
+		parts[i].Hasher = name
+
+		if hashLength > 0 {
+			hash, err := r.read(hashLength)
+			if err != nil {
+				return nil, fmt.Errorf("reading %s hash: %w", name, err)
+			}
+			parts[i].Hash = common.BytesToHex(hash)
+		}
+
+		if !concat {
+			continue
+		}
+
+		value, err := d.types.decode(keyTypes[i].Int64(), r, 0)
+		if err != nil {
+			return nil, fmt.Errorf("decoding key %d: %w", i, err)
+		}
+		parts[i].Value = value
+	}
+
+	if r.remaining() > 0 {
+		return nil, fmt.Errorf("%w: %d bytes", errTrailingBytes, r.remaining())
+	}
+	return parts, nil
+}
+
+// hasherInfo returns the name of the hasher, the length of its hash and whether
+// the hashed data is appended to the hash.
+func hasherInfo(hasher ctypes.StorageHasherV10) (name string, hashLength int, concat bool) {
+	switch {
+	case hasher.IsBlake2_128:
+		return "Blake2_128", 16, false
+	case hasher.IsBlake2_256:
+		return "Blake2_256", 32, false
+	case hasher.IsBlake2_128Concat:
+		return "Blake2_128Concat", 16, true
+	case hasher.IsTwox128:
+		return "Twox128", 16, false
+	case hasher.IsTwox256:
+		return "Twox256", 32, false
+	case hasher.IsTwox64Concat:
+		return "Twox64Concat", 8, true
+	default:
+		return "Identity", 0, true
+	}
+}
@@ -0,0 +1,323 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package storagedecoder
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	ctypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	typeU8 = iota
+	typeU32
+	typeU128
+	typeAccountID
+	typeAccountInfo
+	typeAccountData
+	typeBytes
+	typeKeyTuple
+	typeStatus
+	typeStatusList
+	typeCompactU64
+	typeStr
+	typeI128
+	typeBool
+)
+
+func lookupID(id int64) ctypes.Si1LookupTypeID {
+	return ctypes.NewSi1LookupTypeIDFromUInt(uint64(id))
+}
+
+func primitiveType(primitive ctypes.Si0TypeDefPrimitive) ctypes.Si1Type {
+	return ctypes.Si1Type{Def: ctypes.Si1TypeDef{
+		IsPrimitive: true,
+		Primitive:   ctypes.Si1TypeDefPrimitive{Si0TypeDefPrimitive: primitive},
+	}}
+}
+
+func namedField(name string, typeID int64) ctypes.Si1Field {
+	return ctypes.Si1Field{HasName: true, Name: ctypes.Text(name), Type: lookupID(typeID)}
+}
+
+func newTestMetadata() *ctypes.Metadata {
+	registry := map[int64]ctypes.Si1Type{
+		typeU8:   primitiveType(ctypes.IsU8),
+		typeU32:  primitiveType(ctypes.IsU32),
+		typeU128: primitiveType(ctypes.IsU128),
+		typeAccountID: {Def: ctypes.Si1TypeDef{
+			IsComposite: true,
+			Composite: ctypes.Si1TypeDefComposite{Fields: []ctypes.Si1Field{{
+				Type: lookupID(typeBytes),
+			}}},
+		}},
+		typeBytes: {Def: ctypes.Si1TypeDef{
+			IsArray: true,
+			Array:   ctypes.Si1TypeDefArray{Len: 4, Type: lookupID(typeU8)},
+		}},
+		typeAccountInfo: {Def: ctypes.Si1TypeDef{
+			IsComposite: true,
+			Composite: ctypes.Si1TypeDefComposite{Fields: []ctypes.Si1Field{
+				namedField("nonce", typeU32),
+				namedField("data", typeAccountData),
+			}},
+		}},
+		typeAccountData: {Def: ctypes.Si1TypeDef{
+			IsComposite: true,
+			Composite: ctypes.Si1TypeDefComposite{Fields: []ctypes.Si1Field{
+				namedField("free", typeU128),
+			}},
+		}},
+		typeKeyTuple: {Def: ctypes.Si1TypeDef{
+			IsTuple: true,
+			Tuple:   ctypes.Si1TypeDefTuple{lookupID(typeU32), lookupID(typeAccountID)},
+		}},
+		typeStatus: {Def: ctypes.Si1TypeDef{
+			IsVariant: true,
+			Variant: ctypes.Si1TypeDefVariant{Variants: []ctypes.Si1Variant{
+				{Name: "Idle", Index: 0},
+				{Name: "Active", Index: 1, Fields: []ctypes.Si1Field{{Type: lookupID(typeCompactU64)}}},
+				{Name: "Named", Index: 2, Fields: []ctypes.Si1Field{
+					namedField("name", typeStr),
+					namedField("delta", typeI128),
+					namedField("flag", typeBool),
+				}},
+			}},
+		}},
+		typeStatusList: {Def: ctypes.Si1TypeDef{
+			IsSequence: true,
+			Sequence:   ctypes.Si1TypeDefSequence{Type: lookupID(typeStatus)},
+		}},
+		typeCompactU64: {Def: ctypes.Si1TypeDef{
+			IsCompact: true,
+			Compact:   ctypes.Si1TypeDefCompact{Type: lookupID(typeU32)},
+		}},
+		typeStr:  primitiveType(ctypes.IsStr),
+		typeI128: primitiveType(ctypes.IsI128),
+		typeBool: primitiveType(ctypes.IsBool),
+	}
+
+	metadata := &ctypes.Metadata{Version: 14}
+	for id, typ := range registry {
+		metadata.AsMetadataV14.Lookup.Types = append(metadata.AsMetadataV14.Lookup.Types,
+			ctypes.PortableTypeV14{ID: lookupID(id), Type: typ})
+	}
+
+	metadata.AsMetadataV14.Pallets = []ctypes.PalletMetadataV14{
+		{
+			Name:       "System",
+			HasStorage: true,
+			Storage: ctypes.StorageMetadataV14{
+				Prefix: "System",
+				Items: []ctypes.StorageEntryMetadataV14{
+					{
+						Name: "Number",
+						Type: ctypes.StorageEntryTypeV14{IsPlainType: true, AsPlainType: lookupID(typeU32)},
+					},
+					{
+						Name: "Account",
+						Type: ctypes.StorageEntryTypeV14{IsMap: true, AsMap: ctypes.MapTypeV14{
+							Hashers: []ctypes.StorageHasherV10{{IsBlake2_128Concat: true}},
+							Key:     lookupID(typeAccountID),
+							Value:   lookupID(typeAccountInfo),
+						}},
+					},
+				},
+			},
+		},
+		{
+			Name:       "Example",
+			HasStorage: true,
+			Storage: ctypes.StorageMetadataV14{
+				Prefix: "Example",
+				Items: []ctypes.StorageEntryMetadataV14{
+					{
+						Name: "Statuses",
+						Type: ctypes.StorageEntryTypeV14{IsMap: true, AsMap: ctypes.MapTypeV14{
+							Hashers: []ctypes.StorageHasherV10{{IsTwox64Concat: true}, {IsBlake2_128: true}},
+							Key:     lookupID(typeKeyTuple),
+							Value:   lookupID(typeStatusList),
+						}},
+					},
+				},
+			},
+		},
+		{Name: "NoStorage"},
+	}
+	return metadata
+}
+
+func storagePrefix(t *testing.T, pallet, item string) []byte {
+	t.Helper()
+
+	palletHash, err := common.Twox128Hash([]byte(pallet))
+	require.NoError(t, err)
+	itemHash, err := common.Twox128Hash([]byte(item))
+	require.NoError(t, err)
+	return append(palletHash, itemHash...)
+}
+
+func Test_NewDecoder_unsupportedVersion(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewDecoder(&ctypes.Metadata{Version: 13})
+	assert.ErrorIs(t, err, ErrUnsupportedMetadataVersion)
+	assert.EqualError(t, err, "unsupported metadata version: 13")
+}
+
+func Test_Decoder_Decode(t *testing.T) {
+	t.Parallel()
+
+	decoder, err := NewDecoder(newTestMetadata())
+	require.NoError(t, err)
+
+	accountKey := append(storagePrefix(t, "System", "Account"), bytes.Repeat([]byte{0xaa}, 16)...)
+	accountKey = append(accountKey, 1, 2, 3, 4)
+
+	statusesKey := append(storagePrefix(t, "Example", "Statuses"), bytes.Repeat([]byte{0xbb}, 8)...)
+	statusesKey = append(statusesKey, 7, 0, 0, 0)
+	statusesKey = append(statusesKey, bytes.Repeat([]byte{0xcc}, 16)...)
+
+	testCases := map[string]struct {
+		key        []byte
+		value      []byte
+		entry      Entry
+		errWrapped error
+		errMessage string
+	}{
+		"plain": {
+			key:   storagePrefix(t, "System", "Number"),
+			value: []byte{42, 0, 0, 0},
+			entry: Entry{Pallet: "System", Item: "Number", Value: uint32(42)},
+		},
+		"map": {
+			key:   accountKey,
+			value: append([]byte{5, 0, 0, 0, 0xe8, 0x03}, make([]byte, 14)...),
+			entry: Entry{
+				Pallet: "System",
+				Item:   "Account",
+				Keys: []KeyPart{{
+					Hasher: "Blake2_128Concat",
+					Hash:   "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+					Value:  "0x01020304",
+				}},
+				Value: map[string]any{
+					"nonce": uint32(5),
+					"data":  map[string]any{"free": "1000"},
+				},
+			},
+		},
+		"double_map": {
+			key: statusesKey,
+			value: []byte{
+				3 << 2,
+				0,
+				1, 0x15, 0x01,
+				2, 2 << 2, 'h', 'i',
+				0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+				1,
+			},
+			entry: Entry{
+				Pallet: "Example",
+				Item:   "Statuses",
+				Keys: []KeyPart{
+					{Hasher: "Twox64Concat", Hash: "0xbbbbbbbbbbbbbbbb", Value: uint32(7)},
+					{Hasher: "Blake2_128", Hash: "0xcccccccccccccccccccccccccccccccc"},
+				},
+				Value: []any{
+					"Idle",
+					map[string]any{"Active": uint64(69)},
+					map[string]any{"Named": map[string]any{"name": "hi", "delta": "-1", "flag": true}},
+				},
+			},
+		},
+		"unknown_key": {
+			key:        common.CodeKey,
+			errWrapped: ErrUnknownKey,
+			errMessage: "unknown storage key: 0x3a636f6465",
+		},
+		"trailing_value_bytes": {
+			key:        storagePrefix(t, "System", "Number"),
+			value:      []byte{42, 0, 0, 0, 1},
+			errWrapped: errTrailingBytes,
+			errMessage: "decoding value of System.Number: trailing bytes: 1 bytes",
+		},
+		"short_value": {
+			key:        storagePrefix(t, "System", "Number"),
+			value:      []byte{42},
+			errMessage: "decoding value of System.Number: reading 4 bytes: unexpected EOF",
+		},
+		"short_key": {
+			key:        accountKey[:50],
+			errMessage: "decoding keys of System.Account: decoding key 0: reading 4 bytes: unexpected EOF",
+		},
+		"invalid_variant": {
+			key:        statusesKey,
+			value:      []byte{1 << 2, 9},
+			errWrapped: errInvalidVariant,
+			errMessage: "decoding value of Example.Statuses: decoding element 0: " +
+				"invalid variant index: 9 for type 8",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			entry, err := decoder.Decode(testCase.key, testCase.value)
+			if testCase.errMessage != "" {
+				if testCase.errWrapped != nil {
+					assert.ErrorIs(t, err, testCase.errWrapped)
+				}
+				assert.EqualError(t, err, testCase.errMessage)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.entry, entry)
+
+			_, err = json.Marshal(entry)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_reader_readCompact(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		encoded []byte
+		value   string
+		err     error
+	}{
+		"single_byte": {encoded: []byte{0xfc}, value: "63"},
+		"two_bytes":   {encoded: []byte{0x15, 0x01}, value: "69"},
+		"four_bytes":  {encoded: []byte{0x02, 0x00, 0x01, 0x00}, value: "16384"},
+		"big_integer": {
+			encoded: []byte{0x13, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+			value:   "18446744073709551615",
+		},
+		"not_canonical": {encoded: []byte{0x03, 1, 0, 0, 0}, err: errInvalidCompact},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			r := &reader{data: testCase.encoded}
+			value, err := r.readCompact()
+			assert.ErrorIs(t, err, testCase.err)
+			if testCase.err == nil {
+				assert.Equal(t, testCase.value, value.String())
+				assert.Zero(t, r.remaining())
+			}
+		})
+	}
+}
@@ -0,0 +1,396 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package storagedecoder
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"unicode/utf8"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	ctypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// maxTypeDepth is the maximum nesting of decoded types, guarding against
+// recursive types decoded from malformed data.
+const maxTypeDepth = 256
+
+var (
+	errTypeNotFound      = errors.New("type not found")
+	errTrailingBytes     = errors.New("trailing bytes")
+	errMaxDepth          = errors.New("maximum type depth reached")
+	errUnsupportedType   = errors.New("unsupported type")
+	errInvalidVariant    = errors.New("invalid variant index")
+	errInvalidBool       = errors.New("invalid bool")
+	errInvalidString     = errors.New("invalid utf-8 string")
+	errInvalidCompact    = errors.New("invalid compact integer")
+	errLengthOutOfBounds = errors.New("length out of bounds")
+)
+
+// reader reads SCALE encoded data.
+type reader struct {
+	data   []byte
+	offset int
+}
+
+func (r *reader) remaining() int {
+	return len(r.data) - r.offset
+}
+
+func (r *reader) read(n int) ([]byte, error) {
+	if n < 0 || n > r.remaining() {
+		return nil, fmt.Errorf("reading %d bytes: %w", n, io.ErrUnexpectedEOF)
+	}
+	b := r.data[r.offset : r.offset+n]
+	r.offset += n
+	return b, nil
+}
+
+// readCompact reads a compact encoded integer.
+func (r *reader) readCompact() (*big.Int, error) {
+	first, err := r.read(1)
+	if err != nil {
+		return nil, err
+	}
+
+	switch first[0] & 0b11 {
+	case 0b00:
+		return big.NewInt(int64(first[0] >> 2)), nil
+	case 0b01:
+		next, err := r.read(1)
+		if err != nil {
+			return nil, err
+		}
+		return big.NewInt(int64(binary.LittleEndian.Uint16([]byte{first[0], next[0]}) >> 2)), nil
+	case 0b10:
+		next, err := r.read(3)
+		if err != nil {
+			return nil, err
+		}
+		value := binary.LittleEndian.Uint32([]byte{first[0], next[0], next[1], next[2]})
+		return big.NewInt(int64(value >> 2)), nil
+	default:
+		length := int(first[0]>>2) + 4
+		encoded, err := r.read(length)
+		if err != nil {
+			return nil, err
+		}
+		if encoded[length-1] == 0 {
+			return nil, fmt.Errorf("%w: not canonical", errInvalidCompact)
+		}
+		return littleEndianInt(encoded), nil
+	}
+}
+
+// readLength reads a compact encoded length, checked against the remaining data.
+func (r *reader) readLength() (int, error) {
+	length, err := r.readCompact()
+	if err != nil {
+		return 0, err
+	}
+	if !length.IsInt64() || length.Int64() > int64(r.remaining()) {
+		return 0, fmt.Errorf("%w: %s", errLengthOutOfBounds, length)
+	}
+	return int(length.Int64()), nil
+}
+
+func littleEndianInt(b []byte) *big.Int {
+	bigEndian := make([]byte, len(b))
+	for i := range b {
+		bigEndian[len(b)-1-i] = b[i]
+	}
+	return new(big.Int).SetBytes(bigEndian)
+}
+
+// types is the type registry of the metadata, indexed by type id.
+type types map[int64]*ctypes.Si1Type
+
+// decodeAll decodes the data as the type, which must consume all the data.
+func (t types) decodeAll(typeID int64, data []byte) (any, error) {
+	r := &reader{data: data}
+	value, err := t.decode(typeID, r, 0)
+	if err != nil {
+		return nil, err
+	}
+	if r.remaining() > 0 {
+		return nil, fmt.Errorf("%w: %d bytes", errTrailingBytes, r.remaining())
+	}
+	return value, nil
+}
+
+// decode decodes the type from the reader into a value which can be marshalled to JSON:
+// composites with named fields are maps, sequences, arrays and tuples are slices,
+// byte sequences and arrays are hex strings and integers larger than 64 bits are
+// decimal strings. Variants without fields are their name, and variants with fields
+// are a map of their name to their fields.
+func (t types) decode(typeID int64, r *reader, depth int) (any, error) {
+	if depth > maxTypeDepth {
+		return nil, errMaxDepth
+	}
+
+	typ, ok := t[typeID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", errTypeNotFound, typeID)
+	}
+
+	def := typ.Def
+	switch {
+	case def.IsComposite:
+		return t.decodeFields(def.Composite.Fields, r, depth)
+	case def.IsVariant:
+		index, err := r.read(1)
+		if err != nil {
+			return nil, err
+		}
+		for _, variant := range def.Variant.Variants {
+			if byte(variant.Index) != index[0] {
+				continue
+			}
+			if len(variant.Fields) == 0 {
+				return string(variant.Name), nil
+			}
+			fields, err := t.decodeFields(variant.Fields, r, depth)
+			if err != nil {
+				return nil, fmt.Errorf("decoding variant %s: %w", variant.Name, err)
+			}
+			return map[string]any{string(variant.Name): fields}, nil
+		}
+		return nil, fmt.Errorf("%w: %d for type %d", errInvalidVariant, index[0], typeID)
+	case def.IsSequence:
+		length, err := r.readLength()
+		if err != nil {
+			return nil, err
+		}
+		return t.decodeElements(def.Sequence.Type.Int64(), length, r, depth)
+	case def.IsArray:
+		return t.decodeElements(def.Array.Type.Int64(), int(def.Array.Len), r, depth)
+	case def.IsTuple:
+		values := make([]any, len(def.Tuple))
+		for i, elementType := range def.Tuple {
+			value, err := t.decode(elementType.Int64(), r, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = value
+		}
+		return values, nil
+	case def.IsPrimitive:
+		return decodePrimitive(def.Primitive.Si0TypeDefPrimitive, r)
+	case def.IsCompact:
+		value, err := r.readCompact()
+		if err != nil {
+			return nil, err
+		}
+		if value.IsUint64() {
+			return value.Uint64(), nil
+		}
+		return value.String(), nil
+	case def.IsBitSequence:
+		return t.decodeBitSequence(def.BitSequence, r)
+	default:
+		return nil, fmt.Errorf("%w: %d", errUnsupportedType, typeID)
+	}
+}
+
+// decodeFields decodes the fields of a composite or variant. Named fields are
+// decoded into a map, a single unnamed field into its value and several
+// unnamed fields into a slice.
+func (t types) decodeFields(fields []ctypes.Si1Field, r *reader, depth int) (any, error) {
+	if len(fields) > 0 && fields[0].HasName {
+		values := make(map[string]any, len(fields))
+		for _, field := range fields {
+			value, err := t.decode(field.Type.Int64(), r, depth+1)
+			if err != nil {
+				return nil, fmt.Errorf("decoding field %s: %w", field.Name, err)
+			}
+			values[string(field.Name)] = value
+		}
+		return values, nil
+	}
+
+	if len(fields) == 1 {
+		return t.decode(fields[0].Type.Int64(), r, depth+1)
+	}
+
+	values := make([]any, len(fields))
+	for i, field := range fields {
+		value, err := t.decode(field.Type.Int64(), r, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// decodeElements decodes the elements of a sequence or array, decoding bytes
+// into a hex string.
+func (t types) decodeElements(elementType int64, length int, r *reader, depth int) (any, error) {
+	if t.isU8(elementType) {
+		b, err := r.read(length)
+		if err != nil {
+			return nil, err
+		}
+		return common.BytesToHex(b), nil
+	}
+
+	if length > r.remaining() {
+		// every element is at least a byte long, except zero sized types which
+		// are not stored in sequences
+		return nil, fmt.Errorf("%w: %d elements", errLengthOutOfBounds, length)
+	}
+
+	values := make([]any, length)
+	for i := range values {
+		value, err := t.decode(elementType, r, depth+1)
+		if err != nil {
+			return nil, fmt.Errorf("decoding element %d: %w", i, err)
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+func (t types) isU8(typeID int64) bool {
+	typ, ok := t[typeID]
+	return ok && typ.Def.IsPrimitive && typ.Def.Primitive.Si0TypeDefPrimitive == ctypes.IsU8
+}
+
+// decodeBitSequence decodes a bit sequence into the hex string of its bit store.
+func (t types) decodeBitSequence(bitSequence ctypes.Si1TypeDefBitSequence, r *reader) (any, error) {
+	bits, err := r.readLength()
+	if err != nil {
+		return nil, err
+	}
+
+	storeSize := 1
+	if store, ok := t[bitSequence.BitStoreType.Int64()]; ok && store.Def.IsPrimitive {
+		switch store.Def.Primitive.Si0TypeDefPrimitive {
+		case ctypes.IsU16:
+			storeSize = 2
+		case ctypes.IsU32:
+			storeSize = 4
+		case ctypes.IsU64:
+			storeSize = 8
+		}
+	}
+
+	storeBits := storeSize * 8
+	b, err := r.read((bits + storeBits - 1) / storeBits * storeSize)
+	if err != nil {
+		return nil, err
+	}
+	return common.BytesToHex(b), nil
+}
+
+func decodePrimitive(primitive ctypes.Si0TypeDefPrimitive, r *reader) (any, error) {
+	switch primitive {
+	case ctypes.IsBool:
+		b, err := r.read(1)
+		if err != nil {
+			return nil, err
+		}
+		if b[0] > 1 {
+			return nil, fmt.Errorf("%w: %d", errInvalidBool, b[0])
+		}
+		return b[0] == 1, nil
+	case ctypes.IsChar:
+		b, err := r.read(4)
+		if err != nil {
+			return nil, err
+		}
+		return string(rune(binary.LittleEndian.Uint32(b))), nil
+	case ctypes.IsStr:
+		length, err := r.readLength()
+		if err != nil {
+			return nil, err
+		}
+		b, err := r.read(length)
+		if err != nil {
+			return nil, err
+		}
+		if !utf8.Valid(b) {
+			return nil, errInvalidString
+		}
+		return string(b), nil
+	case ctypes.IsU8:
+		b, err := r.read(1)
+		if err != nil {
+			return nil, err
+		}
+		return b[0], nil
+	case ctypes.IsU16:
+		b, err := r.read(2)
+		if err != nil {
+			return nil, err
+		}
+		return binary.LittleEndian.Uint16(b), nil
+	case ctypes.IsU32:
+		b, err := r.read(4)
+		if err != nil {
+			return nil, err
+		}
+		return binary.LittleEndian.Uint32(b), nil
+	case ctypes.IsU64:
+		b, err := r.read(8)
+		if err != nil {
+			return nil, err
+		}
+		return binary.LittleEndian.Uint64(b), nil
+	case ctypes.IsI8:
+		b, err := r.read(1)
+		if err != nil {
+			return nil, err
+		}
+		return int8(b[0]), nil
+	case ctypes.IsI16:
+		b, err := r.read(2)
+		if err != nil {
+			return nil, err
+		}
+		return int16(binary.LittleEndian.Uint16(b)), nil //nolint:gosec
+	case ctypes.IsI32:
+		b, err := r.read(4)
+		if err != nil {
+			return nil, err
+		}
+		return int32(binary.LittleEndian.Uint32(b)), nil //nolint:gosec
+	case ctypes.IsI64:
+		b, err := r.read(8)
+		if err != nil {
+			return nil, err
+		}
+		return int64(binary.LittleEndian.Uint64(b)), nil //nolint:gosec
+	case ctypes.IsU128, ctypes.IsU256:
+		size := 16
+		if primitive == ctypes.IsU256 {
+			size = 32
+		}
+		b, err := r.read(size)
+		if err != nil {
+			return nil, err
+		}
+		return littleEndianInt(b).String(), nil
+	case ctypes.IsI128, ctypes.IsI256:
+		size := 16
+		if primitive == ctypes.IsI256 {
+			size = 32
+		}
+		b, err := r.read(size)
+		if err != nil {
+			return nil, err
+		}
+		value := littleEndianInt(b)
+		if b[size-1]&0x80 != 0 {
+			// two's complement of a negative integer
+			value.Sub(value, new(big.Int).Lsh(big.NewInt(1), uint(size*8)))
+		}
+		return value.String(), nil
+	default:
+		return nil, fmt.Errorf("%w: primitive %d", errUnsupportedType, primitive)
+	}
+}
@@ -5,6 +5,7 @@
 
 import (
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
 )
 
 // Validity struct see
@@ -28,10 +29,23 @@ func NewValidity(priority uint64, requires, provides [][]byte, longevity uint64,
 	}
 }
 
+// Mortality is the mortality of a transaction, which can only be included in the blocks
+// numbered from its birth block number to its death block number excluded, descending
+// from its birth block.
+type Mortality struct {
+	BirthNumber uint
+	// BirthHash is the hash of the birth block, or the empty hash if the birth block
+	// was not imported yet when the transaction was validated.
+	BirthHash   common.Hash
+	DeathNumber uint
+}
+
 // ValidTransaction struct
 type ValidTransaction struct {
 	Extrinsic types.Extrinsic
 	Validity  *Validity
+	// Mortality is the mortality of the transaction, nil if it is immortal.
+	Mortality *Mortality
 }
 
 // NewValidTransaction returns ValidTransaction
@@ -42,13 +56,12 @@ func NewValidTransaction(extrinsic types.Extrinsic, validity *Validity) *ValidTr
 	}
 }
 
-// // StatusNotification represents information about a transaction status update.
-// type StatusNotification struct {
-// 	Ext                types.Extrinsic
-// 	Status             string
-// 	PeersBroadcastedTo []string
-// 	BlockHash          *common.Hash
-// }
+// StatusNotification represents information about a transaction status update.
+type StatusNotification struct {
+	Status Status
+	// Reason is why the transaction left the pool, if known.
+	Reason string
+}
 
 /*
 Status represents possible transaction statuses.
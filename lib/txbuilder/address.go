@@ -0,0 +1,39 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package txbuilder
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/ss58"
+	ctypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"golang.org/x/crypto/blake2b"
+)
+
+// AccountIDFromAddress returns the account id of the ss58 address. Addresses of
+// 33 bytes compressed ECDSA public keys are converted to the blake2b-256 hash
+// of the public key, like accounts of ECDSA signers.
+func AccountIDFromAddress(address common.Address) ([]byte, error) {
+	_, accountID, err := ss58.Decode(address)
+	if err != nil {
+		return nil, fmt.Errorf("decoding address: %w", err)
+	}
+
+	if len(accountID) == 33 {
+		hash := blake2b.Sum256(accountID)
+		return hash[:], nil
+	}
+	return accountID, nil
+}
+
+// MultiAddressFromAddress returns the multi address of the account of the ss58
+// address, such as the destination of a transfer call.
+func MultiAddressFromAddress(address common.Address) (ctypes.MultiAddress, error) {
+	accountID, err := AccountIDFromAddress(address)
+	if err != nil {
+		return ctypes.MultiAddress{}, err
+	}
+	return ctypes.NewMultiAddressFromAccountID(accountID)
+}
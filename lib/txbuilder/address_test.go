@@ -0,0 +1,77 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package txbuilder
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/ss58"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	ctypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+)
+
+func Test_AccountIDFromAddress(t *testing.T) {
+	t.Parallel()
+
+	keyring, err := keystore.NewSr25519Keyring()
+	require.NoError(t, err)
+
+	alice := keyring.Alice().Public().Encode()
+	// compressed public key of the //Alice ecdsa key
+	ecdsaAlice := common.MustHexToBytes("0x020a1091341fe5664bfa1782d5e04779689068c916b04cb365ec3153755684d9a1")
+	ecdsaAliceHash := blake2b.Sum256(ecdsaAlice)
+
+	testCases := map[string]struct {
+		address    common.Address
+		accountID  []byte
+		errWrapped error
+	}{
+		"substrate_address": {
+			address:   ss58.MustEncode(ss58.SubstratePrefix, alice),
+			accountID: alice,
+		},
+		"polkadot_address": {
+			address:   ss58.MustEncode(0, alice),
+			accountID: alice,
+		},
+		"ecdsa_public_key_address": {
+			address:   ss58.MustEncode(ss58.SubstratePrefix, ecdsaAlice),
+			accountID: ecdsaAliceHash[:],
+		},
+		"invalid_checksum": {
+			address:    "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQZ",
+			errWrapped: ss58.ErrInvalidChecksum,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			accountID, err := AccountIDFromAddress(testCase.address)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			assert.Equal(t, testCase.accountID, accountID)
+		})
+	}
+}
+
+func Test_MultiAddressFromAddress(t *testing.T) {
+	t.Parallel()
+
+	keyring, err := keystore.NewSr25519Keyring()
+	require.NoError(t, err)
+	bob := keyring.Bob().Public().Encode()
+
+	multiAddress, err := MultiAddressFromAddress("5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty")
+	require.NoError(t, err)
+
+	expected, err := ctypes.NewMultiAddressFromAccountID(bob)
+	require.NoError(t, err)
+	assert.Equal(t, expected, multiAddress)
+}
@@ -0,0 +1,266 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package txbuilder
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	ctypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"golang.org/x/crypto/blake2b"
+)
+
+// maxUnhashedPayloadLength is the length above which the signing payload
+// is blake2b-256 hashed before being signed by sr25519 and ed25519 keys.
+const maxUnhashedPayloadLength = 256
+
+// Options are the per-extrinsic signing options.
+type Options struct {
+	// BlockHash and BlockNumber are the hash and number of the block the
+	// mortality period starts at. They are ignored for immortal extrinsics.
+	BlockHash   common.Hash
+	BlockNumber uint64
+	// Period is the number of blocks the extrinsic is valid for.
+	// A zero period builds an immortal extrinsic.
+	Period uint64
+	// Tip is the tip paid to the block author.
+	Tip uint64
+	// Nonce is the account nonce to use. If nil, the nonce is looked up
+	// using the builder's NonceGetter.
+	Nonce *uint64
+}
+
+// Builder constructs extrinsics for a given runtime version.
+type Builder struct {
+	metadata    *ctypes.Metadata
+	version     runtime.Version
+	genesisHash common.Hash
+	nonces      NonceGetter
+	// extensions are the identifiers of the signed extensions of the runtime,
+	// in the order their data is encoded.
+	extensions []string
+}
+
+// NewBuilder creates a new extrinsic builder from decoded metadata and the runtime version.
+// The nonce getter is optional and only used when no nonce is given in the options.
+func NewBuilder(metadata *ctypes.Metadata, version runtime.Version,
+	genesisHash common.Hash, nonces NonceGetter) (*Builder, error) {
+	if metadata == nil {
+		return nil, ErrNilMetadata
+	}
+
+	extensions, err := signedExtensions(metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Builder{
+		metadata:    metadata,
+		version:     version,
+		genesisHash: genesisHash,
+		nonces:      nonces,
+		extensions:  extensions,
+	}, nil
+}
+
+// NewBuilderFromRuntime creates a new extrinsic builder using the metadata
+// and version of the given runtime instance.
+func NewBuilderFromRuntime(instance RuntimeInstance, genesisHash common.Hash,
+	nonces NonceGetter) (*Builder, error) {
+	rawMetadata, err := instance.Metadata()
+	if err != nil {
+		return nil, fmt.Errorf("getting metadata: %w", err)
+	}
+
+	metadata, err := DecodeMetadata(rawMetadata)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := instance.Version()
+	if err != nil {
+		return nil, fmt.Errorf("getting runtime version: %w", err)
+	}
+
+	return NewBuilder(metadata, version, genesisHash, nonces)
+}
+
+// DecodeMetadata decodes the SCALE encoded byte array returned by the
+// `Metadata_metadata` runtime call.
+func DecodeMetadata(rawMetadata []byte) (*ctypes.Metadata, error) {
+	var encodedMetadata []byte
+	err := scale.Unmarshal(rawMetadata, &encodedMetadata)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshalling metadata bytes: %w", err)
+	}
+
+	metadata := &ctypes.Metadata{}
+	err = codec.Decode(encodedMetadata, metadata)
+	if err != nil {
+		return nil, fmt.Errorf("decoding metadata: %w", err)
+	}
+	return metadata, nil
+}
+
+// Metadata returns the decoded metadata used by the builder.
+func (b *Builder) Metadata() *ctypes.Metadata {
+	return b.metadata
+}
+
+// Call builds the call with the given name (e.g. "Balances.transfer") and arguments.
+func (b *Builder) Call(name string, args ...any) (ctypes.Call, error) {
+	call, err := ctypes.NewCall(b.metadata, name, args...)
+	if err != nil {
+		return ctypes.Call{}, fmt.Errorf("creating call %s: %w", name, err)
+	}
+	return call, nil
+}
+
+// BuildUnsigned builds an unsigned extrinsic for the given call, for the calls
+// validating their unsigned transactions. Note the BABE and GRANDPA equivocation
+// reports are built by the runtime, through its submit report equivocation API.
+func (b *Builder) BuildUnsigned(call ctypes.Call) (types.Extrinsic, error) {
+	extrinsic := ctypes.NewExtrinsic(call)
+	return encodeExtrinsic(extrinsic)
+}
+
+// BuildSigned builds an extrinsic for the given call signed by the given key pair.
+func (b *Builder) BuildSigned(signer keystore.KeyPair, call ctypes.Call,
+	options Options) (types.Extrinsic, error) {
+	nonce, err := b.nonce(signer, options)
+	if err != nil {
+		return nil, err
+	}
+
+	era := ImmortalEra()
+	blockHash := b.genesisHash
+	if options.Period != 0 {
+		era = MortalEra(options.Period, options.BlockNumber)
+		blockHash = options.BlockHash
+	}
+
+	encodedCall, err := codec.Encode(call)
+	if err != nil {
+		return nil, fmt.Errorf("encoding call: %w", err)
+	}
+
+	extra, additional, err := encodeSignedExtensions(b.extensions, extensionValues{
+		specVersion:        b.version.SpecVersion,
+		transactionVersion: b.version.TransactionVersion,
+		genesisHash:        b.genesisHash,
+		blockHash:          blockHash,
+		era:                era,
+		nonce:              nonce,
+		tip:                options.Tip,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	payload := make([]byte, 0, len(encodedCall)+len(extra)+len(additional))
+	payload = append(payload, encodedCall...)
+	payload = append(payload, extra...)
+	payload = append(payload, additional...)
+	signature, err := signPayload(signer, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	signerAddress, err := ctypes.NewMultiAddressFromAccountID(accountID(signer))
+	if err != nil {
+		return nil, fmt.Errorf("creating signer address: %w", err)
+	}
+	encodedAddress, err := codec.Encode(signerAddress)
+	if err != nil {
+		return nil, fmt.Errorf("encoding signer address: %w", err)
+	}
+	encodedSignature, err := codec.Encode(signature)
+	if err != nil {
+		return nil, fmt.Errorf("encoding signature: %w", err)
+	}
+
+	// the signed extrinsic is the version byte, the signer address, the signature,
+	// the extra data of the signed extensions and the call, prefixed with its length
+	body := []byte{ctypes.ExtrinsicBitSigned | ctypes.ExtrinsicVersion4}
+	body = append(body, encodedAddress...)
+	body = append(body, encodedSignature...)
+	body = append(body, extra...)
+	body = append(body, encodedCall...)
+	encoded, err := codec.Encode(body)
+	if err != nil {
+		return nil, fmt.Errorf("encoding extrinsic: %w", err)
+	}
+	return types.NewExtrinsic(encoded), nil
+}
+
+func (b *Builder) nonce(signer keystore.KeyPair, options Options) (uint64, error) {
+	if options.Nonce != nil {
+		return *options.Nonce, nil
+	}
+	if b.nonces == nil {
+		return 0, ErrNoNonceGetter
+	}
+
+	nonce, err := b.nonces.AccountNonce(accountID(signer))
+	if err != nil {
+		return 0, fmt.Errorf("getting account nonce: %w", err)
+	}
+	return nonce, nil
+}
+
+// accountID returns the account id of the key pair. ECDSA accounts are
+// identified by the blake2b-256 hash of their compressed public key.
+func accountID(signer keystore.KeyPair) []byte {
+	public := signer.Public().Encode()
+	if signer.Type() == crypto.Secp256k1Type {
+		hash := blake2b.Sum256(public)
+		return hash[:]
+	}
+	return public
+}
+
+// signPayload signs the payload, or its blake2b-256 hash if it is longer than
+// maxUnhashedPayloadLength. ECDSA keys always sign the hash of the payload, since
+// they can only sign 32 bytes messages.
+func signPayload(signer keystore.KeyPair, encodedPayload []byte) (
+	signature ctypes.MultiSignature, err error) {
+	if signer.Type() == crypto.Secp256k1Type || len(encodedPayload) > maxUnhashedPayloadLength {
+		hash := blake2b.Sum256(encodedPayload)
+		encodedPayload = hash[:]
+	}
+
+	sig, err := signer.Sign(encodedPayload)
+	if err != nil {
+		return signature, fmt.Errorf("signing payload: %w", err)
+	}
+
+	switch signer.Type() {
+	case crypto.Sr25519Type:
+		signature.IsSr25519 = true
+		signature.AsSr25519 = ctypes.NewSignature(sig)
+	case crypto.Ed25519Type:
+		signature.IsEd25519 = true
+		signature.AsEd25519 = ctypes.NewSignature(sig)
+	case crypto.Secp256k1Type:
+		signature.IsEcdsa = true
+		signature.AsEcdsa = ctypes.NewEcdsaSignature(sig)
+	default:
+		return signature, fmt.Errorf("%w: %s", keystore.ErrKeyTypeNotSupported, signer.Type())
+	}
+	return signature, nil
+}
+
+func encodeExtrinsic(extrinsic ctypes.Extrinsic) (types.Extrinsic, error) {
+	encoded, err := codec.Encode(extrinsic)
+	if err != nil {
+		return nil, fmt.Errorf("encoding extrinsic: %w", err)
+	}
+	return types.NewExtrinsic(encoded), nil
+}
@@ -0,0 +1,299 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package txbuilder
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	testdata "github.com/ChainSafe/gossamer/dot/rpc/modules/test_data"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/secp256k1"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	ctypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+)
+
+type nonceGetterFunc func(accountID []byte) (uint64, error)
+
+func (f nonceGetterFunc) AccountNonce(accountID []byte) (uint64, error) {
+	return f(accountID)
+}
+
+func newTestBuilder(t *testing.T, nonces NonceGetter) *Builder {
+	t.Helper()
+
+	rawMetadata, err := common.HexToBytes(testdata.NewTestMetadata())
+	require.NoError(t, err)
+	metadata, err := DecodeMetadata(rawMetadata)
+	require.NoError(t, err)
+
+	version := runtime.Version{SpecVersion: 25, TransactionVersion: 1}
+	builder, err := NewBuilder(metadata, version, common.Hash{1}, nonces)
+	require.NoError(t, err)
+	return builder
+}
+
+func Test_Builder_BuildSigned(t *testing.T) {
+	t.Parallel()
+
+	keyring, err := keystore.NewSr25519Keyring()
+	require.NoError(t, err)
+	alice := keyring.Alice()
+
+	nonces := nonceGetterFunc(func(accountID []byte) (uint64, error) {
+		assert.Equal(t, alice.Public().Encode(), accountID)
+		return 7, nil
+	})
+	builder := newTestBuilder(t, nonces)
+
+	bob, err := ctypes.NewMultiAddressFromAccountID(keyring.Bob().Public().Encode())
+	require.NoError(t, err)
+	call, err := builder.Call("Balances.transfer", bob, ctypes.NewUCompactFromUInt(12345))
+	require.NoError(t, err)
+
+	options := Options{
+		BlockHash:   common.Hash{2},
+		BlockNumber: 42,
+		Period:      64,
+		Tip:         3,
+	}
+	encoded, err := builder.BuildSigned(alice, call, options)
+	require.NoError(t, err)
+
+	var extrinsic ctypes.Extrinsic
+	err = codec.Decode(encoded, &extrinsic)
+	require.NoError(t, err)
+
+	require.True(t, extrinsic.IsSigned())
+	assert.Equal(t, call, extrinsic.Method)
+	assert.Equal(t, MortalEra(64, 42), extrinsic.Signature.Era)
+	assert.Equal(t, big.NewInt(7), (*big.Int)(&extrinsic.Signature.Nonce))
+	assert.Equal(t, big.NewInt(3), (*big.Int)(&extrinsic.Signature.Tip))
+	assert.Equal(t, alice.Public().Encode(), extrinsic.Signature.Signer.AsID[:])
+	require.True(t, extrinsic.Signature.Signature.IsSr25519)
+
+	encodedCall, err := codec.Encode(call)
+	require.NoError(t, err)
+	payload, err := codec.Encode(ctypes.ExtrinsicPayloadV4{
+		ExtrinsicPayloadV3: ctypes.ExtrinsicPayloadV3{
+			Method:      encodedCall,
+			Era:         MortalEra(64, 42),
+			Nonce:       ctypes.NewUCompactFromUInt(7),
+			Tip:         ctypes.NewUCompactFromUInt(3),
+			SpecVersion: 25,
+			GenesisHash: ctypes.Hash{1},
+			BlockHash:   ctypes.Hash{2},
+		},
+		TransactionVersion: 1,
+	})
+	require.NoError(t, err)
+
+	ok, err := alice.Public().Verify(payload, extrinsic.Signature.Signature.AsSr25519[:])
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func Test_Builder_BuildSigned_ecdsa(t *testing.T) {
+	t.Parallel()
+
+	signer, err := secp256k1.GenerateKeypair()
+	require.NoError(t, err)
+	builder := newTestBuilder(t, nil)
+
+	call, err := builder.Call("System.remark", []byte{1, 2, 3})
+	require.NoError(t, err)
+	nonce := uint64(5)
+	options := Options{
+		BlockHash:   common.Hash{2},
+		BlockNumber: 42,
+		Period:      64,
+		Nonce:       &nonce,
+	}
+	encoded, err := builder.BuildSigned(signer, call, options)
+	require.NoError(t, err)
+
+	var extrinsic ctypes.Extrinsic
+	err = codec.Decode(encoded, &extrinsic)
+	require.NoError(t, err)
+	require.True(t, extrinsic.Signature.Signature.IsEcdsa)
+	accountID := blake2b.Sum256(signer.Public().Encode())
+	assert.Equal(t, accountID[:], extrinsic.Signature.Signer.AsID[:])
+
+	encodedCall, err := codec.Encode(call)
+	require.NoError(t, err)
+	payload, err := codec.Encode(ctypes.ExtrinsicPayloadV4{
+		ExtrinsicPayloadV3: ctypes.ExtrinsicPayloadV3{
+			Method:      encodedCall,
+			Era:         MortalEra(64, 42),
+			Nonce:       ctypes.NewUCompactFromUInt(nonce),
+			Tip:         ctypes.NewUCompactFromUInt(0),
+			SpecVersion: 25,
+			GenesisHash: ctypes.Hash{1},
+			BlockHash:   ctypes.Hash{2},
+		},
+		TransactionVersion: 1,
+	})
+	require.NoError(t, err)
+	require.LessOrEqual(t, len(payload), maxUnhashedPayloadLength)
+
+	// the short payload is hashed before being signed by the ECDSA key
+	hash := blake2b.Sum256(payload)
+	signature := extrinsic.Signature.Signature.AsEcdsa[:]
+	ok, err := signer.Public().Verify(hash[:], signature[:secp256k1.SignatureLength])
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func Test_Builder_BuildSigned_nonce(t *testing.T) {
+	t.Parallel()
+
+	keyring, err := keystore.NewEd25519Keyring()
+	require.NoError(t, err)
+	alice := keyring.Alice()
+
+	errTest := errors.New("test error")
+
+	testCases := map[string]struct {
+		nonces     NonceGetter
+		nonce      *uint64
+		errWrapped error
+		errMessage string
+	}{
+		"no_nonce_getter": {
+			errWrapped: ErrNoNonceGetter,
+			errMessage: "no nonce given and no nonce getter configured",
+		},
+		"nonce_getter_error": {
+			nonces: nonceGetterFunc(func([]byte) (uint64, error) {
+				return 0, errTest
+			}),
+			errWrapped: errTest,
+			errMessage: "getting account nonce: test error",
+		},
+		"explicit_nonce": {
+			nonce: new(uint64),
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			builder := newTestBuilder(t, testCase.nonces)
+			call, err := builder.Call("System.remark", []byte{1})
+			require.NoError(t, err)
+
+			extrinsic, err := builder.BuildSigned(alice, call, Options{Nonce: testCase.nonce})
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+				return
+			}
+
+			var decoded ctypes.Extrinsic
+			err = codec.Decode(extrinsic, &decoded)
+			require.NoError(t, err)
+			assert.True(t, decoded.Signature.Signature.IsEd25519)
+			assert.True(t, decoded.Signature.Era.IsImmortalEra)
+		})
+	}
+}
+
+func Test_Builder_BuildUnsigned(t *testing.T) {
+	t.Parallel()
+
+	builder := newTestBuilder(t, nil)
+	call, err := builder.Call("System.remark", []byte{1, 2, 3})
+	require.NoError(t, err)
+
+	encoded, err := builder.BuildUnsigned(call)
+	require.NoError(t, err)
+
+	var extrinsic ctypes.Extrinsic
+	err = codec.Decode(encoded, &extrinsic)
+	require.NoError(t, err)
+	assert.False(t, extrinsic.IsSigned())
+	assert.Equal(t, call, extrinsic.Method)
+}
+
+func Test_NewBuilder_unsupportedSignedExtension(t *testing.T) {
+	t.Parallel()
+
+	metadata := &ctypes.Metadata{Version: 14}
+	metadata.AsMetadataV14.Extrinsic.SignedExtensions = []ctypes.SignedExtensionMetadataV14{
+		{Identifier: "CheckNonce"},
+		{Identifier: "CheckUnknown"},
+	}
+
+	_, err := NewBuilder(metadata, runtime.Version{}, common.Hash{}, nil)
+	assert.ErrorIs(t, err, ErrUnsupportedSignedExtension)
+	assert.EqualError(t, err, "unsupported signed extension: CheckUnknown")
+
+	_, err = NewBuilder(nil, runtime.Version{}, common.Hash{}, nil)
+	assert.ErrorIs(t, err, ErrNilMetadata)
+}
+
+func Test_Builder_BuildSigned_metadataSignedExtensions(t *testing.T) {
+	t.Parallel()
+
+	keyring, err := keystore.NewEd25519Keyring()
+	require.NoError(t, err)
+	alice := keyring.Alice()
+
+	call, err := newTestBuilder(t, nil).Call("System.remark", []byte{1})
+	require.NoError(t, err)
+	encodedCall, err := codec.Encode(call)
+	require.NoError(t, err)
+
+	// the extensions are encoded in the order declared by the metadata, the unknown
+	// extensions being accepted if their data is zero-sized
+	metadata := &ctypes.Metadata{Version: 14}
+	metadata.AsMetadataV14.EfficientLookup = map[int64]*ctypes.Si1Type{
+		1: {Def: ctypes.Si1TypeDef{IsPrimitive: true}},
+		2: {Def: ctypes.Si1TypeDef{IsTuple: true}},
+	}
+	metadata.AsMetadataV14.Extrinsic.SignedExtensions = []ctypes.SignedExtensionMetadataV14{
+		{Identifier: "CheckMetadataHash", Type: ctypes.NewSi1LookupTypeIDFromUInt(1)},
+		{Identifier: "CheckNonce"},
+		{Identifier: "CheckZeroSized", Type: ctypes.NewSi1LookupTypeIDFromUInt(2),
+			AdditionalSigned: ctypes.NewSi1LookupTypeIDFromUInt(2)},
+		{Identifier: "CheckSpecVersion"},
+		{Identifier: "ChargeAssetTxPayment"},
+	}
+	version := runtime.Version{SpecVersion: 25, TransactionVersion: 1}
+	builder, err := NewBuilder(metadata, version, common.Hash{1}, nil)
+	require.NoError(t, err)
+
+	nonce := uint64(1)
+	encoded, err := builder.BuildSigned(alice, call, Options{Nonce: &nonce, Tip: 2})
+	require.NoError(t, err)
+
+	var body []byte
+	err = codec.Decode(encoded, &body)
+	require.NoError(t, err)
+	require.Len(t, body, 1+33+65+4+len(encodedCall))
+	assert.Equal(t, byte(0x84), body[0])
+	assert.Equal(t, append([]byte{0}, alice.Public().Encode()...), body[1:34])
+	assert.Equal(t, byte(0), body[34], "ed25519 signature")
+	extra := []byte{0, 1 << 2, 2 << 2, 0}
+	assert.Equal(t, append(extra, encodedCall...), body[99:])
+
+	payload := append(append([]byte{}, encodedCall...), extra...)
+	payload = append(payload, 0, 25, 0, 0, 0)
+	ok, err := alice.Public().Verify(payload, body[35:99])
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// extensions with data the builder cannot encode are rejected
+	metadata.AsMetadataV14.Extrinsic.SignedExtensions[2].Type = ctypes.NewSi1LookupTypeIDFromUInt(1)
+	_, err = NewBuilder(metadata, version, common.Hash{1}, nil)
+	assert.EqualError(t, err, "unsupported signed extension: CheckZeroSized")
+}
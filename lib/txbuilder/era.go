@@ -0,0 +1,109 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package txbuilder
+
+import (
+	"math/bits"
+
+	ctypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	minEraPeriod = 4
+	maxEraPeriod = 1 << 16
+)
+
+// ImmortalEra returns an era for a transaction which is valid forever.
+func ImmortalEra() ctypes.ExtrinsicEra {
+	return ctypes.ExtrinsicEra{IsImmortalEra: true}
+}
+
+// MortalEra returns an era for a transaction which is valid for `period` blocks
+// starting at `current`. The period is rounded up to the next power of two and
+// clamped to [4, 65536], as done by `sp_runtime::generic::Era::mortal`.
+//
+// A period of zero yields an immortal era.
+func MortalEra(period, current uint64) ctypes.ExtrinsicEra {
+	if period == 0 {
+		return ImmortalEra()
+	}
+
+	period = nextPowerOfTwo(period)
+	if period < minEraPeriod {
+		period = minEraPeriod
+	}
+	if period > maxEraPeriod {
+		period = maxEraPeriod
+	}
+
+	phase := current % period
+	quantizeFactor := period >> 12
+	if quantizeFactor < 1 {
+		quantizeFactor = 1
+	}
+
+	trailingZeros := uint64(bits.TrailingZeros64(period)) - 1
+	if trailingZeros < 1 {
+		trailingZeros = 1
+	}
+	if trailingZeros > 15 {
+		trailingZeros = 15
+	}
+	encoded := uint16(trailingZeros) | uint16((phase/quantizeFactor)<<4)
+
+	return ctypes.ExtrinsicEra{
+		IsMortalEra: true,
+		AsMortalEra: ctypes.MortalEra{
+			First:  byte(encoded),
+			Second: byte(encoded >> 8),
+		},
+	}
+}
+
+// EraPeriodPhase decodes the period and phase of the given era.
+// Both are zero for an immortal era.
+func EraPeriodPhase(era ctypes.ExtrinsicEra) (period, phase uint64) {
+	if !era.IsMortalEra {
+		return 0, 0
+	}
+	encoded := uint64(era.AsMortalEra.First) | uint64(era.AsMortalEra.Second)<<8
+	period = 2 << (encoded % (1 << 4))
+	quantizeFactor := period >> 12
+	if quantizeFactor < 1 {
+		quantizeFactor = 1
+	}
+	phase = (encoded >> 4) * quantizeFactor
+	return period, phase
+}
+
+// EraBirth returns the first block number at which a transaction with the given
+// era is valid, given the `current` block number.
+func EraBirth(era ctypes.ExtrinsicEra, current uint64) uint64 {
+	period, phase := EraPeriodPhase(era)
+	if period == 0 {
+		return 0
+	}
+	base := current
+	if phase > base {
+		base = phase
+	}
+	return (base-phase)/period*period + phase
+}
+
+// EraDeath returns the first block number at which a transaction with the given
+// era is no longer valid, given the `current` block number.
+func EraDeath(era ctypes.ExtrinsicEra, current uint64) uint64 {
+	period, _ := EraPeriodPhase(era)
+	if period == 0 {
+		return ^uint64(0)
+	}
+	return EraBirth(era, current) + period
+}
+
+func nextPowerOfTwo(n uint64) uint64 {
+	if n <= 1 {
+		return 1
+	}
+	return 1 << bits.Len64(n-1)
+}
@@ -0,0 +1,81 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package txbuilder
+
+import (
+	"testing"
+
+	ctypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MortalEra(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		period  uint64
+		current uint64
+		era     ctypes.ExtrinsicEra
+		// expected decoded period and phase
+		expectedPeriod uint64
+		expectedPhase  uint64
+	}{
+		"zero_period_is_immortal": {
+			era: ctypes.ExtrinsicEra{IsImmortalEra: true},
+		},
+		"quantized": {
+			period:  32768,
+			current: 20000,
+			era: ctypes.ExtrinsicEra{
+				IsMortalEra: true,
+				AsMortalEra: ctypes.MortalEra{First: 14 + 2500%16*16, Second: 2500 / 16},
+			},
+			expectedPeriod: 32768,
+			expectedPhase:  20000,
+		},
+		"period_rounded_up": {
+			period:         60,
+			current:        42,
+			era:            ctypes.ExtrinsicEra{IsMortalEra: true, AsMortalEra: ctypes.MortalEra{First: 165, Second: 2}},
+			expectedPeriod: 64,
+			expectedPhase:  42,
+		},
+		"period_clamped_to_minimum": {
+			period:         1,
+			current:        5,
+			era:            ctypes.ExtrinsicEra{IsMortalEra: true, AsMortalEra: ctypes.MortalEra{First: 17}},
+			expectedPeriod: 4,
+			expectedPhase:  1,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			era := MortalEra(testCase.period, testCase.current)
+			assert.Equal(t, testCase.era, era)
+
+			period, phase := EraPeriodPhase(era)
+			assert.Equal(t, testCase.expectedPeriod, period)
+			assert.Equal(t, testCase.expectedPhase, phase)
+		})
+	}
+}
+
+func Test_EraBirthDeath(t *testing.T) {
+	t.Parallel()
+
+	era := MortalEra(4, 6)
+	assert.Equal(t, uint64(6), EraBirth(era, 6))
+	assert.Equal(t, uint64(10), EraDeath(era, 6))
+	assert.Equal(t, uint64(6), EraBirth(era, 9))
+	assert.Equal(t, uint64(10), EraBirth(era, 10))
+	assert.Equal(t, uint64(14), EraDeath(era, 10))
+
+	immortal := ImmortalEra()
+	assert.Equal(t, uint64(0), EraBirth(immortal, 1000))
+	assert.Equal(t, ^uint64(0), EraDeath(immortal, 1000))
+}
@@ -0,0 +1,30 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package txbuilder
+
+import "errors"
+
+var (
+	// ErrUnsupportedSignedExtension is returned when the runtime metadata declares a
+	// signed extension the builder does not know how to encode.
+	ErrUnsupportedSignedExtension = errors.New("unsupported signed extension")
+	// ErrNoNonceGetter is returned when no nonce is given and the builder has no
+	// way of looking it up.
+	ErrNoNonceGetter = errors.New("no nonce given and no nonce getter configured")
+	// ErrNilMetadata is returned when the builder is created without metadata.
+	ErrNilMetadata = errors.New("metadata is nil")
+	// ErrCallNotFound is returned when a call is not declared by the runtime metadata.
+	ErrCallNotFound = errors.New("call not found in metadata")
+	// ErrUnsupportedMetadataVersion is returned when the call arguments cannot be
+	// read from the runtime metadata version.
+	ErrUnsupportedMetadataVersion = errors.New("unsupported metadata version")
+	// ErrUnexpectedCallArgument is returned when the runtime metadata declares a
+	// call argument the builder does not know how to encode.
+	ErrUnexpectedCallArgument = errors.New("unexpected call argument")
+	// ErrInvalidThreshold is returned when the multisig threshold is zero or
+	// greater than the number of signatories.
+	ErrInvalidThreshold = errors.New("invalid multisig threshold")
+	// ErrDuplicateSignatory is returned when a multisig signatory is given twice.
+	ErrDuplicateSignatory = errors.New("duplicate multisig signatory")
+)
@@ -0,0 +1,19 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package txbuilder
+
+import (
+	"github.com/ChainSafe/gossamer/lib/runtime"
+)
+
+// RuntimeInstance is the runtime interface needed to build extrinsics.
+type RuntimeInstance interface {
+	Metadata() (metadata []byte, err error)
+	Version() (runtime.Version, error)
+}
+
+// NonceGetter looks up the next nonce for an account.
+type NonceGetter interface {
+	AccountNonce(accountID []byte) (uint64, error)
+}
@@ -0,0 +1,104 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package txbuilder
+
+import (
+	"fmt"
+	"strings"
+
+	ctypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// callArg is an argument of a call as declared by the runtime metadata.
+type callArg struct {
+	name     string
+	typeName string
+	// weightFields is the number of fields of a weight argument declared as a
+	// struct, such as the reference time and proof size of v2 weights. It is
+	// zero for weights declared as a single u64.
+	weightFields int
+}
+
+// callArgs returns the arguments of the call with the given name, such as
+// "Multisig.as_multi", in the order they are encoded.
+func callArgs(metadata *ctypes.Metadata, name string) ([]callArg, error) {
+	pallet, call, ok := strings.Cut(name, ".")
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrCallNotFound, name)
+	}
+
+	switch metadata.Version {
+	case 12:
+		for _, module := range metadata.AsMetadataV12.Modules {
+			if string(module.Name) == pallet && module.HasCalls {
+				return functionArgs(module.Calls, name, call)
+			}
+		}
+	case 13:
+		for _, module := range metadata.AsMetadataV13.Modules {
+			if string(module.Name) == pallet && module.HasCalls {
+				return functionArgs(module.Calls, name, call)
+			}
+		}
+	case 14:
+		return callArgsV14(&metadata.AsMetadataV14, name, pallet, call)
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedMetadataVersion, metadata.Version)
+	}
+	return nil, fmt.Errorf("%w: %s", ErrCallNotFound, name)
+}
+
+func functionArgs(functions []ctypes.FunctionMetadataV4, name, call string) ([]callArg, error) {
+	for _, function := range functions {
+		if string(function.Name) != call {
+			continue
+		}
+
+		args := make([]callArg, len(function.Args))
+		for i, arg := range function.Args {
+			args[i] = callArg{name: string(arg.Name), typeName: string(arg.Type)}
+		}
+		return args, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrCallNotFound, name)
+}
+
+func callArgsV14(metadata *ctypes.MetadataV14, name, pallet, call string) ([]callArg, error) {
+	for _, p := range metadata.Pallets {
+		if string(p.Name) != pallet || !p.HasCalls {
+			continue
+		}
+
+		calls, ok := metadata.EfficientLookup[p.Calls.Type.Int64()]
+		if !ok {
+			break
+		}
+		for _, variant := range calls.Def.Variant.Variants {
+			if string(variant.Name) != call {
+				continue
+			}
+
+			args := make([]callArg, len(variant.Fields))
+			for i, field := range variant.Fields {
+				args[i] = callArg{name: string(field.Name), typeName: string(field.TypeName)}
+				typ, ok := metadata.EfficientLookup[field.Type.Int64()]
+				if ok && strings.HasSuffix(args[i].typeName, "Weight") && typ.Def.IsComposite {
+					args[i].weightFields = len(typ.Def.Composite.Fields)
+				}
+			}
+			return args, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrCallNotFound, name)
+}
+
+// findCallArg returns the argument with the given name.
+func findCallArg(args []callArg, name string) (arg callArg, ok bool) {
+	for _, arg := range args {
+		if arg.name == name {
+			return arg, true
+		}
+	}
+	return callArg{}, false
+}
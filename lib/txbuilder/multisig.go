@@ -0,0 +1,243 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package txbuilder
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	ctypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"golang.org/x/crypto/blake2b"
+)
+
+// multisigAccountPrefix is the prefix of the data hashed to derive the account
+// id of a multisig account in the multisig pallet.
+var multisigAccountPrefix = []byte("modlpy/utilisuba")
+
+// Timepoint is the block number and extrinsic index of the extrinsic which
+// opened a multisig operation.
+type Timepoint struct {
+	Height uint32
+	Index  uint32
+}
+
+// Weight is the maximum weight of a call dispatch. The proof size is ignored
+// by runtimes declaring weights as a single u64.
+type Weight struct {
+	RefTime   uint64
+	ProofSize uint64
+}
+
+// MultisigOptions are the options of a multisig operation.
+type MultisigOptions struct {
+	// Threshold is the number of approvals needed to dispatch the call.
+	Threshold uint16
+	// OtherSignatories are the account ids of the signatories of the multisig
+	// account other than the signer of the extrinsic, in any order.
+	OtherSignatories [][]byte
+	// Timepoint is the timepoint of the first approval of the operation. It must
+	// be nil for the first approval and set for the following ones.
+	Timepoint *Timepoint
+	// MaxWeight is the maximum weight of the call dispatch, used by the final approval.
+	MaxWeight Weight
+	// StoreCall stores the call in the multisig pallet, for runtimes supporting it.
+	StoreCall bool
+}
+
+// MultisigAccountID returns the account id of the multisig account of the
+// signatories for the threshold, as derived by the multisig pallet.
+func MultisigAccountID(threshold uint16, signatories [][]byte) ([]byte, error) {
+	sorted, err := sortSignatories(signatories)
+	if err != nil {
+		return nil, err
+	}
+	if threshold == 0 || int(threshold) > len(sorted) {
+		return nil, fmt.Errorf("%w: %d for %d signatories", ErrInvalidThreshold, threshold, len(sorted))
+	}
+
+	encoded, err := codec.Encode(struct {
+		Signatories []ctypes.AccountID
+		Threshold   uint16
+	}{sorted, threshold})
+	if err != nil {
+		return nil, fmt.Errorf("encoding signatories: %w", err)
+	}
+
+	hash := blake2b.Sum256(append(slices.Clone(multisigAccountPrefix), encoded...))
+	return hash[:], nil
+}
+
+// OtherSignatories returns the signatories without the signer, which is the
+// form expected by the multisig calls. It fails if the signer is not a signatory.
+func OtherSignatories(signatories [][]byte, signer []byte) ([][]byte, error) {
+	others := make([][]byte, 0, len(signatories))
+	for _, signatory := range signatories {
+		if !bytes.Equal(signatory, signer) {
+			others = append(others, signatory)
+		}
+	}
+	if len(others) == len(signatories) {
+		return nil, fmt.Errorf("signer 0x%x is not a signatory", signer)
+	}
+	return others, nil
+}
+
+// CallHash returns the blake2b-256 hash of the encoded call, which identifies
+// the call of multisig operations and proxy announcements.
+func CallHash(call ctypes.Call) (common.Hash, error) {
+	encoded, err := codec.Encode(call)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("encoding call: %w", err)
+	}
+	return blake2b.Sum256(encoded), nil
+}
+
+// AsMulti wraps the call in a `Multisig.as_multi` call approving and, once the
+// threshold is reached, dispatching it from the multisig account. A threshold of
+// one dispatches the call right away using `Multisig.as_multi_threshold_1`.
+func (b *Builder) AsMulti(call ctypes.Call, options MultisigOptions) (ctypes.Call, error) {
+	if options.Threshold == 1 {
+		others, err := sortSignatories(options.OtherSignatories)
+		if err != nil {
+			return ctypes.Call{}, err
+		}
+		return b.Call("Multisig.as_multi_threshold_1", others, call)
+	}
+	return b.multisigCall("Multisig.as_multi", options, &call, nil)
+}
+
+// ApproveAsMulti builds a `Multisig.approve_as_multi` call approving the
+// multisig operation of the call hash without dispatching it.
+func (b *Builder) ApproveAsMulti(callHash common.Hash, options MultisigOptions) (ctypes.Call, error) {
+	return b.multisigCall("Multisig.approve_as_multi", options, nil, &callHash)
+}
+
+// CancelAsMulti builds a `Multisig.cancel_as_multi` call cancelling the
+// multisig operation of the call hash opened at the options timepoint.
+func (b *Builder) CancelAsMulti(callHash common.Hash, options MultisigOptions) (ctypes.Call, error) {
+	if options.Timepoint == nil {
+		return ctypes.Call{}, fmt.Errorf("%w: timepoint is required to cancel", ErrUnexpectedCallArgument)
+	}
+	return b.multisigCall("Multisig.cancel_as_multi", options, nil, &callHash)
+}
+
+// multisigCall builds the multisig call with the given name, encoding the
+// arguments declared by the runtime metadata.
+func (b *Builder) multisigCall(name string, options MultisigOptions,
+	call *ctypes.Call, callHash *common.Hash) (ctypes.Call, error) {
+	others, err := sortSignatories(options.OtherSignatories)
+	if err != nil {
+		return ctypes.Call{}, err
+	}
+	if options.Threshold < 2 || int(options.Threshold) > len(others)+1 {
+		return ctypes.Call{}, fmt.Errorf("%w: %d for %d signatories",
+			ErrInvalidThreshold, options.Threshold, len(others)+1)
+	}
+
+	args, err := callArgs(b.metadata, name)
+	if err != nil {
+		return ctypes.Call{}, err
+	}
+
+	encodedArgs := make([]any, len(args))
+	for i, arg := range args {
+		var value any
+		switch {
+		case arg.name == "threshold":
+			value = options.Threshold
+		case arg.name == "other_signatories":
+			value = others
+		case arg.name == "maybe_timepoint":
+			value = encodeTimepointOption(options.Timepoint)
+		case arg.name == "timepoint" && options.Timepoint != nil:
+			value = *options.Timepoint
+		case arg.name == "call" && call != nil:
+			value, err = encodeMultisigCall(arg, *call)
+			if err != nil {
+				return ctypes.Call{}, err
+			}
+		case arg.name == "call_hash" && callHash != nil:
+			value = *callHash
+		case arg.name == "store_call":
+			value = options.StoreCall
+		case arg.name == "max_weight":
+			value = encodeWeight(arg, options.MaxWeight)
+		default:
+			return ctypes.Call{}, fmt.Errorf("%w: %s of %s", ErrUnexpectedCallArgument, arg.name, name)
+		}
+		encodedArgs[i] = value
+	}
+
+	return b.Call(name, encodedArgs...)
+}
+
+// sortSignatories returns the signatories as account ids sorted in ascending
+// order, as required by the multisig pallet.
+func sortSignatories(signatories [][]byte) ([]ctypes.AccountID, error) {
+	sorted := make([]ctypes.AccountID, len(signatories))
+	for i, signatory := range signatories {
+		accountID, err := ctypes.NewAccountID(signatory)
+		if err != nil {
+			return nil, fmt.Errorf("signatory %d: %w", i, err)
+		}
+		sorted[i] = *accountID
+	}
+
+	slices.SortFunc(sorted, func(a, b ctypes.AccountID) int {
+		return bytes.Compare(a[:], b[:])
+	})
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i] == sorted[i-1] {
+			return nil, fmt.Errorf("%w: 0x%x", ErrDuplicateSignatory, sorted[i][:])
+		}
+	}
+	return sorted, nil
+}
+
+// encodeTimepointOption encodes the optional timepoint.
+func encodeTimepointOption(timepoint *Timepoint) ctypes.Args {
+	if timepoint == nil {
+		return ctypes.Args{0}
+	}
+
+	encoded := []byte{1}
+	encoded = binary.LittleEndian.AppendUint32(encoded, timepoint.Height)
+	encoded = binary.LittleEndian.AppendUint32(encoded, timepoint.Index)
+	return encoded
+}
+
+// encodeMultisigCall encodes the call argument of a multisig call, which older
+// runtimes declare as an opaque call encoded as bytes.
+func encodeMultisigCall(arg callArg, call ctypes.Call) (any, error) {
+	if !strings.Contains(arg.typeName, "Opaque") {
+		return call, nil
+	}
+
+	encoded, err := codec.Encode(call)
+	if err != nil {
+		return nil, fmt.Errorf("encoding call: %w", err)
+	}
+	return encoded, nil
+}
+
+// encodeWeight encodes the weight as declared by the runtime metadata, either as
+// a single u64 or as a struct of compact reference time and proof size.
+func encodeWeight(arg callArg, weight Weight) any {
+	switch arg.weightFields {
+	case 0:
+		return weight.RefTime
+	case 1:
+		return ctypes.NewUCompactFromUInt(weight.RefTime)
+	default:
+		return ctypes.NewWeight(
+			ctypes.NewUCompactFromUInt(weight.RefTime),
+			ctypes.NewUCompactFromUInt(weight.ProofSize),
+		)
+	}
+}
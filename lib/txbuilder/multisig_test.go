@@ -0,0 +1,292 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package txbuilder
+
+import (
+	"encoding/binary"
+	"slices"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	ctypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+)
+
+type testSignatories struct {
+	alice, bob, charlie []byte
+}
+
+func newTestSignatories(t *testing.T) testSignatories {
+	t.Helper()
+
+	keyring, err := keystore.NewSr25519Keyring()
+	require.NoError(t, err)
+	return testSignatories{
+		alice:   keyring.Alice().Public().Encode(),
+		bob:     keyring.Bob().Public().Encode(),
+		charlie: keyring.Charlie().Public().Encode(),
+	}
+}
+
+func Test_MultisigAccountID(t *testing.T) {
+	t.Parallel()
+
+	s := newTestSignatories(t)
+
+	accountID, err := MultisigAccountID(2, [][]byte{s.alice, s.bob, s.charlie})
+	require.NoError(t, err)
+
+	// sorted signatories are bob, charlie and alice
+	data := slices.Clone(multisigAccountPrefix)
+	data = append(data, 3<<2)
+	data = append(data, s.bob...)
+	data = append(data, s.charlie...)
+	data = append(data, s.alice...)
+	data = binary.LittleEndian.AppendUint16(data, 2)
+	expected := blake2b.Sum256(data)
+	assert.Equal(t, expected[:], accountID)
+
+	reordered, err := MultisigAccountID(2, [][]byte{s.charlie, s.alice, s.bob})
+	require.NoError(t, err)
+	assert.Equal(t, accountID, reordered)
+
+	otherThreshold, err := MultisigAccountID(3, [][]byte{s.alice, s.bob, s.charlie})
+	require.NoError(t, err)
+	assert.NotEqual(t, accountID, otherThreshold)
+
+	_, err = MultisigAccountID(4, [][]byte{s.alice, s.bob, s.charlie})
+	assert.ErrorIs(t, err, ErrInvalidThreshold)
+
+	_, err = MultisigAccountID(2, [][]byte{s.alice, s.bob, s.alice})
+	assert.ErrorIs(t, err, ErrDuplicateSignatory)
+}
+
+func Test_OtherSignatories(t *testing.T) {
+	t.Parallel()
+
+	s := newTestSignatories(t)
+
+	others, err := OtherSignatories([][]byte{s.alice, s.bob, s.charlie}, s.bob)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{s.alice, s.charlie}, others)
+
+	_, err = OtherSignatories([][]byte{s.alice, s.charlie}, s.bob)
+	assert.EqualError(t, err, "signer 0x8eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48 "+
+		"is not a signatory")
+}
+
+func Test_Builder_AsMulti(t *testing.T) {
+	t.Parallel()
+
+	s := newTestSignatories(t)
+	builder := newTestBuilder(t, nil)
+
+	dest, err := ctypes.NewMultiAddressFromAccountID(s.charlie)
+	require.NoError(t, err)
+	transfer, err := builder.Call("Balances.transfer", dest, ctypes.NewUCompactFromUInt(12345))
+	require.NoError(t, err)
+	encodedTransfer, err := codec.Encode(transfer)
+	require.NoError(t, err)
+
+	options := MultisigOptions{
+		Threshold:        2,
+		OtherSignatories: [][]byte{s.charlie, s.alice},
+		Timepoint:        &Timepoint{Height: 10, Index: 1},
+		MaxWeight:        Weight{RefTime: 1_000_000},
+		StoreCall:        true,
+	}
+	call, err := builder.AsMulti(transfer, options)
+	require.NoError(t, err)
+
+	expectedIndex, err := builder.Metadata().FindCallIndex("Multisig.as_multi")
+	require.NoError(t, err)
+	assert.Equal(t, expectedIndex, call.CallIndex)
+
+	// threshold
+	expectedArgs := []byte{2, 0}
+	// sorted other signatories
+	expectedArgs = append(expectedArgs, 2<<2)
+	expectedArgs = append(expectedArgs, s.charlie...)
+	expectedArgs = append(expectedArgs, s.alice...)
+	// timepoint
+	expectedArgs = append(expectedArgs, 1, 10, 0, 0, 0, 1, 0, 0, 0)
+	// opaque call
+	expectedArgs = append(expectedArgs, byte(len(encodedTransfer)<<2))
+	expectedArgs = append(expectedArgs, encodedTransfer...)
+	// store call
+	expectedArgs = append(expectedArgs, 1)
+	// u64 max weight
+	expectedArgs = binary.LittleEndian.AppendUint64(expectedArgs, 1_000_000)
+	assert.Equal(t, ctypes.Args(expectedArgs), call.Args)
+}
+
+func Test_Builder_AsMulti_thresholdOne(t *testing.T) {
+	t.Parallel()
+
+	s := newTestSignatories(t)
+	builder := newTestBuilder(t, nil)
+
+	remark, err := builder.Call("System.remark", []byte{1, 2, 3})
+	require.NoError(t, err)
+	encodedRemark, err := codec.Encode(remark)
+	require.NoError(t, err)
+
+	call, err := builder.AsMulti(remark, MultisigOptions{
+		Threshold:        1,
+		OtherSignatories: [][]byte{s.charlie, s.alice},
+	})
+	require.NoError(t, err)
+
+	expectedIndex, err := builder.Metadata().FindCallIndex("Multisig.as_multi_threshold_1")
+	require.NoError(t, err)
+	assert.Equal(t, expectedIndex, call.CallIndex)
+
+	expectedArgs := []byte{2 << 2}
+	expectedArgs = append(expectedArgs, s.charlie...)
+	expectedArgs = append(expectedArgs, s.alice...)
+	expectedArgs = append(expectedArgs, encodedRemark...)
+	assert.Equal(t, ctypes.Args(expectedArgs), call.Args)
+}
+
+func Test_Builder_ApproveAsMulti(t *testing.T) {
+	t.Parallel()
+
+	s := newTestSignatories(t)
+	builder := newTestBuilder(t, nil)
+
+	remark, err := builder.Call("System.remark", []byte{1, 2, 3})
+	require.NoError(t, err)
+	callHash, err := CallHash(remark)
+	require.NoError(t, err)
+
+	encodedRemark, err := codec.Encode(remark)
+	require.NoError(t, err)
+	assert.Equal(t, common.Hash(blake2b.Sum256(encodedRemark)), callHash)
+
+	call, err := builder.ApproveAsMulti(callHash, MultisigOptions{
+		Threshold:        3,
+		OtherSignatories: [][]byte{s.alice, s.charlie},
+	})
+	require.NoError(t, err)
+
+	expectedIndex, err := builder.Metadata().FindCallIndex("Multisig.approve_as_multi")
+	require.NoError(t, err)
+	assert.Equal(t, expectedIndex, call.CallIndex)
+
+	expectedArgs := []byte{3, 0, 2 << 2}
+	expectedArgs = append(expectedArgs, s.charlie...)
+	expectedArgs = append(expectedArgs, s.alice...)
+	// no timepoint for the first approval
+	expectedArgs = append(expectedArgs, 0)
+	expectedArgs = append(expectedArgs, callHash[:]...)
+	expectedArgs = binary.LittleEndian.AppendUint64(expectedArgs, 0)
+	assert.Equal(t, ctypes.Args(expectedArgs), call.Args)
+}
+
+func Test_Builder_CancelAsMulti(t *testing.T) {
+	t.Parallel()
+
+	s := newTestSignatories(t)
+	builder := newTestBuilder(t, nil)
+	callHash := common.Hash{1}
+
+	options := MultisigOptions{
+		Threshold:        2,
+		OtherSignatories: [][]byte{s.alice},
+	}
+	_, err := builder.CancelAsMulti(callHash, options)
+	assert.ErrorIs(t, err, ErrUnexpectedCallArgument)
+
+	options.Timepoint = &Timepoint{Height: 2, Index: 3}
+	call, err := builder.CancelAsMulti(callHash, options)
+	require.NoError(t, err)
+
+	expectedArgs := []byte{2, 0, 1 << 2}
+	expectedArgs = append(expectedArgs, s.alice...)
+	expectedArgs = append(expectedArgs, 2, 0, 0, 0, 3, 0, 0, 0)
+	expectedArgs = append(expectedArgs, callHash[:]...)
+	assert.Equal(t, ctypes.Args(expectedArgs), call.Args)
+}
+
+func Test_Builder_multisigCall_errors(t *testing.T) {
+	t.Parallel()
+
+	s := newTestSignatories(t)
+	builder := newTestBuilder(t, nil)
+
+	testCases := map[string]struct {
+		options    MultisigOptions
+		errWrapped error
+		errMessage string
+	}{
+		"threshold_too_high": {
+			options:    MultisigOptions{Threshold: 3, OtherSignatories: [][]byte{s.alice}},
+			errWrapped: ErrInvalidThreshold,
+			errMessage: "invalid multisig threshold: 3 for 2 signatories",
+		},
+		"zero_threshold": {
+			options:    MultisigOptions{OtherSignatories: [][]byte{s.alice}},
+			errWrapped: ErrInvalidThreshold,
+			errMessage: "invalid multisig threshold: 0 for 2 signatories",
+		},
+		"duplicate_signatory": {
+			options:    MultisigOptions{Threshold: 2, OtherSignatories: [][]byte{s.alice, s.alice}},
+			errWrapped: ErrDuplicateSignatory,
+			errMessage: "duplicate multisig signatory: " +
+				"0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d",
+		},
+		"invalid_signatory": {
+			options:    MultisigOptions{Threshold: 2, OtherSignatories: [][]byte{{1, 2}}},
+			errWrapped: ctypes.ErrInvalidAccountIDBytes,
+			errMessage: "signatory 0: " + ctypes.ErrInvalidAccountIDBytes.Error(),
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := builder.ApproveAsMulti(common.Hash{}, testCase.options)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			assert.EqualError(t, err, testCase.errMessage)
+		})
+	}
+}
+
+func Test_encodeWeight(t *testing.T) {
+	t.Parallel()
+
+	weight := Weight{RefTime: 300, ProofSize: 1}
+
+	encoded, err := codec.Encode(encodeWeight(callArg{typeName: "Weight"}, weight))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{44, 1, 0, 0, 0, 0, 0, 0}, encoded)
+
+	encoded, err = codec.Encode(encodeWeight(callArg{typeName: "Weight", weightFields: 2}, weight))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{177, 4, 4}, encoded)
+}
+
+func Test_encodeMultisigCall(t *testing.T) {
+	t.Parallel()
+
+	call := ctypes.Call{CallIndex: ctypes.CallIndex{SectionIndex: 1, MethodIndex: 2}, Args: ctypes.Args{3}}
+
+	value, err := encodeMultisigCall(callArg{typeName: "Box<<T as Config>::RuntimeCall>"}, call)
+	require.NoError(t, err)
+	encoded, err := codec.Encode(value)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1, 2, 3}, encoded)
+
+	value, err = encodeMultisigCall(callArg{typeName: "WrapperKeepOpaque<<T as Config>::Call>"}, call)
+	require.NoError(t, err)
+	encoded, err = codec.Encode(value)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{3 << 2, 1, 2, 3}, encoded)
+}
@@ -0,0 +1,59 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package txbuilder
+
+import (
+	"fmt"
+	"strings"
+
+	ctypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// Proxy wraps the call in a `Proxy.proxy` call dispatched on behalf of the real
+// account by one of its proxies. The force proxy type is the index of the proxy
+// type in the runtime, or nil to use any proxy type of the signer allowing the call.
+func (b *Builder) Proxy(real []byte, forceProxyType *uint8, call ctypes.Call) (ctypes.Call, error) {
+	const name = "Proxy.proxy"
+	args, err := callArgs(b.metadata, name)
+	if err != nil {
+		return ctypes.Call{}, err
+	}
+
+	encodedArgs := make([]any, len(args))
+	for i, arg := range args {
+		switch arg.name {
+		case "real":
+			encodedArgs[i], err = encodeAccount(arg, real)
+			if err != nil {
+				return ctypes.Call{}, fmt.Errorf("encoding real account: %w", err)
+			}
+		case "force_proxy_type":
+			if forceProxyType == nil {
+				encodedArgs[i] = ctypes.Args{0}
+			} else {
+				encodedArgs[i] = ctypes.Args{1, *forceProxyType}
+			}
+		case "call":
+			encodedArgs[i] = call
+		default:
+			return ctypes.Call{}, fmt.Errorf("%w: %s of %s", ErrUnexpectedCallArgument, arg.name, name)
+		}
+	}
+
+	return b.Call(name, encodedArgs...)
+}
+
+// encodeAccount encodes the account argument either as an account id, or as a
+// multi address for runtimes looking the account up from its address.
+func encodeAccount(arg callArg, accountID []byte) (any, error) {
+	if strings.Contains(arg.typeName, "Lookup") || strings.Contains(arg.typeName, "Address") {
+		return ctypes.NewMultiAddressFromAccountID(accountID)
+	}
+
+	account, err := ctypes.NewAccountID(accountID)
+	if err != nil {
+		return nil, err
+	}
+	return *account, nil
+}
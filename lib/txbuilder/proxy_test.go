@@ -0,0 +1,67 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package txbuilder
+
+import (
+	"testing"
+
+	ctypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Builder_Proxy(t *testing.T) {
+	t.Parallel()
+
+	s := newTestSignatories(t)
+	builder := newTestBuilder(t, nil)
+
+	remark, err := builder.Call("System.remark", []byte{1, 2, 3})
+	require.NoError(t, err)
+	encodedRemark, err := codec.Encode(remark)
+	require.NoError(t, err)
+
+	expectedIndex, err := builder.Metadata().FindCallIndex("Proxy.proxy")
+	require.NoError(t, err)
+
+	call, err := builder.Proxy(s.alice, nil, remark)
+	require.NoError(t, err)
+	assert.Equal(t, expectedIndex, call.CallIndex)
+
+	expectedArgs := append([]byte{}, s.alice...)
+	expectedArgs = append(expectedArgs, 0)
+	expectedArgs = append(expectedArgs, encodedRemark...)
+	assert.Equal(t, ctypes.Args(expectedArgs), call.Args)
+
+	proxyType := uint8(3)
+	call, err = builder.Proxy(s.alice, &proxyType, remark)
+	require.NoError(t, err)
+
+	expectedArgs = append([]byte{}, s.alice...)
+	expectedArgs = append(expectedArgs, 1, 3)
+	expectedArgs = append(expectedArgs, encodedRemark...)
+	assert.Equal(t, ctypes.Args(expectedArgs), call.Args)
+
+	_, err = builder.Proxy(s.alice[:20], nil, remark)
+	assert.ErrorIs(t, err, ctypes.ErrInvalidAccountIDBytes)
+}
+
+func Test_encodeAccount(t *testing.T) {
+	t.Parallel()
+
+	s := newTestSignatories(t)
+
+	value, err := encodeAccount(callArg{typeName: "AccountIdLookupOf<T>"}, s.bob)
+	require.NoError(t, err)
+	encoded, err := codec.Encode(value)
+	require.NoError(t, err)
+	assert.Equal(t, append([]byte{0}, s.bob...), encoded)
+
+	value, err = encodeAccount(callArg{typeName: "T::AccountId"}, s.bob)
+	require.NoError(t, err)
+	encoded, err = codec.Encode(value)
+	require.NoError(t, err)
+	assert.Equal(t, s.bob, encoded)
+}
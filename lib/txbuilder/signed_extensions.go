@@ -0,0 +1,155 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package txbuilder
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	ctypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// defaultSignedExtensions are the signed extensions of the runtimes whose metadata
+// is older than v14 and does not declare them, in the order of the v4 extrinsic payload.
+var defaultSignedExtensions = []string{
+	"CheckSpecVersion",
+	"CheckTxVersion",
+	"CheckGenesis",
+	"CheckMortality",
+	"CheckNonce",
+	"CheckWeight",
+	"ChargeTransactionPayment",
+}
+
+// extensionValues are the values encoded in the extra and additional signed data
+// of the signed extensions of an extrinsic.
+type extensionValues struct {
+	specVersion        uint32
+	transactionVersion uint32
+	genesisHash        common.Hash
+	blockHash          common.Hash
+	era                ctypes.ExtrinsicEra
+	nonce              uint64
+	tip                uint64
+}
+
+// signedExtensions returns the identifiers of the signed extensions declared by the
+// metadata, in the order their data is encoded. Extensions the builder does not know
+// how to encode are only accepted if both their extra and additional signed data are
+// zero-sized, since the runtime would otherwise reject the resulting signature.
+func signedExtensions(metadata *ctypes.Metadata) ([]string, error) {
+	if metadata.Version < 14 {
+		// signed extensions are not part of the metadata before v14
+		return defaultSignedExtensions, nil
+	}
+
+	metadataV14 := &metadata.AsMetadataV14
+	identifiers := make([]string, len(metadataV14.Extrinsic.SignedExtensions))
+	for i, extension := range metadataV14.Extrinsic.SignedExtensions {
+		identifier := string(extension.Identifier)
+		_, known := encodeSignedExtension(identifier, extensionValues{})
+		if !known && (!zeroSized(metadataV14, extension.Type, 0) ||
+			!zeroSized(metadataV14, extension.AdditionalSigned, 0)) {
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedSignedExtension, identifier)
+		}
+		identifiers[i] = identifier
+	}
+	return identifiers, nil
+}
+
+// maxTypeDepth bounds the recursion of zeroSized over malformed type registries.
+const maxTypeDepth = 16
+
+// zeroSized returns true if the type of the registry encodes to no bytes, such as the
+// unit type or a struct with only phantom fields.
+func zeroSized(metadata *ctypes.MetadataV14, id ctypes.Si1LookupTypeID, depth int) bool {
+	typ, ok := metadata.EfficientLookup[id.Int64()]
+	if !ok || depth > maxTypeDepth {
+		return false
+	}
+
+	switch def := typ.Def; {
+	case def.IsComposite:
+		for _, field := range def.Composite.Fields {
+			if !zeroSized(metadata, field.Type, depth+1) {
+				return false
+			}
+		}
+		return true
+	case def.IsTuple:
+		for _, element := range def.Tuple {
+			if !zeroSized(metadata, element, depth+1) {
+				return false
+			}
+		}
+		return true
+	case def.IsArray:
+		return def.Array.Len == 0 || zeroSized(metadata, def.Array.Type, depth+1)
+	default:
+		return false
+	}
+}
+
+// signedExtensionData is the data of a signed extension: its extra data is part of the
+// extrinsic signature, and its additional signed data only of the signing payload.
+type signedExtensionData struct {
+	extra      []any
+	additional []any
+}
+
+// encodeSignedExtension returns the data of the signed extension with the given
+// identifier, and false if the builder does not know how to encode it.
+func encodeSignedExtension(identifier string, values extensionValues) (data signedExtensionData, known bool) {
+	switch identifier {
+	case "CheckNonZeroSender", "CheckWeight", "PrevalidateAttests":
+	case "CheckSpecVersion":
+		data.additional = []any{ctypes.U32(values.specVersion)}
+	case "CheckTxVersion":
+		data.additional = []any{ctypes.U32(values.transactionVersion)}
+	case "CheckGenesis":
+		data.additional = []any{ctypes.Hash(values.genesisHash)}
+	case "CheckMortality", "CheckEra":
+		data.extra = []any{values.era}
+		data.additional = []any{ctypes.Hash(values.blockHash)}
+	case "CheckNonce":
+		data.extra = []any{ctypes.NewUCompactFromUInt(values.nonce)}
+	case "ChargeTransactionPayment":
+		data.extra = []any{ctypes.NewUCompactFromUInt(values.tip)}
+	case "ChargeAssetTxPayment":
+		// the fees are paid in the native asset
+		data.extra = []any{ctypes.NewUCompactFromUInt(values.tip), ctypes.U8(0)}
+	case "CheckMetadataHash":
+		// the metadata hash check is disabled, and no metadata hash is signed
+		data.extra = []any{ctypes.U8(0)}
+		data.additional = []any{ctypes.U8(0)}
+	default:
+		return data, false
+	}
+	return data, true
+}
+
+// encodeSignedExtensions encodes the extra and additional signed data of the signed
+// extensions, in the order of the identifiers given.
+func encodeSignedExtensions(identifiers []string, values extensionValues) (
+	extra, additional []byte, err error) {
+	for _, identifier := range identifiers {
+		data, _ := encodeSignedExtension(identifier, values)
+		for _, value := range data.extra {
+			encoded, err := codec.Encode(value)
+			if err != nil {
+				return nil, nil, fmt.Errorf("encoding extra of %s: %w", identifier, err)
+			}
+			extra = append(extra, encoded...)
+		}
+		for _, value := range data.additional {
+			encoded, err := codec.Encode(value)
+			if err != nil {
+				return nil, nil, fmt.Errorf("encoding additional signed of %s: %w", identifier, err)
+			}
+			additional = append(additional, encoded...)
+		}
+	}
+	return extra, additional, nil
+}
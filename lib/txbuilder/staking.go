@@ -0,0 +1,38 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package txbuilder
+
+import (
+	"fmt"
+
+	ctypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// PayoutStakers builds a `Staking.payout_stakers` call paying out the rewards of
+// the validator and its nominators for the era. Runtimes paging the exposures
+// pay out the next unclaimed page on each call.
+func (b *Builder) PayoutStakers(validatorStash []byte, era uint32) (ctypes.Call, error) {
+	const name = "Staking.payout_stakers"
+	args, err := callArgs(b.metadata, name)
+	if err != nil {
+		return ctypes.Call{}, err
+	}
+
+	encodedArgs := make([]any, len(args))
+	for i, arg := range args {
+		switch arg.name {
+		case "validator_stash":
+			encodedArgs[i], err = encodeAccount(arg, validatorStash)
+			if err != nil {
+				return ctypes.Call{}, fmt.Errorf("encoding validator stash: %w", err)
+			}
+		case "era":
+			encodedArgs[i] = ctypes.NewU32(era)
+		default:
+			return ctypes.Call{}, fmt.Errorf("%w: %s of %s", ErrUnexpectedCallArgument, arg.name, name)
+		}
+	}
+
+	return b.Call(name, encodedArgs...)
+}
@@ -0,0 +1,33 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package txbuilder
+
+import (
+	"testing"
+
+	ctypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Builder_PayoutStakers(t *testing.T) {
+	t.Parallel()
+
+	s := newTestSignatories(t)
+	builder := newTestBuilder(t, nil)
+
+	expectedIndex, err := builder.Metadata().FindCallIndex("Staking.payout_stakers")
+	require.NoError(t, err)
+
+	call, err := builder.PayoutStakers(s.alice, 0x01020304)
+	require.NoError(t, err)
+	assert.Equal(t, expectedIndex, call.CallIndex)
+
+	expectedArgs := append([]byte{}, s.alice...)
+	expectedArgs = append(expectedArgs, 4, 3, 2, 1)
+	assert.Equal(t, ctypes.Args(expectedArgs), call.Args)
+
+	_, err = builder.PayoutStakers(s.alice[:20], 1)
+	assert.ErrorIs(t, err, ctypes.ErrInvalidAccountIDBytes)
+}
@@ -3,7 +3,7 @@
 
 package grandpa
 
-// A dynamically sized, write-once (per bit), lazily allocating bitfield.
+// A dynamically sized, lazily allocating bitfield.
 type bitfield struct {
 	bits []uint64
 }
@@ -52,6 +52,25 @@ func (b *bitfield) SetBit(position uint) { //skipcq: GO-W1029
 	b.bits[wordOff] |= 1 << (63 - bitOff)
 }
 
+// Clear clears all bits of this bitfield that are set in the other bitfield.
+func (b *bitfield) Clear(other bitfield) *bitfield { //skipcq: GO-W1029
+	for i := 0; i < len(b.bits) && i < len(other.bits); i++ {
+		b.bits[i] &^= other.bits[i]
+	}
+	return b
+}
+
+// ClearBit will clear the bit in the bitfield at the specified position.
+func (b *bitfield) ClearBit(position uint) { //skipcq: GO-W1029
+	wordOff := position / 64
+	bitOff := position % 64
+
+	if wordOff >= uint(len(b.bits)) {
+		return
+	}
+	b.bits[wordOff] &^= 1 << (63 - bitOff)
+}
+
 // iter1s will get an iterator over all bits that are set (i.e. 1) in the bitfield,
 // starting at bit position `start` and moving in steps of size `2^step`
 // per word.
@@ -55,6 +55,39 @@ func TestBitfield_SetBit(t *testing.T) {
 	}
 }
 
+func TestBitfield_ClearBit(t *testing.T) {
+	f := func(a bitfield, idx uint) bool {
+		idx = uint(math.Min(float64(idx), 1<<24))
+		a.SetBit(idx)
+		a.ClearBit(idx)
+		return !a.testBit(idx)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBitfield_Clear(t *testing.T) {
+	f := func(a, b bitfield) bool {
+		c := newBitfield()
+		c.Merge(a).Merge(b).Clear(b)
+		for _, bit := range c.iter1s(0, 0) {
+			if !a.testBit(bit.position) || b.testBit(bit.position) {
+				return false
+			}
+		}
+		for _, bit := range a.iter1s(0, 0) {
+			if !b.testBit(bit.position) && !c.testBit(bit.position) {
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
 func TestBitfield_iter1s_bitor(t *testing.T) {
 	f := func(a, b bitfield) bool {
 		c := newBitfield()
@@ -0,0 +1,151 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"sync"
+)
+
+// CachedChain is a Chain memoizing the ancestry segments returned by the wrapped chain,
+// so the ancestry of a block already walked, or of one of its ancestors, is not walked
+// again in the blockchain database, and the ancestry of a descendant of a block walked
+// is only walked down to that block. It is meant to be passed to VoteGraph.Insert, such as
+// for both the prevote and precommit graphs of a round, whose ancestry calls all share
+// the base of the vote graphs: the segments are cached for a single base, and are
+// invalidated when the ancestry of a block is requested from another base, such as
+// after the vote graph base changed.
+type CachedChain[Hash, Number comparable] struct {
+	chain Chain[Hash, Number]
+
+	mutex   sync.Mutex
+	base    Hash
+	hasBase bool
+	// parents are the parents of the blocks whose ancestry from the base was walked,
+	// and of their ancestors down to the base.
+	parents map[Hash]Hash
+	// heads are the blocks walked which are not the parent of another block walked.
+	heads map[Hash]struct{}
+}
+
+// NewCachedChain returns a chain caching the ancestry segments of the chain given.
+func NewCachedChain[Hash, Number comparable](chain Chain[Hash, Number]) *CachedChain[Hash, Number] {
+	return &CachedChain[Hash, Number]{
+		chain:   chain,
+		parents: make(map[Hash]Hash),
+		heads:   make(map[Hash]struct{}),
+	}
+}
+
+// Ancestry returns the ancestry of the block up to but not including the base hash, in
+// reverse order from the parent of the block. It is read from the cache if the block
+// was walked from the same base, or is one of the ancestors walked. If the block is a
+// descendant of a head of the blocks walked, such as a block built on a head, only its
+// ancestry down to that head is walked in the wrapped chain.
+func (cc *CachedChain[Hash, Number]) Ancestry(base, block Hash) ([]Hash, error) {
+	cc.mutex.Lock()
+	defer cc.mutex.Unlock()
+
+	if !cc.hasBase || cc.base != base {
+		cc.invalidate()
+		cc.base = base
+		cc.hasBase = true
+	}
+
+	if _, ok := cc.parents[block]; ok {
+		return cc.cachedAncestry(cc.parents[block]), nil
+	}
+
+	// the block is not walked, so the head it descends from, if any, is its
+	// nearest ancestor walked
+	var (
+		ancestry []Hash
+		err      error
+	)
+	head, found := cc.headAncestorOf(block)
+	if found {
+		ancestry, err = cc.chain.Ancestry(head, block)
+	} else {
+		ancestry, err = cc.chain.Ancestry(base, block)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	hash := block
+	for _, parent := range ancestry {
+		cc.parents[hash] = parent
+		hash = parent
+	}
+	cc.heads[block] = struct{}{}
+	if !found {
+		cc.parents[hash] = base
+		return ancestry, nil
+	}
+
+	cc.parents[hash] = head
+	delete(cc.heads, head)
+	return append(append(ancestry, head), cc.cachedAncestry(cc.parents[head])...), nil
+}
+
+// cachedAncestry returns the cached ancestry of the block walked from the base,
+// starting with the block, or empty if the block is the base.
+func (cc *CachedChain[Hash, Number]) cachedAncestry(block Hash) []Hash {
+	ancestry := make([]Hash, 0)
+	for hash := block; hash != cc.base; hash = cc.parents[hash] {
+		ancestry = append(ancestry, hash)
+	}
+	return ancestry
+}
+
+// headAncestorOf returns the head of the blocks walked which the block descends from,
+// and false if there is none.
+func (cc *CachedChain[Hash, Number]) headAncestorOf(block Hash) (head Hash, found bool) {
+	for walked := range cc.heads {
+		if cc.chain.IsEqualOrDescendantOf(walked, block) {
+			return walked, true
+		}
+	}
+	return head, false
+}
+
+// IsEqualOrDescendantOf returns true if the block is a descendant of or equal to the base.
+// It does not query the wrapped chain for the blocks walked from the cached base.
+func (cc *CachedChain[Hash, Number]) IsEqualOrDescendantOf(base, block Hash) bool {
+	if base == block {
+		return true
+	}
+
+	cc.mutex.Lock()
+	cached := false
+	if cc.hasBase && cc.base == base {
+		_, cached = cc.parents[block]
+	}
+	cc.mutex.Unlock()
+
+	if cached {
+		return true
+	}
+	return cc.chain.IsEqualOrDescendantOf(base, block)
+}
+
+// Len returns the number of blocks whose parent is cached.
+func (cc *CachedChain[Hash, Number]) Len() int {
+	cc.mutex.Lock()
+	defer cc.mutex.Unlock()
+	return len(cc.parents)
+}
+
+// Invalidate clears the cached ancestry segments, releasing their memory once the
+// vote graph is no longer used.
+func (cc *CachedChain[Hash, Number]) Invalidate() {
+	cc.mutex.Lock()
+	defer cc.mutex.Unlock()
+	cc.invalidate()
+	cc.hasBase = false
+}
+
+func (cc *CachedChain[Hash, Number]) invalidate() {
+	cc.parents = make(map[Hash]Hash)
+	cc.heads = make(map[Hash]struct{})
+}
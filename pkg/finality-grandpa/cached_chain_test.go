@@ -0,0 +1,139 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachedChain_Ancestry(t *testing.T) {
+	inner := &countingChain{dummyChain: newDummyChain()}
+	inner.PushBlocks(GenesisHash, []string{"A", "B", "C", "D", "E"})
+	inner.PushBlocks("C", []string{"D2", "E2"})
+	c := NewCachedChain[string, uint](inner)
+
+	ancestry, err := c.Ancestry(GenesisHash, "E")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"D", "C", "B", "A"}, ancestry)
+	assert.Equal(t, 1, inner.ancestryCalls)
+	assert.Equal(t, 5, c.Len())
+
+	// the ancestry of the blocks walked is read from the cache
+	ancestry, err = c.Ancestry(GenesisHash, "E")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"D", "C", "B", "A"}, ancestry)
+	ancestry, err = c.Ancestry(GenesisHash, "C")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"B", "A"}, ancestry)
+	ancestry, err = c.Ancestry(GenesisHash, "A")
+	require.NoError(t, err)
+	assert.Equal(t, []string{}, ancestry)
+	assert.True(t, c.IsEqualOrDescendantOf(GenesisHash, "D"))
+	assert.Equal(t, 1, inner.ancestryCalls)
+
+	ancestry, err = c.Ancestry(GenesisHash, "E2")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"D2", "C", "B", "A"}, ancestry)
+	assert.Equal(t, 2, inner.ancestryCalls)
+	assert.Equal(t, 7, c.Len())
+
+	_, err = c.Ancestry("D", "E2")
+	assert.Error(t, err)
+	assert.Equal(t, 3, inner.ancestryCalls)
+	assert.Equal(t, 0, c.Len())
+	assert.False(t, c.IsEqualOrDescendantOf("D", "E2"))
+
+	// changing the base invalidates the segments walked from the previous base
+	ancestry, err = c.Ancestry("B", "E")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"D", "C"}, ancestry)
+	assert.Equal(t, 4, inner.ancestryCalls)
+	assert.Equal(t, 3, c.Len())
+
+	c.Invalidate()
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestCachedChain_Ancestry_childOfHead(t *testing.T) {
+	inner := &countingChain{dummyChain: newDummyChain()}
+	inner.PushBlocks(GenesisHash, []string{"A", "B", "C", "D", "E"})
+	c := NewCachedChain[string, uint](inner)
+
+	ancestry, err := c.Ancestry(GenesisHash, "D")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"C", "B", "A"}, ancestry)
+	assert.Equal(t, 1, inner.ancestryCalls)
+	assert.Equal(t, 3, inner.walked)
+
+	// only the ancestry of the child of the head down to the head is walked
+	ancestry, err = c.Ancestry(GenesisHash, "E")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"D", "C", "B", "A"}, ancestry)
+	assert.Equal(t, 2, inner.ancestryCalls)
+	assert.Equal(t, 3, inner.walked)
+	assert.Equal(t, 5, c.Len())
+
+	// and the blocks built on the new head too
+	inner.PushBlocks("E", []string{"F", "G"})
+	ancestry, err = c.Ancestry(GenesisHash, "G")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"F", "E", "D", "C", "B", "A"}, ancestry)
+	assert.Equal(t, 3, inner.ancestryCalls)
+	assert.Equal(t, 4, inner.walked)
+
+	// a fork off an ancestor of the head is walked from the base
+	inner.PushBlocks("B", []string{"C2"})
+	ancestry, err = c.Ancestry(GenesisHash, "C2")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"B", "A"}, ancestry)
+	assert.Equal(t, 4, inner.ancestryCalls)
+	assert.Equal(t, 6, inner.walked)
+
+	ancestry, err = c.Ancestry(GenesisHash, "F")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"E", "D", "C", "B", "A"}, ancestry)
+	assert.Equal(t, 4, inner.ancestryCalls)
+}
+
+func TestCachedChain_VoteGraph(t *testing.T) {
+	inner := &countingChain{dummyChain: newDummyChain()}
+	inner.PushBlocks(GenesisHash, []string{"A", "B", "C", "D", "E"})
+	inner.PushBlocks("C", []string{"D2", "E2"})
+	c := NewCachedChain[string, uint](inner)
+
+	// the prevote and precommit graphs of a round share the base and the cached chain
+	prevoteVn := uintVoteNode(0)
+	prevotes := NewVoteGraph[string, uint, *uintVoteNode, int](GenesisHash, uint(1), &prevoteVn, newUintVoteNode)
+	precommitVn := uintVoteNode(0)
+	precommits := NewVoteGraph[string, uint, *uintVoteNode, int](GenesisHash, uint(1), &precommitVn, newUintVoteNode)
+	expectedVn := uintVoteNode(0)
+	expected := NewVoteGraph[string, uint, *uintVoteNode, int](GenesisHash, uint(1), &expectedVn, newUintVoteNode)
+
+	votes := []struct {
+		hash   string
+		number uint
+	}{{"E", 6}, {"D2", 5}, {"C", 4}, {"E2", 6}, {"B", 3}}
+	for _, vote := range votes {
+		require.NoError(t, prevotes.Insert(vote.hash, vote.number, createUintVoteNode(1), c))
+		require.NoError(t, expected.Insert(vote.hash, vote.number, createUintVoteNode(1), inner.dummyChain))
+	}
+	calls := inner.ancestryCalls
+	for _, vote := range votes {
+		require.NoError(t, precommits.Insert(vote.hash, vote.number, createUintVoteNode(1), c))
+	}
+
+	// the ancestry walked for the prevotes is read from the cache for the precommits
+	assert.Equal(t, calls, inner.ancestryCalls)
+	for _, vg := range []*VoteGraph[string, uint, *uintVoteNode, int]{&prevotes, &precommits} {
+		assert.Equal(t, expected.entries.Keys(), vg.entries.Keys())
+		expected.entries.Scan(func(hash string, entry voteGraphEntry[string, uint, *uintVoteNode, int]) bool {
+			assert.Equal(t, entry, getVoteGraphEntry(t, vg, hash), hash)
+			return true
+		})
+		assert.Equal(t, expected.heads.Keys(), vg.heads.Keys())
+	}
+}
@@ -5,7 +5,9 @@
 
 import (
 	"fmt"
+	"io"
 
+	"github.com/ChainSafe/gossamer/pkg/scale"
 	"golang.org/x/exp/constraints"
 )
 
@@ -116,6 +118,8 @@ func weight[ID constraints.Ordered](bits []bit1, voters VoterSet[ID]) (total Vot
 type voteNodeI[voteNode, Vote any] interface {
 	Add(other voteNode)
 	AddVote(other Vote)
+	Sub(other voteNode)
+	RemoveVote(other Vote)
 	Copy() voteNode
 }
 
@@ -131,6 +135,30 @@ func (vn *voteNode[ID]) AddVote(vote vote[ID]) {
 	vn.bits.SetBit(vote.bit.position)
 }
 
+// Sub removes the votes of the other vote-node. Since the vote-node is the set of
+// votes cast in it or its descendants, a vote is removed even if it was also added
+// through another descendant, which does not happen as each voter casts a single
+// vote per stage in the graph of a round.
+func (vn *voteNode[ID]) Sub(other *voteNode[ID]) {
+	vn.bits.Clear(other.bits)
+}
+
+// RemoveVote removes the vote from the vote-node.
+func (vn *voteNode[ID]) RemoveVote(vote vote[ID]) {
+	vn.bits.ClearBit(vote.bit.position)
+}
+
+// MarshalSCALE returns the SCALE encoding of the bits of the vote-node.
+func (vn *voteNode[ID]) MarshalSCALE() ([]byte, error) {
+	return scale.Marshal(vn.bits.bits)
+}
+
+// UnmarshalSCALE decodes the bits of the vote-node encoded by MarshalSCALE.
+func (vn *voteNode[ID]) UnmarshalSCALE(reader io.Reader) error {
+	vn.bits = newBitfield()
+	return scale.NewDecoder(reader).Decode(&vn.bits.bits)
+}
+
 func (vn *voteNode[ID]) Copy() *voteNode[ID] {
 	copiedBits := newBitfield()
 	copiedBits.bits = make([]uint64, len(vn.bits.bits))
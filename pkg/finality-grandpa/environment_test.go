@@ -5,6 +5,7 @@
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -15,6 +16,136 @@
 
 type Signature uint32
 
+// clock is the source of time of the test network and of the round timers.
+type clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+	AfterFunc(d time.Duration, f func())
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (realClock) AfterFunc(d time.Duration, f func())    { time.AfterFunc(d, f) }
+
+type manualClockEvent struct {
+	deadline time.Time
+	sequence uint64
+	f        func()
+}
+
+// manualClock is a clock which only moves forward on calls to Advance, running
+// the timers and delayed message deliveries in the order of their deadlines, so
+// their interactions can be tested deterministically.
+type manualClock struct {
+	mtx      sync.Mutex
+	now      time.Time
+	sequence uint64
+	events   []manualClockEvent
+}
+
+func newManualClock() *manualClock {
+	return &manualClock{now: time.Unix(0, 0)}
+}
+
+func (c *manualClock) Now() time.Time {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.now
+}
+
+func (c *manualClock) After(d time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	c.AfterFunc(d, func() {
+		ch <- c.Now()
+	})
+	return ch
+}
+
+func (c *manualClock) AfterFunc(d time.Duration, f func()) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.sequence++
+	event := manualClockEvent{deadline: c.now.Add(d), sequence: c.sequence, f: f}
+	// events with the same deadline run in the order they were scheduled
+	i := sort.Search(len(c.events), func(i int) bool {
+		return c.events[i].deadline.After(event.deadline)
+	})
+	c.events = append(c.events, manualClockEvent{})
+	copy(c.events[i+1:], c.events[i:])
+	c.events[i] = event
+}
+
+// Advance moves the clock forward by d, running the events due in the meantime.
+// Events scheduled by running events are run too if they are due.
+func (c *manualClock) Advance(d time.Duration) {
+	c.mtx.Lock()
+	target := c.now.Add(d)
+	for len(c.events) > 0 && !c.events[0].deadline.After(target) {
+		event := c.events[0]
+		c.events = c.events[1:]
+		c.now = event.deadline
+		c.mtx.Unlock()
+		event.f()
+		c.mtx.Lock()
+	}
+	c.now = target
+	c.mtx.Unlock()
+}
+
+// Pending returns the number of events waiting for the clock to advance.
+func (c *manualClock) Pending() int {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return len(c.events)
+}
+
+// latencyModel is the delay of the messages of the test network, made of a base
+// latency, a uniformly distributed jitter drawn from a seeded source and an extra
+// delay for the messages delivered to slow nodes. A nil model delivers messages
+// immediately.
+type latencyModel struct {
+	mtx    sync.Mutex
+	base   time.Duration
+	jitter time.Duration
+	slow   map[int]time.Duration
+	rand   *rand.Rand
+}
+
+func newLatencyModel(base, jitter time.Duration, seed uint64) *latencyModel {
+	return &latencyModel{
+		base:   base,
+		jitter: jitter,
+		slow:   make(map[int]time.Duration),
+		rand:   rand.New(rand.NewSource(seed)),
+	}
+}
+
+// SetSlow adds an extra delay to the messages delivered to the node, which is
+// the index of the node in the order nodes are added to a broadcast network.
+func (l *latencyModel) SetSlow(node int, extra time.Duration) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	l.slow[node] = extra
+}
+
+func (l *latencyModel) delay(node int) time.Duration {
+	if l == nil {
+		return 0
+	}
+
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	delay := l.base + l.slow[node]
+	if l.jitter > 0 {
+		delay += time.Duration(l.rand.Int63n(int64(l.jitter) + 1))
+	}
+	return delay
+}
+
 type timer struct {
 	wakerChan *wakerChan[error]
 	expired   bool
@@ -108,15 +239,15 @@ func (e *environment) RoundData(
 
 	rd := RoundData[string, uint32, Signature, ID]{
 		VoterID:        &e.localID,
-		PrevoteTimer:   newTimer(time.NewTimer(500 * time.Millisecond).C),
-		PrecommitTimer: newTimer(time.NewTimer(1000 * time.Millisecond).C),
+		PrevoteTimer:   newTimer(e.network.clock.After(500 * time.Millisecond)),
+		PrecommitTimer: newTimer(e.network.clock.After(1000 * time.Millisecond)),
 		Incoming:       incoming,
 	}
 	return rd
 }
 
-func (*environment) RoundCommitTimer() Timer {
-	inner := time.NewTimer(time.Duration(rand.Int63n(1000)) * time.Millisecond).C
+func (e *environment) RoundCommitTimer() Timer {
+	inner := e.network.clock.After(time.Duration(rand.Int63n(1000)) * time.Millisecond)
 	timer := newTimer(inner)
 	return timer
 }
@@ -206,11 +337,15 @@ type BroadcastNetwork[M, N any] struct {
 	history  []M
 	routing  bool
 	wg       sync.WaitGroup
+	clock    clock
+	latency  *latencyModel
 }
 
-func NewBroadcastNetwork[M, N any]() *BroadcastNetwork[M, N] {
+func NewBroadcastNetwork[M, N any](clock clock, latency *latencyModel) *BroadcastNetwork[M, N] {
 	bn := BroadcastNetwork[M, N]{
 		receiver: make(chan M, 10000),
+		clock:    clock,
+		latency:  latency,
 	}
 	return &bn
 }
@@ -248,8 +383,15 @@ func (bm *BroadcastNetwork[M, N]) route() {
 	defer bm.wg.Done()
 	for msg := range bm.receiver {
 		bm.history = append(bm.history, msg)
-		for _, sender := range bm.senders {
-			sender <- msg
+		for node, sender := range bm.senders {
+			delay := bm.latency.delay(node)
+			if delay == 0 {
+				sender <- msg
+				continue
+			}
+			bm.clock.AfterFunc(delay, func() {
+				sender <- msg
+			})
 		}
 	}
 }
@@ -263,8 +405,9 @@ type RoundNetwork struct {
 	*BroadcastNetwork[SignedMessageError[string, uint32, Signature, ID], Message[string, uint32]]
 }
 
-func NewRoundNetwork() *RoundNetwork {
-	bn := NewBroadcastNetwork[SignedMessageError[string, uint32, Signature, ID], Message[string, uint32]]()
+func NewRoundNetwork(clock clock, latency *latencyModel) *RoundNetwork {
+	bn := NewBroadcastNetwork[SignedMessageError[string, uint32, Signature, ID], Message[string, uint32]](
+		clock, latency)
 	rn := RoundNetwork{bn}
 	return &rn
 }
@@ -280,8 +423,8 @@ type GlobalMessageNetwork struct {
 	*BroadcastNetwork[globalInItem, CommunicationOut]
 }
 
-func NewGlobalMessageNetwork() *GlobalMessageNetwork {
-	bn := NewBroadcastNetwork[globalInItem, CommunicationOut]()
+func NewGlobalMessageNetwork(clock clock, latency *latencyModel) *GlobalMessageNetwork {
+	bn := NewBroadcastNetwork[globalInItem, CommunicationOut](clock, latency)
 	gmn := GlobalMessageNetwork{bn}
 	return &gmn
 }
@@ -297,13 +440,24 @@ func (gmn *GlobalMessageNetwork) AddNode(
 type Network struct {
 	rounds         map[uint64]*RoundNetwork
 	globalMessages GlobalMessageNetwork
+	clock          clock
+	latency        *latencyModel
 	mtx            sync.Mutex
 }
 
 func NewNetwork() *Network {
+	return NewSimulatedNetwork(realClock{}, nil)
+}
+
+// NewSimulatedNetwork returns a test network using the clock for its message
+// deliveries and the round timers of its environments, delaying the messages
+// according to the latency model.
+func NewSimulatedNetwork(clock clock, latency *latencyModel) *Network {
 	return &Network{
 		rounds:         make(map[uint64]*RoundNetwork),
-		globalMessages: *NewGlobalMessageNetwork(),
+		globalMessages: *NewGlobalMessageNetwork(clock, latency),
+		clock:          clock,
+		latency:        latency,
 	}
 }
 
@@ -324,7 +478,7 @@ func (n *Network) MakeRoundComms(
 
 	round, ok := n.rounds[roundNumber]
 	if !ok {
-		round = NewRoundNetwork()
+		round = NewRoundNetwork(n.clock, n.latency)
 		n.rounds[roundNumber] = round
 	}
 	return round.AddNode(func(message Message[string, uint32]) SignedMessageError[string, uint32, Signature, ID] {
@@ -0,0 +1,249 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+// Package grandpamocks provides test doubles for projects embedding the
+// finality-grandpa package, so they can test their Environment and VoteGraph
+// integrations without copying the package internal test helpers.
+package grandpamocks
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	grandpa "github.com/ChainSafe/gossamer/pkg/finality-grandpa"
+)
+
+// GenesisHash is the hash of the genesis block of a Chain.
+const GenesisHash = "genesis"
+
+// GenesisNumber is the number of the genesis block of a Chain.
+const GenesisNumber uint32 = 1
+
+// ErrNotDescendant is returned by Chain.Ancestry when the block is not a
+// descendant of the base.
+var ErrNotDescendant = errors.New("block not descendant of base")
+
+type blockRecord struct {
+	hash   string
+	number uint32
+	parent string
+}
+
+// Chain is an in-memory chain of string hashes and uint32 numbers implementing
+// the grandpa.Chain interface and the BestChainContaining method of
+// grandpa.Environment. Forks are scripted with PushBlocks, and the ancestry
+// lookups can be slowed down with SetAncestryLatency to simulate a database.
+// It is safe for concurrent use.
+type Chain struct {
+	mtx             sync.RWMutex
+	blocks          map[string]blockRecord
+	leaves          []blockRecord
+	finalized       grandpa.HashNumber[string, uint32]
+	ancestryLatency time.Duration
+	ancestryCalls   uint64
+}
+
+var _ grandpa.Chain[string, uint32] = (*Chain)(nil)
+
+// NewChain returns a chain holding the genesis block only.
+func NewChain() *Chain {
+	genesis := blockRecord{hash: GenesisHash, number: GenesisNumber}
+	return &Chain{
+		blocks:    map[string]blockRecord{GenesisHash: genesis},
+		leaves:    []blockRecord{genesis},
+		finalized: grandpa.HashNumber[string, uint32]{Hash: GenesisHash, Number: GenesisNumber},
+	}
+}
+
+// PushBlocks appends the blocks on top of the parent block, each block being
+// the parent of the next one. Pushing blocks on top of a block which already
+// has children creates a fork. It panics if the parent is unknown.
+func (c *Chain) PushBlocks(parent string, blocks ...string) {
+	if len(blocks) == 0 {
+		return
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	parentRecord, ok := c.blocks[parent]
+	if !ok {
+		panic(fmt.Sprintf("unknown parent block %q", parent))
+	}
+
+	for i, leaf := range c.leaves {
+		if leaf.hash == parent {
+			c.leaves = append(c.leaves[:i], c.leaves[i+1:]...)
+			break
+		}
+	}
+
+	number := parentRecord.number
+	for _, hash := range blocks {
+		number++
+		c.blocks[hash] = blockRecord{hash: hash, number: number, parent: parent}
+		parent = hash
+	}
+
+	// leaves are kept in descending order of number, with the latest
+	// pushed leaf first among the leaves of the same number.
+	leaf := c.blocks[parent]
+	index := sort.Search(len(c.leaves), func(i int) bool {
+		return c.leaves[i].number <= leaf.number
+	})
+	c.leaves = append(c.leaves[:index], append([]blockRecord{leaf}, c.leaves[index:]...)...)
+}
+
+// SetAncestryLatency sets the time each Ancestry call takes.
+func (c *Chain) SetAncestryLatency(latency time.Duration) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.ancestryLatency = latency
+}
+
+// AncestryCalls returns the number of Ancestry calls made, including the
+// calls made by IsEqualOrDescendantOf and BestChainContaining.
+func (c *Chain) AncestryCalls() uint64 {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+	return c.ancestryCalls
+}
+
+// Ancestry returns the ancestry of the block up to but not including the base
+// hash, in reverse order from the parent of the block.
+func (c *Chain) Ancestry(base, block string) (ancestors []string, err error) {
+	c.mtx.Lock()
+	c.ancestryCalls++
+	latency := c.ancestryLatency
+	c.mtx.Unlock()
+
+	if latency > 0 {
+		time.Sleep(latency)
+	}
+
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+	return c.ancestry(base, block)
+}
+
+func (c *Chain) ancestry(base, block string) (ancestors []string, err error) {
+	ancestors = make([]string, 0)
+	for {
+		record, ok := c.blocks[block]
+		if !ok || record.hash == GenesisHash {
+			return nil, fmt.Errorf("%w: block %q, base %q", ErrNotDescendant, block, base)
+		}
+
+		block = record.parent
+		if block == base {
+			return ancestors, nil
+		}
+		ancestors = append(ancestors, block)
+	}
+}
+
+// IsEqualOrDescendantOf returns true if the block is the base or a descendant of it.
+func (c *Chain) IsEqualOrDescendantOf(base, block string) bool {
+	if base == block {
+		return true
+	}
+
+	_, err := c.Ancestry(base, block)
+	return err == nil
+}
+
+// Number returns the number of the block. It panics if the block is unknown.
+func (c *Chain) Number(hash string) uint32 {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	record, ok := c.blocks[hash]
+	if !ok {
+		panic(fmt.Sprintf("unknown block %q", hash))
+	}
+	return record.number
+}
+
+// Leaves returns the hashes of the leaves, in descending order of number.
+func (c *Chain) Leaves() []string {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	leaves := make([]string, len(c.leaves))
+	for i, leaf := range c.leaves {
+		leaves[i] = leaf.hash
+	}
+	return leaves
+}
+
+// LastFinalized returns the hash and number of the last finalized block.
+func (c *Chain) LastFinalized() (hash string, number uint32) {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+	return c.finalized.Hash, c.finalized.Number
+}
+
+// SetLastFinalized sets the last finalized block.
+func (c *Chain) SetLastFinalized(hash string, number uint32) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.finalized = grandpa.HashNumber[string, uint32]{Hash: hash, Number: number}
+}
+
+// BestChainContaining returns a channel producing the highest leaf containing
+// the base block, or nil if the base block is unknown.
+func (c *Chain) BestChainContaining(base string) grandpa.BestChain[string, uint32] {
+	bestChain := make(grandpa.BestChain[string, uint32], 1)
+	bestChain <- grandpa.BestChainOutput[string, uint32]{Value: c.bestChainContaining(base)}
+	close(bestChain)
+	return bestChain
+}
+
+func (c *Chain) bestChainContaining(base string) *grandpa.HashNumber[string, uint32] {
+	c.mtx.RLock()
+	baseRecord, ok := c.blocks[base]
+	leaves := make([]blockRecord, len(c.leaves))
+	copy(leaves, c.leaves)
+	c.mtx.RUnlock()
+
+	if !ok {
+		return nil
+	}
+
+	for _, leaf := range leaves {
+		if leaf.number < baseRecord.number {
+			break
+		}
+
+		if c.IsEqualOrDescendantOf(base, leaf.hash) {
+			return &grandpa.HashNumber[string, uint32]{Hash: leaf.hash, Number: leaf.number}
+		}
+	}
+	return nil
+}
+
+// Snapshot returns a copy of the chain, which can be modified without
+// affecting the chain, to script several scenarios from a common chain.
+// The ancestry call counter of the copy starts at zero.
+func (c *Chain) Snapshot() *Chain {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	blocks := make(map[string]blockRecord, len(c.blocks))
+	for hash, record := range c.blocks {
+		blocks[hash] = record
+	}
+
+	leaves := make([]blockRecord, len(c.leaves))
+	copy(leaves, c.leaves)
+
+	return &Chain{
+		blocks:          blocks,
+		leaves:          leaves,
+		finalized:       c.finalized,
+		ancestryLatency: c.ancestryLatency,
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpamocks
+
+import (
+	"testing"
+	"time"
+
+	grandpa "github.com/ChainSafe/gossamer/pkg/finality-grandpa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChain_PushBlocks(t *testing.T) {
+	t.Parallel()
+
+	chain := NewChain()
+	chain.PushBlocks(GenesisHash, "A", "B", "C")
+	chain.PushBlocks(GenesisHash, "A'", "B'", "C'")
+	chain.PushBlocks("A", "B''")
+
+	assert.Equal(t, []string{"C'", "C", "B''"}, chain.Leaves())
+	assert.Equal(t, uint32(4), chain.Number("C"))
+	assert.Equal(t, uint32(3), chain.Number("B''"))
+
+	ancestry, err := chain.Ancestry(GenesisHash, "C")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"B", "A"}, ancestry)
+
+	_, err = chain.Ancestry("A'", "C")
+	assert.ErrorIs(t, err, ErrNotDescendant)
+
+	assert.True(t, chain.IsEqualOrDescendantOf("A", "B''"))
+	assert.False(t, chain.IsEqualOrDescendantOf("A'", "B''"))
+	assert.Equal(t, uint64(4), chain.AncestryCalls())
+}
+
+func TestChain_BestChainContaining(t *testing.T) {
+	t.Parallel()
+
+	chain := NewChain()
+	chain.PushBlocks(GenesisHash, "A", "B", "C")
+	chain.PushBlocks("A", "B'")
+
+	best := <-chain.BestChainContaining("B'")
+	require.NoError(t, best.Error)
+	assert.Equal(t, &grandpa.HashNumber[string, uint32]{Hash: "B'", Number: 3}, best.Value)
+
+	best = <-chain.BestChainContaining("A")
+	assert.Equal(t, &grandpa.HashNumber[string, uint32]{Hash: "C", Number: 4}, best.Value)
+
+	best = <-chain.BestChainContaining("unknown")
+	assert.Nil(t, best.Value)
+}
+
+func TestChain_Snapshot(t *testing.T) {
+	t.Parallel()
+
+	chain := NewChain()
+	chain.PushBlocks(GenesisHash, "A", "B")
+
+	snapshot := chain.Snapshot()
+	snapshot.PushBlocks("B", "C")
+	snapshot.SetLastFinalized("A", 2)
+
+	assert.Equal(t, []string{"B"}, chain.Leaves())
+	assert.Equal(t, []string{"C"}, snapshot.Leaves())
+	hash, number := chain.LastFinalized()
+	assert.Equal(t, GenesisHash, hash)
+	assert.Equal(t, GenesisNumber, number)
+}
+
+func TestChain_SetAncestryLatency(t *testing.T) {
+	t.Parallel()
+
+	chain := NewChain()
+	chain.PushBlocks(GenesisHash, "A")
+	chain.SetAncestryLatency(10 * time.Millisecond)
+
+	start := time.Now()
+	_, err := chain.Ancestry(GenesisHash, "A")
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 10*time.Millisecond)
+}
+
+func TestVoteNode_VoteGraph(t *testing.T) {
+	t.Parallel()
+
+	chain := NewChain()
+	chain.PushBlocks(GenesisHash, "A", "B", "C")
+	chain.PushBlocks("A", "B'", "C'")
+
+	counters := new(VoteNodeCounters)
+	graph := grandpa.NewVoteGraph[string, uint32, *VoteNode, uint](
+		GenesisHash, GenesisNumber, NewVoteNode(counters), NewVoteNodeFactory(counters))
+
+	require.NoError(t, graph.Insert("C", 4, uint(60), chain))
+	require.NoError(t, graph.Insert("C'", 4, uint(40), chain))
+
+	ghost, err := graph.FindGHOST(nil, func(node *VoteNode) bool {
+		return node.Weight >= 50
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &grandpa.HashNumber[string, uint32]{Hash: "C", Number: 4}, ghost)
+
+	// each vote is added to its voted block and to the fork block "A" both descend from.
+	assert.Equal(t, uint64(4), counters.AddVotes())
+}
@@ -0,0 +1,100 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpamocks
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// VoteNodeCounters counts the calls made on the vote nodes sharing them.
+type VoteNodeCounters struct {
+	adds     atomic.Uint64
+	addVotes atomic.Uint64
+	subs     atomic.Uint64
+	removes  atomic.Uint64
+	copies   atomic.Uint64
+}
+
+// Adds returns the number of Add calls.
+func (c *VoteNodeCounters) Adds() uint64 { return c.adds.Load() }
+
+// AddVotes returns the number of AddVote calls.
+func (c *VoteNodeCounters) AddVotes() uint64 { return c.addVotes.Load() }
+
+// Subs returns the number of Sub calls.
+func (c *VoteNodeCounters) Subs() uint64 { return c.subs.Load() }
+
+// RemoveVotes returns the number of RemoveVote calls.
+func (c *VoteNodeCounters) RemoveVotes() uint64 { return c.removes.Load() }
+
+// Copies returns the number of Copy calls.
+func (c *VoteNodeCounters) Copies() uint64 { return c.copies.Load() }
+
+// VoteNode is a vote node accumulating vote weights, usable as the vote node
+// of a grandpa.VoteGraph with uint votes. Its calls are counted in its
+// counters, which are shared with its copies.
+type VoteNode struct {
+	Weight   uint
+	counters *VoteNodeCounters
+}
+
+// NewVoteNode returns a vote node with no weight counting its calls in the
+// given counters. The counters may be nil.
+func NewVoteNode(counters *VoteNodeCounters) *VoteNode {
+	return &VoteNode{counters: counters}
+}
+
+// NewVoteNodeFactory returns a constructor of vote nodes counting their calls
+// in the given counters, to be passed to grandpa.NewVoteGraph.
+func NewVoteNodeFactory(counters *VoteNodeCounters) func() *VoteNode {
+	return func() *VoteNode {
+		return NewVoteNode(counters)
+	}
+}
+
+// Add adds the weight of the other vote node.
+func (vn *VoteNode) Add(other *VoteNode) {
+	if vn.counters != nil {
+		vn.counters.adds.Add(1)
+	}
+	vn.Weight += other.Weight
+}
+
+// AddVote adds the weight of the vote.
+func (vn *VoteNode) AddVote(vote uint) {
+	if vn.counters != nil {
+		vn.counters.addVotes.Add(1)
+	}
+	vn.Weight += vote
+}
+
+// Sub subtracts the weight of the other vote node.
+func (vn *VoteNode) Sub(other *VoteNode) {
+	if vn.counters != nil {
+		vn.counters.subs.Add(1)
+	}
+	vn.Weight -= other.Weight
+}
+
+// RemoveVote subtracts the weight of the vote.
+func (vn *VoteNode) RemoveVote(vote uint) {
+	if vn.counters != nil {
+		vn.counters.removes.Add(1)
+	}
+	vn.Weight -= vote
+}
+
+// Copy returns a copy of the vote node sharing its counters.
+func (vn *VoteNode) Copy() *VoteNode {
+	if vn.counters != nil {
+		vn.counters.copies.Add(1)
+	}
+	copied := *vn
+	return &copied
+}
+
+func (vn *VoteNode) String() string {
+	return fmt.Sprintf("%d", vn.Weight)
+}
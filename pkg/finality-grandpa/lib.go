@@ -296,7 +296,7 @@ func (cvr CommitValidationResult) NumInvalidVoters() uint {
 // but it is recommended for the caller of this function to remove those at
 // signature-verification time.
 func ValidateCommit[ //skipcq: GO-R1005
-	Hash constraints.Ordered,
+	Hash comparable,
 	Number constraints.Unsigned,
 	Signature comparable,
 	ID constraints.Ordered,
@@ -393,7 +393,10 @@ func ValidateCommit[ //skipcq: GO-R1005
 
 	// for the commit to be valid, then a precommit ghost must be found for the
 	// round and it must be equal to the commit target
-	precommitGHOST := round.PrecommitGHOST()
+	precommitGHOST, err := round.PrecommitGHOST()
+	if err != nil {
+		return CommitValidationResult{}, err
+	}
 	switch {
 	case precommitGHOST != nil:
 		if precommitGHOST.Hash == commit.TargetHash && precommitGHOST.Number == commit.TargetNumber {
@@ -12,7 +12,7 @@
 //
 // that point is when the round-estimate is finalized.
 type backgroundRound[
-	Hash constraints.Ordered, Number constraints.Unsigned, Signature comparable,
+	Hash comparable, Number constraints.Unsigned, Signature comparable,
 	ID constraints.Ordered, E Environment[Hash, Number, Signature, ID],
 ] struct {
 	inner           votingRound[Hash, Number, Signature, ID, E]
@@ -139,7 +139,7 @@ func (br *backgroundRound[Hash, Number, Signature, ID, E]) poll(waker *waker) (
 }
 
 type roundCommitter[
-	Hash constraints.Ordered, Number constraints.Unsigned, Signature comparable,
+	Hash comparable, Number constraints.Unsigned, Signature comparable,
 	ID constraints.Ordered, E Environment[Hash, Number, Signature, ID],
 ] struct {
 	commitTimer   Timer
@@ -148,7 +148,7 @@ func (br *backgroundRound[Hash, Number, Signature, ID, E]) poll(waker *waker) (
 }
 
 func newRoundCommitter[
-	Hash constraints.Ordered, Number constraints.Unsigned, Signature comparable,
+	Hash comparable, Number constraints.Unsigned, Signature comparable,
 	ID constraints.Ordered, E Environment[Hash, Number, Signature, ID],
 ](
 	commitTimer Timer,
@@ -230,14 +230,14 @@ func (rc *roundCommitter[Hash, Number, Signature, ID, E]) commit(
 
 // A stream for past rounds, which produces any commit messages from those
 // rounds and drives them to completion.
-type pastRounds[Hash constraints.Ordered, Number constraints.Unsigned, Signature comparable,
+type pastRounds[Hash comparable, Number constraints.Unsigned, Signature comparable,
 	ID constraints.Ordered, E Environment[Hash, Number, Signature, ID],
 ] struct {
 	pastRounds    []backgroundRound[Hash, Number, Signature, ID, E]
 	commitSenders map[uint64]chan Commit[Hash, Number, Signature, ID]
 }
 
-func newPastRounds[Hash constraints.Ordered, Number constraints.Unsigned, Signature comparable,
+func newPastRounds[Hash comparable, Number constraints.Unsigned, Signature comparable,
 	ID constraints.Ordered, E Environment[Hash, Number, Signature, ID]]() *pastRounds[Hash, Number, Signature, ID, E] {
 	return &pastRounds[Hash, Number, Signature, ID, E]{
 		commitSenders: make(map[uint64]chan Commit[Hash, Number, Signature, ID]),
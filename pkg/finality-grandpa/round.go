@@ -213,7 +213,7 @@ type RoundParams[ID constraints.Ordered, Hash comparable, Number constraints.Uns
 }
 
 // Round stores data for a round.
-type Round[ID constraints.Ordered, Hash constraints.Ordered, Number constraints.Unsigned, Signature comparable] struct {
+type Round[ID constraints.Ordered, Hash comparable, Number constraints.Unsigned, Signature comparable] struct {
 	number          uint64
 	context         roundContext[ID]
 	graph           VoteGraph[Hash, Number, *voteNode[ID], vote[ID]]    // DAG of blocks which have been voted on.
@@ -225,6 +225,9 @@ type Round[ID constraints.Ordered, Hash constraints.Ordered, Number constraints.
 	finalized       *HashNumber[Hash, Number]                           // best finalized block in this round.
 	estimate        *HashNumber[Hash, Number]                           // current memoized round-estimate
 	completable     bool                                                // whether the round is completable
+
+	prevoteGhostCache   *GHOSTCache[Hash, Number, *voteNode[ID]] // prevote-GHOST cached by the graph
+	precommitGhostCache *GHOSTCache[Hash, Number, *voteNode[ID]] // precommit-GHOST cached by the graph
 }
 
 // Result of importing a Prevote or Precommit.
@@ -235,14 +238,14 @@ type importResult[ID constraints.Ordered, P, Signature comparable] struct {
 }
 
 // NewRound creates a new round accumulator for given round number and with given weight.
-func NewRound[ID constraints.Ordered, Hash constraints.Ordered, Number constraints.Unsigned, Signature comparable](
+func NewRound[ID constraints.Ordered, Hash comparable, Number constraints.Unsigned, Signature comparable](
 	roundParams RoundParams[ID, Hash, Number],
 ) *Round[ID, Hash, Number, Signature] {
 
 	var newVoteNode = func() *voteNode[ID] {
 		return &voteNode[ID]{newBitfield()}
 	}
-	return &Round[ID, Hash, Number, Signature]{
+	r := &Round[ID, Hash, Number, Signature]{
 		number:  roundParams.RoundNumber,
 		context: newRoundContext(roundParams.Voters),
 		graph: NewVoteGraph[Hash, Number, *voteNode[ID], vote[ID]](
@@ -255,6 +258,13 @@ func NewRound[ID constraints.Ordered, Hash constraints.Ordered, Number constrain
 		precommits:      newVoteTracker[ID, Precommit[Hash, Number], Signature](),
 		historicalVotes: NewHistoricalVotes[Hash, Number, Signature, ID](),
 	}
+	r.prevoteGhostCache = r.graph.NewGHOSTCache(func(v *voteNode[ID]) bool {
+		return r.context.Weight(*v, PrevotePhase) >= VoteWeight(r.context.voters.threshold)
+	})
+	r.precommitGhostCache = r.graph.NewGHOSTCache(func(v *voteNode[ID]) bool {
+		return r.context.Weight(*v, PrecommitPhase) >= VoteWeight(r.context.voters.threshold)
+	})
+	return r
 }
 
 // Number returns the round number.
@@ -314,6 +324,8 @@ func (r *Round[ID, H, N, S]) importPrevote(
 
 		// mark the equivocator as such. no need to "undo" the first vote.
 		r.context.Equivocated(*info, PrevotePhase)
+		// the weight of the equivocator now counts for every vote-node
+		r.graph.InvalidateGHOSTCache(r.prevoteGhostCache)
 
 		// Push the vote into HistoricalVotes.
 		message := Message[H, N]{}
@@ -337,12 +349,17 @@ func (r *Round[ID, H, N, S]) importPrevote(
 	// update prevote-GHOST
 	threshold := r.context.voters.threshold
 	if r.prevotes.currentWeight >= VoteWeight(threshold) {
-		r.prevoteGhost = r.graph.FindGHOST(r.prevoteGhost, func(v *voteNode[ID]) bool {
-			return r.context.Weight(*v, PrevotePhase) >= VoteWeight(threshold)
-		})
+		prevoteGhost, err := r.graph.FindCachedGHOST(r.prevoteGhostCache)
+		if err != nil {
+			return nil, fmt.Errorf("finding prevote GHOST: %w", err)
+		}
+		r.prevoteGhost = prevoteGhost
 	}
 
-	r.update()
+	err := r.update()
+	if err != nil {
+		return nil, err
+	}
 	ir.Equivocation = equivocation
 	return &ir, nil
 }
@@ -399,6 +416,8 @@ func (r *Round[ID, H, N, S]) importPrecommit(
 
 		// mark the equivocator as such. no need to "undo" the first vote.
 		r.context.Equivocated(*info, PrecommitPhase)
+		// the weight of the equivocator now counts for every vote-node
+		r.graph.InvalidateGHOSTCache(r.precommitGhostCache)
 
 		// Push the vote into HistoricalVotes.
 		message := Message[H, N]{}
@@ -419,30 +438,38 @@ func (r *Round[ID, H, N, S]) importPrecommit(
 		panic("invalid voteMultiplicity value")
 	}
 
-	r.update()
+	err := r.update()
+	if err != nil {
+		return nil, err
+	}
 	ir.Equivocation = equivocation
 	return &ir, nil
 }
 
 // update the round-estimate and whether the round is completable.
-func (r *Round[ID, H, N, S]) update() {
+func (r *Round[ID, H, N, S]) update() error {
 	threshold := r.context.voters.threshold
 
 	if r.prevotes.currentWeight < VoteWeight(threshold) {
-		return
+		return nil
 	}
 
 	if r.prevoteGhost == nil {
-		return
+		return nil
 	}
 
 	// anything new finalized? finalized blocks are those which have both
 	// 2/3+ prevote and precommit weight.
 	currentPrecommits := r.precommits.currentWeight
 	if currentPrecommits >= VoteWeight(threshold) {
-		r.finalized = r.graph.FindAncestor(r.prevoteGhost.Hash, r.prevoteGhost.Number, func(v *voteNode[ID]) bool {
-			return r.context.Weight(*v, PrecommitPhase) >= VoteWeight(threshold)
-		})
+		finalized, err := r.graph.FindAncestor(r.prevoteGhost.Hash, r.prevoteGhost.Number,
+			func(v *voteNode[ID]) bool {
+				return r.context.Weight(*v, PrecommitPhase) >= VoteWeight(threshold)
+			})
+		if err != nil {
+			return fmt.Errorf("finding finalized block: %w", err)
+		}
+		r.finalized = finalized
 	}
 
 	// figuring out whether a block can still be committed for is
@@ -490,16 +517,23 @@ func (r *Round[ID, H, N, S]) update() {
 	// the round-estimate is the highest block in the chain with head
 	// `prevoteGhost` that could have supermajority-commits.
 	if r.precommits.currentWeight >= VoteWeight(threshold) {
-		r.estimate = r.graph.FindAncestor(r.prevoteGhost.Hash, r.prevoteGhost.Number, possibleToPrecommit)
+		estimate, err := r.graph.FindAncestor(r.prevoteGhost.Hash, r.prevoteGhost.Number, possibleToPrecommit)
+		if err != nil {
+			return fmt.Errorf("finding estimate: %w", err)
+		}
+		r.estimate = estimate
 	} else {
 		r.estimate = &HashNumber[H, N]{r.prevoteGhost.Hash, r.prevoteGhost.Number}
-		return
+		return nil
 	}
 
 	if r.estimate != nil {
 		var ls bool = r.estimate.Hash != r.prevoteGhost.Hash
 		var rs bool
-		x := r.graph.FindGHOST(r.estimate, possibleToPrecommit)
+		x, err := r.graph.FindGHOST(r.estimate, possibleToPrecommit)
+		if err != nil {
+			return fmt.Errorf("finding precommit GHOST of estimate: %w", err)
+		}
 		if x == nil {
 			rs = true
 		} else {
@@ -509,6 +543,7 @@ func (r *Round[ID, H, N, S]) update() {
 	} else {
 		r.completable = false
 	}
+	return nil
 }
 
 // State returns the current state.
@@ -522,18 +557,20 @@ func (r *Round[ID, H, N, S]) State() RoundState[H, N] {
 }
 
 // PrecommitGHOST will compute and cache the precommit-GHOST.
-func (r *Round[ID, H, N, S]) PrecommitGHOST() *HashNumber[H, N] {
+func (r *Round[ID, H, N, S]) PrecommitGHOST() (*HashNumber[H, N], error) {
 	// update precommit-GHOST
 	var threshold = r.Threshold()
 	if r.precommits.currentWeight >= VoteWeight(threshold) {
-		r.precommitGhost = r.graph.FindGHOST(r.precommitGhost, func(v *voteNode[ID]) bool {
-			return r.context.Weight(*v, PrecommitPhase) >= VoteWeight(threshold)
-		})
+		precommitGhost, err := r.graph.FindCachedGHOST(r.precommitGhostCache)
+		if err != nil {
+			return nil, fmt.Errorf("finding precommit GHOST: %w", err)
+		}
+		r.precommitGhost = precommitGhost
 	}
-	return r.precommitGhost
+	return r.precommitGhost, nil
 }
 
-type yieldVotes[H constraints.Ordered, N constraints.Unsigned, S comparable] struct {
+type yieldVotes[H comparable, N constraints.Unsigned, S comparable] struct {
 	yielded      uint
 	multiplicity voteMultiplicity[Precommit[H, N], S]
 }
@@ -640,6 +677,11 @@ func (r *Round[ID, H, N, S]) Base() HashNumber[H, N] {
 	return r.graph.Base()
 }
 
+// GraphStats returns the size and depth statistics of the vote graph of the round.
+func (r *Round[ID, H, N, S]) GraphStats() VoteGraphStats[N] {
+	return r.graph.Stats()
+}
+
 // Voters returns the round voters and weights.
 func (r *Round[ID, H, N, S]) Voters() VoterSet[ID] {
 	return r.context.voters
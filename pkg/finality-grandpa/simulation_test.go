@@ -0,0 +1,102 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManualClock_Advance(t *testing.T) {
+	t.Parallel()
+
+	clock := newManualClock()
+	start := clock.Now()
+
+	var order []string
+	clock.AfterFunc(20*time.Millisecond, func() { order = append(order, "b") })
+	clock.AfterFunc(10*time.Millisecond, func() {
+		order = append(order, "a")
+		clock.AfterFunc(5*time.Millisecond, func() { order = append(order, "a'") })
+	})
+	clock.AfterFunc(20*time.Millisecond, func() { order = append(order, "c") })
+	clock.AfterFunc(30*time.Millisecond, func() { order = append(order, "d") })
+	after := clock.After(20 * time.Millisecond)
+
+	clock.Advance(19 * time.Millisecond)
+	assert.Equal(t, []string{"a", "a'"}, order)
+	assert.Equal(t, start.Add(19*time.Millisecond), clock.Now())
+	assert.Len(t, after, 0)
+
+	clock.Advance(time.Millisecond)
+	assert.Equal(t, []string{"a", "a'", "b", "c"}, order)
+	require.Len(t, after, 1)
+	assert.Equal(t, start.Add(20*time.Millisecond), <-after)
+	assert.Equal(t, 1, clock.Pending())
+}
+
+func TestLatencyModel_delay(t *testing.T) {
+	t.Parallel()
+
+	var nilModel *latencyModel
+	assert.Equal(t, time.Duration(0), nilModel.delay(0))
+
+	const base, jitter = 50 * time.Millisecond, 20 * time.Millisecond
+	first := newLatencyModel(base, jitter, 1)
+	second := newLatencyModel(base, jitter, 1)
+	first.SetSlow(1, time.Second)
+	second.SetSlow(1, time.Second)
+
+	for i := 0; i < 100; i++ {
+		node := i % 2
+		delay := first.delay(node)
+		assert.Equal(t, delay, second.delay(node))
+
+		minimum := base
+		if node == 1 {
+			minimum += time.Second
+		}
+		assert.GreaterOrEqual(t, delay, minimum)
+		assert.LessOrEqual(t, delay, minimum+jitter)
+	}
+}
+
+func TestSimulatedNetwork_voteAfterPrecommitTimer(t *testing.T) {
+	t.Parallel()
+
+	clock := newManualClock()
+	latency := newLatencyModel(0, 0, 0)
+	// the votes of the first voter reach the second one just after its precommit timer
+	latency.SetSlow(1, 1001*time.Millisecond)
+	network := NewSimulatedNetwork(clock, latency)
+	defer network.Stop()
+
+	firstOut := make(chan Message[string, uint32])
+	firstIn := network.MakeRoundComms(1, 0, firstOut)
+	secondIn := network.MakeRoundComms(1, 1, make(chan Message[string, uint32]))
+	precommitTimer := clock.After(1000 * time.Millisecond)
+
+	firstOut <- NewMessage[string, uint32](Prevote[string, uint32]{TargetHash: "A", TargetNumber: 1})
+	select {
+	case message := <-firstIn:
+		assert.Equal(t, ID(0), message.SignedMessage.ID)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the vote of the first voter")
+	}
+	require.Eventually(t, func() bool {
+		return clock.Pending() == 2
+	}, time.Second, time.Millisecond)
+
+	clock.Advance(1000 * time.Millisecond)
+	assert.Len(t, precommitTimer, 1)
+	assert.Len(t, secondIn, 0)
+
+	clock.Advance(time.Millisecond)
+	require.Len(t, secondIn, 1)
+	message := <-secondIn
+	assert.Equal(t, ID(0), message.SignedMessage.ID)
+}
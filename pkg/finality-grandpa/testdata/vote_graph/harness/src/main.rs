@@ -0,0 +1,134 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+//! Replays the vote graph fixtures generated by the gossamer differential tests
+//! over the vote graph of the parity finality-grandpa crate, and checks the
+//! results of the queries are identical to the ones recorded by gossamer.
+
+use std::collections::HashMap;
+use std::{env, fs, process};
+
+use finality_grandpa::vote_graph::VoteGraph;
+use finality_grandpa::{Chain, Error};
+use serde::Deserialize;
+
+#[derive(Debug, Clone, PartialEq, Deserialize)]
+struct Block {
+	hash: String,
+	number: u32,
+	#[serde(default)]
+	parent: Option<String>,
+}
+
+#[derive(Debug, Deserialize)]
+struct Operation {
+	op: String,
+	block: Option<Block>,
+	#[serde(default)]
+	weight: u64,
+	#[serde(default)]
+	threshold: u64,
+	expected: Option<Block>,
+}
+
+#[derive(Debug, Deserialize)]
+struct Fixture {
+	seed: i64,
+	base: Block,
+	chain: Vec<Block>,
+	operations: Vec<Operation>,
+}
+
+/// Chain of the fixture blocks, mirroring the dummy chain of the gossamer tests.
+struct FixtureChain {
+	parents: HashMap<String, String>,
+}
+
+impl Chain<String, u32> for FixtureChain {
+	fn ancestry(&self, base: String, mut block: String) -> Result<Vec<String>, Error> {
+		let mut ancestry = Vec::new();
+		loop {
+			match self.parents.get(&block) {
+				None => return Err(Error::NotDescendent),
+				Some(parent) if *parent == base => return Ok(ancestry),
+				Some(parent) => {
+					ancestry.push(parent.clone());
+					block = parent.clone();
+				}
+			}
+		}
+	}
+}
+
+fn replay(fixture: &Fixture) -> Vec<String> {
+	let chain = FixtureChain {
+		parents: fixture
+			.chain
+			.iter()
+			.map(|block| (block.hash.clone(), block.parent.clone().unwrap_or_default()))
+			.collect(),
+	};
+	let mut graph = VoteGraph::new(fixture.base.hash.clone(), fixture.base.number, 0u64);
+
+	let mut mismatches = Vec::new();
+	for (i, operation) in fixture.operations.iter().enumerate() {
+		let threshold = operation.threshold;
+		let condition = |weight: &u64| *weight >= threshold;
+		let block = operation.block.as_ref().map(|block| (block.hash.clone(), block.number));
+
+		let result = match operation.op.as_str() {
+			"insert" => {
+				let (hash, number) = block.expect("insert operations have a block");
+				if let Err(err) = graph.insert(hash, number, operation.weight, &chain) {
+					mismatches.push(format!("seed {} operation {}: insert failed: {:?}", fixture.seed, i, err));
+				}
+				continue;
+			}
+			"find_ghost" => graph.find_ghost(block, condition),
+			"find_ancestor" => {
+				let (hash, number) = block.expect("find_ancestor operations have a block");
+				graph.find_ancestor(hash, number, condition)
+			}
+			op => {
+				mismatches.push(format!("seed {} operation {}: unknown operation {}", fixture.seed, i, op));
+				continue;
+			}
+		};
+
+		let expected = operation.expected.as_ref().map(|block| (block.hash.clone(), block.number));
+		if result != expected {
+			mismatches.push(format!(
+				"seed {} operation {} ({}): expected {:?} but got {:?}",
+				fixture.seed, i, operation.op, expected, result
+			));
+		}
+	}
+	mismatches
+}
+
+fn main() {
+	let paths: Vec<String> = env::args().skip(1).collect();
+	if paths.is_empty() {
+		eprintln!("usage: vote-graph-harness <fixtures.json>...");
+		process::exit(2);
+	}
+
+	let mut failed = false;
+	for path in paths {
+		let data = fs::read_to_string(&path).unwrap_or_else(|err| panic!("reading {}: {}", path, err));
+		let fixtures: Vec<Fixture> =
+			serde_json::from_str(&data).unwrap_or_else(|err| panic!("decoding {}: {}", path, err));
+
+		for fixture in &fixtures {
+			for mismatch in replay(fixture) {
+				eprintln!("{}: {}", path, mismatch);
+				failed = true;
+			}
+		}
+		println!("{}: replayed {} fixtures", path, fixtures.len());
+	}
+
+	if failed {
+		process::exit(1);
+	}
+}
@@ -4,15 +4,45 @@
 package grandpa
 
 import (
+	"errors"
 	"fmt"
+	"unsafe"
 
-	"github.com/tidwall/btree"
 	"golang.org/x/exp/constraints"
+	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
 )
 
+var (
+	// ErrEmptyAncestryProof is returned by AdjustBase when the ancestry proof is empty.
+	ErrEmptyAncestryProof = errors.New("empty ancestry proof")
+	// ErrAncestryProofTooLong is returned by AdjustBase when the ancestry proof is
+	// longer than the number of blocks before the current base.
+	ErrAncestryProofTooLong = errors.New("ancestry proof longer than base number")
+	// ErrAncestryProofMismatch is returned by AdjustBase when the ancestry proof
+	// does not connect the new base to the current base.
+	ErrAncestryProofMismatch = errors.New("ancestry proof does not connect to base")
+	// ErrPruneBlockNotInGraph is returned by Prune when the finalized block is neither
+	// a vote-node nor in the ancestor-edge of a vote-node of the graph.
+	ErrPruneBlockNotInGraph = errors.New("finalized block not in graph")
+	// ErrRebaseNotVoteNode is returned by Rebase when the new base is not a vote-node
+	// of the graph at the number given.
+	ErrRebaseNotVoteNode = errors.New("new base is not a vote-node")
+	// ErrMissingEntry is returned when a vote-node referenced by the graph is missing
+	// from it, which means the graph is corrupted.
+	ErrMissingEntry = errors.New("vote-node missing from graph")
+	// ErrInvalidAncestry is returned when the ancestry of a block, as returned by the chain
+	// or recorded in the graph, does not connect the block to the vote-nodes of the graph.
+	ErrInvalidAncestry = errors.New("invalid ancestry")
+	// ErrUnsupportedVote is returned by Insert when the vote is neither a vote nor a vote-node.
+	ErrUnsupportedVote = errors.New("unsupported vote type")
+	// ErrVoteNotInGraph is returned by Remove when the block of the vote is not a
+	// vote-node of the graph, so the vote was never inserted.
+	ErrVoteNotInGraph = errors.New("vote not in graph")
+)
+
 type voteGraphEntry[
-	Hash constraints.Ordered,
+	Hash comparable,
 	Number constraints.Integer,
 	voteNode voteNodeI[voteNode, Vote],
 	Vote any,
@@ -23,6 +53,9 @@
 	ancestors      []Hash
 	descendants    []Hash // descendent vote-nodes
 	cumulativeVote voteNode
+	// votes is the number of votes inserted on the vote-node itself and not removed,
+	// rather than only accumulated from its descendants.
+	votes uint32
 }
 
 // whether the given hash, number pair is a direct ancestor of this node.
@@ -59,24 +92,44 @@ func (vge voteGraphEntry[Hash, Number, voteNode, Vote]) ancestorNode() *Hash {
 	return &h
 }
 
+// headIndex summarises the ancestry of a head vote-node down to the base, so heads
+// which cannot contain a block are skipped without walking their ancestor-edges.
+// The ancestry of a head only grows when the base is adjusted, since introducing
+// branches and compacting vote-nodes only rearrange the ancestor-edges along it.
+type headIndex[Hash comparable, Number constraints.Unsigned] struct {
+	number    Number
+	ancestors map[Hash]struct{}
+}
+
+// whether the given hash, number pair may be in the ancestry of the head.
+func (hi headIndex[Hash, Number]) mayContain(hash Hash, num Number) bool {
+	if num >= hi.number {
+		return false
+	}
+	_, ok := hi.ancestors[hash]
+	return ok
+}
+
 // VoteGraph maintains a DAG of blocks in the chain which have votes attached to them,
 // and vote data which is accumulated along edges.
 type VoteGraph[
-	Hash constraints.Ordered,
+	Hash comparable,
 	Number constraints.Unsigned,
 	voteNode voteNodeI[voteNode, Vote],
 	Vote any,
 ] struct {
-	entries            *btree.Map[Hash, voteGraphEntry[Hash, Number, voteNode, Vote]]
-	heads              *btree.Set[Hash]
+	entries            *hashMap[Hash, voteGraphEntry[Hash, Number, voteNode, Vote]]
+	heads              *hashSet[Hash]
+	headIndexes        map[Hash]headIndex[Hash, Number]
 	base               Hash
 	baseNumber         Number
 	newDefaultvoteNode func() voteNode
+	ghostCaches        []*GHOSTCache[Hash, Number, voteNode]
 }
 
 // NewVoteGraph creates a new `VoteGraph` with base node as given.
 func NewVoteGraph[
-	Hash constraints.Ordered,
+	Hash comparable,
 	Number constraints.Unsigned,
 	voteNode voteNodeI[voteNode, Vote],
 	Vote any,
@@ -86,18 +139,24 @@ func NewVoteGraph[
 	baseNode voteNode,
 	newDefaultvoteNode func() voteNode,
 ) VoteGraph[Hash, Number, voteNode, Vote] {
-	entries := btree.NewMap[Hash, voteGraphEntry[Hash, Number, voteNode, Vote]](2)
+	entries := newHashMap[Hash, voteGraphEntry[Hash, Number, voteNode, Vote]]()
+	// the base node given may hold votes of its own, counted as a single vote
 	entries.Set(baseHash, voteGraphEntry[Hash, Number, voteNode, Vote]{
 		number:         baseNumber,
 		ancestors:      make([]Hash, 0),
 		descendants:    make([]Hash, 0),
 		cumulativeVote: baseNode,
+		votes:          1,
 	})
-	heads := &btree.Set[Hash]{}
+	heads := newHashSet[Hash]()
 	heads.Insert(baseHash)
+	headIndexes := map[Hash]headIndex[Hash, Number]{
+		baseHash: {number: baseNumber, ancestors: make(map[Hash]struct{})},
+	}
 	return VoteGraph[Hash, Number, voteNode, Vote]{
 		entries:            entries,
 		heads:              heads,
+		headIndexes:        headIndexes,
 		base:               baseHash,
 		baseNumber:         baseNumber,
 		newDefaultvoteNode: newDefaultvoteNode,
@@ -117,26 +176,30 @@ func (vg *VoteGraph[Hash, Number, voteNode, Vote]) append(
 	}
 	ancestry = append(ancestry, vg.base)
 
-	var ancestorIndex *int
+	ancestorIndex := -1
+	var ancestorEntry voteGraphEntry[Hash, Number, voteNode, Vote]
 	for i, ancestor := range ancestry {
 		entry, ok := vg.entries.Get(ancestor)
 		if ok {
-			entry.descendants = append(entry.descendants, hash)
-			vg.entries.Set(ancestor, entry)
-			if ancestorIndex == nil {
-				ai := i
-				ancestorIndex = &ai
-				break
-			}
+			ancestorIndex = i
+			ancestorEntry = entry
+			break
 		}
 	}
 
-	if ancestorIndex == nil {
-		panic(fmt.Errorf("base is kept; chain returns ancestry only if the block is a descendent of base;"))
+	// the base is kept, so the ancestry of a descendant of the base always contains a vote-node
+	if ancestorIndex == -1 {
+		return fmt.Errorf("%w: ancestry of %v does not contain a vote-node", ErrInvalidAncestry, hash)
+	}
+	if uint64(ancestorEntry.number)+uint64(ancestorIndex)+1 != uint64(num) {
+		return fmt.Errorf("%w: ancestry of %v at number %d contains vote-node %v at number %d at offset %d",
+			ErrInvalidAncestry, hash, num, ancestry[ancestorIndex], ancestorEntry.number, ancestorIndex)
 	}
 
-	ancestorHash := ancestry[*ancestorIndex]
-	ancestry = ancestry[0 : *ancestorIndex+1]
+	ancestorHash := ancestry[ancestorIndex]
+	ancestry = ancestry[0 : ancestorIndex+1]
+	ancestorEntry.descendants = append(ancestorEntry.descendants, hash)
+	vg.entries.Set(ancestorHash, ancestorEntry)
 
 	vg.entries.Set(hash, voteGraphEntry[Hash, Number, voteNode, Vote]{
 		number:         num,
@@ -147,95 +210,111 @@ func (vg *VoteGraph[Hash, Number, voteNode, Vote]) append(
 
 	vg.heads.Delete(ancestorHash)
 	vg.heads.Insert(hash)
-	return
+	delete(vg.headIndexes, ancestorHash)
+	return vg.indexHead(hash)
+}
+
+// indexHead builds the head index of the given vote-node from the ancestor-edges
+// leading back to the base.
+func (vg *VoteGraph[Hash, Number, voteNode, Vote]) indexHead(hash Hash) error {
+	entry, err := vg.getEntry(hash)
+	if err != nil {
+		return err
+	}
+	index := headIndex[Hash, Number]{
+		number:    entry.number,
+		ancestors: make(map[Hash]struct{}),
+	}
+	for {
+		for _, ancestor := range entry.ancestors {
+			index.ancestors[ancestor] = struct{}{}
+		}
+		parent := entry.ancestorNode()
+		if parent == nil {
+			break
+		}
+		entry, err = vg.getEntry(*parent)
+		if err != nil {
+			return err
+		}
+	}
+	vg.headIndexes[hash] = index
+	return nil
 }
 
 // introduce a branch to given vote-nodes.
 //
 // `descendents` is a list of nodes with ancestor-edges containing the given ancestor.
 //
-// This function panics if any member of `descendents` is not a vote-node
-// or does not have ancestor with given hash and number OR if `ancestorHash`
-// is already a known entry.
-
+// This function returns an error and leaves the graph unchanged if any member of
+// `descendents` is not a vote-node or does not have ancestor with given hash and number.
+// `ancestorHash` must not already be a known entry.
 func (vg *VoteGraph[Hash, Number, voteNode, Vote]) introduceBranch(
 	descendants []Hash,
 	ancestorHash Hash,
 	ancestorNumber Number,
-) {
-	var producedEntry *struct {
-		entry voteGraphEntry[Hash, Number, voteNode, Vote]
-		hash  *Hash
-	}
-	var maybeEntry *struct {
-		entry voteGraphEntry[Hash, Number, voteNode, Vote]
-		hash  *Hash
+) error {
+	if len(descendants) == 0 {
+		return nil
 	}
-	for _, descendant := range descendants {
-		entry, ok := vg.entries.Get(descendant)
-		if !ok {
-			panic("this function only invoked with keys of vote-nodes; qed")
+
+	entries := make([]voteGraphEntry[Hash, Number, voteNode, Vote], len(descendants))
+	for i, descendant := range descendants {
+		entry, err := vg.getEntry(descendant)
+		if err != nil {
+			return err
 		}
 
 		ida := entry.inDirectAncestry(ancestorHash, ancestorNumber)
 		if ida == nil || !*ida {
-			panic("entry is supposed to be in direct ancestry")
-		}
-
-		// example: splitting number 10 at ancestor 4
-		// before: [9 8 7 6 5 4 3 2 1]
-		// after: [9 8 7 6 5 4], [3 2 1]
-		// we ensure the `entry.ancestors` is drained regardless of whether
-		// the `newEntry` has already been constructed.
-		{
-			prevAncestor := entry.ancestorNode()
-			var offset uint
-			if ancestorNumber > entry.number {
-				panic("this function only invoked with direct ancestors; qed")
-			} else {
-				offset = uint(entry.number - ancestorNumber)
-			}
-			newAncestors := entry.ancestors[offset:len(entry.ancestors)]
-			entry.ancestors = entry.ancestors[0:offset]
-			vg.entries.Set(descendant, entry)
-
-			if maybeEntry == nil {
-				maybeEntry = &struct {
-					entry voteGraphEntry[Hash, Number, voteNode, Vote]
-					hash  *Hash
-				}{
-					entry: voteGraphEntry[Hash, Number, voteNode, Vote]{
-						number:         ancestorNumber,
-						ancestors:      newAncestors,
-						descendants:    make([]Hash, 0),
-						cumulativeVote: vg.newDefaultvoteNode(),
-					},
-					hash: prevAncestor,
-				}
-			}
-			maybeEntry.entry.descendants = append(maybeEntry.entry.descendants, descendant)
-			maybeEntry.entry.cumulativeVote.Add(entry.cumulativeVote)
+			return fmt.Errorf("%w: %v at number %d not in the ancestor-edge of vote-node %v",
+				ErrInvalidAncestry, ancestorHash, ancestorNumber, descendant)
 		}
-		producedEntry = maybeEntry
+		entries[i] = entry
 	}
 
-	if producedEntry != nil {
-		newEntry := producedEntry.entry
-		prevAncestor := producedEntry.hash
-		if prevAncestor != nil {
-			prevancestorNode, _ := vg.entries.Get(*prevAncestor)
-			prevancestorNodeDescendants := make([]Hash, 0)
-			for _, d := range prevancestorNode.descendants {
-				if !slices.Contains(newEntry.descendants, d) {
-					prevancestorNodeDescendants = append(prevancestorNodeDescendants, d)
-				}
+	// the descendants share their ancestor-edge below the given ancestor, which
+	// leads to the ancestor vote-node the new vote-node is a descendant of.
+	prevAncestor := entries[0].ancestorNode()
+	var prevAncestorEntry voteGraphEntry[Hash, Number, voteNode, Vote]
+	if prevAncestor != nil {
+		var err error
+		prevAncestorEntry, err = vg.getEntry(*prevAncestor)
+		if err != nil {
+			return err
+		}
+	}
+
+	// example: splitting number 10 at ancestor 4
+	// before: [9 8 7 6 5 4 3 2 1]
+	// after: [9 8 7 6 5 4], [3 2 1]
+	newEntry := voteGraphEntry[Hash, Number, voteNode, Vote]{
+		number:         ancestorNumber,
+		ancestors:      entries[0].ancestors[entries[0].number-ancestorNumber:],
+		descendants:    make([]Hash, 0, len(descendants)),
+		cumulativeVote: vg.newDefaultvoteNode(),
+	}
+	for i, descendant := range descendants {
+		entry := entries[i]
+		entry.ancestors = entry.ancestors[:entry.number-ancestorNumber]
+		vg.entries.Set(descendant, entry)
+
+		newEntry.descendants = append(newEntry.descendants, descendant)
+		newEntry.cumulativeVote.Add(entry.cumulativeVote)
+	}
+
+	if prevAncestor != nil {
+		prevAncestorDescendants := make([]Hash, 0, len(prevAncestorEntry.descendants)+1)
+		for _, d := range prevAncestorEntry.descendants {
+			if !slices.Contains(newEntry.descendants, d) {
+				prevAncestorDescendants = append(prevAncestorDescendants, d)
 			}
-			prevancestorNodeDescendants = append(prevancestorNodeDescendants, ancestorHash)
-			prevancestorNode.descendants = prevancestorNodeDescendants
-			vg.entries.Set(*producedEntry.hash, prevancestorNode)
 		}
-		vg.entries.Set(ancestorHash, producedEntry.entry)
+		prevAncestorEntry.descendants = append(prevAncestorDescendants, ancestorHash)
+		vg.entries.Set(*prevAncestor, prevAncestorEntry)
 	}
+	vg.entries.Set(ancestorHash, newEntry)
+	return nil
 }
 
 // Insert a vote with given value into the graph at given hash and number.
@@ -244,48 +323,192 @@ func (vg *VoteGraph[Hash, Number, voteNode, Vote]) Insert(
 	num Number,
 	vote any,
 	chain Chain[Hash, Number],
+) error {
+	switch vote.(type) {
+	case voteNode, Vote:
+	default:
+		return fmt.Errorf("%w: %T", ErrUnsupportedVote, vote)
+	}
+
+	err := vg.introduceNode(hash, num, chain)
+	if err != nil {
+		return err
+	}
+	err = vg.addToAncestry(hash, vote, 1)
+	if err != nil {
+		return err
+	}
+	return vg.updateGHOSTCaches(hash)
+}
+
+// VoteEntry is a vote with given value, a vote or a vote-node, at given hash and number,
+// inserted into the graph by InsertBatch.
+type VoteEntry[Hash comparable, Number constraints.Unsigned] struct {
+	Hash   Hash
+	Number Number
+	Vote   any
+}
+
+// InsertBatch inserts the given votes into the graph, as Insert does for each of them.
+// The votes are grouped by block, so the ancestry of each block is looked up once and
+// the cumulative vote data of the vote-nodes is updated once per block with the sum
+// of its votes. No vote is inserted if one has an unsupported type, and otherwise the
+// votes of the blocks before the one failing are inserted if an error is returned.
+func (vg *VoteGraph[Hash, Number, voteNode, Vote]) InsertBatch(
+	votes []VoteEntry[Hash, Number],
+	chain Chain[Hash, Number],
+) error {
+	blocks := make([]HashNumber[Hash, Number], 0)
+	sums := make(map[HashNumber[Hash, Number]]voteNode)
+	counts := make(map[HashNumber[Hash, Number]]uint32)
+	for _, entry := range votes {
+		block := HashNumber[Hash, Number]{Hash: entry.Hash, Number: entry.Number}
+		sum, ok := sums[block]
+		if !ok {
+			sum = vg.newDefaultvoteNode()
+			sums[block] = sum
+			blocks = append(blocks, block)
+		}
+
+		switch vote := entry.Vote.(type) {
+		case voteNode:
+			sum.Add(vote)
+		case Vote:
+			sum.AddVote(vote)
+		default:
+			return fmt.Errorf("%w: %T", ErrUnsupportedVote, entry.Vote)
+		}
+		counts[block]++
+	}
+
+	for _, block := range blocks {
+		err := vg.introduceNode(block.Hash, block.Number, chain)
+		if err != nil {
+			return err
+		}
+		err = vg.addToAncestry(block.Hash, sums[block], counts[block])
+		if err != nil {
+			return err
+		}
+		err = vg.updateGHOSTCaches(block.Hash)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// introduceNode makes the given block a vote-node, if it is not already one, by
+// introducing it as a branch of the vote-nodes with it in their ancestor-edge or
+// by appending it to the graph.
+func (vg *VoteGraph[Hash, Number, voteNode, Vote]) introduceNode(
+	hash Hash,
+	num Number,
+	chain Chain[Hash, Number],
 ) error {
 	containing := vg.findContainingNodes(hash, num)
 	switch {
 	case containing == nil:
 		// this entry already exists
+		return nil
 	case len(containing) == 0:
-		err := vg.append(hash, num, chain)
-		if err != nil {
-			return err
-		}
+		return vg.append(hash, num, chain)
 	default:
-		vg.introduceBranch(containing, hash, num)
+		return vg.introduceBranch(containing, hash, num)
 	}
+}
 
-	// update cumulative vote data.
-	// NOTE: below this point, there always exists a node with the given hash and number.
-	var inspectingHash = hash
+// addToAncestry adds the vote, a vote or a vote-node summing count votes, to the cumulative
+// vote data of the given vote-node and its ancestor vote-nodes, which are all looked up
+// first so none is updated if one is missing.
+func (vg *VoteGraph[Hash, Number, voteNode, Vote]) addToAncestry(hash Hash, vote any, count uint32) error {
+	hashes := []Hash{hash}
+	entries := make([]voteGraphEntry[Hash, Number, voteNode, Vote], 0, 1)
 	for {
-		activeEntry, ok := vg.entries.Get(inspectingHash)
-		if !ok {
-			panic("vote-node and its ancestry always exist after initial phase; qed")
+		activeEntry, err := vg.getEntry(hashes[len(hashes)-1])
+		if err != nil {
+			return err
+		}
+		entries = append(entries, activeEntry)
+
+		parent := activeEntry.ancestorNode()
+		if parent == nil {
+			break
 		}
+		hashes = append(hashes, *parent)
+	}
+
+	for i, activeEntry := range entries {
 		switch vote := vote.(type) {
 		case voteNode:
 			activeEntry.cumulativeVote.Add(vote)
 		case Vote:
 			activeEntry.cumulativeVote.AddVote(vote)
-		default:
-			panic(fmt.Errorf("unsupported type to add to cumulativeVote %T", vote))
 		}
-		vg.entries.Set(inspectingHash, activeEntry)
-
-		parent := activeEntry.ancestorNode()
-		if parent != nil {
-			inspectingHash = *parent
-		} else {
-			break
+		if i == 0 {
+			activeEntry.votes += count
 		}
+		vg.entries.Set(hashes[i], activeEntry)
 	}
 	return nil
 }
 
+// Remove a vote with given value, inserted before at given hash and number, from the
+// graph, such as the vote of an equivocating voter. The vote is subtracted from the
+// cumulative vote data of the vote-node and its ancestor vote-nodes, which are left
+// in the graph, unless the vote-node is left without votes and with a single descendant,
+// in which case it is merged into its descendant.
+func (vg *VoteGraph[Hash, Number, voteNode, Vote]) Remove(hash Hash, num Number, vote any) error {
+	switch vote.(type) {
+	case voteNode, Vote:
+	default:
+		return fmt.Errorf("%w: %T", ErrUnsupportedVote, vote)
+	}
+
+	entry, ok := vg.entries.Get(hash)
+	if !ok {
+		return fmt.Errorf("%w: block %v is not a vote-node", ErrVoteNotInGraph, hash)
+	}
+	if entry.number != num {
+		return fmt.Errorf("%w: vote-node %v is at number %d, not %d",
+			ErrVoteNotInGraph, hash, entry.number, num)
+	}
+	if entry.votes == 0 {
+		return fmt.Errorf("%w: vote-node %v has no votes", ErrVoteNotInGraph, hash)
+	}
+
+	// the vote-node and its ancestor vote-nodes are all looked up first,
+	// as in Insert, so none is updated if one is missing.
+	hashes := []Hash{hash}
+	entries := []voteGraphEntry[Hash, Number, voteNode, Vote]{entry}
+	for parent := entry.ancestorNode(); parent != nil; parent = entries[len(entries)-1].ancestorNode() {
+		activeEntry, err := vg.getEntry(*parent)
+		if err != nil {
+			return err
+		}
+		hashes = append(hashes, *parent)
+		entries = append(entries, activeEntry)
+	}
+
+	for i, activeEntry := range entries {
+		switch vote := vote.(type) {
+		case voteNode:
+			activeEntry.cumulativeVote.Sub(vote)
+		case Vote:
+			activeEntry.cumulativeVote.RemoveVote(vote)
+		}
+		if i == 0 {
+			activeEntry.votes--
+		}
+		vg.entries.Set(hashes[i], activeEntry)
+	}
+	vg.resetGHOSTCaches()
+
+	// the vote-node may now be a link of a linear chain
+	_, err := vg.compactNode(hash)
+	return err
+}
+
 // attempts to find the containing node keys for the given hash and number.
 //
 // returns `nil` if there is a node by that key already, and a slice
@@ -301,6 +524,11 @@ func (vg *VoteGraph[Hash, Number, voteNode, Vote]) findContainingNodes(hash Hash
 	visited := make(map[Hash]interface{})
 
 	for _, head := range vg.heads.Keys() {
+		// skip heads which do not have the block in their ancestry
+		if !vg.headIndexes[head].mayContain(hash, num) {
+			continue
+		}
+
 		var activeEntry voteGraphEntry[Hash, Number, voteNode, Vote]
 
 		for {
@@ -353,19 +581,24 @@ func (sc subChain[H, N]) best() *HashNumber[H, N] {
 	}
 }
 
-func (vg *VoteGraph[Hash, Number, voteNode, Vote]) mustGetEntry(
+// getEntry returns the vote-node with the given hash, which is expected to be
+// in the graph since it is referenced by the graph.
+func (vg *VoteGraph[Hash, Number, voteNode, Vote]) getEntry(
 	hash Hash,
-) voteGraphEntry[Hash, Number, voteNode, Vote] {
+) (voteGraphEntry[Hash, Number, voteNode, Vote], error) {
 	entry, ok := vg.entries.Get(hash)
 	if !ok {
-		panic("descendents always present in node storage; qed")
+		return entry, fmt.Errorf("%w: %v", ErrMissingEntry, hash)
 	}
-	return entry
+	return entry, nil
 }
 
-type hashvote[Hash constraints.Ordered, voteNode voteNodeI[voteNode, Vote], Vote any] struct {
-	hash Hash
-	vote voteNode
+// mergeBlock is a block in the ancestor-edges of the descendants searched by
+// ghostFindMergePoint, with the vote accumulated from the descendants walked through it.
+// The vote of the first descendant is only copied once a second one is added to it.
+type mergeBlock[voteNode voteNodeI[voteNode, Vote], Vote any] struct {
+	vote   voteNode
+	copied bool
 }
 
 // given a key, node pair (which must correspond), assuming this node fulfils the condition,
@@ -373,108 +606,88 @@ type hashvote[Hash constraints.Ordered, voteNode voteNodeI[voteNode, Vote], Vote
 // node itself.
 func (vg *VoteGraph[Hash, Number, voteNode, Vote]) ghostFindMergePoint( //skipcq: GO-R1005
 	nodeKey Hash, activeNode *voteGraphEntry[Hash, Number, voteNode, Vote], forceConstrain *HashNumber[Hash, Number],
-	condition func(voteNode) bool) subChain[Hash, Number] {
+	condition func(voteNode) bool) (subChain[Hash, Number], error) {
 
 	var descendantNodes []voteGraphEntry[Hash, Number, voteNode, Vote]
 	for _, descendant := range activeNode.descendants {
+		descendantNode, err := vg.getEntry(descendant)
+		if err != nil {
+			return subChain[Hash, Number]{}, err
+		}
+
 		switch {
 		case forceConstrain == nil:
-			descendantNodes = append(descendantNodes, vg.mustGetEntry(descendant))
+			descendantNodes = append(descendantNodes, descendantNode)
 		default:
-			ida := vg.mustGetEntry(descendant).inDirectAncestry(forceConstrain.Hash, forceConstrain.Number)
+			ida := descendantNode.inDirectAncestry(forceConstrain.Hash, forceConstrain.Number)
 			switch {
 			case ida == nil:
 			case !*ida:
 			case *ida:
-				descendantNodes = append(descendantNodes, vg.mustGetEntry(descendant))
+				descendantNodes = append(descendantNodes, descendantNode)
 			}
 
 		}
 	}
 
-	baseNumber := activeNode.number
 	bestNumber := activeNode.number
-
-	descendantBlocks := make([]hashvote[Hash, voteNode, Vote], 0)
 	hashes := []Hash{nodeKey}
 
-	// TODO: for long ranges of blocks this could get inefficient
-	var offset Number
-	for {
-		offset = offset + 1
+	// the ancestor-edges of the descendants are walked up together one number at a
+	// time, so each block of an edge is visited once, while its descendant is still
+	// in the ancestry of the best block. The blocks of the descendants are merged by
+	// hash and number, so the map needs no clearing between numbers.
+	blocks := make(map[HashNumber[Hash, Number]]*mergeBlock[voteNode, Vote])
+	for len(descendantNodes) > 0 {
+		number := bestNumber + 1
 
 		var newBest *Hash
 		for _, dNode := range descendantNodes {
-			dBlock := dNode.ancestorBlock(baseNumber + offset)
+			dBlock := dNode.ancestorBlock(number)
 			if dBlock == nil {
 				continue
 			}
-			idx, ok := slices.BinarySearchFunc(
-				descendantBlocks,
-				hashvote[Hash, voteNode, Vote]{hash: *dBlock},
-				func(a, b hashvote[Hash, voteNode, Vote]) int {
-					switch {
-					case a.hash == b.hash:
-						return 0
-					case a.hash > b.hash:
-						return 1
-					case a.hash < b.hash:
-						return -1
-					default:
-						panic("unreachable")
-					}
-				},
-			)
-			if ok {
-				descendantBlocks[idx].vote.Add(dNode.cumulativeVote)
-				if condition(descendantBlocks[idx].vote) {
-					newBest = dBlock
-					break
-				}
-			} else {
-				if idx == len(descendantBlocks) {
-					descendantBlocks = append(descendantBlocks, hashvote[Hash, voteNode, Vote]{
-						hash: *dBlock,
-						vote: dNode.cumulativeVote.Copy(),
-					})
-				} else if idx < len(descendantBlocks) {
-					descendantBlocks = append(
-						descendantBlocks[:idx],
-						append([]hashvote[Hash, voteNode, Vote]{{
-							hash: *dBlock,
-							vote: dNode.cumulativeVote.Copy(),
-						}}, descendantBlocks[idx:]...)...)
-				} else {
-					panic("unreachable")
-				}
+			key := HashNumber[Hash, Number]{Hash: *dBlock, Number: number}
+			block, ok := blocks[key]
+			if !ok {
+				blocks[key] = &mergeBlock[voteNode, Vote]{vote: dNode.cumulativeVote}
+				continue
+			}
+			if !block.copied {
+				block.vote = block.vote.Copy()
+				block.copied = true
+			}
+			block.vote.Add(dNode.cumulativeVote)
+			if condition(block.vote) {
+				newBest = dBlock
+				break
 			}
 		}
+		if newBest == nil {
+			break
+		}
 
-		if newBest != nil {
-			bestNumber = bestNumber + 1
-			descendantBlocks = make([]hashvote[Hash, voteNode, Vote], 0)
-			retained := make([]voteGraphEntry[Hash, Number, voteNode, Vote], 0)
-			for _, descendant := range descendantNodes {
-				ida := descendant.inDirectAncestry(*newBest, bestNumber)
-				if ida != nil && *ida {
-					retained = append(retained, descendant)
-				}
+		bestNumber = number
+		// the descendants are filtered in place, as the slice is not shared
+		retained := descendantNodes[:0]
+		for _, descendant := range descendantNodes {
+			ancestor := descendant.ancestorBlock(bestNumber)
+			if ancestor != nil && *ancestor == *newBest {
+				retained = append(retained, descendant)
 			}
-			descendantNodes = retained
-			hashes = append(hashes, *newBest)
-		} else {
-			break
 		}
+		descendantNodes = retained
+		hashes = append(hashes, *newBest)
 	}
 
 	return subChain[Hash, Number]{
 		hashes:     hashes,
 		bestNumber: bestNumber,
-	}
+	}, nil
 }
 
 type hashVoteGraphEntry[
-	Hash constraints.Ordered,
+	Hash comparable,
 	Number constraints.Integer,
 	voteNode voteNodeI[voteNode, Vote],
 	Vote any,
@@ -497,15 +710,7 @@ func(a, b hashvote[Hash, voteNode, Vote]) int {
 func (vg *VoteGraph[Hash, Number, voteNode, Vote]) FindGHOST( //skipcq: GO-R1005
 	currentBest *HashNumber[Hash, Number],
 	condition func(voteNode) bool,
-) *HashNumber[Hash, Number] {
-	var getNode = func(hash Hash) *voteGraphEntry[Hash, Number, voteNode, Vote] {
-		entry, ok := vg.entries.Get(hash)
-		if !ok {
-			panic("node either base or referenced by other in graph; qed")
-		}
-		return &entry
-	}
-
+) (*HashNumber[Hash, Number], error) {
 	var nodeKey Hash
 	var forceConstrain bool
 
@@ -519,9 +724,14 @@ func (vg *VoteGraph[Hash, Number, voteNode, Vote]) FindGHOST( //skipcq: GO-R1005
 			nodeKey = currentBest.Hash
 			forceConstrain = false
 		case len(containing) > 0:
-			ancestor := getNode(containing[0]).ancestorNode()
+			containingNode, err := vg.getEntry(containing[0])
+			if err != nil {
+				return nil, err
+			}
+			ancestor := containingNode.ancestorNode()
 			if ancestor == nil {
-				panic("node containing non-node in history always has ancestor; qed")
+				return nil, fmt.Errorf("%w: vote-node %v containing %v has no ancestor vote-node",
+					ErrInvalidAncestry, containing[0], currentBest.Hash)
 			}
 			nodeKey = *ancestor
 			forceConstrain = true
@@ -531,10 +741,14 @@ func (vg *VoteGraph[Hash, Number, voteNode, Vote]) FindGHOST( //skipcq: GO-R1005
 		}
 	}
 
-	activeNode := getNode(nodeKey)
+	node, err := vg.getEntry(nodeKey)
+	if err != nil {
+		return nil, err
+	}
+	activeNode := &node
 
 	if !condition(activeNode.cumulativeVote) {
-		return nil
+		return nil, nil
 	}
 
 	// breadth-first search starting from this node.
@@ -544,8 +758,12 @@ func (vg *VoteGraph[Hash, Number, voteNode, Vote]) FindGHOST( //skipcq: GO-R1005
 		filteredDescendants := make([]*hashVoteGraphEntry[Hash, Number, voteNode, Vote], 0)
 
 		for _, descendant := range activeNode.descendants {
+			node, err := vg.getEntry(descendant)
+			if err != nil {
+				return nil, err
+			}
+
 			if forceConstrain && currentBest != nil {
-				node := getNode(descendant)
 				ida := node.inDirectAncestry(currentBest.Hash, currentBest.Number)
 				switch {
 				case ida == nil:
@@ -553,14 +771,13 @@ func (vg *VoteGraph[Hash, Number, voteNode, Vote]) FindGHOST( //skipcq: GO-R1005
 				case *ida:
 					filteredDescendants = append(filteredDescendants, &hashVoteGraphEntry[Hash, Number, voteNode, Vote]{
 						hash:  descendant,
-						entry: *node,
+						entry: node,
 					})
 				}
 			} else {
-				node := getNode(descendant)
 				filteredDescendants = append(filteredDescendants, &hashVoteGraphEntry[Hash, Number, voteNode, Vote]{
 					hash:  descendant,
-					entry: *node,
+					entry: node,
 				})
 			}
 		}
@@ -591,7 +808,11 @@ func (vg *VoteGraph[Hash, Number, voteNode, Vote]) FindGHOST( //skipcq: GO-R1005
 		hn = currentBest
 	}
 
-	return vg.ghostFindMergePoint(nodeKey, activeNode, hn, condition).best()
+	mergePoint, err := vg.ghostFindMergePoint(nodeKey, activeNode, hn, condition)
+	if err != nil {
+		return nil, err
+	}
+	return mergePoint.best(), nil
 }
 
 // FindAncestor will find the block with the highest block number in the chain with the given head
@@ -603,19 +824,22 @@ func (vg *VoteGraph[Hash, Number, voteNode, Vote]) FindAncestor(
 	hash Hash,
 	number Number,
 	condition func(voteNode) bool,
-) *HashNumber[Hash, Number] {
+) (*HashNumber[Hash, Number], error) {
 	for {
 		children := vg.findContainingNodes(hash, number)
 		if children == nil {
 			// The block has a vote-node in the graph.
-			node := vg.mustGetEntry(hash)
+			node, err := vg.getEntry(hash)
+			if err != nil {
+				return nil, err
+			}
 			// If the weight is sufficient, we are done.
 			if condition(node.cumulativeVote) {
-				return &HashNumber[Hash, Number]{hash, number}
+				return &HashNumber[Hash, Number]{hash, number}, nil
 			}
 			// Not enough weight, check the parent block.
 			if len(node.ancestors) == 0 {
-				return nil
+				return nil, nil
 			}
 			hash = node.ancestors[0]
 			number = node.number - 1
@@ -623,28 +847,25 @@ func (vg *VoteGraph[Hash, Number, voteNode, Vote]) FindAncestor(
 			// If there are no vote-nodes below the block in the graph,
 			// the block is not in the graph at all.
 			if len(children) == 0 {
-				return nil
+				return nil, nil
 			}
 			// The block is "contained" in the graph (i.e. in the ancestry-chain
 			// of at least one vote-node) but does not itself have a vote-node.
 			// Check if the accumulated weight on all child vote-nodes is sufficient.
-			v := vg.newDefaultvoteNode()
-			for _, c := range children {
-				e := vg.mustGetEntry(c)
-				v.Add(e.cumulativeVote)
+			v, entry, err := vg.sumCumulativeVotes(children)
+			if err != nil {
+				return nil, err
 			}
 			if condition(v) {
-				return &HashNumber[Hash, Number]{hash, number}
+				return &HashNumber[Hash, Number]{hash, number}, nil
 			}
 
-			// Not enough weight, check the parent block.
-			child := children[len(children)-1]
-			entry := vg.mustGetEntry(child)
+			// Not enough weight, check the parent block, from the last child.
 			offset := int(entry.number - number)
 
 			if offset >= len(entry.ancestors) {
 				// Reached base without sufficient weight.
-				return nil
+				return nil, nil
 			}
 			parent := entry.ancestors[offset]
 
@@ -654,38 +875,337 @@ func (vg *VoteGraph[Hash, Number, voteNode, Vote]) FindAncestor(
 	}
 }
 
+// sumCumulativeVotes returns the sum of the cumulative votes of the given vote-nodes,
+// and the last of them.
+func (vg *VoteGraph[Hash, Number, voteNode, Vote]) sumCumulativeVotes(hashes []Hash) (
+	sum voteNode, last voteGraphEntry[Hash, Number, voteNode, Vote], err error) {
+	sum = vg.newDefaultvoteNode()
+	for _, hash := range hashes {
+		last, err = vg.getEntry(hash)
+		if err != nil {
+			return sum, last, err
+		}
+		sum.Add(last.cumulativeVote)
+	}
+	return sum, last, nil
+}
+
+// CumulativeVoteAt returns the vote accumulated on the given block, which is the
+// cumulative vote of its vote-node, or the sum of the cumulative votes of the vote-nodes
+// with the block in their ancestor-edge if it is not a vote-node. It returns false if
+// the block is not in the graph. The vote returned is a copy.
+func (vg *VoteGraph[Hash, Number, voteNode, Vote]) CumulativeVoteAt(hash Hash, number Number) (
+	vote voteNode, ok bool, err error) {
+	children := vg.findContainingNodes(hash, number)
+	if children == nil {
+		node, err := vg.getEntry(hash)
+		if err != nil {
+			return vote, false, err
+		}
+		if node.number != number {
+			return vote, false, nil
+		}
+		return node.cumulativeVote.Copy(), true, nil
+	}
+
+	if len(children) == 0 {
+		return vote, false, nil
+	}
+	vote, _, err = vg.sumCumulativeVotes(children)
+	if err != nil {
+		return vote, false, err
+	}
+	return vote, true, nil
+}
+
 // AdjustBase will adjust the base of the graph. The new base must be an ancestor of the
 // old base.
 //
 // Provide an ancestry proof from the old base to the new. The proof
-// should be in reverse order from the old base's parent.
-func (vg *VoteGraph[Hash, Number, voteNode, Vote]) AdjustBase(ancestryProof []Hash) {
+// should be in reverse order from the old base's parent. The proof must not be empty nor
+// longer than the base number, nor contain the old base or a block twice. If the chain
+// is not nil, the proof is also checked to be the ancestry of the old base in the chain.
+// The graph is left unchanged and an error is returned if the proof is not valid.
+func (vg *VoteGraph[Hash, Number, voteNode, Vote]) AdjustBase(
+	ancestryProof []Hash, chain Chain[Hash, Number]) error {
 	if len(ancestryProof) == 0 {
-		return // empty nothing to do
+		return ErrEmptyAncestryProof
 	}
 	newHash := ancestryProof[len(ancestryProof)-1]
 
-	// not a valid ancestry proof. TODO: error? (TODO copied from rust code)
-	if len(ancestryProof) > int(vg.baseNumber) {
-		return
+	if uint64(len(ancestryProof)) > uint64(vg.baseNumber) {
+		return fmt.Errorf("%w: proof length %d, base number %d",
+			ErrAncestryProofTooLong, len(ancestryProof), vg.baseNumber)
+	}
+
+	seen := make(map[Hash]struct{}, len(ancestryProof)+1)
+	seen[vg.base] = struct{}{}
+	for _, hash := range ancestryProof {
+		if _, ok := seen[hash]; ok {
+			return fmt.Errorf("%w: block %v appears twice", ErrAncestryProofMismatch, hash)
+		}
+		seen[hash] = struct{}{}
+	}
+
+	if chain != nil {
+		// the chain ancestry excludes the new base itself
+		ancestry, err := chain.Ancestry(newHash, vg.base)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrAncestryProofMismatch, err)
+		}
+		if !slices.Equal(ancestry, ancestryProof[:len(ancestryProof)-1]) {
+			return fmt.Errorf("%w: expected ancestry %v, got %v",
+				ErrAncestryProofMismatch, ancestry, ancestryProof[:len(ancestryProof)-1])
+		}
 	}
 
 	newNumber := vg.baseNumber
 	newNumber = newNumber - Number(len(ancestryProof))
 
-	oldEntry := vg.mustGetEntry(vg.base)
+	oldBase := vg.base
+	oldEntry, err := vg.getEntry(oldBase)
+	if err != nil {
+		return err
+	}
 	oldEntry.ancestors = append(oldEntry.ancestors, ancestryProof...)
-	vg.entries.Set(vg.base, oldEntry)
+	vg.entries.Set(oldBase, oldEntry)
 
 	entry := voteGraphEntry[Hash, Number, voteNode, Vote]{
 		number:         newNumber,
 		ancestors:      make([]Hash, 0),
-		descendants:    []Hash{vg.base},
+		descendants:    []Hash{oldBase},
 		cumulativeVote: oldEntry.cumulativeVote.Copy(),
 	}
 	vg.entries.Set(newHash, entry)
 	vg.base = newHash
 	vg.baseNumber = newNumber
+	vg.resetGHOSTCaches()
+
+	// every head has the blocks of the ancestry proof in its ancestry now
+	for _, index := range vg.headIndexes {
+		for _, ancestor := range ancestryProof {
+			index.ancestors[ancestor] = struct{}{}
+		}
+	}
+
+	// the old base may now be a link of a linear chain
+	_, err = vg.compactNode(oldBase)
+	return err
+}
+
+// compactNode merges the vote-node into its descendant if it has a single descendant,
+// no votes of its own and is not the base, so linear chains of vote-nodes without
+// votes are kept as a single entry with a longer ancestor-edge. Such vote-nodes only
+// mirror the cumulative vote of their descendant, and are introduced again as branches
+// if votes are inserted on them. It returns true if the vote-node was merged.
+func (vg *VoteGraph[Hash, Number, voteNode, Vote]) compactNode(hash Hash) (merged bool, err error) {
+	if hash == vg.base {
+		return false, nil
+	}
+
+	entry, ok := vg.entries.Get(hash)
+	if !ok || entry.votes > 0 || len(entry.descendants) != 1 {
+		return false, nil
+	}
+
+	parent := entry.ancestorNode()
+	if parent == nil {
+		return false, fmt.Errorf("%w: vote-node %v other than base has no ancestor vote-node",
+			ErrInvalidAncestry, hash)
+	}
+	parentEntry, err := vg.getEntry(*parent)
+	if err != nil {
+		return false, err
+	}
+
+	descendantHash := entry.descendants[0]
+	descendant, err := vg.getEntry(descendantHash)
+	if err != nil {
+		return false, err
+	}
+	// the ancestor-edge of the descendant ends with the hash of the vote-node, so the
+	// ancestor-edge of the vote-node follows it. A new slice is allocated since the
+	// ancestor-edges of split vote-nodes share their underlying array.
+	ancestors := make([]Hash, 0, len(descendant.ancestors)+len(entry.ancestors))
+	ancestors = append(ancestors, descendant.ancestors...)
+	descendant.ancestors = append(ancestors, entry.ancestors...)
+	vg.entries.Set(descendantHash, descendant)
+
+	for i, parentDescendant := range parentEntry.descendants {
+		if parentDescendant == hash {
+			parentEntry.descendants[i] = descendantHash
+		}
+	}
+	vg.entries.Set(*parent, parentEntry)
+
+	vg.entries.Delete(hash)
+	return true, nil
+}
+
+// PrunedVoteNode is a vote-node removed from the graph by Prune.
+type PrunedVoteNode[Hash comparable, Number constraints.Unsigned, voteNode any] struct {
+	Hash   Hash
+	Number Number
+	// CumulativeVote is the vote accumulated on the vote-node and its descendants
+	// at the time it was removed.
+	CumulativeVote voteNode
+	// Voted is true if votes were inserted on the vote-node itself.
+	Voted bool
+}
+
+// Prune removes the vote-nodes which are not descendants of the finalized block, and
+// re-roots the graph at the finalized block, which becomes the base. The finalized block
+// must be a vote-node at the number given or in the ancestor-edge of a vote-node of the
+// graph, and the graph is left unchanged otherwise. The removed vote-nodes are returned
+// in ascending number order, so their votes can be recorded.
+func (vg *VoteGraph[Hash, Number, voteNode, Vote]) Prune(finalizedHash Hash, finalizedNumber Number) (
+	pruned []PrunedVoteNode[Hash, Number, voteNode], err error) {
+	// the finalized block may be a vote-node already, at the number given as in Rebase
+	if entry, ok := vg.entries.Get(finalizedHash); ok && entry.number != finalizedNumber {
+		return nil, fmt.Errorf("%w: vote-node %v is at number %d, not %d",
+			ErrPruneBlockNotInGraph, finalizedHash, entry.number, finalizedNumber)
+	}
+	if finalizedHash == vg.base {
+		return nil, nil
+	}
+
+	containing := vg.findContainingNodes(finalizedHash, finalizedNumber)
+	switch {
+	case containing == nil:
+		// the finalized block is a vote-node already
+	case len(containing) == 0:
+		return nil, fmt.Errorf("%w: %v at number %d", ErrPruneBlockNotInGraph, finalizedHash, finalizedNumber)
+	default:
+		err = vg.introduceBranch(containing, finalizedHash, finalizedNumber)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// the vote-nodes kept are the finalized block and its descendants
+	kept := map[Hash]struct{}{finalizedHash: {}}
+	queue := []Hash{finalizedHash}
+	for len(queue) > 0 {
+		entry, err := vg.getEntry(queue[0])
+		if err != nil {
+			return nil, err
+		}
+		queue = queue[1:]
+		for _, descendant := range entry.descendants {
+			kept[descendant] = struct{}{}
+			queue = append(queue, descendant)
+		}
+	}
+
+	vg.entries.Scan(func(hash Hash, entry voteGraphEntry[Hash, Number, voteNode, Vote]) bool {
+		if _, ok := kept[hash]; !ok {
+			pruned = append(pruned, PrunedVoteNode[Hash, Number, voteNode]{
+				Hash:           hash,
+				Number:         entry.number,
+				CumulativeVote: entry.cumulativeVote,
+				Voted:          entry.votes > 0,
+			})
+		}
+		return true
+	})
+	// the entries are scanned in hash order
+	slices.SortStableFunc(pruned, func(a, b PrunedVoteNode[Hash, Number, voteNode]) int {
+		switch {
+		case a.Number < b.Number:
+			return -1
+		case a.Number > b.Number:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	for _, node := range pruned {
+		vg.entries.Delete(node.Hash)
+		vg.heads.Delete(node.Hash)
+		delete(vg.headIndexes, node.Hash)
+	}
+
+	finalizedEntry, err := vg.getEntry(finalizedHash)
+	if err != nil {
+		return nil, err
+	}
+	finalizedEntry.ancestors = make([]Hash, 0)
+	vg.entries.Set(finalizedHash, finalizedEntry)
+	vg.base = finalizedHash
+	vg.baseNumber = finalizedNumber
+	vg.resetGHOSTCaches()
+
+	if len(finalizedEntry.descendants) == 0 {
+		vg.heads.Insert(finalizedHash)
+	}
+	// the ancestry of the heads now ends at the finalized block
+	for _, head := range vg.heads.Keys() {
+		err = vg.indexHead(head)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return pruned, nil
+}
+
+// Rebase moves the base of the graph forward to a descendant of the base which is already
+// a vote-node of the graph, such as a block finalized during a round, and discards the
+// vote-nodes which are not in the subtree of the new base. Unlike Prune, the new base is
+// never introduced as a branch, so the graph is left unchanged and an error wrapping
+// ErrRebaseNotVoteNode is returned if it is not a vote-node at the number given.
+func (vg *VoteGraph[Hash, Number, voteNode, Vote]) Rebase(newBaseHash Hash, newBaseNumber Number) error {
+	entry, ok := vg.entries.Get(newBaseHash)
+	if !ok {
+		return fmt.Errorf("%w: %v", ErrRebaseNotVoteNode, newBaseHash)
+	}
+	if entry.number != newBaseNumber {
+		return fmt.Errorf("%w: %v is at number %d, not %d",
+			ErrRebaseNotVoteNode, newBaseHash, entry.number, newBaseNumber)
+	}
+
+	_, err := vg.Prune(newBaseHash, newBaseNumber)
+	return err
+}
+
+// Clone returns a deep copy of the graph, which can be mutated without changing the
+// graph, such as to evaluate a round with votes which have not arrived yet. The vote-nodes
+// are copied with their Copy method. The GHOST caches of the graph are not copied.
+func (vg *VoteGraph[Hash, Number, voteNode, Vote]) Clone() VoteGraph[Hash, Number, voteNode, Vote] {
+	entries := newHashMap[Hash, voteGraphEntry[Hash, Number, voteNode, Vote]]()
+	vg.entries.Scan(func(hash Hash, entry voteGraphEntry[Hash, Number, voteNode, Vote]) bool {
+		entries.Set(hash, voteGraphEntry[Hash, Number, voteNode, Vote]{
+			number:         entry.number,
+			ancestors:      slices.Clone(entry.ancestors),
+			descendants:    slices.Clone(entry.descendants),
+			cumulativeVote: entry.cumulativeVote.Copy(),
+			votes:          entry.votes,
+		})
+		return true
+	})
+
+	heads := newHashSet[Hash]()
+	for _, head := range vg.heads.Keys() {
+		heads.Insert(head)
+	}
+
+	headIndexes := make(map[Hash]headIndex[Hash, Number], len(vg.headIndexes))
+	for head, index := range vg.headIndexes {
+		headIndexes[head] = headIndex[Hash, Number]{
+			number:    index.number,
+			ancestors: maps.Clone(index.ancestors),
+		}
+	}
+
+	return VoteGraph[Hash, Number, voteNode, Vote]{
+		entries:            entries,
+		heads:              heads,
+		headIndexes:        headIndexes,
+		base:               vg.base,
+		baseNumber:         vg.baseNumber,
+		newDefaultvoteNode: vg.newDefaultvoteNode,
+	}
 }
 
 // Base returns the base block.
@@ -695,3 +1215,82 @@ func (vg *VoteGraph[Hash, Number, voteNode, Vote]) Base() HashNumber[Hash, Numbe
 		vg.baseNumber,
 	}
 }
+
+// Keys returns the hashes of the vote-nodes of the graph, including the base. They are
+// in the deterministic order the graph is walked in, which only depends on the hashes:
+// string and byte array hashes are ordered as byte strings, integer hashes by value and
+// other hashes by their default format, so the graphs with the same vote-nodes list
+// them in the same order on every node.
+func (vg *VoteGraph[Hash, Number, voteNode, Vote]) Keys() []Hash {
+	return vg.entries.Keys()
+}
+
+// Heads returns the hashes of the vote-nodes of the graph without descendants, in the
+// same deterministic order as Keys.
+func (vg *VoteGraph[Hash, Number, voteNode, Vote]) Heads() []Hash {
+	return vg.heads.Keys()
+}
+
+// VoteGraphStats are the size and depth statistics of a vote graph, to monitor its growth.
+type VoteGraphStats[Number constraints.Unsigned] struct {
+	// Entries is the number of vote-nodes, including the base.
+	Entries int
+	// Heads is the number of vote-nodes without descendants, one per fork voted on.
+	Heads int
+	// MaxDepth is the number of blocks between the base and the highest vote-node.
+	MaxDepth Number
+	// AncestorHashes is the number of hashes in the ancestor-edges of the vote-nodes.
+	AncestorHashes int
+	// AncestorBytes is the memory allocated for the ancestor-edges, excluding the memory
+	// referenced by the hashes themselves, such as the bytes of string hashes. It is an
+	// upper bound since the ancestor-edges of split vote-nodes may share their memory.
+	AncestorBytes uint64
+}
+
+// Stats returns the size and depth statistics of the graph.
+func (vg *VoteGraph[Hash, Number, voteNode, Vote]) Stats() VoteGraphStats[Number] {
+	var hash Hash
+	hashSize := uint64(unsafe.Sizeof(hash))
+
+	stats := VoteGraphStats[Number]{
+		Entries: vg.entries.Len(),
+		Heads:   vg.heads.Len(),
+	}
+	vg.entries.Scan(func(_ Hash, entry voteGraphEntry[Hash, Number, voteNode, Vote]) bool {
+		if entry.number > vg.baseNumber {
+			stats.MaxDepth = max(stats.MaxDepth, entry.number-vg.baseNumber)
+		}
+		stats.AncestorHashes += len(entry.ancestors)
+		stats.AncestorBytes += uint64(cap(entry.ancestors)) * hashSize
+		return true
+	})
+	return stats
+}
+
+// VoteGraphEntry is a vote-node of the graph, as passed to the callback of Range.
+type VoteGraphEntry[Hash comparable, Number constraints.Unsigned, voteNode any] struct {
+	Hash   Hash
+	Number Number
+	// CumulativeVote is the vote accumulated on the vote-node and its descendants.
+	CumulativeVote voteNode
+	// Descendants are the hashes of the descendant vote-nodes.
+	Descendants []Hash
+	// Voted is true if votes were inserted on the vote-node itself.
+	Voted bool
+}
+
+// Range calls f for each vote-node of the graph in hash order, until f returns false.
+// The entries passed to f are copies, so modifying them does not change the graph, and
+// f must not modify the graph.
+func (vg *VoteGraph[Hash, Number, voteNode, Vote]) Range(
+	f func(entry VoteGraphEntry[Hash, Number, voteNode]) bool) {
+	vg.entries.Scan(func(hash Hash, entry voteGraphEntry[Hash, Number, voteNode, Vote]) bool {
+		return f(VoteGraphEntry[Hash, Number, voteNode]{
+			Hash:           hash,
+			Number:         entry.number,
+			CumulativeVote: entry.cumulativeVote.Copy(),
+			Descendants:    slices.Clone(entry.descendants),
+			Voted:          entry.votes > 0,
+		})
+	})
+}
@@ -0,0 +1,206 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// ErrTrailingBytes is returned by VoteGraph.Decode when the encoded graph is
+// followed by bytes which are not part of it.
+var ErrTrailingBytes = errors.New("trailing bytes after encoded vote graph")
+
+// encode writes the SCALE encoding of the entry to the encoder. The vote-node must
+// implement scale.Marshaler, since a pointer would otherwise be encoded as an option.
+func (vge voteGraphEntry[Hash, Number, voteNode, Vote]) encode(encoder *scale.Encoder) error {
+	for _, value := range []any{vge.number, vge.ancestors, vge.descendants, vge.cumulativeVote, vge.votes} {
+		err := encoder.Encode(value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decode reads the SCALE encoding of the entry written by encode from the decoder,
+// decoding its vote-node into the given vote-node, which must implement scale.Unmarshaler.
+func (vge *voteGraphEntry[Hash, Number, voteNode, Vote]) decode(decoder *scale.Decoder, node voteNode) error {
+	vge.cumulativeVote = node
+	for _, dst := range []any{&vge.number, &vge.ancestors, &vge.descendants, vge.cumulativeVote, &vge.votes} {
+		err := decoder.Decode(dst)
+		if err != nil {
+			return err
+		}
+	}
+
+	// the edges of the vote-nodes are empty rather than nil, as when inserted
+	if vge.ancestors == nil {
+		vge.ancestors = make([]Hash, 0)
+	}
+	if vge.descendants == nil {
+		vge.descendants = make([]Hash, 0)
+	}
+	return nil
+}
+
+// encodeHeads writes the SCALE encoding of the heads, in ascending order, to the encoder.
+func encodeHeads[Hash comparable](encoder *scale.Encoder, heads *hashSet[Hash]) error {
+	return encoder.Encode(heads.Keys())
+}
+
+// decodeHeads reads the SCALE encoding of the heads written by encodeHeads from the decoder.
+func decodeHeads[Hash comparable](decoder *scale.Decoder) (*hashSet[Hash], error) {
+	var hashes []Hash
+	err := decoder.Decode(&hashes)
+	if err != nil {
+		return nil, err
+	}
+
+	heads := newHashSet[Hash]()
+	for _, hash := range hashes {
+		heads.Insert(hash)
+	}
+	return heads, nil
+}
+
+// Encode returns the SCALE encoding of the base, vote-nodes and heads of the graph,
+// from which Decode restores it. The vote-nodes must implement scale.Marshaler.
+func (vg *VoteGraph[Hash, Number, voteNode, Vote]) Encode() ([]byte, error) {
+	buffer := bytes.NewBuffer(nil)
+	encoder := scale.NewEncoder(buffer)
+
+	err := encoder.Encode(vg.base)
+	if err != nil {
+		return nil, fmt.Errorf("encoding base hash: %w", err)
+	}
+	err = encoder.Encode(vg.baseNumber)
+	if err != nil {
+		return nil, fmt.Errorf("encoding base number: %w", err)
+	}
+
+	err = encoder.Encode(uint(vg.entries.Len()))
+	if err != nil {
+		return nil, fmt.Errorf("encoding number of vote-nodes: %w", err)
+	}
+	vg.entries.Scan(func(hash Hash, entry voteGraphEntry[Hash, Number, voteNode, Vote]) bool {
+		err = encoder.Encode(hash)
+		if err != nil {
+			return false
+		}
+		err = entry.encode(encoder)
+		if err != nil {
+			err = fmt.Errorf("vote-node %v: %w", hash, err)
+		}
+		return err == nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encoding vote-nodes: %w", err)
+	}
+
+	err = encodeHeads(encoder, vg.heads)
+	if err != nil {
+		return nil, fmt.Errorf("encoding heads: %w", err)
+	}
+	return buffer.Bytes(), nil
+}
+
+// Decode replaces the graph with the graph encoded by Encode. The vote-nodes are
+// decoded into new default vote-nodes of the graph, which must implement
+// scale.Unmarshaler. The graph is left unchanged if an error is returned.
+func (vg *VoteGraph[Hash, Number, voteNode, Vote]) Decode(encoded []byte) error {
+	reader := bytes.NewReader(encoded)
+	decoder := scale.NewDecoder(reader)
+
+	var (
+		base       Hash
+		baseNumber Number
+		length     uint
+	)
+	err := decoder.Decode(&base)
+	if err != nil {
+		return fmt.Errorf("decoding base hash: %w", err)
+	}
+	err = decoder.Decode(&baseNumber)
+	if err != nil {
+		return fmt.Errorf("decoding base number: %w", err)
+	}
+	err = decoder.Decode(&length)
+	if err != nil {
+		return fmt.Errorf("decoding number of vote-nodes: %w", err)
+	}
+
+	entries := newHashMap[Hash, voteGraphEntry[Hash, Number, voteNode, Vote]]()
+	for i := uint(0); i < length; i++ {
+		var hash Hash
+		err = decoder.Decode(&hash)
+		if err != nil {
+			return fmt.Errorf("decoding hash of vote-node %d: %w", i, err)
+		}
+
+		var entry voteGraphEntry[Hash, Number, voteNode, Vote]
+		err = entry.decode(decoder, vg.newDefaultvoteNode())
+		if err != nil {
+			return fmt.Errorf("decoding vote-node %v: %w", hash, err)
+		}
+		entries.Set(hash, entry)
+	}
+
+	heads, err := decodeHeads[Hash](decoder)
+	if err != nil {
+		return fmt.Errorf("decoding heads: %w", err)
+	}
+
+	_, err = reader.ReadByte()
+	if !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%w: %d bytes", ErrTrailingBytes, reader.Len()+1)
+	}
+
+	decoded := VoteGraph[Hash, Number, voteNode, Vote]{
+		entries:            entries,
+		heads:              heads,
+		headIndexes:        make(map[Hash]headIndex[Hash, Number]),
+		base:               base,
+		baseNumber:         baseNumber,
+		newDefaultvoteNode: vg.newDefaultvoteNode,
+	}
+
+	// the base and heads must be vote-nodes, and the head indexes are built again
+	// from the ancestor-edges, which must lead back to the base.
+	_, err = decoded.getEntry(base)
+	if err != nil {
+		return fmt.Errorf("base: %w", err)
+	}
+	entries.Scan(func(hash Hash, entry voteGraphEntry[Hash, Number, voteNode, Vote]) bool {
+		parent := entry.ancestorNode()
+		if parent == nil {
+			return true
+		}
+		var parentEntry voteGraphEntry[Hash, Number, voteNode, Vote]
+		parentEntry, err = decoded.getEntry(*parent)
+		if err == nil && parentEntry.number >= entry.number {
+			err = fmt.Errorf("%w: vote-node %v at number %d has ancestor vote-node %v at number %d",
+				ErrInvalidAncestry, hash, entry.number, *parent, parentEntry.number)
+		}
+		return err == nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, head := range heads.Keys() {
+		err = decoded.indexHead(head)
+		if err != nil {
+			return fmt.Errorf("indexing head %v: %w", head, err)
+		}
+	}
+
+	decoded.ghostCaches = vg.ghostCaches
+	*vg = decoded
+	vg.resetGHOSTCaches()
+	return nil
+}
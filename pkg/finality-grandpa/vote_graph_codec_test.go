@@ -0,0 +1,103 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVoteGraph_EncodeDecode(t *testing.T) {
+	c := newDummyChain()
+	c.PushBlocks(GenesisHash, []string{"A", "B", "C", "D", "E"})
+	c.PushBlocks("C", []string{"D2", "E2"})
+	c.PushBlocks("B", []string{"C3"})
+
+	vn := uintVoteNode(0)
+	vg := NewVoteGraph[string, uint, *uintVoteNode, int](GenesisHash, uint(1), &vn, newUintVoteNode)
+	require.NoError(t, vg.Insert("E", 6, createUintVoteNode(3), c))
+	require.NoError(t, vg.Insert("E2", 6, createUintVoteNode(2), c))
+	require.NoError(t, vg.Insert("C3", 4, createUintVoteNode(1), c))
+	require.NoError(t, vg.Insert("D", 5, 1, c))
+
+	encoded, err := vg.Encode()
+	require.NoError(t, err)
+
+	restored := NewVoteGraph[string, uint, *uintVoteNode, int]("other", uint(9), &vn, newUintVoteNode)
+	require.NoError(t, restored.Decode(encoded))
+
+	assert.Equal(t, vg.Base(), restored.Base())
+	assert.Equal(t, vg.entries.Keys(), restored.entries.Keys())
+	vg.entries.Scan(func(hash string, entry voteGraphEntry[string, uint, *uintVoteNode, int]) bool {
+		restoredEntry, ok := restored.entries.Get(hash)
+		require.True(t, ok)
+		assert.Equal(t, entry.number, restoredEntry.number, hash)
+		assert.Equal(t, entry.ancestors, restoredEntry.ancestors, hash)
+		assert.Equal(t, entry.descendants, restoredEntry.descendants, hash)
+		assert.Equal(t, entry.cumulativeVote, restoredEntry.cumulativeVote, hash)
+		assert.Equal(t, entry.votes, restoredEntry.votes, hash)
+		return true
+	})
+	assert.Equal(t, vg.heads.Keys(), restored.heads.Keys())
+	assert.Equal(t, vg.headIndexes, restored.headIndexes)
+
+	reencoded, err := restored.Encode()
+	require.NoError(t, err)
+	assert.Equal(t, encoded, reencoded)
+
+	// the restored graph carries on accumulating votes like the original one
+	c.PushBlocks("E", []string{"F"})
+	for _, graph := range []*VoteGraph[string, uint, *uintVoteNode, int]{&vg, &restored} {
+		require.NoError(t, graph.Insert("F", 7, createUintVoteNode(4), c))
+		assert.Equal(t, &HashNumber[string, uint]{"F", 7},
+			findGHOST(t, graph, nil, func(x *uintVoteNode) bool { return *x >= 4 }))
+	}
+}
+
+func TestVoteGraph_Decode_errors(t *testing.T) {
+	c := newDummyChain()
+	c.PushBlocks(GenesisHash, []string{"A", "B"})
+
+	vn := uintVoteNode(0)
+	vg := NewVoteGraph[string, uint, *uintVoteNode, int](GenesisHash, uint(1), &vn, newUintVoteNode)
+	require.NoError(t, vg.Insert("B", 3, createUintVoteNode(1), c))
+	encoded, err := vg.Encode()
+	require.NoError(t, err)
+
+	restored := NewVoteGraph[string, uint, *uintVoteNode, int]("other", uint(9), &vn, newUintVoteNode)
+
+	err = restored.Decode(append(encoded, 0))
+	assert.ErrorIs(t, err, ErrTrailingBytes)
+
+	err = restored.Decode(encoded[:len(encoded)-1])
+	assert.Error(t, err)
+
+	// a head which is not a vote-node
+	withUnknownHead := NewVoteGraph[string, uint, *uintVoteNode, int](GenesisHash, uint(1), &vn, newUintVoteNode)
+	withUnknownHead.heads.Insert("Z")
+	encodedUnknownHead, err := withUnknownHead.Encode()
+	require.NoError(t, err)
+	err = restored.Decode(encodedUnknownHead)
+	assert.ErrorIs(t, err, ErrMissingEntry)
+
+	// the graph is left unchanged by the errors
+	assert.Equal(t, HashNumber[string, uint]{"other", 9}, restored.Base())
+	assert.Equal(t, []string{"other"}, restored.entries.Keys())
+}
+
+func Test_voteNode_MarshalSCALE(t *testing.T) {
+	node := &voteNode[string]{bits: newBitfield()}
+	node.bits.SetBit(3)
+	node.bits.SetBit(130)
+
+	encoded, err := node.MarshalSCALE()
+	require.NoError(t, err)
+
+	decoded := &voteNode[string]{}
+	require.NoError(t, decoded.UnmarshalSCALE(bytes.NewReader(encoded)))
+	assert.Equal(t, node, decoded)
+}
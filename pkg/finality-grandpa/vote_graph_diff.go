@@ -0,0 +1,94 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import "golang.org/x/exp/slices"
+
+// VoteGraphDiff is the difference between two vote graphs, such as the graphs built from
+// the same vote stream by this implementation and by the Rust finality-grandpa crate.
+// The hashes of the vote-nodes differing are listed in hash order.
+type VoteGraphDiff[Hash comparable] struct {
+	// Base is true if the graphs have different base blocks.
+	Base bool
+	// Missing are the vote-nodes of the graph which are not in the other graph.
+	Missing []Hash
+	// Extra are the vote-nodes of the other graph which are not in the graph.
+	Extra []Hash
+	// Edges are the vote-nodes of both graphs with different numbers, ancestor-edges
+	// or descendant vote-nodes.
+	Edges []Hash
+	// CumulativeVotes are the vote-nodes of both graphs with different cumulative votes.
+	CumulativeVotes []Hash
+}
+
+// Empty returns true if the graphs compared are equal.
+func (d VoteGraphDiff[Hash]) Empty() bool {
+	return !d.Base && len(d.Missing) == 0 && len(d.Extra) == 0 &&
+		len(d.Edges) == 0 && len(d.CumulativeVotes) == 0
+}
+
+// Equal returns true if the graph has the same base and vote-nodes as the other graph,
+// as compared by Diff with the equal function given.
+func (vg *VoteGraph[Hash, Number, voteNode, Vote]) Equal(other *VoteGraph[Hash, Number, voteNode, Vote],
+	equal func(a, b voteNode) bool) bool {
+	return vg.Diff(other, equal).Empty()
+}
+
+// Diff compares the graph with the other graph, and returns the vote-nodes whose
+// numbers, ancestor-edges, descendant vote-nodes or cumulative votes differ. The
+// descendant vote-nodes are compared regardless of their order, which depends on the
+// order the votes were inserted in, and the cumulative votes are compared with the equal
+// function given, such as WeightNodesEqual, since vote-nodes holding equal votes may
+// differ in their representation. Whether votes were inserted on the vote-nodes
+// themselves is not compared, since the vote graph of the Rust crate does not record it.
+func (vg *VoteGraph[Hash, Number, voteNode, Vote]) Diff(
+	other *VoteGraph[Hash, Number, voteNode, Vote], equal func(a, b voteNode) bool) VoteGraphDiff[Hash] {
+	diff := VoteGraphDiff[Hash]{
+		Base: vg.base != other.base || vg.baseNumber != other.baseNumber,
+	}
+
+	vg.entries.Scan(func(hash Hash, entry voteGraphEntry[Hash, Number, voteNode, Vote]) bool {
+		otherEntry, ok := other.entries.Get(hash)
+		switch {
+		case !ok:
+			diff.Missing = append(diff.Missing, hash)
+			return true
+		case entry.number != otherEntry.number ||
+			!slices.Equal(entry.ancestors, otherEntry.ancestors) ||
+			!equalHashSets(entry.descendants, otherEntry.descendants):
+			diff.Edges = append(diff.Edges, hash)
+		}
+
+		if !equal(entry.cumulativeVote, otherEntry.cumulativeVote) {
+			diff.CumulativeVotes = append(diff.CumulativeVotes, hash)
+		}
+		return true
+	})
+
+	other.entries.Scan(func(hash Hash, _ voteGraphEntry[Hash, Number, voteNode, Vote]) bool {
+		_, ok := vg.entries.Get(hash)
+		if !ok {
+			diff.Extra = append(diff.Extra, hash)
+		}
+		return true
+	})
+	return diff
+}
+
+func equalHashSets[Hash comparable](a, b []Hash) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[Hash]int, len(a))
+	for _, hash := range a {
+		counts[hash]++
+	}
+	for _, hash := range b {
+		if counts[hash] == 0 {
+			return false
+		}
+		counts[hash]--
+	}
+	return true
+}
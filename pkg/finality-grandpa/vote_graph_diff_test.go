@@ -0,0 +1,104 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func uintVoteNodesEqual(a, b *uintVoteNode) bool {
+	return *a == *b
+}
+
+func TestVoteGraph_Diff(t *testing.T) {
+	c := newDummyChain()
+	c.PushBlocks(GenesisHash, []string{"A", "B", "C", "D", "E"})
+	c.PushBlocks("C", []string{"D2", "E2"})
+	c.PushBlocks("B", []string{"C3"})
+
+	newGraph := func(votes ...VoteEntry[string, uint]) *VoteGraph[string, uint, *uintVoteNode, int] {
+		t.Helper()
+		vn := uintVoteNode(0)
+		vg := NewVoteGraph[string, uint, *uintVoteNode, int](GenesisHash, uint(1), &vn, newUintVoteNode)
+		require.NoError(t, vg.InsertBatch(votes, c))
+		return &vg
+	}
+
+	e := VoteEntry[string, uint]{Hash: "E", Number: 6, Vote: 1}
+	e2 := VoteEntry[string, uint]{Hash: "E2", Number: 6, Vote: 2}
+	c3 := VoteEntry[string, uint]{Hash: "C3", Number: 4, Vote: 3}
+
+	// the descendants of the vote-nodes are compared regardless of the insertion order
+	vg := newGraph(e, e2, c3)
+	assert.True(t, vg.Equal(newGraph(c3, e2, e), uintVoteNodesEqual))
+	assert.True(t, vg.Equal(vg, uintVoteNodesEqual))
+	assert.Empty(t, vg.Diff(newGraph(c3, e2, e), uintVoteNodesEqual))
+
+	// a vote on a new fork adds a vote-node, and changes the edges and votes of its ancestors
+	other := newGraph(e, e2)
+	diff := vg.Diff(other, uintVoteNodesEqual)
+	assert.False(t, vg.Equal(other, uintVoteNodesEqual))
+	assert.Equal(t, VoteGraphDiff[string]{
+		Missing:         []string{"C3"},
+		Edges:           []string{GenesisHash},
+		CumulativeVotes: []string{GenesisHash},
+	}, diff)
+	assert.Equal(t, VoteGraphDiff[string]{
+		Extra:           []string{"C3"},
+		Edges:           []string{GenesisHash},
+		CumulativeVotes: []string{GenesisHash},
+	}, other.Diff(vg, uintVoteNodesEqual))
+
+	// a different vote only changes the cumulative votes
+	other = newGraph(e, e2, VoteEntry[string, uint]{Hash: "C3", Number: 4, Vote: 4})
+	assert.Equal(t, VoteGraphDiff[string]{
+		CumulativeVotes: []string{"C3", GenesisHash},
+	}, vg.Diff(other, uintVoteNodesEqual))
+
+	vn := uintVoteNode(0)
+	otherBase := NewVoteGraph[string, uint, *uintVoteNode, int]("A", uint(2), &vn, newUintVoteNode)
+	require.NoError(t, otherBase.InsertBatch([]VoteEntry[string, uint]{e, e2, c3}, c))
+	diff = vg.Diff(&otherBase, uintVoteNodesEqual)
+	assert.True(t, diff.Base)
+	assert.Equal(t, []string{GenesisHash}, diff.Missing)
+	assert.Equal(t, []string{"A"}, diff.Extra)
+	assert.False(t, diff.Empty())
+}
+
+func TestVoteGraph_Diff_weightNodes(t *testing.T) {
+	c := newDummyChain()
+	c.PushBlocks(GenesisHash, []string{"A", "B", "C"})
+
+	newGraph := func() *VoteGraph[string, uint, *WeightNode[BigWeight], BigWeight] {
+		vg := NewVoteGraph[string, uint, *WeightNode[BigWeight], BigWeight](GenesisHash, uint(1),
+			NewWeightNode(NewBigWeight(big.NewInt(0))),
+			func() *WeightNode[BigWeight] { return NewWeightNode(NewBigWeight(big.NewInt(0))) })
+		return &vg
+	}
+
+	vg := newGraph()
+	require.NoError(t, vg.Insert("C", 4, NewBigWeight(big.NewInt(0)), c))
+	other := newGraph()
+	require.NoError(t, other.Insert("C", 4, NewBigWeight(big.NewInt(5)), c))
+	require.NoError(t, other.Remove("C", 4, NewBigWeight(big.NewInt(5))))
+
+	// the weights are equal while their representations differ
+	entry, ok := vg.entries.Get("C")
+	require.True(t, ok)
+	otherEntry, ok := other.entries.Get("C")
+	require.True(t, ok)
+	require.False(t, reflect.DeepEqual(entry.cumulativeVote, otherEntry.cumulativeVote))
+
+	assert.True(t, vg.Equal(other, WeightNodesEqual[BigWeight]))
+
+	require.NoError(t, other.Insert("C", 4, NewBigWeight(big.NewInt(1)), c))
+	assert.Equal(t, VoteGraphDiff[string]{
+		CumulativeVotes: []string{"C", GenesisHash},
+	}, vg.Diff(other, WeightNodesEqual[BigWeight]))
+}
@@ -0,0 +1,238 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The vote graph differential tests replay randomised sequences of inserts and
+// GHOST queries stored in testdata/vote_graph/fixtures.json and check the results
+// match the expected ones recorded in the fixtures. The expected results are
+// recorded by this implementation, so on their own these tests only catch
+// regressions: the vote-graph-differential CI workflow replays the same fixtures
+// against the parity rust implementation with the harness in
+// testdata/vote_graph/harness, which fails on any result the two disagree on.
+// To regenerate the fixtures and replay them locally:
+//
+//	go test ./pkg/finality-grandpa -run TestVoteGraph_Differential -update-vote-graph-fixtures
+//	cargo run --manifest-path pkg/finality-grandpa/testdata/vote_graph/harness/Cargo.toml -- \
+//		pkg/finality-grandpa/testdata/vote_graph/fixtures.json
+var updateVoteGraphFixtures = flag.Bool("update-vote-graph-fixtures", false,
+	"regenerate the vote graph differential testing fixtures")
+
+var voteGraphFixturesPath = filepath.Join("testdata", "vote_graph", "fixtures.json")
+
+const (
+	voteGraphFixturesCount     = 16
+	voteGraphFixtureBlocks     = 30
+	voteGraphFixtureOperations = 40
+)
+
+type voteGraphFixtureBlock struct {
+	Hash   string `json:"hash"`
+	Number uint32 `json:"number"`
+	Parent string `json:"parent,omitempty"`
+}
+
+// voteGraphFixtureOperation is either an insert of the weight on the block, a GHOST
+// query from the optional current best block, or an ancestor query from the block.
+// Queries use the condition cumulative weight >= threshold, and their expected
+// result is nil when no block is found.
+type voteGraphFixtureOperation struct {
+	Op        string                 `json:"op"`
+	Block     *voteGraphFixtureBlock `json:"block,omitempty"`
+	Weight    uint64                 `json:"weight,omitempty"`
+	Threshold uint64                 `json:"threshold,omitempty"`
+	Expected  *voteGraphFixtureBlock `json:"expected"`
+}
+
+type voteGraphFixture struct {
+	Seed       int64                       `json:"seed"`
+	Base       voteGraphFixtureBlock       `json:"base"`
+	Chain      []voteGraphFixtureBlock     `json:"chain"`
+	Operations []voteGraphFixtureOperation `json:"operations"`
+}
+
+const (
+	voteGraphOpInsert       = "insert"
+	voteGraphOpFindGHOST    = "find_ghost"
+	voteGraphOpFindAncestor = "find_ancestor"
+)
+
+// newRandomVoteGraphFixture generates a random chain with forks and a random sequence
+// of operations over it, without the expected query results.
+func newRandomVoteGraphFixture(seed int64) voteGraphFixture {
+	random := rand.New(rand.NewSource(seed)) //nolint:gosec
+
+	fixture := voteGraphFixture{
+		Seed: seed,
+		Base: voteGraphFixtureBlock{Hash: GenesisHash, Number: 1},
+	}
+
+	blocks := []voteGraphFixtureBlock{fixture.Base}
+	for i := 0; i < voteGraphFixtureBlocks; i++ {
+		// favour extending recent blocks so the chain has long forks
+		parent := blocks[len(blocks)-1-random.Intn(min(len(blocks), 4))]
+		if random.Intn(5) == 0 {
+			parent = blocks[random.Intn(len(blocks))]
+		}
+		block := voteGraphFixtureBlock{
+			Hash:   fmt.Sprintf("B%d", i),
+			Number: parent.Number + 1,
+			Parent: parent.Hash,
+		}
+		blocks = append(blocks, block)
+		fixture.Chain = append(fixture.Chain, block)
+	}
+
+	randomBlock := func() *voteGraphFixtureBlock {
+		block := blocks[random.Intn(len(blocks))]
+		return &voteGraphFixtureBlock{Hash: block.Hash, Number: block.Number}
+	}
+
+	var totalWeight uint64
+	for i := 0; i < voteGraphFixtureOperations; i++ {
+		var operation voteGraphFixtureOperation
+		switch choice := random.Intn(10); {
+		case choice < 6:
+			operation = voteGraphFixtureOperation{
+				Op:     voteGraphOpInsert,
+				Block:  randomBlock(),
+				Weight: uint64(random.Intn(10) + 1),
+			}
+			totalWeight += operation.Weight
+		case choice < 8:
+			operation = voteGraphFixtureOperation{Op: voteGraphOpFindGHOST}
+			if random.Intn(2) == 0 {
+				operation.Block = randomBlock()
+			}
+		default:
+			operation = voteGraphFixtureOperation{
+				Op:    voteGraphOpFindAncestor,
+				Block: randomBlock(),
+			}
+		}
+
+		if operation.Op != voteGraphOpInsert {
+			operation.Threshold = uint64(random.Int63n(int64(totalWeight)+2)) + 1
+		}
+		fixture.Operations = append(fixture.Operations, operation)
+	}
+
+	return fixture
+}
+
+// replayVoteGraphFixture replays the fixture operations on a new vote graph and
+// returns the result of each operation, which is always nil for inserts.
+func replayVoteGraphFixture(t *testing.T, fixture voteGraphFixture) (results []*voteGraphFixtureBlock) {
+	t.Helper()
+
+	chain := newDummyChain()
+	require.Equal(t, voteGraphFixtureBlock{Hash: GenesisHash, Number: 1}, fixture.Base)
+	for _, block := range fixture.Chain {
+		chain.PushBlocks(block.Parent, []string{block.Hash})
+		require.Equal(t, block.Number, chain.Number(block.Hash))
+	}
+
+	vn := uintVoteNode(0)
+	vg := NewVoteGraph[string, uint32, *uintVoteNode, int](fixture.Base.Hash, fixture.Base.Number, &vn, newUintVoteNode)
+
+	for i, operation := range fixture.Operations {
+		condition := func(node *uintVoteNode) bool {
+			return uint64(*node) >= operation.Threshold
+		}
+
+		var result *HashNumber[string, uint32]
+		switch operation.Op {
+		case voteGraphOpInsert:
+			err := vg.Insert(operation.Block.Hash, operation.Block.Number, int(operation.Weight), chain)
+			require.NoErrorf(t, err, "operation %d", i)
+		case voteGraphOpFindGHOST:
+			var currentBest *HashNumber[string, uint32]
+			if operation.Block != nil {
+				currentBest = &HashNumber[string, uint32]{operation.Block.Hash, operation.Block.Number}
+			}
+			var err error
+			result, err = vg.FindGHOST(currentBest, condition)
+			require.NoErrorf(t, err, "operation %d", i)
+		case voteGraphOpFindAncestor:
+			var err error
+			result, err = vg.FindAncestor(operation.Block.Hash, operation.Block.Number, condition)
+			require.NoErrorf(t, err, "operation %d", i)
+		default:
+			t.Fatalf("operation %d: unknown operation %q", i, operation.Op)
+		}
+
+		if result == nil {
+			results = append(results, nil)
+			continue
+		}
+		results = append(results, &voteGraphFixtureBlock{Hash: result.Hash, Number: result.Number})
+	}
+
+	return results
+}
+
+func writeVoteGraphFixtures(t *testing.T) {
+	t.Helper()
+
+	fixtures := make([]voteGraphFixture, voteGraphFixturesCount)
+	for i := range fixtures {
+		fixtures[i] = newRandomVoteGraphFixture(int64(i + 1))
+		results := replayVoteGraphFixture(t, fixtures[i])
+		for j := range fixtures[i].Operations {
+			fixtures[i].Operations[j].Expected = results[j]
+		}
+	}
+
+	data, err := json.MarshalIndent(fixtures, "", "  ")
+	require.NoError(t, err)
+	err = os.MkdirAll(filepath.Dir(voteGraphFixturesPath), os.ModePerm)
+	require.NoError(t, err)
+	err = os.WriteFile(voteGraphFixturesPath, append(data, '\n'), 0o600)
+	require.NoError(t, err)
+}
+
+func TestVoteGraph_Differential(t *testing.T) {
+	if *updateVoteGraphFixtures {
+		writeVoteGraphFixtures(t)
+	}
+
+	data, err := os.ReadFile(voteGraphFixturesPath)
+	require.NoError(t, err)
+
+	var fixtures []voteGraphFixture
+	err = json.Unmarshal(data, &fixtures)
+	require.NoError(t, err)
+	require.NotEmpty(t, fixtures)
+
+	for _, fixture := range fixtures {
+		fixture := fixture
+		t.Run(fmt.Sprintf("seed_%d", fixture.Seed), func(t *testing.T) {
+			t.Parallel()
+
+			results := replayVoteGraphFixture(t, fixture)
+			for i, operation := range fixture.Operations {
+				assert.Equalf(t, operation.Expected, results[i], "operation %d: %s", i, operation.Op)
+			}
+		})
+	}
+}
+
+func TestVoteGraph_Differential_generatorDeterministic(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, newRandomVoteGraphFixture(7), newRandomVoteGraphFixture(7))
+	assert.NotEqual(t, newRandomVoteGraphFixture(7), newRandomVoteGraphFixture(8))
+}
@@ -0,0 +1,56 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Dot writes the graph in the Graphviz DOT language to the writer, to visualise it
+// when debugging finality. Each vote-node is labelled with its hash, its number and
+// its cumulative vote formatted by voteLabel, or with %v if voteLabel is nil. Each
+// vote-node has an edge to its ancestor vote-node, labelled with the number of blocks
+// between them. The base is drawn in bold, the heads as boxes and the vote-nodes
+// without votes of their own dashed.
+func (vg *VoteGraph[Hash, Number, voteNode, Vote]) Dot(w io.Writer, voteLabel func(voteNode) string) error {
+	if voteLabel == nil {
+		voteLabel = func(node voteNode) string { return fmt.Sprintf("%v", node) }
+	}
+
+	lines := []string{"digraph vote_graph {", "\trankdir=BT;"}
+	vg.entries.Scan(func(hash Hash, entry voteGraphEntry[Hash, Number, voteNode, Vote]) bool {
+		id := strconv.Quote(fmt.Sprintf("%v", hash))
+		label := strconv.Quote(fmt.Sprintf("%v\n#%d\n%s", hash, entry.number, voteLabel(entry.cumulativeVote)))
+
+		var attributes string
+		switch {
+		case hash == vg.base:
+			attributes += ", style=bold"
+		case entry.votes == 0:
+			attributes += ", style=dashed"
+		}
+		if vg.heads.Contains(hash) {
+			attributes += ", shape=box"
+		}
+		lines = append(lines, fmt.Sprintf("\t%s [label=%s%s];", id, label, attributes))
+
+		parent := entry.ancestorNode()
+		if parent != nil {
+			lines = append(lines, fmt.Sprintf("\t%s -> %s [label=%d];",
+				id, strconv.Quote(fmt.Sprintf("%v", *parent)), len(entry.ancestors)))
+		}
+		return true
+	})
+	lines = append(lines, "}")
+
+	for _, line := range lines {
+		_, err := fmt.Fprintln(w, line)
+		if err != nil {
+			return fmt.Errorf("writing vote graph: %w", err)
+		}
+	}
+	return nil
+}
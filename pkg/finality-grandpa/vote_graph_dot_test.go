@@ -0,0 +1,50 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("test error") }
+
+func TestVoteGraph_Dot(t *testing.T) {
+	c := newDummyChain()
+	c.PushBlocks(GenesisHash, []string{"A", "B", "C", "D", "E"})
+	c.PushBlocks("C", []string{"D2", "E2"})
+
+	vn := uintVoteNode(0)
+	vg := NewVoteGraph[string, uint, *uintVoteNode, int](GenesisHash, uint(1), &vn, newUintVoteNode)
+	require.NoError(t, vg.Insert("E", 6, 2, c))
+	require.NoError(t, vg.Insert("E2", 6, 1, c))
+	require.NoError(t, vg.Insert("C", 4, 1, c))
+
+	buffer := bytes.NewBuffer(nil)
+	err := vg.Dot(buffer, func(x *uintVoteNode) string { return fmt.Sprintf("weight %d", *x) })
+	require.NoError(t, err)
+
+	expected := `digraph vote_graph {
+	rankdir=BT;
+	"C" [label="C\n#4\nweight 4"];
+	"C" -> "genesis" [label=3];
+	"E" [label="E\n#6\nweight 2", shape=box];
+	"E" -> "C" [label=2];
+	"E2" [label="E2\n#6\nweight 1", shape=box];
+	"E2" -> "C" [label=2];
+	"genesis" [label="genesis\n#1\nweight 4", style=bold];
+}
+`
+	assert.Equal(t, expected, buffer.String())
+
+	err = vg.Dot(failingWriter{}, nil)
+	assert.EqualError(t, err, "writing vote graph: test error")
+}
@@ -0,0 +1,131 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"golang.org/x/exp/constraints"
+)
+
+// GHOSTCache is the GHOST of a vote graph for a condition, cached between the calls to
+// VoteGraph.FindCachedGHOST. The condition must be monotonic, true for a vote-node if it
+// is true for a vote-node with a subset of its votes, and met by a single branch, such as
+// a supermajority weight threshold. The GHOST is then only moved by the votes inserted on
+// it or its descendants, so the cached GHOST is kept when votes are inserted elsewhere,
+// and is otherwise searched again from the cached GHOST rather than from the base.
+type GHOSTCache[Hash comparable, Number constraints.Unsigned, voteNode any] struct {
+	condition func(voteNode) bool
+	ghost     *HashNumber[Hash, Number]
+	// stale is true if votes which may move the GHOST were inserted since it was found.
+	stale bool
+}
+
+// NewGHOSTCache returns a cache of the GHOST of the graph for the monotonic condition,
+// which is kept up to date as votes are inserted into the graph.
+func (vg *VoteGraph[Hash, Number, voteNode, Vote]) NewGHOSTCache(
+	condition func(voteNode) bool) *GHOSTCache[Hash, Number, voteNode] {
+	cache := &GHOSTCache[Hash, Number, voteNode]{
+		condition: condition,
+		stale:     true,
+	}
+	vg.ghostCaches = append(vg.ghostCaches, cache)
+	return cache
+}
+
+// FindCachedGHOST returns the GHOST of the graph for the condition of the cache, as
+// FindGHOST does from the base. It is only searched again if votes were inserted on the
+// GHOST found before or its descendants, starting from that GHOST, so finding the GHOST
+// as votes are inserted takes amortized constant time.
+func (vg *VoteGraph[Hash, Number, voteNode, Vote]) FindCachedGHOST(
+	cache *GHOSTCache[Hash, Number, voteNode]) (*HashNumber[Hash, Number], error) {
+	if cache.stale {
+		ghost, err := vg.FindGHOST(cache.ghost, cache.condition)
+		if err != nil {
+			return nil, err
+		}
+		if ghost == nil && cache.ghost != nil {
+			// the cached GHOST no longer meets the condition, which is not monotonic
+			ghost, err = vg.FindGHOST(nil, cache.condition)
+			if err != nil {
+				return nil, err
+			}
+		}
+		cache.ghost = ghost
+		cache.stale = false
+	}
+
+	if cache.ghost == nil {
+		return nil, nil
+	}
+	ghost := *cache.ghost
+	return &ghost, nil
+}
+
+// InvalidateGHOSTCache marks the GHOST of the cache stale after its condition changed
+// for vote-nodes other than those votes are inserted on, such as when the weight of an
+// equivocator is counted for every vote-node. The GHOST is searched again from the
+// cached GHOST, so the condition must be met by at least the vote-nodes it met before.
+func (vg *VoteGraph[Hash, Number, voteNode, Vote]) InvalidateGHOSTCache(cache *GHOSTCache[Hash, Number, voteNode]) {
+	cache.stale = true
+}
+
+// updateGHOSTCaches marks stale the caches whose GHOST may be moved by votes inserted on
+// the vote-node, which are the caches without GHOST and those with the vote-node at or
+// below their GHOST.
+func (vg *VoteGraph[Hash, Number, voteNode, Vote]) updateGHOSTCaches(hash Hash) error {
+	for _, cache := range vg.ghostCaches {
+		if cache.stale {
+			continue
+		}
+		if cache.ghost == nil {
+			cache.stale = true
+			continue
+		}
+
+		descendant, err := vg.isDescendant(hash, *cache.ghost)
+		if err != nil {
+			return err
+		}
+		cache.stale = descendant
+	}
+	return nil
+}
+
+// resetGHOSTCaches clears the GHOST of the caches, which are searched again from the
+// base, since removing votes or changing the base may move the GHOST backwards.
+func (vg *VoteGraph[Hash, Number, voteNode, Vote]) resetGHOSTCaches() {
+	for _, cache := range vg.ghostCaches {
+		cache.ghost = nil
+		cache.stale = true
+	}
+}
+
+// isDescendant returns true if the vote-node is the block or one of its descendants.
+// The ancestor-edges are walked back until the number of the block, which is close to
+// the vote-node for a GHOST near the heads.
+func (vg *VoteGraph[Hash, Number, voteNode, Vote]) isDescendant(
+	hash Hash, block HashNumber[Hash, Number]) (bool, error) {
+	for {
+		entry, err := vg.getEntry(hash)
+		if err != nil {
+			return false, err
+		}
+
+		switch {
+		case entry.number < block.Number:
+			return false, nil
+		case entry.number == block.Number:
+			return hash == block.Hash, nil
+		}
+
+		inAncestry := entry.inDirectAncestry(block.Hash, block.Number)
+		if inAncestry != nil {
+			return *inAncestry, nil
+		}
+		parent := entry.ancestorNode()
+		if parent == nil {
+			return false, nil
+		}
+		hash = *parent
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVoteGraph_FindCachedGHOST(t *testing.T) {
+	c := newDummyChain()
+	c.PushBlocks(GenesisHash, []string{"A", "B", "C", "D", "E"})
+	c.PushBlocks("C", []string{"D2", "E2"})
+	c.PushBlocks("B", []string{"C3"})
+
+	vn := uintVoteNode(0)
+	vg := NewVoteGraph[string, uint, *uintVoteNode, int](GenesisHash, uint(1), &vn, newUintVoteNode)
+	condition := func(x *uintVoteNode) bool { return *x >= 5 }
+	cache := vg.NewGHOSTCache(condition)
+
+	// the cached GHOST is the GHOST found from the base
+	requireCachedGHOST := func(expected *HashNumber[string, uint]) {
+		t.Helper()
+		ghost, err := vg.FindGHOST(nil, condition)
+		require.NoError(t, err)
+		require.Equal(t, expected, ghost)
+		ghost, err = vg.FindCachedGHOST(cache)
+		require.NoError(t, err)
+		require.Equal(t, expected, ghost)
+	}
+
+	requireCachedGHOST(nil)
+
+	require.NoError(t, vg.Insert("E", 6, 3, c))
+	requireCachedGHOST(nil)
+
+	require.NoError(t, vg.InsertBatch([]VoteEntry[string, uint]{
+		{Hash: "E2", Number: 6, Vote: 1},
+		{Hash: "C3", Number: 4, Vote: 1},
+	}, c))
+	requireCachedGHOST(&HashNumber[string, uint]{"B", 3})
+
+	// votes on the descendants of the GHOST move it forward
+	require.NoError(t, vg.Insert("E", 6, 1, c))
+	assert.True(t, cache.stale)
+	requireCachedGHOST(&HashNumber[string, uint]{"C", 4})
+
+	// votes outside the subtree of the GHOST keep it
+	require.NoError(t, vg.Insert("C3", 4, 1, c))
+	assert.False(t, cache.stale)
+	requireCachedGHOST(&HashNumber[string, uint]{"C", 4})
+
+	require.NoError(t, vg.Insert("E", 6, 2, c))
+	assert.True(t, cache.stale)
+	requireCachedGHOST(&HashNumber[string, uint]{"E", 6})
+
+	// removing votes moves the GHOST backwards
+	require.NoError(t, vg.Remove("E", 6, 2))
+	assert.Nil(t, cache.ghost)
+	requireCachedGHOST(&HashNumber[string, uint]{"C", 4})
+
+	// pruning re-roots the graph, leaving the GHOST at the new base
+	_, err := vg.Prune("C", 4)
+	require.NoError(t, err)
+	assert.Nil(t, cache.ghost)
+	requireCachedGHOST(&HashNumber[string, uint]{"C", 4})
+	require.NoError(t, vg.Insert("D", 5, 1, c))
+	requireCachedGHOST(&HashNumber[string, uint]{"D", 5})
+
+	// a change of the condition is searched again from the cached GHOST
+	threshold := uintVoteNode(6)
+	dynamic := vg.NewGHOSTCache(func(x *uintVoteNode) bool { return *x >= threshold })
+	ghost, err := vg.FindCachedGHOST(dynamic)
+	require.NoError(t, err)
+	assert.Equal(t, &HashNumber[string, uint]{"C", 4}, ghost)
+	threshold = 5
+	vg.InvalidateGHOSTCache(dynamic)
+	ghost, err = vg.FindCachedGHOST(dynamic)
+	require.NoError(t, err)
+	assert.Equal(t, &HashNumber[string, uint]{"D", 5}, ghost)
+}
@@ -0,0 +1,183 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/tidwall/btree"
+)
+
+// hashKey returns the key a hash is ordered by in the vote graph, which is distinct for
+// distinct hashes. String hashes and byte array hashes, such as block hashes, are keyed by
+// their bytes, so they are ordered as byte strings like the hashes of the substrate vote
+// graph. Integer hashes are keyed by their big endian encoding, and other comparable
+// hashes by an encoding of their values, both written by appendKey.
+func hashKey[Hash comparable](hash Hash) string {
+	switch hash := any(hash).(type) {
+	case string:
+		return hash
+	case [32]byte:
+		return string(hash[:])
+	}
+
+	// the value is addressable so the bytes of named byte arrays can be read at once
+	value := reflect.ValueOf(&hash).Elem()
+	switch value.Kind() {
+	case reflect.String:
+		return value.String()
+	case reflect.Array:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			return string(value.Bytes())
+		}
+	}
+	return string(appendKey(nil, value))
+}
+
+// appendKey appends the encoding of the comparable value to the key. The encoding of
+// each value is self-delimiting, so the encodings of the fields of structs and of the
+// elements of arrays are appended one after the other. Values equal with the == operator
+// have the same encoding and distinct values have distinct encodings, pointers being
+// encoded by their address and interface values by their dynamic type and value.
+func appendKey(key []byte, value reflect.Value) []byte {
+	switch value.Kind() {
+	case reflect.Bool:
+		if value.Bool() {
+			return append(key, 1)
+		}
+		return append(key, 0)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return binary.BigEndian.AppendUint64(key, value.Uint())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		// flip the sign bit so negative integers are ordered before positive ones
+		return binary.BigEndian.AppendUint64(key, uint64(value.Int())^(1<<63)) //nolint:gosec
+	case reflect.Float32, reflect.Float64:
+		return appendFloatKey(key, value.Float())
+	case reflect.Complex64, reflect.Complex128:
+		key = appendFloatKey(key, real(value.Complex()))
+		return appendFloatKey(key, imag(value.Complex()))
+	case reflect.String:
+		key = binary.AppendUvarint(key, uint64(value.Len()))
+		return append(key, value.String()...)
+	case reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			key = appendKey(key, value.Index(i))
+		}
+		return key
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			key = appendKey(key, value.Field(i))
+		}
+		return key
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		return binary.BigEndian.AppendUint64(key, uint64(value.Pointer()))
+	case reflect.Interface:
+		if value.IsNil() {
+			return append(key, 0)
+		}
+		elem := value.Elem()
+		key = append(key, 1)
+		key = appendKey(key, reflect.ValueOf(elem.Type().PkgPath()))
+		key = appendKey(key, reflect.ValueOf(elem.Type().String()))
+		return appendKey(key, elem)
+	default:
+		panic(fmt.Sprintf("hash of %s kind is not comparable", value.Kind()))
+	}
+}
+
+// appendFloatKey appends the bits of the float to the key, with the negative zero
+// encoded as the positive zero it is equal to.
+func appendFloatKey(key []byte, f float64) []byte {
+	if f == 0 {
+		f = 0
+	}
+	return binary.BigEndian.AppendUint64(key, math.Float64bits(f))
+}
+
+type hashMapItem[Hash comparable, Value any] struct {
+	hash  Hash
+	value Value
+}
+
+// hashMap is a map ordered by the key of its hashes, which only need to be comparable.
+type hashMap[Hash comparable, Value any] struct {
+	items *btree.Map[string, hashMapItem[Hash, Value]]
+}
+
+func newHashMap[Hash comparable, Value any]() *hashMap[Hash, Value] {
+	return &hashMap[Hash, Value]{
+		items: btree.NewMap[string, hashMapItem[Hash, Value]](2),
+	}
+}
+
+func (m *hashMap[Hash, Value]) Get(hash Hash) (value Value, ok bool) {
+	item, ok := m.items.Get(hashKey(hash))
+	return item.value, ok
+}
+
+func (m *hashMap[Hash, Value]) Set(hash Hash, value Value) {
+	m.items.Set(hashKey(hash), hashMapItem[Hash, Value]{hash: hash, value: value})
+}
+
+func (m *hashMap[Hash, Value]) Delete(hash Hash) {
+	m.items.Delete(hashKey(hash))
+}
+
+func (m *hashMap[Hash, Value]) Len() int {
+	return m.items.Len()
+}
+
+// Scan calls the iterator for the hashes and values of the map in order, until it
+// returns false.
+func (m *hashMap[Hash, Value]) Scan(iter func(hash Hash, value Value) bool) {
+	m.items.Scan(func(_ string, item hashMapItem[Hash, Value]) bool {
+		return iter(item.hash, item.value)
+	})
+}
+
+// Keys returns the hashes of the map in order.
+func (m *hashMap[Hash, Value]) Keys() []Hash {
+	hashes := make([]Hash, 0, m.items.Len())
+	m.items.Scan(func(_ string, item hashMapItem[Hash, Value]) bool {
+		hashes = append(hashes, item.hash)
+		return true
+	})
+	return hashes
+}
+
+// hashSet is a set ordered by the key of its hashes, which only need to be comparable.
+type hashSet[Hash comparable] struct {
+	items *hashMap[Hash, struct{}]
+}
+
+func newHashSet[Hash comparable]() *hashSet[Hash] {
+	return &hashSet[Hash]{
+		items: newHashMap[Hash, struct{}](),
+	}
+}
+
+func (s *hashSet[Hash]) Insert(hash Hash) {
+	s.items.Set(hash, struct{}{})
+}
+
+func (s *hashSet[Hash]) Delete(hash Hash) {
+	s.items.Delete(hash)
+}
+
+func (s *hashSet[Hash]) Contains(hash Hash) bool {
+	_, ok := s.items.Get(hash)
+	return ok
+}
+
+func (s *hashSet[Hash]) Len() int {
+	return s.items.Len()
+}
+
+// Keys returns the hashes of the set in order.
+func (s *hashSet[Hash]) Keys() []Hash {
+	return s.items.Keys()
+}
@@ -0,0 +1,99 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// arrayHash is a block hash which is comparable but not ordered.
+type arrayHash [32]byte
+
+func newArrayHash(hash string) (arrayHash arrayHash) {
+	copy(arrayHash[:], hash)
+	return arrayHash
+}
+
+// arrayHashChain is the dummy chain with its string hashes converted to array hashes.
+type arrayHashChain struct {
+	chain *dummyChain
+}
+
+func (c arrayHashChain) toString(hash arrayHash) string {
+	for i, b := range hash {
+		if b == 0 {
+			return string(hash[:i])
+		}
+	}
+	return string(hash[:])
+}
+
+func (c arrayHashChain) Ancestry(base, block arrayHash) ([]arrayHash, error) {
+	ancestry, err := c.chain.Ancestry(c.toString(base), c.toString(block))
+	if err != nil {
+		return nil, err
+	}
+	hashes := make([]arrayHash, len(ancestry))
+	for i, hash := range ancestry {
+		hashes[i] = newArrayHash(hash)
+	}
+	return hashes, nil
+}
+
+func (c arrayHashChain) IsEqualOrDescendantOf(base, block arrayHash) bool {
+	return c.chain.IsEqualOrDescendantOf(c.toString(base), c.toString(block))
+}
+
+func TestVoteGraph_ArrayHash(t *testing.T) {
+	c := newDummyChain()
+	c.PushBlocks(GenesisHash, []string{"A", "B", "C", "D", "E"})
+	c.PushBlocks("C", []string{"D2", "E2"})
+	chain := arrayHashChain{chain: c}
+
+	vn := uintVoteNode(0)
+	vg := NewVoteGraph[arrayHash, uint, *uintVoteNode, int](newArrayHash(GenesisHash), uint(1), &vn, newUintVoteNode)
+	require.NoError(t, vg.Insert(newArrayHash("E"), 6, 3, chain))
+	require.NoError(t, vg.Insert(newArrayHash("E2"), 6, 2, chain))
+
+	assert.Equal(t, []arrayHash{newArrayHash("E"), newArrayHash("E2")}, vg.heads.Keys())
+	ghost, err := vg.FindGHOST(nil, func(x *uintVoteNode) bool { return *x >= 5 })
+	require.NoError(t, err)
+	assert.Equal(t, &HashNumber[arrayHash, uint]{newArrayHash("C"), 4}, ghost)
+}
+
+func Test_hashKey(t *testing.T) {
+	t.Parallel()
+
+	assert.Less(t, hashKey("A"), hashKey("B"))
+	assert.Less(t, hashKey(newArrayHash("A")), hashKey(newArrayHash("B")))
+	assert.Equal(t, hashKey("A"), hashKey([1]byte{'A'}))
+	assert.Less(t, hashKey(uint(2)), hashKey(uint(256)))
+	assert.Less(t, hashKey(-1), hashKey(0))
+	assert.Less(t, hashKey(int8(-2)), hashKey(int8(-1)))
+
+	// named byte arrays, such as common.Hash, are keyed by their bytes
+	type namedHash [32]byte
+	assert.Equal(t, hashKey([32]byte{1, 2}), hashKey(namedHash{1, 2}))
+
+	// the keys of other hashes are distinct for distinct hashes
+	type pairHash struct {
+		first, second string
+	}
+	assert.NotEqual(t, hashKey(pairHash{"a b", "c"}), hashKey(pairHash{"a", "b c"}))
+	assert.NotEqual(t, hashKey(pairHash{"", "ab"}), hashKey(pairHash{"ab", ""}))
+	assert.NotEqual(t, hashKey([2]string{"a", ""}), hashKey([2]string{"", "a"}))
+	assert.NotEqual(t, hashKey(any(1)), hashKey(any(uint(1))))
+	assert.NotEqual(t, hashKey(any(nil)), hashKey(any(false)))
+	first, second := 1, 1
+	assert.NotEqual(t, hashKey(&first), hashKey(&second))
+
+	// and equal for equal hashes
+	assert.Equal(t, hashKey(pairHash{"a", "b"}), hashKey(pairHash{"a", "b"}))
+	assert.Equal(t, hashKey(&first), hashKey(&first))
+	assert.Equal(t, hashKey(math.Copysign(0, -1)), hashKey(0.0))
+}
@@ -0,0 +1,217 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"io"
+	"sync"
+
+	"golang.org/x/exp/constraints"
+)
+
+// SyncVoteGraph is a VoteGraph safe for concurrent use, so votes received from the
+// network can be inserted while the graph is queried. The queries take a read lock and
+// run concurrently with each other, while the updates take a write lock. The chain and
+// callbacks given to the methods are called with the lock held, so they must not call
+// the methods updating the graph.
+type SyncVoteGraph[
+	Hash comparable,
+	Number constraints.Unsigned,
+	voteNode voteNodeI[voteNode, Vote],
+	Vote any,
+] struct {
+	mtx   sync.RWMutex
+	graph VoteGraph[Hash, Number, voteNode, Vote]
+}
+
+// NewSyncVoteGraph returns a graph safe for concurrent use wrapping the given graph,
+// which must not be used directly afterwards.
+func NewSyncVoteGraph[
+	Hash comparable,
+	Number constraints.Unsigned,
+	voteNode voteNodeI[voteNode, Vote],
+	Vote any,
+](graph VoteGraph[Hash, Number, voteNode, Vote]) *SyncVoteGraph[Hash, Number, voteNode, Vote] {
+	return &SyncVoteGraph[Hash, Number, voteNode, Vote]{graph: graph}
+}
+
+// Insert a vote with given value into the graph at given hash and number.
+func (svg *SyncVoteGraph[Hash, Number, voteNode, Vote]) Insert(
+	hash Hash, num Number, vote any, chain Chain[Hash, Number]) error {
+	svg.mtx.Lock()
+	defer svg.mtx.Unlock()
+	return svg.graph.Insert(hash, num, vote, chain)
+}
+
+// InsertBatch inserts the votes into the graph, as VoteGraph.InsertBatch does.
+func (svg *SyncVoteGraph[Hash, Number, voteNode, Vote]) InsertBatch(
+	votes []VoteEntry[Hash, Number], chain Chain[Hash, Number]) error {
+	svg.mtx.Lock()
+	defer svg.mtx.Unlock()
+	return svg.graph.InsertBatch(votes, chain)
+}
+
+// Remove removes the vote from the graph, as VoteGraph.Remove does.
+func (svg *SyncVoteGraph[Hash, Number, voteNode, Vote]) Remove(hash Hash, num Number, vote any) error {
+	svg.mtx.Lock()
+	defer svg.mtx.Unlock()
+	return svg.graph.Remove(hash, num, vote)
+}
+
+// AdjustBase adjusts the base of the graph, as VoteGraph.AdjustBase does.
+func (svg *SyncVoteGraph[Hash, Number, voteNode, Vote]) AdjustBase(
+	ancestryProof []Hash, chain Chain[Hash, Number]) error {
+	svg.mtx.Lock()
+	defer svg.mtx.Unlock()
+	return svg.graph.AdjustBase(ancestryProof, chain)
+}
+
+// Prune re-roots the graph at the finalized block, as VoteGraph.Prune does.
+func (svg *SyncVoteGraph[Hash, Number, voteNode, Vote]) Prune(finalizedHash Hash, finalizedNumber Number) (
+	[]PrunedVoteNode[Hash, Number, voteNode], error) {
+	svg.mtx.Lock()
+	defer svg.mtx.Unlock()
+	return svg.graph.Prune(finalizedHash, finalizedNumber)
+}
+
+// Rebase moves the base of the graph forward to a vote-node, as VoteGraph.Rebase does.
+func (svg *SyncVoteGraph[Hash, Number, voteNode, Vote]) Rebase(newBaseHash Hash, newBaseNumber Number) error {
+	svg.mtx.Lock()
+	defer svg.mtx.Unlock()
+	return svg.graph.Rebase(newBaseHash, newBaseNumber)
+}
+
+// Decode replaces the graph with the graph encoded by Encode, as VoteGraph.Decode does.
+func (svg *SyncVoteGraph[Hash, Number, voteNode, Vote]) Decode(encoded []byte) error {
+	svg.mtx.Lock()
+	defer svg.mtx.Unlock()
+	return svg.graph.Decode(encoded)
+}
+
+// FindGHOST finds the highest block containing the vote-nodes fulfilling the
+// condition, as VoteGraph.FindGHOST does.
+func (svg *SyncVoteGraph[Hash, Number, voteNode, Vote]) FindGHOST(
+	currentBest *HashNumber[Hash, Number], condition func(voteNode) bool) (*HashNumber[Hash, Number], error) {
+	svg.mtx.RLock()
+	defer svg.mtx.RUnlock()
+	return svg.graph.FindGHOST(currentBest, condition)
+}
+
+// NewGHOSTCache returns a cache of the GHOST of the graph for the condition, as
+// VoteGraph.NewGHOSTCache does.
+func (svg *SyncVoteGraph[Hash, Number, voteNode, Vote]) NewGHOSTCache(
+	condition func(voteNode) bool) *GHOSTCache[Hash, Number, voteNode] {
+	svg.mtx.Lock()
+	defer svg.mtx.Unlock()
+	return svg.graph.NewGHOSTCache(condition)
+}
+
+// FindCachedGHOST returns the GHOST of the graph for the condition of the cache, as
+// VoteGraph.FindCachedGHOST does. It takes the write lock, since the cache is updated.
+func (svg *SyncVoteGraph[Hash, Number, voteNode, Vote]) FindCachedGHOST(
+	cache *GHOSTCache[Hash, Number, voteNode]) (*HashNumber[Hash, Number], error) {
+	svg.mtx.Lock()
+	defer svg.mtx.Unlock()
+	return svg.graph.FindCachedGHOST(cache)
+}
+
+// InvalidateGHOSTCache marks the GHOST of the cache stale, as VoteGraph.InvalidateGHOSTCache does.
+func (svg *SyncVoteGraph[Hash, Number, voteNode, Vote]) InvalidateGHOSTCache(
+	cache *GHOSTCache[Hash, Number, voteNode]) {
+	svg.mtx.Lock()
+	defer svg.mtx.Unlock()
+	svg.graph.InvalidateGHOSTCache(cache)
+}
+
+// FindAncestor finds the highest ancestor of the block fulfilling the condition,
+// as VoteGraph.FindAncestor does.
+func (svg *SyncVoteGraph[Hash, Number, voteNode, Vote]) FindAncestor(
+	hash Hash, number Number, condition func(voteNode) bool) (*HashNumber[Hash, Number], error) {
+	svg.mtx.RLock()
+	defer svg.mtx.RUnlock()
+	return svg.graph.FindAncestor(hash, number, condition)
+}
+
+// CumulativeVoteAt returns the vote accumulated on the block, as VoteGraph.CumulativeVoteAt does.
+func (svg *SyncVoteGraph[Hash, Number, voteNode, Vote]) CumulativeVoteAt(hash Hash, number Number) (
+	voteNode, bool, error) {
+	svg.mtx.RLock()
+	defer svg.mtx.RUnlock()
+	return svg.graph.CumulativeVoteAt(hash, number)
+}
+
+// Base returns the base block.
+func (svg *SyncVoteGraph[Hash, Number, voteNode, Vote]) Base() HashNumber[Hash, Number] {
+	svg.mtx.RLock()
+	defer svg.mtx.RUnlock()
+	return svg.graph.Base()
+}
+
+// Keys returns the hashes of the vote-nodes, as VoteGraph.Keys does.
+func (svg *SyncVoteGraph[Hash, Number, voteNode, Vote]) Keys() []Hash {
+	svg.mtx.RLock()
+	defer svg.mtx.RUnlock()
+	return svg.graph.Keys()
+}
+
+// Heads returns the hashes of the vote-nodes without descendants, as VoteGraph.Heads does.
+func (svg *SyncVoteGraph[Hash, Number, voteNode, Vote]) Heads() []Hash {
+	svg.mtx.RLock()
+	defer svg.mtx.RUnlock()
+	return svg.graph.Heads()
+}
+
+// Stats returns the size statistics of the graph, as VoteGraph.Stats does.
+func (svg *SyncVoteGraph[Hash, Number, voteNode, Vote]) Stats() VoteGraphStats[Number] {
+	svg.mtx.RLock()
+	defer svg.mtx.RUnlock()
+	return svg.graph.Stats()
+}
+
+// Equal returns true if the graph is equal to the other graph, as VoteGraph.Equal does.
+// The other graph is not locked, so it must not be updated concurrently.
+func (svg *SyncVoteGraph[Hash, Number, voteNode, Vote]) Equal(other *VoteGraph[Hash, Number, voteNode, Vote],
+	equal func(a, b voteNode) bool) bool {
+	svg.mtx.RLock()
+	defer svg.mtx.RUnlock()
+	return svg.graph.Equal(other, equal)
+}
+
+// Diff compares the graph with the other graph, as VoteGraph.Diff does. The other graph
+// is not locked, so it must not be updated concurrently.
+func (svg *SyncVoteGraph[Hash, Number, voteNode, Vote]) Diff(
+	other *VoteGraph[Hash, Number, voteNode, Vote], equal func(a, b voteNode) bool) VoteGraphDiff[Hash] {
+	svg.mtx.RLock()
+	defer svg.mtx.RUnlock()
+	return svg.graph.Diff(other, equal)
+}
+
+// Range calls f for each vote-node of the graph, as VoteGraph.Range does.
+func (svg *SyncVoteGraph[Hash, Number, voteNode, Vote]) Range(
+	f func(entry VoteGraphEntry[Hash, Number, voteNode]) bool) {
+	svg.mtx.RLock()
+	defer svg.mtx.RUnlock()
+	svg.graph.Range(f)
+}
+
+// Dot writes the graph in the Graphviz DOT language, as VoteGraph.Dot does.
+func (svg *SyncVoteGraph[Hash, Number, voteNode, Vote]) Dot(w io.Writer, voteLabel func(voteNode) string) error {
+	svg.mtx.RLock()
+	defer svg.mtx.RUnlock()
+	return svg.graph.Dot(w, voteLabel)
+}
+
+// Clone returns a deep copy of the graph, as VoteGraph.Clone does.
+func (svg *SyncVoteGraph[Hash, Number, voteNode, Vote]) Clone() VoteGraph[Hash, Number, voteNode, Vote] {
+	svg.mtx.RLock()
+	defer svg.mtx.RUnlock()
+	return svg.graph.Clone()
+}
+
+// Encode returns the SCALE encoding of the graph, as VoteGraph.Encode does.
+func (svg *SyncVoteGraph[Hash, Number, voteNode, Vote]) Encode() ([]byte, error) {
+	svg.mtx.RLock()
+	defer svg.mtx.RUnlock()
+	return svg.graph.Encode()
+}
@@ -0,0 +1,78 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncVoteGraph(t *testing.T) {
+	c := newDummyChain()
+	c.PushBlocks(GenesisHash, []string{"A", "B", "C", "D", "E"})
+	c.PushBlocks("C", []string{"D2", "E2"})
+	c.PushBlocks("B", []string{"C3"})
+
+	vn := uintVoteNode(0)
+	svg := NewSyncVoteGraph(NewVoteGraph[string, uint, *uintVoteNode, int](
+		GenesisHash, uint(1), &vn, newUintVoteNode))
+
+	votes := []VoteEntry[string, uint]{
+		{Hash: "E", Number: 6, Vote: 1},
+		{Hash: "E2", Number: 6, Vote: 1},
+		{Hash: "C3", Number: 4, Vote: 1},
+		{Hash: "D", Number: 5, Vote: 1},
+	}
+
+	const voters = 8
+	var wg sync.WaitGroup
+	for i := 0; i < voters; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for _, vote := range votes {
+				assert.NoError(t, svg.Insert(vote.Hash, vote.Number, vote.Vote, c))
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for range votes {
+				_, err := svg.FindGHOST(nil, func(x *uintVoteNode) bool { return *x >= voters })
+				assert.NoError(t, err)
+				_, err = svg.FindAncestor("E", 6, func(x *uintVoteNode) bool { return *x >= voters })
+				assert.NoError(t, err)
+				svg.Range(func(VoteGraphEntry[string, uint, *uintVoteNode]) bool { return true })
+				_ = svg.Keys()
+				_ = svg.Heads()
+				_ = svg.Stats()
+			}
+		}()
+	}
+	wg.Wait()
+
+	// the votes of all the voters are inserted
+	ghost, err := svg.FindGHOST(nil, func(x *uintVoteNode) bool { return *x >= 3*voters })
+	require.NoError(t, err)
+	assert.Equal(t, &HashNumber[string, uint]{"C", 4}, ghost)
+	ghost, err = svg.FindGHOST(nil, func(x *uintVoteNode) bool { return *x >= 4*voters })
+	require.NoError(t, err)
+	assert.Equal(t, &HashNumber[string, uint]{"B", 3}, ghost)
+
+	clone := svg.Clone()
+	assert.True(t, svg.Equal(&clone, uintVoteNodesEqual))
+
+	pruned, err := svg.Prune("C", 4)
+	require.NoError(t, err)
+	assert.NotEmpty(t, pruned)
+	assert.Equal(t, HashNumber[string, uint]{"C", 4}, svg.Base())
+	assert.Equal(t, []string{"E", "E2"}, svg.Heads())
+	assert.True(t, svg.Diff(&clone, uintVoteNodesEqual).Base)
+
+	require.NoError(t, svg.Rebase("E", 6))
+	assert.Equal(t, []string{"E"}, svg.Keys())
+	assert.Equal(t, 1, svg.Stats().Entries)
+}
@@ -5,9 +5,14 @@
 
 import (
 	"fmt"
+	"io"
 	"testing"
+	"unsafe"
 
+	"github.com/ChainSafe/gossamer/pkg/scale"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/constraints"
 )
 
 type uintVoteNode uint
@@ -20,6 +25,14 @@ func (uvn *uintVoteNode) AddVote(other int) {
 	*uvn += uintVoteNode(other)
 }
 
+func (uvn *uintVoteNode) Sub(other *uintVoteNode) {
+	*uvn -= *other
+}
+
+func (uvn *uintVoteNode) RemoveVote(other int) {
+	*uvn -= uintVoteNode(other)
+}
+
 func (uvn *uintVoteNode) String() string {
 	return fmt.Sprintf("%+v", *uvn)
 }
@@ -29,6 +42,17 @@ func (uvn *uintVoteNode) Copy() *uintVoteNode {
 	return &copied
 }
 
+func (uvn *uintVoteNode) MarshalSCALE() ([]byte, error) {
+	return scale.Marshal(uint(*uvn))
+}
+
+func (uvn *uintVoteNode) UnmarshalSCALE(reader io.Reader) error {
+	var votes uint
+	err := scale.NewDecoder(reader).Decode(&votes)
+	*uvn = uintVoteNode(votes)
+	return err
+}
+
 func createUintVoteNode(i int) *uintVoteNode {
 	vn := uintVoteNode(i)
 	return &vn
@@ -38,6 +62,24 @@ func newUintVoteNode() *uintVoteNode {
 	return createUintVoteNode(0)
 }
 
+func findGHOST[Hash comparable, Number constraints.Unsigned, voteNode voteNodeI[voteNode, Vote], Vote any](
+	t *testing.T, vg *VoteGraph[Hash, Number, voteNode, Vote], currentBest *HashNumber[Hash, Number],
+	condition func(voteNode) bool) *HashNumber[Hash, Number] {
+	t.Helper()
+	ghost, err := vg.FindGHOST(currentBest, condition)
+	require.NoError(t, err)
+	return ghost
+}
+
+func findAncestor[Hash comparable, Number constraints.Unsigned, voteNode voteNodeI[voteNode, Vote], Vote any](
+	t *testing.T, vg *VoteGraph[Hash, Number, voteNode, Vote], hash Hash, number Number,
+	condition func(voteNode) bool) *HashNumber[Hash, Number] {
+	t.Helper()
+	ancestor, err := vg.FindAncestor(hash, number, condition)
+	require.NoError(t, err)
+	return ancestor
+}
+
 func TestVoteGraph_GraphForkNotAtNode(t *testing.T) {
 	c := newDummyChain()
 	c.PushBlocks(GenesisHash, []string{"A", "B", "C"})
@@ -173,11 +215,12 @@ func TestVoteGraph_GhostMergeAtNode(t *testing.T) {
 	assert.NoError(t, vg.Insert("E1", 6, createUintVoteNode(100), c))
 	assert.NoError(t, vg.Insert("F2", 7, createUintVoteNode(100), c))
 
-	assert.Equal(t, &HashNumber[string, uint]{"C", 4}, vg.FindGHOST(nil, func(i *uintVoteNode) bool { return *i >= 250 }))
 	assert.Equal(t, &HashNumber[string, uint]{"C", 4},
-		vg.FindGHOST(&HashNumber[string, uint]{"C", 4}, func(i *uintVoteNode) bool { return *i >= 250 }))
+		findGHOST(t, &vg, nil, func(i *uintVoteNode) bool { return *i >= 250 }))
+	assert.Equal(t, &HashNumber[string, uint]{"C", 4},
+		findGHOST(t, &vg, &HashNumber[string, uint]{"C", 4}, func(i *uintVoteNode) bool { return *i >= 250 }))
 	assert.Equal(t, &HashNumber[string, uint]{"C", 4},
-		vg.FindGHOST(&HashNumber[string, uint]{"B", 3}, func(i *uintVoteNode) bool { return *i >= 250 }))
+		findGHOST(t, &vg, &HashNumber[string, uint]{"B", 3}, func(i *uintVoteNode) bool { return *i >= 250 }))
 }
 
 func TestVoteGraph_GhostMergeNoteAtNodeOneSideWeighted(t *testing.T) {
@@ -192,13 +235,14 @@ func TestVoteGraph_GhostMergeNoteAtNodeOneSideWeighted(t *testing.T) {
 	assert.NoError(t, vg.Insert("G1", 8, createUintVoteNode(100), c))
 	assert.NoError(t, vg.Insert("H2", 9, createUintVoteNode(150), c))
 
-	assert.Equal(t, &HashNumber[string, uint]{"F", 7}, vg.FindGHOST(nil, func(i *uintVoteNode) bool { return *i >= 250 }))
 	assert.Equal(t, &HashNumber[string, uint]{"F", 7},
-		vg.FindGHOST(&HashNumber[string, uint]{"F", 7}, func(i *uintVoteNode) bool { return *i >= 250 }))
+		findGHOST(t, &vg, nil, func(i *uintVoteNode) bool { return *i >= 250 }))
+	assert.Equal(t, &HashNumber[string, uint]{"F", 7},
+		findGHOST(t, &vg, &HashNumber[string, uint]{"F", 7}, func(i *uintVoteNode) bool { return *i >= 250 }))
 	assert.Equal(t, &HashNumber[string, uint]{"F", 7},
-		vg.FindGHOST(&HashNumber[string, uint]{"C", 4}, func(i *uintVoteNode) bool { return *i >= 250 }))
+		findGHOST(t, &vg, &HashNumber[string, uint]{"C", 4}, func(i *uintVoteNode) bool { return *i >= 250 }))
 	assert.Equal(t, &HashNumber[string, uint]{"F", 7},
-		vg.FindGHOST(&HashNumber[string, uint]{"B", 3}, func(i *uintVoteNode) bool { return *i >= 250 }))
+		findGHOST(t, &vg, &HashNumber[string, uint]{"B", 3}, func(i *uintVoteNode) bool { return *i >= 250 }))
 }
 
 func TestVoteGraph_GhostIntroduceBranch(t *testing.T) {
@@ -217,7 +261,8 @@ func TestVoteGraph_GhostIntroduceBranch(t *testing.T) {
 		return entry
 	}
 
-	assert.Equal(t, &HashNumber[string, uint]{"E", 6}, vg.FindGHOST(nil, func(x *uintVoteNode) bool { return *x >= 10 }))
+	assert.Equal(t, &HashNumber[string, uint]{"E", 6},
+		findGHOST(t, &vg, nil, func(x *uintVoteNode) bool { return *x >= 10 }))
 	assert.Equal(t, []string{"FC", "ED"}, getEntry(GenesisHash).descendants)
 
 	// introduce a branch in the middle.
@@ -228,11 +273,12 @@ func TestVoteGraph_GhostIntroduceBranch(t *testing.T) {
 	assert.Contains(t, getEntry("E").descendants, "ED")
 	assert.Contains(t, getEntry("E").descendants, "FC")
 
-	assert.Equal(t, &HashNumber[string, uint]{"E", 6}, vg.FindGHOST(nil, func(x *uintVoteNode) bool { return *x >= 10 }))
 	assert.Equal(t, &HashNumber[string, uint]{"E", 6},
-		vg.FindGHOST(&HashNumber[string, uint]{"C", 4}, func(x *uintVoteNode) bool { return *x >= 10 }))
+		findGHOST(t, &vg, nil, func(x *uintVoteNode) bool { return *x >= 10 }))
+	assert.Equal(t, &HashNumber[string, uint]{"E", 6},
+		findGHOST(t, &vg, &HashNumber[string, uint]{"C", 4}, func(x *uintVoteNode) bool { return *x >= 10 }))
 	assert.Equal(t, &HashNumber[string, uint]{"E", 6},
-		vg.FindGHOST(&HashNumber[string, uint]{"E", 6}, func(x *uintVoteNode) bool { return *x >= 10 }))
+		findGHOST(t, &vg, &HashNumber[string, uint]{"E", 6}, func(x *uintVoteNode) bool { return *x >= 10 }))
 }
 
 func TestVoteGraph_WalkBackFromBlockInEdgeForkBelow(t *testing.T) {
@@ -250,7 +296,7 @@ func TestVoteGraph_WalkBackFromBlockInEdgeForkBelow(t *testing.T) {
 	for _, block := range []string{"D1", "D2", "E1", "E2", "F1", "F2", "G2"} {
 		number := c.Number(block)
 		assert.Equal(t, &HashNumber[string, uint]{"C", 4},
-			vg.FindAncestor(block, uint(number), func(x *uintVoteNode) bool { return *x > 5 }))
+			findAncestor(t, &vg, block, uint(number), func(x *uintVoteNode) bool { return *x > 5 }))
 	}
 }
 
@@ -267,11 +313,11 @@ func TestVoteGraph_WalkBackFromForkBlockNodeBelow(t *testing.T) {
 	assert.NoError(t, vg.Insert("G2", 8, createUintVoteNode(5), c))
 
 	assert.Equal(t, &HashNumber[string, uint]{"D", 5},
-		vg.FindAncestor("G2", 8, func(x *uintVoteNode) bool { return *x > 5 }))
+		findAncestor(t, &vg, "G2", 8, func(x *uintVoteNode) bool { return *x > 5 }))
 	for _, block := range []string{"E1", "E2", "F1", "F2", "G2"} {
 		number := c.Number(block)
 		assert.Equal(t, &HashNumber[string, uint]{"D", 5},
-			vg.FindAncestor(block, uint(number), func(x *uintVoteNode) bool { return *x > 5 }))
+			findAncestor(t, &vg, block, uint(number), func(x *uintVoteNode) bool { return *x > 5 }))
 	}
 }
 
@@ -291,7 +337,7 @@ func TestVoteGraph_WalkBackAtNode(t *testing.T) {
 	for _, block := range []string{"C", "D1", "D2", "E1", "E2", "F1", "F2", "I1"} {
 		number := c.Number(block)
 		assert.Equal(t, &HashNumber[string, uint]{"C", 4},
-			vg.FindAncestor(block, uint(number), func(x *uintVoteNode) bool { return *x >= 20 }))
+			findAncestor(t, &vg, block, uint(number), func(x *uintVoteNode) bool { return *x >= 20 }))
 	}
 }
 
@@ -308,13 +354,13 @@ func TestVoteGraph_AdjustBase(t *testing.T) {
 
 	assert.Equal(t, HashNumber[string, uint]{"E", 6}, vg.Base())
 
-	vg.AdjustBase([]string{"D", "C", "B", "A"})
+	assert.NoError(t, vg.AdjustBase([]string{"D", "C", "B", "A"}, c))
 
 	assert.Equal(t, HashNumber[string, uint]{"A", 2}, vg.Base())
 
 	c.PushBlocks("A", []string{"3", "4", "5"})
 
-	vg.AdjustBase([]string{GenesisHash})
+	assert.NoError(t, vg.AdjustBase([]string{GenesisHash}, c))
 	assert.Equal(t, HashNumber[string, uint]{GenesisHash, 1}, vg.Base())
 
 	var getEntry = func(key string) voteGraphEntry[string, uint, *uintVoteNode, int] {
@@ -329,6 +375,620 @@ func TestVoteGraph_AdjustBase(t *testing.T) {
 	assert.Equal(t, int(15), int(*getEntry(GenesisHash).cumulativeVote))
 }
 
+func TestVoteGraph_AdjustBase_compaction(t *testing.T) {
+	c := newDummyChain()
+	c.PushBlocks(GenesisHash, []string{"A", "B", "C", "D", "E", "F"})
+	c.PushBlocks("C", []string{"D2", "E2"})
+
+	vn := uintVoteNode(0)
+	vg := NewVoteGraph[string, uint, *uintVoteNode, int]("F", uint(7), &vn, newUintVoteNode)
+	assert.NoError(t, vg.Insert("F", 7, createUintVoteNode(5), c))
+
+	var getEntry = func(key string) voteGraphEntry[string, uint, *uintVoteNode, int] {
+		entry, ok := vg.entries.Get(key)
+		assert.True(t, ok, key)
+		return entry
+	}
+
+	assert.NoError(t, vg.AdjustBase([]string{"E", "D"}, c))
+	assert.NoError(t, vg.AdjustBase([]string{"C", "B"}, c))
+	assert.NoError(t, vg.AdjustBase([]string{"A"}, c))
+
+	// the former bases without votes are merged into the voted initial base
+	assert.Equal(t, []string{"A", "F"}, vg.entries.Keys())
+	assert.Equal(t, []string{"E", "D", "C", "B", "A"}, getEntry("F").ancestors)
+	assert.Equal(t, []string{"F"}, getEntry("A").descendants)
+	assert.Equal(t, createUintVoteNode(5), getEntry("A").cumulativeVote)
+
+	assert.Equal(t, &HashNumber[string, uint]{"F", 7},
+		findGHOST(t, &vg, nil, func(x *uintVoteNode) bool { return *x >= 5 }))
+	assert.Equal(t, &HashNumber[string, uint]{"C", 4},
+		findAncestor(t, &vg, "C", 4, func(x *uintVoteNode) bool { return *x >= 5 }))
+
+	// votes on a merged vote-node introduce it again as a branch
+	assert.NoError(t, vg.Insert("D", 5, createUintVoteNode(2), c))
+	assert.Equal(t, []string{"A", "D", "F"}, vg.entries.Keys())
+	assert.Equal(t, []string{"C", "B", "A"}, getEntry("D").ancestors)
+	assert.Equal(t, []string{"E", "D"}, getEntry("F").ancestors)
+	assert.Equal(t, createUintVoteNode(7), getEntry("D").cumulativeVote)
+
+	assert.NoError(t, vg.Insert("E2", 6, createUintVoteNode(2), c))
+	assert.ElementsMatch(t, []string{"D", "E2"}, getEntry("A").descendants)
+	assert.Equal(t, &HashNumber[string, uint]{"C", 4},
+		findGHOST(t, &vg, nil, func(x *uintVoteNode) bool { return *x >= 9 }))
+}
+
+func TestVoteGraph_AdjustBase_withoutChain(t *testing.T) {
+	c := newDummyChain()
+	c.PushBlocks(GenesisHash, []string{"A", "B", "C", "D", "E"})
+	c.PushBlocks("B", []string{"C2", "D2"})
+
+	vn := uintVoteNode(0)
+	vg := NewVoteGraph[string, uint, *uintVoteNode, int]("E", uint(6), &vn, newUintVoteNode)
+	assert.NoError(t, vg.Insert("E", 6, createUintVoteNode(5), c))
+
+	// the proof is not checked against the chain without chain, so blocks of another
+	// fork are accepted as the ancestry of the base
+	assert.NoError(t, vg.AdjustBase([]string{"D2", "C2"}, nil))
+	assert.Equal(t, HashNumber[string, uint]{"C2", 4}, vg.Base())
+	assert.Equal(t, []string{"D2", "C2"}, getVoteGraphEntry(t, &vg, "E").ancestors)
+	assert.Equal(t, createUintVoteNode(5), getVoteGraphEntry(t, &vg, "C2").cumulativeVote)
+
+	// while the proof is still checked to be consistent
+	err := vg.AdjustBase([]string{"B", "C2"}, nil)
+	assert.ErrorIs(t, err, ErrAncestryProofMismatch)
+	assert.Equal(t, HashNumber[string, uint]{"C2", 4}, vg.Base())
+}
+
+func TestVoteGraph_headIndexes(t *testing.T) {
+	c := newDummyChain()
+	c.PushBlocks(GenesisHash, []string{"A", "B", "C"})
+	c.PushBlocks("C", []string{"D1", "E1"})
+	c.PushBlocks("C", []string{"D2", "E2"})
+
+	vn := uintVoteNode(0)
+	vg := NewVoteGraph[string, uint, *uintVoteNode, int]("A", uint(2), &vn, newUintVoteNode)
+	assert.NoError(t, vg.Insert("E1", 6, createUintVoteNode(1), c))
+	assert.NoError(t, vg.Insert("E2", 6, createUintVoteNode(1), c))
+
+	// the former head base is not indexed anymore
+	assert.Len(t, vg.headIndexes, 2)
+	assert.True(t, vg.headIndexes["E1"].mayContain("C", 4))
+	assert.True(t, vg.headIndexes["E1"].mayContain("D1", 5))
+	assert.False(t, vg.headIndexes["E1"].mayContain("D2", 5))
+	assert.False(t, vg.headIndexes["E1"].mayContain("E1", 6))
+	assert.False(t, vg.headIndexes["E2"].mayContain("D1", 5))
+	assert.Equal(t, []string{"E1"}, vg.findContainingNodes("D1", 5))
+	assert.ElementsMatch(t, []string{"E1", "E2"}, vg.findContainingNodes("C", 4))
+
+	// introducing a branch does not change the ancestry of the heads
+	assert.NoError(t, vg.Insert("C", 4, createUintVoteNode(1), c))
+	assert.Equal(t, []string{"E1"}, vg.findContainingNodes("D1", 5))
+	assert.Equal(t, []string{"C"}, vg.findContainingNodes("B", 3))
+
+	assert.NoError(t, vg.AdjustBase([]string{GenesisHash}, c))
+	assert.True(t, vg.headIndexes["E1"].mayContain(GenesisHash, 1))
+	assert.True(t, vg.headIndexes["E2"].mayContain(GenesisHash, 1))
+}
+
+func TestVoteGraph_Prune(t *testing.T) {
+	c := newDummyChain()
+	c.PushBlocks(GenesisHash, []string{"A", "B", "C", "D", "E"})
+	c.PushBlocks("C", []string{"D2", "E2"})
+	c.PushBlocks("B", []string{"C3"})
+
+	vn := uintVoteNode(0)
+	vg := NewVoteGraph[string, uint, *uintVoteNode, int](GenesisHash, uint(1), &vn, newUintVoteNode)
+	assert.NoError(t, vg.Insert("E", 6, createUintVoteNode(3), c))
+	assert.NoError(t, vg.Insert("E2", 6, createUintVoteNode(2), c))
+	assert.NoError(t, vg.Insert("C3", 4, createUintVoteNode(1), c))
+
+	var getEntry = func(key string) voteGraphEntry[string, uint, *uintVoteNode, int] {
+		entry, ok := vg.entries.Get(key)
+		assert.True(t, ok, key)
+		return entry
+	}
+
+	// the finalized block is introduced as a branch of the vote-nodes containing it
+	pruned, err := vg.Prune("C", 4)
+	assert.NoError(t, err)
+	assert.Equal(t, []PrunedVoteNode[string, uint, *uintVoteNode]{
+		{Hash: GenesisHash, Number: 1, CumulativeVote: createUintVoteNode(6), Voted: true},
+		{Hash: "C3", Number: 4, CumulativeVote: createUintVoteNode(1), Voted: true},
+	}, pruned)
+
+	assert.Equal(t, HashNumber[string, uint]{"C", 4}, vg.Base())
+	assert.Equal(t, []string{"C", "E", "E2"}, vg.entries.Keys())
+	assert.Empty(t, getEntry("C").ancestors)
+	assert.Equal(t, createUintVoteNode(5), getEntry("C").cumulativeVote)
+	assert.Equal(t, []string{"E", "E2"}, vg.heads.Keys())
+	assert.False(t, vg.headIndexes["E"].mayContain("B", 3))
+	assert.True(t, vg.headIndexes["E"].mayContain("D", 5))
+
+	assert.Equal(t, &HashNumber[string, uint]{"E", 6},
+		findGHOST(t, &vg, nil, func(x *uintVoteNode) bool { return *x >= 3 }))
+	assert.Nil(t, findAncestor(t, &vg, "C3", 4, func(x *uintVoteNode) bool { return *x >= 1 }))
+
+	// pruned blocks cannot be finalized
+	_, err = vg.Prune("C3", 4)
+	assert.ErrorIs(t, err, ErrPruneBlockNotInGraph)
+
+	// vote-nodes, including the base, are only finalized at their number
+	_, err = vg.Prune("E", 5)
+	assert.ErrorIs(t, err, ErrPruneBlockNotInGraph)
+	_, err = vg.Prune("C", 3)
+	assert.ErrorIs(t, err, ErrPruneBlockNotInGraph)
+	assert.Equal(t, HashNumber[string, uint]{"C", 4}, vg.Base())
+	assert.Equal(t, []string{"C", "E", "E2"}, vg.entries.Keys())
+
+	// a head finalized becomes the only vote-node
+	pruned, err = vg.Prune("E", 6)
+	assert.NoError(t, err)
+	assert.Equal(t, []PrunedVoteNode[string, uint, *uintVoteNode]{
+		{Hash: "C", Number: 4, CumulativeVote: createUintVoteNode(5)},
+		{Hash: "E2", Number: 6, CumulativeVote: createUintVoteNode(2), Voted: true},
+	}, pruned)
+	assert.Equal(t, []string{"E"}, vg.entries.Keys())
+	assert.Equal(t, []string{"E"}, vg.heads.Keys())
+
+	c.PushBlocks("E", []string{"F"})
+	assert.NoError(t, vg.Insert("F", 7, createUintVoteNode(4), c))
+	assert.Equal(t, createUintVoteNode(7), getEntry("E").cumulativeVote)
+	assert.Equal(t, []string{"F"}, vg.heads.Keys())
+}
+
+func TestVoteGraph_Rebase(t *testing.T) {
+	c := newDummyChain()
+	c.PushBlocks(GenesisHash, []string{"A", "B", "C", "D", "E"})
+	c.PushBlocks("C", []string{"D2", "E2"})
+	c.PushBlocks("B", []string{"C3"})
+
+	vn := uintVoteNode(0)
+	vg := NewVoteGraph[string, uint, *uintVoteNode, int](GenesisHash, uint(1), &vn, newUintVoteNode)
+	assert.NoError(t, vg.Insert("E", 6, createUintVoteNode(3), c))
+	assert.NoError(t, vg.Insert("E2", 6, createUintVoteNode(2), c))
+	assert.NoError(t, vg.Insert("C3", 4, createUintVoteNode(1), c))
+	assert.NoError(t, vg.Insert("C", 4, createUintVoteNode(1), c))
+	entries := vg.entries.Keys()
+
+	// blocks in the ancestor-edge of a vote-node are not rebased on
+	err := vg.Rebase("D", 5)
+	assert.ErrorIs(t, err, ErrRebaseNotVoteNode)
+	err = vg.Rebase("C", 5)
+	assert.ErrorIs(t, err, ErrRebaseNotVoteNode)
+	assert.Equal(t, entries, vg.entries.Keys())
+	assert.Equal(t, HashNumber[string, uint]{GenesisHash, 1}, vg.Base())
+
+	assert.NoError(t, vg.Rebase(GenesisHash, 1))
+	assert.Equal(t, entries, vg.entries.Keys())
+
+	assert.NoError(t, vg.Rebase("C", 4))
+	assert.Equal(t, HashNumber[string, uint]{"C", 4}, vg.Base())
+	assert.Equal(t, []string{"C", "E", "E2"}, vg.entries.Keys())
+	assert.Equal(t, []string{"E", "E2"}, vg.heads.Keys())
+	entry, ok := vg.entries.Get("C")
+	require.True(t, ok)
+	assert.Empty(t, entry.ancestors)
+	assert.Equal(t, createUintVoteNode(6), entry.cumulativeVote)
+
+	assert.Equal(t, &HashNumber[string, uint]{"C", 4},
+		findGHOST(t, &vg, nil, func(x *uintVoteNode) bool { return *x >= 4 }))
+}
+
+func TestVoteGraph_KeysHeads(t *testing.T) {
+	c := newDummyChain()
+	c.PushBlocks(GenesisHash, []string{"A", "B", "C", "D", "E"})
+	c.PushBlocks("C", []string{"D2", "E2"})
+	c.PushBlocks("B", []string{"C3"})
+
+	votes := []VoteEntry[string, uint]{
+		{Hash: "E2", Number: 6, Vote: 1},
+		{Hash: "C3", Number: 4, Vote: 1},
+		{Hash: "E", Number: 6, Vote: 1},
+	}
+
+	// the order only depends on the hashes, not on the order the votes were inserted in
+	for _, order := range [][]int{{0, 1, 2}, {2, 1, 0}, {1, 2, 0}} {
+		vn := uintVoteNode(0)
+		vg := NewVoteGraph[string, uint, *uintVoteNode, int](GenesisHash, uint(1), &vn, newUintVoteNode)
+		for _, i := range order {
+			require.NoError(t, vg.Insert(votes[i].Hash, votes[i].Number, votes[i].Vote, c))
+		}
+		assert.Equal(t, []string{"C3", "E", "E2", GenesisHash}, vg.Keys())
+		assert.Equal(t, []string{"C3", "E", "E2"}, vg.Heads())
+	}
+}
+
+func TestVoteGraph_Stats(t *testing.T) {
+	c := newDummyChain()
+	c.PushBlocks(GenesisHash, []string{"A", "B", "C", "D", "E"})
+	c.PushBlocks("C", []string{"D2", "E2"})
+
+	vn := uintVoteNode(0)
+	vg := NewVoteGraph[string, uint, *uintVoteNode, int](GenesisHash, uint(1), &vn, newUintVoteNode)
+	assert.Equal(t, VoteGraphStats[uint]{Entries: 1, Heads: 1}, vg.Stats())
+
+	assert.NoError(t, vg.Insert("E", 6, createUintVoteNode(3), c))
+	assert.NoError(t, vg.Insert("E2", 6, createUintVoteNode(2), c))
+
+	stats := vg.Stats()
+	assert.Equal(t, 3, stats.Entries)
+	assert.Equal(t, 2, stats.Heads)
+	assert.Equal(t, uint(5), stats.MaxDepth)
+	// E and E2 have ancestor-edges of 5 blocks down to the base
+	assert.Equal(t, 10, stats.AncestorHashes)
+	assert.GreaterOrEqual(t, stats.AncestorBytes, uint64(10*unsafe.Sizeof("")))
+
+	// the depth is relative to the base
+	assert.NoError(t, vg.Insert("C", 4, createUintVoteNode(1), c))
+	assert.NoError(t, vg.Rebase("C", 4))
+	stats = vg.Stats()
+	assert.Equal(t, 3, stats.Entries)
+	assert.Equal(t, uint(2), stats.MaxDepth)
+	assert.Equal(t, 4, stats.AncestorHashes)
+}
+
+func TestVoteGraph_Remove(t *testing.T) {
+	c := newDummyChain()
+	c.PushBlocks(GenesisHash, []string{"A", "B", "C", "D", "E"})
+	c.PushBlocks("C", []string{"D2", "E2"})
+
+	vn := uintVoteNode(0)
+	vg := NewVoteGraph[string, uint, *uintVoteNode, int](GenesisHash, uint(1), &vn, newUintVoteNode)
+	assert.NoError(t, vg.Insert("E", 6, createUintVoteNode(3), c))
+	assert.NoError(t, vg.Insert("E2", 6, 2, c))
+	assert.NoError(t, vg.Insert("E2", 6, 2, c))
+
+	var cumulativeVote = func(key string) *uintVoteNode {
+		entry, ok := vg.entries.Get(key)
+		require.True(t, ok, key)
+		return entry.cumulativeVote
+	}
+	assert.Equal(t, createUintVoteNode(7), cumulativeVote(GenesisHash))
+	assert.Equal(t, &HashNumber[string, uint]{"E2", 6},
+		findGHOST(t, &vg, nil, func(x *uintVoteNode) bool { return *x >= 4 }))
+
+	// the vote is subtracted from the vote-node and its ancestor vote-nodes
+	assert.NoError(t, vg.Remove("E2", 6, 2))
+	assert.Equal(t, createUintVoteNode(2), cumulativeVote("E2"))
+	assert.Equal(t, createUintVoteNode(5), cumulativeVote(GenesisHash))
+	assert.Equal(t, &HashNumber[string, uint]{"C", 4},
+		findGHOST(t, &vg, nil, func(x *uintVoteNode) bool { return *x >= 4 }))
+
+	assert.NoError(t, vg.Remove("E", 6, createUintVoteNode(3)))
+	assert.Equal(t, createUintVoteNode(0), cumulativeVote("E"))
+	assert.Equal(t, createUintVoteNode(2), cumulativeVote(GenesisHash))
+	assert.Equal(t, []string{"E", "E2"}, vg.heads.Keys())
+
+	// only the votes on vote-nodes can be removed
+	err := vg.Remove("D", 5, 1)
+	assert.ErrorIs(t, err, ErrVoteNotInGraph)
+	err = vg.Remove("E2", 5, 1)
+	assert.ErrorIs(t, err, ErrVoteNotInGraph)
+	err = vg.Remove("E2", 6, "1")
+	assert.ErrorIs(t, err, ErrUnsupportedVote)
+	assert.Equal(t, createUintVoteNode(2), cumulativeVote("E2"))
+}
+
+func TestVoteGraph_Remove_compaction(t *testing.T) {
+	c := newDummyChain()
+	c.PushBlocks(GenesisHash, []string{"A", "B", "C", "D", "E"})
+	c.PushBlocks("C", []string{"D2", "E2"})
+
+	vn := uintVoteNode(0)
+	vg := NewVoteGraph[string, uint, *uintVoteNode, int](GenesisHash, uint(1), &vn, newUintVoteNode)
+	assert.NoError(t, vg.Insert("C", 4, 1, c))
+	assert.NoError(t, vg.Insert("C", 4, 1, c))
+	assert.NoError(t, vg.Insert("E", 6, 2, c))
+	assert.Equal(t, []string{"C", "E", GenesisHash}, vg.entries.Keys())
+
+	// the vote-node is kept while it has votes of its own
+	assert.NoError(t, vg.Remove("C", 4, 1))
+	assert.Equal(t, []string{"C", "E", GenesisHash}, vg.entries.Keys())
+
+	// and merged into its single descendant once its last vote is removed
+	assert.NoError(t, vg.Remove("C", 4, 1))
+	assert.Equal(t, []string{"E", GenesisHash}, vg.entries.Keys())
+	assert.Equal(t, []string{"D", "C", "B", "A", GenesisHash}, getVoteGraphEntry(t, &vg, "E").ancestors)
+	assert.Equal(t, []string{"E"}, getVoteGraphEntry(t, &vg, GenesisHash).descendants)
+	assert.Equal(t, createUintVoteNode(2), getVoteGraphEntry(t, &vg, GenesisHash).cumulativeVote)
+
+	err := vg.Remove("C", 4, 1)
+	assert.ErrorIs(t, err, ErrVoteNotInGraph)
+
+	// a vote-node without votes is kept while it is a fork point
+	assert.NoError(t, vg.Insert("C", 4, 1, c))
+	assert.NoError(t, vg.Insert("E2", 6, 3, c))
+	assert.NoError(t, vg.Remove("C", 4, 1))
+	assert.Equal(t, []string{"C", "E", "E2", GenesisHash}, vg.entries.Keys())
+	assert.Equal(t, &HashNumber[string, uint]{"C", 4},
+		findGHOST(t, &vg, nil, func(x *uintVoteNode) bool { return *x >= 5 }))
+
+	// a vote-node without votes of its own cannot have votes removed
+	err = vg.Remove("C", 4, 1)
+	assert.ErrorIs(t, err, ErrVoteNotInGraph)
+}
+
+// countingChain is a chain counting the ancestry lookups made on it.
+type countingChain struct {
+	*dummyChain
+	ancestryCalls int
+	// walked are the numbers of blocks walked by the ancestry calls.
+	walked int
+}
+
+func (cc *countingChain) Ancestry(base, block string) ([]string, error) {
+	cc.ancestryCalls++
+	ancestry, err := cc.dummyChain.Ancestry(base, block)
+	cc.walked += len(ancestry)
+	return ancestry, err
+}
+
+func TestVoteGraph_InsertBatch(t *testing.T) {
+	c := newDummyChain()
+	c.PushBlocks(GenesisHash, []string{"A", "B", "C", "D", "E"})
+	c.PushBlocks("C", []string{"D2", "E2"})
+	c.PushBlocks("B", []string{"C3"})
+
+	votes := []VoteEntry[string, uint]{
+		{Hash: "E", Number: 6, Vote: 1},
+		{Hash: "E2", Number: 6, Vote: 2},
+		{Hash: "E", Number: 6, Vote: createUintVoteNode(3)},
+		{Hash: "C", Number: 4, Vote: 4},
+		{Hash: "C3", Number: 4, Vote: 5},
+		{Hash: "E2", Number: 6, Vote: 6},
+	}
+
+	vn := uintVoteNode(0)
+	expected := NewVoteGraph[string, uint, *uintVoteNode, int](GenesisHash, uint(1), &vn, newUintVoteNode)
+	for _, vote := range votes {
+		require.NoError(t, expected.Insert(vote.Hash, vote.Number, vote.Vote, c))
+	}
+
+	chain := &countingChain{dummyChain: c}
+	vn = uintVoteNode(0)
+	vg := NewVoteGraph[string, uint, *uintVoteNode, int](GenesisHash, uint(1), &vn, newUintVoteNode)
+	require.NoError(t, vg.InsertBatch(votes, chain))
+
+	// the ancestry of each block is looked up once, when appended to the graph
+	assert.Equal(t, 3, chain.ancestryCalls)
+	assert.Equal(t, expected.entries.Keys(), vg.entries.Keys())
+	expected.entries.Scan(func(hash string, entry voteGraphEntry[string, uint, *uintVoteNode, int]) bool {
+		assert.Equal(t, entry, getVoteGraphEntry(t, &vg, hash), hash)
+		return true
+	})
+	assert.Equal(t, expected.heads.Keys(), vg.heads.Keys())
+	assert.Equal(t, createUintVoteNode(21), getVoteGraphEntry(t, &vg, GenesisHash).cumulativeVote)
+
+	// no vote is inserted if one has an unsupported type
+	err := vg.InsertBatch([]VoteEntry[string, uint]{
+		{Hash: "E", Number: 6, Vote: 1},
+		{Hash: "E", Number: 6, Vote: "1"},
+	}, chain)
+	assert.ErrorIs(t, err, ErrUnsupportedVote)
+	assert.Equal(t, createUintVoteNode(21), getVoteGraphEntry(t, &vg, GenesisHash).cumulativeVote)
+}
+
+func getVoteGraphEntry(t *testing.T, vg *VoteGraph[string, uint, *uintVoteNode, int],
+	hash string) voteGraphEntry[string, uint, *uintVoteNode, int] {
+	t.Helper()
+	entry, ok := vg.entries.Get(hash)
+	require.True(t, ok, hash)
+	return entry
+}
+
+// ancestryChain is a chain returning the same ancestry for every block.
+type ancestryChain []string
+
+func (ac ancestryChain) Ancestry(string, string) ([]string, error) { return ac, nil }
+
+func (ancestryChain) IsEqualOrDescendantOf(string, string) bool { return true }
+
+func TestVoteGraph_Range(t *testing.T) {
+	c := newDummyChain()
+	c.PushBlocks(GenesisHash, []string{"A", "B", "C", "D", "E"})
+	c.PushBlocks("C", []string{"D2", "E2"})
+
+	vn := uintVoteNode(0)
+	vg := NewVoteGraph[string, uint, *uintVoteNode, int](GenesisHash, uint(1), &vn, newUintVoteNode)
+	assert.NoError(t, vg.Insert("E", 6, createUintVoteNode(3), c))
+	assert.NoError(t, vg.Insert("E2", 6, createUintVoteNode(2), c))
+	assert.NoError(t, vg.Insert("C", 4, createUintVoteNode(1), c))
+
+	var entries []VoteGraphEntry[string, uint, *uintVoteNode]
+	vg.Range(func(entry VoteGraphEntry[string, uint, *uintVoteNode]) bool {
+		entries = append(entries, entry)
+		return true
+	})
+	assert.Equal(t, []VoteGraphEntry[string, uint, *uintVoteNode]{
+		{Hash: "C", Number: 4, CumulativeVote: createUintVoteNode(6), Descendants: []string{"E", "E2"}, Voted: true},
+		{Hash: "E", Number: 6, CumulativeVote: createUintVoteNode(3), Descendants: []string{}, Voted: true},
+		{Hash: "E2", Number: 6, CumulativeVote: createUintVoteNode(2), Descendants: []string{}, Voted: true},
+		{Hash: GenesisHash, Number: 1, CumulativeVote: createUintVoteNode(6), Descendants: []string{"C"}, Voted: true},
+	}, entries)
+
+	// modifying the entries does not change the graph
+	*entries[0].CumulativeVote = 0
+	entries[0].Descendants[0] = "D"
+	assert.Equal(t, createUintVoteNode(6), getVoteGraphEntry(t, &vg, "C").cumulativeVote)
+	assert.Equal(t, []string{"E", "E2"}, getVoteGraphEntry(t, &vg, "C").descendants)
+
+	// the iteration stops when the callback returns false
+	var hashes []string
+	vg.Range(func(entry VoteGraphEntry[string, uint, *uintVoteNode]) bool {
+		hashes = append(hashes, entry.Hash)
+		return len(hashes) < 2
+	})
+	assert.Equal(t, []string{"C", "E"}, hashes)
+}
+
+func TestVoteGraph_CumulativeVoteAt(t *testing.T) {
+	c := newDummyChain()
+	c.PushBlocks(GenesisHash, []string{"A", "B", "C", "D", "E"})
+	c.PushBlocks("C", []string{"D2", "E2"})
+	c.PushBlocks("B", []string{"C3"})
+
+	vn := uintVoteNode(0)
+	vg := NewVoteGraph[string, uint, *uintVoteNode, int](GenesisHash, uint(1), &vn, newUintVoteNode)
+	assert.NoError(t, vg.Insert("E", 6, createUintVoteNode(3), c))
+	assert.NoError(t, vg.Insert("E2", 6, createUintVoteNode(2), c))
+	assert.NoError(t, vg.Insert("C3", 4, createUintVoteNode(1), c))
+
+	tests := map[string]struct {
+		hash   string
+		number uint
+		vote   *uintVoteNode
+		ok     bool
+	}{
+		"vote-node":                 {hash: "E", number: 6, vote: createUintVoteNode(3), ok: true},
+		"base":                      {hash: GenesisHash, number: 1, vote: createUintVoteNode(6), ok: true},
+		"in_one_ancestor-edge":      {hash: "D", number: 5, vote: createUintVoteNode(3), ok: true},
+		"in_two_ancestor-edges":     {hash: "C", number: 4, vote: createUintVoteNode(5), ok: true},
+		"in_three_ancestor-edges":   {hash: "B", number: 3, vote: createUintVoteNode(6), ok: true},
+		"vote-node_at_other_number": {hash: "E", number: 5},
+		"block_at_other_number":     {hash: "D", number: 4},
+		"unknown_block":             {hash: "F", number: 7},
+	}
+
+	for name, testCase := range tests {
+		t.Run(name, func(t *testing.T) {
+			vote, ok, err := vg.CumulativeVoteAt(testCase.hash, testCase.number)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.ok, ok)
+			assert.Equal(t, testCase.vote, vote)
+		})
+	}
+
+	// the vote returned is a copy
+	vote, ok, err := vg.CumulativeVoteAt("E", 6)
+	require.NoError(t, err)
+	require.True(t, ok)
+	*vote = 0
+	assert.Equal(t, createUintVoteNode(3), getVoteGraphEntry(t, &vg, "E").cumulativeVote)
+}
+
+func TestVoteGraph_errors(t *testing.T) {
+	t.Run("ancestry_without_vote-node", func(t *testing.T) {
+		vn := uintVoteNode(0)
+		vg := NewVoteGraph[string, uint, *uintVoteNode, int](GenesisHash, uint(1), &vn, newUintVoteNode)
+		// the ancestry skips a block, so it ends at the wrong number
+		err := vg.Insert("C", 4, createUintVoteNode(1), ancestryChain{"B"})
+		assert.ErrorIs(t, err, ErrInvalidAncestry)
+		assert.Equal(t, []string{GenesisHash}, vg.entries.Keys())
+		assert.Equal(t, &HashNumber[string, uint]{GenesisHash, 1},
+			findGHOST(t, &vg, nil, func(x *uintVoteNode) bool { return *x >= 0 }))
+	})
+
+	t.Run("unsupported_vote", func(t *testing.T) {
+		c := newDummyChain()
+		c.PushBlocks(GenesisHash, []string{"A"})
+		vn := uintVoteNode(0)
+		vg := NewVoteGraph[string, uint, *uintVoteNode, int](GenesisHash, uint(1), &vn, newUintVoteNode)
+		err := vg.Insert("A", 2, "vote", c)
+		assert.ErrorIs(t, err, ErrUnsupportedVote)
+		assert.Equal(t, []string{GenesisHash}, vg.entries.Keys())
+	})
+
+	t.Run("missing_entry", func(t *testing.T) {
+		c := newDummyChain()
+		c.PushBlocks(GenesisHash, []string{"A", "B", "C"})
+		c.PushBlocks("A", []string{"B2"})
+		vn := uintVoteNode(0)
+		vg := NewVoteGraph[string, uint, *uintVoteNode, int](GenesisHash, uint(1), &vn, newUintVoteNode)
+		require.NoError(t, vg.Insert("C", 4, createUintVoteNode(1), c))
+		require.NoError(t, vg.Insert("B2", 3, createUintVoteNode(1), c))
+		require.NoError(t, vg.Insert("A", 2, createUintVoteNode(1), c))
+
+		// corrupt the graph by removing a vote-node still referenced
+		vg.entries.Delete("A")
+
+		_, err := vg.FindGHOST(nil, func(x *uintVoteNode) bool { return *x >= 1 })
+		assert.ErrorIs(t, err, ErrMissingEntry)
+
+		// the votes are not accumulated on any vote-node
+		err = vg.Insert("C", 4, createUintVoteNode(1), c)
+		assert.ErrorIs(t, err, ErrMissingEntry)
+		entry, _ := vg.entries.Get("C")
+		assert.Equal(t, createUintVoteNode(1), entry.cumulativeVote)
+	})
+}
+
+func TestVoteGraph_AdjustBase_invalidProof(t *testing.T) {
+	c := newDummyChain()
+	c.PushBlocks(GenesisHash, []string{"A", "B", "C", "D", "E"})
+	c.PushBlocks("A", []string{"B2", "C2"})
+
+	testCases := map[string]struct {
+		base          string
+		baseNumber    uint
+		ancestryProof []string
+		noChain       bool
+		errWrapped    error
+	}{
+		"empty_proof": {
+			base:       "E",
+			baseNumber: 6,
+			errWrapped: ErrEmptyAncestryProof,
+		},
+		"proof_longer_than_base_number": {
+			base:          "B",
+			baseNumber:    3,
+			ancestryProof: []string{"A", GenesisHash, "X", "Y"},
+			errWrapped:    ErrAncestryProofTooLong,
+		},
+		"base_number_zero": {
+			base:          GenesisHash,
+			baseNumber:    0,
+			ancestryProof: []string{"X"},
+			errWrapped:    ErrAncestryProofTooLong,
+		},
+		"new_base_not_ancestor": {
+			base:          "E",
+			baseNumber:    6,
+			ancestryProof: []string{"D", "C2"},
+			errWrapped:    ErrAncestryProofMismatch,
+		},
+		"gap_in_proof": {
+			base:          "E",
+			baseNumber:    6,
+			ancestryProof: []string{"D", "B2", "B"},
+			errWrapped:    ErrAncestryProofMismatch,
+		},
+		"base_in_proof_without_chain": {
+			base:          "E",
+			baseNumber:    6,
+			ancestryProof: []string{"D", "E"},
+			noChain:       true,
+			errWrapped:    ErrAncestryProofMismatch,
+		},
+		"repeated_block_without_chain": {
+			base:          "E",
+			baseNumber:    6,
+			ancestryProof: []string{"D", "C", "D"},
+			noChain:       true,
+			errWrapped:    ErrAncestryProofMismatch,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			vn := uintVoteNode(0)
+			vg := NewVoteGraph[string, uint, *uintVoteNode, int](
+				testCase.base, testCase.baseNumber, &vn, newUintVoteNode)
+
+			var chain Chain[string, uint] = c
+			if testCase.noChain {
+				chain = nil
+			}
+			err := vg.AdjustBase(testCase.ancestryProof, chain)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			assert.Equal(t, HashNumber[string, uint]{testCase.base, testCase.baseNumber}, vg.Base())
+			assert.Equal(t, 1, vg.entries.Len())
+		})
+	}
+}
+
 func TestVoteGraph_FindAncestorIsLargest(t *testing.T) {
 	c := newDummyChain()
 	c.PushBlocks(GenesisHash, []string{"A"})
@@ -346,5 +1006,71 @@ func TestVoteGraph_FindAncestorIsLargest(t *testing.T) {
 	assert.NoError(t, vg.Insert("A2", 2, createUintVoteNode(1), c))
 
 	assert.Equal(t, &HashNumber[string, uint]{"A", 1},
-		vg.FindAncestor("A", 1, func(x *uintVoteNode) bool { return *x >= 2 }))
+		findAncestor(t, &vg, "A", 1, func(x *uintVoteNode) bool { return *x >= 2 }))
+}
+
+func TestVoteGraph_FindGHOSTLongRange(t *testing.T) {
+	const length = 5000
+
+	c := newDummyChain()
+	blocks := make([]string, length)
+	for i := range blocks {
+		blocks[i] = fmt.Sprintf("A%d", i)
+	}
+	c.PushBlocks(GenesisHash, blocks)
+	c.PushBlocks(blocks[length-1], []string{"B", "C"})
+	c.PushBlocks(blocks[length-1], []string{"B2", "C2"})
+
+	vn := uintVoteNode(0)
+	vg := NewVoteGraph[string, uint, *uintVoteNode, int](GenesisHash, uint(1), &vn, newUintVoteNode)
+	require.NoError(t, vg.Insert("C", length+3, 3, c))
+	require.NoError(t, vg.Insert("C2", length+3, 2, c))
+
+	// the descendants of the base merge at the last common block, thousands of blocks above it
+	ghost, err := vg.FindGHOST(nil, func(x *uintVoteNode) bool { return *x >= 5 })
+	require.NoError(t, err)
+	assert.Equal(t, &HashNumber[string, uint]{blocks[length-1], length + 1}, ghost)
+
+	ghost, err = vg.FindGHOST(&HashNumber[string, uint]{"A100", 102}, func(x *uintVoteNode) bool { return *x >= 5 })
+	require.NoError(t, err)
+	assert.Equal(t, &HashNumber[string, uint]{blocks[length-1], length + 1}, ghost)
+
+	// merging the blocks does not change the votes of the vote-nodes
+	cumulativeVote, _, err := vg.CumulativeVoteAt("C", length+3)
+	require.NoError(t, err)
+	assert.Equal(t, uintVoteNode(3), *cumulativeVote)
+}
+
+func TestVoteGraph_Clone(t *testing.T) {
+	c := newDummyChain()
+	c.PushBlocks(GenesisHash, []string{"A", "B", "C", "D", "E"})
+	c.PushBlocks("C", []string{"D2", "E2"})
+
+	vn := uintVoteNode(0)
+	vg := NewVoteGraph[string, uint, *uintVoteNode, int](GenesisHash, uint(1), &vn, newUintVoteNode)
+	require.NoError(t, vg.Insert("E", 6, 2, c))
+	require.NoError(t, vg.Insert("E2", 6, 1, c))
+	encoded, err := vg.Encode()
+	require.NoError(t, err)
+
+	clone := vg.Clone()
+	cloneEncoded, err := clone.Encode()
+	require.NoError(t, err)
+	assert.Equal(t, encoded, cloneEncoded)
+
+	// mutating the clone leaves the graph unchanged
+	require.NoError(t, clone.Insert("E", 6, 1, c))
+	require.NoError(t, clone.Remove("E2", 6, 1))
+	_, err = clone.Prune("C", 4)
+	require.NoError(t, err)
+
+	afterEncoded, err := vg.Encode()
+	require.NoError(t, err)
+	assert.Equal(t, encoded, afterEncoded)
+	ghost, err := vg.FindGHOST(nil, func(x *uintVoteNode) bool { return *x >= 3 })
+	require.NoError(t, err)
+	assert.Equal(t, &HashNumber[string, uint]{"C", 4}, ghost)
+	ghost, err = clone.FindGHOST(nil, func(x *uintVoteNode) bool { return *x >= 3 })
+	require.NoError(t, err)
+	assert.Equal(t, &HashNumber[string, uint]{"E", 6}, ghost)
 }
@@ -0,0 +1,160 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"cmp"
+	"math"
+	"math/big"
+	"math/bits"
+)
+
+// Weight is the cumulative vote weight kept by a WeightNode of the vote graph.
+// Implementations must not modify the receiver or the argument.
+type Weight[W any] interface {
+	// Add returns the sum of the weight and other.
+	Add(other W) W
+	// Sub returns the weight minus other, which is at most the weight.
+	Sub(other W) W
+	// Cmp returns -1, 0 or +1 if the weight is less than, equal to or greater
+	// than other.
+	Cmp(other W) int
+}
+
+// WeightNode is a vote-node of the vote graph accumulating plain weights, to use
+// the graph for votes weighted by something else than the voter set, such as
+// token-weighted votes.
+type WeightNode[W Weight[W]] struct {
+	Weight W
+}
+
+// NewWeightNode returns a vote-node with the given weight.
+func NewWeightNode[W Weight[W]](weight W) *WeightNode[W] {
+	return &WeightNode[W]{Weight: weight}
+}
+
+// Add adds the weight of other to the node.
+func (wn *WeightNode[W]) Add(other *WeightNode[W]) {
+	wn.Weight = wn.Weight.Add(other.Weight)
+}
+
+// AddVote adds the weight of a single vote to the node.
+func (wn *WeightNode[W]) AddVote(weight W) {
+	wn.Weight = wn.Weight.Add(weight)
+}
+
+// Sub subtracts the weight of other from the node.
+func (wn *WeightNode[W]) Sub(other *WeightNode[W]) {
+	wn.Weight = wn.Weight.Sub(other.Weight)
+}
+
+// RemoveVote subtracts the weight of a single vote from the node.
+func (wn *WeightNode[W]) RemoveVote(weight W) {
+	wn.Weight = wn.Weight.Sub(weight)
+}
+
+// Copy returns a copy of the node.
+func (wn *WeightNode[W]) Copy() *WeightNode[W] {
+	return &WeightNode[W]{Weight: wn.Weight}
+}
+
+// WeightNodesEqual returns true if the nodes have equal weights, as compared by Cmp,
+// such as to compare vote graphs with VoteGraph.Diff.
+func WeightNodesEqual[W Weight[W]](a, b *WeightNode[W]) bool {
+	return a.Weight.Cmp(b.Weight) == 0
+}
+
+// WeightAtLeast returns a condition for FindGHOST and FindAncestor which is
+// true for the nodes with a weight greater than or equal to the threshold.
+func WeightAtLeast[W Weight[W]](threshold W) func(*WeightNode[W]) bool {
+	return func(wn *WeightNode[W]) bool {
+		return wn.Weight.Cmp(threshold) >= 0
+	}
+}
+
+// U64Weight is a Weight summing uint64 weights. The carry of the sums exceeding the
+// uint64 range is tracked, so that the sum is restored exactly when a weight is
+// removed after an overflow. The zero value is a weight of zero.
+type U64Weight struct {
+	carry uint64
+	value uint64
+}
+
+// NewU64Weight returns a weight with the given value.
+func NewU64Weight(value uint64) U64Weight {
+	return U64Weight{value: value}
+}
+
+// Uint64 returns the weight value, and true if the weight exceeds the uint64 range
+// in which case the value is math.MaxUint64.
+func (w U64Weight) Uint64() (value uint64, overflowed bool) {
+	if w.carry > 0 {
+		return math.MaxUint64, true
+	}
+	return w.value, false
+}
+
+// Add returns the sum of the weights.
+func (w U64Weight) Add(other U64Weight) U64Weight {
+	value, carry := bits.Add64(w.value, other.value, 0)
+	carry, _ = bits.Add64(w.carry, other.carry, carry)
+	return U64Weight{carry: carry, value: value}
+}
+
+// Sub returns the difference of the weights, saturating at zero.
+func (w U64Weight) Sub(other U64Weight) U64Weight {
+	if w.Cmp(other) < 0 {
+		return U64Weight{}
+	}
+	value, borrow := bits.Sub64(w.value, other.value, 0)
+	carry, _ := bits.Sub64(w.carry, other.carry, borrow)
+	return U64Weight{carry: carry, value: value}
+}
+
+// Cmp compares the weights.
+func (w U64Weight) Cmp(other U64Weight) int {
+	if w.carry != other.carry {
+		return cmp.Compare(w.carry, other.carry)
+	}
+	return cmp.Compare(w.value, other.value)
+}
+
+// BigWeight is a Weight backed by a big.Int which cannot overflow.
+// The zero value is a weight of zero.
+type BigWeight struct {
+	value *big.Int
+}
+
+// NewBigWeight returns a weight with a copy of the given value.
+func NewBigWeight(value *big.Int) BigWeight {
+	return BigWeight{value: new(big.Int).Set(value)}
+}
+
+// Int returns a copy of the weight value.
+func (w BigWeight) Int() *big.Int {
+	if w.value == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(w.value)
+}
+
+// Add returns the sum of the weights.
+func (w BigWeight) Add(other BigWeight) BigWeight {
+	return BigWeight{value: new(big.Int).Add(w.Int(), other.Int())}
+}
+
+// Sub returns the difference of the weights.
+func (w BigWeight) Sub(other BigWeight) BigWeight {
+	return BigWeight{value: new(big.Int).Sub(w.Int(), other.Int())}
+}
+
+// Cmp compares the weights.
+func (w BigWeight) Cmp(other BigWeight) int {
+	return w.Int().Cmp(other.Int())
+}
+
+// String returns the decimal representation of the weight.
+func (w BigWeight) String() string {
+	return w.Int().String()
+}
@@ -0,0 +1,113 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestU64Weight_Add(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, NewU64Weight(5), NewU64Weight(2).Add(NewU64Weight(3)))
+
+	maxWeight := NewU64Weight(math.MaxUint64)
+	value, overflowed := NewU64Weight(math.MaxUint64 - 1).Add(NewU64Weight(1)).Uint64()
+	assert.Equal(t, uint64(math.MaxUint64), value)
+	assert.False(t, overflowed)
+
+	sum := maxWeight.Add(maxWeight)
+	value, overflowed = sum.Uint64()
+	assert.Equal(t, uint64(math.MaxUint64), value)
+	assert.True(t, overflowed)
+	assert.Equal(t, 1, sum.Cmp(maxWeight))
+	assert.Equal(t, -1, maxWeight.Cmp(sum))
+}
+
+func TestU64Weight_Sub(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, NewU64Weight(2), NewU64Weight(5).Sub(NewU64Weight(3)))
+	assert.Equal(t, NewU64Weight(0), NewU64Weight(3).Sub(NewU64Weight(5)))
+
+	// the sum is restored exactly after an overflow
+	maxWeight := NewU64Weight(math.MaxUint64)
+	sum := maxWeight.Add(NewU64Weight(10))
+	assert.Equal(t, maxWeight, sum.Sub(NewU64Weight(10)))
+	assert.Equal(t, NewU64Weight(10), sum.Sub(maxWeight))
+	assert.Equal(t, NewU64Weight(0), sum.Sub(sum))
+}
+
+func TestBigWeight(t *testing.T) {
+	t.Parallel()
+
+	var zero BigWeight
+	assert.Equal(t, "0", zero.String())
+
+	maxWeight := NewBigWeight(new(big.Int).SetUint64(math.MaxUint64))
+	sum := maxWeight.Add(maxWeight)
+
+	expected, ok := new(big.Int).SetString("36893488147419103230", 10)
+	require.True(t, ok)
+	assert.Equal(t, expected, sum.Int())
+	assert.Equal(t, 1, sum.Cmp(maxWeight))
+	assert.Equal(t, -1, zero.Cmp(maxWeight))
+	assert.Equal(t, 0, maxWeight.Cmp(NewBigWeight(new(big.Int).SetUint64(math.MaxUint64))))
+	assert.Equal(t, 0, sum.Sub(maxWeight).Cmp(maxWeight))
+	// the operands are left unchanged
+	assert.Equal(t, new(big.Int).SetUint64(math.MaxUint64), maxWeight.Int())
+}
+
+func TestVoteGraph_WeightNode(t *testing.T) {
+	c := newDummyChain()
+	c.PushBlocks(GenesisHash, []string{"A", "B", "C"})
+	c.PushBlocks("C", []string{"D1", "E1", "F1"})
+	c.PushBlocks("C", []string{"D2", "E2", "F2"})
+
+	t.Run("u64", func(t *testing.T) {
+		newNode := func() *WeightNode[U64Weight] {
+			return NewWeightNode(U64Weight{})
+		}
+		vg := NewVoteGraph[string, uint, *WeightNode[U64Weight], U64Weight](
+			GenesisHash, 1, newNode(), newNode)
+		maxWeight := NewU64Weight(math.MaxUint64)
+		require.NoError(t, vg.Insert("B", 3, maxWeight, c))
+		require.NoError(t, vg.Insert("F1", 7, NewWeightNode(NewU64Weight(10)), c))
+
+		// the sum exceeds the uint64 range instead of wrapping around
+		assert.Equal(t, &HashNumber[string, uint]{"B", 3},
+			findGHOST(t, &vg, nil, WeightAtLeast(maxWeight.Add(NewU64Weight(1)))))
+
+		// removing the vote after the overflow restores the exact sum
+		require.NoError(t, vg.Remove("F1", 7, NewU64Weight(10)))
+		assert.Nil(t, findGHOST(t, &vg, nil, WeightAtLeast(maxWeight.Add(NewU64Weight(1)))))
+		assert.Equal(t, &HashNumber[string, uint]{"B", 3}, findGHOST(t, &vg, nil, WeightAtLeast(maxWeight)))
+	})
+
+	t.Run("big_int", func(t *testing.T) {
+		newNode := func() *WeightNode[BigWeight] {
+			return NewWeightNode(BigWeight{})
+		}
+		vg := NewVoteGraph[string, uint, *WeightNode[BigWeight], BigWeight](
+			GenesisHash, 1, newNode(), newNode)
+
+		maxWeight := NewBigWeight(new(big.Int).SetUint64(math.MaxUint64))
+		require.NoError(t, vg.Insert("E1", 6, maxWeight, c))
+		require.NoError(t, vg.Insert("F1", 7, maxWeight, c))
+		require.NoError(t, vg.Insert("F2", 7, maxWeight, c))
+
+		// the weights on top of C exceed the uint64 range
+		threshold := maxWeight.Add(maxWeight).Add(maxWeight)
+		assert.Equal(t, &HashNumber[string, uint]{"C", 4}, findGHOST(t, &vg, nil, WeightAtLeast(threshold)))
+		assert.Equal(t, &HashNumber[string, uint]{"E1", 6},
+			findGHOST(t, &vg, nil, WeightAtLeast(maxWeight.Add(maxWeight))))
+		assert.Equal(t, &HashNumber[string, uint]{"C", 4},
+			findAncestor(t, &vg, "F2", 7, WeightAtLeast(threshold)))
+	})
+}
@@ -273,7 +273,7 @@ func (b *buffered[I]) Close() {
 //
 // may only be called with non-zero last round.
 func instantiateLastRound[
-	Hash constraints.Ordered,
+	Hash comparable,
 	Number constraints.Unsigned,
 	Signature comparable,
 	ID constraints.Ordered,
@@ -314,7 +314,7 @@ func instantiateLastRound[
 // (i.e. best and background rounds). This state exists separately since it's
 // useful for sharing.
 type innerVoterState[
-	Hash constraints.Ordered,
+	Hash comparable,
 	Number constraints.Unsigned,
 	Signature comparable, ID constraints.Ordered,
 	E Environment[Hash, Number, Signature, ID],
@@ -331,7 +331,7 @@ type CommunicationOut struct {
 
 // CommuincationOutVariants is interface constraint of `CommunicationOut`
 type CommuincationOutVariants[
-	Hash constraints.Ordered,
+	Hash comparable,
 	Number constraints.Unsigned,
 	Signature comparable,
 	ID constraints.Ordered,
@@ -340,7 +340,7 @@ type CommunicationOut struct {
 }
 
 func newCommunicationOut[
-	Hash constraints.Ordered,
+	Hash comparable,
 	Number constraints.Unsigned,
 	Signature comparable,
 	ID constraints.Ordered,
@@ -352,7 +352,7 @@ func newCommunicationOut[
 }
 
 func setCommunicationOut[
-	Hash constraints.Ordered,
+	Hash comparable,
 	Number constraints.Unsigned,
 	Signature comparable,
 	ID constraints.Ordered,
@@ -363,7 +363,7 @@ func setCommunicationOut[
 
 // CommunicationOutCommit is a commit message.
 type CommunicationOutCommit[
-	Hash constraints.Ordered,
+	Hash comparable,
 	Number constraints.Unsigned,
 	Signature comparable,
 	ID constraints.Ordered,
@@ -458,14 +458,14 @@ type CommunicationIn struct {
 }
 
 func setCommunicationIn[
-	Hash constraints.Ordered, Number constraints.Unsigned, Signature comparable, ID constraints.Ordered,
+	Hash comparable, Number constraints.Unsigned, Signature comparable, ID constraints.Ordered,
 	T CommunicationInVariants[Hash, Number, Signature, ID],
 ](ci *CommunicationIn, variant T) {
 	ci.variant = variant
 }
 
 func newCommunicationIn[
-	Hash constraints.Ordered, Number constraints.Unsigned, Signature comparable, ID constraints.Ordered,
+	Hash comparable, Number constraints.Unsigned, Signature comparable, ID constraints.Ordered,
 	T CommunicationInVariants[Hash, Number, Signature, ID],
 ](variant T) CommunicationIn {
 	ci := CommunicationIn{}
@@ -474,7 +474,7 @@ func newCommunicationIn[
 }
 
 type CommunicationInVariants[
-	Hash constraints.Ordered,
+	Hash comparable,
 	Number constraints.Unsigned,
 	Signature comparable,
 	ID constraints.Ordered,
@@ -482,7 +482,7 @@ func newCommunicationIn[
 	CommunicationInCommit[Hash, Number, Signature, ID] | CommunicationInCatchUp[Hash, Number, Signature, ID]
 }
 type CommunicationInCommit[
-	Hash constraints.Ordered,
+	Hash comparable,
 	Number constraints.Unsigned,
 	Signature comparable,
 	ID constraints.Ordered,
@@ -493,7 +493,7 @@ func newCommunicationIn[
 }
 
 type CommunicationInCatchUp[
-	Hash constraints.Ordered,
+	Hash comparable,
 	Number constraints.Unsigned,
 	Signature comparable,
 	ID constraints.Ordered,
@@ -525,7 +525,7 @@ type globalInItem struct {
 // Additionally, we also listen to commit messages from rounds that aren't
 // currently running, we validate the commit and dispatch a finalisation
 // notification (if any) to the environment.
-type Voter[Hash constraints.Ordered, Number constraints.Unsigned, Signature comparable, ID constraints.Ordered] struct {
+type Voter[Hash comparable, Number constraints.Unsigned, Signature comparable, ID constraints.Ordered] struct {
 	env                    Environment[Hash, Number, Signature, ID]
 	voters                 VoterSet[ID]
 	inner                  *innerVoterState[Hash, Number, Signature, ID, Environment[Hash, Number, Signature, ID]]
@@ -554,7 +554,7 @@ type Voter[Hash constraints.Ordered, Number constraints.Unsigned, Signature comp
 // correspond to known blocks only (including all its precommits). It
 // is also responsible for validating the signature data in commit
 // messages.
-func NewVoter[Hash constraints.Ordered, Number constraints.Unsigned, Signature comparable, ID constraints.Ordered](
+func NewVoter[Hash comparable, Number constraints.Unsigned, Signature comparable, ID constraints.Ordered](
 	env Environment[Hash, Number, Signature, ID],
 	voters VoterSet[ID],
 	globalIn chan globalInItem,
@@ -950,7 +950,7 @@ func (v *Voter[Hash, Number, Signature, ID]) poll(waker *waker) (bool, error) {
 }
 
 type sharedVoteState[
-	Hash constraints.Ordered,
+	Hash comparable,
 	Number constraints.Unsigned,
 	Signature comparable,
 	ID constraints.Ordered,
@@ -1013,7 +1013,7 @@ type VoterState[ID comparable] interface {
 // and precommits from the catch up imported. If the catch up is invalid `nil`
 // is returned instead.
 func validateCatchUp[ //skipcq: GO-R1005
-	Hash constraints.Ordered,
+	Hash comparable,
 	Number constraints.Unsigned,
 	Signature comparable,
 	ID constraints.Ordered,
@@ -69,7 +69,7 @@ func (v voting) isPrimary() bool {
 
 // Logic for a voter on a specific round.
 type votingRound[
-	Hash constraints.Ordered,
+	Hash comparable,
 	Number constraints.Unsigned,
 	Signature comparable,
 	ID constraints.Ordered,
@@ -90,7 +90,7 @@ func (v voting) isPrimary() bool {
 
 // Create a new voting round.
 func newVotingRound[
-	Hash constraints.Ordered, Number constraints.Unsigned, Signature comparable, ID constraints.Ordered,
+	Hash comparable, Number constraints.Unsigned, Signature comparable, ID constraints.Ordered,
 	E Environment[Hash, Number, Signature, ID],
 ](
 	roundNumber uint64, voters VoterSet[ID], base HashNumber[Hash, Number],
@@ -136,7 +136,7 @@ func newVotingRound[
 // Create a voting round from a completed `Round`. We will not vote further
 // in this round.
 func newVotingRoundCompleted[
-	Hash constraints.Ordered, Number constraints.Unsigned, Signature comparable, ID constraints.Ordered,
+	Hash comparable, Number constraints.Unsigned, Signature comparable, ID constraints.Ordered,
 	E Environment[Hash, Number, Signature, ID],
 ](
 	votes *Round[ID, Hash, Number, Signature],
@@ -729,7 +729,7 @@ func (vr *votingRound[Hash, Number, Signature, ID, E]) constructPrevote(lastRoun
 		// if the blocks are equal, we don't check ancestry.
 		if *primaryBlock == *lastPrevoteG {
 			findDescendentOf = primaryBlock.Hash
-		} else if primaryBlock.Hash >= lastPrevoteG.Hash {
+		} else if primaryBlock.Number >= lastPrevoteG.Number {
 			findDescendentOf = lastRoundEstimate.Hash
 		} else {
 			// from this point onwards, the number of the primary-broadcasted
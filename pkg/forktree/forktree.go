@@ -0,0 +1,223 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+// Package forktree implements a tree of data associated with blocks, such as
+// the GRANDPA scheduled authority set changes, where each fork of the chain is
+// tracked in its own branch and branches are pruned on finality.
+package forktree
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+
+	"golang.org/x/exp/constraints"
+)
+
+// ErrDuplicateHash is returned when importing a block hash already in the tree.
+var ErrDuplicateHash = errors.New("duplicated hashes")
+
+// IsDescendantOf returns true if child is a descendant of parent.
+type IsDescendantOf[H comparable] func(parent, child H) (bool, error)
+
+// Node is a node of the fork tree.
+type Node[H comparable, N constraints.Unsigned, V any] struct {
+	Hash     H
+	Number   N
+	Data     V
+	Children []*Node[H, N, V]
+}
+
+// importNode inserts the data as a descendant of the node, returning false
+// if the block is not a descendant of the node.
+func (n *Node[H, N, V]) importNode(hash H, number N, data V,
+	isDescendantOf IsDescendantOf[H]) (imported bool, err error) {
+	if hash == n.Hash {
+		return false, fmt.Errorf("%w: %v", ErrDuplicateHash, hash)
+	}
+
+	if number <= n.Number {
+		return false, nil
+	}
+
+	isDescendant, err := isDescendantOf(n.Hash, hash)
+	if err != nil {
+		return false, fmt.Errorf("cannot check ancestry: %w", err)
+	}
+
+	if !isDescendant {
+		return false, nil
+	}
+
+	for _, child := range n.Children {
+		imported, err := child.importNode(hash, number, data, isDescendantOf)
+		if err != nil {
+			return false, err
+		}
+
+		if imported {
+			return true, nil
+		}
+	}
+
+	n.Children = append(n.Children, &Node[H, N, V]{Hash: hash, Number: number, Data: data})
+	return true, nil
+}
+
+func (n *Node[H, N, V]) findNode(predicate func(*Node[H, N, V]) (bool, error)) (*Node[H, N, V], error) {
+	ok, err := predicate(n)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok {
+		return n, nil
+	}
+
+	for _, child := range n.Children {
+		node, err := child.findNode(predicate)
+		if err != nil || node != nil {
+			return node, err
+		}
+	}
+
+	return nil, nil
+}
+
+// ForkTree is a tree of data indexed by block hash and number, where the children
+// of a node are the data imported on its descendant blocks. The roots of the tree
+// are data imported on blocks without ancestor in the tree, in import order.
+// The zero value is an empty tree ready to use. A ForkTree is not safe for concurrent use.
+type ForkTree[H comparable, N constraints.Unsigned, V any] struct {
+	roots []*Node[H, N, V]
+}
+
+// New returns a new empty fork tree.
+func New[H comparable, N constraints.Unsigned, V any]() *ForkTree[H, N, V] {
+	return &ForkTree[H, N, V]{}
+}
+
+// Len returns the number of roots of the tree.
+func (t *ForkTree[H, N, V]) Len() int { return len(t.roots) }
+
+// Roots iterates over the roots of the tree, in import order.
+func (t *ForkTree[H, N, V]) Roots() iter.Seq[*Node[H, N, V]] {
+	return func(yield func(*Node[H, N, V]) bool) {
+		for _, root := range t.roots {
+			if !yield(root) {
+				return
+			}
+		}
+	}
+}
+
+// Import imports the data of the block with the given hash and number. The data is
+// imported as a child of the deepest node it descends from, or as a new root.
+// It returns an error wrapping ErrDuplicateHash if the block hash is already in the tree.
+func (t *ForkTree[H, N, V]) Import(hash H, number N, data V, isDescendantOf IsDescendantOf[H]) error {
+	for _, root := range t.roots {
+		imported, err := root.importNode(hash, number, data, isDescendantOf)
+		if err != nil {
+			return err
+		}
+
+		if imported {
+			return nil
+		}
+	}
+
+	t.roots = append(t.roots, &Node[H, N, V]{Hash: hash, Number: number, Data: data})
+	return nil
+}
+
+// FindRoot returns the first root satisfying the predicate, or nil if none does.
+func (t *ForkTree[H, N, V]) FindRoot(predicate func(*Node[H, N, V]) (bool, error)) (*Node[H, N, V], error) {
+	for _, root := range t.roots {
+		ok, err := predicate(root)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			return root, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// FindNode returns the first node satisfying the predicate in depth-first pre-order,
+// or nil if none does.
+func (t *ForkTree[H, N, V]) FindNode(predicate func(*Node[H, N, V]) (bool, error)) (*Node[H, N, V], error) {
+	for _, root := range t.roots {
+		node, err := root.findNode(predicate)
+		if err != nil || node != nil {
+			return node, err
+		}
+	}
+
+	return nil, nil
+}
+
+// FinalizeRoot finalizes the block with the given hash. The first root satisfying
+// the predicate is removed from the tree and returned, its children becoming the new
+// roots. The tree is then pruned of the nodes which are not descendants of the finalized
+// block, including the children of the finalized node imported on other forks.
+// The tree is left unchanged if an error is returned.
+func (t *ForkTree[H, N, V]) FinalizeRoot(hash H, isDescendantOf IsDescendantOf[H],
+	predicate func(*Node[H, N, V]) (bool, error)) (finalized *Node[H, N, V], err error) {
+	finalized, err = t.FindRoot(predicate)
+	if err != nil {
+		return nil, err
+	}
+
+	roots := t.roots
+	if finalized != nil {
+		roots = finalized.Children
+	}
+
+	roots, err = pruneRoots(roots, hash, isDescendantOf)
+	if err != nil {
+		return nil, err
+	}
+
+	t.roots = roots
+	return finalized, nil
+}
+
+// Prune removes the roots, and their children, which are not descendants of the
+// block with the given hash.
+func (t *ForkTree[H, N, V]) Prune(hash H, isDescendantOf IsDescendantOf[H]) error {
+	roots, err := pruneRoots(t.roots, hash, isDescendantOf)
+	if err != nil {
+		return err
+	}
+
+	t.roots = roots
+	return nil
+}
+
+// Clear removes all the nodes of the tree.
+func (t *ForkTree[H, N, V]) Clear() {
+	t.roots = nil
+}
+
+func pruneRoots[H comparable, N constraints.Unsigned, V any](roots []*Node[H, N, V], hash H,
+	isDescendantOf IsDescendantOf[H]) (descendants []*Node[H, N, V], err error) {
+	for _, root := range roots {
+		if root.Hash == hash {
+			descendants = append(descendants, root)
+			continue
+		}
+
+		isDescendant, err := isDescendantOf(hash, root.Hash)
+		if err != nil {
+			return nil, fmt.Errorf("cannot check ancestry: %w", err)
+		}
+
+		if isDescendant {
+			descendants = append(descendants, root)
+		}
+	}
+	return descendants, nil
+}
@@ -0,0 +1,175 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package forktree
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testChain is the chain below, blocks being named after their fork and number:
+//
+//	     / B2 - B3
+//	A0 - A1 - A2 - A3 - A4
+//	               \ C3 - C4
+var testChain = map[string]string{
+	"A1": "A0", "A2": "A1", "A3": "A2", "A4": "A3",
+	"B2": "A1", "B3": "B2",
+	"C3": "A2", "C4": "C3",
+}
+
+func isDescendantOf(parent, child string) (bool, error) {
+	for {
+		ancestor, ok := testChain[child]
+		if !ok {
+			return false, nil
+		}
+		if ancestor == parent {
+			return true, nil
+		}
+		child = ancestor
+	}
+}
+
+func newTestTree(t *testing.T, blocks ...string) *ForkTree[string, uint, string] {
+	t.Helper()
+
+	tree := New[string, uint, string]()
+	for _, block := range blocks {
+		err := tree.Import(block, uint(block[1]-'0'), "data "+block, isDescendantOf)
+		require.NoError(t, err)
+	}
+	return tree
+}
+
+func hashes(nodes []*Node[string, uint, string]) (hashes []string) {
+	for _, node := range nodes {
+		hashes = append(hashes, node.Hash)
+	}
+	return hashes
+}
+
+func Test_ForkTree_Import(t *testing.T) {
+	t.Parallel()
+
+	tree := newTestTree(t, "B2", "A2", "B3", "A3", "C3", "C4")
+
+	roots := slices.Collect(tree.Roots())
+	require.Equal(t, []string{"B2", "A2"}, hashes(roots))
+	assert.Equal(t, []string{"B3"}, hashes(roots[0].Children))
+	assert.Equal(t, "data B3", roots[0].Children[0].Data)
+	assert.Equal(t, []string{"A3", "C3"}, hashes(roots[1].Children))
+	assert.Equal(t, []string{"C4"}, hashes(roots[1].Children[1].Children))
+
+	err := tree.Import("A3", 3, "", isDescendantOf)
+	assert.ErrorIs(t, err, ErrDuplicateHash)
+	assert.EqualError(t, err, "duplicated hashes: A3")
+
+	errTest := errors.New("test error")
+	err = tree.Import("A4", 4, "", func(string, string) (bool, error) { return false, errTest })
+	assert.ErrorIs(t, err, errTest)
+}
+
+func Test_ForkTree_FindNode(t *testing.T) {
+	t.Parallel()
+
+	tree := newTestTree(t, "A1", "A2", "C3", "A4")
+
+	node, err := tree.FindNode(func(node *Node[string, uint, string]) (bool, error) {
+		return node.Number == 3, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "C3", node.Hash)
+
+	root, err := tree.FindRoot(func(node *Node[string, uint, string]) (bool, error) {
+		return node.Number == 3, nil
+	})
+	require.NoError(t, err)
+	assert.Nil(t, root)
+}
+
+func Test_ForkTree_FinalizeRoot(t *testing.T) {
+	t.Parallel()
+
+	numberAtMost := func(number uint) func(*Node[string, uint, string]) (bool, error) {
+		return func(node *Node[string, uint, string]) (bool, error) {
+			return node.Number <= number, nil
+		}
+	}
+
+	testCases := map[string]struct {
+		blocks        []string
+		finalized     string
+		predicate     func(*Node[string, uint, string]) (bool, error)
+		expectedNode  string
+		expectedRoots []string
+	}{
+		"root_finalized_children_pruned": {
+			blocks:        []string{"A2", "B3", "A3", "C4"},
+			finalized:     "A3",
+			predicate:     numberAtMost(3),
+			expectedNode:  "A2",
+			expectedRoots: []string{"A3"},
+		},
+		"no_root_finalized_forks_pruned": {
+			blocks:        []string{"B2", "A3", "C3"},
+			finalized:     "A2",
+			predicate:     numberAtMost(1),
+			expectedRoots: []string{"A3", "C3"},
+		},
+		"ancestor_of_finalized_block_pruned": {
+			blocks:    []string{"A1", "B2"},
+			finalized: "A3",
+			predicate: numberAtMost(0),
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			tree := newTestTree(t, testCase.blocks...)
+
+			node, err := tree.FinalizeRoot(testCase.finalized, isDescendantOf, testCase.predicate)
+			require.NoError(t, err)
+			if testCase.expectedNode == "" {
+				assert.Nil(t, node)
+			} else {
+				assert.Equal(t, testCase.expectedNode, node.Hash)
+			}
+			assert.Equal(t, testCase.expectedRoots, hashes(slices.Collect(tree.Roots())))
+		})
+	}
+}
+
+func Test_ForkTree_FinalizeRoot_error(t *testing.T) {
+	t.Parallel()
+
+	tree := newTestTree(t, "B2", "A2")
+	errTest := errors.New("test error")
+
+	_, err := tree.FinalizeRoot("A3", isDescendantOf, func(*Node[string, uint, string]) (bool, error) {
+		return false, errTest
+	})
+	assert.ErrorIs(t, err, errTest)
+	assert.Equal(t, 2, tree.Len())
+}
+
+func Test_ForkTree_Prune_Clear(t *testing.T) {
+	t.Parallel()
+
+	tree := newTestTree(t, "B2", "A3", "C4", "A4")
+
+	err := tree.Prune("A2", isDescendantOf)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"A3", "C4"}, hashes(slices.Collect(tree.Roots())))
+
+	tree.Clear()
+	assert.Zero(t, tree.Len())
+}
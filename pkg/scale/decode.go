@@ -404,6 +404,10 @@ func (ds *decodeState) decodeMap(dstv reflect.Value) (err error) {
 	if err != nil {
 		return fmt.Errorf("decoding length: %w", err)
 	}
+	// maps of decoded structs are nil, and are allocated here
+	if dstv.IsNil() && numberOfTuples > 0 {
+		dstv.Set(reflect.MakeMapWithSize(dstv.Type(), int(numberOfTuples)))
+	}
 	in := dstv.Interface()
 
 	for i := uint(0); i < numberOfTuples; i++ {
@@ -254,6 +254,23 @@ func Test_decodeState_decodeMap(t *testing.T) {
 	}
 }
 
+func Test_decodeState_decodeMap_nil(t *testing.T) {
+	type mapStruct struct {
+		Map map[int8][]byte
+	}
+
+	var actualOutput mapStruct
+	err := Unmarshal([]byte{4, 2, 44, 115, 111, 109, 101, 32, 115, 116, 114, 105, 110, 103}, &actualOutput)
+	if err != nil {
+		t.Errorf("decodeState.unmarshal() error = %v", err)
+	}
+
+	expectedOutput := mapStruct{Map: map[int8][]byte{2: []byte("some string")}}
+	if !reflect.DeepEqual(actualOutput, expectedOutput) {
+		t.Errorf("decodeState.unmarshal() = %v, want %v", actualOutput, expectedOutput)
+	}
+}
+
 func Test_unmarshal_optionality(t *testing.T) {
 	var ptrTests tests
 	for _, t := range append(tests{}, allTests...) {
@@ -0,0 +1,47 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package db
+
+import "github.com/ChainSafe/gossamer/lib/common"
+
+const (
+	// LocalityPrefixNibbles is the maximum number of nibbles of the path
+	// of a trie node used as prefix of its locality database key.
+	LocalityPrefixNibbles = 6
+	// LocalityPrefixLength is the length in bytes of the prefix of a locality
+	// database key, made of the packed path nibbles followed by their count.
+	LocalityPrefixLength = LocalityPrefixNibbles/2 + 1
+	// LocalityNodeKeyLength is the length in bytes of a locality database key.
+	LocalityNodeKeyLength = LocalityPrefixLength + common.HashLength
+)
+
+// NodeKeyer is implemented by databases storing trie nodes under a key
+// derived from their path in the trie and their hash. Trie nodes of
+// databases not implementing it are stored under their hash.
+type NodeKeyer interface {
+	NodeKey(path, nodeHash []byte) (key []byte)
+}
+
+// LocalityNodeKey returns the database key of the trie node with the given
+// hash and path, where the path is the nibbles leading from the root to the
+// node, excluding the node partial key. The key is prefixed by the first
+// nibbles of the path and their count, so the nodes of a subtree, which are
+// mutated together, are written next to each other in the database keyspace.
+func LocalityNodeKey(path, nodeHash []byte) (key []byte) {
+	if len(path) > LocalityPrefixNibbles {
+		path = path[:LocalityPrefixNibbles]
+	}
+
+	key = make([]byte, LocalityPrefixLength+len(nodeHash))
+	for i, nibble := range path {
+		if i%2 == 0 {
+			key[i/2] = nibble << 4
+		} else {
+			key[i/2] |= nibble & 0x0f
+		}
+	}
+	key[LocalityPrefixLength-1] = byte(len(path))
+	copy(key[LocalityPrefixLength:], nodeHash)
+	return key
+}
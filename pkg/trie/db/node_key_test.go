@@ -0,0 +1,52 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_LocalityNodeKey(t *testing.T) {
+	t.Parallel()
+
+	nodeHash := []byte{
+		1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16,
+		17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32}
+
+	testCases := map[string]struct {
+		path   []byte
+		prefix []byte
+	}{
+		"empty_path": {
+			prefix: []byte{0, 0, 0, 0},
+		},
+		"odd_path": {
+			path:   []byte{0xa, 0xb, 0xc},
+			prefix: []byte{0xab, 0xc0, 0, 3},
+		},
+		"full_path": {
+			path:   []byte{1, 2, 3, 4, 5, 6},
+			prefix: []byte{0x12, 0x34, 0x56, 6},
+		},
+		"path_longer_than_prefix": {
+			path:   []byte{1, 2, 3, 4, 5, 6, 7, 8},
+			prefix: []byte{0x12, 0x34, 0x56, 6},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			key := LocalityNodeKey(testCase.path, nodeHash)
+
+			expectedKey := append(testCase.prefix, nodeHash...)
+			assert.Equal(t, expectedKey, key)
+			assert.Len(t, key, LocalityNodeKeyLength)
+		})
+	}
+}
@@ -5,8 +5,10 @@
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 
+	"github.com/ChainSafe/gossamer/internal/database"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/pkg/trie"
 	"github.com/ChainSafe/gossamer/pkg/trie/codec"
@@ -23,7 +25,7 @@ func (t *InMemoryTrie) Load(db db.DBGetter, rootHash common.Hash) error {
 		return nil
 	}
 	rootHashBytes := rootHash.ToBytes()
-	encodedNode, err := db.Get(rootHashBytes)
+	encodedNode, err := getEncodedNode(db, nil, rootHashBytes)
 	if err != nil {
 		return fmt.Errorf("failed to find root key %s: %w", rootHash, err)
 	}
@@ -42,7 +44,7 @@ func (t *InMemoryTrie) Load(db db.DBGetter, rootHash common.Hash) error {
 	t.root = root
 	t.root.MerkleValue = rootHashBytes
 
-	err = t.loadNode(db, t.root)
+	err = t.loadNode(db, t.root, nil)
 	if err != nil {
 		return err
 	}
@@ -66,7 +68,7 @@ func (t *InMemoryTrie) Load(db db.DBGetter, rootHash common.Hash) error {
 	return nil
 }
 
-func (t *InMemoryTrie) loadNode(db db.DBGetter, n *node.Node) error {
+func (t *InMemoryTrie) loadNode(db db.DBGetter, n *node.Node, path []byte) error {
 	if n.Kind() != node.Branch {
 		return nil
 	}
@@ -90,7 +92,8 @@ func (t *InMemoryTrie) loadNode(db db.DBGetter, n *node.Node) error {
 		}
 
 		nodeHash := merkleValue
-		encodedNode, err := db.Get(nodeHash)
+		nodePath := childPath(path, branch.PartialKey, i)
+		encodedNode, err := getEncodedNode(db, nodePath, nodeHash)
 		if err != nil {
 			return fmt.Errorf("cannot find child node key 0x%x in database: %w", nodeHash, err)
 		}
@@ -109,7 +112,7 @@ func (t *InMemoryTrie) loadNode(db db.DBGetter, n *node.Node) error {
 		decodedNode.MerkleValue = nodeHash
 		branch.Children[i] = decodedNode
 
-		err = t.loadNode(db, decodedNode)
+		err = t.loadNode(db, decodedNode, nodePath)
 		if err != nil {
 			return fmt.Errorf("loading child at index %d with node hash 0x%x: %w", i, nodeHash, err)
 		}
@@ -221,7 +224,7 @@ func GetFromDB(db db.DBGetter, rootHash common.Hash, key []byte) (
 
 	k := codec.KeyLEToNibbles(key)
 
-	encodedRootNode, err := db.Get(rootHash[:])
+	encodedRootNode, err := getEncodedNode(db, nil, rootHash[:])
 	if err != nil {
 		return nil, fmt.Errorf("cannot find root hash key %s: %w", rootHash, err)
 	}
@@ -232,14 +235,14 @@ func GetFromDB(db db.DBGetter, rootHash common.Hash, key []byte) (
 		return nil, fmt.Errorf("cannot decode root node: %w", err)
 	}
 
-	return getFromDBAtNode(db, rootNode, k)
+	return getFromDBAtNode(db, rootNode, k, nil)
 }
 
 // getFromDBAtNode recursively searches through the trie and database
-// for the value corresponding to a key.
+// for the value corresponding to a key, where path is the path of the node.
 // Note it does not copy the value so modifying the value bytes
 // slice will modify the value of the node in the trie.
-func getFromDBAtNode(db db.DBGetter, n *node.Node, key []byte) (
+func getFromDBAtNode(db db.DBGetter, n *node.Node, key, path []byte) (
 	value []byte, err error) {
 	if n.Kind() == node.Leaf {
 		if bytes.Equal(n.PartialKey, key) {
@@ -271,11 +274,12 @@ func getFromDBAtNode(db db.DBGetter, n *node.Node, key []byte) (
 
 	// Child can be either inlined or a hash pointer.
 	childMerkleValue := child.MerkleValue
+	childNodePath := childPath(path, branch.PartialKey, int(childIndex))
 	if len(childMerkleValue) == 0 && child.Kind() == node.Leaf {
-		return getFromDBAtNode(db, child, key[commonPrefixLength+1:])
+		return getFromDBAtNode(db, child, key[commonPrefixLength+1:], childNodePath)
 	}
 
-	encodedChild, err := db.Get(childMerkleValue)
+	encodedChild, err := getEncodedNode(db, childNodePath, childMerkleValue)
 	if err != nil {
 		return nil, fmt.Errorf(
 			"finding child node with hash 0x%x in database: %w",
@@ -290,14 +294,16 @@ func getFromDBAtNode(db db.DBGetter, n *node.Node, key []byte) (
 			childMerkleValue, err)
 	}
 
-	return getFromDBAtNode(db, decodedChild, key[commonPrefixLength+1:])
+	return getFromDBAtNode(db, decodedChild, key[commonPrefixLength+1:], childNodePath)
 	// Note: do not wrap error since it's called recursively.
 }
 
-// WriteDirty writes all dirty nodes to the database and sets them to clean
-func (t *InMemoryTrie) WriteDirty(db db.NewBatcher) error {
-	batch := db.NewBatch()
-	err := t.writeDirtyNode(batch, t.root)
+// WriteDirty writes all dirty nodes to the database and sets them to clean.
+// If the database is a node keyer, the nodes are written under their node key.
+func (t *InMemoryTrie) WriteDirty(nodeDB db.NewBatcher) error {
+	keyer, _ := nodeDB.(db.NodeKeyer)
+	batch := nodeDB.NewBatch()
+	err := t.writeDirtyNode(batch, keyer, t.root, nil)
 	if err != nil {
 		batch.Reset()
 		return err
@@ -306,7 +312,7 @@ func (t *InMemoryTrie) WriteDirty(db db.NewBatcher) error {
 	return batch.Flush()
 }
 
-func (t *InMemoryTrie) writeDirtyNode(db db.DBPutter, n *node.Node) (err error) {
+func (t *InMemoryTrie) writeDirtyNode(db db.DBPutter, keyer db.NodeKeyer, n *node.Node, path []byte) (err error) {
 	if n == nil || !n.Dirty {
 		return nil
 	}
@@ -345,8 +351,12 @@ func (t *InMemoryTrie) writeDirtyNode(db db.DBPutter, n *node.Node) (err error)
 	}
 
 	nodeHash := merkleValue
+	key := nodeHash
+	if keyer != nil {
+		key = keyer.NodeKey(path, nodeHash)
+	}
 
-	err = db.Put(nodeHash, encoding)
+	err = db.Put(key, encoding)
 	if err != nil {
 		return fmt.Errorf(
 			"putting encoding of node with node hash 0x%x in database: %w",
@@ -358,12 +368,12 @@ func (t *InMemoryTrie) writeDirtyNode(db db.DBPutter, n *node.Node) (err error)
 		return nil
 	}
 
-	for _, child := range n.Children {
+	for i, child := range n.Children {
 		if child == nil {
 			continue
 		}
 
-		err = t.writeDirtyNode(db, child)
+		err = t.writeDirtyNode(db, keyer, child, childPath(path, n.PartialKey, i))
 		if err != nil {
 			// Note: do not wrap error since it's returned recursively.
 			return err
@@ -371,7 +381,7 @@ func (t *InMemoryTrie) writeDirtyNode(db db.DBPutter, n *node.Node) (err error)
 	}
 
 	for _, childTrie := range t.childTries {
-		if err := childTrie.writeDirtyNode(db, childTrie.root); err != nil {
+		if err := childTrie.writeDirtyNode(db, keyer, childTrie.root, nil); err != nil {
 			return fmt.Errorf("writing dirty node to database: %w", err)
 		}
 	}
@@ -440,3 +450,38 @@ func (t *InMemoryTrie) getInsertedNodeHashesAtNode(n *node.Node, nodeHashes map[
 
 	return nil
 }
+
+// getEncodedNode gets the encoding of the node with the given path and hash from
+// the database. If the database is a node keyer, the node is read from its node key,
+// and from its hash if it is not found there, for nodes written before the database
+// keys were migrated.
+func getEncodedNode(getter db.DBGetter, path, nodeHash []byte) (encodedNode []byte, err error) {
+	keyer, ok := getter.(db.NodeKeyer)
+	if !ok {
+		return getter.Get(nodeHash)
+	}
+
+	encodedNode, err = getter.Get(keyer.NodeKey(path, nodeHash))
+	if errors.Is(err, database.ErrNotFound) {
+		return getter.Get(nodeHash)
+	}
+	return encodedNode, err
+}
+
+// childPath returns the path of the child at the given index of the branch with
+// the given path and partial key. The path is truncated to the number of nibbles
+// used by the locality node keys, since nodes are not keyed by longer paths.
+func childPath(path, partialKey []byte, childIndex int) []byte {
+	if len(path) >= db.LocalityPrefixNibbles {
+		return path
+	}
+
+	nodePath := make([]byte, 0, len(path)+len(partialKey)+1)
+	nodePath = append(nodePath, path...)
+	nodePath = append(nodePath, partialKey...)
+	nodePath = append(nodePath, byte(childIndex))
+	if len(nodePath) > db.LocalityPrefixNibbles {
+		nodePath = nodePath[:db.LocalityPrefixNibbles]
+	}
+	return nodePath
+}
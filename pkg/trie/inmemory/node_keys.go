@@ -0,0 +1,104 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package inmemory
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/trie"
+	"github.com/ChainSafe/gossamer/pkg/trie/codec"
+	"github.com/ChainSafe/gossamer/pkg/trie/db"
+	"github.com/ChainSafe/gossamer/pkg/trie/node"
+)
+
+// MigrateNodeKeys copies the nodes of the trie with the given root hash, and of its
+// child tries, stored under their hash to their node key given by the keyer.
+// The subtrees with their root node already stored under its node key are skipped,
+// so a trie sharing nodes with an already migrated trie is migrated quickly.
+// The hashes of the nodes copied are added to the migrated map, so their hash
+// keys can be deleted once all the tries of the database are migrated.
+func MigrateNodeKeys(getter db.DBGetter, putter db.DBPutter, keyer db.NodeKeyer,
+	rootHash common.Hash, migrated map[common.Hash]struct{}) error {
+	if rootHash == trie.EmptyHash {
+		return nil
+	}
+
+	return migrateNodeKeysAtNode(getter, putter, keyer, nil, rootHash.ToBytes(), migrated)
+}
+
+// migrateNodeKeysAtNode copies the node with the given hash and full path, that is
+// the path from the root including all the nibbles, and its descendants to their
+// node key. Note it does not wrap errors since it's called recursively.
+func migrateNodeKeysAtNode(getter db.DBGetter, putter db.DBPutter, keyer db.NodeKeyer,
+	fullPath, nodeHash []byte, migrated map[common.Hash]struct{}) error {
+	key := keyer.NodeKey(fullPath, nodeHash)
+	_, err := getter.Get(key)
+	if err == nil {
+		return nil
+	} else if !errors.Is(err, database.ErrNotFound) {
+		return fmt.Errorf("getting node with hash 0x%x: %w", nodeHash, err)
+	}
+
+	encodedNode, err := getter.Get(nodeHash)
+	if err != nil {
+		return fmt.Errorf("getting node with hash 0x%x: %w", nodeHash, err)
+	}
+
+	n, err := node.Decode(bytes.NewReader(encodedNode))
+	if err != nil {
+		return fmt.Errorf("decoding node with hash 0x%x: %w", nodeHash, err)
+	}
+
+	nodeFullKey := make([]byte, 0, len(fullPath)+len(n.PartialKey))
+	nodeFullKey = append(nodeFullKey, fullPath...)
+	nodeFullKey = append(nodeFullKey, n.PartialKey...)
+
+	err = migrateChildTrieNodeKeys(getter, putter, keyer, n, nodeFullKey, migrated)
+	if err != nil {
+		return err
+	}
+
+	for i, child := range n.Children {
+		if child == nil || len(child.MerkleValue) < 32 {
+			// inlined nodes are part of the encoding of their parent
+			continue
+		}
+
+		childFullPath := make([]byte, 0, len(nodeFullKey)+1)
+		childFullPath = append(childFullPath, nodeFullKey...)
+		childFullPath = append(childFullPath, byte(i))
+		err = migrateNodeKeysAtNode(getter, putter, keyer, childFullPath, child.MerkleValue, migrated)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = putter.Put(key, encodedNode)
+	if err != nil {
+		return fmt.Errorf("putting node with hash 0x%x: %w", nodeHash, err)
+	}
+	migrated[common.NewHash(nodeHash)] = struct{}{}
+	return nil
+}
+
+// migrateChildTrieNodeKeys migrates the child trie with its root hash as the storage
+// value of the node with the given full key, if the key is a child storage key.
+func migrateChildTrieNodeKeys(getter db.DBGetter, putter db.DBPutter, keyer db.NodeKeyer,
+	n *node.Node, nodeFullKey []byte, migrated map[common.Hash]struct{}) error {
+	if n.StorageValue == nil || len(nodeFullKey)%2 != 0 ||
+		!bytes.HasPrefix(codec.NibblesToKeyLE(nodeFullKey), ChildStorageKeyPrefix) {
+		return nil
+	}
+
+	err := loadStorageValue(getter, n)
+	if err != nil {
+		return fmt.Errorf("getting child trie root hash: %w", err)
+	}
+
+	return MigrateNodeKeys(getter, putter, keyer, common.BytesToHash(n.StorageValue), migrated)
+}
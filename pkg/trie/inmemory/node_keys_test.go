@@ -0,0 +1,106 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package inmemory
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/trie/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// localityTable is a database table storing trie nodes under their locality key.
+type localityTable struct {
+	database.Table
+}
+
+func (localityTable) NodeKey(path, nodeHash []byte) []byte {
+	return db.LocalityNodeKey(path, nodeHash)
+}
+
+func Test_Trie_Store_Load_NodeKeyer(t *testing.T) {
+	t.Parallel()
+
+	const size = 1000
+	tr, keyValues := makeSeededTrie(t, size)
+	childTrie, _ := makeSeededTrie(t, 10)
+	err := tr.SetChild([]byte("child"), childTrie)
+	require.NoError(t, err)
+
+	table := localityTable{Table: newTestDB(t)}
+	err = tr.WriteDirty(table)
+	require.NoError(t, err)
+
+	rootHash := tr.MustHash()
+	_, err = table.Get(rootHash.ToBytes())
+	require.ErrorIs(t, err, database.ErrNotFound)
+	_, err = table.Get(db.LocalityNodeKey(nil, rootHash.ToBytes()))
+	require.NoError(t, err)
+
+	trieFromDB := NewEmptyTrie()
+	err = trieFromDB.Load(table, rootHash)
+	require.NoError(t, err)
+	assert.Equal(t, tr.String(), trieFromDB.String())
+	assert.Equal(t, tr.childTries, trieFromDB.childTries)
+
+	for keyString, expectedValue := range keyValues {
+		value, err := GetFromDB(table, rootHash, []byte(keyString))
+		require.NoError(t, err)
+		assert.Equal(t, expectedValue, value)
+	}
+}
+
+func Test_MigrateNodeKeys(t *testing.T) {
+	t.Parallel()
+
+	const size = 1000
+	tr, _ := makeSeededTrie(t, size)
+	childTrie, _ := makeSeededTrie(t, 10)
+	err := tr.SetChild([]byte("child"), childTrie)
+	require.NoError(t, err)
+
+	hashTable := newTestDB(t)
+	err = tr.WriteDirty(hashTable)
+	require.NoError(t, err)
+	rootHash := tr.MustHash()
+
+	table := localityTable{Table: hashTable}
+
+	// nodes not migrated are read from their hash key
+	trieFromDB := NewEmptyTrie()
+	err = trieFromDB.Load(table, rootHash)
+	require.NoError(t, err)
+	assert.Equal(t, tr.String(), trieFromDB.String())
+
+	migrated := make(map[common.Hash]struct{})
+	batch := table.NewBatch()
+	err = MigrateNodeKeys(table, batch, table, rootHash, migrated)
+	require.NoError(t, err)
+	require.NoError(t, batch.Flush())
+
+	expectedMigrated := make(map[common.Hash]struct{})
+	PopulateNodeHashes(tr.RootNode(), expectedMigrated)
+	PopulateNodeHashes(childTrie.RootNode(), expectedMigrated)
+	assert.Equal(t, expectedMigrated, migrated)
+
+	for nodeHash := range migrated {
+		err = hashTable.Del(nodeHash.ToBytes())
+		require.NoError(t, err)
+	}
+
+	trieFromDB = NewEmptyTrie()
+	err = trieFromDB.Load(table, rootHash)
+	require.NoError(t, err)
+	assert.Equal(t, tr.String(), trieFromDB.String())
+	assert.Equal(t, tr.childTries, trieFromDB.childTries)
+
+	// migrating a migrated trie is a no-op
+	migrated = make(map[common.Hash]struct{})
+	err = MigrateNodeKeys(table, batch, table, rootHash, migrated)
+	require.NoError(t, err)
+	assert.Empty(t, migrated)
+}
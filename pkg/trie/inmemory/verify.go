@@ -0,0 +1,190 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package inmemory
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/trie"
+	"github.com/ChainSafe/gossamer/pkg/trie/codec"
+	"github.com/ChainSafe/gossamer/pkg/trie/db"
+	"github.com/ChainSafe/gossamer/pkg/trie/node"
+)
+
+var (
+	// ErrNodeHashMismatch is the problem of a trie node whose encoding in the
+	// database does not hash to the hash it is referenced by.
+	ErrNodeHashMismatch = errors.New("node hash mismatch")
+	// ErrNodeCorrupt is the problem of a trie node whose encoding in the database
+	// cannot be decoded.
+	ErrNodeCorrupt = errors.New("node encoding is corrupt")
+	// ErrValueHashMismatch is the problem of a hashed storage value whose value in
+	// the database does not hash to its hash.
+	ErrValueHashMismatch = errors.New("storage value hash mismatch")
+)
+
+// NodeProblem is a trie node missing from the database or corrupt.
+type NodeProblem struct {
+	// Hash is the hash the node is referenced by.
+	Hash common.Hash
+	// Path is the path of the node in nibbles from the root of its trie,
+	// including its partial key for a problem with its storage value.
+	Path []byte
+	// Err is the problem, wrapping database.ErrNotFound for a missing node or
+	// storage value.
+	Err error
+}
+
+func (p NodeProblem) String() string {
+	return fmt.Sprintf("node %s at path 0x%x: %s", p.Hash, p.Path, p.Err)
+}
+
+// VerifyNodes walks the nodes of the trie with the given root hash, and of its child
+// tries, checking every node referenced by hash is in the database, decodes and
+// hashes to its hash, and every hashed storage value is in the database and hashes to
+// its hash. The descendants of a missing or corrupt node cannot be walked, so only the
+// node is reported. The hashes of the nodes with all their descendants intact are added
+// to the verified map, and are skipped, so the tries of consecutive blocks sharing most
+// of their nodes are verified quickly. It returns the number of nodes read, and an error
+// only if the database cannot be read or the context is done.
+func VerifyNodes(ctx context.Context, getter db.DBGetter, rootHash common.Hash,
+	verified map[common.Hash]struct{}) (nodes int, problems []NodeProblem, err error) {
+	verifier := &nodeVerifier{
+		ctx:      ctx,
+		getter:   getter,
+		verified: verified,
+	}
+	_, err = verifier.verifyTrie(rootHash)
+	return verifier.nodes, verifier.problems, err
+}
+
+type nodeVerifier struct {
+	ctx      context.Context
+	getter   db.DBGetter
+	verified map[common.Hash]struct{}
+	nodes    int
+	problems []NodeProblem
+}
+
+func (v *nodeVerifier) verifyTrie(rootHash common.Hash) (intact bool, err error) {
+	if rootHash == trie.EmptyHash {
+		return true, nil
+	}
+	return v.verifyNode(nil, rootHash.ToBytes())
+}
+
+func (v *nodeVerifier) report(nodeHash common.Hash, path []byte, err error) {
+	v.problems = append(v.problems, NodeProblem{
+		Hash: nodeHash,
+		Path: bytes.Clone(path),
+		Err:  err,
+	})
+}
+
+// verifyNode verifies the node with the given full path and hash and its descendants,
+// and returns true if none of them has a problem. Note it does not wrap errors since
+// it's called recursively.
+func (v *nodeVerifier) verifyNode(fullPath, nodeHash []byte) (intact bool, err error) {
+	err = v.ctx.Err()
+	if err != nil {
+		return false, err
+	}
+
+	hash := common.NewHash(nodeHash)
+	if _, ok := v.verified[hash]; ok {
+		return true, nil
+	}
+
+	encodedNode, err := getEncodedNode(v.getter, fullPath, nodeHash)
+	if errors.Is(err, database.ErrNotFound) {
+		v.report(hash, fullPath, err)
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("getting node with hash %s: %w", hash, err)
+	}
+	v.nodes++
+
+	encodedHash, err := common.Blake2bHash(encodedNode)
+	if err != nil {
+		return false, fmt.Errorf("hashing node with hash %s: %w", hash, err)
+	}
+	if encodedHash != hash {
+		v.report(hash, fullPath, fmt.Errorf("%w: encoding hashes to %s", ErrNodeHashMismatch, encodedHash))
+		return false, nil
+	}
+
+	n, err := node.Decode(bytes.NewReader(encodedNode))
+	if err != nil {
+		v.report(hash, fullPath, fmt.Errorf("%w: %s", ErrNodeCorrupt, err))
+		return false, nil
+	}
+
+	nodeFullKey := make([]byte, 0, len(fullPath)+len(n.PartialKey))
+	nodeFullKey = append(nodeFullKey, fullPath...)
+	nodeFullKey = append(nodeFullKey, n.PartialKey...)
+
+	intact, err = v.verifyStorageValue(hash, n, nodeFullKey)
+	if err != nil {
+		return false, err
+	}
+
+	for i, child := range n.Children {
+		if child == nil || len(child.MerkleValue) < 32 {
+			// inlined nodes are part of the encoding of their parent
+			continue
+		}
+
+		childFullPath := make([]byte, 0, len(nodeFullKey)+1)
+		childFullPath = append(childFullPath, nodeFullKey...)
+		childFullPath = append(childFullPath, byte(i))
+		childIntact, err := v.verifyNode(childFullPath, child.MerkleValue)
+		if err != nil {
+			return false, err
+		}
+		intact = intact && childIntact
+	}
+
+	if intact {
+		v.verified[hash] = struct{}{}
+	}
+	return intact, nil
+}
+
+// verifyStorageValue verifies the hashed storage value of the node, and the child trie
+// with its root hash as the storage value of the node if its full key is a child
+// storage key.
+func (v *nodeVerifier) verifyStorageValue(nodeHash common.Hash, n *node.Node, nodeFullKey []byte) (
+	intact bool, err error) {
+	if n.IsHashedValue {
+		valueHash := common.NewHash(n.StorageValue)
+		err = loadStorageValue(v.getter, n)
+		if errors.Is(err, database.ErrNotFound) {
+			v.report(nodeHash, nodeFullKey, fmt.Errorf("getting storage value with hash %s: %w", valueHash, err))
+			return false, nil
+		} else if err != nil {
+			return false, fmt.Errorf("getting storage value with hash %s: %w", valueHash, err)
+		}
+
+		storedHash, err := common.Blake2bHash(n.StorageValue)
+		if err != nil {
+			return false, fmt.Errorf("hashing storage value with hash %s: %w", valueHash, err)
+		}
+		if storedHash != valueHash {
+			v.report(nodeHash, nodeFullKey,
+				fmt.Errorf("%w: value with hash %s hashes to %s", ErrValueHashMismatch, valueHash, storedHash))
+			return false, nil
+		}
+	}
+
+	if n.StorageValue == nil || len(nodeFullKey)%2 != 0 ||
+		!bytes.HasPrefix(codec.NibblesToKeyLE(nodeFullKey), ChildStorageKeyPrefix) {
+		return true, nil
+	}
+	return v.verifyTrie(common.BytesToHash(n.StorageValue))
+}
@@ -0,0 +1,80 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package inmemory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/trie/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_VerifyNodes(t *testing.T) {
+	t.Parallel()
+
+	const size = 1000
+	tr, _ := makeSeededTrie(t, size)
+	childTrie, _ := makeSeededTrie(t, 10)
+	err := tr.SetChild([]byte("child"), childTrie)
+	require.NoError(t, err)
+
+	table := localityTable{Table: newTestDB(t)}
+	err = tr.WriteDirty(table)
+	require.NoError(t, err)
+	rootHash := tr.MustHash()
+
+	expectedNodes := make(map[common.Hash]struct{})
+	PopulateNodeHashes(tr.RootNode(), expectedNodes)
+	PopulateNodeHashes(childTrie.RootNode(), expectedNodes)
+
+	verified := make(map[common.Hash]struct{})
+	nodes, problems, err := VerifyNodes(context.Background(), table, rootHash, verified)
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+	assert.Equal(t, len(expectedNodes), nodes)
+	assert.Equal(t, expectedNodes, verified)
+
+	// verified nodes are skipped
+	nodes, problems, err = VerifyNodes(context.Background(), table, rootHash, verified)
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+	assert.Zero(t, nodes)
+
+	// delete a child of the root and corrupt another one
+	root := tr.RootNode()
+	var childPaths, childHashes [][]byte
+	for i, child := range root.Children {
+		if child != nil && len(child.MerkleValue) == 32 {
+			childPaths = append(childPaths, append(append([]byte{}, root.PartialKey...), byte(i)))
+			childHashes = append(childHashes, child.MerkleValue)
+		}
+	}
+	require.GreaterOrEqual(t, len(childHashes), 2)
+	err = table.Del(db.LocalityNodeKey(childPaths[0], childHashes[0]))
+	require.NoError(t, err)
+	err = table.Put(db.LocalityNodeKey(childPaths[1], childHashes[1]), []byte{1, 2, 3})
+	require.NoError(t, err)
+
+	verified = make(map[common.Hash]struct{})
+	_, problems, err = VerifyNodes(context.Background(), table, rootHash, verified)
+	require.NoError(t, err)
+	require.Len(t, problems, 2)
+	assert.Equal(t, common.NewHash(childHashes[0]), problems[0].Hash)
+	assert.Equal(t, childPaths[0], problems[0].Path)
+	assert.ErrorIs(t, problems[0].Err, database.ErrNotFound)
+	assert.Equal(t, common.NewHash(childHashes[1]), problems[1].Hash)
+	assert.ErrorIs(t, problems[1].Err, ErrNodeHashMismatch)
+	// the ancestors of the problems are not intact
+	assert.NotContains(t, verified, rootHash)
+	assert.NotContains(t, verified, common.NewHash(childHashes[0]))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err = VerifyNodes(ctx, table, rootHash, make(map[common.Hash]struct{}))
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -490,3 +490,18 @@ func Benchmark_decodeHeaderByte(b *testing.B) {
 		_, _, _ = decodeHeaderByte(header)
 	}
 }
+
+func Test_VariantName(t *testing.T) {
+	t.Parallel()
+
+	name, err := VariantName(0b1100_0001)
+	require.NoError(t, err)
+	assert.Equal(t, "BranchWithValue", name)
+
+	name, err = VariantName(0b0010_0011)
+	require.NoError(t, err)
+	assert.Equal(t, "LeafWithHashedValue", name)
+
+	_, err = VariantName(0b0000_1000)
+	assert.ErrorIs(t, err, ErrVariantUnknown)
+}
@@ -76,3 +76,13 @@ func (v variant) String() string {
 	}
 
 }
+
+// VariantName returns the name of the variant of the node encoded
+// with the given header byte, such as Leaf or BranchWithValue.
+func VariantName(header byte) (name string, err error) {
+	nodeVariant, _, err := decodeHeaderByte(header)
+	if err != nil {
+		return "", err
+	}
+	return nodeVariant.String(), nil
+}